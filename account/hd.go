@@ -0,0 +1,111 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/crypto"
+	"golang.org/x/crypto/ed25519"
+)
+
+// HardenedOffset is added to the index of hardened children.
+const HardenedOffset uint32 = 0x80000000
+
+// CoinType is the SLIP-0044 coin type registered for IOST.
+const CoinType uint32 = 291
+
+// DefaultHDPath is the BIP44 path of the first IOST account.
+const DefaultHDPath = "m/44'/291'/0'/0'/0'"
+
+// HDKey is an ed25519 extended key derived as defined in SLIP-0010.
+type HDKey struct {
+	Key       []byte
+	ChainCode []byte
+	Depth     int
+}
+
+// NewMasterHDKey returns the master key of a seed.
+func NewMasterHDKey(seed []byte) *HDKey {
+	key, chainCode := masterKey(seed)
+	return &HDKey{Key: key, ChainCode: chainCode}
+}
+
+// Child derives the child key at index. Only hardened children are allowed for ed25519.
+func (k *HDKey) Child(index uint32) (*HDKey, error) {
+	if index < HardenedOffset {
+		return nil, fmt.Errorf("ed25519 only supports hardened derivation, index %v", index)
+	}
+	data := make([]byte, 1+32+4)
+	copy(data[1:], k.Key)
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return &HDKey{Key: sum[:32], ChainCode: sum[32:], Depth: k.Depth + 1}, nil
+}
+
+// Derive derives the descendant key at path, e.g. "m/44'/291'/0'/0'/0'".
+func (k *HDKey) Derive(path string) (*HDKey, error) {
+	indexes, err := ParseHDPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, i := range indexes {
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// KeyPair returns the ed25519 key pair of the extended key.
+func (k *HDKey) KeyPair() (*KeyPair, error) {
+	return NewKeyPair(ed25519.NewKeyFromSeed(k.Key), crypto.Ed25519)
+}
+
+// ParseHDPath parses a BIP32 path. Hardened indexes are marked by ' or h, and the leading "m" is optional.
+func ParseHDPath(path string) ([]uint32, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/")
+	if path == "" {
+		return []uint32{}, nil
+	}
+	parts := strings.Split(path, "/")
+	indexes := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			offset = HardenedOffset
+			p = p[:len(p)-1]
+		}
+		n, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hd path component %v: %v", p, err)
+		}
+		indexes = append(indexes, uint32(n)+offset)
+	}
+	return indexes, nil
+}
+
+// DeriveKeyPair derives the ed25519 key pair at path from a seed.
+func DeriveKeyPair(seed []byte, path string) (*KeyPair, error) {
+	key, err := NewMasterHDKey(seed).Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	return key.KeyPair()
+}
+
+// DeriveKeyPairFromMnemonic derives the ed25519 key pair at path from a BIP39 mnemonic.
+func DeriveKeyPairFromMnemonic(mnemonic string, passphrase string, path string) (*KeyPair, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	return DeriveKeyPair(MnemonicToSeed(mnemonic, passphrase), path)
+}
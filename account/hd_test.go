@@ -0,0 +1,53 @@
+package account
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHDPath(t *testing.T) {
+	p, err := ParseHDPath(DefaultHDPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{HardenedOffset + 44, HardenedOffset + CoinType, HardenedOffset, HardenedOffset, HardenedOffset}, p)
+	p, err = ParseHDPath("44h/291h/1/2")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{HardenedOffset + 44, HardenedOffset + CoinType, 1, 2}, p)
+	p, err = ParseHDPath("m")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(p))
+	_, err = ParseHDPath("m/44'/abc")
+	assert.NotNil(t, err)
+}
+
+func TestHDKeyDerive(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master := NewMasterHDKey(seed)
+
+	k, err := master.Derive("m/0'")
+	assert.Nil(t, err)
+	assert.Equal(t, "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", hex.EncodeToString(k.Key))
+	assert.Equal(t, "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69", hex.EncodeToString(k.ChainCode))
+
+	k, err = master.Derive("m/0'/1'")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, k.Depth)
+	assert.Equal(t, "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2", hex.EncodeToString(k.Key))
+	assert.Equal(t, "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14", hex.EncodeToString(k.ChainCode))
+
+	_, err = master.Derive("m/0")
+	assert.NotNil(t, err)
+}
+
+func TestDeriveKeyPairFromMnemonic(t *testing.T) {
+	m := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	kp0, err := DeriveKeyPairFromMnemonic(m, "", DefaultHDPath)
+	assert.Nil(t, err)
+	kp1, err := DeriveKeyPairFromMnemonic(m, "", "m/44'/291'/1'/0'/0'")
+	assert.Nil(t, err)
+	assert.NotEqual(t, kp0.Pubkey, kp1.Pubkey)
+	master, err := NewKeyPairFromMnemonic(m, "")
+	assert.Nil(t, err)
+	assert.NotEqual(t, master.Pubkey, kp0.Pubkey)
+}
@@ -0,0 +1,106 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var wordIndex map[string]int
+
+func init() {
+	wordIndex = make(map[string]int, len(englishWordList))
+	for i, w := range englishWordList {
+		wordIndex[w] = i
+	}
+}
+
+// NewMnemonic generates a random BIP39 mnemonic with the given number of words (12, 15, 18, 21 or 24).
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", fmt.Errorf("invalid mnemonic word count %v", words)
+	}
+	entropy := make([]byte, words/3*4)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return NewMnemonicFromEntropy(entropy)
+}
+
+// NewMnemonicFromEntropy encodes the entropy as a BIP39 mnemonic.
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy length %v", len(entropy))
+	}
+	checksumBits := uint(bits / 32)
+	hash := sha256.Sum256(entropy)
+
+	// entropy followed by the first checksumBits bits of its hash
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, checksumBits)
+	n.Or(n, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	count := (bits + int(checksumBits)) / 11
+	words := make([]string, count)
+	mask := big.NewInt(2047)
+	for i := count - 1; i >= 0; i-- {
+		words[i] = englishWordList[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// ValidateMnemonic checks the words and the checksum of a BIP39 mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return fmt.Errorf("invalid mnemonic word count %v", len(words))
+	}
+	n := new(big.Int)
+	for _, w := range words {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return fmt.Errorf("invalid mnemonic word %v", w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(idx)))
+	}
+	checksumBits := uint(len(words) / 3)
+	checksum := new(big.Int).And(n, big.NewInt(int64(1)<<checksumBits-1)).Int64()
+	n.Rsh(n, checksumBits)
+
+	entropy := make([]byte, len(words)/3*4)
+	b := n.Bytes()
+	copy(entropy[len(entropy)-len(b):], b)
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return fmt.Errorf("invalid mnemonic checksum")
+	}
+	return nil
+}
+
+// MnemonicToSeed converts a mnemonic and an optional passphrase to the 64 bytes BIP39 seed.
+func MnemonicToSeed(mnemonic string, passphrase string) []byte {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+// NewKeyPairFromMnemonic derives the ed25519 master key pair of a BIP39 mnemonic as defined in SLIP-0010.
+func NewKeyPairFromMnemonic(mnemonic string, passphrase string) (*KeyPair, error) {
+	return DeriveKeyPairFromMnemonic(mnemonic, passphrase, "m")
+}
+
+// masterKey returns the SLIP-0010 ed25519 master secret and chain code of a seed.
+func masterKey(seed []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,64 @@
+package account
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicFromEntropy(t *testing.T) {
+	cases := []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+	for _, c := range cases {
+		entropy, _ := hex.DecodeString(c.entropy)
+		m, err := NewMnemonicFromEntropy(entropy)
+		assert.Nil(t, err)
+		assert.Equal(t, c.mnemonic, m)
+		assert.Nil(t, ValidateMnemonic(m))
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	m, err := NewMnemonic(24)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(strings.Fields(m)))
+	assert.Nil(t, ValidateMnemonic(m))
+
+	assert.NotNil(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+	assert.NotNil(t, ValidateMnemonic("abandon abandon abandon"))
+	assert.NotNil(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon iost"))
+	_, err = NewMnemonic(13)
+	assert.NotNil(t, err)
+}
+
+func TestMnemonicToSeed(t *testing.T) {
+	seed := MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+}
+
+func TestMasterKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, chainCode := masterKey(seed)
+	assert.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(key))
+	assert.Equal(t, "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", hex.EncodeToString(chainCode))
+}
+
+func TestNewKeyPairFromMnemonic(t *testing.T) {
+	m := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	kp1, err := NewKeyPairFromMnemonic(m, "")
+	assert.Nil(t, err)
+	kp2, err := NewKeyPairFromMnemonic(m, "")
+	assert.Nil(t, err)
+	assert.Equal(t, kp1.Seckey, kp2.Seckey)
+	kp3, err := NewKeyPairFromMnemonic(m, "passphrase")
+	assert.Nil(t, err)
+	assert.NotEqual(t, kp1.Pubkey, kp3.Pubkey)
+}
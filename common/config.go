@@ -52,12 +52,19 @@ type GenesisConfig struct {
 // DBConfig config of the database
 type DBConfig struct {
 	LdbPath string
+	// StateChanges is whether the state keys written by each irreversible block are kept, for GetBlockStateChanges.
+	StateChanges bool
+	// StateHistory is whether the values the state keys had before each irreversible block are kept, for the state
+	// queries at the blocks before the last irreversible block, as archive nodes do.
+	StateHistory bool
 }
 
 // VMConfig config of the v8vm
 type VMConfig struct {
 	JsPath   string
 	LogLevel string
+	// Wasm is whether the wasm contracts are published and called, which all the nodes of a chain have to agree on.
+	Wasm bool
 }
 
 // P2PConfig is the config for p2p network.
@@ -74,7 +81,7 @@ type P2PConfig struct {
 	AdminPort    string
 }
 
-//RPCConfig is the config for RPC Server.
+// RPCConfig is the config for RPC Server.
 type RPCConfig struct {
 	Enable       bool
 	GatewayAddr  string
@@ -82,6 +89,57 @@ type RPCConfig struct {
 	AllowOrigins []string
 	TryTx        bool
 	ExecTx       bool
+	// PendingState is whether GetAccount and GetTokenBalance may include the pending txs, which are then executed on
+	// the head block each time they or the head change.
+	PendingState bool
+	// MaxBlockSubscriptions is how many streams of SubscribeBlocks may be open at once.
+	MaxBlockSubscriptions int
+	// BlockSubscriptionBuffer is how many blocks are read ahead for each stream of SubscribeBlocks.
+	BlockSubscriptionBuffer int
+	// SlowSubscriberTimeout is how long a stream of SubscribeBlocks may leave its buffer full before it is closed.
+	SlowSubscriberTimeout time.Duration
+	// GraphQL is whether the gateway serves GraphQL queries at /graphql.
+	GraphQL bool
+	// WebSocket is whether the gateway serves JSON-RPC 2.0 calls and subscriptions over websocket at /ws.
+	WebSocket bool
+	// APIKeys are the keys required to call the rpcs, given by the x-api-key header or grpc metadata, or by the
+	// api_key parameter of the urls of the gateway. The rpcs are open to all if there is none and no APIKeysFile.
+	APIKeys []*APIKeyConfig
+	// APIKeysFile is a yaml file listing more keys under apikeys, read again when it changes.
+	APIKeysFile string
+	// Compression is whether the gateway compresses its responses with gzip for the requests accepting it. The grpc
+	// calls are always answered with the compression they are made with, gzip being supported.
+	Compression bool
+	// CompressMethods are the rpcs whose responses are compressed by the gateway, like GetBlockByNumber, or GraphQL
+	// for the GraphQL queries, all if empty.
+	CompressMethods []string
+	// CompressMinSize is the size in bytes under which the responses of the gateway are sent uncompressed.
+	CompressMinSize int
+	// ReadyMaxBlocksBehind is how many blocks the head block may be behind the time for the node to be ready at
+	// /readyz of the gateway, 60 if 0.
+	ReadyMaxBlocksBehind int
+	// SlowLogThreshold is how long a call may take before it is logged as slow, with its request, none if 0.
+	SlowLogThreshold time.Duration
+	// MaxRecvMsgSize and MaxSendMsgSize are the most bytes of a message received and sent by the grpc server,
+	// 4 MB and 64 MB if 0.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// MaxConcurrentStreams is how many calls each connection of the grpc server may have at once, 200 if 0.
+	MaxConcurrentStreams int
+	// Timeout is how long a unary call may take before it fails with DeadlineExceeded, 30s if 0.
+	Timeout time.Duration
+	// MethodTimeouts are the timeouts of some rpcs, like EstimateGas, in place of Timeout.
+	MethodTimeouts map[string]time.Duration
+}
+
+// APIKeyConfig is a key allowed to call the rpcs.
+type APIKeyConfig struct {
+	Key string
+	// QPS is how many calls per second the key may make, in bursts of up to Burst calls, unlimited if 0.
+	QPS   float64
+	Burst int
+	// Methods are the rpcs the key may call, like GetChainInfo, or GraphQL for the GraphQL queries, all if empty.
+	Methods []string
 }
 
 // FileLogConfig is the config for filewriter of ilog.
@@ -125,6 +183,18 @@ type DebugConfig struct {
 	ListenAddr string
 }
 
+// AdminConfig is the config of the admin grpc service, which is served apart from the rpcs of RPCConfig.
+type AdminConfig struct {
+	Enable bool
+	// Addr is the address of the admin service. It must be a loopback address unless the service is protected by
+	// mutual tls, with CertFile, KeyFile and ClientCAFile.
+	Addr     string
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the pem file of the certificates authorities of the certificates of the clients.
+	ClientCAFile string
+}
+
 // VersionConfig contrains netname(mainnet / testnet etc) and protocol info
 type VersionConfig struct {
 	NetName         string
@@ -143,6 +213,7 @@ type Config struct {
 	Log      *LogConfig
 	Metrics  *MetricsConfig
 	Debug    *DebugConfig
+	Admin    *AdminConfig
 	Version  *VersionConfig
 }
 
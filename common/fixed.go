@@ -36,6 +36,11 @@ func UnmarshalFixed(s string) (*Fixed, error) {
 	return &Fixed{Value: int64(binary.LittleEndian.Uint64([]byte(s[:8]))), Decimal: int(int32(binary.LittleEndian.Uint32([]byte(s[8:]))))}, nil
 }
 
+// IsOverflow tells whether the error of a fixed point number is an overflow of its int64 value.
+func IsOverflow(err error) bool {
+	return err == errOverflow
+}
+
 func multiplyOverflow(a int64, b int64) bool {
 	x := a * b
 	if a != 0 && x/a != b {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"compress/gzip"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/db/kv/leveldb"
 )
 
@@ -47,27 +49,48 @@ func Load(db db.MVCCDB) (*block.Block, error) {
 }
 */
 
-// ToSnapshot the function for saving db to snapshot.
+// Path returns the path of the snapshot file saved by ToSnapshot.
+func Path(conf *common.Config) string {
+	return filepath.Join(conf.DB.LdbPath, "Snapshot.tar.gz")
+}
+
+// ToSnapshot the function for saving db to snapshot, the state db not being opened meanwhile.
 func ToSnapshot(conf *common.Config) error {
-	var src string
-	src = filepath.Join(conf.DB.LdbPath, "StateDB")
+	return archive(conf.DB.LdbPath, Path(conf))
+}
+
+// SaveSnapshot saves the flushed state of the state db in use to the snapshot file, by archiving a copy of it taken at
+// once, as flushes and compactions keep writing the db.
+func SaveSnapshot(conf *common.Config, stateDB db.MVCCDB) error {
+	dir, err := ioutil.TempDir(conf.DB.LdbPath, "Checkpoint")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := stateDB.Checkpoint(filepath.Join(dir, "StateDB")); err != nil {
+		return fmt.Errorf("failed to copy state db: %v", err)
+	}
+	return archive(dir, Path(conf))
+}
+
+// archive writes the StateDB dir in base to the file dst, which is replaced only once fully written.
+func archive(base string, dst string) error {
+	src := filepath.Join(base, "StateDB")
 	if _, err := os.Stat(src); err != nil {
 		return fmt.Errorf("Unable to tar files - %v", err.Error())
 	}
 
-	file, err := os.Create(filepath.Join(conf.DB.LdbPath, "Snapshot.tar.gz"))
+	tmp := dst + ".tmp"
+	file, err := os.Create(tmp)
 	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp)
 	defer file.Close()
 
 	gzw := gzip.NewWriter(file)
-	defer gzw.Close()
-
 	tw := tar.NewWriter(gzw)
-	defer tw.Close()
-
-	return filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
+	err = filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -75,7 +98,7 @@ func ToSnapshot(conf *common.Config) error {
 		if err != nil {
 			return err
 		}
-		header.Name = strings.TrimPrefix(file, conf.DB.LdbPath)
+		header.Name = strings.TrimPrefix(file, base)
 		if err := tw.WriteHeader(header); err != nil {
 			return err
 		}
@@ -92,6 +115,19 @@ func ToSnapshot(conf *common.Config) error {
 		f.Close()
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
 }
 
 // FromSnapshot the function for loading db from snapshot.
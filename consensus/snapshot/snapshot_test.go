@@ -2,13 +2,16 @@ package snapshot
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/db"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -56,6 +59,58 @@ func TestSnapshot(t *testing.T) {
 	})
 }
 
+func TestSaveSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	keys := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		k, v := randString(64), randString(32)
+		keys[k] = v
+		assert.Nil(t, stateDB.Put("state", k, v))
+	}
+	stateDB.Commit("abc")
+	assert.Nil(t, stateDB.Flush("abc"))
+	// the commits not flushed are not saved
+	assert.Nil(t, stateDB.Put("state", "unflushed", "value"))
+	stateDB.Commit("def")
+
+	config := &common.Config{
+		DB: &common.DBConfig{
+			LdbPath: dir,
+		},
+		Snapshot: &common.SnapshotConfig{
+			Enable:   true,
+			FilePath: filepath.Join(dir, "Snapshot.tar.gz"),
+		},
+	}
+	assert.Nil(t, SaveSnapshot(config, stateDB))
+
+	restored := &common.Config{
+		DB: &common.DBConfig{
+			LdbPath: filepath.Join(dir, "Restored"),
+		},
+		Snapshot: config.Snapshot,
+	}
+	assert.Nil(t, FromSnapshot(restored))
+	restoredDB, err := db.NewMVCCDB(filepath.Join(dir, "Restored", "StateDB"))
+	assert.Nil(t, err)
+	defer restoredDB.Close()
+	assert.Equal(t, "abc", restoredDB.CurrentTag())
+	for k, v := range keys {
+		value, err := restoredDB.Get("state", k)
+		assert.Nil(t, err)
+		assert.Equal(t, v, value)
+	}
+	has, err := restoredDB.Has("state", "unflushed")
+	assert.Nil(t, err)
+	assert.False(t, has)
+}
+
 func BenchmarkSnapshot(b *testing.B) {
 	os.RemoveAll("DB")
 	defer os.RemoveAll("DB")
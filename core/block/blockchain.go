@@ -1,14 +1,17 @@
 package block
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
-
+	"math"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/db/kv"
 )
 
@@ -23,6 +26,8 @@ type BlockChain struct { //nolint:golint
 var (
 	blockLength       = []byte("BlockLength")
 	blockTxTotal      = []byte("BlockTxTotal")
+	stateHistoryStart = []byte("StateHistoryStart")
+	accountIndexStart = []byte("AccountIndexStart")
 	blockNumberPrefix = []byte("n")
 	blockPrefix       = []byte("H")
 	txPrefix          = []byte("t")      // txPrefix + tx hash -> block hash + tx hash
@@ -31,6 +36,10 @@ var (
 	receiptPrefix     = []byte("r")      // receiptPrefix + receipt hash -> block hash + receipt hash
 	bReceiptPrefix    = []byte("b")      // bReceiptPrefix + block hash + receipt hash -> receipt data
 	delaytxPrefix     = []byte("delay-") // delaytxPrefix + tx hash -> tx data
+	accountTxPrefix   = []byte("a")      // accountTxPrefix + account + "/" + reversed block number + reversed tx index -> tx hash
+	stateChangePrefix = []byte("s")      // stateChangePrefix + block number -> state changes of the block
+	// stateHistoryPrefix + table + "/" + key + "/" + block number -> value of the key before the block changed it
+	stateHistoryPrefix = []byte("v")
 )
 
 // NewBlockChain returns a Chain instance
@@ -66,6 +75,16 @@ func NewBlockChain(path string) (Chain, error) {
 			return nil, errors.New("fail to put tx total")
 		}
 	}
+	// the txs of accounts are indexed from the first block pushed by a version indexing them
+	ok, err = levelDB.Has(accountIndexStart)
+	if err != nil {
+		return nil, fmt.Errorf("fail to check has(accountindexstart), %v", err)
+	}
+	if !ok {
+		if err := levelDB.Put(accountIndexStart, common.Int64ToBytes(length)); err != nil {
+			return nil, errors.New("fail to put account index start")
+		}
+	}
 	BC := &BlockChain{
 		blockChainDB: levelDB,
 		length:       length,
@@ -144,6 +163,10 @@ func (bc *BlockChain) Push(block *Block) error {
 		for _, canceledHash := range canceledDelayHashes {
 			bc.blockChainDB.Delete(append(delaytxPrefix, canceledHash...))
 		}
+
+		for _, acc := range txAccounts(t, block.Receipts[i]) {
+			bc.blockChainDB.Put(accountTxKey(acc, number, i), tHash)
+		}
 	}
 	err = bc.blockChainDB.CommitBatch()
 	if err != nil {
@@ -154,6 +177,55 @@ func (bc *BlockChain) Push(block *Block) error {
 	return nil
 }
 
+// txAccounts returns the accounts involved in the tx, which are the publisher, the signers, and the senders and
+// receivers of the token and token721 transfers.
+func txAccounts(t *tx.Tx, r *tx.TxReceipt) []string {
+	accounts := []string{t.Publisher}
+	seen := map[string]bool{t.Publisher: true}
+	add := func(acc string) {
+		if !seen[acc] {
+			seen[acc] = true
+			accounts = append(accounts, acc)
+		}
+	}
+	for _, signer := range t.Signers {
+		add(strings.SplitN(signer, "@", 2)[0])
+	}
+	for _, acc := range r.ParseTransferAccounts() {
+		add(acc)
+	}
+	if r.Status.Code != tx.Success {
+		return accounts
+	}
+	// token721 transfers post no receipt, their accounts are read from the actions
+	for _, a := range t.Actions {
+		if a.Contract != "token721.iost" || a.ActionName != "transfer" {
+			continue
+		}
+		var args []interface{}
+		if err := json.Unmarshal([]byte(a.Data), &args); err != nil || len(args) < 3 {
+			continue
+		}
+		for _, arg := range args[1:3] {
+			if acc, ok := arg.(string); ok {
+				add(acc)
+			}
+		}
+	}
+	return accounts
+}
+
+func accountTxKeyPrefix(account string) []byte {
+	return append(append([]byte{}, accountTxPrefix...), []byte(account+"/")...)
+}
+
+// accountTxKey is reversed in block number and tx index, so that the newest txs of an account come first.
+func accountTxKey(account string, number int64, index int) []byte {
+	key := accountTxKeyPrefix(account)
+	key = append(key, common.Int64ToBytes(math.MaxInt64-number)...)
+	return append(key, common.Int32ToBytes(math.MaxInt32-int32(index))...)
+}
+
 // CheckLength is check length of block in database
 func (bc *BlockChain) CheckLength() {
 	for i := bc.Length(); i > 0; i-- {
@@ -236,6 +308,21 @@ func (bc *BlockChain) GetBlockByHash(hash []byte) (*Block, error) {
 	return &blk, nil
 }
 
+// GetBlockHeadByHash returns the block of the hash with its head and sign only, the txs and receipts stored apart
+// not being read.
+func (bc *BlockChain) GetBlockHeadByHash(hash []byte) (*Block, error) {
+	blockByte, err := bc.getBlockByteByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	var blk Block
+	err = blk.Decode(blockByte)
+	if err != nil {
+		return nil, errors.New("fail to decode blockByte")
+	}
+	return &blk, nil
+}
+
 // GetBlockByNumber is get block by number
 func (bc *BlockChain) GetBlockByNumber(number int64) (*Block, error) {
 	hash, err := bc.GetHashByNumber(number)
@@ -306,6 +393,27 @@ func (bc *BlockChain) GetTx(hash []byte) (*tx.Tx, error) {
 	return &tx, nil
 }
 
+// GetBlockNumberByTxHash gets the number of the block packing the tx with tx's hash.
+func (bc *BlockChain) GetBlockNumberByTxHash(hash []byte) (int64, error) {
+	bTx, err := bc.blockChainDB.Get(append(txPrefix, hash...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to Get the tx: %v", err)
+	}
+	if len(bTx) <= len(hash) {
+		return 0, fmt.Errorf("failed to Get the tx: not found")
+	}
+	blockByte, err := bc.getBlockByteByHash(bTx[:len(bTx)-len(hash)])
+	if err != nil {
+		return 0, err
+	}
+	var blk Block
+	err = blk.Decode(blockByte)
+	if err != nil {
+		return 0, errors.New("fail to decode blockByte")
+	}
+	return blk.Head.Number, nil
+}
+
 // HasTx checks if database has tx.
 func (bc *BlockChain) HasTx(hash []byte) (bool, error) {
 	return bc.blockChainDB.Has(append(txPrefix, hash...))
@@ -358,6 +466,199 @@ func (bc *BlockChain) HasReceipt(hash []byte) (bool, error) {
 	return bc.blockChainDB.Has(append(receiptPrefix, hash...))
 }
 
+// MaxAccountTxsOffset is the max offset of GetTxHashesByAccount, as the txs skipped are iterated over. The older txs
+// are paged with the cursor of GetAccountTxs.
+const MaxAccountTxsOffset = 10000
+
+// GetTxHashesByAccount returns the hashes of txs involving the account, newest first.
+func (bc *BlockChain) GetTxHashesByAccount(account string, offset int, limit int) ([][]byte, error) {
+	if offset > MaxAccountTxsOffset {
+		return nil, fmt.Errorf("offset should be at most %v, get the older account txs with a cursor", MaxAccountTxsOffset)
+	}
+	iter := bc.blockChainDB.NewIteratorByPrefix(accountTxKeyPrefix(account))
+	hashes := make([][]byte, 0, limit)
+	for i := 0; len(hashes) < limit && iter.Next(); i++ {
+		if i < offset {
+			continue
+		}
+		hashes = append(hashes, append([]byte{}, iter.Value()...))
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get account txs: %v", err)
+	}
+	return hashes, nil
+}
+
+// AccountIndexStart returns the number of the first block whose txs are indexed by account, the txs of the older
+// blocks not being listed by GetTxHashesByAccount and GetAccountTxs.
+func (bc *BlockChain) AccountIndexStart() (int64, error) {
+	data, err := bc.blockChainDB.Get(accountIndexStart)
+	if err != nil || len(data) == 0 {
+		return 0, errors.New("fail to get account index start")
+	}
+	return common.BytesToInt64(data), nil
+}
+
+// AccountTx is a tx involving an account, at the index in the txs of its block.
+type AccountTx struct {
+	BlockNumber int64
+	Index       int
+	Hash        []byte
+}
+
+// GetAccountTxs returns the txs involving the account in the blocks of numbers up to from, newest first, starting
+// after the tx of after if not nil.
+func (bc *BlockChain) GetAccountTxs(account string, from int64, after *AccountTx, limit int) ([]*AccountTx, error) {
+	prefix := accountTxKeyPrefix(account)
+	start := accountTxKey(account, from, math.MaxInt32)
+	if after != nil {
+		// the keys are of the same length, so the key of the next tx is the first after this one
+		start = append(accountTxKey(account, after.BlockNumber, after.Index), 0)
+	}
+	iter := bc.blockChainDB.NewIteratorByPrefixFrom(prefix, start)
+	txs := make([]*AccountTx, 0, limit)
+	for len(txs) < limit && iter.Next() {
+		key := iter.Key()[len(prefix):]
+		txs = append(txs, &AccountTx{
+			BlockNumber: math.MaxInt64 - common.BytesToInt64(key[:8]),
+			Index:       int(math.MaxInt32 - common.BytesToInt32(key[8:12])),
+			Hash:        append([]byte{}, iter.Value()...),
+		})
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get account txs: %v", err)
+	}
+	return txs, nil
+}
+
+// stateChange is the stored form of a db.Change, the value being bytes so that json keeps the values which are not
+// utf-8, like the int64 balances and the protobuf contracts.
+type stateChange struct {
+	Table   string
+	Key     string
+	Value   []byte
+	Deleted bool
+}
+
+// PutStateChanges saves the state changes of the block of number.
+func (bc *BlockChain) PutStateChanges(number int64, changes []*db.Change) error {
+	scs := make([]*stateChange, 0, len(changes))
+	for _, c := range changes {
+		scs = append(scs, &stateChange{Table: c.Table, Key: c.Key, Value: []byte(c.Value), Deleted: c.Deleted})
+	}
+	data, err := json.Marshal(scs)
+	if err != nil {
+		return fmt.Errorf("fail to encode state changes, err:%s", err)
+	}
+	err = bc.blockChainDB.Put(append(stateChangePrefix, common.Int64ToBytes(number)...), data)
+	if err != nil {
+		return fmt.Errorf("fail to put state changes, err:%s", err)
+	}
+	return nil
+}
+
+// GetStateChanges returns the state changes of the block of number.
+func (bc *BlockChain) GetStateChanges(number int64) ([]*db.Change, error) {
+	data, err := bc.blockChainDB.Get(append(stateChangePrefix, common.Int64ToBytes(number)...))
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("fail to get state changes by number")
+	}
+	var scs []*stateChange
+	err = json.Unmarshal(data, &scs)
+	if err != nil {
+		return nil, fmt.Errorf("fail to decode state changes, err:%s", err)
+	}
+	changes := make([]*db.Change, 0, len(scs))
+	for _, c := range scs {
+		changes = append(changes, &db.Change{Table: c.Table, Key: c.Key, Value: string(c.Value), Deleted: c.Deleted})
+	}
+	return changes, nil
+}
+
+// stateHistory values are the values of the keys prefixed by stateHistorySet, or stateHistoryUnset if not set.
+const (
+	stateHistorySet   = 'v'
+	stateHistoryUnset = 'n'
+)
+
+func stateHistoryKeyPrefix(table, key string) []byte {
+	return append(append([]byte{}, stateHistoryPrefix...), table+"/"+key+"/"...)
+}
+
+// PutStateHistory saves the values the keys had before the block of number changed them, Deleted if they were not
+// set. The blocks must be put in order.
+func (bc *BlockChain) PutStateHistory(number int64, previous []*db.Change) error {
+	ok, err := bc.blockChainDB.Has(stateHistoryStart)
+	if err != nil {
+		return fmt.Errorf("fail to check state history start, err:%s", err)
+	}
+	err = bc.blockChainDB.BeginBatch()
+	if err != nil {
+		return errors.New("fail to begin batch")
+	}
+	if !ok {
+		bc.blockChainDB.Put(stateHistoryStart, common.Int64ToBytes(number))
+	}
+	for _, c := range previous {
+		value := []byte{stateHistoryUnset}
+		if !c.Deleted {
+			value = append([]byte{stateHistorySet}, c.Value...)
+		}
+		bc.blockChainDB.Put(append(stateHistoryKeyPrefix(c.Table, c.Key), common.Int64ToBytes(number)...), value)
+	}
+	err = bc.blockChainDB.CommitBatch()
+	if err != nil {
+		return fmt.Errorf("fail to put state history, err:%s", err)
+	}
+	return nil
+}
+
+// SkipStateHistory marks the state history of the block of number as not saved, the history starting after it so
+// that the states before it are unavailable.
+func (bc *BlockChain) SkipStateHistory(number int64) error {
+	err := bc.blockChainDB.Put(stateHistoryStart, common.Int64ToBytes(number+1))
+	if err != nil {
+		return fmt.Errorf("fail to skip state history, err:%s", err)
+	}
+	return nil
+}
+
+// StateHistoryStart returns the number of the first block whose state history is saved.
+func (bc *BlockChain) StateHistoryStart() (int64, error) {
+	data, err := bc.blockChainDB.Get(stateHistoryStart)
+	if err != nil || len(data) == 0 {
+		return 0, errors.New("fail to get state history start")
+	}
+	return common.BytesToInt64(data), nil
+}
+
+// GetStateHistory returns the value of the key after the block of number, if it was changed by a later block whose
+// state history is saved. Otherwise it returns nil, the value being the one of the state of the last block saved.
+func (bc *BlockChain) GetStateHistory(table, key string, number int64) (*db.Change, error) {
+	prefix := stateHistoryKeyPrefix(table, key)
+	iter := bc.blockChainDB.NewIteratorByPrefixFrom(prefix, append(append([]byte{}, prefix...), common.Int64ToBytes(number+1)...))
+	defer iter.Release()
+	for iter.Next() {
+		// the longer keys are those of other keys beginning with key + "/"
+		if len(iter.Key()) != len(prefix)+8 {
+			continue
+		}
+		value := iter.Value()
+		if len(value) == 0 || value[0] == stateHistoryUnset {
+			return &db.Change{Table: table, Key: key, Deleted: true}, nil
+		}
+		return &db.Change{Table: table, Key: key, Value: string(value[1:])}, nil
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("fail to get state history: %v", err)
+	}
+	return nil, nil
+}
+
 // Size returns the blockchain db size
 func (bc *BlockChain) Size() (int64, error) {
 	return bc.blockChainDB.Size()
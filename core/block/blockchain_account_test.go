@@ -0,0 +1,288 @@
+package block
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTxHashesByAccount(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	var hashes [][]byte
+	for number := int64(0); number < 3; number++ {
+		blk := &Block{
+			Head: &BlockHead{Version: 2, ParentHash: []byte("parent hash"), Number: number, Time: number},
+			Sign: &crypto.Signature{},
+		}
+		for i := 0; i < 2; i++ {
+			trx := &tx.Tx{Time: number*10 + int64(i), Publisher: "alice"}
+			r := tx.NewTxReceipt(trx.Hash())
+			if i == 1 {
+				r.Receipts = append(r.Receipts, &tx.Receipt{FuncName: "token.iost/transfer", Content: `["iost","alice","bob","1",""]`})
+			}
+			blk.Txs = append(blk.Txs, trx)
+			blk.Receipts = append(blk.Receipts, r)
+			hashes = append([][]byte{trx.Hash()}, hashes...)
+		}
+		blk.CalculateHeadHash()
+		assert.Nil(t, bc.Push(blk))
+	}
+
+	got, err := bc.GetTxHashesByAccount("alice", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, hashes, got)
+
+	got, err = bc.GetTxHashesByAccount("alice", 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[1:3], got)
+
+	got, err = bc.GetTxHashesByAccount("bob", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{hashes[0], hashes[2], hashes[4]}, got)
+
+	got, err = bc.GetTxHashesByAccount("ali", 0, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+
+	got, err = bc.GetTxHashesByAccount("alice", MaxAccountTxsOffset, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+	_, err = bc.GetTxHashesByAccount("alice", MaxAccountTxsOffset+1, 10)
+	assert.NotNil(t, err)
+}
+
+func TestGetAccountTxs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	var txs []*AccountTx
+	for number := int64(0); number < 3; number++ {
+		blk := &Block{
+			Head: &BlockHead{Version: 2, ParentHash: []byte("parent hash"), Number: number, Time: number},
+			Sign: &crypto.Signature{},
+		}
+		for i := 0; i < 2; i++ {
+			trx := &tx.Tx{Time: number*10 + int64(i), Publisher: "alice"}
+			if i == 1 {
+				trx.Signers = []string{"bob@active"}
+				trx.Actions = []*tx.Action{{Contract: "token721.iost", ActionName: "transfer", Data: `["nft","alice","carol","1"]`}}
+			}
+			blk.Txs = append(blk.Txs, trx)
+			blk.Receipts = append(blk.Receipts, tx.NewTxReceipt(trx.Hash()))
+			txs = append([]*AccountTx{{BlockNumber: number, Index: i, Hash: trx.Hash()}}, txs...)
+		}
+		blk.CalculateHeadHash()
+		assert.Nil(t, bc.Push(blk))
+	}
+
+	got, err := bc.GetAccountTxs("alice", math.MaxInt64, nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, txs, got)
+
+	got, err = bc.GetAccountTxs("alice", 1, nil, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, txs[2:5], got)
+
+	got, err = bc.GetAccountTxs("alice", 1, got[2], 3)
+	assert.Nil(t, err)
+	assert.Equal(t, txs[5:], got)
+
+	// the signers and the token721 receivers are indexed
+	for _, acc := range []string{"bob", "carol"} {
+		got, err = bc.GetAccountTxs(acc, math.MaxInt64, nil, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []*AccountTx{txs[0], txs[2], txs[4]}, got)
+	}
+}
+
+func TestGetBlockNumberByTxHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	var hashes [][]byte
+	for number := int64(0); number < 2; number++ {
+		trx := &tx.Tx{Time: number, Publisher: "alice"}
+		blk := &Block{
+			Head:     &BlockHead{Version: 2, ParentHash: []byte("parent hash"), Number: number, Time: number},
+			Sign:     &crypto.Signature{},
+			Txs:      []*tx.Tx{trx},
+			Receipts: []*tx.TxReceipt{tx.NewTxReceipt(trx.Hash())},
+		}
+		blk.CalculateHeadHash()
+		assert.Nil(t, bc.Push(blk))
+		hashes = append(hashes, trx.Hash())
+	}
+
+	for number, hash := range hashes {
+		got, err := bc.GetBlockNumberByTxHash(hash)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(number), got)
+	}
+	_, err = bc.GetBlockNumberByTxHash([]byte("missing"))
+	assert.NotNil(t, err)
+}
+
+func TestStateChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	changes := []*db.Change{
+		{Table: "state", Key: "b-token.iost-k", Value: "s1"},
+		{Table: "state", Key: "m-token.iost-TBalice-iost", Deleted: true},
+	}
+	assert.Nil(t, bc.PutStateChanges(1, changes))
+	got, err := bc.GetStateChanges(1)
+	assert.Nil(t, err)
+	assert.Equal(t, changes, got)
+
+	_, err = bc.GetStateChanges(2)
+	assert.NotNil(t, err)
+}
+
+func TestStateChangesBinaryValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	// the int64 balances are stored as "i" and their 8 little endian bytes, which are not utf-8
+	balance := make([]byte, 8)
+	binary.LittleEndian.PutUint64(balance, uint64(200000000))
+	// the length of the code takes two bytes in the protobuf, the first of which is not utf-8
+	c := &contract.Contract{ID: "Contractabc", Code: strings.Repeat("a", 200), Info: &contract.Info{Lang: "javascript", Version: "1.0.0", Abi: []*contract.ABI{{Name: "transfer", Args: []string{"string", "number"}}}}}
+	changes := []*db.Change{
+		{Table: "state", Key: "m-token.iost-TBalice-iost", Value: "i" + string(balance) + "@"},
+		{Table: "state", Key: "c-Contractabc", Value: c.Encode()},
+	}
+	assert.False(t, utf8.ValidString(changes[0].Value))
+	assert.False(t, utf8.ValidString(changes[1].Value))
+	assert.Nil(t, bc.PutStateChanges(1, changes))
+	got, err := bc.GetStateChanges(1)
+	assert.Nil(t, err)
+	assert.Equal(t, changes, got)
+	assert.Equal(t, uint64(200000000), binary.LittleEndian.Uint64([]byte(got[0].Value[1:9])))
+	decoded := &contract.Contract{}
+	assert.Nil(t, decoded.Decode(got[1].Value))
+	assert.Equal(t, c.ID, decoded.ID)
+	assert.Equal(t, c.Code, decoded.Code)
+}
+
+func TestStateHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	_, err = bc.StateHistoryStart()
+	assert.NotNil(t, err)
+
+	assert.Nil(t, bc.PutStateHistory(3, []*db.Change{
+		{Table: "state", Key: "k", Value: "v0"},
+		{Table: "state", Key: "j", Deleted: true},
+	}))
+	assert.Nil(t, bc.PutStateHistory(5, []*db.Change{
+		{Table: "state", Key: "k", Value: "v3"},
+		{Table: "state", Key: "k/x", Value: "x3"},
+	}))
+	start, err := bc.StateHistoryStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), start)
+
+	for _, c := range []struct {
+		key    string
+		number int64
+		want   *db.Change
+	}{
+		{"k", 2, &db.Change{Table: "state", Key: "k", Value: "v0"}},
+		{"k", 3, &db.Change{Table: "state", Key: "k", Value: "v3"}},
+		{"k", 4, &db.Change{Table: "state", Key: "k", Value: "v3"}},
+		{"k", 5, nil},
+		{"j", 2, &db.Change{Table: "state", Key: "j", Deleted: true}},
+		{"j", 3, nil},
+		{"k/x", 4, &db.Change{Table: "state", Key: "k/x", Value: "x3"}},
+		{"l", 2, nil},
+	} {
+		got, err := bc.GetStateHistory("state", c.key, c.number)
+		assert.Nil(t, err)
+		assert.Equal(t, c.want, got, "%v at %v", c.key, c.number)
+	}
+
+	assert.Nil(t, bc.SkipStateHistory(6))
+	assert.Nil(t, bc.PutStateHistory(7, []*db.Change{{Table: "state", Key: "k", Value: "v6"}}))
+	start, err = bc.StateHistoryStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), start)
+}
+
+func TestAccountIndexStart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := NewBlockChain(dir)
+	assert.Nil(t, err)
+	start, err := bc.AccountIndexStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), start)
+	for number := int64(0); number < 2; number++ {
+		blk := &Block{
+			Head: &BlockHead{Version: 2, ParentHash: []byte("parent hash"), Number: number, Time: number},
+			Sign: &crypto.Signature{},
+		}
+		blk.CalculateHeadHash()
+		assert.Nil(t, bc.Push(blk))
+	}
+	bc.Close()
+
+	// the blocks pushed before the txs of accounts were indexed are left out
+	storage, err := kv.NewStorage(dir, kv.LevelDBStorage)
+	assert.Nil(t, err)
+	assert.Nil(t, storage.Delete(accountIndexStart))
+	storage.Close()
+	bc, err = NewBlockChain(dir)
+	assert.Nil(t, err)
+	start, err = bc.AccountIndexStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), start)
+	bc.Close()
+
+	// the start is kept once set
+	bc, err = NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+	start, err = bc.AccountIndexStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), start)
+}
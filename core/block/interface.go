@@ -1,6 +1,9 @@
 package block
 
-import "github.com/iost-official/go-iost/core/tx"
+import (
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/db"
+)
 
 //go:generate mockgen -destination ../mocks/mock_blockchain.go -package core_mock github.com/iost-official/go-iost/core/block Chain
 
@@ -15,11 +18,22 @@ type Chain interface {
 	GetHashByNumber(number int64) ([]byte, error)
 	GetBlockByNumber(number int64) (*Block, error)
 	GetBlockByHash(blockHash []byte) (*Block, error)
+	GetBlockHeadByHash(blockHash []byte) (*Block, error)
 	GetTx(hash []byte) (*tx.Tx, error)
+	GetBlockNumberByTxHash(hash []byte) (int64, error)
 	HasTx(hash []byte) (bool, error)
 	GetReceipt(Hash []byte) (*tx.TxReceipt, error)
 	GetReceiptByTxHash(Hash []byte) (*tx.TxReceipt, error)
 	HasReceipt(hash []byte) (bool, error)
+	GetTxHashesByAccount(account string, offset int, limit int) ([][]byte, error)
+	GetAccountTxs(account string, from int64, after *AccountTx, limit int) ([]*AccountTx, error)
+	AccountIndexStart() (int64, error)
+	PutStateChanges(number int64, changes []*db.Change) error
+	GetStateChanges(number int64) ([]*db.Change, error)
+	PutStateHistory(number int64, previous []*db.Change) error
+	SkipStateHistory(number int64) error
+	StateHistoryStart() (int64, error)
+	GetStateHistory(table, key string, number int64) (*db.Change, error)
 	Size() (int64, error)
 	Close()
 	AllDelaytx() ([]*tx.Tx, error)
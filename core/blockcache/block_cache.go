@@ -276,6 +276,8 @@ type BlockCacheImpl struct { //nolint:golint
 	witnessNum        int64
 	blockChain        block.Chain
 	stateDB           db.MVCCDB
+	stateChanges      bool
+	stateHistory      bool
 	wal               *wal.WAL
 }
 
@@ -344,6 +346,8 @@ func NewBlockCache(baseVariable global.BaseVariable) (*BlockCacheImpl, error) {
 		leaf:              make(map[*BlockCacheNode]int64),
 		blockChain:        baseVariable.BlockChain(),
 		stateDB:           baseVariable.StateDB().Fork(),
+		stateChanges:      baseVariable.Config().DB.StateChanges,
+		stateHistory:      baseVariable.Config().DB.StateHistory,
 		wal:               w,
 	}
 	bc.linkedRoot.Head.Number = -1
@@ -714,6 +718,75 @@ func (bc *BlockCacheImpl) delSingle() {
 	}
 }
 
+// pushStateChanges saves the state keys written by the block, and the values they had in the state of its parent
+// for the state history, before its commit is freed by the flush and before it becomes the linked root, so that the
+// states before it can be read from the state of the linked root and the history. If its history can't be saved, the
+// history starts after it.
+func (bc *BlockCacheImpl) pushStateChanges(parent *BlockCacheNode, bcn *BlockCacheNode) {
+	changes, err := bc.stateDB.Changes(string(bcn.HeadHash()))
+	if err != nil {
+		ilog.Errorf("get state changes error: %v %v", bcn.HeadHash(), err)
+		bc.skipStateHistory(bcn)
+		return
+	}
+	if bc.stateChanges {
+		err = bc.blockChain.PutStateChanges(bcn.Head.Number, changes)
+		if err != nil {
+			ilog.Errorf("Database error, BlockChain PutStateChanges err: %v %v", bcn.HeadHash(), err)
+		}
+	}
+	if bc.stateHistory {
+		previous, err := previousValues(bc.stateDB, parent, changes)
+		if err != nil {
+			ilog.Errorf("get state history error: %v %v", bcn.HeadHash(), err)
+			bc.skipStateHistory(bcn)
+			return
+		}
+		err = bc.blockChain.PutStateHistory(bcn.Head.Number, previous)
+		if err != nil {
+			ilog.Errorf("Database error, BlockChain PutStateHistory err: %v %v", bcn.HeadHash(), err)
+			bc.skipStateHistory(bcn)
+		}
+	}
+}
+
+func (bc *BlockCacheImpl) skipStateHistory(bcn *BlockCacheNode) {
+	if !bc.stateHistory {
+		return
+	}
+	ilog.Errorf("state history of block %v not saved, the states before it are unavailable", bcn.Head.Number)
+	err := bc.blockChain.SkipStateHistory(bcn.Head.Number)
+	if err != nil {
+		ilog.Errorf("Database error, BlockChain SkipStateHistory err: %v %v", bcn.HeadHash(), err)
+	}
+}
+
+// previousValues returns the values the keys of the changes have in the state of the block, Deleted if not set.
+func previousValues(stateDB db.MVCCDB, bcn *BlockCacheNode, changes []*db.Change) ([]*db.Change, error) {
+	state := stateDB.Fork()
+	if !state.Checkout(string(bcn.HeadHash())) {
+		return nil, fmt.Errorf("checkout state of block %v failed", bcn.Head.Number)
+	}
+	previous := make([]*db.Change, 0, len(changes))
+	for _, c := range changes {
+		p := &db.Change{Table: c.Table, Key: c.Key}
+		ok, err := state.Has(c.Table, c.Key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			p.Value, err = state.Get(c.Table, c.Key)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			p.Deleted = true
+		}
+		previous = append(previous, p)
+	}
+	return previous, nil
+}
+
 func (bc *BlockCacheImpl) flush(bcn *BlockCacheNode) {
 	parent := bcn.GetParent()
 	if parent != bc.LinkedRoot() {
@@ -730,6 +803,10 @@ func (bc *BlockCacheImpl) flush(bcn *BlockCacheNode) {
 		return
 	}
 
+	if bc.stateChanges || bc.stateHistory {
+		bc.pushStateChanges(parent, bcn)
+	}
+
 	bc.updateLinkedRootWitness(parent, bcn)
 	bcn.removeValidWitness(bcn)
 	bc.nmdel(parent.Head.Number)
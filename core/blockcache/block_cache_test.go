@@ -2,6 +2,9 @@ package blockcache
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
 	"testing"
 
 	. "github.com/golang/mock/gomock"
@@ -10,8 +13,12 @@ import (
 
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/wal"
 	"github.com/iost-official/go-iost/vm/database"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func genBlock(fa *block.Block, wit string, num uint64) *block.Block {
@@ -304,3 +311,91 @@ func StringSliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+// historyChain records the number of the linked root when the state history of a block is put.
+type historyChain struct {
+	block.Chain
+	bc    *BlockCacheImpl
+	roots []int64
+}
+
+func (c *historyChain) PutStateHistory(number int64, previous []*db.Change) error {
+	c.roots = append(c.roots, c.bc.LinkedRoot().Head.Number)
+	return c.Chain.PutStateHistory(number, previous)
+}
+
+func TestFlushStateHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockcache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir + "/state")
+	assert.Nil(t, err)
+	defer stateDB.Close()
+	chain, err := block.NewBlockChain(dir + "/chain")
+	assert.Nil(t, err)
+	defer chain.Close()
+	w, err := wal.Create(dir+"/wal", []byte("block_cache_wal"))
+	assert.Nil(t, err)
+
+	b0 := genBlock(nil, "w0", 0)
+	b1 := genBlock(b0, "w1", 1)
+	b2 := genBlock(b1, "w2", 2)
+	for _, b := range []*block.Block{b0, b1, b2} {
+		b.Sign = &crypto.Signature{}
+	}
+	v := database.NewVisitor(0, stateDB)
+	v.Put("Contractabc-count", database.MustMarshal(int64(1)))
+	v.Commit()
+	stateDB.Commit(string(b0.HeadHash()))
+	v.Put("Contractabc-count", database.MustMarshal(int64(2)))
+	v.Commit()
+	stateDB.Commit(string(b1.HeadHash()))
+
+	root := NewBCN(nil, b0)
+	root.Type = Linked
+	n1 := NewBCN(root, b1)
+	n1.Type = Linked
+	n2 := NewBCN(n1, b2)
+	n2.Type = Linked
+	hc := &historyChain{Chain: chain}
+	bc := &BlockCacheImpl{
+		linkedRoot:   root,
+		singleRoot:   NewBCN(nil, nil),
+		head:         n2,
+		hash2node:    new(sync.Map),
+		number2node:  new(sync.Map),
+		leaf:         map[*BlockCacheNode]int64{n2: 2},
+		blockChain:   hc,
+		stateDB:      stateDB,
+		stateHistory: true,
+		wal:          w,
+	}
+	hc.bc = bc
+	for _, n := range []*BlockCacheNode{root, n1, n2} {
+		bc.hmset(n.HeadHash(), n)
+		bc.nmset(n.Head.Number, n)
+	}
+
+	bc.flush(n1)
+	// the history of the block 1 is put while the block 0 is still the linked root
+	assert.Equal(t, []int64{0}, hc.roots)
+	assert.Equal(t, int64(1), bc.LinkedRoot().Head.Number)
+	start, err := chain.StateHistoryStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), start)
+	// the count after the block 0 is read from the state of the linked root and the history
+	state := stateDB.Fork()
+	assert.True(t, state.Checkout(string(bc.LinkedRoot().HeadHash())))
+	count, err := state.Get(database.StateTable, database.BasicPrefix+"Contractabc-count")
+	assert.Nil(t, err)
+	assert.Equal(t, database.MustMarshal(int64(2)), count)
+	c, err := chain.GetStateHistory(database.StateTable, database.BasicPrefix+"Contractabc-count", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, database.MustMarshal(int64(1)), c.Value)
+
+	// the state of the block 2 is not committed, so its history can't be saved and the history starts after it
+	bc.flush(n2)
+	start, err = chain.StateHistoryStart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), start)
+}
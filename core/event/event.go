@@ -1,7 +1,10 @@
 package event
 
 import (
+	"bytes"
+	"encoding/json"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +21,7 @@ type Topic int
 const (
 	ContractReceipt Topic = iota
 	ContractEvent
+	TransactionPending
 )
 
 func (t Topic) String() string {
@@ -26,6 +30,8 @@ func (t Topic) String() string {
 		return "ContractReceipt"
 	case ContractEvent:
 		return "ContractEvent"
+	case TransactionPending:
+		return "TransactionPending"
 	default:
 		return "unknown_topic:" + strconv.Itoa(int(t))
 	}
@@ -36,6 +42,8 @@ type Event struct {
 	Topic Topic
 	Data  string
 	Time  int64
+	// ContractID is the contract posting the event, set by Post from its meta.
+	ContractID string
 }
 
 // NewEvent generate new event with topic and data
@@ -64,10 +72,114 @@ func (m *Meta) Match(meta *Meta) bool {
 	return true
 }
 
+// ArgFilter matches an argument of the events whose data is a json array or object.
+type ArgFilter struct {
+	// Key is the index of the argument in the json array, or its key in the json object.
+	Key string
+	// Values are the values one of which the argument must equal, strings being compared without quotes.
+	Values []string
+}
+
+// Filter selects the events sent to a subscription, its empty fields matching any event.
+type Filter struct {
+	// ContractIDs are the contracts of which the events are sent.
+	ContractIDs []string
+	// Args are the arguments the data of the events must match.
+	Args []ArgFilter
+}
+
+// Match checks whether the event posted with the meta passes the filter.
+func (f *Filter) Match(e *Event, meta *Meta) bool {
+	return f.match(meta, &eventArgs{data: e.Data})
+}
+
+func (f *Filter) match(meta *Meta, args *eventArgs) bool {
+	if len(f.ContractIDs) > 0 {
+		if meta == nil {
+			return false
+		}
+		found := false
+		for _, id := range f.ContractIDs {
+			if id == meta.ContractID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, a := range f.Args {
+		v, ok := args.get(a.Key)
+		if !ok {
+			return false
+		}
+		found := false
+		for _, value := range a.Values {
+			if value == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// eventArgs decodes the data of an event once for the filters of all the subscriptions.
+type eventArgs struct {
+	data    string
+	decoded bool
+	value   interface{}
+}
+
+// get returns the argument of the key as a string, json encoded unless a string or a number.
+func (a *eventArgs) get(key string) (string, bool) {
+	if !a.decoded {
+		a.decoded = true
+		d := json.NewDecoder(strings.NewReader(a.data))
+		d.UseNumber()
+		if err := d.Decode(&a.value); err != nil || d.More() {
+			a.value = nil
+		}
+	}
+	var v interface{}
+	switch value := a.value.(type) {
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(value) {
+			return "", false
+		}
+		v = value[i]
+	case map[string]interface{}:
+		var ok bool
+		if v, ok = value[key]; !ok {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	}
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(v); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
 // Subscription is a struct used for listening specific topics
 type Subscription struct {
 	C      chan<- *Event
-	filter *Meta
+	filter *Filter
 }
 
 var ec *Collector
@@ -88,6 +200,15 @@ func GetCollector() *Collector {
 
 // Subscribe registers a subscription in event collector.
 func (ec *Collector) Subscribe(id int64, topics []Topic, filter *Meta) <-chan *Event {
+	var f *Filter
+	if filter != nil && filter.ContractID != "" {
+		f = &Filter{ContractIDs: []string{filter.ContractID}}
+	}
+	return ec.SubscribeFilter(id, topics, f)
+}
+
+// SubscribeFilter registers a subscription to the events of the topics passing the filter, nil for all of them.
+func (ec *Collector) SubscribeFilter(id int64, topics []Topic, filter *Filter) <-chan *Event {
 	c := make(chan *Event, EventChSize)
 	for _, topic := range topics {
 		m, _ := ec.subMap.LoadOrStore(topic, new(sync.Map))
@@ -110,9 +231,10 @@ func (ec *Collector) Unsubscribe(id int64, topics []Topic) {
 
 func (ec *Collector) sendEvent(e *Event, meta *Meta) {
 	if m, exist := ec.subMap.Load(e.Topic); exist {
+		args := &eventArgs{data: e.Data}
 		m.(*sync.Map).Range(func(k, v interface{}) bool {
 			sub := v.(*Subscription)
-			if sub.filter != nil && !sub.filter.Match(meta) {
+			if sub.filter != nil && !sub.filter.match(meta, args) {
 				return true
 			}
 			select {
@@ -125,7 +247,10 @@ func (ec *Collector) sendEvent(e *Event, meta *Meta) {
 	}
 }
 
-// Post a event.
+// Post a event, whose contract is set from the meta.
 func (ec *Collector) Post(e *Event, meta *Meta) {
+	if meta != nil {
+		e.ContractID = meta.ContractID
+	}
 	go ec.sendEvent(e, meta)
 }
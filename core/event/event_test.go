@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -110,3 +111,57 @@ func TestEventCollectorFullPost(t *testing.T) {
 
 	assert.EqualValues(t, event.EventChSize, atomic.LoadInt32(&count))
 }
+
+func TestFilterMatch(t *testing.T) {
+	transfer := event.NewEvent(event.ContractReceipt, `["iost","alice","bob","1.5",""]`)
+	object := event.NewEvent(event.ContractEvent, `{"to":"bob","n":10,"ok":true,"list":[1,"a"]}`)
+	plain := event.NewEvent(event.ContractEvent, `bob`)
+	token := &event.Meta{ContractID: "token.iost"}
+	for _, c := range []struct {
+		filter event.Filter
+		e      *event.Event
+		meta   *event.Meta
+		match  bool
+	}{
+		{event.Filter{}, plain, nil, true},
+		{event.Filter{ContractIDs: []string{"base.iost", "token.iost"}}, transfer, token, true},
+		{event.Filter{ContractIDs: []string{"base.iost"}}, transfer, token, false},
+		{event.Filter{ContractIDs: []string{"token.iost"}}, transfer, nil, false},
+		{event.Filter{Args: []event.ArgFilter{{Key: "2", Values: []string{"bob"}}}}, transfer, token, true},
+		{event.Filter{Args: []event.ArgFilter{{Key: "2", Values: []string{"carol", "bob"}}, {Key: "0", Values: []string{"iost"}}}}, transfer, token, true},
+		{event.Filter{Args: []event.ArgFilter{{Key: "2", Values: []string{"bob"}}, {Key: "0", Values: []string{"usdt"}}}}, transfer, token, false},
+		{event.Filter{Args: []event.ArgFilter{{Key: "5", Values: []string{""}}}}, transfer, token, false},
+		{event.Filter{Args: []event.ArgFilter{{Key: "to", Values: []string{"bob"}}, {Key: "n", Values: []string{"10"}}}}, object, nil, true},
+		{event.Filter{Args: []event.ArgFilter{{Key: "ok", Values: []string{"true"}}, {Key: "list", Values: []string{`[1,"a"]`}}}}, object, nil, true},
+		{event.Filter{Args: []event.ArgFilter{{Key: "0", Values: []string{"bob"}}}}, plain, nil, false},
+	} {
+		assert.Equal(t, c.match, c.filter.Match(c.e, c.meta), fmt.Sprint(c.filter, c.e.Data))
+	}
+}
+
+func TestEventCollectorSubscribeFilter(t *testing.T) {
+	ilog.Stop()
+	ec := event.GetCollector()
+	ch := ec.SubscribeFilter(10, []event.Topic{event.ContractReceipt}, &event.Filter{
+		ContractIDs: []string{"token.iost"},
+		Args:        []event.ArgFilter{{Key: "2", Values: []string{"bob"}}},
+	})
+	defer ec.Unsubscribe(10, []event.Topic{event.ContractReceipt})
+
+	ec.Post(event.NewEvent(event.ContractReceipt, `["iost","alice","carol","1",""]`), &event.Meta{ContractID: "token.iost"})
+	ec.Post(event.NewEvent(event.ContractReceipt, `["iost","alice","bob","1",""]`), &event.Meta{ContractID: "base.iost"})
+	ec.Post(event.NewEvent(event.ContractReceipt, `["iost","alice","bob","2",""]`), &event.Meta{ContractID: "token.iost"})
+
+	select {
+	case e := <-ch:
+		assert.Equal(t, `["iost","alice","bob","2",""]`, e.Data)
+		assert.Equal(t, "token.iost", e.ContractID)
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+	select {
+	case e := <-ch:
+		t.Fatal("unexpected event", e.Data)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
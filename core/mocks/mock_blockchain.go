@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iost-official/go-iost/core/block"
 	tx "github.com/iost-official/go-iost/core/tx"
+	db "github.com/iost-official/go-iost/db"
 	reflect "reflect"
 )
 
@@ -34,6 +35,19 @@ func (m *MockChain) EXPECT() *MockChainMockRecorder {
 	return m.recorder
 }
 
+// AccountIndexStart mocks base method
+func (m *MockChain) AccountIndexStart() (int64, error) {
+	ret := m.ctrl.Call(m, "AccountIndexStart")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountIndexStart indicates an expected call of AccountIndexStart
+func (mr *MockChainMockRecorder) AccountIndexStart() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountIndexStart", reflect.TypeOf((*MockChain)(nil).AccountIndexStart))
+}
+
 // AllDelaytx mocks base method
 func (m *MockChain) AllDelaytx() ([]*tx.Tx, error) {
 	ret := m.ctrl.Call(m, "AllDelaytx")
@@ -79,6 +93,19 @@ func (mr *MockChainMockRecorder) Draw(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Draw", reflect.TypeOf((*MockChain)(nil).Draw), arg0, arg1)
 }
 
+// GetAccountTxs mocks base method
+func (m *MockChain) GetAccountTxs(arg0 string, arg1 int64, arg2 *block.AccountTx, arg3 int) ([]*block.AccountTx, error) {
+	ret := m.ctrl.Call(m, "GetAccountTxs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*block.AccountTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTxs indicates an expected call of GetAccountTxs
+func (mr *MockChainMockRecorder) GetAccountTxs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTxs", reflect.TypeOf((*MockChain)(nil).GetAccountTxs), arg0, arg1, arg2, arg3)
+}
+
 // GetBlockByHash mocks base method
 func (m *MockChain) GetBlockByHash(arg0 []byte) (*block.Block, error) {
 	ret := m.ctrl.Call(m, "GetBlockByHash", arg0)
@@ -92,6 +119,32 @@ func (mr *MockChainMockRecorder) GetBlockByHash(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockChain)(nil).GetBlockByHash), arg0)
 }
 
+// GetBlockHeadByHash mocks base method
+func (m *MockChain) GetBlockHeadByHash(arg0 []byte) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeadByHash", arg0)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeadByHash indicates an expected call of GetBlockHeadByHash
+func (mr *MockChainMockRecorder) GetBlockHeadByHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeadByHash", reflect.TypeOf((*MockChain)(nil).GetBlockHeadByHash), arg0)
+}
+
+// GetBlockNumberByTxHash mocks base method
+func (m *MockChain) GetBlockNumberByTxHash(arg0 []byte) (int64, error) {
+	ret := m.ctrl.Call(m, "GetBlockNumberByTxHash", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockNumberByTxHash indicates an expected call of GetBlockNumberByTxHash
+func (mr *MockChainMockRecorder) GetBlockNumberByTxHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumberByTxHash", reflect.TypeOf((*MockChain)(nil).GetBlockNumberByTxHash), arg0)
+}
+
 // GetBlockByNumber mocks base method
 func (m *MockChain) GetBlockByNumber(arg0 int64) (*block.Block, error) {
 	ret := m.ctrl.Call(m, "GetBlockByNumber", arg0)
@@ -144,6 +197,45 @@ func (mr *MockChainMockRecorder) GetReceiptByTxHash(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByTxHash", reflect.TypeOf((*MockChain)(nil).GetReceiptByTxHash), arg0)
 }
 
+// GetTxHashesByAccount mocks base method
+func (m *MockChain) GetTxHashesByAccount(arg0 string, arg1, arg2 int) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "GetTxHashesByAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxHashesByAccount indicates an expected call of GetTxHashesByAccount
+func (mr *MockChainMockRecorder) GetTxHashesByAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxHashesByAccount", reflect.TypeOf((*MockChain)(nil).GetTxHashesByAccount), arg0, arg1, arg2)
+}
+
+// GetStateChanges mocks base method
+func (m *MockChain) GetStateChanges(arg0 int64) ([]*db.Change, error) {
+	ret := m.ctrl.Call(m, "GetStateChanges", arg0)
+	ret0, _ := ret[0].([]*db.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateChanges indicates an expected call of GetStateChanges
+func (mr *MockChainMockRecorder) GetStateChanges(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateChanges", reflect.TypeOf((*MockChain)(nil).GetStateChanges), arg0)
+}
+
+// GetStateHistory mocks base method
+func (m *MockChain) GetStateHistory(arg0, arg1 string, arg2 int64) (*db.Change, error) {
+	ret := m.ctrl.Call(m, "GetStateHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*db.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateHistory indicates an expected call of GetStateHistory
+func (mr *MockChainMockRecorder) GetStateHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateHistory", reflect.TypeOf((*MockChain)(nil).GetStateHistory), arg0, arg1, arg2)
+}
+
 // GetTx mocks base method
 func (m *MockChain) GetTx(arg0 []byte) (*tx.Tx, error) {
 	ret := m.ctrl.Call(m, "GetTx", arg0)
@@ -207,6 +299,30 @@ func (mr *MockChainMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockChain)(nil).Push), arg0)
 }
 
+// PutStateChanges mocks base method
+func (m *MockChain) PutStateChanges(arg0 int64, arg1 []*db.Change) error {
+	ret := m.ctrl.Call(m, "PutStateChanges", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutStateChanges indicates an expected call of PutStateChanges
+func (mr *MockChainMockRecorder) PutStateChanges(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutStateChanges", reflect.TypeOf((*MockChain)(nil).PutStateChanges), arg0, arg1)
+}
+
+// PutStateHistory mocks base method
+func (m *MockChain) PutStateHistory(arg0 int64, arg1 []*db.Change) error {
+	ret := m.ctrl.Call(m, "PutStateHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutStateHistory indicates an expected call of PutStateHistory
+func (mr *MockChainMockRecorder) PutStateHistory(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutStateHistory", reflect.TypeOf((*MockChain)(nil).PutStateHistory), arg0, arg1)
+}
+
 // SetLength mocks base method
 func (m *MockChain) SetLength(arg0 int64) {
 	m.ctrl.Call(m, "SetLength", arg0)
@@ -217,6 +333,18 @@ func (mr *MockChainMockRecorder) SetLength(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLength", reflect.TypeOf((*MockChain)(nil).SetLength), arg0)
 }
 
+// SkipStateHistory mocks base method
+func (m *MockChain) SkipStateHistory(arg0 int64) error {
+	ret := m.ctrl.Call(m, "SkipStateHistory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SkipStateHistory indicates an expected call of SkipStateHistory
+func (mr *MockChainMockRecorder) SkipStateHistory(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipStateHistory", reflect.TypeOf((*MockChain)(nil).SkipStateHistory), arg0)
+}
+
 // Size mocks base method
 func (m *MockChain) Size() (int64, error) {
 	ret := m.ctrl.Call(m, "Size")
@@ -230,6 +358,19 @@ func (mr *MockChainMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockChain)(nil).Size))
 }
 
+// StateHistoryStart mocks base method
+func (m *MockChain) StateHistoryStart() (int64, error) {
+	ret := m.ctrl.Call(m, "StateHistoryStart")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateHistoryStart indicates an expected call of StateHistoryStart
+func (mr *MockChainMockRecorder) StateHistoryStart() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateHistoryStart", reflect.TypeOf((*MockChain)(nil).StateHistoryStart))
+}
+
 // Top mocks base method
 func (m *MockChain) Top() (*block.Block, error) {
 	ret := m.ctrl.Call(m, "Top")
@@ -19,11 +19,15 @@ import (
 const (
 	minGasRatio = 100
 	maxGasRatio = 10000
-	minGasLimit = 600000
-	maxGasLimit = 400000000
 	txSizeLimit = 65536
 )
 
+// The bounds of the gas limit of the transactions, in hundredths of gas.
+const (
+	MinGasLimit = 600000
+	MaxGasLimit = 400000000
+)
+
 // values
 var (
 	MaxExpiration = int64(90 * time.Second)
@@ -342,8 +346,8 @@ func (t *Tx) CheckGas() error {
 	if t.GasRatio < minGasRatio || t.GasRatio > maxGasRatio {
 		return fmt.Errorf("gas ratio illegal, should in [%v, %v]", minGasRatio/ratio, maxGasRatio/ratio)
 	}
-	if t.GasLimit < minGasLimit || t.GasLimit > maxGasLimit {
-		return fmt.Errorf("gas limit illegal, should in [%v, %v]", minGasLimit/ratio, maxGasLimit/ratio)
+	if t.GasLimit < MinGasLimit || t.GasLimit > MaxGasLimit {
+		return fmt.Errorf("gas limit illegal, should in [%v, %v]", MinGasLimit/ratio, MaxGasLimit/ratio)
 	}
 	return nil
 }
@@ -239,3 +239,31 @@ func (r *TxReceipt) ParseCancelDelaytx() [][]byte {
 	}
 	return ret
 }
+
+// ParseTransferAccounts returns the senders and receivers of the iost transfers in the receipt.
+func (r *TxReceipt) ParseTransferAccounts() []string {
+	if r.Status.Code != Success {
+		return nil
+	}
+	var ret []string
+	for _, receipt := range r.Receipts {
+		if receipt.FuncName == "token.iost/transfer" || receipt.FuncName == "token.iost/transferFreeze" {
+			var params []interface{}
+			err := json.Unmarshal([]byte(receipt.Content), &params)
+			if err != nil {
+				ilog.Errorf("json decode `%s` failed, err=%v", receipt.Content, err)
+				continue
+			}
+			if len(params) < 3 {
+				ilog.Errorf("param length is %d, less than 3. params=%v", len(params), params)
+				continue
+			}
+			for _, p := range params[1:3] {
+				if acc, ok := p.(string); ok {
+					ret = append(ret, acc)
+				}
+			}
+		}
+	}
+	return ret
+}
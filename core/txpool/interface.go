@@ -16,6 +16,7 @@ type TxPool interface {
 	AddTx(tx *tx.Tx) error
 	DelTx(hash []byte) error
 	DelTxList(delList []*tx.Tx)
+	Flush() int
 	ExistTxs(hash []byte, chainBlock *block.Block) FRet
 	GetFromPending(hash []byte) (*tx.Tx, error)
 	GetFromChain(hash []byte) (*tx.Tx, *tx.TxReceipt, error)
@@ -94,6 +94,18 @@ func (mr *MockTxPoolMockRecorder) ExistTxs(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistTxs", reflect.TypeOf((*MockTxPool)(nil).ExistTxs), arg0, arg1)
 }
 
+// Flush mocks base method
+func (m *MockTxPool) Flush() int {
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush
+func (mr *MockTxPoolMockRecorder) Flush() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockTxPool)(nil).Flush))
+}
+
 // GetFromChain mocks base method
 func (m *MockTxPool) GetFromChain(arg0 []byte) (*tx.Tx, *tx.TxReceipt, error) {
 	ret := m.ctrl.Call(m, "GetFromChain", arg0)
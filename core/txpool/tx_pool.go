@@ -10,6 +10,7 @@ import (
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/event"
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/ilog"
@@ -161,6 +162,7 @@ func (pool *TxPImpl) verifyWorkers() {
 		}
 		pool.pendingTx.Add(&t)
 		pool.mu.Unlock()
+		postPendingTx(&t)
 		metricsReceivedTxCount.Add(1, map[string]string{"from": "p2p"})
 		pool.p2pService.Broadcast(v.Data(), p2p.PublishTx, p2p.NormalMessage)
 	}
@@ -226,12 +228,18 @@ func (pool *TxPImpl) AddTx(t *tx.Tx) error {
 		common.Base58Encode(t.Hash()),
 		pool.pendingTx.Size(),
 	)
+	postPendingTx(t)
 
 	pool.p2pService.Broadcast(t.Encode(), p2p.PublishTx, p2p.NormalMessage)
 	metricsReceivedTxCount.Add(1, map[string]string{"from": "rpc"})
 	return nil
 }
 
+// postPendingTx posts the hash of a transaction newly added to pendingTx to the subscribers.
+func postPendingTx(t *tx.Tx) {
+	event.GetCollector().Post(event.NewEvent(event.TransactionPending, common.Base58Encode(t.Hash())), nil)
+}
+
 // DelTx del the transaction
 func (pool *TxPImpl) DelTx(hash []byte) error {
 	pool.pendingTx.Del(hash)
@@ -245,6 +253,15 @@ func (pool *TxPImpl) DelTxList(delList []*tx.Tx) {
 	}
 }
 
+// Flush deletes all the pending transactions, returning how many they were.
+func (pool *TxPImpl) Flush() int {
+	txs := pool.pendingTx.Txs()
+	for _, t := range txs {
+		pool.pendingTx.Del(t.Hash())
+	}
+	return len(txs)
+}
+
 // ExistTxs determine if the transaction exists
 func (pool *TxPImpl) ExistTxs(hash []byte, chainBlock *block.Block) FRet {
 	var r FRet
@@ -200,6 +200,20 @@ func (st *SortedTxMap) Size() int {
 	return len(st.txMap)
 }
 
+// Txs returns a snapshot of the txs of SortedTxMap, in the order they are packed.
+func (st *SortedTxMap) Txs() []*tx.Tx {
+	st.rw.RLock()
+	defer st.rw.RUnlock()
+
+	txs := make([]*tx.Tx, 0, len(st.txMap))
+	iter := st.tree.Iterator()
+	iter.End()
+	for iter.Prev() {
+		txs = append(txs, iter.Key().(*tx.Tx))
+	}
+	return txs
+}
+
 // Iter returns the iterator of SortedTxMap.
 func (st *SortedTxMap) Iter() *Iterator {
 	iter := st.tree.Iterator()
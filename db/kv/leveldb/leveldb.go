@@ -1,6 +1,7 @@
 package leveldb
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/syndtr/goleveldb/leveldb"
@@ -112,6 +113,40 @@ func (d *DB) Size() (int64, error) {
 	return total, nil
 }
 
+// checkpointBatchSize is how many keys are written at once to a checkpoint.
+const checkpointBatchSize = 10000
+
+// Checkpoint copies the database, as it is when called, to a new database in path
+func (d *DB) Checkpoint(path string) error {
+	snap, err := d.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	cp, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return err
+	}
+	defer cp.Close()
+
+	iter := snap.NewIterator(nil, nil)
+	defer iter.Release()
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		if batch.Len() >= checkpointBatchSize {
+			if err := cp.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return cp.Write(batch, nil)
+}
+
 // Close will close the database
 func (d *DB) Close() error {
 	return d.db.Close()
@@ -125,6 +160,18 @@ func (d *DB) NewIteratorByPrefix(prefix []byte) interface{} {
 	}
 }
 
+// NewIteratorByPrefixFrom returns a new iterator by prefix, starting at the first key not less than start
+func (d *DB) NewIteratorByPrefixFrom(prefix []byte, start []byte) interface{} {
+	r := util.BytesPrefix(prefix)
+	if bytes.Compare(start, r.Start) > 0 {
+		r.Start = start
+	}
+	iter := d.db.NewIterator(r, nil)
+	return &Iter{
+		iter: iter,
+	}
+}
+
 // Iter is the iterator for leveldb
 type Iter struct {
 	iter iterator.Iterator
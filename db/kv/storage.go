@@ -23,8 +23,10 @@ type StorageBackend interface {
 	BeginBatch() error
 	CommitBatch() error
 	Size() (int64, error)
+	Checkpoint(path string) error
 	Close() error
 	NewIteratorByPrefix(prefix []byte) interface{}
+	NewIteratorByPrefixFrom(prefix []byte, start []byte) interface{}
 }
 
 // Storage is a kv database
@@ -58,6 +60,14 @@ func (s *Storage) NewIteratorByPrefix(prefix []byte) *Iterator {
 	}
 }
 
+// NewIteratorByPrefixFrom returns a new iterator by prefix, starting at the first key not less than start
+func (s *Storage) NewIteratorByPrefixFrom(prefix []byte, start []byte) *Iterator {
+	ib := s.StorageBackend.NewIteratorByPrefixFrom(prefix, start).(IteratorBackend)
+	return &Iterator{
+		IteratorBackend: ib,
+	}
+}
+
 // IteratorBackend is the storage iterator backend
 type IteratorBackend interface {
 	Next() bool
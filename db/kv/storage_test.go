@@ -2,6 +2,7 @@ package kv
 
 import (
 	"crypto/rand"
+	"os"
 	"os/exec"
 	"reflect"
 	"testing"
@@ -204,6 +205,49 @@ func (suite *StorageTestSuite) TestRecover() {
 	suite.Equal([]byte{}, value)
 }
 
+func (suite *StorageTestSuite) TestIteratorByPrefixFrom() {
+	keys := func(iter *Iterator) []string {
+		var ret []string
+		for iter.Next() {
+			ret = append(ret, string(iter.Key()))
+		}
+		iter.Release()
+		suite.Nil(iter.Error())
+		return ret
+	}
+	suite.Equal([]string{"key03", "key04", "key05"}, keys(suite.storage.NewIteratorByPrefixFrom([]byte("key"), []byte("key03"))))
+	suite.Equal([]string{"key04", "key05"}, keys(suite.storage.NewIteratorByPrefixFrom([]byte("key"), []byte("key030"))))
+	suite.Equal([]string{"iost01", "iost02", "iost03", "iost04", "iost05"}, keys(suite.storage.NewIteratorByPrefixFrom([]byte("iost"), []byte("a"))))
+	suite.Empty(keys(suite.storage.NewIteratorByPrefixFrom([]byte("iost"), []byte("key"))))
+}
+
+func (suite *StorageTestSuite) TestCheckpoint() {
+	path := DBPATH + "_checkpoint"
+	defer os.RemoveAll(path)
+
+	err := suite.storage.BeginBatch()
+	suite.Nil(err)
+	err = suite.storage.Put([]byte("key06"), []byte("value06"))
+	suite.Nil(err)
+	err = suite.storage.Checkpoint(path)
+	suite.Nil(err)
+	err = suite.storage.CommitBatch()
+	suite.Nil(err)
+
+	checkpoint, err := NewStorage(path, suite.t)
+	suite.Require().Nil(err)
+	defer checkpoint.Close()
+	keys, err := checkpoint.Keys([]byte(""))
+	suite.Nil(err)
+	suite.Len(keys, 10)
+	value, err := checkpoint.Get([]byte("key05"))
+	suite.Nil(err)
+	suite.Equal([]byte("value05"), value)
+	value, err = checkpoint.Get([]byte("key06"))
+	suite.Nil(err)
+	suite.Equal([]byte{}, value)
+}
+
 func (suite *StorageTestSuite) TearDownTest() {
 	err := suite.storage.Close()
 	suite.Nil(err)
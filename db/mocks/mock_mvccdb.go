@@ -33,6 +33,19 @@ func (m *MockMVCCDB) EXPECT() *MockMVCCDBMockRecorder {
 	return m.recorder
 }
 
+// Changes mocks base method
+func (m *MockMVCCDB) Changes(arg0 string) ([]*db.Change, error) {
+	ret := m.ctrl.Call(m, "Changes", arg0)
+	ret0, _ := ret[0].([]*db.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Changes indicates an expected call of Changes
+func (mr *MockMVCCDBMockRecorder) Changes(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockMVCCDB)(nil).Changes), arg0)
+}
+
 // Checkout mocks base method
 func (m *MockMVCCDB) Checkout(arg0 string) bool {
 	ret := m.ctrl.Call(m, "Checkout", arg0)
@@ -45,6 +58,18 @@ func (mr *MockMVCCDBMockRecorder) Checkout(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockMVCCDB)(nil).Checkout), arg0)
 }
 
+// Checkpoint mocks base method
+func (m *MockMVCCDB) Checkpoint(arg0 string) error {
+	ret := m.ctrl.Call(m, "Checkpoint", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Checkpoint indicates an expected call of Checkpoint
+func (mr *MockMVCCDBMockRecorder) Checkpoint(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockMVCCDB)(nil).Checkpoint), arg0)
+}
+
 // Close mocks base method
 func (m *MockMVCCDB) Close() error {
 	ret := m.ctrl.Call(m, "Close")
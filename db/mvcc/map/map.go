@@ -50,13 +50,18 @@ func (m *MVCCMap) Put(key []byte, value interface{}) {
 	m.data[string(key)] = value
 }
 
-func (m *MVCCMap) allFromLink(prefix []byte) []interface{} {
+func (m *MVCCMap) diff(prefix []byte) []interface{} {
 	values := make([]interface{}, 0)
 	for k, v := range m.data {
 		if strings.HasPrefix(k, string(prefix)) {
 			values = append(values, v)
 		}
 	}
+	return values
+}
+
+func (m *MVCCMap) allFromLink(prefix []byte) []interface{} {
+	values := m.diff(prefix)
 	if m.parent == nil {
 		return values
 	}
@@ -71,6 +76,14 @@ func (m *MVCCMap) All(prefix []byte) []interface{} {
 	return m.allFromLink(prefix)
 }
 
+// Diff returns the list of nodes prefixed with prefix which are put in this fork, without the ones of its parents
+func (m *MVCCMap) Diff(prefix []byte) []interface{} {
+	m.rwmu.RLock()
+	defer m.rwmu.RUnlock()
+
+	return m.diff(prefix)
+}
+
 // Fork will fork the map
 // thread safe between all forks of the map
 func (m *MVCCMap) Fork() interface{} {
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/iost-official/go-iost/db/kv"
@@ -32,7 +33,9 @@ type MVCCDB interface {
 	CurrentTag() string
 	Fork() MVCCDB
 	Flush(t string) error
+	Changes(t string) ([]*Change, error)
 	Size() (int64, error)
+	Checkpoint(path string) error
 	Close() error
 }
 
@@ -49,6 +52,18 @@ type Item struct {
 	deleted bool
 }
 
+// Change is a key put or deleted by a commit
+type Change struct {
+	Table   string
+	Key     string
+	Value   string
+	Deleted bool
+}
+
+type differ interface {
+	Diff(prefix []byte) []interface{}
+}
+
 // Commit is the cache of specify tag
 type Commit struct {
 	mvcc.Cache
@@ -368,11 +383,48 @@ func (m *CacheMVCCDB) Flush(t string) error {
 	return nil
 }
 
+// Changes returns the keys put or deleted by the commit of tag since the commit it was checked out from, sorted by table and key
+func (m *CacheMVCCDB) Changes(t string) ([]*Change, error) {
+	commit := m.cm.Get(t)
+	if commit == nil {
+		return nil, fmt.Errorf("not found tag: %v", t)
+	}
+	d, ok := commit.Cache.(differ)
+	if !ok {
+		return nil, fmt.Errorf("cache doesn't support changes")
+	}
+	changes := make([]*Change, 0)
+	for _, v := range d.Diff([]byte("")) {
+		item, ok := v.(*Item)
+		if !ok {
+			return nil, fmt.Errorf("can't assert Item type")
+		}
+		changes = append(changes, &Change{
+			Table:   item.table,
+			Key:     item.key,
+			Value:   item.value,
+			Deleted: item.deleted,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Table != changes[j].Table {
+			return changes[i].Table < changes[j].Table
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
 // Size returns the size of mvccdb
 func (m *CacheMVCCDB) Size() (int64, error) {
 	return m.storage.Size()
 }
 
+// Checkpoint copies the flushed state of mvccdb to a new db in path, consistent as each flush is written at once
+func (m *CacheMVCCDB) Checkpoint(path string) error {
+	return m.storage.Checkpoint(path)
+}
+
 // Close will close the mvccdb
 func (m *CacheMVCCDB) Close() error {
 	return m.storage.Close()
@@ -255,6 +255,35 @@ func (suite *MVCCDBTestSuite) TestFlushAndCheckout() {
 	suite.Equal("", value)
 }
 
+func (suite *MVCCDBTestSuite) TestChanges() {
+	err := suite.mvccdb.Put("table01", "key06", "value06")
+	suite.Nil(err)
+	err = suite.mvccdb.Del("table01", "key04")
+	suite.Nil(err)
+	err = suite.mvccdb.Put("table00", "key01", "value01")
+	suite.Nil(err)
+	suite.mvccdb.Commit("tag1")
+
+	err = suite.mvccdb.Put("table01", "key06", "value066")
+	suite.Nil(err)
+	suite.mvccdb.Commit("tag2")
+
+	changes, err := suite.mvccdb.Changes("tag1")
+	suite.Nil(err)
+	suite.Equal([]*Change{
+		{Table: "table00", Key: "key01", Value: "value01"},
+		{Table: "table01", Key: "key04", Deleted: true},
+		{Table: "table01", Key: "key06", Value: "value06"},
+	}, changes)
+
+	changes, err = suite.mvccdb.Changes("tag2")
+	suite.Nil(err)
+	suite.Equal([]*Change{{Table: "table01", Key: "key06", Value: "value066"}}, changes)
+
+	_, err = suite.mvccdb.Changes("tag3")
+	suite.NotNil(err)
+}
+
 func (suite *MVCCDBTestSuite) TearDownTest() {
 	err := suite.mvccdb.Close()
 	suite.Nil(err, "Close MVCCDB should not fail")
@@ -16,6 +16,7 @@ type FileWriter struct {
 	fd       *os.File
 
 	hourlyTicker *hourlyTicker
+	rotateCh     chan struct{}
 }
 
 // NewFileWriter returns a new instance of NewFileWriter.
@@ -23,11 +24,16 @@ func NewFileWriter(filepath string) *FileWriter {
 	return &FileWriter{
 		filepath:     filepath,
 		hourlyTicker: newHourlyTicker(),
+		rotateCh:     make(chan struct{}, 1),
 	}
 }
 
 // Init inits NewFileWriter.
 func (fw *FileWriter) Init() error {
+	return fw.open(time.Now().Format("2006-01-02_15"))
+}
+
+func (fw *FileWriter) open(suffix string) error {
 	if len(fw.filepath) == 0 {
 		fw.filepath = "./"
 	}
@@ -37,7 +43,7 @@ func (fw *FileWriter) Init() error {
 	if err := os.MkdirAll(fw.filepath, 0755); err != nil {
 		panic(err)
 	}
-	logFile := fmt.Sprintf("iost_%s.log", time.Now().Format("2006-01-02_15"))
+	logFile := fmt.Sprintf("iost_%s.log", suffix)
 	linkFile := filepath.Join(fw.filepath, "iost.log")
 
 	_, err := os.Lstat(linkFile)
@@ -76,6 +82,14 @@ func (fw *FileWriter) Flush() error {
 	return fw.fd.Sync()
 }
 
+// Rotate makes the writer write to a new file from the next message.
+func (fw *FileWriter) Rotate() {
+	select {
+	case fw.rotateCh <- struct{}{}:
+	default:
+	}
+}
+
 // Close closes the writer.
 func (fw *FileWriter) Close() error {
 	return fw.fd.Close()
@@ -87,6 +101,10 @@ func (fw *FileWriter) checkFile() {
 		fw.fd.Sync()
 		fw.fd.Close()
 		fw.Init()
+	case <-fw.rotateCh:
+		fw.fd.Sync()
+		fw.fd.Close()
+		fw.open(time.Now().Format("2006-01-02_15-04-05"))
 	default:
 	}
 }
@@ -62,6 +62,11 @@ func Stop() {
 	defaultLogger.Stop()
 }
 
+// Rotate makes the file writers of the global defaultLogger write to new files.
+func Rotate() {
+	defaultLogger.Rotate()
+}
+
 // Flush flushes the global defaultLogger.
 func Flush() {
 	defaultLogger.Flush()
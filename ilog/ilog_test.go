@@ -1,6 +1,9 @@
 package ilog
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,3 +63,30 @@ func BenchmarkFileLogger(b *testing.B) {
 	}
 	logger.Flush()
 }
+
+func TestRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ilog")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	logger := New()
+	fw := NewFileWriter(dir)
+	err = logger.AddWriter(fw)
+	assert.Nil(t, err)
+	logger.Start()
+	defer logger.Stop()
+
+	logger.Infof("before rotation")
+	logger.Rotate()
+	logger.Infof("after rotation")
+	logger.Flush()
+
+	files, err := filepath.Glob(filepath.Join(dir, "iost_*.log"))
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	link, err := os.Readlink(filepath.Join(dir, "iost.log"))
+	assert.Nil(t, err)
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, link))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bytes), "after rotation")
+	assert.NotContains(t, string(bytes), "before rotation")
+}
@@ -136,6 +136,15 @@ func (logger *Logger) Flush() {
 	}
 }
 
+// Rotate makes the file writers write to new files.
+func (logger *Logger) Rotate() {
+	for _, writer := range logger.writers {
+		if fw, ok := writer.(*FileWriter); ok {
+			fw.Rotate()
+		}
+	}
+}
+
 // SetLevel sets all the writers' level to l.
 func (logger *Logger) SetLevel(l Level) {
 	for _, writer := range logger.writers {
@@ -3,9 +3,12 @@ package iserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/iost-official/go-iost/common"
@@ -15,9 +18,11 @@ import (
 	"github.com/iost-official/go-iost/p2p"
 )
 
-// DebugServer is a http server for debug
+// DebugServer is a http server for debug, which may be started and stopped again while the node runs.
 type DebugServer struct {
+	mu       sync.Mutex
 	srv      *http.Server
+	mux      *http.ServeMux
 	conf     *common.DebugConfig
 	p2p      *p2p.NetService
 	blkCache blockcache.BlockCache
@@ -26,24 +31,28 @@ type DebugServer struct {
 
 // NewDebugServer returns new debug server
 func NewDebugServer(conf *common.DebugConfig, p2p *p2p.NetService, blkCache blockcache.BlockCache, blkChain block.Chain) *DebugServer {
-	return &DebugServer{
-		srv:      &http.Server{Addr: conf.ListenAddr},
+	d := &DebugServer{
+		mux:      http.NewServeMux(),
 		conf:     conf,
 		p2p:      p2p,
 		blkCache: blkCache,
 		blkChain: blkChain,
 	}
+	d.registerRoute()
+	return d
 }
 
-// Start starts debug server
-func (d *DebugServer) Start() error {
-	http.HandleFunc(
+func (d *DebugServer) registerRoute() {
+	// the profiles of net/http/pprof are registered to the default mux
+	d.mux.Handle("/", http.DefaultServeMux)
+
+	d.mux.HandleFunc(
 		"/debug/blockcache/",
 		func(rw http.ResponseWriter, r *http.Request) {
 			rw.Write([]byte(d.blkCache.Draw()))
 		})
 
-	http.HandleFunc(
+	d.mux.HandleFunc(
 		"/debug/blockchain/",
 		func(rw http.ResponseWriter, r *http.Request) {
 			rg := r.URL.Query()
@@ -59,7 +68,7 @@ func (d *DebugServer) Start() error {
 			rw.Write([]byte(d.blkChain.Draw(int64(start), int64(end))))
 		})
 
-	http.HandleFunc(
+	d.mux.HandleFunc(
 		"/debug/p2p/neighbors/",
 		func(rw http.ResponseWriter, r *http.Request) {
 			neighbors := d.p2p.NeighborStat()
@@ -67,7 +76,7 @@ func (d *DebugServer) Start() error {
 			rw.Write(bytes)
 		})
 
-	http.HandleFunc(
+	d.mux.HandleFunc(
 		"/debug/setloglevel/",
 		func(rw http.ResponseWriter, r *http.Request) {
 			q := r.URL.Query()
@@ -79,9 +88,26 @@ func (d *DebugServer) Start() error {
 			}
 			rw.Write([]byte("param error"))
 		})
+}
 
+// Start starts debug server, if not running.
+func (d *DebugServer) Start() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conf == nil {
+		return errors.New("debug server is not configured")
+	}
+	if d.srv != nil {
+		return nil
+	}
+	lis, err := net.Listen("tcp", d.conf.ListenAddr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: d.mux}
+	d.srv = srv
 	go func() {
-		if err := d.srv.ListenAndServe(); err != http.ErrServerClosed {
+		if err := srv.Serve(lis); err != http.ErrServerClosed {
 			ilog.Errorf("Debug server listen failed. err=%v", err)
 		}
 	}()
@@ -89,8 +115,20 @@ func (d *DebugServer) Start() error {
 	return nil
 }
 
-// Stop stops debug server
+// Running returns whether the debug server is running.
+func (d *DebugServer) Running() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.srv != nil
+}
+
+// Stop stops debug server, if running.
 func (d *DebugServer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.srv == nil {
+		return
+	}
 	ilog.Infof("Stopping debug server...")
 
 	ctx, _ := context.WithTimeout(context.Background(), time.Second) // nolint
@@ -99,4 +137,5 @@ func (d *DebugServer) Stop() {
 	} else {
 		ilog.Infof("Stopped debug server.")
 	}
+	d.srv = nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
 	"github.com/iost-official/go-iost/rpc"
+	"github.com/iost-official/go-iost/vm"
 )
 
 // Service defines APIs of resident goroutines.
@@ -28,6 +29,7 @@ type IServer struct {
 	sync      *synchronizer.SyncImpl
 	txp       *txpool.TxPImpl
 	rpcServer *rpc.Server
+	admin     *rpc.AdminServer
 	consensus consensus.Consensus
 	debug     *DebugServer
 }
@@ -35,6 +37,7 @@ type IServer struct {
 // New returns a iserver application
 func New(conf *common.Config) *IServer {
 	tx.ChainID = conf.P2P.ChainID
+	vm.SetWasm(conf.VM != nil && conf.VM.Wasm)
 
 	bv, err := global.New(conf)
 	if err != nil {
@@ -79,12 +82,15 @@ func New(conf *common.Config) *IServer {
 
 	debug := NewDebugServer(conf.Debug, p2pService, blkCache, bv.BlockChain())
 
+	admin := rpc.NewAdminServer(txp, bv, p2pService, debug)
+
 	return &IServer{
 		bv:        bv,
 		p2p:       p2pService,
 		sync:      sync,
 		txp:       txp,
 		rpcServer: rpcServer,
+		admin:     admin,
 		consensus: consensus,
 		debug:     debug,
 	}
@@ -98,6 +104,7 @@ func (s *IServer) Start() error {
 		s.txp,
 		s.consensus,
 		s.rpcServer,
+		s.admin,
 	}
 	for _, s := range Services {
 		if err := s.Start(); err != nil {
@@ -120,6 +127,7 @@ func (s *IServer) Stop() {
 		s.debug.Stop()
 	}
 	Services := []Service{
+		s.admin,
 		s.rpcServer,
 		s.consensus,
 		s.txp,
@@ -0,0 +1,286 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var abiDiffFile string
+
+// abiArgTypes are the arg types accepted by the vm.
+var abiArgTypes = map[string]bool{"string": true, "bool": true, "number": true, "json": true}
+
+type abiAmountLimit struct {
+	Token string `json:"token"`
+	Val   string `json:"val"`
+}
+
+type abiItem struct {
+	Name        string            `json:"name"`
+	Args        []string          `json:"args"`
+	AmountLimit []*abiAmountLimit `json:"amountLimit,omitempty"`
+}
+
+// abiFile is the schema of the .abi files generated by "compile".
+type abiFile struct {
+	Lang    string     `json:"lang"`
+	Version string     `json:"version"`
+	Abi     []*abiItem `json:"abi"`
+}
+
+// checkABIName follows the rules of the js validator on chain.
+func checkABIName(name string) error {
+	if len(name) < 1 || len(name) > 32 {
+		return fmt.Errorf("invalid abi name %v, length should be between 1 and 32", name)
+	}
+	if name[0] == '_' {
+		return fmt.Errorf("invalid abi name %v, should not start with _", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return fmt.Errorf("invalid abi name %v, contains invalid character %q", name, c)
+		}
+	}
+	if name == "init" {
+		return fmt.Errorf("abi should not contain the internal function init")
+	}
+	return nil
+}
+
+// parseABIFile decodes an abi file strictly and checks it as the chain would, except for matching it with the code.
+func parseABIFile(data []byte) (*abiFile, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	a := &abiFile{}
+	if err := decoder.Decode(a); err != nil {
+		return nil, fmt.Errorf("invalid abi json: %v", err)
+	}
+	if a.Lang != "javascript" && a.Lang != "wasm" {
+		return nil, fmt.Errorf("invalid lang %q, only javascript and wasm are supported", a.Lang)
+	}
+	if a.Version == "" {
+		return nil, fmt.Errorf("version should not be empty")
+	}
+	if len(a.Abi) == 0 {
+		return nil, fmt.Errorf("abi list should not be empty")
+	}
+	seen := make(map[string]bool)
+	for _, item := range a.Abi {
+		if item == nil {
+			return nil, fmt.Errorf("abi item should not be null")
+		}
+		if err := checkABIName(item.Name); err != nil {
+			return nil, err
+		}
+		if seen[item.Name] {
+			return nil, fmt.Errorf("duplicated abi %v", item.Name)
+		}
+		seen[item.Name] = true
+		for _, arg := range item.Args {
+			if !abiArgTypes[arg] {
+				return nil, fmt.Errorf("invalid arg type %q of abi %v, should be one of string, bool, number and json", arg, item.Name)
+			}
+		}
+		for _, limit := range item.AmountLimit {
+			if limit == nil || limit.Token == "" || limit.Val == "" {
+				return nil, fmt.Errorf("invalid amount limit of abi %v, token and val should not be empty", item.Name)
+			}
+		}
+	}
+	return a, nil
+}
+
+func loadABIFile(file string) (*abiFile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	a, err := parseABIFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return a, nil
+}
+
+func abiFromContract(c *rpcpb.Contract) []*abiItem {
+	items := make([]*abiItem, 0, len(c.Abis))
+	for _, a := range c.Abis {
+		item := &abiItem{Name: a.Name, Args: a.Args}
+		for _, l := range a.AmountLimit {
+			item.AmountLimit = append(item.AmountLimit, &abiAmountLimit{Token: l.Token, Val: l.Value})
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func printABIChanges(changes []*abiChange) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCHANGE\tOLD\tNEW\tBREAKING")
+	for _, ch := range changes {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", ch.Name, ch.Change, ch.Old, ch.New, ch.Breaking)
+	}
+	return w.Flush()
+}
+
+func formatAmountLimit(limits []*abiAmountLimit) string {
+	s := make([]string, 0, len(limits))
+	for _, l := range limits {
+		s = append(s, l.Token+":"+l.Val)
+	}
+	return strings.Join(s, "|")
+}
+
+type abiChange struct {
+	Name     string `json:"name"`
+	Change   string `json:"change"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+	Breaking bool   `json:"breaking"`
+}
+
+// diffABI compares the deployed abis with the local ones. Removing an abi or changing its args breaks existing callers.
+func diffABI(deployed, local []*abiItem) []*abiChange {
+	var changes []*abiChange
+	localMap := make(map[string]*abiItem)
+	for _, a := range local {
+		localMap[a.Name] = a
+	}
+	deployedMap := make(map[string]*abiItem)
+	for _, old := range deployed {
+		deployedMap[old.Name] = old
+		a, ok := localMap[old.Name]
+		if !ok {
+			changes = append(changes, &abiChange{Name: old.Name, Change: "removed", Old: strings.Join(old.Args, ","), Breaking: true})
+			continue
+		}
+		if strings.Join(old.Args, ",") != strings.Join(a.Args, ",") {
+			changes = append(changes, &abiChange{Name: old.Name, Change: "args_changed", Old: strings.Join(old.Args, ","), New: strings.Join(a.Args, ","), Breaking: true})
+		}
+		if formatAmountLimit(old.AmountLimit) != formatAmountLimit(a.AmountLimit) {
+			changes = append(changes, &abiChange{Name: old.Name, Change: "amount_limit_changed", Old: formatAmountLimit(old.AmountLimit), New: formatAmountLimit(a.AmountLimit)})
+		}
+	}
+	for _, a := range local {
+		if _, ok := deployedMap[a.Name]; !ok {
+			changes = append(changes, &abiChange{Name: a.Name, Change: "added", New: strings.Join(a.Args, ",")})
+		}
+	}
+	return changes
+}
+
+func printABITable(items []*abiItem) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tARGS\tAMOUNT LIMIT")
+	for _, a := range items {
+		fmt.Fprintf(w, "%v\t%v\t%v\n", a.Name, strings.Join(a.Args, ","), formatAmountLimit(a.AmountLimit))
+	}
+	return w.Flush()
+}
+
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Inspect contracts",
+	Long:  `Inspect deployed contracts and local abi files`,
+}
+
+var contractABICmd = &cobra.Command{
+	Use:   "abi contractID",
+	Short: "Show the abi of a deployed contract",
+	Long: `Show the abi of a deployed contract
+	With --diff, compare it with a local abi file before updating the contract. Removed abis and changed args are reported as breaking changes and make the command fail.`,
+	Example: `  iwallet contract abi token.iost
+  iwallet contract abi ContractXXX --diff ./example.js.abi`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "contractID")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var local *abiFile
+		if abiDiffFile != "" {
+			var err error
+			local, err = loadABIFile(abiDiffFile)
+			if err != nil {
+				return err
+			}
+		}
+		c, err := iwalletSDK.GetContract(args[0])
+		if err != nil {
+			return err
+		}
+		deployed := abiFromContract(c)
+		if local == nil {
+			if isMachineOutput() {
+				return printResult(&abiFile{Lang: c.Language, Version: c.Version, Abi: deployed})
+			}
+			fmt.Printf("Contract %v, lang %v, version %v\n", c.Id, c.Language, c.Version)
+			return printABITable(deployed)
+		}
+
+		changes := diffABI(deployed, local.Abi)
+		breaking := 0
+		for _, ch := range changes {
+			if ch.Breaking {
+				breaking++
+			}
+		}
+		if isMachineOutput() {
+			if err := printResult(changes); err != nil {
+				return err
+			}
+		} else if len(changes) == 0 {
+			fmt.Println("No abi changes")
+		} else {
+			printABIChanges(changes)
+		}
+		if breaking > 0 {
+			return fmt.Errorf("%v breaking abi change(s) found", breaking)
+		}
+		return nil
+	},
+}
+
+var contractValidateCmd = &cobra.Command{
+	Use:     "validate abiPath",
+	Short:   "Validate a local abi file",
+	Long:    `Check a local abi file against the abi schema and the rules of the chain. Matching the abi with the code is only checked on chain.`,
+	Example: `  iwallet contract validate ./example.js.abi`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "abiPath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadABIFile(args[0]); err != nil {
+			return err
+		}
+		fmt.Println("OK")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(contractCmd)
+	contractCmd.AddCommand(contractABICmd)
+	contractABICmd.Flags().StringVarP(&abiDiffFile, "diff", "", "", "compare with this local abi file")
+	contractCmd.AddCommand(contractValidateCmd)
+}
@@ -0,0 +1,52 @@
+package iwallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseABIFile(t *testing.T) {
+	files, err := filepath.Glob("../config/genesis/contract/*.abi")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, files)
+	for _, f := range files {
+		_, err := loadABIFile(f)
+		assert.Nil(t, err, f)
+	}
+
+	_, err = parseABIFile([]byte(`{"lang":"javascript","version":"1.0.0","abi":[{"name":"f","args":["int"]}]}`))
+	assert.NotNil(t, err)
+	_, err = parseABIFile([]byte(`{"lang":"javascript","version":"1.0.0","abi":[{"name":"f","arg":["string"]}]}`))
+	assert.NotNil(t, err)
+	_, err = parseABIFile([]byte(`{"lang":"javascript","version":"1.0.0","abi":[{"name":"_f","args":[]}]}`))
+	assert.NotNil(t, err)
+	_, err = parseABIFile([]byte(`{"lang":"javascript","version":"1.0.0","abi":[{"name":"f","args":[]},{"name":"f","args":[]}]}`))
+	assert.NotNil(t, err)
+	_, err = parseABIFile([]byte(`{"lang":"wasm","version":"1.0.0","abi":[{"name":"f","args":["string"]}]}`))
+	assert.Nil(t, err)
+	_, err = parseABIFile([]byte(`{"lang":"lua","version":"1.0.0","abi":[{"name":"f","args":["string"]}]}`))
+	assert.NotNil(t, err)
+}
+
+func TestDiffABI(t *testing.T) {
+	deployed := []*abiItem{
+		{Name: "a", Args: []string{"string"}},
+		{Name: "b", Args: []string{"string", "number"}},
+		{Name: "c", Args: []string{}, AmountLimit: []*abiAmountLimit{{Token: "iost", Val: "1"}}},
+	}
+	local := []*abiItem{
+		{Name: "b", Args: []string{"string", "string"}},
+		{Name: "c", Args: []string{}},
+		{Name: "d", Args: []string{"json"}},
+	}
+	changes := diffABI(deployed, local)
+	assert.Equal(t, []*abiChange{
+		{Name: "a", Change: "removed", Old: "string", Breaking: true},
+		{Name: "b", Change: "args_changed", Old: "string,number", New: "string,string", Breaking: true},
+		{Name: "c", Change: "amount_limit_changed", Old: "iost:1"},
+		{Name: "d", Change: "added", New: "json"},
+	}, changes)
+	assert.Empty(t, diffABI(local, local))
+}
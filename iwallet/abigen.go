@@ -0,0 +1,161 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/iost-official/go-iost/iwallet/abigen"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	abigenABIFile    string
+	abigenCodeFile   string
+	abigenEventsFile string
+	abigenPackage    string
+	abigenType       string
+)
+
+// localContract makes a contract of a local abi file and its code, which is only used for the arg names.
+func localContract(id string, abiPath string, codePath string) (*rpcpb.Contract, error) {
+	a, err := loadABIFile(abiPath)
+	if err != nil {
+		return nil, err
+	}
+	c := &rpcpb.Contract{Id: id, Language: a.Lang, Version: a.Version}
+	if codePath != "" {
+		code, err := ioutil.ReadFile(codePath)
+		if err != nil {
+			return nil, err
+		}
+		c.Code = string(code)
+	}
+	for _, item := range a.Abi {
+		abi := &rpcpb.Contract_ABI{Name: item.Name, Args: item.Args}
+		for _, l := range item.AmountLimit {
+			abi.AmountLimit = append(abi.AmountLimit, &rpcpb.AmountLimit{Token: l.Token, Value: l.Val})
+		}
+		c.Abis = append(c.Abis, abi)
+	}
+	return c, nil
+}
+
+// bindingOf describes the binding of a contract, naming the args as "iwallet call --arg" does.
+func bindingOf(c *rpcpb.Contract, pkg string, typeName string, events []*abigen.Event) (*abigen.Contract, error) {
+	b := &abigen.Contract{Package: pkg, Type: typeName, ID: c.Id, Events: events}
+	for _, a := range c.Abis {
+		params, err := abiParams(c, a.Name)
+		if err != nil {
+			return nil, err
+		}
+		m := &abigen.Method{Name: a.Name}
+		for _, p := range params {
+			m.Params = append(m.Params, &abigen.Param{Name: p.Name, Type: p.Type})
+		}
+		for _, l := range a.AmountLimit {
+			m.AmountLimit = append(m.AmountLimit, &abigen.Amount{Token: l.Token, Value: l.Value})
+		}
+		b.Methods = append(b.Methods, m)
+	}
+	return b, nil
+}
+
+var contractAbigenCmd = &cobra.Command{
+	Use:   "abigen [contractID]",
+	Short: "Generate a typed go binding of a contract",
+	Long: `Generate a go package calling the abis of a contract through the sdk, with a method per abi taking typed args
+	The abi and the code naming the args are fetched from the chain, or read from local files by --abi and --code for
+	a contract not deployed yet. Args whose names are unknown are named arg0, arg1 and so on. string, bool, number and
+	json args are string, bool, int64 and interface{} in go. The txs sent by a binding have the amount limit declared
+	by the abi. Contract events have no abi, they can be described by --events to generate structs decoding them:
+	  [{"name": "transfer", "fields": [{"name": "from", "type": "string"}, {"name": "amount", "type": "number"}]}]`,
+	Example: `  iwallet contract abigen token.iost --pkg token --type Token -o token/token.go
+  iwallet contract abigen --abi ./example.js.abi --code ./example.js --events ./events.json --pkg example -o example.go`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments, expected at most contractID")
+		}
+		if len(args) == 0 && abigenABIFile == "" {
+			return fmt.Errorf("either a contractID or --abi should be given")
+		}
+		if abigenCodeFile != "" && abigenABIFile == "" {
+			return fmt.Errorf("--code should be given together with --abi")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := ""
+		if len(args) == 1 {
+			id = args[0]
+		}
+		var c *rpcpb.Contract
+		var err error
+		if abigenABIFile != "" {
+			c, err = localContract(id, abigenABIFile, abigenCodeFile)
+		} else {
+			c, err = iwalletSDK.GetContract(id)
+		}
+		if err != nil {
+			return err
+		}
+		var events []*abigen.Event
+		if abigenEventsFile != "" {
+			data, err := ioutil.ReadFile(abigenEventsFile)
+			if err != nil {
+				return err
+			}
+			events, err = abigen.ParseEvents(data)
+			if err != nil {
+				return fmt.Errorf("%v: %v", abigenEventsFile, err)
+			}
+		}
+		typeName := abigenType
+		if typeName == "" {
+			typeName = abigen.Exported(abigenPackage)
+		}
+		binding, err := bindingOf(c, abigenPackage, typeName, events)
+		if err != nil {
+			return err
+		}
+		src, err := abigen.Generate(binding)
+		if err != nil {
+			return err
+		}
+		if outputFile == "" {
+			fmt.Print(string(src))
+			return nil
+		}
+		if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+			return err
+		}
+		if !isMachineOutput() {
+			fmt.Printf("Successfully generated %v with %v abis\n", outputFile, len(binding.Methods))
+		}
+		return nil
+	},
+}
+
+func init() {
+	contractCmd.AddCommand(contractAbigenCmd)
+	contractAbigenCmd.Flags().StringVarP(&abigenABIFile, "abi", "", "", "local abi file instead of the abi on chain")
+	contractAbigenCmd.Flags().StringVarP(&abigenCodeFile, "code", "", "", "local code of the abi file, to name the args")
+	contractAbigenCmd.Flags().StringVarP(&abigenEventsFile, "events", "", "", "json file describing the events of the contract")
+	contractAbigenCmd.Flags().StringVarP(&abigenPackage, "pkg", "", "contract", "name of the generated package")
+	contractAbigenCmd.Flags().StringVarP(&abigenType, "type", "", "", "name of the binding type (default the package name capitalized)")
+	contractAbigenCmd.Flags().StringVarP(&outputFile, "output", "o", "", "write the binding to this file instead of stdout")
+}
@@ -0,0 +1,356 @@
+// Package abigen generates typed Go bindings of contracts from their abis. A binding has a method per abi taking
+// typed args, which sends the tx through the sdk with the amount limit declared by the abi, so that applications do
+// not build the json args of actions by hand.
+package abigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Contract is the contract to generate a binding of.
+type Contract struct {
+	// Package is the name of the generated package, and Type the name of the binding in it.
+	Package string
+	Type    string
+	// ID is the contract id used by default, it can be empty for a contract not deployed yet.
+	ID      string
+	Methods []*Method
+	Events  []*Event
+}
+
+// Method is an abi of the contract.
+type Method struct {
+	Name        string
+	Params      []*Param
+	AmountLimit []*Amount
+}
+
+// Param is an arg of an abi or a field of an event. The type is one of the abi arg types string, bool, number and json.
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Amount is an amount limit declared by an abi.
+type Amount struct {
+	Token string
+	Value string
+}
+
+// Event is the json object posted by the contract as an event, which has no abi so it is described separately.
+type Event struct {
+	Name   string   `json:"name"`
+	Fields []*Param `json:"fields"`
+}
+
+// goTypes maps the abi arg types to go types, numbers being int64 as the vm parses them so.
+var goTypes = map[string]string{
+	"string": "string",
+	"bool":   "bool",
+	"number": "int64",
+	"json":   "interface{}",
+}
+
+// ParseEvents decodes the events of a contract, given as a json array like
+// [{"name": "transfer", "fields": [{"name": "from", "type": "string"}, {"name": "amount", "type": "number"}]}].
+func ParseEvents(data []byte) ([]*Event, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var events []*Event
+	if err := decoder.Decode(&events); err != nil {
+		return nil, fmt.Errorf("invalid events json: %v", err)
+	}
+	return events, nil
+}
+
+// Exported converts a name like set_config or balanceOf to an exported go identifier.
+func Exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range name {
+		if c == '_' || c == '$' || c == '-' || c == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		b.WriteRune(c)
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+// reservedParams are the names used by the generated methods themselves.
+var reservedParams = map[string]bool{"c": true, "ctx": true, "action": true, "tx": true, "data": true, "err": true}
+
+// paramNames makes the param names valid and distinct go identifiers. Params named by their index, as their names
+// are unknown, become arg0, arg1 and so on.
+func paramNames(params []*Param) []string {
+	names := make([]string, len(params))
+	seen := make(map[string]bool)
+	for i, p := range params {
+		name := strings.Map(func(c rune) rune {
+			if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+				return c
+			}
+			return '_'
+		}, p.Name)
+		if name == "" || unicode.IsDigit([]rune(name)[0]) {
+			name = "arg" + name
+		}
+		if name == "arg" {
+			name += strconv.Itoa(i)
+		}
+		for token.Lookup(name).IsKeyword() || reservedParams[name] || seen[name] {
+			name += "_"
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+type genParam struct {
+	Name string
+	Type string
+}
+
+type genMethod struct {
+	Name        string
+	GoName      string
+	Params      []*genParam
+	AmountLimit []*Amount
+}
+
+func (m *genMethod) Signature() string {
+	s := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		s = append(s, p.Name+" "+p.Type)
+	}
+	return strings.Join(s, ", ")
+}
+
+func (m *genMethod) Args() string {
+	s := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		s = append(s, p.Name)
+	}
+	return strings.Join(s, ", ")
+}
+
+type genField struct {
+	GoName string
+	Name   string
+	Type   string
+}
+
+type genEvent struct {
+	Name   string
+	GoName string
+	Fields []*genField
+}
+
+type genContract struct {
+	Package string
+	Type    string
+	ID      string
+	Methods []*genMethod
+	Events  []*genEvent
+}
+
+func checkType(t string, what string) (string, error) {
+	goType, ok := goTypes[t]
+	if !ok {
+		return "", fmt.Errorf("invalid type %q of %v, should be one of string, bool, number and json", t, what)
+	}
+	return goType, nil
+}
+
+func prepare(c *Contract) (*genContract, error) {
+	if !token.IsIdentifier(c.Package) {
+		return nil, fmt.Errorf("invalid package name %q", c.Package)
+	}
+	if !token.IsIdentifier(c.Type) || !token.IsExported(c.Type) {
+		return nil, fmt.Errorf("invalid type name %q, should be an exported go identifier", c.Type)
+	}
+	g := &genContract{Package: c.Package, Type: c.Type, ID: c.ID}
+	// identifiers declared by the binding, which the generated names must not collide with
+	methods := map[string]string{"ContractID": "", "SDK": "", "WatchEvents": ""}
+	declare := func(name string, of string) error {
+		if other, ok := methods[name]; ok {
+			if other == "" {
+				return fmt.Errorf("%v collides with the binding", of)
+			}
+			return fmt.Errorf("%v and %v are both named %v in go", of, other, name)
+		}
+		methods[name] = of
+		return nil
+	}
+	for _, m := range c.Methods {
+		gm := &genMethod{Name: m.Name, GoName: Exported(m.Name), AmountLimit: m.AmountLimit}
+		of := "abi " + m.Name
+		for _, name := range []string{gm.GoName, gm.GoName + "Action", gm.GoName + "ReadOnly", gm.GoName + "AmountLimit"} {
+			if err := declare(name, of); err != nil {
+				return nil, err
+			}
+		}
+		names := paramNames(m.Params)
+		for i, p := range m.Params {
+			t, err := checkType(p.Type, fmt.Sprintf("arg %v of abi %v", names[i], m.Name))
+			if err != nil {
+				return nil, err
+			}
+			gm.Params = append(gm.Params, &genParam{Name: names[i], Type: t})
+		}
+		g.Methods = append(g.Methods, gm)
+	}
+	types := map[string]string{c.Type: ""}
+	for _, e := range c.Events {
+		ge := &genEvent{Name: e.Name, GoName: Exported(e.Name) + "Event"}
+		if other, ok := types[ge.GoName]; ok {
+			if other == "" {
+				return nil, fmt.Errorf("event %v collides with the binding", e.Name)
+			}
+			return nil, fmt.Errorf("events %v and %v are both named %v in go", e.Name, other, ge.GoName)
+		}
+		types[ge.GoName] = e.Name
+		fields := make(map[string]bool)
+		for _, f := range e.Fields {
+			t, err := checkType(f.Type, fmt.Sprintf("field %v of event %v", f.Name, e.Name))
+			if err != nil {
+				return nil, err
+			}
+			gf := &genField{GoName: Exported(f.Name), Name: f.Name, Type: t}
+			if fields[gf.GoName] {
+				return nil, fmt.Errorf("fields of event %v are both named %v in go", e.Name, gf.GoName)
+			}
+			fields[gf.GoName] = true
+			ge.Fields = append(ge.Fields, gf)
+		}
+		g.Events = append(g.Events, ge)
+	}
+	return g, nil
+}
+
+// Generate returns the formatted go source of the binding.
+func Generate(c *Contract) ([]byte, error) {
+	g, err := prepare(c)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := bindingTemplate.Execute(&b, g); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %v", err)
+	}
+	return src, nil
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(`// Code generated by iwallet contract abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+{{if .ID}}
+// {{.Type}}ContractID is the id of the contract the binding was generated from.
+const {{.Type}}ContractID = {{printf "%q" .ID}}
+{{end}}
+// {{.Type}} calls the abis of the contract through the sdk.
+type {{.Type}} struct {
+	ContractID string
+	SDK        *sdk.IOSTDevSDK
+}
+
+// New{{.Type}} returns a binding of the contract deployed as contractID.
+func New{{.Type}}(s *sdk.IOSTDevSDK, contractID string) *{{.Type}} {
+	return &{{.Type}}{ContractID: contractID, SDK: s}
+}
+
+// WatchEvents sends the events of the contract until ctx is done, see sdk.SubscribeContractEvents.
+func (c *{{$.Type}}) WatchEvents(ctx context.Context) <-chan *rpcpb.Event {
+	return c.SDK.SubscribeContractEvents(ctx, &rpcpb.SubscribeRequest_Filter{ContractId: c.ContractID}, rpcpb.Event_CONTRACT_EVENT)
+}
+{{range .Methods}}
+// {{.GoName}}Action returns the action calling {{.Name}}.
+func (c *{{$.Type}}) {{.GoName}}Action({{.Signature}}) (*rpcpb.Action, error) {
+	data, err := json.Marshal([]interface{}{ {{- .Args -}} })
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewAction(c.ContractID, {{printf "%q" .Name}}, string(data)), nil
+}
+
+{{- if .AmountLimit}}
+// {{.GoName}}AmountLimit returns the amount limit declared by {{.Name}}, which is the amount limit of the txs sent by {{.GoName}}.
+{{- else}}
+// {{.GoName}}AmountLimit returns the amount limit declared by {{.Name}}, which is none so the txs sent by {{.GoName}} have the amount limit of the sdk.
+{{- end}}
+func (c *{{$.Type}}) {{.GoName}}AmountLimit() []*rpcpb.AmountLimit {
+	return []*rpcpb.AmountLimit{ {{- range .AmountLimit}}
+		{Token: {{printf "%q" .Token}}, Value: {{printf "%q" .Value}}},{{end}}
+	}
+}
+
+// {{.GoName}} sends a tx calling {{.Name}} and returns its hash, after waiting for its receipt if the sdk checks results.
+func (c *{{$.Type}}) {{.GoName}}(ctx context.Context{{if .Params}}, {{.Signature}}{{end}}) (string, error) {
+	action, err := c.{{.GoName}}Action({{.Args}})
+	if err != nil {
+		return "", err
+	}
+	tx, err := c.SDK.CreateTxFromActions([]*rpcpb.Action{action})
+	if err != nil {
+		return "", err
+	}
+	{{- if .AmountLimit}}
+	tx.AmountLimit = c.{{.GoName}}AmountLimit()
+	{{- end}}
+	return c.SDK.SendTxCtx(ctx, tx)
+}
+
+// {{.GoName}}ReadOnly executes {{.Name}} on the node without sending a tx, and returns the receipt holding its result.
+func (c *{{$.Type}}) {{.GoName}}ReadOnly(ctx context.Context{{if .Params}}, {{.Signature}}{{end}}) (*rpcpb.TxReceipt, error) {
+	action, err := c.{{.GoName}}Action({{.Args}})
+	if err != nil {
+		return nil, err
+	}
+	return c.SDK.CallReadOnlyCtx(ctx, c.ContractID, action.ActionName, action.Data)
+}
+{{end}}{{range .Events}}
+// {{.GoName}} is the data of the {{.Name}} event.
+type {{.GoName}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{.Type}} ` + "`" + `json:{{printf "%q" .Name}}` + "`" + `
+{{- end}}
+}
+
+// Parse{{.GoName}} decodes the data of a contract event as a {{.Name}} event.
+func Parse{{.GoName}}(e *rpcpb.Event) (*{{.GoName}}, error) {
+	ev := &{{.GoName}}{}
+	if err := json.Unmarshal([]byte(e.Data), ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+{{end}}`))
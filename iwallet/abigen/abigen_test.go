@@ -0,0 +1,65 @@
+package abigen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExported(t *testing.T) {
+	assert.Equal(t, "Transfer", Exported("transfer"))
+	assert.Equal(t, "SetConfig", Exported("set_config"))
+	assert.Equal(t, "BalanceOf", Exported("balanceOf"))
+	assert.Equal(t, "X2fa", Exported("2fa"))
+}
+
+func TestParamNames(t *testing.T) {
+	names := paramNames([]*Param{{Name: "type"}, {Name: "0"}, {Name: "ctx"}, {Name: "to"}, {Name: "to"}, {Name: ""}})
+	assert.Equal(t, []string{"type_", "arg0", "ctx_", "to", "to_", "arg5"}, names)
+}
+
+func TestGenerate(t *testing.T) {
+	c := &Contract{
+		Package: "example",
+		Type:    "Example",
+		ID:      "ContractABC",
+		Methods: []*Method{
+			{Name: "transfer", Params: []*Param{{"to", "string"}, {"amount", "string"}}, AmountLimit: []*Amount{{"iost", "100"}}},
+			{Name: "set_config", Params: []*Param{{"n", "number"}, {"ok", "bool"}, {"data", "json"}}},
+		},
+		Events: []*Event{{Name: "transfer", Fields: []*Param{{"from", "string"}, {"amount", "number"}}}},
+	}
+	src, err := Generate(c)
+	assert.Nil(t, err)
+	code := string(src)
+	assert.Contains(t, code, "package example")
+	assert.Contains(t, code, `const ExampleContractID = "ContractABC"`)
+	assert.Contains(t, code, "func (c *Example) Transfer(ctx context.Context, to string, amount string) (string, error)")
+	assert.Contains(t, code, "tx.AmountLimit = c.TransferAmountLimit()")
+	assert.Contains(t, code, `{Token: "iost", Value: "100"}`)
+	assert.Contains(t, code, "func (c *Example) SetConfigAction(n int64, ok bool, data_ interface{}) (*rpcpb.Action, error)")
+	assert.Contains(t, code, `sdk.NewAction(c.ContractID, "set_config", string(data))`)
+	assert.Regexp(t, "Amount +int64 +`json:\"amount\"`", code)
+	assert.Contains(t, code, "func ParseTransferEvent(e *rpcpb.Event) (*TransferEvent, error)")
+
+	c.Methods = append(c.Methods, &Method{Name: "setConfig"})
+	_, err = Generate(c)
+	assert.Contains(t, err.Error(), "abi setConfig and abi set_config are both named SetConfig")
+	c.Methods = []*Method{{Name: "watchEvents"}}
+	_, err = Generate(c)
+	assert.Contains(t, err.Error(), "collides with the binding")
+	c.Methods = []*Method{{Name: "get", Params: []*Param{{"key", "bytes"}}}}
+	_, err = Generate(c)
+	assert.Contains(t, err.Error(), `invalid type "bytes"`)
+	c.Methods, c.Type = nil, "example"
+	_, err = Generate(c)
+	assert.NotNil(t, err)
+}
+
+func TestParseEvents(t *testing.T) {
+	events, err := ParseEvents([]byte(`[{"name": "transfer", "fields": [{"name": "from", "type": "string"}]}]`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Event{{Name: "transfer", Fields: []*Param{{"from", "string"}}}}, events)
+	_, err = ParseEvents([]byte(`[{"name": "transfer", "args": []}]`))
+	assert.NotNil(t, err)
+}
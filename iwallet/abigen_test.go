@@ -0,0 +1,27 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/iwallet/abigen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindingOf(t *testing.T) {
+	c, err := localContract("exchange.iost", "../config/genesis/contract/exchange.js.abi", "../config/genesis/contract/exchange.js")
+	assert.Nil(t, err)
+	b, err := bindingOf(c, "exchange", "Exchange", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "exchange.iost", b.ID)
+	assert.Equal(t, "transfer", b.Methods[1].Name)
+	assert.Equal(t, []*abigen.Param{{Name: "tokenSym", Type: "string"}, {Name: "to", Type: "string"}, {Name: "amount", Type: "string"}, {Name: "memo", Type: "string"}}, b.Methods[1].Params)
+	assert.Equal(t, []*abigen.Amount{{Token: "*", Value: "unlimited"}}, b.Methods[1].AmountLimit)
+	_, err = abigen.Generate(b)
+	assert.Nil(t, err)
+
+	c, err = localContract("", "../config/genesis/contract/exchange.js.abi", "")
+	assert.Nil(t, err)
+	b, err = bindingOf(c, "exchange", "Exchange", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", b.Methods[1].Params[0].Name)
+}
@@ -15,7 +15,6 @@
 package iwallet
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -23,8 +22,10 @@ import (
 
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/iost-official/go-iost/sdk"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -33,11 +34,34 @@ var (
 	initialRAM       int64
 	initialBalance   int64
 	initialGasPledge int64
+	mnemonicWords    int
+	mnemonicPass     string
+	recoverMnemonic  bool
+	importFormat     string
+	exportFormat     string
+	exportPerm       string
+	exportOutput     string
+	exportPaper      string
+	importQRImages   []string
+	importStdin      bool
+	onChain          bool
+	keyStore         string
 )
 
 type acc struct {
 	Name    string
 	KeyPair *key
+	OnChain *accountChainInfo `json:",omitempty"`
+}
+
+// accountChainInfo is the on chain state of a local account.
+type accountChainInfo struct {
+	Balance        float64                `json:"balance"`
+	FrozenBalances []*rpcpb.FrozenBalance `json:"frozen_balances,omitempty"`
+	Gas            float64                `json:"gas"`
+	RAM            int64                  `json:"ram"`
+	KeyStatus      map[string]string      `json:"key_status,omitempty"`
+	Error          string                 `json:"error,omitempty"`
 }
 
 type accounts struct {
@@ -45,6 +69,49 @@ type accounts struct {
 	Account []*acc
 }
 
+// minInitialGasPledge is the least IOST pledged for the gas of a new account, which signing up needs.
+const minInitialGasPledge = 10
+
+// checkProvision checks the resources given to a new account.
+func checkProvision(ram int64, gasPledge int64, balance int64) error {
+	if ram < 0 {
+		return fmt.Errorf("invalid initial ram %v, should not be negative", ram)
+	}
+	if gasPledge < minInitialGasPledge {
+		return fmt.Errorf("invalid initial gas pledge %v, should be at least %v IOST", gasPledge, minInitialGasPledge)
+	}
+	if balance < 0 {
+		return fmt.Errorf("invalid initial balance %v, should not be negative", balance)
+	}
+	return nil
+}
+
+// provisionSummary tells what the tx creating the account does besides signing it up.
+func provisionSummary(name string, ram int64, gasPledge int64, balance int64) string {
+	var items []string
+	if ram > 0 {
+		items = append(items, fmt.Sprintf("buys %v bytes of ram", ram))
+	}
+	items = append(items, fmt.Sprintf("pledges %v IOST for gas", gasPledge))
+	if balance > 0 {
+		items = append(items, fmt.Sprintf("transfers %v IOST", balance))
+	}
+	last := len(items) - 1
+	if last > 0 {
+		items = append(items[:last-1], items[last-1]+" and "+items[last])
+	}
+	return fmt.Sprintf("Creating account %v in one transaction, which also %v for it", name, strings.Join(items, ", "))
+}
+
+// dashedCreateFlags lets the resource flags of account create be given with dashes too, eg --initial-balance.
+func dashedCreateFlags(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	switch name {
+	case "initial-ram", "initial-gas-pledge", "initial-balance":
+		name = strings.Replace(name, "-", "_", -1)
+	}
+	return pflag.NormalizedName(name)
+}
+
 // accountCmd represents the account command.
 var accountCmd = &cobra.Command{
 	Use:     "account",
@@ -56,32 +123,56 @@ var accountCmd = &cobra.Command{
 var createCmd = &cobra.Command{
 	Use:   "create accountName",
 	Short: "Create an account on blockchain",
-	Long:  `Create an account on blockchain`,
+	Long: `Create an account on blockchain
+	The account is signed up, given ram, pledged gas and funded by the creator in one transaction, so that either
+	all of them succeed or none does. The creator pays --initial_ram bytes of ram, --initial_gas_pledge IOST pledged
+	for the gas, at least 10, and sends --initial_balance IOST.`,
 	Example: `  iwallet account create test1 --account test0
   iwallet account create test2 --account test0 --initial_balance 0 --initial_gas_pledge 10 --initial_ram 0
-  iwallet account create test3 --account test0 --owner 7Z9US64vfcyopQpyEwV1FF52HTB8maEacjU4SYeAUrt1 --active 7Z9US64vfcyopQpyEwV1FF52HTB8maEacjU4SYeAUrt1`,
+  iwallet account create test3 --account test0 --owner 7Z9US64vfcyopQpyEwV1FF52HTB8maEacjU4SYeAUrt1 --active 7Z9US64vfcyopQpyEwV1FF52HTB8maEacjU4SYeAUrt1
+  iwallet account create test4 --account test0 --store keychain`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
 			return err
 		}
+		if err := checkProvision(initialRAM, initialGasPledge, initialBalance); err != nil {
+			return err
+		}
 		return checkAccount(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var (
 			autoKey    bool
 			okey, akey string
+			mnemonic   string
 			newKp      *account.KeyPair
 			err        error
 		)
 
 		newName := args[0]
+		if err := checkKeyStore(keyStore); err != nil {
+			return err
+		}
+		if keyStore == storeKeychain {
+			if err := probeKeychain(); err != nil {
+				return fmt.Errorf("keychain is not usable: %v", err)
+			}
+		}
 		if strings.ContainsAny(newName, `?*:|/\"`) || len(newName) < 5 || len(newName) > 11 {
 			return fmt.Errorf("invalid account name")
 		}
 
 		if ownerKey == "" && activeKey == "" {
 			autoKey = true
-			newKp, err = account.NewKeyPair(nil, sdk.GetSignAlgoByName(signAlgo))
+			if mnemonicWords > 0 {
+				mnemonic, err = account.NewMnemonic(mnemonicWords)
+				if err != nil {
+					return err
+				}
+				newKp, err = keyPairFromMnemonic(mnemonic)
+			} else {
+				newKp, err = account.NewKeyPair(nil, sdk.GetSignAlgoByName(signAlgo))
+			}
 			if err != nil {
 				return fmt.Errorf("failed to create key pair: %v", err)
 			}
@@ -97,9 +188,19 @@ var createCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to load account: %v", err)
 		}
+		if dryRun {
+			trx, err := iwalletSDK.CreateNewAccountTx(newName, okey, akey, initialGasPledge, initialRAM, initialBalance)
+			if err != nil {
+				return fmt.Errorf("create new account error: %v", err)
+			}
+			return dryRunTx(trx)
+		}
 		if err := iwalletSDK.Connect(); err != nil {
 			return err
 		}
+		if verbose && !isMachineOutput() {
+			fmt.Println(provisionSummary(newName, initialRAM, initialGasPledge, initialBalance))
+		}
 		_, err = iwalletSDK.CreateNewAccount(newName, okey, akey, initialGasPledge, initialRAM, initialBalance)
 		if err != nil {
 			return fmt.Errorf("create new account error: %v", err)
@@ -122,10 +223,20 @@ var createCmd = &cobra.Command{
 			kp := &KeyPairInfo{RawKey: common.Base58Encode(newKp.Seckey), PubKey: common.Base58Encode(newKp.Pubkey), KeyType: signAlgo}
 			accInfo.Keypairs["active"] = kp
 			accInfo.Keypairs["owner"] = kp
+			if mnemonic != "" {
+				accInfo.Derivation = newDerivationInfo(mnemonic)
+			}
+			if keyStore == storeKeychain {
+				accInfo.Store = storeKeychain
+			}
 			err = accInfo.save(encrypt)
 			if err != nil {
 				return fmt.Errorf("failed to save account: %v", err)
 			}
+			if mnemonic != "" {
+				fmt.Println("Mnemonic:", mnemonic)
+				fmt.Println("Write down the mnemonic and keep it safe, it is the only way to recover this account.")
+			}
 		}
 		return nil
 	},
@@ -134,9 +245,12 @@ var createCmd = &cobra.Command{
 var viewCmd = &cobra.Command{
 	Use:   "view [accountName]",
 	Short: "View account by name or omit to show all accounts",
-	Long:  `View account by name or omit to show all accounts`,
+	Long: `View account by name or omit to show all accounts
+	With --on_chain, the balances of the accounts are fetched from the node, and the local keys are checked against
+	the permissions on chain, so that keys which have been rotated away are found`,
 	Example: `  iwallet account view test0
-  iwallet account view`,
+  iwallet account view
+  iwallet account view --on_chain`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := getAccountDir()
 		if err != nil {
@@ -148,12 +262,25 @@ var viewCmd = &cobra.Command{
 			var k key
 			k.Algorithm = ac.Keypairs["active"].KeyType
 			k.Pubkey = ac.Keypairs["active"].PubKey
-			if ac.isEncrypted() {
+			if ac.WatchOnly {
+				k.Seckey = "---watch-only---"
+			} else if ac.Store == storeKeychain {
+				k.Seckey = "---in os keychain---"
+			} else if ac.isEncrypted() {
 				k.Seckey = "---encrypted secret key---"
 			} else {
 				k.Seckey = ac.Keypairs["active"].RawKey
 			}
-			a.Account = append(a.Account, &acc{ac.Name, &k})
+			a.Account = append(a.Account, &acc{Name: ac.Name, KeyPair: &k})
+			if onChain {
+				a.Account[len(a.Account)-1].OnChain = getAccountChainInfo(ac)
+			}
+		}
+		if onChain {
+			if err := iwalletSDK.Connect(); err != nil {
+				return err
+			}
+			defer iwalletSDK.CloseConn()
 		}
 		if len(args) < 1 {
 			files, err := ioutil.ReadDir(dir)
@@ -175,12 +302,7 @@ var viewCmd = &cobra.Command{
 			}
 			addAcc(ac)
 		}
-		info, err := json.MarshalIndent(a, "", "    ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(info))
-		return nil
+		return printResult(a)
 	},
 }
 
@@ -190,18 +312,93 @@ var importCmd = &cobra.Command{
 	Short: "Import an account by name and private key",
 	Long:  `Import an account by name and private key`,
 	Example: `  iwallet account import test0 XXXXXXXXXXXXXXXXXXXXX
-  iwallet account import test0 active:XXXXXXXXXXXXXXXXXXXXX,owner:YYYYYYYYYYYYYYYYYYYYYYYY`,
+  iwallet account import test0 active:XXXXXXXXXXXXXXXXXXXXX,owner:YYYYYYYYYYYYYYYYYYYYYYYY
+  iwallet account import test0 "word1 word2 ... word12" --recover
+  iwallet account import test1 "word1 word2 ... word12" --recover --hd_path "m/44'/291'/1'/0'/0'"
+  iwallet account import test0 test0.keystore.json --format keystore-v3
+  iwallet account import test0 XXXXXXXXXXXXXXXXXXXXX --store keychain
+  iwallet account import test0 --qr_image private_key.jpg
+  iwallet account import test0 --stdin`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if importStdin && len(importQRImages) != 0 {
+			return fmt.Errorf("only one of --stdin and --qr_image can be given")
+		}
+		if importStdin && importFormat != "" {
+			return fmt.Errorf("--stdin reads a private key or a mnemonic, not a keystore file")
+		}
+		if importStdin && passwordStdin {
+			return fmt.Errorf("the private key and the password can not both be read from stdin")
+		}
+		if importStdin || len(importQRImages) != 0 {
+			if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
+				return err
+			}
+			if len(args) > 1 {
+				cmd.Usage()
+				return fmt.Errorf("the private key should not be given with --stdin or --qr_image")
+			}
+			return checkKeyStore(keyStore)
+		}
 		if err := checkArgsNumber(cmd, args, "accountName", "accountPrivateKey"); err != nil {
 			return err
 		}
-		return nil
+		return checkKeyStore(keyStore)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		acc := AccountInfo{Name: name, Keypairs: make(map[string]*KeyPairInfo, 0)}
-		keys := strings.Split(args[1], ",")
-		if len(keys) == 1 {
+		var keyArg string
+		if importStdin {
+			secret, err := readSecretFromStdin(os.Stdin)
+			if err != nil {
+				return err
+			}
+			keyArg = secret
+			// the key never touches the disk in plain text
+			encrypt = true
+		} else if len(importQRImages) != 0 {
+			var texts []string
+			for _, f := range importQRImages {
+				text, err := readQRImage(f)
+				if err != nil {
+					return err
+				}
+				texts = append(texts, text)
+			}
+			keyArg = strings.Join(texts, ",")
+		} else {
+			keyArg = args[1]
+		}
+		keys := strings.Split(keyArg, ",")
+		if recoverMnemonic {
+			mnemonic := strings.Join(strings.Fields(keyArg), " ")
+			newKp, err := keyPairFromMnemonic(mnemonic)
+			if err != nil {
+				return fmt.Errorf("failed to recover from mnemonic: %v", err)
+			}
+			kp := &KeyPairInfo{RawKey: common.Base58Encode(newKp.Seckey), PubKey: common.Base58Encode(newKp.Pubkey), KeyType: "ed25519"}
+			acc.Keypairs["active"] = kp
+			acc.Keypairs["owner"] = kp
+			acc.Derivation = newDerivationInfo(mnemonic)
+		} else if importFormat == keystoreFormatV3 {
+			data, err := ioutil.ReadFile(keyArg)
+			if err != nil {
+				return fmt.Errorf("failed to read keystore file: %v", err)
+			}
+			fmt.Println("decrypting keystore file, need password")
+			password, _, err := readPassword(false, false)
+			if err != nil {
+				return err
+			}
+			kp, err := unmarshalKeystoreV3(data, password)
+			if err != nil {
+				return err
+			}
+			acc.Keypairs["active"] = kp
+			acc.Keypairs["owner"] = kp
+		} else if importFormat != "" {
+			return fmt.Errorf("unsupported keystore format %v", importFormat)
+		} else if len(keys) == 1 {
 			key := keys[0]
 			if len(strings.Split(key, ":")) != 1 {
 				return fmt.Errorf("importing one key need not specifying permission")
@@ -225,6 +422,9 @@ var importCmd = &cobra.Command{
 				acc.Keypairs[splits[0]] = kp
 			}
 		}
+		if keyStore == storeKeychain {
+			acc.Store = storeKeychain
+		}
 		err := acc.save(encrypt)
 		if err != nil {
 			return fmt.Errorf("failed to save account: %v", err)
@@ -257,6 +457,90 @@ var dumpKeyCmd = &cobra.Command{
 	},
 }
 
+var addWatchCmd = &cobra.Command{
+	Use:   "add-watch accountName publicKey",
+	Short: "Add a watch-only account by name and public key",
+	Long:  `Add a watch-only account by name and public key. It can be used to query and to build unsigned transactions, but not to sign`,
+	Example: `  iwallet account add-watch test0 7Z9US64vfcyopQpyEwV1FF52HTB8maEacjU4SYeAUrt1
+  iwallet account add-watch test1 pubkey_in_base58 --sign_algo secp256k1`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "accountName", "publicKey"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := checkPubKeyOfAlgo(args[1], signAlgo); err != nil {
+			return err
+		}
+		acc := NewAccountInfo()
+		acc.Name = name
+		acc.WatchOnly = true
+		kp := &KeyPairInfo{PubKey: args[1], KeyType: signAlgo}
+		acc.Keypairs["active"] = kp
+		acc.Keypairs["owner"] = kp
+		err := acc.save(false)
+		if err != nil {
+			return fmt.Errorf("failed to save account: %v", err)
+		}
+		fmt.Printf("add watch-only account %v done\n", name)
+		return nil
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export accountName",
+	Short: "Export a key of the account to an encrypted keystore file or a paper wallet",
+	Long: `Export a key of the account to an encrypted keystore file which can be imported by other tools.
+	With --paper, write a printable pdf instead, with the account name and the public and private keys of every permission
+	as qr codes and text, which can be imported again by "account import --qr_image". It asks the account name to be typed to confirm`,
+	Example: `  iwallet account export test0 --format keystore-v3
+  iwallet account export test0 --permission owner --output test0_owner.json
+  iwallet account export test0 --paper test0.pdf`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportPaper != "" {
+			return exportPaperWallet(args[0], exportPaper)
+		}
+		if exportFormat != keystoreFormatV3 {
+			return fmt.Errorf("unsupported keystore format %v", exportFormat)
+		}
+		acc, err := loadAccountByName(args[0], true)
+		if err != nil {
+			return err
+		}
+		kp, ok := acc.Keypairs[exportPerm]
+		if !ok {
+			return fmt.Errorf("invalid permission %v", exportPerm)
+		}
+		fmt.Println("encrypting keystore file, need password")
+		password, _, err := readPassword(true, acc.RequireInteractive)
+		if err != nil {
+			return err
+		}
+		data, err := marshalKeystoreV3(kp, password)
+		if err != nil {
+			return err
+		}
+		output := exportOutput
+		if output == "" {
+			output = fmt.Sprintf("%v_%v.keystore.json", acc.Name, exportPerm)
+		}
+		err = ioutil.WriteFile(output, data, 0400)
+		if err != nil {
+			return fmt.Errorf("failed to write keystore file: %v", err)
+		}
+		fmt.Println("Keystore file is saved at:", output)
+		return nil
+	},
+}
+
 var deleteCmd = &cobra.Command{
 	Use:     "delete accountName",
 	Aliases: []string{"del"},
@@ -275,6 +559,12 @@ var deleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to get account dir: %v", err)
 		}
+		if ac, err := loadAccountByName(name, false); err == nil && ac.Store == storeKeychain {
+			if err := ac.deleteFromKeychain(); err != nil {
+				return fmt.Errorf("failed to delete keys from keychain: %v", err)
+			}
+			fmt.Println("Keys of", name, "have been removed from keychain.")
+		}
 		found := false
 		sufs := []string{".json"}
 		for _, algo := range ValidSignAlgos {
@@ -314,11 +604,104 @@ func init() {
 	createCmd.Flags().Int64VarP(&initialRAM, "initial_ram", "", 1024, "buy $initial_ram bytes ram for the new account")
 	createCmd.Flags().Int64VarP(&initialGasPledge, "initial_gas_pledge", "", 10, "pledge $initial_gas_pledge IOSTs for the new account")
 	createCmd.Flags().Int64VarP(&initialBalance, "initial_balance", "", 0, "transfer $initial_balance IOSTs to the new account")
+	createCmd.Flags().SetNormalizeFunc(dashedCreateFlags)
+
+	createCmd.Flags().StringVarP(&keyStore, "store", "", storeFile, "where to keep the secret keys, \"file\" for the account file or \"keychain\" for the os keychain")
+	createCmd.Flags().IntVarP(&mnemonicWords, "mnemonic", "", 0, "generate the key from a new bip39 mnemonic with the given number of words (12 or 24)")
+	accountCmd.PersistentFlags().StringVarP(&mnemonicPass, "mnemonic_passphrase", "", "", "optional bip39 passphrase used with the mnemonic")
 
 	accountCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVarP(&recoverMnemonic, "recover", "", false, "recover the account from a bip39 mnemonic instead of a private key")
+	importCmd.Flags().StringVarP(&keyStore, "store", "", storeFile, "where to keep the secret keys, \"file\" for the account file or \"keychain\" for the os keychain")
+	importCmd.Flags().BoolVarP(&importStdin, "stdin", "", false, "read the private key or mnemonic from stdin without echoing it, keeping it out of the process list and shell history, and save it encrypted")
+	importCmd.Flags().StringSliceVarP(&importQRImages, "qr_image", "", []string{}, "read the private key from the qr code of a paper wallet in these png or jpeg images instead, split by comma")
+	importCmd.Flags().StringVarP(&importFormat, "format", "", "", "import from a keystore file of the given format instead of a private key, only \"keystore-v3\" is supported now")
+
+	accountCmd.AddCommand(addWatchCmd)
+	accountCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "", keystoreFormatV3, "keystore format, only \"keystore-v3\" is supported now")
+	exportCmd.Flags().StringVarP(&exportPerm, "permission", "", "active", "permission of the key to export")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default accountName_permission.keystore.json)")
+	exportCmd.Flags().StringVarP(&exportPaper, "paper", "", "", "write a printable paper wallet pdf with all the keys to this file instead")
+	exportCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "export the paper wallet without typing the account name")
 	accountCmd.PersistentFlags().BoolVarP(&encrypt, "encrypt", "", false, "whether to encrypt local key file")
 
 	accountCmd.AddCommand(viewCmd)
+	viewCmd.Flags().BoolVarP(&onChain, "on_chain", "", false, "also show balances from the node and whether the local keys still match the permissions on chain")
 	accountCmd.AddCommand(deleteCmd)
 	accountCmd.AddCommand(dumpKeyCmd)
 }
+
+func keyPairFromMnemonic(mnemonic string) (*account.KeyPair, error) {
+	if signAlgo != "ed25519" {
+		return nil, fmt.Errorf("mnemonic only supports ed25519 keys")
+	}
+	return account.DeriveKeyPairFromMnemonic(mnemonic, mnemonicPass, hdPath)
+}
+
+func newDerivationInfo(mnemonic string) *DerivationInfo {
+	return &DerivationInfo{Scheme: "bip39", Path: hdPath, WordCount: len(strings.Fields(mnemonic))}
+}
+
+func checkPubKeyOfAlgo(pubkey string, algo string) error {
+	length := 32
+	if algo == "secp256k1" {
+		length = 33
+	}
+	if len(common.Base58Decode(pubkey)) != length {
+		return fmt.Errorf("invalid %v public key %v", algo, pubkey)
+	}
+	return nil
+}
+
+func getAccountChainInfo(ac *AccountInfo) *accountChainInfo {
+	info := &accountChainInfo{}
+	a, err := iwalletSDK.GetAccountInfo(ac.Name)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.Balance = a.Balance
+	info.FrozenBalances = a.FrozenBalances
+	if a.GasInfo != nil {
+		info.Gas = a.GasInfo.CurrentTotal
+	}
+	if a.RamInfo != nil {
+		info.RAM = a.RamInfo.Available
+	}
+	info.KeyStatus = make(map[string]string)
+	for perm, kp := range ac.Keypairs {
+		info.KeyStatus[perm] = keyStatus(a, perm, kp.PubKey)
+	}
+	return info
+}
+
+// keyStatus tells whether the public key alone can still sign for the permission of the account on chain.
+// Keys take effect either directly or through the groups of the permission.
+func keyStatus(a *rpcpb.Account, perm string, pubkey string) string {
+	p, ok := a.Permissions[perm]
+	if !ok {
+		return "permission not found on chain"
+	}
+	var weight int64
+	addWeight := func(items []*rpcpb.Account_Item) {
+		for _, item := range items {
+			if item.IsKeyPair && item.Id == pubkey {
+				weight += item.Weight
+			}
+		}
+	}
+	addWeight(p.Items)
+	for _, name := range p.GroupNames {
+		if g, ok := a.Groups[name]; ok {
+			addWeight(g.Items)
+		}
+	}
+	if weight == 0 {
+		return "MISMATCH: key not found on chain, it may have been rotated"
+	}
+	if weight < p.Threshold {
+		return fmt.Sprintf("weight %v below threshold %v", weight, p.Threshold)
+	}
+	return "ok"
+}
@@ -0,0 +1,45 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyStatus(t *testing.T) {
+	a := &rpcpb.Account{
+		Permissions: map[string]*rpcpb.Account_Permission{
+			"active": {Name: "active", Items: []*rpcpb.Account_Item{{Id: "keyA", IsKeyPair: true, Weight: 1}}, Threshold: 1},
+			"owner":  {Name: "owner", GroupNames: []string{"admins"}, Threshold: 2},
+		},
+		Groups: map[string]*rpcpb.Account_Group{
+			"admins": {Name: "admins", Items: []*rpcpb.Account_Item{{Id: "keyO", IsKeyPair: true, Weight: 1}}},
+		},
+	}
+	assert.Equal(t, "ok", keyStatus(a, "active", "keyA"))
+	assert.Contains(t, keyStatus(a, "active", "keyB"), "MISMATCH")
+	assert.Equal(t, "weight 1 below threshold 2", keyStatus(a, "owner", "keyO"))
+	assert.Equal(t, "permission not found on chain", keyStatus(a, "operate", "keyA"))
+}
+
+func TestCheckProvision(t *testing.T) {
+	assert.Nil(t, checkProvision(0, 10, 0))
+	assert.Nil(t, checkProvision(1024, 20, 5))
+	assert.NotNil(t, checkProvision(-1, 10, 0))
+	assert.NotNil(t, checkProvision(0, 9, 0))
+	assert.NotNil(t, checkProvision(0, 10, -1))
+
+	assert.Equal(t, "Creating account alice in one transaction, which also pledges 10 IOST for gas for it", provisionSummary("alice", 0, 10, 0))
+	assert.Equal(t, "Creating account alice in one transaction, which also buys 1024 bytes of ram, pledges 20 IOST for gas and transfers 5 IOST for it", provisionSummary("alice", 1024, 20, 5))
+}
+
+func TestDashedCreateFlags(t *testing.T) {
+	var balance int64
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Int64Var(&balance, "initial_balance", 0, "")
+	flags.SetNormalizeFunc(dashedCreateFlags)
+	assert.Nil(t, flags.Parse([]string{"--initial-balance", "5"}))
+	assert.Equal(t, int64(5), balance)
+}
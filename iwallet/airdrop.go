@@ -0,0 +1,384 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+var (
+	airdropFile  string
+	airdropTPS   float64
+	airdropState string
+	airdropToken string
+)
+
+// statuses of the transfers of an airdrop
+const (
+	airdropPending = "pending"
+	airdropSent    = "sent"
+	airdropSuccess = "success"
+	airdropFailed  = "failed"
+)
+
+// airdropEntry is the progress of one transfer, it is sent if the tx hash is known but the result is not yet.
+type airdropEntry struct {
+	Row        int    `json:"row"`
+	Receiver   string `json:"receiver"`
+	Amount     string `json:"amount"`
+	Memo       string `json:"memo"`
+	Status     string `json:"status"`
+	TxHash     string `json:"tx_hash,omitempty"`
+	Expiration int64  `json:"expiration,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// airdropProgress is saved to the state file before every transfer is sent, so that a resumed airdrop never sends a
+// transfer again unless its tx has expired without being packed.
+type airdropProgress struct {
+	File    string          `json:"file"`
+	Token   string          `json:"token"`
+	From    string          `json:"from"`
+	Entries []*airdropEntry `json:"entries"`
+}
+
+type airdropReport struct {
+	Token       string          `json:"token"`
+	From        string          `json:"from"`
+	Total       int             `json:"total"`
+	Succeeded   int             `json:"succeeded"`
+	Failed      int             `json:"failed"`
+	Unconfirmed int             `json:"unconfirmed"`
+	Unsent      int             `json:"unsent"`
+	Failures    []*airdropEntry `json:"failures"`
+	StateFile   string          `json:"state_file"`
+}
+
+func newAirdropProgress(file, token, from string, rows []*transferRow) *airdropProgress {
+	p := &airdropProgress{File: file, Token: token, From: from}
+	for _, r := range rows {
+		p.Entries = append(p.Entries, &airdropEntry{Row: r.Row, Receiver: r.Receiver, Amount: r.Amount, Memo: r.Memo, Status: airdropPending})
+	}
+	return p
+}
+
+func loadAirdropProgress(file string) (*airdropProgress, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &airdropProgress{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("invalid state file %v: %v", file, err)
+	}
+	return p, nil
+}
+
+// save writes the state through a temporary file, so that an interrupted write never loses the progress.
+func (p *airdropProgress) save(file string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save state file: %v", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("failed to save state file: %v", err)
+	}
+	return nil
+}
+
+// checkRows makes sure the state belongs to the same airdrop, so that resuming with another file can not pay twice.
+func (p *airdropProgress) checkRows(token, from string, rows []*transferRow) error {
+	if p.Token != token || p.From != from {
+		return fmt.Errorf("the state is of an airdrop of %v from %v, not %v from %v", p.Token, p.From, token, from)
+	}
+	if len(p.Entries) != len(rows) {
+		return fmt.Errorf("the state has %v transfers but the file has %v", len(p.Entries), len(rows))
+	}
+	for i, r := range rows {
+		e := p.Entries[i]
+		if e.Receiver != r.Receiver || e.Amount != r.Amount || e.Memo != r.Memo {
+			return fmt.Errorf("row %v of the file differs from the state: %v %v, was %v %v", r.Row, r.Receiver, r.Amount, e.Receiver, e.Amount)
+		}
+	}
+	return nil
+}
+
+func (p *airdropProgress) count(status string) int {
+	n := 0
+	for _, e := range p.Entries {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// settle updates a sent transfer by what the node knows of its tx at the time now. A tx the node does not know is
+// sent again only after it has expired, when it can not be packed anymore.
+func settle(e *airdropEntry, res *rpcpb.TransactionResponse, err error, now int64) {
+	if err != nil {
+		if sdk.CodeOf(err) == sdk.ErrTxNotFound && now > e.Expiration {
+			e.Status, e.TxHash, e.Expiration, e.Error = airdropPending, "", 0, ""
+		}
+		return
+	}
+	if res.Status != rpcpb.TransactionResponse_IRREVERSIBLE || res.Transaction == nil || res.Transaction.TxReceipt == nil {
+		return
+	}
+	if r := res.Transaction.TxReceipt; r.StatusCode == rpcpb.TxReceipt_SUCCESS {
+		e.Status, e.Error = airdropSuccess, ""
+	} else {
+		e.Status, e.Error = airdropFailed, r.Message
+	}
+}
+
+// sendAirdropEntry signs the transfer and records its hash in the state before sending it. A tx rejected by the node
+// fails, while a tx which may have reached the node stays sent to be checked later.
+func sendAirdropEntry(p *airdropProgress, e *airdropEntry, stateFile string) error {
+	data, err := json.Marshal([]string{p.Token, p.From, e.Receiver, e.Amount, e.Memo})
+	if err != nil {
+		return err
+	}
+	trx, err := iwalletSDK.CreateTxFromActions([]*rpcpb.Action{sdk.NewAction("token.iost", "transfer", string(data))})
+	if err != nil {
+		return err
+	}
+	if network != "" {
+		if err := iwalletSDK.CheckChainID(); err != nil {
+			return err
+		}
+	}
+	signed, err := iwalletSDK.SignTx(trx, signAlgo)
+	if err != nil {
+		return fmt.Errorf("sign tx error %v", err)
+	}
+	e.Status, e.TxHash, e.Expiration, e.Error = airdropSent, common.Base58Encode(sdk.TxHash(signed)), signed.Expiration, ""
+	if err := p.save(stateFile); err != nil {
+		return err
+	}
+	if _, err := iwalletSDK.SendTransaction(signed); err != nil {
+		switch sdk.CodeOf(err) {
+		case sdk.ErrNodeUnavailable, sdk.ErrDuplicateTx:
+			e.Error = err.Error()
+		default:
+			e.Status, e.TxHash, e.Expiration, e.Error = airdropFailed, "", 0, err.Error()
+		}
+		return p.save(stateFile)
+	}
+	return nil
+}
+
+// waitAirdrop checks the sent transfers until they are all irreversible or the wait timeout passes.
+func waitAirdrop(p *airdropProgress, stateFile string) error {
+	deadline := time.Now().Add(waitTimeout)
+	for p.count(airdropSent) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Duration(checkResultDelay*1000) * time.Millisecond)
+		for _, e := range p.Entries {
+			if e.Status == airdropSent {
+				res, err := iwalletSDK.GetTxByHash(e.TxHash)
+				settle(e, res, err, time.Now().UnixNano())
+			}
+		}
+		if err := p.save(stateFile); err != nil {
+			return err
+		}
+		if !isMachineOutput() {
+			fmt.Printf("%v transfers waiting to become irreversible\n", p.count(airdropSent))
+		}
+	}
+	return nil
+}
+
+func newAirdropReport(p *airdropProgress, stateFile string) *airdropReport {
+	r := &airdropReport{
+		Token:       p.Token,
+		From:        p.From,
+		Total:       len(p.Entries),
+		Succeeded:   p.count(airdropSuccess),
+		Failed:      p.count(airdropFailed),
+		Unconfirmed: p.count(airdropSent),
+		Unsent:      p.count(airdropPending),
+		Failures:    []*airdropEntry{},
+		StateFile:   stateFile,
+	}
+	for _, e := range p.Entries {
+		if e.Status == airdropFailed {
+			r.Failures = append(r.Failures, e)
+		}
+	}
+	return r
+}
+
+func printAirdropReport(r *airdropReport) error {
+	fmt.Printf("Airdrop of %v from %v: %v transfers, %v succeeded, %v failed, %v unconfirmed, %v not sent\n",
+		r.Token, r.From, r.Total, r.Succeeded, r.Failed, r.Unconfirmed, r.Unsent)
+	if len(r.Failures) != 0 {
+		fmt.Println("Failures:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ROW\tRECEIVER\tAMOUNT\tREASON")
+		for _, e := range r.Failures {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", e.Row, e.Receiver, e.Amount, e.Error)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if r.Unconfirmed+r.Unsent > 0 {
+		fmt.Printf("Resume by: iwallet airdrop --file %v --resume %v --account %v\n", airdropFile, r.StateFile, r.From)
+	}
+	return nil
+}
+
+func runAirdrop() error {
+	rows, err := loadTransferRows(airdropFile, airdropToken)
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if r.Amount, err = amountOf(r.Amount, airdropToken); err != nil {
+			return fmt.Errorf("invalid transfer at row %v: %v", r.Row, err)
+		}
+	}
+	stateFile := airdropState
+	if stateFile == "" {
+		stateFile = airdropFile + ".state.json"
+		if _, err := os.Stat(stateFile); err == nil {
+			return fmt.Errorf("state file %v of a previous airdrop exists, continue it with --resume %v or remove it", stateFile, stateFile)
+		}
+	}
+	p, err := loadAirdropProgress(stateFile)
+	if os.IsNotExist(err) {
+		p = newAirdropProgress(airdropFile, airdropToken, accountName, rows)
+	} else if err != nil {
+		return err
+	} else if err := p.checkRows(airdropToken, accountName, rows); err != nil {
+		return fmt.Errorf("can not resume from %v: %v", stateFile, err)
+	}
+	if err := p.save(stateFile); err != nil {
+		return err
+	}
+
+	if err := InitAccount(); err != nil {
+		return fmt.Errorf("failed to load account: %v", err)
+	}
+	if err := iwalletSDK.Connect(); err != nil {
+		return err
+	}
+	defer iwalletSDK.CloseConn()
+
+	// settle the transfers sent by an interrupted run first, resending those which expired unpacked
+	for _, e := range p.Entries {
+		if e.Status == airdropSent {
+			res, err := iwalletSDK.GetTxByHash(e.TxHash)
+			settle(e, res, err, time.Now().UnixNano())
+		}
+	}
+	pending := p.count(airdropPending)
+	if !isMachineOutput() {
+		fmt.Printf("Airdrop of %v from %v: %v of %v transfers to send at %v per second, progress saved in %v\n",
+			airdropToken, accountName, pending, len(p.Entries), airdropTPS, stateFile)
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / airdropTPS))
+	defer ticker.Stop()
+	sent := 0
+	for _, e := range p.Entries {
+		if e.Status != airdropPending {
+			continue
+		}
+		if sent > 0 {
+			<-ticker.C
+		}
+		sent++
+		if err := sendAirdropEntry(p, e, stateFile); err != nil {
+			return err
+		}
+		if !isMachineOutput() {
+			fmt.Printf("[%v/%v] row %v\t%v\t%v\t%v\t%v\n", sent, pending, e.Row, e.Receiver, e.Amount, e.TxHash, e.Status)
+		}
+	}
+	if err := waitAirdrop(p, stateFile); err != nil {
+		return err
+	}
+
+	r := newAirdropReport(p, stateFile)
+	if isMachineOutput() {
+		err = printResult(r)
+	} else {
+		err = printAirdropReport(r)
+	}
+	if err != nil {
+		return err
+	}
+	if r.Failed+r.Unconfirmed+r.Unsent > 0 {
+		return fmt.Errorf("%v of %v transfers did not succeed", r.Total-r.Succeeded, r.Total)
+	}
+	return nil
+}
+
+var airdropCmd = &cobra.Command{
+	Use:   "airdrop",
+	Short: "Send tokens to many receivers at a capped rate",
+	Long: `Send tokens to the receivers listed in a csv (receiver,amount[,memo]) or json file, one transaction each at
+	the rate given by --tps, then wait for them to become irreversible and report the failures with reasons.
+	The progress is saved in the file given by --resume (default <file>.state.json) before every transaction is sent,
+	so an interrupted airdrop continues by running it again with --resume. A transfer which was sent is never sent again
+	unless its transaction has expired without being packed.`,
+	Example: `  iwallet airdrop --file recipients.csv --account test0
+  iwallet airdrop --file recipients.csv --tps 5 --resume state.json --account test0
+  iwallet airdrop --file recipients.json --token mytoken --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if airdropFile == "" {
+			cmd.Usage()
+			return fmt.Errorf("please give the receivers by --file")
+		}
+		if airdropTPS <= 0 {
+			return fmt.Errorf("invalid --tps %v, should be positive", airdropTPS)
+		}
+		if delay > 0 {
+			return fmt.Errorf("--delay can not be used with airdrop")
+		}
+		if dryRun {
+			return fmt.Errorf("--dry_run can not be used with airdrop")
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		airdropToken = strings.ToLower(airdropToken)
+		return runAirdrop()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(airdropCmd)
+	airdropCmd.Flags().StringVarP(&airdropFile, "file", "", "", "csv (receiver,amount[,memo]) or json file listing the receivers")
+	airdropCmd.Flags().Float64VarP(&airdropTPS, "tps", "", 5, "max transactions sent per second")
+	airdropCmd.Flags().StringVarP(&airdropState, "resume", "", "", "state file keeping the progress, which a later run with the same file continues (default <file>.state.json)")
+	airdropCmd.Flags().StringVarP(&airdropToken, "token", "", "iost", "token to send")
+}
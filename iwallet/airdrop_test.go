@@ -0,0 +1,63 @@
+package iwallet
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettle(t *testing.T) {
+	sent := func() *airdropEntry {
+		return &airdropEntry{Status: airdropSent, TxHash: "7MDf", Expiration: 100}
+	}
+	e := sent()
+	settle(e, nil, errors.New("rpc error: code = Unknown desc = tx not found"), 50)
+	assert.Equal(t, airdropSent, e.Status)
+	settle(e, nil, errors.New("rpc error: code = Unavailable desc = connection refused"), 200)
+	assert.Equal(t, airdropSent, e.Status)
+	settle(e, nil, errors.New("rpc error: code = Unknown desc = tx not found"), 200)
+	assert.Equal(t, &airdropEntry{Status: airdropPending}, e)
+
+	e = sent()
+	settle(e, &rpcpb.TransactionResponse{Status: rpcpb.TransactionResponse_PACKED, Transaction: &rpcpb.Transaction{TxReceipt: &rpcpb.TxReceipt{}}}, nil, 50)
+	assert.Equal(t, airdropSent, e.Status)
+	settle(e, &rpcpb.TransactionResponse{Status: rpcpb.TransactionResponse_IRREVERSIBLE, Transaction: &rpcpb.Transaction{TxReceipt: &rpcpb.TxReceipt{}}}, nil, 50)
+	assert.Equal(t, airdropSuccess, e.Status)
+
+	e = sent()
+	receipt := &rpcpb.TxReceipt{StatusCode: rpcpb.TxReceipt_RUNTIME_ERROR, Message: "balance not enough"}
+	settle(e, &rpcpb.TransactionResponse{Status: rpcpb.TransactionResponse_IRREVERSIBLE, Transaction: &rpcpb.Transaction{TxReceipt: receipt}}, nil, 50)
+	assert.Equal(t, airdropFailed, e.Status)
+	assert.Equal(t, "balance not enough", e.Error)
+}
+
+func TestAirdropProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "airdrop")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+
+	rows := []*transferRow{{Row: 1, Receiver: "user0001", Amount: "1"}, {Row: 2, Receiver: "user0002", Amount: "2", Memo: "hi"}}
+	p := newAirdropProgress("recipients.csv", "iost", "alice", rows)
+	p.Entries[0].Status, p.Entries[1].Status, p.Entries[1].Error = airdropSuccess, airdropFailed, "balance not enough"
+	assert.Nil(t, p.save(stateFile))
+
+	loaded, err := loadAirdropProgress(stateFile)
+	assert.Nil(t, err)
+	assert.Equal(t, p, loaded)
+	assert.Nil(t, loaded.checkRows("iost", "alice", rows))
+	assert.Contains(t, loaded.checkRows("iost", "bob", rows).Error(), "from alice")
+	assert.Contains(t, loaded.checkRows("iost", "alice", rows[:1]).Error(), "has 2 transfers")
+	changed := []*transferRow{rows[0], {Row: 2, Receiver: "user0002", Amount: "3", Memo: "hi"}}
+	assert.Contains(t, loaded.checkRows("iost", "alice", changed).Error(), "row 2 of the file differs")
+
+	r := newAirdropReport(loaded, stateFile)
+	assert.Equal(t, 1, r.Succeeded)
+	assert.Equal(t, 1, r.Failed)
+	assert.Equal(t, []*airdropEntry{loaded.Entries[1]}, r.Failures)
+}
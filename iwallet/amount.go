@@ -0,0 +1,131 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/spf13/cobra"
+)
+
+// tokenDecimals caches the decimal places of the tokens looked up from the chain.
+var tokenDecimals = make(map[string]int)
+
+// decimalOf returns the decimal places of a token, which token.iost saves in the TI<symbol> map.
+func decimalOf(symbol string) (int, error) {
+	if d, ok := tokenDecimals[symbol]; ok {
+		return d, nil
+	}
+	d, err := iwalletSDK.TokenDecimal(symbol)
+	if err != nil {
+		return 0, err
+	}
+	tokenDecimals[symbol] = d
+	return d, nil
+}
+
+// splitAmount splits a human amount such as "1.5" or "1.5 MYTOKEN" into the number and the token symbol, which is
+// lowercased as token.iost requires and is defaultToken if not given.
+func splitAmount(s string, defaultToken string) (number string, symbol string, err error) {
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		number, symbol = fields[0], defaultToken
+	case 2:
+		number, symbol = fields[0], strings.ToLower(fields[1])
+	default:
+		return "", "", fmt.Errorf("invalid amount %q, should be a number optionally followed by the token", s)
+	}
+	if _, err := common.NewFixed(number, -1); err != nil || strings.HasPrefix(number, "-") {
+		return "", "", fmt.Errorf("invalid amount %q, should be a non-negative decimal number", s)
+	}
+	return number, symbol, nil
+}
+
+// fixedAmount converts the number into the fixed point string of a token with the decimal places. More fraction
+// digits than the token has are rejected instead of silently dropped by the chain, trailing zeros do not count.
+func fixedAmount(number string, decimal int) (string, error) {
+	f, err := common.NewFixed(number, -1)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount %v: %v", number, err)
+	}
+	digits := 0
+	if i := strings.Index(number, "."); i >= 0 {
+		digits = len(strings.TrimRight(number[i+1:], "0"))
+	}
+	if digits > decimal {
+		return "", fmt.Errorf("invalid amount %v, the token has only %v decimals", number, decimal)
+	}
+	if f.ChangeDecimal(decimal).Err != nil {
+		return "", fmt.Errorf("invalid amount %v, too large for a token with %v decimals", number, decimal)
+	}
+	return f.ToString(), nil
+}
+
+// tokenAmount parses a human amount for an action, looking up the decimal places of its token.
+func tokenAmount(s string, defaultToken string) (amount string, symbol string, err error) {
+	number, symbol, err := splitAmount(s, defaultToken)
+	if err != nil {
+		return "", "", err
+	}
+	decimal, err := decimalOf(symbol)
+	if err != nil {
+		return "", "", err
+	}
+	amount, err = fixedAmount(number, decimal)
+	if err != nil {
+		return "", "", err
+	}
+	return amount, symbol, nil
+}
+
+// amountOf parses a human amount of the given token, rejecting amounts in another token.
+func amountOf(s string, symbol string) (string, error) {
+	number, token, err := splitAmount(s, symbol)
+	if err != nil {
+		return "", err
+	}
+	if token != symbol {
+		return "", fmt.Errorf("invalid amount %q, should be in %v", s, symbol)
+	}
+	decimal, err := decimalOf(symbol)
+	if err != nil {
+		return "", err
+	}
+	return fixedAmount(number, decimal)
+}
+
+// checkAmount checks the syntax of a human amount argument, whose decimal places are checked once the token is looked up.
+func checkAmount(cmd *cobra.Command, arg string, argName string) error {
+	if _, _, err := splitAmount(arg, ""); err != nil {
+		cmd.Help()
+		fmt.Println()
+		return fmt.Errorf(`invalid value "%v" for argument "%v": %v`, arg, argName, err)
+	}
+	return nil
+}
+
+// checkBytes checks an argument counting ram bytes, which are whole numbers.
+func checkBytes(cmd *cobra.Command, arg string, argName string) error {
+	if n, err := strconv.ParseInt(arg, 10, 64); err != nil || n <= 0 {
+		cmd.Help()
+		fmt.Println()
+		return fmt.Errorf(`invalid value "%v" for argument "%v": should be a positive number of bytes`, arg, argName)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAmount(t *testing.T) {
+	number, symbol, err := splitAmount("1.5", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.5", number)
+	assert.Equal(t, "iost", symbol)
+	number, symbol, err = splitAmount(" 1.5  MYTOKEN ", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.5", number)
+	assert.Equal(t, "mytoken", symbol)
+
+	for _, s := range []string{"", "1e3", "-1", ".5", "1.5.5", "1 2 iost", "0x10"} {
+		_, _, err = splitAmount(s, "iost")
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestFixedAmount(t *testing.T) {
+	amount, err := fixedAmount("1.50000000000", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.5", amount)
+	amount, err = fixedAmount("100", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", amount)
+	amount, err = fixedAmount("0.123456789012345678", 18)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.123456789012345678", amount)
+
+	_, err = fixedAmount("1.000000001", 8)
+	assert.Contains(t, err.Error(), "the token has only 8 decimals")
+	_, err = fixedAmount("1.5", 0)
+	assert.Contains(t, err.Error(), "the token has only 0 decimals")
+	_, err = fixedAmount("100000000000", 8)
+	assert.Contains(t, err.Error(), "too large")
+}
+
+func TestAmountOf(t *testing.T) {
+	tokenDecimals["iost"] = 8
+	tokenDecimals["ticket"] = 0
+	defer func() {
+		delete(tokenDecimals, "iost")
+		delete(tokenDecimals, "ticket")
+	}()
+
+	amount, symbol, err := tokenAmount("3 TICKET", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, "3", amount)
+	assert.Equal(t, "ticket", symbol)
+	_, _, err = tokenAmount("3.5 ticket", "iost")
+	assert.NotNil(t, err)
+
+	amount, err = amountOf("12.25000000", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, "12.25", amount)
+	_, err = amountOf("1 ticket", "iost")
+	assert.Contains(t, err.Error(), "should be in iost")
+
+	_, err = positiveAmountOf("0", "iost")
+	assert.Contains(t, err.Error(), "should be positive")
+}
@@ -0,0 +1,156 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/iost-official/go-iost/iwallet/jsonschema"
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+var (
+	argsFile   string
+	argsSchema string
+)
+
+// readArgsFile reads the json args file and checks it against the schema file if given.
+func readArgsFile(file string, schemaFile string) (interface{}, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read args file: %v", err)
+	}
+	v, err := jsonschema.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json in args file %v: %v", file, err)
+	}
+	if schemaFile == "" {
+		return v, nil
+	}
+	data, err = ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %v", err)
+	}
+	schema, err := jsonschema.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", schemaFile, err)
+	}
+	if err := schema.Validate(v); err != nil {
+		return nil, fmt.Errorf("args file %v does not match schema %v:\n%v", file, schemaFile, err)
+	}
+	return v, nil
+}
+
+// argsByName orders the args given by name in an object as the params of the abi, checking their types as encodeArg does.
+func argsByName(params []*abiParam, args map[string]interface{}) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(params))
+	valid := make([]string, 0, len(params))
+	known := make(map[string]bool)
+	for _, p := range params {
+		valid = append(valid, p.Name+":"+p.Type)
+		known[p.Name] = true
+	}
+	var unknown, missing []string
+	for name := range args {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown args %v, args are %v", unknown, valid)
+	}
+	for _, p := range params {
+		v, ok := args[p.Name]
+		if !ok {
+			missing = append(missing, p.Name)
+			continue
+		}
+		if err := checkArgType(p, v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("missing args %v, args are %v", missing, valid)
+	}
+	return values, nil
+}
+
+func checkArgType(p *abiParam, v interface{}) error {
+	switch p.Type {
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("arg %v should be a string", p.Name)
+		}
+	case "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("arg %v should be an integer", p.Name)
+		}
+		if _, err := n.Int64(); err != nil {
+			return fmt.Errorf("arg %v should be an integer, got %v", p.Name, n)
+		}
+	case "bool":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("arg %v should be true or false", p.Name)
+		}
+	}
+	return nil
+}
+
+// actionFromArgsFile builds the action from the args file. An array holds the params in order, an object holds them
+// by name like --arg, which needs the abi of the contract.
+func actionFromArgsFile(contract, abiName, file, schemaFile string) (*rpcpb.Action, error) {
+	v, err := readArgsFile(file, schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	switch args := v.(type) {
+	case []interface{}:
+		values = args
+	case map[string]interface{}:
+		c, err := iwalletSDK.GetContract(contract)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contract %v: %v", contract, err)
+		}
+		params, err := abiParams(c, abiName)
+		if err != nil {
+			return nil, err
+		}
+		if values, err = argsByName(params, args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("args file %v should hold an array of args or an object of args by name", file)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(values); err != nil {
+		return nil, err
+	}
+	data, err := resolveActionArgs(strings.TrimSpace(buf.String()))
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Action{Contract: contract, ActionName: abiName, Data: data}, nil
+}
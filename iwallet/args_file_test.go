@@ -0,0 +1,49 @@
+package iwallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgsByName(t *testing.T) {
+	params := []*abiParam{{Name: "token", Type: "string"}, {Name: "count", Type: "number"}, {Name: "ok", Type: "bool"}, {Name: "data", Type: "json"}}
+	values, err := argsByName(params, map[string]interface{}{"ok": true, "data": map[string]interface{}{"a": 1}, "count": json.Number("3"), "token": "iost"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"iost", json.Number("3"), true, map[string]interface{}{"a": 1}}, values)
+
+	_, err = argsByName(params, map[string]interface{}{"token": "iost", "count": json.Number("1.5"), "ok": true, "data": nil})
+	assert.Contains(t, err.Error(), "arg count should be an integer")
+	_, err = argsByName(params, map[string]interface{}{"token": "iost"})
+	assert.Contains(t, err.Error(), "missing args [count ok data]")
+	_, err = argsByName(params, map[string]interface{}{"tokne": "iost"})
+	assert.Contains(t, err.Error(), "unknown args [tokne]")
+}
+
+func TestActionFromArgsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "args")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "transfer.json")
+	schemaFile := filepath.Join(dir, "transfer.schema.json")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`["iost", "alice", "bob", "100000000000000000000.5", "a <memo>"]`), 0600))
+	assert.Nil(t, ioutil.WriteFile(schemaFile, []byte(`{"type": "array", "items": {"type": "string"}, "maxItems": 5}`), 0600))
+
+	action, err := actionFromArgsFile("token.iost", "transfer", file, schemaFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "token.iost", action.Contract)
+	assert.Equal(t, `["iost","alice","bob","100000000000000000000.5","a <memo>"]`, action.Data)
+
+	assert.Nil(t, ioutil.WriteFile(schemaFile, []byte(`{"type": "array", "maxItems": 4}`), 0600))
+	_, err = actionFromArgsFile("token.iost", "transfer", file, schemaFile)
+	assert.Contains(t, err.Error(), "does not match schema")
+	assert.Contains(t, err.Error(), "/: has 5 items, should have at most 4")
+
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`"iost"`), 0600))
+	_, err = actionFromArgsFile("token.iost", "transfer", file, "")
+	assert.Contains(t, err.Error(), "should hold an array of args")
+}
@@ -16,17 +16,103 @@ package iwallet
 
 import (
 	"fmt"
-	"github.com/iost-official/go-iost/sdk"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
 
+	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/spf13/cobra"
 )
 
+var listAllTokens bool
+
+// tokenHolding is the balance of a token, whose type is "token" or "token721".
+type tokenHolding struct {
+	Token   string  `json:"token"`
+	Type    string  `json:"type"`
+	Balance float64 `json:"balance"`
+	Frozen  float64 `json:"frozen"`
+}
+
+// accountHoldings is everything an account holds, the resources and the balances of all tokens.
+type accountHoldings struct {
+	Account      string          `json:"account"`
+	Gas          float64         `json:"gas"`
+	GasLimit     float64         `json:"gas_limit"`
+	RAMUsed      int64           `json:"ram_used"`
+	RAMAvailable int64           `json:"ram_available"`
+	Pledged      float64         `json:"pledged"`
+	Frozen       float64         `json:"frozen"`
+	Tokens       []*tokenHolding `json:"tokens"`
+}
+
+func newAccountHoldings(info *rpcpb.Account, tokens *rpcpb.GetAccountTokensResponse) *accountHoldings {
+	h := &accountHoldings{Account: info.Name, Tokens: make([]*tokenHolding, 0)}
+	if g := info.GasInfo; g != nil {
+		h.Gas = g.CurrentTotal
+		h.GasLimit = g.Limit
+		for _, p := range g.PledgedInfo {
+			h.Pledged += p.Amount
+		}
+	}
+	if r := info.RamInfo; r != nil {
+		h.RAMUsed = r.Used
+		h.RAMAvailable = r.Available
+	}
+	for _, f := range info.FrozenBalances {
+		h.Frozen += f.Amount
+	}
+	for _, t := range tokens.Tokens {
+		holding := &tokenHolding{Token: t.Token, Type: "token", Balance: t.Balance}
+		for _, f := range t.FrozenBalances {
+			holding.Frozen += f.Amount
+		}
+		h.Tokens = append(h.Tokens, holding)
+	}
+	for _, t := range tokens.Token721S {
+		h.Tokens = append(h.Tokens, &tokenHolding{Token: t.Token, Type: "token721", Balance: float64(t.Balance)})
+	}
+	return h
+}
+
+func formatAmount(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func writeHoldings(w io.Writer, h *accountHoldings) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Account:\t%v\n", h.Account)
+	fmt.Fprintf(tw, "Gas:\t%v / %v\n", formatAmount(h.Gas), formatAmount(h.GasLimit))
+	fmt.Fprintf(tw, "RAM:\t%v bytes used, %v bytes available\n", h.RAMUsed, h.RAMAvailable)
+	fmt.Fprintf(tw, "Pledged:\t%v iost\n", formatAmount(h.Pledged))
+	fmt.Fprintf(tw, "Frozen:\t%v iost\n", formatAmount(h.Frozen))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(h.Tokens) == 0 {
+		_, err := fmt.Fprintln(w, "\nNo tokens held")
+		return err
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "TOKEN\tTYPE\tBALANCE\tFROZEN")
+	for _, t := range h.Tokens {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", t.Token, t.Type, formatAmount(t.Balance), formatAmount(t.Frozen))
+	}
+	return tw.Flush()
+}
+
 // accountInfoCmd represents the balance command.
 var accountInfoCmd = &cobra.Command{
-	Use:     "balance accountName",
-	Short:   "Check the information of a specified account",
-	Long:    `Check the information of a specified account`,
-	Example: `  iwallet balance test0`,
+	Use:   "balance accountName",
+	Short: "Check the information of a specified account",
+	Long: `Check the information of a specified account
+
+With --all, list the balances of every token and token721 token held by the account
+together with its gas, ram, pledged and frozen amounts`,
+	Example: `  iwallet balance test0
+  iwallet balance test0 --all
+  iwallet balance test0 --all --output_format json`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
 			return err
@@ -39,11 +125,22 @@ var accountInfoCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(sdk.MarshalTextString(info))
-		return nil
+		if !listAllTokens {
+			return printResult(info)
+		}
+		tokens, err := iwalletSDK.GetAccountTokens(id)
+		if err != nil {
+			return fmt.Errorf("failed to get tokens of %v: %v", id, err)
+		}
+		h := newAccountHoldings(info, tokens)
+		if isMachineOutput() {
+			return printResult(h)
+		}
+		return writeHoldings(os.Stdout, h)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(accountInfoCmd)
+	accountInfoCmd.Flags().BoolVarP(&listAllTokens, "all", "", false, "list all tokens held by the account with its resources")
 }
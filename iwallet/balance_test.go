@@ -0,0 +1,46 @@
+package iwallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHoldings(t *testing.T) {
+	info := &rpcpb.Account{
+		Name: "test0",
+		GasInfo: &rpcpb.Account_GasInfo{CurrentTotal: 1500.5, Limit: 3000, PledgedInfo: []*rpcpb.Account_PledgeInfo{
+			{Pledger: "test0", Amount: 10},
+			{Pledger: "test1", Amount: 5},
+		}},
+		RamInfo:        &rpcpb.Account_RAMInfo{Used: 1024, Available: 2048},
+		FrozenBalances: []*rpcpb.FrozenBalance{{Amount: 3}, {Amount: 4}},
+	}
+	tokens := &rpcpb.GetAccountTokensResponse{
+		Tokens: []*rpcpb.GetAccountTokensResponse_Token{
+			{Token: "iost", Balance: 99.5, FrozenBalances: []*rpcpb.FrozenBalance{{Amount: 3}, {Amount: 4}}},
+			{Token: "mytoken", Balance: 1},
+		},
+		Token721S: []*rpcpb.GetAccountTokensResponse_Token721{{Token: "mynft", Balance: 2}},
+	}
+	h := newAccountHoldings(info, tokens)
+	assert.Equal(t, float64(15), h.Pledged)
+	assert.Equal(t, float64(7), h.Frozen)
+	assert.Equal(t, []*tokenHolding{
+		{Token: "iost", Type: "token", Balance: 99.5, Frozen: 7},
+		{Token: "mytoken", Type: "token", Balance: 1},
+		{Token: "mynft", Type: "token721", Balance: 2},
+	}, h.Tokens)
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeHoldings(&buf, h))
+	assert.Contains(t, buf.String(), "Gas:      1500.5 / 3000\n")
+	assert.Contains(t, buf.String(), "RAM:      1024 bytes used, 2048 bytes available\n")
+	assert.Contains(t, buf.String(), "mynft    token721  2        0\n")
+
+	buf.Reset()
+	assert.Nil(t, writeHoldings(&buf, newAccountHoldings(&rpcpb.Account{Name: "test1"}, &rpcpb.GetAccountTokensResponse{})))
+	assert.Contains(t, buf.String(), "No tokens held")
+}
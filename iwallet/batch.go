@@ -0,0 +1,242 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// approxTransferGas is a conservative gas cost of one token.iost transfer action, used to size batches.
+const approxTransferGas = 10000
+
+const maxBatchSize = 100
+
+type transferRow struct {
+	Row      int    `json:"-"`
+	Receiver string `json:"receiver"`
+	Amount   string `json:"amount"`
+	Memo     string `json:"memo"`
+}
+
+type transferResult struct {
+	row    *transferRow
+	txHash string
+	err    error
+}
+
+// loadTransferRows reads rows from a csv file with columns receiver,amount[,memo] or a json file with an array of
+// {"receiver","amount","memo"} objects. A csv header line is skipped. The amounts should be in the token.
+func loadTransferRows(file string, token string) ([]*transferRow, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rows []*transferRow
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		rows, err = parseTransferJSON(f)
+	} else {
+		rows, err = parseTransferCSV(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch file %v: %v", file, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no transfer in batch file %v", file)
+	}
+	for _, r := range rows {
+		if r.Receiver, err = resolveAccount(r.Receiver); err != nil {
+			return nil, fmt.Errorf("invalid transfer at row %v: %v", r.Row, err)
+		}
+		if err := checkTransferRow(r, token); err != nil {
+			return nil, fmt.Errorf("invalid transfer at row %v: %v", r.Row, err)
+		}
+	}
+	return rows, nil
+}
+
+func parseTransferCSV(r io.Reader) ([]*transferRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*transferRow, 0, len(records))
+	for i, rec := range records {
+		if len(rec) < 2 || len(rec) > 3 {
+			return nil, fmt.Errorf("row %v should have 2 or 3 columns: receiver,amount[,memo]", i+1)
+		}
+		if i == 0 {
+			if _, _, err := splitAmount(rec[1], "iost"); err != nil {
+				continue
+			}
+		}
+		row := &transferRow{Row: i + 1, Receiver: strings.TrimSpace(rec[0]), Amount: strings.TrimSpace(rec[1])}
+		if len(rec) == 3 {
+			row.Memo = rec[2]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseTransferJSON(r io.Reader) ([]*transferRow, error) {
+	var rows []*transferRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		row.Row = i + 1
+	}
+	return rows, nil
+}
+
+func checkTransferRow(r *transferRow, token string) error {
+	if len(r.Receiver) < 5 || len(r.Receiver) > 11 {
+		return fmt.Errorf("invalid receiver %v", r.Receiver)
+	}
+	number, symbol, err := splitAmount(r.Amount, token)
+	if err != nil {
+		return err
+	}
+	if symbol != token {
+		return fmt.Errorf("invalid amount %v, the transfers are in %v", r.Amount, token)
+	}
+	if amount, _ := strconv.ParseFloat(number, 64); amount <= 0 {
+		return fmt.Errorf("invalid amount %v", r.Amount)
+	}
+	return nil
+}
+
+// batchChunkSize returns how many transfers are put into one tx within the gas limit.
+func batchChunkSize(size int, gasLimit float64) int {
+	limit := int(gasLimit / approxTransferGas)
+	if limit > maxBatchSize {
+		limit = maxBatchSize
+	}
+	if size <= 0 || size > limit {
+		size = limit
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+func chunkTransferRows(rows []*transferRow, size int) [][]*transferRow {
+	var chunks [][]*transferRow
+	for len(rows) > size {
+		chunks = append(chunks, rows[:size])
+		rows = rows[size:]
+	}
+	return append(chunks, rows)
+}
+
+func transferActions(token, from string, rows []*transferRow) ([]*rpcpb.Action, error) {
+	actions := make([]*rpcpb.Action, 0, len(rows))
+	for _, r := range rows {
+		data, err := json.Marshal([]string{token, from, r.Receiver, r.Amount, r.Memo})
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, sdk.NewAction("token.iost", "transfer", string(data)))
+	}
+	return actions, nil
+}
+
+func sumAmount(rows []*transferRow) float64 {
+	total := 0.0
+	for _, r := range rows {
+		amount, _ := strconv.ParseFloat(r.Amount, 64)
+		total += amount
+	}
+	return total
+}
+
+func batchTransfer(file string) error {
+	rows, err := loadTransferRows(file, "iost")
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if r.Amount, err = amountOf(r.Amount, "iost"); err != nil {
+			return fmt.Errorf("invalid transfer at row %v: %v", r.Row, err)
+		}
+	}
+	chunks := chunkTransferRows(rows, batchChunkSize(batchSize, gasLimit))
+	fmt.Printf("Batch transfer from %v: %v transfers, %v iost in total, %v transaction(s)\n", accountName, len(rows), sumAmount(rows), len(chunks))
+
+	err = InitAccount()
+	if err != nil {
+		return fmt.Errorf("failed to load account: %v", err)
+	}
+	if err := iwalletSDK.Connect(); err != nil {
+		return err
+	}
+	defer iwalletSDK.CloseConn()
+
+	var results []*transferResult
+	failed := 0
+	for i, chunk := range chunks {
+		fmt.Printf("Sending transaction %v/%v with %v transfers of %v iost...\n", i+1, len(chunks), len(chunk), sumAmount(chunk))
+		hash, err := sendTransferChunk(chunk)
+		if err != nil {
+			fmt.Println("failed:", err)
+			failed += len(chunk)
+		}
+		for _, r := range chunk {
+			results = append(results, &transferResult{row: r, txHash: hash, err: err})
+		}
+	}
+
+	fmt.Println("Result:")
+	for _, r := range results {
+		status := "SUCCESS"
+		if r.err != nil {
+			status = "FAILED: " + r.err.Error()
+		}
+		fmt.Printf("row %v\t%v\t%v\t%v\t%v\n", r.row.Row, r.row.Receiver, r.row.Amount, r.txHash, status)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v of %v transfers failed", failed, len(rows))
+	}
+	fmt.Printf("All %v transfers succeeded\n", len(rows))
+	return nil
+}
+
+func sendTransferChunk(chunk []*transferRow) (string, error) {
+	actions, err := transferActions("iost", accountName, chunk)
+	if err != nil {
+		return "", err
+	}
+	tx, err := iwalletSDK.CreateTxFromActions(actions)
+	if err != nil {
+		return "", err
+	}
+	return iwalletSDK.SendTx(tx)
+}
@@ -0,0 +1,46 @@
+package iwallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransferCSV(t *testing.T) {
+	rows, err := parseTransferCSV(strings.NewReader("receiver,amount,memo\nuser0001,1.5,hello\n# comment\nuser0002, 2\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, &transferRow{Row: 2, Receiver: "user0001", Amount: "1.5", Memo: "hello"}, rows[0])
+	assert.Equal(t, &transferRow{Row: 3, Receiver: "user0002", Amount: "2"}, rows[1])
+	assert.Equal(t, 3.5, sumAmount(rows))
+
+	_, err = parseTransferCSV(strings.NewReader("user0001,1,memo,extra\n"))
+	assert.NotNil(t, err)
+}
+
+func TestParseTransferJSON(t *testing.T) {
+	rows, err := parseTransferJSON(strings.NewReader(`[{"receiver":"user0001","amount":"1"},{"receiver":"user0002","amount":"0.5","memo":"m"}]`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, "m", rows[1].Memo)
+	assert.Nil(t, checkTransferRow(rows[0], "iost"))
+	assert.NotNil(t, checkTransferRow(&transferRow{Receiver: "user0001", Amount: "-1"}, "iost"))
+	assert.NotNil(t, checkTransferRow(&transferRow{Receiver: "u", Amount: "1"}, "iost"))
+}
+
+func TestChunkTransferRows(t *testing.T) {
+	assert.Equal(t, 100, batchChunkSize(0, 4000000))
+	assert.Equal(t, 10, batchChunkSize(50, 100000))
+	assert.Equal(t, 5, batchChunkSize(5, 100000))
+	assert.Equal(t, 1, batchChunkSize(0, 10))
+
+	rows := make([]*transferRow, 7)
+	chunks := chunkTransferRows(rows, 3)
+	assert.Equal(t, 3, len(chunks))
+	assert.Equal(t, 1, len(chunks[2]))
+
+	actions, err := transferActions("iost", "admin", []*transferRow{{Receiver: "user0001", Amount: "1", Memo: `"q"`}})
+	assert.Nil(t, err)
+	assert.Equal(t, `["iost","admin","user0001","1","\"q\""]`, actions[0].Data)
+}
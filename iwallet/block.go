@@ -16,7 +16,7 @@ package iwallet
 
 import (
 	"fmt"
-	"github.com/iost-official/go-iost/sdk"
+	"os"
 	"strconv"
 
 	"github.com/iost-official/go-iost/rpc/pb"
@@ -25,6 +25,8 @@ import (
 
 var method string
 var complete bool
+var blockActions []string
+var headerOnly bool
 
 var methodMap = map[string]func(string) (*rpcpb.BlockResponse, error){
 	"num": func(arg string) (*rpcpb.BlockResponse, error) {
@@ -33,19 +35,45 @@ var methodMap = map[string]func(string) (*rpcpb.BlockResponse, error){
 			err = fmt.Errorf("invalid block number: %v", err)
 			return nil, err
 		}
+		if len(blockActions) > 0 {
+			return iwalletSDK.GetFilteredBlockByNum(num, blockActions)
+		}
 		return iwalletSDK.GetBlockByNum(num, complete)
 	},
 	"hash": func(arg string) (*rpcpb.BlockResponse, error) {
+		if len(blockActions) > 0 {
+			return iwalletSDK.GetFilteredBlockByHash(arg, blockActions)
+		}
 		return iwalletSDK.GetBlockByHash(arg, complete)
 	},
 }
 
+var headerMethodMap = map[string]func(string) (*rpcpb.BlockHeaderResponse, error){
+	"num": func(arg string) (*rpcpb.BlockHeaderResponse, error) {
+		num, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid block number: %v", err)
+			return nil, err
+		}
+		return iwalletSDK.GetBlockHeaderByNum(num)
+	},
+	"hash": func(arg string) (*rpcpb.BlockHeaderResponse, error) {
+		return iwalletSDK.GetBlockHeaderByHash(arg)
+	},
+}
+
 // blockCmd represents the block command.
 var blockCmd = &cobra.Command{
 	Use:   "block blockNum|blockHash",
 	Short: "Print block info",
-	Long:  `Print block info by block number or hash`,
+	Long: `Print block info by block number or hash
+	The status tells how far the block is from becoming irreversible, the transactions are summarized with --complete.
+	With --actions, only the transactions with an action calling one of its contracts or abis are fetched.
+	With --header, only the header of the block signed by its witness is fetched and printed.
+	The raw block is printed with --output_format json`,
 	Example: `  iwallet block 0
+  iwallet block 1000 --actions token.iost/transfer
+  iwallet block 1000 --header
   iwallet block 5dEgmyMURGfe7GxvTLajmaLXTkcqs5JwiJ2C2DE5VvVX -m hash`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
@@ -60,12 +88,21 @@ var blockCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if headerOnly {
+			header, err := headerMethodMap[method](args[0])
+			if err != nil {
+				return err
+			}
+			return printResult(header)
+		}
 		blockInfo, err := methodMap[method](args[0])
 		if err != nil {
 			return err
 		}
-		fmt.Println(sdk.MarshalTextString(blockInfo))
-		return nil
+		if isMachineOutput() {
+			return printResult(blockInfo)
+		}
+		return writeBlock(os.Stdout, blockInfo, libBlock())
 	},
 }
 
@@ -73,4 +110,6 @@ func init() {
 	rootCmd.AddCommand(blockCmd)
 	blockCmd.Flags().StringVarP(&method, "method", "m", "num", `find by block num (set as "num") or hash (set as "hash")`)
 	blockCmd.Flags().BoolVarP(&complete, "complete", "c", false, "indicate whether to fetch all the transactions in the block or not")
+	blockCmd.Flags().StringSliceVarP(&blockActions, "actions", "", []string{}, "fetch only the transactions calling one of these contracts or abis, like token.iost/transfer, split by comma")
+	blockCmd.Flags().BoolVarP(&headerOnly, "header", "", false, "fetch only the block header without the transactions")
 }
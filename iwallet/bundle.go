@@ -0,0 +1,310 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iost-official/go-iost/common"
+)
+
+// requirePattern matches requires of relative paths, which are bundled. Other requires are native modules of the chain.
+var requirePattern = regexp.MustCompile(`require\(\s*['"](\.\.?/[^'"]*)['"]\s*\)`)
+
+// bundler puts a contract split into several js modules into one file.
+// The entry module stays at the top level, since the chain looks for the contract class and
+// "module.exports = CLASS;" there, every other module is wrapped in a function run once before the entry.
+type bundler struct {
+	root     string
+	ids      map[string]int
+	visiting map[string]bool
+	modules  []string
+}
+
+func bundleContract(dir string, entry string) (string, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	b := &bundler{
+		root:     root,
+		ids:      make(map[string]int),
+		visiting: make(map[string]bool),
+	}
+	entryPath, err := b.resolve(filepath.Join(root, entry))
+	if err != nil {
+		return "", err
+	}
+	b.visiting[entryPath] = true
+	code, err := b.rewrite(entryPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append(b.modules, code), "\n"), nil
+}
+
+// resolve finds the module file like node does, by the path itself, with .js appended or as a directory with index.js.
+func (b *bundler) resolve(p string) (string, error) {
+	rel, err := filepath.Rel(b.root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("module %v is outside of %v", p, b.root)
+	}
+	for _, c := range []string{p, p + ".js", filepath.Join(p, "index.js")} {
+		if fi, err := os.Stat(c); err == nil && fi.Mode().IsRegular() {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("module %v not found", rel)
+}
+
+// rewrite reads the module and replaces its relative requires by the variables of the bundled modules.
+func (b *bundler) rewrite(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	code := string(data)
+	var rerr error
+	code = requirePattern.ReplaceAllStringFunc(code, func(m string) string {
+		if rerr != nil {
+			return m
+		}
+		spec := requirePattern.FindStringSubmatch(m)[1]
+		id, err := b.load(filepath.Join(filepath.Dir(file), spec))
+		if err != nil {
+			rerr = fmt.Errorf("%v: %v", b.name(file), err)
+			return m
+		}
+		return moduleVar(id)
+	})
+	if rerr != nil {
+		return "", rerr
+	}
+	return code, nil
+}
+
+// load bundles the module and its dependencies once, dependencies coming first.
+func (b *bundler) load(p string) (int, error) {
+	file, err := b.resolve(p)
+	if err != nil {
+		return 0, err
+	}
+	if id, ok := b.ids[file]; ok {
+		return id, nil
+	}
+	if b.visiting[file] {
+		return 0, fmt.Errorf("circular require of %v", b.name(file))
+	}
+	b.visiting[file] = true
+	code, err := b.rewrite(file)
+	if err != nil {
+		return 0, err
+	}
+	id := len(b.modules)
+	b.ids[file] = id
+	b.modules = append(b.modules, fmt.Sprintf(`// %v
+const %v = (function () {
+const module = { exports: {} };
+const exports = module.exports;
+%v
+return module.exports;
+})();`, b.name(file), moduleVar(id), code))
+	return id, nil
+}
+
+func (b *bundler) name(file string) string {
+	rel, err := filepath.Rel(b.root, file)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+func moduleVar(id int) string {
+	return fmt.Sprintf("__module_%d", id)
+}
+
+// keywords after which a slash starts a regular expression instead of a division.
+var regexpKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true, "delete": true,
+	"void": true, "throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// skipSpace skips the whitespace and comments starting at i, telling whether they break the line.
+func skipSpace(code string, i int) (int, bool) {
+	newline := false
+	for i < len(code) {
+		switch {
+		case isSpace(code[i]):
+			newline = newline || code[i] == '\n'
+			i++
+		case strings.HasPrefix(code[i:], "//"):
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				return len(code), newline
+			}
+			i += end
+		case strings.HasPrefix(code[i:], "/*"):
+			end := strings.Index(code[i+2:], "*/")
+			if end < 0 {
+				return len(code), newline
+			}
+			newline = newline || strings.Contains(code[i:i+2+end], "\n")
+			i += end + 4
+		default:
+			return i, newline
+		}
+	}
+	return i, newline
+}
+
+// minifyJS strips comments and indentation. Line breaks are kept so that automatic semicolon insertion works as before.
+func minifyJS(code string) string {
+	var out strings.Builder
+	// templates holds the brace depth of every ${} of template literals being minified.
+	var templates []int
+	last := func() byte {
+		s := out.String()
+		if len(s) == 0 {
+			return 0
+		}
+		return s[len(s)-1]
+	}
+	lastWord := func() string {
+		s := out.String()
+		i := len(s)
+		for i > 0 && isIdentChar(s[i-1]) {
+			i--
+		}
+		return s[i:]
+	}
+	// copyQuoted copies a string, template or regular expression literal starting at i up to the closing quote.
+	copyQuoted := func(i int, quote byte) int {
+		inClass := false
+		out.WriteByte(code[i])
+		for i++; i < len(code); i++ {
+			c := code[i]
+			out.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(code):
+				i++
+				out.WriteByte(code[i])
+			case quote == '/' && c == '[':
+				inClass = true
+			case quote == '/' && c == ']':
+				inClass = false
+			case quote == '`' && c == '$' && i+1 < len(code) && code[i+1] == '{':
+				i++
+				out.WriteByte('{')
+				templates = append(templates, 0)
+				return i + 1
+			case c == quote && !inClass:
+				return i + 1
+			}
+		}
+		return i
+	}
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case isSpace(c) || strings.HasPrefix(code[i:], "//") || strings.HasPrefix(code[i:], "/*"):
+			j, newline := skipSpace(code, i)
+			prev := last()
+			var next byte
+			if j < len(code) {
+				next = code[j]
+			}
+			switch {
+			case prev == 0 || prev == '\n' || next == 0:
+			case newline:
+				out.WriteByte('\n')
+			case isIdentChar(prev) && isIdentChar(next), prev == next && (prev == '+' || prev == '-'):
+				out.WriteByte(' ')
+			}
+			i = j
+		case c == '"' || c == '\'' || c == '`':
+			i = copyQuoted(i, c)
+		case c == '/':
+			prev := last()
+			if prev == 0 || strings.IndexByte("(,=:[!&|?{};+-*%<>~^\n", prev) >= 0 || regexpKeywords[lastWord()] {
+				i = copyQuoted(i, '/')
+			} else {
+				out.WriteByte(c)
+				i++
+			}
+		case c == '{' && len(templates) > 0:
+			templates[len(templates)-1]++
+			out.WriteByte(c)
+			i++
+		case c == '}' && len(templates) > 0:
+			if templates[len(templates)-1] == 0 {
+				templates = templates[:len(templates)-1]
+				// back in the template literal, which continues up to its closing backtick
+				i = copyQuoted(i, '`')
+				continue
+			}
+			templates[len(templates)-1]--
+			out.WriteByte(c)
+			i++
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// checkSyntax makes sure the code parses, by the V8 engine of node.js which the chain runs contracts with too.
+func checkSyntax(code string) error {
+	f, err := ioutil.TempFile("", "iwallet-contract-*.js")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(code); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	output, err := exec.Command("node", "--check", f.Name()).CombinedOutput()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return fmt.Errorf("%v, please make sure node.js has been installed, or skip the check with --skip_check", err)
+		}
+		return fmt.Errorf("%v", strings.TrimSpace(strings.Replace(string(output), f.Name(), "contract", -1)))
+	}
+	return nil
+}
+
+// codeHash is the hash of the code as it is put into the tx and stored with the contract.
+func codeHash(code string) string {
+	return common.Base58Encode(common.Sha3([]byte(code)))
+}
@@ -0,0 +1,99 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeModules(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, code := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBundleContract(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"index.js": `const math = require('./lib/math');
+const fmt = require("./lib");
+class Counter {
+    init() {}
+    add(a, b) { return fmt(math.add(a, b)); }
+}
+module.exports = Counter;
+`,
+		"lib/math.js":  "module.exports = { add: (a, b) => a + b };\n",
+		"lib/index.js": "const math = require('./math.js');\nmodule.exports = function (v) { return 'sum ' + math.add(v, 0); };\n",
+	})
+	defer os.RemoveAll(dir)
+
+	code, err := bundleContract(dir, "index.js")
+	assert.Nil(t, err)
+	// math is bundled once, before the module requiring it
+	assert.Equal(t, 1, strings.Count(code, "// lib/math.js"))
+	assert.True(t, strings.Index(code, "const __module_0") < strings.Index(code, "const __module_1"))
+	assert.Contains(t, code, "const math = __module_0;\nconst fmt = __module_1;")
+	assert.True(t, strings.HasSuffix(code, "module.exports = Counter;\n"))
+	assert.NotContains(t, code, "require(")
+
+	if _, err := exec.LookPath("node"); err == nil {
+		assert.Nil(t, checkSyntax(code))
+		assert.Nil(t, checkSyntax(minifyJS(code)))
+		assert.NotNil(t, checkSyntax(code+"}"))
+	}
+
+	_, err = bundleContract(dir, "main.js")
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestBundleContractErrors(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"index.js":   "const a = require('./a');\n",
+		"a.js":       "const b = require('./b');\n",
+		"b.js":       "const a = require('./a');\n",
+		"escape.js":  "const x = require('../x');\n",
+		"missing.js": "const x = require('./x');\n",
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := bundleContract(dir, "index.js")
+	assert.Contains(t, err.Error(), "circular require of a.js")
+	_, err = bundleContract(dir, "escape.js")
+	assert.Contains(t, err.Error(), "outside of")
+	_, err = bundleContract(dir, "missing.js")
+	assert.Contains(t, err.Error(), "missing.js: module x not found")
+}
+
+func TestMinifyJS(t *testing.T) {
+	code := `// the contract
+class A {
+    /* init
+       does nothing */
+    init() {}
+    f(a) {
+        const s = "a // b /* c */";
+        const r = /[/]+\//g;
+        const t = ` + "`x ${ a + `y ${ a }` } // z`" + `;
+        return a + +1 - -1 / 2;
+    }
+}
+module.exports = A;
+`
+	assert.Equal(t, "class A{\ninit(){}\nf(a){\nconst s=\"a // b /* c */\";\nconst r=/[/]+\\//g;\nconst t=`x ${a+`y ${a}`} // z`;\nreturn a+ +1- -1/2;\n}\n}\nmodule.exports=A;", minifyJS(code))
+}
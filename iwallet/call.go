@@ -30,15 +30,47 @@ var callCmd = &cobra.Command{
 	Long: `Call the method in contracts
 	Would accept arguments as call actions or load transaction request directly from given file (which could be generated by "save" command).
 	An ACTION is a group of 3 arguments: contract name, function name, method parameters.
-	The method parameters should be a string with format '["arg0","arg1",...]'.`,
+	The method parameters should be a string with format '["arg0","arg1",...]'. A parameter "@alias" is replaced with the account of the alias in the address book.
+	With --arg, the parameters are given by name instead, and are checked and encoded according to the abi of the contract.
+	With --args_file, the parameters are read from a json file holding an array of them, or an object of them by name like --arg.
+	The file is checked against the JSON Schema given by --schema before the transaction is built.`,
 	Example: `  iwallet call "token.iost" "transfer" '["iost","user0001","user0002","123.45",""]' --account test0
+  iwallet call "token.iost" "transfer" '["iost","user0001","@alice","123.45",""]' --account test0
+  iwallet call token.iost transfer --arg token=iost --arg from=user0001 --arg to=@alice --arg amount=10 --arg memo= --account test0
+  iwallet call "token.iost" "transfer" '["iost","alice","bob","1",""]' --signers alice@active --account test0
+  iwallet call token.iost transfer --args_file transfer.json --schema transfer.schema.json --account test0
   iwallet call --tx_file tx.json --account test0`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if len(namedArgs) != 0 && argsFile != "" {
+			return fmt.Errorf("--arg and --args_file can not be used together")
+		}
+		if argsSchema != "" && argsFile == "" {
+			return fmt.Errorf("--schema should be used with --args_file")
+		}
+		if (len(namedArgs) != 0 || argsFile != "") && len(args) != 2 {
+			cmd.Usage()
+			return fmt.Errorf("only contract name and function name should be given with --arg or --args_file")
+		}
 		return checkAccount(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		trx := &rpcpb.TransactionRequest{}
-		if txFile != "" {
+		if len(namedArgs) != 0 || argsFile != "" {
+			var action *rpcpb.Action
+			var err error
+			if argsFile != "" {
+				action, err = actionFromArgsFile(args[0], args[1], argsFile, argsSchema)
+			} else {
+				action, err = actionFromNamedArgs(args[0], args[1], namedArgs)
+			}
+			if err != nil {
+				return err
+			}
+			trx, err = iwalletSDK.CreateTxFromActions([]*rpcpb.Action{action})
+			if err != nil {
+				return err
+			}
+		} else if txFile != "" {
 			if len(args) != 0 {
 				ilog.Warnf("load tx from file %v, will ignore cmd args %v", txFile, args)
 			}
@@ -66,9 +98,9 @@ var callCmd = &cobra.Command{
 		if err := checkSigners(signers); err != nil {
 			return err
 		}
-		trx.Signers = signers
+		trx.Signers = txSigners()
 
-		if len(withSigns) != 0 || len(signKeys) != 0 {
+		if len(trx.Signers) != 0 || len(withSigns) != 0 || len(signKeys) != 0 {
 			ilog.Infof("making multi sig...")
 			err = handleMultiSig(trx, withSigns, signKeys)
 			if err != nil {
@@ -76,16 +108,26 @@ var callCmd = &cobra.Command{
 			}
 		}
 
-		_, err = iwalletSDK.SendTx(trx)
-		return err
+		if estimateOnly || dryRun {
+			return estimateTx(trx)
+		}
+		txHash, err := iwalletSDK.SendTx(trx)
+		if err != nil {
+			return err
+		}
+		return printTxHash(txHash)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(callCmd)
 	callCmd.Flags().StringSliceVarP(&signKeys, "sign_keys", "", []string{}, "optional private key files used for signing, split by comma")
-	callCmd.Flags().StringSliceVarP(&withSigns, "with_signs", "", []string{}, "optional signatures, split by comma")
+	callCmd.Flags().StringSliceVarP(&withSigns, "with_signs", "", []string{}, "optional signature files created by \"iwallet sign-tx\", split by comma")
+	callCmd.Flags().StringArrayVarP(&namedArgs, "arg", "", []string{}, "named parameter of the function as name=value, can be repeated")
+	callCmd.Flags().StringVarP(&argsFile, "args_file", "", "", "load the parameters of the function from this json file, an array of them or an object of them by name")
+	callCmd.Flags().StringVarP(&argsSchema, "schema", "", "", "JSON Schema file the args file is validated against")
 	callCmd.Flags().StringVarP(&txFile, "tx_file", "", "", "load tx from this file")
+	callCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost of the tx without sending it")
 }
 
 var (
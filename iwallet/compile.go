@@ -15,14 +15,27 @@
 package iwallet
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"os/exec"
 	"strings"
 
+	corecontract "github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/iwallet/contract"
 	"github.com/spf13/cobra"
 )
 
+var (
+	compileABI    string
+	compileOutput string
+)
+
+// errNoVM is returned by preprocessContract if iwallet is built without the vm of the node.
+var errNoVM = errors.New("iwallet is built without the contract vm, rebuild it by: make iwallet_vm")
+
 // Generate ABI file.
 func generateABI(codePath string) (string, error) {
 	contractToRun := fmt.Sprintf(`
@@ -45,12 +58,54 @@ func generateABI(codePath string) (string, error) {
 	return codePath + ".abi", nil
 }
 
+// compileResult tells how the contract is stored by the node once published.
+type compileResult struct {
+	CodeSize         int `json:"code_size"`
+	InstrumentedSize int `json:"instrumented_size"`
+	StorageSize      int `json:"storage_size"`
+}
+
+// checkContract runs the checks the node applies to a contract being published, and returns the instrumented code.
+func checkContract(codePath string, abiPath string) (string, *compileResult, error) {
+	code, err := ioutil.ReadFile(codePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read source code file: %v", err)
+	}
+	abi, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read abi file: %v", err)
+	}
+	var info *corecontract.Info
+	if err := json.Unmarshal(abi, &info); err != nil {
+		return "", nil, fmt.Errorf("invalid abi file %v: %v", abiPath, err)
+	}
+	c := &corecontract.Contract{Code: string(code), Info: info}
+	instrumented, err := preprocessContract(c)
+	if err != nil {
+		return "", nil, err
+	}
+	// the node stores the instrumented code together with the init abi it adds
+	c.Code = instrumented
+	c.Info.Abi = append(c.Info.Abi, &corecontract.ABI{Name: "init", Args: []string{}})
+	return instrumented, &compileResult{
+		CodeSize:         len(code),
+		InstrumentedSize: len(instrumented),
+		StorageSize:      len(c.Encode()),
+	}, nil
+}
+
 // compileCmd represents the compile command.
 var compileCmd = &cobra.Command{
-	Use:     "compile codePath",
-	Short:   "Generate contract abi",
-	Long:    `Generate abi from contract javascript code`,
-	Example: `  iwallet compile ./example.js`,
+	Use:   "compile codePath",
+	Short: "Generate contract abi and check the contract",
+	Long: `Generate abi from contract javascript code, and check the contract with the parser, the gas
+	instrumentation and the banned syntax checks the vm of the node applies when publishing, so that a contract
+	which would fail is found before spending gas on publishing it. The abi is generated by node.js unless given
+	by --abi. The size of the instrumented code and of the contract stored on chain, which the publisher pays ram
+	for, are reported, and the instrumented code is saved by --output.
+	The checks need iwallet built with the vm by "make iwallet_vm", or else only the abi is generated.`,
+	Example: `  iwallet compile ./example.js
+  iwallet compile ./example.js --abi ./example.js.abi -o ./example.instrumented.js`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := checkArgsNumber(cmd, args, "codePath"); err != nil {
 			return err
@@ -59,15 +114,49 @@ var compileCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		codePath := args[0]
-		abiPath, err := generateABI(codePath)
+		abiPath := compileABI
+		if abiPath == "" {
+			var err error
+			abiPath, err = generateABI(codePath)
+			if err != nil {
+				return fmt.Errorf("failed to generate abi: %v", err)
+			}
+			if !isMachineOutput() {
+				fmt.Printf("Successfully generated abi file as: %v\n", abiPath)
+			}
+		}
+		instrumented, result, err := checkContract(codePath, abiPath)
+		if err == errNoVM {
+			if compileABI == "" && compileOutput == "" {
+				fmt.Fprintln(os.Stderr, "Skipped checking the contract:", err)
+				return nil
+			}
+			return err
+		}
 		if err != nil {
-			return fmt.Errorf("failed to generate abi: %v", err)
+			return fmt.Errorf("contract rejected by the vm: %v", err)
+		}
+		if compileOutput != "" {
+			if err := ioutil.WriteFile(compileOutput, []byte(instrumented), 0644); err != nil {
+				return fmt.Errorf("failed to save instrumented code: %v", err)
+			}
+		}
+		if isMachineOutput() {
+			return printResult(result)
+		}
+		fmt.Println("The contract passed the checks of the vm")
+		fmt.Printf("Code size: %v bytes\n", result.CodeSize)
+		fmt.Printf("Instrumented code size: %v bytes\n", result.InstrumentedSize)
+		fmt.Printf("Stored contract size: %v bytes, paid for by ram of the publisher\n", result.StorageSize)
+		if compileOutput != "" {
+			fmt.Println("Successfully saved instrumented code as:", compileOutput)
 		}
-		fmt.Printf("Successfully generated abi file as: %v\n", abiPath)
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(compileCmd)
+	compileCmd.Flags().StringVarP(&compileABI, "abi", "", "", "check the contract against this abi file instead of generating one")
+	compileCmd.Flags().StringVarP(&compileOutput, "output", "o", "", "save the code instrumented with gas counting to this file")
 }
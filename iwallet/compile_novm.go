@@ -0,0 +1,11 @@
+// +build !v8
+
+package iwallet
+
+import (
+	"github.com/iost-official/go-iost/core/contract"
+)
+
+func preprocessContract(c *contract.Contract) (string, error) {
+	return "", errNoVM
+}
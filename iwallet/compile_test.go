@@ -0,0 +1,31 @@
+// +build !v8
+
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckContractWithoutVM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iwallet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	code := filepath.Join(dir, "c.js")
+	abi := filepath.Join(dir, "c.js.abi")
+	assert.Nil(t, ioutil.WriteFile(code, []byte("class C { init() {} }\nmodule.exports = C;"), 0600))
+
+	assert.Nil(t, ioutil.WriteFile(abi, []byte("{"), 0600))
+	_, _, err = checkContract(code, abi)
+	assert.Contains(t, err.Error(), "invalid abi file")
+
+	assert.Nil(t, ioutil.WriteFile(abi, []byte(`{"lang": "javascript", "version": "1.0.0", "abi": []}`), 0600))
+	_, _, err = checkContract(code, abi)
+	assert.Equal(t, errNoVM, err)
+	_, _, err = checkContract(filepath.Join(dir, "none.js"), abi)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,12 @@
+// +build v8
+
+package iwallet
+
+import (
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/v8vm"
+)
+
+func preprocessContract(c *contract.Contract) (string, error) {
+	return v8.Preprocess(c)
+}
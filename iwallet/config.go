@@ -0,0 +1,284 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// configEnvPrefix is the prefix of env variables overriding the config file, eg IWALLET_SERVER.
+const configEnvPrefix = "iwallet"
+
+// configKeys are the global flags whose defaults can be set in the config file or by env variables.
+// A flag given on the command line wins over the env variable, which wins over the config file.
+var configKeys = []string{"network", "server", "max_qps", "tls", "ca_cert", "client_cert", "client_key", "chain_id", "account", "remote_signer", "sign_algo", "gas_limit", "gas_ratio", "expiration", "adjust_time", "amount_limit"}
+
+// configErr is an invalid value found when applying the config, reported before running the command.
+var configErr error
+
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+func getConfigFile() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	dir, err := getAccountDir()
+	if err != nil {
+		return "", err
+	}
+	return dir + "/config.yaml", nil
+}
+
+// getReadConfigFile returns the config file to read, which is the legacy $HOME/.iwallet.yaml while the default config
+// file has not been written, and whether it is the legacy one.
+func getReadConfigFile() (string, bool, error) {
+	fileName, err := getConfigFile()
+	if err != nil {
+		return "", false, err
+	}
+	if cfgFile != "" {
+		return fileName, false, nil
+	}
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		return fileName, false, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return fileName, false, nil
+	}
+	legacy := home + "/.iwallet.yaml"
+	if _, err := os.Stat(legacy); err != nil {
+		return fileName, false, nil
+	}
+	return legacy, true, nil
+}
+
+func isConfigKey(key string) bool {
+	for _, k := range configKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// applyConfig sets the flags which are not given on the command line from env variables and the config file.
+func applyConfig(flags *pflag.FlagSet) error {
+	for _, key := range configKeys {
+		f := flags.Lookup(key)
+		if f == nil || f.Changed || !viper.IsSet(key) {
+			continue
+		}
+		if err := f.Value.Set(viper.GetString(key)); err != nil {
+			return fmt.Errorf("invalid config %v: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// parseConfigValue checks the value against the type of the flag, so that the config file keeps typed values.
+func parseConfigValue(key string, value string) (interface{}, error) {
+	if !isConfigKey(key) {
+		return nil, fmt.Errorf("unknown config %v, should be one of %v", key, strings.Join(configKeys, ", "))
+	}
+	var v interface{}
+	var err error
+	switch rootCmd.PersistentFlags().Lookup(key).Value.Type() {
+	case "float64":
+		v, err = strconv.ParseFloat(value, 64)
+	case "int64":
+		v, err = strconv.ParseInt(value, 10, 64)
+	case "uint32":
+		v, err = strconv.ParseUint(value, 10, 32)
+	case "bool":
+		v, err = strconv.ParseBool(value)
+	default:
+		v = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %v for config %v: %v", value, key, err)
+	}
+	if key == "amount_limit" {
+		if _, err := ParseAmountLimit(value); err != nil {
+			return nil, err
+		}
+	}
+	if key == "network" {
+		if _, err := findNetwork(value); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func loadConfigFile(fileName string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		// the legacy config file is read until the config saved replaces it
+		if legacyFile, legacy, _ := getReadConfigFile(); legacy {
+			data, err = ioutil.ReadFile(legacyFile)
+		}
+	}
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %v: %v", fileName, err)
+	}
+	return config, nil
+}
+
+func saveConfigFile(fileName string, config map[string]interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0600)
+}
+
+// configSource tells where the value of the flag comes from.
+func configSource(flags *pflag.FlagSet, key string) string {
+	switch {
+	case flags.Lookup(key).Changed:
+		return "flag"
+	case os.Getenv(strings.ToUpper(configEnvPrefix+"_"+key)) != "":
+		return "env"
+	case network != "" && (key == "server" || key == "chain_id"):
+		return "network " + network
+	case viper.InConfig(key):
+		return "config"
+	default:
+		return "default"
+	}
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Default values of global flags",
+	Long: `Manage default values of global flags, which are saved in ~/.iwallet/config.yaml or the file given by --config
+	Supported keys: ` + strings.Join(configKeys, ", ") + `
+	A flag given on the command line wins over the env variable IWALLET_<KEY>, which wins over the config file`,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set key value",
+	Short: "Set the default value of a global flag",
+	Long:  `Set the default value of a global flag in the config file`,
+	Example: `  iwallet config set server 127.0.0.1:30002
+  iwallet config set account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "key", "value")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		v, err := parseConfigValue(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		fileName, err := getConfigFile()
+		if err != nil {
+			return err
+		}
+		config, err := loadConfigFile(fileName)
+		if err != nil {
+			return err
+		}
+		config[args[0]] = v
+		if err := saveConfigFile(fileName, config); err != nil {
+			return fmt.Errorf("failed to save config: %v", err)
+		}
+		if !isMachineOutput() {
+			fmt.Printf("Successfully set %v to %v in %v\n", args[0], args[1], fileName)
+		}
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:     "get key",
+	Short:   "Show the value of a global flag",
+	Long:    `Show the value of a global flag in effect, taking the command line, env variables and the config file into account`,
+	Example: `  iwallet config get server`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "key"); err != nil {
+			return err
+		}
+		if !isConfigKey(args[0]) {
+			return fmt.Errorf("unknown config %v, should be one of %v", args[0], strings.Join(configKeys, ", "))
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := rootCmd.PersistentFlags()
+		if !isMachineOutput() {
+			fmt.Println(flags.Lookup(args[0]).Value.String())
+			return nil
+		}
+		return printResult(&configEntry{Key: args[0], Value: flags.Lookup(args[0]).Value.String(), Source: configSource(flags, args[0])})
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show all global flags which can be configured",
+	Long:    `Show the values in effect of all global flags which can be configured, and where they come from`,
+	Example: `  iwallet config list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := rootCmd.PersistentFlags()
+		entries := make([]*configEntry, 0, len(configKeys))
+		for _, key := range configKeys {
+			entries = append(entries, &configEntry{Key: key, Value: flags.Lookup(key).Value.String(), Source: configSource(flags, key)})
+		}
+		if isMachineOutput() {
+			return printResult(entries)
+		}
+		if file := viper.ConfigFileUsed(); file != "" {
+			fmt.Println("Config file:", file)
+		}
+		for _, e := range entries {
+			fmt.Printf("%-14v%-30v(%v)\n", e.Key, e.Value, e.Source)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configListCmd)
+}
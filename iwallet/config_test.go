@@ -0,0 +1,91 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigValue(t *testing.T) {
+	v, err := parseConfigValue("gas_ratio", "2.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 2.5, v)
+	v, err = parseConfigValue("chain_id", "1023")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1023), v)
+	v, err = parseConfigValue("server", "localhost:30002")
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:30002", v)
+
+	_, err = parseConfigValue("expiration", "soon")
+	assert.NotNil(t, err)
+	_, err = parseConfigValue("amount_limit", "iost")
+	assert.NotNil(t, err)
+	_, err = parseConfigValue("verbose", "false")
+	assert.NotNil(t, err)
+}
+
+func TestApplyConfig(t *testing.T) {
+	defer viper.Reset()
+	var s string
+	var ratio float64
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&s, "server", "localhost:30002", "")
+	flags.Float64Var(&ratio, "gas_ratio", 1, "")
+	assert.Nil(t, flags.Parse([]string{"--server", "flag:30002"}))
+
+	viper.Set("server", "config:30002")
+	viper.Set("gas_ratio", 2)
+	assert.Nil(t, applyConfig(flags))
+	assert.Equal(t, "flag:30002", s)
+	assert.Equal(t, 2.0, ratio)
+
+	viper.Set("gas_ratio", "high")
+	assert.NotNil(t, applyConfig(flags))
+}
+
+func TestLegacyConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "home")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+	defer viper.Reset()
+	oldServer := server
+	defer func() { server = oldServer }()
+
+	fileName, legacy, err := getReadConfigFile()
+	assert.Nil(t, err)
+	assert.False(t, legacy)
+	assert.Equal(t, filepath.Join(dir, ".iwallet", "config.yaml"), fileName)
+
+	// the legacy config file is read while the new one is missing
+	legacyFile := filepath.Join(dir, ".iwallet.yaml")
+	assert.Nil(t, ioutil.WriteFile(legacyFile, []byte("server: legacy:30002\n"), 0600))
+	fileName, legacy, err = getReadConfigFile()
+	assert.Nil(t, err)
+	assert.True(t, legacy)
+	assert.Equal(t, legacyFile, fileName)
+	initConfig()
+	assert.Nil(t, configErr)
+	assert.Equal(t, "legacy:30002", server)
+
+	// saving the config moves the legacy one to the new file
+	assert.Nil(t, configSetCmd.RunE(configSetCmd, []string{"gas_ratio", "2"}))
+	config, err := loadConfigFile(filepath.Join(dir, ".iwallet", "config.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "legacy:30002", config["server"])
+	assert.Equal(t, 2, config["gas_ratio"])
+	_, legacy, err = getReadConfigFile()
+	assert.Nil(t, err)
+	assert.False(t, legacy)
+}
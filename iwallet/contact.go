@@ -0,0 +1,336 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/spf13/cobra"
+)
+
+const contactPrefix = "@"
+
+var (
+	contactMemo string
+	assumeYes   bool
+)
+
+type contactInfo struct {
+	Account  string `json:"account"`
+	Memo     string `json:"memo,omitempty"`
+	Checksum string `json:"checksum"`
+}
+
+// addressBook maps aliases to accounts. It is saved as contacts.json in the account dir.
+type addressBook map[string]*contactInfo
+
+func getContactsFile() (string, error) {
+	dir, err := getAccountDir()
+	if err != nil {
+		return "", err
+	}
+	return dir + "/contacts.json", nil
+}
+
+func loadAddressBook() (addressBook, error) {
+	fileName, err := getContactsFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return addressBook{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b := addressBook{}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid address book %v: %v", fileName, err)
+	}
+	return b, nil
+}
+
+func (b addressBook) save() error {
+	dir, err := getAccountDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	fileName, err := getContactsFile()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0600)
+}
+
+// accountChecksum is a short fingerprint of an account name, so that a mistyped account is noticed when it is compared.
+func accountChecksum(account string) string {
+	return hex.EncodeToString(common.Sha3([]byte(account)))[:8]
+}
+
+// checkAccountID follows the rules of account.iost.
+func checkAccountID(id string) error {
+	if len(id) < 5 || len(id) > 11 {
+		return fmt.Errorf("invalid account %v, length should be between 5 and 11", id)
+	}
+	if strings.HasPrefix(id, "Contract") {
+		return fmt.Errorf("invalid account %v, should not start with Contract", id)
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_') {
+			return fmt.Errorf("invalid account %v, should only contain a-z, 0-9 and _", id)
+		}
+	}
+	return nil
+}
+
+func checkAlias(alias string) error {
+	if len(alias) < 1 || len(alias) > 32 {
+		return fmt.Errorf("invalid alias %v, length should be between 1 and 32", alias)
+	}
+	for _, c := range alias {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+			return fmt.Errorf("invalid alias %v, should only contain letters, digits, _, - and .", alias)
+		}
+	}
+	return nil
+}
+
+// lookup returns the account of the alias after checking it against the stored checksum.
+func (b addressBook) lookup(alias string) (string, error) {
+	c, ok := b[alias]
+	if !ok {
+		return "", fmt.Errorf("alias %v%v not found in address book", contactPrefix, alias)
+	}
+	if err := checkAccountID(c.Account); err != nil {
+		return "", fmt.Errorf("alias %v%v: %v", contactPrefix, alias, err)
+	}
+	if c.Checksum != accountChecksum(c.Account) {
+		return "", fmt.Errorf("checksum of alias %v%v mismatches its account %v, the address book may have been modified", contactPrefix, alias, c.Account)
+	}
+	return c.Account, nil
+}
+
+// resolveArgs replaces the string arguments of a json array which are known aliases, like "@alice", with their accounts.
+// Arguments which are not json arrays or not known aliases are kept as is.
+func (b addressBook) resolveArgs(args string) (string, error) {
+	var list []interface{}
+	decoder := json.NewDecoder(strings.NewReader(args))
+	decoder.UseNumber()
+	if err := decoder.Decode(&list); err != nil {
+		return args, nil
+	}
+	replaced := false
+	for i, arg := range list {
+		s, ok := arg.(string)
+		if !ok || !strings.HasPrefix(s, contactPrefix) {
+			continue
+		}
+		if _, ok := b[s[len(contactPrefix):]]; !ok {
+			continue
+		}
+		account, err := b.lookup(s[len(contactPrefix):])
+		if err != nil {
+			return "", err
+		}
+		list[i] = account
+		replaced = true
+	}
+	if !replaced {
+		return args, nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(list); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// resolveAccount returns the account of an @alias, or the argument itself if it is not an alias.
+func resolveAccount(s string) (string, error) {
+	if !strings.HasPrefix(s, contactPrefix) {
+		return s, nil
+	}
+	b, err := loadAddressBook()
+	if err != nil {
+		return "", err
+	}
+	return b.lookup(s[len(contactPrefix):])
+}
+
+// resolveActionArgs replaces known aliases in the method parameters of an action.
+func resolveActionArgs(args string) (string, error) {
+	if !strings.Contains(args, contactPrefix) {
+		return args, nil
+	}
+	b, err := loadAddressBook()
+	if err != nil {
+		return "", err
+	}
+	return b.resolveArgs(args)
+}
+
+func confirmChecksum(account string) error {
+	checksum := accountChecksum(account)
+	fmt.Printf("Account %v has checksum %v\n", account, checksum)
+	if assumeYes {
+		return nil
+	}
+	fmt.Print("Type the checksum to confirm: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return err
+	}
+	if strings.TrimSpace(line) != checksum {
+		return fmt.Errorf("checksum mismatch, contact not saved")
+	}
+	return nil
+}
+
+var contactCmd = &cobra.Command{
+	Use:   "contact",
+	Short: "Manage the address book",
+	Long: `Manage the address book saved in ~/.iwallet/contacts.json
+	An alias can be used as @alias instead of an account in transfer and call commands.`,
+}
+
+var contactAddCmd = &cobra.Command{
+	Use:   "add alias account",
+	Short: "Add or update an alias",
+	Long:  `Add or update an alias. If the alias is new or points to another account than before, the checksum of the account has to be confirmed.`,
+	Example: `  iwallet contact add alice user0001
+  iwallet contact add alice user0001 --memo "exchange deposit" --yes`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "alias", "account"); err != nil {
+			return err
+		}
+		if err := checkAlias(strings.TrimPrefix(args[0], contactPrefix)); err != nil {
+			return err
+		}
+		return checkAccountID(args[1])
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		alias, account := strings.TrimPrefix(args[0], contactPrefix), args[1]
+		b, err := loadAddressBook()
+		if err != nil {
+			return err
+		}
+		old, ok := b[alias]
+		if !ok || old.Account != account {
+			if ok {
+				fmt.Printf("Alias %v%v currently points to %v\n", contactPrefix, alias, old.Account)
+			}
+			if err := confirmChecksum(account); err != nil {
+				return err
+			}
+		}
+		b[alias] = &contactInfo{Account: account, Memo: contactMemo, Checksum: accountChecksum(account)}
+		if err := b.save(); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %v%v -> %v\n", contactPrefix, alias, account)
+		return nil
+	},
+}
+
+type contactEntry struct {
+	Alias    string `json:"alias"`
+	Account  string `json:"account"`
+	Memo     string `json:"memo"`
+	Checksum string `json:"checksum"`
+}
+
+var contactListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the address book",
+	Long:    `List the aliases in the address book`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := loadAddressBook()
+		if err != nil {
+			return err
+		}
+		entries := make([]*contactEntry, 0, len(b))
+		for alias, c := range b {
+			entries = append(entries, &contactEntry{Alias: alias, Account: c.Account, Memo: c.Memo, Checksum: c.Checksum})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Alias < entries[j].Alias })
+		if isMachineOutput() {
+			return printResult(entries)
+		}
+		for _, e := range entries {
+			status := ""
+			if e.Checksum != accountChecksum(e.Account) {
+				status = "\tCHECKSUM MISMATCH"
+			}
+			fmt.Printf("%v%v\t%v\t%v\t%v%v\n", contactPrefix, e.Alias, e.Account, e.Checksum, e.Memo, status)
+		}
+		return nil
+	},
+}
+
+var contactRemoveCmd = &cobra.Command{
+	Use:     "remove alias",
+	Aliases: []string{"rm"},
+	Short:   "Remove an alias",
+	Long:    `Remove an alias from the address book`,
+	Example: `  iwallet contact remove alice`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "alias")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		alias := strings.TrimPrefix(args[0], contactPrefix)
+		b, err := loadAddressBook()
+		if err != nil {
+			return err
+		}
+		if _, ok := b[alias]; !ok {
+			return fmt.Errorf("alias %v%v not found in address book", contactPrefix, alias)
+		}
+		delete(b, alias)
+		if err := b.save(); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %v%v\n", contactPrefix, alias)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(contactCmd)
+	contactCmd.AddCommand(contactAddCmd)
+	contactAddCmd.Flags().StringVarP(&contactMemo, "memo", "", "", "memo of the contact")
+	contactAddCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "skip the checksum confirmation")
+	contactCmd.AddCommand(contactListCmd)
+	contactCmd.AddCommand(contactRemoveCmd)
+}
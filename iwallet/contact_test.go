@@ -0,0 +1,35 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressBook(t *testing.T) {
+	b := addressBook{
+		"alice": {Account: "user0001", Checksum: accountChecksum("user0001")},
+		"bob":   {Account: "user0002", Checksum: "00000000"},
+	}
+	account, err := b.lookup("alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "user0001", account)
+	_, err = b.lookup("bob")
+	assert.NotNil(t, err)
+	_, err = b.lookup("carol")
+	assert.NotNil(t, err)
+
+	args, err := b.resolveArgs(`["iost","user0000","@alice","1.5","@unknown <memo>",2]`)
+	assert.Nil(t, err)
+	assert.Equal(t, `["iost","user0000","user0001","1.5","@unknown <memo>",2]`, args)
+	args, err = b.resolveArgs(`["iost", "@carol"]`)
+	assert.Nil(t, err)
+	assert.Equal(t, `["iost", "@carol"]`, args)
+	_, err = b.resolveArgs(`["@bob"]`)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, checkAccountID("user_0001"))
+	assert.NotNil(t, checkAccountID("User0001"))
+	assert.NotNil(t, checkAccountID("usr"))
+	assert.NotNil(t, checkAlias("a b"))
+}
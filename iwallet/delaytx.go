@@ -0,0 +1,139 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// delaytxEntry is a delay transaction waiting to be executed.
+type delaytxEntry struct {
+	TxHash    string `json:"tx_hash" yaml:"tx_hash"`
+	Sent      string `json:"sent" yaml:"sent"`
+	ExecuteAt string `json:"execute_at" yaml:"execute_at"`
+	Action    string `json:"action" yaml:"action"`
+}
+
+func toDelaytxEntry(t *rpcpb.Transaction) *delaytxEntry {
+	actions := make([]string, 0, len(t.Actions))
+	for _, a := range t.Actions {
+		actions = append(actions, a.Contract+"/"+a.ActionName)
+	}
+	return &delaytxEntry{
+		TxHash:    t.Hash,
+		Sent:      time.Unix(0, t.Time).Format("2006-01-02 15:04:05"),
+		ExecuteAt: time.Unix(0, t.Time+t.Delay).Format("2006-01-02 15:04:05"),
+		Action:    strings.Join(actions, ","),
+	}
+}
+
+// checkCancelable makes sure the tx is a delay tx of the account which is not executed yet, since canceling
+// anything else fails on chain after paying for the gas.
+func checkCancelable(t *rpcpb.Transaction, account string, now time.Time) error {
+	if t.Delay <= 0 {
+		return fmt.Errorf("transaction %v is not a delay transaction", t.Hash)
+	}
+	if t.Publisher != account {
+		return fmt.Errorf("transaction %v is published by %v, only the publisher can cancel it", t.Hash, t.Publisher)
+	}
+	if at := time.Unix(0, t.Time+t.Delay); !at.After(now) {
+		return fmt.Errorf("transaction %v is already executed at %v", t.Hash, at.Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
+var delaytxListCmd = &cobra.Command{
+	Use:   "delayed [account]",
+	Short: "List delay transactions waiting to be executed",
+	Long: `List the irreversible delay transactions published by an account which are neither executed nor canceled yet,
+		the first to be executed first`,
+	Example: `  iwallet tx delayed test0
+  iwallet tx delayed --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user := accountName
+		if len(args) > 0 {
+			var err error
+			if user, err = resolveAccount(args[0]); err != nil {
+				return err
+			}
+		}
+		res, err := iwalletSDK.GetDelaytxsByAccount(user)
+		if err != nil {
+			return fmt.Errorf("failed to get delay transactions of %v: %v", user, err)
+		}
+		entries := make([]*delaytxEntry, 0, len(res.Transactions))
+		for _, t := range res.Transactions {
+			entries = append(entries, toDelaytxEntry(t))
+		}
+		if isMachineOutput() {
+			return printResult(entries)
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No delay transactions of %v\n", user)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TX HASH\tSENT\tEXECUTE AT\tACTION")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", e.TxHash, e.Sent, e.ExecuteAt, e.Action)
+		}
+		return w.Flush()
+	},
+}
+
+var delaytxCancelCmd = &cobra.Command{
+	Use:     "cancel-delayed txHash",
+	Short:   "Cancel a delay transaction",
+	Long:    `Cancel a delay transaction before it is executed, which only its publisher can do`,
+	Example: `  iwallet tx cancel-delayed 6AR7... --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "txHash"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := iwalletSDK.GetTxByHash(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %v: %v", args[0], err)
+		}
+		if res.Status == rpcpb.TransactionResponse_PENDING {
+			return fmt.Errorf("transaction %v is still pending, it can be canceled once packed", args[0])
+		}
+		if err := checkCancelable(res.Transaction, txPublisher(), time.Now()); err != nil {
+			return err
+		}
+		return sendAction("system.iost", "cancelDelaytx", args[0])
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(delaytxListCmd)
+	transactionCmd.AddCommand(delaytxCancelCmd)
+	delaytxCancelCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost of canceling without sending the tx")
+}
@@ -0,0 +1,33 @@
+package iwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCancelable(t *testing.T) {
+	now := time.Now()
+	tx := &rpcpb.Transaction{Hash: "hash0", Publisher: "test0", Time: now.Add(-time.Minute).UnixNano(), Delay: int64(time.Hour)}
+	assert.Nil(t, checkCancelable(tx, "test0", now))
+	assert.Contains(t, checkCancelable(tx, "test1", now).Error(), "only the publisher")
+	assert.Contains(t, checkCancelable(tx, "test0", now.Add(time.Hour)).Error(), "already executed")
+
+	tx.Delay = 0
+	assert.Contains(t, checkCancelable(tx, "test0", now).Error(), "not a delay transaction")
+}
+
+func TestToDelaytxEntry(t *testing.T) {
+	sent := time.Date(2019, 1, 2, 3, 4, 5, 0, time.Local)
+	e := toDelaytxEntry(&rpcpb.Transaction{
+		Hash:    "hash0",
+		Time:    sent.UnixNano(),
+		Delay:   int64(90 * time.Minute),
+		Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer"}},
+	})
+	assert.Equal(t, "2019-01-02 03:04:05", e.Sent)
+	assert.Equal(t, "2019-01-02 04:34:05", e.ExecuteAt)
+	assert.Equal(t, "token.iost/transfer", e.Action)
+}
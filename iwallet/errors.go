@@ -0,0 +1,85 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// errorResult is the structured form of a failed command.
+type errorResult struct {
+	Error      string `json:"error"`
+	Code       string `json:"code"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func newErrorResult(err error) *errorResult {
+	code := sdk.CodeOf(err)
+	return &errorResult{Error: err.Error(), Code: string(code), Suggestion: suggestionOf(code, accountName)}
+}
+
+// suggestionOf tells how to fix an error of the code, with the commands filled in for the account if known.
+func suggestionOf(code sdk.ErrorCode, account string) string {
+	if account == "" {
+		account = "ACCOUNT"
+	}
+	switch code {
+	case sdk.ErrNodeUnavailable:
+		return "check that the node is running and reachable, or fail over between servers with: --server host1:30002,host2:30002"
+	case sdk.ErrExecDisabled:
+		return "enable exec_tx in the rpc config of the node, or use another --server"
+	case sdk.ErrInsufficientGas:
+		return fmt.Sprintf("pledge more gas with: iwallet gas pledge AMOUNT --account %v, or lower --gas_limit to the gas the tx needs, see: iwallet estimate", account)
+	case sdk.ErrInsufficientRAM:
+		return fmt.Sprintf("buy more ram with: iwallet ram buy BYTES --account %v", account)
+	case sdk.ErrInsufficientBalance:
+		return fmt.Sprintf("check the balances with: iwallet balance %v --all", account)
+	case sdk.ErrAmountLimit:
+		return `raise the amount limit of the tx with: --amount_limit "iost:AMOUNT|ram:BYTES"`
+	case sdk.ErrBadSignature:
+		return fmt.Sprintf("check the keys against the chain with: iwallet account view %v --on_chain, and the --sign_algo and --chain_id", account)
+	case sdk.ErrNoPermission:
+		return fmt.Sprintf("check the permissions with: iwallet permission list %v, then sign by --sign_permission or --signers", account)
+	case sdk.ErrNoSigner:
+		return "import the key of the account with: iwallet account import NAME KEY, or give its key by --sign_keys or --with_signs"
+	case sdk.ErrTxExpired:
+		return "check the clock of this machine and --tx_time, or let the time of the node be used by --adjust_time"
+	case sdk.ErrDuplicateTx:
+		return "the transaction has been sent already, check it with: iwallet receipt HASH"
+	case sdk.ErrTxPoolFull:
+		return "the node is busy, retry later or use another --server"
+	case sdk.ErrInvalidChainID:
+		return "set --chain_id or --network to the network the node is on, see: iwallet config networks"
+	case sdk.ErrInvalidGasParams:
+		return "set --gas_limit and --gas_ratio within the range given in the error"
+	case sdk.ErrExecutionTimeout:
+		return "the contract ran out of time, check the args or the code of the contract"
+	case sdk.ErrWaitTimeout:
+		return "the transaction may still be packed, check it later with: iwallet receipt HASH --wait, or raise --wait_timeout"
+	case sdk.ErrContractNotFound:
+		return "check the contract id, a contract published by \"iwallet publish\" is named Contract<tx hash>"
+	case sdk.ErrTokenNotFound:
+		return "check the token symbol with: iwallet token info SYMBOL"
+	case sdk.ErrTxNotFound:
+		return "the node does not know the transaction, check the hash, or send it again once expired if it was dropped"
+	case sdk.ErrAccountNotFound:
+		return "check the account name, or create the account with: iwallet account create NAME"
+	case sdk.ErrWrongParameter:
+		return "check the args against the abi of the contract"
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package iwallet
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+	cases := map[string]sdk.ErrorCode{
+		"send tx error rpc error: code = Unknown desc = gas not enough: user alice has 1 < 10000": sdk.ErrInsufficientGas,
+		"send tx error rpc error: code = Unknown desc = VerifyError signer error":                 sdk.ErrBadSignature,
+		"send tx error rpc error: code = Unknown desc = TimeError":                                sdk.ErrTxExpired,
+		"send tx error rpc error: code = Unavailable desc = connection refused":                   sdk.ErrNodeUnavailable,
+		"running action Action{...} error: pay ram failed. no permission. need bob@active":        sdk.ErrNoPermission,
+		"running action Action{...} error: pay ram failed. id: alice need 100, actual 10":         sdk.ErrInsufficientRAM,
+		"running action Action{...} error: token iost exceed amountLimit in tx. need 10":          sdk.ErrAmountLimit,
+		"failed to load account: something else":                                                  sdk.ErrUnknown,
+	}
+	for msg, code := range cases {
+		assert.Equal(t, code, sdk.CodeOf(errors.New(msg)), msg)
+	}
+	assert.Equal(t, sdk.ErrorCode(""), sdk.CodeOf(nil))
+
+	receipt := &rpcpb.TxReceipt{StatusCode: rpcpb.TxReceipt_RUNTIME_ERROR, Message: "running action Action{...} error: Error: invalid vote"}
+	err := fmt.Errorf("transaction would fail: %w", &sdk.ReceiptError{Receipt: receipt})
+	assert.Equal(t, sdk.ErrRuntime, sdk.CodeOf(err))
+	receipt.Message = "out of gas"
+	assert.Equal(t, sdk.ErrInsufficientGas, sdk.CodeOf(err))
+}
+
+func TestNewErrorResult(t *testing.T) {
+	accountName = "alice"
+	defer func() { accountName = "" }()
+	e := newErrorResult(errors.New("gas not enough: user alice has 1 < 10000"))
+	assert.Equal(t, "E_INSUFFICIENT_GAS", e.Code)
+	assert.Contains(t, e.Suggestion, "iwallet gas pledge AMOUNT --account alice")
+
+	e = newErrorResult(errors.New("failed"))
+	assert.Equal(t, "E_UNKNOWN", e.Code)
+	assert.Equal(t, "", e.Suggestion)
+}
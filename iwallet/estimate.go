@@ -0,0 +1,219 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+var (
+	estimateOnly bool
+	dryRun       bool
+)
+
+type txEstimate struct {
+	Status        string           `json:"status"`
+	Message       string           `json:"message"`
+	Returns       []string         `json:"returns"`
+	GasUsage      float64          `json:"gas_usage"`
+	GasLimit      float64          `json:"gas_limit"`
+	RAMUsage      map[string]int64 `json:"ram_usage"`
+	CurrentGas    float64          `json:"current_gas"`
+	AvailableRAM  int64            `json:"available_ram"`
+	GasSufficient bool             `json:"gas_sufficient"`
+	RAMSufficient bool             `json:"ram_sufficient"`
+}
+
+func newTxEstimate(trx *rpcpb.TransactionRequest, receipt *rpcpb.TxReceipt, acc *rpcpb.Account) *txEstimate {
+	e := &txEstimate{
+		Status:   receipt.StatusCode.String(),
+		Message:  receipt.Message,
+		Returns:  receipt.Returns,
+		GasUsage: receipt.GasUsage,
+		GasLimit: trx.GasLimit,
+		RAMUsage: receipt.RamUsage,
+	}
+	if e.RAMUsage == nil {
+		e.RAMUsage = make(map[string]int64)
+	}
+	if acc.GasInfo != nil {
+		e.CurrentGas = acc.GasInfo.CurrentTotal
+	}
+	if acc.RamInfo != nil {
+		e.AvailableRAM = acc.RamInfo.Available
+	}
+	// The node only accepts a tx if the publisher has more gas than the gas limit.
+	e.GasSufficient = e.CurrentGas >= e.GasUsage && e.CurrentGas >= e.GasLimit && e.GasLimit >= e.GasUsage
+	e.RAMSufficient = e.RAMUsage[acc.Name] <= e.AvailableRAM
+	return e
+}
+
+func printTxEstimate(e *txEstimate) {
+	fmt.Printf("Execution status: %v %v\n", e.Status, e.Message)
+	if len(e.Returns) != 0 {
+		fmt.Println("Returns:", e.Returns)
+	}
+	fmt.Printf("Estimated gas usage: %v (gas limit %v, current gas %v)\n", e.GasUsage, e.GasLimit, e.CurrentGas)
+	names := make([]string, 0, len(e.RAMUsage))
+	for name := range e.RAMUsage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("Estimated ram usage of %v: %+d bytes\n", name, e.RAMUsage[name])
+	}
+	fmt.Printf("Available ram of %v: %v bytes\n", txPublisher(), e.AvailableRAM)
+	if !e.GasSufficient {
+		switch {
+		case e.GasLimit < e.GasUsage:
+			fmt.Println("Gas is NOT sufficient: --gas_limit should be at least", e.GasUsage)
+		case e.CurrentGas < e.GasLimit && e.CurrentGas >= e.GasUsage:
+			fmt.Printf("Gas is NOT sufficient: current gas is less than the gas limit, lower --gas_limit to between %v and %v\n", e.GasUsage, e.CurrentGas)
+		default:
+			fmt.Println("Gas is NOT sufficient: pledge more iost for gas")
+		}
+	} else {
+		fmt.Println("Gas is sufficient")
+	}
+	if !e.RAMSufficient {
+		fmt.Println("Ram is NOT sufficient: buy more ram")
+	} else {
+		fmt.Println("Ram is sufficient")
+	}
+}
+
+// execTx executes the tx on the node without broadcasting it. The account should already be loaded.
+func execTx(trx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, *txEstimate, error) {
+	if err := iwalletSDK.Connect(); err != nil {
+		return nil, nil, err
+	}
+	defer iwalletSDK.CloseConn()
+	receipt, err := iwalletSDK.ExecTx(trx)
+	if err != nil {
+		return nil, nil, err
+	}
+	acc, err := iwalletSDK.GetAccountInfo(txPublisher())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get account info: %v", err)
+	}
+	return receipt, newTxEstimate(trx, receipt, acc), nil
+}
+
+// estimateTx executes the tx on the node as a dry run and prints its gas and ram costs. The account should already be loaded.
+func estimateTx(trx *rpcpb.TransactionRequest) error {
+	if dryRun {
+		return dryRunTx(trx)
+	}
+	receipt, e, err := execTx(trx)
+	if err != nil {
+		return err
+	}
+	if isMachineOutput() {
+		if err := printResult(e); err != nil {
+			return err
+		}
+	} else {
+		printTxEstimate(e)
+	}
+	return checkExecStatus(receipt)
+}
+
+// dryRunTx signs and executes the tx on the node, printing its whole receipt besides the costs, and never sends it.
+func dryRunTx(trx *rpcpb.TransactionRequest) error {
+	receipt, e, err := execTx(trx)
+	if err != nil {
+		return err
+	}
+	if isMachineOutput() {
+		r, err := toGeneric(receipt)
+		if err != nil {
+			return err
+		}
+		if err := printResult(map[string]interface{}{"receipt": r, "estimate": e}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("Dry run, the transaction is not sent. Receipt:")
+		fmt.Println(sdk.MarshalTextString(receipt))
+		printTxEstimate(e)
+	}
+	return checkExecStatus(receipt)
+}
+
+func checkExecStatus(receipt *rpcpb.TxReceipt) error {
+	if receipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
+		return fmt.Errorf("transaction would fail: %w", &sdk.ReceiptError{Receipt: receipt})
+	}
+	return nil
+}
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate [ACTION]...",
+	Short: "Estimate the gas and ram cost of a transaction",
+	Long: `Estimate the gas and ram cost of a transaction by executing it on the node without broadcasting, and check whether the gas and ram of the account are sufficient
+	The node should enable exec_tx in its rpc config.
+	Would accept arguments as call actions or load transaction request directly from given file (which could be generated by "save" command).`,
+	Example: `  iwallet estimate "token.iost" "transfer" '["iost","user0001","user0002","123.45",""]' --account user0001
+  iwallet estimate --tx_file tx.json --account user0001`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx := &rpcpb.TransactionRequest{}
+		if txFile != "" {
+			if len(args) != 0 {
+				ilog.Warnf("load tx from file %v, will ignore cmd args %v", txFile, args)
+			}
+			err := sdk.LoadProtoStructFromJSONFile(txFile, trx)
+			if err != nil {
+				return err
+			}
+		} else {
+			actions, err := actionsFromFlags(args)
+			if err != nil {
+				return err
+			}
+			trx, err = iwalletSDK.CreateTxFromActions(actions)
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkSigners(signers); err != nil {
+			return err
+		}
+		trx.Signers = txSigners()
+		err := InitAccount()
+		if err != nil {
+			return fmt.Errorf("failed to load account: %v", err)
+		}
+		if len(trx.Signers) != 0 {
+			if trx.Signatures, err = signWithLocalAccounts(trx); err != nil {
+				return err
+			}
+		}
+		return estimateTx(trx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(estimateCmd)
+	estimateCmd.Flags().StringVarP(&txFile, "tx_file", "", "", "load tx from this file")
+}
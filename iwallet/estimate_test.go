@@ -0,0 +1,34 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTxEstimate(t *testing.T) {
+	trx := &rpcpb.TransactionRequest{GasLimit: 100000}
+	receipt := &rpcpb.TxReceipt{GasUsage: 30000, RamUsage: map[string]int64{"user0001": 300}}
+	acc := &rpcpb.Account{
+		Name:    "user0001",
+		GasInfo: &rpcpb.Account_GasInfo{CurrentTotal: 200000},
+		RamInfo: &rpcpb.Account_RAMInfo{Available: 1000},
+	}
+	e := newTxEstimate(trx, receipt, acc)
+	assert.Equal(t, "SUCCESS", e.Status)
+	assert.True(t, e.GasSufficient)
+	assert.True(t, e.RAMSufficient)
+
+	acc.GasInfo.CurrentTotal = 50000
+	acc.RamInfo.Available = 100
+	e = newTxEstimate(trx, receipt, acc)
+	assert.False(t, e.GasSufficient)
+	assert.False(t, e.RAMSufficient)
+}
+
+func TestCheckExecStatus(t *testing.T) {
+	assert.Nil(t, checkExecStatus(&rpcpb.TxReceipt{StatusCode: rpcpb.TxReceipt_SUCCESS}))
+	err := checkExecStatus(&rpcpb.TxReceipt{StatusCode: rpcpb.TxReceipt_BALANCE_NOT_ENOUGH, Message: "balance not enough"})
+	assert.Equal(t, "transaction would fail: balance not enough", err.Error())
+}
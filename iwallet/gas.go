@@ -0,0 +1,185 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// The pledge rules of gas.iost, see vm/database/gas_handler.go and vm/native/gas.go.
+const (
+	gasMinPledge           = 1.0
+	gasImmediatePerIOST    = 100000.0
+	gasLimitPerIOST        = 300000.0
+	gasFulfillSeconds      = 2 * 24 * 3600
+	gasUnpledgeFreezeHours = 3 * 24
+)
+
+// gasPledgeRate is what pledging one IOST brings.
+type gasPledgeRate struct {
+	ImmediateGas  float64 `json:"immediate_gas"`
+	GasLimit      float64 `json:"gas_limit"`
+	IncreaseSpeed float64 `json:"increase_speed"`
+}
+
+var perIOSTPledgeRate = gasPledgeRate{
+	ImmediateGas:  gasImmediatePerIOST,
+	GasLimit:      gasLimitPerIOST,
+	IncreaseSpeed: (gasLimitPerIOST - gasImmediatePerIOST) / gasFulfillSeconds,
+}
+
+func (r gasPledgeRate) times(amount float64) gasPledgeRate {
+	return gasPledgeRate{ImmediateGas: r.ImmediateGas * amount, GasLimit: r.GasLimit * amount, IncreaseSpeed: r.IncreaseSpeed * amount}
+}
+
+type gasSummary struct {
+	Account        string                 `json:"account"`
+	GasInfo        *rpcpb.Account_GasInfo `json:"gas_info"`
+	LowestGasRatio float64                `json:"lowest_gas_ratio"`
+	MedianGasRatio float64                `json:"median_gas_ratio"`
+	PledgeRate     gasPledgeRate          `json:"pledge_rate_per_iost"`
+}
+
+func checkPledgeAmount(cmd *cobra.Command, args []string) error {
+	if err := checkArgsNumber(cmd, args, "amount"); err != nil {
+		return err
+	}
+	if err := checkAmount(cmd, args[0], "amount"); err != nil {
+		return err
+	}
+	number, _, _ := splitAmount(args[0], "iost")
+	if amount, _ := strconv.ParseFloat(number, 64); amount < gasMinPledge {
+		return fmt.Errorf("one must (un)pledge at least %v iost", gasMinPledge)
+	}
+	return checkAccount(cmd)
+}
+
+// printPledgePreview shows the gas change of the gas user together with its current gas, which is fetched from the node.
+func printPledgePreview(action string, amount float64, user string) error {
+	acc, err := iwalletSDK.GetAccountInfo(user)
+	if err != nil {
+		return fmt.Errorf("failed to get gas info of %v: %v", user, err)
+	}
+	change := perIOSTPledgeRate.times(amount)
+	fmt.Printf("Gas of %v: %.2f now, limit %.2f, increasing %.2f per second\n", user, acc.GasInfo.CurrentTotal, acc.GasInfo.Limit, acc.GasInfo.IncreaseSpeed)
+	if action == "pledge" {
+		fmt.Printf("Pledging %v iost gives %.2f gas at once, raises the limit by %.2f and the increase speed by %.2f per second\n",
+			amount, change.ImmediateGas, change.GasLimit, change.IncreaseSpeed)
+	} else {
+		fmt.Printf("Unpledging %v iost lowers the limit by %.2f and the increase speed by %.2f per second, the iost is frozen for %v hours\n",
+			amount, change.GasLimit, change.IncreaseSpeed, gasUnpledgeFreezeHours)
+	}
+	return nil
+}
+
+func sendPledge(action string, amount string) error {
+	if gasUser == "" {
+		gasUser = accountName
+	}
+	amount, err := amountOf(amount, "iost")
+	if err != nil {
+		return err
+	}
+	if !isMachineOutput() {
+		f, _ := strconv.ParseFloat(amount, 64)
+		if err := printPledgePreview(action, f, gasUser); err != nil {
+			return err
+		}
+	}
+	return sendAction("gas.iost", action, accountName, gasUser, amount)
+}
+
+var gasCmd = &cobra.Command{
+	Use:   "gas",
+	Short: "Gas management",
+	Long:  `Pledge IOST to gas.iost for gas, and show gas rates of the node`,
+}
+
+var gasPledgeCmd = &cobra.Command{
+	Use:   "pledge amount",
+	Short: pledgeCmd.Short,
+	Long:  `Pledge IOST to obtain gas, after showing how much gas it brings`,
+	Example: `  iwallet gas pledge 100 --account test0
+  iwallet gas pledge 100 --account test0 --gas_user test1`,
+	Args: checkPledgeAmount,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sendPledge("pledge", args[0])
+	},
+}
+
+var gasUnpledgeCmd = &cobra.Command{
+	Use:   "unpledge amount",
+	Short: unpledgeCmd.Short,
+	Long:  `Undo pledge and get back the IOST pledged earlier, after showing how much gas is given up`,
+	Example: `  iwallet gas unpledge 100 --account test0
+  iwallet gas unpledge 100 --account test0 --gas_user test1`,
+	Args: checkPledgeAmount,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sendPledge("unpledge", args[0])
+	},
+}
+
+var gasInfoCmd = &cobra.Command{
+	Use:   "info [account]",
+	Short: "Show gas information",
+	Long:  `Show the gas of the account, the gas ratios in the head block and the gas brought by pledging one IOST`,
+	Example: `  iwallet gas info test0
+  iwallet gas info --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user := accountName
+		if len(args) > 0 {
+			var err error
+			if user, err = resolveAccount(args[0]); err != nil {
+				return err
+			}
+		}
+		acc, err := iwalletSDK.GetAccountInfo(user)
+		if err != nil {
+			return fmt.Errorf("failed to get gas info of %v: %v", user, err)
+		}
+		ratio, err := iwalletSDK.GetGasRatio()
+		if err != nil {
+			return fmt.Errorf("failed to get gas ratio: %v", err)
+		}
+		return printResult(&gasSummary{
+			Account:        user,
+			GasInfo:        acc.GasInfo,
+			LowestGasRatio: ratio.LowestGasRatio,
+			MedianGasRatio: ratio.MedianGasRatio,
+			PledgeRate:     perIOSTPledgeRate,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gasCmd)
+	gasCmd.AddCommand(gasPledgeCmd)
+	gasPledgeCmd.Flags().StringVarP(&gasUser, "gas_user", "", "", "gas user that pledge IOST for (default is pledger himself/herself)")
+	gasPledgeCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost without sending the tx")
+	gasCmd.AddCommand(gasUnpledgeCmd)
+	gasUnpledgeCmd.Flags().StringVarP(&gasUser, "gas_user", "", "", "gas user that earlier pledge for (default is pledger himself/herself)")
+	gasUnpledgeCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost without sending the tx")
+	gasCmd.AddCommand(gasInfoCmd)
+}
@@ -0,0 +1,159 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit  int32
+	historyOffset int32
+	historyToken  string
+)
+
+// historyEntry is one transaction in the history of an account.
+type historyEntry struct {
+	Time   string `json:"time"`
+	TxHash string `json:"tx_hash"`
+	Action string `json:"action"`
+	In     string `json:"in"`
+	Out    string `json:"out"`
+	Status string `json:"status"`
+}
+
+// tokenFlow sums up what the account received and sent of the token in the transfers of the receipt.
+func tokenFlow(r *rpcpb.TxReceipt, account string, token string) (in float64, out float64) {
+	if r == nil {
+		return 0, 0
+	}
+	for _, receipt := range r.Receipts {
+		if receipt.FuncName != "token.iost/transfer" && receipt.FuncName != "token.iost/transferFreeze" {
+			continue
+		}
+		var params []interface{}
+		if err := json.Unmarshal([]byte(receipt.Content), &params); err != nil || len(params) < 4 {
+			continue
+		}
+		if fmt.Sprint(params[0]) != token {
+			continue
+		}
+		amount, err := strconv.ParseFloat(fmt.Sprint(params[3]), 64)
+		if err != nil {
+			continue
+		}
+		if params[2] == account {
+			in += amount
+		}
+		if params[1] == account {
+			out += amount
+		}
+	}
+	return in, out
+}
+
+func formatFlow(amount float64, token string) string {
+	if amount == 0 {
+		return ""
+	}
+	// token amounts have at most 8 decimals, the rest is float noise from summing
+	return strconv.FormatFloat(math.Round(amount*1e8)/1e8, 'f', -1, 64) + " " + token
+}
+
+func toHistoryEntry(t *rpcpb.Transaction, account string, token string) *historyEntry {
+	actions := make([]string, 0, len(t.Actions))
+	for _, a := range t.Actions {
+		actions = append(actions, a.Contract+"/"+a.ActionName)
+	}
+	e := &historyEntry{
+		Time:   time.Unix(0, t.Time).Format("2006-01-02 15:04:05"),
+		TxHash: t.Hash,
+		Action: strings.Join(actions, ","),
+	}
+	if t.TxReceipt != nil {
+		e.Status = t.TxReceipt.StatusCode.String()
+		in, out := tokenFlow(t.TxReceipt, account, token)
+		e.In, e.Out = formatFlow(in, token), formatFlow(out, token)
+	}
+	return e
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [account]",
+	Short: "Show recent transactions of an account",
+	Long: `Show recent irreversible transactions published by an account or transferring iost from or to it, newest first
+		The amounts received and sent are shown for the token given by --token
+		Only blocks pushed by a node with the account index are covered, older transactions are not listed`,
+	Example: `  iwallet history test0
+  iwallet history test0 --limit 10 --offset 10
+  iwallet history --account test0 --token iost`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user := accountName
+		if len(args) > 0 {
+			var err error
+			if user, err = resolveAccount(args[0]); err != nil {
+				return err
+			}
+		}
+		res, err := iwalletSDK.GetTxsByAccount(user, historyOffset, historyLimit)
+		if err != nil {
+			return fmt.Errorf("failed to get history of %v: %v", user, err)
+		}
+		entries := make([]*historyEntry, 0, len(res.Transactions))
+		for _, t := range res.Transactions {
+			entries = append(entries, toHistoryEntry(t, user, historyToken))
+		}
+		if isMachineOutput() {
+			return printResult(entries)
+		}
+		for _, e := range entries {
+			var flow []string
+			if e.In != "" {
+				flow = append(flow, "+"+e.In)
+			}
+			if e.Out != "" {
+				flow = append(flow, "-"+e.Out)
+			}
+			fmt.Printf("%v\t%v\t%v\t%v\t%v\n", e.Time, e.TxHash, e.Action, strings.Join(flow, " "), e.Status)
+		}
+		if res.HasMore {
+			fmt.Printf("More transactions with --offset %v\n", historyOffset+int32(len(entries)))
+		} else if res.IndexStart > 0 {
+			fmt.Printf("Transactions of the blocks before %v are not indexed by the node\n", res.IndexStart)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().Int32VarP(&historyLimit, "limit", "", 50, "max count of transactions to show, at most 100")
+	historyCmd.Flags().Int32VarP(&historyOffset, "offset", "", 0, "count of the newest transactions to skip, at most 10000")
+	historyCmd.Flags().StringVarP(&historyToken, "token", "", "iost", "token whose amounts received and sent are shown")
+}
@@ -0,0 +1,44 @@
+package iwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHistoryEntry(t *testing.T) {
+	now := time.Now()
+	tx := &rpcpb.Transaction{
+		Hash:    "hash0",
+		Time:    now.UnixNano(),
+		Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer"}, {Contract: "ram.iost", ActionName: "buy"}},
+		TxReceipt: &rpcpb.TxReceipt{
+			StatusCode: rpcpb.TxReceipt_SUCCESS,
+			Receipts: []*rpcpb.TxReceipt_Receipt{
+				{FuncName: "token.iost/transfer", Content: `["iost","test0","test1","0.1",""]`},
+				{FuncName: "token.iost/transfer", Content: `["iost","test0","test1","0.2",""]`},
+				{FuncName: "token.iost/transfer", Content: `["iost","test1","test0","5",""]`},
+				{FuncName: "token.iost/transfer", Content: `["abc","test0","test1","7",""]`},
+				{FuncName: "token.iost/transferFreeze", Content: `["iost","test2","test0","1",1000,""]`},
+				{FuncName: "ram.iost/buy", Content: `["test0","test0",100]`},
+			},
+		},
+	}
+
+	e := toHistoryEntry(tx, "test0", "iost")
+	assert.Equal(t, now.Format("2006-01-02 15:04:05"), e.Time)
+	assert.Equal(t, "token.iost/transfer,ram.iost/buy", e.Action)
+	assert.Equal(t, "6 iost", e.In)
+	assert.Equal(t, "0.3 iost", e.Out)
+	assert.Equal(t, "SUCCESS", e.Status)
+
+	e = toHistoryEntry(tx, "test0", "abc")
+	assert.Equal(t, "", e.In)
+	assert.Equal(t, "7 abc", e.Out)
+
+	e = toHistoryEntry(tx, "test3", "iost")
+	assert.Equal(t, "", e.In)
+	assert.Equal(t, "", e.Out)
+}
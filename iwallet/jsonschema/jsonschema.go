@@ -0,0 +1,477 @@
+// Package jsonschema validates json values against a JSON Schema, supporting the validation keywords which describe
+// types, required fields and ranges. Unknown keywords such as format are ignored, as the specification allows.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a parsed schema document.
+type Schema struct {
+	root     interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+// Error is a value not matching the schema, at the json pointer Path of the value.
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + e.Message
+}
+
+// Errors are all the mismatches found in a value.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Decode reads a json value keeping numbers exact, as Validate expects.
+func Decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the json value")
+	}
+	return v, nil
+}
+
+// Parse reads a schema document and checks the patterns in it.
+func Parse(data []byte) (*Schema, error) {
+	root, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	s := &Schema{root: root, patterns: make(map[string]*regexp.Regexp)}
+	if err := s.compile(root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return s, nil
+}
+
+func (s *Schema) compile(v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if p, ok := v["pattern"].(string); ok {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %v: %v", p, err)
+			}
+			s.patterns[p] = re
+		}
+		for _, sub := range v {
+			if err := s.compile(sub); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, sub := range v {
+			if err := s.compile(sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks a value decoded by Decode, returning Errors with every mismatch.
+func (s *Schema) Validate(v interface{}) error {
+	var errs Errors
+	s.validate(s.root, v, "", &errs, 0)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// maxRefDepth stops schemas referring to themselves without consuming the value.
+const maxRefDepth = 64
+
+func (s *Schema) validate(schema interface{}, v interface{}, path string, errs *Errors, depth int) {
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, &Error{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			fail("no value is allowed")
+		}
+		return
+	case map[string]interface{}:
+		s.validateObject(schema, v, path, errs, depth, fail)
+	}
+}
+
+func (s *Schema) validateObject(schema map[string]interface{}, v interface{}, path string, errs *Errors, depth int,
+	fail func(string, ...interface{})) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err != nil {
+			fail("%v", err)
+			return
+		}
+		if depth >= maxRefDepth {
+			fail("$ref %v is nested too deeply", ref)
+			return
+		}
+		s.validate(target, v, path, errs, depth+1)
+		return
+	}
+
+	if t, ok := schema["type"]; ok && !matchType(t, v) {
+		fail("%v is %v, should be %v", describe(v), typeOf(v), typeNames(t))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if equal(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values := make([]string, 0, len(enum))
+			for _, e := range enum {
+				values = append(values, describe(e))
+			}
+			fail("%v is not one of %v", describe(v), strings.Join(values, ", "))
+		}
+	}
+	if c, ok := schema["const"]; ok && !equal(c, v) {
+		fail("%v should be %v", describe(v), describe(c))
+	}
+
+	switch v := v.(type) {
+	case json.Number:
+		s.validateNumber(schema, v, fail)
+	case string:
+		s.validateString(schema, v, fail)
+	case []interface{}:
+		s.validateArray(schema, v, path, errs, depth, fail)
+	case map[string]interface{}:
+		s.validateProperties(schema, v, path, errs, depth, fail)
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			s.validate(sub, v, path, errs, depth)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		if s.countMatches(anyOf, v, path, depth) == 0 {
+			fail("%v matches none of anyOf", describe(v))
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		if n := s.countMatches(oneOf, v, path, depth); n != 1 {
+			fail("%v matches %v of oneOf, should match exactly one", describe(v), n)
+		}
+	}
+	if not, ok := schema["not"]; ok && s.countMatches([]interface{}{not}, v, path, depth) == 1 {
+		fail("%v should not match the schema of not", describe(v))
+	}
+}
+
+func (s *Schema) countMatches(schemas []interface{}, v interface{}, path string, depth int) int {
+	n := 0
+	for _, sub := range schemas {
+		var subErrs Errors
+		s.validate(sub, v, path, &subErrs, depth)
+		if len(subErrs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Schema) validateNumber(schema map[string]interface{}, v json.Number, fail func(string, ...interface{})) {
+	x, _ := new(big.Rat).SetString(v.String())
+	limit := func(key string) (*big.Rat, bool) {
+		n, ok := schema[key].(json.Number)
+		if !ok {
+			return nil, false
+		}
+		r, ok := new(big.Rat).SetString(n.String())
+		return r, ok
+	}
+	// draft 4 gives exclusiveMinimum and exclusiveMaximum as booleans modifying minimum and maximum
+	exclusiveMin, _ := schema["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := schema["exclusiveMaximum"].(bool)
+	if min, ok := limit("minimum"); ok {
+		if exclusiveMin && x.Cmp(min) <= 0 {
+			fail("%v should be greater than %v", v, min.RatString())
+		} else if x.Cmp(min) < 0 {
+			fail("%v is less than minimum %v", v, min.RatString())
+		}
+	}
+	if max, ok := limit("maximum"); ok {
+		if exclusiveMax && x.Cmp(max) >= 0 {
+			fail("%v should be less than %v", v, max.RatString())
+		} else if x.Cmp(max) > 0 {
+			fail("%v is greater than maximum %v", v, max.RatString())
+		}
+	}
+	if min, ok := limit("exclusiveMinimum"); ok && x.Cmp(min) <= 0 {
+		fail("%v should be greater than %v", v, min.RatString())
+	}
+	if max, ok := limit("exclusiveMaximum"); ok && x.Cmp(max) >= 0 {
+		fail("%v should be less than %v", v, max.RatString())
+	}
+	if m, ok := limit("multipleOf"); ok && m.Sign() > 0 {
+		if !new(big.Rat).Quo(x, m).IsInt() {
+			fail("%v is not a multiple of %v", v, m.RatString())
+		}
+	}
+}
+
+func (s *Schema) validateString(schema map[string]interface{}, v string, fail func(string, ...interface{})) {
+	n := int64(utf8.RuneCountInString(v))
+	if min, ok := intKeyword(schema, "minLength"); ok && n < min {
+		fail("%v is shorter than %v characters", describe(v), min)
+	}
+	if max, ok := intKeyword(schema, "maxLength"); ok && n > max {
+		fail("%v is longer than %v characters", describe(v), max)
+	}
+	if p, ok := schema["pattern"].(string); ok && !s.patterns[p].MatchString(v) {
+		fail("%v does not match pattern %v", describe(v), p)
+	}
+}
+
+func (s *Schema) validateArray(schema map[string]interface{}, v []interface{}, path string, errs *Errors, depth int,
+	fail func(string, ...interface{})) {
+	n := int64(len(v))
+	if min, ok := intKeyword(schema, "minItems"); ok && n < min {
+		fail("has %v items, should have at least %v", n, min)
+	}
+	if max, ok := intKeyword(schema, "maxItems"); ok && n > max {
+		fail("has %v items, should have at most %v", n, max)
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if equal(v[i], v[j]) {
+					fail("items %v and %v are equal, should be unique", i, j)
+				}
+			}
+		}
+	}
+	switch items := schema["items"].(type) {
+	case []interface{}:
+		// a tuple, checking the items by position
+		for i, item := range v {
+			if i < len(items) {
+				s.validate(items[i], item, path+"/"+strconv.Itoa(i), errs, depth)
+			} else if additional, ok := schema["additionalItems"]; ok {
+				s.validate(additional, item, path+"/"+strconv.Itoa(i), errs, depth)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range v {
+			s.validate(items, item, path+"/"+strconv.Itoa(i), errs, depth)
+		}
+	}
+}
+
+func (s *Schema) validateProperties(schema map[string]interface{}, v map[string]interface{}, path string, errs *Errors,
+	depth int, fail func(string, ...interface{})) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := v[name]; !ok {
+				fail("missing required field %v", name)
+			}
+		}
+	}
+	n := int64(len(v))
+	if min, ok := intKeyword(schema, "minProperties"); ok && n < min {
+		fail("has %v fields, should have at least %v", n, min)
+	}
+	if max, ok := intKeyword(schema, "maxProperties"); ok && n > max {
+		fail("has %v fields, should have at most %v", n, max)
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sub := path + "/" + escapePointer(name)
+		if p, ok := properties[name]; ok {
+			s.validate(p, v[name], sub, errs, depth)
+		} else if hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				*errs = append(*errs, &Error{Path: path, Message: fmt.Sprintf("unknown field %v", name)})
+				continue
+			}
+			s.validate(additional, v[name], sub, errs, depth)
+		}
+	}
+}
+
+// resolve finds the schema of a reference into the document, such as #/definitions/amount.
+func (s *Schema) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("$ref %v is not supported, only references into the schema are", ref)
+	}
+	target := s.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch t := target.(type) {
+		case map[string]interface{}:
+			target = t[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("$ref %v not found", ref)
+			}
+			target = t[i]
+		default:
+			target = nil
+		}
+		if target == nil {
+			return nil, fmt.Errorf("$ref %v not found", ref)
+		}
+	}
+	return target, nil
+}
+
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+func intKeyword(schema map[string]interface{}, key string) (int64, bool) {
+	n, ok := schema[key].(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return i, err == nil
+}
+
+func typeOf(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		if r, ok := new(big.Rat).SetString(v.String()); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func matchType(t interface{}, v interface{}) bool {
+	actual := typeOf(v)
+	match := func(name interface{}) bool {
+		return name == actual || (name == "number" && actual == "integer")
+	}
+	if list, ok := t.([]interface{}); ok {
+		for _, name := range list {
+			if match(name) {
+				return true
+			}
+		}
+		return false
+	}
+	return match(t)
+}
+
+func typeNames(t interface{}) string {
+	if list, ok := t.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, name := range list {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// equal compares json values, numbers by their value.
+func equal(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		n, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, _ := new(big.Rat).SetString(a.String())
+		y, _ := new(big.Rat).SetString(n.String())
+		return x != nil && y != nil && x.Cmp(y) == 0
+	case []interface{}:
+		l, ok := b.([]interface{})
+		if !ok || len(a) != len(l) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], l[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		m, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(m) {
+			return false
+		}
+		for k, v := range a {
+			w, ok := m[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// describe prints a value shortly for the messages.
+func describe(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(data) > 40 {
+		return string(data[:37]) + "..."
+	}
+	return string(data)
+}
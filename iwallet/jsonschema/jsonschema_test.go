@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const transferSchema = `{
+  "type": "array",
+  "items": [
+    {"enum": ["iost", "emogi"]},
+    {"$ref": "#/definitions/account"},
+    {"$ref": "#/definitions/account"},
+    {"type": "string", "pattern": "^[0-9]+(\\.[0-9]{1,8})?$"},
+    {"type": "string", "maxLength": 512}
+  ],
+  "additionalItems": false,
+  "minItems": 5,
+  "definitions": {
+    "account": {"type": "string", "minLength": 5, "maxLength": 11, "pattern": "^[a-z0-9_]+$"}
+  }
+}`
+
+func validate(t *testing.T, schema string, value string) error {
+	s, err := Parse([]byte(schema))
+	assert.Nil(t, err)
+	v, err := Decode([]byte(value))
+	assert.Nil(t, err)
+	return s.Validate(v)
+}
+
+func TestValidateTuple(t *testing.T) {
+	assert.Nil(t, validate(t, transferSchema, `["iost", "alice", "bob01", "12.5", ""]`))
+
+	err := validate(t, transferSchema, `["eth", "al", "bob01", "1.123456789", "", 1]`)
+	assert.NotNil(t, err)
+	assert.Equal(t, `/0: "eth" is not one of "iost", "emogi"
+/1: "al" is shorter than 5 characters
+/3: "1.123456789" does not match pattern ^[0-9]+(\.[0-9]{1,8})?$
+/5: no value is allowed`, err.Error())
+
+	assert.Equal(t, "/: has 1 items, should have at least 5", validate(t, transferSchema, `["iost"]`).Error())
+}
+
+func TestValidateObject(t *testing.T) {
+	schema := `{
+  "type": "object",
+  "required": ["to", "amount"],
+  "properties": {
+    "to": {"type": "string"},
+    "amount": {"type": "integer", "minimum": 1, "exclusiveMaximum": 100},
+    "ratio": {"type": "number", "multipleOf": 0.01},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+    "memo": {"type": ["string", "null"]}
+  },
+  "additionalProperties": false
+}`
+	assert.Nil(t, validate(t, schema, `{"to": "bob", "amount": 99, "ratio": 0.25, "memo": null}`))
+
+	err := validate(t, schema, `{"amount": 100, "ratio": 0.255, "tags": ["a", 1, "a"], "memo": 1, "extra": true}`)
+	assert.NotNil(t, err)
+	errs := err.(Errors)
+	assert.Equal(t, []*Error{
+		{Path: "", Message: "missing required field to"},
+		{Path: "/amount", Message: "100 should be less than 100"},
+		{Path: "", Message: "unknown field extra"},
+		{Path: "/memo", Message: "1 is integer, should be string or null"},
+		{Path: "/ratio", Message: "0.255 is not a multiple of 1/100"},
+		{Path: "/tags", Message: "items 0 and 2 are equal, should be unique"},
+		{Path: "/tags/1", Message: "1 is integer, should be string"},
+	}, []*Error(errs))
+
+	assert.Contains(t, validate(t, schema, `{"to": "bob", "amount": 1.5}`).Error(), "1.5 is number, should be integer")
+	assert.Contains(t, validate(t, schema, `[]`).Error(), "[] is array, should be object")
+}
+
+func TestValidateCombinators(t *testing.T) {
+	schema := `{"oneOf": [{"type": "integer"}, {"type": "number", "minimum": 10}], "not": {"const": 3}}`
+	assert.Nil(t, validate(t, schema, `2`))
+	assert.Nil(t, validate(t, schema, `10.5`))
+	assert.Contains(t, validate(t, schema, `12`).Error(), "matches 2 of oneOf")
+	assert.Contains(t, validate(t, schema, `3`).Error(), "should not match")
+	assert.Nil(t, validate(t, `{"anyOf": [{"type": "string"}, {"type": "boolean"}]}`, `true`))
+	assert.Contains(t, validate(t, `{"anyOf": [{"type": "string"}, {"type": "boolean"}]}`, `1`).Error(), "none of anyOf")
+
+	// draft 4 exclusive bounds
+	assert.Contains(t, validate(t, `{"minimum": 0, "exclusiveMinimum": true}`, `0`).Error(), "should be greater than 0")
+	assert.Nil(t, validate(t, `{"maximum": 1e30}`, `999999999999999999999999999999`))
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]byte(`{"pattern": "("}`))
+	assert.Contains(t, err.Error(), "invalid pattern")
+	_, err = Parse([]byte(`{"type": "string"} {}`))
+	assert.NotNil(t, err)
+
+	s, err := Parse([]byte(`{"$ref": "#/definitions/missing"}`))
+	assert.Nil(t, err)
+	assert.Contains(t, s.Validate("x").Error(), "$ref #/definitions/missing not found")
+	s, err = Parse([]byte(`{"$ref": "#"}`))
+	assert.Nil(t, err)
+	assert.Contains(t, s.Validate("x").Error(), "nested too deeply")
+}
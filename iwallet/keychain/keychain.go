@@ -0,0 +1,23 @@
+// Package keychain keeps secrets in the credential store of the operating system: the Keychain on macOS, the
+// Credential Manager on Windows and the Secret Service (through secret-tool) on Linux.
+package keychain
+
+import "errors"
+
+// ErrNotFound is returned when there is no secret for the service and account.
+var ErrNotFound = errors.New("secret not found in keychain")
+
+// Set saves the secret of the account under the service, replacing the old one.
+func Set(service, account string, secret []byte) error {
+	return set(service, account, secret)
+}
+
+// Get returns the secret of the account under the service.
+func Get(service, account string) ([]byte, error) {
+	return get(service, account)
+}
+
+// Delete removes the secret of the account under the service.
+func Delete(service, account string) error {
+	return del(service, account)
+}
@@ -0,0 +1,39 @@
+package keychain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The secret is passed to security(1) on stdin in interactive mode, so that it does not show up in the process list.
+// It is hex encoded since the keychain holds it as a string.
+func set(service, account string, secret []byte) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -w %v\n", service, account, hex.EncodeToString(secret)))
+	if out, err := cmd.CombinedOutput(); err != nil || bytes.Contains(out, []byte("error")) {
+		return fmt.Errorf("failed to save secret in keychain: %v %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func get(service, account string) ([]byte, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 44 {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read secret from keychain: %v", err)
+	}
+	return hex.DecodeString(strings.TrimSpace(string(out)))
+}
+
+func del(service, account string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run()
+	if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 44 {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package keychain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secret-tool(1) of libsecret talks to the Secret Service, eg gnome-keyring or kwallet. It reads the secret from
+// stdin. The secret is hex encoded since it is stored as a string.
+func set(service, account string, secret []byte) error {
+	cmd := exec.Command("secret-tool", "store", "--label", service+" "+account, "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(hex.EncodeToString(secret))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to save secret in keychain: %v %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func get(service, account string) ([]byte, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok && len(out) == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read secret from keychain: %v", err)
+	}
+	return hex.DecodeString(strings.TrimSpace(string(out)))
+}
+
+func del(service, account string) error {
+	return exec.Command("secret-tool", "clear", "service", service, "account", account).Run()
+}
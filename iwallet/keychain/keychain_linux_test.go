@@ -0,0 +1,51 @@
+package keychain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSecretTool is a secret-tool keeping the secrets in files of its dir.
+const fakeSecretTool = `#!/bin/sh
+cmd=$1
+if [ "$cmd" = store ]; then shift 2; fi
+f="$(dirname "$0")/$(echo "$3.$5" | tr / _).secret"
+case $cmd in
+store) cat > "$f" ;;
+lookup) [ -f "$f" ] && cat "$f" || exit 1 ;;
+clear) rm -f "$f" ;;
+esac
+`
+
+func TestKeychain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keychain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "secret-tool"), []byte(fakeSecretTool), 0700))
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+
+	_, err = Get("iwallet", "alice/active")
+	assert.Equal(t, ErrNotFound, err)
+
+	// the secrets which are not text are kept as they are
+	secret := []byte{0, 1, 0xfe, '\n', 0xff}
+	assert.Nil(t, Set("iwallet", "alice/active", secret))
+	got, err := Get("iwallet", "alice/active")
+	assert.Nil(t, err)
+	assert.Equal(t, secret, got)
+
+	assert.Nil(t, Set("iwallet", "alice/active", []byte("new")))
+	got, err = Get("iwallet", "alice/active")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new"), got)
+
+	assert.Nil(t, Delete("iwallet", "alice/active"))
+	_, err = Get("iwallet", "alice/active")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,20 @@
+// +build !linux,!darwin,!windows
+
+package keychain
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func set(service, account string, secret []byte) error {
+	return fmt.Errorf("keychain is not supported on %v yet", runtime.GOOS)
+}
+
+func get(service, account string) ([]byte, error) {
+	return nil, fmt.Errorf("keychain is not supported on %v yet", runtime.GOOS)
+}
+
+func del(service, account string) error {
+	return fmt.Errorf("keychain is not supported on %v yet", runtime.GOOS)
+}
@@ -0,0 +1,99 @@
+package keychain
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = 1168
+)
+
+// credential is CREDENTIALW of wincred.h.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func targetName(service, account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + account)
+}
+
+func set(service, account string, secret []byte) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("empty secret")
+	}
+	target, err := targetName(service, account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	cred := &credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(secret)),
+		CredentialBlob:     &secret[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if r, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(cred)), 0); r == 0 {
+		return fmt.Errorf("failed to save secret in credential manager: %v", err)
+	}
+	return nil
+}
+
+func get(service, account string) ([]byte, error) {
+	target, err := targetName(service, account)
+	if err != nil {
+		return nil, err
+	}
+	var cred *credential
+	if r, _, err := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred))); r == 0 {
+		if errno, ok := err.(syscall.Errno); ok && errno == errorNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read secret from credential manager: %v", err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	secret := make([]byte, cred.CredentialBlobSize)
+	copy(secret, (*[1 << 20]byte)(unsafe.Pointer(cred.CredentialBlob))[:cred.CredentialBlobSize:cred.CredentialBlobSize])
+	return secret, nil
+}
+
+func del(service, account string) error {
+	target, err := targetName(service, account)
+	if err != nil {
+		return err
+	}
+	if r, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); r == 0 {
+		if errno, ok := err.(syscall.Errno); ok && errno == errorNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete secret from credential manager: %v", err)
+	}
+	return nil
+}
@@ -28,8 +28,18 @@ type KeyPairInfo struct {
 	Salt          string `json:"salt,omitempty"`
 	EncryptedKey  string `json:"encrypted_key,omitempty"`
 	Mac           string `json:"mac,omitempty"`
+	// ScryptN and ScryptP are the kdf parameters of method v1, v0 always uses legacyScryptN and legacyScryptP
+	ScryptN int `json:"scrypt_n,omitempty"`
+	ScryptP int `json:"scrypt_p,omitempty"`
 }
 
+// scrypt parameters of the v0 encryption of keys
+const (
+	legacyScryptN = 32768
+	legacyScryptP = 1
+	scryptR       = 8
+)
+
 // NewKeyPairInfo ...
 func NewKeyPairInfo(rawKey string, keyType string) (*KeyPairInfo, error) {
 	kp := &KeyPairInfo{}
@@ -51,11 +61,23 @@ func (k *KeyPairInfo) encrypt(password []byte) error {
 	if k.EncryptedKey != "" {
 		return nil
 	}
+	return k.seal(password, "v0", legacyScryptN, legacyScryptP)
+}
+
+// reencrypt encrypts the decrypted key again by method v1 with the given kdf parameters, dropping the old encryption.
+func (k *KeyPairInfo) reencrypt(password []byte, scryptN int, scryptP int) error {
+	if k.RawKey == "" {
+		return fmt.Errorf("key should be decrypted before encrypting again")
+	}
+	return k.seal(password, "v1", scryptN, scryptP)
+}
+
+func (k *KeyPairInfo) seal(password []byte, method string, scryptN int, scryptP int) error {
 	salt := make([]byte, 48) // encryptKey + iv + hashSalt
 	if _, err := io.ReadFull(rand.Reader, salt[0:32]); err != nil {
 		return fmt.Errorf("reading from crypto/rand failed: " + err.Error())
 	}
-	key, err := scrypt.Key(password, salt[0:32], 32768, 8, 1, 32)
+	key, err := scrypt.Key(password, salt[0:32], scryptN, scryptR, scryptP, 32)
 	if err != nil {
 		return err
 	}
@@ -69,20 +91,29 @@ func (k *KeyPairInfo) encrypt(password []byte) error {
 	stream.XORKeyStream(outText, inText)
 	mac := common.Sha3(append(key[16:32], outText...))
 
-	k.EncryptMethod = "v0"
+	k.EncryptMethod = method
 	k.Salt = common.Base58Encode(salt)
 	k.EncryptedKey = common.Base58Encode(outText)
 	k.Mac = common.Base58Encode(mac)
+	k.ScryptN, k.ScryptP = 0, 0
+	if method != "v0" {
+		k.ScryptN, k.ScryptP = scryptN, scryptP
+	}
 	return nil
 }
 
 func (k *KeyPairInfo) decrypt(password []byte) error {
-	if k.EncryptMethod != "v0" {
+	scryptN, scryptP := legacyScryptN, legacyScryptP
+	switch k.EncryptMethod {
+	case "v0":
+	case "v1":
+		scryptN, scryptP = k.ScryptN, k.ScryptP
+	default:
 		return fmt.Errorf("version mismatch")
 	}
 	salt := common.Base58Decode(k.Salt)
 
-	key, err := scrypt.Key(password, salt[0:32], 32768, 8, 1, 32)
+	key, err := scrypt.Key(password, salt[0:32], scryptN, scryptR, scryptP, 32)
 	if err != nil {
 		return err
 	}
@@ -103,10 +134,23 @@ func (k *KeyPairInfo) decrypt(password []byte) error {
 	return nil
 }
 
+// DerivationInfo records how the keys of an account were derived from a mnemonic.
+type DerivationInfo struct {
+	Scheme    string `json:"scheme"`
+	Path      string `json:"path"`
+	WordCount int    `json:"word_count"`
+}
+
 // AccountInfo ...
 type AccountInfo struct {
-	Name     string                  `json:"name"`
-	Keypairs map[string]*KeyPairInfo `json:"keypairs"`
+	Name       string                  `json:"name"`
+	Keypairs   map[string]*KeyPairInfo `json:"keypairs"`
+	Derivation *DerivationInfo         `json:"derivation,omitempty"`
+	WatchOnly  bool                    `json:"watch_only,omitempty"`
+	// RequireInteractive refuses non-interactive password sources for the account
+	RequireInteractive bool `json:"require_interactive,omitempty"`
+	// Store is where the secret keys are kept, the account file itself if empty
+	Store string `json:"store,omitempty"`
 }
 
 // NewAccountInfo ...
@@ -115,14 +159,18 @@ func NewAccountInfo() *AccountInfo {
 }
 
 func (a *AccountInfo) isEncrypted() bool {
-	return a.Keypairs["active"].RawKey == ""
+	return !a.WatchOnly && a.Keypairs["active"].RawKey == ""
+}
+
+func errWatchOnly(name string) error {
+	return fmt.Errorf("account %v is watch-only, it has no private key to sign with", name)
 }
 
 func (a *AccountInfo) decrypt() error {
 	cnt := 0
 	for cnt <= 3 {
 		cnt++
-		password, err := readPasswordFromStdin(false)
+		password, interactive, err := readPassword(false, a.RequireInteractive)
 		if err != nil {
 			return err
 		}
@@ -131,6 +179,9 @@ func (a *AccountInfo) decrypt() error {
 			err := kp.decrypt(password)
 			if err != nil {
 				if err.Error() == "wrong password" {
+					if !interactive {
+						return err
+					}
 					fmt.Println("decrypt error:", err)
 					fmt.Println("Please retry")
 					retry = true
@@ -158,9 +209,12 @@ func (a *AccountInfo) save(encrypt bool) error {
 		return err
 	}
 	fileName := dir + "/" + a.Name + ".json"
+	if encrypt && a.WatchOnly {
+		return errWatchOnly(a.Name)
+	}
 	if encrypt {
 		fmt.Println("encrypting seckey, need password")
-		password, err := readPasswordFromStdin(true)
+		password, _, err := readPassword(true, a.RequireInteractive)
 		if err != nil {
 			return err
 		}
@@ -177,14 +231,36 @@ func (a *AccountInfo) save(encrypt bool) error {
 			k.Salt = ""
 			k.EncryptedKey = ""
 			k.Mac = ""
+			k.ScryptN, k.ScryptP = 0, 0
 		}
 	}
-	data, err := json.MarshalIndent(a, "", "  ")
+	return a.writeTo(fileName)
+}
+
+// writeTo replaces the account file by a new one renamed over it, so that the keys are never left half written.
+// The secrets go to the keychain if the account keeps them there.
+func (a *AccountInfo) writeTo(fileName string) error {
+	file := a
+	if a.Store == storeKeychain {
+		var err error
+		if file, err = a.saveToKeychain(); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(fileName, data, 0400)
-	return err
+	tmp := fileName + ".tmp"
+	os.Remove(tmp)
+	if err := ioutil.WriteFile(tmp, data, 0400); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, fileName); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
 }
 
 func loadAccountFromKeyPair(fileName string) (*AccountInfo, error) {
@@ -221,6 +297,14 @@ func loadAccountFromKeyStore(fileName string, ensureDecrypt bool) (*AccountInfo,
 		return nil, err
 	}
 	if ensureDecrypt {
+		if a.WatchOnly {
+			return nil, errWatchOnly(a.Name)
+		}
+		if a.Store == storeKeychain {
+			if err := a.loadFromKeychain(); err != nil {
+				return nil, err
+			}
+		}
 		if a.isEncrypted() {
 			err := a.decrypt()
 			if err != nil {
@@ -0,0 +1,85 @@
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/iost-official/go-iost/iwallet/keychain"
+)
+
+// Backends of account secrets.
+const (
+	storeFile     = "file"
+	storeKeychain = "keychain"
+)
+
+// keychainService is the service name under which iwallet keeps secrets in the os keychain.
+const keychainService = "iwallet"
+
+func checkKeyStore(store string) error {
+	if store != storeFile && store != storeKeychain {
+		return fmt.Errorf("invalid key store %v, should be %v or %v", store, storeFile, storeKeychain)
+	}
+	return nil
+}
+
+// probeKeychain makes sure the keychain works before a new key is created, which would be lost otherwise.
+func probeKeychain() error {
+	account := keychainAccount("probe", "probe")
+	if err := keychain.Set(keychainService, account, []byte("probe")); err != nil {
+		return err
+	}
+	return keychain.Delete(keychainService, account)
+}
+
+func keychainAccount(name string, perm string) string {
+	return name + "/" + perm
+}
+
+// saveToKeychain puts the secret part of every key pair into the keychain, encrypted or not, and returns a copy of
+// the account with only the public keys, which is what is written to the account file.
+func (a *AccountInfo) saveToKeychain() (*AccountInfo, error) {
+	stripped := *a
+	stripped.Keypairs = make(map[string]*KeyPairInfo, len(a.Keypairs))
+	for perm, kp := range a.Keypairs {
+		data, err := json.Marshal(kp)
+		if err != nil {
+			return nil, err
+		}
+		if err := keychain.Set(keychainService, keychainAccount(a.Name, perm), data); err != nil {
+			return nil, err
+		}
+		stripped.Keypairs[perm] = &KeyPairInfo{KeyType: kp.KeyType, PubKey: kp.PubKey}
+	}
+	return &stripped, nil
+}
+
+// loadFromKeychain fills the key pairs with the secrets kept in the keychain.
+func (a *AccountInfo) loadFromKeychain() error {
+	for perm, kp := range a.Keypairs {
+		data, err := keychain.Get(keychainService, keychainAccount(a.Name, perm))
+		if err != nil {
+			return fmt.Errorf("failed to load %v key of %v: %v", perm, a.Name, err)
+		}
+		secret := &KeyPairInfo{}
+		if err := json.Unmarshal(data, secret); err != nil {
+			return fmt.Errorf("invalid %v key of %v in keychain: %v", perm, a.Name, err)
+		}
+		if secret.PubKey != kp.PubKey {
+			return fmt.Errorf("%v key of %v in keychain does not match the account file", perm, a.Name)
+		}
+		a.Keypairs[perm] = secret
+	}
+	return nil
+}
+
+// deleteFromKeychain removes the secrets of the account, ignoring those which are already gone.
+func (a *AccountInfo) deleteFromKeychain() error {
+	for perm := range a.Keypairs {
+		err := keychain.Delete(keychainService, keychainAccount(a.Name, perm))
+		if err != nil && err != keychain.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSecretTool is a secret-tool keeping the secrets in files of its dir.
+const fakeSecretTool = `#!/bin/sh
+cmd=$1
+if [ "$cmd" = store ]; then shift 2; fi
+f="$(dirname "$0")/$(echo "$3.$5" | tr / _).secret"
+case $cmd in
+store) cat > "$f" ;;
+lookup) [ -f "$f" ] && cat "$f" || exit 1 ;;
+clear) rm -f "$f" ;;
+esac
+`
+
+func TestKeychainStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keychain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "secret-tool"), []byte(fakeSecretTool), 0700))
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+	assert.Nil(t, probeKeychain())
+
+	a := NewAccountInfo()
+	a.Name = "alice"
+	a.Store = storeKeychain
+	for _, perm := range []string{"active", "owner"} {
+		kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+		assert.Nil(t, err)
+		a.Keypairs[perm], err = NewKeyPairInfo(common.Base58Encode(kp.Seckey), "ed25519")
+		assert.Nil(t, err)
+	}
+
+	// the account file keeps only the public keys, the secrets being loaded back from the keychain
+	stripped, err := a.saveToKeychain()
+	assert.Nil(t, err)
+	assert.Empty(t, stripped.Keypairs["active"].RawKey)
+	assert.Equal(t, a.Keypairs["active"].PubKey, stripped.Keypairs["active"].PubKey)
+	assert.NotEmpty(t, a.Keypairs["active"].RawKey)
+	assert.Nil(t, stripped.loadFromKeychain())
+	assert.Equal(t, a.Keypairs, stripped.Keypairs)
+
+	// a secret not matching the account file is refused
+	other, err := a.saveToKeychain()
+	assert.Nil(t, err)
+	other.Keypairs["active"].PubKey = a.Keypairs["owner"].PubKey
+	assert.EqualError(t, other.loadFromKeychain(), "active key of alice in keychain does not match the account file")
+
+	// the secrets already gone are skipped when deleting
+	assert.Nil(t, a.deleteFromKeychain())
+	assert.Nil(t, a.deleteFromKeychain())
+	stripped, err = a.saveToKeychain()
+	assert.Nil(t, err)
+	assert.Nil(t, a.deleteFromKeychain())
+	assert.Contains(t, stripped.loadFromKeychain().Error(), "failed to load")
+}
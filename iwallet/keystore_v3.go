@@ -0,0 +1,184 @@
+package iwallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/iost-official/go-iost/common"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	keystoreV3ScryptN = 262144
+	keystoreV3ScryptR = 8
+	keystoreV3ScryptP = 1
+	keystoreV3KeyLen  = 32
+	keystoreV3Cipher  = "aes-128-ctr"
+	keystoreV3KDF     = "scrypt"
+	keystoreV3Version = 3
+	keystoreFormatV3  = "keystore-v3"
+)
+
+type keystoreV3 struct {
+	Version int              `json:"version"`
+	ID      string           `json:"id"`
+	Address string           `json:"address"`
+	KeyType string           `json:"key_type,omitempty"`
+	Crypto  keystoreV3Crypto `json:"crypto"`
+}
+
+type keystoreV3Crypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreV3CipherParams `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    keystoreV3KDFParams    `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreV3CipherParams struct {
+	IV string `json:"iv"`
+}
+
+type keystoreV3KDFParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// encryptKeystoreV3 encrypts a decrypted key pair into the Ethereum keystore v3 format.
+func encryptKeystoreV3(kp *KeyPairInfo, password []byte, scryptN, scryptP int) (*keystoreV3, error) {
+	if kp.RawKey == "" {
+		return nil, fmt.Errorf("key pair is not decrypted")
+	}
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("reading from crypto/rand failed: %v", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, fmt.Errorf("reading from crypto/rand failed: %v", err)
+	}
+	key, err := scrypt.Key(password, salt, scryptN, keystoreV3ScryptR, scryptP, keystoreV3KeyLen)
+	if err != nil {
+		return nil, err
+	}
+	aesBlock, err := aes.NewCipher(key[0:16])
+	if err != nil {
+		return nil, err
+	}
+	inText := common.Base58Decode(kp.RawKey)
+	outText := make([]byte, len(inText))
+	cipher.NewCTR(aesBlock, iv).XORKeyStream(outText, inText)
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	return &keystoreV3{
+		Version: keystoreV3Version,
+		ID:      id.String(),
+		Address: kp.PubKey,
+		KeyType: kp.KeyType,
+		Crypto: keystoreV3Crypto{
+			Cipher:       keystoreV3Cipher,
+			CipherText:   hex.EncodeToString(outText),
+			CipherParams: keystoreV3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          keystoreV3KDF,
+			KDFParams: keystoreV3KDFParams{
+				N:     scryptN,
+				R:     keystoreV3ScryptR,
+				P:     scryptP,
+				DKLen: keystoreV3KeyLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keccak256(key[16:32], outText)),
+		},
+	}, nil
+}
+
+// decryptKeystoreV3 decrypts a keystore v3 file. Files without key_type are treated as secp256k1 keys, as exported by Ethereum tools.
+func decryptKeystoreV3(ks *keystoreV3, password []byte) (*KeyPairInfo, error) {
+	if ks.Version != keystoreV3Version {
+		return nil, fmt.Errorf("unsupported keystore version %v", ks.Version)
+	}
+	c := ks.Crypto
+	if c.Cipher != keystoreV3Cipher {
+		return nil, fmt.Errorf("unsupported cipher %v", c.Cipher)
+	}
+	if c.KDF != keystoreV3KDF {
+		return nil, fmt.Errorf("unsupported kdf %v", c.KDF)
+	}
+	salt, err := hex.DecodeString(c.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %v", err)
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %v", err)
+	}
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %v", err)
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mac: %v", err)
+	}
+	if c.KDFParams.DKLen < 32 {
+		return nil, fmt.Errorf("invalid dklen %v", c.KDFParams.DKLen)
+	}
+	key, err := scrypt.Key(password, salt, c.KDFParams.N, c.KDFParams.R, c.KDFParams.P, c.KDFParams.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(keccak256(key[16:32], cipherText), mac) {
+		return nil, fmt.Errorf("wrong password")
+	}
+	aesBlock, err := aes.NewCipher(key[0:16])
+	if err != nil {
+		return nil, err
+	}
+	plainText := make([]byte, len(cipherText))
+	cipher.NewCTR(aesBlock, iv).XORKeyStream(plainText, cipherText)
+
+	keyType := ks.KeyType
+	if keyType == "" {
+		keyType = "secp256k1"
+	}
+	return NewKeyPairInfo(common.Base58Encode(plainText), keyType)
+}
+
+func marshalKeystoreV3(kp *KeyPairInfo, password []byte) ([]byte, error) {
+	ks, err := encryptKeystoreV3(kp, password, keystoreV3ScryptN, keystoreV3ScryptP)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+func unmarshalKeystoreV3(data []byte, password []byte) (*KeyPairInfo, error) {
+	ks := &keystoreV3{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore v3 file: %v", err)
+	}
+	return decryptKeystoreV3(ks, password)
+}
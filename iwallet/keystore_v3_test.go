@@ -0,0 +1,56 @@
+package iwallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystoreV3RoundTrip(t *testing.T) {
+	for _, algo := range []crypto.Algorithm{crypto.Ed25519, crypto.Secp256k1} {
+		kp, err := account.NewKeyPair(nil, algo)
+		assert.Nil(t, err)
+		info, err := NewKeyPairInfo(common.Base58Encode(kp.Seckey), algo.String())
+		assert.Nil(t, err)
+
+		ks, err := encryptKeystoreV3(info, []byte("secret"), 1024, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, info.PubKey, ks.Address)
+		data, err := json.Marshal(ks)
+		assert.Nil(t, err)
+
+		got, err := unmarshalKeystoreV3(data, []byte("secret"))
+		assert.Nil(t, err)
+		assert.Equal(t, info.RawKey, got.RawKey)
+		assert.Equal(t, info.PubKey, got.PubKey)
+		assert.Equal(t, info.KeyType, got.KeyType)
+
+		_, err = unmarshalKeystoreV3(data, []byte("wrong"))
+		assert.EqualError(t, err, "wrong password")
+	}
+}
+
+func TestKeystoreV3Ethereum(t *testing.T) {
+	// test vector from the Ethereum Web3 Secret Storage Definition
+	data := []byte(`{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`)
+	kp, err := unmarshalKeystoreV3(data, []byte("testpassword"))
+	assert.Nil(t, err)
+	assert.Equal(t, "secp256k1", kp.KeyType)
+	assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(common.Base58Decode(kp.RawKey)))
+}
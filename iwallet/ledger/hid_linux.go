@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const ledgerVendorID = "00002C97"
+
+// openDevice opens the first hidraw node exposed by a Ledger.
+func openDevice() (Device, error) {
+	nodes, err := filepath.Glob("/sys/class/hidraw/hidraw*")
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		uevent, err := ioutil.ReadFile(filepath.Join(node, "device", "uevent"))
+		if err != nil {
+			continue
+		}
+		if !isLedger(string(uevent)) {
+			continue
+		}
+		f, err := os.OpenFile(filepath.Join("/dev", filepath.Base(node)), os.O_RDWR, 0)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open ledger device: %v", err)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("no ledger device found, make sure it is plugged in and unlocked")
+}
+
+// isLedger reports whether a hidraw uevent belongs to a Ledger, e.g. "HID_ID=0003:00002C97:00000001".
+func isLedger(uevent string) bool {
+	for _, line := range strings.Split(uevent, "\n") {
+		if !strings.HasPrefix(line, "HID_ID=") {
+			continue
+		}
+		ids := strings.Split(strings.TrimPrefix(line, "HID_ID="), ":")
+		return len(ids) == 3 && strings.EqualFold(ids[1], ledgerVendorID)
+	}
+	return false
+}
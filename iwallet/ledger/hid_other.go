@@ -0,0 +1,13 @@
+// +build !linux
+
+package ledger
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// openDevice opens the first hidraw node exposed by a Ledger.
+func openDevice() (Device, error) {
+	return nil, fmt.Errorf("ledger is not supported on %v yet", runtime.GOOS)
+}
@@ -0,0 +1,223 @@
+// Package ledger talks to the IOST application on a Ledger hardware wallet.
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+)
+
+// DefaultPath is the BIP32 path used when none is given.
+const DefaultPath = account.DefaultHDPath
+
+const (
+	claIOST         = 0xe0
+	insGetPublicKey = 0x02
+	insSign         = 0x04
+
+	channel    = 0x0101
+	tagAPDU    = 0x05
+	packetSize = 64
+)
+
+// Device is a raw HID connection to a Ledger.
+type Device interface {
+	// Write writes one HID report.
+	Write(report []byte) (int, error)
+	// Read reads one HID report.
+	Read(report []byte) (int, error)
+	// Close closes the device.
+	Close() error
+}
+
+// Ledger is a connected Ledger running the IOST application.
+type Ledger struct {
+	dev Device
+}
+
+// New wraps an opened HID device.
+func New(dev Device) *Ledger {
+	return &Ledger{dev: dev}
+}
+
+// Open opens the first Ledger found on this machine.
+func Open() (*Ledger, error) {
+	dev, err := openDevice()
+	if err != nil {
+		return nil, err
+	}
+	return New(dev), nil
+}
+
+// Close closes the underlying device.
+func (l *Ledger) Close() error {
+	return l.dev.Close()
+}
+
+func serializePath(path []uint32) []byte {
+	buf := make([]byte, 1+4*len(path))
+	buf[0] = byte(len(path))
+	for i, p := range path {
+		binary.BigEndian.PutUint32(buf[1+4*i:], p)
+	}
+	return buf
+}
+
+// GetPublicKey returns the ed25519 public key at the given path.
+// If confirm is set, the key is shown on the device and must be approved.
+func (l *Ledger) GetPublicKey(path []uint32, confirm bool) ([]byte, error) {
+	var p1 byte
+	if confirm {
+		p1 = 0x01
+	}
+	resp, err := l.Exchange(claIOST, insGetPublicKey, p1, 0x00, serializePath(path))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 32 {
+		return nil, fmt.Errorf("invalid public key length %v from ledger", len(resp))
+	}
+	return resp, nil
+}
+
+// Sign asks the device to sign the tx hash with the key at the given path.
+func (l *Ledger) Sign(path []uint32, hash []byte) ([]byte, error) {
+	data := append(serializePath(path), hash...)
+	resp, err := l.Exchange(claIOST, insSign, 0x00, 0x00, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 64 {
+		return nil, fmt.Errorf("invalid signature length %v from ledger", len(resp))
+	}
+	return resp, nil
+}
+
+// Exchange sends an APDU command and returns the response data without the status word.
+func (l *Ledger) Exchange(cla, ins, p1, p2 byte, data []byte) ([]byte, error) {
+	if len(data) > 255 {
+		return nil, fmt.Errorf("apdu data too long: %v", len(data))
+	}
+	apdu := append([]byte{cla, ins, p1, p2, byte(len(data))}, data...)
+	for _, packet := range wrapAPDU(apdu) {
+		// hidraw expects the report id as the first byte
+		if _, err := l.dev.Write(append([]byte{0x00}, packet...)); err != nil {
+			return nil, fmt.Errorf("write to ledger failed: %v", err)
+		}
+	}
+	resp, err := readAPDU(l.dev)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("response from ledger too short")
+	}
+	sw := binary.BigEndian.Uint16(resp[len(resp)-2:])
+	if sw != 0x9000 {
+		return nil, statusError(sw)
+	}
+	return resp[:len(resp)-2], nil
+}
+
+func statusError(sw uint16) error {
+	switch sw {
+	case 0x6985:
+		return errors.New("request rejected on ledger")
+	case 0x6d00, 0x6e00:
+		return errors.New("IOST application is not open on ledger")
+	case 0x6a80:
+		return errors.New("ledger rejected invalid data")
+	default:
+		return fmt.Errorf("ledger returned status 0x%04x", sw)
+	}
+}
+
+func wrapAPDU(apdu []byte) [][]byte {
+	var packets [][]byte
+	data := make([]byte, 2+len(apdu))
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	copy(data[2:], apdu)
+	for seq := 0; len(data) > 0; seq++ {
+		packet := make([]byte, packetSize)
+		binary.BigEndian.PutUint16(packet, channel)
+		packet[2] = tagAPDU
+		binary.BigEndian.PutUint16(packet[3:], uint16(seq))
+		n := copy(packet[5:], data)
+		data = data[n:]
+		packets = append(packets, packet)
+	}
+	return packets
+}
+
+func readAPDU(dev Device) ([]byte, error) {
+	var result []byte
+	total := -1
+	for seq := 0; total < 0 || len(result) < total; seq++ {
+		packet := make([]byte, packetSize)
+		n, err := dev.Read(packet)
+		if err != nil {
+			return nil, fmt.Errorf("read from ledger failed: %v", err)
+		}
+		if n < 5 {
+			return nil, fmt.Errorf("short packet from ledger")
+		}
+		packet = packet[:n]
+		if binary.BigEndian.Uint16(packet) != channel || packet[2] != tagAPDU {
+			return nil, fmt.Errorf("unexpected packet header from ledger")
+		}
+		if int(binary.BigEndian.Uint16(packet[3:])) != seq {
+			return nil, fmt.Errorf("unexpected packet sequence from ledger")
+		}
+		payload := packet[5:]
+		if seq == 0 {
+			if len(payload) < 2 {
+				return nil, fmt.Errorf("short packet from ledger")
+			}
+			total = int(binary.BigEndian.Uint16(payload))
+			payload = payload[2:]
+		}
+		result = append(result, payload...)
+	}
+	return result[:total], nil
+}
+
+// Signer signs transactions with a key held on a Ledger.
+type Signer struct {
+	ledger *Ledger
+	path   []uint32
+	pubkey []byte
+}
+
+// NewSigner fetches the public key at path and returns a signer for it.
+func NewSigner(l *Ledger, path string) (*Signer, error) {
+	p, err := account.ParseHDPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 || len(p) > 10 {
+		return nil, fmt.Errorf("invalid bip32 path length for ledger: %v", path)
+	}
+	pubkey, err := l.GetPublicKey(p, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{ledger: l, path: p, pubkey: pubkey}, nil
+}
+
+// Algorithm ...
+func (s *Signer) Algorithm() crypto.Algorithm {
+	return crypto.Ed25519
+}
+
+// PubKey ...
+func (s *Signer) PubKey() []byte {
+	return s.pubkey
+}
+
+// Sign ...
+func (s *Signer) Sign(hash []byte) ([]byte, error) {
+	return s.ledger.Sign(s.path, hash)
+}
@@ -0,0 +1,56 @@
+package ledger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDevice struct {
+	written  [][]byte
+	response [][]byte
+}
+
+func (d *fakeDevice) Write(report []byte) (int, error) {
+	d.written = append(d.written, report)
+	return len(report), nil
+}
+
+func (d *fakeDevice) Read(report []byte) (int, error) {
+	n := copy(report, d.response[0])
+	d.response = d.response[1:]
+	return n, nil
+}
+
+func (d *fakeDevice) Close() error {
+	return nil
+}
+
+func TestExchange(t *testing.T) {
+	sig := bytes.Repeat([]byte{0xab}, 64)
+	resp := append(sig, 0x90, 0x00)
+	dev := &fakeDevice{response: wrapAPDU(resp)}
+	l := New(dev)
+	path, _ := account.ParseHDPath(DefaultPath)
+	hash := bytes.Repeat([]byte{0x01}, 32)
+
+	got, err := l.Sign(path, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, sig, got)
+
+	// 5 header bytes + 21 path bytes + 32 hash bytes fit in two packets
+	assert.Equal(t, 2, len(dev.written))
+	for i, w := range dev.written {
+		assert.Equal(t, packetSize+1, len(w))
+		assert.Equal(t, []byte{0x00, 0x01, 0x01, tagAPDU, 0x00, byte(i)}, w[:6])
+	}
+	assert.Equal(t, []byte{0x00, 5 + 21 + 32, claIOST, insSign}, dev.written[0][6:10])
+}
+
+func TestExchangeStatus(t *testing.T) {
+	dev := &fakeDevice{response: wrapAPDU([]byte{0x69, 0x85})}
+	_, err := New(dev).GetPublicKey([]uint32{account.HardenedOffset + 44}, true)
+	assert.EqualError(t, err, "request rejected on ledger")
+}
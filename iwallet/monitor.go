@@ -0,0 +1,237 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	monitorWindow     time.Duration
+	monitorMaxBackoff time.Duration
+)
+
+// accountRefresh is how often the balance and gas of the account are fetched while monitoring.
+const accountRefresh = 3 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal, so that the dashboard is redrawn in place.
+const clearScreen = "\033[H\033[2J"
+
+type txSample struct {
+	at  time.Time
+	txs int32
+}
+
+// dashboard is what the monitor shows, updated by every status sent by the node.
+type dashboard struct {
+	Head         int64   `json:"head_block" yaml:"head_block"`
+	HeadHash     string  `json:"head_block_hash" yaml:"head_block_hash"`
+	HeadTime     int64   `json:"head_block_time" yaml:"head_block_time"`
+	Witness      string  `json:"witness" yaml:"witness"`
+	Lib          int64   `json:"lib_block" yaml:"lib_block"`
+	TPS          float64 `json:"tps" yaml:"tps"`
+	Pending      int32   `json:"pending_tx_count" yaml:"pending_tx_count"`
+	Peers        int32   `json:"peer_count" yaml:"peer_count"`
+	Account      string  `json:"account,omitempty" yaml:"account,omitempty"`
+	Balance      float64 `json:"balance,omitempty" yaml:"balance,omitempty"`
+	Gas          float64 `json:"gas,omitempty" yaml:"gas,omitempty"`
+	GasLimit     float64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
+	AccountError string  `json:"account_error,omitempty" yaml:"account_error,omitempty"`
+
+	window    time.Duration
+	start     time.Time
+	samples   []txSample
+	accountAt time.Time
+}
+
+// update applies a status. The tps is averaged over the window, or the time since the first status if shorter,
+// whose transactions are not counted since they were packed before monitoring started.
+func (d *dashboard) update(s *rpcpb.ChainStatusResponse, now time.Time) {
+	d.Head, d.HeadHash, d.HeadTime, d.Witness = s.HeadBlock, s.HeadBlockHash, s.HeadBlockTime, s.HeadBlockWitness
+	d.Lib, d.Pending, d.Peers = s.LibBlock, s.PendingTxCount, s.PeerCount
+	if d.start.IsZero() {
+		d.start = now
+	} else {
+		d.samples = append(d.samples, txSample{at: now, txs: s.TxCount})
+	}
+	cutoff := now.Add(-d.window)
+	i := 0
+	for i < len(d.samples) && !d.samples[i].at.After(cutoff) {
+		i++
+	}
+	d.samples = d.samples[i:]
+	var txs int32
+	for _, sample := range d.samples {
+		txs += sample.txs
+	}
+	span := now.Sub(d.start)
+	if span > d.window {
+		span = d.window
+	}
+	if span < time.Second {
+		span = time.Second
+	}
+	d.TPS = float64(txs) / span.Seconds()
+}
+
+func (d *dashboard) updateAccount(a *rpcpb.Account, err error, now time.Time) {
+	d.accountAt = now
+	if err != nil {
+		d.AccountError = err.Error()
+		return
+	}
+	d.AccountError = ""
+	d.Balance = a.Balance
+	if a.GasInfo != nil {
+		d.Gas, d.GasLimit = a.GasInfo.CurrentTotal, a.GasInfo.Limit
+	}
+}
+
+func (d *dashboard) lines() []string {
+	lines := []string{
+		fmt.Sprintf("Head block:   %v  %v", d.Head, d.HeadHash),
+		fmt.Sprintf("Block time:   %v", time.Unix(0, d.HeadTime).Format("2006-01-02 15:04:05.000")),
+		fmt.Sprintf("Lib block:    %v  (%v behind head)", d.Lib, d.Head-d.Lib),
+		fmt.Sprintf("TPS:          %.2f  (last %v)", d.TPS, d.window),
+		fmt.Sprintf("Pending txs:  %v", d.Pending),
+		fmt.Sprintf("Peers:        %v", d.Peers),
+		fmt.Sprintf("Witness:      %v", d.Witness),
+	}
+	if d.Account == "" {
+		return lines
+	}
+	if d.AccountError != "" {
+		return append(lines, fmt.Sprintf("Account:      %v  %v", d.Account, d.AccountError))
+	}
+	return append(lines,
+		fmt.Sprintf("Account:      %v", d.Account),
+		fmt.Sprintf("Balance:      %v iost", d.Balance),
+		fmt.Sprintf("Gas:          %v / %v", d.Gas, d.GasLimit),
+	)
+}
+
+func printDashboard(d *dashboard) error {
+	switch outputFormat {
+	case outputJSON:
+		// One status per line, so the stream can be consumed line by line.
+		data, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case outputYAML:
+		data, err := yaml.Marshal(d)
+		if err != nil {
+			return err
+		}
+		fmt.Print("---\n" + string(data))
+	default:
+		fmt.Print(clearScreen)
+		fmt.Printf("Monitoring %v at %v, press Ctrl-C to stop\n\n", server, time.Now().Format("15:04:05"))
+		fmt.Println(strings.Join(d.lines(), "\n"))
+	}
+	return nil
+}
+
+// monitorOnce shows the status until the stream breaks. It reports whether any status was received.
+func monitorOnce(ctx context.Context, d *dashboard) (bool, error) {
+	defer iwalletSDK.CloseConn()
+	stream, err := iwalletSDK.SubscribeChainStatus(ctx)
+	if err != nil {
+		return false, err
+	}
+	received := false
+	for {
+		s, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		now := time.Now()
+		d.update(s, now)
+		if d.Account != "" && now.Sub(d.accountAt) >= accountRefresh {
+			a, err := iwalletSDK.GetAccountInfo(d.Account)
+			d.updateAccount(a, err, now)
+		}
+		if err := printDashboard(d); err != nil {
+			return received, err
+		}
+	}
+}
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Watch the chain and the node live",
+	Long: `Show a dashboard of the head and irreversible blocks, tps, pending transactions, peers and the witness
+	producing the head block, refreshed by a stream from the node whenever the head block changes.
+	With --account, the balance and gas of the account are shown too. With --output_format json or yaml,
+	every status is printed instead. The stream is reestablished with exponential backoff if the connection breaks.`,
+	Example: `  iwallet monitor
+  iwallet monitor --account producer0 --window 1m
+  iwallet monitor --output_format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := &dashboard{Account: accountName, window: monitorWindow}
+		if d.Account != "" {
+			var err error
+			if d.Account, err = resolveAccount(d.Account); err != nil {
+				return err
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sig
+			cancel()
+		}()
+
+		var backoff time.Duration
+		for {
+			received, err := monitorOnce(ctx, d)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if received {
+				backoff = 0
+			}
+			backoff = nextBackoff(backoff, monitorMaxBackoff)
+			fmt.Fprintf(os.Stderr, "stream broken: %v, reconnecting in %v\n", err, backoff)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(monitorCmd)
+	monitorCmd.Flags().DurationVarP(&monitorWindow, "window", "", 10*time.Second, "time window to average the tps over")
+	monitorCmd.Flags().DurationVarP(&monitorMaxBackoff, "max_backoff", "", time.Minute, "max wait time between reconnections")
+}
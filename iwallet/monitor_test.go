@@ -0,0 +1,47 @@
+package iwallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardUpdate(t *testing.T) {
+	d := &dashboard{window: 10 * time.Second}
+	now := time.Now()
+	d.update(&rpcpb.ChainStatusResponse{HeadBlock: 100, LibBlock: 90, TxCount: 50, PendingTxCount: 3, PeerCount: 7}, now)
+	assert.Equal(t, int64(100), d.Head)
+	assert.Equal(t, int32(3), d.Pending)
+	// txs of the head block when monitoring starts are not counted
+	assert.Equal(t, 0.0, d.TPS)
+
+	d.update(&rpcpb.ChainStatusResponse{HeadBlock: 102, TxCount: 10}, now.Add(time.Second))
+	d.update(&rpcpb.ChainStatusResponse{HeadBlock: 104, TxCount: 30}, now.Add(2*time.Second))
+	assert.Equal(t, 20.0, d.TPS)
+
+	d.update(&rpcpb.ChainStatusResponse{HeadBlock: 124, TxCount: 20}, now.Add(11*time.Second))
+	assert.Equal(t, 5.0, d.TPS)
+	d.update(&rpcpb.ChainStatusResponse{HeadBlock: 126}, now.Add(30*time.Second))
+	assert.Equal(t, 0.0, d.TPS)
+}
+
+func TestDashboardLines(t *testing.T) {
+	d := &dashboard{window: 10 * time.Second, Head: 120, Lib: 100}
+	lines := d.lines()
+	assert.Equal(t, 7, len(lines))
+	assert.Equal(t, "Lib block:    100  (20 behind head)", lines[2])
+
+	d.Account = "test0"
+	d.updateAccount(&rpcpb.Account{Balance: 12.5, GasInfo: &rpcpb.Account_GasInfo{CurrentTotal: 100, Limit: 300}}, nil, time.Now())
+	lines = d.lines()
+	assert.Equal(t, "Balance:      12.5 iost", lines[8])
+	assert.Equal(t, "Gas:          100 / 300", lines[9])
+
+	d.updateAccount(nil, errors.New("account not found"), time.Now())
+	lines = d.lines()
+	assert.Equal(t, 8, len(lines))
+	assert.Equal(t, "Account:      test0  account not found", lines[7])
+}
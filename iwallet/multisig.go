@@ -0,0 +1,247 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+var broadcast bool
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "Multi signature workflow",
+	Long: `Coordinate a transaction which must be signed by several accounts
+	1. one party creates the unsigned transaction with "multisig init" and shares the file
+	2. every signer signs the file with "multisig sign" and shares the signature file
+	3. anyone combines the signatures with "multisig combine" and broadcasts the transaction
+	The account broadcasting the transaction publishes it and pays its gas and ram, so a fee payer whose key is
+	kept elsewhere can pay for the transactions of the signers too.`,
+}
+
+var multisigInitCmd = &cobra.Command{
+	Use:   "init [ACTION]...",
+	Short: "Create an unsigned transaction to be signed by the signers",
+	Long: `Create an unsigned transaction to be signed by the signers
+	An ACTION is a group of 3 arguments: contract name, function name, method parameters.
+	The method parameters should be a string with format '["arg0","arg1",...]'.`,
+	Example: `  iwallet multisig init "token.iost" "transfer" '["iost","multi0001","user0002","123.45",""]' --signers multi0001@active,user0003@active -o tx.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(signers) == 0 {
+			cmd.Usage()
+			return fmt.Errorf("signers should be provided with --signers flag")
+		}
+		return checkSigners(signers)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		actions, err := actionsFromFlags(args)
+		if err != nil {
+			return err
+		}
+		trx, err := iwalletSDK.CreateTxFromActions(actions)
+		if err != nil {
+			return err
+		}
+		t, err := parseTimeFromStr(txTime)
+		if err != nil {
+			return err
+		}
+		trx.Time = t
+		trx.Expiration = trx.Time + expiration*1e9
+		trx.Signers = signers
+
+		output := outputFile
+		if output == "" {
+			output = "multisig_tx.json"
+		}
+		err = sdk.SaveProtoStructToJSONFile(trx, output)
+		if err != nil {
+			return fmt.Errorf("failed to save transaction: %v", err)
+		}
+		printMultisigTx(trx)
+		fmt.Println("Successfully saved unsigned transaction as:", output)
+		return nil
+	},
+}
+
+var multisigSignCmd = &cobra.Command{
+	Use:   "sign txFile",
+	Short: "Sign an unsigned transaction as one of its signers",
+	Long:  `Check the unsigned transaction created by "multisig init" and sign it with the key of the given account and permission`,
+	Example: `  iwallet multisig sign tx.json --account user0003
+  iwallet multisig sign tx.json --account multi0001 --sign_permission owner -o multi0001.sig.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "txFile"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx, err := loadMultisigTx(args[0])
+		if err != nil {
+			return err
+		}
+		signer := accountName + "@" + signPerm
+		if !containsString(trx.Signers, signer) {
+			return fmt.Errorf("%v is not a signer of this transaction, signers are %v", signer, trx.Signers)
+		}
+		a, err := loadAccountByName(accountName, true)
+		if err != nil {
+			return err
+		}
+		kp, ok := a.Keypairs[signPerm]
+		if !ok {
+			return fmt.Errorf("invalid permission %v", signPerm)
+		}
+		keyPair, err := kp.toKeyPair()
+		if err != nil {
+			return err
+		}
+		printMultisigTx(trx)
+		sig := sdk.GetSignatureOfTx(trx, keyPair)
+		output := outputFile
+		if output == "" {
+			output = fmt.Sprintf("%v_%v.sig.json", accountName, signPerm)
+		}
+		err = sdk.SaveProtoStructToJSONFile(sig, output)
+		if err != nil {
+			return fmt.Errorf("failed to save signature: %v", err)
+		}
+		fmt.Println("Successfully saved signature as:", output)
+		return nil
+	},
+}
+
+var multisigCombineCmd = &cobra.Command{
+	Use:   "combine txFile signatureFile...",
+	Short: "Combine the signatures of the signers into the transaction",
+	Long:  `Verify the signatures against the unsigned transaction, put them into the transaction, and optionally send it as the publisher given by --account`,
+	Example: `  iwallet multisig combine tx.json multi0001_active.sig.json user0003_active.sig.json -o signed.json
+  iwallet multisig combine tx.json multi0001_active.sig.json user0003_active.sig.json --broadcast --account user0002`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "txFile", "signatureFile"); err != nil {
+			return err
+		}
+		if broadcast {
+			return checkAccount(cmd)
+		}
+		if outputFile == "" {
+			cmd.Usage()
+			return fmt.Errorf("output file name should be provided with --output flag if not broadcasting")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx, err := loadMultisigTx(args[0])
+		if err != nil {
+			return err
+		}
+		sigs, err := loadSignaturesForTx(trx, args[1:])
+		if err != nil {
+			return err
+		}
+		for i := range sigs {
+			for j := 0; j < i; j++ {
+				if bytes.Equal(sigs[i].PublicKey, sigs[j].PublicKey) {
+					return fmt.Errorf("duplicated signature of public key %v", common.Base58Encode(sigs[i].PublicKey))
+				}
+			}
+		}
+		trx.Signatures = sigs
+		fmt.Printf("%v valid signature(s) combined\n", len(sigs))
+
+		if outputFile != "" {
+			err = sdk.SaveProtoStructToJSONFile(trx, outputFile)
+			if err != nil {
+				return fmt.Errorf("failed to save transaction: %v", err)
+			}
+			fmt.Println("Successfully saved signed transaction as:", outputFile)
+		}
+		if !broadcast {
+			return nil
+		}
+		err = InitAccount()
+		if err != nil {
+			return fmt.Errorf("failed to load account: %v", err)
+		}
+		if dryRun {
+			return dryRunTx(trx)
+		}
+		_, err = iwalletSDK.SendTx(trx)
+		return err
+	},
+}
+
+func loadMultisigTx(file string) (*rpcpb.TransactionRequest, error) {
+	trx := &rpcpb.TransactionRequest{}
+	err := sdk.LoadProtoStructFromJSONFile(file, trx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction file: %v", err)
+	}
+	if len(trx.Signers) == 0 {
+		return nil, fmt.Errorf("transaction in %v has no signers", file)
+	}
+	if err := checkSigners(trx.Signers); err != nil {
+		return nil, err
+	}
+	if len(trx.PublisherSigs) != 0 {
+		return nil, fmt.Errorf("transaction in %v is already published", file)
+	}
+	if trx.Expiration <= time.Now().UnixNano() {
+		return nil, fmt.Errorf("transaction in %v expired at %v", file, time.Unix(0, trx.Expiration))
+	}
+	if trx.ChainId != chainID {
+		return nil, fmt.Errorf("transaction chain id %v mismatches --chain_id %v", trx.ChainId, chainID)
+	}
+	return trx, nil
+}
+
+func printMultisigTx(trx *rpcpb.TransactionRequest) {
+	fmt.Println("Transaction:")
+	fmt.Println(sdk.MarshalTextString(trx))
+	fmt.Println("Signers:", trx.Signers)
+	fmt.Println("Expiration:", time.Unix(0, trx.Expiration))
+	fmt.Println("Hash to sign:", common.Base58Encode(sdk.TxHashForSign(trx)))
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(multisigCmd)
+
+	multisigCmd.AddCommand(multisigInitCmd)
+	multisigInitCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file to save the unsigned transaction (default multisig_tx.json)")
+
+	multisigCmd.AddCommand(multisigSignCmd)
+	multisigSignCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file to save the signature (default account_permission.sig.json)")
+
+	multisigCmd.AddCommand(multisigCombineCmd)
+	multisigCombineCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file to save the signed transaction")
+	multisigCombineCmd.Flags().BoolVarP(&broadcast, "broadcast", "", false, "send the combined transaction to the chain")
+}
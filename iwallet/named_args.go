@@ -0,0 +1,230 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+var namedArgs []string
+
+// nativeABIParams gives the arg names of native contracts, whose code is not on chain.
+var nativeABIParams = map[string]map[string][]string{
+	"token.iost": {
+		"create":         {"token", "issuer", "totalSupply", "config"},
+		"issue":          {"token", "to", "amount"},
+		"transfer":       {"token", "from", "to", "amount", "memo"},
+		"transferFreeze": {"token", "from", "to", "amount", "unfreezeTime", "memo"},
+		"destroy":        {"token", "from", "amount"},
+		"balanceOf":      {"token", "owner"},
+		"supply":         {"token"},
+		"totalSupply":    {"token"},
+	},
+	"token721.iost": {
+		"create":              {"token", "issuer", "totalSupply"},
+		"issue":               {"token", "to", "metadata"},
+		"transfer":            {"token", "from", "to", "tokenID"},
+		"balanceOf":           {"token", "owner"},
+		"ownerOf":             {"token", "tokenID"},
+		"tokenOfOwnerByIndex": {"token", "owner", "index"},
+		"tokenMetadata":       {"token", "tokenID"},
+	},
+	"gas.iost": {
+		"pledge":   {"pledger", "to", "amount"},
+		"unpledge": {"pledger", "from", "amount"},
+		"transfer": {"from", "to", "amount"},
+	},
+}
+
+var jsMethodRegexp = regexp.MustCompile(`(?m)^\s*(?:async\s+)?([A-Za-z_$][\w$]*)\s*\(([^()]*)\)\s*\{`)
+
+var jsKeywords = map[string]bool{"if": true, "for": true, "while": true, "switch": true, "catch": true, "function": true, "with": true}
+
+// parseJSMethodParams finds the param names of the methods defined in js contract code.
+func parseJSMethodParams(code string) map[string][]string {
+	methods := make(map[string][]string)
+	for _, m := range jsMethodRegexp.FindAllStringSubmatch(code, -1) {
+		if jsKeywords[m[1]] {
+			continue
+		}
+		if _, ok := methods[m[1]]; ok {
+			continue
+		}
+		params := make([]string, 0)
+		for _, p := range strings.Split(m[2], ",") {
+			p = strings.TrimSpace(strings.SplitN(p, "=", 2)[0])
+			if p != "" {
+				params = append(params, p)
+			}
+		}
+		methods[m[1]] = params
+	}
+	return methods
+}
+
+type abiParam struct {
+	Name string
+	Type string
+}
+
+// abiParams returns the names and types of the args of an abi. If the names are unknown, the args are named by their index.
+func abiParams(c *rpcpb.Contract, abiName string) ([]*abiParam, error) {
+	var abi *rpcpb.Contract_ABI
+	names := make([]string, 0, len(c.Abis))
+	for _, a := range c.Abis {
+		names = append(names, a.Name)
+		if a.Name == abiName {
+			abi = a
+		}
+	}
+	if abi == nil {
+		return nil, fmt.Errorf("abi %v not found in contract %v, available abis are %v", abiName, c.Id, names)
+	}
+	var argNames []string
+	if native, ok := nativeABIParams[c.Id]; ok {
+		argNames = native[abiName]
+	} else if c.Language == "javascript" {
+		argNames = parseJSMethodParams(c.Code)[abiName]
+	}
+	if len(argNames) != len(abi.Args) {
+		argNames = nil
+	}
+	params := make([]*abiParam, 0, len(abi.Args))
+	for i, t := range abi.Args {
+		name := strconv.Itoa(i)
+		if argNames != nil {
+			name = argNames[i]
+		}
+		params = append(params, &abiParam{Name: name, Type: t})
+	}
+	return params, nil
+}
+
+// encodeNamedArgs converts name=value pairs into the json args array of the abi. A param can also be given by its index.
+// String values are passed through resolve, which replaces known aliases.
+func encodeNamedArgs(params []*abiParam, kvs []string, resolve func(string) (string, error)) (string, error) {
+	values := make([]interface{}, len(params))
+	set := make([]bool, len(params))
+	valid := make([]string, 0, len(params))
+	for _, p := range params {
+		valid = append(valid, p.Name+":"+p.Type)
+	}
+	for _, kv := range kvs {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 {
+			return "", fmt.Errorf("invalid arg %v, should be name=value", kv)
+		}
+		idx := -1
+		for i, p := range params {
+			if p.Name == pair[0] || strconv.Itoa(i) == pair[0] {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return "", fmt.Errorf("unknown arg %v, args are %v", pair[0], valid)
+		}
+		if set[idx] {
+			return "", fmt.Errorf("duplicated arg %v", params[idx].Name)
+		}
+		v, err := encodeArg(params[idx], pair[1], resolve)
+		if err != nil {
+			return "", err
+		}
+		values[idx] = v
+		set[idx] = true
+	}
+	var missing []string
+	for i, p := range params {
+		if !set[i] {
+			missing = append(missing, p.Name)
+		}
+	}
+	if len(missing) != 0 {
+		return "", fmt.Errorf("missing args %v, args are %v", missing, valid)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(values); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// encodeArg checks the value as the vm parses the arg of the type.
+func encodeArg(p *abiParam, value string, resolve func(string) (string, error)) (interface{}, error) {
+	switch p.Type {
+	case "string":
+		return resolve(value)
+	case "number":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("arg %v should be an integer, got %v", p.Name, value)
+		}
+		return json.Number(value), nil
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("arg %v should be true or false, got %v", p.Name, value)
+		}
+		return b, nil
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("arg %v should be json, got %v", p.Name, value)
+		}
+		return json.RawMessage(value), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %v of arg %v", p.Type, p.Name)
+	}
+}
+
+// actionFromNamedArgs fetches the abi of the contract and builds the action from name=value args.
+func actionFromNamedArgs(contract, abiName string, kvs []string) (*rpcpb.Action, error) {
+	c, err := iwalletSDK.GetContract(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract %v: %v", contract, err)
+	}
+	params, err := abiParams(c, abiName)
+	if err != nil {
+		return nil, err
+	}
+	var book addressBook
+	resolve := func(s string) (string, error) {
+		if !strings.HasPrefix(s, contactPrefix) {
+			return s, nil
+		}
+		if book == nil {
+			if book, err = loadAddressBook(); err != nil {
+				return "", err
+			}
+		}
+		if _, ok := book[s[len(contactPrefix):]]; !ok {
+			return s, nil
+		}
+		return book.lookup(s[len(contactPrefix):])
+	}
+	data, err := encodeNamedArgs(params, kvs, resolve)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Action{Contract: contract, ActionName: abiName, Data: data}, nil
+}
@@ -0,0 +1,65 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSMethodParams(t *testing.T) {
+	code := `class Test {
+    init() {
+    }
+    transfer(from, to, amount = "0") {
+        if (amount > 0) {
+            _helper(from);
+        }
+    }
+    can_update(data) { return true; }
+}
+module.exports = Test;`
+	methods := parseJSMethodParams(code)
+	assert.Equal(t, []string{"from", "to", "amount"}, methods["transfer"])
+	assert.Equal(t, []string{"data"}, methods["can_update"])
+	assert.Equal(t, []string{}, methods["init"])
+	_, ok := methods["if"]
+	assert.False(t, ok)
+}
+
+func TestEncodeNamedArgs(t *testing.T) {
+	c := &rpcpb.Contract{
+		Id:       "ContractXXX",
+		Language: "javascript",
+		Code:     "class A {\n  set(key, n, ok, extra) {\n  }\n}",
+		Abis:     []*rpcpb.Contract_ABI{{Name: "set", Args: []string{"string", "number", "bool", "json"}}},
+	}
+	params, err := abiParams(c, "set")
+	assert.Nil(t, err)
+	assert.Equal(t, "key", params[0].Name)
+	_, err = abiParams(c, "get")
+	assert.NotNil(t, err)
+
+	resolve := func(s string) (string, error) {
+		if s == "@alice" {
+			return "user0001", nil
+		}
+		return s, nil
+	}
+	data, err := encodeNamedArgs(params, []string{"ok=true", "key=@alice", "3={\"a\":[1]}", "n=10"}, resolve)
+	assert.Nil(t, err)
+	assert.Equal(t, `["user0001",10,true,{"a":[1]}]`, data)
+
+	_, err = encodeNamedArgs(params, []string{"key=a", "n=1.5", "ok=true", "extra=1"}, resolve)
+	assert.NotNil(t, err)
+	_, err = encodeNamedArgs(params, []string{"key=a", "n=1", "ok=true"}, resolve)
+	assert.NotNil(t, err)
+	_, err = encodeNamedArgs(params, []string{"key=a", "n=1", "ok=true", "extra=1", "typo=1"}, resolve)
+	assert.NotNil(t, err)
+	_, err = encodeNamedArgs(params, []string{"key=a", "key=b", "n=1", "ok=true", "extra=1"}, resolve)
+	assert.NotNil(t, err)
+
+	params, err = abiParams(&rpcpb.Contract{Id: "token.iost", Language: "native", Abis: []*rpcpb.Contract_ABI{{Name: "supply", Args: []string{"string"}}}}, "supply")
+	assert.Nil(t, err)
+	assert.Equal(t, "token", params[0].Name)
+}
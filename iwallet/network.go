@@ -0,0 +1,204 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var network string
+
+// networkProfile is a named network, whose server and chain id are used unless given on the command line.
+type networkProfile struct {
+	Server  string `json:"server" mapstructure:"server"`
+	ChainID uint32 `json:"chain_id" mapstructure:"chain_id"`
+}
+
+// presetNetworks can be overridden and extended by the networks in the config file.
+var presetNetworks = map[string]*networkProfile{
+	"mainnet": {Server: "api.iost.io:30002", ChainID: 1024},
+	"testnet": {Server: "test.api.iost.io:30002", ChainID: 1023},
+	"local":   {Server: "localhost:30002", ChainID: 1024},
+}
+
+// networks returns the preset networks together with those in the networks section of the config file.
+func networks() (map[string]*networkProfile, error) {
+	all := make(map[string]*networkProfile)
+	for name, p := range presetNetworks {
+		all[name] = p
+	}
+	custom := make(map[string]*networkProfile)
+	if err := viper.UnmarshalKey("networks", &custom); err != nil {
+		return nil, fmt.Errorf("invalid networks in config file: %v", err)
+	}
+	for name, p := range custom {
+		all[name] = p
+	}
+	return all, nil
+}
+
+func findNetwork(name string) (*networkProfile, error) {
+	all, err := networks()
+	if err != nil {
+		return nil, err
+	}
+	p, ok := all[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %v, should be one of %v", name, networkNames(all))
+	}
+	return p, nil
+}
+
+func networkNames(all map[string]*networkProfile) []string {
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyNetwork sets the server and the chain id of the selected network over those in the config file, unless they
+// are given by flags or env variables.
+func applyNetwork(flags *pflag.FlagSet) error {
+	if network == "" {
+		return nil
+	}
+	p, err := findNetwork(network)
+	if err != nil {
+		return err
+	}
+	values := map[string]string{"server": p.Server, "chain_id": strconv.FormatUint(uint64(p.ChainID), 10)}
+	for key, value := range values {
+		if source := configSource(flags, key); source == "flag" || source == "env" {
+			continue
+		}
+		if err := flags.Lookup(key).Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %v of network %v: %v", key, network, err)
+		}
+	}
+	return nil
+}
+
+var configUseNetworkCmd = &cobra.Command{
+	Use:   "use-network name",
+	Short: "Switch the default network",
+	Long: `Switch the default network, whose server and chain id are used unless given on the command line
+	Transactions are only signed after checking the node is on the chain id of the network`,
+	Example: `  iwallet config use-network testnet
+  iwallet config use-network mainnet`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "name")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := findNetwork(args[0])
+		if err != nil {
+			return err
+		}
+		fileName, err := getConfigFile()
+		if err != nil {
+			return err
+		}
+		config, err := loadConfigFile(fileName)
+		if err != nil {
+			return err
+		}
+		config["network"] = args[0]
+		if err := saveConfigFile(fileName, config); err != nil {
+			return fmt.Errorf("failed to save config: %v", err)
+		}
+		if !isMachineOutput() {
+			fmt.Printf("Switched to network %v: server %v, chain id %v\n", args[0], p.Server, p.ChainID)
+		}
+		return nil
+	},
+}
+
+var configAddNetworkCmd = &cobra.Command{
+	Use:     "add-network name server chainID",
+	Short:   "Add a network or override a preset one",
+	Long:    `Add a network to the config file, or override the server of a preset network such as mainnet`,
+	Example: `  iwallet config add-network mynet 10.0.0.1:30002 1024`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "name", "server", "chainID"); err != nil {
+			return err
+		}
+		_, err := strconv.ParseUint(args[2], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid chain id %v", args[2])
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainID, _ := strconv.ParseUint(args[2], 10, 32)
+		fileName, err := getConfigFile()
+		if err != nil {
+			return err
+		}
+		config, err := loadConfigFile(fileName)
+		if err != nil {
+			return err
+		}
+		nets, ok := config["networks"].(map[interface{}]interface{})
+		if !ok {
+			nets = make(map[interface{}]interface{})
+		}
+		nets[args[0]] = map[string]interface{}{"server": args[1], "chain_id": chainID}
+		config["networks"] = nets
+		if err := saveConfigFile(fileName, config); err != nil {
+			return fmt.Errorf("failed to save config: %v", err)
+		}
+		if !isMachineOutput() {
+			fmt.Printf("Successfully added network %v in %v, switch to it by: iwallet config use-network %v\n", args[0], fileName, args[0])
+		}
+		return nil
+	},
+}
+
+var configNetworksCmd = &cobra.Command{
+	Use:     "networks",
+	Short:   "List the networks",
+	Long:    `List the preset networks and those in the config file, the selected one is marked by *`,
+	Example: `  iwallet config networks`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := networks()
+		if err != nil {
+			return err
+		}
+		if isMachineOutput() {
+			return printResult(all)
+		}
+		for _, name := range networkNames(all) {
+			mark := " "
+			if name == network {
+				mark = "*"
+			}
+			fmt.Printf("%v %-10v%-30vchain id %v\n", mark, name, all[name].Server, all[name].ChainID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configUseNetworkCmd)
+	configCmd.AddCommand(configAddNetworkCmd)
+	configCmd.AddCommand(configNetworksCmd)
+}
@@ -0,0 +1,61 @@
+package iwallet
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNetwork(t *testing.T) {
+	defer viper.Reset()
+	p, err := findNetwork("testnet")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1023), p.ChainID)
+	_, err = findNetwork("nonet")
+	assert.NotNil(t, err)
+
+	viper.Set("networks", map[string]interface{}{
+		"testnet": map[string]interface{}{"server": "my.testnet:30002", "chain_id": 1023},
+		"mynet":   map[string]interface{}{"server": "10.0.0.1:30002", "chain_id": 77},
+	})
+	p, err = findNetwork("testnet")
+	assert.Nil(t, err)
+	assert.Equal(t, "my.testnet:30002", p.Server)
+	p, err = findNetwork("mynet")
+	assert.Nil(t, err)
+	assert.Equal(t, &networkProfile{Server: "10.0.0.1:30002", ChainID: 77}, p)
+}
+
+func TestApplyNetwork(t *testing.T) {
+	defer func() { network = "" }()
+	var s string
+	var id uint32
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringVar(&s, "server", "localhost:30002", "")
+		flags.Uint32Var(&id, "chain_id", 1024, "")
+		assert.Nil(t, flags.Parse(args))
+		return flags
+	}
+
+	network = "testnet"
+	assert.Nil(t, applyNetwork(newFlags()))
+	assert.Equal(t, "test.api.iost.io:30002", s)
+	assert.Equal(t, uint32(1023), id)
+
+	assert.Nil(t, applyNetwork(newFlags("--server", "flag:30002")))
+	assert.Equal(t, "flag:30002", s)
+	assert.Equal(t, uint32(1023), id)
+
+	os.Setenv("IWALLET_CHAIN_ID", "5")
+	defer os.Unsetenv("IWALLET_CHAIN_ID")
+	assert.Nil(t, applyNetwork(newFlags()))
+	assert.Equal(t, "test.api.iost.io:30002", s)
+	assert.Equal(t, uint32(1024), id)
+
+	network = "nonet"
+	assert.NotNil(t, applyNetwork(newFlags()))
+}
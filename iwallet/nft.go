@@ -0,0 +1,245 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nftOffset int
+	nftLimit  int
+)
+
+type nftPage struct {
+	Balance  int64    `json:"balance"`
+	Offset   int      `json:"offset"`
+	TokenIDs []string `json:"token_ids"`
+}
+
+// paginate returns at most limit ids starting from offset. A non-positive limit means no limit.
+func paginate(ids []string, offset, limit int) []string {
+	if offset >= len(ids) {
+		return []string{}
+	}
+	ids = ids[offset:]
+	if limit > 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// token721IDs returns the balance of the owner and the ids of the tokens it holds, sorted, fetched a page at a time
+// until there are more than count of them, or all if count is 0.
+func token721IDs(owner string, token string, count int) (int64, []string, error) {
+	var ids []string
+	r := &rpcpb.GetToken721BalanceRequest{Account: owner, Token: token, ByLongestChain: useLongestChain}
+	for {
+		resp, err := iwalletSDK.GetToken721BalancePage(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		ids = append(ids, resp.TokenIDs...)
+		if resp.Cursor == "" || count > 0 && len(ids) > count {
+			return resp.Balance, ids, nil
+		}
+		r.Cursor = resp.Cursor
+	}
+}
+
+// decodeMetadata returns the metadata as json if it is valid json, or as the raw string otherwise.
+func decodeMetadata(metadata string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(metadata), &v); err != nil {
+		return metadata
+	}
+	return v
+}
+
+var nftCmd = &cobra.Command{
+	Use:   "nft",
+	Short: "Non-fungible token management",
+	Long:  `Create, issue, transfer and query non-fungible tokens of token721.iost`,
+}
+
+var nftCreateCmd = &cobra.Command{
+	Use:     "create symbol totalSupply",
+	Short:   "Create a non-fungible token",
+	Long:    `Create a non-fungible token issued by --issuer (default the account)`,
+	Example: `  iwallet nft create kitty 10000 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "totalSupply"); err != nil {
+			return err
+		}
+		if n, err := strconv.ParseInt(args[1], 10, 64); err != nil || n <= 0 {
+			return fmt.Errorf("invalid total supply %v, should be a positive integer", args[1])
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issuer := tokenIssuer
+		if issuer == "" {
+			issuer = accountName
+		}
+		totalSupply, _ := strconv.ParseInt(args[1], 10, 64)
+		return sendAction("token721.iost", "create", args[0], issuer, totalSupply)
+	},
+}
+
+var nftIssueCmd = &cobra.Command{
+	Use:     "issue symbol receiver metadata",
+	Short:   "Issue a non-fungible token",
+	Long:    `Issue a new non-fungible token with the metadata to the receiver as the issuer. The token id is the index of the token.`,
+	Example: `  iwallet nft issue kitty user0001 '{"name":"tom","color":"grey"}' --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "receiver", "metadata"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		receiver, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		return sendAction("token721.iost", "issue", args[0], receiver, args[2])
+	},
+}
+
+var nftTransferCmd = &cobra.Command{
+	Use:     "transfer symbol receiver tokenID",
+	Short:   "Transfer a non-fungible token",
+	Long:    `Transfer a non-fungible token owned by the account to the receiver`,
+	Example: `  iwallet nft transfer kitty @alice 0 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "receiver", "tokenID"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		receiver, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		return sendAction("token721.iost", "transfer", args[0], accountName, receiver, args[2])
+	},
+}
+
+var nftOwnerCmd = &cobra.Command{
+	Use:     "owner symbol tokenID",
+	Short:   "Show the owner of a non-fungible token",
+	Long:    `Show the owner of a non-fungible token`,
+	Example: `  iwallet nft owner kitty 0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "symbol", "tokenID")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := iwalletSDK.GetToken721Owner(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if isMachineOutput() {
+			return printResult(resp)
+		}
+		fmt.Println(resp.Owner)
+		return nil
+	},
+}
+
+var nftMetadataCmd = &cobra.Command{
+	Use:     "metadata symbol tokenID",
+	Short:   "Show the metadata of a non-fungible token",
+	Long:    `Show the metadata of a non-fungible token, decoded if it is json`,
+	Example: `  iwallet nft metadata kitty 0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "symbol", "tokenID")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := iwalletSDK.GetToken721Metadata(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		metadata := decodeMetadata(resp.Metadata)
+		if s, ok := metadata.(string); ok && !isMachineOutput() {
+			fmt.Println(s)
+			return nil
+		}
+		return printResult(metadata)
+	},
+}
+
+var nftListCmd = &cobra.Command{
+	Use:     "list symbol accountName",
+	Aliases: []string{"ls"},
+	Short:   "List the non-fungible tokens owned by an account",
+	Long:    `List the ids of the non-fungible tokens owned by an account, page by page with --offset and --limit`,
+	Example: `  iwallet nft list kitty user0001
+  iwallet nft list kitty user0001 --offset 100 --limit 50`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "accountName"); err != nil {
+			return err
+		}
+		if nftOffset < 0 {
+			return fmt.Errorf("invalid offset %v", nftOffset)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		count := 0
+		if nftLimit > 0 {
+			count = nftOffset + nftLimit
+		}
+		balance, ids, err := token721IDs(owner, args[0], count)
+		if err != nil {
+			return err
+		}
+		page := &nftPage{Balance: balance, Offset: nftOffset, TokenIDs: paginate(ids, nftOffset, nftLimit)}
+		if isMachineOutput() {
+			return printResult(page)
+		}
+		fmt.Printf("%v owns %v %v token(s)\n", owner, balance, args[0])
+		for _, id := range page.TokenIDs {
+			fmt.Println(id)
+		}
+		if next := nftOffset + len(page.TokenIDs); next < len(ids) {
+			fmt.Printf("More tokens with --offset %v\n", next)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nftCmd)
+
+	nftCmd.AddCommand(nftCreateCmd)
+	nftCreateCmd.Flags().StringVarP(&tokenIssuer, "issuer", "", "", "issuer of the token (default the account)")
+	nftCmd.AddCommand(nftIssueCmd)
+	nftCmd.AddCommand(nftTransferCmd)
+	nftCmd.AddCommand(nftOwnerCmd)
+	nftCmd.AddCommand(nftMetadataCmd)
+	nftCmd.AddCommand(nftListCmd)
+	nftListCmd.Flags().IntVarP(&nftOffset, "offset", "", 0, "index of the first token id to show")
+	nftListCmd.Flags().IntVarP(&nftLimit, "limit", "", 100, "max number of token ids to show, 0 for all")
+}
@@ -0,0 +1,20 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	ids := []string{"0", "1", "2", "3", "4"}
+	assert.Equal(t, []string{"0", "1"}, paginate(ids, 0, 2))
+	assert.Equal(t, []string{"3", "4"}, paginate(ids, 3, 10))
+	assert.Equal(t, ids, paginate(ids, 0, 0))
+	assert.Equal(t, []string{}, paginate(ids, 5, 2))
+}
+
+func TestDecodeMetadata(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"name": "tom"}, decodeMetadata(`{"name":"tom"}`))
+	assert.Equal(t, "plain", decodeMetadata("plain"))
+}
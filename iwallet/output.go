@@ -0,0 +1,223 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/sdk"
+	"gopkg.in/yaml.v2"
+)
+
+// output formats
+const (
+	outputText  = ""
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+var outputFormat string
+
+func checkOutputFormat(format string) error {
+	switch format {
+	case outputText, outputJSON, outputYAML, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %v, should be one of json, yaml and table", format)
+	}
+}
+
+// isMachineOutput reports whether structured output is required, in which case nothing but the result should go to stdout.
+func isMachineOutput() bool {
+	return outputFormat != outputText
+}
+
+// printResult prints the result of a command in the format given by --output_format.
+// Protobuf messages use the field names of the rpc api, so the schema is the same as the json rpc gateway.
+func printResult(v interface{}) error {
+	return writeResult(os.Stdout, outputFormat, v)
+}
+
+func writeResult(w io.Writer, format string, v interface{}) error {
+	if format == outputText {
+		if pb, ok := v.(proto.Message); ok {
+			_, err := fmt.Fprintln(w, sdk.MarshalTextString(pb))
+			return err
+		}
+		data, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case outputJSON:
+		data, err := json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputYAML:
+		data, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case outputTable:
+		return writeTable(w, generic)
+	default:
+		return checkOutputFormat(format)
+	}
+}
+
+// toGeneric converts v into maps, slices and scalars through json.
+func toGeneric(v interface{}) (interface{}, error) {
+	var data []byte
+	var err error
+	if pb, ok := v.(proto.Message); ok {
+		var s string
+		s, err = (&jsonpb.Marshaler{EmitDefaults: true, OrigName: true}).MarshalToString(pb)
+		data = []byte(s)
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// writeTable prints a list of objects as rows with a header, and anything else as flattened key value pairs.
+func writeTable(w io.Writer, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if list, ok := v.([]interface{}); ok && len(list) > 0 && isObjectList(list) {
+		var header []string
+		seen := make(map[string]bool)
+		rows := make([]map[string]string, 0, len(list))
+		for _, item := range list {
+			row := make(map[string]string)
+			flatten("", item, row)
+			for k := range row {
+				if !seen[k] {
+					seen[k] = true
+					header = append(header, k)
+				}
+			}
+			rows = append(rows, row)
+		}
+		sort.Strings(header)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			cells := make([]string, len(header))
+			for i, h := range header {
+				cells[i] = row[h]
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	}
+	kv := make(map[string]string)
+	flatten("", v, kv)
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(tw, "%v\t%v\n", k, kv[k])
+	}
+	return tw.Flush()
+}
+
+func isObjectList(list []interface{}) bool {
+	for _, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func flatten(prefix string, v interface{}, out map[string]string) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 && prefix != "" {
+			out[prefix] = "{}"
+		}
+		for k, item := range t {
+			flatten(join(k), item, out)
+		}
+	case []interface{}:
+		if len(t) == 0 && prefix != "" {
+			out[prefix] = "[]"
+		}
+		for i, item := range t {
+			flatten(join(fmt.Sprint(i)), item, out)
+		}
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(t)
+	}
+}
+
+// printTxHash prints the hash of a sent tx in machine output mode. In text mode the sdk has already logged it, so only
+// the way to check an --async tx later is shown.
+func printTxHash(txHash string) error {
+	if !isMachineOutput() {
+		if async {
+			fmt.Println("Check the result later by: iwallet receipt", txHash, "--wait")
+		}
+		if delay > 0 {
+			fmt.Printf("The transaction is executed after %v, cancel it before by: iwallet tx cancel-delayed %v\n", delay, txHash)
+		}
+		return nil
+	}
+	return printResult(map[string]string{"tx_hash": txHash})
+}
+
+// printError prints the error as a structured result with its code, so scripts can branch on failures too.
+func printError(err error) {
+	writeResult(os.Stdout, outputFormat, newErrorResult(err))
+}
@@ -0,0 +1,30 @@
+package iwallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteResult(t *testing.T) {
+	balance := &rpcpb.GetTokenBalanceResponse{Balance: 1.5, FrozenBalances: []*rpcpb.FrozenBalance{}}
+	var buf bytes.Buffer
+	assert.Nil(t, writeResult(&buf, outputJSON, balance))
+	assert.Equal(t, "{\n  \"balance\": 1.5,\n  \"frozen_balances\": []\n}\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, writeResult(&buf, outputYAML, map[string]string{"tx_hash": "abc"}))
+	assert.Equal(t, "tx_hash: abc\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, writeResult(&buf, outputTable, []map[string]interface{}{{"name": "a", "n": 1}, {"name": "bb", "n": 22}}))
+	assert.Equal(t, "N   NAME\n1   a\n22  bb\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, writeResult(&buf, outputTable, map[string]interface{}{"a": map[string]interface{}{"b": 1}, "c": []int{}}))
+	assert.Equal(t, "a.b  1\nc    []\n", buf.String())
+
+	assert.NotNil(t, checkOutputFormat("xml"))
+}
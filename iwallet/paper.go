@@ -0,0 +1,266 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	// decoders of the image formats accepted by --qr_image
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/iwallet/qrcode"
+)
+
+// paperEntry is a value printed on the paper wallet both as a qr code and as text.
+type paperEntry struct {
+	Label string
+	Text  string
+}
+
+// paperEntries lists the account name, then the public and private keys of every permission, a key shared by
+// several permissions once. The private key codes hold what "account import" takes, with the permission if needed.
+func paperEntries(a *AccountInfo) []paperEntry {
+	perms := make(map[string][]string)
+	var keys []string
+	for perm, kp := range a.Keypairs {
+		if _, ok := perms[kp.RawKey]; !ok {
+			keys = append(keys, kp.RawKey)
+		}
+		perms[kp.RawKey] = append(perms[kp.RawKey], perm)
+	}
+	for _, k := range keys {
+		sort.Strings(perms[k])
+	}
+	sort.Slice(keys, func(i, j int) bool { return perms[keys[i]][0] < perms[keys[j]][0] })
+
+	entries := []paperEntry{{Label: "Account name", Text: a.Name}}
+	for _, k := range keys {
+		kp := a.Keypairs[perms[k][0]]
+		names := strings.Join(perms[k], ", ")
+		text := k
+		if len(keys) > 1 {
+			text = perms[k][0] + ":" + k
+		}
+		entries = append(entries,
+			paperEntry{Label: fmt.Sprintf("Public key (%v, %v)", names, kp.KeyType), Text: kp.PubKey},
+			paperEntry{Label: fmt.Sprintf("PRIVATE KEY (%v)", names), Text: text},
+		)
+	}
+	return entries
+}
+
+// pdfText escapes a string for a pdf literal string.
+func pdfText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	return "(" + r.Replace(s) + ")"
+}
+
+// wrapText breaks the text into lines of at most width characters, at spaces if possible.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for len(s) > width {
+		i := strings.LastIndex(s[:width+1], " ")
+		if i <= 0 {
+			lines = append(lines, s[:width])
+			s = s[width:]
+			continue
+		}
+		lines = append(lines, s[:i])
+		s = s[i+1:]
+	}
+	return append(lines, s)
+}
+
+// qrPixels renders the code with one gray byte per module and a 4 modules quiet zone.
+func qrPixels(c *qrcode.Code) (int, []byte) {
+	n := c.Size + 8
+	pixels := make([]byte, n*n)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if !c.Dark(x-4, y-4) {
+				pixels[y*n+x] = 0xff
+			}
+		}
+	}
+	return n, pixels
+}
+
+// writePaperWallet writes a one page A4 pdf with the entries. The pdf is written by hand, embedding the qr codes as
+// deflated gray images and using the standard fonts, which every viewer has.
+func writePaperWallet(w io.Writer, a *AccountInfo, now time.Time) error {
+	const (
+		pageW, pageH = 595, 842
+		margin       = 50
+		qrSize       = 120
+		rowH         = 135
+	)
+	var content bytes.Buffer
+	text := func(font string, size int, x, y int, s string) {
+		fmt.Fprintf(&content, "BT /%v %v Tf %v %v Td %v Tj ET\n", font, size, x, y, pdfText(s))
+	}
+	y := pageH - margin - 20
+	text("F2", 20, margin, y, "IOST Paper Wallet")
+	y -= 22
+	text("F1", 10, margin, y, "Created by iwallet at "+now.Format("2006-01-02 15:04:05 MST"))
+	y -= 14
+	text("F2", 10, margin, y, "Anyone who sees the private key can take the account. Keep this paper offline and safe.")
+	y -= 14
+	text("F1", 10, margin, y, fmt.Sprintf("Import by: iwallet account import %v --qr_image <photo of the private key code>", a.Name))
+	if a.Derivation != nil {
+		y -= 14
+		text("F1", 10, margin, y, fmt.Sprintf("The keys were derived from a %v words mnemonic at %v, which is not kept by iwallet.",
+			a.Derivation.WordCount, a.Derivation.Path))
+	}
+	y -= 20
+
+	var images [][]byte
+	var sizes []int
+	for i, e := range paperEntries(a) {
+		c, err := qrcode.Encode([]byte(e.Text), qrcode.M)
+		if err != nil {
+			return err
+		}
+		n, pixels := qrPixels(c)
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		if _, err := zw.Write(pixels); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		images = append(images, buf.Bytes())
+		sizes = append(sizes, n)
+
+		y -= rowH
+		fmt.Fprintf(&content, "q %v 0 0 %v %v %v cm /Im%v Do Q\n", qrSize, qrSize, margin, y, i)
+		x := margin + qrSize + 15
+		ty := y + qrSize - 20
+		text("F2", 12, x, ty, e.Label)
+		for _, line := range wrapText(e.Text, 52) {
+			ty -= 14
+			text("F3", 10, x, ty, line)
+		}
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"", // the page, which refers to the images
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %v >>\nstream\n%vendstream", content.Len(), content.String()),
+	}
+	var xobjects []string
+	for i, data := range images {
+		xobjects = append(xobjects, fmt.Sprintf("/Im%v %v 0 R", i, len(objects)+1))
+		objects = append(objects, fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %v /Height %v /ColorSpace /DeviceGray "+
+			"/BitsPerComponent 8 /Interpolate false /Filter /FlateDecode /Length %v >>\nstream\n%v\nendstream",
+			sizes[i], sizes[i], len(data), string(data)))
+	}
+	objects[2] = fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %v %v] /Contents 7 0 R "+
+		"/Resources << /Font << /F1 4 0 R /F2 5 0 R /F3 6 0 R >> /XObject << %v >> >> >>",
+		pageW, pageH, strings.Join(xobjects, " "))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%v 0 obj\n%v\nendobj\n", i+1, o)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %v\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %v /Root 1 0 R >>\nstartxref\n%v\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// confirmPaperExport makes the user type the account name, since the paper holds the private keys in plain text.
+func confirmPaperExport(name string) error {
+	if assumeYes {
+		return nil
+	}
+	if isMachineOutput() {
+		return fmt.Errorf("the paper wallet holds the private keys in plain text, export with --yes to confirm")
+	}
+	fmt.Println("WARNING: the paper wallet holds the private keys of", name, "in plain text, anyone who sees it can take the account.")
+	fmt.Println("Print it on a printer you trust and remove the file afterwards.")
+	fmt.Printf("Type the account name to continue: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("export canceled: %v", err)
+	}
+	if strings.TrimSpace(line) != name {
+		return fmt.Errorf("export canceled")
+	}
+	return nil
+}
+
+func exportPaperWallet(name string, file string) error {
+	acc, err := loadAccountByName(name, true)
+	if err != nil {
+		return err
+	}
+	if err := confirmPaperExport(acc.Name); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create paper wallet: %v", err)
+	}
+	if err := writePaperWallet(f, acc, time.Now()); err != nil {
+		f.Close()
+		os.Remove(file)
+		return fmt.Errorf("failed to write paper wallet: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Println("Paper wallet is saved at:", file)
+	return nil
+}
+
+// readQRImage decodes the qr code in a png, jpeg or gif image file.
+func readQRImage(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image %v: %v", file, err)
+	}
+	data, err := qrcode.Decode(img)
+	if err != nil {
+		return "", fmt.Errorf("failed to read qr code in %v: %v", file, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
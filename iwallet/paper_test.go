@@ -0,0 +1,77 @@
+package iwallet
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/iwallet/qrcode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaperEntries(t *testing.T) {
+	kp := &KeyPairInfo{RawKey: "secret", PubKey: "public", KeyType: "ed25519"}
+	a := &AccountInfo{Name: "test0", Keypairs: map[string]*KeyPairInfo{"active": kp, "owner": kp}}
+	assert.Equal(t, []paperEntry{
+		{Label: "Account name", Text: "test0"},
+		{Label: "Public key (active, owner, ed25519)", Text: "public"},
+		{Label: "PRIVATE KEY (active, owner)", Text: "secret"},
+	}, paperEntries(a))
+
+	a.Keypairs["owner"] = &KeyPairInfo{RawKey: "secret2", PubKey: "public2", KeyType: "ed25519"}
+	entries := paperEntries(a)
+	assert.Len(t, entries, 5)
+	assert.Equal(t, "active:secret", entries[2].Text)
+	assert.Equal(t, "owner:secret2", entries[4].Text)
+}
+
+func TestWritePaperWallet(t *testing.T) {
+	kp := &KeyPairInfo{RawKey: "2yquS3ySrGWPEKywCPzX4RTJugqRh7kJSo5aehsLYPEWkUxBWA39oMrZ7ZxuM4fgyXYs2cPwh5n8aNNpH5x2VyK1", PubKey: "Gcv8c2tH8qZrUYnKdEEdTtASsxivic2834MQW6mgxqto", KeyType: "ed25519"}
+	a := &AccountInfo{Name: "test0", Keypairs: map[string]*KeyPairInfo{"active": kp, "owner": kp}}
+	var buf bytes.Buffer
+	assert.Nil(t, writePaperWallet(&buf, a, time.Unix(1544013436, 0)))
+	pdf := buf.String()
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4\n")))
+	assert.Contains(t, pdf, "/Im2 10 0 R")
+	assert.Contains(t, pdf, "(Gcv8c2tH8qZrUYnKdEEdTtASsxivic2834MQW6mgxqto) Tj")
+
+	// the xref points at every object
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindStringSubmatch(pdf)
+	assert.Len(t, m, 2)
+	xref, _ := strconv.Atoi(m[1])
+	assert.True(t, bytes.HasPrefix(buf.Bytes()[xref:], []byte("xref\n0 11\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(pdf, -1)
+	assert.Len(t, offsets, 10)
+	for i, o := range offsets {
+		off, _ := strconv.Atoi(o[1])
+		assert.True(t, bytes.HasPrefix(buf.Bytes()[off:], []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+	}
+}
+
+func TestReadQRImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paper")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	key := "active:2yquS3ySrGWPEKywCPzX4RTJugqRh7kJSo5aehsLYPEWkUxBWA39oMrZ7ZxuM4fgyXYs2cPwh5n8aNNpH5x2VyK1"
+	c, err := qrcode.Encode([]byte(key), qrcode.M)
+	assert.Nil(t, err)
+	file := filepath.Join(dir, "key.png")
+	f, err := os.Create(file)
+	assert.Nil(t, err)
+	assert.Nil(t, png.Encode(f, c.Image(5)))
+	assert.Nil(t, f.Close())
+
+	text, err := readQRImage(file)
+	assert.Nil(t, err)
+	assert.Equal(t, key, text)
+
+	_, err = readQRImage(filepath.Join(dir, "missing.png"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,239 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	newPasswordFile string
+	scryptN         int
+	scryptP         int
+)
+
+func checkScryptParams(n int, p int) error {
+	if n <= 1 || n&(n-1) != 0 {
+		return fmt.Errorf("invalid scrypt n %v, should be a power of 2 greater than 1", n)
+	}
+	if p < 1 {
+		return fmt.Errorf("invalid scrypt p %v, should be at least 1", p)
+	}
+	return nil
+}
+
+// readNewPassword reads the password to encrypt with from --new_password_file, or asks for it twice on the terminal.
+// The other password sources are left for the current password.
+func readNewPassword(interactiveOnly bool) ([]byte, error) {
+	if newPasswordFile != "" {
+		if interactiveOnly {
+			return nil, fmt.Errorf("the account requires the password to be typed on a terminal")
+		}
+		data, err := ioutil.ReadFile(newPasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read new password file: %v", err)
+		}
+		password := firstLine(data)
+		if len(password) == 0 {
+			return nil, fmt.Errorf("new password file %v is empty", newPasswordFile)
+		}
+		return password, nil
+	}
+	if !terminal.IsTerminal(int(syscall.Stdin)) {
+		return nil, fmt.Errorf("no terminal to read the new password from, use --new_password_file")
+	}
+	fmt.Println("Enter the new password")
+	for {
+		password, err := readPasswordFromStdin(true)
+		if err != nil {
+			return nil, err
+		}
+		if len(password) != 0 {
+			return password, nil
+		}
+		fmt.Println("password should not be empty, retry")
+	}
+}
+
+// encryptAccount encrypts the decrypted keys of the account by the new password with the kdf parameters given.
+func encryptAccount(a *AccountInfo, password []byte, n int, p int) error {
+	for perm, kp := range a.Keypairs {
+		if kp.EncryptedKey != "" && kp.RawKey == "" {
+			// the same key pair of several permissions is already encrypted
+			continue
+		}
+		if err := kp.reencrypt(password, n, p); err != nil {
+			return fmt.Errorf("failed to encrypt %v key: %v", perm, err)
+		}
+		kp.RawKey = ""
+	}
+	return nil
+}
+
+// loadKeystoreSecrets loads the account file with its secret keys, from the keychain too, but without decrypting them.
+func loadKeystoreSecrets(fileName string) (*AccountInfo, error) {
+	a, err := loadAccountFromKeyStore(fileName, false)
+	if err != nil {
+		return nil, err
+	}
+	if a.WatchOnly {
+		return nil, errWatchOnly(a.Name)
+	}
+	if a.Store == storeKeychain {
+		if err := a.loadFromKeychain(); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func keystoreFileName(name string) (string, error) {
+	dir, err := getAccountDir()
+	if err != nil {
+		return "", err
+	}
+	return dir + "/" + name + ".json", nil
+}
+
+var passwdCmd = &cobra.Command{
+	Use:   "passwd accountName",
+	Short: "Change the password of an encrypted account",
+	Long: `Decrypt the keys of an encrypted account by the current password, encrypt them again by a new password with
+	the scrypt parameters given, and replace the account file at once so that the keys are never left half written.
+	The current password can be given by --password_file, --password_stdin or IWALLET_PASSWORD, the new one by --new_password_file`,
+	Example: `  iwallet account passwd test0
+  iwallet account passwd test0 --password_file old.txt --new_password_file new.txt`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
+			return err
+		}
+		return checkScryptParams(scryptN, scryptP)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		fileName, err := keystoreFileName(name)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(fileName); err != nil {
+			return fmt.Errorf("no keystore of account %v, encrypt a legacy key file by \"iwallet account encrypt %v\"", name, name)
+		}
+		a, err := loadKeystoreSecrets(fileName)
+		if err != nil {
+			return fmt.Errorf("failed to load account %v: %v", name, err)
+		}
+		if !a.isEncrypted() {
+			return fmt.Errorf("account %v is not encrypted, encrypt it by \"iwallet account encrypt %v\"", name, name)
+		}
+		fmt.Println("Enter the current password")
+		if err := a.decrypt(); err != nil {
+			return err
+		}
+		password, err := readNewPassword(a.RequireInteractive)
+		if err != nil {
+			return err
+		}
+		if err := encryptAccount(a, password, scryptN, scryptP); err != nil {
+			return err
+		}
+		if err := a.writeTo(fileName); err != nil {
+			return fmt.Errorf("failed to save account %v: %v", name, err)
+		}
+		fmt.Println("Successfully changed the password of", name)
+		return nil
+	},
+}
+
+var encryptAccountCmd = &cobra.Command{
+	Use:   "encrypt accountName",
+	Short: "Encrypt the keys of a plaintext account",
+	Long: `Encrypt an account kept in plaintext, either a keystore saved without --encrypt or a legacy key file such as
+	~/.iwallet/accountName_ed25519, which is upgraded to an encrypted keystore and removed with its .pub file then`,
+	Example: `  iwallet account encrypt test0
+  iwallet account encrypt test0 --new_password_file password.txt`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "accountName"); err != nil {
+			return err
+		}
+		return checkScryptParams(scryptN, scryptP)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		fileName, err := keystoreFileName(name)
+		if err != nil {
+			return err
+		}
+		var a *AccountInfo
+		var legacyFile string
+		if _, err := os.Stat(fileName); err == nil {
+			if a, err = loadKeystoreSecrets(fileName); err != nil {
+				return fmt.Errorf("failed to load account %v: %v", name, err)
+			}
+			if a.isEncrypted() {
+				return fmt.Errorf("account %v is already encrypted, change its password by \"iwallet account passwd %v\"", name, name)
+			}
+		} else {
+			for _, algo := range ValidSignAlgos {
+				f := fileName[:len(fileName)-len(".json")] + "_" + algo
+				if _, err := os.Stat(f); err == nil {
+					legacyFile = f
+					break
+				}
+			}
+			if legacyFile == "" {
+				return fmt.Errorf("account %v does not exist", name)
+			}
+			if a, err = loadAccountFromKeyPair(legacyFile); err != nil {
+				return fmt.Errorf("failed to load key file %v: %v", legacyFile, err)
+			}
+		}
+		password, err := readNewPassword(a.RequireInteractive)
+		if err != nil {
+			return err
+		}
+		if err := encryptAccount(a, password, scryptN, scryptP); err != nil {
+			return err
+		}
+		if err := a.writeTo(fileName); err != nil {
+			return fmt.Errorf("failed to save account %v: %v", name, err)
+		}
+		fmt.Println("Encrypted keystore is saved at:", fileName)
+		if legacyFile != "" {
+			for _, f := range []string{legacyFile, legacyFile + ".pub"} {
+				if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to remove plaintext key file %v: %v", f, err)
+				}
+				fmt.Println("File", f, "has been removed.")
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(passwdCmd)
+	accountCmd.AddCommand(encryptAccountCmd)
+	for _, cmd := range []*cobra.Command{passwdCmd, encryptAccountCmd} {
+		cmd.Flags().StringVarP(&newPasswordFile, "new_password_file", "", "", "read the new password from the first line of this file instead of the terminal")
+		cmd.Flags().IntVarP(&scryptN, "scrypt_n", "", keystoreV3ScryptN, "scrypt cost parameter n of the encryption, a power of 2")
+		cmd.Flags().IntVarP(&scryptP, "scrypt_p", "", keystoreV3ScryptP, "scrypt parallelization parameter p of the encryption")
+	}
+}
@@ -0,0 +1,76 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckScryptParams(t *testing.T) {
+	assert.Nil(t, checkScryptParams(262144, 1))
+	assert.NotNil(t, checkScryptParams(1000, 1))
+	assert.NotNil(t, checkScryptParams(1, 1))
+	assert.NotNil(t, checkScryptParams(1024, 0))
+}
+
+func TestEncryptAndChangePassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "home")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+	defer os.Unsetenv(passwordEnv)
+	scryptN, scryptP = 1024, 1
+	defer func() { scryptN, scryptP, newPasswordFile = keystoreV3ScryptN, keystoreV3ScryptP, "" }()
+
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	assert.Nil(t, SaveAccount("alice", kp))
+	newPasswordFile = filepath.Join(dir, "new.txt")
+	assert.Nil(t, ioutil.WriteFile(newPasswordFile, []byte("first\n"), 0600))
+
+	// the legacy key file is upgraded to an encrypted keystore
+	assert.Nil(t, encryptAccountCmd.RunE(encryptAccountCmd, []string{"alice"}))
+	_, err = os.Stat(filepath.Join(dir, ".iwallet", "alice_ed25519"))
+	assert.True(t, os.IsNotExist(err))
+	a, err := loadAccountByName("alice", false)
+	assert.Nil(t, err)
+	assert.True(t, a.isEncrypted())
+	assert.Equal(t, "v1", a.Keypairs["active"].EncryptMethod)
+	assert.Equal(t, 1024, a.Keypairs["active"].ScryptN)
+	assert.Contains(t, encryptAccountCmd.RunE(encryptAccountCmd, []string{"alice"}).Error(), "already encrypted")
+
+	os.Setenv(passwordEnv, "first")
+	assert.Nil(t, ioutil.WriteFile(newPasswordFile, []byte("second\n"), 0600))
+	assert.Nil(t, passwdCmd.RunE(passwdCmd, []string{"alice"}))
+	_, err = os.Stat(filepath.Join(dir, ".iwallet", "alice.json.tmp"))
+	assert.True(t, os.IsNotExist(err))
+
+	os.Setenv(passwordEnv, "second")
+	a, err = loadAccountByName("alice", true)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Base58Encode(kp.Seckey), a.Keypairs["active"].RawKey)
+	assert.Equal(t, common.Base58Encode(kp.Seckey), a.Keypairs["owner"].RawKey)
+
+	os.Setenv(passwordEnv, "first")
+	_, err = loadAccountByName("alice", true)
+	assert.Contains(t, err.Error(), "wrong password")
+
+	// the sdk signs with the keystore iwallet encrypted
+	signer, err := sdk.LoadKeystoreSigner(filepath.Join(dir, ".iwallet", "alice.json"), "owner", []byte("second"))
+	assert.Nil(t, err)
+	assert.Equal(t, kp.Pubkey, signer.PubKey())
+	_, err = sdk.LoadKeystoreSigner(filepath.Join(dir, ".iwallet", "alice.json"), "active", []byte("first"))
+	assert.Contains(t, err.Error(), "wrong password")
+}
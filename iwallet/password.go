@@ -0,0 +1,181 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// passwordEnv is the env variable holding the keystore password for scripts.
+const passwordEnv = "IWALLET_PASSWORD"
+
+var (
+	passwordFile   string
+	passwordStdin  bool
+	interactiveOff bool
+
+	// password read from stdin, which can only be read once
+	stdinPassword []byte
+)
+
+// firstLine is the password in a file or stdin, without the line ending.
+func firstLine(data []byte) []byte {
+	return []byte(strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r"))
+}
+
+// readPasswordFromSource reads the password from --password_file, --password_stdin or IWALLET_PASSWORD in this order.
+// It returns nil if none of them is given.
+func readPasswordFromSource(stdin io.Reader) ([]byte, string, error) {
+	if passwordFile != "" {
+		info, err := os.Stat(passwordFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read password file: %v", err)
+		}
+		if info.Mode().Perm()&0077 != 0 {
+			fmt.Fprintf(os.Stderr, "WARNING: password file %v is accessible by other users, it should be mode 0600\n", passwordFile)
+		}
+		data, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read password file: %v", err)
+		}
+		return firstLine(data), "--password_file", nil
+	}
+	if passwordStdin {
+		if stdinPassword == nil {
+			line, err := bufio.NewReader(stdin).ReadString('\n')
+			if err != nil && (err != io.EOF || line == "") {
+				return nil, "", fmt.Errorf("failed to read password from stdin: %v", err)
+			}
+			stdinPassword = firstLine([]byte(line))
+		}
+		return stdinPassword, "stdin", nil
+	}
+	if p, ok := os.LookupEnv(passwordEnv); ok {
+		return []byte(p), passwordEnv, nil
+	}
+	return nil, "", nil
+}
+
+// readPassword returns the keystore password given by a non-interactive source, or asks for it on the terminal.
+// If interactiveOnly is set, as for accounts marked by "account require-interactive", the terminal is always used.
+// It also tells whether the password was typed, since a wrong password is only worth retrying then.
+func readPassword(repeat bool, interactiveOnly bool) ([]byte, bool, error) {
+	if !interactiveOnly {
+		password, source, err := readPasswordFromSource(os.Stdin)
+		if err != nil {
+			return nil, false, err
+		}
+		if password != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: using keystore password from %v, make sure it is not logged or shared\n", source)
+			return password, false, nil
+		}
+	} else if passwordFile != "" || passwordStdin || !terminal.IsTerminal(int(syscall.Stdin)) {
+		return nil, false, fmt.Errorf("the account requires the password to be typed on a terminal")
+	}
+	if !terminal.IsTerminal(int(syscall.Stdin)) {
+		return nil, false, fmt.Errorf("no terminal to read the password from, use --password_file, --password_stdin or %v", passwordEnv)
+	}
+	password, err := readPasswordFromStdin(repeat)
+	return password, true, err
+}
+
+// readSecretFromStdin reads a secret such as a private key from the terminal without echoing it, or the first line
+// of stdin if it is piped.
+func readSecretFromStdin(stdin io.Reader) (string, error) {
+	var data []byte
+	if f, ok := stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fmt.Print("Enter the private key or mnemonic: ")
+		var err error
+		data, err = terminal.ReadPassword(int(f.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read from terminal: %v", err)
+		}
+	} else {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", fmt.Errorf("failed to read from stdin: %v", err)
+		}
+		data = firstLine([]byte(line))
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("no private key given on stdin")
+	}
+	return secret, nil
+}
+
+// setRequireInteractive updates the flag in the keystore file without decrypting the keys.
+func setRequireInteractive(name string, on bool) error {
+	dir, err := getAccountDir()
+	if err != nil {
+		return err
+	}
+	fileName := dir + "/" + name + ".json"
+	a, err := loadAccountFromKeyStore(fileName, false)
+	if err != nil {
+		return fmt.Errorf("failed to load account %v: %v", name, err)
+	}
+	a.RequireInteractive = on
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := fileName + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0400); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fileName)
+}
+
+var requireInteractiveCmd = &cobra.Command{
+	Use:   "require-interactive accountName",
+	Short: "Require the password of an account to be typed on a terminal",
+	Long: `Require the password of an encrypted account to be typed on a terminal, so that --password_file, --password_stdin
+	and IWALLET_PASSWORD are refused for it. This is meant for high-value accounts on machines which also run scripts`,
+	Example: `  iwallet account require-interactive test0
+  iwallet account require-interactive test0 --off`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "accountName")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := setRequireInteractive(args[0], !interactiveOff); err != nil {
+			return err
+		}
+		if interactiveOff {
+			fmt.Println("The password of", args[0], "can be given by non-interactive sources now")
+		} else {
+			fmt.Println("The password of", args[0], "must be typed on a terminal now")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&passwordFile, "password_file", "", "", "read the keystore password from the first line of this file instead of the terminal")
+	rootCmd.PersistentFlags().BoolVarP(&passwordStdin, "password_stdin", "", false, "read the keystore password from the first line of stdin instead of the terminal")
+	accountCmd.AddCommand(requireInteractiveCmd)
+	requireInteractiveCmd.Flags().BoolVarP(&interactiveOff, "off", "", false, "allow non-interactive password sources again")
+}
@@ -0,0 +1,56 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPasswordFromSource(t *testing.T) {
+	defer func() {
+		passwordFile, passwordStdin, stdinPassword = "", false, nil
+	}()
+	os.Unsetenv(passwordEnv)
+	p, _, err := readPasswordFromSource(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Nil(t, p)
+
+	os.Setenv(passwordEnv, "env")
+	defer os.Unsetenv(passwordEnv)
+	p, source, err := readPasswordFromSource(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Equal(t, "env", string(p))
+	assert.Equal(t, passwordEnv, source)
+
+	passwordStdin = true
+	p, _, err = readPasswordFromSource(strings.NewReader("piped\r\nnext line\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "piped", string(p))
+	p, _, err = readPasswordFromSource(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Equal(t, "piped", string(p))
+
+	f, err := ioutil.TempFile("", "password")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+	f.WriteString("from file\n")
+	f.Close()
+	passwordFile = f.Name()
+	p, _, err = readPasswordFromSource(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Equal(t, "from file", string(p))
+}
+
+func TestReadSecretFromStdin(t *testing.T) {
+	s, err := readSecretFromStdin(strings.NewReader("  2yquS3ySrGWPEKyw \r\nnext line\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2yquS3ySrGWPEKyw", s)
+	s, err = readSecretFromStdin(strings.NewReader("word1 word2 word3"))
+	assert.Nil(t, err)
+	assert.Equal(t, "word1 word2 word3", s)
+	_, err = readSecretFromStdin(strings.NewReader("\n"))
+	assert.NotNil(t, err)
+}
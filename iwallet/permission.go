@@ -0,0 +1,410 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+var permissionForce bool
+
+// permissionChange changes the permissions of the account in place, and returns the auth.iost actions doing the same.
+type permissionChange func(a *rpcpb.Account) ([]*rpcpb.Action, error)
+
+func authAction(method string, args ...interface{}) *rpcpb.Action {
+	// strings and numbers always marshal
+	data, _ := json.Marshal(args)
+	return sdk.NewAction("auth.iost", method, string(data))
+}
+
+// parsePermItem parses a public key or account@permission as auth.iost does.
+func parsePermItem(s string, weight int64) (*rpcpb.Account_Item, error) {
+	parts := strings.SplitN(s, "@", 2)
+	if len(parts) == 1 {
+		if !sdk.CheckPubKey(s) {
+			return nil, fmt.Errorf("invalid public key %v", s)
+		}
+		return &rpcpb.Account_Item{Id: s, IsKeyPair: true, Weight: weight}, nil
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid item %v, should be a public key or account@permission", s)
+	}
+	return &rpcpb.Account_Item{Id: parts[0], Permission: parts[1], Weight: weight}, nil
+}
+
+func itemName(item *rpcpb.Account_Item) string {
+	if item.IsKeyPair {
+		return item.Id
+	}
+	return item.Id + "@" + item.Permission
+}
+
+func findPermItem(items []*rpcpb.Account_Item, name string) int {
+	for i, item := range items {
+		if itemName(item) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// permissionWeight is the total weight of the items and groups of the permission, which must reach the threshold for
+// the permission to be usable at all.
+func permissionWeight(a *rpcpb.Account, p *rpcpb.Account_Permission) int64 {
+	var weight int64
+	for _, item := range p.Items {
+		weight += item.Weight
+	}
+	for _, name := range p.GroupNames {
+		if g, ok := a.Groups[name]; ok {
+			for _, item := range g.Items {
+				weight += item.Weight
+			}
+		}
+	}
+	return weight
+}
+
+// permissionLines renders the permission tree as lines, so that two trees can be diffed line by line.
+func permissionLines(a *rpcpb.Account) []string {
+	var lines []string
+	perms := make([]string, 0, len(a.Permissions))
+	for name := range a.Permissions {
+		perms = append(perms, name)
+	}
+	sort.Strings(perms)
+	for _, name := range perms {
+		p := a.Permissions[name]
+		lines = append(lines, fmt.Sprintf("permission %v threshold %v (total weight %v)", name, p.Threshold, permissionWeight(a, p)))
+		for _, item := range p.Items {
+			lines = append(lines, fmt.Sprintf("permission %v item %v weight %v", name, itemName(item), item.Weight))
+		}
+		for _, g := range p.GroupNames {
+			lines = append(lines, fmt.Sprintf("permission %v group %v", name, g))
+		}
+	}
+	groups := make([]string, 0, len(a.Groups))
+	for name := range a.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		lines = append(lines, fmt.Sprintf("group %v", name))
+		for _, item := range a.Groups[name].Items {
+			lines = append(lines, fmt.Sprintf("group %v item %v weight %v", name, itemName(item), item.Weight))
+		}
+	}
+	return lines
+}
+
+// diffLines lists the lines only in before marked by "-", followed by the lines of after where new ones are marked by "+".
+func diffLines(before []string, after []string) []string {
+	inBefore := make(map[string]bool, len(before))
+	for _, l := range before {
+		inBefore[l] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, l := range after {
+		inAfter[l] = true
+	}
+	var diff []string
+	for _, l := range before {
+		if !inAfter[l] {
+			diff = append(diff, "- "+l)
+		}
+	}
+	for _, l := range after {
+		if inBefore[l] {
+			diff = append(diff, "  "+l)
+		} else {
+			diff = append(diff, "+ "+l)
+		}
+	}
+	return diff
+}
+
+// checkPermissionSafety refuses changes making a permission unusable, which are never allowed for owner and active
+// and only allowed with --force for others.
+func checkPermissionSafety(before *rpcpb.Account, after *rpcpb.Account, force bool) error {
+	for name, p := range after.Permissions {
+		weight := permissionWeight(after, p)
+		if weight >= p.Threshold {
+			continue
+		}
+		if old, ok := before.Permissions[name]; ok && permissionWeight(before, old) < old.Threshold {
+			// it was unusable already
+			continue
+		}
+		if name == "owner" || name == "active" {
+			return fmt.Errorf("refuse to lock the %v permission: total weight %v would be below threshold %v", name, weight, p.Threshold)
+		}
+		if !force {
+			return fmt.Errorf("the %v permission would be unusable: total weight %v below threshold %v, use --force if it is intended", name, weight, p.Threshold)
+		}
+	}
+	return nil
+}
+
+func addKeyChange(perm string, item *rpcpb.Account_Item) permissionChange {
+	return func(a *rpcpb.Account) ([]*rpcpb.Action, error) {
+		p, ok := a.Permissions[perm]
+		if !ok {
+			return nil, fmt.Errorf("permission %v not found", perm)
+		}
+		if item.Weight <= 0 {
+			return nil, fmt.Errorf("weight should be positive")
+		}
+		// auth.iost updates the weight of an existing key, and appends account@permission items
+		if i := findPermItem(p.Items, itemName(item)); i >= 0 && item.IsKeyPair {
+			p.Items[i].Weight = item.Weight
+		} else {
+			p.Items = append(p.Items, item)
+		}
+		return []*rpcpb.Action{authAction("assignPermission", a.Name, perm, itemName(item), item.Weight)}, nil
+	}
+}
+
+func removeKeyChange(perm string, name string) permissionChange {
+	return func(a *rpcpb.Account) ([]*rpcpb.Action, error) {
+		p, ok := a.Permissions[perm]
+		if !ok {
+			return nil, fmt.Errorf("permission %v not found", perm)
+		}
+		i := findPermItem(p.Items, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%v not found in permission %v", name, perm)
+		}
+		p.Items = append(p.Items[:i], p.Items[i+1:]...)
+		return []*rpcpb.Action{authAction("revokePermission", a.Name, perm, name)}, nil
+	}
+}
+
+// setThresholdChange recreates the permission with the new threshold in one tx, since auth.iost cannot change the
+// threshold of an existing permission. Owner and active cannot be dropped, so their thresholds are fixed.
+func setThresholdChange(perm string, threshold int64) permissionChange {
+	return func(a *rpcpb.Account) ([]*rpcpb.Action, error) {
+		if perm == "owner" || perm == "active" {
+			return nil, fmt.Errorf("the threshold of %v cannot be changed by auth.iost", perm)
+		}
+		p, ok := a.Permissions[perm]
+		if !ok {
+			return nil, fmt.Errorf("permission %v not found", perm)
+		}
+		if threshold <= 0 {
+			return nil, fmt.Errorf("threshold should be positive")
+		}
+		actions := []*rpcpb.Action{
+			authAction("dropPermission", a.Name, perm),
+			authAction("addPermission", a.Name, perm, threshold),
+		}
+		for _, item := range p.Items {
+			actions = append(actions, authAction("assignPermission", a.Name, perm, itemName(item), item.Weight))
+		}
+		for _, g := range p.GroupNames {
+			actions = append(actions, authAction("assignPermissionToGroup", a.Name, perm, g))
+		}
+		p.Threshold = threshold
+		return actions, nil
+	}
+}
+
+func addGroupChange(group string) permissionChange {
+	return func(a *rpcpb.Account) ([]*rpcpb.Action, error) {
+		if _, ok := a.Groups[group]; ok {
+			return nil, fmt.Errorf("group %v already exists", group)
+		}
+		if a.Groups == nil {
+			a.Groups = make(map[string]*rpcpb.Account_Group)
+		}
+		a.Groups[group] = &rpcpb.Account_Group{Name: group}
+		return []*rpcpb.Action{authAction("addGroup", a.Name, group)}, nil
+	}
+}
+
+// applyPermissionChange shows the change to the permission tree on chain and sends it after the safety checks.
+// auth.iost requires the owner permission, so it is used for signing unless --sign_permission is given.
+func applyPermissionChange(cmd *cobra.Command, change permissionChange) error {
+	if !cmd.Flag("sign_permission").Changed {
+		signPerm = "owner"
+	}
+	before, err := iwalletSDK.GetAccountInfo(accountName)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions of %v: %v", accountName, err)
+	}
+	after := proto.Clone(before).(*rpcpb.Account)
+	actions, err := change(after)
+	if err != nil {
+		return err
+	}
+	if err := checkPermissionSafety(before, after, permissionForce); err != nil {
+		return err
+	}
+	if !isMachineOutput() {
+		fmt.Println("Permissions of", accountName, "after the change:")
+		for _, l := range diffLines(permissionLines(before), permissionLines(after)) {
+			fmt.Println(l)
+		}
+	}
+	return sendActions(actions...)
+}
+
+func parseWeight(s string, name string) (int64, error) {
+	w, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || w <= 0 {
+		return 0, fmt.Errorf("invalid %v %v, should be a positive integer", name, s)
+	}
+	return w, nil
+}
+
+var permissionCmd = &cobra.Command{
+	Use:     "permission",
+	Aliases: []string{"perm"},
+	Short:   "Account permission management",
+	Long: `Show and change the permissions of an account on chain through auth.iost, with a diff of the change and safety checks
+		The changes are signed with the owner permission by default`,
+}
+
+var permissionAddKeyCmd = &cobra.Command{
+	Use:   "add-key permission item weight",
+	Short: "Add a key or account@permission to a permission",
+	Long:  `Add a public key or account@permission with the weight to a permission, or update the weight of an existing key`,
+	Example: `  iwallet permission add-key active EhNiaU4DzUmjCrvynV3gaUeuj2VjB1v2DCmbGD5U2nSE 50 --account test0
+  iwallet permission add-key myperm test1@active 100 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "permission", "item", "weight"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		weight, err := parseWeight(args[2], "weight")
+		if err != nil {
+			return err
+		}
+		item, err := parsePermItem(args[1], weight)
+		if err != nil {
+			return err
+		}
+		return applyPermissionChange(cmd, addKeyChange(args[0], item))
+	},
+}
+
+var permissionRemoveKeyCmd = &cobra.Command{
+	Use:   "remove-key permission item",
+	Short: "Remove a key or account@permission from a permission",
+	Long: `Remove a public key or account@permission from a permission
+		It is refused if the owner or active permission would not reach its threshold any more`,
+	Example: `  iwallet permission remove-key active EhNiaU4DzUmjCrvynV3gaUeuj2VjB1v2DCmbGD5U2nSE --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "permission", "item"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return applyPermissionChange(cmd, removeKeyChange(args[0], args[1]))
+	},
+}
+
+var permissionSetThresholdCmd = &cobra.Command{
+	Use:   "set-threshold permission threshold",
+	Short: "Change the threshold of a permission",
+	Long: `Change the threshold of a permission other than owner and active, by dropping and recreating it with the same
+		items and groups in one transaction`,
+	Example: `  iwallet permission set-threshold myperm 100 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "permission", "threshold"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		threshold, err := parseWeight(args[1], "threshold")
+		if err != nil {
+			return err
+		}
+		return applyPermissionChange(cmd, setThresholdChange(args[0], threshold))
+	},
+}
+
+var permissionAddGroupCmd = &cobra.Command{
+	Use:     "add-group group",
+	Short:   "Add a permission group",
+	Long:    `Add an empty permission group to the account`,
+	Example: `  iwallet permission add-group mygroup --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "group"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return applyPermissionChange(cmd, addGroupChange(args[0]))
+	},
+}
+
+var permissionListCmd = &cobra.Command{
+	Use:     "list [account]",
+	Aliases: []string{"ls"},
+	Short:   "Show the permissions of an account",
+	Long:    `Show the permissions and groups of an account on chain`,
+	Example: `  iwallet permission list test0
+  iwallet permission list --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := accountName
+		if len(args) > 0 {
+			var err error
+			if name, err = resolveAccount(args[0]); err != nil {
+				return err
+			}
+		}
+		a, err := iwalletSDK.GetAccountInfo(name)
+		if err != nil {
+			return fmt.Errorf("failed to get permissions of %v: %v", name, err)
+		}
+		if isMachineOutput() {
+			return printResult(&rpcpb.Account{Name: a.Name, Permissions: a.Permissions, Groups: a.Groups})
+		}
+		for _, l := range permissionLines(a) {
+			fmt.Println(l)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(permissionCmd)
+	for _, cmd := range []*cobra.Command{permissionAddKeyCmd, permissionRemoveKeyCmd, permissionSetThresholdCmd, permissionAddGroupCmd} {
+		permissionCmd.AddCommand(cmd)
+		cmd.Flags().BoolVarP(&permissionForce, "force", "", false, "allow a permission other than owner and active to become unusable")
+		cmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost without sending the tx")
+	}
+	permissionCmd.AddCommand(permissionListCmd)
+}
@@ -0,0 +1,100 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOwnerKey  = "EhNiaU4DzUmjCrvynV3gaUeuj2VjB1v2DCmbGD5U2nSE"
+	testActiveKey = "2B1T2QxnkDtwHJuqTLrx82dSkcAbGcbcJq2ZY6KAv5HT"
+)
+
+func testPermAccount() *rpcpb.Account {
+	return &rpcpb.Account{
+		Name: "test0",
+		Permissions: map[string]*rpcpb.Account_Permission{
+			"owner":  {Name: "owner", Threshold: 100, Items: []*rpcpb.Account_Item{{Id: testOwnerKey, IsKeyPair: true, Weight: 100}}},
+			"active": {Name: "active", Threshold: 100, Items: []*rpcpb.Account_Item{{Id: testActiveKey, IsKeyPair: true, Weight: 100}}},
+			"myperm": {Name: "myperm", Threshold: 50, GroupNames: []string{"g"},
+				Items: []*rpcpb.Account_Item{{Id: "test1", Permission: "active", Weight: 30}}},
+		},
+		Groups: map[string]*rpcpb.Account_Group{
+			"g": {Name: "g", Items: []*rpcpb.Account_Item{{Id: testActiveKey, IsKeyPair: true, Weight: 20}}},
+		},
+	}
+}
+
+func applyTestChange(t *testing.T, change permissionChange) (*rpcpb.Account, *rpcpb.Account, []*rpcpb.Action, error) {
+	before := testPermAccount()
+	after := proto.Clone(before).(*rpcpb.Account)
+	actions, err := change(after)
+	return before, after, actions, err
+}
+
+func TestPermissionChanges(t *testing.T) {
+	before := testPermAccount()
+	assert.Equal(t, int64(50), permissionWeight(before, before.Permissions["myperm"]))
+
+	item, err := parsePermItem(testActiveKey, 50)
+	assert.Nil(t, err)
+	_, after, _, err := applyTestChange(t, addKeyChange("owner", item))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(150), permissionWeight(after, after.Permissions["owner"]))
+	// the weight of an existing key is updated
+	_, after, actions, err := applyTestChange(t, addKeyChange("owner", &rpcpb.Account_Item{Id: testOwnerKey, IsKeyPair: true, Weight: 50}))
+	assert.Nil(t, err)
+	assert.Len(t, after.Permissions["owner"].Items, 1)
+	assert.Equal(t, `["test0","owner","`+testOwnerKey+`",50]`, actions[0].Data)
+
+	_, err = parsePermItem("@active", 1)
+	assert.NotNil(t, err)
+	_, err = parsePermItem("abc", 1)
+	assert.NotNil(t, err)
+
+	// removing the last owner key is refused
+	b, after, _, err := applyTestChange(t, removeKeyChange("owner", testOwnerKey))
+	assert.Nil(t, err)
+	assert.NotNil(t, checkPermissionSafety(b, after, true))
+	_, _, _, err = applyTestChange(t, removeKeyChange("owner", testActiveKey))
+	assert.NotNil(t, err)
+
+	// other permissions can become unusable with force only
+	b, after, actions, err = applyTestChange(t, removeKeyChange("myperm", "test1@active"))
+	assert.Nil(t, err)
+	assert.Equal(t, "revokePermission", actions[0].ActionName)
+	assert.NotNil(t, checkPermissionSafety(b, after, false))
+	assert.Nil(t, checkPermissionSafety(b, after, true))
+
+	b, after, actions, err = applyTestChange(t, setThresholdChange("myperm", 40))
+	assert.Nil(t, err)
+	assert.Nil(t, checkPermissionSafety(b, after, false))
+	names := make([]string, 0, len(actions))
+	for _, a := range actions {
+		names = append(names, a.ActionName)
+	}
+	assert.Equal(t, []string{"dropPermission", "addPermission", "assignPermission", "assignPermissionToGroup"}, names)
+	assert.Equal(t, `["test0","myperm","test1@active",30]`, actions[2].Data)
+	_, _, _, err = applyTestChange(t, setThresholdChange("owner", 50))
+	assert.NotNil(t, err)
+
+	_, after, _, err = applyTestChange(t, addGroupChange("g2"))
+	assert.Nil(t, err)
+	assert.Contains(t, after.Groups, "g2")
+	_, _, _, err = applyTestChange(t, addGroupChange("g"))
+	assert.NotNil(t, err)
+}
+
+func TestDiffLines(t *testing.T) {
+	before := testPermAccount()
+	_, after, _, err := applyTestChange(t, setThresholdChange("myperm", 40))
+	assert.Nil(t, err)
+	diff := diffLines(permissionLines(before), permissionLines(after))
+	assert.Contains(t, diff, "- permission myperm threshold 50 (total weight 50)")
+	assert.Contains(t, diff, "+ permission myperm threshold 40 (total weight 50)")
+	assert.Contains(t, diff, "  permission myperm item test1@active weight 30")
+	assert.Contains(t, diff, "  group g item "+testActiveKey+" weight 20")
+}
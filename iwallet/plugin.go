@@ -0,0 +1,189 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// pluginPrefix is the prefix of the executables on PATH run for unknown subcommands, eg iwallet-deploy for "iwallet deploy".
+const pluginPrefix = "iwallet-"
+
+// splitPluginArgs splits the args into the global flags before the subcommand, the subcommand and the args after it,
+// which belong to the plugin. The name is empty if a flag is unknown or no subcommand is given.
+func splitPluginArgs(flags *pflag.FlagSet, args []string) (flagArgs []string, name string, rest []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || arg == "-" {
+			return nil, "", nil
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], arg, args[i+1:]
+		}
+		var f *pflag.Flag
+		hasValue := false
+		if strings.HasPrefix(arg, "--") {
+			key := arg[2:]
+			if j := strings.Index(key, "="); j >= 0 {
+				key, hasValue = key[:j], true
+			}
+			f = flags.Lookup(key)
+		} else {
+			f = flags.ShorthandLookup(arg[1:2])
+			hasValue = len(arg) > 2
+		}
+		if f == nil {
+			return nil, "", nil
+		}
+		if !hasValue && f.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return nil, "", nil
+}
+
+// findPlugin returns the executable of the plugin if the args run a subcommand iwallet does not have.
+func findPlugin(args []string) (path string, flagArgs []string, pluginArgs []string, ok bool) {
+	flagArgs, name, pluginArgs := splitPluginArgs(rootCmd.PersistentFlags(), args)
+	if name == "" || name == "help" || name == "completion" || strings.HasPrefix(name, "__") {
+		return "", nil, nil, false
+	}
+	if cmd, _, err := rootCmd.Find([]string{name}); err == nil && cmd != rootCmd {
+		return "", nil, nil, false
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", nil, nil, false
+	}
+	return path, flagArgs, pluginArgs, true
+}
+
+// pluginEnv passes the resolved config to a plugin as the env variables iwallet reads, so that the plugin running
+// iwallet again gets the same server and account.
+func pluginEnv(flags *pflag.FlagSet, configFile string) []string {
+	env := os.Environ()
+	for _, key := range configKeys {
+		if f := flags.Lookup(key); f != nil && f.Value.String() != "" {
+			env = append(env, strings.ToUpper(configEnvPrefix+"_"+key)+"="+f.Value.String())
+		}
+	}
+	env = append(env, "IWALLET_CONFIG="+configFile)
+	if f := flags.Lookup("output_format"); f != nil {
+		env = append(env, "IWALLET_OUTPUT_FORMAT="+f.Value.String())
+	}
+	if bin, err := os.Executable(); err == nil {
+		env = append(env, "IWALLET_BIN="+bin)
+	}
+	return env
+}
+
+// runPlugin runs the plugin with the global flags given before its name applied to the config passed to it.
+func runPlugin(path string, flagArgs []string, args []string) error {
+	flags := rootCmd.PersistentFlags()
+	if err := flags.Parse(flagArgs); err != nil {
+		return err
+	}
+	initConfig()
+	if configErr != nil {
+		return configErr
+	}
+	configFile, _, err := getReadConfigFile()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = pluginEnv(flags, configFile)
+	return cmd.Run()
+}
+
+// listPlugins returns the plugins on PATH by name, the first one found wins like the shell does.
+func listPlugins() map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if f.IsDir() || !strings.HasPrefix(f.Name(), pluginPrefix) || f.Mode()&0111 == 0 {
+				continue
+			}
+			name := strings.TrimPrefix(f.Name(), pluginPrefix)
+			if _, ok := plugins[name]; !ok {
+				plugins[name] = filepath.Join(dir, f.Name())
+			}
+		}
+	}
+	return plugins
+}
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "External subcommands",
+	Long: `An unknown subcommand "iwallet NAME" runs the executable iwallet-NAME found on PATH with the args after NAME
+	The global flags given before NAME are resolved with the config file and passed to the plugin as the env variables
+	IWALLET_SERVER, IWALLET_ACCOUNT, IWALLET_CHAIN_ID and so on, which iwallet reads too, so a plugin running iwallet
+	gets the same config. IWALLET_CONFIG is the config file, IWALLET_OUTPUT_FORMAT the output format and IWALLET_BIN
+	the iwallet executable.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the plugins on PATH",
+	Long:    `List the iwallet-NAME executables found on PATH`,
+	Example: `  iwallet plugin list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins := listPlugins()
+		names := make([]string, 0, len(plugins))
+		for name := range plugins {
+			if c, _, err := rootCmd.Find([]string{name}); err == nil && c != rootCmd {
+				fmt.Fprintf(os.Stderr, "%v is shadowed by the builtin subcommand %v\n", plugins[name], name)
+				delete(plugins, name)
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if isMachineOutput() {
+			return printResult(plugins)
+		}
+		if len(names) == 0 {
+			fmt.Println("No plugins found on PATH")
+			return nil
+		}
+		for _, name := range names {
+			fmt.Printf("%v\t%v\n", name, plugins[name])
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+}
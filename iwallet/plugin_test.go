@@ -0,0 +1,69 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringP("server", "s", "", "")
+	flags.BoolP("verbose", "v", true, "")
+	flags.String("account", "", "")
+
+	flagArgs, name, rest := splitPluginArgs(flags, []string{"-s", "host:30002", "--verbose", "--account=alice", "deploy", "--server", "x", "app"})
+	assert.Equal(t, []string{"-s", "host:30002", "--verbose", "--account=alice"}, flagArgs)
+	assert.Equal(t, "deploy", name)
+	assert.Equal(t, []string{"--server", "x", "app"}, rest)
+
+	_, name, _ = splitPluginArgs(flags, []string{"-shost", "--account", "alice", "deploy"})
+	assert.Equal(t, "deploy", name)
+	_, name, _ = splitPluginArgs(flags, []string{"--unknown", "deploy"})
+	assert.Equal(t, "", name)
+	_, name, _ = splitPluginArgs(flags, []string{"--account"})
+	assert.Equal(t, "", name)
+}
+
+func TestPluginEnv(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("server", "localhost:30002", "")
+	flags.String("account", "", "")
+	flags.Uint32("chain_id", 1024, "")
+	assert.Nil(t, flags.Parse([]string{"--account", "alice"}))
+
+	env := strings.Join(pluginEnv(flags, "/home/alice/.iwallet/config.yaml"), "\n")
+	assert.Contains(t, env, "IWALLET_SERVER=localhost:30002\n")
+	assert.Contains(t, env, "IWALLET_ACCOUNT=alice\n")
+	assert.Contains(t, env, "IWALLET_CHAIN_ID=1024\n")
+	assert.Contains(t, env, "IWALLET_CONFIG=/home/alice/.iwallet/config.yaml")
+}
+
+func TestFindPlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugins")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"iwallet-deploy", "iwallet-balance"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755))
+	}
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", dir)
+
+	p, flagArgs, args, ok := findPlugin([]string{"-s", "host:30002", "deploy", "app"})
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, "iwallet-deploy"), p)
+	assert.Equal(t, []string{"-s", "host:30002"}, flagArgs)
+	assert.Equal(t, []string{"app"}, args)
+
+	_, _, _, ok = findPlugin([]string{"balance", "alice"})
+	assert.False(t, ok)
+	_, _, _, ok = findPlugin([]string{"missing"})
+	assert.False(t, ok)
+	assert.Equal(t, map[string]string{"deploy": filepath.Join(dir, "iwallet-deploy"), "balance": filepath.Join(dir, "iwallet-balance")}, listPlugins())
+}
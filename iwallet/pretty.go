@@ -0,0 +1,255 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// paramsLookup returns the params of an abi to name the args of actions, nil if unknown.
+type paramsLookup func(contract string, abiName string) []*abiParam
+
+// chainParamsLookup looks up the abis on the chain, fetching every contract only once.
+func chainParamsLookup() paramsLookup {
+	contracts := make(map[string]*rpcpb.Contract)
+	return func(contract string, abiName string) []*abiParam {
+		c, ok := contracts[contract]
+		if !ok {
+			c, _ = iwalletSDK.GetContract(contract)
+			contracts[contract] = c
+		}
+		if c == nil {
+			return nil
+		}
+		params, err := abiParams(c, abiName)
+		if err != nil {
+			return nil
+		}
+		return params
+	}
+}
+
+// actionArg is an arg of an action named by the abi.
+type actionArg struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// decodeActionArgs splits the json args of an action, naming them by the params if known and by index otherwise.
+func decodeActionArgs(data string, params []*abiParam) []*actionArg {
+	var values []interface{}
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return []*actionArg{{Name: "data", Value: data}}
+	}
+	args := make([]*actionArg, 0, len(values))
+	for i, v := range values {
+		arg := &actionArg{Name: strconv.Itoa(i)}
+		if i < len(params) {
+			arg.Name, arg.Type = params[i].Name, params[i].Type
+		}
+		if s, ok := v.(string); ok {
+			arg.Value = s
+		} else {
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			encoder.Encode(v)
+			arg.Value = strings.TrimSpace(buf.String())
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// confirmation tells whether the block is irreversible, or how many blocks the irreversible block is behind it.
+// The irreversible block is negative if unknown.
+func confirmation(number int64, lib int64) string {
+	switch {
+	case lib < 0 || number <= 0:
+		return ""
+	case number <= lib:
+		return "irreversible"
+	default:
+		return fmt.Sprintf("%v blocks to become irreversible (irreversible block %v)", number-lib, lib)
+	}
+}
+
+func formatTime(ns int64) string {
+	return time.Unix(0, ns).Format("2006-01-02 15:04:05")
+}
+
+func formatRAMUsage(usage map[string]int64) string {
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("%v %+d bytes", name, usage[name]))
+	}
+	return strings.Join(items, ", ")
+}
+
+func txStatusLine(res *rpcpb.TransactionResponse, lib int64) string {
+	switch res.Status {
+	case rpcpb.TransactionResponse_PENDING:
+		return "PENDING, waiting in the tx pool to be packed"
+	case rpcpb.TransactionResponse_PACKED:
+		if res.BlockNumber == 0 {
+			return "PACKED in a reversible block"
+		}
+		return fmt.Sprintf("PACKED in block %v, %v", res.BlockNumber, confirmation(res.BlockNumber, lib))
+	default:
+		if res.BlockNumber == 0 {
+			return res.Status.String()
+		}
+		return fmt.Sprintf("%v in block %v", res.Status, res.BlockNumber)
+	}
+}
+
+// writeTxResponse prints a transaction with its decoded actions and receipt for humans.
+func writeTxResponse(w io.Writer, res *rpcpb.TransactionResponse, lib int64, lookup paramsLookup) error {
+	t := res.Transaction
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Transaction:\t%v\n", t.Hash)
+	fmt.Fprintf(tw, "Status:\t%v\n", txStatusLine(res, lib))
+	fmt.Fprintf(tw, "Time:\t%v\n", formatTime(t.Time))
+	fmt.Fprintf(tw, "Expiration:\t%v\n", formatTime(t.Expiration))
+	fmt.Fprintf(tw, "Publisher:\t%v\n", t.Publisher)
+	if len(t.Signers) != 0 {
+		fmt.Fprintf(tw, "Signers:\t%v\n", strings.Join(t.Signers, ", "))
+	}
+	fmt.Fprintf(tw, "Gas:\tratio %v, limit %v\n", t.GasRatio, t.GasLimit)
+	if len(t.AmountLimit) != 0 {
+		limits := make([]string, 0, len(t.AmountLimit))
+		for _, l := range t.AmountLimit {
+			limits = append(limits, l.Token+":"+l.Value)
+		}
+		fmt.Fprintf(tw, "Amount limit:\t%v\n", strings.Join(limits, ", "))
+	}
+	if t.Delay > 0 {
+		fmt.Fprintf(tw, "Delay:\t%v\n", time.Duration(t.Delay))
+	}
+	if t.ReferredTx != "" {
+		fmt.Fprintf(tw, "Referred tx:\t%v\n", t.ReferredTx)
+	}
+	if t.ChainId != 0 {
+		fmt.Fprintf(tw, "Chain id:\t%v\n", t.ChainId)
+	}
+	fmt.Fprintln(tw, "Actions:")
+	for i, a := range t.Actions {
+		fmt.Fprintf(tw, "  %v. %v/%v\n", i+1, a.Contract, a.ActionName)
+		for _, arg := range decodeActionArgs(a.Data, lookup(a.Contract, a.ActionName)) {
+			name := arg.Name
+			if arg.Type != "" {
+				name += " (" + arg.Type + ")"
+			}
+			fmt.Fprintf(tw, "     %v\t%v\n", name, arg.Value)
+		}
+	}
+	if r := t.TxReceipt; r != nil {
+		fmt.Fprintln(tw, "Receipt:")
+		fmt.Fprintf(tw, "  Status\t%v\n", r.StatusCode)
+		if r.Message != "" {
+			fmt.Fprintf(tw, "  Message\t%v\n", r.Message)
+		}
+		fmt.Fprintf(tw, "  Gas usage\t%v\n", r.GasUsage)
+		if len(r.RamUsage) != 0 {
+			fmt.Fprintf(tw, "  RAM usage\t%v\n", formatRAMUsage(r.RamUsage))
+		}
+		if len(r.Returns) != 0 {
+			fmt.Fprintf(tw, "  Returns\t%v\n", strings.Join(r.Returns, ", "))
+		}
+		if len(r.Receipts) != 0 {
+			fmt.Fprintln(tw, "  Events")
+			for _, e := range r.Receipts {
+				fmt.Fprintf(tw, "    %v\t%v\n", e.FuncName, e.Content)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// writeBlock prints a block and a summary of its transactions for humans.
+func writeBlock(w io.Writer, res *rpcpb.BlockResponse, lib int64) error {
+	b := res.Block
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Block:\t%v\n", b.Number)
+	fmt.Fprintf(tw, "Hash:\t%v\n", b.Hash)
+	status := res.Status.String()
+	if c := confirmation(b.Number, lib); c != "" && res.Status != rpcpb.BlockResponse_IRREVERSIBLE {
+		status += ", " + c
+	}
+	fmt.Fprintf(tw, "Status:\t%v\n", status)
+	fmt.Fprintf(tw, "Time:\t%v\n", formatTime(b.Time))
+	fmt.Fprintf(tw, "Witness:\t%v\n", b.Witness)
+	fmt.Fprintf(tw, "Parent hash:\t%v\n", b.ParentHash)
+	fmt.Fprintf(tw, "Version:\t%v\n", b.Version)
+	fmt.Fprintf(tw, "Gas usage:\t%v\n", b.GasUsage)
+	fmt.Fprintf(tw, "Tx count:\t%v\n", b.TxCount)
+	fmt.Fprintf(tw, "Tx merkle hash:\t%v\n", b.TxMerkleHash)
+	fmt.Fprintf(tw, "Receipt merkle hash:\t%v\n", b.TxReceiptMerkleHash)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(b.Transactions) == 0 {
+		if b.TxCount > 0 {
+			_, err := fmt.Fprintln(w, "\nList the transactions with --complete")
+			return err
+		}
+		return nil
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "TX HASH\tPUBLISHER\tACTIONS\tSTATUS\tGAS")
+	for _, t := range b.Transactions {
+		actions := make([]string, 0, len(t.Actions))
+		for _, a := range t.Actions {
+			actions = append(actions, a.Contract+"/"+a.ActionName)
+		}
+		status, gas := "", ""
+		if t.TxReceipt != nil {
+			status, gas = t.TxReceipt.StatusCode.String(), fmt.Sprint(t.TxReceipt.GasUsage)
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", t.Hash, t.Publisher, strings.Join(actions, ","), status, gas)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "\nShow a transaction in detail by: iwallet tx HASH")
+	return err
+}
+
+// libBlock returns the irreversible block number of the node, -1 if unknown.
+func libBlock() int64 {
+	info, err := iwalletSDK.GetChainInfo()
+	if err != nil {
+		return -1
+	}
+	return info.LibBlock
+}
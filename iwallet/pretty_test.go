@@ -0,0 +1,75 @@
+package iwallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeActionArgs(t *testing.T) {
+	params := []*abiParam{{Name: "token", Type: "string"}, {Name: "amount", Type: "number"}}
+	args := decodeActionArgs(`["iost", 12, {"a": "<b>"}]`, params)
+	assert.Equal(t, []*actionArg{
+		{Name: "token", Type: "string", Value: "iost"},
+		{Name: "amount", Type: "number", Value: "12"},
+		{Name: "2", Value: `{"a":"<b>"}`},
+	}, args)
+	assert.Equal(t, []*actionArg{{Name: "data", Value: "not json"}}, decodeActionArgs("not json", nil))
+}
+
+func TestConfirmation(t *testing.T) {
+	assert.Equal(t, "irreversible", confirmation(100, 100))
+	assert.Equal(t, "12 blocks to become irreversible (irreversible block 88)", confirmation(100, 88))
+	assert.Equal(t, "", confirmation(100, -1))
+}
+
+func TestWriteTxResponse(t *testing.T) {
+	res := &rpcpb.TransactionResponse{
+		Status:      rpcpb.TransactionResponse_PACKED,
+		BlockNumber: 100,
+		Transaction: &rpcpb.Transaction{
+			Hash:      "7MDf",
+			Publisher: "alice",
+			Signers:   []string{"bob@active"},
+			GasRatio:  1,
+			GasLimit:  100000,
+			Actions:   []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer", Data: `["iost","alice","bob","1.5",""]`}},
+			TxReceipt: &rpcpb.TxReceipt{
+				GasUsage: 5000,
+				RamUsage: map[string]int64{"bob": -2, "alice": 10},
+				Receipts: []*rpcpb.TxReceipt_Receipt{{FuncName: "token.iost/transfer", Content: `["iost","alice","bob","1.5",""]`}},
+			},
+		},
+	}
+	lookup := func(contract string, abiName string) []*abiParam {
+		return []*abiParam{{Name: "token", Type: "string"}, {Name: "from", Type: "string"}, {Name: "to", Type: "string"}, {Name: "amount", Type: "string"}, {Name: "memo", Type: "string"}}
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, writeTxResponse(&buf, res, 90, lookup))
+	out := buf.String()
+	assert.Contains(t, out, "PACKED in block 100, 10 blocks to become irreversible (irreversible block 90)")
+	assert.Contains(t, out, "Signers:")
+	assert.Contains(t, out, "1. token.iost/transfer")
+	assert.Regexp(t, `to \(string\)\s+bob\n`, out)
+	assert.Contains(t, out, "alice +10 bytes, bob -2 bytes")
+	assert.Regexp(t, `token.iost/transfer\s+\["iost","alice","bob","1.5",""\]`, out)
+}
+
+func TestWriteBlock(t *testing.T) {
+	res := &rpcpb.BlockResponse{
+		Status: rpcpb.BlockResponse_PENDING,
+		Block: &rpcpb.Block{
+			Number:       100,
+			Hash:         "Hb",
+			TxCount:      1,
+			Transactions: []*rpcpb.Transaction{{Hash: "7MDf", Publisher: "alice", Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer"}}, TxReceipt: &rpcpb.TxReceipt{GasUsage: 5000}}},
+		},
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, writeBlock(&buf, res, 95))
+	out := buf.String()
+	assert.Contains(t, out, "PENDING, 5 blocks to become irreversible")
+	assert.Regexp(t, `7MDf\s+alice\s+token.iost/transfer\s+SUCCESS\s+5000`, out)
+}
@@ -0,0 +1,347 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+// pendingBonus asks vote_producer.iost for the unclaimed rewards of the account. It needs a loaded account, since
+// the read-only call is executed as a tx of it.
+func pendingBonus(abi string, account string) (string, error) {
+	if accountName == "" {
+		return "", fmt.Errorf("--account is needed to query rewards")
+	}
+	if err := InitAccount(); err != nil {
+		return "", fmt.Errorf("failed to load account: %v", err)
+	}
+	v, err := queryReturn("vote_producer.iost", abi, fmt.Sprintf(`["%v"]`, account))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(v), nil
+}
+
+type producerSummary struct {
+	Account      string                             `json:"account"`
+	Info         *rpcpb.GetProducerVoteInfoResponse `json:"info"`
+	PendingBonus string                             `json:"pending_bonus,omitempty"`
+	BonusError   string                             `json:"bonus_error,omitempty"`
+}
+
+type voterSummary struct {
+	Account      string            `json:"account"`
+	TotalVotes   float64           `json:"total_votes"`
+	Votes        []*rpcpb.VoteInfo `json:"votes"`
+	PendingBonus string            `json:"pending_bonus,omitempty"`
+	BonusError   string            `json:"bonus_error,omitempty"`
+}
+
+// producerStatus is the schedule and the recent block production of a producer. Position is -1 if it is not a witness.
+type producerStatus struct {
+	Account        string  `json:"account"`
+	Pubkey         string  `json:"pubkey"`
+	IsWitness      bool    `json:"is_witness"`
+	Position       int64   `json:"position"`
+	WitnessCount   int     `json:"witness_count"`
+	NextSlotTime   int64   `json:"next_slot_time,omitempty"`
+	FirstBlock     int64   `json:"first_block"`
+	HeadBlock      int64   `json:"head_block"`
+	ProducedBlocks int64   `json:"produced_blocks"`
+	MissedSlots    int64   `json:"missed_slots"`
+	PendingRewards float64 `json:"pending_rewards"`
+}
+
+var statusBlocks int64
+
+func newProducerStatus(producer string, pubkey string, schedule *rpcpb.GetWitnessScheduleResponse) *producerStatus {
+	s := &producerStatus{
+		Account:      producer,
+		Pubkey:       pubkey,
+		Position:     -1,
+		WitnessCount: len(schedule.Witnesses),
+		FirstBlock:   schedule.FirstBlock,
+		HeadBlock:    schedule.HeadBlock,
+	}
+	for _, w := range schedule.Witnesses {
+		if w.Pubkey != pubkey {
+			continue
+		}
+		s.IsWitness = true
+		s.Position = w.Position
+		s.NextSlotTime = w.NextSlotTime
+		s.ProducedBlocks = w.ProducedBlocks
+		s.MissedSlots = w.MissedSlots
+	}
+	return s
+}
+
+func printProducerStatus(s *producerStatus, now time.Time) {
+	fmt.Printf("Producer:        %v (%v)\n", s.Account, s.Pubkey)
+	if !s.IsWitness {
+		fmt.Printf("Witness:         no, not in the current %v witnesses\n", s.WitnessCount)
+	} else {
+		next := time.Unix(0, s.NextSlotTime)
+		wait := "producing now"
+		if next.After(now) {
+			wait = "in " + next.Sub(now).Round(time.Second).String()
+		}
+		fmt.Printf("Witness:         yes, slot %v of %v, next slot at %v (%v)\n", s.Position+1, s.WitnessCount,
+			next.Format("2006-01-02 15:04:05"), wait)
+	}
+	fmt.Printf("Blocks #%v-#%v: %v produced, %v slots missed\n", s.FirstBlock, s.HeadBlock, s.ProducedBlocks, s.MissedSlots)
+	fmt.Printf("Pending rewards: %v contribute, redeem by \"iwallet sys producer-redeem\"\n", s.PendingRewards)
+}
+
+var producerCmd = &cobra.Command{
+	Use:   "producer",
+	Short: "Producer management",
+	Long:  `Register, update and operate a producer of vote_producer.iost`,
+}
+
+var producerRegisterCmd = &cobra.Command{
+	Use:     "register publicKey",
+	Aliases: []string{"reg"},
+	Short:   registerCmd.Short,
+	Long:    registerCmd.Long,
+	Example: `  iwallet producer register XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX --account test0 --location PEK --url iost.io --net_id 123`,
+	Args:    registerCmd.Args,
+	RunE:    registerCmd.RunE,
+}
+
+var producerUpdateCmd = &cobra.Command{
+	Use:     "update",
+	Short:   pupdateCmd.Short,
+	Long:    pupdateCmd.Long,
+	Example: `  iwallet producer update --account test0 --url iost.io`,
+	Args:    pupdateCmd.Args,
+	RunE:    pupdateCmd.RunE,
+}
+
+var producerLoginCmd = &cobra.Command{
+	Use:     "login",
+	Short:   ploginCmd.Short,
+	Long:    ploginCmd.Long,
+	Example: `  iwallet producer login --account test0`,
+	Args:    ploginCmd.Args,
+	RunE:    ploginCmd.RunE,
+}
+
+var producerLogoutCmd = &cobra.Command{
+	Use:     "logout",
+	Short:   plogoutCmd.Short,
+	Long:    plogoutCmd.Long,
+	Example: `  iwallet producer logout --account test0`,
+	Args:    plogoutCmd.Args,
+	RunE:    plogoutCmd.RunE,
+}
+
+var producerInfoCmd = &cobra.Command{
+	Use:   "info [producerID]",
+	Short: "Show producer info, votes and pending rewards",
+	Long: `Show producer info, votes and pending rewards
+	The producer defaults to the account. Pending rewards are queried by a read-only call, which needs --account and exec_tx on the node.`,
+	Example: `  iwallet producer info producer000 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		producer := accountName
+		if len(args) > 0 {
+			producer = args[0]
+		}
+		info, err := getProducerVoteInfo(producer)
+		if err != nil {
+			return err
+		}
+		s := &producerSummary{Account: producer, Info: info}
+		s.PendingBonus, err = pendingBonus("getCandidateBonus", producer)
+		if err != nil {
+			s.BonusError = err.Error()
+		}
+		if isMachineOutput() {
+			return printResult(s)
+		}
+		fmt.Println(sdk.MarshalTextString(info))
+		if s.BonusError != "" {
+			fmt.Println("Pending producer rewards: unknown,", s.BonusError)
+		} else {
+			fmt.Println("Pending producer rewards:", s.PendingBonus, "(half of it can be withdrawn)")
+		}
+		return nil
+	},
+}
+
+var producerStatusCmd = &cobra.Command{
+	Use:   "status [producerID]",
+	Short: "Show whether a producer is a witness and its recent block production",
+	Long: `Show whether a producer is a witness and its recent block production
+	The producer defaults to the account. Produced blocks and missed slots are counted over the recent blocks of the node,
+	missed slots are the slots of the producer without any block. Pending block rewards are the contribution value to redeem.`,
+	Example: `  iwallet producer status producer000
+  iwallet producer status producer000 --blocks 7200`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		producer := accountName
+		if len(args) > 0 {
+			producer = args[0]
+		}
+		info, err := getProducerVoteInfo(producer)
+		if err != nil {
+			return fmt.Errorf("failed to get producer %v: %v", producer, err)
+		}
+		schedule, err := iwalletSDK.GetWitnessSchedule(statusBlocks)
+		if err != nil {
+			return fmt.Errorf("failed to get witness schedule: %v", err)
+		}
+		s := newProducerStatus(producer, info.Pubkey, schedule)
+		rewards, err := iwalletSDK.GetTokenBalance(producer, "contribute")
+		if err != nil {
+			return fmt.Errorf("failed to get pending block rewards: %v", err)
+		}
+		s.PendingRewards = rewards.Balance
+		if isMachineOutput() {
+			return printResult(s)
+		}
+		printProducerStatus(s, time.Now())
+		return nil
+	},
+}
+
+var voteGroupCmd = &cobra.Command{
+	Use:   "vote",
+	Short: "Voting management",
+	Long:  `Vote for producers and claim voting rewards`,
+}
+
+var voteCastCmd = &cobra.Command{
+	Use:     "cast producerID amount",
+	Short:   voteCmd.Short,
+	Long:    voteCmd.Long,
+	Example: `  iwallet vote cast producer000 1000000 --account test0`,
+	Args:    voteCmd.Args,
+	RunE:    voteCmd.RunE,
+}
+
+var voteRevokeCmd = &cobra.Command{
+	Use:     "revoke producerID amount",
+	Short:   unvoteCmd.Short,
+	Long:    unvoteCmd.Long,
+	Example: `  iwallet vote revoke producer000 1000000 --account test0`,
+	Args:    unvoteCmd.Args,
+	RunE:    unvoteCmd.RunE,
+}
+
+var voteStatsCmd = &cobra.Command{
+	Use:   "stats [accountName]",
+	Short: "Show the votes and pending voting rewards of a voter",
+	Long: `Show the votes and pending voting rewards of a voter
+	The voter defaults to the account. Pending rewards are queried by a read-only call, which needs --account and exec_tx on the node.`,
+	Example: `  iwallet vote stats --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return checkAccount(cmd)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		voter := accountName
+		if len(args) > 0 {
+			voter = args[0]
+		}
+		acc, err := iwalletSDK.GetAccountInfo(voter)
+		if err != nil {
+			return err
+		}
+		s := &voterSummary{Account: voter, Votes: acc.VoteInfos}
+		if s.Votes == nil {
+			s.Votes = []*rpcpb.VoteInfo{}
+		}
+		for _, v := range acc.VoteInfos {
+			s.TotalVotes += v.Votes
+		}
+		s.PendingBonus, err = pendingBonus("getVoterBonus", voter)
+		if err != nil {
+			s.BonusError = err.Error()
+		}
+		if isMachineOutput() {
+			return printResult(s)
+		}
+		fmt.Printf("Votes of %v: %v in total\n", voter, s.TotalVotes)
+		for _, v := range s.Votes {
+			fmt.Printf("  %v\t%v\t(cleared %v)\n", v.Option, v.Votes, v.ClearedVotes)
+		}
+		if s.BonusError != "" {
+			fmt.Println("Pending voting rewards: unknown,", s.BonusError)
+		} else {
+			fmt.Println("Pending voting rewards:", s.PendingBonus)
+		}
+		return nil
+	},
+}
+
+var voteRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Voting rewards",
+	Long:  `Claim voting rewards, which are shown by "vote stats"`,
+}
+
+var voteRewardsClaimCmd = &cobra.Command{
+	Use:     "claim",
+	Short:   vwithdrawCmd.Short,
+	Long:    vwithdrawCmd.Long,
+	Example: `  iwallet vote rewards claim --account test0`,
+	Args:    vwithdrawCmd.Args,
+	RunE:    vwithdrawCmd.RunE,
+}
+
+func init() {
+	rootCmd.AddCommand(producerCmd)
+	producerCmd.AddCommand(producerRegisterCmd)
+	producerRegisterCmd.Flags().StringVarP(&location, "location", "", "", "location info")
+	producerRegisterCmd.Flags().StringVarP(&url, "url", "", "", "url address")
+	producerRegisterCmd.Flags().StringVarP(&networkID, "net_id", "", "", "network ID")
+	producerRegisterCmd.Flags().BoolVarP(&isPartner, "partner", "", false, "if is partner instead of producer")
+	producerCmd.AddCommand(producerUpdateCmd)
+	producerUpdateCmd.Flags().StringVarP(&publicKey, "pubkey", "", "", "publick key")
+	producerUpdateCmd.Flags().StringVarP(&location, "location", "", "", "location info")
+	producerUpdateCmd.Flags().StringVarP(&url, "url", "", "", "url address")
+	producerUpdateCmd.Flags().StringVarP(&networkID, "net_id", "", "", "network ID")
+	producerCmd.AddCommand(producerLoginCmd)
+	producerCmd.AddCommand(producerLogoutCmd)
+	producerCmd.AddCommand(producerInfoCmd)
+	producerCmd.AddCommand(producerStatusCmd)
+	producerStatusCmd.Flags().Int64VarP(&statusBlocks, "blocks", "", 0, "number of recent blocks to count the produced blocks and missed slots over, 1200 by default")
+
+	rootCmd.AddCommand(voteGroupCmd)
+	voteGroupCmd.AddCommand(voteCastCmd)
+	voteGroupCmd.AddCommand(voteRevokeCmd)
+	voteGroupCmd.AddCommand(voteStatsCmd)
+	voteGroupCmd.AddCommand(voteRewardsCmd)
+	voteRewardsCmd.AddCommand(voteRewardsClaimCmd)
+}
@@ -0,0 +1,37 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProducerStatus(t *testing.T) {
+	schedule := &rpcpb.GetWitnessScheduleResponse{
+		HeadBlock:  2000,
+		FirstBlock: 801,
+		Witnesses: []*rpcpb.GetWitnessScheduleResponse_Witness{
+			{Pubkey: "key0", Account: "producer000", Position: 0, NextSlotTime: 300, ProducedBlocks: 600},
+			{Pubkey: "key1", Account: "producer001", Position: 1, NextSlotTime: 600, ProducedBlocks: 594, MissedSlots: 1},
+		},
+	}
+	s := newProducerStatus("producer001", "key1", schedule)
+	assert.Equal(t, &producerStatus{
+		Account:        "producer001",
+		Pubkey:         "key1",
+		IsWitness:      true,
+		Position:       1,
+		WitnessCount:   2,
+		NextSlotTime:   600,
+		FirstBlock:     801,
+		HeadBlock:      2000,
+		ProducedBlocks: 594,
+		MissedSlots:    1,
+	}, s)
+
+	s = newProducerStatus("producer002", "key2", schedule)
+	assert.False(t, s.IsWitness)
+	assert.Equal(t, int64(-1), s.Position)
+	assert.Equal(t, int64(0), s.ProducedBlocks)
+}
@@ -0,0 +1,175 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// callGas is the gas of a call of a transaction, SelfGas being without the gas of the calls it makes, which follow it
+// one depth deeper.
+type callGas struct {
+	Depth   int     `json:"depth"`
+	Call    string  `json:"call"`
+	Gas     float64 `json:"gas"`
+	SelfGas float64 `json:"self_gas"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// gasProfileItem is the gas of a host api or a function of a contract, summed over the calls of a transaction.
+type gasProfileItem struct {
+	Contract string  `json:"contract"`
+	Name     string  `json:"name"`
+	Count    int64   `json:"count"`
+	Gas      float64 `json:"gas"`
+}
+
+// txGasProfile is where a transaction used its gas, the host apis and functions being sorted by gas, highest first.
+type txGasProfile struct {
+	TxHash    string            `json:"tx_hash"`
+	Calls     []*callGas        `json:"calls"`
+	HostAPIs  []*gasProfileItem `json:"host_apis"`
+	Functions []*gasProfileItem `json:"functions"`
+}
+
+func newTxGasProfile(txHash string, trace *rpcpb.TraceTransactionResponse) *txGasProfile {
+	p := &txGasProfile{
+		TxHash:    txHash,
+		Calls:     make([]*callGas, 0),
+		HostAPIs:  make([]*gasProfileItem, 0),
+		Functions: make([]*gasProfileItem, 0),
+	}
+	hostAPIs := make(map[string]*gasProfileItem)
+	functions := make(map[string]*gasProfileItem)
+	var walk func(calls []*rpcpb.CallTrace, depth int)
+	walk = func(calls []*rpcpb.CallTrace, depth int) {
+		for _, c := range calls {
+			g := &callGas{Depth: depth, Call: c.Contract + "/" + c.ActionName, Gas: c.Gas, SelfGas: c.Gas, Error: c.Error}
+			for _, sub := range c.Calls {
+				g.SelfGas -= sub.Gas
+			}
+			p.Calls = append(p.Calls, g)
+			p.HostAPIs = addGasProfiles(p.HostAPIs, hostAPIs, c.Contract, c.HostApis)
+			p.Functions = addGasProfiles(p.Functions, functions, c.Contract, c.Functions)
+			walk(c.Calls, depth+1)
+		}
+	}
+	walk(trace.Calls, 0)
+	sortGasProfiles(p.HostAPIs)
+	sortGasProfiles(p.Functions)
+	return p
+}
+
+func addGasProfiles(items []*gasProfileItem, index map[string]*gasProfileItem, contract string, profiles []*rpcpb.GasProfile) []*gasProfileItem {
+	for _, gp := range profiles {
+		key := contract + "/" + gp.Name
+		item, ok := index[key]
+		if !ok {
+			item = &gasProfileItem{Contract: contract, Name: gp.Name}
+			index[key] = item
+			items = append(items, item)
+		}
+		item.Count += gp.Count
+		item.Gas += gp.Gas
+	}
+	return items
+}
+
+func sortGasProfiles(items []*gasProfileItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Gas > items[j].Gas
+	})
+}
+
+func writeGasProfile(w io.Writer, p *txGasProfile) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Transaction:\t%v\n", p.TxHash)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(p.Calls) == 0 {
+		_, err := fmt.Fprintln(w, "\nNo contract called")
+		return err
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "CALL\tGAS\tSELF GAS\tERROR")
+	for _, c := range p.Calls {
+		fmt.Fprintf(tw, "%v%v\t%v\t%v\t%v\n", strings.Repeat("  ", c.Depth), c.Call, formatAmount(c.Gas), formatAmount(c.SelfGas), c.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	if err := writeGasProfileItems(w, "HOST API", p.HostAPIs, "No host api called"); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return writeGasProfileItems(w, "FUNCTION", p.Functions,
+		"No function profiled, only the vms like wasm profiling the functions of the contracts give them")
+}
+
+func writeGasProfileItems(w io.Writer, title string, items []*gasProfileItem, empty string) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(w, empty)
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%v\tCONTRACT\tCOUNT\tGAS\n", title)
+	for _, item := range items {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", item.Name, item.Contract, item.Count, formatAmount(item.Gas))
+	}
+	return tw.Flush()
+}
+
+// profileCmd represents the profile command.
+var profileCmd = &cobra.Command{
+	Use:   "profile transactionHash",
+	Short: "Show where a transaction used its gas",
+	Long: `Execute again a transaction of a block not irreversible yet on the node, and print the gas of its calls to
+	the contracts, of the host apis called by the contracts, and of the functions of the contracts, without the
+	functions they call. Only the vms profiling the functions, like wasm, give them. The node should enable exec_tx in
+	its rpc config`,
+	Example: `  iwallet profile 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT
+  iwallet profile 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT --output_format json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "transactionHash"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trace, err := iwalletSDK.TraceTransaction(args[0])
+		if err != nil {
+			return err
+		}
+		p := newTxGasProfile(args[0], trace)
+		if isMachineOutput() {
+			return printResult(p)
+		}
+		return writeGasProfile(os.Stdout, p)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,50 @@
+package iwallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxGasProfile(t *testing.T) {
+	trace := &rpcpb.TraceTransactionResponse{Calls: []*rpcpb.CallTrace{
+		{
+			Contract: "Contractabc", ActionName: "swap", Gas: 1000,
+			HostApis:  []*rpcpb.GasProfile{{Name: "storage.get", Count: 2, Gas: 600}, {Name: "blockchain.call", Count: 1, Gas: 300}},
+			Functions: []*rpcpb.GasProfile{{Name: "swap", Count: 1, Gas: 50}},
+			Calls: []*rpcpb.CallTrace{{
+				Contract: "Contractabc", ActionName: "price", Gas: 300,
+				HostApis: []*rpcpb.GasProfile{{Name: "storage.get", Count: 1, Gas: 300}},
+			}},
+		},
+		{Contract: "token.iost", ActionName: "transfer", Gas: 200, Error: "balance not enough"},
+	}}
+	p := newTxGasProfile("hash", trace)
+	assert.Equal(t, []*callGas{
+		{Depth: 0, Call: "Contractabc/swap", Gas: 1000, SelfGas: 700},
+		{Depth: 1, Call: "Contractabc/price", Gas: 300, SelfGas: 300},
+		{Depth: 0, Call: "token.iost/transfer", Gas: 200, SelfGas: 200, Error: "balance not enough"},
+	}, p.Calls)
+	assert.Equal(t, []*gasProfileItem{
+		{Contract: "Contractabc", Name: "storage.get", Count: 3, Gas: 900},
+		{Contract: "Contractabc", Name: "blockchain.call", Count: 1, Gas: 300},
+	}, p.HostAPIs)
+	assert.Equal(t, []*gasProfileItem{{Contract: "Contractabc", Name: "swap", Count: 1, Gas: 50}}, p.Functions)
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeGasProfile(&buf, p))
+	assert.Contains(t, buf.String(), "  Contractabc/price  300   300")
+	assert.Contains(t, buf.String(), "storage.get      Contractabc  3      900\n")
+	assert.Contains(t, buf.String(), "swap      Contractabc  1      50\n")
+
+	buf.Reset()
+	assert.Nil(t, writeGasProfile(&buf, newTxGasProfile("hash", &rpcpb.TraceTransactionResponse{})))
+	assert.Contains(t, buf.String(), "No contract called")
+
+	buf.Reset()
+	assert.Nil(t, writeGasProfile(&buf, newTxGasProfile("hash", &rpcpb.TraceTransactionResponse{Calls: trace.Calls[1:]})))
+	assert.Contains(t, buf.String(), "No host api called")
+	assert.Contains(t, buf.String(), "No function profiled")
+}
@@ -15,21 +15,81 @@
 package iwallet
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
-var update bool
+var (
+	update           bool
+	publishEntry     string
+	publishMinify    bool
+	publishSkipCheck bool
+)
+
+// loadContractCode reads the code of the contract, bundling it first if codePath is a directory of js modules. A
+// WebAssembly binary, ending with .wasm, is encoded in base64.
+func loadContractCode(codePath string) (string, error) {
+	fi, err := os.Stat(codePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source code: %v", err)
+	}
+	if !fi.IsDir() && strings.HasSuffix(codePath, ".wasm") {
+		data, err := ioutil.ReadFile(codePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read wasm file: %v", err)
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+	transformed := fi.IsDir() || publishMinify
+	var code string
+	if fi.IsDir() {
+		if code, err = bundleContract(codePath, publishEntry); err != nil {
+			return "", fmt.Errorf("failed to bundle %v: %v", codePath, err)
+		}
+	} else {
+		data, err := ioutil.ReadFile(codePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read source code file: %v", err)
+		}
+		code = string(data)
+	}
+	if publishMinify {
+		code = minifyJS(code)
+	}
+	// a single hand written file is checked by the node when publishing, what iwallet changed is checked here
+	if transformed && !publishSkipCheck {
+		if err := checkSyntax(code); err != nil {
+			return "", fmt.Errorf("invalid contract code: %v", err)
+		}
+	}
+	if outputFile != "" {
+		if err := ioutil.WriteFile(outputFile, []byte(code), 0644); err != nil {
+			return "", fmt.Errorf("failed to save contract code: %v", err)
+		}
+	}
+	return code, nil
+}
 
 // publishCmd represents the publish command.
 var publishCmd = &cobra.Command{
 	Use:     "publish codePath abiPath [contractID [updateID]]",
 	Aliases: []string{"pub"},
 	Short:   "Publish a contract",
-	Long:    `Publish a contract by a contract and an abi file`,
+	Long: `Publish a contract by a contract and an abi file
+		codePath can also be a directory of js modules, which are bundled into one file starting from the --entry module,
+		resolving the requires of relative paths. Requires of other paths are left to the native modules of the chain
+		A WebAssembly contract is published by its .wasm binary, with "wasm" as the lang of the abi file, on the chains enabling wasm
+		The hash of the code to be stored on chain is shown before publishing
+		Before an update, the changes of code and abi compared with the deployed contract are shown.
+		Updates removing an abi or changing its args break existing callers and have to be confirmed, or given --yes`,
 	Example: `  iwallet publish ./example.js ./example.js.abi --account test0
-  iwallet publish -u ./example.js ./example.js.abi ContractXXX --account test0`,
+  iwallet publish -u ./example.js ./example.js.abi ContractXXX --account test0
+  iwallet publish ./example ./example.js.abi --entry example.js --minify --output bundle.js --account test0`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		var err error
 		if update {
@@ -55,14 +115,65 @@ var publishCmd = &cobra.Command{
 			updateID = args[3]
 		}
 
-		err := InitAccount()
+		abiInfo, err := loadABIFile(abiPath)
+		if err != nil {
+			return fmt.Errorf("invalid abi: %v", err)
+		}
+		abi, err := ioutil.ReadFile(abiPath)
+		if err != nil {
+			return fmt.Errorf("failed to read abi file: %v", err)
+		}
+		code, err := loadContractCode(codePath)
+		if err != nil {
+			return err
+		}
+		hash := codeHash(code)
+		if !isMachineOutput() {
+			fmt.Printf("The code hash is: %v (%v bytes)\n", hash, len(code))
+			if outputFile != "" {
+				fmt.Println("Successfully saved contract code as:", outputFile)
+			}
+		}
+		var preview *upgradePreview
+		if update {
+			if preview, err = newUpgradePreview(conID, code, codePath, abiInfo); err != nil {
+				return err
+			}
+			if !isMachineOutput() {
+				printUpgradePreview(preview)
+			}
+		}
+
+		err = InitAccount()
 		if err != nil {
 			return fmt.Errorf("failed to load account: %v", err)
 		}
-		_, txHash, err := iwalletSDK.PublishContract(codePath, abiPath, conID, update, updateID)
+		trx, err := iwalletSDK.CreatePublishContractTxFromCode(code, string(abi), conID, update, updateID)
 		if err != nil {
 			return fmt.Errorf("failed to create tx: %v", err)
 		}
+		if estimateOnly || dryRun {
+			return estimateTx(trx)
+		}
+		if preview != nil {
+			if err := confirmUpgrade(preview); err != nil {
+				return err
+			}
+		}
+		txHash, err := iwalletSDK.SendTx(trx)
+		if err != nil {
+			return fmt.Errorf("failed to create tx: %v", err)
+		}
+		if isMachineOutput() {
+			result := map[string]interface{}{"tx_hash": txHash, "code_hash": hash}
+			if !update {
+				result["contract_id"] = "Contract" + txHash
+			}
+			if preview != nil {
+				result["upgrade"] = preview
+			}
+			return printResult(result)
+		}
 		if !update {
 			fmt.Println("The contract id is: Contract" + txHash)
 		}
@@ -73,4 +184,10 @@ var publishCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(publishCmd)
 	publishCmd.Flags().BoolVarP(&update, "update", "u", false, "update contract")
+	publishCmd.Flags().StringVarP(&publishEntry, "entry", "", "index.js", "entry module of the contract when codePath is a directory")
+	publishCmd.Flags().BoolVarP(&publishMinify, "minify", "", false, "strip comments and indentation from the code to save ram")
+	publishCmd.Flags().BoolVarP(&publishSkipCheck, "skip_check", "", false, "do not check that the bundled or minified code parses, which needs node.js")
+	publishCmd.Flags().StringVarP(&outputFile, "output", "o", "", "also save the code to be published to this file")
+	publishCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "update without confirming breaking abi changes")
+	publishCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost of publishing without sending the tx")
 }
@@ -0,0 +1,289 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/iwallet/qrcode"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+const (
+	qrFramePrefix = "IOST"
+	qrKindTx      = "TX"
+	qrKindSig     = "SIG"
+)
+
+var (
+	qrFrameSize int
+	qrPNG       string
+)
+
+// encodeQRFrames serializes msg into text frames which fit in qr codes.
+// A frame looks like IOST:TX:<id>:<index>/<total>:<payload>, where payload is a piece of the
+// base64 encoded, deflated protobuf binary. The protobuf encoding of messages without maps is deterministic,
+// so the same tx always gives the same frames.
+func encodeQRFrames(kind string, msg proto.Message, frameSize int) ([]string, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	id := hex.EncodeToString(common.Sha3(data))[:8]
+	if frameSize <= 0 {
+		frameSize = len(payload)
+	}
+	total := (len(payload) + frameSize - 1) / frameSize
+	frames := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * frameSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		frames = append(frames, fmt.Sprintf("%v:%v:%v:%v/%v:%v", qrFramePrefix, kind, id, i+1, total, payload[i*frameSize:end]))
+	}
+	return frames, nil
+}
+
+type qrFrame struct {
+	kind    string
+	id      string
+	index   int
+	total   int
+	payload string
+}
+
+func parseQRFrame(s string) (*qrFrame, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ":", 5)
+	if len(parts) != 5 || parts[0] != qrFramePrefix {
+		return nil, fmt.Errorf("not an iwallet qr frame: %v", s)
+	}
+	pos := strings.Split(parts[3], "/")
+	if len(pos) != 2 {
+		return nil, fmt.Errorf("invalid qr frame position %v", parts[3])
+	}
+	index, err := strconv.Atoi(pos[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid qr frame position %v", parts[3])
+	}
+	total, err := strconv.Atoi(pos[1])
+	if err != nil || index < 1 || index > total {
+		return nil, fmt.Errorf("invalid qr frame position %v", parts[3])
+	}
+	return &qrFrame{kind: parts[1], id: parts[2], index: index, total: total, payload: parts[4]}, nil
+}
+
+// qrAssembler collects frames in any order until the message is complete.
+type qrAssembler struct {
+	kind   string
+	id     string
+	frames []string
+	got    int
+}
+
+func (a *qrAssembler) add(s string) error {
+	f, err := parseQRFrame(s)
+	if err != nil {
+		return err
+	}
+	if f.kind != a.kind {
+		return fmt.Errorf("expect %v frame, got %v frame", a.kind, f.kind)
+	}
+	if a.frames == nil {
+		a.id = f.id
+		a.frames = make([]string, f.total)
+	}
+	if f.id != a.id || f.total != len(a.frames) {
+		return fmt.Errorf("frame %v/%v belongs to another message", f.index, f.total)
+	}
+	if a.frames[f.index-1] == "" {
+		a.got++
+	}
+	a.frames[f.index-1] = f.payload
+	return nil
+}
+
+func (a *qrAssembler) complete() bool {
+	return a.frames != nil && a.got == len(a.frames)
+}
+
+func (a *qrAssembler) decode(msg proto.Message) error {
+	if !a.complete() {
+		return fmt.Errorf("missing qr frames, got %v of %v", a.got, len(a.frames))
+	}
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.Join(a.frames, ""))
+	if err != nil {
+		return fmt.Errorf("invalid qr payload: %v", err)
+	}
+	data, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		return fmt.Errorf("invalid qr payload: %v", err)
+	}
+	if hex.EncodeToString(common.Sha3(data))[:8] != a.id {
+		return fmt.Errorf("qr payload checksum mismatch")
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// readQRFrames reads scanned frames line by line, as typed by a qr scanner or pasted from a camera app.
+func readQRFrames(r io.Reader, kind string, msg proto.Message) error {
+	a := &qrAssembler{kind: kind}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	fmt.Printf("Scan the %v qr code frames, one per line:\n", kind)
+	for !a.complete() && scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := a.add(line); err != nil {
+			fmt.Println("ignored:", err)
+			continue
+		}
+		fmt.Printf("got %v of %v frames\n", a.got, len(a.frames))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return a.decode(msg)
+}
+
+// showQRFrames prints the frames as qr codes one by one, or saves them as png files if pngPrefix is given.
+func showQRFrames(frames []string, pngPrefix string) error {
+	reader := bufio.NewReader(os.Stdin)
+	for i, frame := range frames {
+		code, err := qrcode.Encode([]byte(frame), qrcode.M)
+		if err != nil {
+			return err
+		}
+		if pngPrefix != "" {
+			fileName := fmt.Sprintf("%v_%v.png", pngPrefix, i+1)
+			f, err := os.Create(fileName)
+			if err != nil {
+				return err
+			}
+			err = png.Encode(f, code.Image(8))
+			f.Close()
+			if err != nil {
+				return err
+			}
+			fmt.Println("Saved qr code frame as:", fileName)
+			continue
+		}
+		fmt.Print(code.Terminal())
+		fmt.Printf("frame %v/%v\n", i+1, len(frames))
+		if i+1 < len(frames) {
+			fmt.Print("Press Enter to show the next frame")
+			reader.ReadString('\n')
+		}
+	}
+	return nil
+}
+
+var qrCmd = &cobra.Command{
+	Use:   "qr",
+	Short: "Move transactions and signatures to and from an offline machine via qr codes",
+	Long: `Move transactions and signatures to and from an offline machine via qr codes
+	1. on the online machine, create the tx with "save" and show it with "qr export"
+	2. on the offline machine, scan the frames and sign with "sign --from_qr", which shows the signature as qr codes
+	3. on the online machine, scan the signature with "qr import-sig" and send the tx with "call --tx_file --with_signs"`,
+}
+
+var qrExportCmd = &cobra.Command{
+	Use:   "export txFile",
+	Short: "Show an unsigned transaction as qr codes",
+	Long:  `Show an unsigned transaction as a series of qr code frames in the terminal, or save them as png files`,
+	Example: `  iwallet qr export tx.json
+  iwallet qr export tx.json --png tx`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "txFile"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx := &rpcpb.TransactionRequest{}
+		err := sdk.LoadProtoStructFromJSONFile(args[0], trx)
+		if err != nil {
+			return fmt.Errorf("failed to load transaction file: %v", err)
+		}
+		frames, err := encodeQRFrames(qrKindTx, trx, qrFrameSize)
+		if err != nil {
+			return err
+		}
+		return showQRFrames(frames, qrPNG)
+	},
+}
+
+var qrImportSigCmd = &cobra.Command{
+	Use:     "import-sig outputFile",
+	Short:   "Scan a signature shown by \"sign --from_qr\" and save it",
+	Long:    `Read the scanned qr code frames of a signature from stdin and save the signature to a file which can be used with --with_signs`,
+	Example: `  iwallet qr import-sig sign.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "outputFile"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sig := &rpcpb.Signature{}
+		if err := readQRFrames(os.Stdin, qrKindSig, sig); err != nil {
+			return err
+		}
+		err := sdk.SaveProtoStructToJSONFile(sig, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to save signature: %v", err)
+		}
+		fmt.Println("Successfully saved signature as:", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(qrCmd)
+	qrCmd.PersistentFlags().IntVarP(&qrFrameSize, "qr_frame_size", "", 600, "max payload length of one qr code frame")
+	qrCmd.PersistentFlags().StringVarP(&qrPNG, "png", "", "", "save qr code frames as png files with this prefix instead of printing them")
+
+	qrCmd.AddCommand(qrExportCmd)
+	qrCmd.AddCommand(qrImportSigCmd)
+}
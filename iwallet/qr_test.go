@@ -0,0 +1,34 @@
+package iwallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRFrames(t *testing.T) {
+	trx, err := sdk.NewIOSTDevSDK().CreateTxFromActions([]*rpcpb.Action{
+		sdk.NewAction("token.iost", "transfer", `["iost","user0001","user0002","123.45","`+strings.Repeat("memo", 50)+`"]`),
+	})
+	assert.Nil(t, err)
+	frames, err := encodeQRFrames(qrKindTx, trx, 64)
+	assert.Nil(t, err)
+	assert.True(t, len(frames) > 1)
+	again, err := encodeQRFrames(qrKindTx, trx, 64)
+	assert.Nil(t, err)
+	assert.Equal(t, frames, again)
+
+	// frames may be scanned in any order and more than once
+	input := strings.Join(append([]string{frames[len(frames)-1], "garbage"}, frames...), "\n")
+	got := &rpcpb.TransactionRequest{}
+	assert.Nil(t, readQRFrames(strings.NewReader(input), qrKindTx, got))
+	assert.Equal(t, sdk.TxHashForSign(trx), sdk.TxHashForSign(got))
+
+	err = readQRFrames(strings.NewReader(strings.Join(frames[1:], "\n")), qrKindTx, got)
+	assert.NotNil(t, err)
+	err = readQRFrames(strings.NewReader(strings.Join(frames, "\n")), qrKindSig, &rpcpb.Signature{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,600 @@
+package qrcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// errNotFound is returned if no symbol can be read in the image.
+var errNotFound = fmt.Errorf("no qr code found in the image")
+
+// Decode reads the data of a symbol in an image, such as a screenshot or a flat scan of a printed code.
+// The symbol may be scaled and somewhat rotated but not seen in perspective, since the modules are located
+// by the three finder patterns only.
+func Decode(img image.Image) ([]byte, error) {
+	b, err := binarize(img)
+	if err != nil {
+		return nil, err
+	}
+	finders := b.findFinders()
+	// the real finder patterns are crossed by the most scan lines
+	if len(finders) > 6 {
+		finders = finders[:6]
+	}
+	lastErr := errNotFound
+	for i := 0; i < len(finders); i++ {
+		for j := i + 1; j < len(finders); j++ {
+			for k := j + 1; k < len(finders); k++ {
+				data, err := b.decodeAt(finders[i], finders[j], finders[k])
+				if err == nil {
+					return data, nil
+				}
+				if err != errNotFound {
+					lastErr = err
+				}
+			}
+		}
+	}
+	return nil, lastErr
+}
+
+// bitmap is the image binarized into dark and light pixels. Pixels outside of it are light, as the quiet zone is.
+type bitmap struct {
+	w, h int
+	bits []bool
+}
+
+func binarize(img image.Image) (*bitmap, error) {
+	r := img.Bounds()
+	b := &bitmap{w: r.Dx(), h: r.Dy(), bits: make([]bool, r.Dx()*r.Dy())}
+	lum := make([]uint8, len(b.bits))
+	lo, hi := uint8(255), uint8(0)
+	for y := 0; y < b.h; y++ {
+		for x := 0; x < b.w; x++ {
+			v := color.GrayModel.Convert(img.At(r.Min.X+x, r.Min.Y+y)).(color.Gray).Y
+			lum[y*b.w+x] = v
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+	}
+	if int(hi)-int(lo) < 32 {
+		return nil, errNotFound
+	}
+	threshold := (int(lo) + int(hi)) / 2
+	for i, v := range lum {
+		b.bits[i] = int(v) < threshold
+	}
+	return b, nil
+}
+
+func (b *bitmap) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.w && y < b.h
+}
+
+func (b *bitmap) dark(x, y int) bool {
+	return b.inside(x, y) && b.bits[y*b.w+x]
+}
+
+// finder is the center of a finder pattern found, in pixels.
+type finder struct {
+	x, y   float64
+	module float64
+	count  int
+}
+
+// isFinderRuns tells whether the runs of dark, light, dark, light, dark pixels have the 1:1:3:1:1 ratio of a finder pattern.
+func isFinderRuns(runs [5]int) bool {
+	total := 0
+	for _, r := range runs {
+		if r == 0 {
+			return false
+		}
+		total += r
+	}
+	if total < 7 {
+		return false
+	}
+	m := float64(total) / 7
+	v := m / 2
+	return math.Abs(float64(runs[0])-m) < v && math.Abs(float64(runs[1])-m) < v &&
+		math.Abs(float64(runs[2])-3*m) < 3*v && math.Abs(float64(runs[3])-m) < v && math.Abs(float64(runs[4])-m) < v
+}
+
+// crossCheck measures the finder pattern through the dark pixel at x, y in the direction dx, dy. It returns the
+// center of the pattern along that direction and its size in pixels.
+func (b *bitmap) crossCheck(x, y, dx, dy, maxRun int) (float64, int, bool) {
+	var runs [5]int
+	// walk backwards from the center run, then forwards
+	cx, cy := x, y
+	for ; b.dark(cx, cy); cx, cy = cx-dx, cy-dy {
+		runs[2]++
+	}
+	back := runs[2]
+	for ; b.inside(cx, cy) && !b.dark(cx, cy) && runs[1] <= maxRun; cx, cy = cx-dx, cy-dy {
+		runs[1]++
+	}
+	for ; b.dark(cx, cy) && runs[0] <= maxRun; cx, cy = cx-dx, cy-dy {
+		runs[0]++
+	}
+	cx, cy = x+dx, y+dy
+	for ; b.dark(cx, cy); cx, cy = cx+dx, cy+dy {
+		runs[2]++
+	}
+	for ; b.inside(cx, cy) && !b.dark(cx, cy) && runs[3] <= maxRun; cx, cy = cx+dx, cy+dy {
+		runs[3]++
+	}
+	for ; b.dark(cx, cy) && runs[4] <= maxRun; cx, cy = cx+dx, cy+dy {
+		runs[4]++
+	}
+	if !isFinderRuns(runs) {
+		return 0, 0, false
+	}
+	t := x*dx + y*dy
+	lo, hi := t-back+1, t+runs[2]-back+1
+	center := float64(lo+hi) / 2
+	total := runs[0] + runs[1] + runs[2] + runs[3] + runs[4]
+	return center, total, true
+}
+
+// findFinders scans the rows for finder patterns, checks them across, and groups those of the same pattern.
+func (b *bitmap) findFinders() []finder {
+	var found []finder
+	for y := 0; y < b.h; y++ {
+		// run lengths of the row, starting with a light run which may be empty
+		var runs, starts []int
+		dark := false
+		start := 0
+		for x := 0; x <= b.w; x++ {
+			d := x < b.w && b.dark(x, y)
+			if x == b.w || d != dark {
+				runs = append(runs, x-start)
+				starts = append(starts, start)
+				start, dark = x, d
+			}
+		}
+		// dark runs are at odd indexes
+		for i := 1; i+4 < len(runs); i += 2 {
+			var r [5]int
+			copy(r[:], runs[i:i+5])
+			if !isFinderRuns(r) {
+				continue
+			}
+			htotal := r[0] + r[1] + r[2] + r[3] + r[4]
+			cx := starts[i+2] + runs[i+2]/2
+			vy, vtotal, ok := b.crossCheck(cx, y, 0, 1, htotal)
+			if !ok || vtotal > 2*htotal || htotal > 2*vtotal {
+				continue
+			}
+			hx, htotal2, ok := b.crossCheck(cx, int(vy), 1, 0, htotal)
+			if !ok {
+				continue
+			}
+			found = append(found, finder{x: hx, y: vy, module: float64(htotal2+vtotal) / 14, count: 1})
+		}
+	}
+	var groups []finder
+	for _, f := range found {
+		merged := false
+		for i := range groups {
+			g := &groups[i]
+			if math.Abs(g.x-f.x) <= 2*g.module && math.Abs(g.y-f.y) <= 2*g.module &&
+				f.module < 2*g.module && g.module < 2*f.module {
+				n := float64(g.count)
+				g.x = (g.x*n + f.x) / (n + 1)
+				g.y = (g.y*n + f.y) / (n + 1)
+				g.module = (g.module*n + f.module) / (n + 1)
+				g.count++
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			groups = append(groups, f)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].count > groups[j].count })
+	return groups
+}
+
+func distance(a, b finder) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+// decodeAt tries to read the symbol whose finder patterns are the three given.
+func (b *bitmap) decodeAt(f0, f1, f2 finder) ([]byte, error) {
+	// the top left pattern is at the right angle, opposite of the longest side
+	tl, tr, bl := f0, f1, f2
+	d01, d02, d12 := distance(f0, f1), distance(f0, f2), distance(f1, f2)
+	switch {
+	case d01 >= d02 && d01 >= d12:
+		tl, tr, bl = f2, f0, f1
+	case d02 >= d01 && d02 >= d12:
+		tl, tr, bl = f1, f0, f2
+	}
+	if (tr.x-tl.x)*(bl.y-tl.y)-(tr.y-tl.y)*(bl.x-tl.x) < 0 {
+		tr, bl = bl, tr
+	}
+	dtr, dbl := distance(tl, tr), distance(tl, bl)
+	if dtr > 1.2*dbl || dbl > 1.2*dtr {
+		return nil, errNotFound
+	}
+	module := (tl.module + tr.module + bl.module) / 3
+	dim := int(math.Floor((dtr+dbl)/2/module+0.5)) + 7
+	switch dim % 4 {
+	case 0:
+		dim++
+	case 2:
+		dim--
+	case 3:
+		dim -= 2
+	}
+	lastErr := errNotFound
+	for _, size := range []int{dim, dim - 4, dim + 4} {
+		if size < 21 || size > 177 {
+			continue
+		}
+		data, err := b.sample(tl, tr, bl, size).decode()
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// sample reads the modules of a symbol of the size, mapping module coordinates to pixels by the finder patterns,
+// whose centers are at 3.5 modules from the sides.
+func (b *bitmap) sample(tl, tr, bl finder, size int) *Code {
+	c := newCode((size-17)/4, L)
+	span := float64(size - 7)
+	ux, uy := (tr.x-tl.x)/span, (tr.y-tl.y)/span
+	vx, vy := (bl.x-tl.x)/span, (bl.y-tl.y)/span
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			u, v := float64(x)+0.5-3.5, float64(y)+0.5-3.5
+			px, py := tl.x+u*ux+v*vx, tl.y+u*uy+v*vy
+			c.modules[y][x] = b.dark(int(math.Floor(px)), int(math.Floor(py)))
+		}
+	}
+	return c
+}
+
+// readFormat finds the level and mask whose format bits are the closest to either copy in the symbol.
+func (c *Code) readFormat() (Level, int, error) {
+	var copy1, copy2 int
+	put := func(bits *int, i int, x, y int) {
+		if c.modules[y][x] {
+			*bits |= 1 << uint(i)
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		put(&copy1, i, 8, i)
+	}
+	put(&copy1, 6, 8, 7)
+	put(&copy1, 7, 8, 8)
+	put(&copy1, 8, 7, 8)
+	for i := 9; i < 15; i++ {
+		put(&copy1, i, 14-i, 8)
+	}
+	for i := 0; i < 8; i++ {
+		put(&copy2, i, c.Size-1-i, 8)
+	}
+	for i := 8; i < 15; i++ {
+		put(&copy2, i, 8, c.Size-15+i)
+	}
+	best, bestLevel, bestMask := 16, L, 0
+	for level := L; level <= H; level++ {
+		for mask := 0; mask < 8; mask++ {
+			w := formatWord(level, mask)
+			for _, bits := range []int{copy1, copy2} {
+				if d := hamming(w, bits); d < best {
+					best, bestLevel, bestMask = d, level, mask
+				}
+			}
+		}
+	}
+	if best > 3 {
+		return 0, 0, fmt.Errorf("unreadable format information")
+	}
+	return bestLevel, bestMask, nil
+}
+
+func hamming(a, b int) int {
+	n := 0
+	for x := a ^ b; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+// decode reads the codewords of the sampled symbol, corrects their errors and parses the data segments.
+func (c *Code) decode() ([]byte, error) {
+	level, mask, err := c.readFormat()
+	if err != nil {
+		return nil, err
+	}
+	f := newCode(c.Version, level)
+	f.drawFunctionPatterns()
+	rawCodewords := rawDataModules(c.Version) / 8
+	codewords := make([]byte, rawCodewords)
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !f.isFunction[y][x] && i < rawCodewords*8 {
+					if c.modules[y][x] != maskBit(mask, x, y) {
+						codewords[i>>3] |= 1 << uint(7-i&7)
+					}
+					i++
+				}
+			}
+		}
+	}
+
+	// undo the interleaving of addECCAndInterleave
+	numBlocks := numECCBlocks[level][c.Version]
+	blockECCLen := eccCodewordsPerBlock[level][c.Version]
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+	blocks := make([][]byte, numBlocks)
+	for j := range blocks {
+		blocks[j] = make([]byte, shortBlockLen+1)
+	}
+	k := 0
+	for i := 0; i <= shortBlockLen; i++ {
+		for j := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				blocks[j][i] = codewords[k]
+				k++
+			}
+		}
+	}
+	var data []byte
+	for j, block := range blocks {
+		if j < numShortBlocks {
+			pad := shortBlockLen - blockECCLen
+			block = append(block[:pad], block[pad+1:]...)
+		}
+		if err := rsCorrect(block, blockECCLen); err != nil {
+			return nil, err
+		}
+		data = append(data, block[:len(block)-blockECCLen]...)
+	}
+	return parseSegments(data, c.Version)
+}
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// parseSegments reads the numeric, alphanumeric and byte segments of the data codewords.
+func parseSegments(data []byte, version int) ([]byte, error) {
+	r := &bitReader{data: data}
+	countBits := func(small, medium, large int) int {
+		switch {
+		case version <= 9:
+			return small
+		case version <= 26:
+			return medium
+		default:
+			return large
+		}
+	}
+	var out []byte
+	for r.left() >= 4 {
+		mode := r.read(4)
+		switch mode {
+		case 0:
+			return out, nil
+		case 1:
+			n := r.read(countBits(10, 12, 14))
+			for ; n >= 3; n -= 3 {
+				out = append(out, []byte(fmt.Sprintf("%03d", r.read(10)))...)
+			}
+			if n == 2 {
+				out = append(out, []byte(fmt.Sprintf("%02d", r.read(7)))...)
+			} else if n == 1 {
+				out = append(out, []byte(fmt.Sprintf("%d", r.read(4)))...)
+			}
+		case 2:
+			n := r.read(countBits(9, 11, 13))
+			for ; n >= 2; n -= 2 {
+				v := r.read(11)
+				if v/45 >= len(alphanumericChars) {
+					return nil, fmt.Errorf("invalid alphanumeric data")
+				}
+				out = append(out, alphanumericChars[v/45], alphanumericChars[v%45])
+			}
+			if n == 1 {
+				v := r.read(6)
+				if v >= len(alphanumericChars) {
+					return nil, fmt.Errorf("invalid alphanumeric data")
+				}
+				out = append(out, alphanumericChars[v])
+			}
+		case 4:
+			n := r.read(countBits(8, 16, 16))
+			for ; n > 0; n-- {
+				out = append(out, byte(r.read(8)))
+			}
+		case 7:
+			// the eci designator only tells the charset, the bytes are returned as they are
+			if r.read(1) == 1 {
+				if r.read(1) == 0 {
+					r.read(6 + 8)
+				} else {
+					r.read(1 + 5 + 16)
+				}
+			} else {
+				r.read(7)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported qr code mode %v", mode)
+		}
+		if r.overrun {
+			return nil, fmt.Errorf("truncated qr code data")
+		}
+	}
+	return out, nil
+}
+
+type bitReader struct {
+	data    []byte
+	pos     int
+	overrun bool
+}
+
+func (r *bitReader) left() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) read(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		if r.pos >= len(r.data)*8 {
+			r.overrun = true
+			return v
+		}
+		v = v<<1 | int(r.data[r.pos>>3]>>uint(7-r.pos&7)&1)
+		r.pos++
+	}
+	return v
+}
+
+// tables of GF(256) with the polynomial 0x11d used by the reed-solomon codes of qr codes
+var (
+	gfExp [510]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-gfLog[a]]
+}
+
+// polyEval evaluates a polynomial whose coefficients are ordered from the lowest degree.
+func polyEval(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+	return y
+}
+
+// rsCorrect corrects the errors of a block in place, whose first byte is the highest degree coefficient and whose
+// last nsym bytes are the ecc computed by rsRemainder. It finds the errors by Berlekamp-Massey and fixes them by Forney.
+func rsCorrect(block []byte, nsym int) error {
+	n := len(block)
+	synd := make([]byte, nsym)
+	clean := true
+	for i := 0; i < nsym; i++ {
+		var s byte
+		for _, c := range block {
+			s = gfMul(s, gfExp[i]) ^ c
+		}
+		synd[i] = s
+		clean = clean && s == 0
+	}
+	if clean {
+		return nil
+	}
+
+	lambda, prev := []byte{1}, []byte{1}
+	errs, shift, lastD := 0, 1, byte(1)
+	for k := 0; k < nsym; k++ {
+		d := synd[k]
+		for i := 1; i <= errs && i < len(lambda); i++ {
+			d ^= gfMul(lambda[i], synd[k-i])
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		coef := gfMul(d, gfInv(lastD))
+		next := append([]byte{}, lambda...)
+		for len(next) < len(prev)+shift {
+			next = append(next, 0)
+		}
+		for i, p := range prev {
+			next[i+shift] ^= gfMul(coef, p)
+		}
+		if 2*errs <= k {
+			prev, errs, lastD, shift = lambda, k+1-errs, d, 1
+		} else {
+			shift++
+		}
+		lambda = next
+	}
+	for len(lambda) > 1 && lambda[len(lambda)-1] == 0 {
+		lambda = lambda[:len(lambda)-1]
+	}
+	if len(lambda)-1 != errs || 2*errs > nsym {
+		return fmt.Errorf("too many errors in the qr code")
+	}
+
+	// the roots of lambda are the inverses of the error locations
+	var positions []int
+	for p := 0; p < n; p++ {
+		if polyEval(lambda, gfInv(gfExp[p%255])) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	if len(positions) != errs {
+		return fmt.Errorf("too many errors in the qr code")
+	}
+	omega := make([]byte, nsym)
+	for i := range omega {
+		for j := 0; j < len(lambda) && j <= i; j++ {
+			omega[i] ^= gfMul(synd[i-j], lambda[j])
+		}
+	}
+	// the formal derivative keeps the odd terms in characteristic 2
+	deriv := make([]byte, len(lambda))
+	for i := 1; i < len(lambda); i += 2 {
+		deriv[i-1] = lambda[i]
+	}
+	for _, p := range positions {
+		x := gfExp[p%255]
+		xinv := gfInv(x)
+		den := polyEval(deriv, xinv)
+		if den == 0 {
+			return fmt.Errorf("too many errors in the qr code")
+		}
+		block[n-1-p] ^= gfMul(gfMul(x, polyEval(omega, xinv)), gfInv(den))
+	}
+	return nil
+}
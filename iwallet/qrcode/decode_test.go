@@ -0,0 +1,96 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSCorrect(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	block := append(append([]byte{}, data...), rsRemainder(data, rsDivisor(10))...)
+	assert.Nil(t, rsCorrect(block, 10))
+
+	damaged := append([]byte{}, block...)
+	for _, i := range []int{0, 7, 15, 20, 25} {
+		damaged[i] ^= byte(i + 1)
+	}
+	assert.Nil(t, rsCorrect(damaged, 10))
+	assert.Equal(t, block, damaged)
+
+	for _, i := range []int{1, 2, 3, 4, 5, 6} {
+		damaged[i] ^= 0x55
+	}
+	assert.NotNil(t, rsCorrect(damaged, 10))
+}
+
+// transform draws the image scaled, rotated by the angle and moved by the offset on a larger light canvas.
+func transform(img image.Image, scale float64, angle float64, offset int) image.Image {
+	r := img.Bounds()
+	n := int(float64(r.Dx())*scale*1.5) + 2*offset
+	out := image.NewGray(image.Rect(0, 0, n, n))
+	cx, cy := float64(n)/2, float64(n)/2
+	sin, cos := math.Sin(angle), math.Cos(angle)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			dx, dy := float64(x)-cx, float64(y)-cy
+			sx := (dx*cos+dy*sin)/scale + float64(r.Dx())/2
+			sy := (-dx*sin+dy*cos)/scale + float64(r.Dy())/2
+			v := color.Gray{Y: 240}
+			if sx >= 0 && sy >= 0 && int(sx) < r.Dx() && int(sy) < r.Dy() {
+				v.Y = color.GrayModel.Convert(img.At(int(sx), int(sy))).(color.Gray).Y/2 + 100
+			}
+			out.SetGray(x, y, v)
+		}
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	for _, level := range []Level{L, M, Q, H} {
+		for _, n := range []int{1, 30, 90, 200, 400} {
+			data := bytes.Repeat([]byte(fmt.Sprintf("%v-%v:", level, n)), n)[:n]
+			c, err := Encode(data, level)
+			assert.Nil(t, err)
+			got, err := Decode(c.Image(3))
+			assert.Nil(t, err, "level %v version %v", level, c.Version)
+			assert.Equal(t, data, got)
+		}
+	}
+
+	key := []byte("2yquS3ySrGWPEKywCPzX4RTJugqRh7kJSo5aehsLYPEWkUxBWA39oMrZ7ZxuM4fgyXYs2cPwh5n8aNNpH5x2VyK1")
+	c, err := Encode(key, M)
+	assert.Nil(t, err)
+	// scanned at another resolution, slightly rotated, lower contrast and somewhere on a page
+	got, err := Decode(transform(c.Image(4), 2.7, 0.05, 50))
+	assert.Nil(t, err)
+	assert.Equal(t, key, got)
+
+	// damaged modules are corrected
+	for _, p := range [][2]int{{10, 12}, {11, 12}, {20, 25}, {c.Size - 1, c.Size - 1}} {
+		c.modules[p[1]][p[0]] = !c.modules[p[1]][p[0]]
+	}
+	got, err = Decode(c.Image(2))
+	assert.Nil(t, err)
+	assert.Equal(t, key, got)
+
+	_, err = Decode(image.NewGray(image.Rect(0, 0, 50, 50)))
+	assert.Equal(t, errNotFound, err)
+}
+
+func TestParseSegments(t *testing.T) {
+	// HELLO WORLD in alphanumeric mode, 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	got, err := parseSegments(data, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "HELLO WORLD", string(got))
+	// 01234567 in numeric mode, 1-M
+	got, err = parseSegments([]byte{16, 32, 12, 86, 97, 128, 236, 17}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "01234567", string(got))
+}
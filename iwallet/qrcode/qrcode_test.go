@@ -0,0 +1,54 @@
+package qrcode
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// HELLO WORLD as 1-M, from the thonky.com qr code tutorial
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ecc := rsRemainder(data, rsDivisor(10))
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ecc)
+}
+
+func TestFormatWord(t *testing.T) {
+	assert.Equal(t, 0x77c4, formatWord(L, 0))
+	assert.Equal(t, 0x5412, formatWord(M, 0))
+	// level L, mask 4 = 110011000101111
+	assert.Equal(t, 0x662f, formatWord(L, 4))
+}
+
+func TestCapacity(t *testing.T) {
+	assert.Equal(t, 19, dataCodewords(1, L))
+	assert.Equal(t, 9, dataCodewords(1, H))
+	assert.Equal(t, 2956, dataCodewords(40, L))
+	assert.Equal(t, 2334, dataCodewords(40, M))
+	assert.Equal(t, 1666, dataCodewords(40, Q))
+	assert.Equal(t, 1276, dataCodewords(40, H))
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, alignmentPositions(40))
+	assert.Equal(t, []int{6, 22, 38}, alignmentPositions(7))
+}
+
+func TestEncode(t *testing.T) {
+	c, err := Encode([]byte("iost"), M)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, c.Version)
+	assert.Equal(t, 21, c.Size)
+	// finder pattern and timing pattern
+	assert.True(t, c.Dark(0, 0))
+	assert.False(t, c.Dark(1, 1))
+	assert.True(t, c.Dark(2, 2))
+	assert.False(t, c.Dark(7, 7))
+	assert.True(t, c.Dark(8, 6))
+	assert.False(t, c.Dark(9, 6))
+	assert.True(t, c.Dark(8, c.Size-8))
+
+	c, err = Encode(bytes.Repeat([]byte{'a'}, 2331), M)
+	assert.Nil(t, err)
+	assert.Equal(t, 40, c.Version)
+	_, err = Encode(bytes.Repeat([]byte{'a'}, 2332), M)
+	assert.NotNil(t, err)
+}
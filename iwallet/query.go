@@ -0,0 +1,115 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query contract abi [args]",
+	Short: "Call a contract abi in read-only mode",
+	Long: `Execute a contract abi on the node and print the returns and receipts, without sending a transaction to the chain
+	All state changes are dropped. The node should enable exec_tx in its rpc config.
+	The node checks the publisher like a normal tx, so an account with some gas is usually needed.`,
+	Example: `  iwallet query token.iost balanceOf '["iost","user0001"]' --account user0001
+  iwallet query ContractXXX getValue '[]' --account user0001 --output_format json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 2 {
+			return nil
+		}
+		return checkArgsNumber(cmd, args, "contract", "abi", "args")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		methodArgs := "[]"
+		if len(args) > 2 {
+			var err error
+			methodArgs, err = resolveActionArgs(args[2])
+			if err != nil {
+				return err
+			}
+		}
+		if accountName != "" {
+			err := InitAccount()
+			if err != nil {
+				return fmt.Errorf("failed to load account: %v", err)
+			}
+		}
+		receipt, err := iwalletSDK.CallReadOnly(args[0], args[1], methodArgs)
+		if err != nil {
+			return err
+		}
+		if isMachineOutput() {
+			if err := printResult(receipt); err != nil {
+				return err
+			}
+		} else {
+			printQueryResult(receipt)
+		}
+		if receipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
+			return fmt.Errorf("query failed: %v %v", receipt.StatusCode, receipt.Message)
+		}
+		return nil
+	},
+}
+
+// queryReturn calls the abi in read-only mode and returns its first return value.
+func queryReturn(contract, abi, args string) (interface{}, error) {
+	receipt, err := iwalletSDK.CallReadOnly(contract, abi, args)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
+		return nil, fmt.Errorf("query %v.%v failed: %v", contract, abi, receipt.Message)
+	}
+	return parseReturn(receipt.Returns)
+}
+
+// parseReturn decodes the first return value. The vm saves the values returned by an action as a json array.
+func parseReturn(returns []string) (interface{}, error) {
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("no return value")
+	}
+	var values []interface{}
+	if err := json.Unmarshal([]byte(returns[0]), &values); err != nil {
+		return nil, fmt.Errorf("invalid return value %v: %v", returns[0], err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+func printQueryResult(receipt *rpcpb.TxReceipt) {
+	fmt.Println("Returns:")
+	for _, r := range receipt.Returns {
+		fmt.Println(r)
+	}
+	if len(receipt.Receipts) != 0 {
+		fmt.Println("Receipts:")
+		for _, r := range receipt.Receipts {
+			fmt.Printf("%v: %v\n", r.FuncName, r.Content)
+		}
+	}
+	fmt.Println("Gas usage:", receipt.GasUsage)
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+}
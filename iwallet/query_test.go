@@ -0,0 +1,18 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReturn(t *testing.T) {
+	v, err := parseReturn([]string{`["12.50000000"]`})
+	assert.Nil(t, err)
+	assert.Equal(t, "12.50000000", v)
+	v, err = parseReturn([]string{`[]`})
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	_, err = parseReturn(nil)
+	assert.NotNil(t, err)
+}
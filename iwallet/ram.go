@@ -0,0 +1,218 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// The trading rules of ram.iost.
+const (
+	ramMinTrade = 10
+	ramFeeRate  = 0.02
+	ramMinFee   = 0.01
+)
+
+var (
+	ramMaxPrice float64
+	ramMinPrice float64
+)
+
+// ramQuote is what buying or selling some bytes costs or returns at the current state of ram.iost.
+type ramQuote struct {
+	Action       string  `json:"action"`
+	Amount       int64   `json:"amount"`
+	Price        float64 `json:"price"`
+	Fee          float64 `json:"fee"`
+	Total        float64 `json:"total"`
+	AveragePrice float64 `json:"average_price"`
+}
+
+func roundIOST(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// quoteRAM follows the bancor pricing of ram.iost: the iost balance of the contract divided by the left bytes is
+// the sell price, and buying n bytes costs balance * n / (left - n) plus the fee.
+// The contract may issue new ram right before the trade, so the real price can only be a bit lower for buying.
+func quoteRAM(info *rpcpb.RAMInfoResponse, action string, amount int64) (*ramQuote, error) {
+	if amount < ramMinTrade {
+		return nil, fmt.Errorf("minimum ram amount for trading is %v bytes", ramMinTrade)
+	}
+	left := float64(info.AvailableRam)
+	balance := info.SellPrice * left
+	q := &ramQuote{Action: action, Amount: amount}
+	switch action {
+	case "buy":
+		if info.AvailableRam <= amount {
+			return nil, fmt.Errorf("only %v bytes of ram are left for sale", info.AvailableRam)
+		}
+		q.Price = info.BuyPrice
+		raw := roundIOST(balance * float64(amount) / (left - float64(amount)))
+		q.Fee = math.Max(roundIOST(ramFeeRate*raw), ramMinFee)
+		q.Total = roundIOST(raw + q.Fee)
+	case "sell":
+		q.Price = info.SellPrice
+		q.Total = roundIOST(balance * float64(amount) / (left + float64(amount)))
+	default:
+		return nil, fmt.Errorf("invalid ram action %v", action)
+	}
+	q.AveragePrice = q.Total / float64(amount)
+	return q, nil
+}
+
+// checkRAMQuote makes sure the average price of the trade is within the limits set by the user.
+func checkRAMQuote(q *ramQuote, maxPrice, minPrice float64) error {
+	if maxPrice > 0 && q.AveragePrice > maxPrice {
+		return fmt.Errorf("ram price %.8f iost/byte is higher than --max_price %v", q.AveragePrice, maxPrice)
+	}
+	if minPrice > 0 && q.AveragePrice < minPrice {
+		return fmt.Errorf("ram price %.8f iost/byte is lower than --min_price %v", q.AveragePrice, minPrice)
+	}
+	return nil
+}
+
+func printRAMQuote(q *ramQuote) {
+	if q.Action == "buy" {
+		fmt.Printf("Buying %v bytes of ram costs about %.2f iost including %.2f iost fee (%.8f iost/byte)\n", q.Amount, q.Total, q.Fee, q.AveragePrice)
+	} else {
+		fmt.Printf("Selling %v bytes of ram returns about %.2f iost (%.8f iost/byte)\n", q.Amount, q.Total, q.AveragePrice)
+	}
+}
+
+// tradeRAM quotes the trade with the live price from the node, then sends it if the price is acceptable.
+func tradeRAM(action string, amount int64, receiver string) error {
+	info, err := iwalletSDK.GetRAMInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get ram price: %v", err)
+	}
+	q, err := quoteRAM(info, action, amount)
+	if err != nil {
+		return err
+	}
+	if !isMachineOutput() {
+		printRAMQuote(q)
+	}
+	if err := checkRAMQuote(q, ramMaxPrice, ramMinPrice); err != nil {
+		return err
+	}
+	if receiver == "" {
+		receiver = accountName
+	}
+	return sendAction("ram.iost", action, accountName, receiver, amount)
+}
+
+func checkRAMAmount(cmd *cobra.Command, args []string) error {
+	if err := checkArgsNumber(cmd, args, "amount"); err != nil {
+		return err
+	}
+	if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
+		cmd.Help()
+		fmt.Println()
+		return fmt.Errorf(`invalid value "%v" for argument "amount": should be a number of bytes`, args[0])
+	}
+	return nil
+}
+
+var ramCmd = &cobra.Command{
+	Use:   "ram",
+	Short: "Ram market",
+	Long:  `Trade ram with ram.iost at the live price of the node`,
+}
+
+var ramBuyCmd = &cobra.Command{
+	Use:   "buy amount",
+	Short: "Buy ram from system",
+	Long:  `Buy ram from system after showing the cost at the current price. The tx is not sent if the average price is higher than --max_price`,
+	Example: `  iwallet ram buy 1024 --account test0
+  iwallet ram buy 1024 --account test0 --ram_receiver test1 --max_price 0.05`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkRAMAmount(cmd, args); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, _ := strconv.ParseInt(args[0], 10, 64)
+		return tradeRAM("buy", amount, other)
+	},
+}
+
+var ramSellCmd = &cobra.Command{
+	Use:   "sell amount",
+	Short: "Sell unused ram to system",
+	Long:  `Sell unused ram to system after showing the return at the current price. The tx is not sent if the average price is lower than --min_price`,
+	Example: `  iwallet ram sell 1024 --account test0
+  iwallet ram sell 1024 --account test0 --token_receiver test1 --min_price 0.01`,
+	Args: ramBuyCmd.Args,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, _ := strconv.ParseInt(args[0], 10, 64)
+		return tradeRAM("sell", amount, other)
+	},
+}
+
+var ramPriceCmd = &cobra.Command{
+	Use:   "price [amount]",
+	Short: "Show the ram price",
+	Long:  `Show the current ram market of ram.iost, and what buying and selling the amount of bytes would cost and return`,
+	Example: `  iwallet ram price
+  iwallet ram price 1024`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return nil
+		}
+		return checkRAMAmount(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := iwalletSDK.GetRAMInfo()
+		if err != nil {
+			return fmt.Errorf("failed to get ram price: %v", err)
+		}
+		if len(args) == 0 {
+			return printResult(info)
+		}
+		amount, _ := strconv.ParseInt(args[0], 10, 64)
+		result := struct {
+			Market *rpcpb.RAMInfoResponse `json:"market"`
+			Buy    *ramQuote              `json:"buy,omitempty"`
+			Sell   *ramQuote              `json:"sell"`
+		}{Market: info}
+		if result.Buy, err = quoteRAM(info, "buy", amount); err != nil && !isMachineOutput() {
+			fmt.Println(err)
+		}
+		if result.Sell, err = quoteRAM(info, "sell", amount); err != nil {
+			return err
+		}
+		return printResult(result)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ramCmd)
+	ramCmd.AddCommand(ramBuyCmd)
+	ramBuyCmd.Flags().StringVarP(&other, "ram_receiver", "", "", "who gets the bought ram")
+	ramBuyCmd.Flags().Float64VarP(&ramMaxPrice, "max_price", "", 0, "max average price in iost per byte, fee included (0 means no limit)")
+	ramBuyCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost without sending the tx")
+	ramCmd.AddCommand(ramSellCmd)
+	ramSellCmd.Flags().StringVarP(&other, "token_receiver", "", "", "who gets the returned IOST after selling")
+	ramSellCmd.Flags().Float64VarP(&ramMinPrice, "min_price", "", 0, "min average price in iost per byte (0 means no limit)")
+	ramSellCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost without sending the tx")
+	ramCmd.AddCommand(ramPriceCmd)
+}
@@ -0,0 +1,35 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteRAM(t *testing.T) {
+	// 1000 iost in ram.iost for 100000 bytes left
+	info := &rpcpb.RAMInfoResponse{AvailableRam: 100000, SellPrice: 0.01, BuyPrice: 0.0102}
+
+	q, err := quoteRAM(info, "buy", 10000)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.22, q.Fee)
+	assert.Equal(t, 113.33, q.Total)
+	assert.Nil(t, checkRAMQuote(q, 0.012, 0))
+	assert.NotNil(t, checkRAMQuote(q, 0.011, 0))
+
+	q, err = quoteRAM(info, "buy", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, ramMinFee, q.Fee)
+
+	q, err = quoteRAM(info, "sell", 10000)
+	assert.Nil(t, err)
+	assert.Equal(t, 90.91, q.Total)
+	assert.Nil(t, checkRAMQuote(q, 0, 0.009))
+	assert.NotNil(t, checkRAMQuote(q, 0, 0.0095))
+
+	_, err = quoteRAM(info, "buy", 100000)
+	assert.NotNil(t, err)
+	_, err = quoteRAM(info, "sell", 9)
+	assert.NotNil(t, err)
+}
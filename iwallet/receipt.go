@@ -15,18 +15,18 @@
 package iwallet
 
 import (
-	"fmt"
-
-	"github.com/iost-official/go-iost/sdk"
 	"github.com/spf13/cobra"
 )
 
+var waitReceipt bool
+
 // receiptCmd represents the receipt command.
 var receiptCmd = &cobra.Command{
-	Use:     "receipt transactionHash",
-	Short:   "Find receipt",
-	Long:    `Find receipt by transaction hash`,
-	Example: `  iwallet receipt 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT`,
+	Use:   "receipt transactionHash",
+	Short: "Find receipt",
+	Long:  `Find receipt by transaction hash, or wait for the transaction to become irreversible with --wait`,
+	Example: `  iwallet receipt 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT
+  iwallet receipt 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT --wait --wait_timeout 2m`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := checkArgsNumber(cmd, args, "transactionHash"); err != nil {
 			return err
@@ -34,15 +34,19 @@ var receiptCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		txReceipt, err := iwalletSDK.GetTxReceiptByTxHash(args[0])
+		getReceipt := iwalletSDK.GetTxReceiptByTxHash
+		if waitReceipt {
+			getReceipt = iwalletSDK.WaitTx
+		}
+		txReceipt, err := getReceipt(args[0])
 		if err != nil {
 			return err
 		}
-		fmt.Println(sdk.MarshalTextString(txReceipt))
-		return nil
+		return printResult(txReceipt)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(receiptCmd)
+	receiptCmd.Flags().BoolVarP(&waitReceipt, "wait", "", false, "wait until the transaction is irreversible, see --check_result_delay and --wait_timeout")
 }
@@ -3,12 +3,12 @@ package iwallet
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"time"
 
-	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/iwallet/ledger"
 	"github.com/iost-official/go-iost/sdk"
-
-	"github.com/mitchellh/go-homedir"
+	"github.com/iost-official/go-iost/sdk/txbuilder"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,29 +18,59 @@ var startTime time.Time
 
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
-	Use:           "iwallet",
-	Short:         "IOST client",
-	Long:          `An IOST RPC client`,
+	Use:   "iwallet",
+	Short: "IOST client",
+	Long: `An IOST RPC client
+	An unknown subcommand NAME runs the plugin iwallet-NAME on PATH, see "iwallet plugin --help"`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		startTime = time.Now()
+		if configErr != nil {
+			return configErr
+		}
+		if err := checkOutputFormat(outputFormat); err != nil {
+			return err
+		}
 		iwalletSDK = sdk.NewIOSTDevSDK()
 		iwalletSDK.SetChainID(chainID)
+		iwalletSDK.SetNetwork(network)
 		iwalletSDK.SetServer(server)
-		iwalletSDK.SetVerbose(verbose)
+		if useTLS || caCert != "" || clientCert != "" || clientKey != "" {
+			if err := iwalletSDK.SetTLS(caCert, clientCert, clientKey); err != nil {
+				return err
+			}
+		}
+		if apiKey != "" {
+			iwalletSDK.SetAPIKey(apiKey)
+		}
+		iwalletSDK.SetVerbose(verbose && !isMachineOutput())
 		iwalletSDK.SetSignAlgo(signAlgo)
-		iwalletSDK.SetCheckResult(checkResult, checkResultDelay, checkResultMaxRetry)
+		iwalletSDK.SetCheckResult(checkResult && !async, checkResultDelay, waitTimeout)
 		limit, err := ParseAmountLimit(amountLimit)
 		if err != nil {
 			return fmt.Errorf("invalid amount limit %v: %v", amountLimit, err)
 		}
-		iwalletSDK.SetTxInfo(gasLimit, gasRatio, expiration, delaySecond, limit)
+		if err := txbuilder.CheckDelay(delay); err != nil {
+			return err
+		}
+		iwalletSDK.SetTxInfo(gasLimit, gasRatio, expiration, 0, limit)
+		iwalletSDK.SetDelay(delay)
 		iwalletSDK.SetUseLongestChain(useLongestChain)
+		iwalletSDK.SetIncludePending(includePending)
+		iwalletSDK.SetBlockNumber(blockNumber)
+		if maxQPS > 0 {
+			iwalletSDK.SetRateLimit(sdk.RateLimit{QPS: maxQPS})
+		}
+		if adjustTime {
+			p := sdk.DefaultClockPolicy
+			p.Adjust = true
+			iwalletSDK.SetClockPolicy(p)
+		}
 		return nil
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
-		if elapsedTime && (len(cmd.Use) < 4 || cmd.Use[:4] != "help") {
+		if elapsedTime && !isMachineOutput() && (len(cmd.Use) < 4 || cmd.Use[:4] != "help") {
 			fmt.Println("Executed in", time.Since(startTime))
 		}
 	},
@@ -49,8 +79,27 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	if path, flagArgs, args, ok := findPlugin(os.Args[1:]); ok {
+		err := runPlugin(path, flagArgs, args)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		if err != nil {
+			fmt.Println("\033[38;5;1mERROR:\033[38;5;12m", err)
+			os.Exit(1)
+		}
+		return
+	}
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println("\033[38;5;1mERROR:\033[38;5;12m", err)
+		if isMachineOutput() {
+			printError(err)
+		} else {
+			e := newErrorResult(err)
+			fmt.Println("\033[38;5;1mERROR:\033[38;5;12m", err)
+			if e.Suggestion != "" {
+				fmt.Printf("\033[38;5;3m%v:\033[0m %v\n", e.Code, e.Suggestion)
+			}
+		}
 		os.Exit(1)
 	}
 }
@@ -61,25 +110,45 @@ func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "configuration file (default $HOME/.iwallet.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "configuration file (default $HOME/.iwallet/config.yaml)")
 
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", true, "print verbose information")
 	rootCmd.PersistentFlags().BoolVarP(&elapsedTime, "elapsed_time", "", false, "print elapsed time")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output_format", "", "", "print results as json, yaml or table for scripting instead of human readable text")
 	rootCmd.PersistentFlags().StringVarP(&accountName, "account", "a", "", "which account to use")
-	rootCmd.PersistentFlags().StringVarP(&server, "server", "s", "localhost:30002", "set server of this client")
+	rootCmd.PersistentFlags().StringVarP(&server, "server", "s", "localhost:30002", "set server of this client, or a comma separated list of servers to fail over between, or the url of the json gateway of a node like http://localhost:30001 where grpc can't be used")
+	rootCmd.PersistentFlags().BoolVarP(&useTLS, "tls", "", false, "connect to the server with tls, checking its certificate against the CA certificates of the system unless --ca_cert is given")
+	rootCmd.PersistentFlags().StringVarP(&caCert, "ca_cert", "", "", "pem file of the CA certificates to check the server certificate against, implies --tls")
+	rootCmd.PersistentFlags().StringVarP(&clientCert, "client_cert", "", "", "pem file of the client certificate for servers requiring mutual tls, given with --client_key, implies --tls")
+	rootCmd.PersistentFlags().StringVarP(&clientKey, "client_key", "", "", "pem file of the key of --client_cert")
+	rootCmd.PersistentFlags().StringVarP(&apiKey, "api_key", "", "", "api key sent with each call, for nodes requiring one")
 	rootCmd.PersistentFlags().BoolVarP(&useLongestChain, "use_longest", "", false, "get info on longest chain")
+	rootCmd.PersistentFlags().BoolVarP(&includePending, "include_pending", "", false, "get account info and balances with the pending transactions executed on the longest chain, showing the balances expected after a transfer before it is packed")
+	rootCmd.PersistentFlags().Int64VarP(&blockNumber, "block_number", "", 0, "get account info and balances at the block of number, the current state if 0; the blocks before the last irreversible block need a node keeping the state history")
 	rootCmd.PersistentFlags().BoolVarP(&checkResult, "check_result", "", true, "check publish/call status after sending to chain")
-	rootCmd.PersistentFlags().Float32VarP(&checkResultDelay, "check_result_delay", "", 3, "rpc checking will occur at [checkResultDelay] seconds after sending to chain.")
+	rootCmd.PersistentFlags().Float32VarP(&checkResultDelay, "check_result_delay", "", 3, "rpc checking will occur at [checkResultDelay] seconds after sending to chain, the interval is then doubled up to 10 seconds")
 	rootCmd.PersistentFlags().Int32VarP(&checkResultMaxRetry, "check_result_max_retry", "", 30, "max times to call grpc to check tx status")
+	rootCmd.PersistentFlags().MarkDeprecated("check_result_max_retry", "use --wait_timeout instead")
+	rootCmd.PersistentFlags().DurationVarP(&waitTimeout, "wait_timeout", "", sdk.DefaultWaitTimeout, "how long to wait for a sent transaction to become irreversible")
+	rootCmd.PersistentFlags().BoolVarP(&async, "async", "", false, "return the tx hash right after sending without waiting for the result, check it later by \"iwallet receipt hash --wait\"")
 	rootCmd.PersistentFlags().StringVarP(&signAlgo, "sign_algo", "", "ed25519", "sign algorithm")
-	rootCmd.PersistentFlags().StringSliceVarP(&signers, "signers", "", []string{}, "additional signers")
+	rootCmd.PersistentFlags().StringSliceVarP(&signers, "signers", "", []string{}, "additional signers, eg alice@active,bob@active, signing with their keys in the local keystores unless --sign_keys or --with_signs is given")
 	rootCmd.PersistentFlags().Float64VarP(&gasLimit, "gas_limit", "l", 1000000, "gas limit for a transaction")
 	rootCmd.PersistentFlags().Float64VarP(&gasRatio, "gas_ratio", "p", 1.0, "gas ratio for a transaction")
 	rootCmd.PersistentFlags().StringVarP(&amountLimit, "amount_limit", "", "*:unlimited", "amount limit for one transaction, eg iost:300.00|ram:2000")
 	rootCmd.PersistentFlags().Int64VarP(&expiration, "expiration", "e", 60*5, "expiration time for a transaction in seconds")
+	rootCmd.PersistentFlags().DurationVarP(&delay, "delay", "", 0, "delay the execution of transactions, eg 1h, they can be canceled by \"iwallet tx cancel-delayed\" before being executed")
 	rootCmd.PersistentFlags().Uint32VarP(&chainID, "chain_id", "", uint32(1024), "chain id which distinguishes different network")
+	rootCmd.PersistentFlags().StringVarP(&network, "network", "", "", "use the server and chain id of a network, eg mainnet, testnet or local, and check the node is on the chain id before signing")
 	rootCmd.PersistentFlags().StringVarP(&txTime, "tx_time", "", "", "use the special tx time instead of now, format: 2019-01-22T17:00:39+08:00")
+	rootCmd.PersistentFlags().BoolVarP(&adjustTime, "adjust_time", "", false, "set the time of the transactions sent by the clock of the node when the clock of this machine is more than 10s off it")
 	rootCmd.PersistentFlags().StringVarP(&signPerm, "sign_permission", "", "active", "permission used to sign transactions")
+	rootCmd.PersistentFlags().StringVarP(&hardware, "hardware", "", "", "sign transactions with a hardware wallet instead of a key file, only \"ledger\" is supported now")
+	rootCmd.PersistentFlags().StringVarP(&hdPath, "hd_path", "", ledger.DefaultPath, "bip32 path used to derive keys from a mnemonic or to find the key on a hardware wallet")
+	rootCmd.PersistentFlags().StringVarP(&remoteSigner, "remote_signer", "", "", "url of a signing service holding the key of --account@--sign_permission instead of a key file, authenticated by the bearer token IWALLET_REMOTE_SIGNER_TOKEN if set")
+	rootCmd.PersistentFlags().StringVarP(&feePayer, "fee_payer", "", "", "account paying the gas and ram of transactions by publishing them, while --account signs them as a signer")
+	rootCmd.PersistentFlags().Float64VarP(&maxQPS, "max_qps", "", 0, "make at most this many calls to the server per second, eg for public nodes banning busy clients, 0 for no limit")
+	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry_run", "", false, "sign the transaction and execute it on the node to print its receipt and gas and ram cost, without broadcasting it")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -88,27 +157,28 @@ func init() {
 
 // initConfig reads config file and ENV variables if set.
 func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := homedir.Dir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		// Search config in home directory with name ".iwallet" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".iwallet")
+	fileName, legacy, err := getReadConfigFile()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
-
+	if legacy {
+		fmt.Fprintf(os.Stderr, "WARNING: %v is deprecated, move it to $HOME/.iwallet/config.yaml\n", fileName)
+	}
+	viper.SetConfigFile(fileName)
+	viper.SetEnvPrefix(configEnvPrefix)
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	if _, err := os.Stat(fileName); err == nil {
+		if err := viper.ReadInConfig(); err != nil {
+			configErr = fmt.Errorf("failed to read config file %v: %v", fileName, err)
+			return
+		}
+	}
+	configErr = applyConfig(rootCmd.PersistentFlags())
+	if configErr == nil {
+		configErr = applyNetwork(rootCmd.PersistentFlags())
 	}
 }
 
@@ -116,25 +186,40 @@ var iwalletSDK *sdk.IOSTDevSDK
 
 var (
 	server      string
+	useTLS      bool
+	caCert      string
+	clientCert  string
+	clientKey   string
+	apiKey      string
 	accountName string
-	keyPair     *account.KeyPair
 	signAlgo    string
 	signers     []string
 	signPerm    string
+	hardware    string
+	hdPath      string
+	// remoteSigner is the url of the signing service signing for the account
+	remoteSigner string
+	feePayer     string
 
 	gasLimit    float64
 	gasRatio    float64
 	expiration  int64
 	amountLimit string
-	delaySecond int64
+	delay       time.Duration
 
 	checkResult         bool
 	checkResultDelay    float32
 	checkResultMaxRetry int32
+	waitTimeout         time.Duration
+	async               bool
 	useLongestChain     bool
+	includePending      bool
+	blockNumber         int64
 
 	verbose     bool
 	elapsedTime bool
 
-	chainID uint32
+	chainID    uint32
+	maxQPS     float64
+	adjustTime bool
 )
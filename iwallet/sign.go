@@ -17,7 +17,10 @@ package iwallet
 import (
 	"fmt"
 	"github.com/iost-official/go-iost/sdk"
+	"os"
 
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/spf13/cobra"
 )
@@ -25,20 +28,28 @@ import (
 var outputFile string
 var signKeyFile string
 var txFile string
+var fromQR bool
 
 // signCmd represents the command used to sign a transaction.
 var signCmd = &cobra.Command{
-	Use:     "sign txFile keyFile outputFile",
-	Short:   "Sign a tx and save the signature",
-	Long:    `Sign a tx loaded from given file with private key file and save the signature`,
-	Example: `  iwallet sign tx.json ~/.iwallet/test0_ed25519 sign.json`,
+	Use:   "sign txFile keyFile outputFile",
+	Short: "Sign a tx and save the signature",
+	Long:  `Sign a tx loaded from given file with private key file and save the signature`,
+	Example: `  iwallet sign tx.json ~/.iwallet/test0_ed25519 sign.json
+  iwallet sign --from_qr ~/.iwallet/test0_ed25519`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if fromQR {
+			return checkArgsNumber(cmd, args, "keyFile")
+		}
 		if err := checkArgsNumber(cmd, args, "txFile", "keyFile", "outputFile"); err != nil {
 			return err
 		}
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if fromQR {
+			return signFromQR(args)
+		}
 		txFile := args[0]
 		signKeyFile := args[1]
 		outputFile := args[2]
@@ -62,6 +73,168 @@ var signCmd = &cobra.Command{
 	},
 }
 
+// signFromQR signs a tx scanned from qr codes and shows the signature as qr codes. args are keyFile [outputFile].
+func signFromQR(args []string) error {
+	kp, err := sdk.LoadKeyPair(args[0], signAlgo)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %v", err)
+	}
+	trx := &rpcpb.TransactionRequest{}
+	if err := readQRFrames(os.Stdin, qrKindTx, trx); err != nil {
+		return fmt.Errorf("failed to read transaction: %v", err)
+	}
+	fmt.Println("Transaction:")
+	fmt.Println(sdk.MarshalTextString(trx))
+	sig := sdk.GetSignatureOfTx(trx, kp)
+	if len(args) > 1 {
+		err = sdk.SaveProtoStructToJSONFile(sig, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to save signature: %v", err)
+		}
+		fmt.Println("Successfully saved signature as:", args[1])
+	}
+	frames, err := encodeQRFrames(qrKindSig, sig, qrFrameSize)
+	if err != nil {
+		return err
+	}
+	return showQRFrames(frames, qrPNG)
+}
+
+// sigCheck is the result of checking a signature file against a tx.
+type sigCheck struct {
+	File      string `json:"file"`
+	PubKey    string `json:"pubkey"`
+	Algorithm string `json:"algorithm"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
+}
+
+// signerKeyPair is the key given by --key_file, or else the key of --account and --sign_permission in the keystore.
+func signerKeyPair() (*account.KeyPair, error) {
+	if signKeyFile != "" {
+		kp, err := sdk.LoadKeyPair(signKeyFile, signAlgo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key pair: %v", err)
+		}
+		return kp, nil
+	}
+	if accountName == "" {
+		return nil, fmt.Errorf("please give the key by --key_file or --account")
+	}
+	a, err := loadAccountByName(accountName, true)
+	if err != nil {
+		return nil, err
+	}
+	kp, ok := a.Keypairs[signPerm]
+	if !ok {
+		return nil, fmt.Errorf("invalid permission %v", signPerm)
+	}
+	return kp.toKeyPair()
+}
+
+func checkSigFile(trx *rpcpb.TransactionRequest, file string) *sigCheck {
+	c := &sigCheck{File: file}
+	sig := &rpcpb.Signature{}
+	if err := sdk.LoadProtoStructFromJSONFile(file, sig); err != nil {
+		c.Error = err.Error()
+		return c
+	}
+	c.PubKey = common.Base58Encode(sig.PublicKey)
+	c.Algorithm = sig.Algorithm.String()
+	c.Valid = sdk.VerifySigForTx(trx, sig)
+	if !c.Valid {
+		c.Error = "signature does not match the transaction"
+	}
+	return c
+}
+
+var signTxCmd = &cobra.Command{
+	Use:   "sign-tx txFile",
+	Short: "Sign an unsigned tx and save the signature for --with_signs",
+	Long: `Sign the tx saved by "iwallet save" or "iwallet multisig init" as one of its signers, and save the signature as json
+		which can be given to "iwallet call --tx_file" by --with_signs. The key is given by --key_file, or else it is the key
+		of --account and --sign_permission in the keystore`,
+	Example: `  iwallet sign-tx tx.json --key_file ~/.iwallet/test0_ed25519 -o test0.sig.json
+  iwallet sign-tx tx.json --account test0 --sign_permission owner -o test0.sig.json
+  iwallet call --tx_file tx.json --with_signs test0.sig.json,test1.sig.json --account test2`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "txFile")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx := &rpcpb.TransactionRequest{}
+		if err := sdk.LoadProtoStructFromJSONFile(args[0], trx); err != nil {
+			return fmt.Errorf("failed to load transaction file: %v", err)
+		}
+		kp, err := signerKeyPair()
+		if err != nil {
+			return err
+		}
+		sig := sdk.GetSignatureOfTx(trx, kp)
+		output := outputFile
+		if output == "" {
+			output = "sig.json"
+		}
+		if err := sdk.SaveProtoStructToJSONFile(sig, output); err != nil {
+			return fmt.Errorf("failed to save signature: %v", err)
+		}
+		if isMachineOutput() {
+			return printResult(&sigCheck{File: output, PubKey: common.Base58Encode(sig.PublicKey), Algorithm: sig.Algorithm.String(), Valid: true})
+		}
+		fmt.Println("Signed by public key:", common.Base58Encode(sig.PublicKey))
+		fmt.Println("Successfully saved signature as:", output)
+		return nil
+	},
+}
+
+var verifySigCmd = &cobra.Command{
+	Use:     "verify-sig txFile signatureFile...",
+	Short:   "Check signature files against a tx",
+	Long:    `Check that the signature files are valid signatures of the tx, as "iwallet call --with_signs" does, and show their public keys`,
+	Example: `  iwallet verify-sig tx.json test0.sig.json test1.sig.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "txFile", "signatureFile")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx := &rpcpb.TransactionRequest{}
+		if err := sdk.LoadProtoStructFromJSONFile(args[0], trx); err != nil {
+			return fmt.Errorf("failed to load transaction file: %v", err)
+		}
+		checks := make([]*sigCheck, 0, len(args)-1)
+		invalid := 0
+		for _, f := range args[1:] {
+			c := checkSigFile(trx, f)
+			if !c.Valid {
+				invalid++
+			}
+			checks = append(checks, c)
+		}
+		if isMachineOutput() {
+			if err := printResult(checks); err != nil {
+				return err
+			}
+		} else {
+			for _, c := range checks {
+				if c.Valid {
+					fmt.Printf("%v\tOK\t%v %v\n", c.File, c.Algorithm, c.PubKey)
+				} else {
+					fmt.Printf("%v\tINVALID\t%v\n", c.File, c.Error)
+				}
+			}
+		}
+		if invalid > 0 {
+			return fmt.Errorf("%v of %v signatures are invalid", invalid, len(checks))
+		}
+		return nil
+	},
+}
+
 func init() {
+	rootCmd.AddCommand(signTxCmd)
+	signTxCmd.Flags().StringVarP(&signKeyFile, "key_file", "", "", "private key file to sign with instead of the keystore")
+	signTxCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file to save the signature (default sig.json)")
+	rootCmd.AddCommand(verifySigCmd)
 	rootCmd.AddCommand(signCmd)
+	signCmd.Flags().BoolVarP(&fromQR, "from_qr", "", false, "read the tx from scanned qr code frames on stdin and show the signature as qr codes")
+	signCmd.Flags().IntVarP(&qrFrameSize, "qr_frame_size", "", 600, "max payload length of one qr code frame")
+	signCmd.Flags().StringVarP(&qrPNG, "png", "", "", "save qr code frames as png files with this prefix instead of printing them")
 }
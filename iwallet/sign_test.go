@@ -0,0 +1,127 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sign")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	trx := &rpcpb.TransactionRequest{
+		Time:    1544013436179000000,
+		ChainId: 1024,
+		Signers: []string{"test0@active"},
+		Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer", Data: `["iost","test0","test1","1",""]`}},
+	}
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	sigFile := filepath.Join(dir, "test0.sig.json")
+	assert.Nil(t, sdk.SaveProtoStructToJSONFile(sdk.GetSignatureOfTx(trx, kp), sigFile))
+
+	c := checkSigFile(trx, sigFile)
+	assert.True(t, c.Valid)
+	assert.Equal(t, kp.ReadablePubkey(), c.PubKey)
+	assert.Equal(t, "ED25519", c.Algorithm)
+
+	// the signature is only for the tx which was signed
+	sigs, err := loadSignaturesForTx(trx, []string{sigFile})
+	assert.Nil(t, err)
+	assert.Len(t, sigs, 1)
+	trx.Time++
+	c = checkSigFile(trx, sigFile)
+	assert.False(t, c.Valid)
+	assert.NotEmpty(t, c.Error)
+
+	c = checkSigFile(trx, filepath.Join(dir, "missing.json"))
+	assert.False(t, c.Valid)
+	assert.NotEmpty(t, c.Error)
+}
+
+func TestSignTxWithFeePayer(t *testing.T) {
+	user, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	payer, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	s := sdk.NewIOSTDevSDK()
+	s.SetAccount("user0", user)
+	s.SetFeePayer("payer0", sdk.NewKeyPairSigner(payer), "active")
+
+	trx := &rpcpb.TransactionRequest{
+		Time:    1544013436179000000,
+		ChainId: 1024,
+		Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer", Data: `["iost","user0","user1","1",""]`}},
+	}
+	trx, err = s.SignTx(trx, "ed25519")
+	assert.Nil(t, err)
+	// the account signs as a signer, the fee payer publishes
+	assert.Equal(t, "payer0", trx.Publisher)
+	assert.Equal(t, []string{"user0@active"}, trx.Signers)
+	assert.Len(t, trx.Signatures, 1)
+	assert.Equal(t, user.Pubkey, trx.Signatures[0].PublicKey)
+	assert.True(t, crypto.Ed25519.Verify(sdk.TxHashForSign(trx), trx.Signatures[0].PublicKey, trx.Signatures[0].Signature))
+	assert.Len(t, trx.PublisherSigs, 1)
+	assert.Equal(t, payer.Pubkey, trx.PublisherSigs[0].PublicKey)
+
+	// signing again replaces the signature of the account
+	trx, err = s.SignTx(trx, "ed25519")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user0@active"}, trx.Signers)
+	assert.Len(t, trx.Signatures, 1)
+}
+
+func TestTxPublisher(t *testing.T) {
+	accountName, feePayer = "user0", ""
+	defer func() { accountName, feePayer = "", "" }()
+	assert.Equal(t, "user0", txPublisher())
+	feePayer = "payer0"
+	assert.Equal(t, "payer0", txPublisher())
+}
+
+func TestSignWithLocalAccounts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "home")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+
+	alice, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	bob, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	assert.Nil(t, SaveAccount("alice", alice))
+	assert.Nil(t, SaveAccount("bob", bob))
+
+	trx := &rpcpb.TransactionRequest{
+		Time:    1544013436179000000,
+		ChainId: 1024,
+		Signers: []string{"alice@active", "bob@active"},
+		Actions: []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer", Data: `["iost","alice","bob","1",""]`}},
+	}
+	sigs, err := signWithLocalAccounts(trx)
+	assert.Nil(t, err)
+	assert.Len(t, sigs, 2)
+	assert.Equal(t, alice.Pubkey, sigs[0].PublicKey)
+	assert.Equal(t, bob.Pubkey, sigs[1].PublicKey)
+	for _, sig := range sigs {
+		assert.True(t, sdk.VerifySigForTx(trx, sig))
+	}
+
+	trx.Signers = append(trx.Signers, "carol@active")
+	_, err = signWithLocalAccounts(trx)
+	assert.Contains(t, err.Error(), "failed to load signer carol@active")
+}
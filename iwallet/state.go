@@ -36,13 +36,16 @@ var stateCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("cannot get node info: %v", err)
 		}
-		fmt.Print(strings.TrimRight(sdk.MarshalTextString(n), "}"))
 		c, err := iwalletSDK.GetChainInfo()
 		if err != nil {
 			return fmt.Errorf("cannot get chain info: %v", err)
 		}
-		fmt.Println(strings.Replace(sdk.MarshalTextString(c), "{\n", "", 1))
 		iwalletSDK.CloseConn()
+		if isMachineOutput() {
+			return printResult(map[string]interface{}{"node_info": n, "chain_info": c})
+		}
+		fmt.Print(strings.TrimRight(sdk.MarshalTextString(n), "}"))
+		fmt.Println(strings.Replace(sdk.MarshalTextString(c), "{\n", "", 1))
 		return nil
 	},
 }
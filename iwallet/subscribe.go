@@ -0,0 +1,242 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	subContracts  []string
+	subArgs       []string
+	subTopics     []string
+	subMatch      string
+	subMaxBackoff time.Duration
+)
+
+// parseTopics accepts receipt, event, pending, or the enum names of Event.Topic.
+func parseTopics(names []string) ([]rpcpb.Event_Topic, error) {
+	if len(names) == 0 {
+		return []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_RECEIPT, rpcpb.Event_CONTRACT_EVENT}, nil
+	}
+	topics := make([]rpcpb.Event_Topic, 0, len(names))
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case "receipt", "contract_receipt":
+			topics = append(topics, rpcpb.Event_CONTRACT_RECEIPT)
+		case "event", "contract_event":
+			topics = append(topics, rpcpb.Event_CONTRACT_EVENT)
+		case "pending", "transaction_pending":
+			topics = append(topics, rpcpb.Event_TRANSACTION_PENDING)
+		default:
+			return nil, fmt.Errorf("invalid topic %v, should be receipt, event or pending", name)
+		}
+	}
+	return topics, nil
+}
+
+// parseArgFilters parses the --arg flags like key=value, the values of the same key being alternatives.
+func parseArgFilters(args []string) ([]*rpcpb.SubscribeRequest_ArgFilter, error) {
+	var filters []*rpcpb.SubscribeRequest_ArgFilter
+	byKey := make(map[string]*rpcpb.SubscribeRequest_ArgFilter)
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid argument filter %v, should be like key=value", arg)
+		}
+		f, ok := byKey[kv[0]]
+		if !ok {
+			f = &rpcpb.SubscribeRequest_ArgFilter{Key: kv[0]}
+			byKey[kv[0]] = f
+			filters = append(filters, f)
+		}
+		f.Values = append(f.Values, kv[1])
+	}
+	return filters, nil
+}
+
+type decodedEvent struct {
+	Topic    string      `json:"topic" yaml:"topic"`
+	Contract string      `json:"contract,omitempty" yaml:"contract,omitempty"`
+	Time     int64       `json:"time" yaml:"time"`
+	Data     interface{} `json:"data" yaml:"data"`
+}
+
+// decodeEvent decodes the event data as json if possible, which is what most contracts emit. The contract is the one
+// of the event, or the given one for nodes not telling it.
+func decodeEvent(e *rpcpb.Event, contract string) *decodedEvent {
+	if e.ContractId != "" {
+		contract = e.ContractId
+	}
+	d := &decodedEvent{Topic: e.Topic.String(), Contract: contract, Time: e.Time, Data: e.Data}
+	var data interface{}
+	decoder := json.NewDecoder(strings.NewReader(e.Data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err == nil && !decoder.More() {
+		d.Data = data
+	}
+	return d
+}
+
+func printEvent(d *decodedEvent) error {
+	switch outputFormat {
+	case outputJSON:
+		// One event per line, so the stream can be consumed line by line.
+		data, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case outputYAML:
+		data, err := yaml.Marshal(d)
+		if err != nil {
+			return err
+		}
+		fmt.Print("---\n" + string(data))
+	default:
+		data, ok := d.Data.(string)
+		if !ok {
+			b, err := json.Marshal(d.Data)
+			if err != nil {
+				return err
+			}
+			data = string(b)
+		}
+		contract := d.Contract
+		if contract == "" {
+			contract = "*"
+		}
+		fmt.Printf("%v\t%v\t%v\t%v\n", time.Unix(0, d.Time).Format(time.RFC3339), d.Topic, contract, data)
+	}
+	return nil
+}
+
+func nextBackoff(cur, max time.Duration) time.Duration {
+	if cur <= 0 {
+		return time.Second
+	}
+	cur *= 2
+	if cur > max {
+		cur = max
+	}
+	return cur
+}
+
+// subscribeOnce reads events until the stream breaks. It reports whether any event was received.
+func subscribeOnce(ctx context.Context, req *rpcpb.SubscribeRequest) (bool, error) {
+	defer iwalletSDK.CloseConn()
+	stream, err := iwalletSDK.Subscribe(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	received := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		if resp.Event == nil {
+			continue
+		}
+		if subMatch != "" && !strings.Contains(resp.Event.Data, subMatch) {
+			continue
+		}
+		contract := ""
+		if len(subContracts) == 1 {
+			contract = subContracts[0]
+		}
+		if err := printEvent(decodeEvent(resp.Event, contract)); err != nil {
+			return received, err
+		}
+	}
+}
+
+var subscribeCmd = &cobra.Command{
+	Use:   "subscribe",
+	Short: "Print contract events live",
+	Long: `Subscribe to contract receipts and events on the node and print them as they happen
+	The subscription is reestablished with exponential backoff if the connection breaks. Press Ctrl-C to stop.`,
+	Example: `  iwallet subscribe --contract token.iost --topics receipt
+  iwallet subscribe --contract token.iost --arg 0=iost --arg 2=alice --arg 2=bob
+  iwallet subscribe --contract token.iost --match '"transfer"' --output_format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		topics, err := parseTopics(subTopics)
+		if err != nil {
+			return err
+		}
+		argFilters, err := parseArgFilters(subArgs)
+		if err != nil {
+			return err
+		}
+		req := &rpcpb.SubscribeRequest{Topics: topics}
+		if len(subContracts) > 0 || len(argFilters) > 0 {
+			req.Filter = &rpcpb.SubscribeRequest_Filter{Args: argFilters}
+			// a single contract goes in contract_id, which older nodes filter by too
+			if len(subContracts) == 1 {
+				req.Filter.ContractId = subContracts[0]
+			} else {
+				req.Filter.ContractIds = subContracts
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sig
+			cancel()
+		}()
+
+		var backoff time.Duration
+		for {
+			received, err := subscribeOnce(ctx, req)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if received {
+				backoff = 0
+			}
+			backoff = nextBackoff(backoff, subMaxBackoff)
+			fmt.Fprintf(os.Stderr, "subscription broken: %v, reconnecting in %v\n", err, backoff)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(subscribeCmd)
+	subscribeCmd.Flags().StringSliceVarP(&subContracts, "contract", "c", []string{}, "only show events of these contracts")
+	subscribeCmd.Flags().StringArrayVarP(&subArgs, "arg", "", []string{}, "only show events whose data, a json array or object, has the value at the index or key, like 2=bob; repeat for several values or arguments")
+	subscribeCmd.Flags().StringSliceVarP(&subTopics, "topics", "", []string{}, "topics to subscribe, receipt, event and/or pending for the hashes of new pending transactions (default receipt and event)")
+	subscribeCmd.Flags().StringVarP(&subMatch, "match", "", "", "only show events whose data contains this string")
+	subscribeCmd.Flags().DurationVarP(&subMaxBackoff, "max_backoff", "", time.Minute, "max wait time between reconnections")
+}
@@ -0,0 +1,61 @@
+package iwallet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopics(t *testing.T) {
+	topics, err := parseTopics(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(topics))
+	topics, err = parseTopics([]string{"Receipt"})
+	assert.Nil(t, err)
+	assert.Equal(t, []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_RECEIPT}, topics)
+	topics, err = parseTopics([]string{"event", "pending"})
+	assert.Nil(t, err)
+	assert.Equal(t, []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_EVENT, rpcpb.Event_TRANSACTION_PENDING}, topics)
+	_, err = parseTopics([]string{"transfer"})
+	assert.NotNil(t, err)
+}
+
+func TestParseArgFilters(t *testing.T) {
+	filters, err := parseArgFilters([]string{"2=bob", "0=iost", "2=alice", "memo=a=b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*rpcpb.SubscribeRequest_ArgFilter{
+		{Key: "2", Values: []string{"bob", "alice"}},
+		{Key: "0", Values: []string{"iost"}},
+		{Key: "memo", Values: []string{"a=b"}},
+	}, filters)
+	_, err = parseArgFilters([]string{"bob"})
+	assert.NotNil(t, err)
+	_, err = parseArgFilters([]string{"=bob"})
+	assert.NotNil(t, err)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	d := decodeEvent(&rpcpb.Event{Topic: rpcpb.Event_CONTRACT_RECEIPT, Data: `["iost","a","b","1.5",""]`, Time: 1}, "token.iost")
+	assert.Equal(t, "CONTRACT_RECEIPT", d.Topic)
+	assert.Equal(t, []interface{}{"iost", "a", "b", "1.5", ""}, d.Data)
+	d = decodeEvent(&rpcpb.Event{Data: `[]`, ContractId: "base.iost"}, "token.iost")
+	assert.Equal(t, "base.iost", d.Contract)
+	d = decodeEvent(&rpcpb.Event{Data: `plain text`}, "")
+	assert.Equal(t, "plain text", d.Data)
+	d = decodeEvent(&rpcpb.Event{Data: `1 2`}, "")
+	assert.Equal(t, "1 2", d.Data)
+	d = decodeEvent(&rpcpb.Event{Data: `{"n":10}`}, "")
+	assert.Equal(t, map[string]interface{}{"n": json.Number("10")}, d.Data)
+}
+
+func TestNextBackoff(t *testing.T) {
+	b := nextBackoff(0, 5*time.Second)
+	assert.Equal(t, time.Second, b)
+	b = nextBackoff(b, 5*time.Second)
+	assert.Equal(t, 2*time.Second, b)
+	b = nextBackoff(4*time.Second, 5*time.Second)
+	assert.Equal(t, 5*time.Second, b)
+}
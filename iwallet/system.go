@@ -39,8 +39,11 @@ func sendAction(contract, method string, methodArgs ...interface{}) error {
 	if err != nil {
 		return err
 	}
-	action := sdk.NewAction(contract, method, string(methodArgsBytes))
-	actions := []*rpcpb.Action{action}
+	return sendActions(sdk.NewAction(contract, method, string(methodArgsBytes)))
+}
+
+// sendActions sends the actions in one tx, so that they take effect all together or not at all.
+func sendActions(actions ...*rpcpb.Action) error {
 	tx, err := iwalletSDK.CreateTxFromActions(actions)
 	if err != nil {
 		return fmt.Errorf("failed to create tx: %v", err)
@@ -49,12 +52,18 @@ func sendAction(contract, method string, methodArgs ...interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to load account: %v", err)
 	}
+	if estimateOnly || dryRun {
+		return estimateTx(tx)
+	}
 	if err := iwalletSDK.Connect(); err != nil {
 		return err
 	}
-	_, err = iwalletSDK.SendTx(tx)
+	txHash, err := iwalletSDK.SendTx(tx)
 	iwalletSDK.CloseConn()
-	return err
+	if err != nil {
+		return err
+	}
+	return printTxHash(txHash)
 }
 
 func init() {
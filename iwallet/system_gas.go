@@ -31,7 +31,7 @@ var pledgeCmd = &cobra.Command{
 		if err := checkArgsNumber(cmd, args, "amount"); err != nil {
 			return err
 		}
-		if err := checkFloat(cmd, args[0], "amount"); err != nil {
+		if err := checkAmount(cmd, args[0], "amount"); err != nil {
 			return err
 		}
 		return checkAccount(cmd)
@@ -40,7 +40,11 @@ var pledgeCmd = &cobra.Command{
 		if gasUser == "" {
 			gasUser = accountName
 		}
-		return sendAction("gas.iost", "pledge", accountName, gasUser, args[0])
+		amount, err := amountOf(args[0], "iost")
+		if err != nil {
+			return err
+		}
+		return sendAction("gas.iost", "pledge", accountName, gasUser, amount)
 	},
 }
 
@@ -56,7 +60,11 @@ var unpledgeCmd = &cobra.Command{
 		if gasUser == "" {
 			gasUser = accountName
 		}
-		return sendAction("gas.iost", "unpledge", accountName, gasUser, args[0])
+		amount, err := amountOf(args[0], "iost")
+		if err != nil {
+			return err
+		}
+		return sendAction("gas.iost", "unpledge", accountName, gasUser, amount)
 	},
 }
 
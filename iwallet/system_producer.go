@@ -39,13 +39,17 @@ var voteCmd = &cobra.Command{
 		if err := checkArgsNumber(cmd, args, "producerID", "amount"); err != nil {
 			return err
 		}
-		if err := checkFloat(cmd, args[1], "amount"); err != nil {
+		if err := checkAmount(cmd, args[1], "amount"); err != nil {
 			return err
 		}
 		return checkAccount(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return sendAction("vote_producer.iost", "vote", accountName, args[0], args[1])
+		amount, err := amountOf(args[1], "iost")
+		if err != nil {
+			return err
+		}
+		return sendAction("vote_producer.iost", "vote", accountName, args[0], amount)
 	},
 }
 var unvoteCmd = &cobra.Command{
@@ -56,7 +60,11 @@ var unvoteCmd = &cobra.Command{
 	Example: `  iwallet sys unvote producer000 1000000 --account test0`,
 	Args:    voteCmd.Args,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return sendAction("vote_producer.iost", "unvote", accountName, args[0], args[1])
+		amount, err := amountOf(args[1], "iost")
+		if err != nil {
+			return err
+		}
+		return sendAction("vote_producer.iost", "unvote", accountName, args[0], amount)
 	},
 }
 
@@ -248,7 +256,7 @@ var predeemCmd = &cobra.Command{
   iwallet sys producer-redeem 10 --account test0`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
-			if err := checkFloat(cmd, args[0], "amount"); err != nil {
+			if err := checkAmount(cmd, args[0], "amount"); err != nil {
 				return err
 			}
 		}
@@ -257,7 +265,10 @@ var predeemCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		amount := "0"
 		if len(args) > 0 {
-			amount = args[0]
+			var err error
+			if amount, err = amountOf(args[0], "contribute"); err != nil {
+				return err
+			}
 		}
 		return sendAction("bonus.iost", "exchangeIOST", accountName, amount)
 	},
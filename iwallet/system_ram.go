@@ -33,7 +33,7 @@ var buyCmd = &cobra.Command{
 		if err := checkArgsNumber(cmd, args, "amount"); err != nil {
 			return err
 		}
-		if err := checkFloat(cmd, args[0], "amount"); err != nil {
+		if err := checkBytes(cmd, args[0], "amount"); err != nil {
 			return err
 		}
 		return checkAccount(cmd)
@@ -42,7 +42,7 @@ var buyCmd = &cobra.Command{
 		if other == "" {
 			other = accountName
 		}
-		amount, _ := strconv.ParseFloat(args[0], 64)
+		amount, _ := strconv.ParseInt(args[0], 10, 64)
 		return sendAction("ram.iost", "buy", accountName, other, amount)
 	},
 }
@@ -59,7 +59,7 @@ var sellCmd = &cobra.Command{
 		if other == "" {
 			other = accountName
 		}
-		amount, _ := strconv.ParseFloat(args[0], 64)
+		amount, _ := strconv.ParseInt(args[0], 10, 64)
 		return sendAction("ram.iost", "sell", accountName, other, amount)
 	},
 }
@@ -74,13 +74,13 @@ var rtransCmd = &cobra.Command{
 		if err := checkArgsNumber(cmd, args, "receiver", "amount"); err != nil {
 			return err
 		}
-		if err := checkFloat(cmd, args[1], "amount"); err != nil {
+		if err := checkBytes(cmd, args[1], "amount"); err != nil {
 			return err
 		}
 		return checkAccount(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		amount, _ := strconv.ParseFloat(args[1], 64)
+		amount, _ := strconv.ParseInt(args[1], 10, 64)
 		return sendAction("ram.iost", "lend", accountName, args[0], amount)
 	},
 }
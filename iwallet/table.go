@@ -15,10 +15,7 @@
 package iwallet
 
 import (
-	"fmt"
-
 	"github.com/iost-official/go-iost/rpc/pb"
-	"github.com/iost-official/go-iost/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -52,8 +49,7 @@ var tableCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(sdk.MarshalTextString(response))
-		return nil
+		return printResult(response)
 	},
 }
 
@@ -0,0 +1,349 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/iost-official/go-iost/sdk/txbuilder"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var templateValues []string
+
+// txTemplate is a transaction whose strings may hold placeholders like {{.to}}, rendered with the values of the params.
+// The numbers are strings too so that they can be placeholders, eg gas_limit: "{{.gas}}".
+type txTemplate struct {
+	Params      map[string]*templateParam `yaml:"params"`
+	Actions     []*templateAction         `yaml:"actions"`
+	Signers     []string                  `yaml:"signers"`
+	AmountLimit string                    `yaml:"amount_limit"`
+	GasLimit    string                    `yaml:"gas_limit"`
+	GasRatio    string                    `yaml:"gas_ratio"`
+	Expiration  string                    `yaml:"expiration"`
+	Delay       string                    `yaml:"delay"`
+}
+
+// templateParam is a value of the template, which must be given by --set unless it has a default.
+type templateParam struct {
+	Description string  `yaml:"description"`
+	Default     *string `yaml:"default"`
+}
+
+type templateAction struct {
+	Contract string        `yaml:"contract"`
+	Action   string        `yaml:"action"`
+	Args     []interface{} `yaml:"args"`
+}
+
+func loadTxTemplate(file string) (*txTemplate, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	t := &txTemplate{}
+	if err := yaml.UnmarshalStrict(data, t); err != nil {
+		return nil, fmt.Errorf("invalid template %v: %v", file, err)
+	}
+	if len(t.Actions) == 0 {
+		return nil, fmt.Errorf("invalid template %v: no actions", file)
+	}
+	return t, nil
+}
+
+// templateValueMap parses the key=value pairs given by --set and fills in the defaults of the params.
+// The account is given as well unless it is a param, so that {{.account}} is the --account.
+func templateValueMap(params map[string]*templateParam, kvs []string, account string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, kv := range kvs {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid value %v, should be key=value", kv)
+		}
+		key := kv[:i]
+		if _, ok := params[key]; !ok && len(params) != 0 {
+			return nil, fmt.Errorf("unknown param %v, should be one of %v", key, strings.Join(templateParamNames(params), ", "))
+		}
+		values[key] = kv[i+1:]
+	}
+	var missing []string
+	for _, name := range templateParamNames(params) {
+		if _, ok := values[name]; ok {
+			continue
+		}
+		p := params[name]
+		if p != nil && p.Default != nil {
+			values[name] = *p.Default
+			continue
+		}
+		if p != nil && p.Description != "" {
+			name += " (" + p.Description + ")"
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("missing values of params %v, give them by --set key=value", strings.Join(missing, ", "))
+	}
+	if _, ok := values["account"]; !ok {
+		values["account"] = account
+	}
+	return values, nil
+}
+
+func templateParamNames(params map[string]*templateParam) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderString fills in the placeholders, a placeholder without value being an error.
+func renderString(s string, values map[string]string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	t, err := template.New("").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, values); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// renderValue renders the strings in an arg, which may be an array or an object holding them.
+func renderValue(v interface{}, values map[string]string) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return renderString(v, values)
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, e := range v {
+			r, err := renderValue(e, values)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = r
+		}
+		return rendered, nil
+	case map[interface{}]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			r, err := renderValue(e, values)
+			if err != nil {
+				return nil, err
+			}
+			rendered[fmt.Sprint(k)] = r
+		}
+		return rendered, nil
+	default:
+		return v, nil
+	}
+}
+
+// renderedTx is the part of a rendered template which is applied to the tx, empty strings being left to the flags.
+type renderedTx struct {
+	Actions     []*rpcpb.Action
+	Signers     []string
+	AmountLimit string
+	GasLimit    string
+	GasRatio    string
+	Expiration  string
+	Delay       string
+}
+
+func (t *txTemplate) render(values map[string]string) (*renderedTx, error) {
+	r := &renderedTx{}
+	for i, a := range t.Actions {
+		contract, err := renderString(a.Contract, values)
+		if err != nil {
+			return nil, fmt.Errorf("action %v: %v", i+1, err)
+		}
+		actionName, err := renderString(a.Action, values)
+		if err != nil {
+			return nil, fmt.Errorf("action %v: %v", i+1, err)
+		}
+		if contract == "" || actionName == "" {
+			return nil, fmt.Errorf("action %v: contract and action should be given", i+1)
+		}
+		args, err := renderValue(a.Args, values)
+		if err != nil {
+			return nil, fmt.Errorf("action %v: %v", i+1, err)
+		}
+		if a.Args == nil {
+			args = []interface{}{}
+		}
+		data, err := json.Marshal(args)
+		if err != nil {
+			return nil, fmt.Errorf("action %v: %v", i+1, err)
+		}
+		r.Actions = append(r.Actions, sdk.NewAction(contract, actionName, string(data)))
+	}
+	for _, s := range t.Signers {
+		signer, err := renderString(s, values)
+		if err != nil {
+			return nil, fmt.Errorf("signers: %v", err)
+		}
+		r.Signers = append(r.Signers, signer)
+	}
+	fields := []struct {
+		name string
+		in   string
+		out  *string
+	}{
+		{"amount_limit", t.AmountLimit, &r.AmountLimit},
+		{"gas_limit", t.GasLimit, &r.GasLimit},
+		{"gas_ratio", t.GasRatio, &r.GasRatio},
+		{"expiration", t.Expiration, &r.Expiration},
+		{"delay", t.Delay, &r.Delay},
+	}
+	for _, f := range fields {
+		v, err := renderString(f.in, values)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", f.name, err)
+		}
+		*f.out = v
+	}
+	return r, nil
+}
+
+// apply sets the fields of the tx given by the template, unless the flag of a field is given on the command line.
+func (r *renderedTx) apply(trx *rpcpb.TransactionRequest, changed func(flag string) bool) error {
+	if r.AmountLimit != "" && !changed("amount_limit") {
+		limit, err := ParseAmountLimit(r.AmountLimit)
+		if err != nil {
+			return err
+		}
+		trx.AmountLimit = limit
+	}
+	if r.GasLimit != "" && !changed("gas_limit") {
+		v, err := strconv.ParseFloat(r.GasLimit, 64)
+		if err != nil {
+			return fmt.Errorf("invalid gas_limit %v", r.GasLimit)
+		}
+		trx.GasLimit = v
+	}
+	if r.GasRatio != "" && !changed("gas_ratio") {
+		v, err := strconv.ParseFloat(r.GasRatio, 64)
+		if err != nil {
+			return fmt.Errorf("invalid gas_ratio %v", r.GasRatio)
+		}
+		trx.GasRatio = v
+	}
+	if r.Expiration != "" && !changed("expiration") {
+		v, err := strconv.ParseInt(r.Expiration, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid expiration %v, should be seconds", r.Expiration)
+		}
+		trx.Expiration = trx.Time + v*1e9
+	}
+	if r.Delay != "" && !changed("delay") {
+		d, err := time.ParseDuration(r.Delay)
+		if err != nil {
+			return fmt.Errorf("invalid delay %v: %v", r.Delay, err)
+		}
+		if err := txbuilder.CheckDelay(d); err != nil {
+			return err
+		}
+		trx.Delay = int64(d)
+	}
+	trx.Signers = append(append([]string{}, r.Signers...), trx.Signers...)
+	return checkSigners(trx.Signers)
+}
+
+var txTemplateCmd = &cobra.Command{
+	Use:   "template templateFile",
+	Short: "Build a transaction from a template",
+	Long: `Build a transaction from a yaml or json template with placeholders, which makes repeated operations a runbook
+	The strings of the template may hold placeholders like {{.to}}, which are filled in with the values given by
+	--set key=value or the defaults of the params. {{.account}} is the --account unless it is a param. The gas,
+	expiration, delay and amount limit in the template are used unless given on the command line. The transaction
+	is printed, or saved by --output to be signed by "iwallet sign-tx" or sent by "iwallet call --tx_file".
+	A template looks like:
+	  params:
+	    to: {description: receiver}
+	    amount: {default: "100"}
+	  actions:
+	    - contract: token.iost
+	      action: transfer
+	      args: ["iost", "{{.account}}", "{{.to}}", "{{.amount}}", "weekly reward"]
+	  signers: []
+	  amount_limit: "iost:{{.amount}}"
+	  gas_limit: 300000
+	  expiration: 300`,
+	Example: `  iwallet tx template reward.yaml --set to=alice --set amount=50 --account admin -o tx.json
+  iwallet call --tx_file tx.json --account admin`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "templateFile")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t, err := loadTxTemplate(args[0])
+		if err != nil {
+			return err
+		}
+		values, err := templateValueMap(t.Params, templateValues, accountName)
+		if err != nil {
+			return err
+		}
+		rendered, err := t.render(values)
+		if err != nil {
+			return fmt.Errorf("failed to render template %v: %v", args[0], err)
+		}
+		trx, err := iwalletSDK.CreateTxFromActions(rendered.Actions)
+		if err != nil {
+			return err
+		}
+		trx.Time, err = parseTimeFromStr(txTime)
+		if err != nil {
+			return err
+		}
+		trx.Expiration = trx.Time + expiration*1e9
+		trx.Signers = txSigners()
+		if err := rendered.apply(trx, cmd.Flags().Changed); err != nil {
+			return err
+		}
+		if outputFile == "" {
+			return printResult(trx)
+		}
+		if err := sdk.SaveProtoStructToJSONFile(trx, outputFile); err != nil {
+			return fmt.Errorf("failed to save transaction: %v", err)
+		}
+		if !isMachineOutput() {
+			fmt.Println("Successfully saved transaction as:", outputFile)
+			fmt.Printf("Send it by: iwallet call --tx_file %v, or collect the signatures of its signers by: iwallet sign-tx %v\n", outputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(txTemplateCmd)
+	txTemplateCmd.Flags().StringArrayVarP(&templateValues, "set", "", []string{}, "value of a placeholder of the template as key=value, can be repeated")
+	txTemplateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "save the transaction as json to this file")
+}
@@ -0,0 +1,86 @@
+package iwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTxTemplate = `
+params:
+  to: {description: receiver}
+  amount: {default: "100"}
+actions:
+  - contract: token.iost
+    action: transfer
+    args: ["iost", "{{.account}}", "{{.to}}", "{{.amount}}", "weekly reward"]
+  - contract: Contract1
+    action: setConfig
+    args: [{rate: "{{.amount}}", enabled: true}, 3]
+signers: ["{{.to}}@active"]
+amount_limit: "iost:{{.amount}}"
+gas_limit: 300000
+delay: 1h
+`
+
+func loadTestTxTemplate(t *testing.T, content string) (*txTemplate, error) {
+	dir, err := ioutil.TempDir("", "iwallet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "template.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0600))
+	return loadTxTemplate(file)
+}
+
+func TestTemplateValueMap(t *testing.T) {
+	tmpl, err := loadTestTxTemplate(t, testTxTemplate)
+	assert.Nil(t, err)
+	values, err := templateValueMap(tmpl.Params, []string{"to=alice"}, "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"to": "alice", "amount": "100", "account": "admin"}, values)
+
+	values, err = templateValueMap(tmpl.Params, []string{"to=alice", "amount=1=2"}, "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, "1=2", values["amount"])
+
+	_, err = templateValueMap(tmpl.Params, nil, "admin")
+	assert.Contains(t, err.Error(), "to (receiver)")
+	_, err = templateValueMap(tmpl.Params, []string{"to=alice", "memo=x"}, "admin")
+	assert.NotNil(t, err)
+	_, err = templateValueMap(tmpl.Params, []string{"to"}, "admin")
+	assert.NotNil(t, err)
+}
+
+func TestRenderTxTemplate(t *testing.T) {
+	tmpl, err := loadTestTxTemplate(t, testTxTemplate)
+	assert.Nil(t, err)
+	r, err := tmpl.render(map[string]string{"to": "alice", "amount": "50", "account": "admin"})
+	assert.Nil(t, err)
+	assert.Equal(t, `["iost","admin","alice","50","weekly reward"]`, r.Actions[0].Data)
+	assert.Equal(t, `[{"enabled":true,"rate":"50"},3]`, r.Actions[1].Data)
+	assert.Equal(t, []string{"alice@active"}, r.Signers)
+
+	trx := &rpcpb.TransactionRequest{Time: 1, GasLimit: 1000000, Signers: []string{"bob@active"}}
+	assert.Nil(t, r.apply(trx, func(string) bool { return false }))
+	assert.Equal(t, 300000.0, trx.GasLimit)
+	assert.Equal(t, int64(time.Hour), trx.Delay)
+	assert.Equal(t, []*rpcpb.AmountLimit{{Token: "iost", Value: "50"}}, trx.AmountLimit)
+	assert.Equal(t, []string{"alice@active", "bob@active"}, trx.Signers)
+
+	trx = &rpcpb.TransactionRequest{GasLimit: 5}
+	assert.Nil(t, r.apply(trx, func(flag string) bool { return flag == "gas_limit" }))
+	assert.Equal(t, 5.0, trx.GasLimit)
+
+	_, err = tmpl.render(map[string]string{"amount": "50", "account": "admin"})
+	assert.NotNil(t, err)
+
+	_, err = loadTestTxTemplate(t, "actions: []")
+	assert.NotNil(t, err)
+	_, err = loadTestTxTemplate(t, testTxTemplate+"gas: 1\n")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,301 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenDecimal               int
+	tokenFullName              string
+	tokenCanTransfer           bool
+	tokenOnlyIssuerCanTransfer bool
+	tokenIssuer                string
+)
+
+type tokenInfo struct {
+	Symbol                string `json:"symbol"`
+	FullName              string `json:"full_name"`
+	Issuer                string `json:"issuer"`
+	Decimal               int    `json:"decimal"`
+	Supply                string `json:"supply"`
+	TotalSupply           string `json:"total_supply"`
+	CanTransfer           bool   `json:"can_transfer"`
+	OnlyIssuerCanTransfer bool   `json:"only_issuer_can_transfer"`
+}
+
+// getTokenInfo reads the token info saved as the TI<symbol> map by token.iost.
+func getTokenInfo(symbol string) (*tokenInfo, error) {
+	if err := iwalletSDK.Connect(); err != nil {
+		return nil, err
+	}
+	defer iwalletSDK.CloseConn()
+	field := func(name string) (string, error) {
+		resp, err := iwalletSDK.GetContractStorage(&rpcpb.GetContractStorageRequest{
+			Id:             "token.iost",
+			Key:            "TI" + symbol,
+			Field:          name,
+			ByLongestChain: useLongestChain,
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Data, nil
+	}
+	values := make(map[string]string)
+	for _, name := range []string{"issuer", "fullName", "decimal", "supply", "totalSupply", "canTransfer", "onlyIssuerCanTransfer"} {
+		v, err := field(name)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = v
+	}
+	if values["issuer"] == "" || values["issuer"] == "null" {
+		return nil, fmt.Errorf("token %v does not exist", symbol)
+	}
+	decimal, err := strconv.Atoi(values["decimal"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal of token %v: %v", symbol, values["decimal"])
+	}
+	supply, err := strconv.ParseInt(values["supply"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid supply of token %v: %v", symbol, values["supply"])
+	}
+	totalSupply, err := strconv.ParseInt(values["totalSupply"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid total supply of token %v: %v", symbol, values["totalSupply"])
+	}
+	return &tokenInfo{
+		Symbol:                symbol,
+		FullName:              values["fullName"],
+		Issuer:                values["issuer"],
+		Decimal:               decimal,
+		Supply:                (&common.Fixed{Value: supply, Decimal: decimal}).ToString(),
+		TotalSupply:           (&common.Fixed{Value: totalSupply, Decimal: decimal}).ToString(),
+		CanTransfer:           values["canTransfer"] == "true",
+		OnlyIssuerCanTransfer: values["onlyIssuerCanTransfer"] == "true",
+	}, nil
+}
+
+// checkTokenAmount makes sure the amount is positive and has no more fraction digits than the token, which the chain would silently drop.
+func checkTokenAmount(amount string, decimal int) error {
+	if _, err := fixedAmount(amount, decimal); err != nil {
+		return err
+	}
+	if f, _ := common.NewFixed(amount, -1); !f.IsPositive() {
+		return fmt.Errorf("invalid amount %v, should be positive", amount)
+	}
+	return nil
+}
+
+func checkTokenSupply(totalSupply int64, decimal int) error {
+	if decimal < 0 || decimal >= 19 {
+		return fmt.Errorf("invalid decimal %v, should be between 0 and 18", decimal)
+	}
+	if totalSupply <= 0 || totalSupply > math.MaxInt64/int64(math.Pow10(decimal)) {
+		return fmt.Errorf("invalid total supply %v for %v decimals", totalSupply, decimal)
+	}
+	return nil
+}
+
+// positiveAmountOf parses a positive human amount of the token, such as "1.5" or "1.5 mytoken", for an action.
+func positiveAmountOf(s string, symbol string) (string, error) {
+	amount, err := amountOf(s, symbol)
+	if err != nil {
+		return "", err
+	}
+	if err := checkTokenAmount(amount, tokenDecimals[symbol]); err != nil {
+		return "", err
+	}
+	return amount, nil
+}
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Token management",
+	Long:  `Create, issue, transfer and query tokens of token.iost`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create symbol totalSupply",
+	Short: "Create a token",
+	Long:  `Create a token issued by --issuer (default the account). The total supply is the number of whole tokens.`,
+	Example: `  iwallet token create mytoken 21000000 --decimal 4 --full_name "My Token" --account test0
+  iwallet token create ticket 100 --decimal 0 --can_transfer=false --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "totalSupply"); err != nil {
+			return err
+		}
+		totalSupply, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid total supply %v, should be an integer", args[1])
+		}
+		if err := checkTokenSupply(totalSupply, tokenDecimal); err != nil {
+			return err
+		}
+		if len(tokenFullName) > 50 {
+			return fmt.Errorf("full name is too long, at most 50 bytes")
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issuer := tokenIssuer
+		if issuer == "" {
+			issuer = accountName
+		}
+		totalSupply, _ := strconv.ParseInt(args[1], 10, 64)
+		config := map[string]interface{}{
+			"decimal":               tokenDecimal,
+			"canTransfer":           tokenCanTransfer,
+			"onlyIssuerCanTransfer": tokenOnlyIssuerCanTransfer,
+		}
+		if tokenFullName != "" {
+			config["fullName"] = tokenFullName
+		}
+		return sendAction("token.iost", "create", args[0], issuer, totalSupply, config)
+	},
+}
+
+var tokenIssueCmd = &cobra.Command{
+	Use:     "issue symbol receiver amount",
+	Short:   "Issue a token",
+	Long:    `Issue a token to the receiver as the issuer`,
+	Example: `  iwallet token issue mytoken user0001 100.5 --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "receiver", "amount"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		receiver, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		amount, err := positiveAmountOf(args[2], args[0])
+		if err != nil {
+			return err
+		}
+		return sendAction("token.iost", "issue", args[0], receiver, amount)
+	},
+}
+
+var tokenTransferCmd = &cobra.Command{
+	Use:     "transfer symbol receiver amount",
+	Short:   "Transfer a token",
+	Long:    `Transfer a token from the account to the receiver`,
+	Example: `  iwallet token transfer mytoken @alice 1.25 --memo "thanks" --account test0`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "symbol", "receiver", "amount"); err != nil {
+			return err
+		}
+		return checkAccount(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		receiver, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		amount, err := positiveAmountOf(args[2], args[0])
+		if err != nil {
+			return err
+		}
+		return sendAction("token.iost", "transfer", args[0], accountName, receiver, amount, memo)
+	},
+}
+
+var tokenBalanceCmd = &cobra.Command{
+	Use:     "balance symbol accountName",
+	Short:   "Show the token balance of an account",
+	Long:    `Show the token balance of an account, including frozen balances`,
+	Example: `  iwallet token balance mytoken user0001`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "symbol", "accountName")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, err := resolveAccount(args[1])
+		if err != nil {
+			return err
+		}
+		balance, err := iwalletSDK.GetTokenBalance(owner, args[0])
+		if err != nil {
+			return err
+		}
+		return printResult(balance)
+	},
+}
+
+var tokenInfoCmd = &cobra.Command{
+	Use:     "info symbol",
+	Short:   "Show the information of a token",
+	Long:    `Show the issuer, decimal, supply and transfer config of a token`,
+	Example: `  iwallet token info iost`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "symbol")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := getTokenInfo(args[0])
+		if err != nil {
+			return err
+		}
+		return printResult(info)
+	},
+}
+
+var tokenSupplyCmd = &cobra.Command{
+	Use:     "supply symbol",
+	Short:   "Show the supply of a token",
+	Long:    `Show the issued supply and the total supply of a token`,
+	Example: `  iwallet token supply iost`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "symbol")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := getTokenInfo(args[0])
+		if err != nil {
+			return err
+		}
+		if isMachineOutput() {
+			return printResult(map[string]string{"supply": info.Supply, "total_supply": info.TotalSupply})
+		}
+		fmt.Printf("Supply: %v\nTotal supply: %v\n", info.Supply, info.TotalSupply)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCreateCmd.Flags().IntVarP(&tokenDecimal, "decimal", "", 8, "number of decimals")
+	tokenCreateCmd.Flags().StringVarP(&tokenFullName, "full_name", "", "", "full name of the token (default the symbol)")
+	tokenCreateCmd.Flags().BoolVarP(&tokenCanTransfer, "can_transfer", "", true, "whether the token can be transferred")
+	tokenCreateCmd.Flags().BoolVarP(&tokenOnlyIssuerCanTransfer, "only_issuer_can_transfer", "", false, "whether only the issuer can transfer the token")
+	tokenCreateCmd.Flags().StringVarP(&tokenIssuer, "issuer", "", "", "issuer of the token (default the account)")
+
+	tokenCmd.AddCommand(tokenIssueCmd)
+	tokenCmd.AddCommand(tokenTransferCmd)
+	tokenTransferCmd.Flags().StringVarP(&memo, "memo", "", "", "memo of transfer")
+	tokenCmd.AddCommand(tokenBalanceCmd)
+	tokenCmd.AddCommand(tokenInfoCmd)
+	tokenCmd.AddCommand(tokenSupplyCmd)
+}
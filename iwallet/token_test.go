@@ -0,0 +1,21 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTokenAmount(t *testing.T) {
+	assert.Nil(t, checkTokenAmount("1.25", 2))
+	assert.Nil(t, checkTokenAmount("100", 0))
+	assert.NotNil(t, checkTokenAmount("1.255", 2))
+	assert.NotNil(t, checkTokenAmount("0", 8))
+	assert.NotNil(t, checkTokenAmount("-1", 8))
+	assert.NotNil(t, checkTokenAmount("1e3", 8))
+
+	assert.Nil(t, checkTokenSupply(21000000, 8))
+	assert.NotNil(t, checkTokenSupply(100000000000, 18))
+	assert.NotNil(t, checkTokenSupply(1, 19))
+	assert.NotNil(t, checkTokenSupply(0, 8))
+}
@@ -0,0 +1,46 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// traceCmd represents the trace command.
+var traceCmd = &cobra.Command{
+	Use:   "trace transactionHash",
+	Short: "Trace the execution of a transaction",
+	Long: `Execute again a transaction of a block not irreversible yet on the node, and print the calls of its actions
+	to the contracts, with their storage reads and writes, gas, receipts and events. The node should enable exec_tx in
+	its rpc config`,
+	Example: `  iwallet trace 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := checkArgsNumber(cmd, args, "transactionHash"); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trace, err := iwalletSDK.TraceTransaction(args[0])
+		if err != nil {
+			return err
+		}
+		return printResult(trace)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+}
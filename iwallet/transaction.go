@@ -16,7 +16,7 @@ package iwallet
 
 import (
 	"fmt"
-	"github.com/iost-official/go-iost/sdk"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -26,7 +26,11 @@ var transactionCmd = &cobra.Command{
 	Use:     "transaction transactionHash",
 	Aliases: []string{"tx"},
 	Short:   "Find transactions",
-	Long:    `Find transaction by transaction hash`,
+	Long: `Find transaction by transaction hash
+	The args of the actions are named by the abis of the contracts, and the receipt shows the gas and ram usage and
+	the events. The raw transaction is printed with --output_format json`,
+	Example: `  iwallet tx 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT
+  iwallet tx 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT --output_format json`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
 			cmd.Usage()
@@ -39,8 +43,10 @@ var transactionCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(sdk.MarshalTextString(txRaw))
-		return nil
+		if isMachineOutput() {
+			return printResult(txRaw)
+		}
+		return writeTxResponse(os.Stdout, txRaw, libBlock(), chainParamsLookup())
 	},
 }
 
@@ -0,0 +1,201 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/spf13/cobra"
+)
+
+// encodings of raw transactions
+const (
+	encodingAuto   = "auto"
+	encodingHex    = "hex"
+	encodingBase64 = "base64"
+)
+
+var (
+	encodeTxEncoding string
+	decodeTxEncoding string
+)
+
+// rawTx is a transaction together with its binary encoding and hashes.
+type rawTx struct {
+	Encoded     string                    `json:"encoded"`
+	Hash        string                    `json:"hash"`
+	SignHash    string                    `json:"sign_hash"`
+	Transaction *rpcpb.TransactionRequest `json:"transaction"`
+}
+
+// encodeTx encodes the tx as protobuf binary, which is deterministic since the tx contains no map.
+func encodeTx(trx *rpcpb.TransactionRequest, encoding string) (string, error) {
+	data, err := proto.Marshal(trx)
+	if err != nil {
+		return "", err
+	}
+	switch encoding {
+	case encodingHex:
+		return hex.EncodeToString(data), nil
+	case encodingBase64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("invalid encoding %v, should be %v or %v", encoding, encodingHex, encodingBase64)
+	}
+}
+
+func decodeTx(s string, encoding string) (*rpcpb.TransactionRequest, error) {
+	s = strings.TrimSpace(s)
+	if encoding == encodingAuto {
+		encoding = encodingBase64
+		if _, err := hex.DecodeString(s); err == nil {
+			encoding = encodingHex
+		}
+	}
+	var data []byte
+	var err error
+	switch encoding {
+	case encodingHex:
+		data, err = hex.DecodeString(s)
+	case encodingBase64:
+		data, err = base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			data, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		}
+	default:
+		return nil, fmt.Errorf("invalid encoding %v, should be %v, %v or %v", encoding, encodingAuto, encodingHex, encodingBase64)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %v", encoding, err)
+	}
+	trx := &rpcpb.TransactionRequest{}
+	if err := proto.Unmarshal(data, trx); err != nil {
+		return nil, fmt.Errorf("not a valid transaction: %v", err)
+	}
+	return trx, nil
+}
+
+func newRawTx(trx *rpcpb.TransactionRequest, encoding string) (*rawTx, error) {
+	if encoding == encodingAuto {
+		encoding = encodingHex
+	}
+	encoded, err := encodeTx(trx, encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &rawTx{
+		Encoded:     encoded,
+		Hash:        common.Base58Encode(sdk.TxHash(trx)),
+		SignHash:    common.Base58Encode(sdk.TxHashForSign(trx)),
+		Transaction: trx,
+	}, nil
+}
+
+func printRawTx(r *rawTx) error {
+	if isMachineOutput() {
+		return printResult(r)
+	}
+	fmt.Println("Transaction:")
+	fmt.Println(sdk.MarshalTextString(r.Transaction))
+	fmt.Println("Encoded:", r.Encoded)
+	fmt.Println("Hash for signers:", r.SignHash)
+	fmt.Println("Hash:", r.Hash)
+	if len(r.Transaction.PublisherSigs) == 0 {
+		fmt.Println("The transaction is not signed by the publisher yet, its hash changes once signed")
+	}
+	return nil
+}
+
+var txEncodeCmd = &cobra.Command{
+	Use:   "encode txFile",
+	Short: "Encode a transaction file as binary",
+	Long: `Encode a signed or unsigned transaction saved as json, eg by "iwallet save" or "iwallet multisig init",
+		as protobuf binary in hex or base64, and show the hash of it`,
+	Example: `  iwallet tx encode tx.json
+  iwallet tx encode tx.json --encoding base64`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "txFile")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trx := &rpcpb.TransactionRequest{}
+		if err := sdk.LoadProtoStructFromJSONFile(args[0], trx); err != nil {
+			return fmt.Errorf("failed to load transaction file: %v", err)
+		}
+		r, err := newRawTx(trx, encodeTxEncoding)
+		if err != nil {
+			return err
+		}
+		return printRawTx(r)
+	},
+}
+
+var txDecodeCmd = &cobra.Command{
+	Use:   "decode encodedTx",
+	Short: "Decode a binary transaction into json",
+	Long: `Decode a transaction encoded as protobuf binary in hex or base64 into json, and show the hash of it
+		The encoded transaction is read from stdin if it is "-". With --output the json can be used by "iwallet sign"`,
+	Example: `  iwallet tx decode 0a0b...
+  cat tx.b64 | iwallet tx decode - --encoding base64 --output tx.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "encodedTx")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoded := args[0]
+		if encoded == "-" {
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read stdin: %v", err)
+			}
+			encoded = string(data)
+		}
+		trx, err := decodeTx(encoded, decodeTxEncoding)
+		if err != nil {
+			return err
+		}
+		if outputFile != "" {
+			if err := sdk.SaveProtoStructToJSONFile(trx, outputFile); err != nil {
+				return fmt.Errorf("failed to save transaction: %v", err)
+			}
+		}
+		r, err := newRawTx(trx, decodeTxEncoding)
+		if err != nil {
+			return err
+		}
+		if err := printRawTx(r); err != nil {
+			return err
+		}
+		if outputFile != "" && !isMachineOutput() {
+			fmt.Println("Successfully saved transaction as:", outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(txEncodeCmd)
+	txEncodeCmd.Flags().StringVarP(&encodeTxEncoding, "encoding", "", encodingHex, "encoding of the binary, hex or base64")
+	transactionCmd.AddCommand(txDecodeCmd)
+	txDecodeCmd.Flags().StringVarP(&decodeTxEncoding, "encoding", "", encodingAuto, "encoding of the binary, auto, hex or base64")
+	txDecodeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "also save the decoded transaction as json to this file")
+}
@@ -0,0 +1,46 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeTx(t *testing.T) {
+	trx := &rpcpb.TransactionRequest{
+		Time:        1544013436179000000,
+		Expiration:  1544013526179000000,
+		GasRatio:    1,
+		GasLimit:    1000000,
+		ChainId:     1024,
+		Actions:     []*rpcpb.Action{{Contract: "token.iost", ActionName: "transfer", Data: `["iost","test0","test1","1",""]`}},
+		AmountLimit: []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
+		Publisher:   "test0",
+	}
+	for _, encoding := range []string{encodingHex, encodingBase64} {
+		encoded, err := encodeTx(trx, encoding)
+		assert.Nil(t, err)
+		decoded, err := decodeTx(encoded+"\n", encodingAuto)
+		assert.Nil(t, err)
+		assert.True(t, proto.Equal(trx, decoded), encoding)
+		decoded, err = decodeTx(encoded, encoding)
+		assert.Nil(t, err)
+		assert.True(t, proto.Equal(trx, decoded), encoding)
+	}
+
+	_, err := encodeTx(trx, "base58")
+	assert.NotNil(t, err)
+	_, err = decodeTx("zz", encodingHex)
+	assert.NotNil(t, err)
+
+	r, err := newRawTx(trx, encodingAuto)
+	assert.Nil(t, err)
+	unsignedHash := r.Hash
+	trx.PublisherSigs = []*rpcpb.Signature{{Algorithm: rpcpb.Signature_ED25519, Signature: []byte{1}, PublicKey: []byte{2}}}
+	r2, err := newRawTx(trx, encodingAuto)
+	assert.Nil(t, err)
+	assert.Equal(t, r.SignHash, r2.SignHash)
+	assert.NotEqual(t, unsignedHash, r2.Hash)
+}
@@ -15,33 +15,65 @@
 package iwallet
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
 var memo string
+var batchFile string
+var batchSize int
 
 var transferCmd = &cobra.Command{
 	Use:     "transfer receiver amount",
 	Aliases: []string{"trans"},
 	Short:   "Transfer IOST",
-	Long:    `Transfer IOST`,
+	Long: `Transfer IOST
+	The amount may be followed by a token such as "1.5 mytoken" to transfer that token instead.
+	It can not have more decimals than the token, which is looked up from the chain.`,
 	Example: `  iwallet transfer test1 100 --account test0
-  iwallet transfer test1 100 --account test0 --memo "just for test :D\n中文测试\n😏"`,
+  iwallet transfer test1 "1.5 mytoken" --account test0
+  iwallet transfer test1 100 --account test0 --memo "just for test :D\n中文测试\n😏"
+  iwallet transfer @alice 100 --account test0
+  iwallet transfer --batch payouts.csv --account test0`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if batchFile != "" {
+			if estimateOnly {
+				return fmt.Errorf("--estimate_only can not be used with --batch")
+			}
+			if dryRun {
+				return fmt.Errorf("--dry_run can not be used with --batch")
+			}
+			return checkAccount(cmd)
+		}
 		if err := checkArgsNumber(cmd, args, "receiver", "amount"); err != nil {
 			return err
 		}
-		if err := checkFloat(cmd, args[1], "amount"); err != nil {
+		if err := checkAmount(cmd, args[1], "amount"); err != nil {
 			return err
 		}
 		return checkAccount(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return sendAction("token.iost", "transfer", "iost", accountName, args[0], args[1], memo)
+		if batchFile != "" {
+			return batchTransfer(batchFile)
+		}
+		receiver, err := resolveAccount(args[0])
+		if err != nil {
+			return err
+		}
+		amount, symbol, err := tokenAmount(args[1], "iost")
+		if err != nil {
+			return err
+		}
+		return sendAction("token.iost", "transfer", symbol, accountName, receiver, amount, memo)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(transferCmd)
 	transferCmd.Flags().StringVarP(&memo, "memo", "", "", "memo of transfer")
+	transferCmd.Flags().StringVarP(&batchFile, "batch", "", "", "transfer to many receivers listed in a csv (receiver,amount[,memo]) or json file")
+	transferCmd.Flags().BoolVarP(&estimateOnly, "estimate_only", "", false, "only estimate the gas and ram cost of the transfer without sending it")
+	transferCmd.Flags().IntVarP(&batchSize, "batch_size", "", 0, "max transfers in one transaction in batch mode (default decided by gas_limit)")
 }
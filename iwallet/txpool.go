@@ -0,0 +1,139 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	txpoolLimit     int32
+	txpoolOffset    int32
+	txpoolPublisher string
+	txpoolContract  string
+)
+
+// txpoolEntry is a transaction in the pending pool of the node.
+type txpoolEntry struct {
+	TxHash     string  `json:"tx_hash" yaml:"tx_hash"`
+	Time       string  `json:"time" yaml:"time"`
+	Expiration string  `json:"expiration" yaml:"expiration"`
+	Publisher  string  `json:"publisher" yaml:"publisher"`
+	GasRatio   float64 `json:"gas_ratio" yaml:"gas_ratio"`
+	Action     string  `json:"action" yaml:"action"`
+}
+
+func toTxpoolEntry(t *rpcpb.Transaction) *txpoolEntry {
+	actions := make([]string, 0, len(t.Actions))
+	for _, a := range t.Actions {
+		actions = append(actions, a.Contract+"/"+a.ActionName)
+	}
+	return &txpoolEntry{
+		TxHash:     t.Hash,
+		Time:       formatTime(t.Time),
+		Expiration: formatTime(t.Expiration),
+		Publisher:  t.Publisher,
+		GasRatio:   t.GasRatio,
+		Action:     strings.Join(actions, ","),
+	}
+}
+
+var txpoolCmd = &cobra.Command{
+	Use:   "txpool",
+	Short: "Inspect the pending transactions of the node",
+	Long:  `Inspect the transactions in the pending pool of the node, which are neither packed nor expired yet`,
+}
+
+var txpoolListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pending transactions",
+	Long: `List the transactions in the pending pool of the node, the first to be packed first
+		The transactions can be filtered by publisher and by contract called`,
+	Example: `  iwallet txpool list
+  iwallet txpool list --publisher test0 --limit 10
+  iwallet txpool list --contract token.iost --offset 50`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := iwalletSDK.GetPendingTransactions(&rpcpb.GetPendingTransactionsRequest{
+			Offset:    txpoolOffset,
+			Limit:     txpoolLimit,
+			Publisher: txpoolPublisher,
+			Contract:  txpoolContract,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get pending transactions: %v", err)
+		}
+		entries := make([]*txpoolEntry, 0, len(res.Transactions))
+		for _, t := range res.Transactions {
+			entries = append(entries, toTxpoolEntry(t))
+		}
+		if isMachineOutput() {
+			return printResult(entries)
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No pending transactions to show of %v\n", res.Total)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TX HASH\tTIME\tEXPIRATION\tPUBLISHER\tGAS RATIO\tACTION")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", e.TxHash, e.Time, e.Expiration, e.Publisher, e.GasRatio, e.Action)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("%v to %v of %v pending transactions\n", txpoolOffset+1, txpoolOffset+int32(len(entries)), res.Total)
+		if res.HasMore {
+			fmt.Printf("More transactions with --offset %v\n", txpoolOffset+int32(len(entries)))
+		}
+		return nil
+	},
+}
+
+var txpoolGetCmd = &cobra.Command{
+	Use:     "get txHash",
+	Short:   "Find a pending transaction",
+	Long:    `Find a transaction by transaction hash in the pending pool of the node, failing once it is packed`,
+	Example: `  iwallet txpool get 7MDfKBeZToQnnfNHD58cbZ7o4Y2AktKLmiEg776HLPBT`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return checkArgsNumber(cmd, args, "txHash")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := iwalletSDK.GetPendingTxByHash(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get pending transaction %v: %v", args[0], err)
+		}
+		if isMachineOutput() {
+			return printResult(res)
+		}
+		// the last irreversible block only matters to the packed txs
+		return writeTxResponse(os.Stdout, res, -1, chainParamsLookup())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(txpoolCmd)
+	txpoolCmd.AddCommand(txpoolListCmd)
+	txpoolCmd.AddCommand(txpoolGetCmd)
+	txpoolListCmd.Flags().Int32VarP(&txpoolLimit, "limit", "", 50, "max count of transactions to show, at most 100")
+	txpoolListCmd.Flags().Int32VarP(&txpoolOffset, "offset", "", 0, "count of the first transactions to skip")
+	txpoolListCmd.Flags().StringVarP(&txpoolPublisher, "publisher", "", "", "only list the transactions published by the account")
+	txpoolListCmd.Flags().StringVarP(&txpoolContract, "contract", "", "", "only list the transactions calling the contract")
+}
@@ -0,0 +1,29 @@
+package iwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTxpoolEntry(t *testing.T) {
+	sent := time.Date(2019, 1, 2, 3, 4, 5, 0, time.Local)
+	e := toTxpoolEntry(&rpcpb.Transaction{
+		Hash:       "hash0",
+		Time:       sent.UnixNano(),
+		Expiration: sent.Add(90 * time.Second).UnixNano(),
+		Publisher:  "test0",
+		GasRatio:   1.5,
+		Actions: []*rpcpb.Action{
+			{Contract: "token.iost", ActionName: "transfer"},
+			{Contract: "ram.iost", ActionName: "buy"},
+		},
+	})
+	assert.Equal(t, "2019-01-02 03:04:05", e.Time)
+	assert.Equal(t, "2019-01-02 03:05:35", e.Expiration)
+	assert.Equal(t, "test0", e.Publisher)
+	assert.Equal(t, 1.5, e.GasRatio)
+	assert.Equal(t, "token.iost/transfer,ram.iost/buy", e.Action)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// gasMeteringPattern matches what the chain injects into the code when compiling a contract to meter its gas.
+var gasMeteringPattern = regexp.MustCompile(`_IOSTInstruction_counter\.incr\([^)]*\)[;,]?|_IOSTTemplateTag(` + "`" + `)`)
+
+// upgradePreview is what an update changes in a deployed contract.
+type upgradePreview struct {
+	CodeDiff   string       `json:"code_diff"`
+	ABIChanges []*abiChange `json:"abi_changes"`
+	Breaking   int          `json:"breaking"`
+}
+
+// stripGasMetering makes the code stored on chain comparable with its source. The counters of instructions are
+// dropped, while operators rewritten into calls of _IOSTBinaryOp are left as they are.
+func stripGasMetering(code string) string {
+	lines := strings.Split(gasMeteringPattern.ReplaceAllString(code, "$1"), "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+func codeDiff(deployed string, local string, localName string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(stripGasMetering(deployed) + "\n"),
+		B:        difflib.SplitLines(strings.TrimRight(local, "\n") + "\n"),
+		FromFile: "deployed",
+		ToFile:   localName,
+		Context:  3,
+	})
+}
+
+func newUpgradePreview(conID string, code string, codePath string, abi *abiFile) (*upgradePreview, error) {
+	c, err := iwalletSDK.GetContract(conID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract %v: %v", conID, err)
+	}
+	diff, err := codeDiff(c.Code, code, codePath)
+	if err != nil {
+		return nil, err
+	}
+	p := &upgradePreview{
+		CodeDiff:   diff,
+		ABIChanges: diffABI(abiFromContract(c), abi.Abi),
+	}
+	for _, ch := range p.ABIChanges {
+		if ch.Breaking {
+			p.Breaking++
+		}
+	}
+	return p, nil
+}
+
+func printUpgradePreview(p *upgradePreview) {
+	fmt.Println("Code changes, compared with the deployed code without its gas metering:")
+	if p.CodeDiff == "" {
+		fmt.Println("No code changes")
+	} else {
+		fmt.Print(p.CodeDiff)
+	}
+	fmt.Println("Abi changes:")
+	if len(p.ABIChanges) == 0 {
+		fmt.Println("No abi changes")
+	} else {
+		printABIChanges(p.ABIChanges)
+	}
+}
+
+// confirmUpgrade asks before an update breaking existing callers is sent, unless --yes is given.
+func confirmUpgrade(p *upgradePreview) error {
+	if p.Breaking == 0 || assumeYes {
+		return nil
+	}
+	if isMachineOutput() {
+		return fmt.Errorf("%v breaking abi change(s) found, publish with --yes to update anyway", p.Breaking)
+	}
+	fmt.Printf("%v breaking abi change(s) found, update anyway? [y/N]: ", p.Breaking)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("update canceled: %v", err)
+	}
+	if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("update canceled")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package iwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeDiff(t *testing.T) {
+	deployed := `class A {
+    init() {
+        _IOSTInstruction_counter.incr(3);
+    }
+    f(a) {
+        _IOSTInstruction_counter.incr(12);
+        return _IOSTTemplateTag` + "`a ${ a }`" + `;
+    }
+}
+module.exports = A;`
+	assert.Equal(t, "class A {\n    init() {\n    }\n    f(a) {\n        return `a ${ a }`;\n    }\n}\nmodule.exports = A;", stripGasMetering(deployed))
+
+	diff, err := codeDiff(deployed, stripGasMetering(deployed)+"\n", "a.js")
+	assert.Nil(t, err)
+	assert.Equal(t, "", diff)
+
+	diff, err = codeDiff(deployed, "class A {\n    init() {\n    }\n    f(a) {\n        return a;\n    }\n}\nmodule.exports = A;\n", "a.js")
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "--- deployed\n+++ a.js\n")
+	assert.Contains(t, diff, "-        return `a ${ a }`;\n+        return a;\n")
+}
+
+func TestConfirmUpgrade(t *testing.T) {
+	assert.Nil(t, confirmUpgrade(&upgradePreview{}))
+
+	outputFormat = outputJSON
+	defer func() { outputFormat = outputText }()
+	assert.Contains(t, confirmUpgrade(&upgradePreview{Breaking: 2}).Error(), "--yes")
+	assumeYes = true
+	defer func() { assumeYes = false }()
+	assert.Nil(t, confirmUpgrade(&upgradePreview{Breaking: 2}))
+}
@@ -3,15 +3,17 @@ package iwallet
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/iwallet/ledger"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/iost-official/go-iost/sdk"
+	"github.com/iost-official/go-iost/sdk/txbuilder"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 )
@@ -34,20 +36,10 @@ func checkAccount(cmd *cobra.Command) error {
 	return nil
 }
 
-func checkFloat(cmd *cobra.Command, arg string, argName string) error {
-	_, err := strconv.ParseFloat(arg, 64)
-	if err != nil {
-		cmd.Help()
-		fmt.Println()
-		return fmt.Errorf(`invalid value "%v" for argument "%v": %v`, arg, argName, err)
-	}
-	return nil
-}
-
 func checkSigners(signers []string) error {
 	for _, s := range signers {
-		if !(len(strings.Split(s, "@")) == 2) {
-			return fmt.Errorf("signer %v should contain '@'", s)
+		if err := txbuilder.CheckSigner(s); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -111,6 +103,19 @@ func InitAccount() error {
 
 // LoadAndSetAccountForSDK ...
 func LoadAndSetAccountForSDK(s *sdk.IOSTDevSDK) error {
+	if err := loadSigner(s); err != nil {
+		return err
+	}
+	return loadFeePayer(s)
+}
+
+func loadSigner(s *sdk.IOSTDevSDK) error {
+	if hardware != "" {
+		return loadHardwareSigner(s)
+	}
+	if remoteSigner != "" {
+		return loadRemoteSigner(s)
+	}
 	a, err := loadAccountByName(accountName, true)
 	if err != nil {
 		return err
@@ -127,6 +132,87 @@ func LoadAndSetAccountForSDK(s *sdk.IOSTDevSDK) error {
 	return nil
 }
 
+// loadFeePayer loads the active key of the fee payer to publish the txs with. If the key is not here, the fee payer
+// should publish the tx by the multisig workflow instead.
+func loadFeePayer(s *sdk.IOSTDevSDK) error {
+	if feePayer == "" || feePayer == accountName {
+		return nil
+	}
+	a, err := loadAccountByName(feePayer, true)
+	if err != nil {
+		return fmt.Errorf(`failed to load fee payer %v: %v
+if the key of %v is not here, create the transaction by "iwallet multisig init ... --signers %v@%v",
+sign it by "iwallet multisig sign", then let %v publish it by "iwallet multisig combine ... --broadcast --account %v"`,
+			feePayer, err, feePayer, accountName, signPerm, feePayer, feePayer)
+	}
+	kp, ok := a.Keypairs["active"]
+	if !ok {
+		return fmt.Errorf("no active key of fee payer %v", feePayer)
+	}
+	keyPair, err := kp.toKeyPair()
+	if err != nil {
+		return err
+	}
+	s.SetFeePayer(feePayer, sdk.NewKeyPairSigner(keyPair), signPerm)
+	return nil
+}
+
+// txPublisher is the account paying for the txs sent.
+func txPublisher() string {
+	if feePayer != "" {
+		return feePayer
+	}
+	return accountName
+}
+
+// txSigners is the signers of the txs sent. The account signs as one of them if the fee payer publishes instead.
+func txSigners() []string {
+	signer := accountName + "@" + signPerm
+	if feePayer == "" || feePayer == accountName || containsString(signers, signer) {
+		return signers
+	}
+	return append(append([]string{}, signers...), signer)
+}
+
+func loadHardwareSigner(s *sdk.IOSTDevSDK) error {
+	if accountName == "" {
+		return fmt.Errorf("you must provide account name")
+	}
+	if hardware != "ledger" {
+		return fmt.Errorf("unsupported hardware wallet %v", hardware)
+	}
+	l, err := ledger.Open()
+	if err != nil {
+		return err
+	}
+	signer, err := ledger.NewSigner(l, hdPath)
+	if err != nil {
+		l.Close()
+		return err
+	}
+	s.SetSigner(accountName, signer)
+	return nil
+}
+
+// remoteSignerTokenEnv is the bearer token authenticating iwallet with the signing service of --remote_signer.
+const remoteSignerTokenEnv = "IWALLET_REMOTE_SIGNER_TOKEN"
+
+func loadRemoteSigner(s *sdk.IOSTDevSDK) error {
+	if accountName == "" {
+		return fmt.Errorf("you must provide account name")
+	}
+	opts := sdk.RemoteSignerOptions{}
+	if token := os.Getenv(remoteSignerTokenEnv); token != "" {
+		opts.Header = http.Header{"Authorization": {"Bearer " + token}}
+	}
+	signer, err := sdk.NewRemoteSigner(remoteSigner, accountName+"@"+signPerm, opts)
+	if err != nil {
+		return err
+	}
+	s.SetSigner(accountName, signer)
+	return nil
+}
+
 // SaveAccount save account to file
 func SaveAccount(name string, kp *account.KeyPair) error {
 	dir, err := getAccountDir()
@@ -178,7 +264,11 @@ func actionsFromFlags(args []string) ([]*rpcpb.Action, error) {
 	}
 	var actions = make([]*rpcpb.Action, 0)
 	for i := 0; i < len(args); i += 3 {
-		act := sdk.NewAction(args[i], args[i+1], args[i+2]) // Add some checks here.
+		methodArgs, err := resolveActionArgs(args[i+2])
+		if err != nil {
+			return nil, err
+		}
+		act := sdk.NewAction(args[i], args[i+1], methodArgs) // Add some checks here.
 		actions = append(actions, act)
 	}
 	return actions, nil
@@ -189,7 +279,13 @@ func handleMultiSig(t *rpcpb.TransactionRequest, withSigns []string, signKeys []
 	if len(withSigns) != 0 && len(signKeys) != 0 {
 		return fmt.Errorf("at least one of --sign_keys and --with_signs should be empty")
 	}
-	if len(signKeys) > 0 {
+	if len(signKeys) == 0 && len(withSigns) == 0 {
+		var err error
+		sigs, err = signWithLocalAccounts(t)
+		if err != nil {
+			return err
+		}
+	} else if len(signKeys) > 0 {
 		for _, f := range signKeys {
 			kp, err := sdk.LoadKeyPair(f, signAlgo)
 			if err != nil {
@@ -198,22 +294,69 @@ func handleMultiSig(t *rpcpb.TransactionRequest, withSigns []string, signKeys []
 			sigs = append(sigs, sdk.GetSignatureOfTx(t, kp))
 		}
 	} else if len(withSigns) > 0 {
-		for _, f := range withSigns {
-			sig := &rpcpb.Signature{}
-			err := sdk.LoadProtoStructFromJSONFile(f, sig)
-			if err != nil {
-				return fmt.Errorf("invalid signature file %v", f)
-			}
-			if !sdk.VerifySigForTx(t, sig) {
-				return fmt.Errorf("sign verify error %v", f)
-			}
-			sigs = append(sigs, sig)
+		var err error
+		sigs, err = loadSignaturesForTx(t, withSigns)
+		if err != nil {
+			return err
 		}
 	}
 	t.Signatures = sigs
 	return nil
 }
 
+// signWithLocalAccounts signs the tx with the keys of the signers in the local keystores, every keystore being
+// decrypted once. The account is skipped if a fee payer publishes, since the sdk signs for it then.
+func signWithLocalAccounts(t *rpcpb.TransactionRequest) ([]*rpcpb.Signature, error) {
+	sigs := make([]*rpcpb.Signature, 0, len(t.Signers))
+	accounts := make(map[string]*AccountInfo)
+	for _, signer := range t.Signers {
+		if feePayer != "" && signer == accountName+"@"+signPerm {
+			continue
+		}
+		name, perm := splitSigner(signer)
+		a, ok := accounts[name]
+		if !ok {
+			var err error
+			if a, err = loadAccountByName(name, true); err != nil {
+				return nil, fmt.Errorf(`failed to load signer %v: %v
+if the key of %v is not here, sign by the multisig workflow or with --with_signs instead`, signer, err, name)
+			}
+			accounts[name] = a
+		}
+		kp, ok := a.Keypairs[perm]
+		if !ok {
+			return nil, fmt.Errorf("no %v key of signer %v", perm, name)
+		}
+		keyPair, err := kp.toKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sdk.GetSignatureOfTx(t, keyPair))
+	}
+	return sigs, nil
+}
+
+func splitSigner(signer string) (string, string) {
+	i := strings.Index(signer, "@")
+	return signer[:i], signer[i+1:]
+}
+
+func loadSignaturesForTx(t *rpcpb.TransactionRequest, files []string) ([]*rpcpb.Signature, error) {
+	sigs := make([]*rpcpb.Signature, 0, len(files))
+	for _, f := range files {
+		sig := &rpcpb.Signature{}
+		err := sdk.LoadProtoStructFromJSONFile(f, sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature file %v", f)
+		}
+		if !sdk.VerifySigForTx(t, sig) {
+			return nil, fmt.Errorf("sign verify error %v", f)
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
 // ParseAmountLimit ...
 func ParseAmountLimit(limitStr string) ([]*rpcpb.AmountLimit, error) {
 	result := make([]*rpcpb.AmountLimit, 0)
@@ -228,9 +371,8 @@ func ParseAmountLimit(limitStr string) ([]*rpcpb.AmountLimit, error) {
 		}
 		token := limit[0]
 		if limit[1] != "unlimited" {
-			amountLimit, err := strconv.ParseFloat(limit[1], 64)
-			if err != nil {
-				return nil, fmt.Errorf("invalid amount limit %v %v", amountLimit, err)
+			if _, _, err := splitAmount(limit[1], token); err != nil {
+				return nil, fmt.Errorf("invalid amount limit %v: %v", gram, err)
 			}
 		}
 		tokenLimit := &rpcpb.AmountLimit{}
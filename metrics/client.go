@@ -124,6 +124,22 @@ func (c *Client) NewSummary(name string, labels []string) Summary {
 	return NewPromSummary(summaryVec)
 }
 
+// NewHistogram returns a histogram-type metrics counting the observations in the buckets of upper bounds, the
+// default buckets of prometheus if nil.
+func (c *Client) NewHistogram(name string, labels []string, buckets []float64) Histogram {
+	histogramVec := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    name,
+		Help:    "-",
+		Buckets: buckets,
+	}, labels)
+	if c.pusher != nil {
+		c.pusher.Collector(histogramVec)
+	} else {
+		c.collectorCache = append(c.collectorCache, histogramVec)
+	}
+	return NewPromHistogram(histogramVec)
+}
+
 func (c *Client) startPush() {
 	timer := time.NewTimer(pushInterval)
 	for {
@@ -44,6 +44,18 @@ func (mr *MockServiceMockRecorder) Broadcast(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Broadcast", reflect.TypeOf((*MockService)(nil).Broadcast), arg0, arg1, arg2)
 }
 
+// ClosePeer mocks base method
+func (m *MockService) ClosePeer(arg0 string) error {
+	ret := m.ctrl.Call(m, "ClosePeer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePeer indicates an expected call of ClosePeer
+func (mr *MockServiceMockRecorder) ClosePeer(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeer", reflect.TypeOf((*MockService)(nil).ClosePeer), arg0)
+}
+
 // ConnectBPs mocks base method
 func (m *MockService) ConnectBPs(arg0 []string) {
 	m.ctrl.Call(m, "ConnectBPs", arg0)
@@ -54,6 +66,18 @@ func (mr *MockServiceMockRecorder) ConnectBPs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectBPs", reflect.TypeOf((*MockService)(nil).ConnectBPs), arg0)
 }
 
+// ConnectPeer mocks base method
+func (m *MockService) ConnectPeer(arg0 string) error {
+	ret := m.ctrl.Call(m, "ConnectPeer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectPeer indicates an expected call of ConnectPeer
+func (mr *MockServiceMockRecorder) ConnectPeer(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectPeer", reflect.TypeOf((*MockService)(nil).ConnectPeer), arg0)
+}
+
 // Deregister mocks base method
 func (m *MockService) Deregister(arg0 string, arg1 ...p2p.MessageType) {
 	varargs := []interface{}{arg0}
@@ -93,9 +117,21 @@ func (mr *MockServiceMockRecorder) ID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockService)(nil).ID))
 }
 
+// PutIPToBlack mocks base method
+func (m *MockService) PutIPToBlack(arg0 string) {
+	m.ctrl.Call(m, "PutIPToBlack", arg0)
+}
+
+// PutIPToBlack indicates an expected call of PutIPToBlack
+func (mr *MockServiceMockRecorder) PutIPToBlack(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutIPToBlack", reflect.TypeOf((*MockService)(nil).PutIPToBlack), arg0)
+}
+
 // PutPeerToBlack mocks base method
-func (m *MockService) PutPeerToBlack(arg0 string) {
-	m.ctrl.Call(m, "PutPeerToBlack", arg0)
+func (m *MockService) PutPeerToBlack(arg0 string) error {
+	ret := m.ctrl.Call(m, "PutPeerToBlack", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // PutPeerToBlack indicates an expected call of PutPeerToBlack
@@ -42,7 +42,10 @@ type Service interface {
 
 	ID() string
 	ConnectBPs([]string)
-	PutPeerToBlack(string)
+	ConnectPeer(string) error
+	ClosePeer(string) error
+	PutPeerToBlack(string) error
+	PutIPToBlack(string)
 
 	Broadcast([]byte, MessageType, MessagePriority)
 	SendToPeer(PeerID, []byte, MessageType, MessagePriority)
@@ -3,6 +3,7 @@ package p2p
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -226,6 +227,41 @@ func (pm *PeerManager) ConnectBPs(ids []string) {
 	pm.setBPs(ids)
 }
 
+// ConnectPeer connects to the peer of the multiaddr like /ip4/127.0.0.1/tcp/30000/ipfs/<peer id>, and keeps its
+// address in the routing table.
+func (pm *PeerManager) ConnectPeer(s string) error {
+	peerID, addr, err := parseMultiaddr(s)
+	if err != nil {
+		return err
+	}
+	if peerID == pm.host.ID() {
+		return errors.New("can't connect to self")
+	}
+	if pm.isPIDBlack(peerID) {
+		return fmt.Errorf("peer %v is in black list", peerID.Pretty())
+	}
+	pm.storePeerInfo(peerID, []multiaddr.Multiaddr{addr})
+	if pm.GetNeighbor(peerID) != nil {
+		return nil
+	}
+	stream, err := pm.newStream(peerID)
+	if err != nil {
+		return err
+	}
+	pm.HandleStream(stream, outbound)
+	return nil
+}
+
+// ClosePeer closes the connection with the peer, which may connect again later.
+func (pm *PeerManager) ClosePeer(id string) error {
+	pid, err := peer.IDB58Decode(id)
+	if err != nil {
+		return err
+	}
+	pm.RemoveNeighbor(pid)
+	return nil
+}
+
 // HandleStream handles the incoming stream.
 //
 // It checks whether the remote peer already exists.
@@ -824,15 +860,16 @@ func (pm *PeerManager) NeighborStat() map[string]interface{} {
 }
 
 // PutPeerToBlack puts the peer's PID and IP to black list and close the connection.
-func (pm *PeerManager) PutPeerToBlack(id string) {
+func (pm *PeerManager) PutPeerToBlack(id string) error {
 	pid, err := peer.IDB58Decode(id)
 	if err != nil {
 		ilog.Warnf("decode peerID failed. err=%v, id=%v", err, id)
-		return
+		return err
 	}
 	pm.RemoveNeighbor(pid)
 	pm.PutPIDToBlack(pid)
 	pm.deletePeerInfo(pid)
+	return nil
 }
 
 // PutPIDToBlack puts the PID and corresponding ip to black list.
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+	"github.com/iost-official/go-iost/rpc/pb"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// AdminServer is the grpc server of the admin service, kept apart from the public rpcs of Server.
+type AdminServer struct {
+	addr       string
+	grpcServer *grpc.Server
+
+	enable bool
+}
+
+// NewAdminServer returns a new admin server instance.
+func NewAdminServer(tp txpool.TxPool, bv global.BaseVariable, p2pService p2p.Service, debug DebugServer) *AdminServer {
+	c := bv.Config().Admin
+	if c == nil || !c.Enable {
+		return &AdminServer{}
+	}
+	creds, err := adminCredentials(c)
+	if err != nil {
+		ilog.Fatalf("rpc admin service initialization failed, stop the program! err:%v", err)
+	}
+	m := &rpcMetrics{}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				m.unaryMiddleware,
+				grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(p)),
+			),
+		),
+	}
+	if creds != nil {
+		opts = append(opts, creds)
+	}
+	s := &AdminServer{
+		addr:       c.Addr,
+		grpcServer: grpc.NewServer(opts...),
+		enable:     true,
+	}
+	rpcpb.RegisterAdminServiceServer(s.grpcServer, NewAdminService(tp, bv, p2pService, debug))
+	return s
+}
+
+// adminCredentials returns the option of the mutual tls of the admin service, nil if it is not configured, in which
+// case the service must be bound to a loopback address.
+func adminCredentials(c *common.AdminConfig) (grpc.ServerOption, error) {
+	if c.CertFile == "" && c.KeyFile == "" && c.ClientCAFile == "" {
+		host, _, err := net.SplitHostPort(c.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin address %v: %v", c.Addr, err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("admin address %v is not a loopback address, and no tls is configured", c.Addr)
+		}
+		return nil, nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" || c.ClientCAFile == "" {
+		return nil, errors.New("the cert file, the key file and the client ca file are all required by the admin tls")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load admin certificate failed: %v", err)
+	}
+	ca, err := ioutil.ReadFile(c.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read admin client ca failed: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate in admin client ca file %v", c.ClientCAFile)
+	}
+	return grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})), nil
+}
+
+// Start starts the admin server.
+func (s *AdminServer) Start() error {
+	if !s.enable {
+		return nil
+	}
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.grpcServer.Serve(lis); err != nil {
+			ilog.Fatalf("start admin grpc failed. err=%v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the admin server.
+func (s *AdminServer) Stop() {
+	if !s.enable {
+		return
+	}
+	s.grpcServer.GracefulStop()
+}
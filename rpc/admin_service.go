@@ -0,0 +1,124 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// DebugServer is the debug http server of the node, started and stopped by the admin service.
+type DebugServer interface {
+	Start() error
+	Stop()
+	Running() bool
+}
+
+// AdminService implements all the admin rpcs, which operate the node.
+type AdminService struct {
+	txpool     txpool.TxPool
+	bv         global.BaseVariable
+	p2pService p2p.Service
+	debug      DebugServer
+
+	// snapshotMu lets one snapshot be saved at once.
+	snapshotMu sync.Mutex
+}
+
+// NewAdminService returns a new AdminService instance.
+func NewAdminService(tp txpool.TxPool, bv global.BaseVariable, p2pService p2p.Service, debug DebugServer) *AdminService {
+	return &AdminService{
+		txpool:     tp,
+		bv:         bv,
+		p2pService: p2pService,
+		debug:      debug,
+	}
+}
+
+// AddPeer connects to a peer given by its multiaddr.
+func (as *AdminService) AddPeer(ctx context.Context, req *rpcpb.AddPeerRequest) (*rpcpb.AdminResponse, error) {
+	if req.GetAddress() == "" {
+		return nil, errors.New("address is empty")
+	}
+	if err := as.p2pService.ConnectPeer(req.GetAddress()); err != nil {
+		return nil, err
+	}
+	ilog.Infof("admin added peer %v", req.GetAddress())
+	return &rpcpb.AdminResponse{}, nil
+}
+
+// RemovePeer closes the connection with a peer.
+func (as *AdminService) RemovePeer(ctx context.Context, req *rpcpb.PeerRequest) (*rpcpb.AdminResponse, error) {
+	if req.GetId() == "" {
+		return nil, errors.New("id is empty")
+	}
+	if err := as.p2pService.ClosePeer(req.GetId()); err != nil {
+		return nil, err
+	}
+	ilog.Infof("admin removed peer %v", req.GetId())
+	return &rpcpb.AdminResponse{}, nil
+}
+
+// BanPeer puts a peer, or an ip, in the black list.
+func (as *AdminService) BanPeer(ctx context.Context, req *rpcpb.BanPeerRequest) (*rpcpb.AdminResponse, error) {
+	switch {
+	case req.GetId() != "":
+		if err := as.p2pService.PutPeerToBlack(req.GetId()); err != nil {
+			return nil, err
+		}
+		ilog.Infof("admin banned peer %v", req.GetId())
+	case req.GetIp() != "":
+		as.p2pService.PutIPToBlack(req.GetIp())
+		ilog.Infof("admin banned ip %v", req.GetIp())
+	default:
+		return nil, errors.New("id and ip are empty")
+	}
+	return &rpcpb.AdminResponse{}, nil
+}
+
+// FlushTxPool deletes all the pending transactions.
+func (as *AdminService) FlushTxPool(ctx context.Context, req *rpcpb.AdminEmptyRequest) (*rpcpb.FlushTxPoolResponse, error) {
+	n := as.txpool.Flush()
+	ilog.Infof("admin flushed %v txs of the tx pool", n)
+	return &rpcpb.FlushTxPoolResponse{Count: int64(n)}, nil
+}
+
+// TriggerSnapshot saves a copy of the flushed state db to a snapshot file in the db path.
+func (as *AdminService) TriggerSnapshot(ctx context.Context, req *rpcpb.AdminEmptyRequest) (*rpcpb.TriggerSnapshotResponse, error) {
+	as.snapshotMu.Lock()
+	defer as.snapshotMu.Unlock()
+	conf := as.bv.Config()
+	if err := snapshot.SaveSnapshot(conf, as.bv.StateDB()); err != nil {
+		return nil, err
+	}
+	path := snapshot.Path(conf)
+	ilog.Infof("admin saved snapshot %v", path)
+	return &rpcpb.TriggerSnapshotResponse{Path: path}, nil
+}
+
+// RotateLogs makes the log files be written to new files.
+func (as *AdminService) RotateLogs(ctx context.Context, req *rpcpb.AdminEmptyRequest) (*rpcpb.AdminResponse, error) {
+	ilog.Rotate()
+	return &rpcpb.AdminResponse{}, nil
+}
+
+// SetDebug starts or stops the debug http server.
+func (as *AdminService) SetDebug(ctx context.Context, req *rpcpb.SetDebugRequest) (*rpcpb.SetDebugResponse, error) {
+	if as.debug == nil {
+		return nil, errors.New("debug server is not available")
+	}
+	if req.GetEnable() {
+		if err := as.debug.Start(); err != nil {
+			return nil, err
+		}
+	} else {
+		as.debug.Stop()
+	}
+	return &rpcpb.SetDebugResponse{Enabled: as.debug.Running()}, nil
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/p2p"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAdminP2P struct {
+	p2p.Service
+	peers    map[string]bool
+	blackIPs []string
+}
+
+func (s *testAdminP2P) ConnectPeer(addr string) error {
+	s.peers[addr] = true
+	return nil
+}
+
+func (s *testAdminP2P) ClosePeer(id string) error {
+	if !s.peers[id] {
+		return errors.New("invalid peer id")
+	}
+	delete(s.peers, id)
+	return nil
+}
+
+func (s *testAdminP2P) PutPeerToBlack(id string) error {
+	return s.ClosePeer(id)
+}
+
+func (s *testAdminP2P) PutIPToBlack(ip string) {
+	s.blackIPs = append(s.blackIPs, ip)
+}
+
+type testDebugServer struct {
+	running bool
+}
+
+func (d *testDebugServer) Start() error {
+	d.running = true
+	return nil
+}
+
+func (d *testDebugServer) Stop() {
+	d.running = false
+}
+
+func (d *testDebugServer) Running() bool {
+	return d.running
+}
+
+func TestAdminService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	defer stateDB.Close()
+	assert.Nil(t, stateDB.Put("state", "key", "value"))
+	stateDB.Commit("abc")
+	assert.Nil(t, stateDB.Flush("abc"))
+
+	pending := txpool.NewSortedTxMap()
+	pending.Add(tx.NewTx(nil, nil, 100000, 100, 1, 0, 0))
+	pending.Add(tx.NewTx(nil, nil, 100000, 100, 2, 0, 0))
+	net := &testAdminP2P{peers: make(map[string]bool)}
+	debug := &testDebugServer{}
+	bv := &testBaseVariable{config: &common.Config{DB: &common.DBConfig{LdbPath: dir}}, stateDB: stateDB}
+	as := NewAdminService(&testTxPool{pending: pending}, bv, net, debug)
+	ctx := context.Background()
+
+	_, err = as.AddPeer(ctx, &rpcpb.AddPeerRequest{})
+	assert.NotNil(t, err)
+	_, err = as.AddPeer(ctx, &rpcpb.AddPeerRequest{Address: "peer"})
+	assert.Nil(t, err)
+	assert.True(t, net.peers["peer"])
+	_, err = as.RemovePeer(ctx, &rpcpb.PeerRequest{Id: "peer"})
+	assert.Nil(t, err)
+	assert.Empty(t, net.peers)
+	_, err = as.RemovePeer(ctx, &rpcpb.PeerRequest{Id: "peer"})
+	assert.NotNil(t, err)
+
+	_, err = as.BanPeer(ctx, &rpcpb.BanPeerRequest{})
+	assert.NotNil(t, err)
+	_, err = as.BanPeer(ctx, &rpcpb.BanPeerRequest{Ip: "1.2.3.4"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.2.3.4"}, net.blackIPs)
+
+	flush, err := as.FlushTxPool(ctx, &rpcpb.AdminEmptyRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), flush.Count)
+	assert.Equal(t, 0, pending.Size())
+
+	snap, err := as.TriggerSnapshot(ctx, &rpcpb.AdminEmptyRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "Snapshot.tar.gz"), snap.Path)
+	restored := filepath.Join(dir, "Restored")
+	err = snapshot.FromSnapshot(&common.Config{DB: &common.DBConfig{LdbPath: restored}, Snapshot: &common.SnapshotConfig{FilePath: snap.Path}})
+	assert.Nil(t, err)
+	restoredDB, err := db.NewMVCCDB(filepath.Join(restored, "StateDB"))
+	assert.Nil(t, err)
+	defer restoredDB.Close()
+	value, err := restoredDB.Get("state", "key")
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+
+	res, err := as.SetDebug(ctx, &rpcpb.SetDebugRequest{Enable: true})
+	assert.Nil(t, err)
+	assert.True(t, res.Enabled)
+	res, err = as.SetDebug(ctx, &rpcpb.SetDebugRequest{})
+	assert.Nil(t, err)
+	assert.False(t, res.Enabled)
+}
+
+func TestAdminCredentials(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:30004", "localhost:30004", "[::1]:30004"} {
+		creds, err := adminCredentials(&common.AdminConfig{Addr: addr})
+		assert.Nil(t, err, addr)
+		assert.Nil(t, creds, addr)
+	}
+	for _, c := range []*common.AdminConfig{
+		{Addr: "0.0.0.0:30004"},
+		{Addr: ":30004"},
+		{Addr: "30004"},
+		{Addr: "0.0.0.0:30004", CertFile: "cert.pem", KeyFile: "key.pem"},
+		{Addr: "0.0.0.0:30004", CertFile: "cert.pem", KeyFile: "key.pem", ClientCAFile: "ca.pem"},
+	} {
+		_, err := adminCredentials(c)
+		assert.NotNil(t, err, "%+v", c)
+	}
+}
@@ -1,12 +1,16 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/iost-official/go-iost/vm"
@@ -19,12 +23,16 @@ import (
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
 	rpcpb "github.com/iost-official/go-iost/rpc/pb"
 	"github.com/iost-official/go-iost/verifier"
 	"github.com/iost-official/go-iost/vm/database"
 	"github.com/iost-official/go-iost/vm/host"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //go:generate mockgen -destination mock_rpc/mock_api.go -package main github.com/iost-official/go-iost/rpc/pb ApiServiceServer
@@ -36,6 +44,15 @@ type APIService struct {
 	txpool     txpool.TxPool
 	blockchain block.Chain
 	bv         global.BaseVariable
+	// witness is the pubkey of the blocks produced by the node, empty if it has no account.
+	witness string
+
+	// number of the streams of SubscribeBlocks, accessed atomically
+	blockSubscriptions int32
+
+	// the state with the pending txs executed, computed by one query at a time
+	pendingMu    sync.Mutex
+	pendingState *pendingState
 
 	quitCh chan struct{}
 }
@@ -48,6 +65,7 @@ func NewAPIService(tp txpool.TxPool, bcache blockcache.BlockCache, bv global.Bas
 		blockchain: bv.BlockChain(),
 		bc:         bcache,
 		bv:         bv,
+		witness:    witnessOf(bv.Config().ACC),
 		quitCh:     quitCh,
 	}
 }
@@ -112,9 +130,10 @@ func (as *APIService) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest)
 	txHashBytes := common.Base58Decode(req.GetHash())
 	status := rpcpb.TransactionResponse_IRREVERSIBLE
 	var (
-		t         *tx.Tx
-		txReceipt *tx.TxReceipt
-		err       error
+		t           *tx.Tx
+		txReceipt   *tx.TxReceipt
+		blockNumber int64
+		err         error
 	)
 	t, err = as.blockchain.GetTx(txHashBytes)
 	if err != nil {
@@ -126,21 +145,45 @@ func (as *APIService) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest)
 			if err != nil {
 				return nil, errors.New("tx not found")
 			}
+		} else {
+			blockNumber = as.packedBlockNumber(txHashBytes)
 		}
 	} else {
 		txReceipt, err = as.blockchain.GetReceiptByTxHash(txHashBytes)
 		if err != nil {
 			return nil, errors.New("txreceipt not found")
 		}
-
+		blockNumber, _ = as.blockchain.GetBlockNumberByTxHash(txHashBytes)
 	}
 
 	return &rpcpb.TransactionResponse{
 		Status:      status,
 		Transaction: toPbTx(t, txReceipt),
+		BlockNumber: blockNumber,
 	}, nil
 }
 
+// packedBlockNumber finds the block packing the tx among the reversible blocks of the head chain, 0 if not found.
+func (as *APIService) packedBlockNumber(txHash []byte) int64 {
+	if blk := as.packedBlock(txHash); blk != nil {
+		return blk.Head.Number
+	}
+	return 0
+}
+
+// packedBlock finds the block packing the tx among the reversible blocks of the head chain, nil if not found.
+func (as *APIService) packedBlock(txHash []byte) *block.Block {
+	lib := as.bc.LinkedRoot()
+	for node := as.bc.Head(); node != nil && node != lib; node = node.GetParent() {
+		for _, t := range node.Block.Txs {
+			if bytes.Equal(t.Hash(), txHash) {
+				return node.Block
+			}
+		}
+	}
+	return nil
+}
+
 // GetTxReceiptByTxHash returns transaction receipts corresponding to the given tx hash.
 func (as *APIService) GetTxReceiptByTxHash(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TxReceipt, error) {
 	txHashBytes := common.Base58Decode(req.GetHash())
@@ -151,15 +194,298 @@ func (as *APIService) GetTxReceiptByTxHash(ctx context.Context, req *rpcpb.TxHas
 	return toPbTxReceipt(receipt), nil
 }
 
+// TraceTransaction executes again a transaction of a block after the last irreversible block, after the transactions
+// before it in the block on the state of its parent, and returns the calls of its actions traced. The states of the
+// irreversible blocks are not kept, so their transactions can't be traced and FailedPrecondition is returned.
+func (as *APIService) TraceTransaction(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TraceTransactionResponse, error) {
+	if !as.bv.Config().RPC.ExecTx {
+		return nil, errors.New("The node has't enabled this method")
+	}
+	txHash := common.Base58Decode(req.GetHash())
+	blk := as.packedBlock(txHash)
+	if blk == nil {
+		number, err := as.blockchain.GetBlockNumberByTxHash(txHash)
+		if err != nil {
+			return nil, errors.New("tx not found")
+		}
+		blk, err = as.blockchain.GetBlockByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+	}
+	index := -1
+	for i, t := range blk.Txs {
+		if bytes.Equal(t.Hash(), txHash) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("tx not found in block %v", blk.Head.Number)
+	}
+	if lib := as.bc.LinkedRoot().Head.Number; blk.Head.Number <= lib {
+		return nil, status.Errorf(codes.FailedPrecondition, "block %v is irreversible, only the txs of the blocks after the last irreversible block %v can be traced", blk.Head.Number, lib)
+	}
+	stateDB := as.bv.StateDB().Fork()
+	if !stateDB.Checkout(string(blk.Head.ParentHash)) {
+		// the block became irreversible meanwhile
+		return nil, status.Errorf(codes.FailedPrecondition, "state of block %v not found, only the txs of the blocks after the last irreversible block can be traced", blk.Head.Number-1)
+	}
+	v := verifier.Verifier{}
+	receipt, tracer, err := v.Trace(blk, stateDB, index, &verifier.Config{TxTimeLimit: common.MaxTxTimeLimit})
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.TraceTransactionResponse{
+		BlockNumber: blk.Head.Number,
+		Receipt:     toPbTxReceipt(receipt),
+		Calls:       toPbCallTraces(tracer.Calls()),
+	}, nil
+}
+
+// maxTxsByAccount is the max count of transactions returned by one GetTxsByAccount or GetAccountTxs call.
+const maxTxsByAccount = 100
+
+// GetTxsByAccount returns irreversible transactions involving the given account, newest first. The offset is at most
+// block.MaxAccountTxsOffset, GetAccountTxs paging further with its cursor.
+func (as *APIService) GetTxsByAccount(ctx context.Context, req *rpcpb.GetTxsByAccountRequest) (*rpcpb.GetTxsByAccountResponse, error) {
+	if req.GetAccount() == "" {
+		return nil, errors.New("account is empty")
+	}
+	if req.GetOffset() < 0 || req.GetOffset() > block.MaxAccountTxsOffset {
+		return nil, fmt.Errorf("offset should be in [0, %v], page the older txs with the cursor of GetAccountTxs", block.MaxAccountTxsOffset)
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxTxsByAccount {
+		return nil, fmt.Errorf("limit should be in (0, %v]", maxTxsByAccount)
+	}
+	start, err := as.blockchain.AccountIndexStart()
+	if err != nil {
+		return nil, err
+	}
+	// the txs skipped are fetched too, as the txs of an account are only listed from a height down, and one more to
+	// tell whether there are more
+	offset := int(req.GetOffset())
+	txs, err := as.blockchain.GetAccountTxs(req.GetAccount(), math.MaxInt64, nil, offset+limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) <= offset {
+		return &rpcpb.GetTxsByAccountResponse{IndexStart: start}, nil
+	}
+	txs = txs[offset:]
+	res := &rpcpb.GetTxsByAccountResponse{HasMore: len(txs) > limit, IndexStart: start}
+	if res.HasMore {
+		txs = txs[:limit]
+	}
+	trxs, err := as.getAccountTxs(txs)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range trxs {
+		res.Transactions = append(res.Transactions, t.Transaction)
+	}
+	return res, nil
+}
+
+// pageLimit returns the limit of a page, max if 0.
+func pageLimit(limit int32, max int) (int, error) {
+	if limit < 0 || int(limit) > max {
+		return 0, fmt.Errorf("limit should be in [0, %v]", max)
+	}
+	if limit == 0 {
+		return max, nil
+	}
+	return int(limit), nil
+}
+
+// fieldCursor encodes the last of a page of fields sorted as strings, like the keys of a map in storage, for the next
+// page to start after it.
+func fieldCursor(field string) string {
+	return common.Base58Encode([]byte(field))
+}
+
+// fieldsAfter sorts the fields and returns those after the last field of the previous page, all if the cursor is empty.
+// The pages go on where they stopped even if fields are added or removed meanwhile.
+func fieldsAfter(fields []string, cursor string) ([]string, error) {
+	sort.Strings(fields)
+	if cursor == "" {
+		return fields, nil
+	}
+	after := string(common.Base58Decode(cursor))
+	if after == "" {
+		return nil, errors.New("invalid cursor")
+	}
+	return fields[sort.Search(len(fields), func(i int) bool { return fields[i] > after }):], nil
+}
+
+// accountTxCursor encodes the block number and the index of the last tx of a page, for the next page to start after it.
+func accountTxCursor(t *block.AccountTx) string {
+	return common.Base58Encode(append(common.Int64ToBytes(t.BlockNumber), common.Int32ToBytes(int32(t.Index))...))
+}
+
+func parseAccountTxCursor(cursor string) (*block.AccountTx, error) {
+	buf := common.Base58Decode(cursor)
+	if len(buf) != 12 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &block.AccountTx{BlockNumber: common.BytesToInt64(buf[:8]), Index: int(common.BytesToInt32(buf[8:]))}, nil
+}
+
+// GetAccountTxs returns a page of the irreversible transactions involving the given account from a height down,
+// newest first.
+func (as *APIService) GetAccountTxs(ctx context.Context, req *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error) {
+	if req.GetAccount() == "" {
+		return nil, errors.New("account is empty")
+	}
+	if req.GetFromHeight() < 0 {
+		return nil, fmt.Errorf("invalid from_height %v", req.GetFromHeight())
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxTxsByAccount {
+		return nil, fmt.Errorf("limit should be in (0, %v]", maxTxsByAccount)
+	}
+	start, err := as.blockchain.AccountIndexStart()
+	if err != nil {
+		return nil, err
+	}
+	from := req.GetFromHeight()
+	if from == 0 {
+		from = math.MaxInt64
+	} else if from < start {
+		return nil, status.Errorf(codes.FailedPrecondition, "the txs of the blocks before %v are not indexed by account", start)
+	}
+	var after *block.AccountTx
+	if req.GetCursor() != "" {
+		if after, err = parseAccountTxCursor(req.GetCursor()); err != nil {
+			return nil, err
+		}
+	}
+	// one more tx is fetched to tell whether there are more
+	txs, err := as.blockchain.GetAccountTxs(req.GetAccount(), from, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.GetAccountTxsResponse{IndexStart: start}
+	if len(txs) > limit {
+		txs = txs[:limit]
+		res.Cursor = accountTxCursor(txs[limit-1])
+	}
+	if res.Transactions, err = as.getAccountTxs(txs); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getAccountTxs returns the irreversible txs of an account with their receipts.
+func (as *APIService) getAccountTxs(txs []*block.AccountTx) ([]*rpcpb.TransactionResponse, error) {
+	res := make([]*rpcpb.TransactionResponse, 0, len(txs))
+	for _, at := range txs {
+		t, err := as.blockchain.GetTx(at.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("tx %v not found: %v", common.Base58Encode(at.Hash), err)
+		}
+		receipt, err := as.blockchain.GetReceiptByTxHash(at.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("txreceipt %v not found: %v", common.Base58Encode(at.Hash), err)
+		}
+		res = append(res, &rpcpb.TransactionResponse{
+			Status:      rpcpb.TransactionResponse_IRREVERSIBLE,
+			Transaction: toPbTx(t, receipt),
+			BlockNumber: at.BlockNumber,
+		})
+	}
+	return res, nil
+}
+
+// GetDelaytxsByAccount returns the irreversible delay transactions published by an account which are neither executed nor canceled yet.
+func (as *APIService) GetDelaytxsByAccount(ctx context.Context, req *rpcpb.GetDelaytxsByAccountRequest) (*rpcpb.GetDelaytxsByAccountResponse, error) {
+	if req.GetAccount() == "" {
+		return nil, errors.New("account is empty")
+	}
+	txs, err := as.blockchain.AllDelaytx()
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.GetDelaytxsByAccountResponse{}
+	for _, t := range txs {
+		if t.Publisher == req.GetAccount() {
+			res.Transactions = append(res.Transactions, toPbTx(t, nil))
+		}
+	}
+	sort.Slice(res.Transactions, func(i, j int) bool {
+		a, b := res.Transactions[i], res.Transactions[j]
+		return a.Time+a.Delay < b.Time+b.Delay
+	})
+	return res, nil
+}
+
+// maxPendingTxs is the max count of transactions returned by one GetPendingTransactions call.
+const maxPendingTxs = 100
+
+// pendingTxMatch tells whether the pending transaction is published by the publisher and calls the contract, either
+// being empty for any.
+func pendingTxMatch(t *tx.Tx, publisher, contract string) bool {
+	if publisher != "" && t.Publisher != publisher {
+		return false
+	}
+	if contract == "" {
+		return true
+	}
+	for _, a := range t.Actions {
+		if a.Contract == contract {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPendingTransactions returns the transactions in the pending pool, in the order they are packed.
+func (as *APIService) GetPendingTransactions(ctx context.Context, req *rpcpb.GetPendingTransactionsRequest) (*rpcpb.GetPendingTransactionsResponse, error) {
+	if req.GetOffset() < 0 {
+		return nil, fmt.Errorf("invalid offset %v", req.GetOffset())
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxPendingTxs {
+		return nil, fmt.Errorf("limit should be in (0, %v]", maxPendingTxs)
+	}
+	pending, _ := as.txpool.PendingTx()
+	res := &rpcpb.GetPendingTransactionsResponse{}
+	offset := int(req.GetOffset())
+	for _, t := range pending.Txs() {
+		if !pendingTxMatch(t, req.GetPublisher(), req.GetContract()) {
+			continue
+		}
+		res.Total++
+		if n := int(res.Total); n > offset && n <= offset+limit {
+			res.Transactions = append(res.Transactions, toPbTx(t, nil))
+		}
+	}
+	res.HasMore = int(res.Total) > offset+limit
+	return res, nil
+}
+
+// GetPendingTxByHash returns the transaction in the pending pool corresponding to the given hash.
+func (as *APIService) GetPendingTxByHash(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TransactionResponse, error) {
+	t, err := as.txpool.GetFromPending(common.Base58Decode(req.GetHash()))
+	if err != nil {
+		return nil, errors.New("tx not found")
+	}
+	return &rpcpb.TransactionResponse{
+		Status:      rpcpb.TransactionResponse_PENDING,
+		Transaction: toPbTx(t, nil),
+	}, nil
+}
+
 // GetBlockByHash returns block corresponding to the given hash.
 func (as *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHashRequest) (*rpcpb.BlockResponse, error) {
+	filter, err := newTxActionFilter(req.GetActions())
+	if err != nil {
+		return nil, err
+	}
 	hashBytes := common.Base58Decode(req.GetHash())
-	var (
-		blk *block.Block
-		err error
-	)
 	status := rpcpb.BlockResponse_IRREVERSIBLE
-	blk, err = as.blockchain.GetBlockByHash(hashBytes)
+	blk, err := as.blockchain.GetBlockByHash(hashBytes)
 	if err != nil {
 		status = rpcpb.BlockResponse_PENDING
 		blk, err = as.bc.GetBlockByHash(hashBytes)
@@ -169,37 +495,214 @@ func (as *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByH
 	}
 	return &rpcpb.BlockResponse{
 		Status: status,
-		Block:  toPbBlock(blk, req.GetComplete()),
+		Block:  toPbFilteredBlock(blk, req.GetComplete(), filter),
 	}, nil
 }
 
 // GetBlockByNumber returns block corresponding to the given number.
 func (as *APIService) GetBlockByNumber(ctx context.Context, req *rpcpb.GetBlockByNumberRequest) (*rpcpb.BlockResponse, error) {
-	number := req.GetNumber()
-	var (
-		blk *block.Block
-		err error
-	)
+	filter, err := newTxActionFilter(req.GetActions())
+	if err != nil {
+		return nil, err
+	}
+	blk, status, err := as.getBlockByNumber(req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.BlockResponse{
+		Status: status,
+		Block:  toPbFilteredBlock(blk, req.GetComplete(), filter),
+	}, nil
+}
+
+// maxTxActionFilterSize is how many contracts and abis the transactions of a block may be filtered by.
+const maxTxActionFilterSize = 100
+
+// txActionFilter matches the transactions with an action calling one of its contracts, or abis as contract/abi. The
+// nil filter matches all the transactions.
+type txActionFilter map[string]bool
+
+// newTxActionFilter returns the filter of the actions of GetBlockByNumber and GetBlockByHash, nil for none.
+func newTxActionFilter(actions []string) (txActionFilter, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	if len(actions) > maxTxActionFilterSize {
+		return nil, fmt.Errorf("the filter has %v actions, more than %v", len(actions), maxTxActionFilterSize)
+	}
+	f := make(txActionFilter)
+	for _, a := range actions {
+		if a == "" || strings.HasPrefix(a, "/") || strings.HasSuffix(a, "/") || strings.Count(a, "/") > 1 {
+			return nil, fmt.Errorf("invalid action %q, should be a contract or contract/abi", a)
+		}
+		f[a] = true
+	}
+	return f, nil
+}
+
+func (f txActionFilter) match(t *tx.Tx) bool {
+	if f == nil {
+		return true
+	}
+	for _, a := range t.Actions {
+		if f[a.Contract] || f[a.Contract+"/"+a.ActionName] {
+			return true
+		}
+	}
+	return false
+}
+
+// getBlockByNumber returns the block of the number on the longest chain, irreversible if found in the block chain.
+func (as *APIService) getBlockByNumber(number int64) (*block.Block, rpcpb.BlockResponse_Status, error) {
+	blk, err := as.blockchain.GetBlockByNumber(number)
+	if err == nil {
+		return blk, rpcpb.BlockResponse_IRREVERSIBLE, nil
+	}
+	blk, err = as.bc.GetBlockByNumber(number)
+	if err != nil {
+		return nil, rpcpb.BlockResponse_PENDING, err
+	}
+	return blk, rpcpb.BlockResponse_PENDING, nil
+}
+
+// GetBlockHeaderByHash returns the header of the block corresponding to the given hash.
+func (as *APIService) GetBlockHeaderByHash(ctx context.Context, req *rpcpb.GetBlockHeaderByHashRequest) (*rpcpb.BlockHeaderResponse, error) {
+	hash := common.Base58Decode(req.GetHash())
+	blk, status, err := as.getBlockHeadByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.BlockHeaderResponse{
+		Status: status,
+		Header: toPbBlockHeader(blk),
+	}, nil
+}
+
+// GetBlockHeaderByNumber returns the header of the block corresponding to the given number.
+func (as *APIService) GetBlockHeaderByNumber(ctx context.Context, req *rpcpb.GetBlockHeaderByNumberRequest) (*rpcpb.BlockHeaderResponse, error) {
+	var blk *block.Block
 	status := rpcpb.BlockResponse_IRREVERSIBLE
-	blk, err = as.blockchain.GetBlockByNumber(number)
+	hash, err := as.blockchain.GetHashByNumber(req.GetNumber())
+	if err == nil {
+		blk, err = as.blockchain.GetBlockHeadByHash(hash)
+	}
 	if err != nil {
 		status = rpcpb.BlockResponse_PENDING
-		blk, err = as.bc.GetBlockByNumber(number)
+		blk, err = as.bc.GetBlockByNumber(req.GetNumber())
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &rpcpb.BlockResponse{
+	return &rpcpb.BlockHeaderResponse{
 		Status: status,
-		Block:  toPbBlock(blk, req.GetComplete()),
+		Header: toPbBlockHeader(blk),
 	}, nil
 }
 
+// getBlockHeadByHash returns the block of the hash, without its txs if irreversible.
+func (as *APIService) getBlockHeadByHash(hash []byte) (*block.Block, rpcpb.BlockResponse_Status, error) {
+	blk, err := as.blockchain.GetBlockHeadByHash(hash)
+	if err == nil {
+		return blk, rpcpb.BlockResponse_IRREVERSIBLE, nil
+	}
+	blk, err = as.bc.GetBlockByHash(hash)
+	if err != nil {
+		return nil, rpcpb.BlockResponse_PENDING, err
+	}
+	return blk, rpcpb.BlockResponse_PENDING, nil
+}
+
+// The max counts of blocks returned by one GetBlocks call, less for the complete blocks which are much larger.
+const (
+	maxBlocksByRange         = 100
+	maxCompleteBlocksByRange = 20
+)
+
+// GetBlocks returns the blocks of numbers from start to end, up to the head block and to the limit of blocks at once.
+func (as *APIService) GetBlocks(ctx context.Context, req *rpcpb.GetBlocksRequest) (*rpcpb.GetBlocksResponse, error) {
+	if req.GetStart() < 0 || req.GetEnd() < req.GetStart() {
+		return nil, fmt.Errorf("invalid range [%v, %v]", req.GetStart(), req.GetEnd())
+	}
+	limit := maxBlocksByRange
+	if req.GetComplete() {
+		limit = maxCompleteBlocksByRange
+	}
+	end := req.GetEnd()
+	if head := as.bc.Head().Head.Number; end > head {
+		end = head
+	}
+	res := &rpcpb.GetBlocksResponse{}
+	for n := req.GetStart(); n <= end; n++ {
+		if len(res.Blocks) == limit {
+			res.HasMore = true
+			break
+		}
+		blk, status, err := as.getBlockByNumber(n)
+		if err != nil {
+			return nil, fmt.Errorf("block %v not found: %v", n, err)
+		}
+		res.Blocks = append(res.Blocks, &rpcpb.BlockResponse{
+			Status: status,
+			Block:  toPbBlock(blk, req.GetComplete()),
+		})
+	}
+	return res, nil
+}
+
+// GetBlockStateChanges returns the state keys written by the irreversible block of number, if the node keeps them.
+func (as *APIService) GetBlockStateChanges(ctx context.Context, req *rpcpb.GetBlockStateChangesRequest) (*rpcpb.GetBlockStateChangesResponse, error) {
+	if !as.bv.Config().DB.StateChanges {
+		return nil, errors.New("state changes are not kept by the node")
+	}
+	if lib := as.bc.LinkedRoot().Head.Number; req.GetNumber() > lib {
+		return nil, fmt.Errorf("block %v is not irreversible, the last irreversible block is %v", req.GetNumber(), lib)
+	}
+	hash, err := as.blockchain.GetHashByNumber(req.GetNumber())
+	if err != nil {
+		return nil, fmt.Errorf("block %v not found: %v", req.GetNumber(), err)
+	}
+	changes, err := as.blockchain.GetStateChanges(req.GetNumber())
+	if err != nil {
+		return nil, fmt.Errorf("state changes of block %v not found: %v", req.GetNumber(), err)
+	}
+	res := &rpcpb.GetBlockStateChangesResponse{
+		Number: req.GetNumber(),
+		Hash:   common.Base58Encode(hash),
+	}
+	for _, c := range changes {
+		if c.Table != database.StateTable {
+			continue
+		}
+		res.Changes = append(res.Changes, toPbStateChange(c))
+	}
+	return res, nil
+}
+
 // GetAccount returns account information corresponding to the given account name.
 func (as *APIService) GetAccount(ctx context.Context, req *rpcpb.GetAccountRequest) (*rpcpb.Account, error) {
-	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
-	if err != nil {
-		return nil, err
+	var dbVisitor *database.Visitor
+	var blkTime int64
+	switch {
+	case req.GetIncludePending() && req.GetBlockNumber() != 0:
+		return nil, errPendingAtBlock
+	case req.GetIncludePending():
+		var err error
+		dbVisitor, blkTime, err = as.getPendingStateDBVisitor()
+		if err != nil {
+			return nil, err
+		}
+	case req.GetBlockNumber() != 0:
+		v, blk, err := as.getStateDBVisitorByNumber(req.GetBlockNumber())
+		if err != nil {
+			return nil, err
+		}
+		dbVisitor, blkTime = v, blk.Head.Time
+	default:
+		v, b, err := as.getStateDBVisitor(req.ByLongestChain)
+		if err != nil {
+			return nil, err
+		}
+		dbVisitor, blkTime = v, b.Head.Time
 	}
 	// pack basic account information
 	acc, _ := host.ReadAuth(dbVisitor, req.GetName())
@@ -219,12 +722,6 @@ func (as *APIService) GetAccount(ctx context.Context, req *rpcpb.GetAccountReque
 	}
 
 	// pack gas information
-	var blkTime int64
-	if req.GetByLongestChain() {
-		blkTime = as.bc.Head().Head.Time
-	} else {
-		blkTime = as.bc.LinkedRoot().Head.Time
-	}
 	pGas := dbVisitor.PGasAtTime(req.GetName(), blkTime)
 	tGas := dbVisitor.TGas(req.GetName())
 	totalGas := pGas.Add(tGas)
@@ -268,7 +765,18 @@ func (as *APIService) GetAccount(ctx context.Context, req *rpcpb.GetAccountReque
 
 // GetTokenBalance returns contract information corresponding to the given contract ID.
 func (as *APIService) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBalanceRequest) (*rpcpb.GetTokenBalanceResponse, error) {
-	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
+	var dbVisitor *database.Visitor
+	var err error
+	switch {
+	case req.GetIncludePending() && req.GetBlockNumber() != 0:
+		return nil, errPendingAtBlock
+	case req.GetIncludePending():
+		dbVisitor, _, err = as.getPendingStateDBVisitor()
+	case req.GetBlockNumber() != 0:
+		dbVisitor, _, err = as.getStateDBVisitorByNumber(req.GetBlockNumber())
+	default:
+		dbVisitor, _, err = as.getStateDBVisitor(req.ByLongestChain)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -292,8 +800,60 @@ func (as *APIService) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBa
 	}, nil
 }
 
-// GetToken721Balance returns balance of account of an specific token721 token.
-func (as *APIService) GetToken721Balance(ctx context.Context, req *rpcpb.GetTokenBalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+// GetAccountTokens returns the balances of all tokens held by an account.
+func (as *APIService) GetAccountTokens(ctx context.Context, req *rpcpb.GetAccountTokensRequest) (*rpcpb.GetAccountTokensResponse, error) {
+	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
+	if err != nil {
+		return nil, err
+	}
+	acc, _ := host.ReadAuth(dbVisitor, req.GetAccount())
+	if acc == nil {
+		return nil, errors.New("account not found")
+	}
+	ret := &rpcpb.GetAccountTokensResponse{}
+	for _, token := range dbVisitor.TokensOf(req.GetAccount()) {
+		balance := dbVisitor.TokenBalanceFixed(token, req.GetAccount()).ToFloat()
+		frozen := dbVisitor.AllFreezedTokenBalanceFixed(token, req.GetAccount())
+		if balance == 0 && len(frozen) == 0 {
+			continue
+		}
+		frozenBalances := make([]*rpcpb.FrozenBalance, 0)
+		for _, f := range frozen {
+			frozenBalances = append(frozenBalances, &rpcpb.FrozenBalance{
+				Amount: f.Amount.ToFloat(),
+				Time:   f.Ftime,
+			})
+		}
+		ret.Tokens = append(ret.Tokens, &rpcpb.GetAccountTokensResponse_Token{
+			Token:          token,
+			Balance:        balance,
+			FrozenBalances: frozenBalances,
+		})
+	}
+	for _, token := range dbVisitor.Token721sOf(req.GetAccount()) {
+		balance := dbVisitor.Token721Balance(token, req.GetAccount())
+		if balance == 0 {
+			continue
+		}
+		ret.Token721S = append(ret.Token721S, &rpcpb.GetAccountTokensResponse_Token721{
+			Token:   token,
+			Balance: balance,
+		})
+	}
+	sort.Slice(ret.Tokens, func(i, j int) bool { return ret.Tokens[i].Token < ret.Tokens[j].Token })
+	sort.Slice(ret.Token721S, func(i, j int) bool { return ret.Token721S[i].Token < ret.Token721S[j].Token })
+	return ret, nil
+}
+
+// maxToken721IDs is the max count of token ids returned by one GetToken721Balance call.
+const maxToken721IDs = 1000
+
+// GetToken721Balance returns balance of account of an specific token721 token, and a page of the token ids held.
+func (as *APIService) GetToken721Balance(ctx context.Context, req *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+	limit, err := pageLimit(req.GetLimit(), maxToken721IDs)
+	if err != nil {
+		return nil, err
+	}
 	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
 	if err != nil {
 		return nil, err
@@ -303,11 +863,19 @@ func (as *APIService) GetToken721Balance(ctx context.Context, req *rpcpb.GetToke
 		return nil, errors.New("account not found")
 	}
 	balance := dbVisitor.Token721Balance(req.GetToken(), req.GetAccount())
-	ids := dbVisitor.Token721IDList(req.GetToken(), req.GetAccount())
-	return &rpcpb.GetToken721BalanceResponse{
+	ids, err := fieldsAfter(dbVisitor.Token721IDList(req.GetToken(), req.GetAccount()), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.GetToken721BalanceResponse{
 		Balance:  balance,
 		TokenIDs: ids,
-	}, nil
+	}
+	if len(ids) > limit {
+		res.TokenIDs = ids[:limit]
+		res.Cursor = fieldCursor(ids[limit-1])
+	}
+	return res, nil
 }
 
 // GetToken721Metadata returns metadata of an specific token721 token.
@@ -376,11 +944,15 @@ func (as *APIService) GetProducerVoteInfo(ctx context.Context, req *rpcpb.GetPro
 	if err != nil {
 		return nil, err
 	}
-	votes, err := dbVisitor.GetProducerVotes(req.Account)
+	return producerVoteInfo(dbVisitor, req.Account)
+}
+
+func producerVoteInfo(dbVisitor *database.Visitor, account string) (*rpcpb.GetProducerVoteInfoResponse, error) {
+	votes, err := dbVisitor.GetProducerVotes(account)
 	if err != nil {
 		return nil, err
 	}
-	info, err := dbVisitor.GetProducerVoteInfo(req.Account)
+	info, err := dbVisitor.GetProducerVoteInfo(account)
 	if err != nil {
 		return nil, err
 	}
@@ -396,35 +968,257 @@ func (as *APIService) GetProducerVoteInfo(ctx context.Context, req *rpcpb.GetPro
 	}, nil
 }
 
+const (
+	// maxProducers is the max count of candidates returned by one GetProducers call.
+	maxProducers = 100
+	// maxVoters is the max count of voters returned by one GetVoters call, and maxVotersScanned the max count of
+	// accounts having voted it looks through.
+	maxVoters        = 1000
+	maxVotersScanned = 10000
+)
+
+// GetProducers returns a page of the producer candidates and their vote info, sorted by account.
+func (as *APIService) GetProducers(ctx context.Context, req *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error) {
+	limit, err := pageLimit(req.GetLimit(), maxProducers)
+	if err != nil {
+		return nil, err
+	}
+	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := fieldsAfter(dbVisitor.GetProducers(), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.GetProducersResponse{}
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+		res.Cursor = fieldCursor(accounts[limit-1])
+	}
+	for _, account := range accounts {
+		info, err := producerVoteInfo(dbVisitor, account)
+		if err != nil {
+			return nil, fmt.Errorf("get vote info of %v failed: %v", account, err)
+		}
+		res.Producers = append(res.Producers, &rpcpb.GetProducersResponse_Producer{Account: account, Info: info})
+	}
+	return res, nil
+}
+
+// GetVoters returns a page of the voters of a producer candidate and their votes, sorted by account. The page holds
+// fewer voters than the limit if too many accounts having voted for other candidates were looked through.
+func (as *APIService) GetVoters(ctx context.Context, req *rpcpb.GetVotersRequest) (*rpcpb.GetVotersResponse, error) {
+	if req.GetProducer() == "" {
+		return nil, errors.New("producer is empty")
+	}
+	limit, err := pageLimit(req.GetLimit(), maxVoters)
+	if err != nil {
+		return nil, err
+	}
+	dbVisitor, _, err := as.getStateDBVisitor(req.ByLongestChain)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbVisitor.GetProducerVoteInfo(req.GetProducer()); err != nil {
+		return nil, err
+	}
+	voters, err := fieldsAfter(dbVisitor.GetVoters(), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	res := &rpcpb.GetVotersResponse{}
+	for i, voter := range voters {
+		if len(res.Voters) == limit || i == maxVotersScanned {
+			res.Cursor = fieldCursor(voters[i-1])
+			break
+		}
+		for _, v := range dbVisitor.GetAccountVoteInfo(voter) {
+			if v.Option == req.GetProducer() {
+				res.Voters = append(res.Voters, &rpcpb.GetVotersResponse_Voter{
+					Account:      voter,
+					Votes:        v.Votes.ToFloat(),
+					ClearedVotes: v.ClearedVotes.ToFloat(),
+				})
+			}
+		}
+	}
+	return res, nil
+}
+
+const (
+	defaultScheduleBlocks = 1200
+	maxScheduleBlocks     = 10000
+)
+
+// scheduledBlock is a block with the witness list which scheduled the slots after it.
+type scheduledBlock struct {
+	witness string
+	slot    int64
+	active  []string
+}
+
+func slotOfNanoSec(t int64) int64 {
+	return t / int64(time.Second) / common.SlotLength
+}
+
+// recentBlocks returns at most count blocks from the head, newest first. The blocks in the block cache carry their
+// own witness lists, the irreversible ones the witness list of the last irreversible block.
+func (as *APIService) recentBlocks(count int64) []*scheduledBlock {
+	blocks := make([]*scheduledBlock, 0)
+	lib := as.bc.LinkedRoot()
+	for node := as.bc.Head(); node != nil && int64(len(blocks)) < count; node = node.GetParent() {
+		blocks = append(blocks, &scheduledBlock{witness: node.Head.Witness, slot: slotOfNanoSec(node.Head.Time), active: node.Active()})
+		if node == lib {
+			break
+		}
+	}
+	for num := lib.Head.Number - 1; num >= 0 && int64(len(blocks)) < count; num-- {
+		blk, err := as.blockchain.GetBlockByNumber(num)
+		if err != nil {
+			break
+		}
+		blocks = append(blocks, &scheduledBlock{witness: blk.Head.Witness, slot: slotOfNanoSec(blk.Head.Time), active: lib.Active()})
+	}
+	return blocks
+}
+
+// productionStats counts the blocks of every witness, and the slots of every witness without a block between the
+// blocks and from the newest block to the current slot.
+func productionStats(blocks []*scheduledBlock, currentSlot int64) (produced map[string]int64, missed map[string]int64) {
+	produced = make(map[string]int64)
+	missed = make(map[string]int64)
+	countMissed := func(from *scheduledBlock, to int64) {
+		if len(from.active) == 0 || to-from.slot > maxScheduleBlocks {
+			return
+		}
+		for slot := from.slot + 1; slot < to; slot++ {
+			missed[from.active[slot%int64(len(from.active))]]++
+		}
+	}
+	for i, b := range blocks {
+		produced[b.witness]++
+		if i == 0 {
+			countMissed(b, currentSlot)
+		} else {
+			countMissed(b, blocks[i-1].slot)
+		}
+	}
+	return produced, missed
+}
+
+// GetWitnessSchedule returns the witness schedule and the block production statistics of the witnesses.
+func (as *APIService) GetWitnessSchedule(ctx context.Context, req *rpcpb.GetWitnessScheduleRequest) (*rpcpb.GetWitnessScheduleResponse, error) {
+	count := req.GetBlockCount()
+	if count <= 0 {
+		count = defaultScheduleBlocks
+	}
+	if count > maxScheduleBlocks {
+		return nil, fmt.Errorf("block count should be at most %v", maxScheduleBlocks)
+	}
+	dbVisitor, head, err := as.getStateDBVisitor(true)
+	if err != nil {
+		return nil, err
+	}
+	currentSlot := slotOfNanoSec(time.Now().UnixNano())
+	blocks := as.recentBlocks(count)
+	produced, missed := productionStats(blocks, currentSlot)
+	active := head.Active()
+	ret := &rpcpb.GetWitnessScheduleResponse{
+		HeadBlock:   head.Head.Number,
+		FirstBlock:  head.Head.Number - int64(len(blocks)) + 1,
+		CurrentSlot: currentSlot,
+		SlotLength:  common.SlotLength,
+	}
+	n := int64(len(active))
+	for i, pubkey := range active {
+		next := currentSlot + ((int64(i)-currentSlot%n)+n)%n
+		ret.Witnesses = append(ret.Witnesses, &rpcpb.GetWitnessScheduleResponse_Witness{
+			Pubkey:         pubkey,
+			Account:        dbVisitor.GetProducerOfPubkey(pubkey),
+			Position:       int64(i),
+			NextSlotTime:   next * common.SlotLength * int64(time.Second),
+			ProducedBlocks: produced[pubkey],
+			MissedSlots:    missed[pubkey],
+		})
+	}
+	return ret, nil
+}
+
 // GetContractStorage returns contract storage corresponding to the given key and field.
 func (as *APIService) GetContractStorage(ctx context.Context, req *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error) {
-	dbVisitor, bcn, err := as.getStateDBVisitor(req.ByLongestChain)
+	var dbVisitor *database.Visitor
+	var blk *block.Block
+	if req.GetBlockNumber() != 0 {
+		var err error
+		dbVisitor, blk, err = as.getStateDBVisitorByNumber(req.GetBlockNumber())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		v, bcn, err := as.getStateDBVisitor(req.ByLongestChain)
+		if err != nil {
+			return nil, err
+		}
+		dbVisitor, blk = v, bcn.Block
+	}
+	h := host.NewHost(host.NewContext(nil), dbVisitor, nil, nil)
+	data, err := contractStorageData(h, req.GetId(), req.GetKey(), req.GetField())
 	if err != nil {
 		return nil, err
 	}
-	h := host.NewHost(host.NewContext(nil), dbVisitor, nil, nil)
+	return &rpcpb.GetContractStorageResponse{
+		Data:        data,
+		BlockHash:   common.Base58Encode(blk.HeadHash()),
+		BlockNumber: blk.Head.Number,
+	}, nil
+}
+
+// contractStorageData returns the value of the key, or of the field of the map key if the field is not empty, as a
+// json string unless it is a string.
+func contractStorageData(h *host.Host, id, key, field string) (string, error) {
 	var value interface{}
 	switch {
-	case req.GetField() == "":
-		value, _ = h.GlobalGet(req.GetId(), req.GetKey())
+	case field == "":
+		value, _ = h.GlobalGet(id, key)
 	default:
-		value, _ = h.GlobalMapGet(req.GetId(), req.GetKey(), req.GetField())
+		value, _ = h.GlobalMapGet(id, key, field)
 	}
-	var data string
 	if value != nil && reflect.TypeOf(value).Kind() == reflect.String {
-		data = value.(string)
-	} else {
-		bytes, err := json.Marshal(value)
-		if err != nil {
-			return nil, fmt.Errorf("cannot unmarshal %v", value)
-		}
-		data = string(bytes)
+		return value.(string), nil
 	}
-	return &rpcpb.GetContractStorageResponse{
-		Data:        data,
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot unmarshal %v", value)
+	}
+	return string(bytes), nil
+}
+
+// maxBatchContractStorage is the most keys GetBatchContractStorage returns at once.
+const maxBatchContractStorage = 100
+
+// GetBatchContractStorage returns the values of the keys of contract storage, all read at the same block.
+func (as *APIService) GetBatchContractStorage(ctx context.Context, req *rpcpb.GetBatchContractStorageRequest) (*rpcpb.GetBatchContractStorageResponse, error) {
+	if len(req.GetQueries()) > maxBatchContractStorage {
+		return nil, fmt.Errorf("too many queries %v, the most are %v", len(req.GetQueries()), maxBatchContractStorage)
+	}
+	dbVisitor, bcn, err := as.getStateDBVisitor(req.ByLongestChain)
+	if err != nil {
+		return nil, err
+	}
+	h := host.NewHost(host.NewContext(nil), dbVisitor, nil, nil)
+	res := &rpcpb.GetBatchContractStorageResponse{
 		BlockHash:   common.Base58Encode(bcn.HeadHash()),
 		BlockNumber: bcn.Head.Number,
-	}, nil
+	}
+	for _, q := range req.GetQueries() {
+		data, err := contractStorageData(h, q.GetId(), q.GetKey(), q.GetField())
+		if err != nil {
+			return nil, err
+		}
+		res.Data = append(res.Data, data)
+	}
+	return res, nil
 }
 
 // GetContractStorageFields returns contract storage corresponding to the given fields.
@@ -444,7 +1238,9 @@ func (as *APIService) GetContractStorageFields(ctx context.Context, req *rpcpb.G
 	}, nil
 }
 
-func (as *APIService) tryTransaction(t *tx.Tx) (*tx.TxReceipt, error) {
+// nextBlock returns the head of the block following the head block, with the state of the head block, to try the
+// transactions in.
+func (as *APIService) nextBlock() (*block.BlockHead, db.MVCCDB, error) {
 	topBlock := as.bc.Head()
 	blkHead := &block.BlockHead{
 		Version:    0,
@@ -452,12 +1248,20 @@ func (as *APIService) tryTransaction(t *tx.Tx) (*tx.TxReceipt, error) {
 		Number:     topBlock.Head.Number + 1,
 		Time:       time.Now().UnixNano(),
 	}
-	v := verifier.Verifier{}
 	stateDB := as.bv.StateDB().Fork()
 	ok := stateDB.Checkout(string(topBlock.HeadHash()))
 	if !ok {
-		return nil, fmt.Errorf("failed to checkout blockhash: %s", common.Base58Encode(topBlock.HeadHash()))
+		return nil, nil, fmt.Errorf("failed to checkout blockhash: %s", common.Base58Encode(topBlock.HeadHash()))
+	}
+	return blkHead, stateDB, nil
+}
+
+func (as *APIService) tryTransaction(t *tx.Tx) (*tx.TxReceipt, error) {
+	blkHead, stateDB, err := as.nextBlock()
+	if err != nil {
+		return nil, err
 	}
+	v := verifier.Verifier{}
 	return v.Try(blkHead, stateDB, t, cverifier.TxExecTimeLimit)
 }
 
@@ -503,6 +1307,128 @@ func (as *APIService) ExecTransaction(ctx context.Context, req *rpcpb.Transactio
 	return toPbTxReceipt(receipt), nil
 }
 
+// fillSigns sets the signatures left empty, of which only the public keys are given, to placeholders as long as the
+// signatures of their algorithms, for the transaction to pay the net of the transaction signed.
+func fillSigns(t *tx.Tx, placeholders map[crypto.Algorithm][]byte) {
+	for _, sigs := range [][]*crypto.Signature{t.Signs, t.PublishSigns} {
+		for _, sig := range sigs {
+			if len(sig.Sig) > 0 {
+				continue
+			}
+			p, ok := placeholders[sig.Algorithm]
+			if !ok {
+				p = sig.Algorithm.Sign(make([]byte, 32), sig.Algorithm.GenSeckey())
+				placeholders[sig.Algorithm] = p
+			}
+			sig.Sig = p
+		}
+	}
+}
+
+// searchGasLimit returns the smallest gas limit in (lo, hi] that the transaction succeeds with, hi succeeding with the
+// receipt given, and its receipt. The gas limits probed are at first the ones given, and then the middle ones.
+func searchGasLimit(lo, hi int64, receipt *tx.TxReceipt, probes []int64, succeeds func(int64) (*tx.TxReceipt, bool)) (int64, *tx.TxReceipt) {
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if len(probes) > 0 {
+			if p := probes[0]; p > lo && p < hi {
+				mid = p
+			}
+			probes = probes[1:]
+		}
+		if r, ok := succeeds(mid); ok {
+			hi, receipt = mid, r
+		} else {
+			lo = mid
+		}
+	}
+	return hi, receipt
+}
+
+// estimateGasMargin is the part of the smallest gas limit of a transaction added to the gas limit recommended for it.
+const estimateGasMargin = 0.2
+
+// EstimateGas executes the transaction at the head block with the gas limits it may have, up to the gas of the
+// publisher, searching by bisection the smallest one it succeeds with. Like ExecTransaction, the signatures are not
+// verified, their public keys only giving their permissions, so that the transaction may be estimated before being
+// signed with the gas limit recommended, the signatures left empty.
+func (as *APIService) EstimateGas(ctx context.Context, req *rpcpb.TransactionRequest) (*rpcpb.EstimateGasResponse, error) {
+	if !as.bv.Config().RPC.ExecTx {
+		return nil, errors.New("The node has't enabled this method")
+	}
+	blkHead, stateDB, err := as.nextBlock()
+	if err != nil {
+		return nil, err
+	}
+	v := verifier.Verifier{}
+	placeholders := make(map[crypto.Algorithm][]byte)
+	run := func(gasLimit int64) (*tx.TxReceipt, error) {
+		t := toCoreTx(req)
+		t.GasLimit = gasLimit
+		fillSigns(t, placeholders)
+		return v.Estimate(blkHead, stateDB.Fork(), t, cverifier.TxExecTimeLimit)
+	}
+	succeeds := func(gasLimit int64) (*tx.TxReceipt, bool) {
+		r, err := run(gasLimit)
+		return r, err == nil && r.Status.Code == tx.Success
+	}
+
+	maxLimit := int64(tx.MaxGasLimit)
+	gas := database.NewVisitor(0, stateDB).TotalGasAtTime(req.GetPublisher(), blkHead.Time)
+	if gas.Value < maxLimit {
+		maxLimit = gas.Value
+	}
+	if maxLimit < tx.MinGasLimit {
+		return nil, fmt.Errorf("gas not enough: user %v has %v < %v", req.GetPublisher(), gas.ToString(), tx.MinGasLimit/100)
+	}
+	receipt, err := run(maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status.Code != tx.Success {
+		return nil, fmt.Errorf("transaction failed with the gas limit %v: %v", float64(maxLimit)/100, receipt.Status.Message)
+	}
+	// the gas used is usually the smallest gas limit
+	hi, receipt := searchGasLimit(tx.MinGasLimit-1, maxLimit, receipt, []int64{receipt.GasUsage, receipt.GasUsage - 1}, succeeds)
+	gasLimit := int64(float64(hi) * (1 + estimateGasMargin))
+	if gasLimit > maxLimit {
+		gasLimit = maxLimit
+	}
+	return &rpcpb.EstimateGasResponse{
+		GasUsed:     float64(receipt.GasUsage) / 100,
+		MinGasLimit: float64(hi) / 100,
+		GasLimit:    float64(gasLimit) / 100,
+		Receipt:     toPbTxReceipt(receipt),
+	}, nil
+}
+
+// maxEventFilterSize is how many contracts and argument values the filter of Subscribe may have.
+const maxEventFilterSize = 100
+
+// toEventFilter converts the filter of Subscribe, nil for none.
+func toEventFilter(f *rpcpb.SubscribeRequest_Filter) (*event.Filter, error) {
+	if f == nil {
+		return nil, nil
+	}
+	filter := &event.Filter{}
+	if f.GetContractId() != "" {
+		filter.ContractIDs = append(filter.ContractIDs, f.GetContractId())
+	}
+	filter.ContractIDs = append(filter.ContractIDs, f.GetContractIds()...)
+	size := len(filter.ContractIDs)
+	for _, a := range f.GetArgs() {
+		if len(a.GetValues()) == 0 {
+			return nil, fmt.Errorf("no value for the argument %v of the filter", a.GetKey())
+		}
+		size += len(a.GetValues())
+		filter.Args = append(filter.Args, event.ArgFilter{Key: a.GetKey(), Values: a.GetValues()})
+	}
+	if size > maxEventFilterSize {
+		return nil, fmt.Errorf("the filter has %v contracts and values, more than %v", size, maxEventFilterSize)
+	}
+	return filter, nil
+}
+
 // Subscribe used for event.
 func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiService_SubscribeServer) error {
 
@@ -510,16 +1436,14 @@ func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiServic
 	for _, t := range req.Topics {
 		topics = append(topics, event.Topic(t))
 	}
-	var filter *event.Meta
-	if req.GetFilter() != nil {
-		filter = &event.Meta{
-			ContractID: req.GetFilter().GetContractId(),
-		}
+	filter, err := toEventFilter(req.GetFilter())
+	if err != nil {
+		return err
 	}
 
 	ec := event.GetCollector()
 	id := time.Now().UnixNano()
-	ch := ec.Subscribe(id, topics, filter)
+	ch := ec.SubscribeFilter(id, topics, filter)
 	defer ec.Unsubscribe(id, topics)
 
 	timeup := time.NewTimer(time.Hour)
@@ -533,9 +1457,10 @@ func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiServic
 			return res.Context().Err()
 		case ev := <-ch:
 			e := &rpcpb.Event{
-				Topic: rpcpb.Event_Topic(ev.Topic),
-				Data:  ev.Data,
-				Time:  ev.Time,
+				Topic:      rpcpb.Event_Topic(ev.Topic),
+				Data:       ev.Data,
+				Time:       ev.Time,
+				ContractId: ev.ContractID,
 			}
 			err := res.Send(&rpcpb.SubscribeResponse{Event: e})
 			if err != nil {
@@ -545,6 +1470,64 @@ func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiServic
 		}
 	}
 }
+
+// chainStatusInterval is how often SubscribeChainStatus checks the head block, and chainStatusRefresh is how often the
+// status is sent anyway to refresh the pending transactions and peers.
+const (
+	chainStatusInterval = 100 * time.Millisecond
+	chainStatusRefresh  = time.Second
+)
+
+func (as *APIService) chainStatus(head *blockcache.BlockCacheNode, prev *blockcache.BlockCacheNode) *rpcpb.ChainStatusResponse {
+	var txCount int
+	switch {
+	case prev == nil:
+		txCount = len(head.Txs)
+	case prev != head:
+		for n := head; n != nil && n != prev && n.Head.Number > prev.Head.Number; n = n.GetParent() {
+			txCount += len(n.Txs)
+		}
+	}
+	pending, _ := as.txpool.PendingTx()
+	return &rpcpb.ChainStatusResponse{
+		HeadBlock:        head.Head.Number,
+		HeadBlockHash:    common.Base58Encode(head.HeadHash()),
+		HeadBlockTime:    head.Head.Time,
+		HeadBlockWitness: head.Head.Witness,
+		LibBlock:         as.bc.LinkedRoot().Head.Number,
+		TxCount:          int32(txCount),
+		PendingTxCount:   int32(pending.Size()),
+		PeerCount:        int32(len(as.p2pService.GetAllNeighbors())),
+	}
+}
+
+// SubscribeChainStatus sends the status of the chain and the node whenever the head block changes.
+func (as *APIService) SubscribeChainStatus(req *rpcpb.EmptyRequest, res rpcpb.ApiService_SubscribeChainStatusServer) error {
+	ticker := time.NewTicker(chainStatusInterval)
+	defer ticker.Stop()
+	timeup := time.NewTimer(time.Hour)
+	var prev *blockcache.BlockCacheNode
+	var sent time.Time
+	for {
+		head := as.bc.Head()
+		if head != prev || time.Since(sent) >= chainStatusRefresh {
+			if err := res.Send(as.chainStatus(head, prev)); err != nil {
+				ilog.Errorf("stream send failed. err=%v", err)
+				return err
+			}
+			prev, sent = head, time.Now()
+		}
+		select {
+		case <-timeup.C:
+			return nil
+		case <-as.quitCh:
+			return nil
+		case <-res.Context().Done():
+			return res.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
 func (as *APIService) getStateDBVisitorByHash(hash []byte) (db *database.Visitor, err error) {
 	stateDB := as.bv.StateDB().Fork()
 	ok := stateDB.Checkout(string(hash))
@@ -560,6 +1543,122 @@ func (as *APIService) getStateDBVisitorByHash(hash []byte) (db *database.Visitor
 	return
 }
 
+// errPendingAtBlock is the error of the state queries at a block including the pending txs.
+var errPendingAtBlock = errors.New("include_pending and block_number can't be both set")
+
+// getStateDBVisitorByNumber returns the state after the block of number on the longest chain, and the block. The
+// states of the blocks before the last irreversible block are read from the state history.
+func (as *APIService) getStateDBVisitorByNumber(number int64) (*database.Visitor, *block.Block, error) {
+	if number < 0 {
+		return nil, nil, fmt.Errorf("invalid block number %v", number)
+	}
+	lib := as.bc.LinkedRoot()
+	if number >= lib.Head.Number {
+		blk := lib.Block
+		if number > lib.Head.Number {
+			var err error
+			blk, err = as.bc.GetBlockByNumber(number)
+			if err != nil {
+				return nil, nil, fmt.Errorf("block %v not found on the longest chain", number)
+			}
+		}
+		v, err := as.getStateDBVisitorByHash(blk.HeadHash())
+		if err != nil {
+			return nil, nil, err
+		}
+		return v, blk, nil
+	}
+	if !as.bv.Config().DB.StateHistory {
+		return nil, nil, fmt.Errorf("the node keeps no state history, the states before the last irreversible block %v are unavailable", lib.Head.Number)
+	}
+	hash, err := as.blockchain.GetHashByNumber(number)
+	if err != nil {
+		return nil, nil, fmt.Errorf("block %v not found: %v", number, err)
+	}
+	blk, err := as.blockchain.GetBlockHeadByHash(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("block %v not found: %v", number, err)
+	}
+	// the keys not changed since the block are read from the last irreversible block, retried 3 times as it may be
+	// flushed
+	var stateDB db.MVCCDB
+	for i := 0; i < 3 && stateDB == nil; i++ {
+		stateDB = as.bv.StateDB().Fork()
+		if !stateDB.Checkout(string(as.bc.LinkedRoot().HeadHash())) {
+			stateDB = nil
+		}
+	}
+	if stateDB == nil {
+		return nil, nil, errors.New("db checkout of the last irreversible block failed")
+	}
+	// the start is read after the checkout, as the history of a block is saved, or skipped, before it becomes the last
+	// irreversible block
+	start, err := as.blockchain.StateHistoryStart()
+	if err != nil || number < start-1 {
+		return nil, nil, fmt.Errorf("the state history of block %v is not kept", number)
+	}
+	return database.NewVisitor(0, &historyState{state: stateDB, chain: as.blockchain, number: number}), blk, nil
+}
+
+// pendingStateTimeout is how long the pending txs are executed at most for a query including them.
+const pendingStateTimeout = 300 * time.Millisecond
+
+// pendingState is the state of a head block with the pending txs executed on it, and the time of the block they are
+// executed in. It is kept until the txs or the head change.
+type pendingState struct {
+	key     string
+	stateDB db.MVCCDB
+	time    int64
+}
+
+// pendingStateKey identifies the pending txs and their head block.
+func pendingStateKey(head *blockcache.BlockCacheNode, txs []*tx.Tx) string {
+	buf := append([]byte{}, head.HeadHash()...)
+	for _, t := range txs {
+		buf = append(buf, t.Hash()...)
+	}
+	return string(common.Sha3(buf))
+}
+
+// getPendingStateDBVisitor returns the state of the head block of the pending txs with the txs executed on it in the
+// order they are packed, and the time of the block they are executed in. The txs are executed again only once they or
+// the head change, the queries made meanwhile reading the same state.
+func (as *APIService) getPendingStateDBVisitor() (*database.Visitor, int64, error) {
+	if !as.bv.Config().RPC.PendingState {
+		return nil, 0, status.Error(codes.FailedPrecondition, "the node has not enabled include_pending, see pendingstate in its rpc config")
+	}
+	pending, head := as.txpool.PendingTx()
+	if head == nil {
+		head = as.bc.Head()
+	}
+	txs := pending.Txs()
+	key := pendingStateKey(head, txs)
+	as.pendingMu.Lock()
+	defer as.pendingMu.Unlock()
+	if ps := as.pendingState; ps != nil && ps.key == key {
+		return database.NewVisitor(0, ps.stateDB), ps.time, nil
+	}
+	blkHead := &block.BlockHead{
+		ParentHash: head.HeadHash(),
+		Number:     head.Head.Number + 1,
+		Time:       time.Now().UnixNano(),
+	}
+	stateDB := as.bv.StateDB().Fork()
+	if !stateDB.Checkout(string(head.HeadHash())) {
+		return nil, 0, fmt.Errorf("failed to checkout blockhash: %s", common.Base58Encode(head.HeadHash()))
+	}
+	v := verifier.Verifier{}
+	_, err := v.Pending(blkHead, stateDB, txs, &verifier.Config{
+		Timeout:     pendingStateTimeout,
+		TxTimeLimit: cverifier.TxExecTimeLimit,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	as.pendingState = &pendingState{key: key, stateDB: stateDB, time: blkHead.Time}
+	return database.NewVisitor(0, stateDB), blkHead.Time, nil
+}
+
 func (as *APIService) getStateDBVisitor(longestChain bool) (*database.Visitor, *blockcache.BlockCacheNode, error) {
 	var err error
 	var db *database.Visitor
@@ -0,0 +1,708 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/rpc/graphql"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testTxPool struct {
+	txpool.TxPool
+	pending *txpool.SortedTxMap
+}
+
+func (p *testTxPool) PendingTx() (*txpool.SortedTxMap, *blockcache.BlockCacheNode) {
+	return p.pending, nil
+}
+
+func (p *testTxPool) Flush() int {
+	txs := p.pending.Txs()
+	for _, t := range txs {
+		p.pending.Del(t.Hash())
+	}
+	return len(txs)
+}
+
+func (p *testTxPool) GetFromPending(hash []byte) (*tx.Tx, error) {
+	if t := p.pending.Get(hash); t != nil {
+		return t, nil
+	}
+	return nil, txpool.ErrTxNotFound
+}
+
+func TestGetPendingTransactions(t *testing.T) {
+	pending := txpool.NewSortedTxMap()
+	var txs []*tx.Tx
+	for i := 0; i < 5; i++ {
+		contract := "token.iost"
+		if i%2 == 1 {
+			contract = "ram.iost"
+		}
+		// the txs of higher gas ratios are packed first
+		t := tx.NewTx([]*tx.Action{{Contract: contract, ActionName: "a"}}, nil, 100000, int64(100+i), int64(i), 0, 1024)
+		t.Publisher = fmt.Sprint("user", i%3)
+		pending.Add(t)
+		txs = append([]*tx.Tx{t}, txs...)
+	}
+	as := &APIService{txpool: &testTxPool{pending: pending}}
+	ctx := context.Background()
+	hashes := func(res *rpcpb.GetPendingTransactionsResponse) (h []string) {
+		for _, trx := range res.Transactions {
+			h = append(h, trx.Hash)
+		}
+		return
+	}
+	hash := func(i int) string {
+		return common.Base58Encode(txs[i].Hash())
+	}
+
+	res, err := as.GetPendingTransactions(ctx, &rpcpb.GetPendingTransactionsRequest{Offset: 1, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{hash(1), hash(2)}, hashes(res))
+	assert.True(t, res.HasMore)
+	assert.Equal(t, int32(5), res.Total)
+
+	res, err = as.GetPendingTransactions(ctx, &rpcpb.GetPendingTransactionsRequest{Limit: 10, Contract: "ram.iost"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{hash(1), hash(3)}, hashes(res))
+	assert.False(t, res.HasMore)
+
+	res, err = as.GetPendingTransactions(ctx, &rpcpb.GetPendingTransactionsRequest{Limit: 1, Publisher: "user1", Contract: "token.iost"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{hash(0)}, hashes(res))
+	assert.Equal(t, int32(1), res.Total)
+
+	_, err = as.GetPendingTransactions(ctx, &rpcpb.GetPendingTransactionsRequest{Limit: maxPendingTxs + 1})
+	assert.NotNil(t, err)
+	_, err = as.GetPendingTransactions(ctx, &rpcpb.GetPendingTransactionsRequest{Offset: -1, Limit: 1})
+	assert.NotNil(t, err)
+
+	tr, err := as.GetPendingTxByHash(ctx, &rpcpb.TxHashRequest{Hash: hash(0)})
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.TransactionResponse_PENDING, tr.Status)
+	assert.Equal(t, hash(0), tr.Transaction.Hash)
+	_, err = as.GetPendingTxByHash(ctx, &rpcpb.TxHashRequest{Hash: "x"})
+	assert.NotNil(t, err)
+}
+
+func TestGetAccountTxs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := block.NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	var hashes []string
+	for number := int64(0); number < 3; number++ {
+		blk := &block.Block{
+			Head: &block.BlockHead{Version: 2, ParentHash: []byte("parent hash"), Number: number, Time: number},
+			Sign: &crypto.Signature{},
+		}
+		for i := 0; i < 2; i++ {
+			trx := &tx.Tx{Time: number*10 + int64(i), Publisher: "alice"}
+			blk.Txs = append(blk.Txs, trx)
+			blk.Receipts = append(blk.Receipts, tx.NewTxReceipt(trx.Hash()))
+			hashes = append([]string{common.Base58Encode(trx.Hash())}, hashes...)
+		}
+		blk.CalculateHeadHash()
+		assert.Nil(t, bc.Push(blk))
+	}
+	as := &APIService{blockchain: bc}
+	ctx := context.Background()
+	page := func(req *rpcpb.GetAccountTxsRequest) (h []string, cursor string) {
+		res, err := as.GetAccountTxs(ctx, req)
+		assert.Nil(t, err)
+		for _, r := range res.Transactions {
+			assert.Equal(t, rpcpb.TransactionResponse_IRREVERSIBLE, r.Status)
+			h = append(h, r.Transaction.Hash)
+		}
+		return h, res.Cursor
+	}
+
+	h, cursor := page(&rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 4})
+	assert.Equal(t, hashes[:4], h)
+	h, cursor = page(&rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 4, Cursor: cursor})
+	assert.Equal(t, hashes[4:], h)
+	assert.Empty(t, cursor)
+
+	h, cursor = page(&rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 1, FromHeight: 1})
+	assert.Equal(t, hashes[2:3], h)
+	h, _ = page(&rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 10, FromHeight: 1, Cursor: cursor})
+	assert.Equal(t, hashes[3:], h)
+
+	_, err = as.GetAccountTxs(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 1, Cursor: "x"})
+	assert.NotNil(t, err)
+	_, err = as.GetAccountTxs(ctx, &rpcpb.GetAccountTxsRequest{Limit: 1})
+	assert.NotNil(t, err)
+
+	offsetPage := func(offset, limit int32) (h []string, hasMore bool) {
+		res, err := as.GetTxsByAccount(ctx, &rpcpb.GetTxsByAccountRequest{Account: "alice", Offset: offset, Limit: limit})
+		assert.Nil(t, err)
+		for _, trx := range res.Transactions {
+			h = append(h, trx.Hash)
+		}
+		return h, res.HasMore
+	}
+	h, hasMore := offsetPage(1, 3)
+	assert.Equal(t, hashes[1:4], h)
+	assert.True(t, hasMore)
+	h, hasMore = offsetPage(4, 3)
+	assert.Equal(t, hashes[4:], h)
+	assert.False(t, hasMore)
+	h, hasMore = offsetPage(6, 3)
+	assert.Empty(t, h)
+	assert.False(t, hasMore)
+	_, err = as.GetTxsByAccount(ctx, &rpcpb.GetTxsByAccountRequest{Account: "alice", Offset: block.MaxAccountTxsOffset + 1, Limit: 1})
+	assert.NotNil(t, err)
+
+	// the txs of the blocks pushed before the txs were indexed by account are not listed
+	as.blockchain = &testIndexChain{Chain: bc, start: 2}
+	res, err := as.GetTxsByAccount(ctx, &rpcpb.GetTxsByAccountRequest{Account: "alice", Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.IndexStart)
+	_, err = as.GetAccountTxs(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 1, FromHeight: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	page2, err := as.GetAccountTxs(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 1, FromHeight: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), page2.IndexStart)
+	assert.Equal(t, hashes[0], page2.Transactions[0].Transaction.Hash)
+}
+
+type testIndexChain struct {
+	block.Chain
+	start int64
+}
+
+func (bc *testIndexChain) AccountIndexStart() (int64, error) {
+	return bc.start, nil
+}
+
+func TestGetBlocks(t *testing.T) {
+	c := &testChain{lib: 150}
+	c.grow(0, 200, "a")
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	ctx := context.Background()
+	numbers := func(res *rpcpb.GetBlocksResponse) (n []int64) {
+		for _, b := range res.Blocks {
+			n = append(n, b.Block.Number)
+		}
+		return
+	}
+
+	res, err := as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: 149, End: 151})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{149, 150, 151}, numbers(res))
+	assert.Equal(t, rpcpb.BlockResponse_IRREVERSIBLE, res.Blocks[1].Status)
+	assert.Equal(t, rpcpb.BlockResponse_PENDING, res.Blocks[2].Status)
+	assert.False(t, res.HasMore)
+
+	// the range is cut at the head block, and by the limit
+	res, err = as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: 199, End: 300})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{199, 200}, numbers(res))
+	assert.False(t, res.HasMore)
+	res, err = as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: 10, End: 300})
+	assert.Nil(t, err)
+	assert.Len(t, res.Blocks, maxBlocksByRange)
+	assert.True(t, res.HasMore)
+	res, err = as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: 10, End: 300, Complete: true})
+	assert.Nil(t, err)
+	assert.Len(t, res.Blocks, maxCompleteBlocksByRange)
+	assert.Equal(t, int64(10+maxCompleteBlocksByRange-1), res.Blocks[maxCompleteBlocksByRange-1].Block.Number)
+
+	_, err = as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: 2, End: 1})
+	assert.NotNil(t, err)
+}
+
+func TestGetBlockHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockchain")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc, err := block.NewBlockChain(dir)
+	assert.Nil(t, err)
+	defer bc.Close()
+
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			Version:    2,
+			ParentHash: []byte("parent hash"),
+			Witness:    "witness",
+			Time:       10,
+			Info:       []byte(`{"mode":1,"thread":2,"batch":[1]}`),
+		},
+		Sign: &crypto.Signature{Algorithm: crypto.Ed25519, Sig: []byte("sig"), Pubkey: []byte("pubkey")},
+	}
+	for i := 0; i < 2; i++ {
+		trx := &tx.Tx{Time: int64(i), Publisher: "alice"}
+		blk.Txs = append(blk.Txs, trx)
+		blk.Receipts = append(blk.Receipts, tx.NewTxReceipt(trx.Hash()))
+	}
+	blk.CalculateHeadHash()
+	assert.Nil(t, bc.Push(blk))
+	c := &testChain{lib: 0}
+	c.grow(0, 1, "a")
+	as := &APIService{blockchain: bc, bc: &testBlockCache{c: c}}
+	ctx := context.Background()
+
+	res, err := as.GetBlockHeaderByNumber(ctx, &rpcpb.GetBlockHeaderByNumberRequest{Number: 0})
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.BlockResponse_IRREVERSIBLE, res.Status)
+	h := res.Header
+	assert.Equal(t, common.Base58Encode(blk.HeadHash()), h.Hash)
+	assert.Equal(t, common.Base58Encode([]byte("parent hash")), h.ParentHash)
+	assert.Equal(t, "witness", h.Witness)
+	assert.Equal(t, int64(10), h.Time)
+	assert.Equal(t, int64(2), h.TxCount)
+	assert.Equal(t, &rpcpb.Block_Info{Mode: 1, Thread: 2, BatchIndex: []int32{1}}, h.Info)
+	assert.Equal(t, &rpcpb.Signature{Algorithm: rpcpb.Signature_ED25519, Signature: []byte("sig"), PublicKey: []byte("pubkey")}, h.Sign)
+	byHash, err := as.GetBlockHeaderByHash(ctx, &rpcpb.GetBlockHeaderByHashRequest{Hash: h.Hash})
+	assert.Nil(t, err)
+	assert.Equal(t, res, byHash)
+
+	// the blocks not irreversible are in the block cache
+	res, err = as.GetBlockHeaderByNumber(ctx, &rpcpb.GetBlockHeaderByNumberRequest{Number: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.BlockResponse_PENDING, res.Status)
+	assert.Equal(t, common.Base58Encode(c.blocks[1].HeadHash()), res.Header.Hash)
+	_, err = as.GetBlockHeaderByNumber(ctx, &rpcpb.GetBlockHeaderByNumberRequest{Number: 2})
+	assert.NotNil(t, err)
+}
+
+func TestGetBlockByNumberActions(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	b := c.blocks[3]
+	for _, a := range []*tx.Action{
+		tx.NewAction("token.iost", "transfer", `[]`),
+		tx.NewAction("token.iost", "issue", `[]`),
+		tx.NewAction("vote_producer.iost", "vote", `[]`),
+	} {
+		trx := tx.NewTx([]*tx.Action{a}, nil, 100000, 100, 0, 0, 0)
+		b.Txs = append(b.Txs, trx)
+		b.Receipts = append(b.Receipts, tx.NewTxReceipt(trx.Hash()))
+	}
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	ctx := context.Background()
+	abis := func(actions ...string) (abis []string) {
+		res, err := as.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: 3, Complete: true, Actions: actions})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), res.Block.TxCount)
+		for _, trx := range res.Block.Transactions {
+			abis = append(abis, trx.Actions[0].Contract+"/"+trx.Actions[0].ActionName)
+			assert.NotNil(t, trx.TxReceipt)
+		}
+		return
+	}
+
+	assert.Len(t, abis(), 3)
+	assert.Equal(t, []string{"token.iost/transfer"}, abis("token.iost/transfer"))
+	assert.Equal(t, []string{"token.iost/transfer", "token.iost/issue"}, abis("token.iost"))
+	assert.Equal(t, []string{"token.iost/issue", "vote_producer.iost/vote"}, abis("vote_producer.iost", "token.iost/issue"))
+	assert.Empty(t, abis("token.iost/destroy"))
+
+	for _, a := range []string{"", "/transfer", "token.iost/", "token.iost/transfer/x"} {
+		_, err := as.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: 3, Complete: true, Actions: []string{a}})
+		assert.NotNil(t, err, a)
+	}
+}
+
+type testStateChain struct {
+	block.Chain
+	changes map[int64][]*db.Change
+}
+
+func (bc *testStateChain) GetStateChanges(n int64) ([]*db.Change, error) {
+	changes, ok := bc.changes[n]
+	if !ok {
+		return nil, fmt.Errorf("fail to get state changes by number")
+	}
+	return changes, nil
+}
+
+func TestSearchGasLimit(t *testing.T) {
+	const smallest = 800800
+	var probed []int64
+	succeeds := func(gasLimit int64) (*tx.TxReceipt, bool) {
+		probed = append(probed, gasLimit)
+		return &tx.TxReceipt{GasUsage: gasLimit}, gasLimit >= smallest
+	}
+	top := &tx.TxReceipt{GasUsage: smallest}
+	hi, r := searchGasLimit(tx.MinGasLimit-1, tx.MaxGasLimit, top, []int64{smallest, smallest - 1}, succeeds)
+	assert.Equal(t, int64(smallest), hi)
+	assert.Equal(t, int64(smallest), r.GasUsage)
+	assert.Equal(t, []int64{smallest, smallest - 1}, probed)
+
+	// no probe, or probes out of the range, bisect
+	probed = nil
+	hi, _ = searchGasLimit(tx.MinGasLimit-1, tx.MaxGasLimit, top, []int64{tx.MaxGasLimit + 1}, succeeds)
+	assert.Equal(t, int64(smallest), hi)
+	assert.True(t, len(probed) < 30, "%v probes", len(probed))
+
+	probed = nil
+	hi, r = searchGasLimit(tx.MinGasLimit-1, tx.MinGasLimit, &tx.TxReceipt{GasUsage: 1}, nil, succeeds)
+	assert.Equal(t, int64(tx.MinGasLimit), hi)
+	assert.Equal(t, int64(1), r.GasUsage)
+	assert.Empty(t, probed)
+}
+
+func TestFillSigns(t *testing.T) {
+	kp, err := account.NewKeyPair(nil, crypto.Secp256k1)
+	assert.Nil(t, err)
+	trx := tx.NewTx(nil, nil, tx.MinGasLimit, 100, 0, 0, 0)
+	signed, err := tx.SignTx(trx, "admin", []*account.KeyPair{kp})
+	assert.Nil(t, err)
+	sig := signed.PublishSigns[0]
+	trx.Signs = []*crypto.Signature{{Algorithm: crypto.Ed25519, Pubkey: []byte("ed")}, {Algorithm: crypto.Secp256k1, Pubkey: kp.Pubkey}}
+	trx.PublishSigns = []*crypto.Signature{{Algorithm: crypto.Secp256k1, Pubkey: kp.Pubkey}, sig}
+	placeholders := make(map[crypto.Algorithm][]byte)
+	fillSigns(trx, placeholders)
+	assert.Len(t, trx.Signs[0].Sig, 64)
+	for _, s := range append(trx.Signs[1:], trx.PublishSigns...) {
+		assert.Len(t, s.Sig, len(sig.Sig))
+	}
+	assert.Equal(t, sig, trx.PublishSigns[1])
+	assert.Len(t, placeholders, 2)
+}
+
+func TestGetBlockStateChanges(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 10, "a")
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	as.blockchain = &testStateChain{Chain: as.blockchain, changes: map[int64][]*db.Change{
+		3: {
+			{Table: "state", Key: "b-Contractabc-count", Value: database.MustMarshal(int64(2))},
+			{Table: "state", Key: "b-gas.iost-alicegs", Value: database.MustMarshal(&common.Fixed{Value: 150000000, Decimal: 8})},
+			{Table: "state", Key: "c-Contractabc", Value: "code"},
+			{Table: "state", Key: "m-Contractabc-owners", Value: "@alice@bob"},
+			{Table: "state", Key: "m-Contractabc-owners-bob", Deleted: true},
+			{Table: "state", Key: "m-token.iost-TBalice-iost", Value: database.MustMarshal(int64(100))},
+			{Table: "state", Key: "m-token.iost-TIiost-decimal", Value: database.MustMarshal(int64(8))},
+			{Table: "state", Key: "b-ram.iost-usedSpace", Value: database.MustMarshal("1024")},
+		},
+	}}
+	as.bv = &testBaseVariable{config: &common.Config{DB: &common.DBConfig{}}}
+	ctx := context.Background()
+
+	_, err := as.GetBlockStateChanges(ctx, &rpcpb.GetBlockStateChangesRequest{Number: 3})
+	assert.NotNil(t, err)
+
+	as.bv = &testBaseVariable{config: &common.Config{DB: &common.DBConfig{StateChanges: true}}}
+	res, err := as.GetBlockStateChanges(ctx, &rpcpb.GetBlockStateChangesRequest{Number: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.Number)
+	assert.Equal(t, common.Base58Encode(c.blocks[3].HeadHash()), res.Hash)
+	assert.Equal(t, []*rpcpb.StateChange{
+		{Kind: rpcpb.StateChange_STORAGE, Contract: "Contractabc", Key: "b-Contractabc-count", Value: "2"},
+		{Kind: rpcpb.StateChange_GAS, Contract: "gas.iost", Key: "b-gas.iost-alicegs", Value: "1.5"},
+		{Kind: rpcpb.StateChange_CONTRACT, Contract: "Contractabc", Key: "c-Contractabc"},
+		{Kind: rpcpb.StateChange_STORAGE, Contract: "Contractabc", Key: "m-Contractabc-owners", Value: `["alice","bob"]`},
+		{Kind: rpcpb.StateChange_STORAGE, Contract: "Contractabc", Key: "m-Contractabc-owners-bob", Deleted: true},
+		{Kind: rpcpb.StateChange_TOKEN_BALANCE, Contract: "token.iost", Key: "m-token.iost-TBalice-iost", Value: "100"},
+		{Kind: rpcpb.StateChange_STORAGE, Contract: "token.iost", Key: "m-token.iost-TIiost-decimal", Value: "8"},
+		{Kind: rpcpb.StateChange_RAM, Contract: "ram.iost", Key: "b-ram.iost-usedSpace", Value: "1024"},
+	}, res.Changes)
+
+	// the blocks not irreversible yet, and the ones whose changes were not kept
+	_, err = as.GetBlockStateChanges(ctx, &rpcpb.GetBlockStateChangesRequest{Number: 6})
+	assert.NotNil(t, err)
+	_, err = as.GetBlockStateChanges(ctx, &rpcpb.GetBlockStateChangesRequest{Number: 4})
+	assert.NotNil(t, err)
+}
+
+func TestTraceTransaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statedb")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir)
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	c := &testChain{lib: 1}
+	c.grow(0, 3, "a")
+	trx := tx.NewTx(nil, nil, tx.MinGasLimit, 100, 0, 0, 0)
+	c.blocks[3].Head.Time = trx.Time
+	c.blocks[3].Txs = []*tx.Tx{trx}
+	ctx := context.Background()
+	req := &rpcpb.TxHashRequest{Hash: common.Base58Encode(trx.Hash())}
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	_, err = as.TraceTransaction(ctx, req)
+	assert.NotNil(t, err)
+
+	as.bv = &testBaseVariable{config: &common.Config{RPC: &common.RPCConfig{ExecTx: true}}, stateDB: stateDB}
+	_, err = as.TraceTransaction(ctx, req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = state of block 2 not found, only the txs of the blocks after the last irreversible block can be traced")
+
+	stateDB.Commit(string(c.blocks[2].HeadHash()))
+	res, err := as.TraceTransaction(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.BlockNumber)
+	assert.Equal(t, rpcpb.TxReceipt_SUCCESS, res.Receipt.StatusCode)
+	assert.Empty(t, res.Calls)
+
+	// the txs of the irreversible blocks can't be traced, even with the state of their parent
+	old := tx.NewTx(nil, nil, tx.MinGasLimit, 100, 0, 0, 0)
+	c.blocks[1].Head.Time = old.Time
+	c.blocks[1].Txs = []*tx.Tx{old}
+	stateDB.Commit(string(c.blocks[0].HeadHash()))
+	_, err = as.TraceTransaction(ctx, &rpcpb.TxHashRequest{Hash: common.Base58Encode(old.Hash())})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = block 1 is irreversible, only the txs of the blocks after the last irreversible block 1 can be traced")
+}
+
+func TestGetTokenBalancePending(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statedb")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir)
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	c := &testChain{lib: 1}
+	c.grow(0, 3, "a")
+	v := database.NewVisitor(0, stateDB)
+	v.SetTokenBalance("iost", "alice", 100)
+	v.Commit()
+	stateDB.Commit(string(c.blocks[3].HeadHash()))
+
+	// the tx of a publisher without gas is not able to be packed
+	pending := txpool.NewSortedTxMap()
+	trx := tx.NewTx(nil, nil, tx.MinGasLimit, 100, time.Now().Add(time.Minute).UnixNano(), 0, 0)
+	trx.Publisher = "bob"
+	pending.Add(trx)
+	rpcConfig := &common.RPCConfig{PendingState: true}
+	as := newTestBlocksService(c, rpcConfig)
+	as.bv = &testBaseVariable{config: &common.Config{RPC: rpcConfig}, stateDB: stateDB}
+	as.txpool = &testTxPool{pending: pending}
+	ctx := context.Background()
+	head, err := as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost", ByLongestChain: true})
+	assert.Nil(t, err)
+	assert.NotZero(t, head.Balance)
+	res, err := as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost", IncludePending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, head.Balance, res.Balance)
+
+	// the pending txs are executed again only once they change
+	computed := as.pendingState
+	assert.NotNil(t, computed)
+	_, err = as.GetAccount(ctx, &rpcpb.GetAccountRequest{Name: "alice", IncludePending: true})
+	assert.NotNil(t, err) // alice has no account, only a balance
+	assert.True(t, computed == as.pendingState)
+	other := tx.NewTx(nil, nil, tx.MinGasLimit, 100, time.Now().Add(time.Minute).UnixNano(), 0, 0)
+	other.Publisher = "carol"
+	pending.Add(other)
+	res, err = as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost", IncludePending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, head.Balance, res.Balance)
+	assert.False(t, computed == as.pendingState)
+
+	// the pending txs are not executed unless the node enables it
+	rpcConfig.PendingState = false
+	_, err = as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost", IncludePending: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = as.GetAccount(ctx, &rpcpb.GetAccountRequest{Name: "alice", IncludePending: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// the state of the last irreversible block is not committed
+	_, err = as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost"})
+	assert.NotNil(t, err)
+}
+
+func TestGetBatchContractStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statedb")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir)
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	c := &testChain{lib: 5}
+	c.grow(0, 10, "a")
+	v := database.NewVisitor(0, stateDB)
+	v.Put("Contractabc-count", database.MustMarshal(int64(2)))
+	v.MPut("Contractabc-owners", "bob", database.MustMarshal("admin"))
+	v.Commit()
+	stateDB.Commit(string(c.blocks[5].HeadHash()))
+
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	as.bv = &testBaseVariable{stateDB: stateDB}
+	ctx := context.Background()
+	res, err := as.GetBatchContractStorage(ctx, &rpcpb.GetBatchContractStorageRequest{Queries: []*rpcpb.GetBatchContractStorageRequest_Query{
+		{Id: "Contractabc", Key: "count"},
+		{Id: "Contractabc", Key: "owners", Field: "bob"},
+		{Id: "Contractabc", Key: "owners", Field: "carol"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "admin", "null"}, res.Data)
+	assert.Equal(t, int64(5), res.BlockNumber)
+	assert.Equal(t, common.Base58Encode(c.blocks[5].HeadHash()), res.BlockHash)
+
+	_, err = as.GetBatchContractStorage(ctx, &rpcpb.GetBatchContractStorageRequest{
+		Queries: make([]*rpcpb.GetBatchContractStorageRequest_Query, maxBatchContractStorage+1),
+	})
+	assert.NotNil(t, err)
+}
+
+func TestGetToken721Balance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statedb")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir)
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	c := &testChain{lib: 5}
+	c.grow(0, 10, "a")
+	v := database.NewVisitor(0, stateDB)
+	v.MPut("auth.iost-auth", "alice", database.MustMarshal(`{"id":"alice"}`))
+	v.MPut("token721.iost-T721Balice", "kitty", database.MustMarshal(int64(5)))
+	for _, id := range []string{"3", "0", "4", "1", "2"} {
+		v.MPut("token721.iost-T721Mkitty#alice", id, database.MustMarshal("meta"))
+	}
+	v.Commit()
+	stateDB.Commit(string(c.blocks[5].HeadHash()))
+
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	as.bv = &testBaseVariable{stateDB: stateDB}
+	ctx := context.Background()
+	var ids []string
+	req := &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty", Limit: 2}
+	for {
+		res, err := as.GetToken721Balance(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(5), res.Balance)
+		assert.True(t, len(res.TokenIDs) <= 2)
+		ids = append(ids, res.TokenIDs...)
+		if res.Cursor == "" {
+			break
+		}
+		req.Cursor = res.Cursor
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids)
+
+	res, err := as.GetToken721Balance(ctx, &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty"})
+	assert.Nil(t, err)
+	assert.Len(t, res.TokenIDs, 5)
+	assert.Empty(t, res.Cursor)
+
+	_, err = as.GetToken721Balance(ctx, &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty", Limit: maxToken721IDs + 1})
+	assert.NotNil(t, err)
+	_, err = as.GetToken721Balance(ctx, &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty", Cursor: "0OIl"})
+	assert.NotNil(t, err)
+}
+
+func TestGetProducersAndVoters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statedb")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir)
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	c := &testChain{lib: 5}
+	c.grow(0, 10, "a")
+	v := database.NewVisitor(0, stateDB)
+	v.Put("vote_producer.iost-voteId", database.MustMarshal("1"))
+	for _, p := range []string{"carol", "bob", "dave"} {
+		v.MPut("vote_producer.iost-producerTable", p, database.MustMarshal(`{"pubkey":"key of `+p+`","status":1,"isProducer":true}`))
+		v.MPut("vote.iost-v_1", p, database.MustMarshal(`{"votes":"100"}`))
+	}
+	v.MPut("vote.iost-u_1", "u1", database.MustMarshal(`{"bob":["10","0","2"]}`))
+	v.MPut("vote.iost-u_1", "u2", database.MustMarshal(`{"carol":["5","0","0"]}`))
+	v.MPut("vote.iost-u_1", "u3", database.MustMarshal(`{"bob":["1","0","0"],"carol":["3","0","0"]}`))
+	v.Commit()
+	stateDB.Commit(string(c.blocks[5].HeadHash()))
+
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	as.bv = &testBaseVariable{stateDB: stateDB}
+	ctx := context.Background()
+
+	res, err := as.GetProducers(ctx, &rpcpb.GetProducersRequest{Limit: 2})
+	assert.Nil(t, err)
+	if assert.Len(t, res.Producers, 2) {
+		assert.Equal(t, "bob", res.Producers[0].Account)
+		assert.Equal(t, "key of bob", res.Producers[0].Info.Pubkey)
+		assert.Equal(t, "APPROVED", res.Producers[0].Info.Status)
+		assert.Equal(t, float64(100), res.Producers[0].Info.Votes)
+		assert.Equal(t, "carol", res.Producers[1].Account)
+	}
+	res, err = as.GetProducers(ctx, &rpcpb.GetProducersRequest{Limit: 2, Cursor: res.Cursor})
+	assert.Nil(t, err)
+	if assert.Len(t, res.Producers, 1) {
+		assert.Equal(t, "dave", res.Producers[0].Account)
+	}
+	assert.Empty(t, res.Cursor)
+
+	voters, err := as.GetVoters(ctx, &rpcpb.GetVotersRequest{Producer: "bob", Limit: 1})
+	assert.Nil(t, err)
+	if assert.Len(t, voters.Voters, 1) {
+		assert.Equal(t, &rpcpb.GetVotersResponse_Voter{Account: "u1", Votes: 8, ClearedVotes: 2}, voters.Voters[0])
+	}
+	voters, err = as.GetVoters(ctx, &rpcpb.GetVotersRequest{Producer: "bob", Limit: 1, Cursor: voters.Cursor})
+	assert.Nil(t, err)
+	if assert.Len(t, voters.Voters, 1) {
+		assert.Equal(t, "u3", voters.Voters[0].Account)
+	}
+	voters, err = as.GetVoters(ctx, &rpcpb.GetVotersRequest{Producer: "carol"})
+	assert.Nil(t, err)
+	assert.Len(t, voters.Voters, 2)
+	assert.Empty(t, voters.Cursor)
+	voters, err = as.GetVoters(ctx, &rpcpb.GetVotersRequest{Producer: "dave"})
+	assert.Nil(t, err)
+	assert.Empty(t, voters.Voters)
+
+	_, err = as.GetVoters(ctx, &rpcpb.GetVotersRequest{Producer: "erin"})
+	assert.NotNil(t, err)
+	_, err = as.GetProducers(ctx, &rpcpb.GetProducersRequest{Limit: -1})
+	assert.NotNil(t, err)
+}
+
+func TestGraphQL(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	trx := &tx.Tx{Time: 1, Publisher: "alice"}
+	c.blocks[3].Txs = []*tx.Tx{trx}
+	c.blocks[3].Receipts = []*tx.TxReceipt{{TxHash: trx.Hash(), GasUsage: 100, Status: &tx.Status{Code: tx.Success}}}
+	schema := newGraphQLSchema(newTestBlocksService(c, &common.RPCConfig{}))
+	execute := func(query string) string {
+		b, err := json.Marshal(schema.Execute(context.Background(), &graphql.Request{Query: query}))
+		assert.Nil(t, err)
+		return string(b)
+	}
+
+	// the transactions of the blocks are only read if they are queried
+	got := execute(`{ block(number: 3) { status block { number txCount transactions { publisher txReceipt { gasUsage statusCode } } } } }`)
+	assert.Equal(t, `{"data":{"block":{"status":"IRREVERSIBLE","block":{"number":"3","txCount":"1","transactions":[`+
+		`{"publisher":"alice","txReceipt":{"gasUsage":1,"statusCode":"SUCCESS"}}]}}}}`, got)
+	got = execute(`{ block(number: 3) { block { transactions { publisher } } } }`)
+	assert.Contains(t, got, `"publisher":"alice"`)
+	got = execute(`{ block(number: 3) { block { txCount transactions @skip(if: true) { publisher } } } }`)
+	assert.Equal(t, `{"data":{"block":{"block":{"txCount":"1"}}}}`, got)
+
+	got = execute(`{ blocks(start: 4, end: 6) { blocks { status block { number } } hasMore } }`)
+	assert.Equal(t, `{"data":{"blocks":{"blocks":[{"status":"IRREVERSIBLE","block":{"number":"4"}},`+
+		`{"status":"IRREVERSIBLE","block":{"number":"5"}},{"status":"PENDING","block":{"number":"6"}}],"hasMore":false}}}`, got)
+
+	got = execute(`{ block { status } }`)
+	assert.Equal(t, `{"data":{"block":null},"errors":[{"message":"the number or the hash of the block is required","path":["block"]}]}`, got)
+}
@@ -0,0 +1,271 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiKeyHeader is the http header, and the grpc metadata, giving the api key of a call.
+	apiKeyHeader = "x-api-key"
+	// apiKeyParam is the url parameter giving the api key of a call to the gateway, for the clients which can't set
+	// headers like the websockets of the browsers.
+	apiKeyParam = "api_key"
+	// retryAfterTrailer is the grpc trailer telling how many seconds to wait before calling again with the api key.
+	retryAfterTrailer = "retry-after"
+	// graphQLMethod is the method name of the GraphQL queries in the methods allowed for a key.
+	graphQLMethod = "GraphQL"
+	// apiKeysReloadInterval is how often the file of the api keys is checked for changes.
+	apiKeysReloadInterval = 5 * time.Second
+)
+
+type apiKey struct {
+	config  *common.APIKeyConfig
+	limiter *rate.Limiter
+	methods map[string]bool
+}
+
+func newAPIKey(c *common.APIKeyConfig) *apiKey {
+	k := &apiKey{config: c, limiter: rate.NewLimiter(rate.Inf, 0)}
+	if c.QPS > 0 {
+		burst := c.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(c.QPS))
+		}
+		k.limiter = rate.NewLimiter(rate.Limit(c.QPS), burst)
+	}
+	if len(c.Methods) > 0 {
+		k.methods = make(map[string]bool)
+		for _, m := range c.Methods {
+			k.methods[m] = true
+		}
+	}
+	return k
+}
+
+// apiKeys checks the api keys of the calls, the methods they may call, and limits the rate of the calls of each key.
+// The keys of the config are completed by those of a file, which is read again when it changes.
+type apiKeys struct {
+	configKeys []*common.APIKeyConfig
+	file       string
+
+	mu      sync.RWMutex
+	keys    map[string]*apiKey
+	modTime time.Time
+}
+
+// newAPIKeys returns the api keys of the config, nil if the rpcs are open to all.
+func newAPIKeys(c *common.RPCConfig) (*apiKeys, error) {
+	if c == nil || len(c.APIKeys) == 0 && c.APIKeysFile == "" {
+		return nil, nil
+	}
+	a := &apiKeys{configKeys: c.APIKeys, file: c.APIKeysFile}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// reload reads the file of the keys if it changed since read. The rate limits of the keys left unchanged go on.
+func (a *apiKeys) reload() error {
+	configs := a.configKeys
+	if a.file != "" {
+		info, err := os.Stat(a.file)
+		if err != nil {
+			return fmt.Errorf("read api keys failed: %v", err)
+		}
+		if a.keys != nil && info.ModTime().Equal(a.modTime) {
+			return nil
+		}
+		f, err := readAPIKeysFile(a.file)
+		if err != nil {
+			return fmt.Errorf("read api keys failed: %v", err)
+		}
+		configs = append(append([]*common.APIKeyConfig{}, configs...), f...)
+		a.modTime = info.ModTime()
+	}
+	keys := make(map[string]*apiKey)
+	for _, c := range configs {
+		if c.Key == "" {
+			return fmt.Errorf("read api keys failed: empty key")
+		}
+		k := newAPIKey(c)
+		a.mu.RLock()
+		old := a.keys[c.Key]
+		a.mu.RUnlock()
+		if old != nil && old.config.QPS == c.QPS && old.config.Burst == c.Burst {
+			k.limiter = old.limiter
+		}
+		keys[c.Key] = k
+	}
+	a.mu.Lock()
+	a.keys = keys
+	a.mu.Unlock()
+	return nil
+}
+
+// readAPIKeysFile reads the keys listed under apikeys in the yaml file, decoded like the config of the node.
+func readAPIKeysFile(file string) ([]*common.APIKeyConfig, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(r); err != nil {
+		return nil, err
+	}
+	var f struct {
+		APIKeys []*common.APIKeyConfig
+	}
+	if err := v.Unmarshal(&f); err != nil {
+		return nil, err
+	}
+	return f.APIKeys, nil
+}
+
+// watch reloads the file of the keys whenever it changes, until the server quits. A file which can't be read leaves
+// the keys unchanged.
+func (a *apiKeys) watch(quitCh chan struct{}) {
+	if a.file == "" {
+		return
+	}
+	ticker := time.NewTicker(apiKeysReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quitCh:
+			return
+		case <-ticker.C:
+			if err := a.reload(); err != nil {
+				ilog.Errorf("reload api keys failed. err=%v", err)
+			}
+		}
+	}
+}
+
+// retryError is the error of a call over the rate limit of its key, with the time to wait before calling again.
+type retryError struct {
+	error
+	after time.Duration
+}
+
+// retryAfter returns the seconds to wait, rounded up, as the Retry-After header.
+func (e *retryError) retryAfter() string {
+	return strconv.Itoa(int(math.Ceil(e.after.Seconds())))
+}
+
+// check returns the status error of a call of the method with the key, nil if it is allowed.
+func (a *apiKeys) check(key string, method string) error {
+	if key == "" {
+		return status.Error(codes.Unauthenticated, "api key required")
+	}
+	a.mu.RLock()
+	k := a.keys[key]
+	a.mu.RUnlock()
+	if k == nil {
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	if k.methods != nil && !k.methods[method] {
+		return status.Errorf(codes.PermissionDenied, "method %v is not allowed for the api key", method)
+	}
+	r := k.limiter.Reserve()
+	if !r.OK() {
+		return &retryError{status.Error(codes.ResourceExhausted, "rate limit of the api key exceeded"), time.Second}
+	}
+	if d := r.Delay(); d > 0 {
+		r.Cancel()
+		return &retryError{status.Errorf(codes.ResourceExhausted, "rate limit of the api key exceeded, retry after %v", d), d}
+	}
+	return nil
+}
+
+// checkContext checks the key of the incoming grpc call, setting the retry-after trailer if over the rate limit.
+func (a *apiKeys) checkContext(ctx context.Context, fullMethod string, setTrailer func(metadata.MD)) error {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(apiKeyHeader); len(keys) > 0 {
+			key = keys[0]
+		}
+	}
+	err := a.check(key, fullMethod[strings.LastIndex(fullMethod, "/")+1:])
+	if e, ok := err.(*retryError); ok {
+		setTrailer(metadata.Pairs(retryAfterTrailer, e.retryAfter()))
+		return e.error
+	}
+	return err
+}
+
+func (a *apiKeys) unaryMiddleware(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	setTrailer := func(md metadata.MD) { grpc.SetTrailer(ctx, md) }
+	if err := a.checkContext(ctx, info.FullMethod, setTrailer); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *apiKeys) streamMiddleware(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.checkContext(ss.Context(), info.FullMethod, ss.SetTrailer); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// handler checks the key of the http requests which don't go through grpc, as calls of the method.
+func (a *apiKeys) handler(method string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := a.check(r.Header.Get(apiKeyHeader), method)
+		if e, ok := err.(*retryError); ok {
+			w.Header().Set("Retry-After", e.retryAfter())
+			err = e.error
+		}
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(status.Code(err)))
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// apiKeyFromParam moves the api key given by the url parameter into the header, for the gateway to forward it.
+func apiKeyFromParam(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if key := q.Get(apiKeyParam); key != "" {
+			q.Del(apiKeyParam)
+			r.URL.RawQuery = q.Encode()
+			r.Header.Set(apiKeyHeader, key)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// httpStatus is the http status of the errors of the api keys, 400 for the others like for any error of the gateway.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/iost-official/go-iost/common"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIKeysCheck(t *testing.T) {
+	a, err := newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{
+		{Key: "all"},
+		{Key: "some", Methods: []string{"GetChainInfo"}},
+		{Key: "slow", QPS: 1, Burst: 2},
+	}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(a.check("", "GetChainInfo")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(a.check("unknown", "GetChainInfo")))
+	assert.Nil(t, a.check("all", "GetBlockByNumber"))
+	assert.Nil(t, a.check("some", "GetChainInfo"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.check("some", "GetBlockByNumber")))
+
+	assert.Nil(t, a.check("slow", "GetChainInfo"))
+	assert.Nil(t, a.check("slow", "GetChainInfo"))
+	err = a.check("slow", "GetChainInfo")
+	if assert.IsType(t, &retryError{}, err) {
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err.(*retryError).error))
+		assert.Equal(t, "1", err.(*retryError).retryAfter())
+	}
+
+	a, err = newAPIKeys(&common.RPCConfig{})
+	assert.Nil(t, err)
+	assert.Nil(t, a)
+	_, err = newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{{QPS: 1}}})
+	assert.NotNil(t, err)
+}
+
+func TestAPIKeysReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikeys")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "apikeys.yml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("apikeys:\n  - key: a\n    qps: 1\n    burst: 1\n"), 0600))
+
+	a, err := newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{{Key: "config"}}, APIKeysFile: file})
+	assert.Nil(t, err)
+	assert.Nil(t, a.check("config", "GetChainInfo"))
+	assert.Nil(t, a.check("a", "GetChainInfo"))
+	assert.NotNil(t, a.check("a", "GetChainInfo"))
+
+	// the unchanged keys keep their rate limit
+	assert.Nil(t, ioutil.WriteFile(file, []byte("apikeys:\n  - key: a\n    qps: 1\n    burst: 1\n  - key: b\n    methods: [GetChainInfo]\n"), 0600))
+	assert.Nil(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+	assert.Nil(t, a.reload())
+	assert.NotNil(t, a.check("a", "GetChainInfo"))
+	assert.Nil(t, a.check("b", "GetChainInfo"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.check("b", "GetBlockByNumber")))
+
+	// a broken file leaves the keys unchanged
+	assert.Nil(t, ioutil.WriteFile(file, []byte("apikeys: [\n"), 0600))
+	assert.Nil(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.NotNil(t, a.reload())
+	assert.Nil(t, a.check("b", "GetChainInfo"))
+}
+
+func TestAPIKeysMiddleware(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	a, err := newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{{Key: "k", QPS: 0.001, Burst: 1}}})
+	assert.Nil(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	gs := grpc.NewServer(grpc.UnaryInterceptor(a.unaryMiddleware), grpc.StreamInterceptor(a.streamMiddleware))
+	rpcpb.RegisterApiServiceServer(gs, newTestBlocksService(c, &common.RPCConfig{}))
+	go gs.Serve(lis)
+	defer gs.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+	client := rpcpb.NewApiServiceClient(conn)
+
+	_, err = client.GetBlockByNumber(context.Background(), &rpcpb.GetBlockByNumberRequest{Number: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "k")
+	_, err = client.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: 1})
+	assert.Nil(t, err)
+	var trailer metadata.MD
+	_, err = client.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: 1}, grpc.Trailer(&trailer))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Len(t, trailer.Get(retryAfterTrailer), 1)
+
+	stream, err := client.SubscribeBlocks(ctx, &rpcpb.SubscribeBlocksRequest{})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Len(t, stream.Trailer().Get(retryAfterTrailer), 1)
+}
+
+func TestAPIKeysHandler(t *testing.T) {
+	a, err := newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{{Key: "k", Methods: []string{graphQLMethod}, QPS: 0.001, Burst: 1}}})
+	assert.Nil(t, err)
+	h := apiKeyFromParam(a.handler(graphQLMethod, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get(apiKeyParam))
+	})))
+
+	for _, c := range []struct {
+		url    string
+		status int
+	}{
+		{"/graphql", http.StatusUnauthorized},
+		{"/graphql?api_key=x", http.StatusUnauthorized},
+		{"/graphql?api_key=k&query=q", http.StatusOK},
+		{"/graphql?api_key=k", http.StatusTooManyRequests},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", c.url, nil))
+		assert.Equal(t, c.status, w.Code, c.url)
+		if c.status == http.StatusTooManyRequests {
+			assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		}
+	}
+
+	a, err = newAPIKeys(&common.RPCConfig{APIKeys: []*common.APIKeyConfig{{Key: "k", Methods: []string{"GetChainInfo"}}}})
+	assert.Nil(t, err)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/graphql", nil)
+	r.Header.Set("X-Api-Key", "k")
+	a.handler(graphQLMethod, http.NotFoundHandler()).ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGatewayErrorStatus(t *testing.T) {
+	md := runtime.ServerMetadata{TrailerMD: metadata.Pairs(retryAfterTrailer, "3")}
+	w := httptest.NewRecorder()
+	errorHandler(runtime.NewServerMetadataContext(context.Background(), md), nil, nil, w, nil, status.Error(codes.ResourceExhausted, "slow down"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	errorHandler(context.Background(), nil, nil, w, nil, status.Error(codes.NotFound, "not found"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,287 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/ilog"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The limits of SubscribeBlocks used if not set in the config.
+const (
+	defaultMaxBlockSubscriptions   = 100
+	defaultBlockSubscriptionBuffer = 64
+	defaultSlowSubscriberTimeout   = 30 * time.Second
+)
+
+func (as *APIService) blockSubscriptionLimits() (max int, buffer int, timeout time.Duration) {
+	max, buffer, timeout = defaultMaxBlockSubscriptions, defaultBlockSubscriptionBuffer, defaultSlowSubscriberTimeout
+	if c := as.bv.Config().RPC; c != nil {
+		if c.MaxBlockSubscriptions > 0 {
+			max = c.MaxBlockSubscriptions
+		}
+		if c.BlockSubscriptionBuffer > 0 {
+			buffer = c.BlockSubscriptionBuffer
+		}
+		if c.SlowSubscriberTimeout > 0 {
+			timeout = c.SlowSubscriberTimeout
+		}
+	}
+	return max, buffer, timeout
+}
+
+// sentBlock is a block sent to a subscriber.
+type sentBlock struct {
+	number int64
+	hash   []byte
+}
+
+// blockResumeToken encodes the number and the hash of the block, for the subscriber to resume after it.
+func blockResumeToken(b sentBlock) string {
+	buf := make([]byte, 8, 8+len(b.hash))
+	binary.BigEndian.PutUint64(buf, uint64(b.number))
+	return common.Base58Encode(append(buf, b.hash...))
+}
+
+func parseBlockResumeToken(token string) (sentBlock, error) {
+	buf := common.Base58Decode(token)
+	if len(buf) <= 8 {
+		return sentBlock{}, errors.New("invalid resume token")
+	}
+	return sentBlock{number: int64(binary.BigEndian.Uint64(buf)), hash: buf[8:]}, nil
+}
+
+// onChain tells whether the block is still on the longest chain.
+func (as *APIService) onChain(b sentBlock) bool {
+	if hash, err := as.blockchain.GetHashByNumber(b.number); err == nil {
+		return bytes.Equal(hash, b.hash)
+	}
+	blk, err := as.bc.GetBlockByNumber(b.number)
+	return err == nil && bytes.Equal(blk.HeadHash(), b.hash)
+}
+
+// blockStream walks the longest chain for a subscriber. The pending blocks sent are kept until irreversible, so that
+// those reverted by a fork are sent again as replaced by the new longest chain.
+type blockStream struct {
+	as           *APIService
+	complete     bool
+	irreversible bool
+
+	// the number of the next block to send
+	next int64
+	// the blocks sent which may still be reverted, and the last one sent
+	sent []sentBlock
+	// the number to send again from if all the blocks of sent are reverted
+	floor int64
+}
+
+func (as *APIService) newBlockStream(req *rpcpb.SubscribeBlocksRequest) (*blockStream, error) {
+	bs := &blockStream{
+		as:           as,
+		complete:     req.GetComplete(),
+		irreversible: req.GetIrreversible(),
+	}
+	lib := as.bc.LinkedRoot().Head.Number
+	switch {
+	case req.GetResumeToken() != "":
+		b, err := parseBlockResumeToken(req.GetResumeToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if b.number <= lib && !as.onChain(b) {
+			return nil, status.Error(codes.InvalidArgument, "the block of the resume token is not on the chain")
+		}
+		bs.next, bs.sent = b.number+1, []sentBlock{b}
+		// the blocks before a pending one may have been reverted along with it
+		bs.floor = bs.next
+		if b.number > lib {
+			bs.floor = lib + 1
+		}
+	case req.GetFromNumber() < 0:
+		return nil, status.Error(codes.InvalidArgument, "negative from_number")
+	case req.GetFromNumber() > 0:
+		bs.next = req.GetFromNumber()
+	case bs.irreversible:
+		bs.next = lib + 1
+	default:
+		bs.next = as.bc.Head().Head.Number + 1
+	}
+	if bs.sent == nil {
+		bs.floor = bs.next
+	}
+	return bs, nil
+}
+
+// rewind goes back to the first block reverted since sent, and forgets the irreversible blocks sent but the last.
+func (bs *blockStream) rewind(lib int64) {
+	reverted := false
+	for len(bs.sent) > 0 && !bs.as.onChain(bs.sent[len(bs.sent)-1]) {
+		reverted = true
+		bs.next = bs.sent[len(bs.sent)-1].number
+		bs.sent = bs.sent[:len(bs.sent)-1]
+	}
+	if reverted && len(bs.sent) == 0 {
+		bs.next = bs.floor
+	}
+	for len(bs.sent) > 1 && bs.sent[0].number <= lib {
+		bs.floor = bs.sent[0].number + 1
+		bs.sent = bs.sent[1:]
+	}
+}
+
+// nextBlock returns the next block to send, nil if not produced yet.
+func (bs *blockStream) nextBlock() (*rpcpb.SubscribeBlocksResponse, error) {
+	lib := bs.as.bc.LinkedRoot().Head.Number
+	bs.rewind(lib)
+	limit := bs.as.bc.Head().Head.Number
+	if bs.irreversible {
+		limit = lib
+	}
+	if bs.next > limit {
+		return nil, nil
+	}
+	blk, st, err := bs.as.getBlockByNumber(bs.next)
+	if err != nil {
+		if bs.next < lib {
+			return nil, status.Errorf(codes.NotFound, "block %v not found", bs.next)
+		}
+		// the block was just flushed or reverted, it is read again with the chain settled
+		return nil, nil
+	}
+	if n := len(bs.sent); n > 0 && !bytes.Equal(blk.Head.ParentHash, bs.sent[n-1].hash) {
+		// the chain switched to another fork since rewound
+		return nil, nil
+	}
+	b := sentBlock{number: bs.next, hash: blk.HeadHash()}
+	bs.sent = append(bs.sent, b)
+	bs.next++
+	return &rpcpb.SubscribeBlocksResponse{
+		Status:      st,
+		Block:       toPbBlock(blk, bs.complete),
+		ResumeToken: blockResumeToken(b),
+	}, nil
+}
+
+// SubscribeBlocks sends the blocks from a height, or from the block after the one of the resume token, following the
+// longest chain. A block of a number already sent replaces the one sent, which was reverted. The blocks are read
+// ahead into a buffer, and a subscriber leaving the buffer full for too long is disconnected to resume later. They are
+// sent by a goroutine starting no send once the handler returns.
+func (as *APIService) SubscribeBlocks(req *rpcpb.SubscribeBlocksRequest, res rpcpb.ApiService_SubscribeBlocksServer) error {
+	max, size, timeout := as.blockSubscriptionLimits()
+	if n := atomic.AddInt32(&as.blockSubscriptions, 1); int(n) > max {
+		atomic.AddInt32(&as.blockSubscriptions, -1)
+		return status.Errorf(codes.ResourceExhausted, "too many block subscriptions, the limit is %v", max)
+	}
+	defer atomic.AddInt32(&as.blockSubscriptions, -1)
+
+	bs, err := as.newBlockStream(req)
+	if err != nil {
+		return err
+	}
+
+	buf := make(chan *rpcpb.SubscribeBlocksResponse, size)
+	sendErr := make(chan error, 1)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	// no send is started once the handler returns, sending tells a send is in progress
+	var mu sync.Mutex
+	closed, sending := false, false
+	go func() {
+		defer close(exited)
+		for {
+			var r *rpcpb.SubscribeBlocksResponse
+			select {
+			case <-done:
+				return
+			case r = <-buf:
+			}
+			mu.Lock()
+			if closed {
+				mu.Unlock()
+				return
+			}
+			sending = true
+			mu.Unlock()
+			err := res.Send(r)
+			mu.Lock()
+			sending = false
+			mu.Unlock()
+			if err != nil {
+				sendErr <- err
+				return
+			}
+		}
+	}()
+	slowExit := false
+	defer func() {
+		close(done)
+		mu.Lock()
+		closed = true
+		blocked := sending
+		mu.Unlock()
+		if !blocked {
+			<-exited
+			return
+		}
+		// the send blocked by a slow subscriber fails once the stream is closed, the others are given time to end
+		if !slowExit {
+			select {
+			case <-exited:
+			case <-time.After(timeout):
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(chainStatusInterval)
+	defer ticker.Stop()
+	timeup := time.NewTimer(time.Hour)
+	defer timeup.Stop()
+	slow := time.NewTimer(timeout)
+	slow.Stop()
+	defer slow.Stop()
+	for {
+		r, err := bs.nextBlock()
+		if err != nil {
+			return err
+		}
+		var wait, full <-chan time.Time
+		var in chan<- *rpcpb.SubscribeBlocksResponse
+		if r == nil {
+			wait = ticker.C
+		} else if len(buf) < cap(buf) {
+			buf <- r
+			continue
+		} else {
+			slow.Reset(timeout)
+			in, full = buf, slow.C
+		}
+		select {
+		case <-wait:
+		case in <- r:
+			if !slow.Stop() {
+				<-slow.C
+			}
+		case <-full:
+			slowExit = true
+			ilog.Infof("block subscriber too slow, disconnected at block %v", r.Block.Number)
+			return status.Errorf(codes.ResourceExhausted, "no block was received for %v, resume from the token of the last block received", timeout)
+		case err := <-sendErr:
+			ilog.Errorf("stream send failed. err=%v", err)
+			return err
+		case <-timeup.C:
+			return nil
+		case <-as.quitCh:
+			return nil
+		case <-res.Context().Done():
+			return res.Context().Err()
+		}
+	}
+}
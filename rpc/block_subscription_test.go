@@ -0,0 +1,307 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/db"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testChain is the longest chain, whose blocks up to lib are in the block chain and the others in the block cache.
+type testChain struct {
+	mu     sync.Mutex
+	blocks []*block.Block
+	lib    int64
+}
+
+// grow replaces the blocks from the number by new ones up to head, produced by the witness.
+func (c *testChain) grow(from, head int64, witness string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = c.blocks[:from]
+	for n := from; n <= head; n++ {
+		var parent []byte
+		if n > 0 {
+			parent = c.blocks[n-1].HeadHash()
+		}
+		b := &block.Block{Head: &block.BlockHead{Number: n, ParentHash: parent, Witness: witness}}
+		b.CalculateHeadHash()
+		c.blocks = append(c.blocks, b)
+	}
+}
+
+func (c *testChain) get(n int64, irreversible bool) (*block.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n < 0 || n >= int64(len(c.blocks)) || irreversible != (n <= c.lib) {
+		return nil, fmt.Errorf("block not found")
+	}
+	return c.blocks[n], nil
+}
+
+// node returns the head block if head, or else the last irreversible block.
+func (c *testChain) node(head bool) *blockcache.BlockCacheNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if head {
+		return &blockcache.BlockCacheNode{Block: c.blocks[len(c.blocks)-1]}
+	}
+	return &blockcache.BlockCacheNode{Block: c.blocks[c.lib]}
+}
+
+type testBlockChain struct {
+	block.Chain
+	c *testChain
+}
+
+func (bc *testBlockChain) GetBlockByNumber(n int64) (*block.Block, error) {
+	return bc.c.get(n, true)
+}
+
+func (bc *testBlockChain) GetHashByNumber(n int64) ([]byte, error) {
+	b, err := bc.c.get(n, true)
+	if err != nil {
+		return nil, err
+	}
+	return b.HeadHash(), nil
+}
+
+func (bc *testBlockChain) GetBlockNumberByTxHash(hash []byte) (int64, error) {
+	bc.c.mu.Lock()
+	defer bc.c.mu.Unlock()
+	for _, b := range bc.c.blocks {
+		for _, t := range b.Txs {
+			if bytes.Equal(t.Hash(), hash) {
+				return b.Head.Number, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("tx not found")
+}
+
+type testBlockCache struct {
+	blockcache.BlockCache
+	c *testChain
+}
+
+func (bc *testBlockCache) GetBlockByNumber(n int64) (*block.Block, error) {
+	return bc.c.get(n, false)
+}
+
+func (bc *testBlockCache) Head() *blockcache.BlockCacheNode {
+	return bc.c.node(true)
+}
+
+func (bc *testBlockCache) LinkedRoot() *blockcache.BlockCacheNode {
+	return bc.c.node(false)
+}
+
+type testBaseVariable struct {
+	global.BaseVariable
+	config  *common.Config
+	stateDB db.MVCCDB
+}
+
+func (bv *testBaseVariable) Config() *common.Config {
+	return bv.config
+}
+
+func (bv *testBaseVariable) StateDB() db.MVCCDB {
+	return bv.stateDB
+}
+
+type testBlocksServer struct {
+	rpcpb.ApiService_SubscribeBlocksServer
+	ctx context.Context
+	ch  chan *rpcpb.SubscribeBlocksResponse
+}
+
+func (s *testBlocksServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testBlocksServer) Send(r *rpcpb.SubscribeBlocksResponse) error {
+	select {
+	case s.ch <- r:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func newTestBlocksService(c *testChain, config *common.RPCConfig) *APIService {
+	return &APIService{
+		bc:         &testBlockCache{c: c},
+		blockchain: &testBlockChain{c: c},
+		bv:         &testBaseVariable{config: &common.Config{RPC: config}},
+		quitCh:     make(chan struct{}),
+	}
+}
+
+// subscribeBlocks opens a subscription, whose error is sent to the returned channel once it ends.
+func subscribeBlocks(ctx context.Context, as *APIService, req *rpcpb.SubscribeBlocksRequest) (*testBlocksServer, chan error) {
+	s := &testBlocksServer{ctx: ctx, ch: make(chan *rpcpb.SubscribeBlocksResponse)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- as.SubscribeBlocks(req, s)
+	}()
+	return s, errCh
+}
+
+func recvBlocks(t *testing.T, s *testBlocksServer, count int) []*rpcpb.SubscribeBlocksResponse {
+	var res []*rpcpb.SubscribeBlocksResponse
+	for i := 0; i < count; i++ {
+		select {
+		case r := <-s.ch:
+			res = append(res, r)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block %v of %v not received", i+1, count)
+		}
+	}
+	return res
+}
+
+func blockNumbers(res []*rpcpb.SubscribeBlocksResponse) (numbers []int64, witnesses []string) {
+	for _, r := range res {
+		numbers = append(numbers, r.Block.Number)
+		witnesses = append(witnesses, r.Block.Witness)
+	}
+	return
+}
+
+func TestSubscribeBlocks(t *testing.T) {
+	c := &testChain{lib: 10}
+	c.grow(0, 12, "a")
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, errCh := subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{FromNumber: 9})
+	res := recvBlocks(t, s, 4)
+	numbers, _ := blockNumbers(res)
+	assert.Equal(t, []int64{9, 10, 11, 12}, numbers)
+	assert.Equal(t, rpcpb.BlockResponse_IRREVERSIBLE, res[1].Status)
+	assert.Equal(t, rpcpb.BlockResponse_PENDING, res[2].Status)
+	token11, token12 := res[2].ResumeToken, res[3].ResumeToken
+
+	// the reverted block is sent again as replaced by the fork
+	c.grow(12, 13, "b")
+	numbers, witnesses := blockNumbers(recvBlocks(t, s, 2))
+	assert.Equal(t, []int64{12, 13}, numbers)
+	assert.Equal(t, []string{"b", "b"}, witnesses)
+	cancel()
+	assert.Equal(t, context.Canceled, <-errCh)
+
+	// the subscription is resumed after the block of the token
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s, _ = subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{ResumeToken: token11})
+	numbers, witnesses = blockNumbers(recvBlocks(t, s, 2))
+	assert.Equal(t, []int64{12, 13}, numbers)
+	assert.Equal(t, []string{"b", "b"}, witnesses)
+
+	// or from the last irreversible block if the block of the token was reverted
+	s, _ = subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{ResumeToken: token12})
+	numbers, _ = blockNumbers(recvBlocks(t, s, 3))
+	assert.Equal(t, []int64{11, 12, 13}, numbers)
+
+	// the irreversible blocks only
+	s, _ = subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{FromNumber: 10, Irreversible: true})
+	numbers, _ = blockNumbers(recvBlocks(t, s, 1))
+	assert.Equal(t, []int64{10}, numbers)
+	c.mu.Lock()
+	c.lib = 12
+	c.mu.Unlock()
+	numbers, _ = blockNumbers(recvBlocks(t, s, 2))
+	assert.Equal(t, []int64{11, 12}, numbers)
+
+	_, errCh = subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{ResumeToken: "x"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(<-errCh))
+}
+
+func TestSubscribeBlocksLimits(t *testing.T) {
+	c := &testChain{lib: 100}
+	c.grow(0, 100, "a")
+	as := newTestBlocksService(c, &common.RPCConfig{
+		MaxBlockSubscriptions:   1,
+		BlockSubscriptionBuffer: 2,
+		SlowSubscriberTimeout:   100 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, errCh := subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{FromNumber: 1})
+	recvBlocks(t, s, 1)
+	_, errCh2 := subscribeBlocks(ctx, as, &rpcpb.SubscribeBlocksRequest{FromNumber: 1})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(<-errCh2))
+
+	// the subscriber not receiving the blocks is disconnected
+	select {
+	case err := <-errCh:
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow subscriber not disconnected")
+	}
+}
+
+// lateBlocksServer counts the blocks sent once the handler returned.
+type lateBlocksServer struct {
+	*testBlocksServer
+	returned int32
+	late     int32
+}
+
+func (s *lateBlocksServer) Send(r *rpcpb.SubscribeBlocksResponse) error {
+	if atomic.LoadInt32(&s.returned) == 1 {
+		atomic.AddInt32(&s.late, 1)
+	}
+	return s.testBlocksServer.Send(r)
+}
+
+func TestSubscribeBlocksNoSendAfterReturn(t *testing.T) {
+	c := &testChain{lib: 100}
+	c.grow(0, 100, "a")
+	as := newTestBlocksService(c, &common.RPCConfig{
+		BlockSubscriptionBuffer: 2,
+		SlowSubscriberTimeout:   100 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &lateBlocksServer{testBlocksServer: &testBlocksServer{ctx: ctx, ch: make(chan *rpcpb.SubscribeBlocksResponse)}}
+	errCh := make(chan error, 1)
+	go func() {
+		err := as.SubscribeBlocks(&rpcpb.SubscribeBlocksRequest{FromNumber: 1}, s)
+		atomic.StoreInt32(&s.returned, 1)
+		errCh <- err
+	}()
+
+	// the subscriber is disconnected while a block is being sent
+	select {
+	case err := <-errCh:
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow subscriber not disconnected")
+	}
+	// the send in progress ends, no other starts though the buffer is not empty
+	recvBlocks(t, s.testBlocksServer, 1)
+	for i := 0; i < 5; i++ {
+		select {
+		case <-s.ch:
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&s.late))
+}
@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	// the grpc calls made with gzip are answered compressed
+	_ "github.com/iost-official/go-iost/rpc/gzip"
+)
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// gatewayMethod returns the rpc of the path of the gateway, like GetBlockByNumber for /getBlockByNumber/1/true.
+func gatewayMethod(path string) string {
+	name := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	if name == "" {
+		return ""
+	}
+	if name == "graphql" {
+		return graphQLMethod
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// compressHandler compresses with gzip the responses of the methods, all if empty, for the requests accepting it.
+// The responses shorter than minSize are sent uncompressed, unless they are streamed.
+func compressHandler(methods []string, minSize int, h http.Handler) http.Handler {
+	var allowed map[string]bool
+	if len(methods) > 0 {
+		allowed = make(map[string]bool)
+		for _, m := range methods {
+			allowed[m] = true
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "" || allowed != nil && !allowed[gatewayMethod(r.URL.Path)] {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, minSize: minSize, status: http.StatusOK}
+		defer cw.close()
+		h.ServeHTTP(cw, r)
+	})
+}
+
+// acceptsGzip returns whether the Accept-Encoding header accepts gzip.
+func acceptsGzip(header string) bool {
+	for _, e := range strings.Split(header, ",") {
+		parts := strings.Split(e, ";")
+		name := strings.TrimSpace(parts[0])
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				q, err := strconv.ParseFloat(p[2:], 64)
+				return err == nil && q > 0
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressWriter buffers the response until minSize bytes are written, or it is flushed, before compressing it. The
+// shorter responses are written uncompressed when the handler returns.
+type compressWriter struct {
+	http.ResponseWriter
+	minSize int
+	status  int
+	buf     []byte
+	gz      *gzip.Writer
+	plain   bool
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.gz != nil || w.plain {
+		return
+	}
+	w.status = status
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	if w.plain {
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends what is written so far, compressed, for the streams.
+func (w *compressWriter) Flush() {
+	if w.gz == nil && !w.plain {
+		if err := w.start(true); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// start writes the header and the buffered bytes, compressed or not.
+func (w *compressWriter) start(compress bool) error {
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		compress = false
+	}
+	if compress {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	} else {
+		w.plain = true
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (w *compressWriter) close() {
+	if w.gz == nil && !w.plain {
+		w.start(false)
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,200 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/tx"
+	gzipcompressor "github.com/iost-official/go-iost/rpc/gzip"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestGatewayMethod(t *testing.T) {
+	assert.Equal(t, "GetBlockByNumber", gatewayMethod("/getBlockByNumber/1/true"))
+	assert.Equal(t, "GetChainInfo", gatewayMethod("/getChainInfo"))
+	assert.Equal(t, graphQLMethod, gatewayMethod("/graphql"))
+	assert.Equal(t, "", gatewayMethod("/"))
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	for header, accepts := range map[string]bool{
+		"":                      false,
+		"gzip":                  true,
+		"deflate, gzip;q=0.8":   true,
+		"br, *":                 true,
+		"gzip;q=0":              false,
+		"gzip; q=0.000, br":     false,
+		"identity, deflate":     false,
+		"x-gzip, gzip ; q=1.0 ": true,
+	} {
+		assert.Equal(t, accepts, acceptsGzip(header), header)
+	}
+}
+
+func gunzip(t *testing.T, b []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	assert.Nil(t, err)
+	s, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	return string(s)
+}
+
+func TestCompressHandler(t *testing.T) {
+	long := strings.Repeat("block ", 100)
+	h := compressHandler([]string{"GetBlockByNumber", "SubscribeBlocks"}, 100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getBlockByNumber/1/true", "/getChainInfo":
+			w.Header().Set("Content-Length", fmt.Sprint(len(long)))
+			w.Write([]byte(long[:50]))
+			w.Write([]byte(long[50:]))
+		case "/getBlockByNumber/2/true":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("not found"))
+		case "/subscribeBlocks":
+			w.Write([]byte("first"))
+			w.(http.Flusher).Flush()
+			w.Write([]byte("second"))
+		}
+	}))
+	serve := func(path string, acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/getBlockByNumber/1/true", "gzip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	assert.Equal(t, long, gunzip(t, w.Body.Bytes()))
+
+	w = serve("/getBlockByNumber/1/true", "")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, long, w.Body.String())
+
+	// the methods not configured are not compressed
+	w = serve("/getChainInfo", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Header().Get("Vary"))
+	assert.Equal(t, long, w.Body.String())
+
+	// the short responses neither
+	w = serve("/getBlockByNumber/2/true", "gzip")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "not found", w.Body.String())
+
+	// the streams are compressed from the first flush
+	w = serve("/subscribeBlocks", "gzip")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "firstsecond", gunzip(t, w.Body.Bytes()))
+}
+
+func TestGRPCCompression(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	gs := grpc.NewServer()
+	rpcpb.RegisterApiServiceServer(gs, newTestBlocksService(c, &common.RPCConfig{}))
+	go gs.Serve(lis)
+	defer gs.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.UseCompressor(gzipcompressor.Name)))
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	res, err := rpcpb.NewApiServiceClient(conn).GetBlockByNumber(context.Background(), &rpcpb.GetBlockByNumberRequest{Number: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), res.Block.Number)
+}
+
+// benchmarkBlock returns a complete block of a thousand transfers, half a MB of json.
+func benchmarkBlock() *rpcpb.Block {
+	b := &block.Block{Head: &block.BlockHead{Number: 1000, Witness: "IOSTwitness"}}
+	for i := 0; i < 1000; i++ {
+		t := tx.NewTx([]*tx.Action{
+			tx.NewAction("token.iost", "transfer", fmt.Sprintf(`["iost","user%v","user%v","%v.5","memo"]`, i, i+1, i)),
+		}, nil, 1000000, 100, 1e18, 0, 1024)
+		t.Publisher = fmt.Sprintf("user%v", i)
+		r := tx.NewTxReceipt(t.Hash())
+		r.GasUsage = 314
+		r.RAMUsage = map[string]int64{t.Publisher: 256}
+		r.Returns = []string{"[]"}
+		b.Txs = append(b.Txs, t)
+		b.Receipts = append(b.Receipts, r)
+	}
+	b.CalculateHeadHash()
+	return toPbBlock(b, true)
+}
+
+func BenchmarkGatewayCompression(b *testing.B) {
+	res := &rpcpb.BlockResponse{Status: rpcpb.BlockResponse_IRREVERSIBLE, Block: benchmarkBlock()}
+	m := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	body, err := m.MarshalToString(res)
+	if err != nil {
+		b.Fatal(err)
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	for _, c := range []struct {
+		name    string
+		handler http.Handler
+	}{
+		{"identity", h},
+		{"gzip", compressHandler(nil, 1024, h)},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			var size int
+			for i := 0; i < b.N; i++ {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/getBlockByNumber/1000/true", nil)
+				r.Header.Set("Accept-Encoding", "gzip")
+				c.handler.ServeHTTP(w, r)
+				size = w.Body.Len()
+			}
+			b.ReportMetric(float64(size), "B/response")
+		})
+	}
+}
+
+func BenchmarkGRPCCompression(b *testing.B) {
+	data, err := proto.Marshal(&rpcpb.BlockResponse{Status: rpcpb.BlockResponse_IRREVERSIBLE, Block: benchmarkBlock()})
+	if err != nil {
+		b.Fatal(err)
+	}
+	compressor := encoding.GetCompressor(gzipcompressor.Name)
+	b.SetBytes(int64(len(data)))
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		w, err := compressor.Compress(&buf)
+		if err != nil {
+			b.Fatal(err)
+		}
+		w.Write(data)
+		w.Close()
+	}
+	b.ReportMetric(float64(buf.Len()), "B/response")
+	b.ReportMetric(float64(len(data)), "B/uncompressed")
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
@@ -9,8 +10,11 @@ import (
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/iost-official/go-iost/verifier"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
 )
 
 func toPbAction(a *tx.Action) *rpcpb.Action {
@@ -42,6 +46,54 @@ func toPbTxReceipt(tr *tx.TxReceipt) *rpcpb.TxReceipt {
 	return ret
 }
 
+func toPbCallTraces(calls []*host.CallTrace) []*rpcpb.CallTrace {
+	var ret []*rpcpb.CallTrace
+	for _, c := range calls {
+		pc := &rpcpb.CallTrace{
+			Contract:   c.Contract,
+			ActionName: c.ABI,
+			Data:       c.Args,
+			Returns:    c.Returns,
+			Error:      c.Error,
+			Gas:        float64(c.Gas),
+			Events:     c.Events,
+			Calls:      toPbCallTraces(c.Calls),
+			HostApis:   toPbGasProfiles(c.HostAPIs),
+			Functions:  toPbGasProfiles(c.Functions),
+		}
+		for _, s := range c.Storage {
+			pc.Storage = append(pc.Storage, &rpcpb.StorageTrace{
+				Op:       rpcpb.StorageTrace_Op(s.Op),
+				Contract: s.Contract,
+				Key:      s.Key,
+				Field:    s.Field,
+				Value:    s.Value,
+				Payer:    s.Payer,
+			})
+		}
+		for _, r := range c.Receipts {
+			pc.Receipts = append(pc.Receipts, &rpcpb.TxReceipt_Receipt{
+				FuncName: r.FuncName,
+				Content:  r.Content,
+			})
+		}
+		ret = append(ret, pc)
+	}
+	return ret
+}
+
+func toPbGasProfiles(profiles []*host.GasProfile) []*rpcpb.GasProfile {
+	var ret []*rpcpb.GasProfile
+	for _, p := range profiles {
+		ret = append(ret, &rpcpb.GasProfile{
+			Name:  p.Name,
+			Count: p.Count,
+			Gas:   float64(p.Gas),
+		})
+	}
+	return ret
+}
+
 func toPbAmountLimit(a *contract.Amount) *rpcpb.AmountLimit {
 	return &rpcpb.AmountLimit{
 		Token: a.Token,
@@ -73,6 +125,11 @@ func toPbTx(t *tx.Tx, tr *tx.TxReceipt) *rpcpb.Transaction {
 }
 
 func toPbBlock(blk *block.Block, complete bool) *rpcpb.Block {
+	return toPbFilteredBlock(blk, complete, nil)
+}
+
+// toPbFilteredBlock converts the block with only the transactions matching the filter if complete.
+func toPbFilteredBlock(blk *block.Block, complete bool, filter txActionFilter) *rpcpb.Block {
 	ret := &rpcpb.Block{
 		Hash:                common.Base58Encode(blk.HeadHash()),
 		Version:             blk.Head.Version,
@@ -85,19 +142,54 @@ func toPbBlock(blk *block.Block, complete bool) *rpcpb.Block {
 		GasUsage:            float64(blk.CalculateGasUsage()) / 100,
 		TxCount:             int64(len(blk.Txs)),
 	}
+	ret.Info = toPbBlockInfo(blk.Head.Info)
+	if complete {
+		for i, t := range blk.Txs {
+			if filter.match(t) {
+				ret.Transactions = append(ret.Transactions, toPbTx(t, blk.Receipts[i]))
+			}
+		}
+	}
+	return ret
+}
+
+// toPbBlockHeader converts the header of the block, whose txs may not be loaded but counted by their hashes.
+func toPbBlockHeader(blk *block.Block) *rpcpb.BlockHeader {
+	txCount := len(blk.Txs)
+	if blk.TxHashes != nil {
+		txCount = len(blk.TxHashes)
+	}
+	ret := &rpcpb.BlockHeader{
+		Hash:                common.Base58Encode(blk.HeadHash()),
+		Version:             blk.Head.Version,
+		ParentHash:          common.Base58Encode(blk.Head.ParentHash),
+		TxMerkleHash:        common.Base58Encode(blk.Head.TxMerkleHash),
+		TxReceiptMerkleHash: common.Base58Encode(blk.Head.TxReceiptMerkleHash),
+		Number:              blk.Head.Number,
+		Witness:             blk.Head.Witness,
+		Time:                blk.Head.Time,
+		TxCount:             int64(txCount),
+		Info:                toPbBlockInfo(blk.Head.Info),
+	}
+	if blk.Sign != nil {
+		ret.Sign = &rpcpb.Signature{
+			Algorithm: rpcpb.Signature_Algorithm(blk.Sign.Algorithm),
+			Signature: blk.Sign.Sig,
+			PublicKey: blk.Sign.Pubkey,
+		}
+	}
+	return ret
+}
+
+func toPbBlockInfo(b []byte) *rpcpb.Block_Info {
 	var info verifier.Info
-	json.Unmarshal(blk.Head.Info, &info)
-	ret.Info = &rpcpb.Block_Info{
+	json.Unmarshal(b, &info)
+	ret := &rpcpb.Block_Info{
 		Mode:   int32(info.Mode),
 		Thread: int32(info.Thread),
 	}
 	for _, i := range info.Batch {
-		ret.Info.BatchIndex = append(ret.Info.BatchIndex, int32(i))
-	}
-	if complete {
-		for i, t := range blk.Txs {
-			ret.Transactions = append(ret.Transactions, toPbTx(t, blk.Receipts[i]))
-		}
+		ret.BatchIndex = append(ret.BatchIndex, int32(i))
 	}
 	return ret
 }
@@ -166,6 +258,57 @@ func toPbContract(c *contract.Contract) *rpcpb.Contract {
 	return ret
 }
 
+func toPbStateChange(c *db.Change) *rpcpb.StateChange {
+	ret := &rpcpb.StateChange{
+		Key:     c.Key,
+		Deleted: c.Deleted,
+	}
+	if strings.HasPrefix(c.Key, database.ContractPrefix) {
+		ret.Kind = rpcpb.StateChange_CONTRACT
+		ret.Contract = strings.TrimPrefix(c.Key, database.ContractPrefix)
+		return ret
+	}
+	if strings.HasPrefix(c.Key, database.BasicPrefix) || strings.HasPrefix(c.Key, database.MapPrefix) {
+		parts := strings.SplitN(c.Key[len(database.BasicPrefix):], database.Separator, 2)
+		ret.Contract = parts[0]
+		switch {
+		case ret.Contract == database.TokenContractName && len(parts) == 2 &&
+			(strings.HasPrefix(parts[1], "TB") || strings.HasPrefix(parts[1], "TF")):
+			ret.Kind = rpcpb.StateChange_TOKEN_BALANCE
+		case ret.Contract == database.RAMContractName:
+			ret.Kind = rpcpb.StateChange_RAM
+		case ret.Contract == database.GasContractName:
+			ret.Kind = rpcpb.StateChange_GAS
+		}
+	}
+	if !c.Deleted {
+		ret.Value = toStateValue(c.Value)
+	}
+	return ret
+}
+
+// toStateValue returns the value stored in the state as a string, the way GetContractStorage does.
+func toStateValue(raw string) string {
+	if strings.HasPrefix(raw, database.MapHolderPrefix) {
+		// the fields of a map are separated by the same separator as the extra of a value
+		fields, _ := json.Marshal(strings.Split(raw, database.ApplicationSeparator)[1:])
+		return string(fields)
+	}
+	switch v := database.Unmarshal(raw).(type) {
+	case string:
+		return v
+	case database.SerializedJSON:
+		return string(v)
+	case *common.Fixed:
+		return v.ToString()
+	case error:
+		return raw
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
 func toCoreTx(t *rpcpb.TransactionRequest) *tx.Tx {
 	ret := &tx.Tx{
 		Time:       t.Time,
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iost-official/go-iost/rpc/graphql"
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// newGraphQLSchema returns the schema of the GraphQL queries served by the gateway, which are resolved by the api service
+// like the rpcs of the same names, with the same types of results.
+func newGraphQLSchema(as *APIService) *graphql.Schema {
+	return &graphql.Schema{
+		Query: map[string]*graphql.FieldDef{
+			"chainInfo": {
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetChainInfo(ctx, &rpcpb.EmptyRequest{})
+				},
+			},
+			"block": {
+				Args: map[string]string{"number": "Int", "hash": "String"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					// the transactions are only packed if they are queried
+					complete := f.Selects("block", "transactions")
+					if f.Has("hash") {
+						return as.GetBlockByHash(ctx, &rpcpb.GetBlockByHashRequest{Hash: f.String("hash"), Complete: complete})
+					}
+					if !f.Has("number") {
+						return nil, errors.New("the number or the hash of the block is required")
+					}
+					return as.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: f.Int("number"), Complete: complete})
+				},
+			},
+			"blocks": {
+				Args: map[string]string{"start": "Int!", "end": "Int!"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetBlocks(ctx, &rpcpb.GetBlocksRequest{
+						Start:    f.Int("start"),
+						End:      f.Int("end"),
+						Complete: f.Selects("blocks", "block", "transactions"),
+					})
+				},
+			},
+			"transaction": {
+				Args: map[string]string{"hash": "String!"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetTxByHash(ctx, &rpcpb.TxHashRequest{Hash: f.String("hash")})
+				},
+			},
+			"txReceipt": {
+				Args: map[string]string{"hash": "String!"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetTxReceiptByTxHash(ctx, &rpcpb.TxHashRequest{Hash: f.String("hash")})
+				},
+			},
+			"account": {
+				Args: map[string]string{"name": "String!", "byLongestChain": "Boolean"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetAccount(ctx, &rpcpb.GetAccountRequest{Name: f.String("name"), ByLongestChain: f.Bool("byLongestChain")})
+				},
+			},
+			"tokenBalance": {
+				Args: map[string]string{"account": "String!", "token": "String!", "byLongestChain": "Boolean"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{
+						Account:        f.String("account"),
+						Token:          f.String("token"),
+						ByLongestChain: f.Bool("byLongestChain"),
+					})
+				},
+			},
+			"contract": {
+				Args: map[string]string{"id": "String!", "byLongestChain": "Boolean"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetContract(ctx, &rpcpb.GetContractRequest{Id: f.String("id"), ByLongestChain: f.Bool("byLongestChain")})
+				},
+			},
+			"contractStorage": {
+				Args: map[string]string{"id": "String!", "key": "String!", "field": "String", "byLongestChain": "Boolean"},
+				Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+					return as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{
+						Id:             f.String("id"),
+						Key:            f.String("key"),
+						Field:          f.String("field"),
+						ByLongestChain: f.Bool("byLongestChain"),
+					})
+				},
+			},
+		},
+		Fields: map[string]map[string]*graphql.FieldDef{
+			"Account": {
+				"tokenBalance": {
+					Args: map[string]string{"token": "String!", "byLongestChain": "Boolean"},
+					Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+						return as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{
+							Account:        f.Source.(*rpcpb.Account).Name,
+							Token:          f.String("token"),
+							ByLongestChain: f.Bool("byLongestChain"),
+						})
+					},
+				},
+			},
+			"TransactionResponse": {
+				"block": {
+					Resolve: func(ctx context.Context, f *graphql.Field) (interface{}, error) {
+						res := f.Source.(*rpcpb.TransactionResponse)
+						if res.Status == rpcpb.TransactionResponse_PENDING {
+							return nil, nil
+						}
+						return as.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{
+							Number:   res.BlockNumber,
+							Complete: f.Selects("block", "transactions"),
+						})
+					},
+				},
+			},
+		},
+	}
+}
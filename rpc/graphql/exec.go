@@ -0,0 +1,559 @@
+// Package graphql serves GraphQL queries of a schema of resolver functions. It supports the query operations with
+// variables, aliases, fragments and the skip and include directives, but not the mutations, the subscriptions nor the
+// introspection.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxDepth is the max depth of the selections of a query.
+const maxDepth = 12
+
+// Schema is the queries served, of the root fields and the extra fields added to the object types.
+//
+// Every other field of an object type is a field of the go struct the resolver returns, named as its json name given by the
+// protobuf tag, or its go name in lower camel case. Enums are their names, 64 bits integers are strings like in the json of
+// the gateway, bytes are base64 strings, and maps are lists of objects of key and value.
+type Schema struct {
+	Query map[string]*FieldDef
+	// Fields are the extra fields of the object types, by the type name, which is the go type name without underscores.
+	Fields map[string]map[string]*FieldDef
+}
+
+// FieldDef is a field resolved by a function.
+type FieldDef struct {
+	// Args are the types of the arguments, as String, Int, Float or Boolean, suffixed by ! if the argument is required.
+	Args    map[string]string
+	Resolve func(ctx context.Context, f *Field) (interface{}, error)
+}
+
+// Field is the field being resolved.
+type Field struct {
+	// Source is the object the field belongs to, nil for the root fields.
+	Source interface{}
+	// Args are the arguments given, int64 for the Int ones, and float64, string or bool for the others.
+	Args map[string]interface{}
+
+	selections []selection
+	doc        *document
+}
+
+// String returns the string argument, or "" if it is not given.
+func (f *Field) String(name string) string {
+	s, _ := f.Args[name].(string)
+	return s
+}
+
+// Int returns the int argument, or 0 if it is not given.
+func (f *Field) Int(name string) int64 {
+	i, _ := f.Args[name].(int64)
+	return i
+}
+
+// Bool returns the boolean argument, or false if it is not given.
+func (f *Field) Bool(name string) bool {
+	b, _ := f.Args[name].(bool)
+	return b
+}
+
+// Has returns whether the argument is given.
+func (f *Field) Has(name string) bool {
+	_, ok := f.Args[name]
+	return ok
+}
+
+// Selects returns whether the query selects the path of sub fields of the field, for resolvers to skip the parts of the
+// result not queried.
+func (f *Field) Selects(path ...string) bool {
+	sels := f.selections
+	for _, name := range path {
+		sels = f.sub(sels, name, make(map[string]bool))
+		if sels == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// sub returns the selections of the fields named name among sels, or nil if there is none.
+func (f *Field) sub(sels []selection, name string, visited map[string]bool) []selection {
+	var found []selection
+	for _, sel := range sels {
+		var s []selection
+		switch sel := sel.(type) {
+		case *field:
+			if sel.name == name {
+				s = append([]selection{}, sel.selections...)
+			}
+		case *inlineFragment:
+			s = f.sub(sel.selections, name, visited)
+		case *fragmentSpread:
+			if frag := f.doc.fragments[sel.name]; frag != nil && !visited[sel.name] {
+				visited[sel.name] = true
+				s = f.sub(frag.selections, name, visited)
+			}
+		}
+		if s != nil {
+			found = append(append([]selection{}, found...), s...)
+		}
+	}
+	return found
+}
+
+// Error is an error of a query, with the path of the field it happened at.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Request is a query with its variables.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the result of a query, with no data if the query failed before the execution.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Execute runs the query.
+func (s *Schema) Execute(ctx context.Context, req *Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	vars, err := variables(op, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	e := &executor{ctx: ctx, schema: s, doc: doc, vars: vars}
+	data := e.object(reflect.Value{}, "Query", s.Query, op.selections, nil, 0)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("the operation name is required since the document has several operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func variables(op *operation, given map[string]interface{}) (map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	for _, def := range op.variables {
+		v, ok := given[def.name]
+		if !ok && def.defValue != nil {
+			v, ok = def.defValue, true
+		}
+		if def.typ.nonNull && v == nil {
+			return nil, fmt.Errorf("variable $%v of type %v is required", def.name, def.typ)
+		}
+		if ok {
+			vars[def.name] = v
+		}
+	}
+	return vars, nil
+}
+
+type executor struct {
+	ctx    context.Context
+	schema *Schema
+	doc    *document
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+func (e *executor) errorf(path []interface{}, format string, a ...interface{}) {
+	e.errors = append(e.errors, &Error{Message: fmt.Sprintf(format, a...), Path: path})
+}
+
+// object is the result of an object, of the fields in the order of the query.
+type object struct {
+	keys   []string
+	values []interface{}
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// collect returns the fields selected on the type, merging the fields of the same response key.
+func (e *executor) collect(typeName string, sels []selection, fields []*field, visited map[string]bool) []*field {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *field:
+			if !e.included(sel.directives) {
+				continue
+			}
+			merged := false
+			for i, f := range fields {
+				if f.key() == sel.key() {
+					c := *f
+					c.selections = append(append([]selection{}, f.selections...), sel.selections...)
+					fields[i] = &c
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				fields = append(fields, sel)
+			}
+		case *inlineFragment:
+			if e.included(sel.directives) && (sel.on == "" || sel.on == typeName) {
+				fields = e.collect(typeName, sel.selections, fields, visited)
+			}
+		case *fragmentSpread:
+			frag := e.doc.fragments[sel.name]
+			if !e.included(sel.directives) || visited[sel.name] || frag == nil || frag.on != typeName {
+				continue
+			}
+			visited[sel.name] = true
+			fields = e.collect(typeName, frag.selections, fields, visited)
+		}
+	}
+	return fields
+}
+
+func (e *executor) included(directives []*directive) bool {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+		var cond bool
+		for _, arg := range d.args {
+			if arg.name == "if" {
+				cond, _ = e.resolve(arg.value).(bool)
+			}
+		}
+		if cond == (d.name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve replaces the variables of the value by their values.
+func (e *executor) resolve(v interface{}) interface{} {
+	switch v := v.(type) {
+	case variable:
+		return e.vars[string(v)]
+	case listValue:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = e.resolve(elem)
+		}
+		return list
+	case objValue:
+		obj := make(map[string]interface{}, len(v))
+		for _, arg := range v {
+			obj[arg.name] = e.resolve(arg.value)
+		}
+		return obj
+	}
+	return v
+}
+
+// object resolves the fields of an object, the ones of defs first, then the ones of the go value src.
+func (e *executor) object(src reflect.Value, typeName string, defs map[string]*FieldDef, sels []selection, path []interface{}, depth int) interface{} {
+	if depth > maxDepth {
+		e.errorf(path, "the query is deeper than %v", maxDepth)
+		return nil
+	}
+	obj := &object{}
+	for _, f := range e.collect(typeName, sels, nil, make(map[string]bool)) {
+		fieldPath := appendPath(path, f.key())
+		obj.keys = append(obj.keys, f.key())
+		obj.values = append(obj.values, e.field(src, typeName, defs, f, fieldPath, depth))
+	}
+	return obj
+}
+
+func (e *executor) field(src reflect.Value, typeName string, defs map[string]*FieldDef, f *field, path []interface{}, depth int) interface{} {
+	if f.name == "__typename" {
+		return typeName
+	}
+	if def := defs[f.name]; def != nil {
+		args, err := e.args(def, f)
+		if err != nil {
+			e.errorf(path, "%v", err)
+			return nil
+		}
+		var source interface{}
+		if src.IsValid() {
+			source = src.Interface()
+		}
+		v, err := e.call(def, &Field{Source: source, Args: args, selections: f.selections, doc: e.doc})
+		if err != nil {
+			e.errorf(path, "%v", err)
+			return nil
+		}
+		return e.value(reflect.ValueOf(v), f, path, depth)
+	}
+	if src.IsValid() {
+		if i, ok := structFields(src.Type())[f.name]; ok {
+			if len(f.args) > 0 {
+				e.errorf(path, "field %q of type %q has no arguments", f.name, typeName)
+				return nil
+			}
+			return e.value(reflect.Indirect(src).Field(i), f, path, depth)
+		}
+	}
+	e.errorf(path, "cannot query field %q on type %q", f.name, typeName)
+	return nil
+}
+
+func (e *executor) call(def *FieldDef, f *Field) (v interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	return def.Resolve(e.ctx, f)
+}
+
+func (e *executor) args(def *FieldDef, f *field) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	for _, arg := range f.args {
+		typ, ok := def.Args[arg.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q on field %q", arg.name, f.name)
+		}
+		if _, ok := args[arg.name]; ok {
+			return nil, fmt.Errorf("there can be only one argument named %q", arg.name)
+		}
+		v := e.resolve(arg.value)
+		if v == nil {
+			continue
+		}
+		_, isVar := arg.value.(variable)
+		v, err := coerce(strings.TrimSuffix(typ, "!"), v, isVar)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q of field %q: %v", arg.name, f.name, err)
+		}
+		args[arg.name] = v
+	}
+	for name, typ := range def.Args {
+		if _, ok := args[name]; !ok && strings.HasSuffix(typ, "!") {
+			return nil, fmt.Errorf("argument %q of type %q is required on field %q", name, typ, f.name)
+		}
+	}
+	return args, nil
+}
+
+// coerce converts the value of a literal or the json of a variable to the type.
+func coerce(typ string, v interface{}, isVar bool) (interface{}, error) {
+	switch typ {
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "Int":
+		switch n := v.(type) {
+		case int64:
+			return n, nil
+		case float64:
+			if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+				return int64(n), nil
+			}
+		case json.Number:
+			if i, err := n.Int64(); err == nil {
+				return i, nil
+			}
+		case string:
+			// int64 values are strings in the json of the gateway, so they are accepted as variables.
+			if i, err := strconv.ParseInt(n, 10, 64); err == nil && isVar {
+				return i, nil
+			}
+		}
+	case "Float":
+		switch n := v.(type) {
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case json.Number:
+			if f, err := n.Float64(); err == nil {
+				return f, nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %v", typ)
+	}
+	return nil, fmt.Errorf("%v is not a valid %v", v, typ)
+}
+
+var stringer = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// value converts the go value of the field to its result.
+func (e *executor) value(v reflect.Value, f *field, path []interface{}, depth int) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Struct) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Struct:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil
+		}
+		t := reflect.Indirect(v).Type()
+		if len(f.selections) == 0 {
+			e.errorf(path, "field %q of type %q must have a selection of subfields", f.name, typeName(t))
+			return nil
+		}
+		return e.object(v, typeName(t), e.schema.Fields[typeName(t)], f.selections, path, depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return e.scalar(base64.StdEncoding.EncodeToString(v.Bytes()), f, path)
+		}
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = e.value(v.Index(i), f, appendPath(path, i), depth)
+		}
+		return list
+	case reflect.Map:
+		return e.mapValue(v, f, path, depth)
+	case reflect.Int64:
+		return e.scalar(strconv.FormatInt(v.Int(), 10), f, path)
+	case reflect.Uint64:
+		return e.scalar(strconv.FormatUint(v.Uint(), 10), f, path)
+	case reflect.Int32:
+		if v.Type().Implements(stringer) {
+			return e.scalar(v.Interface().(fmt.Stringer).String(), f, path)
+		}
+	}
+	return e.scalar(v.Interface(), f, path)
+}
+
+func (e *executor) scalar(v interface{}, f *field, path []interface{}) interface{} {
+	if len(f.selections) > 0 {
+		e.errorf(path, "field %q must not have a selection since it has no subfields", f.name)
+		return nil
+	}
+	return v
+}
+
+// mapValue returns the entries of the map sorted by key, as objects of the key and the value.
+func (e *executor) mapValue(v reflect.Value, f *field, path []interface{}, depth int) interface{} {
+	if len(f.selections) == 0 {
+		e.errorf(path, "field %q must have a selection of key and value", f.name)
+		return nil
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	list := make([]interface{}, len(keys))
+	for i, k := range keys {
+		entryPath := appendPath(path, i)
+		obj := &object{}
+		for _, sub := range e.collect("", f.selections, nil, make(map[string]bool)) {
+			var value interface{}
+			switch sub.name {
+			case "key":
+				value = e.value(k, sub, appendPath(entryPath, sub.key()), depth+1)
+			case "value":
+				value = e.value(v.MapIndex(k), sub, appendPath(entryPath, sub.key()), depth+1)
+			default:
+				e.errorf(appendPath(entryPath, sub.key()), "cannot query field %q on a map entry", sub.name)
+			}
+			obj.keys = append(obj.keys, sub.key())
+			obj.values = append(obj.values, value)
+		}
+		list[i] = obj
+	}
+	return list
+}
+
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), key)
+}
+
+func typeName(t reflect.Type) string {
+	return strings.Replace(t.Name(), "_", "", -1)
+}
+
+var fieldsCache sync.Map
+
+// structFields returns the indexes of the fields of the struct type by their graphql names.
+func structFields(t reflect.Type) map[string]int {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.(map[string]int)
+	}
+	fields := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" || strings.HasPrefix(sf.Name, "XXX_") {
+			continue
+		}
+		name := strings.ToLower(sf.Name[:1]) + sf.Name[1:]
+		for _, part := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+			if strings.HasPrefix(part, "json=") {
+				name = strings.TrimPrefix(part, "json=")
+			}
+		}
+		fields[name] = i
+	}
+	fieldsCache.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,220 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus int32
+
+func (s testStatus) String() string {
+	return map[testStatus]string{0: "PENDING", 1: "DONE"}[s]
+}
+
+type testTx struct {
+	Hash                 string            `protobuf:"bytes,1,opt,name=hash,proto3"`
+	GasLimit             float64           `protobuf:"fixed64,2,opt,name=gas_limit,json=gasLimit,proto3"`
+	Status               testStatus        `protobuf:"varint,3,opt,name=status,proto3"`
+	Data                 []byte            `protobuf:"bytes,4,opt,name=data,proto3"`
+	XXX_unrecognized     []byte            `json:"-"`
+	Balances             map[string]string `protobuf:"bytes,5,rep,name=balances,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+}
+
+type testBlock struct {
+	Number int64     `protobuf:"varint,1,opt,name=number,proto3"`
+	Txs    []*testTx `protobuf:"bytes,2,rep,name=txs,proto3"`
+}
+
+var blocks = []*testBlock{
+	{Number: 0},
+	{Number: 1, Txs: []*testTx{
+		{Hash: "h1", GasLimit: 1.5, Status: 1, Data: []byte("data"), Balances: map[string]string{"iost": "1", "ram": "2"}},
+		{Hash: "h2"},
+	}},
+}
+
+func testSchema() *Schema {
+	return &Schema{
+		Query: map[string]*FieldDef{
+			"block": {
+				Args: map[string]string{"number": "Int!"},
+				Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+					if f.Int("number") >= int64(len(blocks)) {
+						return nil, errors.New("block not found")
+					}
+					return blocks[f.Int("number")], nil
+				},
+			},
+			"echo": {
+				Args: map[string]string{"s": "String", "f": "Float", "b": "Boolean"},
+				Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+					if !f.Has("s") {
+						return "none", nil
+					}
+					return f.String("s"), nil
+				},
+			},
+			"panic": {
+				Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+					panic("boom")
+				},
+			},
+		},
+		Fields: map[string]map[string]*FieldDef{
+			"testBlock": {
+				"self": {
+					Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+						return f.Source, nil
+					},
+				},
+			},
+			"testTx": {
+				"short": {
+					Args: map[string]string{"n": "Int"},
+					Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+						hash := f.Source.(*testTx).Hash
+						if f.Has("n") && int(f.Int("n")) < len(hash) {
+							hash = hash[:f.Int("n")]
+						}
+						return hash, nil
+					},
+				},
+			},
+		},
+	}
+}
+
+func execute(t *testing.T, req *Request) string {
+	b, err := json.Marshal(testSchema().Execute(context.Background(), req))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestExecute(t *testing.T) {
+	got := execute(t, &Request{Query: `
+		# a comment
+		{
+			block(number: 1) {
+				__typename
+				number
+				txs { hash gasLimit status data balances { key value } short(n: 1) }
+			}
+			other: block(number: 0) { number, txs { hash } }
+		}`})
+	assert.Equal(t, `{"data":{"block":{"__typename":"testBlock","number":"1","txs":[`+
+		`{"hash":"h1","gasLimit":1.5,"status":"DONE","data":"ZGF0YQ==","balances":[{"key":"iost","value":"1"},{"key":"ram","value":"2"}],"short":"h"},`+
+		`{"hash":"h2","gasLimit":0,"status":"PENDING","data":"","balances":[],"short":"h"}]},`+
+		`"other":{"number":"0","txs":[]}}}`, got)
+}
+
+func TestFragments(t *testing.T) {
+	got := execute(t, &Request{Query: `
+		query Q($n: Int!, $withHash: Boolean = true) {
+			block(number: $n) {
+				...blockFields
+				txs { hash @include(if: $withHash) ... on testTx { status } ... on other { nothing } }
+			}
+		}
+		fragment blockFields on testBlock { number txs { short } }`,
+		Variables: map[string]interface{}{"n": float64(1), "withHash": false},
+	})
+	assert.Equal(t, `{"data":{"block":{"number":"1","txs":[{"short":"h1","status":"DONE"},{"short":"h2","status":"PENDING"}]}}}`, got)
+
+	got = execute(t, &Request{
+		Query:         `query A { echo(s: "a\"é") } query B { echo @skip(if: true) e: echo }`,
+		OperationName: "B",
+	})
+	assert.Equal(t, `{"data":{"e":"none"}}`, got)
+	got = execute(t, &Request{Query: `query A { echo(s: "a\"é", f: 1, b: false) } query B { echo }`, OperationName: "A"})
+	assert.Equal(t, `{"data":{"echo":"a\"é"}}`, got)
+}
+
+func TestSelects(t *testing.T) {
+	var selected []bool
+	s := &Schema{Query: map[string]*FieldDef{
+		"block": {
+			Resolve: func(ctx context.Context, f *Field) (interface{}, error) {
+				selected = append(selected, f.Selects("txs", "hash"))
+				return blocks[1], nil
+			},
+		},
+	}}
+	resp := s.Execute(context.Background(), &Request{Query: `
+		{
+			a: block { txs { hash } }
+			b: block { ...f }
+			c: block { txs { status } }
+			d: block { ... { txs { status hash } } }
+		}
+		fragment f on testBlock { txs { hash } }`})
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, []bool{true, true, false, true}, selected)
+}
+
+func TestErrors(t *testing.T) {
+	for _, c := range []struct {
+		query string
+		want  string
+	}{
+		{`{ block(number: 1) { number`, `{"errors":[{"message":"syntax error at 1:28: unexpected end of the document"}]}`},
+		{`mutation { echo }`, `{"errors":[{"message":"mutation operations are not supported, only queries"}]}`},
+		{`{ block(number: 1) { ...missing } }`, `{"errors":[{"message":"unknown fragment \"missing\""}]}`},
+		{`query A { echo } query B { echo }`, `{"errors":[{"message":"the operation name is required since the document has several operations"}]}`},
+		{`query($n: Int!) { block(number: $n) { number } }`, `{"errors":[{"message":"variable $n of type Int! is required"}]}`},
+		{`{ block(number: 5) { number } echo }`, `{"data":{"block":null,"echo":"none"},"errors":[{"message":"block not found","path":["block"]}]}`},
+		{`{ block { number } }`, `{"data":{"block":null},"errors":[{"message":"argument \"number\" of type \"Int!\" is required on field \"block\"","path":["block"]}]}`},
+		{`{ block(number: "1") { number } }`, `{"data":{"block":null},"errors":[{"message":"argument \"number\" of field \"block\": 1 is not a valid Int","path":["block"]}]}`},
+		{`{ echo(x: 1) }`, `{"data":{"echo":null},"errors":[{"message":"unknown argument \"x\" on field \"echo\"","path":["echo"]}]}`},
+		{`{ block(number: 1) }`, `{"data":{"block":null},"errors":[{"message":"field \"block\" of type \"testBlock\" must have a selection of subfields","path":["block"]}]}`},
+		{`{ block(number: 1) { number { x } } }`, `{"data":{"block":{"number":null}},"errors":[{"message":"field \"number\" must not have a selection since it has no subfields","path":["block","number"]}]}`},
+		{`{ block(number: 1) { txs { unknown } } }`, `{"data":{"block":{"txs":[{"unknown":null},{"unknown":null}]}},"errors":[` +
+			`{"message":"cannot query field \"unknown\" on type \"testTx\"","path":["block","txs",0,"unknown"]},` +
+			`{"message":"cannot query field \"unknown\" on type \"testTx\"","path":["block","txs",1,"unknown"]}]}`},
+		{`{ panic }`, `{"data":{"panic":null},"errors":[{"message":"internal error: boom","path":["panic"]}]}`},
+	} {
+		assert.Equal(t, c.want, execute(t, &Request{Query: c.query}), c.query)
+	}
+
+	query := "{ block(number: 1) { " + strings.Repeat("self { ", 12) + "number" + strings.Repeat(" }", 12) + " } }"
+	got := execute(t, &Request{Query: query})
+	assert.Contains(t, got, `"message":"the query is deeper than 12"`)
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler(testSchema()))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(`{"query":"query($n: Int) { block(number: $n) { number } }","variables":{"n":1}}`))
+	assert.Nil(t, err)
+	var got map[string]interface{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&got))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"data": map[string]interface{}{"block": map[string]interface{}{"number": "1"}}}, got)
+
+	resp, err = http.Get(server.URL + "?query=%7Bblock(number%3A%241)%7Bnumber%7D%7D")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + `?query=query($n:Int!){block(number:$n){number}}&variables={"n":"0"}`)
+	assert.Nil(t, err)
+	got = nil
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&got))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"data": map[string]interface{}{"block": map[string]interface{}{"number": "0"}}}, got)
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
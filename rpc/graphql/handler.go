@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/iost-official/go-iost/ilog"
+)
+
+// maxRequestSize is the max size of the body of a query.
+const maxRequestSize = 1 << 20
+
+// Handler returns the http handler of the queries of the schema, given by the json body of POST requests, or by the query,
+// operationName and variables parameters of GET requests.
+func Handler(s *Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := readRequest(r)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, &Response{Errors: []*Error{{Message: err.Error()}}})
+			return
+		}
+		resp := s.Execute(r.Context(), req)
+		status := http.StatusOK
+		if resp.Data == nil {
+			status = http.StatusBadRequest
+		}
+		writeResponse(w, status, resp)
+	})
+}
+
+func readRequest(r *http.Request) (*Request, error) {
+	req := &Request{}
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return nil, fmt.Errorf("invalid variables: %v", err)
+			}
+		}
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestSize))
+		if err != nil {
+			return nil, fmt.Errorf("read request failed: %v", err)
+		}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, fmt.Errorf("invalid request: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("method %v is not allowed", r.Method)
+	}
+	if req.Query == "" {
+		return nil, fmt.Errorf("the query is missing")
+	}
+	return req, nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *Response) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		ilog.Errorf("marshal graphql response failed: %v", err)
+		status = http.StatusInternalServerError
+		b = []byte(`{"errors":[{"message":"internal error"}]}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
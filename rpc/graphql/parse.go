@@ -0,0 +1,691 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	col   int
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("syntax error at %v:%v: %v", l.line, l.col, fmt.Sprintf(format, a...))
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n; i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 0
+		}
+		l.pos++
+		l.col++
+	}
+}
+
+// skip skips the white spaces, the commas and the comments, which the grammar ignores.
+func (l *lexer) skip() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\ufeff"):
+			l.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	l.skip()
+	tok := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		return tok, nil
+	}
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		tok.kind, tok.value = tokenPunct, "..."
+	case strings.IndexByte("!$():=@[]{}", c) >= 0:
+		l.advance(1)
+		tok.kind, tok.value = tokenPunct, string(c)
+	case isNameStart(c):
+		for l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind, tok.value = tokenName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		return l.string(tok)
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return tok, l.errorf("unexpected character %q", r)
+	}
+	return tok, nil
+}
+
+func (l *lexer) digits() int {
+	n := 0
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+		n++
+	}
+	return n
+}
+
+func (l *lexer) number(tok token) (token, error) {
+	start := l.pos
+	tok.kind = tokenInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	if l.digits() == 0 {
+		return tok, l.errorf("invalid number %q", l.src[start:l.pos])
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if l.digits() == 0 {
+			return tok, l.errorf("invalid number %q", l.src[start:l.pos])
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if l.digits() == 0 {
+			return tok, l.errorf("invalid number %q", l.src[start:l.pos])
+		}
+	}
+	tok.value = l.src[start:l.pos]
+	return tok, nil
+}
+
+func (l *lexer) string(tok token) (token, error) {
+	tok.kind = tokenString
+	l.advance(1)
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return tok, l.errorf("unterminated string")
+		}
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			tok.value = b.String()
+			return tok, nil
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return tok, l.errorf("unterminated string")
+			}
+			e := l.src[l.pos+1]
+			if e == 'u' {
+				if l.pos+6 > len(l.src) {
+					return tok, l.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+				if err != nil {
+					return tok, l.errorf("invalid unicode escape %q", l.src[l.pos:l.pos+6])
+				}
+				b.WriteRune(rune(r))
+				l.advance(6)
+				continue
+			}
+			escaped, ok := map[byte]byte{'"': '"', '\\': '\\', '/': '/', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t'}[e]
+			if !ok {
+				return tok, l.errorf("invalid escape %q", l.src[l.pos:l.pos+2])
+			}
+			b.WriteByte(escaped)
+			l.advance(2)
+		default:
+			b.WriteByte(c)
+			l.advance(1)
+		}
+	}
+}
+
+// document is a parsed query, of the operations and the fragments they spread.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	name       string
+	variables  []*variableDef
+	selections []selection
+}
+
+type variableDef struct {
+	name     string
+	typ      *typeRef
+	defValue interface{}
+}
+
+// typeRef is a type of a variable, a named type unless it is a list of elem.
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	args       []*argument
+	directives []*directive
+	selections []selection
+}
+
+func (f *field) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type argument struct {
+	name  string
+	value interface{}
+}
+
+type directive struct {
+	name string
+	args []*argument
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+}
+
+type inlineFragment struct {
+	on         string
+	directives []*directive
+	selections []selection
+}
+
+type fragment struct {
+	name       string
+	on         string
+	selections []selection
+}
+
+// The values of the arguments are the go values of the literals, int64 for the Int ones, or these types.
+type (
+	variable  string
+	enumValue string
+	listValue []interface{}
+	objValue  []*argument
+)
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func parse(src string) (*document, error) {
+	p := &parser{lex: &lexer{src: src, line: 1, col: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokenEOF {
+		if p.peekName("fragment") {
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if doc.fragments[f.name] != nil {
+				return nil, fmt.Errorf("there can be only one fragment named %q", f.name)
+			}
+			doc.fragments[f.name] = f
+			continue
+		}
+		op, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("the document has no operation")
+	}
+	for _, op := range doc.operations {
+		if err := doc.validate(op.selections); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range doc.fragments {
+		if err := doc.validate(f.selections); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// validate checks the fragments spread by the selections are defined.
+func (d *document) validate(sels []selection) error {
+	for _, sel := range sels {
+		var err error
+		switch sel := sel.(type) {
+		case *field:
+			err = d.validate(sel.selections)
+		case *inlineFragment:
+			err = d.validate(sel.selections)
+		case *fragmentSpread:
+			if d.fragments[sel.name] == nil {
+				err = fmt.Errorf("unknown fragment %q", sel.name)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) read() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("syntax error at %v:%v: %v", p.tok.line, p.tok.col, fmt.Sprintf(format, a...))
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.errorf("unexpected end of the document")
+	}
+	return p.errorf("unexpected %q", p.tok.value)
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == punct
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokenName && p.tok.value == name
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected()
+	}
+	return p.read()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.read()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{}
+	if !p.peek("{") {
+		kind, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if kind != "query" {
+			return nil, fmt.Errorf("%v operations are not supported, only queries", kind)
+		}
+		if p.tok.kind == tokenName {
+			if op.name, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek("(") {
+			if op.variables, err = p.variableDefs(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	op.selections, err = p.selectionSet()
+	return op, err
+}
+
+func (p *parser) variableDefs() ([]*variableDef, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var defs []*variableDef
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		def := &variableDef{}
+		var err error
+		if def.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if def.typ, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if p.peek("=") {
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			if def.defValue, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, p.read()
+}
+
+func (p *parser) typeRef() (*typeRef, error) {
+	t := &typeRef{}
+	if p.peek("[") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		t.elem = elem
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if t.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("!") {
+		t.nonNull = true
+		return t, p.read()
+	}
+	return t, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	f := &fragment{}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.name == "on" {
+		return nil, p.errorf("a fragment can not be named on")
+	}
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if f.on, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	f.selections, err = p.selectionSet()
+	return f, err
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []selection
+	for !p.peek("}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, p.errorf("a selection set can not be empty")
+	}
+	return sels, p.read()
+}
+
+func (p *parser) selection() (selection, error) {
+	if p.peek("...") {
+		return p.spread()
+	}
+	f := &field{}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.peek(":") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		f.alias = f.name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if f.args, err = p.arguments(false, false); err != nil {
+			return nil, err
+		}
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) spread() (selection, error) {
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName && !p.peekName("on") {
+		s := &fragmentSpread{}
+		var err error
+		if s.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		s.directives, err = p.directives()
+		return s, err
+	}
+	f := &inlineFragment{}
+	var err error
+	if p.peekName("on") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if f.on, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	f.selections, err = p.selectionSet()
+	return f, err
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var ds []*directive
+	for p.peek("@") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		d := &directive{}
+		var err error
+		if d.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if p.peek("(") {
+			if d.args, err = p.arguments(false, false); err != nil {
+				return nil, err
+			}
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+// arguments parses the arguments in parentheses, or the fields of an object value in braces if obj.
+func (p *parser) arguments(obj, constant bool) ([]*argument, error) {
+	open, closing := "(", ")"
+	if obj {
+		open, closing = "{", "}"
+	}
+	if err := p.expect(open); err != nil {
+		return nil, err
+	}
+	var args []*argument
+	for !p.peek(closing) {
+		arg := &argument{}
+		var err error
+		if arg.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if !obj && len(args) == 0 {
+		return nil, p.errorf("the arguments can not be empty")
+	}
+	return args, p.read()
+}
+
+// value parses a value, where variables are not allowed if constant.
+func (p *parser) value(constant bool) (interface{}, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		i, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid int %v", tok.value)
+		}
+		return i, p.read()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %v", tok.value)
+		}
+		return f, p.read()
+	case tokenString:
+		return tok.value, p.read()
+	case tokenName:
+		switch tok.value {
+		case "true":
+			return true, p.read()
+		case "false":
+			return false, p.read()
+		case "null":
+			return nil, p.read()
+		}
+		return enumValue(tok.value), p.read()
+	}
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return variable(name), err
+	case p.peek("["):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		list := listValue{}
+		for !p.peek("]") {
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.read()
+	case p.peek("{"):
+		args, err := p.arguments(true, constant)
+		return objValue(args), err
+	}
+	return nil, p.unexpected()
+}
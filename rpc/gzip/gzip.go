@@ -0,0 +1,77 @@
+// Package gzip registers the gzip compressor of the grpc calls, like the package encoding/gzip of the later versions
+// of grpc. Importing it lets the servers decompress the calls made with the compressor and compress their responses,
+// and lets the clients make these calls with grpc.UseCompressor(gzip.Name).
+package gzip
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name of the compressor, sent as the grpc-encoding of the calls.
+const Name = "gzip"
+
+func init() {
+	encoding.RegisterCompressor(&compressor{})
+}
+
+type compressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+type writer struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+type reader struct {
+	*gzip.Reader
+	pool *sync.Pool
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if z, ok := c.writers.Get().(*writer); ok {
+		z.Reset(w)
+		return z, nil
+	}
+	return &writer{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+// Close closes the gzip stream and puts back the writer in the pool.
+func (z *writer) Close() error {
+	defer z.pool.Put(z)
+	return z.Writer.Close()
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	z, ok := c.readers.Get().(*reader)
+	if !ok {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return &reader{Reader: gr, pool: &c.readers}, nil
+	}
+	if err := z.Reset(r); err != nil {
+		c.readers.Put(z)
+		return nil, err
+	}
+	return z, nil
+}
+
+// Read puts back the reader in the pool once the stream is read.
+func (z *reader) Read(p []byte) (n int, err error) {
+	n, err = z.Reader.Read(p)
+	if err == io.EOF {
+		z.pool.Put(z)
+	}
+	return n, err
+}
+
+func (c *compressor) Name() string {
+	return Name
+}
@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMaxRecvMsgSize       = 4 * 1024 * 1024
+	defaultMaxSendMsgSize       = 64 * 1024 * 1024
+	defaultMaxConcurrentStreams = 200
+	defaultTimeout              = 30 * time.Second
+	// minMsgSize is the least bytes allowed for the messages, under which the usual requests would be refused.
+	minMsgSize = 64 * 1024
+)
+
+// rpcLimits are the sizes of the messages, the streams of a connection and the timeouts of the calls of the server.
+type rpcLimits struct {
+	maxRecvMsgSize       int
+	maxSendMsgSize       int
+	maxConcurrentStreams uint32
+	timeout              time.Duration
+	// methodTimeouts are the timeouts by lower case rpc name, viper giving the keys of the maps in lower case.
+	methodTimeouts map[string]time.Duration
+}
+
+// rpcMethods returns the lower case names of the rpcs of the api service.
+func rpcMethods() map[string]bool {
+	t := reflect.TypeOf((*rpcpb.ApiServiceServer)(nil)).Elem()
+	methods := make(map[string]bool, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		methods[strings.ToLower(t.Method(i).Name)] = true
+	}
+	return methods
+}
+
+// newRPCLimits returns the limits of the config, the defaults in place of the zeros.
+func newRPCLimits(c *common.RPCConfig) (*rpcLimits, error) {
+	l := &rpcLimits{
+		maxRecvMsgSize:       defaultMaxRecvMsgSize,
+		maxSendMsgSize:       defaultMaxSendMsgSize,
+		maxConcurrentStreams: defaultMaxConcurrentStreams,
+		timeout:              defaultTimeout,
+		methodTimeouts:       make(map[string]time.Duration),
+	}
+	if c == nil {
+		return l, nil
+	}
+	if c.MaxRecvMsgSize < 0 || c.MaxRecvMsgSize > 0 && c.MaxRecvMsgSize < minMsgSize {
+		return nil, fmt.Errorf("invalid max recv msg size %v, should be at least %v", c.MaxRecvMsgSize, minMsgSize)
+	}
+	if c.MaxRecvMsgSize > 0 {
+		l.maxRecvMsgSize = c.MaxRecvMsgSize
+	}
+	if c.MaxSendMsgSize < 0 || c.MaxSendMsgSize > 0 && c.MaxSendMsgSize < minMsgSize {
+		return nil, fmt.Errorf("invalid max send msg size %v, should be at least %v", c.MaxSendMsgSize, minMsgSize)
+	}
+	if c.MaxSendMsgSize > 0 {
+		l.maxSendMsgSize = c.MaxSendMsgSize
+	}
+	if c.MaxConcurrentStreams < 0 {
+		return nil, fmt.Errorf("invalid max concurrent streams %v", c.MaxConcurrentStreams)
+	}
+	if c.MaxConcurrentStreams > 0 {
+		l.maxConcurrentStreams = uint32(c.MaxConcurrentStreams)
+	}
+	if c.Timeout < 0 {
+		return nil, fmt.Errorf("invalid timeout %v", c.Timeout)
+	}
+	if c.Timeout > 0 {
+		l.timeout = c.Timeout
+	}
+	methods := rpcMethods()
+	for m, d := range c.MethodTimeouts {
+		if !methods[strings.ToLower(m)] {
+			return nil, fmt.Errorf("invalid timeout of %v: unknown rpc", m)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid timeout of %v: %v", m, d)
+		}
+		l.methodTimeouts[strings.ToLower(m)] = d
+	}
+	return l, nil
+}
+
+func (l *rpcLimits) methodTimeout(method string) time.Duration {
+	if d, ok := l.methodTimeouts[strings.ToLower(method)]; ok {
+		return d
+	}
+	return l.timeout
+}
+
+// serverOptions returns the options of the grpc server applying the limits.
+func (l *rpcLimits) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(l.maxRecvMsgSize),
+		grpc.MaxSendMsgSize(l.maxSendMsgSize),
+		grpc.MaxConcurrentStreams(l.maxConcurrentStreams),
+	}
+}
+
+// dialOption lets the clients of the gateway send and receive the messages allowed by the server.
+func (l *rpcLimits) dialOption() grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(l.maxSendMsgSize), grpc.MaxCallSendMsgSize(l.maxRecvMsgSize))
+}
+
+type unaryResult struct {
+	res interface{}
+	err error
+}
+
+// unaryMiddleware fails the calls taking longer than their timeout with DeadlineExceeded. The context of the handler
+// is canceled then, and what it returns later is dropped.
+func (l *rpcLimits) unaryMiddleware(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.methodTimeout(methodName(info.FullMethod)))
+	defer cancel()
+	ch := make(chan unaryResult, 1)
+	go func() {
+		res, err := handler(ctx, req)
+		ch <- unaryResult{res, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
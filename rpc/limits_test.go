@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewRPCLimits(t *testing.T) {
+	l, err := newRPCLimits(&common.RPCConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, defaultMaxRecvMsgSize, l.maxRecvMsgSize)
+	assert.Equal(t, defaultMaxSendMsgSize, l.maxSendMsgSize)
+	assert.Equal(t, uint32(defaultMaxConcurrentStreams), l.maxConcurrentStreams)
+	assert.Equal(t, defaultTimeout, l.methodTimeout("GetChainInfo"))
+
+	l, err = newRPCLimits(&common.RPCConfig{
+		MaxRecvMsgSize:       1 << 20,
+		MaxConcurrentStreams: 10,
+		Timeout:              time.Second,
+		// viper gives the keys in lower case
+		MethodTimeouts: map[string]time.Duration{"estimategas": 5 * time.Second},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1<<20, l.maxRecvMsgSize)
+	assert.Equal(t, uint32(10), l.maxConcurrentStreams)
+	assert.Equal(t, time.Second, l.methodTimeout("GetChainInfo"))
+	assert.Equal(t, 5*time.Second, l.methodTimeout("EstimateGas"))
+
+	for _, c := range []*common.RPCConfig{
+		{MaxRecvMsgSize: -1},
+		{MaxSendMsgSize: 1024},
+		{MaxConcurrentStreams: -1},
+		{Timeout: -time.Second},
+		{MethodTimeouts: map[string]time.Duration{"Unknown": time.Second}},
+		{MethodTimeouts: map[string]time.Duration{"EstimateGas": 0}},
+	} {
+		_, err = newRPCLimits(c)
+		assert.NotNil(t, err, "%+v", c)
+	}
+}
+
+func TestLimitsMiddleware(t *testing.T) {
+	l, err := newRPCLimits(&common.RPCConfig{
+		Timeout:        time.Second,
+		MethodTimeouts: map[string]time.Duration{"EstimateGas": 10 * time.Millisecond},
+	})
+	assert.Nil(t, err)
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return &rpcpb.ChainInfoResponse{HeadBlock: 3}, nil
+	}
+
+	res, err := l.unaryMiddleware(context.Background(), &rpcpb.EmptyRequest{}, &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetChainInfo"}, slow)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.(*rpcpb.ChainInfoResponse).HeadBlock)
+
+	_, err = l.unaryMiddleware(context.Background(), &rpcpb.TransactionRequest{}, &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/EstimateGas"}, slow)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
@@ -2,27 +2,119 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/metrics"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
+// sizeBuckets are the upper bounds in bytes of the buckets of the sizes of the messages.
+var sizeBuckets = []float64{100, 1e3, 1e4, 1e5, 1e6, 1e7}
+
 var (
 	requestCounter = metrics.NewCounter("iost_rpc_request", []string{"method"})
+	errorCounter   = metrics.NewCounter("iost_rpc_error", []string{"method", "code"})
+	// latencyHistogram is the time in seconds of the unary calls.
+	latencyHistogram = metrics.NewHistogram("iost_rpc_latency", []string{"method"},
+		[]float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5})
+	// requestSizeHistogram and responseSizeHistogram are the bytes of the messages received and sent, each message
+	// of the streams being observed.
+	requestSizeHistogram  = metrics.NewHistogram("iost_rpc_request_bytes", []string{"method"}, sizeBuckets)
+	responseSizeHistogram = metrics.NewHistogram("iost_rpc_response_bytes", []string{"method"}, sizeBuckets)
 )
 
-func metricsUnaryMiddleware(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	i := strings.LastIndex(info.FullMethod, "/")
-	ilog.Debugf("receive rpc request: %s, request: %v", info.FullMethod[i+1:], req)
-	requestCounter.Add(1, map[string]string{"method": info.FullMethod[i+1:]})
-	return handler(ctx, req)
+// maxSlowLogRequest is how many bytes of the request are logged with a slow call.
+const maxSlowLogRequest = 1024
+
+// rpcMetrics observes the calls of each method, and logs the unary calls slower than slowThreshold, if not 0.
+type rpcMetrics struct {
+	slowThreshold time.Duration
+}
+
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func messageSize(m interface{}) int {
+	if pm, ok := m.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
+
+// requestText returns the request as logged with a slow call, cut after maxSlowLogRequest bytes.
+func requestText(req interface{}) string {
+	var r string
+	if pm, ok := req.(proto.Message); ok {
+		r = proto.CompactTextString(pm)
+	} else {
+		r = fmt.Sprint(req)
+	}
+	if len(r) > maxSlowLogRequest {
+		r = r[:maxSlowLogRequest] + "..."
+	}
+	return r
+}
+
+func observeError(method string, err error) {
+	if err != nil {
+		errorCounter.Add(1, map[string]string{"method": method, "code": status.Code(err).String()})
+	}
+}
+
+func (m *rpcMetrics) unaryMiddleware(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := methodName(info.FullMethod)
+	ilog.Debugf("receive rpc request: %s, request: %v", method, req)
+	labels := map[string]string{"method": method}
+	requestCounter.Add(1, labels)
+	requestSizeHistogram.Observe(float64(messageSize(req)), labels)
+	start := time.Now()
+	res, err := handler(ctx, req)
+	d := time.Since(start)
+	latencyHistogram.Observe(d.Seconds(), labels)
+	observeError(method, err)
+	if err == nil {
+		responseSizeHistogram.Observe(float64(messageSize(res)), labels)
+	}
+	if m.slowThreshold > 0 && d >= m.slowThreshold {
+		ilog.Warnf("slow rpc %v took %v, err: %v, request: %v", method, d, err, requestText(req))
+	}
+	return res, err
+}
+
+func (m *rpcMetrics) streamMiddleware(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := methodName(info.FullMethod)
+	ilog.Debugf("receive rpc stream: %s", method)
+	labels := map[string]string{"method": method}
+	requestCounter.Add(1, labels)
+	err := handler(srv, &metricsStream{ServerStream: ss, labels: labels})
+	observeError(method, err)
+	return err
+}
+
+// metricsStream observes the sizes of the messages of a stream.
+type metricsStream struct {
+	grpc.ServerStream
+	labels map[string]string
+}
+
+func (s *metricsStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		responseSizeHistogram.Observe(float64(messageSize(m)), s.labels)
+	}
+	return err
 }
 
-func metricsStreamMiddleware(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	i := strings.LastIndex(info.FullMethod, "/")
-	ilog.Debugf("receive rpc stream: %s", info.FullMethod[i+1:])
-	requestCounter.Add(1, map[string]string{"method": info.FullMethod[i+1:]})
-	return handler(srv, ss)
+func (s *metricsStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		requestSizeHistogram.Observe(float64(messageSize(m)), s.labels)
+	}
+	return err
 }
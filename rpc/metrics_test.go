@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	sent []interface{}
+}
+
+func (s *testServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestRequestText(t *testing.T) {
+	assert.Equal(t, `number:5 complete:true `, requestText(&rpcpb.GetBlockByNumberRequest{Number: 5, Complete: true}))
+	long := requestText(&rpcpb.GetContractStorageRequest{Id: strings.Repeat("a", 2*maxSlowLogRequest)})
+	assert.Len(t, long, maxSlowLogRequest+3)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := &rpcMetrics{slowThreshold: time.Millisecond}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetChainInfo"}
+	res, err := m.unaryMiddleware(context.Background(), &rpcpb.EmptyRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(2 * time.Millisecond)
+		return &rpcpb.ChainInfoResponse{HeadBlock: 3}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.(*rpcpb.ChainInfoResponse).HeadBlock)
+
+	_, err = m.unaryMiddleware(context.Background(), &rpcpb.EmptyRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed")
+
+	s := &testServerStream{}
+	err = m.streamMiddleware(nil, s, &grpc.StreamServerInfo{FullMethod: "/rpcpb.ApiService/SubscribeBlocks"}, func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.SendMsg(&rpcpb.SubscribeBlocksResponse{})
+	})
+	assert.Nil(t, err)
+	assert.Len(t, s.sent, 1)
+}
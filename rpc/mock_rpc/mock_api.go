@@ -34,6 +34,19 @@ func (m *MockApiServiceServer) EXPECT() *MockApiServiceServerMockRecorder {
 	return m.recorder
 }
 
+// EstimateGas mocks base method
+func (m *MockApiServiceServer) EstimateGas(arg0 context.Context, arg1 *pb.TransactionRequest) (*pb.EstimateGasResponse, error) {
+	ret := m.ctrl.Call(m, "EstimateGas", arg0, arg1)
+	ret0, _ := ret[0].(*pb.EstimateGasResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas
+func (mr *MockApiServiceServerMockRecorder) EstimateGas(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockApiServiceServer)(nil).EstimateGas), arg0, arg1)
+}
+
 // ExecTransaction mocks base method
 func (m *MockApiServiceServer) ExecTransaction(arg0 context.Context, arg1 *pb.TransactionRequest) (*pb.TxReceipt, error) {
 	ret := m.ctrl.Call(m, "ExecTransaction", arg0, arg1)
@@ -60,6 +73,45 @@ func (mr *MockApiServiceServerMockRecorder) GetAccount(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockApiServiceServer)(nil).GetAccount), arg0, arg1)
 }
 
+// GetAccountTokens mocks base method
+func (m *MockApiServiceServer) GetAccountTokens(arg0 context.Context, arg1 *pb.GetAccountTokensRequest) (*pb.GetAccountTokensResponse, error) {
+	ret := m.ctrl.Call(m, "GetAccountTokens", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetAccountTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTokens indicates an expected call of GetAccountTokens
+func (mr *MockApiServiceServerMockRecorder) GetAccountTokens(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTokens", reflect.TypeOf((*MockApiServiceServer)(nil).GetAccountTokens), arg0, arg1)
+}
+
+// GetAccountTxs mocks base method
+func (m *MockApiServiceServer) GetAccountTxs(arg0 context.Context, arg1 *pb.GetAccountTxsRequest) (*pb.GetAccountTxsResponse, error) {
+	ret := m.ctrl.Call(m, "GetAccountTxs", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetAccountTxsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTxs indicates an expected call of GetAccountTxs
+func (mr *MockApiServiceServerMockRecorder) GetAccountTxs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTxs", reflect.TypeOf((*MockApiServiceServer)(nil).GetAccountTxs), arg0, arg1)
+}
+
+// GetBatchContractStorage mocks base method
+func (m *MockApiServiceServer) GetBatchContractStorage(arg0 context.Context, arg1 *pb.GetBatchContractStorageRequest) (*pb.GetBatchContractStorageResponse, error) {
+	ret := m.ctrl.Call(m, "GetBatchContractStorage", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetBatchContractStorageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchContractStorage indicates an expected call of GetBatchContractStorage
+func (mr *MockApiServiceServerMockRecorder) GetBatchContractStorage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchContractStorage", reflect.TypeOf((*MockApiServiceServer)(nil).GetBatchContractStorage), arg0, arg1)
+}
+
 // GetBlockByHash mocks base method
 func (m *MockApiServiceServer) GetBlockByHash(arg0 context.Context, arg1 *pb.GetBlockByHashRequest) (*pb.BlockResponse, error) {
 	ret := m.ctrl.Call(m, "GetBlockByHash", arg0, arg1)
@@ -86,6 +138,58 @@ func (mr *MockApiServiceServerMockRecorder) GetBlockByNumber(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockByNumber), arg0, arg1)
 }
 
+// GetBlockHeaderByHash mocks base method
+func (m *MockApiServiceServer) GetBlockHeaderByHash(arg0 context.Context, arg1 *pb.GetBlockHeaderByHashRequest) (*pb.BlockHeaderResponse, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeaderByHash", arg0, arg1)
+	ret0, _ := ret[0].(*pb.BlockHeaderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeaderByHash indicates an expected call of GetBlockHeaderByHash
+func (mr *MockApiServiceServerMockRecorder) GetBlockHeaderByHash(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeaderByHash", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockHeaderByHash), arg0, arg1)
+}
+
+// GetBlockHeaderByNumber mocks base method
+func (m *MockApiServiceServer) GetBlockHeaderByNumber(arg0 context.Context, arg1 *pb.GetBlockHeaderByNumberRequest) (*pb.BlockHeaderResponse, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeaderByNumber", arg0, arg1)
+	ret0, _ := ret[0].(*pb.BlockHeaderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeaderByNumber indicates an expected call of GetBlockHeaderByNumber
+func (mr *MockApiServiceServerMockRecorder) GetBlockHeaderByNumber(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeaderByNumber", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockHeaderByNumber), arg0, arg1)
+}
+
+// GetBlockStateChanges mocks base method
+func (m *MockApiServiceServer) GetBlockStateChanges(arg0 context.Context, arg1 *pb.GetBlockStateChangesRequest) (*pb.GetBlockStateChangesResponse, error) {
+	ret := m.ctrl.Call(m, "GetBlockStateChanges", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetBlockStateChangesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockStateChanges indicates an expected call of GetBlockStateChanges
+func (mr *MockApiServiceServerMockRecorder) GetBlockStateChanges(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockStateChanges", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockStateChanges), arg0, arg1)
+}
+
+// GetBlocks mocks base method
+func (m *MockApiServiceServer) GetBlocks(arg0 context.Context, arg1 *pb.GetBlocksRequest) (*pb.GetBlocksResponse, error) {
+	ret := m.ctrl.Call(m, "GetBlocks", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetBlocksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks
+func (mr *MockApiServiceServerMockRecorder) GetBlocks(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlocks), arg0, arg1)
+}
+
 // GetChainInfo mocks base method
 func (m *MockApiServiceServer) GetChainInfo(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.ChainInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetChainInfo", arg0, arg1)
@@ -138,6 +242,19 @@ func (mr *MockApiServiceServerMockRecorder) GetContractStorageFields(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractStorageFields", reflect.TypeOf((*MockApiServiceServer)(nil).GetContractStorageFields), arg0, arg1)
 }
 
+// GetDelaytxsByAccount mocks base method
+func (m *MockApiServiceServer) GetDelaytxsByAccount(arg0 context.Context, arg1 *pb.GetDelaytxsByAccountRequest) (*pb.GetDelaytxsByAccountResponse, error) {
+	ret := m.ctrl.Call(m, "GetDelaytxsByAccount", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetDelaytxsByAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelaytxsByAccount indicates an expected call of GetDelaytxsByAccount
+func (mr *MockApiServiceServerMockRecorder) GetDelaytxsByAccount(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelaytxsByAccount", reflect.TypeOf((*MockApiServiceServer)(nil).GetDelaytxsByAccount), arg0, arg1)
+}
+
 // GetGasRatio mocks base method
 func (m *MockApiServiceServer) GetGasRatio(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.GasRatioResponse, error) {
 	ret := m.ctrl.Call(m, "GetGasRatio", arg0, arg1)
@@ -164,6 +281,45 @@ func (mr *MockApiServiceServerMockRecorder) GetNodeInfo(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetNodeInfo), arg0, arg1)
 }
 
+// GetNodeStatus mocks base method
+func (m *MockApiServiceServer) GetNodeStatus(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.NodeStatusResponse, error) {
+	ret := m.ctrl.Call(m, "GetNodeStatus", arg0, arg1)
+	ret0, _ := ret[0].(*pb.NodeStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeStatus indicates an expected call of GetNodeStatus
+func (mr *MockApiServiceServerMockRecorder) GetNodeStatus(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeStatus", reflect.TypeOf((*MockApiServiceServer)(nil).GetNodeStatus), arg0, arg1)
+}
+
+// GetPendingTransactions mocks base method
+func (m *MockApiServiceServer) GetPendingTransactions(arg0 context.Context, arg1 *pb.GetPendingTransactionsRequest) (*pb.GetPendingTransactionsResponse, error) {
+	ret := m.ctrl.Call(m, "GetPendingTransactions", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetPendingTransactionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingTransactions indicates an expected call of GetPendingTransactions
+func (mr *MockApiServiceServerMockRecorder) GetPendingTransactions(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTransactions", reflect.TypeOf((*MockApiServiceServer)(nil).GetPendingTransactions), arg0, arg1)
+}
+
+// GetPendingTxByHash mocks base method
+func (m *MockApiServiceServer) GetPendingTxByHash(arg0 context.Context, arg1 *pb.TxHashRequest) (*pb.TransactionResponse, error) {
+	ret := m.ctrl.Call(m, "GetPendingTxByHash", arg0, arg1)
+	ret0, _ := ret[0].(*pb.TransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingTxByHash indicates an expected call of GetPendingTxByHash
+func (mr *MockApiServiceServerMockRecorder) GetPendingTxByHash(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTxByHash", reflect.TypeOf((*MockApiServiceServer)(nil).GetPendingTxByHash), arg0, arg1)
+}
+
 // GetProducerVoteInfo mocks base method
 func (m *MockApiServiceServer) GetProducerVoteInfo(arg0 context.Context, arg1 *pb.GetProducerVoteInfoRequest) (*pb.GetProducerVoteInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetProducerVoteInfo", arg0, arg1)
@@ -177,6 +333,19 @@ func (mr *MockApiServiceServerMockRecorder) GetProducerVoteInfo(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducerVoteInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetProducerVoteInfo), arg0, arg1)
 }
 
+// GetProducers mocks base method
+func (m *MockApiServiceServer) GetProducers(arg0 context.Context, arg1 *pb.GetProducersRequest) (*pb.GetProducersResponse, error) {
+	ret := m.ctrl.Call(m, "GetProducers", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetProducersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProducers indicates an expected call of GetProducers
+func (mr *MockApiServiceServerMockRecorder) GetProducers(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducers", reflect.TypeOf((*MockApiServiceServer)(nil).GetProducers), arg0, arg1)
+}
+
 // GetRAMInfo mocks base method
 func (m *MockApiServiceServer) GetRAMInfo(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.RAMInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetRAMInfo", arg0, arg1)
@@ -191,7 +360,7 @@ func (mr *MockApiServiceServerMockRecorder) GetRAMInfo(arg0, arg1 interface{}) *
 }
 
 // GetToken721Balance mocks base method
-func (m *MockApiServiceServer) GetToken721Balance(arg0 context.Context, arg1 *pb.GetTokenBalanceRequest) (*pb.GetToken721BalanceResponse, error) {
+func (m *MockApiServiceServer) GetToken721Balance(arg0 context.Context, arg1 *pb.GetToken721BalanceRequest) (*pb.GetToken721BalanceResponse, error) {
 	ret := m.ctrl.Call(m, "GetToken721Balance", arg0, arg1)
 	ret0, _ := ret[0].(*pb.GetToken721BalanceResponse)
 	ret1, _ := ret[1].(error)
@@ -268,6 +437,45 @@ func (mr *MockApiServiceServerMockRecorder) GetTxReceiptByTxHash(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxReceiptByTxHash", reflect.TypeOf((*MockApiServiceServer)(nil).GetTxReceiptByTxHash), arg0, arg1)
 }
 
+// GetTxsByAccount mocks base method
+func (m *MockApiServiceServer) GetTxsByAccount(arg0 context.Context, arg1 *pb.GetTxsByAccountRequest) (*pb.GetTxsByAccountResponse, error) {
+	ret := m.ctrl.Call(m, "GetTxsByAccount", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetTxsByAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxsByAccount indicates an expected call of GetTxsByAccount
+func (mr *MockApiServiceServerMockRecorder) GetTxsByAccount(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxsByAccount", reflect.TypeOf((*MockApiServiceServer)(nil).GetTxsByAccount), arg0, arg1)
+}
+
+// GetVoters mocks base method
+func (m *MockApiServiceServer) GetVoters(arg0 context.Context, arg1 *pb.GetVotersRequest) (*pb.GetVotersResponse, error) {
+	ret := m.ctrl.Call(m, "GetVoters", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetVotersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoters indicates an expected call of GetVoters
+func (mr *MockApiServiceServerMockRecorder) GetVoters(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoters", reflect.TypeOf((*MockApiServiceServer)(nil).GetVoters), arg0, arg1)
+}
+
+// GetWitnessSchedule mocks base method
+func (m *MockApiServiceServer) GetWitnessSchedule(arg0 context.Context, arg1 *pb.GetWitnessScheduleRequest) (*pb.GetWitnessScheduleResponse, error) {
+	ret := m.ctrl.Call(m, "GetWitnessSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetWitnessScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWitnessSchedule indicates an expected call of GetWitnessSchedule
+func (mr *MockApiServiceServerMockRecorder) GetWitnessSchedule(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWitnessSchedule", reflect.TypeOf((*MockApiServiceServer)(nil).GetWitnessSchedule), arg0, arg1)
+}
+
 // SendTransaction mocks base method
 func (m *MockApiServiceServer) SendTransaction(arg0 context.Context, arg1 *pb.TransactionRequest) (*pb.SendTransactionResponse, error) {
 	ret := m.ctrl.Call(m, "SendTransaction", arg0, arg1)
@@ -292,3 +500,40 @@ func (m *MockApiServiceServer) Subscribe(arg0 *pb.SubscribeRequest, arg1 pb.ApiS
 func (mr *MockApiServiceServerMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockApiServiceServer)(nil).Subscribe), arg0, arg1)
 }
+
+// SubscribeBlocks mocks base method
+func (m *MockApiServiceServer) SubscribeBlocks(arg0 *pb.SubscribeBlocksRequest, arg1 pb.ApiService_SubscribeBlocksServer) error {
+	ret := m.ctrl.Call(m, "SubscribeBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeBlocks indicates an expected call of SubscribeBlocks
+func (mr *MockApiServiceServerMockRecorder) SubscribeBlocks(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlocks", reflect.TypeOf((*MockApiServiceServer)(nil).SubscribeBlocks), arg0, arg1)
+}
+
+// SubscribeChainStatus mocks base method
+func (m *MockApiServiceServer) SubscribeChainStatus(arg0 *pb.EmptyRequest, arg1 pb.ApiService_SubscribeChainStatusServer) error {
+	ret := m.ctrl.Call(m, "SubscribeChainStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeChainStatus indicates an expected call of SubscribeChainStatus
+func (mr *MockApiServiceServerMockRecorder) SubscribeChainStatus(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChainStatus", reflect.TypeOf((*MockApiServiceServer)(nil).SubscribeChainStatus), arg0, arg1)
+}
+
+// TraceTransaction mocks base method
+func (m *MockApiServiceServer) TraceTransaction(arg0 context.Context, arg1 *pb.TxHashRequest) (*pb.TraceTransactionResponse, error) {
+	ret := m.ctrl.Call(m, "TraceTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*pb.TraceTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceTransaction indicates an expected call of TraceTransaction
+func (mr *MockApiServiceServerMockRecorder) TraceTransaction(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceTransaction", reflect.TypeOf((*MockApiServiceServer)(nil).TraceTransaction), arg0, arg1)
+}
@@ -0,0 +1,700 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rpc/pb/admin.proto
+
+package rpcpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AdminEmptyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdminEmptyRequest) Reset()         { *m = AdminEmptyRequest{} }
+func (m *AdminEmptyRequest) String() string { return proto.CompactTextString(m) }
+func (*AdminEmptyRequest) ProtoMessage()    {}
+func (*AdminEmptyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{0}
+}
+
+func (m *AdminEmptyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdminEmptyRequest.Unmarshal(m, b)
+}
+func (m *AdminEmptyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdminEmptyRequest.Marshal(b, m, deterministic)
+}
+func (m *AdminEmptyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminEmptyRequest.Merge(m, src)
+}
+func (m *AdminEmptyRequest) XXX_Size() int {
+	return xxx_messageInfo_AdminEmptyRequest.Size(m)
+}
+func (m *AdminEmptyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminEmptyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminEmptyRequest proto.InternalMessageInfo
+
+type AdminResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdminResponse) Reset()         { *m = AdminResponse{} }
+func (m *AdminResponse) String() string { return proto.CompactTextString(m) }
+func (*AdminResponse) ProtoMessage()    {}
+func (*AdminResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{1}
+}
+
+func (m *AdminResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdminResponse.Unmarshal(m, b)
+}
+func (m *AdminResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdminResponse.Marshal(b, m, deterministic)
+}
+func (m *AdminResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminResponse.Merge(m, src)
+}
+func (m *AdminResponse) XXX_Size() int {
+	return xxx_messageInfo_AdminResponse.Size(m)
+}
+func (m *AdminResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminResponse proto.InternalMessageInfo
+
+type AddPeerRequest struct {
+	// the multiaddr of the peer, like /ip4/127.0.0.1/tcp/30000/ipfs/<peer id>
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPeerRequest) Reset()         { *m = AddPeerRequest{} }
+func (m *AddPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*AddPeerRequest) ProtoMessage()    {}
+func (*AddPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{2}
+}
+
+func (m *AddPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPeerRequest.Unmarshal(m, b)
+}
+func (m *AddPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *AddPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerRequest.Merge(m, src)
+}
+func (m *AddPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_AddPeerRequest.Size(m)
+}
+func (m *AddPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPeerRequest proto.InternalMessageInfo
+
+func (m *AddPeerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type PeerRequest struct {
+	// the id of the peer
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerRequest) Reset()         { *m = PeerRequest{} }
+func (m *PeerRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerRequest) ProtoMessage()    {}
+func (*PeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{3}
+}
+
+func (m *PeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerRequest.Unmarshal(m, b)
+}
+func (m *PeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRequest.Merge(m, src)
+}
+func (m *PeerRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerRequest.Size(m)
+}
+func (m *PeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerRequest proto.InternalMessageInfo
+
+func (m *PeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type BanPeerRequest struct {
+	// the id of the peer, whose ips are banned too
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the ip to ban, if no id
+	Ip                   string   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanPeerRequest) Reset()         { *m = BanPeerRequest{} }
+func (m *BanPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*BanPeerRequest) ProtoMessage()    {}
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{4}
+}
+
+func (m *BanPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanPeerRequest.Unmarshal(m, b)
+}
+func (m *BanPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *BanPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanPeerRequest.Merge(m, src)
+}
+func (m *BanPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_BanPeerRequest.Size(m)
+}
+func (m *BanPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanPeerRequest proto.InternalMessageInfo
+
+func (m *BanPeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BanPeerRequest) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+type FlushTxPoolResponse struct {
+	// how many pending transactions were deleted
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlushTxPoolResponse) Reset()         { *m = FlushTxPoolResponse{} }
+func (m *FlushTxPoolResponse) String() string { return proto.CompactTextString(m) }
+func (*FlushTxPoolResponse) ProtoMessage()    {}
+func (*FlushTxPoolResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{5}
+}
+
+func (m *FlushTxPoolResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlushTxPoolResponse.Unmarshal(m, b)
+}
+func (m *FlushTxPoolResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlushTxPoolResponse.Marshal(b, m, deterministic)
+}
+func (m *FlushTxPoolResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushTxPoolResponse.Merge(m, src)
+}
+func (m *FlushTxPoolResponse) XXX_Size() int {
+	return xxx_messageInfo_FlushTxPoolResponse.Size(m)
+}
+func (m *FlushTxPoolResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushTxPoolResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlushTxPoolResponse proto.InternalMessageInfo
+
+func (m *FlushTxPoolResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type TriggerSnapshotResponse struct {
+	// the path of the snapshot file
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerSnapshotResponse) Reset()         { *m = TriggerSnapshotResponse{} }
+func (m *TriggerSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*TriggerSnapshotResponse) ProtoMessage()    {}
+func (*TriggerSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{6}
+}
+
+func (m *TriggerSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSnapshotResponse.Unmarshal(m, b)
+}
+func (m *TriggerSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *TriggerSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSnapshotResponse.Merge(m, src)
+}
+func (m *TriggerSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_TriggerSnapshotResponse.Size(m)
+}
+func (m *TriggerSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSnapshotResponse proto.InternalMessageInfo
+
+func (m *TriggerSnapshotResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type SetDebugRequest struct {
+	// whether the debug http server runs
+	Enable               bool     `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDebugRequest) Reset()         { *m = SetDebugRequest{} }
+func (m *SetDebugRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDebugRequest) ProtoMessage()    {}
+func (*SetDebugRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{7}
+}
+
+func (m *SetDebugRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDebugRequest.Unmarshal(m, b)
+}
+func (m *SetDebugRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDebugRequest.Marshal(b, m, deterministic)
+}
+func (m *SetDebugRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDebugRequest.Merge(m, src)
+}
+func (m *SetDebugRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDebugRequest.Size(m)
+}
+func (m *SetDebugRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDebugRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDebugRequest proto.InternalMessageInfo
+
+func (m *SetDebugRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+type SetDebugResponse struct {
+	// whether the debug http server runs after the call
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDebugResponse) Reset()         { *m = SetDebugResponse{} }
+func (m *SetDebugResponse) String() string { return proto.CompactTextString(m) }
+func (*SetDebugResponse) ProtoMessage()    {}
+func (*SetDebugResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf6f9d2f3d6468af, []int{8}
+}
+
+func (m *SetDebugResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDebugResponse.Unmarshal(m, b)
+}
+func (m *SetDebugResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDebugResponse.Marshal(b, m, deterministic)
+}
+func (m *SetDebugResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDebugResponse.Merge(m, src)
+}
+func (m *SetDebugResponse) XXX_Size() int {
+	return xxx_messageInfo_SetDebugResponse.Size(m)
+}
+func (m *SetDebugResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDebugResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDebugResponse proto.InternalMessageInfo
+
+func (m *SetDebugResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*AdminEmptyRequest)(nil), "rpcpb.AdminEmptyRequest")
+	proto.RegisterType((*AdminResponse)(nil), "rpcpb.AdminResponse")
+	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
+	proto.RegisterType((*PeerRequest)(nil), "rpcpb.PeerRequest")
+	proto.RegisterType((*BanPeerRequest)(nil), "rpcpb.BanPeerRequest")
+	proto.RegisterType((*FlushTxPoolResponse)(nil), "rpcpb.FlushTxPoolResponse")
+	proto.RegisterType((*TriggerSnapshotResponse)(nil), "rpcpb.TriggerSnapshotResponse")
+	proto.RegisterType((*SetDebugRequest)(nil), "rpcpb.SetDebugRequest")
+	proto.RegisterType((*SetDebugResponse)(nil), "rpcpb.SetDebugResponse")
+}
+
+func init() { proto.RegisterFile("rpc/pb/admin.proto", fileDescriptor_bf6f9d2f3d6468af) }
+
+var fileDescriptor_bf6f9d2f3d6468af = []byte{
+	// 373 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcf, 0x6e, 0xe2, 0x30,
+	0x10, 0xc6, 0x05, 0x2c, 0x84, 0x1d, 0x76, 0x61, 0xd7, 0xb0, 0x10, 0x45, 0xda, 0xd5, 0xca, 0xa7,
+	0xfd, 0xd3, 0x42, 0xd5, 0x4a, 0x5c, 0xda, 0x0b, 0xfd, 0x77, 0x69, 0x0f, 0x28, 0xf0, 0x02, 0x49,
+	0x3c, 0x0a, 0x91, 0x20, 0x76, 0x6d, 0x07, 0xb5, 0xcf, 0xd7, 0x17, 0xab, 0x6a, 0x1c, 0x1a, 0x28,
+	0xa0, 0xde, 0xf2, 0xcd, 0xfc, 0xbe, 0xb1, 0xf3, 0x4d, 0x02, 0x44, 0x8a, 0x68, 0x20, 0xc2, 0x41,
+	0xc0, 0x16, 0x49, 0xda, 0x17, 0x92, 0x6b, 0x4e, 0xaa, 0x52, 0x44, 0x22, 0xa4, 0x6d, 0xf8, 0x3e,
+	0x7a, 0xad, 0xde, 0x2c, 0x84, 0x7e, 0xf2, 0xf1, 0x21, 0x43, 0xa5, 0x69, 0x0b, 0xbe, 0x9a, 0xa2,
+	0x8f, 0x4a, 0xf0, 0x54, 0x21, 0xfd, 0x07, 0xcd, 0x11, 0x63, 0x63, 0x44, 0x69, 0x11, 0xe2, 0x82,
+	0x13, 0x30, 0x26, 0x51, 0x29, 0xb7, 0xf4, 0xbb, 0xf4, 0xe7, 0xb3, 0x9f, 0x4b, 0xfa, 0x13, 0x1a,
+	0x45, 0xb0, 0x09, 0xe5, 0x84, 0x59, 0xa6, 0x9c, 0x30, 0x7a, 0x02, 0xcd, 0xcb, 0x20, 0x3d, 0x40,
+	0x18, 0x2d, 0xdc, 0xb2, 0xd5, 0x82, 0xfe, 0x87, 0xf6, 0xed, 0x3c, 0x53, 0xb3, 0xe9, 0xe3, 0x98,
+	0xf3, 0x79, 0x7e, 0x27, 0xd2, 0x81, 0x6a, 0xc4, 0xb3, 0x54, 0x1b, 0x67, 0xc5, 0x5f, 0x09, 0x7a,
+	0x0c, 0xbd, 0xa9, 0x4c, 0xe2, 0x18, 0xe5, 0x24, 0x0d, 0x84, 0x9a, 0x71, 0xbd, 0x36, 0x10, 0xf8,
+	0x24, 0x02, 0x3d, 0xb3, 0x27, 0x99, 0x67, 0xfa, 0x17, 0x5a, 0x13, 0xd4, 0xd7, 0x18, 0x66, 0x71,
+	0x7e, 0x9d, 0x2e, 0xd4, 0x30, 0x0d, 0xc2, 0x39, 0x1a, 0xb0, 0xee, 0x5b, 0x45, 0x8f, 0xe0, 0xdb,
+	0x1b, 0x6a, 0x47, 0xba, 0xe0, 0xac, 0xba, 0xcc, 0xc2, 0xb9, 0x3c, 0x7d, 0xae, 0xc0, 0x17, 0x93,
+	0xe1, 0x04, 0xe5, 0x32, 0x89, 0x90, 0x0c, 0xc1, 0xb1, 0x11, 0x92, 0x1f, 0x7d, 0x93, 0x7d, 0x7f,
+	0x33, 0x52, 0xaf, 0xb3, 0x2e, 0x17, 0xa2, 0x27, 0x43, 0x00, 0x1f, 0x17, 0x7c, 0x89, 0xc6, 0x4a,
+	0x2c, 0xf3, 0x11, 0x9f, 0x63, 0x73, 0x5e, 0x9f, 0xb7, 0x99, 0xfb, 0x1e, 0xdf, 0x15, 0x34, 0x0a,
+	0x69, 0x13, 0xb7, 0x08, 0x15, 0x3f, 0x12, 0xcf, 0xb3, 0x9d, 0x5d, 0xbb, 0xb9, 0x83, 0xd6, 0xd6,
+	0x16, 0x0e, 0x0c, 0xfa, 0x65, 0x3b, 0xfb, 0xf6, 0x76, 0x01, 0xe0, 0x73, 0x1d, 0x68, 0xbc, 0xe7,
+	0xb1, 0x3a, 0x30, 0x67, 0xf7, 0xfb, 0x9c, 0x43, 0x3d, 0x5f, 0x1b, 0xe9, 0x5a, 0x62, 0x6b, 0xe5,
+	0x5e, 0xef, 0x5d, 0x7d, 0x65, 0x0e, 0x6b, 0xe6, 0x5f, 0x39, 0x7b, 0x19, 0x00, 0x58, 0xd8, 0xec,
+	0x21, 0x41, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// connect to a peer given by its multiaddr
+	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	// close the connection with a peer, which may connect again
+	RemovePeer(ctx context.Context, in *PeerRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	// put a peer, or an ip, in the black list, closing the connections with it
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	// delete all the pending transactions of the tx pool
+	FlushTxPool(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*FlushTxPoolResponse, error)
+	// save the state db to a snapshot file
+	TriggerSnapshot(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*TriggerSnapshotResponse, error)
+	// make the log files be written to new files
+	RotateLogs(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	// start or stop the debug http server
+	SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/AddPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemovePeer(ctx context.Context, in *PeerRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RemovePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/BanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) FlushTxPool(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*FlushTxPoolResponse, error) {
+	out := new(FlushTxPoolResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/FlushTxPool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TriggerSnapshot(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*TriggerSnapshotResponse, error) {
+	out := new(TriggerSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/TriggerSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RotateLogs(ctx context.Context, in *AdminEmptyRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RotateLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugResponse, error) {
+	out := new(SetDebugResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/SetDebug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// connect to a peer given by its multiaddr
+	AddPeer(context.Context, *AddPeerRequest) (*AdminResponse, error)
+	// close the connection with a peer, which may connect again
+	RemovePeer(context.Context, *PeerRequest) (*AdminResponse, error)
+	// put a peer, or an ip, in the black list, closing the connections with it
+	BanPeer(context.Context, *BanPeerRequest) (*AdminResponse, error)
+	// delete all the pending transactions of the tx pool
+	FlushTxPool(context.Context, *AdminEmptyRequest) (*FlushTxPoolResponse, error)
+	// save the state db to a snapshot file
+	TriggerSnapshot(context.Context, *AdminEmptyRequest) (*TriggerSnapshotResponse, error)
+	// make the log files be written to new files
+	RotateLogs(context.Context, *AdminEmptyRequest) (*AdminResponse, error)
+	// start or stop the debug http server
+	SetDebug(context.Context, *SetDebugRequest) (*SetDebugResponse, error)
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPeer(ctx, req.(*AddPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemovePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemovePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RemovePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemovePeer(ctx, req.(*PeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/BanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_FlushTxPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).FlushTxPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/FlushTxPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).FlushTxPool(ctx, req.(*AdminEmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TriggerSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TriggerSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/TriggerSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TriggerSnapshot(ctx, req.(*AdminEmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RotateLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RotateLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RotateLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RotateLogs(ctx, req.(*AdminEmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetDebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetDebug(ctx, req.(*SetDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddPeer",
+			Handler:    _AdminService_AddPeer_Handler,
+		},
+		{
+			MethodName: "RemovePeer",
+			Handler:    _AdminService_RemovePeer_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _AdminService_BanPeer_Handler,
+		},
+		{
+			MethodName: "FlushTxPool",
+			Handler:    _AdminService_FlushTxPool_Handler,
+		},
+		{
+			MethodName: "TriggerSnapshot",
+			Handler:    _AdminService_TriggerSnapshot_Handler,
+		},
+		{
+			MethodName: "RotateLogs",
+			Handler:    _AdminService_RotateLogs_Handler,
+		},
+		{
+			MethodName: "SetDebug",
+			Handler:    _AdminService_SetDebug_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/pb/admin.proto",
+}
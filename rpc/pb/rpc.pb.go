@@ -76,7 +76,7 @@ func (x TxReceipt_StatusCode) String() string {
 }
 
 func (TxReceipt_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{6, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{7, 0}
 }
 
 // The enumeration defines transaction status.
@@ -108,7 +108,7 @@ func (x TransactionResponse_Status) String() string {
 }
 
 func (TransactionResponse_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{8, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{9, 0}
 }
 
 // The enumeration defines the signature algorithm.
@@ -140,7 +140,39 @@ func (x Signature_Algorithm) String() string {
 }
 
 func (Signature_Algorithm) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{9, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{18, 0}
+}
+
+// The enumeration defines the operations of the storage.
+type StorageTrace_Op int32
+
+const (
+	// read
+	StorageTrace_READ StorageTrace_Op = 0
+	// write
+	StorageTrace_WRITE StorageTrace_Op = 1
+	// delete
+	StorageTrace_DELETE StorageTrace_Op = 2
+)
+
+var StorageTrace_Op_name = map[int32]string{
+	0: "READ",
+	1: "WRITE",
+	2: "DELETE",
+}
+
+var StorageTrace_Op_value = map[string]int32{
+	"READ":   0,
+	"WRITE":  1,
+	"DELETE": 2,
+}
+
+func (x StorageTrace_Op) String() string {
+	return proto.EnumName(StorageTrace_Op_name, int32(x))
+}
+
+func (StorageTrace_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{24, 0}
 }
 
 // The enumeration defines block status.
@@ -168,7 +200,47 @@ func (x BlockResponse_Status) String() string {
 }
 
 func (BlockResponse_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{12, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{26, 0}
+}
+
+// The enumeration defines what the state key is.
+type StateChange_Kind int32
+
+const (
+	// contract storage
+	StateChange_STORAGE StateChange_Kind = 0
+	// token balance or frozen balance of an account
+	StateChange_TOKEN_BALANCE StateChange_Kind = 1
+	// storage of ram.iost
+	StateChange_RAM StateChange_Kind = 2
+	// storage of gas.iost
+	StateChange_GAS StateChange_Kind = 3
+	// contract code
+	StateChange_CONTRACT StateChange_Kind = 4
+)
+
+var StateChange_Kind_name = map[int32]string{
+	0: "STORAGE",
+	1: "TOKEN_BALANCE",
+	2: "RAM",
+	3: "GAS",
+	4: "CONTRACT",
+}
+
+var StateChange_Kind_value = map[string]int32{
+	"STORAGE":       0,
+	"TOKEN_BALANCE": 1,
+	"RAM":           2,
+	"GAS":           3,
+	"CONTRACT":      4,
+}
+
+func (x StateChange_Kind) String() string {
+	return proto.EnumName(StateChange_Kind_name, int32(x))
+}
+
+func (StateChange_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{39, 0}
 }
 
 type Event_Topic int32
@@ -178,16 +250,20 @@ const (
 	Event_CONTRACT_RECEIPT Event_Topic = 0
 	// contract event
 	Event_CONTRACT_EVENT Event_Topic = 1
+	// hash of a transaction added to the transaction pool
+	Event_TRANSACTION_PENDING Event_Topic = 2
 )
 
 var Event_Topic_name = map[int32]string{
 	0: "CONTRACT_RECEIPT",
 	1: "CONTRACT_EVENT",
+	2: "TRANSACTION_PENDING",
 }
 
 var Event_Topic_value = map[string]int32{
-	"CONTRACT_RECEIPT": 0,
-	"CONTRACT_EVENT":   1,
+	"CONTRACT_RECEIPT":    0,
+	"CONTRACT_EVENT":      1,
+	"TRANSACTION_PENDING": 2,
 }
 
 func (x Event_Topic) String() string {
@@ -195,7 +271,7 @@ func (x Event_Topic) String() string {
 }
 
 func (Event_Topic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{37, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{72, 0}
 }
 
 // The message defines an empty request.
@@ -425,6 +501,164 @@ func (m *NodeInfoResponse) GetNetwork() *NetworkInfo {
 	return nil
 }
 
+// The message contains the health and the sync status of the node.
+type NodeStatusResponse struct {
+	// node mode, ModeNormal once synced
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// number of the head block
+	HeadBlock int64 `protobuf:"varint,2,opt,name=head_block,json=headBlock,proto3" json:"head_block,omitempty"`
+	// time of the head block, in nanoseconds
+	HeadBlockTime int64 `protobuf:"varint,3,opt,name=head_block_time,json=headBlockTime,proto3" json:"head_block_time,omitempty"`
+	// estimated count of the blocks produced by the chain since the head block, from its time
+	BlocksBehind int64 `protobuf:"varint,4,opt,name=blocks_behind,json=blocksBehind,proto3" json:"blocks_behind,omitempty"`
+	// number of the last irreversible block
+	LibBlock int64 `protobuf:"varint,5,opt,name=lib_block,json=libBlock,proto3" json:"lib_block,omitempty"`
+	// peer connection count
+	PeerCount int32 `protobuf:"varint,6,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
+	// count of the transactions in the pending pool
+	PendingTxCount int32 `protobuf:"varint,7,opt,name=pending_tx_count,json=pendingTxCount,proto3" json:"pending_tx_count,omitempty"`
+	// whether the block and the state databases can be read
+	DbOk bool `protobuf:"varint,8,opt,name=db_ok,json=dbOk,proto3" json:"db_ok,omitempty"`
+	// error reading the databases if not db_ok
+	DbError string `protobuf:"bytes,9,opt,name=db_error,json=dbError,proto3" json:"db_error,omitempty"`
+	// witness pubkey of the node, empty if it has no account configured
+	Witness string `protobuf:"bytes,10,opt,name=witness,proto3" json:"witness,omitempty"`
+	// whether the witness is an active producer
+	IsProducer bool `protobuf:"varint,11,opt,name=is_producer,json=isProducer,proto3" json:"is_producer,omitempty"`
+	// number of the last reversible block produced by the witness, 0 if none
+	LastProducedBlock int64 `protobuf:"varint,12,opt,name=last_produced_block,json=lastProducedBlock,proto3" json:"last_produced_block,omitempty"`
+	// time of the last produced block, in nanoseconds
+	LastProducedBlockTime int64 `protobuf:"varint,13,opt,name=last_produced_block_time,json=lastProducedBlockTime,proto3" json:"last_produced_block_time,omitempty"`
+	// whether the node is synced and healthy, so ready to serve
+	Ready                bool     `protobuf:"varint,14,opt,name=ready,proto3" json:"ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeStatusResponse) Reset()         { *m = NodeStatusResponse{} }
+func (m *NodeStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeStatusResponse) ProtoMessage()    {}
+func (*NodeStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{4}
+}
+
+func (m *NodeStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeStatusResponse.Unmarshal(m, b)
+}
+func (m *NodeStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *NodeStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStatusResponse.Merge(m, src)
+}
+func (m *NodeStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeStatusResponse.Size(m)
+}
+func (m *NodeStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeStatusResponse proto.InternalMessageInfo
+
+func (m *NodeStatusResponse) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *NodeStatusResponse) GetHeadBlock() int64 {
+	if m != nil {
+		return m.HeadBlock
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetHeadBlockTime() int64 {
+	if m != nil {
+		return m.HeadBlockTime
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetBlocksBehind() int64 {
+	if m != nil {
+		return m.BlocksBehind
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetLibBlock() int64 {
+	if m != nil {
+		return m.LibBlock
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetPeerCount() int32 {
+	if m != nil {
+		return m.PeerCount
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetPendingTxCount() int32 {
+	if m != nil {
+		return m.PendingTxCount
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetDbOk() bool {
+	if m != nil {
+		return m.DbOk
+	}
+	return false
+}
+
+func (m *NodeStatusResponse) GetDbError() string {
+	if m != nil {
+		return m.DbError
+	}
+	return ""
+}
+
+func (m *NodeStatusResponse) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+func (m *NodeStatusResponse) GetIsProducer() bool {
+	if m != nil {
+		return m.IsProducer
+	}
+	return false
+}
+
+func (m *NodeStatusResponse) GetLastProducedBlock() int64 {
+	if m != nil {
+		return m.LastProducedBlock
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetLastProducedBlockTime() int64 {
+	if m != nil {
+		return m.LastProducedBlockTime
+	}
+	return 0
+}
+
+func (m *NodeStatusResponse) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
 // The message defines transaction amount limit struct.
 type AmountLimit struct {
 	// token name
@@ -440,7 +674,7 @@ func (m *AmountLimit) Reset()         { *m = AmountLimit{} }
 func (m *AmountLimit) String() string { return proto.CompactTextString(m) }
 func (*AmountLimit) ProtoMessage()    {}
 func (*AmountLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{4}
+	return fileDescriptor_1b773bf3e696f610, []int{5}
 }
 
 func (m *AmountLimit) XXX_Unmarshal(b []byte) error {
@@ -492,7 +726,7 @@ func (m *Action) Reset()         { *m = Action{} }
 func (m *Action) String() string { return proto.CompactTextString(m) }
 func (*Action) ProtoMessage()    {}
 func (*Action) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{5}
+	return fileDescriptor_1b773bf3e696f610, []int{6}
 }
 
 func (m *Action) XXX_Unmarshal(b []byte) error {
@@ -559,7 +793,7 @@ func (m *TxReceipt) Reset()         { *m = TxReceipt{} }
 func (m *TxReceipt) String() string { return proto.CompactTextString(m) }
 func (*TxReceipt) ProtoMessage()    {}
 func (*TxReceipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{6}
+	return fileDescriptor_1b773bf3e696f610, []int{7}
 }
 
 func (m *TxReceipt) XXX_Unmarshal(b []byte) error {
@@ -644,7 +878,7 @@ func (m *TxReceipt_Receipt) Reset()         { *m = TxReceipt_Receipt{} }
 func (m *TxReceipt_Receipt) String() string { return proto.CompactTextString(m) }
 func (*TxReceipt_Receipt) ProtoMessage()    {}
 func (*TxReceipt_Receipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{6, 1}
+	return fileDescriptor_1b773bf3e696f610, []int{7, 1}
 }
 
 func (m *TxReceipt_Receipt) XXX_Unmarshal(b []byte) error {
@@ -716,7 +950,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{7}
+	return fileDescriptor_1b773bf3e696f610, []int{8}
 }
 
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
@@ -833,17 +1067,19 @@ type TransactionResponse struct {
 	// transaction status
 	Status TransactionResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.TransactionResponse_Status" json:"status,omitempty"`
 	// transaction
-	Transaction          *Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Transaction *Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// number of the block packing the transaction, 0 if pending
+	BlockNumber          int64    `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransactionResponse) Reset()         { *m = TransactionResponse{} }
 func (m *TransactionResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionResponse) ProtoMessage()    {}
 func (*TransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{8}
+	return fileDescriptor_1b773bf3e696f610, []int{9}
 }
 
 func (m *TransactionResponse) XXX_Unmarshal(b []byte) error {
@@ -878,760 +1114,2794 @@ func (m *TransactionResponse) GetTransaction() *Transaction {
 	return nil
 }
 
-// The message defines signature struct.
-type Signature struct {
-	// signature algorithm
-	Algorithm Signature_Algorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=rpcpb.Signature_Algorithm" json:"algorithm,omitempty"`
-	// signature bytes
-	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	// public key
-	PublicKey            []byte   `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+func (m *TransactionResponse) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// The message defines the request of transactions of an account.
+type GetTxsByAccountRequest struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// how many of the newest transactions to skip, at most 10000, GetAccountTxs paging further
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// max count of transactions returned
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Signature) Reset()         { *m = Signature{} }
-func (m *Signature) String() string { return proto.CompactTextString(m) }
-func (*Signature) ProtoMessage()    {}
-func (*Signature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{9}
+func (m *GetTxsByAccountRequest) Reset()         { *m = GetTxsByAccountRequest{} }
+func (m *GetTxsByAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAccountRequest) ProtoMessage()    {}
+func (*GetTxsByAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{10}
 }
 
-func (m *Signature) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Signature.Unmarshal(m, b)
+func (m *GetTxsByAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAccountRequest.Unmarshal(m, b)
 }
-func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+func (m *GetTxsByAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAccountRequest.Marshal(b, m, deterministic)
 }
-func (m *Signature) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Signature.Merge(m, src)
+func (m *GetTxsByAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAccountRequest.Merge(m, src)
 }
-func (m *Signature) XXX_Size() int {
-	return xxx_messageInfo_Signature.Size(m)
+func (m *GetTxsByAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAccountRequest.Size(m)
 }
-func (m *Signature) XXX_DiscardUnknown() {
-	xxx_messageInfo_Signature.DiscardUnknown(m)
+func (m *GetTxsByAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAccountRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Signature proto.InternalMessageInfo
+var xxx_messageInfo_GetTxsByAccountRequest proto.InternalMessageInfo
 
-func (m *Signature) GetAlgorithm() Signature_Algorithm {
+func (m *GetTxsByAccountRequest) GetAccount() string {
 	if m != nil {
-		return m.Algorithm
+		return m.Account
 	}
-	return Signature_UNKNOWN
+	return ""
 }
 
-func (m *Signature) GetSignature() []byte {
+func (m *GetTxsByAccountRequest) GetOffset() int32 {
 	if m != nil {
-		return m.Signature
+		return m.Offset
 	}
-	return nil
+	return 0
 }
 
-func (m *Signature) GetPublicKey() []byte {
+func (m *GetTxsByAccountRequest) GetLimit() int32 {
 	if m != nil {
-		return m.PublicKey
+		return m.Limit
 	}
-	return nil
+	return 0
 }
 
-// The message defines the transaction request.
-type TransactionRequest struct {
-	// transaction timestamp
-	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
-	// expiration timestamp
-	Expiration int64 `protobuf:"varint,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
-	// gas price
-	GasRatio float64 `protobuf:"fixed64,3,opt,name=gas_ratio,json=gasRatio,proto3" json:"gas_ratio,omitempty"`
-	// gas limit
-	GasLimit float64 `protobuf:"fixed64,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	// delay nanoseconds
-	Delay int64 `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
-	// chain id
-	ChainId uint32 `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	// action list
-	Actions []*Action `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
-	// amount limit
-	AmountLimit []*AmountLimit `protobuf:"bytes,8,rep,name=amount_limit,json=amountLimit,proto3" json:"amount_limit,omitempty"`
-	// signer list
-	Signers []string `protobuf:"bytes,9,rep,name=signers,proto3" json:"signers,omitempty"`
-	// signatures of signers
-	Signatures []*Signature `protobuf:"bytes,10,rep,name=signatures,proto3" json:"signatures,omitempty"`
-	// publisher
-	Publisher string `protobuf:"bytes,11,opt,name=publisher,proto3" json:"publisher,omitempty"`
-	// signatures of publisher
-	PublisherSigs        []*Signature `protobuf:"bytes,12,rep,name=publisher_sigs,json=publisherSigs,proto3" json:"publisher_sigs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+// The message contains transactions of an account.
+type GetTxsByAccountResponse struct {
+	// transactions with receipts, newest first
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// whether there are older transactions
+	HasMore bool `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// number of the first block whose transactions are indexed by account, the transactions of the older blocks not being listed
+	IndexStart           int64    `protobuf:"varint,3,opt,name=index_start,json=indexStart,proto3" json:"index_start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransactionRequest) Reset()         { *m = TransactionRequest{} }
-func (m *TransactionRequest) String() string { return proto.CompactTextString(m) }
-func (*TransactionRequest) ProtoMessage()    {}
-func (*TransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{10}
+func (m *GetTxsByAccountResponse) Reset()         { *m = GetTxsByAccountResponse{} }
+func (m *GetTxsByAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAccountResponse) ProtoMessage()    {}
+func (*GetTxsByAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{11}
 }
 
-func (m *TransactionRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TransactionRequest.Unmarshal(m, b)
+func (m *GetTxsByAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAccountResponse.Unmarshal(m, b)
 }
-func (m *TransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TransactionRequest.Marshal(b, m, deterministic)
+func (m *GetTxsByAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAccountResponse.Marshal(b, m, deterministic)
 }
-func (m *TransactionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransactionRequest.Merge(m, src)
+func (m *GetTxsByAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAccountResponse.Merge(m, src)
 }
-func (m *TransactionRequest) XXX_Size() int {
-	return xxx_messageInfo_TransactionRequest.Size(m)
+func (m *GetTxsByAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAccountResponse.Size(m)
 }
-func (m *TransactionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransactionRequest.DiscardUnknown(m)
+func (m *GetTxsByAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAccountResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransactionRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetTxsByAccountResponse proto.InternalMessageInfo
 
-func (m *TransactionRequest) GetTime() int64 {
+func (m *GetTxsByAccountResponse) GetTransactions() []*Transaction {
 	if m != nil {
-		return m.Time
+		return m.Transactions
 	}
-	return 0
+	return nil
 }
 
-func (m *TransactionRequest) GetExpiration() int64 {
+func (m *GetTxsByAccountResponse) GetHasMore() bool {
 	if m != nil {
-		return m.Expiration
+		return m.HasMore
 	}
-	return 0
+	return false
 }
 
-func (m *TransactionRequest) GetGasRatio() float64 {
+func (m *GetTxsByAccountResponse) GetIndexStart() int64 {
 	if m != nil {
-		return m.GasRatio
+		return m.IndexStart
 	}
 	return 0
 }
 
-func (m *TransactionRequest) GetGasLimit() float64 {
-	if m != nil {
-		return m.GasLimit
-	}
-	return 0
+// The message defines the request of a page of transactions of an account.
+type GetAccountTxsRequest struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// height of the newest block to get transactions from, 0 for the last irreversible block, refused if before index_start
+	FromHeight int64 `protobuf:"varint,2,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	// max count of transactions returned
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// cursor of the previous page to get the next one, empty for the first
+	Cursor               string   `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransactionRequest) GetDelay() int64 {
-	if m != nil {
-		return m.Delay
-	}
-	return 0
+func (m *GetAccountTxsRequest) Reset()         { *m = GetAccountTxsRequest{} }
+func (m *GetAccountTxsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTxsRequest) ProtoMessage()    {}
+func (*GetAccountTxsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{12}
 }
 
-func (m *TransactionRequest) GetChainId() uint32 {
+func (m *GetAccountTxsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTxsRequest.Unmarshal(m, b)
+}
+func (m *GetAccountTxsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTxsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTxsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTxsRequest.Merge(m, src)
+}
+func (m *GetAccountTxsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTxsRequest.Size(m)
+}
+func (m *GetAccountTxsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTxsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTxsRequest proto.InternalMessageInfo
+
+func (m *GetAccountTxsRequest) GetAccount() string {
 	if m != nil {
-		return m.ChainId
+		return m.Account
 	}
-	return 0
+	return ""
 }
 
-func (m *TransactionRequest) GetActions() []*Action {
+func (m *GetAccountTxsRequest) GetFromHeight() int64 {
 	if m != nil {
-		return m.Actions
+		return m.FromHeight
 	}
-	return nil
+	return 0
 }
 
-func (m *TransactionRequest) GetAmountLimit() []*AmountLimit {
+func (m *GetAccountTxsRequest) GetLimit() int32 {
 	if m != nil {
-		return m.AmountLimit
+		return m.Limit
 	}
-	return nil
+	return 0
 }
 
-func (m *TransactionRequest) GetSigners() []string {
+func (m *GetAccountTxsRequest) GetCursor() string {
 	if m != nil {
-		return m.Signers
+		return m.Cursor
 	}
-	return nil
+	return ""
 }
 
-func (m *TransactionRequest) GetSignatures() []*Signature {
+// The message contains a page of transactions of an account.
+type GetAccountTxsResponse struct {
+	// transactions with receipts and block numbers, newest first
+	Transactions []*TransactionResponse `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// cursor of the next page, empty if there are no older transactions
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// number of the first block whose transactions are indexed by account, the transactions of the older blocks not being listed
+	IndexStart           int64    `protobuf:"varint,3,opt,name=index_start,json=indexStart,proto3" json:"index_start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountTxsResponse) Reset()         { *m = GetAccountTxsResponse{} }
+func (m *GetAccountTxsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTxsResponse) ProtoMessage()    {}
+func (*GetAccountTxsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{13}
+}
+
+func (m *GetAccountTxsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTxsResponse.Unmarshal(m, b)
+}
+func (m *GetAccountTxsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTxsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTxsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTxsResponse.Merge(m, src)
+}
+func (m *GetAccountTxsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTxsResponse.Size(m)
+}
+func (m *GetAccountTxsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTxsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTxsResponse proto.InternalMessageInfo
+
+func (m *GetAccountTxsResponse) GetTransactions() []*TransactionResponse {
 	if m != nil {
-		return m.Signatures
+		return m.Transactions
 	}
 	return nil
 }
 
-func (m *TransactionRequest) GetPublisher() string {
+func (m *GetAccountTxsResponse) GetCursor() string {
 	if m != nil {
-		return m.Publisher
+		return m.Cursor
 	}
 	return ""
 }
 
-func (m *TransactionRequest) GetPublisherSigs() []*Signature {
+func (m *GetAccountTxsResponse) GetIndexStart() int64 {
 	if m != nil {
-		return m.PublisherSigs
+		return m.IndexStart
 	}
-	return nil
+	return 0
 }
 
-// The message defines the block struct.
-type Block struct {
-	// block hash
-	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	// block version
-	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	// parent block hash
-	ParentHash string `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
-	// transaction merkle tree root hash
-	TxMerkleHash string `protobuf:"bytes,4,opt,name=tx_merkle_hash,json=txMerkleHash,proto3" json:"tx_merkle_hash,omitempty"`
-	// transaction receipt merkle tree root hash
-	TxReceiptMerkleHash string `protobuf:"bytes,5,opt,name=tx_receipt_merkle_hash,json=txReceiptMerkleHash,proto3" json:"tx_receipt_merkle_hash,omitempty"`
-	// block number
-	Number int64 `protobuf:"varint,6,opt,name=number,proto3" json:"number,omitempty"`
-	// block producer witness
-	Witness string `protobuf:"bytes,7,opt,name=witness,proto3" json:"witness,omitempty"`
-	// block timestamp
-	Time int64 `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
-	// block gas usage
-	GasUsage float64 `protobuf:"fixed64,9,opt,name=gas_usage,json=gasUsage,proto3" json:"gas_usage,omitempty"`
-	// transaction count
-	TxCount int64 `protobuf:"varint,10,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
-	// extra information
-	Info *Block_Info `protobuf:"bytes,11,opt,name=info,proto3" json:"info,omitempty"`
-	// block transactions
-	Transactions         []*Transaction `protobuf:"bytes,12,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+// The message defines the request of delay transactions of an account.
+type GetDelaytxsByAccountRequest struct {
+	// publisher of the delay transactions
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Block) Reset()         { *m = Block{} }
-func (m *Block) String() string { return proto.CompactTextString(m) }
-func (*Block) ProtoMessage()    {}
-func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{11}
+func (m *GetDelaytxsByAccountRequest) Reset()         { *m = GetDelaytxsByAccountRequest{} }
+func (m *GetDelaytxsByAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDelaytxsByAccountRequest) ProtoMessage()    {}
+func (*GetDelaytxsByAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{14}
 }
 
-func (m *Block) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Block.Unmarshal(m, b)
+func (m *GetDelaytxsByAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDelaytxsByAccountRequest.Unmarshal(m, b)
 }
-func (m *Block) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Block.Marshal(b, m, deterministic)
+func (m *GetDelaytxsByAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDelaytxsByAccountRequest.Marshal(b, m, deterministic)
 }
-func (m *Block) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Block.Merge(m, src)
+func (m *GetDelaytxsByAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDelaytxsByAccountRequest.Merge(m, src)
 }
-func (m *Block) XXX_Size() int {
-	return xxx_messageInfo_Block.Size(m)
+func (m *GetDelaytxsByAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDelaytxsByAccountRequest.Size(m)
 }
-func (m *Block) XXX_DiscardUnknown() {
-	xxx_messageInfo_Block.DiscardUnknown(m)
+func (m *GetDelaytxsByAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDelaytxsByAccountRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Block proto.InternalMessageInfo
+var xxx_messageInfo_GetDelaytxsByAccountRequest proto.InternalMessageInfo
 
-func (m *Block) GetHash() string {
+func (m *GetDelaytxsByAccountRequest) GetAccount() string {
 	if m != nil {
-		return m.Hash
+		return m.Account
 	}
 	return ""
 }
 
-func (m *Block) GetVersion() int64 {
-	if m != nil {
-		return m.Version
-	}
-	return 0
+// The message contains delay transactions of an account.
+type GetDelaytxsByAccountResponse struct {
+	// delay transactions, the first to be executed first
+	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *Block) GetParentHash() string {
-	if m != nil {
-		return m.ParentHash
-	}
-	return ""
+func (m *GetDelaytxsByAccountResponse) Reset()         { *m = GetDelaytxsByAccountResponse{} }
+func (m *GetDelaytxsByAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDelaytxsByAccountResponse) ProtoMessage()    {}
+func (*GetDelaytxsByAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{15}
 }
 
-func (m *Block) GetTxMerkleHash() string {
-	if m != nil {
-		return m.TxMerkleHash
-	}
-	return ""
+func (m *GetDelaytxsByAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDelaytxsByAccountResponse.Unmarshal(m, b)
+}
+func (m *GetDelaytxsByAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDelaytxsByAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDelaytxsByAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDelaytxsByAccountResponse.Merge(m, src)
+}
+func (m *GetDelaytxsByAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDelaytxsByAccountResponse.Size(m)
+}
+func (m *GetDelaytxsByAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDelaytxsByAccountResponse.DiscardUnknown(m)
 }
 
-func (m *Block) GetTxReceiptMerkleHash() string {
+var xxx_messageInfo_GetDelaytxsByAccountResponse proto.InternalMessageInfo
+
+func (m *GetDelaytxsByAccountResponse) GetTransactions() []*Transaction {
 	if m != nil {
-		return m.TxReceiptMerkleHash
+		return m.Transactions
 	}
-	return ""
+	return nil
 }
 
-func (m *Block) GetNumber() int64 {
-	if m != nil {
-		return m.Number
-	}
-	return 0
+// The message defines the request of pending transactions.
+type GetPendingTransactionsRequest struct {
+	// how many of the first transactions to skip
+	Offset int32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// max count of transactions returned
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// publisher of the transactions, empty for any
+	Publisher string `protobuf:"bytes,3,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	// contract called by an action of the transactions, empty for any
+	Contract             string   `protobuf:"bytes,4,opt,name=contract,proto3" json:"contract,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Block) GetWitness() string {
-	if m != nil {
-		return m.Witness
-	}
-	return ""
+func (m *GetPendingTransactionsRequest) Reset()         { *m = GetPendingTransactionsRequest{} }
+func (m *GetPendingTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPendingTransactionsRequest) ProtoMessage()    {}
+func (*GetPendingTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{16}
 }
 
-func (m *Block) GetTime() int64 {
-	if m != nil {
-		return m.Time
-	}
-	return 0
+func (m *GetPendingTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingTransactionsRequest.Unmarshal(m, b)
+}
+func (m *GetPendingTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPendingTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingTransactionsRequest.Merge(m, src)
+}
+func (m *GetPendingTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPendingTransactionsRequest.Size(m)
+}
+func (m *GetPendingTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingTransactionsRequest.DiscardUnknown(m)
 }
 
-func (m *Block) GetGasUsage() float64 {
+var xxx_messageInfo_GetPendingTransactionsRequest proto.InternalMessageInfo
+
+func (m *GetPendingTransactionsRequest) GetOffset() int32 {
 	if m != nil {
-		return m.GasUsage
+		return m.Offset
 	}
 	return 0
 }
 
-func (m *Block) GetTxCount() int64 {
+func (m *GetPendingTransactionsRequest) GetLimit() int32 {
 	if m != nil {
-		return m.TxCount
+		return m.Limit
 	}
 	return 0
 }
 
-func (m *Block) GetInfo() *Block_Info {
+func (m *GetPendingTransactionsRequest) GetPublisher() string {
 	if m != nil {
-		return m.Info
+		return m.Publisher
 	}
-	return nil
+	return ""
 }
 
-func (m *Block) GetTransactions() []*Transaction {
+func (m *GetPendingTransactionsRequest) GetContract() string {
 	if m != nil {
-		return m.Transactions
+		return m.Contract
 	}
-	return nil
+	return ""
 }
 
-// The message defines block extra information
-type Block_Info struct {
-	// pack mode
-	Mode int32 `protobuf:"varint,1,opt,name=mode,proto3" json:"mode,omitempty"`
-	// transaction execution thread number
-	Thread int32 `protobuf:"varint,2,opt,name=thread,proto3" json:"thread,omitempty"`
-	// transaction index of every batch execution
-	BatchIndex           []int32  `protobuf:"varint,3,rep,packed,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"`
+// The message contains pending transactions.
+type GetPendingTransactionsResponse struct {
+	// pending transactions, the first to be packed first
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// whether there are more transactions
+	HasMore bool `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// count of the pending transactions matching the filters
+	Total                int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Block_Info) Reset()         { *m = Block_Info{} }
-func (m *Block_Info) String() string { return proto.CompactTextString(m) }
-func (*Block_Info) ProtoMessage()    {}
-func (*Block_Info) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{11, 0}
+func (m *GetPendingTransactionsResponse) Reset()         { *m = GetPendingTransactionsResponse{} }
+func (m *GetPendingTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingTransactionsResponse) ProtoMessage()    {}
+func (*GetPendingTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{17}
 }
 
-func (m *Block_Info) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Block_Info.Unmarshal(m, b)
+func (m *GetPendingTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingTransactionsResponse.Unmarshal(m, b)
 }
-func (m *Block_Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Block_Info.Marshal(b, m, deterministic)
+func (m *GetPendingTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingTransactionsResponse.Marshal(b, m, deterministic)
 }
-func (m *Block_Info) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Block_Info.Merge(m, src)
+func (m *GetPendingTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingTransactionsResponse.Merge(m, src)
 }
-func (m *Block_Info) XXX_Size() int {
-	return xxx_messageInfo_Block_Info.Size(m)
+func (m *GetPendingTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPendingTransactionsResponse.Size(m)
 }
-func (m *Block_Info) XXX_DiscardUnknown() {
-	xxx_messageInfo_Block_Info.DiscardUnknown(m)
+func (m *GetPendingTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingTransactionsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Block_Info proto.InternalMessageInfo
+var xxx_messageInfo_GetPendingTransactionsResponse proto.InternalMessageInfo
 
-func (m *Block_Info) GetMode() int32 {
+func (m *GetPendingTransactionsResponse) GetTransactions() []*Transaction {
 	if m != nil {
-		return m.Mode
+		return m.Transactions
 	}
-	return 0
+	return nil
 }
 
-func (m *Block_Info) GetThread() int32 {
+func (m *GetPendingTransactionsResponse) GetHasMore() bool {
 	if m != nil {
-		return m.Thread
+		return m.HasMore
 	}
-	return 0
+	return false
 }
 
-func (m *Block_Info) GetBatchIndex() []int32 {
+func (m *GetPendingTransactionsResponse) GetTotal() int32 {
 	if m != nil {
-		return m.BatchIndex
+		return m.Total
 	}
-	return nil
+	return 0
 }
 
-type BlockResponse struct {
-	// transaction status
-	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
-	// block
-	Block                *Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+// The message defines signature struct.
+type Signature struct {
+	// signature algorithm
+	Algorithm Signature_Algorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=rpcpb.Signature_Algorithm" json:"algorithm,omitempty"`
+	// signature bytes
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// public key
+	PublicKey            []byte   `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
-func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
-func (*BlockResponse) ProtoMessage()    {}
-func (*BlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{12}
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{18}
 }
 
-func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BlockResponse.Unmarshal(m, b)
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
 }
-func (m *BlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BlockResponse.Marshal(b, m, deterministic)
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
 }
-func (m *BlockResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlockResponse.Merge(m, src)
+func (m *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(m, src)
 }
-func (m *BlockResponse) XXX_Size() int {
-	return xxx_messageInfo_BlockResponse.Size(m)
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
 }
-func (m *BlockResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlockResponse.DiscardUnknown(m)
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlockResponse proto.InternalMessageInfo
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetAlgorithm() Signature_Algorithm {
+	if m != nil {
+		return m.Algorithm
+	}
+	return Signature_UNKNOWN
+}
+
+func (m *Signature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Signature) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// The message defines the transaction request.
+type TransactionRequest struct {
+	// transaction timestamp
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// expiration timestamp
+	Expiration int64 `protobuf:"varint,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	// gas price
+	GasRatio float64 `protobuf:"fixed64,3,opt,name=gas_ratio,json=gasRatio,proto3" json:"gas_ratio,omitempty"`
+	// gas limit
+	GasLimit float64 `protobuf:"fixed64,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// delay nanoseconds
+	Delay int64 `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
+	// chain id
+	ChainId uint32 `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// action list
+	Actions []*Action `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	// amount limit
+	AmountLimit []*AmountLimit `protobuf:"bytes,8,rep,name=amount_limit,json=amountLimit,proto3" json:"amount_limit,omitempty"`
+	// signer list
+	Signers []string `protobuf:"bytes,9,rep,name=signers,proto3" json:"signers,omitempty"`
+	// signatures of signers
+	Signatures []*Signature `protobuf:"bytes,10,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	// publisher
+	Publisher string `protobuf:"bytes,11,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	// signatures of publisher
+	PublisherSigs        []*Signature `protobuf:"bytes,12,rep,name=publisher_sigs,json=publisherSigs,proto3" json:"publisher_sigs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TransactionRequest) Reset()         { *m = TransactionRequest{} }
+func (m *TransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionRequest) ProtoMessage()    {}
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{19}
+}
+
+func (m *TransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionRequest.Unmarshal(m, b)
+}
+func (m *TransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *TransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionRequest.Merge(m, src)
+}
+func (m *TransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_TransactionRequest.Size(m)
+}
+func (m *TransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionRequest proto.InternalMessageInfo
+
+func (m *TransactionRequest) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetGasRatio() float64 {
+	if m != nil {
+		return m.GasRatio
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetGasLimit() float64 {
+	if m != nil {
+		return m.GasLimit
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetDelay() int64 {
+	if m != nil {
+		return m.Delay
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *TransactionRequest) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetAmountLimit() []*AmountLimit {
+	if m != nil {
+		return m.AmountLimit
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetSigners() []string {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetSignatures() []*Signature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetPublisher() string {
+	if m != nil {
+		return m.Publisher
+	}
+	return ""
+}
+
+func (m *TransactionRequest) GetPublisherSigs() []*Signature {
+	if m != nil {
+		return m.PublisherSigs
+	}
+	return nil
+}
+
+// The message contains the gas estimated for a transaction.
+type EstimateGasResponse struct {
+	// gas used by the transaction executed with the smallest gas limit it succeeds with
+	GasUsed float64 `protobuf:"fixed64,1,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	// smallest gas limit the transaction succeeds with
+	MinGasLimit float64 `protobuf:"fixed64,2,opt,name=min_gas_limit,json=minGasLimit,proto3" json:"min_gas_limit,omitempty"`
+	// gas limit recommended, with a margin over the smallest one for the state changing before the transaction is packed
+	GasLimit float64 `protobuf:"fixed64,3,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// receipt of the transaction executed with the smallest gas limit
+	Receipt              *TxReceipt `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *EstimateGasResponse) Reset()         { *m = EstimateGasResponse{} }
+func (m *EstimateGasResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasResponse) ProtoMessage()    {}
+func (*EstimateGasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{20}
+}
+
+func (m *EstimateGasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateGasResponse.Unmarshal(m, b)
+}
+func (m *EstimateGasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateGasResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimateGasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateGasResponse.Merge(m, src)
+}
+func (m *EstimateGasResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateGasResponse.Size(m)
+}
+func (m *EstimateGasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateGasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateGasResponse proto.InternalMessageInfo
+
+func (m *EstimateGasResponse) GetGasUsed() float64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *EstimateGasResponse) GetMinGasLimit() float64 {
+	if m != nil {
+		return m.MinGasLimit
+	}
+	return 0
+}
+
+func (m *EstimateGasResponse) GetGasLimit() float64 {
+	if m != nil {
+		return m.GasLimit
+	}
+	return 0
+}
+
+func (m *EstimateGasResponse) GetReceipt() *TxReceipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+// The message contains the trace of a transaction executed again.
+type TraceTransactionResponse struct {
+	// number of the block packing the transaction
+	BlockNumber int64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// receipt of the transaction executed again
+	Receipt *TxReceipt `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// calls of the actions of the transaction
+	Calls                []*CallTrace `protobuf:"bytes,3,rep,name=calls,proto3" json:"calls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TraceTransactionResponse) Reset()         { *m = TraceTransactionResponse{} }
+func (m *TraceTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceTransactionResponse) ProtoMessage()    {}
+func (*TraceTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{21}
+}
+
+func (m *TraceTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceTransactionResponse.Unmarshal(m, b)
+}
+func (m *TraceTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *TraceTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTransactionResponse.Merge(m, src)
+}
+func (m *TraceTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_TraceTransactionResponse.Size(m)
+}
+func (m *TraceTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTransactionResponse proto.InternalMessageInfo
+
+func (m *TraceTransactionResponse) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TraceTransactionResponse) GetReceipt() *TxReceipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *TraceTransactionResponse) GetCalls() []*CallTrace {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+// The message defines a call of a contract abi traced, with the calls it makes to the other contracts.
+type CallTrace struct {
+	// contract id
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// abi called
+	ActionName string `protobuf:"bytes,2,opt,name=action_name,json=actionName,proto3" json:"action_name,omitempty"`
+	// arguments in json
+	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// return values in json
+	Returns string `protobuf:"bytes,4,opt,name=returns,proto3" json:"returns,omitempty"`
+	// error of the call failed
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// gas of the call, with the gas of its calls
+	Gas float64 `protobuf:"fixed64,6,opt,name=gas,proto3" json:"gas,omitempty"`
+	// reads and writes of the storage, in order
+	Storage []*StorageTrace `protobuf:"bytes,7,rep,name=storage,proto3" json:"storage,omitempty"`
+	// receipts posted
+	Receipts []*TxReceipt_Receipt `protobuf:"bytes,8,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	// events posted
+	Events []string `protobuf:"bytes,9,rep,name=events,proto3" json:"events,omitempty"`
+	// calls made to the other contracts
+	Calls []*CallTrace `protobuf:"bytes,10,rep,name=calls,proto3" json:"calls,omitempty"`
+	// gas of the host apis called by the code of the contract, highest first
+	HostApis []*GasProfile `protobuf:"bytes,11,rep,name=host_apis,json=hostApis,proto3" json:"host_apis,omitempty"`
+	// gas of the functions of the code of the contract, without the functions they call, highest first. Only the vms
+	// profiling the functions, like wasm, give them
+	Functions            []*GasProfile `protobuf:"bytes,12,rep,name=functions,proto3" json:"functions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CallTrace) Reset()         { *m = CallTrace{} }
+func (m *CallTrace) String() string { return proto.CompactTextString(m) }
+func (*CallTrace) ProtoMessage()    {}
+func (*CallTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{22}
+}
+
+func (m *CallTrace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallTrace.Unmarshal(m, b)
+}
+func (m *CallTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallTrace.Marshal(b, m, deterministic)
+}
+func (m *CallTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallTrace.Merge(m, src)
+}
+func (m *CallTrace) XXX_Size() int {
+	return xxx_messageInfo_CallTrace.Size(m)
+}
+func (m *CallTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallTrace proto.InternalMessageInfo
+
+func (m *CallTrace) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *CallTrace) GetActionName() string {
+	if m != nil {
+		return m.ActionName
+	}
+	return ""
+}
+
+func (m *CallTrace) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *CallTrace) GetReturns() string {
+	if m != nil {
+		return m.Returns
+	}
+	return ""
+}
+
+func (m *CallTrace) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CallTrace) GetGas() float64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *CallTrace) GetStorage() []*StorageTrace {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *CallTrace) GetReceipts() []*TxReceipt_Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+func (m *CallTrace) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *CallTrace) GetCalls() []*CallTrace {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+func (m *CallTrace) GetHostApis() []*GasProfile {
+	if m != nil {
+		return m.HostApis
+	}
+	return nil
+}
+
+func (m *CallTrace) GetFunctions() []*GasProfile {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+// The message defines the gas used by a host api or a function of a contract in a call.
+type GasProfile struct {
+	// name of the host api or the function
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// times it ran
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// gas used
+	Gas                  float64  `protobuf:"fixed64,3,opt,name=gas,proto3" json:"gas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GasProfile) Reset()         { *m = GasProfile{} }
+func (m *GasProfile) String() string { return proto.CompactTextString(m) }
+func (*GasProfile) ProtoMessage()    {}
+func (*GasProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{23}
+}
+
+func (m *GasProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GasProfile.Unmarshal(m, b)
+}
+func (m *GasProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GasProfile.Marshal(b, m, deterministic)
+}
+func (m *GasProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GasProfile.Merge(m, src)
+}
+func (m *GasProfile) XXX_Size() int {
+	return xxx_messageInfo_GasProfile.Size(m)
+}
+func (m *GasProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_GasProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GasProfile proto.InternalMessageInfo
+
+func (m *GasProfile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GasProfile) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GasProfile) GetGas() float64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+// The message defines a read or a write of the storage of a contract.
+type StorageTrace struct {
+	// operation
+	Op StorageTrace_Op `protobuf:"varint,1,opt,name=op,proto3,enum=rpcpb.StorageTrace_Op" json:"op,omitempty"`
+	// contract of the storage
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	// key
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// field of the map, empty if the key is not a map
+	Field string `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty"`
+	// value read or written
+	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// ram payer of the value written
+	Payer                string   `protobuf:"bytes,6,opt,name=payer,proto3" json:"payer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageTrace) Reset()         { *m = StorageTrace{} }
+func (m *StorageTrace) String() string { return proto.CompactTextString(m) }
+func (*StorageTrace) ProtoMessage()    {}
+func (*StorageTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{24}
+}
+
+func (m *StorageTrace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageTrace.Unmarshal(m, b)
+}
+func (m *StorageTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageTrace.Marshal(b, m, deterministic)
+}
+func (m *StorageTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageTrace.Merge(m, src)
+}
+func (m *StorageTrace) XXX_Size() int {
+	return xxx_messageInfo_StorageTrace.Size(m)
+}
+func (m *StorageTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageTrace proto.InternalMessageInfo
+
+func (m *StorageTrace) GetOp() StorageTrace_Op {
+	if m != nil {
+		return m.Op
+	}
+	return StorageTrace_READ
+}
+
+func (m *StorageTrace) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *StorageTrace) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageTrace) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *StorageTrace) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StorageTrace) GetPayer() string {
+	if m != nil {
+		return m.Payer
+	}
+	return ""
+}
+
+// The message defines the block struct.
+type Block struct {
+	// block hash
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// block version
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// parent block hash
+	ParentHash string `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	// transaction merkle tree root hash
+	TxMerkleHash string `protobuf:"bytes,4,opt,name=tx_merkle_hash,json=txMerkleHash,proto3" json:"tx_merkle_hash,omitempty"`
+	// transaction receipt merkle tree root hash
+	TxReceiptMerkleHash string `protobuf:"bytes,5,opt,name=tx_receipt_merkle_hash,json=txReceiptMerkleHash,proto3" json:"tx_receipt_merkle_hash,omitempty"`
+	// block number
+	Number int64 `protobuf:"varint,6,opt,name=number,proto3" json:"number,omitempty"`
+	// block producer witness
+	Witness string `protobuf:"bytes,7,opt,name=witness,proto3" json:"witness,omitempty"`
+	// block timestamp
+	Time int64 `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+	// block gas usage
+	GasUsage float64 `protobuf:"fixed64,9,opt,name=gas_usage,json=gasUsage,proto3" json:"gas_usage,omitempty"`
+	// transaction count
+	TxCount int64 `protobuf:"varint,10,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	// extra information
+	Info *Block_Info `protobuf:"bytes,11,opt,name=info,proto3" json:"info,omitempty"`
+	// block transactions
+	Transactions         []*Transaction `protobuf:"bytes,12,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Block) Reset()         { *m = Block{} }
+func (m *Block) String() string { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()    {}
+func (*Block) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{25}
+}
+
+func (m *Block) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Block.Unmarshal(m, b)
+}
+func (m *Block) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Block.Marshal(b, m, deterministic)
+}
+func (m *Block) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Block.Merge(m, src)
+}
+func (m *Block) XXX_Size() int {
+	return xxx_messageInfo_Block.Size(m)
+}
+func (m *Block) XXX_DiscardUnknown() {
+	xxx_messageInfo_Block.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Block proto.InternalMessageInfo
+
+func (m *Block) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Block) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Block) GetParentHash() string {
+	if m != nil {
+		return m.ParentHash
+	}
+	return ""
+}
+
+func (m *Block) GetTxMerkleHash() string {
+	if m != nil {
+		return m.TxMerkleHash
+	}
+	return ""
+}
+
+func (m *Block) GetTxReceiptMerkleHash() string {
+	if m != nil {
+		return m.TxReceiptMerkleHash
+	}
+	return ""
+}
+
+func (m *Block) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Block) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+func (m *Block) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Block) GetGasUsage() float64 {
+	if m != nil {
+		return m.GasUsage
+	}
+	return 0
+}
+
+func (m *Block) GetTxCount() int64 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *Block) GetInfo() *Block_Info {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *Block) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// The message defines block extra information
+type Block_Info struct {
+	// pack mode
+	Mode int32 `protobuf:"varint,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// transaction execution thread number
+	Thread int32 `protobuf:"varint,2,opt,name=thread,proto3" json:"thread,omitempty"`
+	// transaction index of every batch execution
+	BatchIndex           []int32  `protobuf:"varint,3,rep,packed,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Block_Info) Reset()         { *m = Block_Info{} }
+func (m *Block_Info) String() string { return proto.CompactTextString(m) }
+func (*Block_Info) ProtoMessage()    {}
+func (*Block_Info) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{25, 0}
+}
+
+func (m *Block_Info) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Block_Info.Unmarshal(m, b)
+}
+func (m *Block_Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Block_Info.Marshal(b, m, deterministic)
+}
+func (m *Block_Info) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Block_Info.Merge(m, src)
+}
+func (m *Block_Info) XXX_Size() int {
+	return xxx_messageInfo_Block_Info.Size(m)
+}
+func (m *Block_Info) XXX_DiscardUnknown() {
+	xxx_messageInfo_Block_Info.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Block_Info proto.InternalMessageInfo
+
+func (m *Block_Info) GetMode() int32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *Block_Info) GetThread() int32 {
+	if m != nil {
+		return m.Thread
+	}
+	return 0
+}
+
+func (m *Block_Info) GetBatchIndex() []int32 {
+	if m != nil {
+		return m.BatchIndex
+	}
+	return nil
+}
+
+type BlockResponse struct {
+	// transaction status
+	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
+	// block
+	Block                *Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
+func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockResponse) ProtoMessage()    {}
+func (*BlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{26}
+}
+
+func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockResponse.Unmarshal(m, b)
+}
+func (m *BlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResponse.Merge(m, src)
+}
+func (m *BlockResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockResponse.Size(m)
+}
+func (m *BlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResponse proto.InternalMessageInfo
 
 func (m *BlockResponse) GetStatus() BlockResponse_Status {
 	if m != nil {
-		return m.Status
+		return m.Status
+	}
+	return BlockResponse_PENDING
+}
+
+func (m *BlockResponse) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// The message defines the header of a block, signed by its witness.
+type BlockHeader struct {
+	// block hash
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// block version
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// parent block hash
+	ParentHash string `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	// transaction merkle tree root hash
+	TxMerkleHash string `protobuf:"bytes,4,opt,name=tx_merkle_hash,json=txMerkleHash,proto3" json:"tx_merkle_hash,omitempty"`
+	// transaction receipt merkle tree root hash
+	TxReceiptMerkleHash string `protobuf:"bytes,5,opt,name=tx_receipt_merkle_hash,json=txReceiptMerkleHash,proto3" json:"tx_receipt_merkle_hash,omitempty"`
+	// block number
+	Number int64 `protobuf:"varint,6,opt,name=number,proto3" json:"number,omitempty"`
+	// block producer witness
+	Witness string `protobuf:"bytes,7,opt,name=witness,proto3" json:"witness,omitempty"`
+	// block timestamp
+	Time int64 `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+	// transaction count
+	TxCount int64 `protobuf:"varint,9,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	// extra information
+	Info *Block_Info `protobuf:"bytes,10,opt,name=info,proto3" json:"info,omitempty"`
+	// signature of the witness
+	Sign                 *Signature `protobuf:"bytes,11,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
+func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
+func (*BlockHeader) ProtoMessage()    {}
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{27}
+}
+
+func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
+}
+func (m *BlockHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeader.Marshal(b, m, deterministic)
+}
+func (m *BlockHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeader.Merge(m, src)
+}
+func (m *BlockHeader) XXX_Size() int {
+	return xxx_messageInfo_BlockHeader.Size(m)
+}
+func (m *BlockHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeader proto.InternalMessageInfo
+
+func (m *BlockHeader) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *BlockHeader) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetParentHash() string {
+	if m != nil {
+		return m.ParentHash
+	}
+	return ""
+}
+
+func (m *BlockHeader) GetTxMerkleHash() string {
+	if m != nil {
+		return m.TxMerkleHash
+	}
+	return ""
+}
+
+func (m *BlockHeader) GetTxReceiptMerkleHash() string {
+	if m != nil {
+		return m.TxReceiptMerkleHash
+	}
+	return ""
+}
+
+func (m *BlockHeader) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+func (m *BlockHeader) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetTxCount() int64 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetInfo() *Block_Info {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetSign() *Signature {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type BlockHeaderResponse struct {
+	// block status
+	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
+	// block header
+	Header               *BlockHeader `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BlockHeaderResponse) Reset()         { *m = BlockHeaderResponse{} }
+func (m *BlockHeaderResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaderResponse) ProtoMessage()    {}
+func (*BlockHeaderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{28}
+}
+
+func (m *BlockHeaderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaderResponse.Unmarshal(m, b)
+}
+func (m *BlockHeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaderResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockHeaderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaderResponse.Merge(m, src)
+}
+func (m *BlockHeaderResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaderResponse.Size(m)
+}
+func (m *BlockHeaderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaderResponse proto.InternalMessageInfo
+
+func (m *BlockHeaderResponse) GetStatus() BlockResponse_Status {
+	if m != nil {
+		return m.Status
+	}
+	return BlockResponse_PENDING
+}
+
+func (m *BlockHeaderResponse) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+// The message defines chain information response.
+type ChainInfoResponse struct {
+	// the name of network, such mainnet or testnet
+	NetName string `protobuf:"bytes,1,opt,name=net_name,json=netName,proto3" json:"net_name,omitempty"`
+	// the iost protocol version
+	ProtocolVersion string `protobuf:"bytes,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// chain id
+	ChainId uint32 `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// head block height
+	HeadBlock int64 `protobuf:"varint,4,opt,name=head_block,json=headBlock,proto3" json:"head_block,omitempty"`
+	// head block hash
+	HeadBlockHash string `protobuf:"bytes,5,opt,name=head_block_hash,json=headBlockHash,proto3" json:"head_block_hash,omitempty"`
+	// last irreversible block number
+	LibBlock int64 `protobuf:"varint,6,opt,name=lib_block,json=libBlock,proto3" json:"lib_block,omitempty"`
+	// last irreversible block hash
+	LibBlockHash string `protobuf:"bytes,7,opt,name=lib_block_hash,json=libBlockHash,proto3" json:"lib_block_hash,omitempty"`
+	// the current witness list
+	WitnessList          []string `protobuf:"bytes,8,rep,name=witness_list,json=witnessList,proto3" json:"witness_list,omitempty"`
+	LibWitnessList       []string `protobuf:"bytes,9,rep,name=lib_witness_list,json=libWitnessList,proto3" json:"lib_witness_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainInfoResponse) Reset()         { *m = ChainInfoResponse{} }
+func (m *ChainInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*ChainInfoResponse) ProtoMessage()    {}
+func (*ChainInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{29}
+}
+
+func (m *ChainInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainInfoResponse.Unmarshal(m, b)
+}
+func (m *ChainInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *ChainInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainInfoResponse.Merge(m, src)
+}
+func (m *ChainInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_ChainInfoResponse.Size(m)
+}
+func (m *ChainInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainInfoResponse proto.InternalMessageInfo
+
+func (m *ChainInfoResponse) GetNetName() string {
+	if m != nil {
+		return m.NetName
+	}
+	return ""
+}
+
+func (m *ChainInfoResponse) GetProtocolVersion() string {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return ""
+}
+
+func (m *ChainInfoResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ChainInfoResponse) GetHeadBlock() int64 {
+	if m != nil {
+		return m.HeadBlock
+	}
+	return 0
+}
+
+func (m *ChainInfoResponse) GetHeadBlockHash() string {
+	if m != nil {
+		return m.HeadBlockHash
+	}
+	return ""
+}
+
+func (m *ChainInfoResponse) GetLibBlock() int64 {
+	if m != nil {
+		return m.LibBlock
+	}
+	return 0
+}
+
+func (m *ChainInfoResponse) GetLibBlockHash() string {
+	if m != nil {
+		return m.LibBlockHash
+	}
+	return ""
+}
+
+func (m *ChainInfoResponse) GetWitnessList() []string {
+	if m != nil {
+		return m.WitnessList
+	}
+	return nil
+}
+
+func (m *ChainInfoResponse) GetLibWitnessList() []string {
+	if m != nil {
+		return m.LibWitnessList
+	}
+	return nil
+}
+
+// The message containing the status of the chain and the node.
+type ChainStatusResponse struct {
+	// head block height
+	HeadBlock int64 `protobuf:"varint,1,opt,name=head_block,json=headBlock,proto3" json:"head_block,omitempty"`
+	// head block hash
+	HeadBlockHash string `protobuf:"bytes,2,opt,name=head_block_hash,json=headBlockHash,proto3" json:"head_block_hash,omitempty"`
+	// head block time
+	HeadBlockTime int64 `protobuf:"varint,3,opt,name=head_block_time,json=headBlockTime,proto3" json:"head_block_time,omitempty"`
+	// the witness producing the head block
+	HeadBlockWitness string `protobuf:"bytes,4,opt,name=head_block_witness,json=headBlockWitness,proto3" json:"head_block_witness,omitempty"`
+	// last irreversible block number
+	LibBlock int64 `protobuf:"varint,5,opt,name=lib_block,json=libBlock,proto3" json:"lib_block,omitempty"`
+	// transaction count of the blocks since the previous status, or of the head block in the first status
+	TxCount int32 `protobuf:"varint,6,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	// transaction count pending in transaction pool
+	PendingTxCount int32 `protobuf:"varint,7,opt,name=pending_tx_count,json=pendingTxCount,proto3" json:"pending_tx_count,omitempty"`
+	// peer connection count
+	PeerCount            int32    `protobuf:"varint,8,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainStatusResponse) Reset()         { *m = ChainStatusResponse{} }
+func (m *ChainStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ChainStatusResponse) ProtoMessage()    {}
+func (*ChainStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{30}
+}
+
+func (m *ChainStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainStatusResponse.Unmarshal(m, b)
+}
+func (m *ChainStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ChainStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainStatusResponse.Merge(m, src)
+}
+func (m *ChainStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ChainStatusResponse.Size(m)
+}
+func (m *ChainStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainStatusResponse proto.InternalMessageInfo
+
+func (m *ChainStatusResponse) GetHeadBlock() int64 {
+	if m != nil {
+		return m.HeadBlock
+	}
+	return 0
+}
+
+func (m *ChainStatusResponse) GetHeadBlockHash() string {
+	if m != nil {
+		return m.HeadBlockHash
+	}
+	return ""
+}
+
+func (m *ChainStatusResponse) GetHeadBlockTime() int64 {
+	if m != nil {
+		return m.HeadBlockTime
+	}
+	return 0
+}
+
+func (m *ChainStatusResponse) GetHeadBlockWitness() string {
+	if m != nil {
+		return m.HeadBlockWitness
+	}
+	return ""
+}
+
+func (m *ChainStatusResponse) GetLibBlock() int64 {
+	if m != nil {
+		return m.LibBlock
+	}
+	return 0
+}
+
+func (m *ChainStatusResponse) GetTxCount() int32 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *ChainStatusResponse) GetPendingTxCount() int32 {
+	if m != nil {
+		return m.PendingTxCount
+	}
+	return 0
+}
+
+func (m *ChainStatusResponse) GetPeerCount() int32 {
+	if m != nil {
+		return m.PeerCount
+	}
+	return 0
+}
+
+// The request message containing the tx's hash.
+type TxHashRequest struct {
+	// tx hash
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxHashRequest) Reset()         { *m = TxHashRequest{} }
+func (m *TxHashRequest) String() string { return proto.CompactTextString(m) }
+func (*TxHashRequest) ProtoMessage()    {}
+func (*TxHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{31}
+}
+
+func (m *TxHashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxHashRequest.Unmarshal(m, b)
+}
+func (m *TxHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxHashRequest.Marshal(b, m, deterministic)
+}
+func (m *TxHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxHashRequest.Merge(m, src)
+}
+func (m *TxHashRequest) XXX_Size() int {
+	return xxx_messageInfo_TxHashRequest.Size(m)
+}
+func (m *TxHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxHashRequest proto.InternalMessageInfo
+
+func (m *TxHashRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// The request message containing the block's hash.
+type GetBlockByHashRequest struct {
+	// block hash
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// complete means whether including the full transactions and transaction receipts
+	Complete bool `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	// contracts, or abis like token.iost/transfer, to return only the transactions with an action calling one of them;
+	// all the transactions if empty
+	Actions              []string `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockByHashRequest) Reset()         { *m = GetBlockByHashRequest{} }
+func (m *GetBlockByHashRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockByHashRequest) ProtoMessage()    {}
+func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{32}
+}
+
+func (m *GetBlockByHashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockByHashRequest.Unmarshal(m, b)
+}
+func (m *GetBlockByHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockByHashRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockByHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockByHashRequest.Merge(m, src)
+}
+func (m *GetBlockByHashRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockByHashRequest.Size(m)
+}
+func (m *GetBlockByHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockByHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockByHashRequest proto.InternalMessageInfo
+
+func (m *GetBlockByHashRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GetBlockByHashRequest) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+func (m *GetBlockByHashRequest) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+// The request message containing the block's number.
+type GetBlockByNumberRequest struct {
+	// block number
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// complete means whether including the full transactions and transaction receipts
+	Complete bool `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	// contracts, or abis like token.iost/transfer, to return only the transactions with an action calling one of them;
+	// all the transactions if empty
+	Actions              []string `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockByNumberRequest) Reset()         { *m = GetBlockByNumberRequest{} }
+func (m *GetBlockByNumberRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockByNumberRequest) ProtoMessage()    {}
+func (*GetBlockByNumberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{33}
+}
+
+func (m *GetBlockByNumberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockByNumberRequest.Unmarshal(m, b)
+}
+func (m *GetBlockByNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockByNumberRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockByNumberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockByNumberRequest.Merge(m, src)
+}
+func (m *GetBlockByNumberRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockByNumberRequest.Size(m)
+}
+func (m *GetBlockByNumberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockByNumberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockByNumberRequest proto.InternalMessageInfo
+
+func (m *GetBlockByNumberRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *GetBlockByNumberRequest) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+func (m *GetBlockByNumberRequest) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+// The request message containing the block's hash.
+type GetBlockHeaderByHashRequest struct {
+	// block hash
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockHeaderByHashRequest) Reset()         { *m = GetBlockHeaderByHashRequest{} }
+func (m *GetBlockHeaderByHashRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockHeaderByHashRequest) ProtoMessage()    {}
+func (*GetBlockHeaderByHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{34}
+}
+
+func (m *GetBlockHeaderByHashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockHeaderByHashRequest.Unmarshal(m, b)
+}
+func (m *GetBlockHeaderByHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockHeaderByHashRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockHeaderByHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockHeaderByHashRequest.Merge(m, src)
+}
+func (m *GetBlockHeaderByHashRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockHeaderByHashRequest.Size(m)
+}
+func (m *GetBlockHeaderByHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockHeaderByHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockHeaderByHashRequest proto.InternalMessageInfo
+
+func (m *GetBlockHeaderByHashRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// The request message containing the block's number.
+type GetBlockHeaderByNumberRequest struct {
+	// block number
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockHeaderByNumberRequest) Reset()         { *m = GetBlockHeaderByNumberRequest{} }
+func (m *GetBlockHeaderByNumberRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockHeaderByNumberRequest) ProtoMessage()    {}
+func (*GetBlockHeaderByNumberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{35}
+}
+
+func (m *GetBlockHeaderByNumberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockHeaderByNumberRequest.Unmarshal(m, b)
+}
+func (m *GetBlockHeaderByNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockHeaderByNumberRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockHeaderByNumberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockHeaderByNumberRequest.Merge(m, src)
+}
+func (m *GetBlockHeaderByNumberRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockHeaderByNumberRequest.Size(m)
+}
+func (m *GetBlockHeaderByNumberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockHeaderByNumberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockHeaderByNumberRequest proto.InternalMessageInfo
+
+func (m *GetBlockHeaderByNumberRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// The request message containing a range of block numbers.
+type GetBlocksRequest struct {
+	// number of the first block
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// number of the last block
+	End int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// complete means whether including the full transactions and transaction receipts
+	Complete             bool     `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
+func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksRequest) ProtoMessage()    {}
+func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{36}
+}
+
+func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlocksRequest.Unmarshal(m, b)
+}
+func (m *GetBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksRequest.Merge(m, src)
+}
+func (m *GetBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlocksRequest.Size(m)
+}
+func (m *GetBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksRequest proto.InternalMessageInfo
+
+func (m *GetBlocksRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GetBlocksRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *GetBlocksRequest) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// The message contains the blocks of a range.
+type GetBlocksResponse struct {
+	// blocks from the start of the range, up to the head block
+	Blocks []*BlockResponse `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// whether the blocks returned were cut by the limit of the node, the next ones being got from the number after the last block
+	HasMore              bool     `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlocksResponse) Reset()         { *m = GetBlocksResponse{} }
+func (m *GetBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksResponse) ProtoMessage()    {}
+func (*GetBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{37}
+}
+
+func (m *GetBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlocksResponse.Unmarshal(m, b)
+}
+func (m *GetBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlocksResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksResponse.Merge(m, src)
+}
+func (m *GetBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlocksResponse.Size(m)
+}
+func (m *GetBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksResponse proto.InternalMessageInfo
+
+func (m *GetBlocksResponse) GetBlocks() []*BlockResponse {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *GetBlocksResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+// The request message containing the block's number.
+type GetBlockStateChangesRequest struct {
+	// block number
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockStateChangesRequest) Reset()         { *m = GetBlockStateChangesRequest{} }
+func (m *GetBlockStateChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockStateChangesRequest) ProtoMessage()    {}
+func (*GetBlockStateChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{38}
+}
+
+func (m *GetBlockStateChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockStateChangesRequest.Unmarshal(m, b)
+}
+func (m *GetBlockStateChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockStateChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockStateChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockStateChangesRequest.Merge(m, src)
+}
+func (m *GetBlockStateChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockStateChangesRequest.Size(m)
+}
+func (m *GetBlockStateChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockStateChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockStateChangesRequest proto.InternalMessageInfo
+
+func (m *GetBlockStateChangesRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// The message defines a state key written by a block.
+type StateChange struct {
+	// kind of the key
+	Kind StateChange_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=rpcpb.StateChange_Kind" json:"kind,omitempty"`
+	// contract owning the key
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	// state key
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// value written, empty for contract code
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// whether the key is deleted
+	Deleted              bool     `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChange) Reset()         { *m = StateChange{} }
+func (m *StateChange) String() string { return proto.CompactTextString(m) }
+func (*StateChange) ProtoMessage()    {}
+func (*StateChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{39}
+}
+
+func (m *StateChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChange.Unmarshal(m, b)
+}
+func (m *StateChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChange.Marshal(b, m, deterministic)
+}
+func (m *StateChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChange.Merge(m, src)
+}
+func (m *StateChange) XXX_Size() int {
+	return xxx_messageInfo_StateChange.Size(m)
+}
+func (m *StateChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChange proto.InternalMessageInfo
+
+func (m *StateChange) GetKind() StateChange_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return StateChange_STORAGE
+}
+
+func (m *StateChange) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *StateChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChange) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StateChange) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// The message contains the state keys written by a block.
+type GetBlockStateChangesResponse struct {
+	// block number
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// block hash
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// state keys sorted in order
+	Changes              []*StateChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetBlockStateChangesResponse) Reset()         { *m = GetBlockStateChangesResponse{} }
+func (m *GetBlockStateChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockStateChangesResponse) ProtoMessage()    {}
+func (*GetBlockStateChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{40}
+}
+
+func (m *GetBlockStateChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockStateChangesResponse.Unmarshal(m, b)
+}
+func (m *GetBlockStateChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockStateChangesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockStateChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockStateChangesResponse.Merge(m, src)
+}
+func (m *GetBlockStateChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockStateChangesResponse.Size(m)
+}
+func (m *GetBlockStateChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockStateChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockStateChangesResponse proto.InternalMessageInfo
+
+func (m *GetBlockStateChangesResponse) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *GetBlockStateChangesResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GetBlockStateChangesResponse) GetChanges() []*StateChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// The message defines the account's frozen balance.
+type FrozenBalance struct {
+	// balance amount
+	Amount float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// free time
+	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FrozenBalance) Reset()         { *m = FrozenBalance{} }
+func (m *FrozenBalance) String() string { return proto.CompactTextString(m) }
+func (*FrozenBalance) ProtoMessage()    {}
+func (*FrozenBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{41}
+}
+
+func (m *FrozenBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FrozenBalance.Unmarshal(m, b)
+}
+func (m *FrozenBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FrozenBalance.Marshal(b, m, deterministic)
+}
+func (m *FrozenBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrozenBalance.Merge(m, src)
+}
+func (m *FrozenBalance) XXX_Size() int {
+	return xxx_messageInfo_FrozenBalance.Size(m)
+}
+func (m *FrozenBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrozenBalance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FrozenBalance proto.InternalMessageInfo
+
+func (m *FrozenBalance) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
 	}
-	return BlockResponse_PENDING
+	return 0
 }
 
-func (m *BlockResponse) GetBlock() *Block {
+func (m *FrozenBalance) GetTime() int64 {
 	if m != nil {
-		return m.Block
+		return m.Time
 	}
-	return nil
+	return 0
 }
 
-// The message defines chain information response.
-type ChainInfoResponse struct {
-	// the name of network, such mainnet or testnet
-	NetName string `protobuf:"bytes,1,opt,name=net_name,json=netName,proto3" json:"net_name,omitempty"`
-	// the iost protocol version
-	ProtocolVersion string `protobuf:"bytes,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
-	// chain id
-	ChainId uint32 `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	// head block height
-	HeadBlock int64 `protobuf:"varint,4,opt,name=head_block,json=headBlock,proto3" json:"head_block,omitempty"`
-	// head block hash
-	HeadBlockHash string `protobuf:"bytes,5,opt,name=head_block_hash,json=headBlockHash,proto3" json:"head_block_hash,omitempty"`
-	// last irreversible block number
-	LibBlock int64 `protobuf:"varint,6,opt,name=lib_block,json=libBlock,proto3" json:"lib_block,omitempty"`
-	// last irreversible block hash
-	LibBlockHash string `protobuf:"bytes,7,opt,name=lib_block_hash,json=libBlockHash,proto3" json:"lib_block_hash,omitempty"`
-	// the current witness list
-	WitnessList          []string `protobuf:"bytes,8,rep,name=witness_list,json=witnessList,proto3" json:"witness_list,omitempty"`
-	LibWitnessList       []string `protobuf:"bytes,9,rep,name=lib_witness_list,json=libWitnessList,proto3" json:"lib_witness_list,omitempty"`
+// The message defines the account's vote info.
+type VoteInfo struct {
+	// option name
+	Option string `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+	// votes
+	Votes float64 `protobuf:"fixed64,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	// cleared votes
+	ClearedVotes         float64  `protobuf:"fixed64,3,opt,name=cleared_votes,json=clearedVotes,proto3" json:"cleared_votes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChainInfoResponse) Reset()         { *m = ChainInfoResponse{} }
-func (m *ChainInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*ChainInfoResponse) ProtoMessage()    {}
-func (*ChainInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{13}
+func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
+func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
+func (*VoteInfo) ProtoMessage()    {}
+func (*VoteInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{42}
 }
 
-func (m *ChainInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChainInfoResponse.Unmarshal(m, b)
+func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoteInfo.Unmarshal(m, b)
 }
-func (m *ChainInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChainInfoResponse.Marshal(b, m, deterministic)
+func (m *VoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoteInfo.Marshal(b, m, deterministic)
 }
-func (m *ChainInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChainInfoResponse.Merge(m, src)
+func (m *VoteInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoteInfo.Merge(m, src)
 }
-func (m *ChainInfoResponse) XXX_Size() int {
-	return xxx_messageInfo_ChainInfoResponse.Size(m)
+func (m *VoteInfo) XXX_Size() int {
+	return xxx_messageInfo_VoteInfo.Size(m)
 }
-func (m *ChainInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChainInfoResponse.DiscardUnknown(m)
+func (m *VoteInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoteInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChainInfoResponse proto.InternalMessageInfo
+var xxx_messageInfo_VoteInfo proto.InternalMessageInfo
 
-func (m *ChainInfoResponse) GetNetName() string {
+func (m *VoteInfo) GetOption() string {
 	if m != nil {
-		return m.NetName
+		return m.Option
 	}
 	return ""
 }
 
-func (m *ChainInfoResponse) GetProtocolVersion() string {
+func (m *VoteInfo) GetVotes() float64 {
 	if m != nil {
-		return m.ProtocolVersion
+		return m.Votes
 	}
-	return ""
+	return 0
 }
 
-func (m *ChainInfoResponse) GetChainId() uint32 {
+func (m *VoteInfo) GetClearedVotes() float64 {
 	if m != nil {
-		return m.ChainId
+		return m.ClearedVotes
 	}
 	return 0
 }
 
-func (m *ChainInfoResponse) GetHeadBlock() int64 {
+type GetProducerVoteInfoRequest struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProducerVoteInfoRequest) Reset()         { *m = GetProducerVoteInfoRequest{} }
+func (m *GetProducerVoteInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProducerVoteInfoRequest) ProtoMessage()    {}
+func (*GetProducerVoteInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{43}
+}
+
+func (m *GetProducerVoteInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducerVoteInfoRequest.Unmarshal(m, b)
+}
+func (m *GetProducerVoteInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducerVoteInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetProducerVoteInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducerVoteInfoRequest.Merge(m, src)
+}
+func (m *GetProducerVoteInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetProducerVoteInfoRequest.Size(m)
+}
+func (m *GetProducerVoteInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducerVoteInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProducerVoteInfoRequest proto.InternalMessageInfo
+
+func (m *GetProducerVoteInfoRequest) GetAccount() string {
 	if m != nil {
-		return m.HeadBlock
+		return m.Account
 	}
-	return 0
+	return ""
 }
 
-func (m *ChainInfoResponse) GetHeadBlockHash() string {
+func (m *GetProducerVoteInfoRequest) GetByLongestChain() bool {
 	if m != nil {
-		return m.HeadBlockHash
+		return m.ByLongestChain
+	}
+	return false
+}
+
+type GetProducerVoteInfoResponse struct {
+	// producer's public key
+	Pubkey string `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// producer's location
+	Loc string `protobuf:"bytes,2,opt,name=loc,proto3" json:"loc,omitempty"`
+	// producer's url
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// producer's net id
+	NetId string `protobuf:"bytes,4,opt,name=net_id,json=netId,proto3" json:"net_id,omitempty"`
+	// is producer
+	IsProducer bool `protobuf:"varint,5,opt,name=is_producer,json=isProducer,proto3" json:"is_producer,omitempty"`
+	// is approved
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// is online
+	Online bool `protobuf:"varint,7,opt,name=online,proto3" json:"online,omitempty"`
+	// votes
+	Votes                float64  `protobuf:"fixed64,8,opt,name=votes,proto3" json:"votes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProducerVoteInfoResponse) Reset()         { *m = GetProducerVoteInfoResponse{} }
+func (m *GetProducerVoteInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetProducerVoteInfoResponse) ProtoMessage()    {}
+func (*GetProducerVoteInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{44}
+}
+
+func (m *GetProducerVoteInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducerVoteInfoResponse.Unmarshal(m, b)
+}
+func (m *GetProducerVoteInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducerVoteInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetProducerVoteInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducerVoteInfoResponse.Merge(m, src)
+}
+func (m *GetProducerVoteInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetProducerVoteInfoResponse.Size(m)
+}
+func (m *GetProducerVoteInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducerVoteInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProducerVoteInfoResponse proto.InternalMessageInfo
+
+func (m *GetProducerVoteInfoResponse) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *GetProducerVoteInfoResponse) GetLoc() string {
+	if m != nil {
+		return m.Loc
+	}
+	return ""
+}
+
+func (m *GetProducerVoteInfoResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *GetProducerVoteInfoResponse) GetNetId() string {
+	if m != nil {
+		return m.NetId
+	}
+	return ""
+}
+
+func (m *GetProducerVoteInfoResponse) GetIsProducer() bool {
+	if m != nil {
+		return m.IsProducer
+	}
+	return false
+}
+
+func (m *GetProducerVoteInfoResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetProducerVoteInfoResponse) GetOnline() bool {
+	if m != nil {
+		return m.Online
 	}
-	return ""
+	return false
 }
 
-func (m *ChainInfoResponse) GetLibBlock() int64 {
+func (m *GetProducerVoteInfoResponse) GetVotes() float64 {
 	if m != nil {
-		return m.LibBlock
+		return m.Votes
 	}
 	return 0
 }
 
-func (m *ChainInfoResponse) GetLibBlockHash() string {
+// The message defines the request of a page of producer candidates.
+type GetProducersRequest struct {
+	// max count of candidates returned, 100 if 0, at most 100
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// cursor of the previous page to get the next one, empty for the first
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,3,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProducersRequest) Reset()         { *m = GetProducersRequest{} }
+func (m *GetProducersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProducersRequest) ProtoMessage()    {}
+func (*GetProducersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{45}
+}
+
+func (m *GetProducersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducersRequest.Unmarshal(m, b)
+}
+func (m *GetProducersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducersRequest.Marshal(b, m, deterministic)
+}
+func (m *GetProducersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducersRequest.Merge(m, src)
+}
+func (m *GetProducersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetProducersRequest.Size(m)
+}
+func (m *GetProducersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProducersRequest proto.InternalMessageInfo
+
+func (m *GetProducersRequest) GetLimit() int32 {
 	if m != nil {
-		return m.LibBlockHash
+		return m.Limit
 	}
-	return ""
+	return 0
 }
 
-func (m *ChainInfoResponse) GetWitnessList() []string {
+func (m *GetProducersRequest) GetCursor() string {
 	if m != nil {
-		return m.WitnessList
+		return m.Cursor
 	}
-	return nil
+	return ""
 }
 
-func (m *ChainInfoResponse) GetLibWitnessList() []string {
+func (m *GetProducersRequest) GetByLongestChain() bool {
 	if m != nil {
-		return m.LibWitnessList
+		return m.ByLongestChain
 	}
-	return nil
+	return false
 }
 
-// The request message containing the tx's hash.
-type TxHashRequest struct {
-	// tx hash
-	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+// The message contains a page of producer candidates.
+type GetProducersResponse struct {
+	// candidates sorted by account
+	Producers []*GetProducersResponse_Producer `protobuf:"bytes,1,rep,name=producers,proto3" json:"producers,omitempty"`
+	// cursor of the next page, empty if there are no more candidates
+	Cursor               string   `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TxHashRequest) Reset()         { *m = TxHashRequest{} }
-func (m *TxHashRequest) String() string { return proto.CompactTextString(m) }
-func (*TxHashRequest) ProtoMessage()    {}
-func (*TxHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{14}
+func (m *GetProducersResponse) Reset()         { *m = GetProducersResponse{} }
+func (m *GetProducersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetProducersResponse) ProtoMessage()    {}
+func (*GetProducersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{46}
 }
 
-func (m *TxHashRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TxHashRequest.Unmarshal(m, b)
+func (m *GetProducersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducersResponse.Unmarshal(m, b)
 }
-func (m *TxHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TxHashRequest.Marshal(b, m, deterministic)
+func (m *GetProducersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducersResponse.Marshal(b, m, deterministic)
 }
-func (m *TxHashRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TxHashRequest.Merge(m, src)
+func (m *GetProducersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducersResponse.Merge(m, src)
 }
-func (m *TxHashRequest) XXX_Size() int {
-	return xxx_messageInfo_TxHashRequest.Size(m)
+func (m *GetProducersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetProducersResponse.Size(m)
 }
-func (m *TxHashRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TxHashRequest.DiscardUnknown(m)
+func (m *GetProducersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducersResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TxHashRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetProducersResponse proto.InternalMessageInfo
 
-func (m *TxHashRequest) GetHash() string {
+func (m *GetProducersResponse) GetProducers() []*GetProducersResponse_Producer {
 	if m != nil {
-		return m.Hash
+		return m.Producers
+	}
+	return nil
+}
+
+func (m *GetProducersResponse) GetCursor() string {
+	if m != nil {
+		return m.Cursor
 	}
 	return ""
 }
 
-// The request message containing the block's hash.
-type GetBlockByHashRequest struct {
-	// block hash
-	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	// complete means whether including the full transactions and transaction receipts
-	Complete             bool     `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// The message defines a producer candidate.
+type GetProducersResponse_Producer struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// vote infomation of the candidate
+	Info                 *GetProducerVoteInfoResponse `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func (m *GetBlockByHashRequest) Reset()         { *m = GetBlockByHashRequest{} }
-func (m *GetBlockByHashRequest) String() string { return proto.CompactTextString(m) }
-func (*GetBlockByHashRequest) ProtoMessage()    {}
-func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{15}
+func (m *GetProducersResponse_Producer) Reset()         { *m = GetProducersResponse_Producer{} }
+func (m *GetProducersResponse_Producer) String() string { return proto.CompactTextString(m) }
+func (*GetProducersResponse_Producer) ProtoMessage()    {}
+func (*GetProducersResponse_Producer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{46, 0}
 }
 
-func (m *GetBlockByHashRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBlockByHashRequest.Unmarshal(m, b)
+func (m *GetProducersResponse_Producer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducersResponse_Producer.Unmarshal(m, b)
 }
-func (m *GetBlockByHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBlockByHashRequest.Marshal(b, m, deterministic)
+func (m *GetProducersResponse_Producer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducersResponse_Producer.Marshal(b, m, deterministic)
 }
-func (m *GetBlockByHashRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBlockByHashRequest.Merge(m, src)
+func (m *GetProducersResponse_Producer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducersResponse_Producer.Merge(m, src)
 }
-func (m *GetBlockByHashRequest) XXX_Size() int {
-	return xxx_messageInfo_GetBlockByHashRequest.Size(m)
+func (m *GetProducersResponse_Producer) XXX_Size() int {
+	return xxx_messageInfo_GetProducersResponse_Producer.Size(m)
 }
-func (m *GetBlockByHashRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBlockByHashRequest.DiscardUnknown(m)
+func (m *GetProducersResponse_Producer) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducersResponse_Producer.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBlockByHashRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetProducersResponse_Producer proto.InternalMessageInfo
 
-func (m *GetBlockByHashRequest) GetHash() string {
+func (m *GetProducersResponse_Producer) GetAccount() string {
 	if m != nil {
-		return m.Hash
+		return m.Account
 	}
 	return ""
 }
 
-func (m *GetBlockByHashRequest) GetComplete() bool {
+func (m *GetProducersResponse_Producer) GetInfo() *GetProducerVoteInfoResponse {
 	if m != nil {
-		return m.Complete
+		return m.Info
 	}
-	return false
+	return nil
 }
 
-// The request message containing the block's number.
-type GetBlockByNumberRequest struct {
-	// block number
-	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	// complete means whether including the full transactions and transaction receipts
-	Complete             bool     `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+// The message defines the request of a page of voters of a producer candidate.
+type GetVotersRequest struct {
+	// account of the candidate
+	Producer string `protobuf:"bytes,1,opt,name=producer,proto3" json:"producer,omitempty"`
+	// max count of voters returned, 1000 if 0, at most 1000
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// cursor of the previous page to get the next one, empty for the first
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,4,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetBlockByNumberRequest) Reset()         { *m = GetBlockByNumberRequest{} }
-func (m *GetBlockByNumberRequest) String() string { return proto.CompactTextString(m) }
-func (*GetBlockByNumberRequest) ProtoMessage()    {}
-func (*GetBlockByNumberRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{16}
+func (m *GetVotersRequest) Reset()         { *m = GetVotersRequest{} }
+func (m *GetVotersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetVotersRequest) ProtoMessage()    {}
+func (*GetVotersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{47}
 }
 
-func (m *GetBlockByNumberRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBlockByNumberRequest.Unmarshal(m, b)
+func (m *GetVotersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVotersRequest.Unmarshal(m, b)
 }
-func (m *GetBlockByNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBlockByNumberRequest.Marshal(b, m, deterministic)
+func (m *GetVotersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVotersRequest.Marshal(b, m, deterministic)
 }
-func (m *GetBlockByNumberRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBlockByNumberRequest.Merge(m, src)
+func (m *GetVotersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVotersRequest.Merge(m, src)
 }
-func (m *GetBlockByNumberRequest) XXX_Size() int {
-	return xxx_messageInfo_GetBlockByNumberRequest.Size(m)
+func (m *GetVotersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetVotersRequest.Size(m)
 }
-func (m *GetBlockByNumberRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBlockByNumberRequest.DiscardUnknown(m)
+func (m *GetVotersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVotersRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBlockByNumberRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetVotersRequest proto.InternalMessageInfo
 
-func (m *GetBlockByNumberRequest) GetNumber() int64 {
+func (m *GetVotersRequest) GetProducer() string {
 	if m != nil {
-		return m.Number
+		return m.Producer
+	}
+	return ""
+}
+
+func (m *GetVotersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
 	return 0
 }
 
-func (m *GetBlockByNumberRequest) GetComplete() bool {
+func (m *GetVotersRequest) GetCursor() string {
 	if m != nil {
-		return m.Complete
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *GetVotersRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
 	}
 	return false
 }
 
-// The message defines the account's frozen balance.
-type FrozenBalance struct {
-	// balance amount
-	Amount float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	// free time
-	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+// The message contains a page of voters of a producer candidate.
+type GetVotersResponse struct {
+	// voters sorted by account, fewer than the limit if the node stopped looking through the accounts having voted
+	Voters []*GetVotersResponse_Voter `protobuf:"bytes,1,rep,name=voters,proto3" json:"voters,omitempty"`
+	// cursor of the next page, empty if there are no more voters
+	Cursor               string   `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FrozenBalance) Reset()         { *m = FrozenBalance{} }
-func (m *FrozenBalance) String() string { return proto.CompactTextString(m) }
-func (*FrozenBalance) ProtoMessage()    {}
-func (*FrozenBalance) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{17}
+func (m *GetVotersResponse) Reset()         { *m = GetVotersResponse{} }
+func (m *GetVotersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetVotersResponse) ProtoMessage()    {}
+func (*GetVotersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{48}
 }
 
-func (m *FrozenBalance) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FrozenBalance.Unmarshal(m, b)
+func (m *GetVotersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVotersResponse.Unmarshal(m, b)
 }
-func (m *FrozenBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FrozenBalance.Marshal(b, m, deterministic)
+func (m *GetVotersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVotersResponse.Marshal(b, m, deterministic)
 }
-func (m *FrozenBalance) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FrozenBalance.Merge(m, src)
+func (m *GetVotersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVotersResponse.Merge(m, src)
 }
-func (m *FrozenBalance) XXX_Size() int {
-	return xxx_messageInfo_FrozenBalance.Size(m)
+func (m *GetVotersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetVotersResponse.Size(m)
 }
-func (m *FrozenBalance) XXX_DiscardUnknown() {
-	xxx_messageInfo_FrozenBalance.DiscardUnknown(m)
+func (m *GetVotersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVotersResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FrozenBalance proto.InternalMessageInfo
+var xxx_messageInfo_GetVotersResponse proto.InternalMessageInfo
 
-func (m *FrozenBalance) GetAmount() float64 {
+func (m *GetVotersResponse) GetVoters() []*GetVotersResponse_Voter {
 	if m != nil {
-		return m.Amount
+		return m.Voters
 	}
-	return 0
+	return nil
 }
 
-func (m *FrozenBalance) GetTime() int64 {
+func (m *GetVotersResponse) GetCursor() string {
 	if m != nil {
-		return m.Time
+		return m.Cursor
 	}
-	return 0
+	return ""
 }
 
-// The message defines the account's vote info.
-type VoteInfo struct {
-	// option name
-	Option string `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+// The message defines the votes of a voter for the candidate.
+type GetVotersResponse_Voter struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	// votes
 	Votes float64 `protobuf:"fixed64,2,opt,name=votes,proto3" json:"votes,omitempty"`
 	// cleared votes
@@ -1641,200 +3911,252 @@ type VoteInfo struct {
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
-func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
-func (*VoteInfo) ProtoMessage()    {}
-func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{18}
+func (m *GetVotersResponse_Voter) Reset()         { *m = GetVotersResponse_Voter{} }
+func (m *GetVotersResponse_Voter) String() string { return proto.CompactTextString(m) }
+func (*GetVotersResponse_Voter) ProtoMessage()    {}
+func (*GetVotersResponse_Voter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{48, 0}
 }
 
-func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_VoteInfo.Unmarshal(m, b)
+func (m *GetVotersResponse_Voter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVotersResponse_Voter.Unmarshal(m, b)
 }
-func (m *VoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_VoteInfo.Marshal(b, m, deterministic)
+func (m *GetVotersResponse_Voter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVotersResponse_Voter.Marshal(b, m, deterministic)
 }
-func (m *VoteInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VoteInfo.Merge(m, src)
+func (m *GetVotersResponse_Voter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVotersResponse_Voter.Merge(m, src)
 }
-func (m *VoteInfo) XXX_Size() int {
-	return xxx_messageInfo_VoteInfo.Size(m)
+func (m *GetVotersResponse_Voter) XXX_Size() int {
+	return xxx_messageInfo_GetVotersResponse_Voter.Size(m)
 }
-func (m *VoteInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_VoteInfo.DiscardUnknown(m)
+func (m *GetVotersResponse_Voter) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVotersResponse_Voter.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VoteInfo proto.InternalMessageInfo
+var xxx_messageInfo_GetVotersResponse_Voter proto.InternalMessageInfo
 
-func (m *VoteInfo) GetOption() string {
+func (m *GetVotersResponse_Voter) GetAccount() string {
 	if m != nil {
-		return m.Option
+		return m.Account
 	}
 	return ""
 }
 
-func (m *VoteInfo) GetVotes() float64 {
+func (m *GetVotersResponse_Voter) GetVotes() float64 {
 	if m != nil {
 		return m.Votes
 	}
 	return 0
 }
 
-func (m *VoteInfo) GetClearedVotes() float64 {
+func (m *GetVotersResponse_Voter) GetClearedVotes() float64 {
 	if m != nil {
 		return m.ClearedVotes
 	}
 	return 0
 }
 
-type GetProducerVoteInfoRequest struct {
-	// account name
-	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	// get data by longest chain's head block or last irreversible block
-	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+// The message defines get witness schedule request.
+type GetWitnessScheduleRequest struct {
+	// the number of recent blocks the statistics are counted over, 1200 if 0, at most 10000
+	BlockCount           int64    `protobuf:"varint,1,opt,name=block_count,json=blockCount,proto3" json:"block_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetProducerVoteInfoRequest) Reset()         { *m = GetProducerVoteInfoRequest{} }
-func (m *GetProducerVoteInfoRequest) String() string { return proto.CompactTextString(m) }
-func (*GetProducerVoteInfoRequest) ProtoMessage()    {}
-func (*GetProducerVoteInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{19}
+func (m *GetWitnessScheduleRequest) Reset()         { *m = GetWitnessScheduleRequest{} }
+func (m *GetWitnessScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessScheduleRequest) ProtoMessage()    {}
+func (*GetWitnessScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{49}
 }
 
-func (m *GetProducerVoteInfoRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetProducerVoteInfoRequest.Unmarshal(m, b)
+func (m *GetWitnessScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessScheduleRequest.Unmarshal(m, b)
 }
-func (m *GetProducerVoteInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetProducerVoteInfoRequest.Marshal(b, m, deterministic)
+func (m *GetWitnessScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessScheduleRequest.Marshal(b, m, deterministic)
 }
-func (m *GetProducerVoteInfoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetProducerVoteInfoRequest.Merge(m, src)
+func (m *GetWitnessScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessScheduleRequest.Merge(m, src)
 }
-func (m *GetProducerVoteInfoRequest) XXX_Size() int {
-	return xxx_messageInfo_GetProducerVoteInfoRequest.Size(m)
+func (m *GetWitnessScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessScheduleRequest.Size(m)
 }
-func (m *GetProducerVoteInfoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetProducerVoteInfoRequest.DiscardUnknown(m)
+func (m *GetWitnessScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessScheduleRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetProducerVoteInfoRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetWitnessScheduleRequest proto.InternalMessageInfo
 
-func (m *GetProducerVoteInfoRequest) GetAccount() string {
+func (m *GetWitnessScheduleRequest) GetBlockCount() int64 {
 	if m != nil {
-		return m.Account
+		return m.BlockCount
 	}
-	return ""
+	return 0
 }
 
-func (m *GetProducerVoteInfoRequest) GetByLongestChain() bool {
+// The message defines get witness schedule response.
+type GetWitnessScheduleResponse struct {
+	// head block number
+	HeadBlock int64 `protobuf:"varint,1,opt,name=head_block,json=headBlock,proto3" json:"head_block,omitempty"`
+	// the first block number the statistics are counted from
+	FirstBlock int64 `protobuf:"varint,2,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
+	// the current slot, which is the time in seconds divided by slot_length
+	CurrentSlot int64 `protobuf:"varint,3,opt,name=current_slot,json=currentSlot,proto3" json:"current_slot,omitempty"`
+	// slot length in seconds
+	SlotLength int64 `protobuf:"varint,4,opt,name=slot_length,json=slotLength,proto3" json:"slot_length,omitempty"`
+	// the witnesses of the head block in the order of their slots
+	Witnesses            []*GetWitnessScheduleResponse_Witness `protobuf:"bytes,5,rep,name=witnesses,proto3" json:"witnesses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *GetWitnessScheduleResponse) Reset()         { *m = GetWitnessScheduleResponse{} }
+func (m *GetWitnessScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessScheduleResponse) ProtoMessage()    {}
+func (*GetWitnessScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{50}
+}
+
+func (m *GetWitnessScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessScheduleResponse.Unmarshal(m, b)
+}
+func (m *GetWitnessScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWitnessScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessScheduleResponse.Merge(m, src)
+}
+func (m *GetWitnessScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessScheduleResponse.Size(m)
+}
+func (m *GetWitnessScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWitnessScheduleResponse proto.InternalMessageInfo
+
+func (m *GetWitnessScheduleResponse) GetHeadBlock() int64 {
 	if m != nil {
-		return m.ByLongestChain
+		return m.HeadBlock
 	}
-	return false
+	return 0
 }
 
-type GetProducerVoteInfoResponse struct {
-	// producer's public key
+func (m *GetWitnessScheduleResponse) GetFirstBlock() int64 {
+	if m != nil {
+		return m.FirstBlock
+	}
+	return 0
+}
+
+func (m *GetWitnessScheduleResponse) GetCurrentSlot() int64 {
+	if m != nil {
+		return m.CurrentSlot
+	}
+	return 0
+}
+
+func (m *GetWitnessScheduleResponse) GetSlotLength() int64 {
+	if m != nil {
+		return m.SlotLength
+	}
+	return 0
+}
+
+func (m *GetWitnessScheduleResponse) GetWitnesses() []*GetWitnessScheduleResponse_Witness {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
+}
+
+// The message defines the schedule and the statistics of a witness.
+type GetWitnessScheduleResponse_Witness struct {
+	// witness's public key
 	Pubkey string `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
-	// producer's location
-	Loc string `protobuf:"bytes,2,opt,name=loc,proto3" json:"loc,omitempty"`
-	// producer's url
-	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
-	// producer's net id
-	NetId string `protobuf:"bytes,4,opt,name=net_id,json=netId,proto3" json:"net_id,omitempty"`
-	// is producer
-	IsProducer bool `protobuf:"varint,5,opt,name=is_producer,json=isProducer,proto3" json:"is_producer,omitempty"`
-	// is approved
-	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	// is online
-	Online bool `protobuf:"varint,7,opt,name=online,proto3" json:"online,omitempty"`
-	// votes
-	Votes                float64  `protobuf:"fixed64,8,opt,name=votes,proto3" json:"votes,omitempty"`
+	// the producer account of the public key
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// the position in the witness list, which is the order of the slots
+	Position int64 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	// the start time of the next slot of the witness in nanoseconds, which is the current slot if it is producing
+	NextSlotTime int64 `protobuf:"varint,4,opt,name=next_slot_time,json=nextSlotTime,proto3" json:"next_slot_time,omitempty"`
+	// the number of blocks produced in the counted blocks
+	ProducedBlocks int64 `protobuf:"varint,5,opt,name=produced_blocks,json=producedBlocks,proto3" json:"produced_blocks,omitempty"`
+	// the number of slots of the witness without any block in the counted blocks
+	MissedSlots          int64    `protobuf:"varint,6,opt,name=missed_slots,json=missedSlots,proto3" json:"missed_slots,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetProducerVoteInfoResponse) Reset()         { *m = GetProducerVoteInfoResponse{} }
-func (m *GetProducerVoteInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*GetProducerVoteInfoResponse) ProtoMessage()    {}
-func (*GetProducerVoteInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{20}
+func (m *GetWitnessScheduleResponse_Witness) Reset()         { *m = GetWitnessScheduleResponse_Witness{} }
+func (m *GetWitnessScheduleResponse_Witness) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessScheduleResponse_Witness) ProtoMessage()    {}
+func (*GetWitnessScheduleResponse_Witness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{50, 0}
 }
 
-func (m *GetProducerVoteInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetProducerVoteInfoResponse.Unmarshal(m, b)
+func (m *GetWitnessScheduleResponse_Witness) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessScheduleResponse_Witness.Unmarshal(m, b)
 }
-func (m *GetProducerVoteInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetProducerVoteInfoResponse.Marshal(b, m, deterministic)
+func (m *GetWitnessScheduleResponse_Witness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessScheduleResponse_Witness.Marshal(b, m, deterministic)
 }
-func (m *GetProducerVoteInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetProducerVoteInfoResponse.Merge(m, src)
+func (m *GetWitnessScheduleResponse_Witness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessScheduleResponse_Witness.Merge(m, src)
 }
-func (m *GetProducerVoteInfoResponse) XXX_Size() int {
-	return xxx_messageInfo_GetProducerVoteInfoResponse.Size(m)
+func (m *GetWitnessScheduleResponse_Witness) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessScheduleResponse_Witness.Size(m)
 }
-func (m *GetProducerVoteInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetProducerVoteInfoResponse.DiscardUnknown(m)
+func (m *GetWitnessScheduleResponse_Witness) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessScheduleResponse_Witness.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetProducerVoteInfoResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetWitnessScheduleResponse_Witness proto.InternalMessageInfo
 
-func (m *GetProducerVoteInfoResponse) GetPubkey() string {
+func (m *GetWitnessScheduleResponse_Witness) GetPubkey() string {
 	if m != nil {
 		return m.Pubkey
 	}
 	return ""
 }
 
-func (m *GetProducerVoteInfoResponse) GetLoc() string {
-	if m != nil {
-		return m.Loc
-	}
-	return ""
-}
-
-func (m *GetProducerVoteInfoResponse) GetUrl() string {
-	if m != nil {
-		return m.Url
-	}
-	return ""
-}
-
-func (m *GetProducerVoteInfoResponse) GetNetId() string {
+func (m *GetWitnessScheduleResponse_Witness) GetAccount() string {
 	if m != nil {
-		return m.NetId
+		return m.Account
 	}
 	return ""
 }
 
-func (m *GetProducerVoteInfoResponse) GetIsProducer() bool {
+func (m *GetWitnessScheduleResponse_Witness) GetPosition() int64 {
 	if m != nil {
-		return m.IsProducer
+		return m.Position
 	}
-	return false
+	return 0
 }
 
-func (m *GetProducerVoteInfoResponse) GetStatus() string {
+func (m *GetWitnessScheduleResponse_Witness) GetNextSlotTime() int64 {
 	if m != nil {
-		return m.Status
+		return m.NextSlotTime
 	}
-	return ""
+	return 0
 }
 
-func (m *GetProducerVoteInfoResponse) GetOnline() bool {
+func (m *GetWitnessScheduleResponse_Witness) GetProducedBlocks() int64 {
 	if m != nil {
-		return m.Online
+		return m.ProducedBlocks
 	}
-	return false
+	return 0
 }
 
-func (m *GetProducerVoteInfoResponse) GetVotes() float64 {
+func (m *GetWitnessScheduleResponse_Witness) GetMissedSlots() int64 {
 	if m != nil {
-		return m.Votes
+		return m.MissedSlots
 	}
 	return 0
 }
@@ -1853,7 +4175,7 @@ func (m *GasRatioResponse) Reset()         { *m = GasRatioResponse{} }
 func (m *GasRatioResponse) String() string { return proto.CompactTextString(m) }
 func (*GasRatioResponse) ProtoMessage()    {}
 func (*GasRatioResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{21}
+	return fileDescriptor_1b773bf3e696f610, []int{51}
 }
 
 func (m *GasRatioResponse) XXX_Unmarshal(b []byte) error {
@@ -1915,7 +4237,7 @@ func (m *Account) Reset()         { *m = Account{} }
 func (m *Account) String() string { return proto.CompactTextString(m) }
 func (*Account) ProtoMessage()    {}
 func (*Account) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22}
+	return fileDescriptor_1b773bf3e696f610, []int{52}
 }
 
 func (m *Account) XXX_Unmarshal(b []byte) error {
@@ -2007,7 +4329,7 @@ func (m *Account_PledgeInfo) Reset()         { *m = Account_PledgeInfo{} }
 func (m *Account_PledgeInfo) String() string { return proto.CompactTextString(m) }
 func (*Account_PledgeInfo) ProtoMessage()    {}
 func (*Account_PledgeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 0}
 }
 
 func (m *Account_PledgeInfo) XXX_Unmarshal(b []byte) error {
@@ -2063,7 +4385,7 @@ func (m *Account_GasInfo) Reset()         { *m = Account_GasInfo{} }
 func (m *Account_GasInfo) String() string { return proto.CompactTextString(m) }
 func (*Account_GasInfo) ProtoMessage()    {}
 func (*Account_GasInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 1}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 1}
 }
 
 func (m *Account_GasInfo) XXX_Unmarshal(b []byte) error {
@@ -2143,7 +4465,7 @@ func (m *Account_RAMInfo) Reset()         { *m = Account_RAMInfo{} }
 func (m *Account_RAMInfo) String() string { return proto.CompactTextString(m) }
 func (*Account_RAMInfo) ProtoMessage()    {}
 func (*Account_RAMInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 2}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 2}
 }
 
 func (m *Account_RAMInfo) XXX_Unmarshal(b []byte) error {
@@ -2204,7 +4526,7 @@ func (m *Account_Item) Reset()         { *m = Account_Item{} }
 func (m *Account_Item) String() string { return proto.CompactTextString(m) }
 func (*Account_Item) ProtoMessage()    {}
 func (*Account_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 3}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 3}
 }
 
 func (m *Account_Item) XXX_Unmarshal(b []byte) error {
@@ -2268,7 +4590,7 @@ func (m *Account_Group) Reset()         { *m = Account_Group{} }
 func (m *Account_Group) String() string { return proto.CompactTextString(m) }
 func (*Account_Group) ProtoMessage()    {}
 func (*Account_Group) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 4}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 4}
 }
 
 func (m *Account_Group) XXX_Unmarshal(b []byte) error {
@@ -2322,7 +4644,7 @@ func (m *Account_Permission) Reset()         { *m = Account_Permission{} }
 func (m *Account_Permission) String() string { return proto.CompactTextString(m) }
 func (*Account_Permission) ProtoMessage()    {}
 func (*Account_Permission) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{22, 5}
+	return fileDescriptor_1b773bf3e696f610, []int{52, 5}
 }
 
 func (m *Account_Permission) XXX_Unmarshal(b []byte) error {
@@ -2376,7 +4698,11 @@ type GetAccountRequest struct {
 	// account name
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// get account by longest chain's head block or last irreversible block
-	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	ByLongestChain bool `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	// get account with the pending txs executed on the longest chain's head block, implies by_longest_chain, refused unless the node enables pendingstate in its rpc config
+	IncludePending bool `protobuf:"varint,3,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	// get account at the block of number on the longest chain, the blocks before the last irreversible block needing the state history of the node, the current state if 0
+	BlockNumber          int64    `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2386,7 +4712,7 @@ func (m *GetAccountRequest) Reset()         { *m = GetAccountRequest{} }
 func (m *GetAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAccountRequest) ProtoMessage()    {}
 func (*GetAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{23}
+	return fileDescriptor_1b773bf3e696f610, []int{53}
 }
 
 func (m *GetAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -2421,6 +4747,20 @@ func (m *GetAccountRequest) GetByLongestChain() bool {
 	return false
 }
 
+func (m *GetAccountRequest) GetIncludePending() bool {
+	if m != nil {
+		return m.IncludePending
+	}
+	return false
+}
+
+func (m *GetAccountRequest) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 // The message defines the contract struct.
 type Contract struct {
 	// contract id
@@ -2442,7 +4782,7 @@ func (m *Contract) Reset()         { *m = Contract{} }
 func (m *Contract) String() string { return proto.CompactTextString(m) }
 func (*Contract) ProtoMessage()    {}
 func (*Contract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{24}
+	return fileDescriptor_1b773bf3e696f610, []int{54}
 }
 
 func (m *Contract) XXX_Unmarshal(b []byte) error {
@@ -2515,7 +4855,7 @@ func (m *Contract_ABI) Reset()         { *m = Contract_ABI{} }
 func (m *Contract_ABI) String() string { return proto.CompactTextString(m) }
 func (*Contract_ABI) ProtoMessage()    {}
 func (*Contract_ABI) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{24, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{54, 0}
 }
 
 func (m *Contract_ABI) XXX_Unmarshal(b []byte) error {
@@ -2572,7 +4912,7 @@ func (m *GetContractRequest) Reset()         { *m = GetContractRequest{} }
 func (m *GetContractRequest) String() string { return proto.CompactTextString(m) }
 func (*GetContractRequest) ProtoMessage()    {}
 func (*GetContractRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{25}
+	return fileDescriptor_1b773bf3e696f610, []int{55}
 }
 
 func (m *GetContractRequest) XXX_Unmarshal(b []byte) error {
@@ -2616,7 +4956,9 @@ type GetContractStorageRequest struct {
 	// get the value from StateDB, field is needed if StateDB[key] is a map.(we get StateDB[key][field] in this case)
 	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
 	// get data by longest chain's head block or last irreversible block
-	ByLongestChain       bool     `protobuf:"varint,4,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	ByLongestChain bool `protobuf:"varint,4,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	// get data at the block of number on the longest chain, the blocks before the last irreversible block needing the state history of the node, the current state if 0
+	BlockNumber          int64    `protobuf:"varint,5,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2626,7 +4968,7 @@ func (m *GetContractStorageRequest) Reset()         { *m = GetContractStorageReq
 func (m *GetContractStorageRequest) String() string { return proto.CompactTextString(m) }
 func (*GetContractStorageRequest) ProtoMessage()    {}
 func (*GetContractStorageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{26}
+	return fileDescriptor_1b773bf3e696f610, []int{56}
 }
 
 func (m *GetContractStorageRequest) XXX_Unmarshal(b []byte) error {
@@ -2675,6 +5017,13 @@ func (m *GetContractStorageRequest) GetByLongestChain() bool {
 	return false
 }
 
+func (m *GetContractStorageRequest) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 // The message defines get contract storage response.
 type GetContractStorageResponse struct {
 	// the json string data
@@ -2692,7 +5041,7 @@ func (m *GetContractStorageResponse) Reset()         { *m = GetContractStorageRe
 func (m *GetContractStorageResponse) String() string { return proto.CompactTextString(m) }
 func (*GetContractStorageResponse) ProtoMessage()    {}
 func (*GetContractStorageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{27}
+	return fileDescriptor_1b773bf3e696f610, []int{57}
 }
 
 func (m *GetContractStorageResponse) XXX_Unmarshal(b []byte) error {
@@ -2734,6 +5083,174 @@ func (m *GetContractStorageResponse) GetBlockNumber() int64 {
 	return 0
 }
 
+// The message defines get batch contract storage request.
+type GetBatchContractStorageRequest struct {
+	// the keys to get, as many as the node returns at once
+	Queries []*GetBatchContractStorageRequest_Query `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBatchContractStorageRequest) Reset()         { *m = GetBatchContractStorageRequest{} }
+func (m *GetBatchContractStorageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBatchContractStorageRequest) ProtoMessage()    {}
+func (*GetBatchContractStorageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{58}
+}
+
+func (m *GetBatchContractStorageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBatchContractStorageRequest.Unmarshal(m, b)
+}
+func (m *GetBatchContractStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBatchContractStorageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBatchContractStorageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBatchContractStorageRequest.Merge(m, src)
+}
+func (m *GetBatchContractStorageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBatchContractStorageRequest.Size(m)
+}
+func (m *GetBatchContractStorageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBatchContractStorageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBatchContractStorageRequest proto.InternalMessageInfo
+
+func (m *GetBatchContractStorageRequest) GetQueries() []*GetBatchContractStorageRequest_Query {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+func (m *GetBatchContractStorageRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
+	}
+	return false
+}
+
+// The message defines a key of contract storage.
+type GetBatchContractStorageRequest_Query struct {
+	// contract id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the key in the StateDB
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// the field of StateDB[key], if it is a map
+	Field                string   `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBatchContractStorageRequest_Query) Reset()         { *m = GetBatchContractStorageRequest_Query{} }
+func (m *GetBatchContractStorageRequest_Query) String() string { return proto.CompactTextString(m) }
+func (*GetBatchContractStorageRequest_Query) ProtoMessage()    {}
+func (*GetBatchContractStorageRequest_Query) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{58, 0}
+}
+
+func (m *GetBatchContractStorageRequest_Query) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBatchContractStorageRequest_Query.Unmarshal(m, b)
+}
+func (m *GetBatchContractStorageRequest_Query) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBatchContractStorageRequest_Query.Marshal(b, m, deterministic)
+}
+func (m *GetBatchContractStorageRequest_Query) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBatchContractStorageRequest_Query.Merge(m, src)
+}
+func (m *GetBatchContractStorageRequest_Query) XXX_Size() int {
+	return xxx_messageInfo_GetBatchContractStorageRequest_Query.Size(m)
+}
+func (m *GetBatchContractStorageRequest_Query) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBatchContractStorageRequest_Query.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBatchContractStorageRequest_Query proto.InternalMessageInfo
+
+func (m *GetBatchContractStorageRequest_Query) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetBatchContractStorageRequest_Query) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetBatchContractStorageRequest_Query) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+// The message defines get batch contract storage response.
+type GetBatchContractStorageResponse struct {
+	// the json string data of the queries, in order
+	Data []string `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// block hash
+	BlockHash string `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// block number
+	BlockNumber          int64    `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBatchContractStorageResponse) Reset()         { *m = GetBatchContractStorageResponse{} }
+func (m *GetBatchContractStorageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBatchContractStorageResponse) ProtoMessage()    {}
+func (*GetBatchContractStorageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{59}
+}
+
+func (m *GetBatchContractStorageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBatchContractStorageResponse.Unmarshal(m, b)
+}
+func (m *GetBatchContractStorageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBatchContractStorageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBatchContractStorageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBatchContractStorageResponse.Merge(m, src)
+}
+func (m *GetBatchContractStorageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBatchContractStorageResponse.Size(m)
+}
+func (m *GetBatchContractStorageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBatchContractStorageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBatchContractStorageResponse proto.InternalMessageInfo
+
+func (m *GetBatchContractStorageResponse) GetData() []string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetBatchContractStorageResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *GetBatchContractStorageResponse) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 // The message defines get contract storage request.
 type GetContractStorageFieldsRequest struct {
 	// contract id
@@ -2751,7 +5268,7 @@ func (m *GetContractStorageFieldsRequest) Reset()         { *m = GetContractStor
 func (m *GetContractStorageFieldsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetContractStorageFieldsRequest) ProtoMessage()    {}
 func (*GetContractStorageFieldsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{28}
+	return fileDescriptor_1b773bf3e696f610, []int{60}
 }
 
 func (m *GetContractStorageFieldsRequest) XXX_Unmarshal(b []byte) error {
@@ -2810,7 +5327,7 @@ func (m *GetContractStorageFieldsResponse) Reset()         { *m = GetContractSto
 func (m *GetContractStorageFieldsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetContractStorageFieldsResponse) ProtoMessage()    {}
 func (*GetContractStorageFieldsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{29}
+	return fileDescriptor_1b773bf3e696f610, []int{61}
 }
 
 func (m *GetContractStorageFieldsResponse) XXX_Unmarshal(b []byte) error {
@@ -2865,7 +5382,7 @@ func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse
 func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionResponse) ProtoMessage()    {}
 func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{30}
+	return fileDescriptor_1b773bf3e696f610, []int{62}
 }
 
 func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
@@ -2908,7 +5425,7 @@ func (m *GetTokenBalanceResponse) Reset()         { *m = GetTokenBalanceResponse
 func (m *GetTokenBalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTokenBalanceResponse) ProtoMessage()    {}
 func (*GetTokenBalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{31}
+	return fileDescriptor_1b773bf3e696f610, []int{63}
 }
 
 func (m *GetTokenBalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -2950,7 +5467,11 @@ type GetTokenBalanceRequest struct {
 	// the token name
 	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	// get data by longest chain's head block or last irreversible block
-	ByLongestChain       bool     `protobuf:"varint,3,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	ByLongestChain bool `protobuf:"varint,3,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	// get data with the pending txs executed on the longest chain's head block, implies by_longest_chain, refused unless the node enables pendingstate in its rpc config
+	IncludePending bool `protobuf:"varint,4,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	// get data at the block of number on the longest chain, the blocks before the last irreversible block needing the state history of the node, the current state if 0
+	BlockNumber          int64    `protobuf:"varint,5,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2960,54 +5481,147 @@ func (m *GetTokenBalanceRequest) Reset()         { *m = GetTokenBalanceRequest{}
 func (m *GetTokenBalanceRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTokenBalanceRequest) ProtoMessage()    {}
 func (*GetTokenBalanceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{32}
+	return fileDescriptor_1b773bf3e696f610, []int{64}
+}
+
+func (m *GetTokenBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTokenBalanceRequest.Unmarshal(m, b)
+}
+func (m *GetTokenBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTokenBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTokenBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokenBalanceRequest.Merge(m, src)
+}
+func (m *GetTokenBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTokenBalanceRequest.Size(m)
+}
+func (m *GetTokenBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokenBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokenBalanceRequest proto.InternalMessageInfo
+
+func (m *GetTokenBalanceRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *GetTokenBalanceRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetTokenBalanceRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
+	}
+	return false
+}
+
+func (m *GetTokenBalanceRequest) GetIncludePending() bool {
+	if m != nil {
+		return m.IncludePending
+	}
+	return false
+}
+
+func (m *GetTokenBalanceRequest) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// The message defines get token721 balance request.
+type GetToken721BalanceRequest struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// the token name
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain bool `protobuf:"varint,3,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	// max count of token ids returned, 1000 if 0, at most 1000
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// cursor of the previous page to get the next one, empty for the first
+	Cursor               string   `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetToken721BalanceRequest) Reset()         { *m = GetToken721BalanceRequest{} }
+func (m *GetToken721BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetToken721BalanceRequest) ProtoMessage()    {}
+func (*GetToken721BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{65}
 }
 
-func (m *GetTokenBalanceRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTokenBalanceRequest.Unmarshal(m, b)
+func (m *GetToken721BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetToken721BalanceRequest.Unmarshal(m, b)
 }
-func (m *GetTokenBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTokenBalanceRequest.Marshal(b, m, deterministic)
+func (m *GetToken721BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetToken721BalanceRequest.Marshal(b, m, deterministic)
 }
-func (m *GetTokenBalanceRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTokenBalanceRequest.Merge(m, src)
+func (m *GetToken721BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetToken721BalanceRequest.Merge(m, src)
 }
-func (m *GetTokenBalanceRequest) XXX_Size() int {
-	return xxx_messageInfo_GetTokenBalanceRequest.Size(m)
+func (m *GetToken721BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetToken721BalanceRequest.Size(m)
 }
-func (m *GetTokenBalanceRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTokenBalanceRequest.DiscardUnknown(m)
+func (m *GetToken721BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetToken721BalanceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTokenBalanceRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetToken721BalanceRequest proto.InternalMessageInfo
 
-func (m *GetTokenBalanceRequest) GetAccount() string {
+func (m *GetToken721BalanceRequest) GetAccount() string {
 	if m != nil {
 		return m.Account
 	}
 	return ""
 }
 
-func (m *GetTokenBalanceRequest) GetToken() string {
+func (m *GetToken721BalanceRequest) GetToken() string {
 	if m != nil {
 		return m.Token
 	}
 	return ""
 }
 
-func (m *GetTokenBalanceRequest) GetByLongestChain() bool {
+func (m *GetToken721BalanceRequest) GetByLongestChain() bool {
 	if m != nil {
 		return m.ByLongestChain
 	}
 	return false
 }
 
+func (m *GetToken721BalanceRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetToken721BalanceRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
 // The message defines get token721 balance response.
 type GetToken721BalanceResponse struct {
 	// token balance
 	Balance int64 `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
-	// balance information
-	TokenIDs             []string `protobuf:"bytes,2,rep,name=tokenIDs,proto3" json:"tokenIDs,omitempty"`
+	// a page of the token ids held, sorted
+	TokenIDs []string `protobuf:"bytes,2,rep,name=tokenIDs,proto3" json:"tokenIDs,omitempty"`
+	// cursor of the next page, empty if there are no more token ids
+	Cursor               string   `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3017,7 +5631,7 @@ func (m *GetToken721BalanceResponse) Reset()         { *m = GetToken721BalanceRe
 func (m *GetToken721BalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721BalanceResponse) ProtoMessage()    {}
 func (*GetToken721BalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{33}
+	return fileDescriptor_1b773bf3e696f610, []int{66}
 }
 
 func (m *GetToken721BalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -3052,6 +5666,13 @@ func (m *GetToken721BalanceResponse) GetTokenIDs() []string {
 	return nil
 }
 
+func (m *GetToken721BalanceResponse) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
 // The message defines get token721 info request.
 type GetToken721InfoRequest struct {
 	// the token name
@@ -3069,7 +5690,7 @@ func (m *GetToken721InfoRequest) Reset()         { *m = GetToken721InfoRequest{}
 func (m *GetToken721InfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetToken721InfoRequest) ProtoMessage()    {}
 func (*GetToken721InfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{34}
+	return fileDescriptor_1b773bf3e696f610, []int{67}
 }
 
 func (m *GetToken721InfoRequest) XXX_Unmarshal(b []byte) error {
@@ -3124,7 +5745,7 @@ func (m *GetToken721MetadataResponse) Reset()         { *m = GetToken721Metadata
 func (m *GetToken721MetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721MetadataResponse) ProtoMessage()    {}
 func (*GetToken721MetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{35}
+	return fileDescriptor_1b773bf3e696f610, []int{68}
 }
 
 func (m *GetToken721MetadataResponse) XXX_Unmarshal(b []byte) error {
@@ -3165,7 +5786,7 @@ func (m *GetToken721OwnerResponse) Reset()         { *m = GetToken721OwnerRespon
 func (m *GetToken721OwnerResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721OwnerResponse) ProtoMessage()    {}
 func (*GetToken721OwnerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{36}
+	return fileDescriptor_1b773bf3e696f610, []int{69}
 }
 
 func (m *GetToken721OwnerResponse) XXX_Unmarshal(b []byte) error {
@@ -3193,6 +5814,215 @@ func (m *GetToken721OwnerResponse) GetOwner() string {
 	return ""
 }
 
+// The message defines get account tokens request.
+type GetAccountTokensRequest struct {
+	// account name
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountTokensRequest) Reset()         { *m = GetAccountTokensRequest{} }
+func (m *GetAccountTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTokensRequest) ProtoMessage()    {}
+func (*GetAccountTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{70}
+}
+
+func (m *GetAccountTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTokensRequest.Unmarshal(m, b)
+}
+func (m *GetAccountTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTokensRequest.Merge(m, src)
+}
+func (m *GetAccountTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTokensRequest.Size(m)
+}
+func (m *GetAccountTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTokensRequest proto.InternalMessageInfo
+
+func (m *GetAccountTokensRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *GetAccountTokensRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
+	}
+	return false
+}
+
+// The message defines get account tokens response.
+type GetAccountTokensResponse struct {
+	// the tokens held, sorted by name
+	Tokens []*GetAccountTokensResponse_Token `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	// the token721 tokens held, sorted by name
+	Token721S            []*GetAccountTokensResponse_Token721 `protobuf:"bytes,2,rep,name=token721s,proto3" json:"token721s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *GetAccountTokensResponse) Reset()         { *m = GetAccountTokensResponse{} }
+func (m *GetAccountTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTokensResponse) ProtoMessage()    {}
+func (*GetAccountTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{71}
+}
+
+func (m *GetAccountTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTokensResponse.Unmarshal(m, b)
+}
+func (m *GetAccountTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTokensResponse.Merge(m, src)
+}
+func (m *GetAccountTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTokensResponse.Size(m)
+}
+func (m *GetAccountTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTokensResponse proto.InternalMessageInfo
+
+func (m *GetAccountTokensResponse) GetTokens() []*GetAccountTokensResponse_Token {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+func (m *GetAccountTokensResponse) GetToken721S() []*GetAccountTokensResponse_Token721 {
+	if m != nil {
+		return m.Token721S
+	}
+	return nil
+}
+
+// The message defines the balance of a token.
+type GetAccountTokensResponse_Token struct {
+	// the token name
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// the balance of the token
+	Balance float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// frozen balance information
+	FrozenBalances       []*FrozenBalance `protobuf:"bytes,3,rep,name=frozen_balances,json=frozenBalances,proto3" json:"frozen_balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetAccountTokensResponse_Token) Reset()         { *m = GetAccountTokensResponse_Token{} }
+func (m *GetAccountTokensResponse_Token) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTokensResponse_Token) ProtoMessage()    {}
+func (*GetAccountTokensResponse_Token) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{71, 0}
+}
+
+func (m *GetAccountTokensResponse_Token) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTokensResponse_Token.Unmarshal(m, b)
+}
+func (m *GetAccountTokensResponse_Token) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTokensResponse_Token.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTokensResponse_Token) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTokensResponse_Token.Merge(m, src)
+}
+func (m *GetAccountTokensResponse_Token) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTokensResponse_Token.Size(m)
+}
+func (m *GetAccountTokensResponse_Token) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTokensResponse_Token.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTokensResponse_Token proto.InternalMessageInfo
+
+func (m *GetAccountTokensResponse_Token) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetAccountTokensResponse_Token) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *GetAccountTokensResponse_Token) GetFrozenBalances() []*FrozenBalance {
+	if m != nil {
+		return m.FrozenBalances
+	}
+	return nil
+}
+
+// The message defines the balance of a token721 token.
+type GetAccountTokensResponse_Token721 struct {
+	// the token name
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// the number of tokens held
+	Balance              int64    `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountTokensResponse_Token721) Reset()         { *m = GetAccountTokensResponse_Token721{} }
+func (m *GetAccountTokensResponse_Token721) String() string { return proto.CompactTextString(m) }
+func (*GetAccountTokensResponse_Token721) ProtoMessage()    {}
+func (*GetAccountTokensResponse_Token721) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{71, 1}
+}
+
+func (m *GetAccountTokensResponse_Token721) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountTokensResponse_Token721.Unmarshal(m, b)
+}
+func (m *GetAccountTokensResponse_Token721) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountTokensResponse_Token721.Marshal(b, m, deterministic)
+}
+func (m *GetAccountTokensResponse_Token721) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountTokensResponse_Token721.Merge(m, src)
+}
+func (m *GetAccountTokensResponse_Token721) XXX_Size() int {
+	return xxx_messageInfo_GetAccountTokensResponse_Token721.Size(m)
+}
+func (m *GetAccountTokensResponse_Token721) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountTokensResponse_Token721.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountTokensResponse_Token721 proto.InternalMessageInfo
+
+func (m *GetAccountTokensResponse_Token721) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetAccountTokensResponse_Token721) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
 // The message defines event struct.
 type Event struct {
 	// event topic
@@ -3200,7 +6030,9 @@ type Event struct {
 	// event data
 	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	// event time
-	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	// the contract posting the event
+	ContractId           string   `protobuf:"bytes,4,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3210,7 +6042,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{37}
+	return fileDescriptor_1b773bf3e696f610, []int{72}
 }
 
 func (m *Event) XXX_Unmarshal(b []byte) error {
@@ -3252,6 +6084,13 @@ func (m *Event) GetTime() int64 {
 	return 0
 }
 
+func (m *Event) GetContractId() string {
+	if m != nil {
+		return m.ContractId
+	}
+	return ""
+}
+
 // The message defines subscribe request.
 type SubscribeRequest struct {
 	Topics               []Event_Topic            `protobuf:"varint,1,rep,packed,name=topics,proto3,enum=rpcpb.Event_Topic" json:"topics,omitempty"`
@@ -3265,7 +6104,7 @@ func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
 func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeRequest) ProtoMessage()    {}
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{38}
+	return fileDescriptor_1b773bf3e696f610, []int{73}
 }
 
 func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
@@ -3302,17 +6141,21 @@ func (m *SubscribeRequest) GetFilter() *SubscribeRequest_Filter {
 
 type SubscribeRequest_Filter struct {
 	// contract id
-	ContractId           string   `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ContractId string `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3" json:"contract_id,omitempty"`
+	// contract ids, the events of any of which are sent along with those of contract_id
+	ContractIds []string `protobuf:"bytes,2,rep,name=contract_ids,json=contractIds,proto3" json:"contract_ids,omitempty"`
+	// arguments the data of the events must match, the data being a json array or object
+	Args                 []*SubscribeRequest_ArgFilter `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *SubscribeRequest_Filter) Reset()         { *m = SubscribeRequest_Filter{} }
 func (m *SubscribeRequest_Filter) String() string { return proto.CompactTextString(m) }
 func (*SubscribeRequest_Filter) ProtoMessage()    {}
 func (*SubscribeRequest_Filter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{38, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{73, 0}
 }
 
 func (m *SubscribeRequest_Filter) XXX_Unmarshal(b []byte) error {
@@ -3340,6 +6183,69 @@ func (m *SubscribeRequest_Filter) GetContractId() string {
 	return ""
 }
 
+func (m *SubscribeRequest_Filter) GetContractIds() []string {
+	if m != nil {
+		return m.ContractIds
+	}
+	return nil
+}
+
+func (m *SubscribeRequest_Filter) GetArgs() []*SubscribeRequest_ArgFilter {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type SubscribeRequest_ArgFilter struct {
+	// index of the argument in the json array, or its key in the json object
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// values one of which the argument must equal, strings being compared without quotes
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest_ArgFilter) Reset()         { *m = SubscribeRequest_ArgFilter{} }
+func (m *SubscribeRequest_ArgFilter) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest_ArgFilter) ProtoMessage()    {}
+func (*SubscribeRequest_ArgFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{73, 1}
+}
+
+func (m *SubscribeRequest_ArgFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest_ArgFilter.Unmarshal(m, b)
+}
+func (m *SubscribeRequest_ArgFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest_ArgFilter.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest_ArgFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest_ArgFilter.Merge(m, src)
+}
+func (m *SubscribeRequest_ArgFilter) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest_ArgFilter.Size(m)
+}
+func (m *SubscribeRequest_ArgFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest_ArgFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest_ArgFilter proto.InternalMessageInfo
+
+func (m *SubscribeRequest_ArgFilter) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SubscribeRequest_ArgFilter) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // The message defines subscribe response.
 type SubscribeResponse struct {
 	Event                *Event   `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -3352,7 +6258,7 @@ func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
 func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
 func (*SubscribeResponse) ProtoMessage()    {}
 func (*SubscribeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{39}
+	return fileDescriptor_1b773bf3e696f610, []int{74}
 }
 
 func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
@@ -3371,25 +6277,155 @@ func (m *SubscribeResponse) XXX_DiscardUnknown() {
 	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+func (m *SubscribeResponse) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+// The message defines block subscription request.
+type SubscribeBlocksRequest struct {
+	// height of the first block to send, 0 for the block after the head block
+	FromNumber int64 `protobuf:"varint,1,opt,name=from_number,json=fromNumber,proto3" json:"from_number,omitempty"`
+	// resume token of the last block received, to send the blocks following it; from_number is ignored if set
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// complete means return all transactions and receipts of the blocks
+	Complete bool `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	// only send the irreversible blocks
+	Irreversible         bool     `protobuf:"varint,4,opt,name=irreversible,proto3" json:"irreversible,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeBlocksRequest) Reset()         { *m = SubscribeBlocksRequest{} }
+func (m *SubscribeBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeBlocksRequest) ProtoMessage()    {}
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{75}
+}
+
+func (m *SubscribeBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeBlocksRequest.Unmarshal(m, b)
+}
+func (m *SubscribeBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeBlocksRequest.Merge(m, src)
+}
+func (m *SubscribeBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeBlocksRequest.Size(m)
+}
+func (m *SubscribeBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeBlocksRequest proto.InternalMessageInfo
+
+func (m *SubscribeBlocksRequest) GetFromNumber() int64 {
+	if m != nil {
+		return m.FromNumber
+	}
+	return 0
+}
+
+func (m *SubscribeBlocksRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *SubscribeBlocksRequest) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+func (m *SubscribeBlocksRequest) GetIrreversible() bool {
+	if m != nil {
+		return m.Irreversible
+	}
+	return false
+}
+
+// The message defines block subscription response.
+type SubscribeBlocksResponse struct {
+	// block status
+	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
+	// block
+	Block *Block `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	// token to resume the subscription after this block
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeBlocksResponse) Reset()         { *m = SubscribeBlocksResponse{} }
+func (m *SubscribeBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeBlocksResponse) ProtoMessage()    {}
+func (*SubscribeBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{76}
+}
+
+func (m *SubscribeBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeBlocksResponse.Unmarshal(m, b)
+}
+func (m *SubscribeBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeBlocksResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeBlocksResponse.Merge(m, src)
+}
+func (m *SubscribeBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeBlocksResponse.Size(m)
+}
+func (m *SubscribeBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeBlocksResponse proto.InternalMessageInfo
 
-func (m *SubscribeResponse) GetEvent() *Event {
+func (m *SubscribeBlocksResponse) GetStatus() BlockResponse_Status {
 	if m != nil {
-		return m.Event
+		return m.Status
+	}
+	return BlockResponse_PENDING
+}
+
+func (m *SubscribeBlocksResponse) GetBlock() *Block {
+	if m != nil {
+		return m.Block
 	}
 	return nil
 }
 
+func (m *SubscribeBlocksResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("rpcpb.TxReceipt_StatusCode", TxReceipt_StatusCode_name, TxReceipt_StatusCode_value)
 	proto.RegisterEnum("rpcpb.TransactionResponse_Status", TransactionResponse_Status_name, TransactionResponse_Status_value)
 	proto.RegisterEnum("rpcpb.Signature_Algorithm", Signature_Algorithm_name, Signature_Algorithm_value)
+	proto.RegisterEnum("rpcpb.StorageTrace_Op", StorageTrace_Op_name, StorageTrace_Op_value)
 	proto.RegisterEnum("rpcpb.BlockResponse_Status", BlockResponse_Status_name, BlockResponse_Status_value)
+	proto.RegisterEnum("rpcpb.StateChange_Kind", StateChange_Kind_name, StateChange_Kind_value)
 	proto.RegisterEnum("rpcpb.Event_Topic", Event_Topic_name, Event_Topic_value)
 	proto.RegisterType((*EmptyRequest)(nil), "rpcpb.EmptyRequest")
 	proto.RegisterType((*NetworkInfo)(nil), "rpcpb.NetworkInfo")
 	proto.RegisterType((*RAMInfoResponse)(nil), "rpcpb.RAMInfoResponse")
 	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
+	proto.RegisterType((*NodeStatusResponse)(nil), "rpcpb.NodeStatusResponse")
 	proto.RegisterType((*AmountLimit)(nil), "rpcpb.AmountLimit")
 	proto.RegisterType((*Action)(nil), "rpcpb.Action")
 	proto.RegisterType((*TxReceipt)(nil), "rpcpb.TxReceipt")
@@ -3397,19 +6433,51 @@ func init() {
 	proto.RegisterType((*TxReceipt_Receipt)(nil), "rpcpb.TxReceipt.Receipt")
 	proto.RegisterType((*Transaction)(nil), "rpcpb.Transaction")
 	proto.RegisterType((*TransactionResponse)(nil), "rpcpb.TransactionResponse")
+	proto.RegisterType((*GetTxsByAccountRequest)(nil), "rpcpb.GetTxsByAccountRequest")
+	proto.RegisterType((*GetTxsByAccountResponse)(nil), "rpcpb.GetTxsByAccountResponse")
+	proto.RegisterType((*GetAccountTxsRequest)(nil), "rpcpb.GetAccountTxsRequest")
+	proto.RegisterType((*GetAccountTxsResponse)(nil), "rpcpb.GetAccountTxsResponse")
+	proto.RegisterType((*GetDelaytxsByAccountRequest)(nil), "rpcpb.GetDelaytxsByAccountRequest")
+	proto.RegisterType((*GetDelaytxsByAccountResponse)(nil), "rpcpb.GetDelaytxsByAccountResponse")
+	proto.RegisterType((*GetPendingTransactionsRequest)(nil), "rpcpb.GetPendingTransactionsRequest")
+	proto.RegisterType((*GetPendingTransactionsResponse)(nil), "rpcpb.GetPendingTransactionsResponse")
 	proto.RegisterType((*Signature)(nil), "rpcpb.Signature")
 	proto.RegisterType((*TransactionRequest)(nil), "rpcpb.TransactionRequest")
+	proto.RegisterType((*EstimateGasResponse)(nil), "rpcpb.EstimateGasResponse")
+	proto.RegisterType((*TraceTransactionResponse)(nil), "rpcpb.TraceTransactionResponse")
+	proto.RegisterType((*CallTrace)(nil), "rpcpb.CallTrace")
+	proto.RegisterType((*GasProfile)(nil), "rpcpb.GasProfile")
+	proto.RegisterType((*StorageTrace)(nil), "rpcpb.StorageTrace")
 	proto.RegisterType((*Block)(nil), "rpcpb.Block")
 	proto.RegisterType((*Block_Info)(nil), "rpcpb.Block.Info")
 	proto.RegisterType((*BlockResponse)(nil), "rpcpb.BlockResponse")
+	proto.RegisterType((*BlockHeader)(nil), "rpcpb.BlockHeader")
+	proto.RegisterType((*BlockHeaderResponse)(nil), "rpcpb.BlockHeaderResponse")
 	proto.RegisterType((*ChainInfoResponse)(nil), "rpcpb.ChainInfoResponse")
+	proto.RegisterType((*ChainStatusResponse)(nil), "rpcpb.ChainStatusResponse")
 	proto.RegisterType((*TxHashRequest)(nil), "rpcpb.TxHashRequest")
 	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
 	proto.RegisterType((*GetBlockByNumberRequest)(nil), "rpcpb.GetBlockByNumberRequest")
+	proto.RegisterType((*GetBlockHeaderByHashRequest)(nil), "rpcpb.GetBlockHeaderByHashRequest")
+	proto.RegisterType((*GetBlockHeaderByNumberRequest)(nil), "rpcpb.GetBlockHeaderByNumberRequest")
+	proto.RegisterType((*GetBlocksRequest)(nil), "rpcpb.GetBlocksRequest")
+	proto.RegisterType((*GetBlocksResponse)(nil), "rpcpb.GetBlocksResponse")
+	proto.RegisterType((*GetBlockStateChangesRequest)(nil), "rpcpb.GetBlockStateChangesRequest")
+	proto.RegisterType((*StateChange)(nil), "rpcpb.StateChange")
+	proto.RegisterType((*GetBlockStateChangesResponse)(nil), "rpcpb.GetBlockStateChangesResponse")
 	proto.RegisterType((*FrozenBalance)(nil), "rpcpb.FrozenBalance")
 	proto.RegisterType((*VoteInfo)(nil), "rpcpb.VoteInfo")
 	proto.RegisterType((*GetProducerVoteInfoRequest)(nil), "rpcpb.GetProducerVoteInfoRequest")
 	proto.RegisterType((*GetProducerVoteInfoResponse)(nil), "rpcpb.GetProducerVoteInfoResponse")
+	proto.RegisterType((*GetProducersRequest)(nil), "rpcpb.GetProducersRequest")
+	proto.RegisterType((*GetProducersResponse)(nil), "rpcpb.GetProducersResponse")
+	proto.RegisterType((*GetProducersResponse_Producer)(nil), "rpcpb.GetProducersResponse.Producer")
+	proto.RegisterType((*GetVotersRequest)(nil), "rpcpb.GetVotersRequest")
+	proto.RegisterType((*GetVotersResponse)(nil), "rpcpb.GetVotersResponse")
+	proto.RegisterType((*GetVotersResponse_Voter)(nil), "rpcpb.GetVotersResponse.Voter")
+	proto.RegisterType((*GetWitnessScheduleRequest)(nil), "rpcpb.GetWitnessScheduleRequest")
+	proto.RegisterType((*GetWitnessScheduleResponse)(nil), "rpcpb.GetWitnessScheduleResponse")
+	proto.RegisterType((*GetWitnessScheduleResponse_Witness)(nil), "rpcpb.GetWitnessScheduleResponse.Witness")
 	proto.RegisterType((*GasRatioResponse)(nil), "rpcpb.GasRatioResponse")
 	proto.RegisterType((*Account)(nil), "rpcpb.Account")
 	proto.RegisterMapType((map[string]*Account_Group)(nil), "rpcpb.Account.GroupsEntry")
@@ -3426,242 +6494,393 @@ func init() {
 	proto.RegisterType((*GetContractRequest)(nil), "rpcpb.GetContractRequest")
 	proto.RegisterType((*GetContractStorageRequest)(nil), "rpcpb.GetContractStorageRequest")
 	proto.RegisterType((*GetContractStorageResponse)(nil), "rpcpb.GetContractStorageResponse")
+	proto.RegisterType((*GetBatchContractStorageRequest)(nil), "rpcpb.GetBatchContractStorageRequest")
+	proto.RegisterType((*GetBatchContractStorageRequest_Query)(nil), "rpcpb.GetBatchContractStorageRequest.Query")
+	proto.RegisterType((*GetBatchContractStorageResponse)(nil), "rpcpb.GetBatchContractStorageResponse")
 	proto.RegisterType((*GetContractStorageFieldsRequest)(nil), "rpcpb.GetContractStorageFieldsRequest")
 	proto.RegisterType((*GetContractStorageFieldsResponse)(nil), "rpcpb.GetContractStorageFieldsResponse")
 	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
 	proto.RegisterType((*GetTokenBalanceResponse)(nil), "rpcpb.GetTokenBalanceResponse")
 	proto.RegisterType((*GetTokenBalanceRequest)(nil), "rpcpb.GetTokenBalanceRequest")
+	proto.RegisterType((*GetToken721BalanceRequest)(nil), "rpcpb.GetToken721BalanceRequest")
 	proto.RegisterType((*GetToken721BalanceResponse)(nil), "rpcpb.GetToken721BalanceResponse")
 	proto.RegisterType((*GetToken721InfoRequest)(nil), "rpcpb.GetToken721InfoRequest")
 	proto.RegisterType((*GetToken721MetadataResponse)(nil), "rpcpb.GetToken721MetadataResponse")
 	proto.RegisterType((*GetToken721OwnerResponse)(nil), "rpcpb.GetToken721OwnerResponse")
+	proto.RegisterType((*GetAccountTokensRequest)(nil), "rpcpb.GetAccountTokensRequest")
+	proto.RegisterType((*GetAccountTokensResponse)(nil), "rpcpb.GetAccountTokensResponse")
+	proto.RegisterType((*GetAccountTokensResponse_Token)(nil), "rpcpb.GetAccountTokensResponse.Token")
+	proto.RegisterType((*GetAccountTokensResponse_Token721)(nil), "rpcpb.GetAccountTokensResponse.Token721")
 	proto.RegisterType((*Event)(nil), "rpcpb.Event")
 	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
 	proto.RegisterType((*SubscribeRequest_Filter)(nil), "rpcpb.SubscribeRequest.Filter")
+	proto.RegisterType((*SubscribeRequest_ArgFilter)(nil), "rpcpb.SubscribeRequest.ArgFilter")
 	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+	proto.RegisterType((*SubscribeBlocksRequest)(nil), "rpcpb.SubscribeBlocksRequest")
+	proto.RegisterType((*SubscribeBlocksResponse)(nil), "rpcpb.SubscribeBlocksResponse")
 }
 
 func init() { proto.RegisterFile("rpc/pb/rpc.proto", fileDescriptor_1b773bf3e696f610) }
 
 var fileDescriptor_1b773bf3e696f610 = []byte{
-	// 3460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x39, 0x4d, 0x73, 0x1b, 0xc7,
-	0xb1, 0x5a, 0x7c, 0x6f, 0x03, 0x04, 0xa1, 0x21, 0x25, 0x41, 0xd0, 0x17, 0xb5, 0x96, 0xad, 0x8f,
-	0xb2, 0x09, 0x8b, 0xb2, 0x2c, 0x4b, 0x96, 0xdf, 0x33, 0x48, 0x41, 0x34, 0x4b, 0x12, 0x48, 0x2f,
-	0x40, 0xe9, 0xb9, 0xea, 0xbd, 0x5a, 0x2f, 0x80, 0x21, 0xb8, 0x25, 0x60, 0x17, 0x6f, 0x77, 0x21,
-	0x81, 0x8f, 0x4f, 0x97, 0x1c, 0x53, 0xa9, 0xa4, 0x5c, 0x3e, 0x24, 0x87, 0x5c, 0x72, 0xf5, 0x0f,
-	0x48, 0x52, 0x95, 0x9f, 0x90, 0x63, 0x0e, 0x39, 0xe6, 0x90, 0xfc, 0x80, 0x54, 0xf9, 0x9c, 0xaa,
-	0xd4, 0xf4, 0xcc, 0xec, 0x2e, 0x16, 0x0b, 0x89, 0xa9, 0xca, 0x09, 0xdb, 0x3d, 0x3d, 0xdd, 0x3d,
-	0xd3, 0x9f, 0xd3, 0x80, 0x8a, 0x3b, 0xee, 0xd5, 0xc7, 0xdd, 0xba, 0x3b, 0xee, 0xad, 0x8f, 0x5d,
-	0xc7, 0x77, 0x48, 0xd6, 0x1d, 0xf7, 0xc6, 0xdd, 0xda, 0xc5, 0x81, 0xe3, 0x0c, 0x86, 0xb4, 0x6e,
-	0x8e, 0xad, 0xba, 0x69, 0xdb, 0x8e, 0x6f, 0xfa, 0x96, 0x63, 0x7b, 0x9c, 0x48, 0x2b, 0x43, 0xa9,
-	0x39, 0x1a, 0xfb, 0x47, 0x3a, 0xfd, 0xdf, 0x09, 0xf5, 0x7c, 0xed, 0x21, 0x14, 0x5b, 0xd4, 0x7f,
-	0xed, 0xb8, 0x2f, 0x77, 0xec, 0x03, 0x87, 0x94, 0x21, 0x65, 0xf5, 0xab, 0xca, 0x9a, 0x72, 0x43,
-	0xd5, 0x53, 0x56, 0x9f, 0x5c, 0x02, 0x18, 0x53, 0xea, 0x1a, 0x3d, 0x67, 0x62, 0xfb, 0xd5, 0xd4,
-	0x9a, 0x72, 0x23, 0xab, 0xab, 0x0c, 0xb3, 0xc5, 0x10, 0xda, 0x0f, 0x0a, 0x2c, 0xeb, 0x8d, 0x67,
-	0x6c, 0xab, 0x4e, 0xbd, 0xb1, 0x63, 0x7b, 0x94, 0x9c, 0x87, 0xc2, 0xc4, 0xa3, 0x7d, 0xc3, 0x35,
-	0x47, 0xc8, 0x28, 0xad, 0xe7, 0x19, 0xac, 0x9b, 0x23, 0xf2, 0x1e, 0x2c, 0x99, 0xaf, 0x4c, 0x6b,
-	0x68, 0x76, 0x87, 0x14, 0xd7, 0x53, 0xb8, 0x5e, 0x0a, 0x90, 0x8c, 0xe8, 0x02, 0xa8, 0xbe, 0xe3,
-	0x9b, 0x43, 0x24, 0x48, 0x23, 0x41, 0x01, 0x11, 0x6c, 0xf1, 0x12, 0x80, 0x47, 0x87, 0x43, 0x63,
-	0xec, 0x5a, 0x3d, 0x5a, 0xcd, 0xac, 0x29, 0x37, 0x14, 0x5d, 0x65, 0x98, 0x3d, 0x86, 0x60, 0x7b,
-	0xbb, 0x93, 0x23, 0xb1, 0x9a, 0xc5, 0xd5, 0x42, 0x77, 0x72, 0x84, 0x8b, 0xda, 0xcf, 0x15, 0xa8,
-	0xb4, 0x9c, 0x3e, 0x9d, 0xd1, 0xf6, 0x12, 0x40, 0x77, 0x62, 0x0d, 0xfb, 0x86, 0x6f, 0x8d, 0xa8,
-	0x38, 0xb8, 0x8a, 0x98, 0x8e, 0x35, 0xc2, 0xc3, 0x0c, 0x2c, 0xdf, 0x38, 0x34, 0xbd, 0x43, 0x54,
-	0x56, 0xd5, 0xf3, 0x03, 0xcb, 0xff, 0xca, 0xf4, 0x0e, 0x09, 0x81, 0xcc, 0xc8, 0xe9, 0x53, 0x54,
-	0x51, 0xd5, 0xf1, 0x9b, 0x7c, 0x08, 0x79, 0x9b, 0xdf, 0x26, 0xea, 0x56, 0xdc, 0x20, 0xeb, 0x68,
-	0x94, 0xf5, 0xc8, 0x1d, 0xeb, 0x92, 0x44, 0xbb, 0x0f, 0xc5, 0xc6, 0x88, 0xdd, 0xe3, 0x53, 0x6b,
-	0x64, 0xf9, 0x64, 0x15, 0xb2, 0xbe, 0xf3, 0x92, 0xda, 0x42, 0x0b, 0x0e, 0x30, 0xec, 0x2b, 0x73,
-	0x38, 0xa1, 0x42, 0x3c, 0x07, 0xb4, 0x6f, 0x20, 0xd7, 0xe8, 0x31, 0xbb, 0x92, 0x1a, 0x14, 0x7a,
-	0x8e, 0xed, 0xbb, 0x66, 0xcf, 0x17, 0x1b, 0x03, 0x98, 0x5c, 0x81, 0xa2, 0x89, 0x54, 0x86, 0x6d,
-	0x8e, 0x24, 0x07, 0xe0, 0xa8, 0x96, 0x39, 0xa2, 0xec, 0x0c, 0x7d, 0xd3, 0x37, 0xe5, 0x19, 0xd8,
-	0xb7, 0xf6, 0x97, 0x0c, 0xa8, 0x9d, 0xa9, 0x4e, 0x7b, 0xd4, 0x1a, 0xfb, 0xe4, 0x1c, 0xe4, 0xfd,
-	0x29, 0x3f, 0x3f, 0xe7, 0x9e, 0xf3, 0xa7, 0x78, 0xfc, 0x0b, 0xa0, 0x0e, 0x4c, 0xcf, 0x98, 0x78,
-	0xe6, 0x80, 0x73, 0x56, 0xf4, 0xc2, 0xc0, 0xf4, 0xf6, 0x19, 0x4c, 0x3e, 0x07, 0xd5, 0x35, 0x47,
-	0x62, 0x31, 0xbd, 0x96, 0xbe, 0x51, 0xdc, 0xb8, 0x2c, 0x6e, 0x22, 0x60, 0xbd, 0xae, 0x9b, 0x23,
-	0xa4, 0x6e, 0xda, 0xbe, 0x7b, 0xa4, 0x17, 0x5c, 0x01, 0x92, 0x87, 0x50, 0xf4, 0x7c, 0xd3, 0x9f,
-	0x78, 0x46, 0x8f, 0xdd, 0x2f, 0xbb, 0xc8, 0xf2, 0xc6, 0x85, 0xb9, 0xed, 0x6d, 0xa4, 0xd9, 0x72,
-	0xfa, 0x54, 0x07, 0x2f, 0xf8, 0x26, 0x55, 0xc8, 0x8f, 0xa8, 0x87, 0x82, 0xb3, 0xdc, 0x60, 0x02,
-	0x64, 0x2b, 0x2e, 0xf5, 0x27, 0xae, 0xed, 0x55, 0x73, 0x6b, 0x69, 0xb6, 0x22, 0x40, 0xf2, 0x09,
-	0x14, 0x5c, 0xce, 0xd5, 0xab, 0xe6, 0x51, 0xdb, 0xea, 0xbc, 0xb6, 0xfc, 0x57, 0x0f, 0x28, 0x6b,
-	0x9f, 0xc3, 0xd2, 0xcc, 0x11, 0x48, 0x05, 0xd2, 0x2f, 0xe9, 0x91, 0xb8, 0x27, 0xf6, 0x39, 0x6b,
-	0xbc, 0xb4, 0x30, 0xde, 0x83, 0xd4, 0x67, 0x4a, 0xed, 0x4b, 0xc8, 0xcb, 0x2b, 0xbe, 0x00, 0xea,
-	0xc1, 0xc4, 0xee, 0x71, 0x1b, 0x09, 0x13, 0x32, 0x04, 0x5a, 0xa8, 0x0a, 0x79, 0x66, 0x4e, 0x2a,
-	0xa2, 0x4f, 0xd5, 0x25, 0xa8, 0xfd, 0x4e, 0x01, 0x08, 0xef, 0x80, 0x14, 0x21, 0xdf, 0xde, 0xdf,
-	0xda, 0x6a, 0xb6, 0xdb, 0x95, 0x53, 0x64, 0x19, 0x8a, 0xdb, 0x8d, 0xb6, 0xa1, 0xef, 0xb7, 0x8c,
-	0xdd, 0xfd, 0x4e, 0x45, 0x21, 0x67, 0x81, 0x6c, 0x36, 0x9e, 0x36, 0x5a, 0x5b, 0x4d, 0xa3, 0xb5,
-	0xdb, 0x31, 0x9a, 0xad, 0xdd, 0xfd, 0xed, 0xaf, 0x2a, 0x29, 0xb2, 0x02, 0xcb, 0x2f, 0xf4, 0xdd,
-	0xd6, 0xb6, 0xb1, 0xd7, 0xd0, 0x1b, 0xcf, 0x9a, 0x9d, 0xa6, 0x5e, 0x49, 0x93, 0xd3, 0xb0, 0xa4,
-	0xef, 0xb7, 0x3a, 0x3b, 0xcf, 0x9a, 0x46, 0x53, 0xd7, 0x77, 0xf5, 0x4a, 0x86, 0x71, 0x67, 0x30,
-	0x63, 0x96, 0x0d, 0x37, 0x75, 0xfe, 0xcb, 0x78, 0xbc, 0xab, 0x3f, 0x6b, 0x74, 0x2a, 0x39, 0x26,
-	0xe1, 0xd1, 0xfe, 0xde, 0xd3, 0x9d, 0xad, 0x46, 0xa7, 0x69, 0xb4, 0x9b, 0x1d, 0x63, 0x6b, 0xf7,
-	0x51, 0xb3, 0x92, 0x67, 0xcc, 0xf6, 0x5b, 0x4f, 0x5a, 0xbb, 0x2f, 0x5a, 0x82, 0x59, 0x41, 0xfb,
-	0x21, 0x0d, 0xc5, 0x8e, 0x6b, 0xda, 0x1e, 0xf7, 0x44, 0xe6, 0x85, 0x11, 0x07, 0xc3, 0x6f, 0x86,
-	0xc3, 0x88, 0xe4, 0x17, 0x87, 0xdf, 0xe4, 0x32, 0x00, 0x9d, 0x8e, 0x2d, 0x17, 0x13, 0x9a, 0x48,
-	0x0d, 0x11, 0x8c, 0x74, 0x49, 0x84, 0x44, 0x6e, 0x60, 0x2e, 0xa9, 0x33, 0x58, 0x2e, 0x0e, 0x59,
-	0xa8, 0xc9, 0xd4, 0x30, 0x30, 0xbd, 0x20, 0xf4, 0xfa, 0x74, 0x68, 0x1e, 0x55, 0x73, 0xdc, 0x4e,
-	0x08, 0xb0, 0xe0, 0xef, 0x1d, 0x9a, 0x96, 0x6d, 0x58, 0xfd, 0x6a, 0x7e, 0x4d, 0xb9, 0xb1, 0xa4,
-	0xe7, 0x11, 0xde, 0xe9, 0x93, 0xeb, 0x90, 0xe7, 0xca, 0x7b, 0xd5, 0x02, 0x3a, 0xcc, 0x92, 0x70,
-	0x18, 0x1e, 0x95, 0xba, 0x5c, 0x65, 0xf6, 0xf3, 0xac, 0x81, 0x4d, 0x5d, 0xaf, 0xaa, 0x72, 0xa7,
-	0x13, 0x20, 0xb9, 0x08, 0xea, 0x78, 0xd2, 0x1d, 0x5a, 0xde, 0x21, 0x75, 0xab, 0xc0, 0x13, 0x4f,
-	0x80, 0x60, 0xa1, 0xeb, 0xd2, 0x03, 0xea, 0xba, 0xb4, 0x6f, 0xf8, 0xd3, 0x6a, 0x91, 0x87, 0xae,
-	0x44, 0x75, 0xa6, 0xe4, 0x2e, 0x94, 0x4c, 0x4c, 0x1e, 0xe2, 0x48, 0x25, 0x54, 0x43, 0xe6, 0x9b,
-	0x48, 0x5e, 0xd1, 0x8b, 0x66, 0x24, 0xc9, 0xd4, 0x01, 0xfc, 0xa9, 0x21, 0x7c, 0xb8, 0xba, 0x84,
-	0x49, 0xaa, 0x12, 0x77, 0x76, 0x5d, 0xf5, 0xe5, 0xa7, 0xf6, 0x07, 0x05, 0x56, 0x22, 0xc6, 0x0a,
-	0x12, 0xe7, 0x7d, 0xc8, 0xf1, 0xa8, 0x43, 0xb3, 0x95, 0x37, 0xae, 0x4a, 0x26, 0xf3, 0xb4, 0x22,
-	0x54, 0x75, 0xb1, 0x81, 0x7c, 0x02, 0x45, 0x3f, 0xa4, 0x42, 0x13, 0x87, 0x9a, 0x47, 0xf7, 0x47,
-	0xc9, 0xb4, 0x3b, 0x90, 0xe3, 0x7c, 0x98, 0x33, 0xee, 0x35, 0x5b, 0x8f, 0x76, 0x5a, 0xdb, 0x95,
-	0x53, 0x04, 0x20, 0xb7, 0xd7, 0xd8, 0x7a, 0xd2, 0x7c, 0x54, 0x51, 0x48, 0x05, 0x4a, 0x3b, 0xba,
-	0xde, 0x7c, 0xde, 0xd4, 0xdb, 0x3b, 0x9b, 0x4f, 0x9b, 0x95, 0x94, 0xf6, 0x7b, 0x05, 0xd4, 0xb6,
-	0x35, 0xb0, 0x4d, 0x7f, 0xe2, 0x52, 0xf2, 0x19, 0xa8, 0xe6, 0x70, 0xe0, 0xb8, 0x96, 0x7f, 0x38,
-	0x12, 0x6a, 0xd7, 0x84, 0xd8, 0x80, 0x68, 0xbd, 0x21, 0x29, 0xf4, 0x90, 0x98, 0x19, 0xcb, 0x93,
-	0x14, 0xa8, 0x70, 0x49, 0x0f, 0x11, 0x58, 0x25, 0x99, 0xe5, 0x7a, 0x06, 0x8b, 0xff, 0x34, 0x5f,
-	0xe6, 0x98, 0x27, 0xf4, 0x48, 0xfb, 0x04, 0xd4, 0x80, 0x29, 0x53, 0x5e, 0xc4, 0x43, 0xe5, 0x14,
-	0x59, 0x02, 0xb5, 0xdd, 0xdc, 0xda, 0xdb, 0xb8, 0xfb, 0xe9, 0x93, 0xdb, 0x15, 0x85, 0xad, 0x35,
-	0x1f, 0x6d, 0xdc, 0xbd, 0x7b, 0xfb, 0x7e, 0x25, 0xa5, 0xfd, 0x36, 0x0d, 0x64, 0xe6, 0x32, 0xb1,
-	0x60, 0x07, 0x81, 0xa1, 0x2c, 0x0c, 0x8c, 0xd4, 0xdb, 0x03, 0x23, 0xfd, 0xb6, 0xc0, 0xc8, 0x2c,
-	0x0a, 0x8c, 0xec, 0xa2, 0xc0, 0xc8, 0x2d, 0x0c, 0x8c, 0xfc, 0x5b, 0x03, 0x23, 0xee, 0xbf, 0x85,
-	0x93, 0xf9, 0xef, 0xe2, 0x78, 0xfa, 0x18, 0x20, 0xb0, 0x88, 0x57, 0x05, 0x64, 0x57, 0x89, 0x5b,
-	0x57, 0x8f, 0xd0, 0xcc, 0x46, 0x60, 0x31, 0x1e, 0x81, 0xf7, 0xa0, 0x1c, 0x00, 0x86, 0x67, 0x0d,
-	0x3c, 0x11, 0x62, 0xf3, 0x3c, 0x97, 0x02, 0xba, 0xb6, 0x35, 0xf0, 0xb4, 0xbf, 0xa6, 0x21, 0xbb,
-	0x39, 0x74, 0x7a, 0x2f, 0x13, 0x13, 0x5b, 0x15, 0xf2, 0xaf, 0xa8, 0xeb, 0x85, 0x86, 0x92, 0x20,
-	0x0b, 0xf9, 0xb1, 0xe9, 0x52, 0x5b, 0xb4, 0x1b, 0xbc, 0x26, 0x03, 0x47, 0x61, 0xc9, 0xbd, 0x06,
-	0x65, 0x7f, 0x6a, 0x8c, 0xa8, 0xfb, 0x72, 0x48, 0x39, 0x4d, 0x06, 0x69, 0x4a, 0xfe, 0xf4, 0x19,
-	0x22, 0x91, 0xea, 0x0e, 0x9c, 0x0d, 0x23, 0x7c, 0x86, 0x9a, 0xd7, 0xc3, 0x95, 0x20, 0xb6, 0x23,
-	0x9b, 0xce, 0x42, 0xce, 0x9e, 0x8c, 0xba, 0xd4, 0x15, 0x19, 0x50, 0x40, 0x4c, 0xdb, 0xd7, 0x96,
-	0x6f, 0x53, 0xcf, 0xc3, 0x0c, 0xa8, 0xea, 0x12, 0x0c, 0xfc, 0xb0, 0x10, 0xf1, 0xc3, 0x99, 0x9e,
-	0x40, 0x8d, 0xf5, 0x04, 0xe7, 0xa1, 0xe0, 0x4f, 0x45, 0x23, 0x09, 0xfc, 0xe4, 0xfe, 0x14, 0xdb,
-	0x48, 0xf2, 0x3e, 0x64, 0x2c, 0xfb, 0xc0, 0x41, 0x1b, 0x14, 0x37, 0x4e, 0x8b, 0x0b, 0xc6, 0x3b,
-	0x5c, 0xc7, 0x96, 0x09, 0x97, 0xc9, 0xa7, 0x50, 0x8a, 0x24, 0x04, 0x2f, 0x96, 0xf2, 0xa2, 0xb1,
-	0x32, 0x43, 0x57, 0x6b, 0x43, 0x06, 0x9b, 0x5b, 0xd9, 0xb1, 0x29, 0xd8, 0xc6, 0xf2, 0x8e, 0xed,
-	0x2c, 0xe4, 0xfc, 0x43, 0x97, 0x9a, 0x7d, 0xd1, 0xdc, 0x0a, 0x88, 0x19, 0xa3, 0x6b, 0xfa, 0xbd,
-	0x43, 0xc3, 0xb2, 0xfb, 0x74, 0x8a, 0x3d, 0x4c, 0x56, 0x07, 0x44, 0xed, 0x30, 0x8c, 0xf6, 0x9d,
-	0x02, 0x4b, 0xa8, 0x61, 0x90, 0x11, 0xef, 0xc4, 0x32, 0xe2, 0x85, 0xe8, 0x39, 0x16, 0xe5, 0x42,
-	0x0d, 0xb2, 0x5d, 0xb6, 0x2e, 0xb2, 0x60, 0x69, 0x66, 0x0f, 0x5f, 0xd2, 0xae, 0x27, 0x67, 0xbe,
-	0x78, 0xb6, 0x53, 0xb4, 0x3f, 0xa6, 0xe0, 0xf4, 0x16, 0x06, 0x62, 0xac, 0x21, 0xb7, 0xa9, 0x1f,
-	0x6d, 0x2f, 0x58, 0x07, 0x8a, 0xdd, 0xc5, 0x4d, 0xa8, 0xe0, 0xb3, 0xa0, 0xe7, 0x0c, 0x8d, 0xa8,
-	0x57, 0xaa, 0xfa, 0xb2, 0xc4, 0x3f, 0x17, 0xde, 0x19, 0x8d, 0xf9, 0xf4, 0x6c, 0xcc, 0x5f, 0x02,
-	0x38, 0xa4, 0x66, 0xdf, 0xe0, 0x07, 0xc9, 0xa0, 0x6d, 0x55, 0x86, 0xe1, 0x51, 0xf0, 0x01, 0x2c,
-	0x87, 0xcb, 0x51, 0x4f, 0x5c, 0x0a, 0x68, 0x64, 0x47, 0x39, 0xb4, 0xba, 0x82, 0x0b, 0x77, 0xc3,
-	0xc2, 0xd0, 0xea, 0x72, 0x26, 0xd7, 0xa0, 0x1c, 0x2c, 0x72, 0x1e, 0xdc, 0x1f, 0x4b, 0x92, 0x02,
-	0x59, 0x5c, 0x85, 0x92, 0xf0, 0x4f, 0x63, 0x68, 0x79, 0x3c, 0xa9, 0xa8, 0x7a, 0x51, 0xe0, 0x9e,
-	0x5a, 0x9e, 0x4f, 0x6e, 0x40, 0x85, 0x31, 0x9a, 0x21, 0xe3, 0x99, 0x84, 0x09, 0x78, 0x11, 0x52,
-	0x6a, 0xef, 0xc1, 0x52, 0x07, 0x7b, 0xdd, 0x48, 0xea, 0x8d, 0x87, 0xb3, 0xb6, 0x0d, 0x67, 0xb6,
-	0xa9, 0x8f, 0x1a, 0x6c, 0x1e, 0xbd, 0x83, 0x98, 0xf7, 0xea, 0xa3, 0xf1, 0x90, 0xfa, 0xbc, 0x88,
-	0x14, 0xf4, 0x00, 0xd6, 0x9e, 0xc1, 0xb9, 0x90, 0x51, 0x0b, 0xa3, 0x4f, 0xb2, 0x0a, 0x83, 0x53,
-	0x99, 0x09, 0xce, 0xb7, 0xb1, 0xfb, 0x1c, 0x96, 0x1e, 0xbb, 0xce, 0xff, 0x51, 0x7b, 0xd3, 0x1c,
-	0x9a, 0x76, 0x0f, 0x1d, 0x9d, 0xe7, 0x51, 0x64, 0xa2, 0xe8, 0x02, 0x4a, 0x6a, 0xb4, 0xb4, 0xff,
-	0x81, 0xc2, 0x73, 0xc7, 0xc7, 0x87, 0x12, 0xdb, 0xe7, 0x8c, 0xb1, 0xae, 0x88, 0xfe, 0x9f, 0x43,
-	0xd8, 0xda, 0x3a, 0x3e, 0xf5, 0x44, 0xef, 0xcf, 0x01, 0xf6, 0xc2, 0xeb, 0x0d, 0xa9, 0xc9, 0xba,
-	0x16, 0xbe, 0xca, 0xab, 0x4d, 0x49, 0x20, 0x19, 0x57, 0x4f, 0xfb, 0x16, 0x6a, 0xdb, 0xd4, 0xdf,
-	0x73, 0x9d, 0xfe, 0xa4, 0x47, 0x5d, 0x29, 0x49, 0x9e, 0xb6, 0xca, 0x2a, 0x48, 0x2f, 0xd0, 0x54,
-	0xd5, 0x25, 0xc8, 0x4c, 0xd7, 0x3d, 0x32, 0x86, 0x8e, 0x3d, 0xa0, 0x9e, 0x6f, 0xa0, 0xf7, 0x89,
-	0x73, 0x97, 0xbb, 0x47, 0x4f, 0x39, 0x1a, 0xdd, 0x5f, 0xfb, 0xb3, 0x02, 0x17, 0x12, 0x45, 0x88,
-	0x90, 0x38, 0x0b, 0xb9, 0xf1, 0xa4, 0x1b, 0x36, 0xeb, 0x02, 0x62, 0x1d, 0xfc, 0xd0, 0xe9, 0x89,
-	0x10, 0x60, 0x9f, 0x0c, 0x33, 0x71, 0x87, 0x22, 0x19, 0xb3, 0x4f, 0x72, 0x06, 0x72, 0x2c, 0x9c,
-	0xac, 0xbe, 0xc8, 0xbe, 0x59, 0x9b, 0xfa, 0x3b, 0x98, 0x30, 0x2c, 0xcf, 0x18, 0x0b, 0x89, 0xe8,
-	0xe1, 0x05, 0x1d, 0x2c, 0x4f, 0xea, 0xc0, 0x64, 0x8a, 0xf4, 0x90, 0xe3, 0x32, 0x45, 0x06, 0x60,
-	0x17, 0x6c, 0x0f, 0x2d, 0x9b, 0xa2, 0x47, 0x17, 0x74, 0x01, 0x85, 0x17, 0x5c, 0x88, 0x5c, 0xb0,
-	0x76, 0x00, 0x95, 0x6d, 0x51, 0xb9, 0x83, 0xd3, 0x30, 0x97, 0x76, 0x5e, 0xb3, 0x3b, 0x09, 0xab,
-	0x3c, 0x37, 0x72, 0x99, 0xe3, 0xe5, 0x0e, 0x46, 0x39, 0xa2, 0x7d, 0xcb, 0xb4, 0x23, 0x94, 0xdc,
-	0x7e, 0x65, 0x8e, 0x97, 0x94, 0xda, 0x3f, 0x54, 0xc8, 0x37, 0xc4, 0xbd, 0x13, 0xc8, 0x44, 0x92,
-	0x07, 0x7e, 0x33, 0x2b, 0x75, 0xb9, 0x67, 0x09, 0x06, 0x12, 0x24, 0xb7, 0x81, 0xe5, 0x7c, 0x03,
-	0x13, 0x7a, 0x1a, 0x93, 0xda, 0xd9, 0xa0, 0x05, 0x40, 0x7e, 0xeb, 0xdb, 0xa6, 0xc7, 0x1f, 0xc2,
-	0x03, 0xfe, 0xc1, 0xb6, 0xb0, 0xe7, 0x22, 0x6e, 0xc9, 0x24, 0x6e, 0x91, 0x43, 0x86, 0xbc, 0x6b,
-	0x8e, 0x70, 0x4b, 0x03, 0x8a, 0x63, 0xea, 0x8e, 0x2c, 0xcf, 0xc3, 0x52, 0x90, 0xc5, 0x52, 0x70,
-	0x25, 0xb6, 0x6b, 0x2f, 0xa4, 0xe0, 0x8f, 0xcc, 0xe8, 0x1e, 0xb2, 0x01, 0xb9, 0x81, 0xeb, 0x4c,
-	0xc6, 0xfc, 0x39, 0x58, 0x0c, 0x5a, 0xc1, 0x40, 0x4d, 0x5c, 0xe4, 0x1b, 0x05, 0x25, 0xf9, 0x02,
-	0x96, 0x0f, 0x30, 0xac, 0x0c, 0x71, 0x5c, 0xd9, 0xe6, 0xac, 0x8a, 0xcd, 0x33, 0x41, 0xa7, 0x97,
-	0x0f, 0xa2, 0xa0, 0x47, 0xd6, 0x01, 0x98, 0x19, 0xf1, 0xa4, 0xf2, 0xe5, 0xb0, 0x2c, 0x76, 0x06,
-	0x4e, 0xaa, 0xbe, 0x12, 0x5f, 0x5e, 0xed, 0x3f, 0x00, 0xf6, 0x86, 0xb4, 0x3f, 0xe0, 0xa1, 0x58,
-	0x85, 0xfc, 0x18, 0x21, 0x57, 0x46, 0x86, 0x00, 0x23, 0xc1, 0x9d, 0x8a, 0x06, 0x77, 0xed, 0x47,
-	0x05, 0xf2, 0xe2, 0xb6, 0x31, 0x34, 0x27, 0x2e, 0xf6, 0x17, 0x38, 0x4e, 0x11, 0x2e, 0x52, 0x12,
-	0xc8, 0x0e, 0xc3, 0xb1, 0x82, 0x80, 0xa5, 0xf3, 0x80, 0xba, 0x38, 0xa4, 0x19, 0x98, 0x32, 0xc0,
-	0x97, 0xa3, 0xf8, 0x6d, 0xd3, 0xc3, 0xa6, 0x17, 0xc5, 0x23, 0x11, 0x8f, 0x73, 0x95, 0x63, 0xd8,
-	0xf2, 0xfb, 0x50, 0xb6, 0xec, 0x9e, 0x4b, 0x4d, 0x8f, 0x1a, 0xde, 0x98, 0xd2, 0xbe, 0xe8, 0x2d,
-	0x97, 0x24, 0xb6, 0xcd, 0x90, 0xcc, 0xcb, 0xa3, 0x4f, 0x32, 0x0e, 0x90, 0x87, 0x50, 0xe2, 0x9c,
-	0xfa, 0xdc, 0x29, 0xb8, 0x81, 0xce, 0xc7, 0xcd, 0x1b, 0x5c, 0x8d, 0x5e, 0x14, 0xe4, 0x0c, 0xa8,
-	0x7d, 0x0d, 0x79, 0xe1, 0x2f, 0xac, 0xc5, 0x0b, 0x86, 0x4b, 0x22, 0x7b, 0x86, 0x08, 0xe6, 0xd8,
-	0x13, 0x8f, 0xf6, 0x65, 0xee, 0x63, 0xdf, 0x7c, 0x0a, 0xc3, 0xae, 0x87, 0xbf, 0x2f, 0x39, 0x50,
-	0xb3, 0x21, 0xb3, 0xe3, 0xd3, 0xd1, 0xdc, 0x7c, 0xec, 0x32, 0x46, 0xfd, 0x4b, 0x7a, 0x64, 0x8c,
-	0x4d, 0xcb, 0x15, 0xd9, 0x48, 0xb5, 0xbc, 0x27, 0xf4, 0x68, 0xcf, 0xb4, 0xd0, 0x30, 0xaf, 0xa9,
-	0x35, 0x38, 0xf4, 0x05, 0x3b, 0x01, 0xb1, 0x8e, 0x3d, 0x74, 0x45, 0x91, 0x48, 0x22, 0x98, 0xda,
-	0x63, 0xc8, 0xa2, 0xfb, 0x25, 0xc6, 0xde, 0x4d, 0xc8, 0x5a, 0x3e, 0x1d, 0x31, 0xcb, 0xb0, 0x6b,
-	0x59, 0x89, 0x5d, 0x0b, 0x53, 0x54, 0xe7, 0x14, 0xb5, 0x9f, 0x2a, 0x00, 0x61, 0x14, 0x24, 0x72,
-	0xbb, 0x02, 0x45, 0x74, 0x6e, 0x6c, 0x10, 0x38, 0x4f, 0x55, 0x07, 0x44, 0xb1, 0x1e, 0xc1, 0x0b,
-	0xc5, 0xa5, 0xdf, 0x25, 0x8e, 0x5d, 0x37, 0xeb, 0x9f, 0xbc, 0x43, 0x67, 0xd8, 0x97, 0x8d, 0x40,
-	0x80, 0xa8, 0x7d, 0x03, 0x95, 0x78, 0x44, 0x26, 0xcc, 0x4c, 0xea, 0xd1, 0x99, 0x49, 0x82, 0xd1,
-	0x03, 0x0e, 0xd1, 0x71, 0xca, 0x2e, 0x14, 0x23, 0xe1, 0x9a, 0xc0, 0xf5, 0xd6, 0x2c, 0xd7, 0xd5,
-	0xa4, 0x58, 0x8f, 0x30, 0xd4, 0xbe, 0x86, 0xd3, 0xdb, 0xd4, 0x17, 0xcb, 0x91, 0x9a, 0x3e, 0x77,
-	0x7d, 0x27, 0x2f, 0x4a, 0x3f, 0x2a, 0x50, 0xd8, 0x92, 0xa3, 0xb9, 0xb8, 0x23, 0x11, 0xc8, 0xe0,
-	0xb4, 0x8b, 0x97, 0x1e, 0xfc, 0x66, 0xf5, 0x7d, 0x68, 0xda, 0x83, 0x09, 0x1f, 0xa2, 0xe1, 0x5c,
-	0x48, 0xc2, 0xd1, 0x67, 0x04, 0xf7, 0x9e, 0xe0, 0x19, 0x71, 0x1d, 0x32, 0x66, 0xd7, 0x92, 0x29,
-	0x51, 0x5a, 0x4b, 0x0a, 0x5e, 0x6f, 0x6c, 0xee, 0xe8, 0x48, 0x50, 0xeb, 0x43, 0xba, 0xb1, 0xb9,
-	0x93, 0x78, 0x28, 0x02, 0x19, 0xd3, 0x1d, 0x48, 0x67, 0xc0, 0xef, 0xb9, 0x07, 0x5b, 0xfa, 0x44,
-	0x0f, 0x36, 0xad, 0x05, 0x64, 0x9b, 0xfa, 0x52, 0xbc, 0xbc, 0xc9, 0xf8, 0xf1, 0x4f, 0x7e, 0x8b,
-	0x6f, 0xe0, 0x7c, 0x84, 0x5f, 0xdb, 0x77, 0x5c, 0x73, 0x40, 0x17, 0xb1, 0x15, 0x7e, 0x90, 0x9a,
-	0x99, 0xc8, 0x1d, 0x58, 0x74, 0xd8, 0x17, 0x17, 0xca, 0x81, 0x44, 0xf1, 0x99, 0x44, 0xf1, 0x2e,
-	0xf6, 0x2e, 0x73, 0xe2, 0x45, 0x25, 0x96, 0xf3, 0x54, 0x25, 0x9c, 0xa7, 0xe2, 0x84, 0x39, 0xec,
-	0x5a, 0x53, 0x62, 0xc2, 0x1c, 0x6d, 0x59, 0xf9, 0xb2, 0x68, 0xf1, 0x78, 0x9e, 0x28, 0x22, 0x8e,
-	0xb7, 0x81, 0xda, 0x08, 0xae, 0xcc, 0xcb, 0x7c, 0xcc, 0x14, 0xf7, 0x4e, 0x7e, 0xf0, 0xa4, 0x23,
-	0xa6, 0x13, 0x8f, 0xf8, 0xff, 0xb0, 0xb6, 0x58, 0x5c, 0xd8, 0x40, 0xe1, 0xcd, 0xb1, 0xb7, 0x0e,
-	0x73, 0x11, 0x01, 0xfd, 0x1b, 0x0e, 0xfb, 0x11, 0x9c, 0x6b, 0x53, 0xbb, 0x9f, 0x34, 0x72, 0x4a,
-	0xea, 0xbf, 0x5d, 0x6c, 0x9b, 0x3b, 0xce, 0xcb, 0xb0, 0xe8, 0x4a, 0xf2, 0x48, 0x8b, 0xa2, 0xcc,
-	0xb6, 0x28, 0x09, 0x55, 0x3c, 0x75, 0xf2, 0x2a, 0xae, 0xb9, 0x70, 0x76, 0x4e, 0xe6, 0xbb, 0x7a,
-	0xd7, 0x60, 0xb8, 0x9f, 0x8a, 0x0e, 0xf7, 0x4f, 0x6e, 0x14, 0x1d, 0xfd, 0x0e, 0x65, 0xde, 0xdb,
-	0xb8, 0xfd, 0x8e, 0xa3, 0xa6, 0xc3, 0xa3, 0xd6, 0xa0, 0x80, 0xa2, 0x76, 0x1e, 0xc9, 0x68, 0x0e,
-	0x60, 0xcd, 0x0b, 0xcf, 0x71, 0x6f, 0xe3, 0x76, 0xb4, 0x07, 0x4f, 0xfe, 0x2b, 0xe2, 0xbc, 0xe0,
-	0xc5, 0x7a, 0x5f, 0x31, 0x8c, 0xe6, 0xbc, 0xfa, 0xff, 0xc2, 0x41, 0xee, 0x63, 0x67, 0x2e, 0x85,
-	0x3e, 0xa3, 0xbe, 0xc9, 0xa2, 0x24, 0x38, 0x49, 0x0d, 0x0a, 0x23, 0x81, 0x93, 0xb3, 0x70, 0x09,
-	0x6b, 0x1f, 0x43, 0x35, 0xb2, 0x75, 0xf7, 0xb5, 0xcd, 0x9e, 0x48, 0x62, 0xdf, 0x2a, 0x64, 0x1d,
-	0x86, 0x90, 0x1a, 0x23, 0xa0, 0xfd, 0x4c, 0x81, 0x6c, 0xf3, 0x15, 0xc5, 0xb7, 0x43, 0xd6, 0x77,
-	0xc6, 0x56, 0x4f, 0xbc, 0xcd, 0x65, 0xda, 0xc2, 0xc5, 0xf5, 0x0e, 0x5b, 0xd1, 0x39, 0x41, 0x10,
-	0xc3, 0xa9, 0x48, 0x0c, 0xcb, 0x47, 0x52, 0x3a, 0xf2, 0x48, 0xba, 0x0d, 0x59, 0xdc, 0x47, 0x56,
-	0xa1, 0xb2, 0xb5, 0xdb, 0xea, 0xe8, 0x8d, 0xad, 0x8e, 0xa1, 0x37, 0xb7, 0x9a, 0x3b, 0x7b, 0x9d,
-	0xca, 0x29, 0x42, 0xa0, 0x1c, 0x60, 0x9b, 0xcf, 0x9b, 0xad, 0x4e, 0x45, 0xd1, 0x7e, 0xa3, 0x40,
-	0xa5, 0x3d, 0xe9, 0x7a, 0x3d, 0xd7, 0xea, 0x06, 0x3e, 0x73, 0x0b, 0x72, 0x28, 0x98, 0x87, 0x52,
-	0xb2, 0x6a, 0x82, 0x82, 0x7c, 0xca, 0xc2, 0x6e, 0xe8, 0x53, 0x57, 0x94, 0x31, 0xf9, 0xa7, 0x4a,
-	0x9c, 0xe9, 0xfa, 0x63, 0xa4, 0xd2, 0x05, 0x75, 0xed, 0x26, 0xe4, 0x38, 0x86, 0x55, 0x7b, 0xf9,
-	0xf7, 0x90, 0x11, 0x64, 0x0c, 0x90, 0xa8, 0x9d, 0xbe, 0x76, 0x0f, 0x4e, 0x47, 0xb8, 0x89, 0xdb,
-	0xd5, 0x20, 0x4b, 0x99, 0x3a, 0x48, 0x1f, 0x4e, 0x29, 0x50, 0x45, 0x9d, 0x2f, 0x6d, 0xfc, 0xfd,
-	0x34, 0x40, 0x63, 0x6c, 0xb5, 0xa9, 0xfb, 0xca, 0xea, 0x51, 0xf2, 0x35, 0x14, 0xb7, 0xa9, 0x2f,
-	0xff, 0x6f, 0x23, 0xb2, 0x0e, 0x45, 0xff, 0x7c, 0xac, 0x9d, 0x93, 0xff, 0x8e, 0xc5, 0xfe, 0x95,
-	0xd3, 0x56, 0x7f, 0xf2, 0xa7, 0xbf, 0x7d, 0x9f, 0x2a, 0x93, 0x52, 0x7d, 0x10, 0xe1, 0xd1, 0x81,
-	0x12, 0x4b, 0x4c, 0x72, 0xc0, 0x91, 0xcc, 0x53, 0xfe, 0x73, 0x33, 0x37, 0x07, 0xd1, 0xce, 0x20,
-	0xd3, 0x65, 0xb2, 0xc4, 0x98, 0x86, 0x5c, 0x5a, 0x00, 0xdb, 0xd4, 0x97, 0x0d, 0x63, 0x22, 0x4f,
-	0xf9, 0x1a, 0x89, 0xfd, 0xd5, 0xa9, 0xad, 0x20, 0xc7, 0x25, 0x52, 0x64, 0x1c, 0x25, 0x87, 0xff,
-	0xc6, 0x83, 0x77, 0xa6, 0x7c, 0x1c, 0x40, 0x56, 0x83, 0xe1, 0x7a, 0x64, 0x3a, 0x50, 0xab, 0x2d,
-	0x9e, 0x96, 0x6b, 0x17, 0x90, 0xeb, 0x19, 0xb2, 0xc2, 0xb8, 0x4a, 0x3e, 0xf5, 0x63, 0x96, 0xee,
-	0xde, 0x90, 0x3e, 0xac, 0x22, 0x77, 0x31, 0xc2, 0xdb, 0x3c, 0xe2, 0x7c, 0x17, 0x88, 0x99, 0x9b,
-	0xec, 0x6b, 0xd7, 0x90, 0xf9, 0x65, 0x72, 0x91, 0x33, 0x8f, 0xb1, 0x91, 0x52, 0x1c, 0x28, 0xcf,
-	0x4e, 0x35, 0xc8, 0x45, 0xc1, 0x29, 0x71, 0xd8, 0x51, 0x5b, 0x4d, 0x1a, 0x75, 0x69, 0x37, 0x51,
-	0xd6, 0x7b, 0xe4, 0x2a, 0x93, 0x15, 0xd9, 0x25, 0xa4, 0xd4, 0x8f, 0xe5, 0xb4, 0xe2, 0x0d, 0x79,
-	0x0d, 0x95, 0xf8, 0xf4, 0x83, 0x5c, 0x9e, 0x13, 0x39, 0x33, 0x16, 0x59, 0x20, 0xf4, 0x23, 0x14,
-	0x7a, 0x9d, 0xbc, 0x1f, 0x11, 0xca, 0xf7, 0xd5, 0x8f, 0x79, 0xd5, 0x99, 0x11, 0x4c, 0xd1, 0xfa,
-	0xf2, 0xa5, 0x5b, 0x0d, 0x45, 0xce, 0xb6, 0x7e, 0xb5, 0xf2, 0x6c, 0xc3, 0x38, 0x2b, 0x46, 0x20,
-	0xeb, 0xc7, 0xac, 0x79, 0x7a, 0x53, 0x3f, 0x8e, 0xa7, 0xc2, 0x37, 0xe4, 0x17, 0x0a, 0x2c, 0xc7,
-	0x6a, 0x06, 0xb9, 0x14, 0x0a, 0x4b, 0xa8, 0x25, 0xb5, 0xcb, 0x8b, 0x96, 0xc5, 0x41, 0xbf, 0x40,
-	0x0d, 0xee, 0x91, 0xbb, 0x68, 0xc9, 0x08, 0x45, 0xfd, 0x58, 0x14, 0x9d, 0x37, 0xf5, 0x63, 0xcc,
-	0xcf, 0x89, 0x1a, 0xfd, 0x4a, 0xc1, 0xc6, 0x2c, 0x56, 0x51, 0xde, 0xa5, 0xd4, 0xd5, 0xd8, 0xf2,
-	0x7c, 0x2d, 0xd2, 0xbe, 0x44, 0xbd, 0x1e, 0x90, 0xcf, 0x02, 0xbd, 0x42, 0xa2, 0x93, 0xa9, 0xf6,
-	0x6b, 0x05, 0x56, 0x12, 0x6a, 0xc4, 0x9c, 0x6e, 0xb3, 0x45, 0xab, 0xa6, 0xcd, 0x2f, 0xc7, 0xcb,
-	0x8b, 0xb6, 0x89, 0xca, 0x3d, 0x24, 0x0f, 0xa2, 0xca, 0x49, 0xaa, 0x50, 0x27, 0x59, 0xe6, 0x12,
-	0xd5, 0xfb, 0x5e, 0x41, 0x67, 0x9d, 0xa9, 0x43, 0xef, 0xd2, 0xed, 0xca, 0xfc, 0xf2, 0x4c, 0xfd,
-	0xd2, 0xfe, 0x13, 0x15, 0xbb, 0x4f, 0xee, 0x45, 0x15, 0x43, 0x92, 0x13, 0x6a, 0xc5, 0xf3, 0x6d,
-	0x30, 0xe9, 0x79, 0x6b, 0xbe, 0x8d, 0x4f, 0x90, 0x66, 0xf3, 0x6d, 0xc0, 0xe3, 0x97, 0xdc, 0x0e,
-	0xf1, 0x29, 0x1a, 0x89, 0x38, 0xc1, 0x82, 0x21, 0x5e, 0xd4, 0x16, 0x8b, 0x86, 0x70, 0xda, 0x7d,
-	0x14, 0x7a, 0x87, 0xdc, 0x66, 0x42, 0xe3, 0x54, 0x51, 0x4f, 0x99, 0x3f, 0xec, 0x00, 0x0f, 0x1b,
-	0x3c, 0xa6, 0xce, 0x87, 0xd2, 0x62, 0x0f, 0x8d, 0xda, 0x72, 0xec, 0xfd, 0xa3, 0x7d, 0x88, 0x52,
-	0x3f, 0x20, 0xd7, 0xb0, 0x0a, 0x08, 0x6c, 0xfd, 0x78, 0xc1, 0xad, 0x1e, 0xcd, 0xbc, 0x5e, 0x44,
-	0x2f, 0x4c, 0xd6, 0xe6, 0xe5, 0xcd, 0x3e, 0x44, 0xa2, 0x71, 0xb2, 0xe0, 0xad, 0xa0, 0x5d, 0x46,
-	0x45, 0xaa, 0xda, 0x4a, 0x54, 0x11, 0x41, 0xf4, 0x40, 0xb9, 0x45, 0xbe, 0x53, 0xb0, 0xdd, 0x49,
-	0xec, 0xc3, 0xc9, 0x07, 0x0b, 0xf9, 0xcf, 0xbc, 0x0b, 0x6a, 0xd7, 0xdf, 0x49, 0x27, 0xb4, 0x11,
-	0x75, 0x41, 0x3b, 0x9f, 0xa0, 0x0d, 0x27, 0x65, 0x3a, 0x7d, 0x0b, 0xcb, 0xb1, 0xe6, 0x3c, 0xb8,
-	0xfb, 0xf9, 0xbf, 0x2a, 0x83, 0x0c, 0xb6, 0xa0, 0x9f, 0xd7, 0x08, 0xca, 0x2c, 0x69, 0xf9, 0xba,
-	0xc7, 0x28, 0xa6, 0x4c, 0x82, 0x0e, 0xcb, 0xcd, 0x29, 0xed, 0x9d, 0x50, 0xc2, 0x7c, 0x7d, 0x0b,
-	0x79, 0x52, 0xc6, 0x06, 0x79, 0xbe, 0x00, 0x35, 0x68, 0x69, 0xc8, 0xb9, 0x05, 0x2d, 0x53, 0xd0,
-	0x38, 0xcc, 0x75, 0x3f, 0xb2, 0x71, 0xd0, 0xa0, 0xee, 0xc9, 0xb5, 0x07, 0xca, 0xad, 0x8f, 0x95,
-	0x6e, 0x0e, 0xff, 0x24, 0xb9, 0xf3, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb5, 0xf5, 0xf5, 0xcc,
-	0x8c, 0x25, 0x00, 0x00,
+	// 5699 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3b, 0x5d, 0x6f, 0x23, 0xc9,
+	0x71, 0x37, 0xfc, 0x10, 0xc9, 0x22, 0x25, 0x71, 0x5b, 0x7b, 0x5a, 0xee, 0xec, 0xf7, 0xec, 0xed,
+	0xc7, 0x7d, 0x89, 0xb7, 0x3a, 0xef, 0xad, 0xef, 0x7c, 0xfe, 0xa0, 0xb4, 0x5c, 0x9d, 0xb2, 0xbb,
+	0x92, 0x3c, 0xe2, 0xde, 0xc5, 0x80, 0x8d, 0xf1, 0x90, 0x6c, 0x51, 0xe3, 0x25, 0x39, 0xf4, 0xcc,
+	0x70, 0x57, 0xca, 0x7a, 0x91, 0x20, 0x71, 0xe2, 0x7c, 0x20, 0x5f, 0x30, 0x82, 0x3c, 0xc4, 0x06,
+	0x02, 0x24, 0x4f, 0x7e, 0xc8, 0x4b, 0x90, 0x8f, 0xa7, 0x00, 0x79, 0x09, 0x10, 0x04, 0x01, 0x82,
+	0x04, 0x41, 0xde, 0x92, 0x00, 0x71, 0x7e, 0x81, 0x9f, 0x03, 0x04, 0x5d, 0xdd, 0x3d, 0xd3, 0xf3,
+	0x41, 0x89, 0xb6, 0xcf, 0x79, 0xc9, 0x93, 0xd8, 0xd5, 0xd5, 0x55, 0xd5, 0xd5, 0x35, 0xd5, 0x55,
+	0xd5, 0x25, 0xa8, 0x7b, 0x93, 0x5e, 0x73, 0xd2, 0x6d, 0x7a, 0x93, 0xde, 0xda, 0xc4, 0x73, 0x03,
+	0x97, 0x14, 0xbd, 0x49, 0x6f, 0xd2, 0xd5, 0x2f, 0x0e, 0x5c, 0x77, 0x30, 0xa4, 0x4d, 0x7b, 0xe2,
+	0x34, 0xed, 0xf1, 0xd8, 0x0d, 0xec, 0xc0, 0x71, 0xc7, 0x3e, 0x47, 0x32, 0x96, 0xa0, 0xd6, 0x1e,
+	0x4d, 0x82, 0x63, 0x93, 0x7e, 0x73, 0x4a, 0xfd, 0xc0, 0xf8, 0x10, 0xaa, 0x3b, 0x34, 0x78, 0xee,
+	0x7a, 0x4f, 0xb7, 0xc7, 0x07, 0x2e, 0x59, 0x82, 0x9c, 0xd3, 0x6f, 0x68, 0x57, 0xb5, 0xdb, 0x15,
+	0x33, 0xe7, 0xf4, 0xc9, 0x25, 0x80, 0x09, 0xa5, 0x9e, 0xd5, 0x73, 0xa7, 0xe3, 0xa0, 0x91, 0xbb,
+	0xaa, 0xdd, 0x2e, 0x9a, 0x15, 0x06, 0xd9, 0x64, 0x00, 0xe3, 0x07, 0x1a, 0x2c, 0x9b, 0xad, 0xc7,
+	0x6c, 0xa9, 0x49, 0xfd, 0x89, 0x3b, 0xf6, 0x29, 0x39, 0x0f, 0xe5, 0xa9, 0x4f, 0xfb, 0x96, 0x67,
+	0x8f, 0x90, 0x50, 0xde, 0x2c, 0xb1, 0xb1, 0x69, 0x8f, 0xc8, 0x75, 0x58, 0xb4, 0x9f, 0xd9, 0xce,
+	0xd0, 0xee, 0x0e, 0x29, 0xce, 0xe7, 0x70, 0xbe, 0x16, 0x02, 0x19, 0xd2, 0x05, 0xa8, 0x04, 0x6e,
+	0x60, 0x0f, 0x11, 0x21, 0x8f, 0x08, 0x65, 0x04, 0xb0, 0xc9, 0x4b, 0x00, 0x3e, 0x1d, 0x0e, 0xad,
+	0x89, 0xe7, 0xf4, 0x68, 0xa3, 0x70, 0x55, 0xbb, 0xad, 0x99, 0x15, 0x06, 0xd9, 0x63, 0x00, 0xb6,
+	0xb6, 0x3b, 0x3d, 0x16, 0xb3, 0x45, 0x9c, 0x2d, 0x77, 0xa7, 0xc7, 0x38, 0x69, 0xfc, 0x8e, 0x06,
+	0xf5, 0x1d, 0xb7, 0x4f, 0x63, 0xd2, 0x5e, 0x02, 0xe8, 0x4e, 0x9d, 0x61, 0xdf, 0x0a, 0x9c, 0x11,
+	0x15, 0x1b, 0xaf, 0x20, 0xa4, 0xe3, 0x8c, 0x70, 0x33, 0x03, 0x27, 0xb0, 0x0e, 0x6d, 0xff, 0x10,
+	0x85, 0xad, 0x98, 0xa5, 0x81, 0x13, 0x7c, 0x64, 0xfb, 0x87, 0x84, 0x40, 0x61, 0xe4, 0xf6, 0x29,
+	0x8a, 0x58, 0x31, 0xf1, 0x37, 0x79, 0x0b, 0x4a, 0x63, 0xae, 0x4d, 0x94, 0xad, 0xba, 0x4e, 0xd6,
+	0xf0, 0x50, 0xd6, 0x14, 0x1d, 0x9b, 0x12, 0xc5, 0xf8, 0x61, 0x1e, 0x08, 0x13, 0x68, 0x3f, 0xb0,
+	0x83, 0xa9, 0x1f, 0x8a, 0x24, 0x09, 0x6b, 0x0a, 0xe1, 0x4b, 0x00, 0x87, 0xd4, 0xee, 0x5b, 0xdd,
+	0xa1, 0xdb, 0x7b, 0x2a, 0xd4, 0x56, 0x61, 0x90, 0x0d, 0x06, 0x20, 0x37, 0x61, 0x39, 0x9a, 0xe6,
+	0x5b, 0xe1, 0x9a, 0x5b, 0x0c, 0x71, 0x70, 0x3b, 0xd7, 0x61, 0x11, 0x51, 0x7c, 0xab, 0x4b, 0x0f,
+	0x9d, 0x71, 0x1f, 0xa5, 0xcc, 0x9b, 0x35, 0x0e, 0xdc, 0x40, 0x18, 0x53, 0xe2, 0xd0, 0xe9, 0x0a,
+	0x56, 0x45, 0x7e, 0x00, 0x43, 0xa7, 0xcb, 0x39, 0xc5, 0x0d, 0x62, 0x21, 0x61, 0x10, 0xe4, 0x36,
+	0xd4, 0x27, 0x74, 0xdc, 0x77, 0xc6, 0x03, 0x2b, 0x38, 0x12, 0x48, 0x25, 0x44, 0x5a, 0x12, 0xf0,
+	0xce, 0x11, 0xc7, 0x5c, 0x81, 0x62, 0xbf, 0x6b, 0xb9, 0x4f, 0x1b, 0xe5, 0xab, 0xda, 0xed, 0xb2,
+	0x59, 0xe8, 0x77, 0x77, 0x9f, 0x32, 0x75, 0xf7, 0xbb, 0x16, 0xf5, 0x3c, 0xd7, 0x6b, 0x54, 0xb8,
+	0xba, 0xfb, 0xdd, 0x36, 0x1b, 0x92, 0x06, 0x94, 0x9e, 0x3b, 0xc1, 0x98, 0xfa, 0x7e, 0x03, 0xf8,
+	0x8c, 0x18, 0x92, 0x2b, 0x50, 0x75, 0x7c, 0x6b, 0xe2, 0xb9, 0xfd, 0x69, 0x8f, 0x7a, 0x8d, 0x2a,
+	0xd2, 0x03, 0xc7, 0xdf, 0x13, 0x10, 0xb2, 0x06, 0x2b, 0x43, 0xdb, 0x0f, 0x24, 0x8a, 0xd4, 0x62,
+	0x0d, 0xb7, 0x76, 0x86, 0x4d, 0x09, 0x54, 0xa1, 0xcd, 0x7b, 0xd0, 0xc8, 0xc0, 0xe7, 0x6a, 0x5d,
+	0xc4, 0x45, 0xaf, 0xa6, 0x16, 0xa1, 0x7a, 0xcf, 0x42, 0xd1, 0xa3, 0x76, 0xff, 0xb8, 0xb1, 0x84,
+	0x32, 0xf0, 0x81, 0xf1, 0x3e, 0x54, 0x5b, 0x23, 0xb6, 0xe7, 0x47, 0xce, 0xc8, 0x09, 0x18, 0x52,
+	0xe0, 0x3e, 0xa5, 0x63, 0x71, 0xbe, 0x7c, 0xc0, 0xa0, 0xcf, 0xec, 0xe1, 0x94, 0x0a, 0x2b, 0xe3,
+	0x03, 0xe3, 0x2b, 0xb0, 0xd0, 0xea, 0xb1, 0xcf, 0x97, 0xe8, 0x50, 0xee, 0xb9, 0xe3, 0xc0, 0xb3,
+	0x7b, 0x81, 0x58, 0x18, 0x8e, 0x99, 0x02, 0x6c, 0xc4, 0xb2, 0xc6, 0xf6, 0x48, 0x52, 0x00, 0x0e,
+	0xda, 0xb1, 0x47, 0x68, 0x51, 0x7d, 0x3b, 0xb0, 0xa5, 0xa9, 0xb2, 0xdf, 0xc6, 0x7f, 0x14, 0xa0,
+	0xd2, 0x39, 0x32, 0x69, 0x8f, 0x3a, 0x93, 0x80, 0x9c, 0x83, 0x52, 0x70, 0xc4, 0xcd, 0x9c, 0x53,
+	0x5f, 0x08, 0x8e, 0xd0, 0xca, 0x2f, 0x40, 0x65, 0x60, 0xfb, 0xd6, 0xd4, 0xb7, 0x07, 0x9c, 0xb2,
+	0x66, 0x96, 0x07, 0xb6, 0xff, 0x84, 0x8d, 0xc9, 0xe7, 0xa0, 0xe2, 0xd9, 0x23, 0x31, 0x99, 0xbf,
+	0x9a, 0xbf, 0x5d, 0x5d, 0xbf, 0x2c, 0x0c, 0x3e, 0x24, 0xbd, 0x66, 0xda, 0x23, 0xc4, 0x6e, 0x8f,
+	0x03, 0xef, 0xd8, 0x2c, 0x7b, 0x62, 0x48, 0x3e, 0x84, 0xaa, 0x8f, 0x86, 0x6f, 0xf5, 0x98, 0xb5,
+	0x33, 0x4b, 0x5c, 0x5a, 0xbf, 0x90, 0x5a, 0xce, 0x3f, 0x8e, 0x4d, 0xb7, 0x4f, 0x4d, 0xf0, 0xc3,
+	0xdf, 0xcc, 0x1c, 0x46, 0xd4, 0x47, 0xc6, 0x45, 0x6e, 0x0e, 0x62, 0xc8, 0x66, 0x3c, 0x1a, 0x4c,
+	0xbd, 0xb1, 0xdf, 0x58, 0xb8, 0x9a, 0x67, 0x33, 0x62, 0x48, 0x3e, 0x03, 0x65, 0x8f, 0x53, 0xf5,
+	0x1b, 0x25, 0x94, 0xb6, 0x91, 0x96, 0x96, 0xff, 0x35, 0x43, 0x4c, 0xfd, 0x73, 0xb0, 0x18, 0xdb,
+	0x02, 0xa9, 0x43, 0xfe, 0x29, 0x3d, 0x16, 0x7a, 0x62, 0x3f, 0xe3, 0x87, 0x97, 0x17, 0x87, 0xf7,
+	0x41, 0xee, 0xb3, 0x9a, 0xfe, 0x25, 0x28, 0x49, 0x15, 0x5f, 0x80, 0xca, 0xc1, 0x74, 0xdc, 0xe3,
+	0x67, 0x24, 0x8e, 0x90, 0x01, 0xf0, 0x84, 0x1a, 0x50, 0x62, 0xc7, 0x49, 0x85, 0x93, 0xad, 0x98,
+	0x72, 0x68, 0xfc, 0x95, 0x06, 0x10, 0xe9, 0x80, 0x54, 0xa1, 0xb4, 0xff, 0x64, 0x73, 0xb3, 0xbd,
+	0xbf, 0x5f, 0x7f, 0x85, 0x2c, 0x43, 0x75, 0xab, 0xb5, 0x6f, 0x99, 0x4f, 0x76, 0xac, 0xdd, 0x27,
+	0x9d, 0xba, 0x46, 0x56, 0x81, 0x6c, 0xb4, 0x1e, 0xb5, 0x76, 0x36, 0xdb, 0xd6, 0xce, 0x6e, 0xc7,
+	0x6a, 0xef, 0xec, 0x3e, 0xd9, 0xfa, 0xa8, 0x9e, 0x23, 0x2b, 0xb0, 0xfc, 0x89, 0xb9, 0xbb, 0xb3,
+	0x65, 0xed, 0xb5, 0xcc, 0xd6, 0xe3, 0x76, 0xa7, 0x6d, 0xd6, 0xf3, 0xe4, 0x0c, 0x2c, 0x9a, 0x4f,
+	0x76, 0x3a, 0xdb, 0x8f, 0xdb, 0x56, 0xdb, 0x34, 0x77, 0xcd, 0x7a, 0x81, 0x51, 0x67, 0x63, 0x46,
+	0xac, 0x18, 0x2d, 0xea, 0xfc, 0xbc, 0xf5, 0x60, 0xd7, 0x7c, 0xdc, 0xea, 0xd4, 0x17, 0x18, 0x87,
+	0xfb, 0x4f, 0xf6, 0x1e, 0x6d, 0x6f, 0xb6, 0x3a, 0x6d, 0x6b, 0xbf, 0xdd, 0xb1, 0x36, 0x77, 0xef,
+	0xb7, 0xeb, 0x25, 0x46, 0xec, 0xc9, 0xce, 0xc3, 0x9d, 0xdd, 0x4f, 0x76, 0x04, 0xb1, 0xb2, 0xf1,
+	0x83, 0x3c, 0x54, 0x3b, 0x9e, 0x3d, 0xf6, 0xb9, 0x25, 0x32, 0x2b, 0x54, 0x0c, 0x0c, 0x7f, 0x33,
+	0x18, 0x7e, 0x56, 0x5c, 0x71, 0xf8, 0x9b, 0x5c, 0x06, 0xa0, 0x47, 0x13, 0xc7, 0xc3, 0x7b, 0x4b,
+	0xf8, 0x31, 0x05, 0x22, 0x4d, 0x12, 0x47, 0x8d, 0x42, 0x68, 0x92, 0x26, 0x1b, 0xcb, 0xc9, 0x21,
+	0xfb, 0xd4, 0xe4, 0x0d, 0x30, 0xb0, 0xfd, 0xf0, 0xd3, 0xeb, 0xd3, 0xa1, 0x7d, 0x8c, 0x7e, 0x2b,
+	0x6f, 0xf2, 0x01, 0x73, 0x3a, 0xbd, 0x43, 0xdb, 0x19, 0x5b, 0x4e, 0x1f, 0x7d, 0xd5, 0xa2, 0x59,
+	0xc2, 0xf1, 0x76, 0x9f, 0xdc, 0x82, 0x12, 0x17, 0xde, 0x6f, 0x94, 0xd1, 0x60, 0x16, 0x85, 0xc1,
+	0xf0, 0xaf, 0xd2, 0x94, 0xb3, 0xec, 0xfc, 0x7c, 0x67, 0x30, 0xa6, 0x9e, 0xdf, 0xa8, 0x70, 0xa3,
+	0x13, 0x43, 0x72, 0x11, 0x2a, 0x93, 0x69, 0x77, 0xe8, 0xf8, 0x87, 0xd4, 0x13, 0x9e, 0x2b, 0x02,
+	0xb0, 0x4f, 0xd7, 0xa3, 0x07, 0xd4, 0xf3, 0x68, 0xdf, 0x0a, 0x8e, 0xd0, 0x77, 0x55, 0x4c, 0x90,
+	0xa0, 0xce, 0x11, 0xb9, 0x0b, 0x35, 0x1b, 0x9d, 0x87, 0xd8, 0x52, 0xed, 0x6a, 0x5e, 0xb9, 0x56,
+	0x14, 0xbf, 0x62, 0x56, 0xed, 0x68, 0x40, 0x9a, 0x00, 0xc1, 0x91, 0x25, 0x6c, 0x18, 0x9d, 0x56,
+	0x75, 0xbd, 0x9e, 0x34, 0x76, 0xb3, 0x12, 0xc8, 0x9f, 0xc6, 0xbf, 0x6b, 0xb0, 0xa2, 0x1c, 0x56,
+	0x78, 0x19, 0xbd, 0x0f, 0x0b, 0xfc, 0xab, 0xc3, 0x63, 0x5b, 0x5a, 0xbf, 0x26, 0x89, 0xa4, 0x71,
+	0xc5, 0xa7, 0x6a, 0x8a, 0x05, 0xe4, 0x33, 0x50, 0x0d, 0x22, 0x2c, 0x3c, 0xe2, 0x48, 0x72, 0x75,
+	0xbd, 0x8a, 0x46, 0xae, 0x01, 0xbf, 0x8d, 0xac, 0xf1, 0x74, 0xd4, 0xa5, 0x9e, 0x38, 0xff, 0x2a,
+	0xc2, 0x76, 0x10, 0x64, 0xbc, 0x0b, 0x0b, 0x9c, 0x15, 0xb3, 0xd7, 0xbd, 0xf6, 0xce, 0xfd, 0xed,
+	0x9d, 0xad, 0xfa, 0x2b, 0x04, 0x60, 0x61, 0xaf, 0xb5, 0xf9, 0xb0, 0x7d, 0xbf, 0xae, 0x91, 0x3a,
+	0xd4, 0xb6, 0x4d, 0xb3, 0xfd, 0x71, 0xdb, 0xdc, 0xdf, 0xde, 0x78, 0xd4, 0xae, 0xe7, 0x8c, 0xaf,
+	0xc3, 0xea, 0x16, 0x0d, 0x3a, 0x47, 0xfe, 0xc6, 0x71, 0xab, 0x87, 0x17, 0x93, 0x08, 0x81, 0xd8,
+	0xd9, 0xd9, 0x1c, 0x22, 0x4c, 0x53, 0x0e, 0xc9, 0x2a, 0x2c, 0xb8, 0x07, 0x07, 0x3e, 0x95, 0x91,
+	0x8f, 0x18, 0x31, 0x3b, 0xe2, 0xa7, 0x91, 0x47, 0x30, 0x1f, 0x18, 0xbf, 0xad, 0xc1, 0xb9, 0x14,
+	0x0b, 0xa1, 0xc6, 0xf7, 0xa0, 0xa6, 0x6c, 0x92, 0x29, 0x33, 0x3f, 0x43, 0x19, 0x31, 0x3c, 0x66,
+	0x9b, 0x87, 0xb6, 0x6f, 0x8d, 0x5c, 0x8f, 0x7f, 0x23, 0x65, 0xb3, 0x74, 0x68, 0xfb, 0x8f, 0x5d,
+	0x8f, 0xe2, 0xb5, 0x37, 0xee, 0xd3, 0x23, 0xcb, 0x0f, 0x6c, 0x2f, 0x90, 0xdf, 0x09, 0x82, 0xf6,
+	0x19, 0xc4, 0xf8, 0x45, 0x38, 0xbb, 0x45, 0x03, 0x21, 0x49, 0xe7, 0xc8, 0x3f, 0x7d, 0xbf, 0x57,
+	0xa0, 0x7a, 0xe0, 0xb9, 0x23, 0xeb, 0x90, 0x3a, 0x83, 0xc3, 0x40, 0x7c, 0x94, 0xc0, 0x40, 0x1f,
+	0x21, 0x24, 0x7b, 0xe3, 0x4c, 0x4d, 0xbd, 0xa9, 0xe7, 0xbb, 0x1e, 0x7e, 0x8d, 0x15, 0x53, 0x8c,
+	0x8c, 0xdf, 0xd3, 0xe0, 0xd5, 0x84, 0x04, 0x42, 0x1d, 0x5f, 0xc8, 0x54, 0x87, 0x3e, 0xdb, 0xb6,
+	0x12, 0x6a, 0x89, 0x38, 0xe6, 0x54, 0x8e, 0xa7, 0xeb, 0xe4, 0x1e, 0x5c, 0xd8, 0xa2, 0xc1, 0x7d,
+	0xf6, 0xdd, 0x07, 0x3f, 0x8e, 0x29, 0x18, 0x1f, 0xc3, 0xc5, 0xec, 0x85, 0x3f, 0xdd, 0x01, 0x1b,
+	0xdf, 0xd1, 0xe0, 0xd2, 0x16, 0x0d, 0xf6, 0x44, 0x70, 0xa4, 0x4c, 0x49, 0x99, 0x22, 0x23, 0xd4,
+	0xb2, 0x8d, 0x30, 0xa7, 0x9e, 0x45, 0xcc, 0xdd, 0xe4, 0x93, 0xee, 0x46, 0x8d, 0x22, 0x0a, 0xf1,
+	0x28, 0xc2, 0xf8, 0x0d, 0x0d, 0x2e, 0xcf, 0x92, 0xe4, 0x67, 0x67, 0xc5, 0x18, 0x0d, 0x05, 0xf6,
+	0x50, 0x5a, 0x14, 0x0e, 0x8c, 0xbf, 0xd6, 0xa0, 0xb2, 0xef, 0x0c, 0xc6, 0x76, 0x30, 0xf5, 0x28,
+	0xf9, 0x2c, 0x54, 0xec, 0xe1, 0xc0, 0xf5, 0x9c, 0xe0, 0x70, 0x24, 0xdc, 0x90, 0x34, 0x95, 0x10,
+	0x69, 0xad, 0x25, 0x31, 0xcc, 0x08, 0x99, 0x69, 0xc3, 0x97, 0x18, 0xc8, 0xb9, 0x66, 0x46, 0x00,
+	0x8c, 0x65, 0x99, 0x6a, 0x7a, 0x16, 0xbb, 0xcf, 0xf3, 0x7c, 0x9a, 0x43, 0x1e, 0xd2, 0x63, 0xe3,
+	0x33, 0x50, 0x09, 0x89, 0x32, 0x4f, 0x23, 0xee, 0xb7, 0xfa, 0x2b, 0x64, 0x11, 0x2a, 0xfb, 0xed,
+	0xcd, 0xbd, 0xf5, 0xbb, 0xef, 0x3d, 0xbc, 0x53, 0xd7, 0xd8, 0x5c, 0xfb, 0xfe, 0xfa, 0xdd, 0xbb,
+	0x77, 0xde, 0xaf, 0xe7, 0x8c, 0xbf, 0xcc, 0x03, 0x89, 0x19, 0x30, 0x3f, 0x45, 0x79, 0xd1, 0x69,
+	0x33, 0x2f, 0xba, 0xdc, 0xc9, 0x17, 0x5d, 0xfe, 0xa4, 0x8b, 0xae, 0x30, 0xeb, 0xa2, 0x2b, 0xce,
+	0xba, 0xe8, 0x16, 0x66, 0x5e, 0x74, 0xa5, 0x13, 0x2f, 0xba, 0xe4, 0x7d, 0x54, 0x9e, 0xef, 0x3e,
+	0x9a, 0x7d, 0x3f, 0xbe, 0x03, 0x10, 0x9e, 0x08, 0x0b, 0xed, 0xf3, 0xca, 0x4d, 0x15, 0x9e, 0xae,
+	0xa9, 0xe0, 0xc4, 0x4d, 0xbc, 0x9a, 0x34, 0xf1, 0x7b, 0xb0, 0x14, 0x0e, 0x2c, 0xdf, 0x19, 0xf8,
+	0x8d, 0xda, 0x0c, 0x9a, 0x8b, 0x21, 0xde, 0xbe, 0x33, 0xf0, 0x8d, 0xef, 0x6b, 0xb0, 0xd2, 0xf6,
+	0x03, 0x67, 0x64, 0x07, 0x74, 0xcb, 0xf6, 0xd5, 0x7c, 0x96, 0x47, 0xc0, 0x94, 0x27, 0xc6, 0x9a,
+	0x59, 0xc2, 0x00, 0x98, 0xf6, 0x89, 0x01, 0x8b, 0x23, 0x67, 0x6c, 0x45, 0xe7, 0xc0, 0x03, 0xe4,
+	0xea, 0xc8, 0x19, 0x6f, 0xc9, 0xa3, 0x88, 0x9d, 0x53, 0x3e, 0x71, 0x4e, 0x6f, 0xb0, 0x58, 0x95,
+	0xdf, 0xd1, 0x85, 0x19, 0x77, 0xb4, 0x44, 0x30, 0x7e, 0x5f, 0x83, 0x46, 0xc7, 0xb3, 0x7b, 0x34,
+	0xeb, 0x9a, 0x4e, 0xde, 0x9a, 0x5a, 0xea, 0xd6, 0x54, 0x79, 0xe5, 0x4e, 0xe1, 0x45, 0x6e, 0x42,
+	0xb1, 0x67, 0x0f, 0x87, 0xbe, 0x08, 0xea, 0x25, 0xe6, 0xa6, 0x3d, 0x1c, 0xa2, 0x08, 0x26, 0x9f,
+	0x36, 0xfe, 0x34, 0x0f, 0x95, 0x10, 0xf8, 0xa9, 0xe7, 0x28, 0x6a, 0x28, 0xcf, 0xbd, 0x95, 0x1c,
+	0x32, 0x03, 0xe7, 0x59, 0x22, 0x0f, 0xfe, 0xf9, 0x80, 0x45, 0xe6, 0x03, 0xdb, 0x47, 0xdb, 0xd6,
+	0x4c, 0xf6, 0x93, 0xbc, 0x0d, 0x25, 0x3f, 0x70, 0x3d, 0x96, 0x26, 0x70, 0xbb, 0x5e, 0x91, 0x66,
+	0xc0, 0xa1, 0x7c, 0x37, 0x12, 0x27, 0x96, 0x21, 0x94, 0xe7, 0xcd, 0x10, 0x98, 0x87, 0xa6, 0xcf,
+	0xe8, 0x38, 0x90, 0xb6, 0x2d, 0x46, 0x91, 0x16, 0xe1, 0x44, 0x2d, 0x92, 0x35, 0xa8, 0x1c, 0xba,
+	0x7e, 0x60, 0xd9, 0x13, 0xc7, 0x6f, 0x54, 0x11, 0xf7, 0x8c, 0xc0, 0xdd, 0xb2, 0x59, 0x1a, 0x7b,
+	0xe0, 0x0c, 0xa9, 0x59, 0x66, 0x38, 0xad, 0x89, 0xe3, 0x93, 0x26, 0xcf, 0x24, 0xf8, 0xe7, 0x5a,
+	0x9b, 0x85, 0x1f, 0xe1, 0x18, 0x1f, 0x01, 0x44, 0x13, 0x4c, 0xd3, 0x4a, 0x0e, 0x82, 0xbf, 0x99,
+	0x3e, 0xa3, 0x12, 0x4f, 0xde, 0xe4, 0x03, 0xa9, 0xcf, 0x7c, 0xa8, 0x4f, 0xe3, 0x1f, 0x35, 0xa8,
+	0xa9, 0xaa, 0x23, 0x37, 0x21, 0xe7, 0x4e, 0x84, 0x53, 0x5e, 0xcd, 0xd0, 0xed, 0xda, 0xee, 0xc4,
+	0xcc, 0xb9, 0x93, 0x98, 0x6d, 0xe4, 0x12, 0xb6, 0x21, 0x12, 0xaa, 0x7c, 0x2c, 0xa1, 0x3a, 0x70,
+	0xe8, 0xb0, 0x2f, 0x8e, 0x9d, 0x0f, 0xa2, 0x34, 0xab, 0xa8, 0xe4, 0xc8, 0x0c, 0x3a, 0xb1, 0x8f,
+	0xa9, 0x87, 0xc7, 0x5e, 0x31, 0xf9, 0xc0, 0xb8, 0x01, 0xb9, 0xdd, 0x09, 0x29, 0x43, 0xc1, 0x6c,
+	0xb7, 0xee, 0xd7, 0x5f, 0x21, 0x15, 0x28, 0x7e, 0x62, 0x6e, 0x77, 0xda, 0x75, 0x8d, 0xc5, 0x89,
+	0xf7, 0xdb, 0x8f, 0xda, 0x1d, 0x16, 0x15, 0xfe, 0x57, 0x1e, 0x8a, 0x3c, 0xe9, 0xcf, 0xca, 0x4e,
+	0x1a, 0x50, 0x7a, 0x46, 0x3d, 0x3f, 0xf2, 0xce, 0x72, 0xc8, 0xcc, 0x79, 0x62, 0x7b, 0x74, 0x2c,
+	0x4a, 0x43, 0x5c, 0x74, 0xe0, 0x20, 0xcc, 0x9b, 0x5f, 0x83, 0xa5, 0xe0, 0xc8, 0x1a, 0x51, 0xef,
+	0xe9, 0x90, 0x72, 0x1c, 0xbe, 0x95, 0x5a, 0x70, 0xf4, 0x18, 0x81, 0x88, 0xf5, 0x2e, 0xac, 0x46,
+	0x61, 0x7a, 0x0c, 0x9b, 0x6f, 0x71, 0x25, 0x0c, 0xd0, 0x95, 0x45, 0xab, 0xb0, 0x20, 0xbe, 0x72,
+	0x9e, 0xc6, 0x88, 0x91, 0x5a, 0x21, 0x29, 0xc5, 0x2b, 0x24, 0xf2, 0xf2, 0x29, 0x2b, 0x97, 0x4f,
+	0x2c, 0xb1, 0xaf, 0x24, 0x12, 0xfb, 0xf3, 0x50, 0x0e, 0xcb, 0x37, 0xc0, 0x77, 0x1e, 0x88, 0xba,
+	0xcd, 0x0d, 0x28, 0x38, 0xe3, 0x03, 0x17, 0x1d, 0x6f, 0x64, 0x77, 0xa8, 0xc3, 0x35, 0x2c, 0x6f,
+	0xe1, 0x74, 0x2a, 0x54, 0xa8, 0xcd, 0x17, 0x2a, 0xe8, 0xfb, 0x50, 0x60, 0x54, 0x62, 0x45, 0xb0,
+	0xa2, 0x28, 0x82, 0xad, 0xc2, 0x42, 0x70, 0xc8, 0x6a, 0x2a, 0x32, 0x1c, 0xe7, 0x23, 0x76, 0x18,
+	0x5d, 0x3b, 0xe8, 0x1d, 0x5a, 0x18, 0xe8, 0xa1, 0xcf, 0x2a, 0x9a, 0x80, 0xa0, 0x6d, 0x06, 0x61,
+	0xae, 0x73, 0x11, 0x25, 0x0c, 0xfd, 0xe5, 0xbb, 0x89, 0xb4, 0xe6, 0x82, 0xba, 0x8f, 0x59, 0x09,
+	0x8d, 0x01, 0xc5, 0xa8, 0xfe, 0x56, 0x5d, 0xaf, 0xc5, 0xd6, 0xf0, 0x29, 0xe3, 0x56, 0x76, 0x6e,
+	0x92, 0xcc, 0x47, 0x34, 0xe3, 0xbf, 0x73, 0x50, 0xc5, 0x95, 0x1f, 0x51, 0xbb, 0x4f, 0xbd, 0xff,
+	0x77, 0xf6, 0xa7, 0x9a, 0x58, 0x25, 0xdb, 0xc4, 0xe0, 0x64, 0x13, 0x7b, 0x0d, 0x0a, 0x2c, 0x2a,
+	0x10, 0x96, 0x98, 0xbe, 0xdf, 0x71, 0xd6, 0x78, 0x06, 0x2b, 0x8a, 0x9a, 0x7f, 0x3a, 0x03, 0x78,
+	0x03, 0x16, 0x0e, 0x91, 0x4c, 0x22, 0x99, 0x55, 0x19, 0x08, 0x0c, 0xe3, 0x1f, 0x72, 0x70, 0x66,
+	0x13, 0xa3, 0xab, 0x44, 0x71, 0x7c, 0x4c, 0x03, 0xb5, 0x06, 0xc4, 0xaa, 0xc1, 0x78, 0x01, 0xbe,
+	0x0e, 0x75, 0x2c, 0xd1, 0xf7, 0xdc, 0xa1, 0xa5, 0x9e, 0x7a, 0xc5, 0x5c, 0x96, 0xf0, 0x8f, 0xc5,
+	0xe9, 0xab, 0x81, 0x5c, 0x3e, 0x1e, 0xc8, 0xc5, 0x0b, 0xc5, 0x85, 0x93, 0x0b, 0xc5, 0xca, 0x49,
+	0x47, 0x85, 0x62, 0x59, 0xf6, 0x8b, 0x6a, 0xc0, 0x0b, 0x89, 0x1a, 0xf0, 0x6b, 0xb0, 0x14, 0x4e,
+	0x72, 0x1a, 0xfc, 0xbc, 0x6b, 0x12, 0x03, 0x49, 0x5c, 0x83, 0x9a, 0x38, 0x7f, 0x6b, 0xe8, 0xf8,
+	0x3c, 0x52, 0xac, 0x98, 0x55, 0x01, 0x7b, 0xe4, 0xf8, 0x58, 0x2d, 0x66, 0x84, 0x62, 0x68, 0xfc,
+	0x0a, 0x65, 0x0c, 0x3e, 0x89, 0x30, 0x8d, 0x3f, 0xcf, 0xc1, 0x0a, 0x6a, 0x33, 0x51, 0x2b, 0x8f,
+	0x6f, 0x57, 0x9b, 0x63, 0xbb, 0xb9, 0xac, 0xed, 0xce, 0x5b, 0x3f, 0x7f, 0x0b, 0x88, 0x82, 0x27,
+	0xad, 0x9d, 0x7f, 0x59, 0xf5, 0x10, 0x55, 0x08, 0x7e, 0x72, 0x21, 0x5d, 0xb5, 0x7f, 0x5e, 0x46,
+	0x0f, 0xed, 0x7f, 0xfe, 0x22, 0x7a, 0xbc, 0x1a, 0x5f, 0x4e, 0x3e, 0xcf, 0x5c, 0x87, 0xc5, 0x0e,
+	0x96, 0x71, 0x95, 0x2c, 0x24, 0xe9, 0x64, 0x0c, 0x1b, 0x93, 0x74, 0x14, 0x6a, 0xe3, 0xf8, 0x14,
+	0x64, 0x7e, 0x8d, 0x8f, 0x26, 0x43, 0x1a, 0xc8, 0x4c, 0x2e, 0x1c, 0xf3, 0xe4, 0x99, 0x7b, 0xfb,
+	0x3c, 0x8f, 0xf1, 0xc5, 0xd0, 0x18, 0xc0, 0xb9, 0x88, 0x05, 0x0f, 0x47, 0x95, 0xec, 0x36, 0x16,
+	0xb2, 0x8a, 0xd1, 0x4f, 0xc8, 0xe8, 0x0e, 0x5c, 0x90, 0x8c, 0xf8, 0xe7, 0x78, 0xea, 0x8e, 0x8c,
+	0x7b, 0x70, 0x29, 0xb9, 0x64, 0x2e, 0x09, 0x8d, 0x8f, 0xa1, 0x2e, 0x17, 0x86, 0xb9, 0xfa, 0x59,
+	0x28, 0xf2, 0xca, 0x03, 0x47, 0xe5, 0x03, 0x16, 0xdf, 0xd0, 0x71, 0x5f, 0xb8, 0x70, 0xf6, 0x33,
+	0xb6, 0xbb, 0x7c, 0x7c, 0x77, 0xc6, 0x57, 0xe1, 0x8c, 0x42, 0x57, 0xd8, 0xf9, 0x5b, 0xb0, 0xc0,
+	0xdf, 0x68, 0x44, 0xce, 0x7d, 0x36, 0xcb, 0x5d, 0x99, 0x02, 0xe7, 0x84, 0x7c, 0xdb, 0xb8, 0x1b,
+	0x69, 0x88, 0x7d, 0x4a, 0x74, 0xf3, 0xd0, 0x1e, 0x0f, 0xa8, 0x7f, 0xda, 0x66, 0xff, 0x53, 0x83,
+	0xaa, 0x82, 0x4f, 0xde, 0x84, 0xc2, 0x53, 0xf6, 0x7e, 0xc4, 0x9d, 0xe7, 0xb9, 0x30, 0xf0, 0x0b,
+	0x31, 0xd6, 0x1e, 0x3a, 0xe3, 0xbe, 0x89, 0x48, 0x3f, 0x7e, 0xec, 0xc7, 0xa3, 0xbc, 0x82, 0x1a,
+	0xe5, 0x35, 0xa0, 0xd4, 0xa7, 0x4c, 0x3f, 0x7d, 0xfc, 0x92, 0xca, 0xa6, 0x1c, 0x1a, 0x0f, 0xa0,
+	0xc0, 0x78, 0x61, 0x65, 0xbc, 0xb3, 0x6b, 0xb6, 0xb6, 0xda, 0xf5, 0x57, 0x58, 0x39, 0xba, 0xb3,
+	0xfb, 0xb0, 0xbd, 0x63, 0x89, 0x72, 0x78, 0x5d, 0x23, 0x25, 0xc8, 0x9b, 0xad, 0xc7, 0xf5, 0x1c,
+	0xfb, 0xb1, 0xd5, 0xda, 0xaf, 0xe7, 0x49, 0x0d, 0xca, 0x9b, 0xbb, 0x3b, 0x1d, 0xb3, 0xb5, 0xd9,
+	0xa9, 0x17, 0x8c, 0x23, 0xb8, 0x98, 0xad, 0x19, 0x71, 0x04, 0xb3, 0x2c, 0x55, 0x1a, 0x55, 0x4e,
+	0xf9, 0x4c, 0xde, 0x82, 0x52, 0x8f, 0x2f, 0x17, 0x19, 0x14, 0x49, 0x6b, 0xc8, 0x94, 0x28, 0xc6,
+	0xe7, 0x60, 0xf1, 0x81, 0xe7, 0xfe, 0x02, 0x1d, 0x6f, 0xd8, 0x43, 0x7b, 0xdc, 0x43, 0x56, 0x3c,
+	0x79, 0x16, 0x09, 0xa7, 0x18, 0x65, 0x55, 0xcb, 0x8d, 0xaf, 0x41, 0xf9, 0x63, 0x37, 0xc0, 0x47,
+	0x4d, 0xb6, 0xce, 0x9d, 0x60, 0x31, 0x41, 0x3c, 0xe2, 0xf0, 0x11, 0xaa, 0xd4, 0x0d, 0xa8, 0x2f,
+	0xf2, 0x53, 0x3e, 0x60, 0x8f, 0x81, 0xbd, 0x21, 0xb5, 0x59, 0xe9, 0x99, 0xcf, 0xf2, 0x38, 0xbf,
+	0x26, 0x80, 0x8c, 0xaa, 0x6f, 0x7c, 0x1d, 0xf4, 0x2d, 0x2a, 0x9f, 0xba, 0x3c, 0xc9, 0xe9, 0xf4,
+	0x52, 0xe2, 0x6d, 0xa8, 0x77, 0x8f, 0xad, 0xa1, 0xcb, 0x36, 0x18, 0x58, 0x78, 0x3b, 0x09, 0x53,
+	0x5c, 0xea, 0x1e, 0x3f, 0xe2, 0x60, 0x74, 0xe8, 0xc6, 0xbf, 0x69, 0x70, 0x21, 0x93, 0x45, 0xa4,
+	0xf7, 0xc9, 0xb4, 0x1b, 0xbd, 0xb8, 0x88, 0x11, 0xb3, 0x9c, 0xa1, 0xdb, 0x13, 0x6a, 0x67, 0x3f,
+	0x19, 0x64, 0xea, 0x0d, 0xa5, 0x2d, 0x4d, 0xbd, 0x21, 0x79, 0x15, 0x16, 0xd8, 0x75, 0xeb, 0x84,
+	0x89, 0xc4, 0x98, 0x06, 0xdb, 0xfd, 0xe4, 0x8b, 0x61, 0x31, 0xf5, 0x62, 0xb8, 0x1a, 0x46, 0x07,
+	0x3c, 0xa9, 0x10, 0x23, 0x06, 0x77, 0xc7, 0x43, 0x67, 0x4c, 0xd1, 0x1f, 0x97, 0x4d, 0x31, 0x8a,
+	0x14, 0x5c, 0x56, 0x14, 0x6c, 0x8c, 0x60, 0x45, 0xd9, 0x98, 0xea, 0x24, 0x78, 0x35, 0x40, 0xcb,
+	0x2e, 0xa2, 0xc6, 0x4b, 0x9a, 0x59, 0x8a, 0xcc, 0x67, 0x2a, 0xf2, 0x9f, 0x34, 0x38, 0x1b, 0xe7,
+	0x27, 0x34, 0xb8, 0x01, 0x15, 0xb9, 0x57, 0xe9, 0x3f, 0x5e, 0x93, 0xf9, 0x62, 0x06, 0xfe, 0x9a,
+	0x84, 0x98, 0xd1, 0xb2, 0x59, 0xe2, 0xe9, 0x5f, 0x85, 0x72, 0xa8, 0xb5, 0xd9, 0xd6, 0xf0, 0x9e,
+	0x88, 0xe8, 0x78, 0xd8, 0x64, 0xa4, 0x99, 0x27, 0x4f, 0x9d, 0x87, 0x78, 0xc6, 0xaf, 0x69, 0xe8,
+	0x64, 0xd9, 0x6c, 0xa4, 0x3f, 0x1d, 0xca, 0xe1, 0xd1, 0x89, 0x32, 0x83, 0x1c, 0xcf, 0x28, 0x8a,
+	0x46, 0xc2, 0xe7, 0x4f, 0xd5, 0x6d, 0x21, 0x53, 0xb7, 0x7f, 0xab, 0xc1, 0x19, 0x45, 0x90, 0xb0,
+	0x1e, 0xba, 0xf0, 0xcc, 0x0d, 0x22, 0xad, 0x5e, 0x8e, 0x36, 0x16, 0xc7, 0x5c, 0xc3, 0xa1, 0x29,
+	0xb0, 0x4f, 0x50, 0x66, 0x11, 0x11, 0x4f, 0xd0, 0xe4, 0x4f, 0xf1, 0x29, 0x7f, 0x08, 0xe7, 0xb7,
+	0x68, 0x20, 0x82, 0x93, 0xfd, 0xde, 0x21, 0xed, 0x4f, 0x87, 0x54, 0x2a, 0x95, 0xe5, 0x50, 0x18,
+	0xd4, 0x44, 0x5c, 0xf3, 0x26, 0x20, 0x88, 0xc7, 0x12, 0x7f, 0x91, 0x07, 0x3d, 0x6b, 0xf9, 0x7c,
+	0x81, 0x18, 0x7b, 0x59, 0x70, 0x3c, 0x3f, 0x88, 0x35, 0x30, 0x00, 0x82, 0x38, 0xc2, 0x35, 0xa8,
+	0xf5, 0xa6, 0x1e, 0x66, 0x34, 0xfe, 0xd0, 0x95, 0xa5, 0xfb, 0xaa, 0x80, 0xed, 0x0f, 0x5d, 0x14,
+	0x91, 0x4d, 0x59, 0x43, 0x3a, 0x1e, 0x04, 0x87, 0x22, 0xb6, 0x05, 0x06, 0x7a, 0x84, 0x10, 0xb2,
+	0x05, 0x15, 0x11, 0x92, 0x51, 0xbf, 0x51, 0xc4, 0x13, 0x79, 0x3d, 0x3a, 0x91, 0x19, 0x92, 0xaf,
+	0x09, 0xb8, 0x19, 0xad, 0xd5, 0xff, 0x5e, 0x83, 0x92, 0x00, 0xcf, 0x74, 0x3f, 0xca, 0x11, 0xe5,
+	0xe2, 0x47, 0xc4, 0xec, 0xd3, 0xf5, 0x1d, 0xe5, 0xf5, 0x32, 0x1c, 0xb3, 0xd0, 0x79, 0x4c, 0x8f,
+	0xf8, 0x1e, 0x79, 0x9c, 0x29, 0x3a, 0x30, 0x18, 0x94, 0xed, 0x12, 0xc3, 0xcc, 0x5b, 0xb0, 0x1c,
+	0xef, 0x3d, 0xf0, 0x45, 0xf8, 0xb8, 0x34, 0x51, 0x7b, 0x0e, 0x7c, 0xa6, 0xb5, 0x91, 0xe3, 0xb3,
+	0x6e, 0x1b, 0x46, 0xd0, 0x17, 0x91, 0x7a, 0x95, 0xc3, 0x18, 0x39, 0xdf, 0x38, 0x80, 0xfa, 0x96,
+	0xa8, 0x19, 0x87, 0x87, 0xc5, 0xe2, 0x6e, 0xf7, 0x39, 0xb3, 0xf9, 0xa8, 0xbe, 0xcc, 0x6f, 0x9a,
+	0x25, 0x0e, 0x97, 0x2b, 0x18, 0xe6, 0x88, 0xf6, 0x1d, 0x7b, 0xac, 0x60, 0x72, 0xcb, 0x5b, 0xe2,
+	0x70, 0x89, 0x69, 0xfc, 0x4f, 0x05, 0x4a, 0xe2, 0x51, 0x24, 0xb3, 0xc2, 0xd4, 0x80, 0x52, 0x97,
+	0x5f, 0x6f, 0x82, 0x80, 0x1c, 0x92, 0x3b, 0xbc, 0xc0, 0x8a, 0x0e, 0x22, 0x8f, 0x0e, 0x62, 0x35,
+	0x2c, 0x3e, 0x23, 0x3d, 0x56, 0xd5, 0xe2, 0x9d, 0x33, 0x03, 0xfe, 0x83, 0x2d, 0x61, 0x8d, 0x07,
+	0xb8, 0xa4, 0x90, 0xb9, 0x44, 0x76, 0x25, 0x95, 0x3c, 0x7b, 0x84, 0x4b, 0x5a, 0x50, 0x9d, 0x50,
+	0x8f, 0x69, 0x06, 0x03, 0x47, 0x6e, 0x1e, 0x57, 0x12, 0xab, 0xf6, 0x22, 0x0c, 0xde, 0xae, 0xa0,
+	0xae, 0x21, 0xeb, 0xb0, 0x30, 0xf0, 0xdc, 0xe9, 0x84, 0x37, 0x16, 0x44, 0xef, 0x55, 0xa1, 0x98,
+	0x38, 0xc9, 0x17, 0x0a, 0x4c, 0xf2, 0x79, 0x58, 0x3e, 0xc0, 0xbb, 0xdd, 0x12, 0xdb, 0x95, 0x05,
+	0x76, 0x19, 0xc1, 0xc5, 0x6e, 0x7e, 0x73, 0xe9, 0x40, 0x1d, 0xb2, 0xd2, 0x20, 0xb0, 0x0f, 0x1a,
+	0x77, 0x2a, 0x4b, 0x92, 0xcb, 0x62, 0x65, 0xe8, 0x33, 0x2b, 0xcf, 0xc4, 0x2f, 0x5f, 0xff, 0x02,
+	0xc0, 0xde, 0x90, 0xf6, 0x07, 0x38, 0x64, 0x3a, 0x9f, 0xe0, 0x48, 0x3a, 0x4a, 0x39, 0x54, 0x22,
+	0x8c, 0x9c, 0x1a, 0x61, 0xe8, 0x3f, 0xd2, 0xa0, 0x24, 0xb4, 0x8d, 0x4e, 0x45, 0x7c, 0x92, 0xfc,
+	0x89, 0x46, 0x13, 0x4e, 0x85, 0x03, 0x3b, 0x0c, 0xc6, 0xb2, 0x56, 0xac, 0xdf, 0x1c, 0x50, 0x0f,
+	0xbb, 0xba, 0x58, 0xbd, 0x90, 0x93, 0x5c, 0x56, 0xe1, 0x5b, 0xb6, 0x8f, 0xc9, 0x0a, 0xb2, 0xb7,
+	0xa2, 0xa2, 0x62, 0x85, 0x43, 0xd8, 0xf4, 0x0d, 0x58, 0x72, 0xc6, 0x3d, 0x8f, 0xda, 0x3e, 0xb5,
+	0xfc, 0x09, 0xa5, 0x7d, 0xf1, 0xaa, 0xb1, 0x28, 0xa1, 0xfb, 0x0c, 0x18, 0x79, 0x78, 0xfe, 0xb8,
+	0xcf, 0x07, 0xe4, 0x43, 0xa8, 0x71, 0x4a, 0x7d, 0x6e, 0x14, 0xfc, 0x80, 0xce, 0x27, 0x8f, 0x37,
+	0x54, 0x8d, 0x59, 0x15, 0xe8, 0x6c, 0xa0, 0x7f, 0x19, 0x4a, 0xc2, 0x5e, 0xd8, 0xe3, 0x42, 0xd8,
+	0x8d, 0x26, 0xdd, 0x58, 0x08, 0x60, 0x86, 0x8d, 0xef, 0x00, 0x22, 0x00, 0x9b, 0xfa, 0x5c, 0xa0,
+	0xe8, 0x05, 0x2b, 0x2f, 0x5e, 0xb0, 0xf4, 0x31, 0x14, 0xb6, 0x03, 0x3a, 0x4a, 0x35, 0xd4, 0x5d,
+	0xc6, 0xd0, 0xe3, 0x29, 0x3d, 0xb6, 0x26, 0xb6, 0xe3, 0x89, 0x90, 0xa8, 0xe2, 0xf8, 0x0f, 0xe9,
+	0xf1, 0x9e, 0xed, 0xe0, 0xc1, 0x3c, 0xe7, 0xaf, 0xaf, 0x9c, 0x9c, 0x18, 0xb1, 0xb7, 0xa2, 0xc8,
+	0x14, 0x45, 0x34, 0xa3, 0x40, 0xf4, 0x07, 0x50, 0x44, 0xf3, 0xcb, 0xfc, 0xf6, 0x5e, 0x87, 0xa2,
+	0x13, 0xd0, 0x11, 0x3b, 0x19, 0xb5, 0x06, 0x2e, 0xd5, 0xc2, 0x04, 0x35, 0x39, 0x86, 0xfe, 0x9b,
+	0x1a, 0x40, 0xf4, 0x15, 0x64, 0x52, 0xbb, 0x02, 0x55, 0x34, 0x6e, 0xac, 0x62, 0x70, 0x9a, 0x15,
+	0x13, 0x10, 0xc4, 0x0a, 0x19, 0x7e, 0xc4, 0x2e, 0x7f, 0x1a, 0x3b, 0xa6, 0x6e, 0x56, 0xc4, 0xf3,
+	0x0f, 0xdd, 0xa1, 0x6c, 0x46, 0x8b, 0x00, 0xfa, 0x57, 0xa0, 0x9e, 0xfc, 0x22, 0x33, 0xba, 0x6f,
+	0x9a, 0x6a, 0xf7, 0x4d, 0xc6, 0xa1, 0x87, 0x14, 0xd4, 0xc6, 0x9c, 0x5d, 0xa8, 0x2a, 0x9f, 0x6b,
+	0x06, 0xd5, 0x37, 0xe2, 0x54, 0xcf, 0x66, 0x7d, 0xeb, 0x0a, 0x41, 0xe3, 0xfb, 0x3c, 0x42, 0x48,
+	0x3c, 0x28, 0x67, 0xe9, 0x6f, 0xee, 0xd0, 0x98, 0xdd, 0x03, 0xce, 0xb8, 0x37, 0x9c, 0xf6, 0xa9,
+	0x25, 0x12, 0x7f, 0x19, 0xfa, 0x09, 0xb0, 0x78, 0xac, 0x4d, 0x3d, 0xff, 0x14, 0xd2, 0x4d, 0x13,
+	0x3f, 0xd2, 0xa0, 0xbc, 0x29, 0xb3, 0xae, 0xa4, 0x55, 0x12, 0x28, 0x60, 0x13, 0x96, 0xc8, 0x61,
+	0xd8, 0x6f, 0x76, 0x8d, 0x0d, 0xed, 0xf1, 0x60, 0xca, 0x7b, 0xbb, 0x18, 0x3c, 0x1c, 0xab, 0x85,
+	0x49, 0xf1, 0x30, 0x23, 0x86, 0xe4, 0x16, 0x14, 0xec, 0xae, 0x23, 0xfd, 0xab, 0x3c, 0x7a, 0xc9,
+	0x78, 0xad, 0xb5, 0xb1, 0x6d, 0x22, 0x82, 0xde, 0x87, 0x7c, 0x6b, 0x63, 0x3b, 0x53, 0x41, 0x04,
+	0x0a, 0xb6, 0x37, 0x90, 0x96, 0x85, 0xbf, 0x53, 0xef, 0x8e, 0xf9, 0xb9, 0xde, 0x1d, 0x8d, 0x1d,
+	0x20, 0x5b, 0x34, 0x90, 0xec, 0xe5, 0xa9, 0x24, 0xb7, 0x3f, 0x7f, 0xb2, 0xf2, 0xc7, 0x1a, 0x9c,
+	0x57, 0x08, 0x8a, 0x97, 0x8e, 0x59, 0x74, 0x85, 0x55, 0xe5, 0x32, 0x1e, 0x36, 0xf2, 0xea, 0xc3,
+	0xc6, 0xdc, 0x71, 0x68, 0xea, 0xa0, 0x8b, 0xe9, 0x83, 0xf6, 0x40, 0xcf, 0x92, 0x30, 0x6a, 0x2e,
+	0xc5, 0x67, 0x36, 0x4d, 0x79, 0x66, 0x63, 0x3d, 0xb0, 0xc9, 0x02, 0x59, 0xa5, 0xab, 0x16, 0xf2,
+	0x4e, 0xeb, 0xc8, 0xf9, 0x67, 0xde, 0x3b, 0xb0, 0xc1, 0x4a, 0xee, 0x33, 0x74, 0xd3, 0x86, 0xd2,
+	0x37, 0xa7, 0xd4, 0x73, 0xa8, 0x0c, 0x96, 0xdf, 0x8c, 0x42, 0xb3, 0x13, 0xd6, 0xad, 0x7d, 0x79,
+	0x4a, 0xbd, 0x63, 0x53, 0xae, 0x9d, 0xff, 0xa8, 0xf4, 0x2f, 0x42, 0x11, 0xd7, 0xfe, 0xa4, 0xa7,
+	0x62, 0x3c, 0x87, 0x2b, 0x33, 0x65, 0x4b, 0x69, 0x33, 0xff, 0x29, 0x6a, 0x73, 0x84, 0x8c, 0x13,
+	0x3c, 0x1f, 0x30, 0x99, 0xfc, 0xf9, 0x2d, 0x6d, 0xfe, 0xbc, 0xf1, 0x5b, 0x70, 0x75, 0x36, 0xbb,
+	0x28, 0x09, 0x47, 0xa5, 0xf8, 0x62, 0xab, 0x62, 0xf4, 0x29, 0x6c, 0xf6, 0x6d, 0x38, 0xb7, 0x4f,
+	0xc7, 0xfd, 0xac, 0x47, 0xed, 0xac, 0x72, 0x9d, 0xc7, 0x7b, 0xac, 0xdc, 0xa7, 0x51, 0xcc, 0x24,
+	0xd1, 0x95, 0x08, 0x53, 0x8b, 0x47, 0x98, 0x19, 0x41, 0x58, 0x6e, 0xfe, 0x20, 0xcc, 0xf8, 0x1b,
+	0x0d, 0x56, 0x53, 0x4c, 0x4f, 0x2b, 0x80, 0x84, 0x6d, 0xbe, 0x39, 0xb5, 0xcd, 0x77, 0xee, 0x53,
+	0xc9, 0xf2, 0xfd, 0x85, 0xb9, 0x7c, 0x7f, 0x86, 0x4b, 0xf8, 0x13, 0xee, 0xb5, 0x70, 0x03, 0xf7,
+	0xd6, 0xef, 0xfc, 0x9f, 0xed, 0x21, 0x8c, 0xd5, 0x0a, 0xd9, 0xd9, 0x78, 0x31, 0xd6, 0x2e, 0xf6,
+	0x0d, 0xd0, 0xb3, 0x84, 0xcc, 0x3e, 0xdd, 0x7c, 0x74, 0xba, 0x3a, 0x94, 0x51, 0xb0, 0xed, 0xfb,
+	0xf2, 0xca, 0x08, 0xc7, 0xb3, 0x32, 0x7f, 0xc3, 0x8f, 0x4e, 0xf4, 0xde, 0xfa, 0x1d, 0xb5, 0xa4,
+	0x95, 0xdd, 0x9e, 0x7d, 0x5e, 0xf0, 0x60, 0xa5, 0x24, 0x91, 0xee, 0x71, 0x1e, 0xfd, 0x1f, 0xe3,
+	0x43, 0x7b, 0x1f, 0x2e, 0x28, 0x4c, 0x1f, 0xd3, 0xc0, 0x66, 0x0e, 0x23, 0xdc, 0xa1, 0x0e, 0xe5,
+	0x91, 0x80, 0xc9, 0xba, 0x86, 0x1c, 0x1b, 0xef, 0x40, 0x43, 0x59, 0xba, 0xfb, 0x7c, 0xac, 0x3c,
+	0x65, 0x9d, 0x85, 0xa2, 0xcb, 0x00, 0x52, 0x62, 0x1c, 0x18, 0x5f, 0x83, 0x73, 0x51, 0x38, 0x82,
+	0x0b, 0xfd, 0x4f, 0xb3, 0x6a, 0xf7, 0xaf, 0x39, 0x68, 0xa4, 0xe9, 0x0b, 0x89, 0x3e, 0x0f, 0x0b,
+	0xa8, 0x1d, 0xe9, 0xea, 0x6f, 0x44, 0xae, 0x3e, 0x73, 0xc1, 0x1a, 0x0e, 0x4d, 0xb1, 0x88, 0x3c,
+	0x80, 0x4a, 0x20, 0x76, 0x2a, 0x3f, 0xd4, 0xdb, 0x73, 0x51, 0xb8, 0xb7, 0x7e, 0xc7, 0x8c, 0x96,
+	0xea, 0xcf, 0xa0, 0xd8, 0x91, 0xcd, 0xf5, 0x19, 0x67, 0x3a, 0x3b, 0x23, 0xcd, 0xf0, 0x17, 0xf9,
+	0xf9, 0xfd, 0x85, 0xfe, 0x01, 0x94, 0xa5, 0x38, 0xf3, 0xb1, 0x8e, 0x8c, 0xd9, 0xf8, 0x3b, 0x0d,
+	0x8a, 0x6d, 0xd6, 0x3e, 0x42, 0x6e, 0xb3, 0x95, 0x13, 0xa7, 0x27, 0x6a, 0xec, 0x32, 0xd4, 0xc1,
+	0xc9, 0xb5, 0x0e, 0x9b, 0x31, 0x39, 0x42, 0x78, 0x0b, 0xe5, 0x94, 0x3b, 0x5d, 0x96, 0x8a, 0xf3,
+	0xca, 0x93, 0xeb, 0x15, 0xa8, 0xca, 0xba, 0x7b, 0x54, 0x12, 0x05, 0x09, 0xda, 0xee, 0x1b, 0x3f,
+	0xc7, 0x14, 0xc6, 0x28, 0x9e, 0x85, 0xba, 0xac, 0x8c, 0x5b, 0x66, 0x7b, 0xb3, 0xbd, 0xbd, 0xd7,
+	0xa9, 0xbf, 0x42, 0x08, 0x2c, 0x85, 0xd0, 0xf6, 0xc7, 0xed, 0x1d, 0xd6, 0x71, 0x7e, 0x0e, 0x56,
+	0x3a, 0x66, 0x6b, 0x67, 0xbf, 0xb5, 0xd9, 0xd9, 0xde, 0xdd, 0xb1, 0xe4, 0x8b, 0x77, 0x8e, 0xbd,
+	0xd8, 0xd5, 0xf7, 0xa7, 0x5d, 0xbf, 0xe7, 0x39, 0xdd, 0xd0, 0xd5, 0xbc, 0xc1, 0x0c, 0x63, 0xe2,
+	0xf4, 0xb8, 0x61, 0x64, 0x6f, 0x4a, 0x60, 0xb0, 0xe2, 0xda, 0x81, 0x33, 0x0c, 0xc2, 0xc7, 0x56,
+	0x59, 0x5c, 0x4b, 0x12, 0x5d, 0x7b, 0x80, 0x58, 0xa6, 0xc0, 0xd6, 0x7f, 0x45, 0x83, 0x05, 0x0e,
+	0x4a, 0x6e, 0x58, 0x4b, 0x6e, 0x18, 0xab, 0x4e, 0x11, 0x82, 0x74, 0x1f, 0xd5, 0x08, 0x83, 0x05,
+	0x9e, 0x3c, 0x18, 0xe5, 0x06, 0x70, 0x6d, 0x96, 0x10, 0x2d, 0x6f, 0x20, 0xe4, 0x40, 0x74, 0xfd,
+	0x2e, 0x54, 0x42, 0x50, 0x46, 0x76, 0xb1, 0x0a, 0x0b, 0x98, 0x3a, 0x48, 0x96, 0x62, 0x64, 0xdc,
+	0x83, 0x33, 0x0a, 0x69, 0xf1, 0x39, 0x19, 0x50, 0xc4, 0x8e, 0xa2, 0x86, 0x16, 0xeb, 0x3b, 0x40,
+	0xa5, 0x99, 0x7c, 0xca, 0xf8, 0x9e, 0x06, 0xab, 0xe1, 0xca, 0xf8, 0xa3, 0x94, 0xec, 0xea, 0x8d,
+	0xbd, 0x5e, 0x60, 0x57, 0xaf, 0xe8, 0x0c, 0xbb, 0x06, 0x35, 0x8f, 0xfa, 0xd3, 0x11, 0xb5, 0x54,
+	0x6f, 0x5f, 0xe5, 0x30, 0xfe, 0x05, 0x9d, 0xf0, 0x60, 0x45, 0x0c, 0xa8, 0x39, 0x9e, 0x47, 0x31,
+	0x03, 0x60, 0x59, 0x33, 0xbf, 0xa6, 0x62, 0x30, 0xe3, 0x0f, 0x34, 0x38, 0x97, 0x12, 0xef, 0x67,
+	0xdc, 0x8b, 0x91, 0xda, 0x57, 0x3e, 0xb5, 0xaf, 0xf5, 0x3f, 0xbb, 0x0e, 0xd0, 0x9a, 0x38, 0xfb,
+	0xd4, 0x7b, 0xe6, 0xf4, 0x28, 0xf9, 0x32, 0x54, 0xb7, 0x68, 0x20, 0xff, 0x49, 0x8c, 0xc8, 0xf4,
+	0x45, 0xfd, 0x8f, 0x39, 0x5d, 0x3e, 0x76, 0x25, 0xff, 0x95, 0xcc, 0x38, 0xfb, 0xcb, 0xff, 0xf2,
+	0xc3, 0xef, 0xe6, 0x96, 0x48, 0xad, 0x39, 0x50, 0x68, 0x7c, 0x02, 0x8b, 0x82, 0x24, 0xdf, 0x41,
+	0x36, 0xd1, 0xf3, 0x0a, 0xd1, 0xf8, 0x13, 0xb7, 0xb1, 0x8a, 0x64, 0xeb, 0x64, 0x49, 0x92, 0x15,
+	0x74, 0x3a, 0x50, 0xdb, 0xa2, 0xdc, 0x1b, 0xcf, 0x16, 0x56, 0xb6, 0xaf, 0xa5, 0x3a, 0x11, 0x8c,
+	0x57, 0x91, 0xec, 0x32, 0x59, 0x64, 0x64, 0x23, 0x2a, 0x3b, 0x00, 0x5b, 0x34, 0x90, 0xd5, 0x90,
+	0x4c, 0x9a, 0xb2, 0xd4, 0x96, 0xf8, 0xc7, 0x3f, 0x63, 0x05, 0x29, 0x2e, 0x92, 0x2a, 0xa3, 0x28,
+	0x29, 0x7c, 0x15, 0x35, 0xda, 0x39, 0xe2, 0x0f, 0xb1, 0xe4, 0x6c, 0xd8, 0x4e, 0xa7, 0xbc, 0xcb,
+	0xea, 0x27, 0x34, 0x7e, 0x1b, 0x17, 0x90, 0xea, 0xab, 0x64, 0xa5, 0x39, 0x88, 0xe8, 0x34, 0x5f,
+	0xb0, 0x60, 0xf0, 0x25, 0xe9, 0xe3, 0x8b, 0x47, 0xd8, 0x9b, 0xb7, 0x71, 0xdc, 0x39, 0x3a, 0x81,
+	0x4d, 0xaa, 0xe1, 0xd1, 0x78, 0x0d, 0x89, 0x5f, 0x26, 0x17, 0x39, 0xf1, 0x04, 0x19, 0xc9, 0xa5,
+	0x0b, 0xf5, 0x64, 0xe3, 0xe5, 0x0c, 0x0e, 0x57, 0xa2, 0x8d, 0x64, 0xf6, 0x69, 0x1a, 0xe7, 0x90,
+	0xe1, 0x19, 0xb2, 0xdc, 0x0c, 0x10, 0xe5, 0x48, 0xf2, 0xf8, 0x55, 0x0d, 0x96, 0x13, 0xff, 0x3c,
+	0x40, 0x2e, 0x45, 0x97, 0x5e, 0xc6, 0xff, 0x2d, 0xe8, 0x97, 0x67, 0x4d, 0x0b, 0x5e, 0xef, 0x22,
+	0xaf, 0xb7, 0xc9, 0x9b, 0xcd, 0x41, 0x1c, 0xa3, 0xf9, 0x42, 0xdc, 0xf7, 0x2f, 0x9b, 0x2f, 0x78,
+	0x33, 0xf9, 0xcb, 0xe6, 0x0b, 0x8c, 0xcd, 0x5e, 0x12, 0x8a, 0xe6, 0x1a, 0xb5, 0xec, 0x93, 0x0b,
+	0xe9, 0x9b, 0x37, 0xfc, 0x57, 0x02, 0xfd, 0x62, 0xf6, 0xa4, 0x10, 0xe0, 0x3c, 0x0a, 0xb0, 0x62,
+	0xa0, 0xe5, 0x46, 0xf3, 0x1f, 0x68, 0x6f, 0x90, 0x5f, 0xe7, 0x6f, 0x55, 0xa9, 0x7e, 0x7a, 0xa2,
+	0xbc, 0x0d, 0xcd, 0xea, 0xd2, 0xd7, 0xaf, 0x9f, 0x88, 0x23, 0x98, 0xdf, 0x42, 0xe6, 0xd7, 0xc8,
+	0x95, 0xe6, 0x20, 0x03, 0x2d, 0x52, 0x01, 0xf9, 0x25, 0x1e, 0xdd, 0x67, 0xf4, 0xbd, 0x13, 0xf5,
+	0x95, 0x6c, 0x66, 0x83, 0xbe, 0x7e, 0xe3, 0x14, 0xac, 0x2c, 0x6d, 0x48, 0x44, 0xae, 0x8d, 0x6f,
+	0x60, 0x95, 0x22, 0x84, 0xfd, 0xc4, 0xdf, 0x8a, 0x81, 0x2c, 0x2e, 0x12, 0xbd, 0x39, 0x48, 0x91,
+	0x93, 0x86, 0xe6, 0xc2, 0x52, 0xbc, 0xdd, 0x83, 0x28, 0x87, 0x98, 0xee, 0x02, 0xd1, 0x33, 0x3b,
+	0x0d, 0x8c, 0xd7, 0x91, 0xd3, 0x75, 0x72, 0x8d, 0x71, 0x52, 0x56, 0x09, 0x2e, 0xcd, 0x17, 0xf2,
+	0x76, 0x78, 0x49, 0x9e, 0x47, 0x7d, 0x12, 0xb2, 0xb5, 0x82, 0x5c, 0x4e, 0xb1, 0x8c, 0xf5, 0x5c,
+	0xcc, 0x60, 0xfa, 0x36, 0x32, 0xbd, 0x45, 0x6e, 0x34, 0x07, 0x89, 0x75, 0xcd, 0x17, 0xfc, 0x72,
+	0x8b, 0x31, 0xfe, 0x16, 0x9a, 0x58, 0xaa, 0x19, 0x44, 0x35, 0xb1, 0x59, 0x9d, 0x22, 0xa1, 0x96,
+	0x33, 0x5a, 0xc7, 0xe2, 0x4e, 0x23, 0x45, 0x41, 0xea, 0xf9, 0xdb, 0xdc, 0xac, 0x32, 0x1a, 0x4b,
+	0x54, 0xb3, 0x9a, 0xdd, 0x77, 0x72, 0xa2, 0x08, 0xb7, 0x51, 0x04, 0x83, 0x5c, 0x6d, 0x0e, 0x32,
+	0x69, 0x84, 0xfa, 0x20, 0x4f, 0xa1, 0x22, 0xd9, 0xf8, 0xe4, 0x5c, 0x82, 0xb1, 0x9f, 0xbc, 0x26,
+	0x52, 0x8d, 0x27, 0xc6, 0x9b, 0xc8, 0xe9, 0x06, 0xb9, 0x1e, 0x72, 0xf2, 0x9b, 0x2f, 0xb0, 0xad,
+	0xe5, 0x65, 0xf3, 0x05, 0x1d, 0xf7, 0x63, 0x1a, 0xff, 0x8e, 0x16, 0xa9, 0x5c, 0xed, 0xa1, 0x48,
+	0xa9, 0x3c, 0xa3, 0xf5, 0x44, 0xbf, 0x7e, 0x22, 0x8e, 0x10, 0xe7, 0x26, 0x8a, 0x73, 0x95, 0x5c,
+	0x6e, 0x0e, 0x32, 0xd0, 0xa2, 0x6d, 0x53, 0xbc, 0xc6, 0xa4, 0x53, 0x69, 0xa4, 0xdc, 0x94, 0x64,
+	0xba, 0x14, 0x2f, 0xeb, 0xc6, 0x4d, 0x2c, 0xf4, 0x15, 0xac, 0x2a, 0xf9, 0xb2, 0xf9, 0x22, 0x99,
+	0x1a, 0xbd, 0x24, 0xbf, 0x2b, 0xbc, 0xb6, 0x52, 0x19, 0x88, 0x79, 0xed, 0x74, 0xc5, 0x40, 0xbf,
+	0x3c, 0x6b, 0x5a, 0xec, 0xf0, 0xf3, 0x28, 0xc1, 0x3d, 0x72, 0xb7, 0x39, 0x88, 0x63, 0xa8, 0x5e,
+	0x1b, 0xe3, 0x99, 0x4c, 0x89, 0xfe, 0x48, 0x43, 0x5f, 0x92, 0xc8, 0xa2, 0xc9, 0xd5, 0x04, 0xd7,
+	0x54, 0x15, 0x40, 0xbf, 0x76, 0x02, 0x86, 0x10, 0xed, 0x4b, 0x28, 0xda, 0x07, 0xe4, 0xb3, 0xcd,
+	0x41, 0x0a, 0x69, 0x3e, 0xe9, 0xbe, 0xa7, 0x61, 0x4b, 0x44, 0x32, 0x05, 0x4e, 0xe9, 0x2c, 0x9e,
+	0x93, 0xeb, 0x46, 0x7a, 0x3a, 0x99, 0x3d, 0x1b, 0x1b, 0x28, 0xdc, 0x87, 0xe4, 0x83, 0xe6, 0x20,
+	0x8d, 0x15, 0xc9, 0x24, 0xb3, 0xf8, 0x4c, 0xf1, 0xbe, 0xcb, 0xdb, 0x0d, 0x62, 0x69, 0xf6, 0x69,
+	0xb2, 0x5d, 0x49, 0x4f, 0xc7, 0xd2, 0x73, 0xe3, 0x8b, 0x28, 0xd8, 0xfb, 0xe4, 0x5e, 0x73, 0x90,
+	0x40, 0x99, 0x53, 0xaa, 0xdf, 0xe2, 0x52, 0xc5, 0xf2, 0x5e, 0xd5, 0x83, 0x66, 0xe5, 0xf8, 0xfa,
+	0x95, 0x99, 0xf3, 0x42, 0xac, 0xf7, 0x50, 0xac, 0x77, 0xc8, 0x5a, 0x73, 0x90, 0x40, 0x51, 0x8f,
+	0x32, 0x2d, 0x0d, 0x0f, 0x91, 0xc3, 0x07, 0xe2, 0x13, 0x43, 0xe4, 0xe4, 0xc3, 0x73, 0x3c, 0x44,
+	0x0e, 0x69, 0xfc, 0xa1, 0x16, 0x6b, 0x94, 0x09, 0xdb, 0x99, 0xae, 0x9d, 0xd4, 0x27, 0x92, 0xb2,
+	0x8c, 0x59, 0xad, 0x24, 0xc6, 0xfb, 0xc8, 0xf4, 0x5d, 0x72, 0xa7, 0x39, 0x48, 0x63, 0x9d, 0xbc,
+	0x59, 0x1b, 0x63, 0xec, 0xbd, 0xb0, 0x0b, 0x46, 0xcf, 0x6c, 0x9b, 0xe1, 0xa2, 0x5c, 0x38, 0xa1,
+	0xa5, 0xc6, 0x68, 0xa0, 0x0c, 0xc4, 0x58, 0x54, 0x65, 0xc0, 0xbb, 0xff, 0x09, 0x3a, 0x68, 0xde,
+	0x2e, 0xa2, 0x3a, 0xe8, 0x58, 0xcf, 0x8b, 0xde, 0x48, 0x4f, 0xc4, 0xe3, 0x78, 0x03, 0x9a, 0x03,
+	0x39, 0xc7, 0xc8, 0x7e, 0x9b, 0xfb, 0x81, 0x44, 0xd3, 0x83, 0xea, 0x07, 0xb2, 0x1b, 0x41, 0xf4,
+	0x6b, 0x27, 0x60, 0x64, 0x5d, 0xfe, 0x09, 0xa4, 0xe6, 0x0b, 0xa5, 0x8d, 0xe4, 0x25, 0x19, 0x40,
+	0x55, 0xa9, 0x2d, 0x93, 0xf3, 0x11, 0xf1, 0xc4, 0x9b, 0x8c, 0xbe, 0x9c, 0x78, 0x2a, 0x32, 0xde,
+	0x42, 0x2e, 0x37, 0xc9, 0x6b, 0x98, 0xa0, 0x08, 0x68, 0xf3, 0xc5, 0x8c, 0x8f, 0xe4, 0x18, 0x48,
+	0xba, 0x88, 0xad, 0x6e, 0x37, 0xfb, 0x79, 0x41, 0xbf, 0x76, 0x02, 0x86, 0xd8, 0xee, 0x65, 0x14,
+	0xa4, 0x61, 0xac, 0x34, 0x07, 0x29, 0x24, 0xa6, 0x6a, 0xf1, 0x7f, 0xbf, 0x59, 0x0f, 0x05, 0xe4,
+	0xc6, 0x5c, 0x8f, 0x1c, 0xfa, 0xcd, 0xd3, 0xd0, 0x84, 0x28, 0xd7, 0x51, 0x94, 0x4b, 0x46, 0xa3,
+	0x39, 0xc8, 0xc6, 0x64, 0xf2, 0xb0, 0x7f, 0x14, 0x9b, 0x55, 0xd0, 0x27, 0x37, 0x67, 0xee, 0x37,
+	0xf6, 0xc0, 0xa0, 0xdf, 0x3a, 0x15, 0x2f, 0x1e, 0x0d, 0x19, 0xe7, 0x9b, 0x83, 0x19, 0xa8, 0x4c,
+	0xa6, 0xaf, 0xc3, 0x72, 0xa2, 0xca, 0x1f, 0xda, 0x42, 0xfa, 0x9f, 0x25, 0xc3, 0x3b, 0x72, 0xc6,
+	0xc3, 0x80, 0x41, 0x90, 0x67, 0xcd, 0x28, 0x35, 0x7d, 0x86, 0x71, 0xc4, 0x38, 0x98, 0xb0, 0xdc,
+	0x3e, 0xa2, 0xbd, 0x39, 0x39, 0xa4, 0x53, 0xc1, 0x88, 0x26, 0x65, 0x64, 0x90, 0xe6, 0xd7, 0xa0,
+	0xaa, 0xfc, 0x47, 0xe0, 0x49, 0xf4, 0xa4, 0x63, 0xc8, 0xf8, 0x07, 0x42, 0x99, 0xf3, 0x19, 0xb5,
+	0x26, 0x8d, 0x66, 0x19, 0xf9, 0x4f, 0xa0, 0x12, 0xd6, 0x44, 0xc2, 0x4f, 0x3f, 0x59, 0x59, 0xd2,
+	0x1b, 0xe9, 0x89, 0xd4, 0xa7, 0xef, 0xcb, 0xb9, 0x0f, 0xb4, 0x37, 0xde, 0xd1, 0xc8, 0x21, 0x9c,
+	0x0d, 0xb1, 0x95, 0xae, 0xf9, 0x6c, 0x67, 0xad, 0xab, 0x25, 0x82, 0x44, 0xed, 0xe1, 0x12, 0x72,
+	0x38, 0x47, 0x5e, 0x8d, 0x38, 0x28, 0x68, 0xef, 0x68, 0xc4, 0x85, 0xe5, 0x44, 0x59, 0x27, 0xbc,
+	0x2f, 0xb3, 0xab, 0x51, 0xfa, 0xe5, 0x59, 0xd3, 0xf1, 0x7c, 0xdf, 0xa8, 0x37, 0xfd, 0x38, 0x06,
+	0x6e, 0xad, 0xbb, 0x80, 0xff, 0x0b, 0xf1, 0xee, 0xff, 0x0e, 0x00, 0x74, 0xab, 0xe8, 0x40, 0xff,
+	0x48, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3678,6 +6897,8 @@ const _ = grpc.SupportPackageIsVersion4
 type ApiServiceClient interface {
 	// get the node information
 	GetNodeInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error)
+	// get the health and the sync status of the node
+	GetNodeStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*NodeStatusResponse, error)
 	// get blockchain information
 	GetChainInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ChainInfoResponse, error)
 	// get current blockchain ram information
@@ -3686,36 +6907,72 @@ type ApiServiceClient interface {
 	GetTxByHash(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	// get transaction receipt by transaction hash
 	GetTxReceiptByTxHash(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TxReceipt, error)
+	// execute again a transaction of a block after the last irreversible block, with the transactions before it, tracing the calls of its actions to the contracts, the storage they read and write and the receipts and events they post. The transactions of the irreversible blocks fail with FAILED_PRECONDITION, as their states are not kept
+	TraceTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TraceTransactionResponse, error)
+	// get irreversible transactions of an account, newest first
+	GetTxsByAccount(ctx context.Context, in *GetTxsByAccountRequest, opts ...grpc.CallOption) (*GetTxsByAccountResponse, error)
+	// get irreversible transactions of an account from a height down, newest first, paginated by cursor
+	GetAccountTxs(ctx context.Context, in *GetAccountTxsRequest, opts ...grpc.CallOption) (*GetAccountTxsResponse, error)
+	// get irreversible delay transactions of an account which are neither executed nor canceled yet
+	GetDelaytxsByAccount(ctx context.Context, in *GetDelaytxsByAccountRequest, opts ...grpc.CallOption) (*GetDelaytxsByAccountResponse, error)
+	// get transactions in the pending pool of the node, in the order they are packed
+	GetPendingTransactions(ctx context.Context, in *GetPendingTransactionsRequest, opts ...grpc.CallOption) (*GetPendingTransactionsResponse, error)
+	// get transaction in the pending pool of the node by transaction hash
+	GetPendingTxByHash(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	// get block by hash
 	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// get block by number
 	GetBlockByNumber(ctx context.Context, in *GetBlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// get the header of a block by hash, without its transactions
+	GetBlockHeaderByHash(ctx context.Context, in *GetBlockHeaderByHashRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error)
+	// get the header of a block by number, without its transactions
+	GetBlockHeaderByNumber(ctx context.Context, in *GetBlockHeaderByNumberRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error)
+	// get the blocks of a range of numbers, as many as the node returns at once
+	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error)
+	// get the state keys written by an irreversible block
+	GetBlockStateChanges(ctx context.Context, in *GetBlockStateChangesRequest, opts ...grpc.CallOption) (*GetBlockStateChangesResponse, error)
 	// get account
 	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	// get token balance
 	GetTokenBalance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetTokenBalanceResponse, error)
-	// get token721 balance
-	GetToken721Balance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error)
+	// get token721 balance and a page of the token ids held
+	GetToken721Balance(ctx context.Context, in *GetToken721BalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error)
 	// get token721 metadata
 	GetToken721Metadata(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721MetadataResponse, error)
 	// get token721 owner
 	GetToken721Owner(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721OwnerResponse, error)
+	// get the balances of all tokens held by an account
+	GetAccountTokens(ctx context.Context, in *GetAccountTokensRequest, opts ...grpc.CallOption) (*GetAccountTokensResponse, error)
 	// get gas ratio infomation
 	GetGasRatio(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GasRatioResponse, error)
 	// get producer vote infomation
 	GetProducerVoteInfo(ctx context.Context, in *GetProducerVoteInfoRequest, opts ...grpc.CallOption) (*GetProducerVoteInfoResponse, error)
+	// get a page of the producer candidates and their vote infomation, sorted by account
+	GetProducers(ctx context.Context, in *GetProducersRequest, opts ...grpc.CallOption) (*GetProducersResponse, error)
+	// get a page of the voters of a producer candidate, sorted by account
+	GetVoters(ctx context.Context, in *GetVotersRequest, opts ...grpc.CallOption) (*GetVotersResponse, error)
+	// get the witness schedule and the block production statistics of the witnesses
+	GetWitnessSchedule(ctx context.Context, in *GetWitnessScheduleRequest, opts ...grpc.CallOption) (*GetWitnessScheduleResponse, error)
 	// get contract
 	GetContract(ctx context.Context, in *GetContractRequest, opts ...grpc.CallOption) (*Contract, error)
 	// get contract storage
 	GetContractStorage(ctx context.Context, in *GetContractStorageRequest, opts ...grpc.CallOption) (*GetContractStorageResponse, error)
+	// get the values of several keys of contract storage at the same block
+	GetBatchContractStorage(ctx context.Context, in *GetBatchContractStorageRequest, opts ...grpc.CallOption) (*GetBatchContractStorageResponse, error)
 	// get contract fields storage
 	GetContractStorageFields(ctx context.Context, in *GetContractStorageFieldsRequest, opts ...grpc.CallOption) (*GetContractStorageFieldsResponse, error)
 	// send transaction
 	SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
 	// execute transaction
 	ExecTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TxReceipt, error)
+	// estimate the gas of a transaction by executing it with the gas limits it may have, the public keys of its signatures giving their permissions without the signatures being verified
+	EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
 	// subscribe an event
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
+	// subscribe the status of the chain and the node, sent whenever the head block changes
+	SubscribeChainStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (ApiService_SubscribeChainStatusClient, error)
+	// subscribe the blocks from a height or from a resume token, sent as they are produced after the ones already in the chain
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (ApiService_SubscribeBlocksClient, error)
 }
 
 type apiServiceClient struct {
@@ -3735,6 +6992,15 @@ func (c *apiServiceClient) GetNodeInfo(ctx context.Context, in *EmptyRequest, op
 	return out, nil
 }
 
+func (c *apiServiceClient) GetNodeStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*NodeStatusResponse, error) {
+	out := new(NodeStatusResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetChainInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ChainInfoResponse, error) {
 	out := new(ChainInfoResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetChainInfo", in, out, opts...)
@@ -3771,6 +7037,60 @@ func (c *apiServiceClient) GetTxReceiptByTxHash(ctx context.Context, in *TxHashR
 	return out, nil
 }
 
+func (c *apiServiceClient) TraceTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TraceTransactionResponse, error) {
+	out := new(TraceTransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/TraceTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetTxsByAccount(ctx context.Context, in *GetTxsByAccountRequest, opts ...grpc.CallOption) (*GetTxsByAccountResponse, error) {
+	out := new(GetTxsByAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetTxsByAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetAccountTxs(ctx context.Context, in *GetAccountTxsRequest, opts ...grpc.CallOption) (*GetAccountTxsResponse, error) {
+	out := new(GetAccountTxsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetAccountTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetDelaytxsByAccount(ctx context.Context, in *GetDelaytxsByAccountRequest, opts ...grpc.CallOption) (*GetDelaytxsByAccountResponse, error) {
+	out := new(GetDelaytxsByAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetDelaytxsByAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetPendingTransactions(ctx context.Context, in *GetPendingTransactionsRequest, opts ...grpc.CallOption) (*GetPendingTransactionsResponse, error) {
+	out := new(GetPendingTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetPendingTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetPendingTxByHash(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetPendingTxByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
 	out := new(BlockResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, opts...)
@@ -3789,6 +7109,42 @@ func (c *apiServiceClient) GetBlockByNumber(ctx context.Context, in *GetBlockByN
 	return out, nil
 }
 
+func (c *apiServiceClient) GetBlockHeaderByHash(ctx context.Context, in *GetBlockHeaderByHashRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error) {
+	out := new(BlockHeaderResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockHeaderByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockHeaderByNumber(ctx context.Context, in *GetBlockHeaderByNumberRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error) {
+	out := new(BlockHeaderResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockHeaderByNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error) {
+	out := new(GetBlocksResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockStateChanges(ctx context.Context, in *GetBlockStateChangesRequest, opts ...grpc.CallOption) (*GetBlockStateChangesResponse, error) {
+	out := new(GetBlockStateChangesResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockStateChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error) {
 	out := new(Account)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetAccount", in, out, opts...)
@@ -3807,7 +7163,7 @@ func (c *apiServiceClient) GetTokenBalance(ctx context.Context, in *GetTokenBala
 	return out, nil
 }
 
-func (c *apiServiceClient) GetToken721Balance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error) {
+func (c *apiServiceClient) GetToken721Balance(ctx context.Context, in *GetToken721BalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error) {
 	out := new(GetToken721BalanceResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetToken721Balance", in, out, opts...)
 	if err != nil {
@@ -3834,6 +7190,15 @@ func (c *apiServiceClient) GetToken721Owner(ctx context.Context, in *GetToken721
 	return out, nil
 }
 
+func (c *apiServiceClient) GetAccountTokens(ctx context.Context, in *GetAccountTokensRequest, opts ...grpc.CallOption) (*GetAccountTokensResponse, error) {
+	out := new(GetAccountTokensResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetAccountTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetGasRatio(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GasRatioResponse, error) {
 	out := new(GasRatioResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetGasRatio", in, out, opts...)
@@ -3852,6 +7217,33 @@ func (c *apiServiceClient) GetProducerVoteInfo(ctx context.Context, in *GetProdu
 	return out, nil
 }
 
+func (c *apiServiceClient) GetProducers(ctx context.Context, in *GetProducersRequest, opts ...grpc.CallOption) (*GetProducersResponse, error) {
+	out := new(GetProducersResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetProducers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetVoters(ctx context.Context, in *GetVotersRequest, opts ...grpc.CallOption) (*GetVotersResponse, error) {
+	out := new(GetVotersResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetVoters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetWitnessSchedule(ctx context.Context, in *GetWitnessScheduleRequest, opts ...grpc.CallOption) (*GetWitnessScheduleResponse, error) {
+	out := new(GetWitnessScheduleResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetWitnessSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetContract(ctx context.Context, in *GetContractRequest, opts ...grpc.CallOption) (*Contract, error) {
 	out := new(Contract)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetContract", in, out, opts...)
@@ -3870,6 +7262,15 @@ func (c *apiServiceClient) GetContractStorage(ctx context.Context, in *GetContra
 	return out, nil
 }
 
+func (c *apiServiceClient) GetBatchContractStorage(ctx context.Context, in *GetBatchContractStorageRequest, opts ...grpc.CallOption) (*GetBatchContractStorageResponse, error) {
+	out := new(GetBatchContractStorageResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBatchContractStorage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetContractStorageFields(ctx context.Context, in *GetContractStorageFieldsRequest, opts ...grpc.CallOption) (*GetContractStorageFieldsResponse, error) {
 	out := new(GetContractStorageFieldsResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetContractStorageFields", in, out, opts...)
@@ -3897,6 +7298,15 @@ func (c *apiServiceClient) ExecTransaction(ctx context.Context, in *TransactionR
 	return out, nil
 }
 
+func (c *apiServiceClient) EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
+	out := new(EstimateGasResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/EstimateGas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[0], "/rpcpb.ApiService/Subscribe", opts...)
 	if err != nil {
@@ -3929,10 +7339,76 @@ func (x *apiServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
 	return m, nil
 }
 
+func (c *apiServiceClient) SubscribeChainStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (ApiService_SubscribeChainStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[1], "/rpcpb.ApiService/SubscribeChainStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceSubscribeChainStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_SubscribeChainStatusClient interface {
+	Recv() (*ChainStatusResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceSubscribeChainStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceSubscribeChainStatusClient) Recv() (*ChainStatusResponse, error) {
+	m := new(ChainStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *apiServiceClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (ApiService_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[2], "/rpcpb.ApiService/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_SubscribeBlocksClient interface {
+	Recv() (*SubscribeBlocksResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceSubscribeBlocksClient) Recv() (*SubscribeBlocksResponse, error) {
+	m := new(SubscribeBlocksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ApiServiceServer is the server API for ApiService service.
 type ApiServiceServer interface {
 	// get the node information
 	GetNodeInfo(context.Context, *EmptyRequest) (*NodeInfoResponse, error)
+	// get the health and the sync status of the node
+	GetNodeStatus(context.Context, *EmptyRequest) (*NodeStatusResponse, error)
 	// get blockchain information
 	GetChainInfo(context.Context, *EmptyRequest) (*ChainInfoResponse, error)
 	// get current blockchain ram information
@@ -3941,128 +7417,290 @@ type ApiServiceServer interface {
 	GetTxByHash(context.Context, *TxHashRequest) (*TransactionResponse, error)
 	// get transaction receipt by transaction hash
 	GetTxReceiptByTxHash(context.Context, *TxHashRequest) (*TxReceipt, error)
+	// execute again a transaction of a block after the last irreversible block, with the transactions before it, tracing the calls of its actions to the contracts, the storage they read and write and the receipts and events they post. The transactions of the irreversible blocks fail with FAILED_PRECONDITION, as their states are not kept
+	TraceTransaction(context.Context, *TxHashRequest) (*TraceTransactionResponse, error)
+	// get irreversible transactions of an account, newest first
+	GetTxsByAccount(context.Context, *GetTxsByAccountRequest) (*GetTxsByAccountResponse, error)
+	// get irreversible transactions of an account from a height down, newest first, paginated by cursor
+	GetAccountTxs(context.Context, *GetAccountTxsRequest) (*GetAccountTxsResponse, error)
+	// get irreversible delay transactions of an account which are neither executed nor canceled yet
+	GetDelaytxsByAccount(context.Context, *GetDelaytxsByAccountRequest) (*GetDelaytxsByAccountResponse, error)
+	// get transactions in the pending pool of the node, in the order they are packed
+	GetPendingTransactions(context.Context, *GetPendingTransactionsRequest) (*GetPendingTransactionsResponse, error)
+	// get transaction in the pending pool of the node by transaction hash
+	GetPendingTxByHash(context.Context, *TxHashRequest) (*TransactionResponse, error)
 	// get block by hash
 	GetBlockByHash(context.Context, *GetBlockByHashRequest) (*BlockResponse, error)
 	// get block by number
 	GetBlockByNumber(context.Context, *GetBlockByNumberRequest) (*BlockResponse, error)
+	// get the header of a block by hash, without its transactions
+	GetBlockHeaderByHash(context.Context, *GetBlockHeaderByHashRequest) (*BlockHeaderResponse, error)
+	// get the header of a block by number, without its transactions
+	GetBlockHeaderByNumber(context.Context, *GetBlockHeaderByNumberRequest) (*BlockHeaderResponse, error)
+	// get the blocks of a range of numbers, as many as the node returns at once
+	GetBlocks(context.Context, *GetBlocksRequest) (*GetBlocksResponse, error)
+	// get the state keys written by an irreversible block
+	GetBlockStateChanges(context.Context, *GetBlockStateChangesRequest) (*GetBlockStateChangesResponse, error)
 	// get account
 	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
 	// get token balance
 	GetTokenBalance(context.Context, *GetTokenBalanceRequest) (*GetTokenBalanceResponse, error)
-	// get token721 balance
-	GetToken721Balance(context.Context, *GetTokenBalanceRequest) (*GetToken721BalanceResponse, error)
+	// get token721 balance and a page of the token ids held
+	GetToken721Balance(context.Context, *GetToken721BalanceRequest) (*GetToken721BalanceResponse, error)
 	// get token721 metadata
 	GetToken721Metadata(context.Context, *GetToken721InfoRequest) (*GetToken721MetadataResponse, error)
 	// get token721 owner
 	GetToken721Owner(context.Context, *GetToken721InfoRequest) (*GetToken721OwnerResponse, error)
+	// get the balances of all tokens held by an account
+	GetAccountTokens(context.Context, *GetAccountTokensRequest) (*GetAccountTokensResponse, error)
 	// get gas ratio infomation
 	GetGasRatio(context.Context, *EmptyRequest) (*GasRatioResponse, error)
 	// get producer vote infomation
 	GetProducerVoteInfo(context.Context, *GetProducerVoteInfoRequest) (*GetProducerVoteInfoResponse, error)
+	// get a page of the producer candidates and their vote infomation, sorted by account
+	GetProducers(context.Context, *GetProducersRequest) (*GetProducersResponse, error)
+	// get a page of the voters of a producer candidate, sorted by account
+	GetVoters(context.Context, *GetVotersRequest) (*GetVotersResponse, error)
+	// get the witness schedule and the block production statistics of the witnesses
+	GetWitnessSchedule(context.Context, *GetWitnessScheduleRequest) (*GetWitnessScheduleResponse, error)
 	// get contract
 	GetContract(context.Context, *GetContractRequest) (*Contract, error)
 	// get contract storage
 	GetContractStorage(context.Context, *GetContractStorageRequest) (*GetContractStorageResponse, error)
+	// get the values of several keys of contract storage at the same block
+	GetBatchContractStorage(context.Context, *GetBatchContractStorageRequest) (*GetBatchContractStorageResponse, error)
 	// get contract fields storage
 	GetContractStorageFields(context.Context, *GetContractStorageFieldsRequest) (*GetContractStorageFieldsResponse, error)
 	// send transaction
 	SendTransaction(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
 	// execute transaction
 	ExecTransaction(context.Context, *TransactionRequest) (*TxReceipt, error)
+	// estimate the gas of a transaction by executing it with the gas limits it may have, the public keys of its signatures giving their permissions without the signatures being verified
+	EstimateGas(context.Context, *TransactionRequest) (*EstimateGasResponse, error)
 	// subscribe an event
 	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
+	// subscribe the status of the chain and the node, sent whenever the head block changes
+	SubscribeChainStatus(*EmptyRequest, ApiService_SubscribeChainStatusServer) error
+	// subscribe the blocks from a height or from a resume token, sent as they are produced after the ones already in the chain
+	SubscribeBlocks(*SubscribeBlocksRequest, ApiService_SubscribeBlocksServer) error
+}
+
+func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
+	s.RegisterService(&_ApiService_serviceDesc, srv)
+}
+
+func _ApiService_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetNodeInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetNodeStatus(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetChainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetChainInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetChainInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetChainInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetRAMInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetRAMInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetRAMInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetRAMInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTxByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTxByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTxByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTxByHash(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
-	s.RegisterService(&_ApiService_serviceDesc, srv)
+func _ApiService_GetTxReceiptByTxHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTxReceiptByTxHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTxReceiptByTxHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTxReceiptByTxHash(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+func _ApiService_TraceTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetNodeInfo(ctx, in)
+		return srv.(ApiServiceServer).TraceTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetNodeInfo",
+		FullMethod: "/rpcpb.ApiService/TraceTransaction",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetNodeInfo(ctx, req.(*EmptyRequest))
+		return srv.(ApiServiceServer).TraceTransaction(ctx, req.(*TxHashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetChainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+func _ApiService_GetTxsByAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxsByAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetChainInfo(ctx, in)
+		return srv.(ApiServiceServer).GetTxsByAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetChainInfo",
+		FullMethod: "/rpcpb.ApiService/GetTxsByAccount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetChainInfo(ctx, req.(*EmptyRequest))
+		return srv.(ApiServiceServer).GetTxsByAccount(ctx, req.(*GetTxsByAccountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetRAMInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+func _ApiService_GetAccountTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountTxsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetRAMInfo(ctx, in)
+		return srv.(ApiServiceServer).GetAccountTxs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetRAMInfo",
+		FullMethod: "/rpcpb.ApiService/GetAccountTxs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetRAMInfo(ctx, req.(*EmptyRequest))
+		return srv.(ApiServiceServer).GetAccountTxs(ctx, req.(*GetAccountTxsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetTxByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TxHashRequest)
+func _ApiService_GetDelaytxsByAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDelaytxsByAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetTxByHash(ctx, in)
+		return srv.(ApiServiceServer).GetDelaytxsByAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetTxByHash",
+		FullMethod: "/rpcpb.ApiService/GetDelaytxsByAccount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetTxByHash(ctx, req.(*TxHashRequest))
+		return srv.(ApiServiceServer).GetDelaytxsByAccount(ctx, req.(*GetDelaytxsByAccountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetTxReceiptByTxHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ApiService_GetPendingTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetPendingTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetPendingTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetPendingTransactions(ctx, req.(*GetPendingTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetPendingTxByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TxHashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetTxReceiptByTxHash(ctx, in)
+		return srv.(ApiServiceServer).GetPendingTxByHash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetTxReceiptByTxHash",
+		FullMethod: "/rpcpb.ApiService/GetPendingTxByHash",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetTxReceiptByTxHash(ctx, req.(*TxHashRequest))
+		return srv.(ApiServiceServer).GetPendingTxByHash(ctx, req.(*TxHashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -4103,6 +7741,78 @@ func _ApiService_GetBlockByNumber_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetBlockHeaderByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockHeaderByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockHeaderByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockHeaderByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockHeaderByHash(ctx, req.(*GetBlockHeaderByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockHeaderByNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockHeaderByNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockHeaderByNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockHeaderByNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockHeaderByNumber(ctx, req.(*GetBlockHeaderByNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlocks(ctx, req.(*GetBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockStateChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockStateChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockStateChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockStateChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockStateChanges(ctx, req.(*GetBlockStateChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAccountRequest)
 	if err := dec(in); err != nil {
@@ -4140,7 +7850,7 @@ func _ApiService_GetTokenBalance_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _ApiService_GetToken721Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTokenBalanceRequest)
+	in := new(GetToken721BalanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -4152,7 +7862,7 @@ func _ApiService_GetToken721Balance_Handler(srv interface{}, ctx context.Context
 		FullMethod: "/rpcpb.ApiService/GetToken721Balance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetToken721Balance(ctx, req.(*GetTokenBalanceRequest))
+		return srv.(ApiServiceServer).GetToken721Balance(ctx, req.(*GetToken721BalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -4193,6 +7903,24 @@ func _ApiService_GetToken721Owner_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetAccountTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetAccountTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetAccountTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetAccountTokens(ctx, req.(*GetAccountTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetGasRatio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
@@ -4229,6 +7957,60 @@ func _ApiService_GetProducerVoteInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetProducers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProducersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetProducers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetProducers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetProducers(ctx, req.(*GetProducersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetVoters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVotersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetVoters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetVoters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetVoters(ctx, req.(*GetVotersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetWitnessSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWitnessScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetWitnessSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetWitnessSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetWitnessSchedule(ctx, req.(*GetWitnessScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetContractRequest)
 	if err := dec(in); err != nil {
@@ -4265,6 +8047,24 @@ func _ApiService_GetContractStorage_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetBatchContractStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchContractStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBatchContractStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBatchContractStorage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBatchContractStorage(ctx, req.(*GetBatchContractStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetContractStorageFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetContractStorageFieldsRequest)
 	if err := dec(in); err != nil {
@@ -4319,6 +8119,24 @@ func _ApiService_ExecTransaction_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_EstimateGas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).EstimateGas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/EstimateGas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).EstimateGas(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -4340,6 +8158,48 @@ func (x *apiServiceSubscribeServer) Send(m *SubscribeResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ApiService_SubscribeChainStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).SubscribeChainStatus(m, &apiServiceSubscribeChainStatusServer{stream})
+}
+
+type ApiService_SubscribeChainStatusServer interface {
+	Send(*ChainStatusResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceSubscribeChainStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceSubscribeChainStatusServer) Send(m *ChainStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ApiService_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).SubscribeBlocks(m, &apiServiceSubscribeBlocksServer{stream})
+}
+
+type ApiService_SubscribeBlocksServer interface {
+	Send(*SubscribeBlocksResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceSubscribeBlocksServer) Send(m *SubscribeBlocksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ApiService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.ApiService",
 	HandlerType: (*ApiServiceServer)(nil),
@@ -4348,6 +8208,10 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodeInfo",
 			Handler:    _ApiService_GetNodeInfo_Handler,
 		},
+		{
+			MethodName: "GetNodeStatus",
+			Handler:    _ApiService_GetNodeStatus_Handler,
+		},
 		{
 			MethodName: "GetChainInfo",
 			Handler:    _ApiService_GetChainInfo_Handler,
@@ -4364,6 +8228,30 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTxReceiptByTxHash",
 			Handler:    _ApiService_GetTxReceiptByTxHash_Handler,
 		},
+		{
+			MethodName: "TraceTransaction",
+			Handler:    _ApiService_TraceTransaction_Handler,
+		},
+		{
+			MethodName: "GetTxsByAccount",
+			Handler:    _ApiService_GetTxsByAccount_Handler,
+		},
+		{
+			MethodName: "GetAccountTxs",
+			Handler:    _ApiService_GetAccountTxs_Handler,
+		},
+		{
+			MethodName: "GetDelaytxsByAccount",
+			Handler:    _ApiService_GetDelaytxsByAccount_Handler,
+		},
+		{
+			MethodName: "GetPendingTransactions",
+			Handler:    _ApiService_GetPendingTransactions_Handler,
+		},
+		{
+			MethodName: "GetPendingTxByHash",
+			Handler:    _ApiService_GetPendingTxByHash_Handler,
+		},
 		{
 			MethodName: "GetBlockByHash",
 			Handler:    _ApiService_GetBlockByHash_Handler,
@@ -4372,6 +8260,22 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockByNumber",
 			Handler:    _ApiService_GetBlockByNumber_Handler,
 		},
+		{
+			MethodName: "GetBlockHeaderByHash",
+			Handler:    _ApiService_GetBlockHeaderByHash_Handler,
+		},
+		{
+			MethodName: "GetBlockHeaderByNumber",
+			Handler:    _ApiService_GetBlockHeaderByNumber_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _ApiService_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlockStateChanges",
+			Handler:    _ApiService_GetBlockStateChanges_Handler,
+		},
 		{
 			MethodName: "GetAccount",
 			Handler:    _ApiService_GetAccount_Handler,
@@ -4392,6 +8296,10 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetToken721Owner",
 			Handler:    _ApiService_GetToken721Owner_Handler,
 		},
+		{
+			MethodName: "GetAccountTokens",
+			Handler:    _ApiService_GetAccountTokens_Handler,
+		},
 		{
 			MethodName: "GetGasRatio",
 			Handler:    _ApiService_GetGasRatio_Handler,
@@ -4400,6 +8308,18 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetProducerVoteInfo",
 			Handler:    _ApiService_GetProducerVoteInfo_Handler,
 		},
+		{
+			MethodName: "GetProducers",
+			Handler:    _ApiService_GetProducers_Handler,
+		},
+		{
+			MethodName: "GetVoters",
+			Handler:    _ApiService_GetVoters_Handler,
+		},
+		{
+			MethodName: "GetWitnessSchedule",
+			Handler:    _ApiService_GetWitnessSchedule_Handler,
+		},
 		{
 			MethodName: "GetContract",
 			Handler:    _ApiService_GetContract_Handler,
@@ -4408,6 +8328,10 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetContractStorage",
 			Handler:    _ApiService_GetContractStorage_Handler,
 		},
+		{
+			MethodName: "GetBatchContractStorage",
+			Handler:    _ApiService_GetBatchContractStorage_Handler,
+		},
 		{
 			MethodName: "GetContractStorageFields",
 			Handler:    _ApiService_GetContractStorageFields_Handler,
@@ -4420,6 +8344,10 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExecTransaction",
 			Handler:    _ApiService_ExecTransaction_Handler,
 		},
+		{
+			MethodName: "EstimateGas",
+			Handler:    _ApiService_EstimateGas_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4427,6 +8355,16 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ApiService_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeChainStatus",
+			Handler:       _ApiService_SubscribeChainStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _ApiService_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc/pb/rpc.proto",
 }
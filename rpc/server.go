@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/iost-official/go-iost/core/blockcache"
@@ -13,6 +14,7 @@ import (
 	"github.com/iost-official/go-iost/core/txpool"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
+	"github.com/iost-official/go-iost/rpc/graphql"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/rs/cors"
 	"golang.org/x/net/netutil"
@@ -21,11 +23,11 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	maxConcurrentStreams = 200
-	connectionLimit      = 128
+	connectionLimit = 128
 )
 
 // Server is the rpc server including grpc server and json gateway server.
@@ -36,6 +38,22 @@ type Server struct {
 	gatewayAddr   string
 	gatewayServer *http.Server
 	allowOrigins  []string
+	// graphQLSchema is the schema served at /graphql of the gateway, nil if GraphQL is disabled.
+	graphQLSchema *graphql.Schema
+	// webSocket is whether the gateway serves JSON-RPC over websocket at /ws, through the grpc client conn wsConn.
+	webSocket bool
+	wsConn    *grpc.ClientConn
+	// apiKeys checks the keys of the calls, nil if the rpcs are open to all.
+	apiKeys *apiKeys
+	// compression is whether the gateway compresses the responses of compressMethods, all if empty, which are not
+	// shorter than compressMinSize.
+	compression     bool
+	compressMethods []string
+	compressMinSize int
+	// limits are the sizes of the messages and the timeouts of the calls of the grpc server.
+	limits *rpcLimits
+
+	apiService *APIService
 
 	quitCh chan struct{}
 
@@ -54,23 +72,48 @@ func New(tp txpool.TxPool, bc blockcache.BlockCache, bv global.BaseVariable, p2p
 		allowOrigins: bv.Config().RPC.AllowOrigins,
 		quitCh:       make(chan struct{}),
 		enable:       bv.Config().RPC.Enable,
+		webSocket:    bv.Config().RPC.WebSocket,
+
+		compression:     bv.Config().RPC.Compression,
+		compressMethods: bv.Config().RPC.CompressMethods,
+		compressMinSize: bv.Config().RPC.CompressMinSize,
+	}
+	keys, err := newAPIKeys(bv.Config().RPC)
+	if err != nil {
+		ilog.Fatalf("rpc api keys initialization failed, stop the program! err:%v", err)
+	}
+	s.apiKeys = keys
+	limits, err := newRPCLimits(bv.Config().RPC)
+	if err != nil {
+		ilog.Fatalf("rpc config is invalid, stop the program! err:%v", err)
+	}
+	s.limits = limits
+	m := &rpcMetrics{slowThreshold: bv.Config().RPC.SlowLogThreshold}
+	unary := []grpc.UnaryServerInterceptor{m.unaryMiddleware}
+	stream := []grpc.StreamServerInterceptor{m.streamMiddleware}
+	if keys != nil {
+		unary = append(unary, keys.unaryMiddleware)
+		stream = append(stream, keys.streamMiddleware)
 	}
-	s.grpcServer = grpc.NewServer(
+	unary = append(unary, limits.unaryMiddleware)
+	s.grpcServer = grpc.NewServer(append([]grpc.ServerOption{
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
-				metricsUnaryMiddleware,
-				grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(p)),
+				append(unary, grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(p)))...,
 			),
 		),
 		grpc.StreamInterceptor(
 			grpc_middleware.ChainStreamServer(
-				metricsStreamMiddleware,
-				grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandler(p)),
+				append(stream, grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandler(p)))...,
 			),
 		),
-		grpc.MaxConcurrentStreams(maxConcurrentStreams))
+	}, limits.serverOptions()...)...)
 	apiService := NewAPIService(tp, bc, bv, p2pService, s.quitCh)
 	rpcpb.RegisterApiServiceServer(s.grpcServer, apiService)
+	s.apiService = apiService
+	if bv.Config().RPC.GraphQL {
+		s.graphQLSchema = newGraphQLSchema(apiService)
+	}
 	return s
 }
 
@@ -82,6 +125,9 @@ func (s *Server) Start() error {
 	if err := s.startGrpc(); err != nil {
 		return err
 	}
+	if s.apiKeys != nil {
+		go s.apiKeys.watch(s.quitCh)
+	}
 	return s.startGateway()
 }
 
@@ -102,20 +148,44 @@ func (s *Server) startGrpc() error {
 func (s *Server) startGateway() error {
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
-		runtime.WithProtoErrorHandler(errorHandler))
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+		runtime.WithProtoErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher))
+	opts := []grpc.DialOption{grpc.WithInsecure(), s.limits.dialOption()}
 	err := rpcpb.RegisterApiServiceHandlerFromEndpoint(context.Background(), mux, s.grpcAddr, opts)
 	if err != nil {
 		return err
 	}
+	handler := http.NewServeMux()
+	handler.Handle("/", mux)
+	handler.Handle("/healthz", healthHandler(s.apiService, false))
+	handler.Handle("/readyz", healthHandler(s.apiService, true))
+	if s.graphQLSchema != nil {
+		var h http.Handler = graphql.Handler(s.graphQLSchema)
+		if s.apiKeys != nil {
+			// the queries are resolved by the api service without going through grpc
+			h = s.apiKeys.handler(graphQLMethod, h)
+		}
+		handler.Handle("/graphql", h)
+	}
+	if s.webSocket {
+		s.wsConn, err = grpc.Dial(s.grpcAddr, opts...)
+		if err != nil {
+			return err
+		}
+		handler.Handle("/ws", newWSHandler(rpcpb.NewApiServiceClient(s.wsConn), s.allowOrigins, s.quitCh))
+	}
+	var h http.Handler = handler
+	if s.compression {
+		h = compressHandler(s.compressMethods, s.compressMinSize, h)
+	}
 	c := cors.New(cors.Options{
-		AllowedHeaders: []string{"Content-Type", "Accept"},
+		AllowedHeaders: []string{"Content-Type", "Accept", apiKeyHeader},
 		AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "DELETE"},
 		AllowedOrigins: s.allowOrigins,
 	})
 	s.gatewayServer = &http.Server{
 		Addr:    s.gatewayAddr,
-		Handler: c.Handler(mux),
+		Handler: c.Handler(apiKeyFromParam(h)),
 	}
 	go func() {
 		if err := s.gatewayServer.ListenAndServe(); err != http.ErrServerClosed {
@@ -125,8 +195,21 @@ func (s *Server) startGateway() error {
 	return nil
 }
 
-func errorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
-	w.WriteHeader(400)
+// headerMatcher forwards the api key header to grpc besides the headers forwarded by default.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, apiKeyHeader) {
+		return apiKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if after := md.TrailerMD.Get(retryAfterTrailer); len(after) > 0 {
+			w.Header().Set("Retry-After", after[0])
+		}
+	}
+	w.WriteHeader(httpStatus(status.Code(err)))
 	bytes, e := json.Marshal(err)
 	if e != nil {
 		bytes = []byte(fmt.Sprint(err))
@@ -142,5 +225,8 @@ func (s *Server) Stop() {
 	close(s.quitCh)
 	ctx, _ := context.WithTimeout(context.Background(), time.Second) // nolint
 	s.gatewayServer.Shutdown(ctx)
+	if s.wsConn != nil {
+		s.wsConn.Close()
+	}
 	s.grpcServer.GracefulStop()
 }
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+var errHistoryReadOnly = errors.New("the state history is read only")
+
+// historyState is the state after the block of number, read from the state of the last irreversible block for the
+// keys not changed since the block, and from the state history for the others.
+type historyState struct {
+	state  db.MVCCDB
+	chain  block.Chain
+	number int64
+}
+
+var _ database.IMultiValue = &historyState{}
+
+// history returns the value of the key after the block if it was changed later, nil if not. The state is read before,
+// as the state history of a block is saved before its state is flushed.
+func (h *historyState) history(table, key string) (*db.Change, error) {
+	c, err := h.chain.GetStateHistory(table, key, h.number)
+	if err != nil {
+		return nil, fmt.Errorf("get state history failed: %v", err)
+	}
+	return c, nil
+}
+
+func (h *historyState) Get(table string, key string) (string, error) {
+	v, err := h.state.Get(table, key)
+	if err != nil {
+		return "", err
+	}
+	c, err := h.history(table, key)
+	if err != nil {
+		return "", err
+	}
+	if c != nil {
+		return c.Value, nil
+	}
+	return v, nil
+}
+
+func (h *historyState) Has(table string, key string) (bool, error) {
+	ok, err := h.state.Has(table, key)
+	if err != nil {
+		return false, err
+	}
+	c, err := h.history(table, key)
+	if err != nil {
+		return false, err
+	}
+	if c != nil {
+		return !c.Deleted, nil
+	}
+	return ok, nil
+}
+
+func (h *historyState) Put(table string, key string, value string) error {
+	return errHistoryReadOnly
+}
+
+func (h *historyState) Del(table string, key string) error {
+	return errHistoryReadOnly
+}
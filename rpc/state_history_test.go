@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHistoryChain is a testBlockChain keeping the state history in a block chain db.
+type testHistoryChain struct {
+	*testBlockChain
+	history block.Chain
+}
+
+func (bc *testHistoryChain) GetBlockHeadByHash(hash []byte) (*block.Block, error) {
+	for _, b := range bc.c.blocks {
+		if string(b.HeadHash()) == string(hash) {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("block not found")
+}
+
+func (bc *testHistoryChain) StateHistoryStart() (int64, error) {
+	return bc.history.StateHistoryStart()
+}
+
+func (bc *testHistoryChain) GetStateHistory(table, key string, number int64) (*db.Change, error) {
+	return bc.history.GetStateHistory(table, key, number)
+}
+
+func TestStateHistoryQueries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statehistory")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(dir + "/state")
+	assert.Nil(t, err)
+	defer stateDB.Close()
+	history, err := block.NewBlockChain(dir + "/chain")
+	assert.Nil(t, err)
+	defer history.Close()
+
+	c := &testChain{lib: 5}
+	c.grow(0, 10, "a")
+	v := database.NewVisitor(0, stateDB)
+	v.Put("Contractabc-count", database.MustMarshal(int64(3)))
+	v.MPut("Contractabc-owners", "bob", database.MustMarshal("admin"))
+	v.Commit()
+	stateDB.Commit(string(c.blocks[5].HeadHash()))
+	// the block 4 changed the count from 1 to 2, and the block 5 from 2 to 3 adding bob
+	assert.Nil(t, history.PutStateHistory(4, []*db.Change{
+		{Table: database.StateTable, Key: database.BasicPrefix + "Contractabc-count", Value: database.MustMarshal(int64(1))},
+	}))
+	assert.Nil(t, history.PutStateHistory(5, []*db.Change{
+		{Table: database.StateTable, Key: database.BasicPrefix + "Contractabc-count", Value: database.MustMarshal(int64(2))},
+		{Table: database.StateTable, Key: database.MapPrefix + "Contractabc-owners-bob", Deleted: true},
+	}))
+
+	as := newTestBlocksService(c, &common.RPCConfig{})
+	as.blockchain = &testHistoryChain{testBlockChain: &testBlockChain{c: c}, history: history}
+	bv := &testBaseVariable{config: &common.Config{DB: &common.DBConfig{StateHistory: true}}, stateDB: stateDB}
+	as.bv = bv
+	ctx := context.Background()
+	for _, want := range []struct {
+		number int64
+		count  string
+		owner  string
+	}{
+		{3, "1", "null"},
+		{4, "2", "null"},
+		{5, "3", "admin"},
+	} {
+		res, err := as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{Id: "Contractabc", Key: "count", BlockNumber: want.number})
+		assert.Nil(t, err)
+		assert.Equal(t, want.count, res.Data, "block %v", want.number)
+		assert.Equal(t, want.number, res.BlockNumber)
+		assert.Equal(t, common.Base58Encode(c.blocks[want.number].HeadHash()), res.BlockHash)
+		res, err = as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{Id: "Contractabc", Key: "owners", Field: "bob", BlockNumber: want.number})
+		assert.Nil(t, err)
+		assert.Equal(t, want.owner, res.Data, "block %v", want.number)
+	}
+
+	_, err = as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{Id: "Contractabc", Key: "count", BlockNumber: 2})
+	assert.NotNil(t, err)
+	_, err = as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{Id: "Contractabc", Key: "count", BlockNumber: 11})
+	assert.NotNil(t, err)
+	_, err = as.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: "alice", Token: "iost", IncludePending: true, BlockNumber: 3})
+	assert.Equal(t, errPendingAtBlock, err)
+
+	bv.config.DB.StateHistory = false
+	_, err = as.GetContractStorage(ctx, &rpcpb.GetContractStorageRequest{Id: "Contractabc", Key: "count", BlockNumber: 3})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/crypto"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+// defaultReadyMaxBlocksBehind is how many blocks a node may be behind and still be ready, 30 seconds of blocks.
+const defaultReadyMaxBlocksBehind = 60
+
+// witnessOf returns the pubkey signing the blocks produced by the node with the account, empty if none.
+func witnessOf(c *common.ACCConfig) string {
+	if c == nil || c.SecKey == "" {
+		return ""
+	}
+	acc, err := account.NewKeyPair(common.Base58Decode(c.SecKey), crypto.NewAlgorithm(c.Algorithm))
+	if err != nil {
+		return ""
+	}
+	return acc.ReadablePubkey()
+}
+
+// GetNodeStatus returns the health and the sync status of the node.
+func (as *APIService) GetNodeStatus(context.Context, *rpcpb.EmptyRequest) (*rpcpb.NodeStatusResponse, error) {
+	return as.nodeStatus(time.Now()), nil
+}
+
+func (as *APIService) nodeStatus(now time.Time) *rpcpb.NodeStatusResponse {
+	head := as.bc.Head()
+	lib := as.bc.LinkedRoot()
+	res := &rpcpb.NodeStatusResponse{
+		Mode:          as.bv.Mode().String(),
+		HeadBlock:     head.Head.Number,
+		HeadBlockTime: head.Head.Time,
+		LibBlock:      lib.Head.Number,
+		PeerCount:     int32(len(as.p2pService.GetAllNeighbors())),
+		DbOk:          true,
+		Witness:       as.witness,
+	}
+	// the chain produces a block every slot divided by the continuous blocks of a witness
+	continuous := as.bv.Continuous()
+	if continuous <= 0 {
+		continuous = 1
+	}
+	interval := int64(common.SlotLength) * int64(time.Second) / int64(continuous)
+	if behind := (now.UnixNano() - head.Head.Time) / interval; behind > 0 {
+		res.BlocksBehind = behind
+	}
+	if pending, _ := as.txpool.PendingTx(); pending != nil {
+		res.PendingTxCount = int32(pending.Size())
+	}
+	if err := as.checkDB(); err != nil {
+		res.DbOk = false
+		res.DbError = err.Error()
+	}
+	if as.witness != "" {
+		for _, w := range head.Active() {
+			if w == as.witness {
+				res.IsProducer = true
+				break
+			}
+		}
+		for n := head; n != nil && n.Head.Number >= lib.Head.Number; n = n.GetParent() {
+			if n.Head.Witness == as.witness {
+				res.LastProducedBlock = n.Head.Number
+				res.LastProducedBlockTime = n.Head.Time
+				break
+			}
+		}
+	}
+	maxBehind := int64(as.bv.Config().RPC.ReadyMaxBlocksBehind)
+	if maxBehind <= 0 {
+		maxBehind = defaultReadyMaxBlocksBehind
+	}
+	res.Ready = res.DbOk && as.bv.Mode() == global.ModeNormal && res.BlocksBehind <= maxBehind
+	return res
+}
+
+// checkDB reads the top of the block chain and a key of the state, returning the error of the database failing.
+func (as *APIService) checkDB() error {
+	if _, err := as.blockchain.Top(); err != nil {
+		return fmt.Errorf("read block db failed: %v", err)
+	}
+	if _, err := as.bv.StateDB().Has(database.StateTable, database.ContractPrefix+"token.iost"); err != nil {
+		return fmt.Errorf("read state db failed: %v", err)
+	}
+	return nil
+}
+
+// healthHandler serves the status of the node, with 503 unless it is healthy, by the databases being readable at
+// /healthz, or ready by being synced too at /readyz. These are served to the load balancers without api key.
+func healthHandler(as *APIService, ready bool) http.Handler {
+	m := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := as.nodeStatus(time.Now())
+		ok := res.DbOk
+		if ready {
+			ok = res.Ready
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		m.Marshal(w, res)
+	})
+}
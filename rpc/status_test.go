@@ -0,0 +1,154 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+type statusBaseVariable struct {
+	testBaseVariable
+	mode global.TMode
+}
+
+func (bv *statusBaseVariable) Mode() global.TMode {
+	return bv.mode
+}
+
+func (bv *statusBaseVariable) Continuous() int {
+	return 6
+}
+
+type statusBlockChain struct {
+	block.Chain
+	err error
+}
+
+func (bc *statusBlockChain) Top() (*block.Block, error) {
+	return nil, bc.err
+}
+
+type statusStateDB struct {
+	db.MVCCDB
+	err error
+}
+
+func (s *statusStateDB) Has(table string, key string) (bool, error) {
+	return false, s.err
+}
+
+type statusBlockCache struct {
+	blockcache.BlockCache
+	head, lib *blockcache.BlockCacheNode
+}
+
+func (bc *statusBlockCache) Head() *blockcache.BlockCacheNode {
+	return bc.head
+}
+
+func (bc *statusBlockCache) LinkedRoot() *blockcache.BlockCacheNode {
+	return bc.lib
+}
+
+type statusP2P struct {
+	p2p.Service
+}
+
+func (s *statusP2P) GetAllNeighbors() []*p2p.Peer {
+	return make([]*p2p.Peer, 3)
+}
+
+func TestNodeStatus(t *testing.T) {
+	seckey := crypto.Ed25519.GenSeckey()
+	acc, err := account.NewKeyPair(seckey, crypto.Ed25519)
+	assert.Nil(t, err)
+	witness := witnessOf(&common.ACCConfig{SecKey: common.Base58Encode(seckey), Algorithm: "ed25519"})
+	assert.Equal(t, acc.ReadablePubkey(), witness)
+	assert.Empty(t, witnessOf(&common.ACCConfig{}))
+
+	now := time.Unix(1000, 0)
+	var parent *blockcache.BlockCacheNode
+	for i, w := range []string{"other", witness, "other", "other"} {
+		n := &blockcache.BlockCacheNode{Block: &block.Block{Head: &block.BlockHead{
+			Number:  int64(10 + i),
+			Witness: w,
+			Time:    now.Add(time.Duration(i-3) * time.Second).UnixNano(),
+		}}}
+		n.SetParent(parent)
+		parent = n
+	}
+	head := parent
+	head.ActiveWitnessList = []string{"other", witness}
+	lib := head.GetParent().GetParent().GetParent()
+	pending := txpool.NewSortedTxMap()
+	bv := &statusBaseVariable{testBaseVariable: testBaseVariable{
+		config:  &common.Config{RPC: &common.RPCConfig{ReadyMaxBlocksBehind: 10}},
+		stateDB: &statusStateDB{},
+	}}
+	chain := &statusBlockChain{}
+	as := &APIService{
+		bc:         &statusBlockCache{head: head, lib: lib},
+		blockchain: chain,
+		bv:         bv,
+		txpool:     &testTxPool{pending: pending},
+		p2pService: &statusP2P{},
+		witness:    witness,
+	}
+
+	res := as.nodeStatus(now)
+	assert.Equal(t, "ModeNormal", res.Mode)
+	assert.Equal(t, int64(13), res.HeadBlock)
+	assert.Equal(t, int64(10), res.LibBlock)
+	assert.Equal(t, int64(0), res.BlocksBehind)
+	assert.Equal(t, int32(3), res.PeerCount)
+	assert.True(t, res.DbOk)
+	assert.True(t, res.IsProducer)
+	assert.Equal(t, int64(11), res.LastProducedBlock)
+	assert.True(t, res.Ready)
+
+	// half a second per block
+	res = as.nodeStatus(now.Add(6 * time.Second))
+	assert.Equal(t, int64(12), res.BlocksBehind)
+	assert.False(t, res.Ready)
+
+	bv.mode = global.ModeSync
+	assert.False(t, as.nodeStatus(now).Ready)
+	bv.mode = global.ModeNormal
+
+	as.witness = "unknown"
+	res = as.nodeStatus(now)
+	assert.False(t, res.IsProducer)
+	assert.Equal(t, int64(0), res.LastProducedBlock)
+
+	chain.err = errors.New("leveldb: closed")
+	res = as.nodeStatus(now)
+	assert.False(t, res.DbOk)
+	assert.Contains(t, res.DbError, "leveldb: closed")
+	assert.False(t, res.Ready)
+	chain.err = nil
+
+	// the head block is too old to be ready, but the node is healthy
+	head.Head.Time = time.Now().Add(-time.Minute).UnixNano()
+	for path, code := range map[string]int{"/healthz": http.StatusOK, "/readyz": http.StatusServiceUnavailable} {
+		w := httptest.NewRecorder()
+		healthHandler(as, path == "/readyz").ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, code, w.Code, path)
+		var body map[string]interface{}
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "13", body["head_block"])
+	}
+}
@@ -0,0 +1,492 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/iost-official/go-iost/ilog"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The limits of the websocket gateway.
+const (
+	maxWSConns         = 128
+	maxWSSubscriptions = 16
+	maxWSPendingCalls  = 16
+	maxWSMessageSize   = 1 << 20
+	wsPingInterval     = 30 * time.Second
+	wsPongTimeout      = 60 * time.Second
+	wsWriteTimeout     = 10 * time.Second
+)
+
+// The error codes of JSON-RPC 2.0, and the code of the errors returned by the rpcs.
+const (
+	wsParseError     = -32700
+	wsInvalidRequest = -32600
+	wsMethodNotFound = -32601
+	wsInvalidParams  = -32602
+	wsServerError    = -32000
+)
+
+var (
+	wsMarshaler   = &jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	wsUnmarshaler = &jsonpb.Unmarshaler{}
+)
+
+type wsRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type wsError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	// GRPCCode is the grpc status code of the errors returned by the rpcs.
+	GRPCCode *int `json:"grpc_code,omitempty"`
+}
+
+func (e *wsError) Error() string {
+	return e.Message
+}
+
+func wsErrorf(code int, format string, a ...interface{}) *wsError {
+	return &wsError{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// toWSError returns the error of an rpc, with its grpc code.
+func toWSError(err error) *wsError {
+	if e, ok := err.(*wsError); ok {
+		return e
+	}
+	st := status.Convert(err)
+	code := int(st.Code())
+	return &wsError{Code: wsServerError, Message: st.Message(), GRPCCode: &code}
+}
+
+type wsResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *wsError        `json:"error,omitempty"`
+}
+
+type wsNotification struct {
+	JSONRPC string       `json:"jsonrpc"`
+	Method  string       `json:"method"`
+	Params  *wsPushEvent `json:"params"`
+}
+
+// wsPushEvent is a message of a subscription, or its end with the error which ended it if any.
+type wsPushEvent struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result,omitempty"`
+	End          bool            `json:"end,omitempty"`
+	Error        *wsError        `json:"error,omitempty"`
+}
+
+// wsMethod is an unary rpc of the api service, called by the lower camel case of its name.
+type wsMethod struct {
+	name    string
+	reqType reflect.Type
+}
+
+var wsMethods = func() map[string]*wsMethod {
+	methods := make(map[string]*wsMethod)
+	t := reflect.TypeOf((*rpcpb.ApiServiceClient)(nil)).Elem()
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		// the streaming rpcs return a stream interface instead of a message
+		if m.Type.NumOut() != 2 || m.Type.Out(0).Kind() != reflect.Ptr {
+			continue
+		}
+		methods[strings.ToLower(m.Name[:1])+m.Name[1:]] = &wsMethod{name: m.Name, reqType: m.Type.In(1).Elem()}
+	}
+	return methods
+}()
+
+// wsTopic is a kind of subscription, pushing the messages of a stream until it ends.
+type wsTopic struct {
+	newRequest func() proto.Message
+	run        func(ctx context.Context, client rpcpb.ApiServiceClient, req proto.Message, send func(proto.Message) error) error
+}
+
+var wsTopics = map[string]*wsTopic{
+	"blocks": {
+		newRequest: func() proto.Message { return &rpcpb.SubscribeBlocksRequest{} },
+		run: func(ctx context.Context, client rpcpb.ApiServiceClient, req proto.Message, send func(proto.Message) error) error {
+			stream, err := client.SubscribeBlocks(ctx, req.(*rpcpb.SubscribeBlocksRequest))
+			if err != nil {
+				return err
+			}
+			return forward(func() (proto.Message, error) { return stream.Recv() }, send)
+		},
+	},
+	"events": {
+		newRequest: func() proto.Message { return &rpcpb.SubscribeRequest{} },
+		run: func(ctx context.Context, client rpcpb.ApiServiceClient, req proto.Message, send func(proto.Message) error) error {
+			stream, err := client.Subscribe(ctx, req.(*rpcpb.SubscribeRequest))
+			if err != nil {
+				return err
+			}
+			return forward(func() (proto.Message, error) { return stream.Recv() }, send)
+		},
+	},
+	"chainStatus": {
+		newRequest: func() proto.Message { return &rpcpb.EmptyRequest{} },
+		run: func(ctx context.Context, client rpcpb.ApiServiceClient, req proto.Message, send func(proto.Message) error) error {
+			stream, err := client.SubscribeChainStatus(ctx, req.(*rpcpb.EmptyRequest))
+			if err != nil {
+				return err
+			}
+			return forward(func() (proto.Message, error) { return stream.Recv() }, send)
+		},
+	},
+	"txStatus": {
+		newRequest: func() proto.Message { return &rpcpb.TxHashRequest{} },
+		run:        watchTxStatus,
+	},
+}
+
+// forward sends the messages received from a stream, until the stream ends.
+func forward(recv func() (proto.Message, error), send func(proto.Message) error) error {
+	for {
+		m, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := send(m); err != nil {
+			return err
+		}
+	}
+}
+
+// watchTxStatus sends the transaction whenever its status changes as the head block changes, until it is irreversible.
+// A transaction not found yet is waited for.
+func watchTxStatus(ctx context.Context, client rpcpb.ApiServiceClient, req proto.Message, send func(proto.Message) error) error {
+	stream, err := client.SubscribeChainStatus(ctx, &rpcpb.EmptyRequest{})
+	if err != nil {
+		return err
+	}
+	var prev *rpcpb.TransactionResponse
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		res, err := client.GetTxByHash(ctx, req.(*rpcpb.TxHashRequest))
+		if err != nil {
+			continue
+		}
+		if prev == nil || res.Status != prev.Status || res.BlockNumber != prev.BlockNumber {
+			if err := send(res); err != nil {
+				return err
+			}
+			prev = res
+		}
+		if res.Status == rpcpb.TransactionResponse_IRREVERSIBLE {
+			return nil
+		}
+	}
+}
+
+// wsHandler serves JSON-RPC 2.0 over websocket, calling the rpcs of the api service through the grpc client, and
+// pushing the messages of the subscriptions as notifications.
+type wsHandler struct {
+	client   rpcpb.ApiServiceClient
+	upgrader websocket.Upgrader
+	quitCh   chan struct{}
+	conns    int32
+}
+
+func newWSHandler(client rpcpb.ApiServiceClient, allowOrigins []string, quitCh chan struct{}) *wsHandler {
+	return &wsHandler{
+		client: client,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" || len(allowOrigins) == 0 {
+					return true
+				}
+				for _, o := range allowOrigins {
+					if o == "*" || o == origin {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		quitCh: quitCh,
+	}
+}
+
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if n := atomic.AddInt32(&h.conns, 1); n > maxWSConns {
+		atomic.AddInt32(&h.conns, -1)
+		http.Error(w, fmt.Sprintf("too many websocket connections, the limit is %v", maxWSConns), http.StatusServiceUnavailable)
+		return
+	}
+	defer atomic.AddInt32(&h.conns, -1)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		ilog.Debugf("websocket upgrade failed. err=%v", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		// the key of the connection is checked at each call
+		ctx = metadata.AppendToOutgoingContext(ctx, apiKeyHeader, key)
+	}
+	c := &wsConn{
+		h:       h,
+		conn:    conn,
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: make(chan struct{}, maxWSPendingCalls),
+		subs:    make(map[string]context.CancelFunc),
+	}
+	c.serve()
+}
+
+// wsConn is a websocket connection. Its requests are handled concurrently, up to maxWSPendingCalls at once before the
+// next ones are read, and its messages are written one at a time.
+type wsConn struct {
+	h       *wsHandler
+	conn    *websocket.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	pending chan struct{}
+
+	writeMu sync.Mutex
+
+	mu     sync.Mutex
+	subs   map[string]context.CancelFunc
+	nextID int64
+}
+
+func (c *wsConn) serve() {
+	defer c.conn.Close()
+	defer c.cancel()
+	c.conn.SetReadLimit(maxWSMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+	go c.keepAlive()
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.pending <- struct{}{}
+		go func() {
+			defer func() { <-c.pending }()
+			c.handle(msg)
+		}()
+	}
+}
+
+// keepAlive pings the peer until the connection or the server is closed.
+func (c *wsConn) keepAlive() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.h.quitCh:
+			c.conn.Close()
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			c.writeMu.Unlock()
+			if err != nil {
+				c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConn) write(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.conn.WriteMessage(websocket.TextMessage, b)
+}
+
+func (c *wsConn) handle(msg []byte) {
+	var req wsRequest
+	if err := json.Unmarshal(msg, &req); err != nil {
+		c.write(&wsResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: wsErrorf(wsParseError, "parse error: %v", err)})
+		return
+	}
+	if req.ID == nil {
+		req.ID = json.RawMessage("null")
+	}
+	var (
+		result json.RawMessage
+		start  func()
+		err    error
+	)
+	if req.Method == "subscribe" {
+		result, start, err = c.subscribe(req.Params)
+	} else {
+		result, err = c.call(&req)
+	}
+	res := &wsResponse{JSONRPC: "2.0", ID: req.ID}
+	if err != nil {
+		res.Error = toWSError(err)
+	} else {
+		res.Result = result
+	}
+	if err := c.write(res); err != nil {
+		ilog.Debugf("websocket write failed. err=%v", err)
+	}
+	// the messages of a subscription are sent after its id
+	if start != nil {
+		go start()
+	}
+}
+
+func (c *wsConn) call(req *wsRequest) (json.RawMessage, error) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, wsErrorf(wsInvalidRequest, "invalid request")
+	}
+	if req.Method == "unsubscribe" {
+		return c.unsubscribe(req.Params)
+	}
+	m := wsMethods[req.Method]
+	if m == nil {
+		return nil, wsErrorf(wsMethodNotFound, "method %v not found", req.Method)
+	}
+	in := reflect.New(m.reqType).Interface().(proto.Message)
+	if err := unmarshalParams(req.Params, in); err != nil {
+		return nil, err
+	}
+	out := reflect.ValueOf(c.h.client).MethodByName(m.name).Call([]reflect.Value{reflect.ValueOf(c.ctx), reflect.ValueOf(in)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	return marshalResult(out[0].Interface().(proto.Message))
+}
+
+func unmarshalParams(params json.RawMessage, m proto.Message) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := wsUnmarshaler.Unmarshal(strings.NewReader(string(params)), m); err != nil {
+		return wsErrorf(wsInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
+
+func marshalResult(m proto.Message) (json.RawMessage, error) {
+	s, err := wsMarshaler.MarshalToString(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(s), nil
+}
+
+type wsSubscribeParams struct {
+	Topic  string          `json:"topic"`
+	Params json.RawMessage `json:"params"`
+}
+
+// subscribe opens a subscription to the topic, returning its id, and the function pushing its messages.
+func (c *wsConn) subscribe(params json.RawMessage) (json.RawMessage, func(), error) {
+	var p wsSubscribeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, nil, wsErrorf(wsInvalidParams, "invalid params: %v", err)
+	}
+	topic := wsTopics[p.Topic]
+	if topic == nil {
+		return nil, nil, wsErrorf(wsInvalidParams, "unknown topic %q", p.Topic)
+	}
+	req := topic.newRequest()
+	if err := unmarshalParams(p.Params, req); err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.subs) >= maxWSSubscriptions {
+		return nil, nil, wsErrorf(wsServerError, "too many subscriptions, the limit is %v", maxWSSubscriptions)
+	}
+	c.nextID++
+	id := strconv.FormatInt(c.nextID, 10)
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.subs[id] = cancel
+
+	start := func() {
+		err := topic.run(ctx, c.h.client, req, func(m proto.Message) error {
+			result, err := marshalResult(m)
+			if err != nil {
+				return err
+			}
+			return c.write(&wsNotification{JSONRPC: "2.0", Method: "subscription", Params: &wsPushEvent{Subscription: id, Result: result}})
+		})
+		c.mu.Lock()
+		_, open := c.subs[id]
+		delete(c.subs, id)
+		c.mu.Unlock()
+		cancel()
+		// the end of the subscriptions closed by unsubscribe or with the connection is not sent
+		if !open || c.ctx.Err() != nil {
+			return
+		}
+		end := &wsPushEvent{Subscription: id, End: true}
+		if err != nil {
+			end.Error = toWSError(err)
+		}
+		c.write(&wsNotification{JSONRPC: "2.0", Method: "subscription", Params: end})
+	}
+	result, _ := json.Marshal(id)
+	return result, start, nil
+}
+
+type wsUnsubscribeParams struct {
+	Subscription string `json:"subscription"`
+}
+
+func (c *wsConn) unsubscribe(params json.RawMessage) (json.RawMessage, error) {
+	var p wsUnsubscribeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, wsErrorf(wsInvalidParams, "invalid params: %v", err)
+	}
+	c.mu.Lock()
+	cancel, ok := c.subs[p.Subscription]
+	delete(c.subs, p.Subscription)
+	c.mu.Unlock()
+	if !ok {
+		return nil, wsErrorf(wsInvalidParams, "unknown subscription %q", p.Subscription)
+	}
+	cancel()
+	return json.RawMessage("true"), nil
+}
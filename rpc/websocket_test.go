@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iost-official/go-iost/common"
+	rpcpb "github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// wsMessage is a response or a notification.
+type wsMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *wsError        `json:"error"`
+	Params *wsPushEvent    `json:"params"`
+}
+
+func dialWS(t *testing.T, as *APIService) (*websocket.Conn, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	gs := grpc.NewServer()
+	rpcpb.RegisterApiServiceServer(gs, as)
+	go gs.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	server := httptest.NewServer(newWSHandler(rpcpb.NewApiServiceClient(conn), []string{"http://allowed"}, as.quitCh))
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.Nil(t, err)
+	return ws, func() {
+		ws.Close()
+		server.Close()
+		conn.Close()
+		gs.Stop()
+	}
+}
+
+func readWS(t *testing.T, ws *websocket.Conn) *wsMessage {
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	m := &wsMessage{}
+	assert.Nil(t, ws.ReadJSON(m))
+	return m
+}
+
+func TestWebSocketCalls(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	ws, closeWS := dialWS(t, newTestBlocksService(c, &common.RPCConfig{}))
+	defer closeWS()
+
+	assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"getBlockByNumber","params":{"number":"5"}}`)))
+	m := readWS(t, ws)
+	assert.Equal(t, "1", string(m.ID))
+	assert.Nil(t, m.Error)
+	var res map[string]interface{}
+	assert.Nil(t, json.Unmarshal(m.Result, &res))
+	assert.Equal(t, "IRREVERSIBLE", res["status"])
+	assert.Equal(t, "5", res["block"].(map[string]interface{})["number"])
+
+	for _, c := range []struct {
+		req  string
+		code int
+	}{
+		{`{"jsonrpc":"2.0","id":"a","method":"getBlockByNumber","params":{"number":"100"}}`, wsServerError},
+		{`{"jsonrpc":"2.0","id":"a","method":"getBlockByNumber","params":{"unknown":1}}`, wsInvalidParams},
+		{`{"jsonrpc":"2.0","id":"a","method":"subscribeBlocks"}`, wsMethodNotFound},
+		{`{"jsonrpc":"2.0","id":"a","method":"subscribe","params":{"topic":"unknown"}}`, wsInvalidParams},
+		{`{"jsonrpc":"2.0","id":"a","method":"unsubscribe","params":{"subscription":"1"}}`, wsInvalidParams},
+		{`{"id":"a","method":"getBlockByNumber"}`, wsInvalidRequest},
+		{`{`, wsParseError},
+	} {
+		assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(c.req)))
+		m := readWS(t, ws)
+		if assert.NotNil(t, m.Error, c.req) {
+			assert.Equal(t, c.code, m.Error.Code, c.req)
+		}
+	}
+}
+
+func TestWebSocketSubscriptions(t *testing.T) {
+	c := &testChain{lib: 5}
+	c.grow(0, 6, "a")
+	ws, closeWS := dialWS(t, newTestBlocksService(c, &common.RPCConfig{}))
+	defer closeWS()
+
+	assert.Nil(t, ws.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "subscribe",
+		"params":  map[string]interface{}{"topic": "blocks", "params": map[string]interface{}{"from_number": "5"}},
+	}))
+	m := readWS(t, ws)
+	assert.Nil(t, m.Error)
+	var id string
+	assert.Nil(t, json.Unmarshal(m.Result, &id))
+
+	var numbers []string
+	for len(numbers) < 2 {
+		m := readWS(t, ws)
+		assert.Equal(t, "subscription", m.Method)
+		assert.Equal(t, id, m.Params.Subscription)
+		var res map[string]interface{}
+		assert.Nil(t, json.Unmarshal(m.Params.Result, &res))
+		numbers = append(numbers, res["block"].(map[string]interface{})["number"].(string))
+	}
+	assert.Equal(t, []string{"5", "6"}, numbers)
+
+	assert.Nil(t, ws.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "unsubscribe",
+		"params":  map[string]interface{}{"subscription": id},
+	}))
+	m = readWS(t, ws)
+	assert.Equal(t, "2", string(m.ID))
+	assert.Equal(t, "true", string(m.Result))
+
+	// a failed subscription ends with its error
+	assert.Nil(t, ws.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      3,
+		"method":  "subscribe",
+		"params":  map[string]interface{}{"topic": "blocks", "params": map[string]interface{}{"resume_token": "x"}},
+	}))
+	m = readWS(t, ws)
+	assert.Nil(t, json.Unmarshal(m.Result, &id))
+	m = readWS(t, ws)
+	assert.Equal(t, id, m.Params.Subscription)
+	assert.True(t, m.Params.End)
+	if assert.NotNil(t, m.Params.Error) {
+		assert.Equal(t, 3, *m.Params.Error.GRPCCode)
+	}
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	h := newWSHandler(nil, []string{"http://allowed"}, nil)
+	r := httptest.NewRequest("GET", "/ws", nil)
+	assert.True(t, h.upgrader.CheckOrigin(r))
+	r.Header.Set("Origin", "http://allowed")
+	assert.True(t, h.upgrader.CheckOrigin(r))
+	r.Header.Set("Origin", "http://other")
+	assert.False(t, h.upgrader.CheckOrigin(r))
+}
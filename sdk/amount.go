@@ -0,0 +1,241 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// Errors of the amount arithmetic, which are wrapped with the amounts involved.
+var (
+	// ErrAmountOverflow is the error of an amount too large for an int64 of the smallest unit of its token.
+	ErrAmountOverflow = errors.New("amount overflow")
+	// ErrPrecisionLoss is the error of an amount with more decimal places than its token, which the chain would
+	// silently truncate.
+	ErrPrecisionLoss = errors.New("amount has more decimal places than the token")
+)
+
+// Amount is an amount of a token held as an int64 of the smallest unit of the token, like token.iost does, eg 1.5
+// iost with 8 decimal places is 150000000. Amounts are values whose operations return new amounts, failing instead of
+// losing precision or overflowing. Amounts of different decimal places can be mixed, the result having the most.
+type Amount struct {
+	value   int64
+	decimal int
+}
+
+// NewAmount parses a decimal number like "1.5" as an amount of a token with the decimal places.
+func NewAmount(s string, decimal int) (Amount, error) {
+	if decimal < 0 {
+		return Amount{}, fmt.Errorf("invalid decimal %v", decimal)
+	}
+	f, err := common.NewFixed(s, -1)
+	if err != nil {
+		if common.IsOverflow(err) {
+			return Amount{}, fmt.Errorf("%w: %v", ErrAmountOverflow, s)
+		}
+		return Amount{}, fmt.Errorf("invalid amount %q: %v", s, err)
+	}
+	return Amount{value: f.Value, decimal: f.Decimal}.Rescale(decimal)
+}
+
+// AmountFromUnits returns the amount of units of the smallest unit of a token with the decimal places.
+func AmountFromUnits(units int64, decimal int) Amount {
+	return Amount{value: units, decimal: decimal}
+}
+
+// AmountFromFixed returns the amount of a fixed point number.
+func AmountFromFixed(f *common.Fixed) Amount {
+	return Amount{value: f.Value, decimal: f.Decimal}
+}
+
+// Fixed returns the amount as a fixed point number.
+func (a Amount) Fixed() *common.Fixed {
+	return &common.Fixed{Value: a.value, Decimal: a.decimal}
+}
+
+// Units returns the amount in the smallest unit of the token.
+func (a Amount) Units() int64 {
+	return a.value
+}
+
+// Decimal returns the decimal places of the amount.
+func (a Amount) Decimal() int {
+	return a.decimal
+}
+
+// Sign returns -1, 0 or 1 if the amount is negative, zero or positive.
+func (a Amount) Sign() int {
+	switch {
+	case a.value < 0:
+		return -1
+	case a.value > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero tells whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a.value == 0
+}
+
+// Rescale returns the amount with the decimal places, failing if digits would be dropped or the amount overflows.
+func (a Amount) Rescale(decimal int) (Amount, error) {
+	if decimal < 0 {
+		return Amount{}, fmt.Errorf("invalid decimal %v", decimal)
+	}
+	value := a.value
+	for d := a.decimal; d > decimal; d-- {
+		if value%10 != 0 {
+			return Amount{}, fmt.Errorf("%w: %v has more than %v decimal places", ErrPrecisionLoss, a, decimal)
+		}
+		value /= 10
+	}
+	for d := a.decimal; d < decimal; d++ {
+		if value > math.MaxInt64/10 || value < math.MinInt64/10 {
+			return Amount{}, fmt.Errorf("%w: %v with %v decimal places", ErrAmountOverflow, a, decimal)
+		}
+		value *= 10
+	}
+	return Amount{value: value, decimal: decimal}, nil
+}
+
+// unify rescales two amounts to the most decimal places of both.
+func unify(a Amount, b Amount) (Amount, Amount, error) {
+	decimal := a.decimal
+	if b.decimal > decimal {
+		decimal = b.decimal
+	}
+	a, err := a.Rescale(decimal)
+	if err != nil {
+		return Amount{}, Amount{}, err
+	}
+	b, err = b.Rescale(decimal)
+	if err != nil {
+		return Amount{}, Amount{}, err
+	}
+	return a, b, nil
+}
+
+// Add returns a+b.
+func (a Amount) Add(b Amount) (Amount, error) {
+	x, y, err := unify(a, b)
+	if err != nil {
+		return Amount{}, err
+	}
+	sum := x.value + y.value
+	if (y.value > 0 && sum < x.value) || (y.value < 0 && sum > x.value) {
+		return Amount{}, fmt.Errorf("%w: %v + %v", ErrAmountOverflow, a, b)
+	}
+	return Amount{value: sum, decimal: x.decimal}, nil
+}
+
+// Sub returns a-b.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b.value == math.MinInt64 {
+		return Amount{}, fmt.Errorf("%w: %v - %v", ErrAmountOverflow, a, b)
+	}
+	return a.Add(Amount{value: -b.value, decimal: b.decimal})
+}
+
+// Mul returns the amount multiplied by n.
+func (a Amount) Mul(n int64) (Amount, error) {
+	if a.value != 0 && n != 0 {
+		p := a.value * n
+		if p/n != a.value || (n == -1 && a.value == math.MinInt64) {
+			return Amount{}, fmt.Errorf("%w: %v * %v", ErrAmountOverflow, a, n)
+		}
+	}
+	return Amount{value: a.value * n, decimal: a.decimal}, nil
+}
+
+// Cmp returns -1, 0 or 1 if a is less than, equal to or greater than b.
+func (a Amount) Cmp(b Amount) int {
+	x, y := big.NewInt(a.value), big.NewInt(b.value)
+	if a.decimal < b.decimal {
+		x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(b.decimal-a.decimal)), nil))
+	} else if b.decimal < a.decimal {
+		y.Mul(y, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.decimal-b.decimal)), nil))
+	}
+	return x.Cmp(y)
+}
+
+// StringFixed formats the amount with all its decimal places, eg "1.50000000".
+func (a Amount) StringFixed() string {
+	u := uint64(a.value)
+	if a.value < 0 {
+		u = -u
+	}
+	digits := strconv.FormatUint(u, 10)
+	if a.decimal > 0 {
+		if len(digits) <= a.decimal {
+			digits = strings.Repeat("0", a.decimal-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-a.decimal] + "." + digits[len(digits)-a.decimal:]
+	}
+	if a.value < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// String formats the amount without trailing zeros, eg "1.5", which is how amounts are given to contracts.
+func (a Amount) String() string {
+	s := a.StringFixed()
+	if a.decimal > 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// TokenDecimal returns the decimal places of a token, which token.iost saves in the TI<symbol> map. It is cached
+// like the chain info, see `CachedChainInfo`.
+func (s *IOSTDevSDK) TokenDecimal(token string) (int, error) {
+	return s.TokenDecimalCtx(context.Background(), token)
+}
+
+// TokenDecimalCtx is TokenDecimal with a context to cancel the call.
+func (s *IOSTDevSDK) TokenDecimalCtx(ctx context.Context, token string) (int, error) {
+	if d, ok := s.cache.decimal(token); ok {
+		return d, nil
+	}
+	resp, err := s.GetContractStorageCtx(ctx, &rpcpb.GetContractStorageRequest{
+		Id:             "token.iost",
+		Key:            "TI" + token,
+		Field:          "decimal",
+		ByLongestChain: s.useLongestChain,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get decimal of token %v: %w", token, err)
+	}
+	if resp.Data == "" || resp.Data == "null" {
+		return 0, fmt.Errorf("token %v does not exist", token)
+	}
+	d, err := strconv.Atoi(resp.Data)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal of token %v: %v", token, resp.Data)
+	}
+	s.cache.setDecimal(token, d)
+	return d, nil
+}
+
+// TokenAmount parses a decimal number as an amount of the token, looking up its decimal places.
+func (s *IOSTDevSDK) TokenAmount(amount string, token string) (Amount, error) {
+	return s.TokenAmountCtx(context.Background(), amount, token)
+}
+
+// TokenAmountCtx is TokenAmount with a context to cancel the call.
+func (s *IOSTDevSDK) TokenAmountCtx(ctx context.Context, amount string, token string) (Amount, error) {
+	decimal, err := s.TokenDecimalCtx(ctx, token)
+	if err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(amount, decimal)
+}
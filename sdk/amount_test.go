@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAmount(t *testing.T) {
+	a, err := NewAmount("1.5", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(150000000), a.Units())
+	assert.Equal(t, 8, a.Decimal())
+	assert.Equal(t, "1.5", a.String())
+	assert.Equal(t, "1.50000000", a.StringFixed())
+
+	a, err = NewAmount("-0.010", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), a.Units())
+	assert.Equal(t, "-0.01", a.String())
+
+	_, err = NewAmount("1.005", 2)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+	_, err = NewAmount("92233720368.54775808", 8)
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = NewAmount("1e5", 8)
+	assert.NotNil(t, err)
+	_, err = NewAmount("1", -1)
+	assert.NotNil(t, err)
+}
+
+func TestAmountFormat(t *testing.T) {
+	assert.Equal(t, "0", AmountFromUnits(0, 8).String())
+	assert.Equal(t, "0.00000000", AmountFromUnits(0, 8).StringFixed())
+	assert.Equal(t, "100", AmountFromUnits(100, 0).String())
+	assert.Equal(t, "10", AmountFromUnits(1000, 2).String())
+	assert.Equal(t, "0.0001", AmountFromUnits(1, 4).String())
+	assert.Equal(t, "-92233720368.54775808", AmountFromUnits(math.MinInt64, 8).String())
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := AmountFromUnits(150, 2)
+	b := AmountFromUnits(25, 1)
+	sum, err := a.Add(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "4", sum.String())
+	assert.Equal(t, 2, sum.Decimal())
+	diff, err := a.Sub(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "-1", diff.String())
+	prod, err := b.Mul(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "7.5", prod.String())
+
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, AmountFromUnits(10, 1).Cmp(AmountFromUnits(100, 2)))
+	assert.Equal(t, 1, AmountFromUnits(math.MaxInt64, 0).Cmp(AmountFromUnits(math.MaxInt64, 8)))
+	assert.Equal(t, -1, diff.Sign())
+	assert.True(t, AmountFromUnits(0, 8).IsZero())
+
+	max := AmountFromUnits(math.MaxInt64, 8)
+	_, err = max.Add(AmountFromUnits(1, 8))
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = AmountFromUnits(math.MinInt64, 8).Sub(AmountFromUnits(1, 8))
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = AmountFromUnits(0, 0).Sub(AmountFromUnits(math.MinInt64, 0))
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = max.Mul(2)
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = AmountFromUnits(math.MinInt64, 0).Mul(-1)
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+	_, err = AmountFromUnits(math.MaxInt64, 0).Add(AmountFromUnits(1, 8))
+	assert.True(t, errors.Is(err, ErrAmountOverflow))
+}
+
+func TestAmountRescale(t *testing.T) {
+	a, err := AmountFromUnits(1500, 3).Rescale(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(15), a.Units())
+	_, err = AmountFromUnits(1501, 3).Rescale(1)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+	a, err = AmountFromUnits(15, 1).Rescale(8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(150000000), a.Units())
+
+	f := a.Fixed()
+	assert.Equal(t, "1.5", f.ToString())
+	assert.Equal(t, a, AmountFromFixed(f))
+}
@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// DefaultBatchWorkers is how many calls of a batch are made at a time by default.
+const DefaultBatchWorkers = 8
+
+// Batch collects read calls to make them concurrently, see `Execute`.
+type Batch struct {
+	s       *IOSTDevSDK
+	workers int
+	calls   []func(ctx context.Context) (interface{}, error)
+}
+
+// Batch returns an empty batch of calls made through the connections of the sdk. Set a PoolSize in the connection
+// options for the calls to be spread over several connections.
+func (s *IOSTDevSDK) Batch() *Batch {
+	return &Batch{s: s, workers: DefaultBatchWorkers}
+}
+
+// SetWorkers sets how many calls are made at a time, at least one.
+func (b *Batch) SetWorkers(n int) *Batch {
+	if n < 1 {
+		n = 1
+	}
+	b.workers = n
+	return b
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Add adds a call and returns its index in the results.
+func (b *Batch) Add(call func(ctx context.Context) (interface{}, error)) int {
+	b.calls = append(b.calls, call)
+	return len(b.calls) - 1
+}
+
+// GetAccount adds a call of GetAccountInfo, whose result is a *rpcpb.Account.
+func (b *Batch) GetAccount(id string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetAccountInfoCtx(ctx, id)
+	})
+}
+
+// GetTokenBalance adds a call of GetTokenBalance, whose result is a *rpcpb.GetTokenBalanceResponse.
+func (b *Batch) GetTokenBalance(account string, token string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetTokenBalanceCtx(ctx, account, token)
+	})
+}
+
+// GetAccountTokens adds a call of GetAccountTokens, whose result is a *rpcpb.GetAccountTokensResponse.
+func (b *Batch) GetAccountTokens(account string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetAccountTokensCtx(ctx, account)
+	})
+}
+
+// GetContractStorage adds a call of GetContractStorage, whose result is a *rpcpb.GetContractStorageResponse.
+func (b *Batch) GetContractStorage(r *rpcpb.GetContractStorageRequest) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetContractStorageCtx(ctx, r)
+	})
+}
+
+// GetContract adds a call of GetContract, whose result is a *rpcpb.Contract.
+func (b *Batch) GetContract(id string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetContractCtx(ctx, id)
+	})
+}
+
+// GetChainInfo adds a call of GetChainInfo, whose result is a *rpcpb.ChainInfoResponse.
+func (b *Batch) GetChainInfo() int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetChainInfoCtx(ctx)
+	})
+}
+
+// GetBlockByNum adds a call of GetBlockByNum, whose result is a *rpcpb.BlockResponse.
+func (b *Batch) GetBlockByNum(num int64, complete bool) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetBlockByNumCtx(ctx, num, complete)
+	})
+}
+
+// GetTxByHash adds a call of GetTxByHash, whose result is a *rpcpb.TransactionResponse.
+func (b *Batch) GetTxByHash(hash string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetTxByHashCtx(ctx, hash)
+	})
+}
+
+// GetTxReceiptByTxHash adds a call of GetTxReceiptByTxHash, whose result is a *rpcpb.TxReceipt.
+func (b *Batch) GetTxReceiptByTxHash(hash string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.GetTxReceiptByTxHashCtx(ctx, hash)
+	})
+}
+
+// CallReadOnly adds a call of CallReadOnly, whose result is a *rpcpb.TxReceipt.
+func (b *Batch) CallReadOnly(contract string, abi string, args string) int {
+	return b.Add(func(ctx context.Context) (interface{}, error) {
+		return b.s.CallReadOnlyCtx(ctx, contract, abi, args)
+	})
+}
+
+// BatchError is the error of a batch some calls of which failed.
+type BatchError struct {
+	// Errors holds the error of each call in order, nil for the calls which succeeded.
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	var failed []string
+	for i, err := range e.Errors {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("call %v: %v", i, err))
+		}
+	}
+	return fmt.Sprintf("%v of %v calls failed: %v", len(failed), len(e.Errors), strings.Join(failed, "; "))
+}
+
+// Execute makes the calls of the batch, at most workers at a time, and returns their results in the order they were
+// added. If some calls fail, the others are still made and the error is a *BatchError telling which failed, whose
+// results are nil. The sdk is connected for the whole batch if it is not already.
+func (b *Batch) Execute(ctx context.Context) ([]interface{}, error) {
+	results := make([]interface{}, len(b.calls))
+	if len(b.calls) == 0 {
+		return results, nil
+	}
+	if !b.s.connected() {
+		if err := b.s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer b.s.CloseConn()
+	}
+	errs := make([]error, len(b.calls))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	workers := b.workers
+	if workers > len(b.calls) {
+		workers = len(b.calls)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = b.calls[i](ctx)
+				if errs[i] != nil {
+					results[i] = nil
+				}
+			}
+		}()
+	}
+	for i := range b.calls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return results, &BatchError{Errors: errs}
+		}
+	}
+	return results, nil
+}
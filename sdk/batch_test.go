@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatch(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.txs["found"] = true
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	// the results are in the order the calls were added, the failed ones being nil
+	b := s.Batch()
+	b.GetChainInfo()
+	b.GetTxByHash("missing")
+	b.GetTxByHash("found")
+	assert.Equal(t, 3, b.Len())
+	results, err := b.Execute(context.Background())
+	assert.Len(t, results, 3)
+	assert.Equal(t, int64(100), results[0].(*rpcpb.ChainInfoResponse).HeadBlock)
+	assert.Nil(t, results[1])
+	assert.Equal(t, rpcpb.TransactionResponse_PACKED, results[2].(*rpcpb.TransactionResponse).Status)
+	batchErr, ok := err.(*BatchError)
+	assert.True(t, ok)
+	assert.Nil(t, batchErr.Errors[0])
+	assert.Equal(t, codes.NotFound, status.Code(batchErr.Errors[1]))
+	assert.Nil(t, batchErr.Errors[2])
+	assert.Contains(t, err.Error(), "1 of 3 calls failed: call 1:")
+
+	results, err = s.Batch().Execute(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+}
+
+func TestBatchWorkers(t *testing.T) {
+	s := NewIOSTDevSDK()
+	s.pool = &connPool{}
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	b := s.Batch().SetWorkers(3)
+	for i := 0; i < 10; i++ {
+		i := i
+		b.Add(func(ctx context.Context) (interface{}, error) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return i, nil
+		})
+	}
+	results, err := b.Execute(context.Background())
+	assert.Nil(t, err)
+	for i, r := range results {
+		assert.Equal(t, i, r)
+	}
+	assert.Equal(t, 3, maxRunning)
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// DefaultCacheTTL is how long the chain metadata cached by a new sdk is used before being fetched again.
+const DefaultCacheTTL = 10 * time.Second
+
+// metaCache keeps the chain metadata which rarely changes, or which hot paths do not need exactly up to date.
+type metaCache struct {
+	mu  sync.Mutex
+	ttl time.Duration
+
+	chainInfo   *rpcpb.ChainInfoResponse
+	chainInfoAt time.Time
+	gasRatio    *rpcpb.GasRatioResponse
+	gasRatioAt  time.Time
+	decimals    map[string]cachedDecimal
+	// skew of the local clock measured by `clockSkew`, if skewOK
+	skew   time.Duration
+	skewOK bool
+	skewAt time.Time
+}
+
+type cachedDecimal struct {
+	decimal int
+	at      time.Time
+}
+
+func (c *metaCache) fresh(at time.Time) bool {
+	return c.ttl > 0 && !at.IsZero() && time.Since(at) < c.ttl
+}
+
+func (c *metaCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chainInfo, c.chainInfoAt = nil, time.Time{}
+	c.gasRatio, c.gasRatioAt = nil, time.Time{}
+	c.decimals = nil
+	c.skew, c.skewOK, c.skewAt = 0, false, time.Time{}
+}
+
+// SetCacheTTL sets how long the chain metadata is cached, see `CachedChainInfo`. 0 disables the cache.
+func (s *IOSTDevSDK) SetCacheTTL(ttl time.Duration) {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	s.cache.ttl = ttl
+}
+
+// Refresh drops the cached chain metadata, so that it is fetched again when next used. Callers needing it up to date,
+// like the exact head block, should refresh first or call the node directly.
+func (s *IOSTDevSDK) Refresh() {
+	s.cache.clear()
+}
+
+// CachedChainInfo returns the chain info, which is fetched again once older than the cache ttl. The chain id is
+// reliable, while the head and irreversible block numbers lag behind by up to the ttl.
+func (s *IOSTDevSDK) CachedChainInfo(ctx context.Context) (*rpcpb.ChainInfoResponse, error) {
+	c := &s.cache
+	c.mu.Lock()
+	if c.fresh(c.chainInfoAt) {
+		info := c.chainInfo
+		c.mu.Unlock()
+		return info, nil
+	}
+	c.mu.Unlock()
+	info, err := s.GetChainInfoCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.chainInfo, c.chainInfoAt = info, time.Now()
+	c.mu.Unlock()
+	return info, nil
+}
+
+// CachedGasRatio returns the gas ratios of the recent txs, which are fetched again once older than the cache ttl.
+func (s *IOSTDevSDK) CachedGasRatio(ctx context.Context) (*rpcpb.GasRatioResponse, error) {
+	c := &s.cache
+	c.mu.Lock()
+	if c.fresh(c.gasRatioAt) {
+		ratio := c.gasRatio
+		c.mu.Unlock()
+		return ratio, nil
+	}
+	c.mu.Unlock()
+	ratio, err := s.GetGasRatioCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.gasRatio, c.gasRatioAt = ratio, time.Now()
+	c.mu.Unlock()
+	return ratio, nil
+}
+
+func (c *metaCache) decimal(token string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.decimals[token]
+	if !ok || !c.fresh(d.at) {
+		return 0, false
+	}
+	return d.decimal, true
+}
+
+func (c *metaCache) setDecimal(token string, decimal int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decimals == nil {
+		c.decimals = make(map[string]cachedDecimal)
+	}
+	c.decimals[token] = cachedDecimal{decimal: decimal, at: time.Now()}
+}
@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedChainInfo(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+	s.SetCacheTTL(time.Hour)
+	ctx := context.Background()
+	head := func() int64 {
+		info, err := s.CachedChainInfo(ctx)
+		assert.Nil(t, err)
+		return info.HeadBlock
+	}
+
+	// the chain info is fetched once within the ttl, and again once refreshed
+	assert.Equal(t, int64(100), head())
+	n.setHead(200)
+	assert.Equal(t, int64(100), head())
+	assert.Equal(t, 1, n.callCount("GetChainInfo"))
+	s.Refresh()
+	assert.Equal(t, int64(200), head())
+	assert.Equal(t, 2, n.callCount("GetChainInfo"))
+
+	// or once older than the ttl
+	s.SetCacheTTL(20 * time.Millisecond)
+	n.setHead(300)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, int64(300), head())
+	assert.Equal(t, 3, n.callCount("GetChainInfo"))
+
+	// and each time with no ttl
+	s.SetCacheTTL(0)
+	head()
+	head()
+	assert.Equal(t, 5, n.callCount("GetChainInfo"))
+}
+
+func TestCachedTokenDecimal(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.storage = map[string]string{"token.iost/TIiost/decimal": "8", "token.iost/TIabc/decimal": "null"}
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+	s.SetCacheTTL(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		d, err := s.TokenDecimal("iost")
+		assert.Nil(t, err)
+		assert.Equal(t, 8, d)
+	}
+	assert.Equal(t, 1, n.callCount("GetContractStorage"))
+	s.Refresh()
+	_, err := s.TokenDecimal("iost")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n.callCount("GetContractStorage"))
+
+	// the tokens which do not exist are not cached
+	for i := 0; i < 2; i++ {
+		_, err = s.TokenDecimal("abc")
+		assert.EqualError(t, err, "token abc does not exist")
+	}
+	assert.Equal(t, 4, n.callCount("GetContractStorage"))
+}
+
+func TestCacheFailover(t *testing.T) {
+	a, b := newTestNode(t, 100), newTestNode(t, 100)
+	defer b.stop()
+	s := newTestSDK(t, a, b)
+	defer s.CloseConn()
+	s.SetCacheTTL(time.Hour)
+	info, err := s.CachedChainInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), info.HeadBlock)
+
+	// the metadata cached from the server left is dropped
+	b.setHead(200)
+	a.stop()
+	_, err = s.GetNodeInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, b.addr, s.Server())
+	info, err = s.CachedChainInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200), info.HeadBlock)
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// ClockPolicy decides how the sdk deals with the local clock drifting from the one of the node, which then rejects the
+// txs as expired or as created in the future.
+type ClockPolicy struct {
+	// MaxSkew is how far the local clock may be from the one of the node before it is warned about, 0 disables the
+	// checks. The clock of the node is estimated by the time of its head block, so skews under a second can't be told.
+	MaxSkew time.Duration
+	// Adjust shifts the time and expiration of the txs sent by `SendTx` and executed by `ExecTx` by the skew once it
+	// exceeds MaxSkew, instead of only warning about it.
+	Adjust bool
+	// RetryExpired makes `SendTx` send a tx the node rejects as expired once more, with its time set by the clock of
+	// the node and signed again. Txs already signed by other signers are sent as they are, as their signatures would
+	// be lost.
+	RetryExpired bool
+	// CheckInterval is how often the skew is measured again.
+	CheckInterval time.Duration
+}
+
+// DefaultClockPolicy is the clock policy of a new sdk.
+var DefaultClockPolicy = ClockPolicy{
+	MaxSkew:       10 * time.Second,
+	RetryExpired:  true,
+	CheckInterval: 10 * time.Minute,
+}
+
+// SetClockPolicy sets how the skew between the local clock and the one of the node is dealt with, see `ClockPolicy`.
+func (s *IOSTDevSDK) SetClockPolicy(p ClockPolicy) {
+	s.clockPolicy = p
+}
+
+// ClockSkew returns how far the local clock is ahead of the one of the node, negative if behind. The clock of the node
+// is estimated by the time of its head block, which is produced every half second, so the skew is up to a second
+// more than the actual one.
+func (s *IOSTDevSDK) ClockSkew() (time.Duration, error) {
+	return s.ClockSkewCtx(context.Background())
+}
+
+// ClockSkewCtx is ClockSkew with a context to cancel the calls.
+func (s *IOSTDevSDK) ClockSkewCtx(ctx context.Context) (time.Duration, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return 0, err
+		}
+		defer s.CloseConn()
+	}
+	info, err := s.GetChainInfoCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	res, err := s.GetBlockByNumCtx(ctx, info.HeadBlock, false)
+	if err != nil {
+		return 0, err
+	}
+	// the block is compared with the local time halfway through the call
+	now := start.Add(time.Since(start) / 2)
+	return now.Sub(time.Unix(0, res.Block.Time)), nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// clockSkew returns the skew measured within the check interval, measuring it again if older or if forced, and warns
+// if it exceeds the max skew. ok is false if the skew could not be measured, which is not tried again before the
+// interval has passed unless forced.
+func (s *IOSTDevSDK) clockSkew(ctx context.Context, force bool) (skew time.Duration, ok bool) {
+	c := &s.cache
+	c.mu.Lock()
+	if !force && !c.skewAt.IsZero() && time.Since(c.skewAt) < s.clockPolicy.CheckInterval {
+		skew, ok = c.skew, c.skewOK
+		c.mu.Unlock()
+		return skew, ok
+	}
+	c.mu.Unlock()
+	skew, err := s.ClockSkewCtx(ctx)
+	if err != nil {
+		s.log("Failed to check the clock against the node:", err)
+	} else if p := s.clockPolicy; p.MaxSkew > 0 && absDuration(skew) > p.MaxSkew {
+		if skew > 0 {
+			s.log("Warning: the local clock is", skew, "ahead of the clock of node", s.Server())
+		} else {
+			s.log("Warning: the local clock is", -skew, "behind the clock of node", s.Server())
+		}
+	}
+	c.mu.Lock()
+	c.skew, c.skewOK, c.skewAt = skew, err == nil, time.Now()
+	c.mu.Unlock()
+	return skew, err == nil
+}
+
+// adjustTime checks the clock if due, and shifts the time and expiration of the tx by the skew if the policy adjusts
+// it. Txs already signed by signers are left as they are.
+func (s *IOSTDevSDK) adjustTime(ctx context.Context, tx *rpcpb.TransactionRequest) {
+	p := s.clockPolicy
+	if p.MaxSkew <= 0 || len(tx.Signatures) != 0 {
+		return
+	}
+	skew, ok := s.clockSkew(ctx, false)
+	if !ok || !p.Adjust || absDuration(skew) <= p.MaxSkew {
+		return
+	}
+	s.log("Shifting the time of the transaction by", -skew, "to the clock of the node")
+	tx.Time -= int64(skew)
+	tx.Expiration -= int64(skew)
+}
+
+// refreshTime sets the time of the tx to now by the clock of the node, or by the local clock if it can't be
+// measured, keeping how long the tx lasts.
+func (s *IOSTDevSDK) refreshTime(ctx context.Context, tx *rpcpb.TransactionRequest) {
+	now := time.Now()
+	if skew, ok := s.clockSkew(ctx, true); ok {
+		now = now.Add(-skew)
+	}
+	lasts := tx.Expiration - tx.Time
+	tx.Time = now.UnixNano()
+	tx.Expiration = tx.Time + lasts
+}
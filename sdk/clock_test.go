@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// clockNode is a node whose clock is behind the local one by skew, checking the time of the txs like the tx pool.
+type clockNode struct {
+	rpcpb.ApiServiceClient
+	skew time.Duration
+
+	mu    sync.Mutex
+	tries int
+	sent  []*rpcpb.TransactionRequest
+}
+
+func (n *clockNode) now() int64 {
+	return time.Now().Add(-n.skew).UnixNano()
+}
+
+func (n *clockNode) GetChainInfo(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.ChainInfoResponse, error) {
+	return &rpcpb.ChainInfoResponse{ChainId: 1024, HeadBlock: 100}, nil
+}
+
+func (n *clockNode) GetBlockByNumber(ctx context.Context, in *rpcpb.GetBlockByNumberRequest, opts ...grpc.CallOption) (*rpcpb.BlockResponse, error) {
+	return &rpcpb.BlockResponse{Block: &rpcpb.Block{Number: in.Number, Time: n.now()}}, nil
+}
+
+func (n *clockNode) SendTransaction(ctx context.Context, in *rpcpb.TransactionRequest, opts ...grpc.CallOption) (*rpcpb.SendTransactionResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.tries++
+	now := n.now()
+	if in.Time > now+int64(time.Second) || in.Expiration <= now || now-in.Time > int64(90*time.Second) {
+		return nil, status.Error(codes.Unknown, "TimeError")
+	}
+	n.sent = append(n.sent, in)
+	return &rpcpb.SendTransactionResponse{Hash: fmt.Sprint(len(n.sent))}, nil
+}
+
+func TestClockSkew(t *testing.T) {
+	node := &clockNode{skew: 30 * time.Second}
+	ts := newGatewayServer(t, node)
+	defer ts.Close()
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	newSDK := func(p ClockPolicy) *IOSTDevSDK {
+		s := NewIOSTDevSDK()
+		s.SetServer(ts.URL)
+		s.SetAccount("alice", kp)
+		s.SetCheckResult(false, 0, 0)
+		s.SetClockPolicy(p)
+		return s
+	}
+	ctx := context.Background()
+	actions := []*rpcpb.Action{NewAction("token.iost", "transfer", `["iost","alice","bob","1",""]`)}
+
+	s := newSDK(DefaultClockPolicy)
+	skew, err := s.ClockSkewCtx(ctx)
+	assert.Nil(t, err)
+	assert.True(t, skew > 29*time.Second && skew < 31*time.Second, skew.String())
+
+	// the tx from the future is sent again with the time of the node
+	hash, err := s.SendTxFromActionsCtx(ctx, actions)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", hash)
+	assert.Equal(t, 2, node.tries)
+	tx := node.sent[0]
+	assert.True(t, tx.Time < node.now())
+	assert.Equal(t, s.expiration*1e9, tx.Expiration-tx.Time)
+
+	// the tx is shifted before being sent
+	s = newSDK(ClockPolicy{MaxSkew: 10 * time.Second, Adjust: true, CheckInterval: time.Minute})
+	_, err = s.SendTxFromActionsCtx(ctx, actions)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, node.tries)
+
+	// the skew under the max is left as it is
+	s = newSDK(ClockPolicy{MaxSkew: time.Minute, Adjust: true, CheckInterval: time.Minute})
+	_, err = s.SendTxFromActionsCtx(ctx, actions)
+	assert.True(t, errors.Is(err, ErrTxExpired), fmt.Sprint(err))
+	assert.Equal(t, 4, node.tries)
+
+	// as is a tx signed by other signers, which would lose their signatures
+	s = newSDK(DefaultClockPolicy)
+	tx, err = s.CreateTxFromActions(actions)
+	assert.Nil(t, err)
+	tx.Signers = []string{"bob@active"}
+	tx.Signatures = []*rpcpb.Signature{{}}
+	_, err = s.SendTxCtx(ctx, tx)
+	assert.True(t, errors.Is(err, ErrTxExpired), fmt.Sprint(err))
+	assert.Equal(t, 5, node.tries)
+}
@@ -0,0 +1,291 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/gzip"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// ConnOptions tunes the connections of the sdk.
+type ConnOptions struct {
+	// PoolSize is how many connections are opened to the server, the calls being spread over them in turn. A node
+	// serves at most 200 concurrent calls on a connection, so busy services sharing the sdk between goroutines
+	// should open several.
+	PoolSize int
+	// KeepaliveTime is how long a connection may be idle before it is pinged, 0 disables the pings. Nodes close the
+	// connections pinging more often than every 5 minutes by default. KeepaliveTimeout is how long to wait for the
+	// answer before the connection is closed, and PermitWithoutStream allows pings while there is no call.
+	KeepaliveTime       time.Duration
+	KeepaliveTimeout    time.Duration
+	PermitWithoutStream bool
+	// MaxRecvMsgSize and MaxSendMsgSize limit the size in bytes of the messages, 0 keeps the grpc defaults, 4MB for
+	// the messages received and no limit for those sent.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// Credentials secures the connections, which are insecure if nil, see `TLSCredentials`.
+	Credentials credentials.TransportCredentials
+	// HTTPClient sends the requests to the servers given by the url of their json gateway, http.DefaultClient if
+	// nil. Its transport configures tls and proxies, the options above being those of grpc.
+	HTTPClient *http.Client
+	// APIKey is sent with each call, for the nodes requiring api keys.
+	APIKey string
+	// Compression makes the grpc calls compressed with gzip, the nodes answering them compressed too, which pays off
+	// for the large responses like complete blocks over slow links. The responses of the json gateways are
+	// decompressed by the http transport whenever the node compresses them.
+	Compression bool
+}
+
+// DefaultConnOptions are the connection options of a new sdk.
+var DefaultConnOptions = ConnOptions{
+	PoolSize:         1,
+	KeepaliveTimeout: 20 * time.Second,
+}
+
+// TLSCredentials returns the credentials of tls connections, for nodes behind a tls terminating load balancer or
+// requiring mutual tls. The server certificate is checked against the CA certificates of the pem file caCertFile,
+// or those of the system if empty. The client certificate and key pem files are given for mutual tls, or both empty.
+func TLSCredentials(caCertFile string, clientCertFile string, clientKeyFile string) (credentials.TransportCredentials, error) {
+	config, err := tlsConfig(caCertFile, clientCertFile, clientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func tlsConfig(caCertFile string, clientCertFile string, clientKeyFile string) (*tls.Config, error) {
+	config := &tls.Config{}
+	if caCertFile != "" {
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %v", caCertFile)
+		}
+	}
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		return nil, fmt.Errorf("the client certificate and key should be given together")
+	}
+	if clientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func (o ConnOptions) dialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if o.Credentials != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(o.Credentials)}
+	}
+	if o.APIKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apiKeyCredentials(o.APIKey)))
+	}
+	if o.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                o.KeepaliveTime,
+			Timeout:             o.KeepaliveTimeout,
+			PermitWithoutStream: o.PermitWithoutStream,
+		}))
+	}
+	var callOpts []grpc.CallOption
+	if o.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(o.MaxRecvMsgSize))
+	}
+	if o.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.MaxSendMsgSize))
+	}
+	if o.Compression {
+		callOpts = append(callOpts, grpc.UseCompressor(gzip.Name))
+	}
+	if len(callOpts) != 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts
+}
+
+// SetConnOptions sets the options of the connections opened afterwards.
+func (s *IOSTDevSDK) SetConnOptions(o ConnOptions) {
+	s.connOptions = o
+}
+
+// apiKeyHeader is the metadata, and the http header for the json gateway, giving the api key of a call.
+const apiKeyHeader = "x-api-key"
+
+// SetAPIKey sets the api key sent with the calls of the connections opened afterwards.
+func (s *IOSTDevSDK) SetAPIKey(key string) {
+	s.connOptions.APIKey = key
+}
+
+// apiKeyCredentials sends the api key as the metadata of the calls, over tls or not.
+type apiKeyCredentials string
+
+func (k apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{apiKeyHeader: string(k)}, nil
+}
+
+func (k apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// SetTLS makes the connections opened afterwards use tls, see `TLSCredentials`. The requests to json gateways given
+// by an https url check the certificates the same way.
+func (s *IOSTDevSDK) SetTLS(caCertFile string, clientCertFile string, clientKeyFile string) error {
+	config, err := tlsConfig(caCertFile, clientCertFile, clientKeyFile)
+	if err != nil {
+		return err
+	}
+	s.connOptions.Credentials = credentials.NewTLS(config)
+	s.connOptions.HTTPClient = &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}}
+	return nil
+}
+
+// connPool is the connections to a server, or the client of its json gateway with no connection.
+type connPool struct {
+	server  string
+	conns   []*grpc.ClientConn
+	next    uint32
+	gateway *gatewayClient
+
+	// the calls in flight on the connections, a retired pool being closed once it has none
+	mu      sync.Mutex
+	calls   int
+	retired bool
+	closed  bool
+	onClose func()
+}
+
+// get returns the connections in turn.
+func (p *connPool) get() *grpc.ClientConn {
+	n := atomic.AddUint32(&p.next, 1)
+	return p.conns[n%uint32(len(p.conns))]
+}
+
+func (p *connPool) has(cc *grpc.ClientConn) bool {
+	for _, conn := range p.conns {
+		if conn == cc {
+			return true
+		}
+	}
+	return false
+}
+
+// acquire counts a call made on a connection of the pool, it returns false if the pool is closed.
+func (p *connPool) acquire() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.calls++
+	return true
+}
+
+// release ends a call counted by `acquire`.
+func (p *connPool) release() {
+	p.mu.Lock()
+	p.calls--
+	idle := p.retired && p.calls == 0
+	p.mu.Unlock()
+	if idle {
+		p.closeRetired()
+	}
+}
+
+// retire closes the pool once its calls in flight are done, but not before grace has passed, so that the calls about
+// to be made on the connections already taken from it still get through. onClose is called once it is closed.
+func (p *connPool) retire(grace time.Duration, onClose func()) {
+	p.mu.Lock()
+	p.onClose = onClose
+	p.mu.Unlock()
+	time.AfterFunc(grace, func() {
+		p.mu.Lock()
+		p.retired = true
+		idle := p.calls == 0
+		p.mu.Unlock()
+		if idle {
+			p.closeRetired()
+		}
+	})
+}
+
+func (p *connPool) closeRetired() {
+	if !p.close() {
+		return
+	}
+	p.mu.Lock()
+	onClose := p.onClose
+	p.mu.Unlock()
+	if onClose != nil {
+		onClose()
+	}
+}
+
+// close closes the connections, it returns false if they were closed already.
+func (p *connPool) close() bool {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return false
+	}
+	p.closed = true
+	p.mu.Unlock()
+	for _, conn := range p.conns {
+		conn.Close()
+	}
+	return true
+}
+
+// dialPool opens the connections of the pool to the server, starting with the given one if any.
+func (s *IOSTDevSDK) dialPool(ctx context.Context, server string, first *grpc.ClientConn) (*connPool, error) {
+	p := &connPool{server: server}
+	if first != nil {
+		p.conns = append(p.conns, first)
+	}
+	opts := s.dialOptions(s.retryInterceptor)
+	for len(p.conns) < s.connOptions.PoolSize || len(p.conns) == 0 {
+		conn, err := grpc.DialContext(ctx, server, opts...)
+		if err != nil {
+			p.close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+	}
+	return p, nil
+}
+
+// apiClient returns the api client calling the server over a connection of the pool, or over its json gateway. It
+// has no connection if not connected.
+func (s *IOSTDevSDK) apiClient() rpcpb.ApiServiceClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.pool == nil {
+		return rpcpb.NewApiServiceClient(nil)
+	}
+	if s.pool.gateway != nil {
+		return s.pool.gateway
+	}
+	return rpcpb.NewApiServiceClient(s.pool.get())
+}
+
+func (s *IOSTDevSDK) connected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pool != nil
+}
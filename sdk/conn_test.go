@@ -0,0 +1,142 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func TestConnPool(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := NewIOSTDevSDK()
+	s.SetServer(n.addr)
+	s.SetRetryPolicy(testRetryPolicy)
+	o := DefaultConnOptions
+	o.PoolSize = 3
+	s.SetConnOptions(o)
+	assert.Nil(t, s.Connect())
+	defer s.CloseConn()
+	assert.Len(t, s.pool.conns, 3)
+
+	// the goroutines sharing the sdk spread their calls over all the connections
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				info, err := s.GetChainInfo()
+				assert.Nil(t, err)
+				assert.Equal(t, int64(100), info.HeadBlock)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 80, n.callCount("GetChainInfo"))
+	n.mu.Lock()
+	assert.Len(t, n.peers, 3)
+	n.mu.Unlock()
+}
+
+func TestConnMaxRecvMsgSize(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := NewIOSTDevSDK()
+	s.SetServer(n.addr)
+	s.SetRetryPolicy(testRetryPolicy)
+	o := DefaultConnOptions
+	o.MaxRecvMsgSize = 10
+	s.SetConnOptions(o)
+	assert.Nil(t, s.Connect())
+	defer s.CloseConn()
+
+	_, err := s.GetNodeInfoCtx(WithRetries(context.Background(), 0))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	// the small messages still get through
+	_, err = s.GetChainInfo()
+	assert.Nil(t, err)
+}
+
+// testCert makes a certificate signed by the parent, or self signed if nil, and writes it and its key as pem files.
+func testCert(t *testing.T, dir string, name string, template *x509.Certificate, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template.Subject = pkix.Name{CommonName: name}
+	template.NotBefore, template.NotAfter = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	signer, signerKey := template, interface{}(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".pem"), certPem, 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".key"), keyPem, 0600))
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	assert.Nil(t, err)
+	cert.Leaf, err = x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return cert
+}
+
+func TestConnTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ca := testCert(t, dir, "ca", &x509.Certificate{SerialNumber: big.NewInt(1), IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}, nil)
+	serverCert := testCert(t, dir, "server", &x509.Certificate{SerialNumber: big.NewInt(2), IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}, &ca)
+	testCert(t, dir, "client", &x509.Certificate{SerialNumber: big.NewInt(3), ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}, &ca)
+	testCert(t, dir, "other", &x509.Certificate{SerialNumber: big.NewInt(4), IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}, nil)
+	file := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	// the node requires the client certificates signed by the ca
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}, ClientCAs: pool, ClientAuth: tls.RequireAndVerifyClientCert})
+	n := newTestNode(t, 100, grpc.Creds(creds))
+	defer n.stop()
+	call := func(caCert, clientCert, clientKey string) error {
+		s := NewIOSTDevSDK()
+		s.SetServer(n.addr)
+		if err := s.SetTLS(caCert, clientCert, clientKey); err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(WithRetries(context.Background(), 0), time.Second)
+		defer cancel()
+		_, err := s.GetChainInfoCtx(ctx)
+		return err
+	}
+
+	assert.Nil(t, call(file("ca.pem"), file("client.pem"), file("client.key")))
+	assert.NotNil(t, call(file("ca.pem"), "", ""))
+	assert.NotNil(t, call(file("other.pem"), file("client.pem"), file("client.key")))
+
+	assert.EqualError(t, call(file("ca.pem"), file("client.pem"), ""), "the client certificate and key should be given together")
+	assert.Contains(t, call(file("client.key"), "", "").Error(), "no certificate found in")
+	assert.Contains(t, call(file("missing.pem"), "", "").Error(), "failed to read CA certificate")
+}
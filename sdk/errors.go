@@ -0,0 +1,193 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCode classifies the errors reported by the node or the sdk, so that scripts can branch on it instead of the message.
+// The codes are errors themselves, which the errors of the node and the failed receipts match:
+//
+//	if _, err := s.SendTx(tx); errors.Is(err, sdk.ErrInsufficientBalance) {
+type ErrorCode string
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// Error codes.
+const (
+	ErrUnknown             ErrorCode = "E_UNKNOWN"
+	ErrNodeUnavailable     ErrorCode = "E_NODE_UNAVAILABLE"
+	ErrExecDisabled        ErrorCode = "E_EXEC_DISABLED"
+	ErrInsufficientGas     ErrorCode = "E_INSUFFICIENT_GAS"
+	ErrInsufficientRAM     ErrorCode = "E_INSUFFICIENT_RAM"
+	ErrInsufficientBalance ErrorCode = "E_INSUFFICIENT_BALANCE"
+	ErrAmountLimit         ErrorCode = "E_AMOUNT_LIMIT_EXCEEDED"
+	ErrBadSignature        ErrorCode = "E_BAD_SIGNATURE"
+	ErrNoPermission        ErrorCode = "E_NO_PERMISSION"
+	ErrNoSigner            ErrorCode = "E_NO_SIGNER"
+	ErrTxExpired           ErrorCode = "E_TX_EXPIRED"
+	ErrDuplicateTx         ErrorCode = "E_DUPLICATE_TX"
+	ErrTxPoolFull          ErrorCode = "E_TXPOOL_FULL"
+	ErrInvalidChainID      ErrorCode = "E_INVALID_CHAIN_ID"
+	ErrInvalidGasParams    ErrorCode = "E_INVALID_GAS_PARAMS"
+	ErrExecutionTimeout    ErrorCode = "E_EXECUTION_TIMEOUT"
+	ErrWaitTimeout         ErrorCode = "E_WAIT_TIMEOUT"
+	ErrContractNotFound    ErrorCode = "E_CONTRACT_NOT_FOUND"
+	ErrTokenNotFound       ErrorCode = "E_TOKEN_NOT_FOUND"
+	ErrTxNotFound          ErrorCode = "E_TX_NOT_FOUND"
+	ErrAccountNotFound     ErrorCode = "E_ACCOUNT_NOT_FOUND"
+	ErrWrongParameter      ErrorCode = "E_WRONG_PARAMETER"
+	ErrRuntime             ErrorCode = "E_RUNTIME_ERROR"
+)
+
+// errorPatterns maps the messages of the node and the sdk to codes, the first matching pattern wins.
+var errorPatterns = []struct {
+	pattern string
+	code    ErrorCode
+}{
+	{"code = Unavailable", ErrNodeUnavailable},
+	{"no server available", ErrNodeUnavailable},
+	{"has't enabled this method", ErrExecDisabled},
+	{"exceed amountLimit", ErrAmountLimit},
+	{"token not exists in amountLimit", ErrAmountLimit},
+	{"no permission", ErrNoPermission},
+	{"unauthorized publisher", ErrNoPermission},
+	{"unauthorized signer", ErrNoPermission},
+	{"pay ram failed", ErrInsufficientRAM},
+	{"gas not enough", ErrInsufficientGas},
+	{"out of gas", ErrInsufficientGas},
+	{"balance not enough", ErrInsufficientBalance},
+	{"no signer for", ErrNoSigner},
+	{"signer error", ErrBadSignature},
+	{"publisher error", ErrBadSignature},
+	{"sign tx error", ErrBadSignature},
+	{"TimeError", ErrTxExpired},
+	{"transaction expired", ErrTxExpired},
+	{"invalid time and expiration", ErrTxExpired},
+	{"tx exists in pending", ErrDuplicateTx},
+	{"tx exists in chain", ErrDuplicateTx},
+	{"txpool is full", ErrTxPoolFull},
+	{"invalid chain_id", ErrInvalidChainID},
+	{"gas limit illegal", ErrInvalidGasParams},
+	{"gas ratio illegal", ErrInvalidGasParams},
+	{"execution killed", ErrExecutionTimeout},
+	{"transaction is still", ErrWaitTimeout},
+	{"transaction not found after", ErrWaitTimeout},
+	{"contract not exists", ErrContractNotFound},
+	{"token not exists", ErrTokenNotFound},
+	{"tx not found", ErrTxNotFound},
+	{"account not found", ErrAccountNotFound},
+}
+
+// Error is an error of the node, returned through grpc, classified by its code.
+type Error struct {
+	Code ErrorCode
+	Err  error
+}
+
+// WrapError classifies an error of the node returned through grpc as an *Error, leaving the other errors as they are.
+// The sdk wraps the errors of its calls itself, this is for fakes and interceptors returning errors like nodes do.
+func WrapError(err error) error {
+	var e *Error
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	code := ErrNodeUnavailable
+	if st.Code() != codes.Unavailable {
+		code = codeOfMessage(st.Message())
+	}
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap ...
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is tells whether the error is of the code target.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.Code
+}
+
+// GRPCStatus returns the grpc status of the error, so that `status.Code` and `status.FromError` see through it.
+func (e *Error) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+// ReceiptError is the error of a transaction failing on chain, whose receipt tells why.
+type ReceiptError struct {
+	Receipt *rpcpb.TxReceipt
+}
+
+func (e *ReceiptError) Error() string {
+	return e.Receipt.Message
+}
+
+// Is tells whether the receipt failed with the code target, see `ReceiptCodeOf`.
+func (e *ReceiptError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == ReceiptCodeOf(e.Receipt)
+}
+
+// CodeOf returns the code of an error by its message, which is ErrUnknown if not recognized.
+func CodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var re *ReceiptError
+	if errors.As(err, &re) {
+		return ReceiptCodeOf(re.Receipt)
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return codeOfMessage(err.Error())
+}
+
+// ReceiptCodeOf returns the code of a failed receipt by its message, or by its status code if not recognized.
+func ReceiptCodeOf(receipt *rpcpb.TxReceipt) ErrorCode {
+	if receipt.StatusCode == rpcpb.TxReceipt_SUCCESS {
+		return ""
+	}
+	if code := codeOfMessage(receipt.Message); code != ErrUnknown {
+		return code
+	}
+	switch receipt.StatusCode {
+	case rpcpb.TxReceipt_GAS_RUN_OUT:
+		return ErrInsufficientGas
+	case rpcpb.TxReceipt_BALANCE_NOT_ENOUGH:
+		return ErrInsufficientBalance
+	case rpcpb.TxReceipt_WRONG_PARAMETER:
+		return ErrWrongParameter
+	case rpcpb.TxReceipt_TIMEOUT:
+		return ErrExecutionTimeout
+	case rpcpb.TxReceipt_RUNTIME_ERROR:
+		return ErrRuntime
+	}
+	return ErrUnknown
+}
+
+func codeOfMessage(msg string) ErrorCode {
+	for _, p := range errorPatterns {
+		if strings.Contains(msg, p.pattern) {
+			return p.code
+		}
+	}
+	return ErrUnknown
+}
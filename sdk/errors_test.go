@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrapError(t *testing.T) {
+	err := WrapError(status.Error(codes.Unknown, "tx exists in chain"))
+	assert.True(t, errors.Is(err, ErrDuplicateTx))
+	assert.False(t, errors.Is(err, ErrTxExpired))
+	assert.Equal(t, ErrDuplicateTx, CodeOf(fmt.Errorf("send tx error: %w", err)))
+	assert.Equal(t, "rpc error: code = Unknown desc = tx exists in chain", err.Error())
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.Equal(t, err, WrapError(err))
+
+	err = WrapError(status.Error(codes.Unavailable, "connection refused"))
+	assert.True(t, errors.Is(err, ErrNodeUnavailable))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.True(t, errors.Is(WrapError(status.Error(codes.Unknown, "tx not found")), ErrTxNotFound))
+	assert.True(t, errors.Is(WrapError(status.Error(codes.Unknown, "something else")), ErrUnknown))
+
+	// the errors of the sdk itself are left as they are
+	err = errors.New("tx exists in chain")
+	assert.Equal(t, err, WrapError(err))
+	assert.Nil(t, WrapError(nil))
+
+	receipt := &rpcpb.TxReceipt{StatusCode: rpcpb.TxReceipt_GAS_RUN_OUT, Message: "out of gas"}
+	err = fmt.Errorf("transaction failed: %w", &ReceiptError{Receipt: receipt})
+	assert.True(t, errors.Is(err, ErrInsufficientGas))
+	assert.False(t, errors.Is(err, ErrInsufficientBalance))
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+)
+
+const (
+	// healthCheckTimeout is how long a server has to answer the health check.
+	healthCheckTimeout = 3 * time.Second
+	// maxServerLag is how many blocks a server may be behind the best one before it is considered stale.
+	maxServerLag = 30
+)
+
+type serverHealth struct {
+	server string
+	conn   *grpc.ClientConn
+	head   int64
+	err    error
+}
+
+func splitServers(server string) []string {
+	var servers []string
+	for _, s := range strings.Split(server, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
+// checkServer connects to the server and asks for its head block.
+func (s *IOSTDevSDK) checkServer(ctx context.Context, server string) *serverHealth {
+	h := &serverHealth{server: server}
+	// a server failing the check is skipped rather than retried
+	ctx = context.WithValue(ctx, noRetryKey{}, true)
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	opts := append(s.dialOptions(s.retryInterceptor), grpc.WithBlock())
+	h.conn, h.err = grpc.DialContext(ctx, server, opts...)
+	if h.err != nil {
+		return h
+	}
+	info, err := rpcpb.NewApiServiceClient(h.conn).GetChainInfo(ctx, &rpcpb.EmptyRequest{})
+	if err != nil {
+		h.conn.Close()
+		h.conn, h.err = nil, err
+		return h
+	}
+	h.head = info.HeadBlock
+	return h
+}
+
+// pickServer health checks all servers but the excluded one at the same time, and returns the connection to the first
+// server in the given order which is not stale.
+func (s *IOSTDevSDK) pickServer(ctx context.Context, exclude string) (*serverHealth, error) {
+	var candidates []string
+	for _, server := range s.servers {
+		if server != exclude {
+			candidates = append(candidates, server)
+		}
+	}
+	results := make([]*serverHealth, len(candidates))
+	done := make(chan struct{})
+	for i, server := range candidates {
+		go func(i int, server string) {
+			results[i] = s.checkServer(ctx, server)
+			done <- struct{}{}
+		}(i, server)
+	}
+	for range candidates {
+		<-done
+	}
+	best := int64(-1)
+	for _, h := range results {
+		if h.err == nil && h.head > best {
+			best = h.head
+		}
+	}
+	var picked *serverHealth
+	var errs []string
+	for _, h := range results {
+		switch {
+		case h.err != nil:
+			errs = append(errs, fmt.Sprintf("%v: %v", h.server, h.err))
+		case picked == nil && h.head >= best-maxServerLag:
+			picked = h
+		default:
+			if h.head < best-maxServerLag {
+				s.log("Server", h.server, "is stale at block", h.head, "while the best is at", best)
+			}
+			h.conn.Close()
+		}
+	}
+	if picked == nil {
+		return nil, fmt.Errorf("no server available: %v", strings.Join(errs, "; "))
+	}
+	for _, e := range errs {
+		s.log("Server", e)
+	}
+	return picked, nil
+}
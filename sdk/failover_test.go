@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitServers(t *testing.T) {
+	assert.Equal(t, []string{"a:30002", "b:30002"}, splitServers(" a:30002, ,b:30002,"))
+	assert.Nil(t, splitServers(""))
+}
+
+func TestPickServer(t *testing.T) {
+	a, b, c := newTestNode(t, 0), newTestNode(t, 100), newTestNode(t, 80)
+	defer a.stop()
+	defer b.stop()
+	defer c.stop()
+
+	// the stale server is skipped, and the first of the others in the given order is picked
+	s := newTestSDK(t, a, c, b)
+	assert.Equal(t, c.addr, s.Server())
+	s.CloseConn()
+
+	// the excluded server is not checked
+	s.mu.Lock()
+	h, err := s.pickServer(context.Background(), c.addr)
+	s.mu.Unlock()
+	assert.Nil(t, err)
+	assert.Equal(t, b.addr, h.server)
+	h.conn.Close()
+	assert.Equal(t, 1, c.callCount("GetChainInfo"))
+}
+
+func TestPickServerNoneAvailable(t *testing.T) {
+	a, b := newTestNode(t, 100), newTestNode(t, 100)
+	a.stop()
+	b.stop()
+	s := NewIOSTDevSDK()
+	s.SetServer(a.addr + "," + b.addr)
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := s.ConnectCtx(ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no server available: "+a.addr)
+	assert.Contains(t, err.Error(), b.addr)
+	assert.False(t, s.connected())
+}
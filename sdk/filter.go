@@ -0,0 +1,275 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// EventSource is what a `Filter` subscribes to, which the sdk and its fakes implement.
+type EventSource interface {
+	SubscribeNewBlocks(ctx context.Context, fromHeight int64) <-chan *rpcpb.Block
+	SubscribeContractEvents(ctx context.Context, filter *rpcpb.SubscribeRequest_Filter, topics ...rpcpb.Event_Topic) <-chan *rpcpb.Event
+}
+
+// Filter selects contract events by several conditions, which all have to match:
+//
+//	f := sdk.NewFilter().Contract("token.iost").Topics(rpcpb.Event_CONTRACT_RECEIPT).Arg(2, "bob").Blocks(1000, 2000)
+//	events, err := s.SubscribeEvents(ctx, f)
+//
+// Nodes filter the events by contract and topic only, the other conditions are checked by the sdk. Its methods can
+// be chained, the first invalid condition being reported when subscribing.
+type Filter struct {
+	contract  string
+	topics    []rpcpb.Event_Topic
+	args      []argMatch
+	fromBlock int64
+	toBlock   int64
+	err       error
+}
+
+// argMatch matches a value of the json data of an event, the element index of an array or the field key of an
+// object, against values.
+type argMatch struct {
+	index  int
+	key    string
+	values []string
+}
+
+// NewFilter returns a filter matching the receipts and the events of all contracts.
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+func (f *Filter) setErr(err error) *Filter {
+	if f.err == nil {
+		f.err = err
+	}
+	return f
+}
+
+// Contract limits the events to those of the contract.
+func (f *Filter) Contract(id string) *Filter {
+	f.contract = id
+	return f
+}
+
+// Topics limits the events to the topics, the receipts and events by default.
+func (f *Filter) Topics(topics ...rpcpb.Event_Topic) *Filter {
+	f.topics = append(f.topics, topics...)
+	return f
+}
+
+// Arg limits the events to those whose data is a json array, like the args of a receipt, whose element index is one
+// of the values. Numbers and booleans are given as they are written in json, and strings without quotes.
+func (f *Filter) Arg(index int, values ...string) *Filter {
+	if index < 0 {
+		return f.setErr(fmt.Errorf("invalid arg index %v", index))
+	}
+	if len(values) == 0 {
+		return f.setErr(fmt.Errorf("no value of arg %v", index))
+	}
+	f.args = append(f.args, argMatch{index: index, values: values})
+	return f
+}
+
+// Field limits the events to those whose data is a json object whose field key is one of the values, see `Arg`.
+func (f *Filter) Field(key string, values ...string) *Filter {
+	if key == "" {
+		return f.setErr(fmt.Errorf("empty field key"))
+	}
+	if len(values) == 0 {
+		return f.setErr(fmt.Errorf("no value of field %v", key))
+	}
+	f.args = append(f.args, argMatch{index: -1, key: key, values: values})
+	return f
+}
+
+// Blocks limits the events to the receipts of the txs of the blocks from one to another, both included. A from of 0
+// starts with the next new block, and a to of 0 never ends, Blocks(0, 0) being no range at all. Only receipts can be
+// read from blocks, as the events posted by contracts are not saved on chain, so the topics should include
+// `rpcpb.Event_CONTRACT_RECEIPT`.
+func (f *Filter) Blocks(from int64, to int64) *Filter {
+	if from < 0 || to < 0 || (to != 0 && from > to) {
+		return f.setErr(fmt.Errorf("invalid block range %v to %v", from, to))
+	}
+	f.fromBlock, f.toBlock = from, to
+	return f
+}
+
+func (f *Filter) byBlocks() bool {
+	return f.fromBlock != 0 || f.toBlock != 0
+}
+
+func (f *Filter) topicsOrDefault() []rpcpb.Event_Topic {
+	if len(f.topics) == 0 {
+		return []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_RECEIPT, rpcpb.Event_CONTRACT_EVENT}
+	}
+	return f.topics
+}
+
+// Err returns the first invalid condition given to the filter.
+func (f *Filter) Err() error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.byBlocks() && !hasTopic(f.topicsOrDefault(), rpcpb.Event_CONTRACT_RECEIPT) {
+		return fmt.Errorf("a block range needs the topic %v, as only receipts are saved in blocks", rpcpb.Event_CONTRACT_RECEIPT)
+	}
+	return nil
+}
+
+func hasTopic(topics []rpcpb.Event_Topic, topic rpcpb.Event_Topic) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// Match tells whether the event matches the topics and the args of the filter. The contract and the blocks of an
+// event are not part of it, so they are left to the subscription.
+func (f *Filter) Match(e *rpcpb.Event) bool {
+	if !hasTopic(f.topicsOrDefault(), e.Topic) {
+		return false
+	}
+	if len(f.args) == 0 {
+		return true
+	}
+	dec := json.NewDecoder(strings.NewReader(e.Data))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return false
+	}
+	for _, a := range f.args {
+		if !a.match(data) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a argMatch) match(data interface{}) bool {
+	var v interface{}
+	if a.index >= 0 {
+		arr, ok := data.([]interface{})
+		if !ok || a.index >= len(arr) {
+			return false
+		}
+		v = arr[a.index]
+	} else {
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if v, ok = obj[a.key]; !ok {
+			return false
+		}
+	}
+	s := jsonValueString(v)
+	for _, value := range a.values {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonValueString formats a decoded json value the way `Arg` values are given.
+func jsonValueString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// Subscribe sends the events of the source matching the filter until ctx is done, or until the last block of its
+// range is read. Without a block range, the events are those the node posts as it runs txs, see
+// `SubscribeContractEvents`. With one, they are the receipts of the txs of the blocks, read in order, see
+// `SubscribeNewBlocks`. The channel is closed once done.
+func (f *Filter) Subscribe(ctx context.Context, src EventSource) (<-chan *rpcpb.Event, error) {
+	if err := f.Err(); err != nil {
+		return nil, err
+	}
+	ch := make(chan *rpcpb.Event, streamChSize)
+	ctx, cancel := context.WithCancel(ctx)
+	if !f.byBlocks() {
+		var filter *rpcpb.SubscribeRequest_Filter
+		if f.contract != "" {
+			filter = &rpcpb.SubscribeRequest_Filter{ContractId: f.contract}
+		}
+		events := src.SubscribeContractEvents(ctx, filter, f.topicsOrDefault()...)
+		go func() {
+			defer close(ch)
+			defer cancel()
+			for e := range events {
+				if !f.Match(e) {
+					continue
+				}
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch, nil
+	}
+	blocks := src.SubscribeNewBlocks(ctx, f.fromBlock)
+	go func() {
+		defer close(ch)
+		defer cancel()
+		for b := range blocks {
+			for _, e := range f.blockReceipts(b) {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if f.toBlock != 0 && b.Number >= f.toBlock {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// blockReceipts returns the receipts of the txs of the block matching the filter, as events timed at the block.
+func (f *Filter) blockReceipts(b *rpcpb.Block) []*rpcpb.Event {
+	var events []*rpcpb.Event
+	for _, t := range b.Transactions {
+		if t.TxReceipt == nil {
+			continue
+		}
+		for _, r := range t.TxReceipt.Receipts {
+			if f.contract != "" && strings.SplitN(r.FuncName, "/", 2)[0] != f.contract {
+				continue
+			}
+			e := &rpcpb.Event{Topic: rpcpb.Event_CONTRACT_RECEIPT, Data: r.Content, Time: b.Time}
+			if f.Match(e) {
+				events = append(events, e)
+			}
+		}
+	}
+	return events
+}
+
+// SubscribeEvents sends the contract events matching the filter until ctx is done, see `Filter.Subscribe`.
+func (s *IOSTDevSDK) SubscribeEvents(ctx context.Context, f *Filter) (<-chan *rpcpb.Event, error) {
+	return f.Subscribe(ctx, s)
+}
@@ -0,0 +1,39 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	receipt := func(data string) *rpcpb.Event {
+		return &rpcpb.Event{Topic: rpcpb.Event_CONTRACT_RECEIPT, Data: data}
+	}
+	transfer := receipt(`["iost", "alice", "bob", "1.5", ""]`)
+
+	assert.True(t, NewFilter().Match(transfer))
+	assert.False(t, NewFilter().Topics(rpcpb.Event_CONTRACT_EVENT).Match(transfer))
+	assert.True(t, NewFilter().Arg(0, "iost").Arg(2, "bob", "carol").Match(transfer))
+	assert.False(t, NewFilter().Arg(2, "carol").Match(transfer))
+	assert.False(t, NewFilter().Arg(9, "bob").Match(transfer))
+	assert.True(t, NewFilter().Arg(3, "1.5").Match(transfer))
+
+	event := &rpcpb.Event{Topic: rpcpb.Event_CONTRACT_EVENT, Data: `{"kind": "bid", "price": 10, "final": true, "extra": [1]}`}
+	assert.True(t, NewFilter().Field("kind", "bid").Field("price", "10").Field("final", "true").Match(event))
+	assert.True(t, NewFilter().Field("extra", "[1]").Match(event))
+	assert.False(t, NewFilter().Field("kind", "ask").Match(event))
+	assert.False(t, NewFilter().Field("missing", "x").Match(event))
+	assert.False(t, NewFilter().Arg(0, "bid").Match(event))
+	assert.False(t, NewFilter().Field("kind", "bid").Match(receipt("not json")))
+}
+
+func TestFilterErr(t *testing.T) {
+	assert.Nil(t, NewFilter().Contract("token.iost").Blocks(10, 20).Err())
+	assert.NotNil(t, NewFilter().Arg(-1, "x").Err())
+	assert.NotNil(t, NewFilter().Arg(0).Err())
+	assert.NotNil(t, NewFilter().Field("", "x").Err())
+	assert.NotNil(t, NewFilter().Blocks(20, 10).Err())
+	assert.NotNil(t, NewFilter().Topics(rpcpb.Event_CONTRACT_EVENT).Blocks(10, 0).Err())
+}
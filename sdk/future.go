@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// TxFuture is the result of a tx sent by `SendTxAsync`, which is tracked in the background until it is irreversible.
+type TxFuture struct {
+	hash    string
+	updates chan *rpcpb.TransactionResponse
+	done    chan struct{}
+	receipt *rpcpb.TxReceipt
+	err     error
+}
+
+// Hash returns the hash of the tx.
+func (f *TxFuture) Hash() string {
+	return f.hash
+}
+
+// Updates sends the tx once it is packed in a block, then once the block is irreversible, and is closed when the
+// tracking is over. The tx may be irreversible by the first check, in which case only that update is sent.
+func (f *TxFuture) Updates() <-chan *rpcpb.TransactionResponse {
+	return f.updates
+}
+
+// Done is closed when the tx is irreversible or the tracking failed, after which `Wait` returns at once.
+func (f *TxFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits until the tx is irreversible and returns its receipt. The error is a *ReceiptError if the tx failed on
+// chain, or tells why the tx could not be tracked, like the wait timeout of the sdk being reached. If ctx is done
+// first its error is returned, while the tx is still tracked.
+func (f *TxFuture) Wait(ctx context.Context) (*rpcpb.TxReceipt, error) {
+	select {
+	case <-f.done:
+		return f.receipt, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SendTxAsync signs and sends the tx, and returns once the node accepted it, without waiting for its result
+// whatever the sdk is set to check.
+func (s *IOSTDevSDK) SendTxAsync(tx *rpcpb.TransactionRequest) (*TxFuture, error) {
+	return s.SendTxAsyncCtx(context.Background(), tx)
+}
+
+// SendTxAsyncCtx is SendTxAsync with a context, which cancels sending the tx and then tracking it.
+func (s *IOSTDevSDK) SendTxAsyncCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (*TxFuture, error) {
+	start := time.Now()
+	txHash, err := s.signAndSend(ctx, tx)
+	if err != nil {
+		s.observeTx(err, start)
+		return nil, err
+	}
+	f := &TxFuture{
+		hash:    txHash,
+		updates: make(chan *rpcpb.TransactionResponse, 2),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(f.done)
+		defer close(f.updates)
+		defer func() { s.observeTx(f.err, start) }()
+		packed := false
+		res, err := s.pollTx(ctx, txHash, func(res *rpcpb.TransactionResponse) {
+			// a packed tx may be back in the pool if its block is reverted, it is only reported once
+			if res.Status == rpcpb.TransactionResponse_PACKED && !packed || res.Status == rpcpb.TransactionResponse_IRREVERSIBLE {
+				packed = true
+				f.updates <- res
+			}
+		})
+		if err != nil {
+			f.err = err
+			return
+		}
+		f.receipt = res.Transaction.TxReceipt
+		if f.receipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
+			f.err = &ReceiptError{Receipt: f.receipt}
+		}
+	}()
+	return f, nil
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestTxSDK(t *testing.T, n *testNode) *IOSTDevSDK {
+	s := newTestSDK(t, n)
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	s.SetAccount("alice", kp)
+	s.SetClockPolicy(ClockPolicy{})
+	s.SetCheckResult(false, 0, 5*time.Second)
+	return s
+}
+
+func newTestTx(i int64) *rpcpb.TransactionRequest {
+	return &rpcpb.TransactionRequest{Time: i, Expiration: i + 1, GasLimit: 100000, GasRatio: 1, ChainId: 1024}
+}
+
+func TestSendTxAsync(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.packedPolls = 2
+	n.mu.Unlock()
+	s := newTestTxSDK(t, n)
+	defer s.CloseConn()
+
+	f, err := s.SendTxAsync(newTestTx(1))
+	assert.Nil(t, err)
+	n.mu.Lock()
+	assert.True(t, n.txs[f.Hash()])
+	n.mu.Unlock()
+
+	// the tx found packed twice is reported once, then once irreversible
+	var updates []rpcpb.TransactionResponse_Status
+	for res := range f.Updates() {
+		updates = append(updates, res.Status)
+	}
+	assert.Equal(t, []rpcpb.TransactionResponse_Status{rpcpb.TransactionResponse_PACKED, rpcpb.TransactionResponse_IRREVERSIBLE}, updates)
+	<-f.Done()
+	receipt, err := f.Wait(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, f.Hash(), receipt.TxHash)
+	assert.Equal(t, 3, n.callCount("GetTxByHash"))
+}
+
+func TestSendTxAsyncFailed(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.packedPolls, n.receiptCode = 1, rpcpb.TxReceipt_RUNTIME_ERROR
+	n.mu.Unlock()
+	s := newTestTxSDK(t, n)
+	defer s.CloseConn()
+
+	// the tx failing on chain gives its receipt with the error
+	f, err := s.SendTxAsync(newTestTx(1))
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	_, err = f.Wait(ctx)
+	cancel()
+	assert.Equal(t, context.DeadlineExceeded, err)
+	receipt, err := f.Wait(context.Background())
+	assert.IsType(t, &ReceiptError{}, err)
+	assert.Equal(t, rpcpb.TxReceipt_RUNTIME_ERROR, receipt.StatusCode)
+
+	// the tx rejected by the node gives no future
+	n.failNext(1, status.Error(codes.InvalidArgument, "invalid tx"))
+	f, err = s.SendTxAsync(newTestTx(2))
+	assert.NotNil(t, err)
+	assert.Nil(t, f)
+}
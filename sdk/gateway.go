@@ -0,0 +1,767 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// isGateway tells whether the server is given by the url of the http json gateway of a node, like
+// http://localhost:30001, rather than by the address of its grpc api.
+func isGateway(server string) bool {
+	return strings.HasPrefix(server, "http://") || strings.HasPrefix(server, "https://")
+}
+
+var (
+	gatewayMarshaler   = &jsonpb.Marshaler{OrigName: true}
+	gatewayUnmarshaler = &jsonpb.Unmarshaler{AllowUnknownFields: true}
+)
+
+// gatewayRoute is the http route of an rpc method on the json gateway, as mapped in rpc.proto. The request is sent
+// as the json body of a post, or else given by the path of a get.
+type gatewayRoute struct {
+	post bool
+	path func(in interface{}) string
+}
+
+// gatewayPath joins the escaped parts of a path.
+func gatewayPath(parts ...interface{}) string {
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(fmt.Sprint(p)))
+	}
+	return b.String()
+}
+
+// withQuery appends the query parameters to the path of the gateway, if any.
+func withQuery(path string, q url.Values) string {
+	if query := q.Encode(); query != "" {
+		return path + "?" + query
+	}
+	return path
+}
+
+// stateQuery returns the query including the pending txs in the state queried, if includePending, or querying the
+// state at the block of blockNumber, if not 0.
+func stateQuery(includePending bool, blockNumber int64) url.Values {
+	q := url.Values{}
+	if includePending {
+		q.Set("include_pending", "true")
+	}
+	if blockNumber != 0 {
+		q.Set("block_number", strconv.FormatInt(blockNumber, 10))
+	}
+	return q
+}
+
+func postRoute(path string) gatewayRoute {
+	return gatewayRoute{post: true, path: func(interface{}) string { return path }}
+}
+
+func getRoute(path string) gatewayRoute {
+	return gatewayRoute{path: func(interface{}) string { return path }}
+}
+
+// gatewayRoutes are the routes of the rpc methods by their name.
+var gatewayRoutes = map[string]gatewayRoute{
+	"GetNodeInfo":   getRoute("/getNodeInfo"),
+	"GetNodeStatus": getRoute("/getNodeStatus"),
+	"GetChainInfo":  getRoute("/getChainInfo"),
+	"GetRAMInfo":    getRoute("/getRAMInfo"),
+	"GetGasRatio":   getRoute("/getGasRatio"),
+	"GetTxByHash": {path: func(in interface{}) string {
+		return gatewayPath("getTxByHash", in.(*rpcpb.TxHashRequest).Hash)
+	}},
+	"GetTxReceiptByTxHash": {path: func(in interface{}) string {
+		return gatewayPath("getTxReceiptByTxHash", in.(*rpcpb.TxHashRequest).Hash)
+	}},
+	"TraceTransaction": {path: func(in interface{}) string {
+		return gatewayPath("traceTx", in.(*rpcpb.TxHashRequest).Hash)
+	}},
+	"GetTxsByAccount": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetTxsByAccountRequest)
+		return gatewayPath("getTxsByAccount", r.Account, r.Offset, r.Limit)
+	}},
+	"GetDelaytxsByAccount": {path: func(in interface{}) string {
+		return gatewayPath("getDelaytxsByAccount", in.(*rpcpb.GetDelaytxsByAccountRequest).Account)
+	}},
+	"GetPendingTxByHash": {path: func(in interface{}) string {
+		return gatewayPath("getPendingTxByHash", in.(*rpcpb.TxHashRequest).Hash)
+	}},
+	"GetBlockByHash": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetBlockByHashRequest)
+		return withQuery(gatewayPath("getBlockByHash", r.Hash, r.Complete), url.Values{"actions": r.Actions})
+	}},
+	"GetBlockByNumber": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetBlockByNumberRequest)
+		return withQuery(gatewayPath("getBlockByNumber", r.Number, r.Complete), url.Values{"actions": r.Actions})
+	}},
+	"GetBlockHeaderByHash": {path: func(in interface{}) string {
+		return gatewayPath("getBlockHeaderByHash", in.(*rpcpb.GetBlockHeaderByHashRequest).Hash)
+	}},
+	"GetBlockHeaderByNumber": {path: func(in interface{}) string {
+		return gatewayPath("getBlockHeaderByNumber", in.(*rpcpb.GetBlockHeaderByNumberRequest).Number)
+	}},
+	"GetBlocks": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetBlocksRequest)
+		return gatewayPath("getBlocks", r.Start, r.End, r.Complete)
+	}},
+	"GetBlockStateChanges": {path: func(in interface{}) string {
+		return gatewayPath("getBlockStateChanges", in.(*rpcpb.GetBlockStateChangesRequest).Number)
+	}},
+	"GetAccount": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetAccountRequest)
+		return withQuery(gatewayPath("getAccount", r.Name, r.ByLongestChain), stateQuery(r.IncludePending, r.BlockNumber))
+	}},
+	"GetTokenBalance": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetTokenBalanceRequest)
+		return withQuery(gatewayPath("getTokenBalance", r.Account, r.Token, r.ByLongestChain), stateQuery(r.IncludePending, r.BlockNumber))
+	}},
+	"GetToken721Balance": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetToken721BalanceRequest)
+		path := gatewayPath("getToken721Balance", r.Account, r.Token, r.ByLongestChain)
+		q := url.Values{}
+		if r.Limit != 0 {
+			q.Set("limit", fmt.Sprint(r.Limit))
+		}
+		if r.Cursor != "" {
+			q.Set("cursor", r.Cursor)
+		}
+		return withQuery(path, q)
+	}},
+	"GetToken721Metadata": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetToken721InfoRequest)
+		return gatewayPath("getToken721Metadata", r.Token, r.TokenId, r.ByLongestChain)
+	}},
+	"GetToken721Owner": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetToken721InfoRequest)
+		return gatewayPath("getToken721Owner", r.Token, r.TokenId, r.ByLongestChain)
+	}},
+	"GetAccountTokens": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetAccountTokensRequest)
+		return gatewayPath("getAccountTokens", r.Account, r.ByLongestChain)
+	}},
+	"GetProducerVoteInfo": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetProducerVoteInfoRequest)
+		return gatewayPath("getProducerVoteInfo", r.Account, r.ByLongestChain)
+	}},
+	"GetWitnessSchedule": {path: func(in interface{}) string {
+		return gatewayPath("getWitnessSchedule", in.(*rpcpb.GetWitnessScheduleRequest).BlockCount)
+	}},
+	"GetContract": {path: func(in interface{}) string {
+		r := in.(*rpcpb.GetContractRequest)
+		return gatewayPath("getContract", r.Id, r.ByLongestChain)
+	}},
+	"GetContractStorage":       postRoute("/getContractStorage"),
+	"GetContractStorageFields": postRoute("/getContractStorageFields"),
+	"GetPendingTransactions":   postRoute("/getPendingTxs"),
+	"GetAccountTxs":            postRoute("/getAccountTxs"),
+	"GetBatchContractStorage":  postRoute("/getBatchContractStorage"),
+	"GetProducers":             postRoute("/getProducers"),
+	"GetVoters":                postRoute("/getVoters"),
+	"EstimateGas":              postRoute("/estimateGas"),
+	"SendTransaction":          postRoute("/sendTx"),
+	"ExecTransaction":          postRoute("/execTx"),
+	"Subscribe":                postRoute("/subscribe"),
+	"SubscribeChainStatus":     getRoute("/subscribeChainStatus"),
+	"SubscribeBlocks":          postRoute("/subscribeBlocks"),
+}
+
+// gatewayClient is the api client calling a node by its json gateway, for environments where grpc can't be used,
+// like behind proxies only letting plain http through. The calls go through the interceptor like those of a grpc
+// connection, with a nil connection, and fail with the grpc status errors the node answers, so that the sdk works
+// the same over both. The call options are ignored.
+type gatewayClient struct {
+	url         string
+	client      *http.Client
+	apiKey      string
+	interceptor grpc.UnaryClientInterceptor
+}
+
+var _ rpcpb.ApiServiceClient = (*gatewayClient)(nil)
+
+// newGatewayClient returns the client of the json gateway at the url, whose calls go through the interceptors of the
+// application and then the given interceptor if not nil.
+func (s *IOSTDevSDK) newGatewayClient(server string, last grpc.UnaryClientInterceptor) *gatewayClient {
+	client := s.connOptions.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &gatewayClient{
+		url:         strings.TrimRight(server, "/"),
+		client:      client,
+		apiKey:      s.connOptions.APIKey,
+		interceptor: s.unaryInterceptor(last),
+	}
+}
+
+func (g *gatewayClient) invoke(ctx context.Context, method string, in, out interface{}, opts ...grpc.CallOption) error {
+	method = "/rpcpb.ApiService/" + method
+	if g.interceptor == nil {
+		return g.call(ctx, method, in, out, nil, opts...)
+	}
+	return g.interceptor(ctx, method, in, out, nil, g.call, opts...)
+}
+
+// call is the invoker sending the request of the method to the gateway.
+func (g *gatewayClient) call(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	resp, err := g.do(ctx, method, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := gatewayUnmarshaler.Unmarshal(resp.Body, reply.(proto.Message)); err != nil {
+		return gatewayReadError(ctx, err)
+	}
+	return nil
+}
+
+// do sends the request of the method, returning the response if the node answered it successfully.
+func (g *gatewayClient) do(ctx context.Context, method string, req interface{}) (*http.Response, error) {
+	route, ok := gatewayRoutes[method[strings.LastIndex(method, "/")+1:]]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "method %v is not served by the json gateway", method)
+	}
+	httpMethod, body := http.MethodGet, io.Reader(nil)
+	if route.post {
+		data, err := gatewayMarshaler.MarshalToString(req.(proto.Message))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		httpMethod, body = http.MethodPost, strings.NewReader(data)
+	}
+	r, err := http.NewRequest(httpMethod, g.url+route.path(req), body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if g.apiKey != "" {
+		r.Header.Set(apiKeyHeader, g.apiKey)
+	}
+	resp, err := g.client.Do(r.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx.Err())
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, gatewayError(resp)
+	}
+	return resp, nil
+}
+
+// gatewayError reads the error a node answers, which is the grpc status of the call, into a status error. The
+// errors of proxies in front of the node are classified by their http status.
+func gatewayError(resp *http.Response) error {
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	var e struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if json.Unmarshal(data, &e) == nil && (e.Message != "" || e.Error != "") {
+		if e.Message == "" {
+			e.Message = e.Error
+		}
+		if e.Code == int32(codes.OK) {
+			e.Code = int32(codes.Unknown)
+		}
+		return status.Error(codes.Code(e.Code), e.Message)
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return status.Error(codes.Unavailable, resp.Status)
+	case http.StatusTooManyRequests:
+		return status.Error(codes.ResourceExhausted, resp.Status)
+	}
+	return status.Error(codes.Unknown, resp.Status)
+}
+
+// gatewayReadError is the error of a response which could not be read.
+func gatewayReadError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return contextError(ctx.Err())
+	}
+	if _, ok := err.(*json.SyntaxError); ok || err == io.ErrUnexpectedEOF {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Errorf(codes.Internal, "invalid response of the json gateway: %v", err)
+}
+
+// gatewayStream reads the messages of a subscription, which the gateway answers as a json object per line holding
+// either a message as its result, or the error ending the stream.
+type gatewayStream struct {
+	ctx  context.Context
+	body io.ReadCloser
+	dec  *json.Decoder
+}
+
+func (g *gatewayClient) stream(ctx context.Context, method string, in interface{}) (*gatewayStream, error) {
+	resp, err := g.do(ctx, "/rpcpb.ApiService/"+method, in)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewayStream{ctx: ctx, body: resp.Body, dec: json.NewDecoder(resp.Body)}, nil
+}
+
+// Header ...
+func (s *gatewayStream) Header() (metadata.MD, error) {
+	return nil, nil
+}
+
+// Trailer ...
+func (s *gatewayStream) Trailer() metadata.MD {
+	return nil
+}
+
+// CloseSend ...
+func (s *gatewayStream) CloseSend() error {
+	return nil
+}
+
+// Context ...
+func (s *gatewayStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg fails, as the subscriptions of the gateway are only sent their request.
+func (s *gatewayStream) SendMsg(m interface{}) error {
+	return status.Error(codes.Unimplemented, "the json gateway does not stream requests")
+}
+
+// RecvMsg reads the next message of the stream. The body is closed once the stream ends.
+func (s *gatewayStream) RecvMsg(m interface{}) error {
+	var chunk struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int32  `json:"grpc_code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := s.dec.Decode(&chunk); err != nil {
+		s.body.Close()
+		if err == io.EOF {
+			return io.EOF
+		}
+		return gatewayReadError(s.ctx, err)
+	}
+	if chunk.Error != nil {
+		s.body.Close()
+		return status.Error(codes.Code(chunk.Error.Code), chunk.Error.Message)
+	}
+	if err := gatewayUnmarshaler.Unmarshal(bytes.NewReader(chunk.Result), m.(proto.Message)); err != nil {
+		s.body.Close()
+		return gatewayReadError(s.ctx, err)
+	}
+	return nil
+}
+
+type gatewaySubscribeClient struct {
+	*gatewayStream
+}
+
+// Recv ...
+func (c *gatewaySubscribeClient) Recv() (*rpcpb.SubscribeResponse, error) {
+	m := new(rpcpb.SubscribeResponse)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type gatewayChainStatusClient struct {
+	*gatewayStream
+}
+
+// Recv ...
+func (c *gatewayChainStatusClient) Recv() (*rpcpb.ChainStatusResponse, error) {
+	m := new(rpcpb.ChainStatusResponse)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type gatewayBlocksClient struct {
+	*gatewayStream
+}
+
+// Recv ...
+func (c *gatewayBlocksClient) Recv() (*rpcpb.SubscribeBlocksResponse, error) {
+	m := new(rpcpb.SubscribeBlocksResponse)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EstimateGas ...
+func (g *gatewayClient) EstimateGas(ctx context.Context, in *rpcpb.TransactionRequest, opts ...grpc.CallOption) (*rpcpb.EstimateGasResponse, error) {
+	out := new(rpcpb.EstimateGasResponse)
+	if err := g.invoke(ctx, "EstimateGas", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Subscribe ...
+func (g *gatewayClient) Subscribe(ctx context.Context, in *rpcpb.SubscribeRequest, opts ...grpc.CallOption) (rpcpb.ApiService_SubscribeClient, error) {
+	st, err := g.stream(ctx, "Subscribe", in)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaySubscribeClient{st}, nil
+}
+
+// SubscribeChainStatus ...
+func (g *gatewayClient) SubscribeChainStatus(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (rpcpb.ApiService_SubscribeChainStatusClient, error) {
+	st, err := g.stream(ctx, "SubscribeChainStatus", in)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewayChainStatusClient{st}, nil
+}
+
+// SubscribeBlocks ...
+func (g *gatewayClient) SubscribeBlocks(ctx context.Context, in *rpcpb.SubscribeBlocksRequest, opts ...grpc.CallOption) (rpcpb.ApiService_SubscribeBlocksClient, error) {
+	st, err := g.stream(ctx, "SubscribeBlocks", in)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewayBlocksClient{st}, nil
+}
+
+// GetNodeInfo ...
+func (g *gatewayClient) GetNodeInfo(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.NodeInfoResponse, error) {
+	out := new(rpcpb.NodeInfoResponse)
+	if err := g.invoke(ctx, "GetNodeInfo", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetNodeStatus ...
+func (g *gatewayClient) GetNodeStatus(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.NodeStatusResponse, error) {
+	out := new(rpcpb.NodeStatusResponse)
+	if err := g.invoke(ctx, "GetNodeStatus", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetChainInfo ...
+func (g *gatewayClient) GetChainInfo(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.ChainInfoResponse, error) {
+	out := new(rpcpb.ChainInfoResponse)
+	if err := g.invoke(ctx, "GetChainInfo", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRAMInfo ...
+func (g *gatewayClient) GetRAMInfo(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.RAMInfoResponse, error) {
+	out := new(rpcpb.RAMInfoResponse)
+	if err := g.invoke(ctx, "GetRAMInfo", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTxByHash ...
+func (g *gatewayClient) GetTxByHash(ctx context.Context, in *rpcpb.TxHashRequest, opts ...grpc.CallOption) (*rpcpb.TransactionResponse, error) {
+	out := new(rpcpb.TransactionResponse)
+	if err := g.invoke(ctx, "GetTxByHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTxReceiptByTxHash ...
+func (g *gatewayClient) GetTxReceiptByTxHash(ctx context.Context, in *rpcpb.TxHashRequest, opts ...grpc.CallOption) (*rpcpb.TxReceipt, error) {
+	out := new(rpcpb.TxReceipt)
+	if err := g.invoke(ctx, "GetTxReceiptByTxHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TraceTransaction ...
+func (g *gatewayClient) TraceTransaction(ctx context.Context, in *rpcpb.TxHashRequest, opts ...grpc.CallOption) (*rpcpb.TraceTransactionResponse, error) {
+	out := new(rpcpb.TraceTransactionResponse)
+	if err := g.invoke(ctx, "TraceTransaction", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTxsByAccount ...
+func (g *gatewayClient) GetTxsByAccount(ctx context.Context, in *rpcpb.GetTxsByAccountRequest, opts ...grpc.CallOption) (*rpcpb.GetTxsByAccountResponse, error) {
+	out := new(rpcpb.GetTxsByAccountResponse)
+	if err := g.invoke(ctx, "GetTxsByAccount", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccountTxs ...
+func (g *gatewayClient) GetAccountTxs(ctx context.Context, in *rpcpb.GetAccountTxsRequest, opts ...grpc.CallOption) (*rpcpb.GetAccountTxsResponse, error) {
+	out := new(rpcpb.GetAccountTxsResponse)
+	if err := g.invoke(ctx, "GetAccountTxs", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDelaytxsByAccount ...
+func (g *gatewayClient) GetDelaytxsByAccount(ctx context.Context, in *rpcpb.GetDelaytxsByAccountRequest, opts ...grpc.CallOption) (*rpcpb.GetDelaytxsByAccountResponse, error) {
+	out := new(rpcpb.GetDelaytxsByAccountResponse)
+	if err := g.invoke(ctx, "GetDelaytxsByAccount", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPendingTransactions ...
+func (g *gatewayClient) GetPendingTransactions(ctx context.Context, in *rpcpb.GetPendingTransactionsRequest, opts ...grpc.CallOption) (*rpcpb.GetPendingTransactionsResponse, error) {
+	out := new(rpcpb.GetPendingTransactionsResponse)
+	if err := g.invoke(ctx, "GetPendingTransactions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPendingTxByHash ...
+func (g *gatewayClient) GetPendingTxByHash(ctx context.Context, in *rpcpb.TxHashRequest, opts ...grpc.CallOption) (*rpcpb.TransactionResponse, error) {
+	out := new(rpcpb.TransactionResponse)
+	if err := g.invoke(ctx, "GetPendingTxByHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlockByHash ...
+func (g *gatewayClient) GetBlockByHash(ctx context.Context, in *rpcpb.GetBlockByHashRequest, opts ...grpc.CallOption) (*rpcpb.BlockResponse, error) {
+	out := new(rpcpb.BlockResponse)
+	if err := g.invoke(ctx, "GetBlockByHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlockByNumber ...
+func (g *gatewayClient) GetBlockByNumber(ctx context.Context, in *rpcpb.GetBlockByNumberRequest, opts ...grpc.CallOption) (*rpcpb.BlockResponse, error) {
+	out := new(rpcpb.BlockResponse)
+	if err := g.invoke(ctx, "GetBlockByNumber", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlockHeaderByHash ...
+func (g *gatewayClient) GetBlockHeaderByHash(ctx context.Context, in *rpcpb.GetBlockHeaderByHashRequest, opts ...grpc.CallOption) (*rpcpb.BlockHeaderResponse, error) {
+	out := new(rpcpb.BlockHeaderResponse)
+	if err := g.invoke(ctx, "GetBlockHeaderByHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlockHeaderByNumber ...
+func (g *gatewayClient) GetBlockHeaderByNumber(ctx context.Context, in *rpcpb.GetBlockHeaderByNumberRequest, opts ...grpc.CallOption) (*rpcpb.BlockHeaderResponse, error) {
+	out := new(rpcpb.BlockHeaderResponse)
+	if err := g.invoke(ctx, "GetBlockHeaderByNumber", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlocks ...
+func (g *gatewayClient) GetBlocks(ctx context.Context, in *rpcpb.GetBlocksRequest, opts ...grpc.CallOption) (*rpcpb.GetBlocksResponse, error) {
+	out := new(rpcpb.GetBlocksResponse)
+	if err := g.invoke(ctx, "GetBlocks", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlockStateChanges ...
+func (g *gatewayClient) GetBlockStateChanges(ctx context.Context, in *rpcpb.GetBlockStateChangesRequest, opts ...grpc.CallOption) (*rpcpb.GetBlockStateChangesResponse, error) {
+	out := new(rpcpb.GetBlockStateChangesResponse)
+	if err := g.invoke(ctx, "GetBlockStateChanges", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccount ...
+func (g *gatewayClient) GetAccount(ctx context.Context, in *rpcpb.GetAccountRequest, opts ...grpc.CallOption) (*rpcpb.Account, error) {
+	out := new(rpcpb.Account)
+	if err := g.invoke(ctx, "GetAccount", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTokenBalance ...
+func (g *gatewayClient) GetTokenBalance(ctx context.Context, in *rpcpb.GetTokenBalanceRequest, opts ...grpc.CallOption) (*rpcpb.GetTokenBalanceResponse, error) {
+	out := new(rpcpb.GetTokenBalanceResponse)
+	if err := g.invoke(ctx, "GetTokenBalance", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetToken721Balance ...
+func (g *gatewayClient) GetToken721Balance(ctx context.Context, in *rpcpb.GetToken721BalanceRequest, opts ...grpc.CallOption) (*rpcpb.GetToken721BalanceResponse, error) {
+	out := new(rpcpb.GetToken721BalanceResponse)
+	if err := g.invoke(ctx, "GetToken721Balance", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetToken721Metadata ...
+func (g *gatewayClient) GetToken721Metadata(ctx context.Context, in *rpcpb.GetToken721InfoRequest, opts ...grpc.CallOption) (*rpcpb.GetToken721MetadataResponse, error) {
+	out := new(rpcpb.GetToken721MetadataResponse)
+	if err := g.invoke(ctx, "GetToken721Metadata", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetToken721Owner ...
+func (g *gatewayClient) GetToken721Owner(ctx context.Context, in *rpcpb.GetToken721InfoRequest, opts ...grpc.CallOption) (*rpcpb.GetToken721OwnerResponse, error) {
+	out := new(rpcpb.GetToken721OwnerResponse)
+	if err := g.invoke(ctx, "GetToken721Owner", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccountTokens ...
+func (g *gatewayClient) GetAccountTokens(ctx context.Context, in *rpcpb.GetAccountTokensRequest, opts ...grpc.CallOption) (*rpcpb.GetAccountTokensResponse, error) {
+	out := new(rpcpb.GetAccountTokensResponse)
+	if err := g.invoke(ctx, "GetAccountTokens", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGasRatio ...
+func (g *gatewayClient) GetGasRatio(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.GasRatioResponse, error) {
+	out := new(rpcpb.GasRatioResponse)
+	if err := g.invoke(ctx, "GetGasRatio", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetProducerVoteInfo ...
+func (g *gatewayClient) GetProducerVoteInfo(ctx context.Context, in *rpcpb.GetProducerVoteInfoRequest, opts ...grpc.CallOption) (*rpcpb.GetProducerVoteInfoResponse, error) {
+	out := new(rpcpb.GetProducerVoteInfoResponse)
+	if err := g.invoke(ctx, "GetProducerVoteInfo", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetProducers ...
+func (g *gatewayClient) GetProducers(ctx context.Context, in *rpcpb.GetProducersRequest, opts ...grpc.CallOption) (*rpcpb.GetProducersResponse, error) {
+	out := new(rpcpb.GetProducersResponse)
+	if err := g.invoke(ctx, "GetProducers", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetVoters ...
+func (g *gatewayClient) GetVoters(ctx context.Context, in *rpcpb.GetVotersRequest, opts ...grpc.CallOption) (*rpcpb.GetVotersResponse, error) {
+	out := new(rpcpb.GetVotersResponse)
+	if err := g.invoke(ctx, "GetVoters", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWitnessSchedule ...
+func (g *gatewayClient) GetWitnessSchedule(ctx context.Context, in *rpcpb.GetWitnessScheduleRequest, opts ...grpc.CallOption) (*rpcpb.GetWitnessScheduleResponse, error) {
+	out := new(rpcpb.GetWitnessScheduleResponse)
+	if err := g.invoke(ctx, "GetWitnessSchedule", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetContract ...
+func (g *gatewayClient) GetContract(ctx context.Context, in *rpcpb.GetContractRequest, opts ...grpc.CallOption) (*rpcpb.Contract, error) {
+	out := new(rpcpb.Contract)
+	if err := g.invoke(ctx, "GetContract", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetContractStorage ...
+func (g *gatewayClient) GetContractStorage(ctx context.Context, in *rpcpb.GetContractStorageRequest, opts ...grpc.CallOption) (*rpcpb.GetContractStorageResponse, error) {
+	out := new(rpcpb.GetContractStorageResponse)
+	if err := g.invoke(ctx, "GetContractStorage", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBatchContractStorage ...
+func (g *gatewayClient) GetBatchContractStorage(ctx context.Context, in *rpcpb.GetBatchContractStorageRequest, opts ...grpc.CallOption) (*rpcpb.GetBatchContractStorageResponse, error) {
+	out := new(rpcpb.GetBatchContractStorageResponse)
+	if err := g.invoke(ctx, "GetBatchContractStorage", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetContractStorageFields ...
+func (g *gatewayClient) GetContractStorageFields(ctx context.Context, in *rpcpb.GetContractStorageFieldsRequest, opts ...grpc.CallOption) (*rpcpb.GetContractStorageFieldsResponse, error) {
+	out := new(rpcpb.GetContractStorageFieldsResponse)
+	if err := g.invoke(ctx, "GetContractStorageFields", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SendTransaction ...
+func (g *gatewayClient) SendTransaction(ctx context.Context, in *rpcpb.TransactionRequest, opts ...grpc.CallOption) (*rpcpb.SendTransactionResponse, error) {
+	out := new(rpcpb.SendTransactionResponse)
+	if err := g.invoke(ctx, "SendTransaction", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecTransaction ...
+func (g *gatewayClient) ExecTransaction(ctx context.Context, in *rpcpb.TransactionRequest, opts ...grpc.CallOption) (*rpcpb.TxReceipt, error) {
+	out := new(rpcpb.TxReceipt)
+	if err := g.invoke(ctx, "ExecTransaction", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,225 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gatewayNode is the api of a node behind a json gateway, set up like the one of the node.
+type gatewayNode struct {
+	rpcpb.ApiServiceClient
+
+	mu        sync.Mutex
+	chainInfo int
+	sent      []*rpcpb.TransactionRequest
+}
+
+func (n *gatewayNode) GetChainInfo(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (*rpcpb.ChainInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.chainInfo++; n.chainInfo == 1 {
+		return nil, status.Error(codes.Unavailable, "node busy")
+	}
+	return &rpcpb.ChainInfoResponse{NetName: "testnet", HeadBlock: 1 << 40, LibBlock: 2}, nil
+}
+
+func (n *gatewayNode) GetTxByHash(ctx context.Context, in *rpcpb.TxHashRequest, opts ...grpc.CallOption) (*rpcpb.TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unknown, "tx not found: %v", in.Hash)
+}
+
+func (n *gatewayNode) GetBlockByNumber(ctx context.Context, in *rpcpb.GetBlockByNumberRequest, opts ...grpc.CallOption) (*rpcpb.BlockResponse, error) {
+	ret := &rpcpb.Block{Number: in.Number}
+	for _, a := range in.Actions {
+		ret.Transactions = append(ret.Transactions, &rpcpb.Transaction{Actions: []*rpcpb.Action{{Contract: a}}})
+	}
+	return &rpcpb.BlockResponse{Block: ret}, nil
+}
+
+func (n *gatewayNode) GetTokenBalance(ctx context.Context, in *rpcpb.GetTokenBalanceRequest, opts ...grpc.CallOption) (*rpcpb.GetTokenBalanceResponse, error) {
+	ret := &rpcpb.GetTokenBalanceResponse{Balance: 1}
+	if in.IncludePending {
+		ret.Balance = 2
+	}
+	if in.BlockNumber != 0 {
+		ret.Balance = float64(in.BlockNumber)
+	}
+	return ret, nil
+}
+
+func (n *gatewayNode) GetBlockStateChanges(ctx context.Context, in *rpcpb.GetBlockStateChangesRequest, opts ...grpc.CallOption) (*rpcpb.GetBlockStateChangesResponse, error) {
+	return &rpcpb.GetBlockStateChangesResponse{Number: in.Number}, nil
+}
+
+func (n *gatewayNode) SendTransaction(ctx context.Context, in *rpcpb.TransactionRequest, opts ...grpc.CallOption) (*rpcpb.SendTransactionResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, in)
+	return &rpcpb.SendTransactionResponse{Hash: "hash"}, nil
+}
+
+func (n *gatewayNode) SubscribeChainStatus(ctx context.Context, in *rpcpb.EmptyRequest, opts ...grpc.CallOption) (rpcpb.ApiService_SubscribeChainStatusClient, error) {
+	return &chainStatusStream{ctx: ctx}, nil
+}
+
+type chainStatusStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	sent bool
+}
+
+func (s *chainStatusStream) Header() (metadata.MD, error) {
+	return nil, nil
+}
+
+func (s *chainStatusStream) Recv() (*rpcpb.ChainStatusResponse, error) {
+	if !s.sent {
+		s.sent = true
+		return &rpcpb.ChainStatusResponse{HeadBlock: 3, LibBlock: 2}, nil
+	}
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func newGatewayServer(t *testing.T, node rpcpb.ApiServiceClient) *httptest.Server {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		runtime.WithProtoErrorHandler(func(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+			w.WriteHeader(400)
+			bytes, _ := json.Marshal(err)
+			w.Write(bytes)
+		}))
+	assert.Nil(t, rpcpb.RegisterApiServiceHandlerClient(context.Background(), mux, node))
+	return httptest.NewServer(mux)
+}
+
+func TestGateway(t *testing.T) {
+	node := &gatewayNode{}
+	ts := newGatewayServer(t, node)
+	defer ts.Close()
+	s := NewIOSTDevSDK()
+	s.SetServer(ts.URL)
+	s.SetRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	var methods []string
+	s.AddUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		methods = append(methods, method)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+	ctx := context.Background()
+
+	// the busy node is retried
+	info, err := s.GetChainInfoCtx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testnet", info.NetName)
+	assert.Equal(t, int64(1<<40), info.HeadBlock)
+	assert.Equal(t, 2, node.chainInfo)
+
+	_, err = s.GetTxByHashCtx(ctx, "a b")
+	assert.True(t, errors.Is(err, ErrTxNotFound), fmt.Sprint(err))
+	assert.Contains(t, err.Error(), "tx not found: a b")
+
+	tx := &rpcpb.TransactionRequest{
+		Time:       1,
+		Expiration: 2,
+		GasLimit:   100000,
+		Actions:    []*rpcpb.Action{NewAction("token.iost", "transfer", `["iost","a","b","1",""]`)},
+	}
+	hash, err := s.SendTransactionCtx(ctx, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", hash)
+	assert.Equal(t, 1, len(node.sent))
+	assert.Equal(t, tx.String(), node.sent[0].String())
+	assert.Equal(t, []string{"/rpcpb.ApiService/GetChainInfo", "/rpcpb.ApiService/GetTxByHash", "/rpcpb.ApiService/SendTransaction"}, methods)
+
+	// the filter of the block is sent as query parameters
+	blk, err := s.GetFilteredBlockByNumCtx(ctx, 7, []string{"token.iost/transfer", "vote_producer.iost"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), blk.Block.Number)
+	if assert.Len(t, blk.Block.Transactions, 2) {
+		assert.Equal(t, "token.iost/transfer", blk.Block.Transactions[0].Actions[0].Contract)
+		assert.Equal(t, "vote_producer.iost", blk.Block.Transactions[1].Actions[0].Contract)
+	}
+
+	changes, err := s.GetBlockStateChangesCtx(ctx, 8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), changes.Number)
+
+	// the pending txs are included by a query parameter
+	balance, err := s.GetTokenBalanceCtx(ctx, "a", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), balance.Balance)
+	s.SetIncludePending(true)
+	balance, err = s.GetTokenBalanceCtx(ctx, "a", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), balance.Balance)
+	s.SetIncludePending(false)
+	s.SetBlockNumber(5)
+	balance, err = s.GetTokenBalanceCtx(ctx, "a", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), balance.Balance)
+
+	// the subscriptions are streamed too
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	ch := s.SubscribeNewBlocks(ctx, 1)
+	for i := int64(1); i <= 2; i++ {
+		select {
+		case b := <-ch:
+			assert.Equal(t, i, b.Number)
+		case <-ctx.Done():
+			t.Fatal("block not received")
+		}
+	}
+	cancel()
+}
+
+func TestGatewayError(t *testing.T) {
+	for _, c := range []struct {
+		status int
+		body   string
+		code   codes.Code
+	}{
+		{400, `{"code":8,"message":"tx pool is full"}`, codes.ResourceExhausted},
+		{400, `{"error":"bad request"}`, codes.Unknown},
+		{502, `<html>Bad Gateway</html>`, codes.Unavailable},
+		{429, ``, codes.ResourceExhausted},
+		{404, `not found`, codes.Unknown},
+	} {
+		w := httptest.NewRecorder()
+		w.WriteHeader(c.status)
+		w.WriteString(c.body)
+		assert.Equal(t, c.code, status.Code(gatewayError(w.Result())), c.body)
+	}
+}
+
+func TestGatewayAPIKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-Api-Key"))
+		w.Write([]byte(`{"net_name":"testnet"}`))
+	}))
+	defer ts.Close()
+	s := NewIOSTDevSDK()
+	s.SetServer(ts.URL)
+	s.SetAPIKey("secret")
+	info, err := s.GetChainInfoCtx(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "testnet", info.NetName)
+	assert.Equal(t, []string{"secret"}, keys)
+}
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+)
+
+// TxHooks are called around each tx sent by the sdk, to log, trace or change it. Any of them can be nil.
+type TxHooks struct {
+	// BeforeSign is called with the tx before it is signed, and may change it. An error aborts sending the tx.
+	BeforeSign func(ctx context.Context, tx *rpcpb.TransactionRequest) error
+	// AfterSign is called with the signed tx before it is sent. An error aborts sending the tx.
+	AfterSign func(ctx context.Context, tx *rpcpb.TransactionRequest) error
+	// AfterSend is called once the tx has been sent, with its hash or the error of sending it.
+	AfterSend func(ctx context.Context, tx *rpcpb.TransactionRequest, hash string, err error)
+}
+
+// AddUnaryInterceptor adds an interceptor of the calls made by the connections opened afterwards. Interceptors are
+// called in the order they are added, around the retries of a call so that each call is seen once, see
+// `RetryPolicy`. The calls the sdk makes itself, like health checks, go through them too.
+func (s *IOSTDevSDK) AddUnaryInterceptor(i grpc.UnaryClientInterceptor) {
+	s.unaryInterceptors = append(s.unaryInterceptors, i)
+}
+
+// AddStreamInterceptor adds an interceptor of the subscriptions made by the connections opened afterwards, called
+// in the order they are added.
+func (s *IOSTDevSDK) AddStreamInterceptor(i grpc.StreamClientInterceptor) {
+	s.streamInterceptors = append(s.streamInterceptors, i)
+}
+
+// AddTxHooks adds hooks called around each tx sent by `SendTx` and the calls built on it, in the order they are
+// added. Txs signed beforehand and sent by `SendTransaction` are not seen.
+func (s *IOSTDevSDK) AddTxHooks(h TxHooks) {
+	s.txHooks = append(s.txHooks, h)
+}
+
+// dialOptions returns the options of a connection, whose calls go through the interceptors of the application and
+// then the given interceptor if not nil.
+func (s *IOSTDevSDK) dialOptions(last grpc.UnaryClientInterceptor) []grpc.DialOption {
+	opts := s.connOptions.dialOptions()
+	if unary := s.unaryInterceptor(last); unary != nil {
+		opts = append(opts, grpc.WithUnaryInterceptor(unary))
+	}
+	if len(s.streamInterceptors) != 0 {
+		opts = append(opts, grpc.WithStreamInterceptor(chainStream(append([]grpc.StreamClientInterceptor{}, s.streamInterceptors...))))
+	}
+	return opts
+}
+
+// unaryInterceptor returns the interceptor calling those of the application and then the given one if not nil, or
+// nil if there is none.
+func (s *IOSTDevSDK) unaryInterceptor(last grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	unary := append([]grpc.UnaryClientInterceptor{}, s.unaryInterceptors...)
+	if last != nil {
+		unary = append(unary, last)
+	}
+	if len(unary) == 0 {
+		return nil
+	}
+	return chainUnary(unary)
+}
+
+// chainUnary makes an interceptor calling the interceptors in order, as grpc takes only one.
+func chainUnary(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		next := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inner, opts...)
+			}
+		}
+		return next(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// chainStream makes a stream interceptor calling the interceptors in order.
+func chainStream(interceptors []grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		next := streamer
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return interceptor(ctx, desc, cc, method, inner, opts...)
+			}
+		}
+		return next(ctx, desc, cc, method, opts...)
+	}
+}
+
+func (s *IOSTDevSDK) beforeSign(ctx context.Context, tx *rpcpb.TransactionRequest) error {
+	for _, h := range s.txHooks {
+		if h.BeforeSign != nil {
+			if err := h.BeforeSign(ctx, tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *IOSTDevSDK) afterSign(ctx context.Context, tx *rpcpb.TransactionRequest) error {
+	for _, h := range s.txHooks {
+		if h.AfterSign != nil {
+			if err := h.AfterSign(ctx, tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *IOSTDevSDK) afterSend(ctx context.Context, tx *rpcpb.TransactionRequest, hash string, err error) {
+	for _, h := range s.txHooks {
+		if h.AfterSend != nil {
+			h.AfterSend(ctx, tx, hash, err)
+		}
+	}
+}
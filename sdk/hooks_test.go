@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryInterceptors(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := NewIOSTDevSDK()
+	s.SetServer(n.addr)
+	s.SetRetryPolicy(testRetryPolicy)
+	var seen []string
+	intercept := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			seen = append(seen, name+" "+method)
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			seen = append(seen, name+" done")
+			return err
+		}
+	}
+	s.AddUnaryInterceptor(intercept("a"))
+	s.AddUnaryInterceptor(intercept("b"))
+	assert.Nil(t, s.Connect())
+	defer s.CloseConn()
+
+	// the interceptors are called in order, around the retries of the call
+	n.failNext(1, status.Error(codes.Unavailable, "down"))
+	_, err := s.GetChainInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n.callCount("GetChainInfo"))
+	assert.Equal(t, []string{
+		"a /rpcpb.ApiService/GetChainInfo",
+		"b /rpcpb.ApiService/GetChainInfo",
+		"b done",
+		"a done",
+	}, seen)
+}
+
+func TestTxHooks(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	s.SetAccount("alice", kp)
+	s.SetClockPolicy(ClockPolicy{})
+	s.SetCheckResult(false, 0, 0)
+
+	var seen []string
+	var sentHash string
+	s.AddTxHooks(TxHooks{
+		BeforeSign: func(ctx context.Context, tx *rpcpb.TransactionRequest) error {
+			seen = append(seen, "before sign")
+			tx.GasLimit = 200000
+			return nil
+		},
+		AfterSend: func(ctx context.Context, tx *rpcpb.TransactionRequest, hash string, err error) {
+			seen = append(seen, "after send")
+			sentHash = hash
+			assert.Nil(t, err)
+		},
+	})
+	s.AddTxHooks(TxHooks{
+		AfterSign: func(ctx context.Context, tx *rpcpb.TransactionRequest) error {
+			seen = append(seen, "after sign")
+			assert.Equal(t, float64(200000), tx.GasLimit)
+			assert.NotEmpty(t, tx.PublisherSigs)
+			return nil
+		},
+	})
+	hash, err := s.SendTx(newTestTx(1))
+	assert.Nil(t, err)
+	assert.Equal(t, hash, sentHash)
+	assert.Equal(t, []string{"before sign", "after sign", "after send"}, seen)
+
+	// an error of a hook aborts sending the tx
+	s.AddTxHooks(TxHooks{
+		AfterSign: func(ctx context.Context, tx *rpcpb.TransactionRequest) error {
+			return errors.New("rejected")
+		},
+	})
+	seen = nil
+	_, err = s.SendTx(newTestTx(2))
+	assert.EqualError(t, err, "rejected")
+	assert.Equal(t, []string{"before sign", "after sign"}, seen)
+	assert.Equal(t, 1, n.callCount("SendTransaction"))
+}
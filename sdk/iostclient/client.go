@@ -0,0 +1,95 @@
+// Package iostclient defines the operations of the sdk as interfaces, so that applications can depend on them
+// instead of *sdk.IOSTDevSDK and be unit tested against the fake of package clienttest:
+//
+//	func Pay(ctx context.Context, c iostclient.Client, to string, amount string) (string, error) {
+//		act := sdk.NewAction("token.iost", "transfer", fmt.Sprintf(`["iost", "me", "%v", "%v", ""]`, to, amount))
+//		return c.SendTxFromActionsCtx(ctx, []*rpcpb.Action{act})
+//	}
+//
+// The setters, the batches and the iterators stay on the sdk, as they configure it or return its own types.
+package iostclient
+
+import (
+	"context"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// Querier reads the chain and the node.
+type Querier interface {
+	GetNodeInfoCtx(ctx context.Context) (*rpcpb.NodeInfoResponse, error)
+	GetNodeStatusCtx(ctx context.Context) (*rpcpb.NodeStatusResponse, error)
+	GetChainInfoCtx(ctx context.Context) (*rpcpb.ChainInfoResponse, error)
+	CachedChainInfo(ctx context.Context) (*rpcpb.ChainInfoResponse, error)
+	GetRAMInfoCtx(ctx context.Context) (*rpcpb.RAMInfoResponse, error)
+	GetGasRatioCtx(ctx context.Context) (*rpcpb.GasRatioResponse, error)
+	CachedGasRatio(ctx context.Context) (*rpcpb.GasRatioResponse, error)
+	GetWitnessScheduleCtx(ctx context.Context, blockCount int64) (*rpcpb.GetWitnessScheduleResponse, error)
+
+	GetAccountInfoCtx(ctx context.Context, id string) (*rpcpb.Account, error)
+	GetTokenBalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetTokenBalanceResponse, error)
+	GetAccountTokensCtx(ctx context.Context, account string) (*rpcpb.GetAccountTokensResponse, error)
+	GetToken721BalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetToken721BalanceResponse, error)
+	GetToken721BalancePageCtx(ctx context.Context, r *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error)
+	GetToken721MetadataCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721MetadataResponse, error)
+	GetToken721OwnerCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721OwnerResponse, error)
+	TokenDecimalCtx(ctx context.Context, token string) (int, error)
+	TokenAmountCtx(ctx context.Context, amount string, token string) (sdk.Amount, error)
+	GetProducerVoteInfoCtx(ctx context.Context, r *rpcpb.GetProducerVoteInfoRequest) (*rpcpb.GetProducerVoteInfoResponse, error)
+	GetProducersCtx(ctx context.Context, r *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error)
+	GetVotersCtx(ctx context.Context, r *rpcpb.GetVotersRequest) (*rpcpb.GetVotersResponse, error)
+
+	GetContractCtx(ctx context.Context, id string) (*rpcpb.Contract, error)
+	GetContractStorageCtx(ctx context.Context, r *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error)
+	GetBatchContractStorageCtx(ctx context.Context, r *rpcpb.GetBatchContractStorageRequest) (*rpcpb.GetBatchContractStorageResponse, error)
+	GetContractStorageFieldsCtx(ctx context.Context, r *rpcpb.GetContractStorageFieldsRequest) (*rpcpb.GetContractStorageFieldsResponse, error)
+	CallReadOnlyCtx(ctx context.Context, contract string, abi string, args string) (*rpcpb.TxReceipt, error)
+
+	GetBlockByNumCtx(ctx context.Context, num int64, complete bool) (*rpcpb.BlockResponse, error)
+	GetBlocksCtx(ctx context.Context, start int64, end int64, complete bool) (*rpcpb.GetBlocksResponse, error)
+	GetBlockStateChangesCtx(ctx context.Context, number int64) (*rpcpb.GetBlockStateChangesResponse, error)
+	GetBlockByHashCtx(ctx context.Context, hash string, complete bool) (*rpcpb.BlockResponse, error)
+	GetBlockHeaderByNumCtx(ctx context.Context, num int64) (*rpcpb.BlockHeaderResponse, error)
+	GetBlockHeaderByHashCtx(ctx context.Context, hash string) (*rpcpb.BlockHeaderResponse, error)
+	GetTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error)
+	GetTxReceiptByTxHashCtx(ctx context.Context, txHashStr string) (*rpcpb.TxReceipt, error)
+	GetTxsByAccountCtx(ctx context.Context, account string, offset int32, limit int32) (*rpcpb.GetTxsByAccountResponse, error)
+	GetAccountTxsCtx(ctx context.Context, r *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error)
+	GetDelaytxsByAccountCtx(ctx context.Context, account string) (*rpcpb.GetDelaytxsByAccountResponse, error)
+	GetPendingTransactionsCtx(ctx context.Context, r *rpcpb.GetPendingTransactionsRequest) (*rpcpb.GetPendingTransactionsResponse, error)
+	GetPendingTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error)
+}
+
+// TxSender builds, sends and executes txs as the account of the client.
+type TxSender interface {
+	CreateTxFromActions(actions []*rpcpb.Action) (*rpcpb.TransactionRequest, error)
+	CheckChainIDCtx(ctx context.Context) error
+	SendTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (string, error)
+	SendTxFromActionsCtx(ctx context.Context, actions []*rpcpb.Action) (string, error)
+	SendTransactionCtx(ctx context.Context, signedTx *rpcpb.TransactionRequest) (string, error)
+	ExecTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error)
+	ExecTransactionCtx(ctx context.Context, t *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error)
+	WaitTxCtx(ctx context.Context, txHash string) (*rpcpb.TxReceipt, error)
+
+	PledgeForGasAndRAMCtx(ctx context.Context, gasPledged int64, ram int64) error
+	CreateNewAccountCtx(ctx context.Context, newID string, ownerKey string, activeKey string, initialGasPledge int64, initialRAM int64, initialCoins int64) (string, error)
+	PublishContractCtx(ctx context.Context, codePath string, abiPath string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, string, error)
+}
+
+// Subscriber follows the new blocks, txs and contract events, sent on channels closed once ctx is done.
+type Subscriber interface {
+	SubscribeNewBlocks(ctx context.Context, fromHeight int64) <-chan *rpcpb.Block
+	SubscribePendingTx(ctx context.Context) <-chan *rpcpb.Transaction
+	SubscribeContractEvents(ctx context.Context, filter *rpcpb.SubscribeRequest_Filter, topics ...rpcpb.Event_Topic) <-chan *rpcpb.Event
+	SubscribeEvents(ctx context.Context, f *sdk.Filter) (<-chan *rpcpb.Event, error)
+}
+
+// Client is all the operations of the sdk on a node, which *sdk.IOSTDevSDK implements.
+type Client interface {
+	Querier
+	TxSender
+	Subscriber
+}
+
+var _ Client = (*sdk.IOSTDevSDK)(nil)
@@ -0,0 +1,1322 @@
+// Package clienttest provides an in-memory fake of iostclient.Client, so that applications can be unit tested
+// without a node:
+//
+//	f := clienttest.New("alice")
+//	f.SetBalance("alice", "iost", "100")
+//	f.AddAccount("bob")
+//	hash, err := Pay(ctx, f, "bob", "1.5")
+//	// f.Balance("bob", "iost") is now "1.5", and f.GetTxReceiptByTxHashCtx(ctx, hash) its receipt
+//
+//	f.FailNext("SendTxFromActionsCtx", status.Error(codes.Unavailable, "node down"))
+//	_, err = Pay(ctx, f, "bob", "1.5") // fails with the error
+//
+// The txs are run like on a node: the actions call handlers in order, and a failing action reverts the tx, whose
+// receipt then gives the error. The token transfers, the account sign ups, the gas pledges, the ram buys and the
+// contract publishing done by the sdk are built in, and applications add handlers of their own contracts by
+// `Handle`. Each tx sent is packed at once in a new irreversible block. The clock of the fake starts at a fixed
+// time and moves on with each tx, so that the txs, their hashes and their results are the same on every run.
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/iost-official/go-iost/sdk/iostclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Witness is the producer of the blocks of the fake chain.
+	Witness = "clienttest"
+	// NetName is the network name the fake chain reports.
+	NetName = "clienttest"
+
+	startTime     = int64(1577836800) * int64(time.Second)
+	blockInterval = int64(500 * time.Millisecond)
+	txInterval    = int64(time.Millisecond)
+	// maxTxsByAccount is the most txs a node returns at once.
+	maxTxsByAccount = 100
+	// maxPendingTxs is the most pending txs a node returns at once.
+	maxPendingTxs = 100
+	// maxBatchContractStorage is the most keys of contract storage a node returns at once.
+	maxBatchContractStorage = 100
+	// maxToken721IDs is the most token ids of a token721 a node returns at once.
+	maxToken721IDs = 1000
+	// maxProducers and maxVoters are the most producer candidates and voters a node returns at once.
+	maxProducers = 100
+	maxVoters    = 1000
+	// the most blocks a node returns at once, less if complete
+	maxBlocksByRange         = 100
+	maxCompleteBlocksByRange = 20
+)
+
+// Fake is a fake node and the sdk connected to it as an account, which publishes all the txs. It is safe for
+// concurrent use.
+type Fake struct {
+	mu        sync.Mutex
+	publisher string
+	chainID   uint32
+	now       int64
+	// builder builds the txs of the calls the sdk builds itself, without any network call
+	builder *sdk.IOSTDevSDK
+
+	state    *State
+	handlers map[string]Handler
+	failures map[string][]error
+
+	nodeInfo *rpcpb.NodeInfoResponse
+	// nodeStatus is the status of the node, but for its blocks
+	nodeStatus *rpcpb.NodeStatusResponse
+	ramInfo    *rpcpb.RAMInfoResponse
+	gasRatio   *rpcpb.GasRatioResponse
+	producers  map[string]*rpcpb.GetProducerVoteInfoResponse
+	// votes are the votes of the voters by producer
+	votes map[string]map[string]float64
+
+	blocks []*rpcpb.Block
+	txs    map[string]*rpcpb.TransactionResponse
+	sent   []*rpcpb.Transaction
+	subs   []*subscriber
+	// stateChanges are the state keys changed by the blocks, by number
+	stateChanges map[int64][]*rpcpb.StateChange
+}
+
+var _ iostclient.Client = (*Fake)(nil)
+
+// New returns a fake chain of chain id 1024 where the publisher account exists, without any balance.
+func New(publisher string) *Fake {
+	f := &Fake{
+		publisher:  publisher,
+		chainID:    1024,
+		now:        startTime,
+		builder:    sdk.NewIOSTDevSDK(),
+		state:      newState(),
+		handlers:   make(map[string]Handler),
+		failures:   make(map[string][]error),
+		nodeInfo:   &rpcpb.NodeInfoResponse{Mode: "ModeNormal"},
+		nodeStatus: &rpcpb.NodeStatusResponse{Mode: "ModeNormal", DbOk: true, Ready: true},
+		ramInfo:    &rpcpb.RAMInfoResponse{},
+		gasRatio:   &rpcpb.GasRatioResponse{LowestGasRatio: 1, MedianGasRatio: 1},
+		producers:  make(map[string]*rpcpb.GetProducerVoteInfoResponse),
+		votes:      make(map[string]map[string]float64),
+		txs:        make(map[string]*rpcpb.TransactionResponse),
+
+		stateChanges: make(map[int64][]*rpcpb.StateChange),
+	}
+	f.builder.SetSigner(publisher, nil)
+	f.state.SetAccount(newAccount(publisher))
+	f.blocks = []*rpcpb.Block{{
+		Hash:    common.Base58Encode(common.Sha3([]byte("genesis"))),
+		Witness: Witness,
+		Time:    startTime,
+		Info:    &rpcpb.Block_Info{},
+	}}
+	return f
+}
+
+func newAccount(name string) *rpcpb.Account {
+	return &rpcpb.Account{
+		Name:        name,
+		GasInfo:     &rpcpb.Account_GasInfo{},
+		RamInfo:     &rpcpb.Account_RAMInfo{},
+		Permissions: map[string]*rpcpb.Account_Permission{},
+	}
+}
+
+/////////////////////////////////////// setup ///////////////////////////////////////
+
+// Publisher returns the account publishing the txs.
+func (f *Fake) Publisher() string {
+	return f.publisher
+}
+
+// SetChainID sets the chain id of the fake chain and of the txs built by the fake. Txs of another chain id are
+// rejected.
+func (f *Fake) SetChainID(chainID uint32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.chainID = chainID
+}
+
+// AddAccount creates the account, without any balance, unless it exists.
+func (f *Fake) AddAccount(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.state.Account(name) == nil {
+		f.state.SetAccount(newAccount(name))
+	}
+}
+
+// AddToken creates the token with the decimal places. The iost token exists with 8 decimal places.
+func (f *Fake) AddToken(token string, decimal int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.AddToken(token, decimal)
+}
+
+// SetBalance sets the balance of the token held by the account, creating the account if it does not exist and the
+// token with 8 decimal places if it does not exist. It panics if the amount is invalid, as it is meant for setting
+// up tests.
+func (f *Fake) SetBalance(account string, token string, amount string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	decimal, ok := f.state.TokenDecimal(token)
+	if !ok {
+		decimal = 8
+		f.state.AddToken(token, decimal)
+	}
+	a, err := sdk.NewAmount(amount, decimal)
+	if err != nil {
+		panic(fmt.Sprintf("clienttest: invalid balance of %v: %v", token, err))
+	}
+	if !f.state.holder(account) {
+		f.state.SetAccount(newAccount(account))
+	}
+	f.state.SetBalance(account, token, a)
+}
+
+// Balance returns the balance of the token held by the account, like "1.5".
+func (f *Fake) Balance(account string, token string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state.Balance(account, token).String()
+}
+
+// Update calls fn with the state of the chain, to read or change anything the other setters do not, like the
+// storage of a contract. fn must not call the fake.
+func (f *Fake) Update(fn func(st *State)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(f.state)
+}
+
+// Handle sets the handler of the abi of the contract, which is called to run the actions calling it, replacing the
+// built in handler if any. The actions of an existing contract without a handler succeed without doing anything.
+// Handlers are called with the fake locked, so they must not call it.
+func (f *Fake) Handle(contract string, abi string, h Handler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[contract+"/"+abi] = h
+}
+
+// FailNext makes the next calls of the method, named as in iostclient.Client like "SendTxCtx", fail with the errors
+// in order, before they do anything. Calls do not go through each other, so the errors of "SendTxCtx" are not
+// returned by "SendTxFromActionsCtx".
+func (f *Fake) FailNext(method string, errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[method] = append(f.failures[method], errs...)
+}
+
+// SetNodeInfo sets the node info returned by `GetNodeInfoCtx`.
+func (f *Fake) SetNodeInfo(info *rpcpb.NodeInfoResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodeInfo = info
+}
+
+// SetNodeStatus sets the node status returned by `GetNodeStatusCtx`, whose head and irreversible blocks are those of
+// the fake chain.
+func (f *Fake) SetNodeStatus(status *rpcpb.NodeStatusResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodeStatus = status
+}
+
+// SetRAMInfo sets the ram info returned by `GetRAMInfoCtx`.
+func (f *Fake) SetRAMInfo(info *rpcpb.RAMInfoResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ramInfo = info
+}
+
+// SetGasRatio sets the gas ratios returned by `GetGasRatioCtx`, which are 1 by default.
+func (f *Fake) SetGasRatio(ratio *rpcpb.GasRatioResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gasRatio = ratio
+}
+
+// SetProducerVoteInfo sets the producer info and votes of the account.
+func (f *Fake) SetProducerVoteInfo(account string, info *rpcpb.GetProducerVoteInfoResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.producers[account] = info
+}
+
+// SetVotes sets the votes of the voter for the producer, 0 removing the voter.
+func (f *Fake) SetVotes(voter string, producer string, votes float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.votes[producer] == nil {
+		f.votes[producer] = make(map[string]float64)
+	}
+	if votes == 0 {
+		delete(f.votes[producer], voter)
+		return
+	}
+	f.votes[producer][voter] = votes
+}
+
+// Sent returns the txs sent so far in order, with their hashes and receipts, including the failed ones.
+func (f *Fake) Sent() []*rpcpb.Transaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ret := make([]*rpcpb.Transaction, len(f.sent))
+	for i, t := range f.sent {
+		ret[i] = proto.Clone(t).(*rpcpb.Transaction)
+	}
+	return ret
+}
+
+// EmitEvent sends the event of the contract to the subscriptions of its topic, see `SubscribeContractEvents`.
+func (f *Fake) EmitEvent(contract string, topic rpcpb.Event_Topic, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcast(contractEvent{contract: contract, event: &rpcpb.Event{Topic: topic, Data: data, Time: f.now}})
+}
+
+// begin fails a call whose context is done or which is scripted to fail.
+func (f *Fake) begin(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if errs := f.failures[method]; len(errs) != 0 {
+		f.failures[method] = errs[1:]
+		return sdk.WrapError(errs[0])
+	}
+	return nil
+}
+
+// nodeError returns an error the way the sdk returns those of the node.
+func nodeError(format string, a ...interface{}) error {
+	return sdk.WrapError(status.Errorf(codes.Unknown, format, a...))
+}
+
+func (f *Fake) head() *rpcpb.Block {
+	return f.blocks[len(f.blocks)-1]
+}
+
+/////////////////////////////////////// queries ///////////////////////////////////////
+
+// GetNodeInfoCtx returns the node info set by `SetNodeInfo`.
+func (f *Fake) GetNodeInfoCtx(ctx context.Context) (*rpcpb.NodeInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetNodeInfoCtx"); err != nil {
+		return nil, err
+	}
+	return proto.Clone(f.nodeInfo).(*rpcpb.NodeInfoResponse), nil
+}
+
+// GetNodeStatusCtx returns the node status set by `SetNodeStatus`, at the head block.
+func (f *Fake) GetNodeStatusCtx(ctx context.Context) (*rpcpb.NodeStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetNodeStatusCtx"); err != nil {
+		return nil, err
+	}
+	ret := proto.Clone(f.nodeStatus).(*rpcpb.NodeStatusResponse)
+	head := f.head()
+	ret.HeadBlock = head.Number
+	ret.HeadBlockTime = head.Time
+	ret.LibBlock = head.Number
+	return ret, nil
+}
+
+// GetChainInfoCtx returns the chain info, whose head block is always irreversible.
+func (f *Fake) GetChainInfoCtx(ctx context.Context) (*rpcpb.ChainInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetChainInfoCtx"); err != nil {
+		return nil, err
+	}
+	return f.chainInfo(), nil
+}
+
+func (f *Fake) chainInfo() *rpcpb.ChainInfoResponse {
+	head := f.head()
+	return &rpcpb.ChainInfoResponse{
+		NetName:         NetName,
+		ProtocolVersion: "1.0",
+		ChainId:         f.chainID,
+		HeadBlock:       head.Number,
+		HeadBlockHash:   head.Hash,
+		LibBlock:        head.Number,
+		LibBlockHash:    head.Hash,
+		WitnessList:     []string{Witness},
+		LibWitnessList:  []string{Witness},
+	}
+}
+
+// CachedChainInfo returns the chain info, which the fake never caches.
+func (f *Fake) CachedChainInfo(ctx context.Context) (*rpcpb.ChainInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "CachedChainInfo"); err != nil {
+		return nil, err
+	}
+	return f.chainInfo(), nil
+}
+
+// GetRAMInfoCtx returns the ram info set by `SetRAMInfo`.
+func (f *Fake) GetRAMInfoCtx(ctx context.Context) (*rpcpb.RAMInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetRAMInfoCtx"); err != nil {
+		return nil, err
+	}
+	return proto.Clone(f.ramInfo).(*rpcpb.RAMInfoResponse), nil
+}
+
+// GetGasRatioCtx returns the gas ratios set by `SetGasRatio`.
+func (f *Fake) GetGasRatioCtx(ctx context.Context) (*rpcpb.GasRatioResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetGasRatioCtx"); err != nil {
+		return nil, err
+	}
+	return proto.Clone(f.gasRatio).(*rpcpb.GasRatioResponse), nil
+}
+
+// CachedGasRatio returns the gas ratios set by `SetGasRatio`.
+func (f *Fake) CachedGasRatio(ctx context.Context) (*rpcpb.GasRatioResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "CachedGasRatio"); err != nil {
+		return nil, err
+	}
+	return proto.Clone(f.gasRatio).(*rpcpb.GasRatioResponse), nil
+}
+
+// GetWitnessScheduleCtx returns the head block only, as the fake chain has no witness schedule.
+func (f *Fake) GetWitnessScheduleCtx(ctx context.Context, blockCount int64) (*rpcpb.GetWitnessScheduleResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetWitnessScheduleCtx"); err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetWitnessScheduleResponse{HeadBlock: f.head().Number}, nil
+}
+
+// GetAccountInfoCtx returns the account with its iost balance.
+func (f *Fake) GetAccountInfoCtx(ctx context.Context, id string) (*rpcpb.Account, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetAccountInfoCtx"); err != nil {
+		return nil, err
+	}
+	acc := f.state.Account(id)
+	if acc == nil {
+		return nil, nodeError("account not found")
+	}
+	ret := proto.Clone(acc).(*rpcpb.Account)
+	ret.Balance = f.state.Balance(id, "iost").Fixed().ToFloat()
+	return ret, nil
+}
+
+// GetTokenBalanceCtx returns the balance of the token held by the account or the contract.
+func (f *Fake) GetTokenBalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetTokenBalanceResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetTokenBalanceCtx"); err != nil {
+		return nil, err
+	}
+	if !f.state.holder(account) {
+		return nil, nodeError("account not found")
+	}
+	return &rpcpb.GetTokenBalanceResponse{Balance: f.state.Balance(account, token).Fixed().ToFloat()}, nil
+}
+
+// GetAccountTokensCtx returns the tokens held by the account, sorted by name.
+func (f *Fake) GetAccountTokensCtx(ctx context.Context, account string) (*rpcpb.GetAccountTokensResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetAccountTokensCtx"); err != nil {
+		return nil, err
+	}
+	if !f.state.holder(account) {
+		return nil, nodeError("account not found")
+	}
+	ret := &rpcpb.GetAccountTokensResponse{}
+	for _, token := range sortedKeys(f.state.balances[account]) {
+		if b := f.state.Balance(account, token); !b.IsZero() {
+			ret.Tokens = append(ret.Tokens, &rpcpb.GetAccountTokensResponse_Token{Token: token, Balance: b.Fixed().ToFloat()})
+		}
+	}
+	tokens := make([]string, 0, len(f.state.token721))
+	for token := range f.state.token721 {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	for _, token := range tokens {
+		if ids := f.state.token721Of(account, token); len(ids) != 0 {
+			ret.Token721S = append(ret.Token721S, &rpcpb.GetAccountTokensResponse_Token721{Token: token, Balance: int64(len(ids))})
+		}
+	}
+	return ret, nil
+}
+
+// GetToken721BalanceCtx returns the tokens of the token721 held by the account.
+func (f *Fake) GetToken721BalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetToken721BalanceResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetToken721BalanceCtx"); err != nil {
+		return nil, err
+	}
+	if !f.state.holder(account) {
+		return nil, nodeError("account not found")
+	}
+	return f.token721Balance(&rpcpb.GetToken721BalanceRequest{Account: account, Token: token})
+}
+
+// GetToken721BalancePageCtx returns a page of the tokens of the token721 held by the account.
+func (f *Fake) GetToken721BalancePageCtx(ctx context.Context, r *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetToken721BalancePageCtx"); err != nil {
+		return nil, err
+	}
+	if !f.state.holder(r.Account) {
+		return nil, nodeError("account not found")
+	}
+	return f.token721Balance(r)
+}
+
+func (f *Fake) token721Balance(r *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+	ids := f.state.token721Of(r.Account, r.Token)
+	page, cursor, err := fieldPage(ids, r.Limit, maxToken721IDs, r.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetToken721BalanceResponse{Balance: int64(len(ids)), TokenIDs: page, Cursor: cursor}, nil
+}
+
+// fieldPage returns the fields after the cursor, which is the last field of the previous page, sorted, at most limit
+// of them or max if 0, and the cursor of the next page.
+func fieldPage(fields []string, limit int32, max int32, cursor string) ([]string, string, error) {
+	if limit < 0 || limit > max {
+		return nil, "", nodeError("limit should be in [0, %v]", max)
+	}
+	if limit == 0 {
+		limit = max
+	}
+	fields = append([]string{}, fields...)
+	sort.Strings(fields)
+	fields = fields[sort.Search(len(fields), func(i int) bool { return fields[i] > cursor }):]
+	if len(fields) > int(limit) {
+		return fields[:limit], fields[limit-1], nil
+	}
+	return fields, "", nil
+}
+
+func (f *Fake) token721(token string, tokenID string) (token721, error) {
+	t, ok := f.state.token721[token][tokenID]
+	if !ok {
+		return token721{}, nodeError("token %v of %v not exists", tokenID, token)
+	}
+	return t, nil
+}
+
+// GetToken721MetadataCtx returns the metadata of a token of the token721.
+func (f *Fake) GetToken721MetadataCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721MetadataResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetToken721MetadataCtx"); err != nil {
+		return nil, err
+	}
+	t, err := f.token721(token, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetToken721MetadataResponse{Metadata: t.metadata}, nil
+}
+
+// GetToken721OwnerCtx returns the owner of a token of the token721.
+func (f *Fake) GetToken721OwnerCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721OwnerResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetToken721OwnerCtx"); err != nil {
+		return nil, err
+	}
+	t, err := f.token721(token, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetToken721OwnerResponse{Owner: t.owner}, nil
+}
+
+// TokenDecimalCtx returns the decimal places of the token.
+func (f *Fake) TokenDecimalCtx(ctx context.Context, token string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "TokenDecimalCtx"); err != nil {
+		return 0, err
+	}
+	return f.tokenDecimal(token)
+}
+
+func (f *Fake) tokenDecimal(token string) (int, error) {
+	d, ok := f.state.TokenDecimal(token)
+	if !ok {
+		return 0, fmt.Errorf("token %v does not exist", token)
+	}
+	return d, nil
+}
+
+// TokenAmountCtx parses a decimal number as an amount of the token.
+func (f *Fake) TokenAmountCtx(ctx context.Context, amount string, token string) (sdk.Amount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "TokenAmountCtx"); err != nil {
+		return sdk.Amount{}, err
+	}
+	d, err := f.tokenDecimal(token)
+	if err != nil {
+		return sdk.Amount{}, err
+	}
+	return sdk.NewAmount(amount, d)
+}
+
+// GetProducerVoteInfoCtx returns the producer info set by `SetProducerVoteInfo`.
+func (f *Fake) GetProducerVoteInfoCtx(ctx context.Context, r *rpcpb.GetProducerVoteInfoRequest) (*rpcpb.GetProducerVoteInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetProducerVoteInfoCtx"); err != nil {
+		return nil, err
+	}
+	info, ok := f.producers[r.Account]
+	if !ok {
+		return nil, nodeError("producer %v not exists", r.Account)
+	}
+	return proto.Clone(info).(*rpcpb.GetProducerVoteInfoResponse), nil
+}
+
+// GetProducersCtx returns a page of the producers set by `SetProducerVoteInfo`, the cursor being the last account.
+func (f *Fake) GetProducersCtx(ctx context.Context, r *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetProducersCtx"); err != nil {
+		return nil, err
+	}
+	accounts := make([]string, 0, len(f.producers))
+	for a := range f.producers {
+		accounts = append(accounts, a)
+	}
+	page, cursor, err := fieldPage(accounts, r.Limit, maxProducers, r.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	ret := &rpcpb.GetProducersResponse{Cursor: cursor}
+	for _, a := range page {
+		ret.Producers = append(ret.Producers, &rpcpb.GetProducersResponse_Producer{
+			Account: a,
+			Info:    proto.Clone(f.producers[a]).(*rpcpb.GetProducerVoteInfoResponse),
+		})
+	}
+	return ret, nil
+}
+
+// GetVotersCtx returns a page of the voters of the producer set by `SetVotes`, the cursor being the last account.
+func (f *Fake) GetVotersCtx(ctx context.Context, r *rpcpb.GetVotersRequest) (*rpcpb.GetVotersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetVotersCtx"); err != nil {
+		return nil, err
+	}
+	if r.Producer == "" {
+		return nil, nodeError("producer is empty")
+	}
+	if _, ok := f.producers[r.Producer]; !ok {
+		return nil, nodeError("can't get producer info")
+	}
+	voters := make([]string, 0, len(f.votes[r.Producer]))
+	for v := range f.votes[r.Producer] {
+		voters = append(voters, v)
+	}
+	page, cursor, err := fieldPage(voters, r.Limit, maxVoters, r.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	ret := &rpcpb.GetVotersResponse{Cursor: cursor}
+	for _, v := range page {
+		ret.Voters = append(ret.Voters, &rpcpb.GetVotersResponse_Voter{Account: v, Votes: f.votes[r.Producer][v]})
+	}
+	return ret, nil
+}
+
+// GetContractCtx returns the contract.
+func (f *Fake) GetContractCtx(ctx context.Context, id string) (*rpcpb.Contract, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetContractCtx"); err != nil {
+		return nil, err
+	}
+	c := f.state.Contract(id)
+	if c == nil {
+		return nil, nodeError("contract not found")
+	}
+	return proto.Clone(c).(*rpcpb.Contract), nil
+}
+
+// GetContractStorageCtx returns a value in the storage of the contract, "null" if not set like nodes do.
+func (f *Fake) GetContractStorageCtx(ctx context.Context, r *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetContractStorageCtx"); err != nil {
+		return nil, err
+	}
+	data, ok := f.state.Storage(r.Id, r.Key, r.Field)
+	if !ok {
+		data = "null"
+	}
+	head := f.head()
+	return &rpcpb.GetContractStorageResponse{Data: data, BlockHash: head.Hash, BlockNumber: head.Number}, nil
+}
+
+// GetBatchContractStorageCtx returns the values of the keys in the storage of the contracts, "null" for the ones not
+// set.
+func (f *Fake) GetBatchContractStorageCtx(ctx context.Context, r *rpcpb.GetBatchContractStorageRequest) (*rpcpb.GetBatchContractStorageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBatchContractStorageCtx"); err != nil {
+		return nil, err
+	}
+	if len(r.Queries) > maxBatchContractStorage {
+		return nil, nodeError("too many queries %v, the most are %v", len(r.Queries), maxBatchContractStorage)
+	}
+	head := f.head()
+	ret := &rpcpb.GetBatchContractStorageResponse{BlockHash: head.Hash, BlockNumber: head.Number}
+	for _, q := range r.Queries {
+		data, ok := f.state.Storage(q.Id, q.Key, q.Field)
+		if !ok {
+			data = "null"
+		}
+		ret.Data = append(ret.Data, data)
+	}
+	return ret, nil
+}
+
+// GetContractStorageFieldsCtx returns the fields of a map in the storage of the contract, sorted.
+func (f *Fake) GetContractStorageFieldsCtx(ctx context.Context, r *rpcpb.GetContractStorageFieldsRequest) (*rpcpb.GetContractStorageFieldsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetContractStorageFieldsCtx"); err != nil {
+		return nil, err
+	}
+	head := f.head()
+	return &rpcpb.GetContractStorageFieldsResponse{Fields: f.state.storageFields(r.Id, r.Key), BlockHash: head.Hash, BlockNumber: head.Number}, nil
+}
+
+// CallReadOnlyCtx runs the abi of the contract as the publisher without changing the state, and returns the receipt.
+func (f *Fake) CallReadOnlyCtx(ctx context.Context, contract string, abi string, args string) (*rpcpb.TxReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "CallReadOnlyCtx"); err != nil {
+		return nil, err
+	}
+	tx, err := f.newTx([]*rpcpb.Action{sdk.NewAction(contract, abi, args)})
+	if err != nil {
+		return nil, err
+	}
+	return f.exec(tx)
+}
+
+// GetBlockByNumCtx returns the block of the number, with its txs if complete.
+func (f *Fake) GetBlockByNumCtx(ctx context.Context, num int64, complete bool) (*rpcpb.BlockResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlockByNumCtx"); err != nil {
+		return nil, err
+	}
+	if num < 0 || num >= int64(len(f.blocks)) {
+		return nil, nodeError("block %v not found", num)
+	}
+	return blockResponse(f.blocks[num], complete), nil
+}
+
+// GetBlocksCtx returns the blocks of numbers from start to end, up to the head block and to the limit of a node.
+func (f *Fake) GetBlocksCtx(ctx context.Context, start int64, end int64, complete bool) (*rpcpb.GetBlocksResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlocksCtx"); err != nil {
+		return nil, err
+	}
+	if start < 0 || end < start {
+		return nil, nodeError("invalid range [%v, %v]", start, end)
+	}
+	limit := maxBlocksByRange
+	if complete {
+		limit = maxCompleteBlocksByRange
+	}
+	ret := &rpcpb.GetBlocksResponse{}
+	for n := start; n <= end && n < int64(len(f.blocks)); n++ {
+		if len(ret.Blocks) == limit {
+			ret.HasMore = true
+			break
+		}
+		ret.Blocks = append(ret.Blocks, blockResponse(f.blocks[n], complete))
+	}
+	return ret, nil
+}
+
+// GetBlockStateChangesCtx returns the state keys changed by the block of number, which the state setters of the fake
+// do not change.
+func (f *Fake) GetBlockStateChangesCtx(ctx context.Context, number int64) (*rpcpb.GetBlockStateChangesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlockStateChangesCtx"); err != nil {
+		return nil, err
+	}
+	if number < 0 || number >= int64(len(f.blocks)) {
+		return nil, nodeError("block %v not found", number)
+	}
+	ret := &rpcpb.GetBlockStateChangesResponse{Number: number, Hash: f.blocks[number].Hash}
+	for _, c := range f.stateChanges[number] {
+		ret.Changes = append(ret.Changes, proto.Clone(c).(*rpcpb.StateChange))
+	}
+	return ret, nil
+}
+
+// GetBlockByHashCtx returns the block of the hash, with its txs if complete.
+func (f *Fake) GetBlockByHashCtx(ctx context.Context, hash string, complete bool) (*rpcpb.BlockResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlockByHashCtx"); err != nil {
+		return nil, err
+	}
+	for _, b := range f.blocks {
+		if b.Hash == hash {
+			return blockResponse(b, complete), nil
+		}
+	}
+	return nil, nodeError("block %v not found", hash)
+}
+
+// GetBlockHeaderByNumCtx returns the header of the block of the number.
+func (f *Fake) GetBlockHeaderByNumCtx(ctx context.Context, num int64) (*rpcpb.BlockHeaderResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlockHeaderByNumCtx"); err != nil {
+		return nil, err
+	}
+	if num < 0 || num >= int64(len(f.blocks)) {
+		return nil, nodeError("block %v not found", num)
+	}
+	return blockHeaderResponse(f.blocks[num]), nil
+}
+
+// GetBlockHeaderByHashCtx returns the header of the block of the hash.
+func (f *Fake) GetBlockHeaderByHashCtx(ctx context.Context, hash string) (*rpcpb.BlockHeaderResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetBlockHeaderByHashCtx"); err != nil {
+		return nil, err
+	}
+	for _, b := range f.blocks {
+		if b.Hash == hash {
+			return blockHeaderResponse(b), nil
+		}
+	}
+	return nil, nodeError("block %v not found", hash)
+}
+
+func blockHeaderResponse(b *rpcpb.Block) *rpcpb.BlockHeaderResponse {
+	header := &rpcpb.BlockHeader{
+		Hash:                b.Hash,
+		Version:             b.Version,
+		ParentHash:          b.ParentHash,
+		TxMerkleHash:        b.TxMerkleHash,
+		TxReceiptMerkleHash: b.TxReceiptMerkleHash,
+		Number:              b.Number,
+		Witness:             b.Witness,
+		Time:                b.Time,
+		TxCount:             b.TxCount,
+	}
+	if b.Info != nil {
+		header.Info = proto.Clone(b.Info).(*rpcpb.Block_Info)
+	}
+	return &rpcpb.BlockHeaderResponse{Status: rpcpb.BlockResponse_IRREVERSIBLE, Header: header}
+}
+
+func blockResponse(b *rpcpb.Block, complete bool) *rpcpb.BlockResponse {
+	ret := proto.Clone(b).(*rpcpb.Block)
+	if !complete {
+		ret.Transactions = nil
+	}
+	return &rpcpb.BlockResponse{Status: rpcpb.BlockResponse_IRREVERSIBLE, Block: ret}
+}
+
+// GetTxByHashCtx returns the tx of the hash, which is irreversible once sent.
+func (f *Fake) GetTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetTxByHashCtx"); err != nil {
+		return nil, err
+	}
+	res, ok := f.txs[hash]
+	if !ok {
+		return nil, nodeError("tx not found")
+	}
+	return proto.Clone(res).(*rpcpb.TransactionResponse), nil
+}
+
+// GetTxReceiptByTxHashCtx returns the receipt of the tx of the hash.
+func (f *Fake) GetTxReceiptByTxHashCtx(ctx context.Context, txHashStr string) (*rpcpb.TxReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetTxReceiptByTxHashCtx"); err != nil {
+		return nil, err
+	}
+	res, ok := f.txs[txHashStr]
+	if !ok {
+		return nil, nodeError("txreceipt not found")
+	}
+	return proto.Clone(res.Transaction.TxReceipt).(*rpcpb.TxReceipt), nil
+}
+
+// GetTxsByAccountCtx returns the txs published or signed by the account, newest first.
+func (f *Fake) GetTxsByAccountCtx(ctx context.Context, account string, offset int32, limit int32) (*rpcpb.GetTxsByAccountResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetTxsByAccountCtx"); err != nil {
+		return nil, err
+	}
+	if account == "" {
+		return nil, nodeError("account is empty")
+	}
+	if offset < 0 {
+		return nil, nodeError("invalid offset %v", offset)
+	}
+	if limit <= 0 || limit > maxTxsByAccount {
+		return nil, nodeError("limit should be in (0, %v]", maxTxsByAccount)
+	}
+	var txs []*rpcpb.Transaction
+	for i := len(f.sent) - 1; i >= 0; i-- {
+		t := f.sent[i]
+		if signedBy(t.Publisher, t.Signers, account) {
+			txs = append(txs, t)
+		}
+	}
+	ret := &rpcpb.GetTxsByAccountResponse{}
+	for i := int(offset); i < len(txs) && i < int(offset+limit); i++ {
+		ret.Transactions = append(ret.Transactions, proto.Clone(txs[i]).(*rpcpb.Transaction))
+	}
+	ret.HasMore = int(offset+limit) < len(txs)
+	return ret, nil
+}
+
+// GetAccountTxsCtx returns a page of the txs published or signed by the account, newest first, the cursor being the
+// number of the block of the last tx as each tx is packed in its own block.
+func (f *Fake) GetAccountTxsCtx(ctx context.Context, r *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetAccountTxsCtx"); err != nil {
+		return nil, err
+	}
+	if r.Account == "" {
+		return nil, nodeError("account is empty")
+	}
+	if r.FromHeight < 0 {
+		return nil, nodeError("invalid from_height %v", r.FromHeight)
+	}
+	if r.Limit <= 0 || r.Limit > maxTxsByAccount {
+		return nil, nodeError("limit should be in (0, %v]", maxTxsByAccount)
+	}
+	before := int64(math.MaxInt64)
+	if r.FromHeight > 0 {
+		before = r.FromHeight + 1
+	}
+	if r.Cursor != "" {
+		n, err := strconv.ParseInt(r.Cursor, 10, 64)
+		if err != nil {
+			return nil, nodeError("invalid cursor")
+		}
+		before = n
+	}
+	ret := &rpcpb.GetAccountTxsResponse{}
+	for i := len(f.sent) - 1; i >= 0; i-- {
+		t := f.sent[i]
+		res := f.txs[t.Hash]
+		if res.BlockNumber >= before || !signedBy(t.Publisher, t.Signers, r.Account) {
+			continue
+		}
+		if len(ret.Transactions) == int(r.Limit) {
+			ret.Cursor = strconv.FormatInt(ret.Transactions[r.Limit-1].BlockNumber, 10)
+			break
+		}
+		ret.Transactions = append(ret.Transactions, proto.Clone(res).(*rpcpb.TransactionResponse))
+	}
+	return ret, nil
+}
+
+// GetDelaytxsByAccountCtx returns no tx, as the fake chain runs the delayed txs at once.
+func (f *Fake) GetDelaytxsByAccountCtx(ctx context.Context, account string) (*rpcpb.GetDelaytxsByAccountResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetDelaytxsByAccountCtx"); err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetDelaytxsByAccountResponse{}, nil
+}
+
+// GetPendingTransactionsCtx returns no tx, as the fake chain packs the txs at once.
+func (f *Fake) GetPendingTransactionsCtx(ctx context.Context, r *rpcpb.GetPendingTransactionsRequest) (*rpcpb.GetPendingTransactionsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetPendingTransactionsCtx"); err != nil {
+		return nil, err
+	}
+	if r.Offset < 0 {
+		return nil, nodeError("invalid offset %v", r.Offset)
+	}
+	if r.Limit <= 0 || r.Limit > maxPendingTxs {
+		return nil, nodeError("limit should be in (0, %v]", maxPendingTxs)
+	}
+	return &rpcpb.GetPendingTransactionsResponse{}, nil
+}
+
+// GetPendingTxByHashCtx finds no tx, as the fake chain packs the txs at once.
+func (f *Fake) GetPendingTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "GetPendingTxByHashCtx"); err != nil {
+		return nil, err
+	}
+	return nil, nodeError("tx not found")
+}
+
+/////////////////////////////////////// txs ///////////////////////////////////////
+
+// CreateTxFromActions builds a tx of the actions at the time of the clock of the fake, like the sdk does with its
+// defaults.
+func (f *Fake) CreateTxFromActions(actions []*rpcpb.Action) (*rpcpb.TransactionRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.newTx(actions)
+}
+
+func (f *Fake) newTx(actions []*rpcpb.Action) (*rpcpb.TransactionRequest, error) {
+	f.now += txInterval
+	return sdk.BuildUnsignedTx(sdk.TxParams{
+		ChainID:     f.chainID,
+		Time:        f.now,
+		Expiration:  f.now + int64(5*time.Minute),
+		GasLimit:    1000000,
+		GasRatio:    1,
+		AmountLimit: []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
+	}, actions...)
+}
+
+// CheckChainIDCtx succeeds, as the fake is the node.
+func (f *Fake) CheckChainIDCtx(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.begin(ctx, "CheckChainIDCtx")
+}
+
+// SendTxCtx sends the tx as the publisher and returns its hash, or a *sdk.ReceiptError if it failed on chain like
+// the sdk checking the result does.
+func (f *Fake) SendTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "SendTxCtx"); err != nil {
+		return "", err
+	}
+	return f.send(tx, true)
+}
+
+// SendTxFromActionsCtx sends a tx of the actions, see `SendTxCtx`.
+func (f *Fake) SendTxFromActionsCtx(ctx context.Context, actions []*rpcpb.Action) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "SendTxFromActionsCtx"); err != nil {
+		return "", err
+	}
+	tx, err := f.newTx(actions)
+	if err != nil {
+		return "", err
+	}
+	return f.send(tx, true)
+}
+
+// SendTransactionCtx sends the tx as is, as the publisher if it has none, and returns its hash without checking
+// the result. Signatures are not checked.
+func (f *Fake) SendTransactionCtx(ctx context.Context, signedTx *rpcpb.TransactionRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "SendTransactionCtx"); err != nil {
+		return "", err
+	}
+	return f.send(signedTx, false)
+}
+
+// ExecTxCtx runs the tx as the publisher without changing the state, and returns the receipt.
+func (f *Fake) ExecTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "ExecTxCtx"); err != nil {
+		return nil, err
+	}
+	return f.exec(tx)
+}
+
+// ExecTransactionCtx runs the tx as is without changing the state, and returns the receipt.
+func (f *Fake) ExecTransactionCtx(ctx context.Context, t *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "ExecTransactionCtx"); err != nil {
+		return nil, err
+	}
+	return f.exec(t)
+}
+
+// WaitTxCtx returns the receipt of the tx, which is irreversible once sent.
+func (f *Fake) WaitTxCtx(ctx context.Context, txHash string) (*rpcpb.TxReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "WaitTxCtx"); err != nil {
+		return nil, err
+	}
+	res, ok := f.txs[txHash]
+	if !ok {
+		return nil, fmt.Errorf("transaction not found after %v", sdk.DefaultWaitTimeout)
+	}
+	return proto.Clone(res.Transaction.TxReceipt).(*rpcpb.TxReceipt), nil
+}
+
+// PledgeForGasAndRAMCtx pledges iost for gas and buys ram for the publisher, see `SendTxCtx`.
+func (f *Fake) PledgeForGasAndRAMCtx(ctx context.Context, gasPledged int64, ram int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "PledgeForGasAndRAMCtx"); err != nil {
+		return err
+	}
+	acts := []*rpcpb.Action{sdk.NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, f.publisher, f.publisher, gasPledged))}
+	if ram > 0 {
+		acts = append(acts, sdk.NewAction("ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, f.publisher, f.publisher, ram)))
+	}
+	tx, err := f.newTx(acts)
+	if err != nil {
+		return err
+	}
+	_, err = f.send(tx, true)
+	return err
+}
+
+// CreateNewAccountCtx signs up an account paid by the publisher, see `SendTxCtx`.
+func (f *Fake) CreateNewAccountCtx(ctx context.Context, newID string, ownerKey string, activeKey string, initialGasPledge int64, initialRAM int64, initialCoins int64) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "CreateNewAccountCtx"); err != nil {
+		return "", err
+	}
+	t, err := f.builder.CreateNewAccountTx(newID, ownerKey, activeKey, initialGasPledge, initialRAM, initialCoins)
+	if err != nil {
+		return "", err
+	}
+	tx, err := f.newTx(t.Actions)
+	if err != nil {
+		return "", err
+	}
+	return f.send(tx, true)
+}
+
+// PublishContractCtx publishes the contract of the files, see `SendTxCtx`. The id of a new contract is returned by
+// its receipt.
+func (f *Fake) PublishContractCtx(ctx context.Context, codePath string, abiPath string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin(ctx, "PublishContractCtx"); err != nil {
+		return nil, "", err
+	}
+	t, err := f.builder.CreatePublishContractTx(codePath, abiPath, conID, update, updateID)
+	if err != nil {
+		return nil, "", err
+	}
+	tx, err := f.newTx(t.Actions)
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := f.send(tx, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return tx, hash, nil
+}
+
+// prepare copies the tx to run, as the publisher if it has none, and returns it with its hash.
+func (f *Fake) prepare(tx *rpcpb.TransactionRequest) (*rpcpb.TransactionRequest, string, error) {
+	t := proto.Clone(tx).(*rpcpb.TransactionRequest)
+	if t.Publisher == "" {
+		t.Publisher = f.publisher
+	}
+	if t.ChainId != f.chainID {
+		return nil, "", nodeError("invalid chain_id %v, the chain id is %v", t.ChainId, f.chainID)
+	}
+	if len(t.Actions) == 0 {
+		return nil, "", nodeError("tx has no action")
+	}
+	return t, common.Base58Encode(sdk.TxHash(t)), nil
+}
+
+func (f *Fake) exec(tx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	t, hash, err := f.prepare(tx)
+	if err != nil {
+		return nil, err
+	}
+	_, receipt := f.run(t, hash)
+	return receipt, nil
+}
+
+// send runs the tx and packs it in a new block, returning a *sdk.ReceiptError if it failed and checkResult is set.
+func (f *Fake) send(tx *rpcpb.TransactionRequest, checkResult bool) (string, error) {
+	t, hash, err := f.prepare(tx)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := f.txs[hash]; ok {
+		return "", nodeError("tx exists in chain")
+	}
+	st, receipt := f.run(t, hash)
+	var changes []*rpcpb.StateChange
+	if receipt.StatusCode == rpcpb.TxReceipt_SUCCESS {
+		changes = st.changes(f.state)
+		f.state = st
+	}
+	f.pack(t, hash, receipt, changes)
+	if checkResult && receipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
+		return hash, &sdk.ReceiptError{Receipt: proto.Clone(receipt).(*rpcpb.TxReceipt)}
+	}
+	return hash, nil
+}
+
+// run runs the actions of the tx in order on a copy of the state, which is returned with the receipt. The copy is
+// to be dropped if the tx failed.
+func (f *Fake) run(t *rpcpb.TransactionRequest, hash string) (*State, *rpcpb.TxReceipt) {
+	st := f.state.clone()
+	receipt := &rpcpb.TxReceipt{TxHash: hash, StatusCode: rpcpb.TxReceipt_SUCCESS, RamUsage: map[string]int64{}}
+	for _, act := range t.Actions {
+		ret, err := f.call(st, t, act)
+		if err != nil {
+			receipt.StatusCode = statusOf(err)
+			receipt.Message = err.Error()
+			receipt.Receipts = nil
+			return st, receipt
+		}
+		if ret == "" {
+			ret = "[]"
+		}
+		receipt.Returns = append(receipt.Returns, ret)
+		receipt.Receipts = append(receipt.Receipts, &rpcpb.TxReceipt_Receipt{FuncName: act.Contract + "/" + act.ActionName, Content: act.Data})
+	}
+	return st, receipt
+}
+
+func (f *Fake) call(st *State, t *rpcpb.TransactionRequest, act *rpcpb.Action) (string, error) {
+	c := st.Contract(act.Contract)
+	if c == nil {
+		return "", fmt.Errorf("contract not exists: %v", act.Contract)
+	}
+	if len(c.Abis) != 0 && !hasABI(c, act.ActionName) {
+		return "", fmt.Errorf("abi not found: %v of %v", act.ActionName, act.Contract)
+	}
+	dec := json.NewDecoder(strings.NewReader(act.Data))
+	dec.UseNumber()
+	var args []interface{}
+	if err := dec.Decode(&args); err != nil {
+		return "", fmt.Errorf("wrong parameter: invalid args %v: %v", act.Data, err)
+	}
+	key := act.Contract + "/" + act.ActionName
+	h, ok := f.handlers[key]
+	if !ok {
+		h = builtinHandlers[key]
+	}
+	if h == nil {
+		return "", nil
+	}
+	return h(st, &Call{Tx: t, Action: act, Args: args})
+}
+
+func hasABI(c *rpcpb.Contract, name string) bool {
+	for _, abi := range c.Abis {
+		if abi.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// statusOf returns the status of a tx failing with the error, by its message like `sdk.CodeOf` does.
+func statusOf(err error) rpcpb.TxReceipt_StatusCode {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "balance not enough"):
+		return rpcpb.TxReceipt_BALANCE_NOT_ENOUGH
+	case strings.Contains(msg, "wrong parameter"):
+		return rpcpb.TxReceipt_WRONG_PARAMETER
+	}
+	return rpcpb.TxReceipt_RUNTIME_ERROR
+}
+
+// pack packs the tx in a new irreversible block changing the state keys, and sends the tx, the block and the
+// receipts of the tx to the subscriptions.
+func (f *Fake) pack(t *rpcpb.TransactionRequest, hash string, receipt *rpcpb.TxReceipt, changes []*rpcpb.StateChange) {
+	parent := f.head()
+	f.now += blockInterval
+	tx := &rpcpb.Transaction{
+		Hash:        hash,
+		Time:        t.Time,
+		Expiration:  t.Expiration,
+		GasRatio:    t.GasRatio,
+		GasLimit:    t.GasLimit,
+		Delay:       t.Delay,
+		ChainId:     t.ChainId,
+		Actions:     t.Actions,
+		Signers:     t.Signers,
+		Publisher:   t.Publisher,
+		AmountLimit: t.AmountLimit,
+		TxReceipt:   receipt,
+	}
+	block := &rpcpb.Block{
+		Hash:         common.Base58Encode(common.Sha3([]byte(parent.Hash + hash))),
+		ParentHash:   parent.Hash,
+		Number:       parent.Number + 1,
+		Witness:      Witness,
+		Time:         f.now,
+		TxCount:      1,
+		Info:         &rpcpb.Block_Info{},
+		Transactions: []*rpcpb.Transaction{tx},
+	}
+	f.blocks = append(f.blocks, block)
+	f.stateChanges[block.Number] = changes
+	f.txs[hash] = &rpcpb.TransactionResponse{Status: rpcpb.TransactionResponse_IRREVERSIBLE, Transaction: tx, BlockNumber: block.Number}
+	f.sent = append(f.sent, tx)
+
+	f.broadcast(tx)
+	f.broadcast(block)
+	for _, r := range receipt.Receipts {
+		contract := strings.SplitN(r.FuncName, "/", 2)[0]
+		f.broadcast(contractEvent{contract: contract, event: &rpcpb.Event{Topic: rpcpb.Event_CONTRACT_RECEIPT, Data: r.Content, Time: f.now}})
+	}
+}
+
+func sortedKeys(m map[string]sdk.Amount) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
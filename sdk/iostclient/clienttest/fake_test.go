@@ -0,0 +1,376 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/iost-official/go-iost/sdk/iostclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func pay(ctx context.Context, c iostclient.Client, from string, to string, amount string) (string, error) {
+	act := sdk.NewAction("token.iost", "transfer", fmt.Sprintf(`["iost", "%v", "%v", "%v", ""]`, from, to, amount))
+	return c.SendTxFromActionsCtx(ctx, []*rpcpb.Action{act})
+}
+
+func TestTransfer(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "100")
+	f.AddAccount("bob")
+
+	hash, err := pay(ctx, f, "alice", "bob", "1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "98.5", f.Balance("alice", "iost"))
+	assert.Equal(t, "1.5", f.Balance("bob", "iost"))
+	receipt, err := f.GetTxReceiptByTxHashCtx(ctx, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.TxReceipt_SUCCESS, receipt.StatusCode)
+	assert.Equal(t, "token.iost/transfer", receipt.Receipts[0].FuncName)
+	balance, err := f.GetTokenBalanceCtx(ctx, "bob", "iost")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, balance.Balance)
+
+	info, err := f.GetChainInfoCtx(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), info.HeadBlock)
+	blocks, err := f.GetBlocksCtx(ctx, 0, 10, true)
+	assert.Nil(t, err)
+	assert.Len(t, blocks.Blocks, 2)
+	assert.Len(t, blocks.Blocks[1].Block.Transactions, 1)
+	assert.False(t, blocks.HasMore)
+	header, err := f.GetBlockHeaderByHashCtx(ctx, blocks.Blocks[1].Block.Hash)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), header.Header.Number)
+	assert.Equal(t, int64(1), header.Header.TxCount)
+	assert.Equal(t, blocks.Blocks[0].Block.Hash, header.Header.ParentHash)
+	_, err = f.GetBlockHeaderByNumCtx(ctx, 2)
+	assert.NotNil(t, err)
+	res, err := f.GetTxByHashCtx(ctx, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.TransactionResponse_IRREVERSIBLE, res.Status)
+	assert.Equal(t, "alice", res.Transaction.Publisher)
+
+	txs, err := f.GetTxsByAccountCtx(ctx, "alice", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, txs.Transactions, 1)
+	assert.False(t, txs.HasMore)
+
+	changes, err := f.GetBlockStateChangesCtx(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks.Blocks[1].Block.Hash, changes.Hash)
+	assert.Equal(t, []*rpcpb.StateChange{
+		{Kind: rpcpb.StateChange_TOKEN_BALANCE, Contract: "token.iost", Key: "m-token.iost-TBalice-iost", Value: "9850000000"},
+		{Kind: rpcpb.StateChange_TOKEN_BALANCE, Contract: "token.iost", Key: "m-token.iost-TBbob-iost", Value: "150000000"},
+	}, changes.Changes)
+	_, err = f.GetBlockStateChangesCtx(ctx, 2)
+	assert.NotNil(t, err)
+}
+
+func TestFailedTx(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "1")
+	f.AddAccount("bob")
+
+	act := sdk.NewAction("token.iost", "transfer", `["iost", "alice", "bob", "0.5", ""]`)
+	hash, err := f.SendTxFromActionsCtx(ctx, []*rpcpb.Action{act, act, act})
+	var re *sdk.ReceiptError
+	assert.True(t, errors.As(err, &re))
+	assert.Equal(t, sdk.ErrInsufficientBalance, sdk.CodeOf(err))
+	assert.True(t, errors.Is(err, sdk.ErrInsufficientBalance))
+	assert.Equal(t, rpcpb.TxReceipt_BALANCE_NOT_ENOUGH, re.Receipt.StatusCode)
+	assert.Equal(t, "1", f.Balance("alice", "iost"))
+	assert.Equal(t, "0", f.Balance("bob", "iost"))
+	assert.Len(t, f.Sent(), 1)
+
+	_, err = f.SendTransactionCtx(ctx, &rpcpb.TransactionRequest{ChainId: 1024, Actions: []*rpcpb.Action{act}})
+	assert.Nil(t, err)
+	_, err = f.SendTransactionCtx(ctx, &rpcpb.TransactionRequest{ChainId: 1024, Actions: []*rpcpb.Action{act}})
+	assert.Equal(t, sdk.ErrDuplicateTx, sdk.CodeOf(err))
+	assert.True(t, errors.Is(err, sdk.ErrDuplicateTx))
+	_, err = f.SendTransactionCtx(ctx, &rpcpb.TransactionRequest{ChainId: 1, Actions: []*rpcpb.Action{act}})
+	assert.Equal(t, sdk.ErrInvalidChainID, sdk.CodeOf(err))
+
+	_, err = pay(ctx, f, "bob", "alice", "0.1")
+	assert.Equal(t, sdk.ErrNoPermission, sdk.CodeOf(err))
+	_, err = f.WaitTxCtx(ctx, hash)
+	assert.Nil(t, err)
+}
+
+func TestGetAccountTxs(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "10")
+	f.AddAccount("bob")
+	var hashes []string
+	for i := 0; i < 3; i++ {
+		hash, err := pay(ctx, f, "alice", "bob", "1")
+		assert.Nil(t, err)
+		hashes = append([]string{hash}, hashes...)
+	}
+	txHashes := func(res *rpcpb.GetAccountTxsResponse) (h []string) {
+		for _, t := range res.Transactions {
+			h = append(h, t.Transaction.Hash)
+		}
+		return
+	}
+
+	res, err := f.GetAccountTxsCtx(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[:2], txHashes(res))
+	assert.NotEmpty(t, res.Cursor)
+	res, err = f.GetAccountTxsCtx(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 2, Cursor: res.Cursor})
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[2:], txHashes(res))
+	assert.Empty(t, res.Cursor)
+
+	res, err = f.GetAccountTxsCtx(ctx, &rpcpb.GetAccountTxsRequest{Account: "alice", Limit: 10, FromHeight: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[1:], txHashes(res))
+}
+
+func TestPages(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.Update(func(st *State) {
+		for _, id := range []string{"2", "0", "1"} {
+			st.SetToken721("kitty", id, "alice", "")
+		}
+	})
+	res, err := f.GetToken721BalancePageCtx(ctx, &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.Balance)
+	assert.Equal(t, []string{"0", "1"}, res.TokenIDs)
+	res, err = f.GetToken721BalancePageCtx(ctx, &rpcpb.GetToken721BalanceRequest{Account: "alice", Token: "kitty", Limit: 2, Cursor: res.Cursor})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, res.TokenIDs)
+	assert.Empty(t, res.Cursor)
+
+	f.SetProducerVoteInfo("bob", &rpcpb.GetProducerVoteInfoResponse{Votes: 3})
+	f.SetProducerVoteInfo("carol", &rpcpb.GetProducerVoteInfoResponse{})
+	f.SetVotes("alice", "bob", 1)
+	f.SetVotes("dave", "bob", 2)
+	producers, err := f.GetProducersCtx(ctx, &rpcpb.GetProducersRequest{Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", producers.Producers[0].Account)
+	producers, err = f.GetProducersCtx(ctx, &rpcpb.GetProducersRequest{Cursor: producers.Cursor})
+	assert.Nil(t, err)
+	assert.Equal(t, "carol", producers.Producers[0].Account)
+	assert.Empty(t, producers.Cursor)
+	voters, err := f.GetVotersCtx(ctx, &rpcpb.GetVotersRequest{Producer: "bob"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*rpcpb.GetVotersResponse_Voter{{Account: "alice", Votes: 1}, {Account: "dave", Votes: 2}}, voters.Voters)
+	_, err = f.GetVotersCtx(ctx, &rpcpb.GetVotersRequest{Producer: "erin"})
+	assert.NotNil(t, err)
+}
+
+func TestFailNext(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "10")
+	down := errors.New("node down")
+	f.FailNext("SendTxFromActionsCtx", down, down)
+
+	_, err := pay(ctx, f, "alice", "gas.iost", "1")
+	assert.Equal(t, down, err)
+	_, err = pay(ctx, f, "alice", "gas.iost", "1")
+	assert.Equal(t, down, err)
+	_, err = pay(ctx, f, "alice", "gas.iost", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "9", f.Balance("alice", "iost"))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = f.GetAccountInfoCtx(cancelled, "alice")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDeterministic(t *testing.T) {
+	run := func() []string {
+		f := New("alice")
+		f.SetBalance("alice", "iost", "10")
+		f.AddAccount("bob")
+		var hashes []string
+		for i := 0; i < 3; i++ {
+			hash, err := pay(context.Background(), f, "alice", "bob", "1")
+			assert.Nil(t, err)
+			hashes = append(hashes, hash)
+		}
+		info, _ := f.GetChainInfoCtx(context.Background())
+		return append(hashes, info.HeadBlockHash)
+	}
+	assert.Equal(t, run(), run())
+}
+
+func TestHandlers(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.Update(func(st *State) {
+		st.SetContract(&rpcpb.Contract{Id: "counter", Abis: []*rpcpb.Contract_ABI{{Name: "add"}, {Name: "get"}}})
+	})
+	f.Handle("counter", "add", func(st *State, c *Call) (string, error) {
+		n, err := c.Arg(0)
+		if err != nil {
+			return "", err
+		}
+		st.SetStorage("counter", "n", "", n)
+		return "", nil
+	})
+	f.Handle("counter", "get", func(st *State, c *Call) (string, error) {
+		n, _ := st.Storage("counter", "n", "")
+		return fmt.Sprintf(`["%v"]`, n), nil
+	})
+
+	_, err := f.SendTxFromActionsCtx(ctx, []*rpcpb.Action{sdk.NewAction("counter", "add", `[3]`)})
+	assert.Nil(t, err)
+	receipt, err := f.CallReadOnlyCtx(ctx, "counter", "get", `[]`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`["3"]`}, receipt.Returns)
+	storage, err := f.GetContractStorageCtx(ctx, &rpcpb.GetContractStorageRequest{Id: "counter", Key: "n"})
+	assert.Nil(t, err)
+	assert.Equal(t, "3", storage.Data)
+	batch, err := f.GetBatchContractStorageCtx(ctx, &rpcpb.GetBatchContractStorageRequest{Queries: []*rpcpb.GetBatchContractStorageRequest_Query{
+		{Id: "counter", Key: "n"}, {Id: "counter", Key: "m"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "null"}, batch.Data)
+	assert.Equal(t, storage.BlockHash, batch.BlockHash)
+	changes, err := f.GetBlockStateChangesCtx(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*rpcpb.StateChange{{Contract: "counter", Key: "b-counter-n", Value: "3"}}, changes.Changes)
+
+	receipt, err = f.CallReadOnlyCtx(ctx, "counter", "reset", `[]`)
+	assert.Nil(t, err)
+	assert.Equal(t, rpcpb.TxReceipt_RUNTIME_ERROR, receipt.StatusCode)
+	_, err = f.SendTxFromActionsCtx(ctx, []*rpcpb.Action{sdk.NewAction("nothing", "add", `[]`)})
+	assert.Equal(t, sdk.ErrContractNotFound, sdk.CodeOf(err))
+}
+
+func TestCreateNewAccount(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "100")
+
+	_, err := f.CreateNewAccountCtx(ctx, "carol", "owner", "active", 20, 1000, 5)
+	assert.Nil(t, err)
+	acc, err := f.GetAccountInfoCtx(ctx, "carol")
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, acc.Balance)
+	assert.Equal(t, "active", acc.Permissions["active"].Items[0].Id)
+	assert.Equal(t, "85", f.Balance("alice", "iost"))
+
+	_, err = f.CreateNewAccountCtx(ctx, "carol", "owner", "active", 20, 1000, 5)
+	assert.NotNil(t, err)
+	_, err = f.GetAccountInfoCtx(ctx, "dave")
+	assert.NotNil(t, err)
+}
+
+func TestPublishContract(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "clienttest")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	code, abi := filepath.Join(dir, "c.js"), filepath.Join(dir, "c.abi")
+	assert.Nil(t, ioutil.WriteFile(code, []byte("class C {}"), 0644))
+	assert.Nil(t, ioutil.WriteFile(abi, []byte(`{"lang": "javascript", "version": "1.0.0", "abi": [{"name": "hi", "args": []}]}`), 0644))
+	f := New("alice")
+
+	_, hash, err := f.PublishContractCtx(ctx, code, abi, "", false, "")
+	assert.Nil(t, err)
+	receipt, err := f.GetTxReceiptByTxHashCtx(ctx, hash)
+	assert.Nil(t, err)
+	id := `["Contract` + hash + `"]`
+	assert.Equal(t, []string{id}, receipt.Returns)
+	c, err := f.GetContractCtx(ctx, "Contract"+hash)
+	assert.Nil(t, err)
+	assert.Equal(t, "javascript", c.Language)
+	assert.Equal(t, "hi", c.Abis[0].Name)
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := New("alice")
+	f.SetBalance("alice", "iost", "10")
+	f.AddAccount("bob")
+	_, err := pay(ctx, f, "alice", "bob", "1")
+	assert.Nil(t, err)
+
+	blocks := f.SubscribeNewBlocks(ctx, 1)
+	events := f.SubscribeContractEvents(ctx, &rpcpb.SubscribeRequest_Filter{ContractId: "token.iost"})
+	_, err = pay(ctx, f, "alice", "bob", "2")
+	assert.Nil(t, err)
+	f.EmitEvent("other", rpcpb.Event_CONTRACT_EVENT, "ignored")
+	f.EmitEvent("token.iost", rpcpb.Event_CONTRACT_EVENT, "hello")
+
+	for _, num := range []int64{1, 2} {
+		select {
+		case b := <-blocks:
+			assert.Equal(t, num, b.Number)
+		case <-time.After(time.Second):
+			t.Fatal("block not sent")
+		}
+	}
+	for _, data := range []string{`["iost", "alice", "bob", "2", ""]`, "hello"} {
+		select {
+		case e := <-events:
+			assert.Equal(t, data, e.Data)
+		case <-time.After(time.Second):
+			t.Fatal("event not sent")
+		}
+	}
+	cancel()
+	for range blocks {
+	}
+	for range events {
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	ctx := context.Background()
+	f := New("alice")
+	f.SetBalance("alice", "iost", "10")
+	f.AddAccount("bob")
+	f.AddAccount("carol")
+	for _, to := range []string{"bob", "carol", "bob", "bob"} {
+		_, err := pay(ctx, f, "alice", to, "1")
+		assert.Nil(t, err)
+	}
+
+	events, err := f.SubscribeEvents(ctx, sdk.NewFilter().Contract("token.iost").Arg(2, "bob").Blocks(1, 3))
+	assert.Nil(t, err)
+	var got []string
+	for e := range events {
+		got = append(got, e.Data)
+	}
+	assert.Equal(t, []string{`["iost", "alice", "bob", "1", ""]`, `["iost", "alice", "bob", "1", ""]`}, got)
+
+	events, err = f.SubscribeEvents(ctx, sdk.NewFilter().Contract("other").Blocks(1, 4))
+	assert.Nil(t, err)
+	for range events {
+		t.Fatal("event of another contract")
+	}
+	_, err = f.SubscribeEvents(ctx, sdk.NewFilter().Topics(rpcpb.Event_CONTRACT_EVENT).Blocks(1, 4))
+	assert.NotNil(t, err)
+
+	live, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err = f.SubscribeEvents(live, sdk.NewFilter().Contract("game").Topics(rpcpb.Event_CONTRACT_EVENT).Field("kind", "win"))
+	assert.Nil(t, err)
+	f.EmitEvent("game", rpcpb.Event_CONTRACT_EVENT, `{"kind": "lose"}`)
+	f.EmitEvent("game", rpcpb.Event_CONTRACT_EVENT, `{"kind": "win"}`)
+	select {
+	case e := <-events:
+		assert.Equal(t, `{"kind": "win"}`, e.Data)
+	case <-time.After(time.Second):
+		t.Fatal("event not sent")
+	}
+}
@@ -0,0 +1,452 @@
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// State is the state of the fake chain, which the handlers of the actions read and change. The changes of a failed
+// tx are reverted, so the values it holds are replaced by the setters rather than changed in place.
+type State struct {
+	accounts  map[string]*rpcpb.Account
+	tokens    map[string]int
+	balances  map[string]map[string]sdk.Amount
+	contracts map[string]*rpcpb.Contract
+	storage   map[string]map[string]map[string]string
+	token721  map[string]map[string]token721
+}
+
+type token721 struct {
+	owner    string
+	metadata string
+}
+
+func newState() *State {
+	st := &State{
+		accounts:  make(map[string]*rpcpb.Account),
+		tokens:    map[string]int{"iost": 8},
+		balances:  make(map[string]map[string]sdk.Amount),
+		contracts: make(map[string]*rpcpb.Contract),
+		storage:   make(map[string]map[string]map[string]string),
+		token721:  make(map[string]map[string]token721),
+	}
+	for _, id := range []string{"auth.iost", "gas.iost", "ram.iost", "system.iost", "token.iost", "token721.iost", "vote_producer.iost"} {
+		st.contracts[id] = &rpcpb.Contract{Id: id, Language: "native", Version: "1.0.0"}
+	}
+	return st
+}
+
+// clone copies the maps of the state, the values being shared as they are never changed in place.
+func (st *State) clone() *State {
+	c := &State{
+		accounts:  make(map[string]*rpcpb.Account, len(st.accounts)),
+		tokens:    make(map[string]int, len(st.tokens)),
+		balances:  make(map[string]map[string]sdk.Amount, len(st.balances)),
+		contracts: make(map[string]*rpcpb.Contract, len(st.contracts)),
+		storage:   make(map[string]map[string]map[string]string, len(st.storage)),
+		token721:  make(map[string]map[string]token721, len(st.token721)),
+	}
+	for k, v := range st.accounts {
+		c.accounts[k] = v
+	}
+	for k, v := range st.tokens {
+		c.tokens[k] = v
+	}
+	for k, v := range st.balances {
+		c.balances[k] = make(map[string]sdk.Amount, len(v))
+		for token, b := range v {
+			c.balances[k][token] = b
+		}
+	}
+	for k, v := range st.contracts {
+		c.contracts[k] = v
+	}
+	for id, keys := range st.storage {
+		c.storage[id] = make(map[string]map[string]string, len(keys))
+		for key, fields := range keys {
+			c.storage[id][key] = make(map[string]string, len(fields))
+			for f, v := range fields {
+				c.storage[id][key][f] = v
+			}
+		}
+	}
+	for k, v := range st.token721 {
+		c.token721[k] = make(map[string]token721, len(v))
+		for id, t := range v {
+			c.token721[k][id] = t
+		}
+	}
+	return c
+}
+
+// Account returns the account, nil if it does not exist.
+func (st *State) Account(name string) *rpcpb.Account {
+	return st.accounts[name]
+}
+
+// SetAccount adds or replaces the account.
+func (st *State) SetAccount(acc *rpcpb.Account) {
+	st.accounts[acc.Name] = acc
+}
+
+// AddToken creates the token with the decimal places, or changes them if it exists.
+func (st *State) AddToken(token string, decimal int) {
+	st.tokens[token] = decimal
+}
+
+// TokenDecimal returns the decimal places of the token and whether it exists.
+func (st *State) TokenDecimal(token string) (int, bool) {
+	d, ok := st.tokens[token]
+	return d, ok
+}
+
+// Balance returns the balance of the token held by the account or the contract.
+func (st *State) Balance(account string, token string) sdk.Amount {
+	if b, ok := st.balances[account][token]; ok {
+		return b
+	}
+	return sdk.AmountFromUnits(0, st.tokens[token])
+}
+
+// SetBalance sets the balance of the token held by the account or the contract.
+func (st *State) SetBalance(account string, token string, balance sdk.Amount) {
+	if st.balances[account] == nil {
+		st.balances[account] = make(map[string]sdk.Amount)
+	}
+	st.balances[account][token] = balance
+}
+
+// holder tells whether the account or the contract exists, which can thus hold tokens.
+func (st *State) holder(name string) bool {
+	return st.accounts[name] != nil || st.contracts[name] != nil
+}
+
+// Transfer moves the amount of the token between two accounts or contracts, failing like token.iost does.
+func (st *State) Transfer(token string, from string, to string, amount string) error {
+	decimal, ok := st.tokens[token]
+	if !ok {
+		return fmt.Errorf("token not exists: %v", token)
+	}
+	a, err := sdk.NewAmount(amount, decimal)
+	if err != nil {
+		return err
+	}
+	if a.Sign() <= 0 {
+		return fmt.Errorf("invalid amount %v", amount)
+	}
+	for _, name := range []string{from, to} {
+		if !st.holder(name) {
+			return fmt.Errorf("account not exists: %v", name)
+		}
+	}
+	fromBalance, err := st.Balance(from, token).Sub(a)
+	if err != nil {
+		return err
+	}
+	if fromBalance.Sign() < 0 {
+		return fmt.Errorf("balance not enough %v < %v", st.Balance(from, token), a)
+	}
+	toBalance, err := st.Balance(to, token).Add(a)
+	if err != nil {
+		return err
+	}
+	st.SetBalance(from, token, fromBalance)
+	st.SetBalance(to, token, toBalance)
+	return nil
+}
+
+// Contract returns the contract, nil if it does not exist.
+func (st *State) Contract(id string) *rpcpb.Contract {
+	return st.contracts[id]
+}
+
+// SetContract adds or replaces the contract.
+func (st *State) SetContract(c *rpcpb.Contract) {
+	st.contracts[c.Id] = c
+}
+
+// Storage returns the value of the field of the map key in the storage of the contract, or of the key itself if
+// the field is empty, and whether it is set.
+func (st *State) Storage(contract string, key string, field string) (string, bool) {
+	v, ok := st.storage[contract][key][field]
+	return v, ok
+}
+
+// SetStorage sets the value of the field of the map key in the storage of the contract, or of the key itself if the
+// field is empty.
+func (st *State) SetStorage(contract string, key string, field string, value string) {
+	if st.storage[contract] == nil {
+		st.storage[contract] = make(map[string]map[string]string)
+	}
+	if st.storage[contract][key] == nil {
+		st.storage[contract][key] = make(map[string]string)
+	}
+	st.storage[contract][key][field] = value
+}
+
+// DeleteStorage removes the field of the map key in the storage of the contract.
+func (st *State) DeleteStorage(contract string, key string, field string) {
+	delete(st.storage[contract][key], field)
+}
+
+// storageFields returns the fields of the map key in the storage of the contract, sorted.
+func (st *State) storageFields(contract string, key string) []string {
+	fields := make([]string, 0, len(st.storage[contract][key]))
+	for f := range st.storage[contract][key] {
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// SetToken721 sets the owner and the metadata of a token of the token721.
+func (st *State) SetToken721(token string, tokenID string, owner string, metadata string) {
+	if st.token721[token] == nil {
+		st.token721[token] = make(map[string]token721)
+	}
+	st.token721[token][tokenID] = token721{owner: owner, metadata: metadata}
+}
+
+// token721Of returns the ids of the tokens of the token721 held by the account, sorted.
+func (st *State) token721Of(account string, token string) []string {
+	var ids []string
+	for id, t := range st.token721[token] {
+		if t.owner == account {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// changes returns the state keys changed from the old state, keyed and valued like on a node: the balances are held
+// in units by token.iost, and the contract code is not given.
+func (st *State) changes(old *State) []*rpcpb.StateChange {
+	var changes []*rpcpb.StateChange
+	for acc, balances := range st.balances {
+		for token, b := range balances {
+			if ob, ok := old.balances[acc][token]; ok && ob.Cmp(b) == 0 {
+				continue
+			}
+			changes = append(changes, &rpcpb.StateChange{
+				Kind:     rpcpb.StateChange_TOKEN_BALANCE,
+				Contract: "token.iost",
+				Key:      "m-token.iost-TB" + acc + "-" + token,
+				Value:    strconv.FormatInt(b.Units(), 10),
+			})
+		}
+	}
+	for id, c := range st.contracts {
+		if old.contracts[id] != c {
+			changes = append(changes, &rpcpb.StateChange{Kind: rpcpb.StateChange_CONTRACT, Contract: id, Key: "c-" + id})
+		}
+	}
+	storageChange := func(contract string, key string, field string) *rpcpb.StateChange {
+		c := &rpcpb.StateChange{Contract: contract, Key: "b-" + contract + "-" + key}
+		if field != "" {
+			c.Key = "m-" + contract + "-" + key + "-" + field
+		}
+		switch contract {
+		case "ram.iost":
+			c.Kind = rpcpb.StateChange_RAM
+		case "gas.iost":
+			c.Kind = rpcpb.StateChange_GAS
+		}
+		return c
+	}
+	for id, keys := range st.storage {
+		for key, fields := range keys {
+			for field, v := range fields {
+				if ov, ok := old.storage[id][key][field]; ok && ov == v {
+					continue
+				}
+				c := storageChange(id, key, field)
+				c.Value = v
+				changes = append(changes, c)
+			}
+		}
+	}
+	for id, keys := range old.storage {
+		for key, fields := range keys {
+			for field := range fields {
+				if _, ok := st.storage[id][key][field]; !ok {
+					c := storageChange(id, key, field)
+					c.Deleted = true
+					changes = append(changes, c)
+				}
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// Call is an action being run by a handler, with the tx it is part of.
+type Call struct {
+	Tx     *rpcpb.TransactionRequest
+	Action *rpcpb.Action
+	// Args are the arguments of the action decoded from its json data.
+	Args []interface{}
+}
+
+// Signed tells whether the account published or signed the tx, and thus authorizes the action.
+func (c *Call) Signed(account string) bool {
+	return signedBy(c.Tx.Publisher, c.Tx.Signers, account)
+}
+
+func signedBy(publisher string, signers []string, account string) bool {
+	if publisher == account {
+		return true
+	}
+	for _, s := range signers {
+		if s == account || strings.HasPrefix(s, account+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// Arg returns the argument i as a string, numbers being formatted as they are in the json data.
+func (c *Call) Arg(i int) (string, error) {
+	if i >= len(c.Args) {
+		return "", fmt.Errorf("wrong parameter: %v needs at least %v args", c.Action.ActionName, i+1)
+	}
+	switch v := c.Args[i].(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("wrong parameter: arg %v of %v is not a string", i, c.Action.ActionName)
+}
+
+// Handler runs an action on the state, returning what the abi returns as a json array like the vm does, eg `["a"]`,
+// empty if nothing, or the error failing the tx.
+type Handler func(st *State, c *Call) (string, error)
+
+// builtinHandlers run the actions of the system contracts which the sdk sends itself.
+var builtinHandlers = map[string]Handler{
+	"token.iost/transfer":    transfer,
+	"auth.iost/signUp":       signUp,
+	"gas.iost/pledge":        pledge,
+	"ram.iost/buy":           buyRAM,
+	"system.iost/setCode":    setCode,
+	"system.iost/updateCode": setCode,
+}
+
+func args(c *Call, n int) ([]string, error) {
+	ret := make([]string, n)
+	for i := range ret {
+		a, err := c.Arg(i)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = a
+	}
+	return ret, nil
+}
+
+func transfer(st *State, c *Call) (string, error) {
+	a, err := args(c, 4)
+	if err != nil {
+		return "", err
+	}
+	if !c.Signed(a[1]) {
+		return "", fmt.Errorf("transfer need issuer permission: no permission of %v", a[1])
+	}
+	return "", st.Transfer(a[0], a[1], a[2], a[3])
+}
+
+func signUp(st *State, c *Call) (string, error) {
+	a, err := args(c, 3)
+	if err != nil {
+		return "", err
+	}
+	if st.holder(a[0]) {
+		return "", fmt.Errorf("id existed > %v", a[0])
+	}
+	perm := func(name string, key string) *rpcpb.Account_Permission {
+		return &rpcpb.Account_Permission{
+			Name:      name,
+			Items:     []*rpcpb.Account_Item{{Id: key, IsKeyPair: true, Weight: 100}},
+			Threshold: 100,
+		}
+	}
+	st.SetAccount(&rpcpb.Account{
+		Name:        a[0],
+		GasInfo:     &rpcpb.Account_GasInfo{},
+		RamInfo:     &rpcpb.Account_RAMInfo{},
+		Permissions: map[string]*rpcpb.Account_Permission{"owner": perm("owner", a[1]), "active": perm("active", a[2])},
+	})
+	return "", nil
+}
+
+func pledge(st *State, c *Call) (string, error) {
+	a, err := args(c, 3)
+	if err != nil {
+		return "", err
+	}
+	if !c.Signed(a[0]) {
+		return "", fmt.Errorf("no permission of %v to pledge", a[0])
+	}
+	if st.Account(a[1]) == nil {
+		return "", fmt.Errorf("account not exists: %v", a[1])
+	}
+	return "", st.Transfer("iost", a[0], "gas.iost", a[2])
+}
+
+func buyRAM(st *State, c *Call) (string, error) {
+	a, err := args(c, 3)
+	if err != nil {
+		return "", err
+	}
+	if !c.Signed(a[0]) {
+		return "", fmt.Errorf("no permission of %v to buy ram", a[0])
+	}
+	if st.Account(a[1]) == nil {
+		return "", fmt.Errorf("account not exists: %v", a[1])
+	}
+	return "", nil
+}
+
+func setCode(st *State, c *Call) (string, error) {
+	a, err := args(c, 1)
+	if err != nil {
+		return "", err
+	}
+	var con contract.Contract
+	if err := json.Unmarshal([]byte(a[0]), &con); err != nil {
+		return "", fmt.Errorf("wrong parameter: invalid contract: %v", err)
+	}
+	if c.Action.ActionName == "updateCode" {
+		if st.Contract(con.ID) == nil {
+			return "", fmt.Errorf("contract not exists: %v", con.ID)
+		}
+	} else {
+		con.ID = "Contract" + common.Base58Encode(sdk.TxHash(c.Tx))
+	}
+	pc := &rpcpb.Contract{Id: con.ID, Code: con.Code}
+	if con.Info != nil {
+		pc.Language, pc.Version = con.Info.Lang, con.Info.Version
+		for _, abi := range con.Info.Abi {
+			pc.Abis = append(pc.Abis, &rpcpb.Contract_ABI{Name: abi.Name, Args: abi.Args})
+		}
+	}
+	st.SetContract(pc)
+	ret, err := json.Marshal([]string{con.ID})
+	return string(ret), err
+}
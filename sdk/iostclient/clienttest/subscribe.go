@@ -0,0 +1,170 @@
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// contractEvent is an event of a contract, which subscriptions may filter by contract.
+type contractEvent struct {
+	contract string
+	event    *rpcpb.Event
+}
+
+// subscriber queues what the fake sends to a subscription, so that the fake never waits for the application, and
+// passes it on to the channel of the subscription in a goroutine until ctx is done.
+type subscriber struct {
+	ctx    context.Context
+	accept func(x interface{}) bool
+	send   func(x interface{}) bool
+	close  func()
+
+	mu    sync.Mutex
+	queue []interface{}
+	wake  chan struct{}
+}
+
+// subscribe starts a subscription with the fake locked, first sending the backlog.
+func (f *Fake) subscribe(ctx context.Context, backlog []interface{}, accept func(x interface{}) bool, send func(x interface{}) bool, closeCh func()) {
+	s := &subscriber{ctx: ctx, accept: accept, send: send, close: closeCh, queue: backlog, wake: make(chan struct{}, 1)}
+	f.subs = append(f.subs, s)
+	go s.run()
+}
+
+func (s *subscriber) run() {
+	defer s.close()
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.wake:
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		x := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		if !s.send(x) {
+			return
+		}
+	}
+}
+
+func (s *subscriber) push(x interface{}) {
+	s.mu.Lock()
+	s.queue = append(s.queue, x)
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// broadcast queues x to the subscriptions accepting it, dropping the ones done.
+func (f *Fake) broadcast(x interface{}) {
+	subs := f.subs[:0]
+	for _, s := range f.subs {
+		if s.ctx.Err() != nil {
+			continue
+		}
+		subs = append(subs, s)
+		if s.accept(x) {
+			s.push(x)
+		}
+	}
+	for i := len(subs); i < len(f.subs); i++ {
+		f.subs[i] = nil
+	}
+	f.subs = subs
+}
+
+// SubscribeNewBlocks sends the blocks from fromHeight on in order, with their txs, until ctx is done. A fromHeight
+// of 0 starts with the next new block. The channel is closed once ctx is done.
+func (f *Fake) SubscribeNewBlocks(ctx context.Context, fromHeight int64) <-chan *rpcpb.Block {
+	ch := make(chan *rpcpb.Block)
+	var backlog []interface{}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if fromHeight > 0 {
+		for _, b := range f.blocks {
+			if b.Number >= fromHeight {
+				backlog = append(backlog, b)
+			}
+		}
+	}
+	f.subscribe(ctx, backlog, func(x interface{}) bool {
+		_, ok := x.(*rpcpb.Block)
+		return ok
+	}, func(x interface{}) bool {
+		select {
+		case ch <- proto.Clone(x.(*rpcpb.Block)).(*rpcpb.Block):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// SubscribePendingTx sends the txs as they are sent, until ctx is done. The channel is closed once ctx is done.
+func (f *Fake) SubscribePendingTx(ctx context.Context) <-chan *rpcpb.Transaction {
+	ch := make(chan *rpcpb.Transaction)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribe(ctx, nil, func(x interface{}) bool {
+		_, ok := x.(*rpcpb.Transaction)
+		return ok
+	}, func(x interface{}) bool {
+		select {
+		case ch <- proto.Clone(x.(*rpcpb.Transaction)).(*rpcpb.Transaction):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// SubscribeContractEvents sends the events of the topics, the receipts and events by default, until ctx is done.
+// The receipts are those of the actions of the txs sent, and the events those emitted by `EmitEvent`. A filter
+// limits them to a contract. The channel is closed once ctx is done.
+func (f *Fake) SubscribeContractEvents(ctx context.Context, filter *rpcpb.SubscribeRequest_Filter, topics ...rpcpb.Event_Topic) <-chan *rpcpb.Event {
+	ch := make(chan *rpcpb.Event)
+	if len(topics) == 0 {
+		topics = []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_RECEIPT, rpcpb.Event_CONTRACT_EVENT}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribe(ctx, nil, func(x interface{}) bool {
+		e, ok := x.(contractEvent)
+		if !ok || (filter != nil && filter.ContractId != "" && filter.ContractId != e.contract) {
+			return false
+		}
+		for _, t := range topics {
+			if t == e.event.Topic {
+				return true
+			}
+		}
+		return false
+	}, func(x interface{}) bool {
+		select {
+		case ch <- proto.Clone(x.(contractEvent).event).(*rpcpb.Event):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// SubscribeEvents sends the events matching the filter, see `sdk.Filter.Subscribe`.
+func (f *Fake) SubscribeEvents(ctx context.Context, filter *sdk.Filter) (<-chan *rpcpb.Event, error) {
+	return filter.Subscribe(ctx, f)
+}
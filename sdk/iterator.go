@@ -0,0 +1,309 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// iteratorPageSize is how many items an iterator fetches at a time, which is the most txs a node returns at once.
+const iteratorPageSize = 100
+
+// pager goes through the items of a query fetched a page at a time. The iterators wrap it with typed getters, and
+// stop at the first error, which is then returned by their Err like bufio.Scanner does.
+type pager struct {
+	ctx context.Context
+	// fetch returns the next page and whether there are more after it
+	fetch func(ctx context.Context) ([]interface{}, bool, error)
+	page  []interface{}
+	item  interface{}
+	done  bool
+	err   error
+}
+
+func (p *pager) next() bool {
+	for len(p.page) == 0 {
+		if p.done || p.err != nil {
+			p.item = nil
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			continue
+		}
+		page, more, err := p.fetch(p.ctx)
+		p.page, p.done, p.err = page, !more, err
+	}
+	p.item, p.page = p.page[0], p.page[1:]
+	return true
+}
+
+// fieldPages makes a fetch going through the fields of a map in the storage of a contract, which are listed once and
+// then passed a page at a time to fetchPage.
+func (s *IOSTDevSDK) fieldPages(contract string, key string, fetchPage func(ctx context.Context, fields []string) ([]interface{}, error)) func(ctx context.Context) ([]interface{}, bool, error) {
+	var fields []string
+	listed := false
+	return func(ctx context.Context) ([]interface{}, bool, error) {
+		if !listed {
+			res, err := s.GetContractStorageFieldsCtx(ctx, &rpcpb.GetContractStorageFieldsRequest{Id: contract, Key: key, ByLongestChain: s.useLongestChain})
+			if err != nil {
+				return nil, false, err
+			}
+			fields, listed = res.Fields, true
+		}
+		n := len(fields)
+		if n > iteratorPageSize {
+			n = iteratorPageSize
+		}
+		page, err := fetchPage(ctx, fields[:n])
+		if err != nil {
+			return nil, false, err
+		}
+		fields = fields[n:]
+		return page, len(fields) != 0, nil
+	}
+}
+
+// TxIterator goes through the txs of an account, see `TxsByAccountIterator`.
+type TxIterator struct {
+	p pager
+}
+
+// TxsByAccountIterator returns an iterator of the irreversible txs of the account, newest first, fetched a page at
+// a time:
+//
+//	it := s.TxsByAccountIterator(ctx, "admin")
+//	for it.Next() {
+//		fmt.Println(it.Tx().Hash)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// The pages go on after the last tx seen, so the txs made while iterating are not seen.
+func (s *IOSTDevSDK) TxsByAccountIterator(ctx context.Context, account string) *TxIterator {
+	cursor := ""
+	return &TxIterator{p: pager{ctx: ctx, fetch: func(ctx context.Context) ([]interface{}, bool, error) {
+		res, err := s.GetAccountTxsCtx(ctx, &rpcpb.GetAccountTxsRequest{Account: account, Limit: iteratorPageSize, Cursor: cursor})
+		if err != nil {
+			return nil, false, err
+		}
+		page := make([]interface{}, len(res.Transactions))
+		for i, t := range res.Transactions {
+			page[i] = t.Transaction
+		}
+		cursor = res.Cursor
+		return page, cursor != "", nil
+	}}}
+}
+
+// Next fetches the next tx, fetching the next page if needed, and tells whether there is one.
+func (it *TxIterator) Next() bool {
+	return it.p.next()
+}
+
+// Tx returns the tx fetched by `Next`.
+func (it *TxIterator) Tx() *rpcpb.Transaction {
+	t, _ := it.p.item.(*rpcpb.Transaction)
+	return t
+}
+
+// Err returns the error which stopped the iteration, nil if all txs were fetched.
+func (it *TxIterator) Err() error {
+	return it.p.err
+}
+
+// StorageEntry is a field of a map in the storage of a contract and its value.
+type StorageEntry struct {
+	Field string
+	Value string
+}
+
+// StorageIterator goes through the fields of a map in the storage of a contract, see `ContractStorageIterator`.
+type StorageIterator struct {
+	p pager
+}
+
+// ContractStorageIterator returns an iterator of the fields of the map key in the storage of the contract and their
+// values, which are fetched a page at a time by concurrent calls, see `Batch`. The fields are listed once at the
+// start, so fields added while iterating are not seen, and fields removed are seen with an empty value.
+func (s *IOSTDevSDK) ContractStorageIterator(ctx context.Context, contract string, key string) *StorageIterator {
+	return &StorageIterator{p: pager{ctx: ctx, fetch: s.fieldPages(contract, key, func(ctx context.Context, fields []string) ([]interface{}, error) {
+		b := s.Batch()
+		for _, f := range fields {
+			b.GetContractStorage(&rpcpb.GetContractStorageRequest{Id: contract, Key: key, Field: f, ByLongestChain: s.useLongestChain})
+		}
+		results, err := b.Execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page := make([]interface{}, len(fields))
+		for i, f := range fields {
+			page[i] = &StorageEntry{Field: f, Value: results[i].(*rpcpb.GetContractStorageResponse).Data}
+		}
+		return page, nil
+	})}}
+}
+
+// Next fetches the next field, fetching the next page if needed, and tells whether there is one.
+func (it *StorageIterator) Next() bool {
+	return it.p.next()
+}
+
+// Entry returns the field fetched by `Next`.
+func (it *StorageIterator) Entry() *StorageEntry {
+	e, _ := it.p.item.(*StorageEntry)
+	return e
+}
+
+// Err returns the error which stopped the iteration, nil if all fields were fetched.
+func (it *StorageIterator) Err() error {
+	return it.p.err
+}
+
+// Token721 is a token721 token and its metadata.
+type Token721 struct {
+	ID       string
+	Metadata string
+}
+
+// Token721Iterator goes through the token721 tokens held by an account, see `Token721HoldingsIterator`.
+type Token721Iterator struct {
+	p pager
+}
+
+// Token721HoldingsIterator returns an iterator of the tokens of the token721 held by the account, sorted by id, which
+// are fetched a page at a time with their metadata got by concurrent calls. The pages go on after the last id seen,
+// so tokens received while iterating are seen only if their ids come after it.
+func (s *IOSTDevSDK) Token721HoldingsIterator(ctx context.Context, account string, token string) *Token721Iterator {
+	cursor := ""
+	return &Token721Iterator{p: pager{ctx: ctx, fetch: func(ctx context.Context) ([]interface{}, bool, error) {
+		res, err := s.GetToken721BalancePageCtx(ctx, &rpcpb.GetToken721BalanceRequest{
+			Account:        account,
+			Token:          token,
+			ByLongestChain: s.useLongestChain,
+			Limit:          iteratorPageSize,
+			Cursor:         cursor,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		b := s.Batch()
+		for _, id := range res.TokenIDs {
+			id := id
+			b.Add(func(ctx context.Context) (interface{}, error) {
+				return s.GetToken721MetadataCtx(ctx, token, id)
+			})
+		}
+		results, err := b.Execute(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		page := make([]interface{}, len(res.TokenIDs))
+		for i, id := range res.TokenIDs {
+			page[i] = &Token721{ID: id, Metadata: results[i].(*rpcpb.GetToken721MetadataResponse).Metadata}
+		}
+		cursor = res.Cursor
+		return page, cursor != "", nil
+	}}}
+}
+
+// Next fetches the next token, fetching the next page if needed, and tells whether there is one.
+func (it *Token721Iterator) Next() bool {
+	return it.p.next()
+}
+
+// Token returns the token fetched by `Next`.
+func (it *Token721Iterator) Token() *Token721 {
+	t, _ := it.p.item.(*Token721)
+	return t
+}
+
+// Err returns the error which stopped the iteration, nil if all tokens were fetched.
+func (it *Token721Iterator) Err() error {
+	return it.p.err
+}
+
+// ProducerVotes is a producer candidate and its votes.
+type ProducerVotes struct {
+	Account string
+	Info    *rpcpb.GetProducerVoteInfoResponse
+}
+
+// ProducerIterator goes through the producer candidates, see `ProducersIterator`.
+type ProducerIterator struct {
+	p pager
+}
+
+// ProducersIterator returns an iterator of the accounts registered as producer candidates in vote_producer.iost and
+// their votes, sorted by account, which are fetched a page at a time.
+func (s *IOSTDevSDK) ProducersIterator(ctx context.Context) *ProducerIterator {
+	cursor := ""
+	return &ProducerIterator{p: pager{ctx: ctx, fetch: func(ctx context.Context) ([]interface{}, bool, error) {
+		res, err := s.GetProducersCtx(ctx, &rpcpb.GetProducersRequest{Cursor: cursor, ByLongestChain: s.useLongestChain})
+		if err != nil {
+			return nil, false, err
+		}
+		page := make([]interface{}, len(res.Producers))
+		for i, p := range res.Producers {
+			page[i] = &ProducerVotes{Account: p.Account, Info: p.Info}
+		}
+		cursor = res.Cursor
+		return page, cursor != "", nil
+	}}}
+}
+
+// Next fetches the next candidate, fetching the next page if needed, and tells whether there is one.
+func (it *ProducerIterator) Next() bool {
+	return it.p.next()
+}
+
+// Producer returns the candidate fetched by `Next`.
+func (it *ProducerIterator) Producer() *ProducerVotes {
+	p, _ := it.p.item.(*ProducerVotes)
+	return p
+}
+
+// Err returns the error which stopped the iteration, nil if all candidates were fetched.
+func (it *ProducerIterator) Err() error {
+	return it.p.err
+}
+
+// VoterIterator goes through the voters of a producer candidate, see `VotersIterator`.
+type VoterIterator struct {
+	p pager
+}
+
+// VotersIterator returns an iterator of the voters of the producer candidate and their votes, sorted by account,
+// which are fetched a page at a time.
+func (s *IOSTDevSDK) VotersIterator(ctx context.Context, producer string) *VoterIterator {
+	cursor := ""
+	return &VoterIterator{p: pager{ctx: ctx, fetch: func(ctx context.Context) ([]interface{}, bool, error) {
+		res, err := s.GetVotersCtx(ctx, &rpcpb.GetVotersRequest{Producer: producer, Cursor: cursor, ByLongestChain: s.useLongestChain})
+		if err != nil {
+			return nil, false, err
+		}
+		page := make([]interface{}, len(res.Voters))
+		for i, v := range res.Voters {
+			page[i] = v
+		}
+		cursor = res.Cursor
+		return page, cursor != "", nil
+	}}}
+}
+
+// Next fetches the next voter, fetching the next page if needed, and tells whether there is one.
+func (it *VoterIterator) Next() bool {
+	return it.p.next()
+}
+
+// Voter returns the voter fetched by `Next`.
+func (it *VoterIterator) Voter() *rpcpb.GetVotersResponse_Voter {
+	v, _ := it.p.item.(*rpcpb.GetVotersResponse_Voter)
+	return v
+}
+
+// Err returns the error which stopped the iteration, nil if all voters were fetched.
+func (it *VoterIterator) Err() error {
+	return it.p.err
+}
@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testPageSize is how many producers the test node returns at a time
+const testPageSize = 2
+
+// page returns the items of the page starting at the index given by cursor, and the cursor of the next page.
+func page(items []string, cursor string, limit int) ([]string, string) {
+	start, _ := strconv.Atoi(cursor)
+	end := start + limit
+	if end >= len(items) {
+		return items[start:], ""
+	}
+	return items[start:end], strconv.Itoa(end)
+}
+
+func (n *testNode) GetAccountTxs(ctx context.Context, req *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	hashes, cursor := page(n.accountTxs, req.Cursor, int(req.Limit))
+	res := &rpcpb.GetAccountTxsResponse{Cursor: cursor}
+	for _, h := range hashes {
+		res.Transactions = append(res.Transactions, &rpcpb.TransactionResponse{
+			Status:      rpcpb.TransactionResponse_IRREVERSIBLE,
+			Transaction: &rpcpb.Transaction{Hash: h},
+		})
+	}
+	return res, nil
+}
+
+func (n *testNode) GetProducers(ctx context.Context, req *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	accounts, cursor := page(n.producers, req.Cursor, testPageSize)
+	res := &rpcpb.GetProducersResponse{Cursor: cursor}
+	for _, a := range accounts {
+		res.Producers = append(res.Producers, &rpcpb.GetProducersResponse_Producer{Account: a, Info: &rpcpb.GetProducerVoteInfoResponse{Loc: a}})
+	}
+	return res, nil
+}
+
+func (n *testNode) GetContractStorageFields(ctx context.Context, req *rpcpb.GetContractStorageFieldsRequest) (*rpcpb.GetContractStorageFieldsResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &rpcpb.GetContractStorageFieldsResponse{Fields: n.fields}, nil
+}
+
+func (n *testNode) GetContractStorage(ctx context.Context, req *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	k := req.Id + "/" + req.Key + "/" + req.Field
+	if v, ok := n.storage[k]; ok {
+		return &rpcpb.GetContractStorageResponse{Data: v}, nil
+	}
+	return &rpcpb.GetContractStorageResponse{Data: k}, nil
+}
+
+func TestTxsByAccountIterator(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	var hashes []string
+	for i := 0; i < iteratorPageSize+5; i++ {
+		hashes = append(hashes, fmt.Sprint("tx", i))
+	}
+	n.mu.Lock()
+	n.accountTxs = hashes
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	var got []string
+	it := s.TxsByAccountIterator(context.Background(), "alice")
+	for it.Next() {
+		got = append(got, it.Tx().Hash)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, hashes, got)
+	assert.Equal(t, 2, n.callCount("GetAccountTxs"))
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Tx())
+}
+
+func TestContractStorageIterator(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	var fields []string
+	for i := 0; i < iteratorPageSize+50; i++ {
+		fields = append(fields, fmt.Sprint("f", i))
+	}
+	n.mu.Lock()
+	n.fields = fields
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	// the fields are listed once and their values got a page at a time
+	i := 0
+	it := s.ContractStorageIterator(context.Background(), "Contractabc", "m")
+	for it.Next() {
+		assert.Equal(t, &StorageEntry{Field: fields[i], Value: "Contractabc/m/" + fields[i]}, it.Entry())
+		i++
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, len(fields), i)
+	assert.Equal(t, 1, n.callCount("GetContractStorageFields"))
+	assert.Equal(t, len(fields), n.callCount("GetContractStorage"))
+}
+
+func TestProducersIterator(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.producers = []string{"a", "b", "c", "d", "e"}
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	var got []string
+	it := s.ProducersIterator(context.Background())
+	for it.Next() {
+		assert.Equal(t, it.Producer().Account, it.Producer().Info.Loc)
+		got = append(got, it.Producer().Account)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, got)
+	assert.Equal(t, 3, n.callCount("GetProducers"))
+
+	// the iteration stops at the first error, after the candidates of the pages fetched before
+	got = nil
+	it = s.ProducersIterator(context.Background())
+	for it.Next() {
+		got = append(got, it.Producer().Account)
+		if len(got) == testPageSize {
+			n.failNext(1, status.Error(codes.InvalidArgument, "invalid cursor"))
+		}
+	}
+	assert.Equal(t, codes.InvalidArgument, status.Code(it.Err()))
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	// and once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = s.ProducersIterator(ctx)
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+// keystore is the part of an account file of iwallet, ~/.iwallet/NAME.json, holding its keys.
+type keystore struct {
+	Name      string                  `json:"name"`
+	Keypairs  map[string]*keystoreKey `json:"keypairs"`
+	WatchOnly bool                    `json:"watch_only,omitempty"`
+	Store     string                  `json:"store,omitempty"`
+}
+
+type keystoreKey struct {
+	RawKey        string `json:"raw_key,omitempty"`
+	KeyType       string `json:"key_type"`
+	PubKey        string `json:"public_key"`
+	EncryptMethod string `json:"encrypt_method,omitempty"`
+	Salt          string `json:"salt,omitempty"`
+	EncryptedKey  string `json:"encrypted_key,omitempty"`
+	Mac           string `json:"mac,omitempty"`
+	ScryptN       int    `json:"scrypt_n,omitempty"`
+	ScryptP       int    `json:"scrypt_p,omitempty"`
+}
+
+// LoadKeystoreSigner returns the signer of the key of the permission perm in an account file of iwallet, decrypting it
+// with the password if encrypted. Accounts keeping their keys in the keychain of the system are not supported, as
+// the keychain is only reachable by iwallet.
+func LoadKeystoreSigner(fileName string, perm string, password []byte) (*KeyPairSigner, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	ks := &keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore %v: %v", fileName, err)
+	}
+	if ks.WatchOnly {
+		return nil, fmt.Errorf("account %v is watch-only, it has no private key to sign with", ks.Name)
+	}
+	if ks.Store != "" {
+		return nil, fmt.Errorf("keys of account %v are kept in the %v, load them by iwallet", ks.Name, ks.Store)
+	}
+	k, ok := ks.Keypairs[perm]
+	if !ok {
+		return nil, fmt.Errorf("no %v key of account %v", perm, ks.Name)
+	}
+	seckey := common.Base58Decode(k.RawKey)
+	if k.RawKey == "" {
+		if seckey, err = k.decrypt(password); err != nil {
+			return nil, err
+		}
+	}
+	kp, err := account.NewKeyPair(seckey, GetSignAlgoByName(k.KeyType))
+	if err != nil {
+		return nil, err
+	}
+	if k.PubKey != "" && common.Base58Encode(kp.Pubkey) != k.PubKey {
+		return nil, fmt.Errorf("key of account %v does not match its public key %v", ks.Name, k.PubKey)
+	}
+	return NewKeyPairSigner(kp), nil
+}
+
+// decrypt decrypts the key the way iwallet encrypts it, by method v0 or v1.
+func (k *keystoreKey) decrypt(password []byte) ([]byte, error) {
+	scryptN, scryptP := 32768, 1
+	switch k.EncryptMethod {
+	case "v0":
+	case "v1":
+		scryptN, scryptP = k.ScryptN, k.ScryptP
+	default:
+		return nil, fmt.Errorf("version mismatch")
+	}
+	salt := common.Base58Decode(k.Salt)
+	if len(salt) != 48 {
+		return nil, fmt.Errorf("invalid salt")
+	}
+	key, err := scrypt.Key(password, salt[0:32], scryptN, 8, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	aesBlock, err := aes.NewCipher(key[0:16])
+	if err != nil {
+		return nil, err
+	}
+	inText := common.Base58Decode(k.EncryptedKey)
+	mac := common.Sha3(append(key[16:32], inText...))
+	if !bytes.Equal(mac, common.Base58Decode(k.Mac)) {
+		return nil, fmt.Errorf("wrong password")
+	}
+	outText := make([]byte, len(inText))
+	cipher.NewCTR(aesBlock, salt[32:48]).XORKeyStream(outText, inText)
+	return outText, nil
+}
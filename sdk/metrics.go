@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"strings"
+	"time"
+)
+
+// Kinds of reconnections given to `MetricsCollector.ObserveReconnect`.
+const (
+	// ReconnectFailover is a switch to another server, as the one in use is unavailable or lagging behind.
+	ReconnectFailover = "failover"
+	// ReconnectSubscription is a subscription opened again after it broke.
+	ReconnectSubscription = "subscription"
+)
+
+// MetricsCollector receives the metrics of the sdk, eg to export them to Prometheus by package sdk/prommetrics. Its
+// methods are called from the goroutines making the calls, so they should be safe for concurrent use and fast.
+type MetricsCollector interface {
+	// ObserveCall is called once a call to the node is over, with the rpc method named like GetTxByHash, its error,
+	// nil on success, and how long it took including its retries. Subscriptions are not observed.
+	ObserveCall(method string, err error, d time.Duration)
+	// ObserveRetry is called before a call is retried, with the error of the try which failed.
+	ObserveRetry(method string, err error)
+	// ObserveTx is called once the outcome of a tx sent by `SendTx` or `SendTxAsync` is known, with its error, nil
+	// on success, and how long it took from being signed. The outcome is that the node accepted the tx if the sdk
+	// does not wait for its result.
+	ObserveTx(err error, d time.Duration)
+	// ObserveReconnect is called when the sdk connects again to the servers, the kind being ReconnectFailover or
+	// ReconnectSubscription.
+	ObserveReconnect(kind string, server string)
+}
+
+// SetMetricsCollector sets the collector of the metrics of the sdk, nil for none.
+func (s *IOSTDevSDK) SetMetricsCollector(m MetricsCollector) {
+	s.metrics = m
+}
+
+func shortMethod(method string) string {
+	return method[strings.LastIndex(method, "/")+1:]
+}
+
+func (s *IOSTDevSDK) observeCall(method string, err error, start time.Time) {
+	if s.metrics != nil {
+		s.metrics.ObserveCall(shortMethod(method), err, time.Since(start))
+	}
+}
+
+func (s *IOSTDevSDK) observeRetry(method string, err error) {
+	if s.metrics != nil {
+		s.metrics.ObserveRetry(shortMethod(method), err)
+	}
+}
+
+func (s *IOSTDevSDK) observeTx(err error, start time.Time) {
+	if s.metrics != nil {
+		s.metrics.ObserveTx(err, time.Since(start))
+	}
+}
+
+func (s *IOSTDevSDK) observeReconnect(kind string, server string) {
+	if s.metrics != nil {
+		s.metrics.ObserveReconnect(kind, server)
+	}
+}
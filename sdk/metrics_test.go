@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingCollector struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (c *recordingCollector) record(a ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, fmt.Sprint(a...))
+}
+
+func (c *recordingCollector) ObserveCall(method string, err error, d time.Duration) {
+	c.record("call ", method, " ", CodeOf(err))
+}
+
+func (c *recordingCollector) ObserveRetry(method string, err error) {
+	c.record("retry ", method, " ", CodeOf(err))
+}
+
+func (c *recordingCollector) ObserveTx(err error, d time.Duration) {
+	c.record("tx ", CodeOf(err))
+}
+
+func (c *recordingCollector) ObserveReconnect(kind string, server string) {
+	c.record("reconnect ", kind)
+}
+
+func TestMetricsCollector(t *testing.T) {
+	ts := newGatewayServer(t, &gatewayNode{})
+	defer ts.Close()
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	s := NewIOSTDevSDK()
+	s.SetServer(ts.URL)
+	s.SetAccount("alice", kp)
+	s.SetCheckResult(false, 0, 0)
+	s.SetClockPolicy(ClockPolicy{})
+	s.SetRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	c := &recordingCollector{}
+	s.SetMetricsCollector(c)
+	ctx := context.Background()
+
+	_, err = s.GetChainInfoCtx(ctx)
+	assert.Nil(t, err)
+	_, err = s.GetTxByHashCtx(ctx, "a")
+	assert.NotNil(t, err)
+	_, err = s.SendTxFromActionsCtx(ctx, []*rpcpb.Action{NewAction("token.iost", "transfer", `["iost","alice","bob","1",""]`)})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"retry GetChainInfo E_NODE_UNAVAILABLE",
+		"call GetChainInfo ",
+		"call GetTxByHash E_TX_NOT_FOUND",
+		"call SendTransaction ",
+		"tx ",
+	}, c.events)
+}
@@ -0,0 +1,281 @@
+// Package multisig coordinates the signing of a tx by the keys of several signers, checking the signatures against
+// the permissions of the signers on chain:
+//
+//	session, err := multisig.NewSession(ctx, tx, s)
+//	err = session.AddSignature(sigOfAlice)
+//	for _, r := range session.Status() {
+//		fmt.Println(r.Signer, "needs", r.Remaining(), "more weight")
+//	}
+//	signed, err := session.FinalizeWith("payer", payerSigner)
+//	hash, err := s.SendTransaction(signed)
+//
+// The permissions are read once when the session is created, so that the signatures are checked offline afterwards.
+package multisig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// AccountSource reads the accounts of the signers and their permissions, which the sdk implements.
+type AccountSource interface {
+	GetAccountInfoCtx(ctx context.Context, id string) (*rpcpb.Account, error)
+}
+
+// Requirement is how far a signer of the tx is from being authorized by the signatures so far.
+type Requirement struct {
+	// Signer is the permission signing the tx, like "alice@active".
+	Signer string
+	// Threshold is the weight the permission needs, and Weight the weight of the signatures so far.
+	Threshold int64
+	Weight    int64
+	// Satisfied tells whether the signer is authorized, which it may be by the permission the chain falls back to,
+	// like owner for active, without its own weight reaching its threshold.
+	Satisfied bool
+}
+
+// Remaining returns the weight still needed by the permission, 0 once satisfied.
+func (r Requirement) Remaining() int64 {
+	if r.Satisfied || r.Weight >= r.Threshold {
+		return 0
+	}
+	return r.Threshold - r.Weight
+}
+
+// Session collects the signatures of the signers of a tx until they are all authorized. It is not safe for
+// concurrent use.
+type Session struct {
+	tx       *rpcpb.TransactionRequest
+	accounts map[string]*rpcpb.Account
+	// keys are the public keys in the permissions of the signers, base58 encoded like in the permissions
+	keys map[string]bool
+}
+
+// NewSession starts a session of the unsigned tx, reading the permissions of its signers and of the accounts they
+// delegate to. The signatures already attached to the tx are checked like those added later.
+func NewSession(ctx context.Context, tx *rpcpb.TransactionRequest, src AccountSource) (*Session, error) {
+	if len(tx.Signers) == 0 {
+		return nil, fmt.Errorf("tx has no signer")
+	}
+	if len(tx.PublisherSigs) != 0 {
+		return nil, fmt.Errorf("tx already signed by the publisher, whose signature would be invalidated")
+	}
+	var pending []string
+	for _, signer := range tx.Signers {
+		id, _, err := splitSigner(signer)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, id)
+	}
+	s := &Session{
+		tx:       proto.Clone(tx).(*rpcpb.TransactionRequest),
+		accounts: make(map[string]*rpcpb.Account),
+		keys:     make(map[string]bool),
+	}
+	s.tx.Signatures = nil
+	for len(pending) != 0 {
+		id := pending[0]
+		pending = pending[1:]
+		if _, ok := s.accounts[id]; ok {
+			continue
+		}
+		acc, err := src.GetAccountInfoCtx(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get permissions of %v: %v", id, err)
+		}
+		s.accounts[id] = acc
+		for _, item := range items(acc) {
+			if item.IsKeyPair {
+				s.keys[item.Id] = true
+			} else if !isContract(item.Id) {
+				pending = append(pending, item.Id)
+			}
+		}
+	}
+	for _, sig := range tx.Signatures {
+		if err := s.AddSignature(sig); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func splitSigner(signer string) (string, string, error) {
+	parts := strings.Split(signer, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("signer %v should be account@permission", signer)
+	}
+	return parts[0], parts[1], nil
+}
+
+// isContract tells whether the id is of a contract, which the chain authorizes by the calling contract only.
+func isContract(id string) bool {
+	return strings.HasPrefix(id, "Contract") || strings.Contains(id, ".")
+}
+
+// items returns the items of all the permissions and groups of the account.
+func items(acc *rpcpb.Account) []*rpcpb.Account_Item {
+	var ret []*rpcpb.Account_Item
+	for _, p := range acc.Permissions {
+		ret = append(ret, p.Items...)
+	}
+	for _, g := range acc.Groups {
+		ret = append(ret, g.Items...)
+	}
+	return ret
+}
+
+// AddSignature adds the signature of a key, which should sign the tx and be in the permissions of a signer. A key
+// signs once.
+func (s *Session) AddSignature(sig *rpcpb.Signature) error {
+	key := common.Base58Encode(sig.PublicKey)
+	for _, other := range s.tx.Signatures {
+		if bytes.Equal(other.PublicKey, sig.PublicKey) {
+			return fmt.Errorf("duplicated signature of public key %v", key)
+		}
+	}
+	if !s.keys[key] {
+		return fmt.Errorf("public key %v is in no permission of the signers %v", key, s.tx.Signers)
+	}
+	if !sdk.VerifySigForTx(s.tx, sig) {
+		return fmt.Errorf("invalid signature of public key %v", key)
+	}
+	s.tx.Signatures = append(s.tx.Signatures, sig)
+	return nil
+}
+
+// Sign adds the signature of the signer, see `AddSignature`.
+func (s *Session) Sign(signer sdk.Signer) error {
+	sig, err := sign(signer, sdk.TxHashForSign(s.tx))
+	if err != nil {
+		return err
+	}
+	return s.AddSignature(sig)
+}
+
+func sign(signer sdk.Signer, hash []byte) (*rpcpb.Signature, error) {
+	sig, err := signer.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Signature{
+		Algorithm: rpcpb.Signature_Algorithm(signer.Algorithm()),
+		Signature: sig,
+		PublicKey: signer.PubKey(),
+	}, nil
+}
+
+// Status returns how far each signer of the tx is from being authorized, in the order of the signers.
+func (s *Session) Status() []Requirement {
+	signed := make(map[string]bool, len(s.tx.Signatures))
+	for _, sig := range s.tx.Signatures {
+		signed[common.Base58Encode(sig.PublicKey)] = true
+	}
+	ret := make([]Requirement, 0, len(s.tx.Signers))
+	for _, signer := range s.tx.Signers {
+		id, perm, _ := splitSigner(signer)
+		weight, threshold, ok := s.auth(id, perm, signed, make(map[string]bool))
+		ret = append(ret, Requirement{Signer: signer, Threshold: threshold, Weight: weight, Satisfied: ok})
+	}
+	return ret
+}
+
+// auth computes whether the permission of the account is authorized by the signed keys the way the chain does,
+// along with the weight of the permission and its threshold. A missing permission falls back to active, and active
+// to owner.
+func (s *Session) auth(id string, perm string, signed map[string]bool, reenter map[string]bool) (int64, int64, bool) {
+	if reenter[id+"@"+perm] {
+		return 0, 0, false
+	}
+	reenter[id+"@"+perm] = true
+	acc := s.accounts[id]
+	if acc == nil {
+		return 0, 0, false
+	}
+	p, ok := acc.Permissions[perm]
+	if !ok {
+		if perm == "owner" || perm == "active" {
+			return 0, 0, false
+		}
+		return s.auth(id, "active", signed, reenter)
+	}
+	items := p.Items
+	for _, g := range p.GroupNames {
+		if grp, ok := acc.Groups[g]; ok {
+			items = append(items, grp.Items...)
+		}
+	}
+	var weight int64
+	for _, item := range items {
+		if item.IsKeyPair {
+			if signed[item.Id] {
+				weight += item.Weight
+			}
+		} else if _, _, ok := s.auth(item.Id, item.Permission, signed, reenter); ok {
+			weight += item.Weight
+		}
+		if weight >= p.Threshold {
+			return weight, p.Threshold, true
+		}
+	}
+	switch perm {
+	case "owner":
+		return weight, p.Threshold, false
+	case "active":
+		_, _, ok = s.auth(id, "owner", signed, reenter)
+	default:
+		_, _, ok = s.auth(id, "active", signed, reenter)
+	}
+	return weight, p.Threshold, ok
+}
+
+// Complete tells whether all the signers are authorized.
+func (s *Session) Complete() bool {
+	for _, r := range s.Status() {
+		if !r.Satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// Tx returns the tx with the signatures so far, to be passed on to the other signers.
+func (s *Session) Tx() *rpcpb.TransactionRequest {
+	return proto.Clone(s.tx).(*rpcpb.TransactionRequest)
+}
+
+// Finalize returns the tx with the signatures once all the signers are authorized, to be published by `SendTx` of
+// the sdk signing it as the publisher.
+func (s *Session) Finalize() (*rpcpb.TransactionRequest, error) {
+	for _, r := range s.Status() {
+		if !r.Satisfied {
+			return nil, fmt.Errorf("signer %v needs %v more weight of %v", r.Signer, r.Remaining(), r.Threshold)
+		}
+	}
+	return s.Tx(), nil
+}
+
+// FinalizeWith returns the tx with the signatures, signed by the publisher too, which can be broadcast as is by
+// `SendTransaction` of the sdk.
+func (s *Session) FinalizeWith(publisher string, signer sdk.Signer) (*rpcpb.TransactionRequest, error) {
+	t, err := s.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := sign(signer, sdk.TxHashForPublish(t))
+	if err != nil {
+		return nil, err
+	}
+	if err := sdk.AttachPublisherSignature(t, publisher, sig); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
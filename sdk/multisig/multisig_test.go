@@ -0,0 +1,157 @@
+package multisig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+type accounts map[string]*rpcpb.Account
+
+func (a accounts) GetAccountInfoCtx(ctx context.Context, id string) (*rpcpb.Account, error) {
+	acc, ok := a[id]
+	if !ok {
+		return nil, fmt.Errorf("account %v not exists", id)
+	}
+	return acc, nil
+}
+
+func newSigner(t *testing.T) *sdk.KeyPairSigner {
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	return sdk.NewKeyPairSigner(kp)
+}
+
+func keyItem(s sdk.Signer, weight int64) *rpcpb.Account_Item {
+	return &rpcpb.Account_Item{Id: common.Base58Encode(s.PubKey()), IsKeyPair: true, Weight: weight}
+}
+
+func newTx(signers ...string) *rpcpb.TransactionRequest {
+	return &rpcpb.TransactionRequest{
+		Time:       1,
+		Expiration: 2,
+		GasRatio:   1,
+		GasLimit:   100000,
+		ChainId:    1024,
+		Actions:    []*rpcpb.Action{sdk.NewAction("token.iost", "transfer", `["iost", "a", "b", "1", ""]`)},
+		Signers:    signers,
+	}
+}
+
+func TestSession(t *testing.T) {
+	k1, k2, k3, owner := newSigner(t), newSigner(t), newSigner(t), newSigner(t)
+	src := accounts{"a": {
+		Name: "a",
+		Permissions: map[string]*rpcpb.Account_Permission{
+			"owner":  {Name: "owner", Items: []*rpcpb.Account_Item{keyItem(owner, 1)}, Threshold: 1},
+			"active": {Name: "active", Items: []*rpcpb.Account_Item{keyItem(k1, 1), keyItem(k2, 1), keyItem(k3, 2)}, Threshold: 3},
+		},
+	}}
+	s, err := NewSession(context.Background(), newTx("a@active"), src)
+	assert.Nil(t, err)
+	assert.Equal(t, []Requirement{{Signer: "a@active", Threshold: 3}}, s.Status())
+
+	assert.Nil(t, s.Sign(k1))
+	assert.Contains(t, s.Sign(k1).Error(), "duplicated signature")
+	assert.Contains(t, s.Sign(newSigner(t)).Error(), "in no permission")
+	r := s.Status()[0]
+	assert.Equal(t, int64(1), r.Weight)
+	assert.Equal(t, int64(2), r.Remaining())
+	assert.False(t, s.Complete())
+	_, err = s.Finalize()
+	assert.Contains(t, err.Error(), "needs 2 more weight")
+
+	// the partially signed tx goes on to the next signer
+	s, err = NewSession(context.Background(), s.Tx(), src)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Sign(k3))
+	assert.True(t, s.Complete())
+	tx, err := s.Finalize()
+	assert.Nil(t, err)
+	assert.Len(t, tx.Signatures, 2)
+	for _, sig := range tx.Signatures {
+		assert.True(t, sdk.VerifySigForTx(tx, sig))
+	}
+
+	payer := newSigner(t)
+	tx, err = s.FinalizeWith("payer", payer)
+	assert.Nil(t, err)
+	assert.Equal(t, "payer", tx.Publisher)
+	assert.Len(t, tx.PublisherSigs, 1)
+	_, err = NewSession(context.Background(), tx, src)
+	assert.NotNil(t, err)
+}
+
+func TestSessionInvalidSignature(t *testing.T) {
+	k1 := newSigner(t)
+	src := accounts{"a": {Permissions: map[string]*rpcpb.Account_Permission{
+		"active": {Items: []*rpcpb.Account_Item{keyItem(k1, 1)}, Threshold: 1},
+	}}}
+	tx := newTx("a@active")
+	s, err := NewSession(context.Background(), tx, src)
+	assert.Nil(t, err)
+	hash := sdk.TxHashForSign(tx)
+	hash[0]++
+	b, err := k1.Sign(hash)
+	assert.Nil(t, err)
+	err = s.AddSignature(&rpcpb.Signature{Algorithm: rpcpb.Signature_ED25519, Signature: b, PublicKey: k1.PubKey()})
+	assert.Contains(t, err.Error(), "invalid signature")
+
+	_, err = NewSession(context.Background(), newTx("b@active"), src)
+	assert.Contains(t, err.Error(), "failed to get permissions of b")
+	_, err = NewSession(context.Background(), newTx("a"), src)
+	assert.NotNil(t, err)
+}
+
+func TestSessionPermissionTree(t *testing.T) {
+	k1, k2, owner, bkey := newSigner(t), newSigner(t), newSigner(t), newSigner(t)
+	src := accounts{
+		"a": {
+			Permissions: map[string]*rpcpb.Account_Permission{
+				"owner":  {Items: []*rpcpb.Account_Item{keyItem(owner, 1)}, Threshold: 1},
+				"active": {Items: []*rpcpb.Account_Item{keyItem(k1, 1)}, Threshold: 2},
+				"vote": {
+					GroupNames: []string{"team"},
+					Items:      []*rpcpb.Account_Item{{Id: "b", Permission: "active", Weight: 1}},
+					Threshold:  2,
+				},
+			},
+			Groups: map[string]*rpcpb.Account_Group{"team": {Items: []*rpcpb.Account_Item{keyItem(k2, 1)}}},
+		},
+		"b": {Permissions: map[string]*rpcpb.Account_Permission{
+			"active": {Items: []*rpcpb.Account_Item{keyItem(bkey, 1)}, Threshold: 1},
+		}},
+	}
+
+	// an account item and a group make up a custom permission
+	s, err := NewSession(context.Background(), newTx("a@vote"), src)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Sign(k2))
+	assert.False(t, s.Complete())
+	assert.Nil(t, s.Sign(bkey))
+	assert.Equal(t, []Requirement{{Signer: "a@vote", Threshold: 2, Weight: 2, Satisfied: true}}, s.Status())
+
+	// the owner stands in for active
+	s, err = NewSession(context.Background(), newTx("a@active"), src)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Sign(owner))
+	assert.Equal(t, []Requirement{{Signer: "a@active", Threshold: 2, Weight: 0, Satisfied: true}}, s.Status())
+	assert.Equal(t, int64(0), s.Status()[0].Remaining())
+
+	// a missing permission falls back to active
+	s, err = NewSession(context.Background(), newTx("a@transfer", "b@active"), src)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Sign(bkey))
+	st := s.Status()
+	assert.False(t, st[0].Satisfied)
+	assert.True(t, st[1].Satisfied)
+	assert.Nil(t, s.Sign(owner))
+	assert.True(t, s.Complete())
+}
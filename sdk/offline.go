@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+)
+
+// TxParams are the fields of a tx built offline, which are all given explicitly as no node is asked.
+type TxParams struct {
+	ChainID uint32
+	// Time is when the tx is created and Expiration when it expires, both in unix nanoseconds. A Time of 0 is now.
+	Time       int64
+	Expiration int64
+	// Delay is how long the tx is delayed before being executed.
+	Delay       time.Duration
+	GasLimit    float64
+	GasRatio    float64
+	AmountLimit []*rpcpb.AmountLimit
+	// Signers are the permissions signing the tx besides the publisher, like "admin@active".
+	Signers []string
+}
+
+// BuildUnsignedTx builds a tx of the actions without any network call, to be signed by `AttachSignature` and
+// `AttachPublisherSignature`.
+func BuildUnsignedTx(p TxParams, actions ...*rpcpb.Action) (*rpcpb.TransactionRequest, error) {
+	if p.ChainID == 0 {
+		return nil, fmt.Errorf("chain id should be given")
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no action")
+	}
+	if len(p.AmountLimit) == 0 {
+		return nil, fmt.Errorf("empty amount limit")
+	}
+	if p.GasLimit <= 0 || p.GasRatio <= 0 {
+		return nil, fmt.Errorf("gas limit and gas ratio should be positive")
+	}
+	txTime := p.Time
+	if txTime == 0 {
+		txTime = time.Now().UnixNano()
+	}
+	if p.Expiration <= txTime {
+		return nil, fmt.Errorf("expiration %v should be after the tx time %v", p.Expiration, txTime)
+	}
+	signers := append([]string{}, p.Signers...)
+	return &rpcpb.TransactionRequest{
+		Time:          txTime,
+		Actions:       actions,
+		Signers:       signers,
+		GasLimit:      p.GasLimit,
+		GasRatio:      p.GasRatio,
+		Expiration:    p.Expiration,
+		PublisherSigs: []*rpcpb.Signature{},
+		Delay:         int64(p.Delay),
+		ChainId:       p.ChainID,
+		AmountLimit:   p.AmountLimit,
+		Signatures:    []*rpcpb.Signature{},
+	}, nil
+}
+
+// TxHashForPublish returns the hash which the publisher of the tx signs, covering the signatures of the signers
+// which should thus be attached first.
+func TxHashForPublish(t *rpcpb.TransactionRequest) []byte {
+	return common.Sha3(txToBytes(t, true))
+}
+
+// AttachSignature adds the signature of a signer of the tx, made over `TxHashForSign`, replacing any earlier
+// signature of the same key. It fails if the signature does not verify.
+func AttachSignature(t *rpcpb.TransactionRequest, sig *rpcpb.Signature) error {
+	if len(t.PublisherSigs) != 0 {
+		return fmt.Errorf("tx already signed by the publisher, whose signature would be invalidated")
+	}
+	if !VerifySigForTx(t, sig) {
+		return fmt.Errorf("invalid signature of public key %v", common.Base58Encode(sig.PublicKey))
+	}
+	t.Signatures = append(withoutSigOf(t.Signatures, sig.PublicKey), sig)
+	return nil
+}
+
+// AttachPublisherSignature sets the publisher of the tx and its signature, made over `TxHashForPublish`. It fails
+// if the signature does not verify.
+func AttachPublisherSignature(t *rpcpb.TransactionRequest, publisher string, sig *rpcpb.Signature) error {
+	if publisher == "" {
+		return fmt.Errorf("publisher should be given")
+	}
+	if !GetSignAlgoByEnum(sig.Algorithm).Verify(TxHashForPublish(t), sig.PublicKey, sig.Signature) {
+		return fmt.Errorf("invalid publisher signature of public key %v", common.Base58Encode(sig.PublicKey))
+	}
+	t.Publisher = publisher
+	t.PublisherSigs = []*rpcpb.Signature{sig}
+	return nil
+}
+
+// MarshalSignedTx encodes a tx signed by its publisher as json, in the format of `SaveProtoStructToJSONFile`, to be
+// sent as is later by `SendTransaction` of a connected sdk. It fails if the publisher signature is missing or does
+// not verify.
+func MarshalSignedTx(t *rpcpb.TransactionRequest) ([]byte, error) {
+	if t.Publisher == "" || len(t.PublisherSigs) == 0 {
+		return nil, fmt.Errorf("tx not signed by the publisher")
+	}
+	hash := TxHashForPublish(t)
+	for _, sig := range t.PublisherSigs {
+		if !GetSignAlgoByEnum(sig.Algorithm).Verify(hash, sig.PublicKey, sig.Signature) {
+			return nil, fmt.Errorf("invalid publisher signature of public key %v", common.Base58Encode(sig.PublicKey))
+		}
+	}
+	r, err := (&jsonpb.Marshaler{EmitDefaults: true, Indent: "    "}).MarshalToString(t)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r), nil
+}
+
+// UnmarshalSignedTx decodes a tx encoded by `MarshalSignedTx`.
+func UnmarshalSignedTx(data []byte) (*rpcpb.TransactionRequest, error) {
+	t := &rpcpb.TransactionRequest{}
+	if err := jsonpb.UnmarshalString(string(data), t); err != nil {
+		return nil, fmt.Errorf("not a valid tx json: %v", err)
+	}
+	return t, nil
+}
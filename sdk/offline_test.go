@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineTx(t *testing.T) {
+	p := TxParams{
+		ChainID:     1024,
+		Time:        1000,
+		Expiration:  2000,
+		GasLimit:    100000,
+		GasRatio:    1,
+		AmountLimit: []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
+		Signers:     []string{"bob@active"},
+	}
+	action := NewAction("token.iost", "transfer", `["iost","alice","bob","1",""]`)
+	tx, err := BuildUnsignedTx(p, action)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), tx.Time)
+	assert.Equal(t, []string{"bob@active"}, tx.Signers)
+
+	bob, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	alice, err := account.NewKeyPair(nil, crypto.Secp256k1)
+	assert.Nil(t, err)
+
+	// the signature of the signer is attached first, then that of the publisher which covers it
+	assert.NotNil(t, AttachSignature(tx, toRPCSign(bob.Sign(TxHashForPublish(tx)))))
+	assert.Nil(t, AttachSignature(tx, GetSignatureOfTx(tx, bob)))
+	assert.Nil(t, AttachSignature(tx, GetSignatureOfTx(tx, bob)))
+	assert.Len(t, tx.Signatures, 1)
+	_, err = MarshalSignedTx(tx)
+	assert.EqualError(t, err, "tx not signed by the publisher")
+	assert.NotNil(t, AttachPublisherSignature(tx, "alice", toRPCSign(alice.Sign(TxHashForSign(tx)))))
+	assert.Nil(t, AttachPublisherSignature(tx, "alice", toRPCSign(alice.Sign(TxHashForPublish(tx)))))
+	assert.NotNil(t, AttachSignature(tx, GetSignatureOfTx(tx, bob)))
+
+	data, err := MarshalSignedTx(tx)
+	assert.Nil(t, err)
+	got, err := UnmarshalSignedTx(data)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Base58Encode(TxHash(tx)), common.Base58Encode(TxHash(got)))
+	assert.Equal(t, "alice", got.Publisher)
+
+	// a publisher signature no longer matching the tx is refused
+	got.GasLimit = 200000
+	_, err = MarshalSignedTx(got)
+	assert.Contains(t, err.Error(), "invalid publisher signature")
+	_, err = UnmarshalSignedTx([]byte("{"))
+	assert.NotNil(t, err)
+}
+
+func TestBuildUnsignedTxParams(t *testing.T) {
+	p := TxParams{
+		ChainID:     1024,
+		Expiration:  2000,
+		GasLimit:    100000,
+		GasRatio:    1,
+		AmountLimit: []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
+	}
+	action := NewAction("token.iost", "transfer", `[]`)
+	// the time is now if not given, which is after the expiration
+	_, err := BuildUnsignedTx(p, action)
+	assert.Contains(t, err.Error(), "expiration 2000 should be after the tx time")
+
+	p.Time = 1000
+	_, err = BuildUnsignedTx(p)
+	assert.EqualError(t, err, "no action")
+	for _, change := range []func(p *TxParams){
+		func(p *TxParams) { p.ChainID = 0 },
+		func(p *TxParams) { p.AmountLimit = nil },
+		func(p *TxParams) { p.GasRatio = 0 },
+	} {
+		q := p
+		change(&q)
+		_, err = BuildUnsignedTx(q, action)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package prommetrics exports the metrics of the sdk to Prometheus.
+//
+//	c := prommetrics.New("myservice")
+//	prometheus.MustRegister(c)
+//	s.SetMetricsCollector(c)
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CodeOK is the code label of the calls and txs which succeeded, the others are labeled by `sdk.CodeOf`.
+const CodeOK = "OK"
+
+// Collector is a `sdk.MetricsCollector` which is also a `prometheus.Collector` of the metrics it observes.
+type Collector struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	retries         *prometheus.CounterVec
+	txs             *prometheus.CounterVec
+	txDuration      prometheus.Histogram
+	reconnects      *prometheus.CounterVec
+}
+
+// New returns a collector whose metrics are named like <namespace>_sdk_requests_total, without prefix if the
+// namespace is empty.
+func New(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "requests_total",
+			Help:      "Calls to the node by rpc method and error code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the calls to the node including their retries, by rpc method.",
+		}, []string{"method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "retries_total",
+			Help:      "Calls to the node retried by rpc method and error code of the failed try.",
+		}, []string{"method", "code"}),
+		txs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "txs_total",
+			Help:      "Transactions sent by outcome.",
+		}, []string{"code"}),
+		txDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "tx_duration_seconds",
+			Help:      "Time from signing a transaction to knowing its outcome.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+		}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "reconnects_total",
+			Help:      "Reconnections to the node by kind and server.",
+		}, []string{"kind", "server"}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.requestDuration, c.retries, c.txs, c.txDuration, c.reconnects}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+func code(err error) string {
+	if err == nil {
+		return CodeOK
+	}
+	return string(sdk.CodeOf(err))
+}
+
+// ObserveCall implements sdk.MetricsCollector.
+func (c *Collector) ObserveCall(method string, err error, d time.Duration) {
+	c.requests.WithLabelValues(method, code(err)).Inc()
+	c.requestDuration.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// ObserveRetry implements sdk.MetricsCollector.
+func (c *Collector) ObserveRetry(method string, err error) {
+	c.retries.WithLabelValues(method, code(err)).Inc()
+}
+
+// ObserveTx implements sdk.MetricsCollector.
+func (c *Collector) ObserveTx(err error, d time.Duration) {
+	c.txs.WithLabelValues(code(err)).Inc()
+	c.txDuration.Observe(d.Seconds())
+}
+
+// ObserveReconnect implements sdk.MetricsCollector.
+func (c *Collector) ObserveReconnect(kind string, server string) {
+	c.reconnects.WithLabelValues(kind, server).Inc()
+}
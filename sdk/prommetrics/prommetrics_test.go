@@ -0,0 +1,53 @@
+package prommetrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	c := New("test")
+	r := prometheus.NewRegistry()
+	assert.Nil(t, r.Register(c))
+
+	c.ObserveCall("GetChainInfo", nil, time.Millisecond)
+	c.ObserveCall("GetChainInfo", nil, time.Millisecond)
+	c.ObserveCall("GetTxByHash", errors.New("tx not found"), time.Millisecond)
+	c.ObserveRetry("GetChainInfo", errors.New("rpc error: code = Unavailable desc = node busy"))
+	c.ObserveTx(errors.New("rpc error: code = Unknown desc = TimeError"), time.Second)
+	c.ObserveReconnect(sdk.ReconnectFailover, "localhost:30002")
+
+	families, err := r.Gather()
+	assert.Nil(t, err)
+	values := make(map[string]float64)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			// the labels are sorted by name
+			name := f.GetName()
+			for _, l := range m.GetLabel() {
+				name += " " + l.GetValue()
+			}
+			switch {
+			case m.Counter != nil:
+				values[name] = m.Counter.GetValue()
+			case m.Histogram != nil:
+				values[name] = float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"test_sdk_requests_total OK GetChainInfo":                2,
+		"test_sdk_requests_total E_TX_NOT_FOUND GetTxByHash":     1,
+		"test_sdk_request_duration_seconds GetChainInfo":         2,
+		"test_sdk_request_duration_seconds GetTxByHash":          1,
+		"test_sdk_retries_total E_NODE_UNAVAILABLE GetChainInfo": 1,
+		"test_sdk_txs_total E_TX_EXPIRED":                        1,
+		"test_sdk_tx_duration_seconds":                           1,
+		"test_sdk_reconnects_total failover localhost:30002":     1,
+	}, values)
+}
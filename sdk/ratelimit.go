@@ -0,0 +1,142 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimit caps the calls the sdk makes to the nodes, so that bulk jobs like airdrops and indexers are not banned by
+// public nodes. Calls over the limits wait for their turn, or fail once their context is done. Each retry of a call
+// counts as a call, while subscriptions are not limited as they are long lived.
+type RateLimit struct {
+	// QPS is how many calls are made per second at most, over all methods, 0 for no limit. Burst is how many calls can
+	// be made at once after some idle time, 1 if less.
+	QPS   float64
+	Burst int
+	// MaxInFlight is how many calls may be running at once, 0 for no limit.
+	MaxInFlight int
+	// Methods overrides QPS and Burst for the rpc methods named like GetTxByHash, which then get their own bucket
+	// instead of sharing the one of all methods.
+	Methods map[string]MethodLimit
+}
+
+// MethodLimit is the rate limit of a method, see `RateLimit`.
+type MethodLimit struct {
+	QPS   float64
+	Burst int
+}
+
+// SetRateLimit sets the limits of the calls made to the nodes, see `RateLimit`. The zero RateLimit removes them.
+func (s *IOSTDevSDK) SetRateLimit(l RateLimit) {
+	s.limiter = newRateLimiter(l)
+}
+
+// rateLimiter applies a rate limit, its buckets being shared by all the connections of the sdk.
+type rateLimiter struct {
+	all      *bucket
+	methods  map[string]*bucket
+	inFlight chan struct{}
+}
+
+func newRateLimiter(l RateLimit) *rateLimiter {
+	r := &rateLimiter{all: newBucket(l.QPS, l.Burst), methods: make(map[string]*bucket, len(l.Methods))}
+	for m, ml := range l.Methods {
+		r.methods[m] = newBucket(ml.QPS, ml.Burst)
+	}
+	if l.MaxInFlight > 0 {
+		r.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+	return r
+}
+
+// wrap returns the invoker making the calls once the limits allow.
+func (r *rateLimiter) wrap(invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if r == nil {
+		return invoker
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		b, ok := r.methods[method[strings.LastIndex(method, "/")+1:]]
+		if !ok {
+			b = r.all
+		}
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+		if r.inFlight != nil {
+			select {
+			case r.inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return contextError(ctx.Err())
+			}
+			defer func() { <-r.inFlight }()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// bucket is a token bucket of rate tokens per second holding burst tokens at most.
+type bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full bucket, or nil for no limit.
+func newBucket(qps float64, burst int) *bucket {
+	if qps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{rate: qps, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait takes a token, waiting for it if the bucket is empty. The token is given back if ctx is done first.
+func (b *bucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	// the token is taken now even if not there yet, so that the calls waiting are served in turn
+	b.tokens--
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return contextError(ctx.Err())
+	}
+}
+
+// contextError returns the error of a done context the way grpc does for the calls it cancels.
+func contextError(err error) error {
+	if err == context.DeadlineExceeded {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Canceled, err.Error())
+}
@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitQPS(t *testing.T) {
+	var calls int32
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	limited := newRateLimiter(RateLimit{QPS: 20, Burst: 2, Methods: map[string]MethodLimit{"GetChainInfo": {}}}).wrap(invoker)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, limited(ctx, "/rpcpb.ApiService/GetTxByHash", nil, nil, nil))
+	}
+	// the burst goes at once, then a call every 50ms
+	assert.True(t, time.Since(start) >= 190*time.Millisecond, time.Since(start))
+
+	// the method overridden has no limit
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, limited(ctx, "/rpcpb.ApiService/GetChainInfo", nil, nil, nil))
+	}
+	assert.True(t, time.Since(start) < 50*time.Millisecond, time.Since(start))
+
+	// a call which would wait longer than its deadline fails
+	limited = newRateLimiter(RateLimit{QPS: 1}).wrap(invoker)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Nil(t, limited(ctx, "/rpcpb.ApiService/GetTxByHash", nil, nil, nil))
+	err := limited(ctx, "/rpcpb.ApiService/GetTxByHash", nil, nil, nil)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, int32(17), atomic.LoadInt32(&calls))
+
+	assert.Nil(t, (*rateLimiter)(nil).wrap(invoker)(context.Background(), "/rpcpb.ApiService/GetTxByHash", nil, nil, nil))
+}
+
+func TestRateLimitInFlight(t *testing.T) {
+	var running, most int32
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+	limited := newRateLimiter(RateLimit{MaxInFlight: 2}).wrap(invoker)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, limited(context.Background(), "/rpcpb.ApiService/GetTxByHash", nil, nil, nil))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&most))
+}
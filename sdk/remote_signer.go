@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+)
+
+// RemoteSigner signs with a key held by a signing service, like one in front of an HSM or a KMS, so that the secret
+// key never reaches the application. The service answers json posts on two paths of its url:
+//
+//	POST /key   {"key_id": "alice@active"}                 => {"algorithm": "ed25519", "public_key": "BASE58"}
+//	POST /sign  {"key_id": "alice@active", "hash": "BASE58"} => {"signature": "BASE58"}
+//
+// Failures are answered by a status other than 200 with {"error": "..."}. `NewSignerHandler` serves this protocol.
+type RemoteSigner struct {
+	url    string
+	keyID  string
+	client *http.Client
+	header http.Header
+
+	algo   crypto.Algorithm
+	pubkey []byte
+}
+
+// RemoteSignerOptions tunes the requests of a remote signer.
+type RemoteSignerOptions struct {
+	// Client sends the requests, a client with a timeout of 30s if nil. Its transport configures tls.
+	Client *http.Client
+	// Header is added to the requests, eg to authenticate with the service.
+	Header http.Header
+}
+
+type remoteKeyRequest struct {
+	KeyID string `json:"key_id"`
+	Hash  string `json:"hash,omitempty"`
+}
+
+type remoteKeyResponse struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewRemoteSigner returns the signer of the key keyID of the signing service at url, asking it for the public key.
+func NewRemoteSigner(url string, keyID string, opts RemoteSignerOptions) (*RemoteSigner, error) {
+	s := &RemoteSigner{url: strings.TrimRight(url, "/"), keyID: keyID, client: opts.Client, header: opts.Header}
+	if s.client == nil {
+		s.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	res, err := s.post("/key", &remoteKeyRequest{KeyID: keyID})
+	if err != nil {
+		return nil, err
+	}
+	s.algo = GetSignAlgoByName(res.Algorithm)
+	if s.algo.String() != res.Algorithm {
+		return nil, fmt.Errorf("remote signer error: unsupported algorithm %v of key %v", res.Algorithm, keyID)
+	}
+	if s.pubkey = common.Base58Decode(res.PublicKey); len(s.pubkey) == 0 {
+		return nil, fmt.Errorf("remote signer error: no public key of key %v", keyID)
+	}
+	return s, nil
+}
+
+func (s *RemoteSigner) post(path string, req *remoteKeyRequest) (*remoteKeyResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	r, err := http.NewRequest(http.MethodPost, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range s.header {
+		r.Header[k] = v
+	}
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer error: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer error: %v", err)
+	}
+	res := &remoteKeyResponse{}
+	if err := json.Unmarshal(data, res); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("remote signer error: invalid response %q", data)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if res.Error == "" {
+			res.Error = resp.Status
+		}
+		return nil, fmt.Errorf("remote signer error: %v", res.Error)
+	}
+	return res, nil
+}
+
+// Algorithm ...
+func (s *RemoteSigner) Algorithm() crypto.Algorithm {
+	return s.algo
+}
+
+// PubKey ...
+func (s *RemoteSigner) PubKey() []byte {
+	return s.pubkey
+}
+
+// Sign asks the service to sign the hash, checking the signature it answers.
+func (s *RemoteSigner) Sign(hash []byte) ([]byte, error) {
+	res, err := s.post("/sign", &remoteKeyRequest{KeyID: s.keyID, Hash: common.Base58Encode(hash)})
+	if err != nil {
+		return nil, err
+	}
+	sig := common.Base58Decode(res.Signature)
+	if !s.algo.Verify(hash, s.pubkey, sig) {
+		return nil, fmt.Errorf("remote signer error: invalid signature of key %v", s.keyID)
+	}
+	return sig, nil
+}
+
+// NewSignerHandler serves the protocol of `RemoteSigner` with the signers by key id, for signing services wrapping
+// their keys in a `Signer`. It does not authenticate the requests, which is left to the handlers around it.
+func NewSignerHandler(signers map[string]Signer) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(path string, f func(signer Signer, req *remoteKeyRequest) (*remoteKeyResponse, error)) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			reply := func(status int, res *remoteKeyResponse) {
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(res)
+			}
+			if r.Method != http.MethodPost {
+				reply(http.StatusMethodNotAllowed, &remoteKeyResponse{Error: "method not allowed"})
+				return
+			}
+			req := &remoteKeyRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				reply(http.StatusBadRequest, &remoteKeyResponse{Error: "invalid request: " + err.Error()})
+				return
+			}
+			signer, ok := signers[req.KeyID]
+			if !ok {
+				reply(http.StatusNotFound, &remoteKeyResponse{Error: fmt.Sprintf("key %v not found", req.KeyID)})
+				return
+			}
+			res, err := f(signer, req)
+			if err != nil {
+				reply(http.StatusInternalServerError, &remoteKeyResponse{Error: err.Error()})
+				return
+			}
+			reply(http.StatusOK, res)
+		})
+	}
+	handle("/key", func(signer Signer, req *remoteKeyRequest) (*remoteKeyResponse, error) {
+		return &remoteKeyResponse{Algorithm: signer.Algorithm().String(), PublicKey: common.Base58Encode(signer.PubKey())}, nil
+	})
+	handle("/sign", func(signer Signer, req *remoteKeyRequest) (*remoteKeyResponse, error) {
+		sig, err := signer.Sign(common.Base58Decode(req.Hash))
+		if err != nil {
+			return nil, err
+		}
+		return &remoteKeyResponse{Signature: common.Base58Encode(sig)}, nil
+	})
+	return mux
+}
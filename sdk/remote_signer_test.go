@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	kp, err := account.NewKeyPair(nil, crypto.Secp256k1)
+	assert.Nil(t, err)
+	handler := NewSignerHandler(map[string]Signer{"alice@active": NewKeyPairSigner(kp)})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	opts := RemoteSignerOptions{Header: http.Header{"Authorization": {"Bearer token"}}}
+
+	s, err := NewRemoteSigner(server.URL+"/", "alice@active", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, crypto.Secp256k1, s.Algorithm())
+	assert.Equal(t, kp.Pubkey, s.PubKey())
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	sig, err := s.Sign(hash)
+	assert.Nil(t, err)
+	assert.True(t, crypto.Secp256k1.Verify(hash, kp.Pubkey, sig))
+
+	_, err = NewRemoteSigner(server.URL, "bob@active", opts)
+	assert.Contains(t, err.Error(), "key bob@active not found")
+	_, err = NewRemoteSigner(server.URL, "alice@active", RemoteSignerOptions{})
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+}
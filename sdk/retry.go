@@ -0,0 +1,297 @@
+package sdk
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy decides how the calls failing with transient errors, such as an unreachable or busy node, are retried.
+// With several servers each retry is made on the next healthy one.
+type RetryPolicy struct {
+	// MaxRetries is how many times a call is retried, 0 disables retrying.
+	MaxRetries int
+	// Methods overrides MaxRetries for the rpc methods named like GetChainInfo.
+	Methods map[string]int
+	// InitialBackoff is the wait before the first retry, which is doubled after each retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction of the backoff which is randomized, between 0 and 1, so that clients do not retry in sync.
+	Jitter float64
+	// HealthCheckInterval is how often the servers are checked again, so that a server lagging behind the others in
+	// block height is left for an up to date one. 0 disables the checks, servers are then only left when unreachable.
+	HealthCheckInterval time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of a new sdk.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:          2,
+	InitialBackoff:      200 * time.Millisecond,
+	MaxBackoff:          5 * time.Second,
+	Jitter:              0.2,
+	HealthCheckInterval: time.Minute,
+}
+
+const sendTransactionMethod = "/rpcpb.ApiService/SendTransaction"
+
+type retriesKey struct{}
+
+type noRetryKey struct{}
+
+// WithRetries overrides the retry count of the policy for the calls made with the returned context, eg
+// s.GetChainInfoCtx(sdk.WithRetries(ctx, 5)).
+func WithRetries(ctx context.Context, retries int) context.Context {
+	return context.WithValue(ctx, retriesKey{}, retries)
+}
+
+func (p *RetryPolicy) retries(ctx context.Context, method string) int {
+	if n, ok := ctx.Value(retriesKey{}).(int); ok {
+		return n
+	}
+	if n, ok := p.Methods[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return n
+	}
+	return p.MaxRetries
+}
+
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff
+	for i := 0; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// SetRetryPolicy sets how failed calls are retried, see `RetryPolicy`.
+func (s *IOSTDevSDK) SetRetryPolicy(p RetryPolicy) {
+	s.retryPolicy = p
+}
+
+// retryable tells whether the call may succeed if made again, as the node could not be reached or was busy.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return CodeOf(err) == ErrTxPoolFull
+}
+
+// retryInterceptor retries the calls failing with transient errors by the retry policy, moving on to the next healthy
+// server, which is then used for all later calls. Each try waits for the rate limit, see `SetRateLimit`. The error
+// left is classified by `WrapError`, and the call is observed by the metrics collector if any.
+func (s *IOSTDevSDK) retryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := WrapError(s.retry(ctx, method, req, reply, cc, s.track(s.limiter.wrap(invoker)), opts...))
+	s.observeCall(method, err, start)
+	return err
+}
+
+// track counts the calls in flight on the pool of their connection, so that a pool failed over is closed only once
+// they are done. A call on a connection of a pool closed meanwhile is made on the pool in use instead. The health
+// checks are made on connections of their own, while the lock may be held.
+func (s *IOSTDevSDK) track(invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Value(noRetryKey{}).(bool); ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		p := s.poolOf(cc)
+		if p != nil && !p.acquire() {
+			s.mu.RLock()
+			p = s.pool
+			s.mu.RUnlock()
+			if p == nil || p.gateway != nil || !p.acquire() {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+			cc = p.get()
+		}
+		if p != nil {
+			defer p.release()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// poolOf returns the pool of the connection, nil if it is not one of the sdk like those of the health checks.
+func (s *IOSTDevSDK) poolOf(cc *grpc.ClientConn) *connPool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.pool != nil && s.pool.has(cc) {
+		return s.pool
+	}
+	for _, p := range s.stalePools {
+		if p.has(cc) {
+			return p
+		}
+	}
+	return nil
+}
+
+// retry makes the call until it succeeds or fails with an error which is not transient, see `retryInterceptor`.
+// A tx is not sent again blindly: its hash is computed the same way as nodes do, and if the node already has the tx
+// it is not resent. Sending the same tx twice cannot execute it twice anyway, so the duplicate error of a resent tx
+// which made it to the chain meanwhile is taken as a success.
+func (s *IOSTDevSDK) retry(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Value(noRetryKey{}).(bool); ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	s.checkHealth(cc)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	retries := s.retryPolicy.retries(ctx, method)
+	for i := 0; i < retries && retryable(err); i++ {
+		wait := s.retryPolicy.backoff(i)
+		s.log("Call", method, "failed:", err, "retrying in", wait)
+		s.observeRetry(method, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		cc = s.failover(ctx, cc, err)
+		if method != sendTransactionMethod {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			continue
+		}
+		hash := common.Base58Encode(TxHash(req.(*rpcpb.TransactionRequest)))
+		if invoker(ctx, "/rpcpb.ApiService/GetTxByHash", &rpcpb.TxHashRequest{Hash: hash}, &rpcpb.TransactionResponse{}, cc, opts...) == nil {
+			s.log("Transaction", hash, "has been sent already")
+			reply.(*rpcpb.SendTransactionResponse).Hash = hash
+			return nil
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if CodeOf(err) == ErrDuplicateTx {
+			reply.(*rpcpb.SendTransactionResponse).Hash = hash
+			return nil
+		}
+	}
+	return err
+}
+
+// failover switches to the next healthy server after a connection error, if there are several. The servers are
+// checked without holding the lock, so that the other calls go on meanwhile.
+func (s *IOSTDevSDK) failover(ctx context.Context, cc *grpc.ClientConn, err error) *grpc.ClientConn {
+	if len(s.servers) < 2 || status.Code(err) != codes.Unavailable {
+		return cc
+	}
+	s.mu.RLock()
+	current, server := s.pool, s.server
+	s.mu.RUnlock()
+	if current == nil || current.gateway != nil {
+		return cc
+	}
+	if !current.has(cc) {
+		// an earlier call has already switched to another server
+		return current.get()
+	}
+	s.log("Server", server, "is unavailable:", err)
+	h, perr := s.pickServer(ctx, server)
+	if perr != nil {
+		return cc
+	}
+	pool, perr := s.dialPool(ctx, h.server, h.conn)
+	if perr != nil {
+		return cc
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pool != current {
+		// another call has switched meanwhile
+		pool.close()
+		if s.pool == nil {
+			return cc
+		}
+		return s.pool.get()
+	}
+	s.log("Switched to server", h.server)
+	s.switchPool(pool)
+	return pool.get()
+}
+
+// checkHealth checks the servers again in the background once the health check interval has passed.
+func (s *IOSTDevSDK) checkHealth(cc *grpc.ClientConn) {
+	interval := s.retryPolicy.HealthCheckInterval
+	if len(s.servers) < 2 || interval <= 0 {
+		return
+	}
+	s.mu.RLock()
+	due := s.pool != nil && s.pool.has(cc) && time.Since(s.lastHealthCheck) >= interval
+	s.mu.RUnlock()
+	if !due {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.lastHealthCheck) < interval {
+		// checked by another call meanwhile
+		return
+	}
+	s.lastHealthCheck = time.Now()
+	go s.rebalance()
+}
+
+// rebalance switches to another server if the one in use is lagging behind, or an earlier server in the list is back.
+func (s *IOSTDevSDK) rebalance() {
+	h, err := s.pickServer(context.Background(), "")
+	if err != nil {
+		return
+	}
+	if h.server == s.Server() {
+		h.conn.Close()
+		return
+	}
+	pool, err := s.dialPool(context.Background(), h.server, h.conn)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pool == nil || s.pool.gateway != nil || h.server == s.server {
+		pool.close()
+		return
+	}
+	s.log("Switched from server", s.server, "to", h.server)
+	s.switchPool(pool)
+}
+
+// staleConnGrace is how long the connections of a pool failed over are kept at least, for the calls about to be made
+// on them.
+var staleConnGrace = 10 * time.Second
+
+// switchPool uses the connections to another server for later calls. The old connections may still be in use by
+// other calls, so they are closed once these are done. The cached chain metadata is of the old server and dropped.
+func (s *IOSTDevSDK) switchPool(pool *connPool) {
+	old := s.pool
+	s.stalePools = append(s.stalePools, old)
+	old.retire(staleConnGrace, func() {
+		s.dropStalePool(old)
+	})
+	s.pool, s.server = pool, pool.server
+	s.cache.clear()
+	s.observeReconnect(ReconnectFailover, pool.server)
+}
+
+// dropStalePool forgets the pool once it is closed.
+func (s *IOSTDevSDK) dropStalePool(p *connPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, sp := range s.stalePools {
+		if sp == p {
+			s.stalePools = append(s.stalePools[:i], s.stalePools[i+1:]...)
+			return
+		}
+	}
+}
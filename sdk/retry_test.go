@@ -0,0 +1,323 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testNode is a node served over grpc on a local port, which can be stopped and started again on the same address.
+type testNode struct {
+	rpcpb.ApiServiceServer
+	addr string
+	gs   *grpc.Server
+	opts []grpc.ServerOption
+
+	mu    sync.Mutex
+	head  int64
+	calls map[string]int
+	txs   map[string]bool
+	// peers are the client addresses of the calls, one per connection
+	peers map[string]bool
+	// failures is how many of the next calls fail with failErr
+	failures int
+	failErr  error
+	// lostAnswers is how many of the next txs sent are added while the call fails, as if the answer was lost
+	lostAnswers int
+	// GetNodeInfo waits for wait to be closed if not nil
+	wait chan struct{}
+	// packedPolls is how many times the txs are found packed before they are irreversible with a receipt of
+	// receiptCode, they stay packed if 0
+	packedPolls int
+	polls       map[string]int
+	receiptCode rpcpb.TxReceipt_StatusCode
+	// the lists paged by the iterators, see iterator_test.go
+	accountTxs []string
+	producers  []string
+	fields     []string
+	// storage holds the values of the contract storage by id/key/field, which are id/key/field if not set
+	storage map[string]string
+}
+
+func newTestNode(t *testing.T, head int64, opts ...grpc.ServerOption) *testNode {
+	n := &testNode{opts: opts, head: head, calls: make(map[string]int), txs: make(map[string]bool), peers: make(map[string]bool), polls: make(map[string]int)}
+	n.start(t, "127.0.0.1:0")
+	return n
+}
+
+func (n *testNode) start(t *testing.T, addr string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.addr = lis.Addr().String()
+	n.gs = grpc.NewServer(append([]grpc.ServerOption{grpc.UnaryInterceptor(n.intercept)}, n.opts...)...)
+	rpcpb.RegisterApiServiceServer(n.gs, n)
+	go n.gs.Serve(lis)
+}
+
+func (n *testNode) stop() {
+	n.gs.Stop()
+}
+
+func (n *testNode) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	n.mu.Lock()
+	n.calls[info.FullMethod]++
+	if p, ok := peer.FromContext(ctx); ok {
+		n.peers[p.Addr.String()] = true
+	}
+	fail := n.failures > 0
+	if fail {
+		n.failures--
+	}
+	n.mu.Unlock()
+	if fail {
+		return nil, n.failErr
+	}
+	return handler(ctx, req)
+}
+
+func (n *testNode) failNext(failures int, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failures, n.failErr = failures, err
+}
+
+func (n *testNode) setHead(head int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.head = head
+}
+
+func (n *testNode) callCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls["/rpcpb.ApiService/"+method]
+}
+
+func (n *testNode) GetChainInfo(ctx context.Context, req *rpcpb.EmptyRequest) (*rpcpb.ChainInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &rpcpb.ChainInfoResponse{ChainId: 1024, HeadBlock: n.head}, nil
+}
+
+func (n *testNode) GetNodeInfo(ctx context.Context, req *rpcpb.EmptyRequest) (*rpcpb.NodeInfoResponse, error) {
+	n.mu.Lock()
+	wait := n.wait
+	n.mu.Unlock()
+	if wait != nil {
+		<-wait
+	}
+	return &rpcpb.NodeInfoResponse{BuildTime: n.addr}, nil
+}
+
+func (n *testNode) SendTransaction(ctx context.Context, req *rpcpb.TransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	hash := common.Base58Encode(TxHash(req))
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.txs[hash] {
+		return nil, status.Error(codes.Unknown, "duplicate tx")
+	}
+	n.txs[hash] = true
+	if n.lostAnswers > 0 {
+		n.lostAnswers--
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	return &rpcpb.SendTransactionResponse{Hash: hash}, nil
+}
+
+func (n *testNode) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TransactionResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.txs[req.Hash] {
+		return nil, status.Error(codes.NotFound, "tx not found")
+	}
+	if n.packedPolls == 0 || n.polls[req.Hash] < n.packedPolls {
+		n.polls[req.Hash]++
+		return &rpcpb.TransactionResponse{Status: rpcpb.TransactionResponse_PACKED}, nil
+	}
+	return &rpcpb.TransactionResponse{
+		Status:      rpcpb.TransactionResponse_IRREVERSIBLE,
+		Transaction: &rpcpb.Transaction{Hash: req.Hash, TxReceipt: &rpcpb.TxReceipt{TxHash: req.Hash, StatusCode: n.receiptCode}},
+	}, nil
+}
+
+// testRetryPolicy retries quickly and checks no server in the background.
+var testRetryPolicy = RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+func newTestSDK(t *testing.T, nodes ...*testNode) *IOSTDevSDK {
+	s := NewIOSTDevSDK()
+	servers := ""
+	for i, n := range nodes {
+		if i > 0 {
+			servers += ","
+		}
+		servers += n.addr
+	}
+	s.SetServer(servers)
+	s.SetRetryPolicy(testRetryPolicy)
+	assert.Nil(t, s.Connect())
+	return s
+}
+
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRetryFailover(t *testing.T) {
+	a, b := newTestNode(t, 100), newTestNode(t, 100)
+	defer b.stop()
+	s := newTestSDK(t, a, b)
+	defer s.CloseConn()
+	assert.Equal(t, a.addr, s.Server())
+
+	// the first server goes down, the call is made again on the second one, which is used afterwards
+	a.stop()
+	info, err := s.GetChainInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), info.HeadBlock)
+	assert.Equal(t, b.addr, s.Server())
+
+	// a busy server is retried, not left
+	b.failNext(1, status.Error(codes.ResourceExhausted, "busy"))
+	_, err = s.GetChainInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, b.addr, s.Server())
+
+	// once the second server goes down too, the first one back is used
+	a.start(t, a.addr)
+	defer a.stop()
+	b.stop()
+	_, err = s.GetChainInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, a.addr, s.Server())
+}
+
+func TestRetryNotRetried(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	n.failNext(1, status.Error(codes.InvalidArgument, "invalid"))
+	_, err := s.GetChainInfo()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, n.callCount("GetChainInfo"))
+
+	n.failNext(1, status.Error(codes.Unavailable, "down"))
+	_, err = s.GetChainInfoCtx(WithRetries(context.Background(), 0))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, n.callCount("GetChainInfo"))
+
+	n.failNext(3, status.Error(codes.Unavailable, "down"))
+	_, err = s.GetChainInfo()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 5, n.callCount("GetChainInfo"))
+}
+
+func TestRetrySendTransaction(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+	newTx := func(i int64) *rpcpb.TransactionRequest {
+		return &rpcpb.TransactionRequest{Time: i, Publisher: "alice", GasLimit: 100000, GasRatio: 1, ChainId: 1024}
+	}
+
+	// the tx made it to the node but the answer was lost: it is found by its hash and not sent again
+	n.mu.Lock()
+	n.lostAnswers = 1
+	n.mu.Unlock()
+	hash, err := s.SendTransaction(newTx(1))
+	assert.Nil(t, err)
+	assert.Equal(t, common.Base58Encode(TxHash(newTx(1))), hash)
+	assert.Equal(t, 1, n.callCount("SendTransaction"))
+	assert.Equal(t, 1, n.callCount("GetTxByHash"))
+
+	// the tx did not make it to the node: it is not found and sent again
+	n.failNext(1, status.Error(codes.Unavailable, "down"))
+	hash, err = s.SendTransaction(newTx(2))
+	assert.Nil(t, err)
+	assert.Equal(t, common.Base58Encode(TxHash(newTx(2))), hash)
+	assert.Equal(t, 3, n.callCount("SendTransaction"))
+	assert.Equal(t, 2, n.callCount("GetTxByHash"))
+
+	// the tx rejected by the node is not sent again
+	_, err = s.SendTransaction(newTx(2))
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, n.callCount("SendTransaction"))
+	assert.Equal(t, 2, n.callCount("GetTxByHash"))
+}
+
+func TestRetryStalePool(t *testing.T) {
+	grace := staleConnGrace
+	staleConnGrace = 50 * time.Millisecond
+	defer func() {
+		staleConnGrace = grace
+	}()
+	a, b := newTestNode(t, 100), newTestNode(t, 100)
+	defer a.stop()
+	defer b.stop()
+	s := newTestSDK(t, a, b)
+	defer s.CloseConn()
+	s.mu.RLock()
+	old := s.pool
+	s.mu.RUnlock()
+
+	// a call is in flight on the first server when it is left for lagging behind
+	wait := make(chan struct{})
+	a.mu.Lock()
+	a.wait = wait
+	a.mu.Unlock()
+	done := make(chan error)
+	go func() {
+		_, err := s.GetNodeInfo()
+		done <- err
+	}()
+	waitFor(t, func() bool { return a.callCount("GetNodeInfo") == 1 }, "the call did not reach the server")
+	a.setHead(0)
+	s.rebalance()
+	assert.Equal(t, b.addr, s.Server())
+
+	// its connections are kept past the grace until the call is done, and then closed
+	time.Sleep(2 * staleConnGrace)
+	assert.NotEqual(t, connectivity.Shutdown, old.conns[0].GetState())
+	close(wait)
+	assert.Nil(t, <-done)
+	waitFor(t, func() bool { return old.conns[0].GetState() == connectivity.Shutdown }, "the stale pool is not closed")
+	waitFor(t, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return len(s.stalePools) == 0
+	}, "the stale pool is not dropped")
+
+	// with no call in flight the pool is closed after the grace
+	s.mu.RLock()
+	old = s.pool
+	s.mu.RUnlock()
+	a.setHead(100)
+	b.setHead(0)
+	s.rebalance()
+	assert.Equal(t, a.addr, s.Server())
+	waitFor(t, func() bool { return old.conns[0].GetState() == connectivity.Shutdown }, "the stale pool is not closed")
+	info, err := s.GetNodeInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, a.addr, info.BuildTime)
+}
@@ -1,16 +1,18 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/iost-official/go-iost/account"
-	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"google.golang.org/grpc"
@@ -18,12 +20,17 @@ import (
 
 // IOSTDevSDK ...
 type IOSTDevSDK struct {
-	// the remote server to connect to
-	server string
+	// the remote server to connect to, which is one of servers
+	server  string
+	servers []string
 
 	// account used for sending tx
 	accountName string
-	keyPair     *account.KeyPair
+	signer      Signer
+	// account paying for the tx instead, which publishes the tx while the account signs it as a signer with signerPerm
+	feePayer       string
+	feePayerSigner Signer
+	signerPerm     string
 	// signing algorithm
 	signAlgo string
 
@@ -32,39 +39,82 @@ type IOSTDevSDK struct {
 	gasRatio    float64
 	expiration  int64
 	amountLimit []*rpcpb.AmountLimit
-	delaySecond int64
+	// how long the tx is delayed before being executed
+	delay time.Duration
 
 	// whether to check tx after sending by `SendTx`
-	checkResult         bool
-	checkResultDelay    float32
-	checkResultMaxRetry int32
+	checkResult bool
+	// seconds before the first check, the interval is doubled after each check
+	checkResultDelay float32
+	// how long to wait for the tx to become irreversible
+	waitTimeout time.Duration
 
 	// query longest chain when fetching information from blockchain, currently only used in `GetAccountInfo`
 	useLongestChain bool
+	// query the state with the pending txs executed on the longest chain in `GetAccountInfo` and `GetTokenBalance`
+	includePending bool
+	// query the state at the block of number in `GetAccountInfo` and `GetTokenBalance`, the current state if 0
+	blockNumber int64
 
 	// if false, be silent
 	verbose bool
 
 	// chain id set in tx
 	chainID uint32
+	// network the chain id belongs to, if set the node is checked to be on the chain id before signing by `SendTx`
+	network string
+	// the server found on the chain id, empty if not checked yet
+	checkedServer string
 
-	// internal connection
-	rpcConn *grpc.ClientConn
+	// internal connections, which are safe to share between goroutines once connected by `Connect`
+	mu          sync.RWMutex
+	pool        *connPool
+	connOptions ConnOptions
+	// connections to servers which have been failed over, closed once their calls are done or by `CloseConn`
+	stalePools []*connPool
+	// how failed calls are retried, and when the servers were last checked by it
+	retryPolicy     RetryPolicy
+	lastHealthCheck time.Time
+	// limits of the calls, nil if none, see `SetRateLimit`
+	limiter *rateLimiter
+	// how the skew of the local clock is dealt with, see `SetClockPolicy`
+	clockPolicy ClockPolicy
+	// collector of the metrics, nil if none, see `SetMetricsCollector`
+	metrics MetricsCollector
+	// interceptors and hooks of the application, see `AddUnaryInterceptor` and `AddTxHooks`
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	txHooks            []TxHooks
+	// chain metadata cached by `CachedChainInfo` and `TokenDecimal`
+	cache metaCache
 }
 
+// Receipt polling parameters.
+const (
+	// DefaultWaitTimeout is how long `SendTx` waits for a tx to become irreversible by default.
+	DefaultWaitTimeout = 90 * time.Second
+	minCheckInterval   = 100 * time.Millisecond
+	maxCheckInterval   = 10 * time.Second
+)
+
 // NewIOSTDevSDK creatimg an SDK with reasonable params
 func NewIOSTDevSDK() *IOSTDevSDK {
 	return &IOSTDevSDK{
-		server:              "localhost:30002",
-		checkResult:         true,
-		checkResultDelay:    3,
-		checkResultMaxRetry: 20,
-		signAlgo:            "ed25519",
-		gasLimit:            1000000,
-		gasRatio:            1.0,
-		amountLimit:         []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
-		expiration:          60 * 5,
-		chainID:             uint32(1024),
+		server:           "localhost:30002",
+		servers:          []string{"localhost:30002"},
+		checkResult:      true,
+		checkResultDelay: 3,
+		waitTimeout:      DefaultWaitTimeout,
+		signAlgo:         "ed25519",
+		gasLimit:         1000000,
+		gasRatio:         1.0,
+		amountLimit:      []*rpcpb.AmountLimit{{Token: "*", Value: "unlimited"}},
+		expiration:       60 * 5,
+		chainID:          uint32(1024),
+		retryPolicy:      DefaultRetryPolicy,
+		clockPolicy:      DefaultClockPolicy,
+		connOptions:      DefaultConnOptions,
+		cache:            metaCache{ttl: DefaultCacheTTL},
 	}
 }
 
@@ -73,12 +123,33 @@ func NewIOSTDevSDK() *IOSTDevSDK {
 // SetChainID sets chainID.
 func (s *IOSTDevSDK) SetChainID(chainID uint32) {
 	s.chainID = chainID
+	s.checkedServer = ""
+}
+
+// SetNetwork names the network of the chain id, and makes `SendTx` and `ExecTx` check the node is on the chain id
+// before signing, so that a tx meant for one network is not signed while connected to another.
+func (s *IOSTDevSDK) SetNetwork(network string) {
+	s.network = network
+	s.checkedServer = ""
 }
 
 // SetAccount ...
 func (s *IOSTDevSDK) SetAccount(name string, kp *account.KeyPair) {
+	s.SetSigner(name, NewKeyPairSigner(kp))
+}
+
+// SetSigner sets the account used for sending tx together with the signer holding its key.
+func (s *IOSTDevSDK) SetSigner(name string, signer Signer) {
 	s.accountName = name
-	s.keyPair = kp
+	s.signer = signer
+}
+
+// SetFeePayer lets another account pay the gas and ram of the txs sent, by publishing them with its signer.
+// The account set by SetAccount or SetSigner then signs the txs as a signer with the permission perm.
+func (s *IOSTDevSDK) SetFeePayer(payer string, signer Signer, perm string) {
+	s.feePayer = payer
+	s.feePayerSigner = signer
+	s.signerPerm = perm
 }
 
 // SetTxInfo ...
@@ -86,22 +157,45 @@ func (s *IOSTDevSDK) SetTxInfo(gasLimit float64, gasRatio float64, expiration in
 	s.gasLimit = gasLimit
 	s.gasRatio = gasRatio
 	s.expiration = expiration
-	s.delaySecond = delaySecond
+	s.delay = time.Duration(delaySecond) * time.Second
 	if amountLimit != nil && len(amountLimit) != 0 {
 		s.amountLimit = amountLimit
 	}
 }
 
-// SetCheckResult ...
-func (s *IOSTDevSDK) SetCheckResult(checkResult bool, checkResultDelay float32, checkResultMaxRetry int32) {
+// SetDelay sets how long the txs created are delayed before being executed, 0 for executing them right away.
+func (s *IOSTDevSDK) SetDelay(delay time.Duration) {
+	s.delay = delay
+}
+
+// SetCheckResult sets whether `SendTx` waits for the tx to become irreversible, the delay in seconds before the first
+// check and how long to wait at most.
+func (s *IOSTDevSDK) SetCheckResult(checkResult bool, checkResultDelay float32, waitTimeout time.Duration) {
 	s.checkResult = checkResult
 	s.checkResultDelay = checkResultDelay
-	s.checkResultMaxRetry = checkResultMaxRetry
+	s.waitTimeout = waitTimeout
 }
 
-// SetServer ...
+// SetServer sets the server to connect to. A comma separated list of servers enables failover: the first healthy
+// and up to date one is used, and calls failing with connection errors are retried on the others by the retry policy.
+// A server given by the url of the json gateway of a node, like http://localhost:30001, is called over http instead
+// of grpc, for environments where grpc can't be used. The calls are retried and limited the same, but there is no
+// failover from a gateway, and the stream interceptors are not called.
 func (s *IOSTDevSDK) SetServer(server string) {
-	s.server = server
+	s.servers = splitServers(server)
+	if len(s.servers) == 0 {
+		s.servers = []string{server}
+	}
+	s.server = s.servers[0]
+	s.checkedServer = ""
+	s.cache.clear()
+}
+
+// Server returns the server currently in use.
+func (s *IOSTDevSDK) Server() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.server
 }
 
 // SetSignAlgo ...
@@ -119,21 +213,72 @@ func (s *IOSTDevSDK) SetUseLongestChain(useLongestChain bool) {
 	s.useLongestChain = useLongestChain
 }
 
+// SetIncludePending sets whether the account info and the token balances are queried with the pending txs executed,
+// to show the balances expected after the txs sent. The node refuses such queries unless it enables pendingstate in
+// its rpc config.
+func (s *IOSTDevSDK) SetIncludePending(includePending bool) {
+	s.includePending = includePending
+}
+
+// SetBlockNumber sets the block whose state is queried by the account info and the token balances, the current state
+// if 0. The blocks before the last irreversible block need a node keeping the state history.
+func (s *IOSTDevSDK) SetBlockNumber(number int64) {
+	s.blockNumber = number
+}
+
 // Connect ...
 func (s *IOSTDevSDK) Connect() (err error) {
-	if s.rpcConn == nil {
+	return s.ConnectCtx(context.Background())
+}
+
+// ConnectCtx is Connect with a context to cancel the health checks of the servers to fail over between.
+func (s *IOSTDevSDK) ConnectCtx(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pool != nil {
+		return nil
+	}
+	if isGateway(s.server) {
+		s.log("Using the json gateway", s.server)
+		s.pool = &connPool{server: s.server, gateway: s.newGatewayClient(s.server, s.retryInterceptor)}
+		return nil
+	}
+	if len(s.servers) < 2 {
 		s.log("Connecting to server", s.server, "...")
-		s.rpcConn, err = grpc.Dial(s.server, grpc.WithInsecure())
+		pool, err := s.dialPool(ctx, s.server, nil)
+		if err != nil {
+			return err
+		}
+		s.pool = pool
+		return nil
+	}
+	s.log("Checking servers", s.servers, "...")
+	h, err := s.pickServer(ctx, "")
+	if err != nil {
+		return err
+	}
+	pool, err := s.dialPool(ctx, h.server, h.conn)
+	if err != nil {
+		return err
 	}
-	return
+	s.log("Connected to server", h.server)
+	s.pool, s.server = pool, h.server
+	s.lastHealthCheck = time.Now()
+	return nil
 }
 
 // CloseConn ...
 func (s *IOSTDevSDK) CloseConn() {
-	if s.rpcConn != nil {
-		s.rpcConn.Close()
-		s.rpcConn = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pool != nil {
+		s.pool.close()
+		s.pool = nil
+	}
+	for _, p := range s.stalePools {
+		p.close()
 	}
+	s.stalePools = nil
 }
 
 func (s *IOSTDevSDK) log(a ...interface{}) {
@@ -146,133 +291,677 @@ func (s *IOSTDevSDK) log(a ...interface{}) {
 
 // GetContractStorage ...
 func (s *IOSTDevSDK) GetContractStorage(r *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetContractStorageCtx(context.Background(), r)
+}
+
+// GetContractStorageCtx is GetContractStorage with a context to cancel the call.
+func (s *IOSTDevSDK) GetContractStorageCtx(ctx context.Context, r *rpcpb.GetContractStorageRequest) (*rpcpb.GetContractStorageResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	value, err := client.GetContractStorage(context.Background(), r)
+	client := s.apiClient()
+	value, err := client.GetContractStorage(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
+// GetBatchContractStorage returns the values of several keys of contract storage, all read at the same block.
+func (s *IOSTDevSDK) GetBatchContractStorage(r *rpcpb.GetBatchContractStorageRequest) (*rpcpb.GetBatchContractStorageResponse, error) {
+	return s.GetBatchContractStorageCtx(context.Background(), r)
+}
+
+// GetBatchContractStorageCtx is GetBatchContractStorage with a context to cancel the call.
+func (s *IOSTDevSDK) GetBatchContractStorageCtx(ctx context.Context, r *rpcpb.GetBatchContractStorageRequest) (*rpcpb.GetBatchContractStorageResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBatchContractStorage(ctx, r)
+}
+
+// GetContractStorageFields returns the fields of a map in the storage of a contract.
+func (s *IOSTDevSDK) GetContractStorageFields(r *rpcpb.GetContractStorageFieldsRequest) (*rpcpb.GetContractStorageFieldsResponse, error) {
+	return s.GetContractStorageFieldsCtx(context.Background(), r)
+}
+
+// GetContractStorageFieldsCtx is GetContractStorageFields with a context to cancel the call.
+func (s *IOSTDevSDK) GetContractStorageFieldsCtx(ctx context.Context, r *rpcpb.GetContractStorageFieldsRequest) (*rpcpb.GetContractStorageFieldsResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetContractStorageFields(ctx, r)
+}
+
 // GetNodeInfo ...
 func (s *IOSTDevSDK) GetNodeInfo() (*rpcpb.NodeInfoResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetNodeInfoCtx(context.Background())
+}
+
+// GetNodeInfoCtx is GetNodeInfo with a context to cancel the call.
+func (s *IOSTDevSDK) GetNodeInfoCtx(ctx context.Context) (*rpcpb.NodeInfoResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	value, err := client.GetNodeInfo(context.Background(), &rpcpb.EmptyRequest{})
+	client := s.apiClient()
+	value, err := client.GetNodeInfo(ctx, &rpcpb.EmptyRequest{})
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
+// GetNodeStatus returns the health and the sync status of the node, telling by Ready whether it is synced and healthy.
+func (s *IOSTDevSDK) GetNodeStatus() (*rpcpb.NodeStatusResponse, error) {
+	return s.GetNodeStatusCtx(context.Background())
+}
+
+// GetNodeStatusCtx is GetNodeStatus with a context to cancel the call.
+func (s *IOSTDevSDK) GetNodeStatusCtx(ctx context.Context) (*rpcpb.NodeStatusResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetNodeStatus(ctx, &rpcpb.EmptyRequest{})
+}
+
 // GetChainInfo ...
 func (s *IOSTDevSDK) GetChainInfo() (*rpcpb.ChainInfoResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetChainInfoCtx(context.Background())
+}
+
+// GetChainInfoCtx is GetChainInfo with a context to cancel the call.
+func (s *IOSTDevSDK) GetChainInfoCtx(ctx context.Context) (*rpcpb.ChainInfoResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	value, err := client.GetChainInfo(context.Background(), &rpcpb.EmptyRequest{})
+	client := s.apiClient()
+	value, err := client.GetChainInfo(ctx, &rpcpb.EmptyRequest{})
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
+// GetRAMInfo returns the ram market state of ram.iost
+func (s *IOSTDevSDK) GetRAMInfo() (*rpcpb.RAMInfoResponse, error) {
+	return s.GetRAMInfoCtx(context.Background())
+}
+
+// GetRAMInfoCtx is GetRAMInfo with a context to cancel the call.
+func (s *IOSTDevSDK) GetRAMInfoCtx(ctx context.Context) (*rpcpb.RAMInfoResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetRAMInfo(ctx, &rpcpb.EmptyRequest{})
+}
+
+// GetGasRatio returns the lowest and median gas ratio of the txs in the head block
+func (s *IOSTDevSDK) GetGasRatio() (*rpcpb.GasRatioResponse, error) {
+	return s.GetGasRatioCtx(context.Background())
+}
+
+// GetGasRatioCtx is GetGasRatio with a context to cancel the call.
+func (s *IOSTDevSDK) GetGasRatioCtx(ctx context.Context) (*rpcpb.GasRatioResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetGasRatio(ctx, &rpcpb.EmptyRequest{})
+}
+
 // GetAccountInfo return account info
 func (s *IOSTDevSDK) GetAccountInfo(id string) (*rpcpb.Account, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetAccountInfoCtx(context.Background(), id)
+}
+
+// GetAccountInfoCtx is GetAccountInfo with a context to cancel the call.
+func (s *IOSTDevSDK) GetAccountInfoCtx(ctx context.Context, id string) (*rpcpb.Account, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	req := &rpcpb.GetAccountRequest{Name: id, ByLongestChain: s.useLongestChain}
-	value, err := client.GetAccount(context.Background(), req)
+	client := s.apiClient()
+	req := &rpcpb.GetAccountRequest{Name: id, ByLongestChain: s.useLongestChain, IncludePending: s.includePending, BlockNumber: s.blockNumber}
+	value, err := client.GetAccount(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
+// GetWitnessSchedule returns the witness schedule and the production statistics over the recent blocks, 0 for the node's default
+func (s *IOSTDevSDK) GetWitnessSchedule(blockCount int64) (*rpcpb.GetWitnessScheduleResponse, error) {
+	return s.GetWitnessScheduleCtx(context.Background(), blockCount)
+}
+
+// GetWitnessScheduleCtx is GetWitnessSchedule with a context to cancel the call.
+func (s *IOSTDevSDK) GetWitnessScheduleCtx(ctx context.Context, blockCount int64) (*rpcpb.GetWitnessScheduleResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetWitnessSchedule(ctx, &rpcpb.GetWitnessScheduleRequest{BlockCount: blockCount})
+}
+
+// GetTokenBalance returns the balance of the token owned by the account
+func (s *IOSTDevSDK) GetTokenBalance(account string, token string) (*rpcpb.GetTokenBalanceResponse, error) {
+	return s.GetTokenBalanceCtx(context.Background(), account, token)
+}
+
+// GetTokenBalanceCtx is GetTokenBalance with a context to cancel the call.
+func (s *IOSTDevSDK) GetTokenBalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetTokenBalanceResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetTokenBalance(ctx, &rpcpb.GetTokenBalanceRequest{Account: account, Token: token, ByLongestChain: s.useLongestChain, IncludePending: s.includePending, BlockNumber: s.blockNumber})
+}
+
+// GetAccountTokens returns the balances of all tokens and token721 tokens held by the account
+func (s *IOSTDevSDK) GetAccountTokens(account string) (*rpcpb.GetAccountTokensResponse, error) {
+	return s.GetAccountTokensCtx(context.Background(), account)
+}
+
+// GetAccountTokensCtx is GetAccountTokens with a context to cancel the call.
+func (s *IOSTDevSDK) GetAccountTokensCtx(ctx context.Context, account string) (*rpcpb.GetAccountTokensResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetAccountTokens(ctx, &rpcpb.GetAccountTokensRequest{Account: account, ByLongestChain: s.useLongestChain})
+}
+
+// GetToken721Balance returns the number of the token721 tokens owned by the account and the first page of their ids,
+// see `GetToken721BalancePage` and `Token721HoldingsIterator` for the next ones
+func (s *IOSTDevSDK) GetToken721Balance(account string, token string) (*rpcpb.GetToken721BalanceResponse, error) {
+	return s.GetToken721BalanceCtx(context.Background(), account, token)
+}
+
+// GetToken721BalanceCtx is GetToken721Balance with a context to cancel the call.
+func (s *IOSTDevSDK) GetToken721BalanceCtx(ctx context.Context, account string, token string) (*rpcpb.GetToken721BalanceResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetToken721Balance(ctx, &rpcpb.GetToken721BalanceRequest{Account: account, Token: token, ByLongestChain: s.useLongestChain})
+}
+
+// GetToken721BalancePage returns the number of the token721 tokens owned by the account and a page of their ids, the
+// next page being got with the cursor of the response
+func (s *IOSTDevSDK) GetToken721BalancePage(r *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+	return s.GetToken721BalancePageCtx(context.Background(), r)
+}
+
+// GetToken721BalancePageCtx is GetToken721BalancePage with a context to cancel the call.
+func (s *IOSTDevSDK) GetToken721BalancePageCtx(ctx context.Context, r *rpcpb.GetToken721BalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetToken721Balance(ctx, r)
+}
+
+// GetToken721Metadata returns the metadata of a token721 token
+func (s *IOSTDevSDK) GetToken721Metadata(token string, tokenID string) (*rpcpb.GetToken721MetadataResponse, error) {
+	return s.GetToken721MetadataCtx(context.Background(), token, tokenID)
+}
+
+// GetToken721MetadataCtx is GetToken721Metadata with a context to cancel the call.
+func (s *IOSTDevSDK) GetToken721MetadataCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721MetadataResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetToken721Metadata(ctx, &rpcpb.GetToken721InfoRequest{Token: token, TokenId: tokenID, ByLongestChain: s.useLongestChain})
+}
+
+// GetToken721Owner returns the owner of a token721 token
+func (s *IOSTDevSDK) GetToken721Owner(token string, tokenID string) (*rpcpb.GetToken721OwnerResponse, error) {
+	return s.GetToken721OwnerCtx(context.Background(), token, tokenID)
+}
+
+// GetToken721OwnerCtx is GetToken721Owner with a context to cancel the call.
+func (s *IOSTDevSDK) GetToken721OwnerCtx(ctx context.Context, token string, tokenID string) (*rpcpb.GetToken721OwnerResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetToken721Owner(ctx, &rpcpb.GetToken721InfoRequest{Token: token, TokenId: tokenID, ByLongestChain: s.useLongestChain})
+}
+
+// GetContract returns the deployed contract with its abis
+func (s *IOSTDevSDK) GetContract(id string) (*rpcpb.Contract, error) {
+	return s.GetContractCtx(context.Background(), id)
+}
+
+// GetContractCtx is GetContract with a context to cancel the call.
+func (s *IOSTDevSDK) GetContractCtx(ctx context.Context, id string) (*rpcpb.Contract, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetContract(ctx, &rpcpb.GetContractRequest{Id: id, ByLongestChain: s.useLongestChain})
+}
+
 // GetBlockByNum ...
 func (s *IOSTDevSDK) GetBlockByNum(num int64, complete bool) (*rpcpb.BlockResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetBlockByNumCtx(context.Background(), num, complete)
+}
+
+// GetBlockByNumCtx is GetBlockByNum with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlockByNumCtx(ctx context.Context, num int64, complete bool) (*rpcpb.BlockResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: num, Complete: complete})
+}
+
+// GetFilteredBlockByNum returns the complete block of number with only the txs having an action calling one of the
+// contracts, or abis like token.iost/transfer.
+func (s *IOSTDevSDK) GetFilteredBlockByNum(num int64, actions []string) (*rpcpb.BlockResponse, error) {
+	return s.GetFilteredBlockByNumCtx(context.Background(), num, actions)
+}
+
+// GetFilteredBlockByNumCtx is GetFilteredBlockByNum with a context to cancel the call.
+func (s *IOSTDevSDK) GetFilteredBlockByNumCtx(ctx context.Context, num int64, actions []string) (*rpcpb.BlockResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: num, Complete: true, Actions: actions})
+}
+
+// GetBlocks returns the blocks of numbers from start to end, as many as the node returns at once, which tells by
+// HasMore whether the blocks were cut by its limit.
+func (s *IOSTDevSDK) GetBlocks(start int64, end int64, complete bool) (*rpcpb.GetBlocksResponse, error) {
+	return s.GetBlocksCtx(context.Background(), start, end, complete)
+}
+
+// GetBlocksCtx is GetBlocks with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlocksCtx(ctx context.Context, start int64, end int64, complete bool) (*rpcpb.GetBlocksResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlocks(ctx, &rpcpb.GetBlocksRequest{Start: start, End: end, Complete: complete})
+}
+
+// GetBlockHeaderByNum returns the header of the block of number, without its transactions
+func (s *IOSTDevSDK) GetBlockHeaderByNum(num int64) (*rpcpb.BlockHeaderResponse, error) {
+	return s.GetBlockHeaderByNumCtx(context.Background(), num)
+}
+
+// GetBlockHeaderByNumCtx is GetBlockHeaderByNum with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlockHeaderByNumCtx(ctx context.Context, num int64) (*rpcpb.BlockHeaderResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlockHeaderByNumber(ctx, &rpcpb.GetBlockHeaderByNumberRequest{Number: num})
+}
+
+// GetBlockHeaderByHash returns the header of the block of hash, without its transactions
+func (s *IOSTDevSDK) GetBlockHeaderByHash(hash string) (*rpcpb.BlockHeaderResponse, error) {
+	return s.GetBlockHeaderByHashCtx(context.Background(), hash)
+}
+
+// GetBlockHeaderByHashCtx is GetBlockHeaderByHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlockHeaderByHashCtx(ctx context.Context, hash string) (*rpcpb.BlockHeaderResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlockHeaderByHash(ctx, &rpcpb.GetBlockHeaderByHashRequest{Hash: hash})
+}
+
+// GetBlockStateChanges returns the state keys written by the irreversible block of number, which the node keeps if
+// it is configured to.
+func (s *IOSTDevSDK) GetBlockStateChanges(number int64) (*rpcpb.GetBlockStateChangesResponse, error) {
+	return s.GetBlockStateChangesCtx(context.Background(), number)
+}
+
+// GetBlockStateChangesCtx is GetBlockStateChanges with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlockStateChangesCtx(ctx context.Context, number int64) (*rpcpb.GetBlockStateChangesResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	return client.GetBlockByNumber(context.Background(), &rpcpb.GetBlockByNumberRequest{Number: num, Complete: complete})
+	client := s.apiClient()
+	return client.GetBlockStateChanges(ctx, &rpcpb.GetBlockStateChangesRequest{Number: number})
 }
 
 // GetBlockByHash ...
 func (s *IOSTDevSDK) GetBlockByHash(hash string, complete bool) (*rpcpb.BlockResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetBlockByHashCtx(context.Background(), hash, complete)
+}
+
+// GetBlockByHashCtx is GetBlockByHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetBlockByHashCtx(ctx context.Context, hash string, complete bool) (*rpcpb.BlockResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetBlockByHash(ctx, &rpcpb.GetBlockByHashRequest{Hash: hash, Complete: complete})
+}
+
+// GetFilteredBlockByHash returns the complete block of hash with only the txs having an action calling one of the
+// contracts, or abis like token.iost/transfer.
+func (s *IOSTDevSDK) GetFilteredBlockByHash(hash string, actions []string) (*rpcpb.BlockResponse, error) {
+	return s.GetFilteredBlockByHashCtx(context.Background(), hash, actions)
+}
+
+// GetFilteredBlockByHashCtx is GetFilteredBlockByHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetFilteredBlockByHashCtx(ctx context.Context, hash string, actions []string) (*rpcpb.BlockResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	return client.GetBlockByHash(context.Background(), &rpcpb.GetBlockByHashRequest{Hash: hash, Complete: complete})
+	client := s.apiClient()
+	return client.GetBlockByHash(ctx, &rpcpb.GetBlockByHashRequest{Hash: hash, Complete: true, Actions: actions})
 }
 
 // GetTxByHash ...
 func (s *IOSTDevSDK) GetTxByHash(hash string) (*rpcpb.TransactionResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetTxByHashCtx(context.Background(), hash)
+}
+
+// GetTxByHashCtx is GetTxByHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	return client.GetTxByHash(context.Background(), &rpcpb.TxHashRequest{Hash: hash})
+	client := s.apiClient()
+	return client.GetTxByHash(ctx, &rpcpb.TxHashRequest{Hash: hash})
 }
 
 // GetTxReceiptByTxHash ...
 func (s *IOSTDevSDK) GetTxReceiptByTxHash(txHashStr string) (*rpcpb.TxReceipt, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetTxReceiptByTxHashCtx(context.Background(), txHashStr)
+}
+
+// GetTxReceiptByTxHashCtx is GetTxReceiptByTxHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetTxReceiptByTxHashCtx(ctx context.Context, txHashStr string) (*rpcpb.TxReceipt, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetTxReceiptByTxHash(ctx, &rpcpb.TxHashRequest{Hash: txHashStr})
+}
+
+// TraceTransaction executes again the transaction of a block after the last irreversible block on the node, and
+// returns the calls of its actions traced. The node should enable exec_tx in its rpc config.
+func (s *IOSTDevSDK) TraceTransaction(txHash string) (*rpcpb.TraceTransactionResponse, error) {
+	return s.TraceTransactionCtx(context.Background(), txHash)
+}
+
+// TraceTransactionCtx is TraceTransaction with a context to cancel the call.
+func (s *IOSTDevSDK) TraceTransactionCtx(ctx context.Context, txHash string) (*rpcpb.TraceTransactionResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	return client.GetTxReceiptByTxHash(context.Background(), &rpcpb.TxHashRequest{Hash: txHashStr})
+	client := s.apiClient()
+	return client.TraceTransaction(ctx, &rpcpb.TxHashRequest{Hash: txHash})
+}
+
+// GetTxsByAccount returns irreversible transactions involving the account, newest first, skipping at most 10000 of
+// them. GetAccountTxs pages further with its cursor.
+func (s *IOSTDevSDK) GetTxsByAccount(account string, offset int32, limit int32) (*rpcpb.GetTxsByAccountResponse, error) {
+	return s.GetTxsByAccountCtx(context.Background(), account, offset, limit)
+}
+
+// GetTxsByAccountCtx is GetTxsByAccount with a context to cancel the call.
+func (s *IOSTDevSDK) GetTxsByAccountCtx(ctx context.Context, account string, offset int32, limit int32) (*rpcpb.GetTxsByAccountResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetTxsByAccount(ctx, &rpcpb.GetTxsByAccountRequest{Account: account, Offset: offset, Limit: limit})
+}
+
+// GetAccountTxs returns a page of the irreversible txs involving the account, newest first. The next page is got with
+// the cursor of the response, which is empty after the last page.
+func (s *IOSTDevSDK) GetAccountTxs(r *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error) {
+	return s.GetAccountTxsCtx(context.Background(), r)
+}
+
+// GetAccountTxsCtx is GetAccountTxs with a context to cancel the call.
+func (s *IOSTDevSDK) GetAccountTxsCtx(ctx context.Context, r *rpcpb.GetAccountTxsRequest) (*rpcpb.GetAccountTxsResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetAccountTxs(ctx, r)
 }
 
 // SendTransaction send raw transaction to server
 func (s *IOSTDevSDK) SendTransaction(signedTx *rpcpb.TransactionRequest) (string, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.SendTransactionCtx(context.Background(), signedTx)
+}
+
+// SendTransactionCtx is SendTransaction with a context to cancel the call.
+func (s *IOSTDevSDK) SendTransactionCtx(ctx context.Context, signedTx *rpcpb.TransactionRequest) (string, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return "", err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	resp, err := client.SendTransaction(context.Background(), signedTx)
+	client := s.apiClient()
+	resp, err := client.SendTransaction(ctx, signedTx)
 	if err != nil {
 		return "", err
 	}
 	return resp.Hash, nil
 }
 
+// ExecTransaction executes the transaction on the node without sending it to the chain. The node should enable exec_tx in its rpc config.
+func (s *IOSTDevSDK) ExecTransaction(t *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	return s.ExecTransactionCtx(context.Background(), t)
+}
+
+// ExecTransactionCtx is ExecTransaction with a context to cancel the call.
+func (s *IOSTDevSDK) ExecTransactionCtx(ctx context.Context, t *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.ExecTransaction(ctx, t)
+}
+
+// EstimateGas executes the transaction on the node with the gas limits it may have, and returns the smallest one it
+// succeeds with and the one recommended. The signatures may be left empty, with only their public keys, for the
+// transaction to be signed with the gas limit recommended. The node should enable exec_tx in its rpc config.
+func (s *IOSTDevSDK) EstimateGas(t *rpcpb.TransactionRequest) (*rpcpb.EstimateGasResponse, error) {
+	return s.EstimateGasCtx(context.Background(), t)
+}
+
+// EstimateGasCtx is EstimateGas with a context to cancel the call.
+func (s *IOSTDevSDK) EstimateGasCtx(ctx context.Context, t *rpcpb.TransactionRequest) (*rpcpb.EstimateGasResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.EstimateGas(ctx, t)
+}
+
+// GetDelaytxsByAccount returns the delay txs published by the account which are neither executed nor canceled yet.
+func (s *IOSTDevSDK) GetDelaytxsByAccount(account string) (*rpcpb.GetDelaytxsByAccountResponse, error) {
+	return s.GetDelaytxsByAccountCtx(context.Background(), account)
+}
+
+// GetDelaytxsByAccountCtx is GetDelaytxsByAccount with a context to cancel the call.
+func (s *IOSTDevSDK) GetDelaytxsByAccountCtx(ctx context.Context, account string) (*rpcpb.GetDelaytxsByAccountResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetDelaytxsByAccount(ctx, &rpcpb.GetDelaytxsByAccountRequest{Account: account})
+}
+
+// GetPendingTransactions returns the txs in the pending pool of the node matching the request, in the order they are packed.
+func (s *IOSTDevSDK) GetPendingTransactions(r *rpcpb.GetPendingTransactionsRequest) (*rpcpb.GetPendingTransactionsResponse, error) {
+	return s.GetPendingTransactionsCtx(context.Background(), r)
+}
+
+// GetPendingTransactionsCtx is GetPendingTransactions with a context to cancel the call.
+func (s *IOSTDevSDK) GetPendingTransactionsCtx(ctx context.Context, r *rpcpb.GetPendingTransactionsRequest) (*rpcpb.GetPendingTransactionsResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetPendingTransactions(ctx, r)
+}
+
+// GetPendingTxByHash returns the tx of the hash if it is in the pending pool of the node.
+func (s *IOSTDevSDK) GetPendingTxByHash(hash string) (*rpcpb.TransactionResponse, error) {
+	return s.GetPendingTxByHashCtx(context.Background(), hash)
+}
+
+// GetPendingTxByHashCtx is GetPendingTxByHash with a context to cancel the call.
+func (s *IOSTDevSDK) GetPendingTxByHashCtx(ctx context.Context, hash string) (*rpcpb.TransactionResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetPendingTxByHash(ctx, &rpcpb.TxHashRequest{Hash: hash})
+}
+
+// Subscribe opens a stream of contract events. The stream ends when ctx is canceled.
+func (s *IOSTDevSDK) Subscribe(ctx context.Context, r *rpcpb.SubscribeRequest) (rpcpb.ApiService_SubscribeClient, error) {
+	if err := s.ConnectCtx(ctx); err != nil {
+		return nil, err
+	}
+	client := s.apiClient()
+	return client.Subscribe(ctx, r)
+}
+
+// SubscribeChainStatus opens a stream of the chain and node status, sent whenever the head block changes. The stream ends when ctx is canceled.
+func (s *IOSTDevSDK) SubscribeChainStatus(ctx context.Context) (rpcpb.ApiService_SubscribeChainStatusClient, error) {
+	if err := s.ConnectCtx(ctx); err != nil {
+		return nil, err
+	}
+	client := s.apiClient()
+	return client.SubscribeChainStatus(ctx, &rpcpb.EmptyRequest{})
+}
+
+// SubscribeBlocks opens a stream of the blocks from the height or the resume token of the request. Each block comes
+// with the token to resume the stream after it, so that a stream which broke can go on without missing blocks.
+// The stream ends when ctx is canceled.
+func (s *IOSTDevSDK) SubscribeBlocks(ctx context.Context, r *rpcpb.SubscribeBlocksRequest) (rpcpb.ApiService_SubscribeBlocksClient, error) {
+	if err := s.ConnectCtx(ctx); err != nil {
+		return nil, err
+	}
+	client := s.apiClient()
+	return client.SubscribeBlocks(ctx, r)
+}
+
 ////////////////////////////////////// transaction related /////////////////////////////////
 
 // CreateTxFromActions ...
@@ -292,7 +981,7 @@ func (s *IOSTDevSDK) CreateTxFromActions(actions []*rpcpb.Action) (*rpcpb.Transa
 		GasRatio:      s.gasRatio,
 		Expiration:    expiration,
 		PublisherSigs: []*rpcpb.Signature{},
-		Delay:         s.delaySecond * 1e9,
+		Delay:         int64(s.delay),
 		ChainId:       s.chainID,
 		AmountLimit:   s.amountLimit,
 		Signatures:    []*rpcpb.Signature{},
@@ -300,96 +989,339 @@ func (s *IOSTDevSDK) CreateTxFromActions(actions []*rpcpb.Action) (*rpcpb.Transa
 	return ret, nil
 }
 
-// SignTx ...
+// SignTx signs the tx as publisher. The algorithm is decided by the signer, signAlgo is kept for compatibility.
+// With a fee payer, the account is added to the signers unless it is one already, signs the tx as a signer and the
+// fee payer signs it as publisher. Signatures of other signers should be collected with the account among the signers.
 func (s *IOSTDevSDK) SignTx(t *rpcpb.TransactionRequest, signAlgo string) (*rpcpb.TransactionRequest, error) {
-	signAlgorithm := GetSignAlgoByName(signAlgo)
-	txHashBytes := common.Sha3(txToBytes(t, true))
-	publishSig := &rpcpb.Signature{
-		Algorithm: rpcpb.Signature_Algorithm(signAlgorithm),
-		Signature: signAlgorithm.Sign(txHashBytes, s.keyPair.Seckey),
-		PublicKey: signAlgorithm.GetPubkey(s.keyPair.Seckey),
+	if s.signer == nil {
+		return nil, fmt.Errorf("no signer for account %v", s.accountName)
+	}
+	publisher, publisherSigner := s.accountName, s.signer
+	if s.feePayer != "" {
+		if s.feePayerSigner == nil {
+			return nil, fmt.Errorf("no signer for fee payer %v", s.feePayer)
+		}
+		if !hasSigner(t.Signers, s.accountName) {
+			t.Signers = append(t.Signers, s.accountName+"@"+s.signerPerm)
+		}
+		sig, err := signWith(s.signer, TxHashForSign(t))
+		if err != nil {
+			return nil, err
+		}
+		t.Signatures = append(withoutSigOf(t.Signatures, sig.PublicKey), sig)
+		publisher, publisherSigner = s.feePayer, s.feePayerSigner
+	}
+	publishSig, err := signWith(publisherSigner, TxHashForPublish(t))
+	if err != nil {
+		return nil, err
 	}
 	t.PublisherSigs = []*rpcpb.Signature{publishSig}
-	t.Publisher = s.accountName
+	t.Publisher = publisher
 	return t, nil
 }
 
-func (s *IOSTDevSDK) checkTransaction(txHash string) error {
-	s.log("Checking transaction receipt...")
-	for i := int32(0); i < s.checkResultMaxRetry; i++ {
-		time.Sleep(time.Duration(s.checkResultDelay*1000) * time.Millisecond)
-		txReceipt, err := s.GetTxReceiptByTxHash(txHash)
+func signWith(signer Signer, hash []byte) (*rpcpb.Signature, error) {
+	sig, err := signer.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Signature{
+		Algorithm: rpcpb.Signature_Algorithm(signer.Algorithm()),
+		Signature: sig,
+		PublicKey: signer.PubKey(),
+	}, nil
+}
+
+// hasSigner tells whether the account signs the tx with any permission.
+func hasSigner(signers []string, account string) bool {
+	for _, s := range signers {
+		if strings.HasPrefix(s, account+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutSigOf drops the signature of the public key, so that signing again replaces it.
+func withoutSigOf(sigs []*rpcpb.Signature, pubKey []byte) []*rpcpb.Signature {
+	ret := make([]*rpcpb.Signature, 0, len(sigs))
+	for _, sig := range sigs {
+		if !bytes.Equal(sig.PublicKey, pubKey) {
+			ret = append(ret, sig)
+		}
+	}
+	return ret
+}
+
+// nextCheckInterval doubles the interval between checks of a tx, up to maxCheckInterval.
+func nextCheckInterval(interval time.Duration) time.Duration {
+	interval *= 2
+	if interval < minCheckInterval {
+		return minCheckInterval
+	}
+	if interval > maxCheckInterval {
+		return maxCheckInterval
+	}
+	return interval
+}
+
+// WaitTx polls the tx with exponential backoff until it is irreversible, and returns its receipt.
+// It gives up once the wait timeout is reached.
+func (s *IOSTDevSDK) WaitTx(txHash string) (*rpcpb.TxReceipt, error) {
+	return s.WaitTxCtx(context.Background(), txHash)
+}
+
+// WaitTxCtx is WaitTx with a context, which stops the waiting once it is done.
+func (s *IOSTDevSDK) WaitTxCtx(ctx context.Context, txHash string) (*rpcpb.TxReceipt, error) {
+	res, err := s.pollTx(ctx, txHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Transaction.TxReceipt, nil
+}
+
+// pollTx polls the tx until it is irreversible and returns it, calling onStatus if not nil each time its status changes.
+func (s *IOSTDevSDK) pollTx(ctx context.Context, txHash string, onStatus func(res *rpcpb.TransactionResponse)) (*rpcpb.TransactionResponse, error) {
+	deadline := time.Now().Add(s.waitTimeout)
+	interval := time.Duration(s.checkResultDelay*1000) * time.Millisecond
+	status := ""
+	for {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			break
+		}
+		if interval < wait {
+			wait = interval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval = nextCheckInterval(interval)
+		res, err := s.GetTxByHashCtx(ctx, txHash)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			s.log("...", err)
 			continue
 		}
-		if txReceipt == nil {
-			s.log("...")
-			continue
+		if onStatus != nil && res.Status.String() != status {
+			onStatus(res)
 		}
-		if txReceipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
-			s.log("Transaction receipt:")
-			s.log(MarshalTextString(txReceipt))
-			return fmt.Errorf(txReceipt.Message)
+		status = res.Status.String()
+		s.log("...", status)
+		if res.Status == rpcpb.TransactionResponse_IRREVERSIBLE {
+			return res, nil
 		}
+	}
+	if status != "" {
+		return nil, fmt.Errorf("transaction is still %v after %v", status, s.waitTimeout)
+	}
+	return nil, fmt.Errorf("transaction not found after %v", s.waitTimeout)
+}
 
-		s.log("SUCCESS!")
+func (s *IOSTDevSDK) checkTransaction(ctx context.Context, txHash string) error {
+	s.log("Checking transaction receipt...")
+	txReceipt, err := s.WaitTxCtx(ctx, txHash)
+	if err != nil {
+		return err
+	}
+	if txReceipt.StatusCode != rpcpb.TxReceipt_SUCCESS {
 		s.log("Transaction receipt:")
 		s.log(MarshalTextString(txReceipt))
+		return &ReceiptError{Receipt: txReceipt}
+	}
+
+	s.log("SUCCESS!")
+	s.log("Transaction receipt:")
+	s.log(MarshalTextString(txReceipt))
+	return nil
+}
+
+// CheckChainID checks the node reports the chain id set in txs. The result is kept until the server in use changes.
+func (s *IOSTDevSDK) CheckChainID() error {
+	return s.CheckChainIDCtx(context.Background())
+}
+
+// CheckChainIDCtx is CheckChainID with a context to cancel the call.
+func (s *IOSTDevSDK) CheckChainIDCtx(ctx context.Context) error {
+	s.mu.RLock()
+	checked := s.checkedServer != "" && s.checkedServer == s.server
+	s.mu.RUnlock()
+	if checked {
 		return nil
 	}
-	return fmt.Errorf("exceeded max retry times")
+	info, err := s.CachedChainInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the chain id of the node: %w", err)
+	}
+	if info.ChainId != s.chainID {
+		network := s.network
+		if network == "" {
+			network = "the tx"
+		}
+		return fmt.Errorf("invalid chain_id: node %v is on chain id %v, while %v is on chain id %v", s.Server(), info.ChainId, network, s.chainID)
+	}
+	s.mu.Lock()
+	s.checkedServer = s.server
+	s.mu.Unlock()
+	return nil
 }
 
 // SendTx send transaction and check result if sdk.checkResult is set
 func (s *IOSTDevSDK) SendTx(tx *rpcpb.TransactionRequest) (string, error) {
+	return s.SendTxCtx(context.Background(), tx)
+}
+
+// SendTxCtx is SendTx with a context, which cancels sending the tx or waiting for its result.
+// Once the tx has been sent, its hash is returned together with the error of the context.
+func (s *IOSTDevSDK) SendTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (string, error) {
+	start := time.Now()
+	txHash, err := s.signAndSend(ctx, tx)
+	if err != nil {
+		s.observeTx(err, start)
+		return "", err
+	}
+	if s.checkResult {
+		err = s.checkTransaction(ctx, txHash)
+	}
+	s.observeTx(err, start)
+	if err != nil {
+		return txHash, err
+	}
+	return txHash, nil
+}
+
+// signAndSend signs and sends the tx, sending it once more with a refreshed time if rejected as expired, see
+// `ClockPolicy`.
+func (s *IOSTDevSDK) signAndSend(ctx context.Context, tx *rpcpb.TransactionRequest) (string, error) {
+	if s.network != "" {
+		if err := s.CheckChainIDCtx(ctx); err != nil {
+			return "", err
+		}
+	}
+	retry := s.clockPolicy.RetryExpired && len(tx.Signatures) == 0
+	s.adjustTime(ctx, tx)
+	if err := s.beforeSign(ctx, tx); err != nil {
+		return "", err
+	}
+	txHash, err := s.signAndSendOnce(ctx, tx)
+	if err != nil && retry && CodeOf(err) == ErrTxExpired && ctx.Err() == nil {
+		s.log("Transaction rejected as expired:", err)
+		s.log("Sending it again with the time of the node...")
+		s.refreshTime(ctx, tx)
+		txHash, err = s.signAndSendOnce(ctx, tx)
+	}
+	if err != nil {
+		return "", err
+	}
+	s.log("Transaction has been sent.")
+	s.log("The transaction hash is:", txHash)
+	return txHash, nil
+}
+
+func (s *IOSTDevSDK) signAndSendOnce(ctx context.Context, tx *rpcpb.TransactionRequest) (string, error) {
 	signedTx, err := s.SignTx(tx, s.signAlgo)
 	if err != nil {
-		return "", fmt.Errorf("sign tx error %v", err)
+		return "", fmt.Errorf("sign tx error %w", err)
+	}
+	if err := s.afterSign(ctx, signedTx); err != nil {
+		return "", err
 	}
 	s.log("Sending transaction...")
 	s.log("Transaction:")
 	s.log(MarshalTextString(signedTx))
-	txHash, err := s.SendTransaction(signedTx)
+	txHash, err := s.SendTransactionCtx(ctx, signedTx)
+	s.afterSend(ctx, signedTx, txHash, err)
 	if err != nil {
-		return "", fmt.Errorf("send tx error %v", err)
+		return "", fmt.Errorf("send tx error %w", err)
 	}
-	s.log("Transaction has been sent.")
-	s.log("The transaction hash is:", txHash)
-	if s.checkResult {
-		if err = s.checkTransaction(txHash); err != nil {
-			return txHash, err
+	return txHash, nil
+}
+
+// ExecTx signs the transaction and executes it on the node as a dry run, which gives the receipt without changing the chain.
+func (s *IOSTDevSDK) ExecTx(tx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	return s.ExecTxCtx(context.Background(), tx)
+}
+
+// ExecTxCtx is ExecTx with a context to cancel the call.
+func (s *IOSTDevSDK) ExecTxCtx(ctx context.Context, tx *rpcpb.TransactionRequest) (*rpcpb.TxReceipt, error) {
+	if s.network != "" {
+		if err := s.CheckChainIDCtx(ctx); err != nil {
+			return nil, err
 		}
 	}
-	return txHash, nil
+	s.adjustTime(ctx, tx)
+	signedTx, err := s.SignTx(tx, s.signAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("sign tx error %w", err)
+	}
+	receipt, err := s.ExecTransactionCtx(ctx, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("exec tx error %w", err)
+	}
+	return receipt, nil
+}
+
+// CallReadOnly executes a contract abi on the node and returns the receipt, without sending a tx to the chain.
+// The node checks the publisher like a normal tx, so the account should be set unless the node skips the check.
+func (s *IOSTDevSDK) CallReadOnly(contract string, abi string, args string) (*rpcpb.TxReceipt, error) {
+	return s.CallReadOnlyCtx(context.Background(), contract, abi, args)
+}
+
+// CallReadOnlyCtx is CallReadOnly with a context to cancel the call.
+func (s *IOSTDevSDK) CallReadOnlyCtx(ctx context.Context, contract string, abi string, args string) (*rpcpb.TxReceipt, error) {
+	tx, err := s.CreateTxFromActions([]*rpcpb.Action{NewAction(contract, abi, args)})
+	if err != nil {
+		return nil, err
+	}
+	if s.signer != nil {
+		return s.ExecTxCtx(ctx, tx)
+	}
+	tx.Publisher = s.accountName
+	return s.ExecTransactionCtx(ctx, tx)
 }
 
 // SendTxFromActions send transaction and check result if sdk.checkResult is set
 func (s *IOSTDevSDK) SendTxFromActions(actions []*rpcpb.Action) (txHash string, err error) {
+	return s.SendTxFromActionsCtx(context.Background(), actions)
+}
+
+// SendTxFromActionsCtx is SendTxFromActions with a context, see SendTxCtx.
+func (s *IOSTDevSDK) SendTxFromActionsCtx(ctx context.Context, actions []*rpcpb.Action) (txHash string, err error) {
 	trx, err := s.CreateTxFromActions(actions)
 	if err != nil {
 		return "", err
 	}
-	return s.SendTx(trx)
+	return s.SendTxCtx(ctx, trx)
 }
 
 ////////////////////////////////////// some common used contract calling /////////////////////////////////////////////
 
 // PledgeForGasAndRAM ...
 func (s *IOSTDevSDK) PledgeForGasAndRAM(gasPledged int64, ram int64) error {
+	return s.PledgeForGasAndRAMCtx(context.Background(), gasPledged, ram)
+}
+
+// PledgeForGasAndRAMCtx is PledgeForGasAndRAM with a context, see SendTxCtx.
+func (s *IOSTDevSDK) PledgeForGasAndRAMCtx(ctx context.Context, gasPledged int64, ram int64) error {
 	var acts []*rpcpb.Action
 	acts = append(acts, NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, s.accountName, s.accountName, gasPledged)))
 	if ram > 0 {
 		acts = append(acts, NewAction("ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, s.accountName, s.accountName, ram)))
 	}
-	_, err := s.SendTxFromActions(acts)
+	_, err := s.SendTxFromActionsCtx(ctx, acts)
 	if err != nil {
 		return err
 	}
 	/*
 		info, err := s.GetAccountInfo(s.accountName)
 		if err != nil {
-			return fmt.Errorf("failed to get account info: %v", err)
+			return fmt.Errorf("failed to get account info: %w", err)
 		}
 		s.log("Account info of <", s.accountName, ">:")
 		s.log(MarshalTextString(info))
@@ -399,6 +1331,20 @@ func (s *IOSTDevSDK) PledgeForGasAndRAM(gasPledged int64, ram int64) error {
 
 // CreateNewAccount ... return txHash
 func (s *IOSTDevSDK) CreateNewAccount(newID string, ownerKey string, activeKey string, initialGasPledge int64, initialRAM int64, initialCoins int64) (string, error) {
+	return s.CreateNewAccountCtx(context.Background(), newID, ownerKey, activeKey, initialGasPledge, initialRAM, initialCoins)
+}
+
+// CreateNewAccountCtx is CreateNewAccount with a context, see SendTxCtx.
+func (s *IOSTDevSDK) CreateNewAccountCtx(ctx context.Context, newID string, ownerKey string, activeKey string, initialGasPledge int64, initialRAM int64, initialCoins int64) (string, error) {
+	trx, err := s.CreateNewAccountTx(newID, ownerKey, activeKey, initialGasPledge, initialRAM, initialCoins)
+	if err != nil {
+		return "", err
+	}
+	return s.SendTxCtx(ctx, trx)
+}
+
+// CreateNewAccountTx creates the unsigned transaction signing up an account with its initial ram, gas and balance.
+func (s *IOSTDevSDK) CreateNewAccountTx(newID string, ownerKey string, activeKey string, initialGasPledge int64, initialRAM int64, initialCoins int64) (*rpcpb.TransactionRequest, error) {
 	var acts []*rpcpb.Action
 	acts = append(acts, NewAction("auth.iost", "signUp", fmt.Sprintf(`["%v", "%v", "%v"]`, newID, ownerKey, activeKey)))
 	if initialRAM > 0 {
@@ -407,7 +1353,7 @@ func (s *IOSTDevSDK) CreateNewAccount(newID string, ownerKey string, activeKey s
 	var registerInitialPledge int64 = 10
 	initialGasPledge -= registerInitialPledge
 	if initialGasPledge < 0 {
-		return "", fmt.Errorf("min gas pledge is 10")
+		return nil, fmt.Errorf("min gas pledge is 10")
 	}
 	if initialGasPledge > 0 {
 		acts = append(acts, NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, s.accountName, newID, initialGasPledge)))
@@ -415,31 +1361,49 @@ func (s *IOSTDevSDK) CreateNewAccount(newID string, ownerKey string, activeKey s
 	if initialCoins > 0 {
 		acts = append(acts, NewAction("token.iost", "transfer", fmt.Sprintf(`["iost", "%v", "%v", "%v", ""]`, s.accountName, newID, initialCoins)))
 	}
-	txHash, err := s.SendTxFromActions(acts)
-	if err != nil {
-		return txHash, err
-	}
-	return txHash, nil
+	return s.CreateTxFromActions(acts)
 }
 
 // PublishContract converts contract js code to transaction. If 'send', also send it to chain.
 func (s *IOSTDevSDK) PublishContract(codePath string, abiPath string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, string, error) {
+	return s.PublishContractCtx(context.Background(), codePath, abiPath, conID, update, updateID)
+}
+
+// PublishContractCtx is PublishContract with a context, see SendTxCtx.
+func (s *IOSTDevSDK) PublishContractCtx(ctx context.Context, codePath string, abiPath string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, string, error) {
+	trx, err := s.CreatePublishContractTx(codePath, abiPath, conID, update, updateID)
+	if err != nil {
+		return nil, "", err
+	}
+	txHash, err := s.SendTxCtx(ctx, trx)
+	if err != nil {
+		return nil, "", err
+	}
+	return trx, txHash, nil
+}
+
+// CreatePublishContractTx converts contract js code to an unsigned transaction.
+func (s *IOSTDevSDK) CreatePublishContractTx(codePath string, abiPath string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, error) {
 	fd, err := ioutil.ReadFile(codePath)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to read source code file: %v", err)
+		return nil, fmt.Errorf("failed to read source code file: %v", err)
 	}
 	code := string(fd)
 
 	fd, err = ioutil.ReadFile(abiPath)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to read abi file: %v", err)
+		return nil, fmt.Errorf("failed to read abi file: %v", err)
 	}
 	abi := string(fd)
+	return s.CreatePublishContractTxFromCode(code, abi, conID, update, updateID)
+}
 
+// CreatePublishContractTxFromCode converts contract js code and abi to an unsigned transaction.
+func (s *IOSTDevSDK) CreatePublishContractTxFromCode(code string, abi string, conID string, update bool, updateID string) (*rpcpb.TransactionRequest, error) {
 	var info *contract.Info
-	err = json.Unmarshal([]byte(abi), &info)
+	err := json.Unmarshal([]byte(abi), &info)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	c := &contract.Contract{
 		ID:   conID,
@@ -455,13 +1419,13 @@ func (s *IOSTDevSDK) PublishContract(codePath string, abiPath string, conID stri
 	if marshalMethod == "json" {
 		buf, err := json.Marshal(c)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 		contractStr = string(buf)
 	} else {
 		buf, err := proto.Marshal(c)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 		contractStr = base64.StdEncoding.EncodeToString(buf)
 	}
@@ -471,32 +1435,63 @@ func (s *IOSTDevSDK) PublishContract(codePath string, abiPath string, conID stri
 	}
 	data, err := json.Marshal(arr)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	action := NewAction("system.iost", methodName, string(data))
-	trx, err := s.CreateTxFromActions([]*rpcpb.Action{action})
-	if err != nil {
-		return nil, "", err
-	}
-	txHash, err := s.SendTx(trx)
-	if err != nil {
-		return nil, "", err
-	}
-	return trx, txHash, nil
+	return s.CreateTxFromActions([]*rpcpb.Action{action})
 }
 
 // GetProducerVoteInfo ...
 func (s *IOSTDevSDK) GetProducerVoteInfo(r *rpcpb.GetProducerVoteInfoRequest) (*rpcpb.GetProducerVoteInfoResponse, error) {
-	if s.rpcConn == nil {
-		if err := s.Connect(); err != nil {
+	return s.GetProducerVoteInfoCtx(context.Background(), r)
+}
+
+// GetProducerVoteInfoCtx is GetProducerVoteInfo with a context to cancel the call.
+func (s *IOSTDevSDK) GetProducerVoteInfoCtx(ctx context.Context, r *rpcpb.GetProducerVoteInfoRequest) (*rpcpb.GetProducerVoteInfoResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
 			return nil, err
 		}
 		defer s.CloseConn()
 	}
-	client := rpcpb.NewApiServiceClient(s.rpcConn)
-	value, err := client.GetProducerVoteInfo(context.Background(), r)
+	client := s.apiClient()
+	value, err := client.GetProducerVoteInfo(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
+
+// GetProducers returns a page of the producer candidates and their vote info, see `ProducersIterator`
+func (s *IOSTDevSDK) GetProducers(r *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error) {
+	return s.GetProducersCtx(context.Background(), r)
+}
+
+// GetProducersCtx is GetProducers with a context to cancel the call.
+func (s *IOSTDevSDK) GetProducersCtx(ctx context.Context, r *rpcpb.GetProducersRequest) (*rpcpb.GetProducersResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetProducers(ctx, r)
+}
+
+// GetVoters returns a page of the voters of a producer candidate and their votes, see `VotersIterator`
+func (s *IOSTDevSDK) GetVoters(r *rpcpb.GetVotersRequest) (*rpcpb.GetVotersResponse, error) {
+	return s.GetVotersCtx(context.Background(), r)
+}
+
+// GetVotersCtx is GetVoters with a context to cancel the call.
+func (s *IOSTDevSDK) GetVotersCtx(ctx context.Context, r *rpcpb.GetVotersRequest) (*rpcpb.GetVotersResponse, error) {
+	if !s.connected() {
+		if err := s.ConnectCtx(ctx); err != nil {
+			return nil, err
+		}
+		defer s.CloseConn()
+	}
+	client := s.apiClient()
+	return client.GetVoters(ctx, r)
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNextCheckInterval(t *testing.T) {
+	assert.Equal(t, minCheckInterval, nextCheckInterval(0))
+	assert.Equal(t, 6*time.Second, nextCheckInterval(3*time.Second))
+	assert.Equal(t, maxCheckInterval, nextCheckInterval(6*time.Second))
+}
+
+func TestWaitTx(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.txs["packed"], n.txs["irreversible"] = true, true
+	n.packedPolls = 2
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	// the checks back off until the tx is irreversible
+	s.SetCheckResult(true, 0, 5*time.Second)
+	start := time.Now()
+	receipt, err := s.WaitTx("irreversible")
+	assert.Nil(t, err)
+	assert.Equal(t, "irreversible", receipt.TxHash)
+	assert.True(t, time.Since(start) >= minCheckInterval+2*minCheckInterval)
+	assert.Equal(t, 3, n.callCount("GetTxByHash"))
+
+	// or until the wait timeout
+	s.SetCheckResult(true, 0, 150*time.Millisecond)
+	n.mu.Lock()
+	n.packedPolls = 10
+	n.mu.Unlock()
+	_, err = s.WaitTx("packed")
+	assert.EqualError(t, err, "transaction is still PACKED after 150ms")
+	_, err = s.WaitTx("missing")
+	assert.EqualError(t, err, "transaction not found after 150ms")
+}
+
+func TestSendTxCheckResult(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	n.mu.Lock()
+	n.packedPolls, n.receiptCode = 1, rpcpb.TxReceipt_BALANCE_NOT_ENOUGH
+	n.mu.Unlock()
+	s := newTestTxSDK(t, n)
+	defer s.CloseConn()
+
+	// the tx failing on chain is reported once irreversible
+	s.SetCheckResult(true, 0, 5*time.Second)
+	hash, err := s.SendTx(newTestTx(1))
+	assert.IsType(t, &ReceiptError{}, err)
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, 2, n.callCount("GetTxByHash"))
+
+	// it is not waited for without checking the result
+	s.SetCheckResult(false, 0, 5*time.Second)
+	_, err = s.SendTx(newTestTx(2))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n.callCount("GetTxByHash"))
+}
+
+func TestCtxCancel(t *testing.T) {
+	n := newTestNode(t, 100)
+	defer n.stop()
+	wait := make(chan struct{})
+	defer close(wait)
+	n.mu.Lock()
+	n.txs["packed"] = true
+	n.wait = wait
+	n.mu.Unlock()
+	s := newTestSDK(t, n)
+	defer s.CloseConn()
+
+	// a hung call is given up at the deadline and not retried
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err := s.GetNodeInfoCtx(ctx)
+	cancel()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 1, n.callCount("GetNodeInfo"))
+
+	// so is waiting for a tx, well before the wait timeout
+	s.SetCheckResult(true, 0, time.Minute)
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(150*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = s.WaitTxCtx(ctx, "packed")
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
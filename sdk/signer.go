@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+)
+
+// Signer signs transaction hashes on behalf of an account without exposing the secret key.
+type Signer interface {
+	// Algorithm returns the signing algorithm.
+	Algorithm() crypto.Algorithm
+	// PubKey returns the raw public key.
+	PubKey() []byte
+	// Sign signs the given hash.
+	Sign(hash []byte) ([]byte, error)
+}
+
+// KeyPairSigner signs with a local key pair.
+type KeyPairSigner struct {
+	kp *account.KeyPair
+}
+
+// NewKeyPairSigner returns a signer backed by a local key pair.
+func NewKeyPairSigner(kp *account.KeyPair) *KeyPairSigner {
+	return &KeyPairSigner{kp: kp}
+}
+
+// Algorithm ...
+func (s *KeyPairSigner) Algorithm() crypto.Algorithm {
+	return s.kp.Algorithm
+}
+
+// PubKey ...
+func (s *KeyPairSigner) PubKey() []byte {
+	return s.kp.Pubkey
+}
+
+// Sign ...
+func (s *KeyPairSigner) Sign(hash []byte) ([]byte, error) {
+	return s.kp.Algorithm.Sign(hash, s.kp.Seckey), nil
+}
@@ -0,0 +1,206 @@
+// Package awskms signs with secp256k1 keys of AWS KMS, which never leave its HSMs:
+//
+//	c, err := awskms.NewClient(awskms.Options{Region: "us-east-1", Credentials: creds})
+//	keyID, err := c.CreateKey(ctx, "hot wallet")
+//	kmsSigner, err := c.NewSigner(ctx, keyID)
+//	fmt.Println(signer.PublicKey(kmsSigner)) // to create the account with
+//	iostSDK.SetSigner("hotwallet", kmsSigner)
+//
+// It calls the json api of KMS directly, signing the requests by AWS signature version 4, so that applications do
+// not depend on the AWS sdk. The credentials need the kms:CreateKey, kms:GetPublicKey and kms:Sign permissions.
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/sdk/signer"
+)
+
+// KMS names of the keys and signatures of iost.
+const (
+	KeySpec          = "ECC_SECG_P256K1"
+	SigningAlgorithm = "ECDSA_SHA_256"
+)
+
+// Credentials are the AWS access keys signing the requests, SessionToken being set for temporary ones.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// CredentialsFromEnv reads the credentials from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+func CredentialsFromEnv() (Credentials, error) {
+	c := Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return c, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY should be set")
+	}
+	return c, nil
+}
+
+// Options configures a client of KMS.
+type Options struct {
+	// Region is the region of the keys, eg us-east-1.
+	Region      string
+	Credentials Credentials
+	// Endpoint is the url of KMS, https://kms.REGION.amazonaws.com if empty, eg for VPC endpoints.
+	Endpoint string
+	// Client sends the requests, a client with a timeout of 30s if nil.
+	Client *http.Client
+}
+
+// Client calls the KMS api of a region.
+type Client struct {
+	opts Options
+	now  func() time.Time
+}
+
+// NewClient returns a client of KMS.
+func NewClient(opts Options) (*Client, error) {
+	if opts.Region == "" {
+		return nil, fmt.Errorf("no region of aws kms")
+	}
+	if opts.Credentials.AccessKeyID == "" || opts.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("no credentials of aws kms")
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://kms." + opts.Region + ".amazonaws.com"
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{opts: opts, now: time.Now}, nil
+}
+
+// call sends the action of the KMS api, decoding its answer into res.
+func (c *Client) call(ctx context.Context, action string, req interface{}, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r, err := http.NewRequest(http.MethodPost, c.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r = r.WithContext(ctx)
+	r.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	r.Header.Set("X-Amz-Target", "TrentService."+action)
+	signV4(r, body, c.opts.Credentials, c.opts.Region, "kms", c.now())
+	resp, err := c.opts.Client.Do(r)
+	if err != nil {
+		return fmt.Errorf("aws kms %v error: %v", action, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("aws kms %v error: %v", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &e) != nil || e.Type == "" {
+			return fmt.Errorf("aws kms %v error: %v", action, resp.Status)
+		}
+		// the type may be prefixed by its namespace, eg com.amazonaws.kms#NotFoundException
+		return fmt.Errorf("aws kms %v error: %v: %v", action, e.Type[strings.LastIndex(e.Type, "#")+1:], e.Message)
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("aws kms %v error: invalid response: %v", action, err)
+	}
+	return nil
+}
+
+// CreateKey creates a secp256k1 signing key in KMS, returning its id.
+func (c *Client) CreateKey(ctx context.Context, description string) (string, error) {
+	var res struct {
+		KeyMetadata struct {
+			KeyID string `json:"KeyId"`
+		}
+	}
+	err := c.call(ctx, "CreateKey", map[string]string{
+		"KeySpec":     KeySpec,
+		"KeyUsage":    "SIGN_VERIFY",
+		"Description": description,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.KeyMetadata.KeyID, nil
+}
+
+// Signer signs with a key of KMS.
+type Signer struct {
+	c      *Client
+	keyID  string
+	pubkey []byte
+}
+
+// NewSigner returns the signer of the key of KMS, given by its id, arn or alias like alias/hotwallet, reading its
+// public key.
+func (c *Client) NewSigner(ctx context.Context, keyID string) (*Signer, error) {
+	var res struct {
+		PublicKey []byte
+		KeySpec   string
+		KeyUsage  string
+	}
+	if err := c.call(ctx, "GetPublicKey", map[string]string{"KeyId": keyID}, &res); err != nil {
+		return nil, err
+	}
+	if res.KeySpec != KeySpec || res.KeyUsage != "SIGN_VERIFY" {
+		return nil, fmt.Errorf("key %v is a %v key for %v, iost needs a %v key for SIGN_VERIFY", keyID, res.KeySpec, res.KeyUsage, KeySpec)
+	}
+	_, pubkey, err := signer.ParsePublicKey(res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{c: c, keyID: keyID, pubkey: pubkey}, nil
+}
+
+// Algorithm ...
+func (s *Signer) Algorithm() crypto.Algorithm {
+	return crypto.Secp256k1
+}
+
+// PubKey ...
+func (s *Signer) PubKey() []byte {
+	return s.pubkey
+}
+
+// Sign signs the hash by KMS, checking the signature it answers.
+func (s *Signer) Sign(hash []byte) ([]byte, error) {
+	var res struct {
+		Signature []byte
+	}
+	err := s.c.call(context.Background(), "Sign", map[string]interface{}{
+		"KeyId":            s.keyID,
+		"Message":          hash,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": SigningAlgorithm,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.ParseECDSASignature(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.Secp256k1.Verify(hash, s.pubkey, sig) {
+		return nil, fmt.Errorf("aws kms Sign error: invalid signature of key %v", s.keyID)
+	}
+	return sig, nil
+}
@@ -0,0 +1,113 @@
+package awskms
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// the example of the AWS signature version 4 documentation
+func TestSignV4(t *testing.T) {
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	assert.Equal(t, "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9",
+		hex.EncodeToString(signingKey(creds.SecretAccessKey, "20150830", "us-east-1", "iam")))
+
+	r, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	assert.Nil(t, err)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signV4(r, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", r.Header.Get("Authorization"))
+}
+
+// fakeKMS answers the actions of KMS with local keys, making signatures of the higher S.
+type fakeKMS struct {
+	keys map[string]*account.KeyPair
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fail := func(typ string, msg string) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"__type": typ, "message": msg})
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		fail("UnrecognizedClientException", "The security token included in the request is invalid.")
+		return
+	}
+	var req struct {
+		KeyID   string `json:"KeyId"`
+		Message []byte
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	kp := f.keys[req.KeyID]
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "TrentService.")
+	if kp == nil && action != "CreateKey" {
+		fail("com.amazonaws.kms#NotFoundException", "Key '"+req.KeyID+"' does not exist")
+		return
+	}
+	var res interface{}
+	switch action {
+	case "CreateKey":
+		kp, _ = account.NewKeyPair(nil, crypto.Secp256k1)
+		f.keys["key1"] = kp
+		res = map[string]interface{}{"KeyMetadata": map[string]string{"KeyId": "key1"}}
+	case "GetPublicKey":
+		x, y := secp256k1.S256().ScalarBaseMult(kp.Seckey)
+		key := append([]byte{4}, append(x.FillBytes(make([]byte, 32)), y.FillBytes(make([]byte, 32))...)...)
+		der, _ := asn1.Marshal(struct {
+			Algorithm struct{ Algorithm, Parameters asn1.ObjectIdentifier }
+			PublicKey asn1.BitString
+		}{
+			Algorithm: struct{ Algorithm, Parameters asn1.ObjectIdentifier }{
+				asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, asn1.ObjectIdentifier{1, 3, 132, 0, 10},
+			},
+			PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+		})
+		res = map[string]interface{}{"PublicKey": der, "KeySpec": KeySpec, "KeyUsage": "SIGN_VERIFY"}
+	case "Sign":
+		sig := crypto.Secp256k1.Sign(req.Message, kp.Seckey)
+		s := new(big.Int).Sub(secp256k1.S256().Params().N, new(big.Int).SetBytes(sig[32:]))
+		der, _ := asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), s})
+		res = map[string]interface{}{"Signature": der}
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+func TestSigner(t *testing.T) {
+	server := httptest.NewServer(&fakeKMS{keys: map[string]*account.KeyPair{}})
+	defer server.Close()
+	ctx := context.Background()
+	c, err := NewClient(Options{Region: "us-east-1", Endpoint: server.URL, Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}})
+	assert.Nil(t, err)
+
+	keyID, err := c.CreateKey(ctx, "test")
+	assert.Nil(t, err)
+	s, err := c.NewSigner(ctx, keyID)
+	assert.Nil(t, err)
+	assert.Equal(t, crypto.Secp256k1, s.Algorithm())
+	assert.Len(t, s.PubKey(), 33)
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	sig, err := s.Sign(hash)
+	assert.Nil(t, err)
+	assert.True(t, crypto.Secp256k1.Verify(hash, s.PubKey(), sig))
+
+	_, err = c.NewSigner(ctx, "key2")
+	assert.Equal(t, "aws kms GetPublicKey error: NotFoundException: Key 'key2' does not exist", err.Error())
+	c, err = NewClient(Options{Region: "us-east-1", Endpoint: server.URL, Credentials: Credentials{AccessKeyID: "other", SecretAccessKey: "secret"}})
+	assert.Nil(t, err)
+	_, err = c.NewSigner(ctx, keyID)
+	assert.Contains(t, err.Error(), "UnrecognizedClientException")
+}
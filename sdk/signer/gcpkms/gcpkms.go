@@ -0,0 +1,243 @@
+// Package gcpkms signs with keys of Google Cloud KMS, secp256k1 ones in its HSMs or ed25519 ones:
+//
+//	c, err := gcpkms.NewClient(gcpkms.Options{Token: gcpkms.MetadataToken()})
+//	version, err := c.CreateKey(ctx, "projects/P/locations/global/keyRings/R", "hotwallet", crypto.Secp256k1)
+//	kmsSigner, err := c.NewSigner(ctx, version)
+//	fmt.Println(signer.PublicKey(kmsSigner)) // to create the account with
+//	iostSDK.SetSigner("hotwallet", kmsSigner)
+//
+// It calls the rest api of Cloud KMS directly, so that applications do not depend on the Google Cloud sdk. The
+// service account needs the roles/cloudkms.admin role to create keys, and roles/cloudkms.signerVerifier to sign.
+package gcpkms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/sdk/signer"
+)
+
+// KMS names of the algorithms of the keys of iost.
+const (
+	AlgorithmSecp256k1 = "EC_SIGN_SECP256K1_SHA256"
+	AlgorithmEd25519   = "EC_SIGN_ED25519"
+)
+
+// TokenSource returns the oauth2 access token authorizing the requests, with the cloud-platform or cloudkms scope.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a token source of a fixed token, eg one from "gcloud auth print-access-token".
+func StaticToken(token string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// MetadataToken returns a token source of the service account of the instance, read from the metadata server on
+// Compute Engine, GKE and Cloud Run. The token is cached until a minute before it expires.
+func MetadataToken() TokenSource {
+	var mu sync.Mutex
+	var token string
+	var expiry time.Time
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" && time.Now().Before(expiry) {
+			return token, nil
+		}
+		r, err := http.NewRequest(http.MethodGet, "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token", nil)
+		if err != nil {
+			return "", err
+		}
+		r.Header.Set("Metadata-Flavor", "Google")
+		resp, err := client.Do(r.WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to get token from metadata server: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to get token from metadata server: %v", resp.Status)
+		}
+		var res struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return "", fmt.Errorf("failed to get token from metadata server: %v", err)
+		}
+		token, expiry = res.AccessToken, time.Now().Add(time.Duration(res.ExpiresIn)*time.Second-time.Minute)
+		return token, nil
+	}
+}
+
+// Options configures a client of Cloud KMS.
+type Options struct {
+	Token TokenSource
+	// Endpoint is the url of Cloud KMS, https://cloudkms.googleapis.com if empty.
+	Endpoint string
+	// Client sends the requests, a client with a timeout of 30s if nil.
+	Client *http.Client
+}
+
+// Client calls the Cloud KMS api.
+type Client struct {
+	opts Options
+}
+
+// NewClient returns a client of Cloud KMS.
+func NewClient(opts Options) (*Client, error) {
+	if opts.Token == nil {
+		return nil, fmt.Errorf("no token source of gcp kms")
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://cloudkms.googleapis.com"
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{opts: opts}, nil
+}
+
+// call sends the request to the resource path of the api, like projects/P/.../cryptoKeyVersions/1:asymmetricSign,
+// decoding its answer into res. A nil req is sent as a GET.
+func (c *Client) call(ctx context.Context, path string, req interface{}, res interface{}) error {
+	token, err := c.opts.Token(ctx)
+	if err != nil {
+		return err
+	}
+	method, body := http.MethodGet, []byte(nil)
+	if req != nil {
+		method = http.MethodPost
+		if body, err = json.Marshal(req); err != nil {
+			return err
+		}
+	}
+	r, err := http.NewRequest(method, c.opts.Endpoint+"/v1/"+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r = r.WithContext(ctx)
+	r.Header.Set("Authorization", "Bearer "+token)
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := c.opts.Client.Do(r)
+	if err != nil {
+		return fmt.Errorf("gcp kms error: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("gcp kms error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &e) != nil || e.Error.Message == "" {
+			return fmt.Errorf("gcp kms error: %v", resp.Status)
+		}
+		return fmt.Errorf("gcp kms error: %v: %v", e.Error.Status, e.Error.Message)
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("gcp kms error: invalid response: %v", err)
+	}
+	return nil
+}
+
+// CreateKey creates a signing key of the algorithm in the key ring, like projects/P/locations/L/keyRings/R, returning
+// the name of its first version to sign with. Secp256k1 keys are kept in HSMs, the only protection level Cloud KMS
+// allows for them, and ed25519 ones in software.
+func (c *Client) CreateKey(ctx context.Context, keyRing string, id string, algo crypto.Algorithm) (string, error) {
+	template := map[string]string{"algorithm": AlgorithmSecp256k1, "protectionLevel": "HSM"}
+	if algo == crypto.Ed25519 {
+		template = map[string]string{"algorithm": AlgorithmEd25519, "protectionLevel": "SOFTWARE"}
+	}
+	var res struct {
+		Name string `json:"name"`
+	}
+	err := c.call(ctx, keyRing+"/cryptoKeys?cryptoKeyId="+url.QueryEscape(id), map[string]interface{}{
+		"purpose":         "ASYMMETRIC_SIGN",
+		"versionTemplate": template,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.Name + "/cryptoKeyVersions/1", nil
+}
+
+// Signer signs with a key version of Cloud KMS.
+type Signer struct {
+	c       *Client
+	version string
+	algo    crypto.Algorithm
+	pubkey  []byte
+}
+
+// NewSigner returns the signer of the key version, like projects/P/locations/L/keyRings/R/cryptoKeys/K/cryptoKeyVersions/1,
+// reading its public key.
+func (c *Client) NewSigner(ctx context.Context, version string) (*Signer, error) {
+	var res struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := c.call(ctx, version+"/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != AlgorithmSecp256k1 && res.Algorithm != AlgorithmEd25519 {
+		return nil, fmt.Errorf("key %v is a %v key, iost needs a %v or %v key", version, res.Algorithm, AlgorithmSecp256k1, AlgorithmEd25519)
+	}
+	algo, pubkey, err := signer.ParsePEMPublicKey(res.Pem)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{c: c, version: version, algo: algo, pubkey: pubkey}, nil
+}
+
+// Algorithm ...
+func (s *Signer) Algorithm() crypto.Algorithm {
+	return s.algo
+}
+
+// PubKey ...
+func (s *Signer) PubKey() []byte {
+	return s.pubkey
+}
+
+// Sign signs the hash by Cloud KMS, checking the signature it answers. Secp256k1 keys sign the hash as the digest,
+// ed25519 ones as the data.
+func (s *Signer) Sign(hash []byte) ([]byte, error) {
+	req := map[string]interface{}{"data": hash}
+	if s.algo == crypto.Secp256k1 {
+		req = map[string]interface{}{"digest": map[string][]byte{"sha256": hash}}
+	}
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.c.call(context.Background(), s.version+":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	sig := res.Signature
+	if s.algo == crypto.Secp256k1 {
+		var err error
+		if sig, err = signer.ParseECDSASignature(sig); err != nil {
+			return nil, err
+		}
+	}
+	if !s.algo.Verify(hash, s.pubkey, sig) {
+		return nil, fmt.Errorf("gcp kms error: invalid signature of key %v", s.version)
+	}
+	return sig, nil
+}
@@ -0,0 +1,121 @@
+package gcpkms
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const keyRing = "projects/p/locations/global/keyRings/r"
+
+// fakeKMS answers Cloud KMS with local keys by version name.
+type fakeKMS struct {
+	keys map[string]*account.KeyPair
+}
+
+func pemPublicKey(kp *account.KeyPair) string {
+	type algorithmIdentifier struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier `asn1:"optional"`
+	}
+	alg := algorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 101, 112}}
+	key := kp.Pubkey
+	if kp.Algorithm == crypto.Secp256k1 {
+		alg = algorithmIdentifier{asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, asn1.ObjectIdentifier{1, 3, 132, 0, 10}}
+		x, y := secp256k1.S256().ScalarBaseMult(kp.Seckey)
+		key = append([]byte{4}, append(x.FillBytes(make([]byte, 32)), y.FillBytes(make([]byte, 32))...)...)
+	}
+	der, _ := asn1.Marshal(struct {
+		Algorithm algorithmIdentifier
+		PublicKey asn1.BitString
+	}{alg, asn1.BitString{Bytes: key, BitLength: 8 * len(key)}})
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fail := func(code int, status string, msg string) {
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": code, "status": status, "message": msg}})
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		fail(http.StatusUnauthorized, "UNAUTHENTICATED", "Request had invalid authentication credentials.")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	var req struct {
+		Purpose         string
+		VersionTemplate struct{ Algorithm string }
+		Data            []byte
+		Digest          struct{ Sha256 []byte }
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case path == keyRing+"/cryptoKeys":
+		algo := crypto.Secp256k1
+		if req.VersionTemplate.Algorithm == AlgorithmEd25519 {
+			algo = crypto.Ed25519
+		}
+		kp, _ := account.NewKeyPair(nil, algo)
+		name := keyRing + "/cryptoKeys/" + r.URL.Query().Get("cryptoKeyId")
+		f.keys[name+"/cryptoKeyVersions/1"] = kp
+		json.NewEncoder(w).Encode(map[string]string{"name": name})
+	case strings.HasSuffix(path, "/publicKey") && f.keys[strings.TrimSuffix(path, "/publicKey")] != nil:
+		kp := f.keys[strings.TrimSuffix(path, "/publicKey")]
+		algo := AlgorithmSecp256k1
+		if kp.Algorithm == crypto.Ed25519 {
+			algo = AlgorithmEd25519
+		}
+		json.NewEncoder(w).Encode(map[string]string{"pem": pemPublicKey(kp), "algorithm": algo})
+	case strings.HasSuffix(path, ":asymmetricSign") && f.keys[strings.TrimSuffix(path, ":asymmetricSign")] != nil:
+		kp := f.keys[strings.TrimSuffix(path, ":asymmetricSign")]
+		var sig []byte
+		if kp.Algorithm == crypto.Ed25519 {
+			sig = crypto.Ed25519.Sign(req.Data, kp.Seckey)
+		} else {
+			rs := crypto.Secp256k1.Sign(req.Digest.Sha256, kp.Seckey)
+			sig, _ = asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(rs[:32]), new(big.Int).SetBytes(rs[32:])})
+		}
+		json.NewEncoder(w).Encode(map[string][]byte{"signature": sig})
+	default:
+		fail(http.StatusNotFound, "NOT_FOUND", path+" not found.")
+	}
+}
+
+func TestSigner(t *testing.T) {
+	server := httptest.NewServer(&fakeKMS{keys: map[string]*account.KeyPair{}})
+	defer server.Close()
+	ctx := context.Background()
+	c, err := NewClient(Options{Token: StaticToken("token"), Endpoint: server.URL})
+	assert.Nil(t, err)
+
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	for _, algo := range []crypto.Algorithm{crypto.Secp256k1, crypto.Ed25519} {
+		version, err := c.CreateKey(ctx, keyRing, algo.String(), algo)
+		assert.Nil(t, err)
+		assert.Equal(t, keyRing+"/cryptoKeys/"+algo.String()+"/cryptoKeyVersions/1", version)
+		s, err := c.NewSigner(ctx, version)
+		assert.Nil(t, err)
+		assert.Equal(t, algo, s.Algorithm())
+		sig, err := s.Sign(hash)
+		assert.Nil(t, err)
+		assert.True(t, algo.Verify(hash, s.PubKey(), sig))
+	}
+
+	_, err = c.NewSigner(ctx, keyRing+"/cryptoKeys/k/cryptoKeyVersions/1")
+	assert.Contains(t, err.Error(), "gcp kms error: NOT_FOUND")
+	c, err = NewClient(Options{Token: StaticToken("other"), Endpoint: server.URL})
+	assert.Nil(t, err)
+	_, err = c.CreateKey(ctx, keyRing, "k", crypto.Secp256k1)
+	assert.Contains(t, err.Error(), "gcp kms error: UNAUTHENTICATED")
+}
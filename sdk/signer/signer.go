@@ -0,0 +1,103 @@
+// Package signer holds what the signers of keys kept out of the application share, like the cloud KMS signers of
+// packages awskms and gcpkms: reading the public keys and signatures they answer in the standard encodings into
+// those of iost.
+package signer
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidEd25519     = asn1.ObjectIdentifier{1, 3, 101, 112}
+)
+
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.RawValue `asn1:"optional"`
+	}
+	PublicKey asn1.BitString
+}
+
+// ParsePublicKey reads a public key from its DER encoded subject public key info, which the KMSs answer, into the raw
+// public key of iost: the compressed point of a secp256k1 key, or the 32 bytes of an ed25519 key.
+func ParsePublicKey(der []byte) (crypto.Algorithm, []byte, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	if len(rest) != 0 {
+		return 0, nil, fmt.Errorf("invalid public key: trailing data")
+	}
+	key := info.PublicKey.RightAlign()
+	switch {
+	case info.Algorithm.Algorithm.Equal(oidEd25519):
+		if len(key) != 32 {
+			return 0, nil, fmt.Errorf("invalid ed25519 public key of %v bytes", len(key))
+		}
+		return crypto.Ed25519, key, nil
+	case info.Algorithm.Algorithm.Equal(oidECPublicKey):
+		var curve asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidSecp256k1) {
+			return 0, nil, fmt.Errorf("unsupported curve of public key, only secp256k1 is used by iost")
+		}
+		if len(key) != 65 || key[0] != 4 {
+			return 0, nil, fmt.Errorf("invalid secp256k1 public key")
+		}
+		x, y := new(big.Int).SetBytes(key[1:33]), new(big.Int).SetBytes(key[33:])
+		if !secp256k1.S256().IsOnCurve(x, y) {
+			return 0, nil, fmt.Errorf("invalid secp256k1 public key")
+		}
+		return crypto.Secp256k1, secp256k1.CompressPubkey(x, y), nil
+	}
+	return 0, nil, fmt.Errorf("unsupported public key algorithm %v", info.Algorithm.Algorithm)
+}
+
+// ParsePEMPublicKey reads a public key from its PEM encoded subject public key info, see `ParsePublicKey`.
+func ParsePEMPublicKey(data string) (crypto.Algorithm, []byte, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return 0, nil, fmt.Errorf("invalid public key: no PUBLIC KEY pem block")
+	}
+	return ParsePublicKey(block.Bytes)
+}
+
+// ParseECDSASignature reads a DER encoded ecdsa signature into the 64 bytes R || S signature of a secp256k1 key of
+// iost. S is replaced by N - S if greater than N / 2, since the chain only accepts the lower S of the two valid ones.
+func ParseECDSASignature(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	n := secp256k1.S256().Params().N
+	if len(rest) != 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(n) >= 0 || sig.S.Cmp(n) >= 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	if sig.S.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sig.S = new(big.Int).Sub(n, sig.S)
+	}
+	ret := make([]byte, 64)
+	sig.R.FillBytes(ret[:32])
+	sig.S.FillBytes(ret[32:])
+	return ret, nil
+}
+
+// PublicKey returns the public key of the signer in the base58 format of iost, to be set in the permissions of an
+// account, eg by "iwallet account create --owner KEY --active KEY".
+func PublicKey(s sdk.Signer) string {
+	return common.Base58Encode(s.PubKey())
+}
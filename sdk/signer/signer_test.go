@@ -0,0 +1,81 @@
+package signer
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+func marshalPublicKey(t *testing.T, kp *account.KeyPair) []byte {
+	info := struct {
+		Algorithm algorithmIdentifier
+		PublicKey asn1.BitString
+	}{}
+	if kp.Algorithm == crypto.Ed25519 {
+		info.Algorithm.Algorithm = oidEd25519
+		info.PublicKey = asn1.BitString{Bytes: kp.Pubkey, BitLength: 8 * len(kp.Pubkey)}
+	} else {
+		info.Algorithm = algorithmIdentifier{oidECPublicKey, oidSecp256k1}
+		x, y := secp256k1.S256().ScalarBaseMult(kp.Seckey)
+		key := make([]byte, 65)
+		key[0] = 4
+		x.FillBytes(key[1:33])
+		y.FillBytes(key[33:])
+		info.PublicKey = asn1.BitString{Bytes: key, BitLength: 8 * len(key)}
+	}
+	der, err := asn1.Marshal(info)
+	assert.Nil(t, err)
+	return der
+}
+
+func TestParsePublicKey(t *testing.T) {
+	for _, algo := range []crypto.Algorithm{crypto.Secp256k1, crypto.Ed25519} {
+		kp, err := account.NewKeyPair(nil, algo)
+		assert.Nil(t, err)
+		der := marshalPublicKey(t, kp)
+		a, pubkey, err := ParsePublicKey(der)
+		assert.Nil(t, err)
+		assert.Equal(t, algo, a)
+		assert.Equal(t, kp.Pubkey, pubkey)
+
+		a, pubkey, err = ParsePEMPublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+		assert.Nil(t, err)
+		assert.Equal(t, algo, a)
+		assert.Equal(t, kp.Pubkey, pubkey)
+	}
+	_, _, err := ParsePublicKey([]byte("key"))
+	assert.NotNil(t, err)
+	_, _, err = ParsePEMPublicKey("key")
+	assert.NotNil(t, err)
+}
+
+func TestParseECDSASignature(t *testing.T) {
+	kp, err := account.NewKeyPair(nil, crypto.Secp256k1)
+	assert.Nil(t, err)
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	sig := crypto.Secp256k1.Sign(hash, kp.Seckey)
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+
+	// both the lower S and the higher one are read as the lower one
+	for _, s := range []*big.Int{s, new(big.Int).Sub(secp256k1.S256().Params().N, s)} {
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		assert.Nil(t, err)
+		parsed, err := ParseECDSASignature(der)
+		assert.Nil(t, err)
+		assert.Equal(t, sig, parsed)
+		assert.True(t, crypto.Secp256k1.Verify(hash, kp.Pubkey, parsed))
+	}
+	_, err = ParseECDSASignature([]byte("sig"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,214 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// streamChSize is the buffer size of the channels returned by the subscriptions.
+	streamChSize = 100
+	// minStreamBackoff and maxStreamBackoff bound the wait between reconnections of a broken subscription.
+	minStreamBackoff = time.Second
+	maxStreamBackoff = time.Minute
+)
+
+func nextStreamBackoff(cur time.Duration) time.Duration {
+	if cur < minStreamBackoff {
+		return minStreamBackoff
+	}
+	cur *= 2
+	if cur > maxStreamBackoff {
+		cur = maxStreamBackoff
+	}
+	return cur
+}
+
+// streamServers returns the servers to subscribe to in turn, starting with the one in use.
+func (s *IOSTDevSDK) streamServers() []string {
+	current := s.Server()
+	servers := []string{current}
+	for _, server := range s.servers {
+		if server != current {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// runStream keeps a subscription open until ctx is done. Each attempt dials a connection of its own, so that the
+// subscription does not disturb other calls of the sdk, and moves on to the next server if there are several.
+// open reads the stream until it breaks, and reports whether anything was received so that the backoff is reset.
+func (s *IOSTDevSDK) runStream(ctx context.Context, servers []string, dialOpts []grpc.DialOption, open func(client rpcpb.ApiServiceClient) (bool, error)) {
+	var backoff time.Duration
+	for i := 0; ; i++ {
+		server := servers[i%len(servers)]
+		if i > 0 {
+			s.observeReconnect(ReconnectSubscription, server)
+		}
+		received, err := s.streamOnce(ctx, server, dialOpts, open)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = 0
+		}
+		backoff = nextStreamBackoff(backoff)
+		s.log("Subscription to", server, "broken:", err, "reconnecting in", backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *IOSTDevSDK) streamOnce(ctx context.Context, server string, dialOpts []grpc.DialOption, open func(client rpcpb.ApiServiceClient) (bool, error)) (bool, error) {
+	if isGateway(server) {
+		return open(s.newGatewayClient(server, nil))
+	}
+	conn, err := grpc.DialContext(ctx, server, dialOpts...)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	return open(rpcpb.NewApiServiceClient(conn))
+}
+
+// SubscribeNewBlocks sends the blocks from fromHeight on in order, with their transactions, until ctx is done.
+// Only irreversible blocks are sent unless the sdk uses the longest chain, in which case blocks are sent as soon as
+// they are the head and may be reverted later. A fromHeight of 0 starts with the next new block.
+// The subscription is reestablished if the connection breaks and resumes from the block after the last one sent,
+// so no block is missed or sent twice. The channel is closed once ctx is done.
+func (s *IOSTDevSDK) SubscribeNewBlocks(ctx context.Context, fromHeight int64) <-chan *rpcpb.Block {
+	ch := make(chan *rpcpb.Block, streamChSize)
+	next := fromHeight
+	longestChain := s.useLongestChain
+	servers, dialOpts := s.streamServers(), s.dialOptions(nil)
+	go func() {
+		defer close(ch)
+		s.runStream(ctx, servers, dialOpts, func(client rpcpb.ApiServiceClient) (bool, error) {
+			stream, err := client.SubscribeChainStatus(ctx, &rpcpb.EmptyRequest{})
+			if err != nil {
+				return false, err
+			}
+			received := false
+			for {
+				st, err := stream.Recv()
+				if err != nil {
+					return received, err
+				}
+				top := st.LibBlock
+				if longestChain {
+					top = st.HeadBlock
+				}
+				if next <= 0 {
+					next = top + 1
+				}
+				for ; next <= top; next++ {
+					res, err := client.GetBlockByNumber(ctx, &rpcpb.GetBlockByNumberRequest{Number: next, Complete: true})
+					if err != nil {
+						return received, err
+					}
+					select {
+					case ch <- res.Block:
+					case <-ctx.Done():
+						return received, ctx.Err()
+					}
+					received = true
+				}
+			}
+		})
+	}()
+	return ch
+}
+
+// SubscribePendingTx sends the transactions added to the transaction pool of the node until ctx is done.
+// The subscription is reestablished if the connection breaks, the transactions added meanwhile are missed though.
+// The channel is closed once ctx is done.
+func (s *IOSTDevSDK) SubscribePendingTx(ctx context.Context) <-chan *rpcpb.Transaction {
+	ch := make(chan *rpcpb.Transaction, streamChSize)
+	req := &rpcpb.SubscribeRequest{Topics: []rpcpb.Event_Topic{rpcpb.Event_TRANSACTION_PENDING}}
+	servers, dialOpts := s.streamServers(), s.dialOptions(nil)
+	go func() {
+		defer close(ch)
+		s.runStream(ctx, servers, dialOpts, func(client rpcpb.ApiServiceClient) (bool, error) {
+			stream, err := client.Subscribe(ctx, req)
+			if err != nil {
+				return false, err
+			}
+			received := false
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					return received, err
+				}
+				received = true
+				if resp.Event == nil {
+					continue
+				}
+				res, err := client.GetTxByHash(ctx, &rpcpb.TxHashRequest{Hash: resp.Event.Data})
+				if err != nil {
+					if ctx.Err() != nil || status.Code(err) == codes.Unavailable {
+						return received, err
+					}
+					// dropped from the pool before it could be fetched
+					s.log("Failed to get pending tx", resp.Event.Data, ":", err)
+					continue
+				}
+				select {
+				case ch <- res.Transaction:
+				case <-ctx.Done():
+					return received, ctx.Err()
+				}
+			}
+		})
+	}()
+	return ch
+}
+
+// SubscribeContractEvents sends the contract events of the topics, the receipts and events by default, until ctx is
+// done. A filter limits them to some contracts and to the events whose json data has some argument values, which the
+// node checks before sending them. The subscription is reestablished if the connection breaks, the events
+// posted meanwhile are missed though. The channel is closed once ctx is done.
+func (s *IOSTDevSDK) SubscribeContractEvents(ctx context.Context, filter *rpcpb.SubscribeRequest_Filter, topics ...rpcpb.Event_Topic) <-chan *rpcpb.Event {
+	ch := make(chan *rpcpb.Event, streamChSize)
+	if len(topics) == 0 {
+		topics = []rpcpb.Event_Topic{rpcpb.Event_CONTRACT_RECEIPT, rpcpb.Event_CONTRACT_EVENT}
+	}
+	req := &rpcpb.SubscribeRequest{Topics: topics, Filter: filter}
+	servers, dialOpts := s.streamServers(), s.dialOptions(nil)
+	go func() {
+		defer close(ch)
+		s.runStream(ctx, servers, dialOpts, func(client rpcpb.ApiServiceClient) (bool, error) {
+			stream, err := client.Subscribe(ctx, req)
+			if err != nil {
+				return false, err
+			}
+			received := false
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					return received, err
+				}
+				received = true
+				if resp.Event == nil {
+					continue
+				}
+				select {
+				case ch <- resp.Event:
+				case <-ctx.Done():
+					return received, ctx.Err()
+				}
+			}
+		})
+	}()
+	return ch
+}
@@ -0,0 +1,228 @@
+// Package txbuilder builds transactions step by step, like
+//
+//	tx, err := txbuilder.NewTx().Call("token.iost", "transfer", "iost", "a", "b", "1", "").AmountLimit("iost", "1").Build()
+//
+// checking the fields the same way as nodes do, so that an invalid tx is rejected before being signed and sent.
+package txbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/iost-official/go-iost/sdk"
+)
+
+// Limits of the fields of a tx accepted by nodes.
+const (
+	MinGasLimit = 6000
+	MaxGasLimit = 4000000
+	MinGasRatio = 1
+	MaxGasRatio = 100
+	MaxDelay    = 720 * time.Hour
+)
+
+// Defaults of a new tx, which are those of the sdk.
+const (
+	DefaultChainID    = 1024
+	DefaultGasLimit   = 1000000
+	DefaultGasRatio   = 1
+	DefaultExpiration = 5 * time.Minute
+)
+
+// CheckGas checks the gas limit and the gas ratio are in the range accepted by nodes.
+func CheckGas(gasLimit float64, gasRatio float64) error {
+	if gasLimit < MinGasLimit || gasLimit > MaxGasLimit {
+		return fmt.Errorf("invalid gas limit %v, should be between %v and %v", gasLimit, MinGasLimit, MaxGasLimit)
+	}
+	if gasRatio < MinGasRatio || gasRatio > MaxGasRatio {
+		return fmt.Errorf("invalid gas ratio %v, should be between %v and %v", gasRatio, MinGasRatio, MaxGasRatio)
+	}
+	return nil
+}
+
+// CheckDelay checks the delay is accepted by nodes.
+func CheckDelay(d time.Duration) error {
+	if d < 0 || d > MaxDelay {
+		return fmt.Errorf("invalid delay %v, should be between 0 and %v", d, MaxDelay)
+	}
+	return nil
+}
+
+// CheckSigner checks the signer is a permission of an account, like "admin@active".
+func CheckSigner(signer string) error {
+	parts := strings.Split(signer, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("signer %v should be account@permission", signer)
+	}
+	return nil
+}
+
+// CheckAmountLimit checks the amount limit of a token is unlimited or a non-negative number. The token "*" limits all
+// tokens.
+func CheckAmountLimit(token string, value string) error {
+	if token == "" {
+		return fmt.Errorf("empty token of amount limit %v", value)
+	}
+	if value == "unlimited" {
+		return nil
+	}
+	if _, err := common.NewFixed(value, -1); err != nil || strings.HasPrefix(value, "-") {
+		return fmt.Errorf("invalid amount limit %v of %v, should be unlimited or a non-negative number", value, token)
+	}
+	return nil
+}
+
+// Builder builds a tx. Its methods can be chained, the first invalid value being reported by `Build`.
+type Builder struct {
+	actions     []*rpcpb.Action
+	gasLimit    float64
+	gasRatio    float64
+	amountLimit []*rpcpb.AmountLimit
+	delay       time.Duration
+	expiration  time.Duration
+	txTime      time.Time
+	chainID     uint32
+	signers     []string
+	err         error
+}
+
+// NewTx returns a builder of a tx with the default gas and expiration of the sdk, and no amount limit.
+func NewTx() *Builder {
+	return &Builder{
+		gasLimit:   DefaultGasLimit,
+		gasRatio:   DefaultGasRatio,
+		expiration: DefaultExpiration,
+		chainID:    DefaultChainID,
+	}
+}
+
+func (b *Builder) fail(err error) *Builder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Call adds an action calling the abi of the contract with the args, which are encoded as a json array.
+func (b *Builder) Call(contract string, abi string, args ...interface{}) *Builder {
+	if args == nil {
+		args = []interface{}{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return b.fail(fmt.Errorf("invalid args of %v.%v: %v", contract, abi, err))
+	}
+	return b.Action(sdk.NewAction(contract, abi, string(data)))
+}
+
+// Action adds an action.
+func (b *Builder) Action(a *rpcpb.Action) *Builder {
+	if a.Contract == "" || a.ActionName == "" {
+		return b.fail(fmt.Errorf("action should have a contract and an abi, got %q and %q", a.Contract, a.ActionName))
+	}
+	b.actions = append(b.actions, a)
+	return b
+}
+
+// GasLimit sets the most gas the tx may use.
+func (b *Builder) GasLimit(gasLimit float64) *Builder {
+	b.gasLimit = gasLimit
+	return b
+}
+
+// GasRatio sets the gas ratio of the tx.
+func (b *Builder) GasRatio(gasRatio float64) *Builder {
+	b.gasRatio = gasRatio
+	return b
+}
+
+// AmountLimit adds the most of the token the tx may spend, which is a number or "unlimited". Setting the limit of a
+// token again replaces it.
+func (b *Builder) AmountLimit(token string, value string) *Builder {
+	if err := CheckAmountLimit(token, value); err != nil {
+		return b.fail(err)
+	}
+	for _, l := range b.amountLimit {
+		if l.Token == token {
+			l.Value = value
+			return b
+		}
+	}
+	b.amountLimit = append(b.amountLimit, &rpcpb.AmountLimit{Token: token, Value: value})
+	return b
+}
+
+// Delay sets how long the tx is delayed before being executed.
+func (b *Builder) Delay(d time.Duration) *Builder {
+	b.delay = d
+	return b
+}
+
+// Expiration sets how long after its time the tx expires.
+func (b *Builder) Expiration(d time.Duration) *Builder {
+	b.expiration = d
+	return b
+}
+
+// Time sets the time of the tx, which is the time of `Build` by default.
+func (b *Builder) Time(t time.Time) *Builder {
+	b.txTime = t
+	return b
+}
+
+// ChainID sets the chain id of the tx.
+func (b *Builder) ChainID(chainID uint32) *Builder {
+	b.chainID = chainID
+	return b
+}
+
+// Signers adds the permissions signing the tx besides the publisher, like "admin@active".
+func (b *Builder) Signers(signers ...string) *Builder {
+	for _, s := range signers {
+		if err := CheckSigner(s); err != nil {
+			return b.fail(err)
+		}
+	}
+	b.signers = append(b.signers, signers...)
+	return b
+}
+
+// Build checks the tx and returns it unsigned, see `sdk.AttachSignature` and `sdk.IOSTDevSDK.SendTx` to sign it.
+func (b *Builder) Build() (*rpcpb.TransactionRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.actions) == 0 {
+		return nil, fmt.Errorf("no action, add one with Call")
+	}
+	if len(b.amountLimit) == 0 {
+		return nil, fmt.Errorf(`no amount limit, set one with AmountLimit like AmountLimit("iost", "100") or AmountLimit("*", "unlimited")`)
+	}
+	if err := CheckGas(b.gasLimit, b.gasRatio); err != nil {
+		return nil, err
+	}
+	if err := CheckDelay(b.delay); err != nil {
+		return nil, err
+	}
+	if b.expiration <= 0 {
+		return nil, fmt.Errorf("invalid expiration %v, should be positive", b.expiration)
+	}
+	txTime := b.txTime
+	if txTime.IsZero() {
+		txTime = time.Now()
+	}
+	return sdk.BuildUnsignedTx(sdk.TxParams{
+		ChainID:     b.chainID,
+		Time:        txTime.UnixNano(),
+		Expiration:  txTime.Add(b.expiration).UnixNano(),
+		Delay:       b.delay,
+		GasLimit:    b.gasLimit,
+		GasRatio:    b.gasRatio,
+		AmountLimit: b.amountLimit,
+		Signers:     b.signers,
+	}, b.actions...)
+}
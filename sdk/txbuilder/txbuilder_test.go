@@ -0,0 +1,61 @@
+package txbuilder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tx, err := NewTx().
+		Call("token.iost", "transfer", "iost", "a", "b", "1.5", "").
+		GasLimit(300000).
+		AmountLimit("iost", "100").
+		AmountLimit("iost", "2").
+		Delay(time.Hour).
+		Signers("b@active").
+		Time(now).
+		Build()
+	assert.Nil(t, err)
+	assert.Equal(t, `["iost","a","b","1.5",""]`, tx.Actions[0].Data)
+	assert.Equal(t, float64(300000), tx.GasLimit)
+	assert.Equal(t, []*rpcpb.AmountLimit{{Token: "iost", Value: "2"}}, tx.AmountLimit)
+	assert.Equal(t, int64(time.Hour), tx.Delay)
+	assert.Equal(t, []string{"b@active"}, tx.Signers)
+	assert.Equal(t, now.UnixNano(), tx.Time)
+	assert.Equal(t, now.Add(DefaultExpiration).UnixNano(), tx.Expiration)
+	assert.Equal(t, uint32(DefaultChainID), tx.ChainId)
+
+	tx, err = NewTx().Call("vote_producer.iost", "logout").AmountLimit("*", "unlimited").Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", tx.Actions[0].Data)
+}
+
+func TestBuildInvalid(t *testing.T) {
+	valid := func() *Builder {
+		return NewTx().Call("token.iost", "transfer").AmountLimit("*", "unlimited")
+	}
+	_, err := NewTx().AmountLimit("*", "unlimited").Build()
+	assert.Contains(t, err.Error(), "no action")
+	_, err = NewTx().Call("token.iost", "transfer").Build()
+	assert.Contains(t, err.Error(), "no amount limit")
+	_, err = valid().GasLimit(100).Build()
+	assert.Contains(t, err.Error(), "invalid gas limit")
+	_, err = valid().GasRatio(0.5).Build()
+	assert.Contains(t, err.Error(), "invalid gas ratio")
+	_, err = valid().Delay(800 * time.Hour).Build()
+	assert.Contains(t, err.Error(), "invalid delay")
+	_, err = valid().Expiration(0).Build()
+	assert.Contains(t, err.Error(), "invalid expiration")
+	_, err = valid().Signers("admin").Build()
+	assert.Contains(t, err.Error(), "signer admin should be account@permission")
+	_, err = valid().AmountLimit("iost", "-1").Build()
+	assert.Contains(t, err.Error(), "invalid amount limit -1 of iost")
+	_, err = valid().Call("", "transfer").Build()
+	assert.Contains(t, err.Error(), "should have a contract and an abi")
+	_, err = valid().Call("token.iost", "transfer", make(chan int)).Build()
+	assert.Contains(t, err.Error(), "invalid args of token.iost.transfer")
+}
@@ -54,6 +54,11 @@ func LoadKeyPair(privKeyFile string, algo string) (*account.KeyPair, error) {
 	return account.NewKeyPair(fsk, GetSignAlgoByName(algo))
 }
 
+// DeriveKeyPair derives the ed25519 key pair at the bip32 path from a mnemonic, eg m/44'/291'/0'/0'/0'.
+func DeriveKeyPair(mnemonic string, passphrase string, path string) (*account.KeyPair, error) {
+	return account.DeriveKeyPairFromMnemonic(mnemonic, passphrase, path)
+}
+
 // SaveProtoStructToJSONFile ...
 func SaveProtoStructToJSONFile(pb proto.Message, fileName string) error {
 	r, err := (&jsonpb.Marshaler{EmitDefaults: true, Indent: "    "}).MarshalToString(pb)
@@ -135,6 +140,24 @@ func CheckPubKey(k string) bool {
 	return true
 }
 
+// TxHashForSign returns the hash which signers of the tx sign.
+func TxHashForSign(t *rpcpb.TransactionRequest) []byte {
+	return common.Sha3(txToBytes(t, false))
+}
+
+// TxHash returns the hash of the tx computed the same way as nodes do, which is the hash returned by sending the tx.
+func TxHash(t *rpcpb.TransactionRequest) []byte {
+	se := common.NewSimpleEncoder()
+	se.WriteBytes(nil)
+	se.WriteString(t.Publisher)
+	signBytes := make([][]byte, 0, len(t.PublisherSigs))
+	for _, sig := range t.PublisherSigs {
+		signBytes = append(signBytes, signatureToBytes(sig))
+	}
+	se.WriteBytesSlice(signBytes)
+	return common.Sha3(append(txToBytes(t, true), se.Bytes()...))
+}
+
 // VerifySigForTx ...
 func VerifySigForTx(t *rpcpb.TransactionRequest, sig *rpcpb.Signature) bool {
 	hash := common.Sha3(txToBytes(t, false))
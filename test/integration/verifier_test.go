@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/ilog"
 	. "github.com/iost-official/go-iost/verifier"
 	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
 	"github.com/iost-official/go-iost/vm/native"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -43,6 +47,91 @@ func TestTransfer(t *testing.T) {
 			So(r.GasUsage, ShouldEqual, 800800)
 		})
 
+		Convey("test estimate of transfer", func() {
+			trx := tx.NewTx([]*tx.Action{{
+				Contract:   "token.iost",
+				ActionName: "transfer",
+				Data:       fmt.Sprintf(`["iost","%v","%v","%v",""]`, acc0.ID, acc1.ID, 0.0001),
+			}}, nil, 800800, 100, s.Head.Time+10000000, 0, 0)
+			trx.Time = s.Head.Time
+			trx.AmountLimit = append(trx.AmountLimit, &contract.Amount{Token: "*", Val: "unlimited"})
+			stx, err := tx.SignTx(trx, acc.ID, []*account.KeyPair{acc.KeyPair})
+			So(err, ShouldBeNil)
+			r, err := s.Verifier.Estimate(s.Head, s.Mvcc, stx, time.Second)
+			So(err, ShouldBeNil)
+			So(r.Status.Code, ShouldEqual, tx.Success)
+			So(r.GasUsage, ShouldEqual, 800800)
+			So(s.Visitor.TokenBalance("iost", acc1.ID), ShouldEqual, int64(0))
+
+			stx.GasLimit = 800799
+			r, err = s.Verifier.Estimate(s.Head, s.Mvcc, stx, time.Second)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "out of gas")
+		})
+
+		Convey("test trace of transfer", func() {
+			s.Visitor.Commit()
+			// the forks of the db begin at its last tag
+			s.Mvcc.Commit("trace")
+			blk := &block.Block{Head: s.Head}
+			for _, amount := range []string{"0.0001", "0.0002"} {
+				trx := tx.NewTx([]*tx.Action{{
+					Contract:   "token.iost",
+					ActionName: "transfer",
+					Data:       fmt.Sprintf(`["iost","%v","%v","%v",""]`, acc0.ID, acc1.ID, amount),
+				}}, nil, 1000000, 100, s.Head.Time+10000000, 0, 0)
+				trx.Time = s.Head.Time
+				trx.AmountLimit = append(trx.AmountLimit, &contract.Amount{Token: "*", Val: "unlimited"})
+				stx, err := tx.SignTx(trx, acc.ID, []*account.KeyPair{acc.KeyPair})
+				So(err, ShouldBeNil)
+				blk.Txs = append(blk.Txs, stx)
+			}
+			r, tracer, err := s.Verifier.Trace(blk, s.Mvcc.Fork(), 1, &Config{TxTimeLimit: common.MaxTxTimeLimit})
+			So(err, ShouldBeNil)
+			So(r.Status.Code, ShouldEqual, tx.Success)
+			So(len(tracer.Calls()), ShouldEqual, 1)
+			c := tracer.Calls()[0]
+			So(c.Contract, ShouldEqual, "token.iost")
+			So(c.ABI, ShouldEqual, "transfer")
+			So(c.Receipts[0].Content, ShouldEqual, fmt.Sprintf(`["iost","%v","%v","0.0002",""]`, acc0.ID, acc1.ID))
+			// the balances read are the ones after the tx before
+			last := c.Storage[len(c.Storage)-1]
+			So(last.Op, ShouldEqual, host.StorageWrite)
+			So(last.Key, ShouldEqual, "TB"+acc0.ID)
+			So(last.Value, ShouldEqual, "99999970000")
+			So(s.Visitor.TokenBalance("iost", acc1.ID), ShouldEqual, int64(0))
+		})
+
+		Convey("test pending transfers", func() {
+			s.Visitor.Commit()
+			s.Mvcc.Commit("pending")
+			var txs []*tx.Tx
+			for i, amount := range []string{"0.0001", "0.0002", "0.0004"} {
+				expiration := s.Head.Time + 10000000
+				if i == 2 {
+					expiration = s.Head.Time
+				}
+				trx := tx.NewTx([]*tx.Action{{
+					Contract:   "token.iost",
+					ActionName: "transfer",
+					Data:       fmt.Sprintf(`["iost","%v","%v","%v",""]`, acc0.ID, acc1.ID, amount),
+				}}, nil, 1000000, 100, expiration, 0, 0)
+				trx.Time = s.Head.Time
+				trx.AmountLimit = append(trx.AmountLimit, &contract.Amount{Token: "*", Val: "unlimited"})
+				stx, err := tx.SignTx(trx, acc.ID, []*account.KeyPair{acc.KeyPair})
+				So(err, ShouldBeNil)
+				txs = append(txs, stx)
+			}
+			db := s.Mvcc.Fork()
+			receipts, err := s.Verifier.Pending(s.Head, db, txs, &Config{Timeout: time.Second, TxTimeLimit: common.MaxTxTimeLimit})
+			So(err, ShouldBeNil)
+			// the expired tx is skipped
+			So(len(receipts), ShouldEqual, 2)
+			So(receipts[1].Status.Code, ShouldEqual, tx.Success)
+			So(database.NewVisitor(0, db).TokenBalance("iost", acc1.ID), ShouldEqual, int64(30000))
+			So(s.Visitor.TokenBalance("iost", acc1.ID), ShouldEqual, int64(0))
+		})
+
 		Convey("test of token memo", func() {
 			r, err := s.Call("token.iost", "transfer", fmt.Sprintf(`["iost","%v","%v","%v","memo"]`, acc0.ID, acc1.ID, 0.0001), acc.ID, acc.KeyPair)
 			So(err, ShouldBeNil)
@@ -51,7 +51,7 @@ func (t *gobangHandle) Prepare() error {
 	iostSDK.SetServer(call.GetClient(0).Addr())
 	iostSDK.SetAccount("admin", rootAcc)
 	iostSDK.SetTxInfo(100000, 1, 90, 0, nil)
-	iostSDK.SetCheckResult(true, 3, 10)
+	iostSDK.SetCheckResult(true, 3, 30*time.Second)
 	testAcc, err = account.NewKeyPair(nil, crypto.Ed25519)
 	if err != nil {
 		return err
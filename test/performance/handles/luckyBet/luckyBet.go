@@ -81,7 +81,7 @@ func (t *luckyBetHandler) Prepare() error {
 	iostSDK.SetServer(client.Addr())
 	iostSDK.SetAccount("admin", acc)
 	iostSDK.SetTxInfo(3000000.0, 1.0, 90, 0, nil)
-	iostSDK.SetCheckResult(true, 3, 10)
+	iostSDK.SetCheckResult(true, 3, 30*time.Second)
 	var err error
 	t.testKp, err = account.NewKeyPair(nil, crypto.Ed25519)
 	if err != nil {
@@ -73,7 +73,7 @@ func (t *transferHandler) Prepare() error {
 	iostSDK.SetServer(client.Addr())
 	iostSDK.SetAccount("admin", acc)
 	iostSDK.SetTxInfo(500000.0, 1.0, 90, 0, nil)
-	iostSDK.SetCheckResult(true, 3, 10)
+	iostSDK.SetCheckResult(true, 3, 30*time.Second)
 	testKp, err := account.NewKeyPair(nil, crypto.Ed25519)
 	if err != nil {
 		return err
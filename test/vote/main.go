@@ -69,7 +69,7 @@ func initSDKs() {
 		iostSDK.SetAccount(a, kp)
 		iostSDK.SetServer(server)
 		iostSDK.SetTxInfo(2000000, 1, 300, 0, nil)
-		iostSDK.SetCheckResult(true, 3, 10)
+		iostSDK.SetCheckResult(true, 3, 30*time.Second)
 		iostSDK.SetVerbose(true)
 		iostSDKs[a] = iostSDK
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/vm"
 	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
 )
 
 // values
@@ -90,6 +91,118 @@ func (v *Verifier) Try(bh *block.BlockHead, db database.IMultiValue, t *tx.Tx, l
 	return r, err
 }
 
+// Estimate exec tx like Try, and pay its cost for the receipt to have the gas used, without committing it
+func (v *Verifier) Estimate(bh *block.BlockHead, db database.IMultiValue, t *tx.Tx, limit time.Duration) (*tx.TxReceipt, error) {
+	var isolator vm.Isolator
+	vi := database.NewVisitor(100, db)
+	var l ilog.Logger
+	l.Stop()
+	err := isolator.Prepare(bh, vi, &l)
+	if err != nil {
+		return &tx.TxReceipt{}, err
+	}
+	err = isolator.PrepareTx(t, limit)
+	if err != nil {
+		return &tx.TxReceipt{}, err
+	}
+	_, err = isolator.Run()
+	if err != nil {
+		return &tx.TxReceipt{}, err
+	}
+	return isolator.PayCost()
+}
+
+// Trace exec the txs of the block before the tx at index on the state of its parent, and trace the tx
+func (v *Verifier) Trace(blk *block.Block, db database.IMultiValue, index int, c *Config) (*tx.TxReceipt, *host.Tracer, error) {
+	if index < 0 || index >= len(blk.Txs) {
+		return nil, nil, fmt.Errorf("tx index %v out of range [0, %v)", index, len(blk.Txs))
+	}
+	tracer := host.NewTracer()
+	isolator := &vm.Isolator{}
+	vi := database.NewVisitor(100, db)
+	var l ilog.Logger
+	l.Stop()
+	err := isolator.Prepare(blk.Head, vi, &l)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, t := range blk.Txs[:index+1] {
+		isolator.ClearTx()
+		if k == 0 {
+			isolator.TriggerBlockBaseMode()
+		}
+		if k == index {
+			isolator.SetTracer(tracer)
+		}
+		to := c.TxTimeLimit * 2
+		if k < len(blk.Receipts) && blk.Receipts[k].Status.Code == tx.ErrorTimeout {
+			to = c.TxTimeLimit / 2
+		}
+		err = isolator.PrepareTx(t, to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("prepare tx %v error: %v", k, err)
+		}
+		r, err := isolator.Run()
+		if err != nil {
+			return nil, nil, fmt.Errorf("run tx %v error: %v", k, err)
+		}
+		if k > 0 {
+			r, err = isolator.PayCost()
+			if err != nil {
+				return nil, nil, fmt.Errorf("pay cost of tx %v error: %v", k, err)
+			}
+		}
+		if k == index {
+			return r, tracer, nil
+		}
+		isolator.Commit()
+	}
+	return nil, nil, nil
+}
+
+// Pending exec the txs on db one after another like the txs of a block generated, until the timeout of c, and returns
+// the receipts of the txs committed, the txs not able to be packed being skipped
+func (v *Verifier) Pending(bh *block.BlockHead, db database.IMultiValue, txs []*tx.Tx, c *Config) ([]*tx.TxReceipt, error) {
+	isolator := &vm.Isolator{}
+	vi := database.NewVisitor(100, db)
+	var l ilog.Logger
+	l.Stop()
+	err := isolator.Prepare(bh, vi, &l)
+	if err != nil {
+		return nil, err
+	}
+	var receipts []*tx.TxReceipt
+	to := time.Now().Add(c.Timeout)
+	blockGasLimit := common.MaxBlockGasLimit
+	for _, t := range txs {
+		limit := time.Until(to)
+		if limit > c.TxTimeLimit {
+			limit = c.TxTimeLimit
+		}
+		if limit < 500*time.Microsecond {
+			break
+		}
+		if !t.IsCreatedBefore(bh.Time) || (t.IsExpired(bh.Time) && !t.IsDefer()) || t.GasLimit > blockGasLimit {
+			continue
+		}
+		isolator.ClearTx()
+		if err := isolator.PrepareTx(t, limit); err != nil {
+			continue
+		}
+		if _, err := isolator.Run(); err != nil {
+			continue
+		}
+		r, err := isolator.PayCost()
+		if err != nil {
+			continue
+		}
+		isolator.Commit()
+		receipts = append(receipts, r)
+		blockGasLimit -= r.GasUsage
+	}
+	return receipts, nil
+}
+
 // Gen gen block
 func (v *Verifier) Gen(blk, parent *block.Block, witnessList *blockcache.WitnessList, db database.IMultiValue, iter *txpool.SortedTxMap, c *Config) (droplist []*tx.Tx, errs []error, err error) {
 	isolator := &vm.Isolator{}
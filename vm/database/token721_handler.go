@@ -37,6 +37,15 @@ func (m *Token721Handler) Token721Balance(tokenName, acc string) int64 {
 	return ib
 }
 
+// Token721sOf get the names of the token721 tokens acc has held, which are the fields of its balance map
+func (m *Token721Handler) Token721sOf(acc string) []string {
+	fields := m.db.Get("m-" + Token721ContractName + "-" + "T721B" + acc)
+	if len(fields) == 0 {
+		return []string{}
+	}
+	return strings.Split(fields, "@")[1:]
+}
+
 // Token721IDList get token balance of acc
 func (m *Token721Handler) Token721IDList(tokenName, acc string) []string {
 	ids := m.db.Get(m.idKey(tokenName, acc))
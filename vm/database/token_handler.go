@@ -3,6 +3,8 @@ package database
 import (
 	"encoding/json"
 	"errors"
+	"strings"
+
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/ilog"
 )
@@ -51,6 +53,15 @@ func (m *TokenHandler) TokenBalance(tokenName, acc string) int64 {
 	return ib
 }
 
+// TokensOf get the names of the tokens acc has held, which are the fields of its balance map
+func (m *TokenHandler) TokensOf(acc string) []string {
+	fields := m.db.Get("m-" + TokenContractName + "-" + "TB" + acc)
+	if len(fields) == 0 {
+		return []string{}
+	}
+	return strings.Split(fields, "@")[1:]
+}
+
 // TokenBalanceFixed get token balance of acc
 func (m *TokenHandler) TokenBalanceFixed(tokenName, acc string) *common.Fixed {
 	ib := m.TokenBalance(tokenName, acc)
@@ -2,6 +2,7 @@ package database
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/bitly/go-simplejson"
 	"github.com/iost-official/go-iost/common"
@@ -103,6 +104,27 @@ func (v *VoteHandler) GetProducerVoteInfo(account string) (*ProducerVoteInfo, er
 	return ret, nil
 }
 
+// GetProducers returns the accounts registered as producer candidates
+func (v *VoteHandler) GetProducers() []string {
+	return v.MKeys(VoteProducerContractName + "-producerTable")
+}
+
+// GetVoters returns the accounts having votes for producer candidates
+func (v *VoteHandler) GetVoters() []string {
+	idVal := v.Get(VoteProducerContractName + "-voteId")
+	voteID, ok := Unmarshal(idVal).(string)
+	if !ok {
+		return nil
+	}
+	return v.MKeys(VoteContractName + "-u_" + voteID)
+}
+
+// GetProducerOfPubkey returns the producer account which registered the public key, or "" if none did
+func (v *VoteHandler) GetProducerOfPubkey(pubkey string) string {
+	account, _ := Unmarshal(v.MGet(VoteProducerContractName+"-producerKeyToId", pubkey)).(string)
+	return strings.Trim(account, `"`)
+}
+
 // GetProducerVotes ...
 func (v *VoteHandler) GetProducerVotes(account string) (*common.Fixed, error) {
 	idVal := v.Get(VoteProducerContractName + "-voteId")
@@ -57,6 +57,7 @@ func (h *DBHandler) Put(key string, value interface{}, ramPayer ...string) (cont
 
 	h.payRAM(mk, sv, oldV, payer)
 	h.h.db.Put(mk, sv)
+	h.trace(StorageWrite, "", key, "", value, payer)
 
 	cost := contract.NewCost(0, 0, int64(len(sv)/10))
 	if cost.ToGas() < Costs["PutCost"].ToGas() {
@@ -69,6 +70,7 @@ func (h *DBHandler) Put(key string, value interface{}, ramPayer ...string) (cont
 func (h *DBHandler) Get(key string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyKey(key)
 	rtn := h.parseValue(h.h.db.Get(mk))
+	h.trace(StorageRead, "", key, "", rtn, "")
 	return rtn, Costs["GetCost"]
 }
 
@@ -81,6 +83,7 @@ func (h *DBHandler) Del(key string) (contract.Cost, error) {
 	mk := h.modifyKey(key)
 	h.releaseRAM(mk)
 	h.h.db.Del(mk)
+	h.trace(StorageDelete, "", key, "", nil, "")
 	return Costs["DelCost"], nil
 }
 
@@ -116,6 +119,7 @@ func (h *DBHandler) MapPut(key, field string, value interface{}, ramPayer ...str
 
 	h.payRAMForMap(mk, field, sv, oldV, payer)
 	h.h.db.MPut(mk, field, sv)
+	h.trace(StorageWrite, "", key, field, value, payer)
 
 	cost := contract.NewCost(0, 0, int64(len(sv)/10))
 	if cost.ToGas() < Costs["PutCost"].ToGas() {
@@ -128,6 +132,7 @@ func (h *DBHandler) MapPut(key, field string, value interface{}, ramPayer ...str
 func (h *DBHandler) MapGet(key, field string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyKey(key)
 	rtn := h.parseValue(h.h.db.MGet(mk, field))
+	h.trace(StorageRead, "", key, field, rtn, "")
 	return rtn, Costs["GetCost"]
 }
 
@@ -150,6 +155,7 @@ func (h *DBHandler) MapDel(key, field string) (contract.Cost, error) {
 	mk := h.modifyKey(key)
 	h.releaseRAMForMap(mk, field)
 	h.h.db.MDel(mk, field)
+	h.trace(StorageDelete, "", key, field, nil, "")
 	return Costs["DelCost"], nil
 }
 
@@ -175,6 +181,7 @@ func (h *DBHandler) GlobalHas(con, key string) (bool, contract.Cost) {
 func (h *DBHandler) GlobalGet(con, key string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
 	rtn := h.parseValue(h.h.db.Get(mk))
+	h.trace(StorageRead, con, key, "", rtn, "")
 	return rtn, Costs["GetCost"]
 }
 
@@ -188,6 +195,7 @@ func (h *DBHandler) GlobalMapHas(con, key, field string) (bool, contract.Cost) {
 func (h *DBHandler) GlobalMapGet(con, key, field string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
 	rtn := h.parseValue(h.h.db.MGet(mk, field))
+	h.trace(StorageRead, con, key, field, rtn, "")
 	return rtn, Costs["GetCost"]
 }
 
@@ -203,6 +211,18 @@ func (h *DBHandler) GlobalMapLen(con, key string) (length int, cost contract.Cos
 	return len(k), cost
 }
 
+// trace records the operation to the tracer of the host, if any, con being the contract of the storage or empty for
+// the contract called.
+func (h *DBHandler) trace(op StorageOp, con, key, field string, value interface{}, payer string) {
+	if h.h.tracer == nil {
+		return
+	}
+	if con == "" {
+		con, _ = h.h.ctx.Value("contract_name").(string)
+	}
+	h.h.tracer.storage(op, con, key, field, value, payer)
+}
+
 func (h *DBHandler) modifyKey(key string) string {
 	contractName, ok := h.h.ctx.Value("contract_name").(string)
 	if !ok {
@@ -20,5 +20,6 @@ func (p *EventPoster) PostEvent(data string) contract.Cost {
 	e := event.NewEvent(event.ContractEvent, data)
 	event.GetCollector().Post(e,
 		&event.Meta{ContractID: p.h.Context().Value("contract_name").(string)})
+	p.h.tracer.event(data)
 	return EventCost(len(data))
 }
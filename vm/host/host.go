@@ -37,6 +37,7 @@ type Host struct {
 	ctx     *Context
 	db      *database.Visitor
 	monitor Monitor
+	tracer  *Tracer
 
 	deadline time.Time
 }
@@ -72,6 +73,16 @@ func (h *Host) SetContext(ctx *Context) {
 
 }
 
+// Tracer returns the tracer of the tx executed, nil if not traced
+func (h *Host) Tracer() *Tracer {
+	return h.tracer
+}
+
+// SetTracer set the tracer of the txs executed, nil to stop tracing
+func (h *Host) SetTracer(t *Tracer) {
+	h.tracer = t
+}
+
 // Call  call a new contract in this context
 func (h *Host) Call(cont, api, jarg string, withAuth ...bool) ([]interface{}, contract.Cost, error) {
 
@@ -0,0 +1,174 @@
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+)
+
+// StorageOp is the operation of the storage traced.
+type StorageOp int
+
+// StorageOp list
+const (
+	StorageRead StorageOp = iota
+	StorageWrite
+	StorageDelete
+)
+
+// StorageTrace is a read or a write of the storage of a contract.
+type StorageTrace struct {
+	Op       StorageOp
+	Contract string
+	Key      string
+	Field    string
+	Value    string
+	Payer    string
+}
+
+// CallTrace is a call of a contract abi, with the calls it makes to the other contracts.
+type CallTrace struct {
+	Contract string
+	ABI      string
+	Args     string
+	Returns  string
+	Error    string
+	// Gas is the gas of the call, with the gas of its calls.
+	Gas      int64
+	Storage  []*StorageTrace
+	Receipts []*tx.Receipt
+	Events   []string
+	Calls    []*CallTrace
+	// HostAPIs is the gas of the host apis called by the code of the contract, and Functions the gas of the functions
+	// of the code, without the functions they call, if the vm profiles them. Both are sorted by gas, highest first.
+	HostAPIs  []*GasProfile
+	Functions []*GasProfile
+}
+
+// GasProfile is the gas used by a host api or a function of a contract in a call, with the number of times it ran.
+type GasProfile struct {
+	Name  string
+	Count int64
+	Gas   int64
+}
+
+// Tracer records the calls of the actions of a tx executed by the host having it. The methods do nothing on a nil
+// Tracer, the host not tracing.
+type Tracer struct {
+	calls []*CallTrace
+	stack []*CallTrace
+}
+
+// NewTracer returns a new Tracer.
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// Calls returns the calls of the actions traced.
+func (t *Tracer) Calls() []*CallTrace {
+	return t.calls
+}
+
+// BeginCall records a call, the calls and storage traced until it ends being its own.
+func (t *Tracer) BeginCall(cont, api, args string) {
+	if t == nil {
+		return
+	}
+	c := &CallTrace{Contract: cont, ABI: api, Args: args}
+	if len(t.stack) == 0 {
+		t.calls = append(t.calls, c)
+	} else {
+		parent := t.stack[len(t.stack)-1]
+		parent.Calls = append(parent.Calls, c)
+	}
+	t.stack = append(t.stack, c)
+}
+
+// EndCall ends the call begun last with its result.
+func (t *Tracer) EndCall(rtn []interface{}, cost contract.Cost, err error) {
+	if t == nil || len(t.stack) == 0 {
+		return
+	}
+	c := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	if rtn != nil {
+		b, e := json.Marshal(rtn)
+		if e != nil {
+			c.Returns = fmt.Sprint(rtn)
+		} else {
+			c.Returns = string(b)
+		}
+	}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	c.Gas = cost.ToGas()
+	sortProfiles(c.HostAPIs)
+	sortProfiles(c.Functions)
+}
+
+// HostAPI records the gas of a host api called by the code of the contract of the current call.
+func (t *Tracer) HostAPI(name string, gas int64) {
+	if c := t.current(); c != nil {
+		c.HostAPIs = addProfile(c.HostAPIs, name, gas)
+	}
+}
+
+// Function records the gas of a function of the code of the contract of the current call, which the vm profiling it
+// records each time the function returns.
+func (t *Tracer) Function(name string, gas int64) {
+	if c := t.current(); c != nil {
+		c.Functions = addProfile(c.Functions, name, gas)
+	}
+}
+
+func addProfile(ps []*GasProfile, name string, gas int64) []*GasProfile {
+	for _, p := range ps {
+		if p.Name == name {
+			p.Count++
+			p.Gas += gas
+			return ps
+		}
+	}
+	return append(ps, &GasProfile{Name: name, Count: 1, Gas: gas})
+}
+
+func sortProfiles(ps []*GasProfile) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		return ps[i].Gas > ps[j].Gas
+	})
+}
+
+func (t *Tracer) current() *CallTrace {
+	if t == nil || len(t.stack) == 0 {
+		return nil
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+func (t *Tracer) storage(op StorageOp, con, key, field string, value interface{}, payer string) {
+	c := t.current()
+	if c == nil {
+		return
+	}
+	s := &StorageTrace{Op: op, Contract: con, Key: key, Field: field, Payer: payer}
+	if value != nil {
+		s.Value = fmt.Sprint(value)
+	}
+	c.Storage = append(c.Storage, s)
+}
+
+func (t *Tracer) receipt(r *tx.Receipt) {
+	if c := t.current(); c != nil {
+		c.Receipts = append(c.Receipts, r)
+	}
+}
+
+func (t *Tracer) event(data string) {
+	if c := t.current(); c != nil {
+		c.Events = append(c.Events, data)
+	}
+}
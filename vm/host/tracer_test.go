@@ -0,0 +1,78 @@
+package host
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+)
+
+func TestTracer(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.Set("contract_name", "contractName")
+	ctx.Set("abi_name", "abi")
+	ctx.GSet("receipts", []*tx.Receipt{})
+	mock, host := myinit(t, ctx)
+	mock.EXPECT().Put(Any(), Any(), Any()).AnyTimes()
+	mock.EXPECT().Del(Any(), Any()).AnyTimes()
+	mock.EXPECT().Get("state", "b-contractName-hello").Return("", nil)
+	mock.EXPECT().Get("state", "b-other-hello").Return("sworld@other", nil)
+
+	// not traced
+	host.Put("hello", "world")
+	tracer := NewTracer()
+	host.SetTracer(tracer)
+
+	tracer.BeginCall("contractName", "abi", `["a"]`)
+	host.Get("hello")
+	tracer.HostAPI("storage.get", 300)
+	tracer.Function("f", 5)
+	tracer.BeginCall("other", "abi2", `[]`)
+	host.GlobalGet("other", "hello")
+	host.receipt("done")
+	tracer.EndCall(nil, contract.NewCost(0, 0, 10), errors.New("failed"))
+	host.Del("hello")
+	tracer.HostAPI("storage.del", 400)
+	tracer.HostAPI("storage.get", 300)
+	tracer.EndCall([]interface{}{"ok"}, contract.NewCost(0, 1, 20), nil)
+	host.SetTracer(nil)
+	host.Get("hello")
+
+	calls := tracer.Calls()
+	if len(calls) != 1 {
+		t.Fatal(calls)
+	}
+	c := calls[0]
+	if c.Contract != "contractName" || c.ABI != "abi" || c.Args != `["a"]` || c.Returns != `["ok"]` || c.Gas != 30 || c.Error != "" {
+		t.Fatal(c)
+	}
+	if len(c.Storage) != 2 || c.Storage[0].Op != StorageRead || c.Storage[0].Value != "world" ||
+		c.Storage[1].Op != StorageDelete || c.Storage[1].Key != "hello" {
+		t.Fatal(c.Storage)
+	}
+	if len(c.HostAPIs) != 2 || c.HostAPIs[0].Name != "storage.get" || c.HostAPIs[0].Count != 2 || c.HostAPIs[0].Gas != 600 ||
+		c.HostAPIs[1].Name != "storage.del" || c.HostAPIs[1].Gas != 400 {
+		t.Fatal(c.HostAPIs)
+	}
+	if len(c.Functions) != 1 || c.Functions[0].Name != "f" || c.Functions[0].Count != 1 || c.Functions[0].Gas != 5 {
+		t.Fatal(c.Functions)
+	}
+	if len(c.Calls) != 1 {
+		t.Fatal(c.Calls)
+	}
+	c = c.Calls[0]
+	if c.Contract != "other" || c.Error != "failed" || c.Gas != 10 || c.Returns != "" {
+		t.Fatal(c)
+	}
+	if len(c.Storage) != 1 || c.Storage[0].Contract != "other" || c.Storage[0].Value != "world" {
+		t.Fatal(c.Storage)
+	}
+	if len(c.Receipts) != 1 || c.Receipts[0].Content != "done" {
+		t.Fatal(c.Receipts)
+	}
+	if len(c.HostAPIs) != 0 || len(c.Functions) != 0 {
+		t.Fatal(c.HostAPIs, c.Functions)
+	}
+}
@@ -25,6 +25,7 @@ func (h *APIDelegate) receipt(s string) {
 
 	rs := h.h.ctx.GValue("receipts").([]*tx.Receipt)
 	h.h.ctx.GSet("receipts", append(rs, rec))
+	h.h.tracer.receipt(rec)
 
 	// post event for receipt
 	event.GetCollector().Post(event.NewEvent(event.ContractReceipt, rec.Content),
@@ -301,6 +301,11 @@ func (i *Isolator) Commit() {
 	i.h.DB().Commit()
 }
 
+// SetTracer trace the txs run, nil to stop tracing
+func (i *Isolator) SetTracer(t *host.Tracer) {
+	i.h.SetTracer(t)
+}
+
 // ClearAll clear this isolator
 func (i *Isolator) ClearAll() {
 	i.h = nil
@@ -19,11 +19,16 @@ import (
 	"github.com/iost-official/go-iost/vm/host"
 	"github.com/iost-official/go-iost/vm/native"
 	"github.com/iost-official/go-iost/vm/v8vm"
+	"github.com/iost-official/go-iost/vm/wasm"
 )
 
+// ErrWasmInactive is returned for the wasm contracts until the chain enables them.
+var ErrWasmInactive = errors.New("wasm contracts are not enabled on this chain")
+
 // Monitor ...
 type Monitor struct {
-	vms map[string]VM
+	vms  map[string]VM
+	wasm bool
 }
 
 // NewMonitor ...
@@ -36,6 +41,21 @@ func NewMonitor() *Monitor {
 	return m
 }
 
+// SetWasm sets whether the wasm contracts are compiled, validated and called, before any tx is executed.
+func (m *Monitor) SetWasm(enabled bool) {
+	m.wasm = enabled
+	if !enabled {
+		delete(m.vms, "wasm")
+	} else if _, ok := m.vms["wasm"]; !ok {
+		m.vms["wasm"] = Factory("wasm")
+	}
+}
+
+// SetWasm sets whether the txs executed accept the wasm contracts, the nodes of a chain forking unless they agree.
+func SetWasm(enabled bool) {
+	staticMonitor.SetWasm(enabled)
+}
+
 func (m *Monitor) prepareContract(h *host.Host, contractName, api, jarg string) (c *contract.Contract, abi *contract.ABI, args []interface{}, err error) {
 	var cid string
 	if h.IsDomain(contractName) {
@@ -102,6 +122,12 @@ func getAmountLimitMap(h *host.Host, amountList []*contract.Amount) (map[string]
 // Call ...
 // nolint
 func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn []interface{}, cost contract.Cost, err error) {
+	if t := h.Tracer(); t != nil {
+		t.BeginCall(contractName, api, jarg)
+		defer func() {
+			t.EndCall(rtn, cost, err)
+		}()
+	}
 	c, abi, args, err := m.prepareContract(h, contractName, api, jarg)
 	if err != nil {
 		return nil, host.Costs["GetCost"], fmt.Errorf("prepare contract: %v", err)
@@ -125,8 +151,13 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 	switch c.Info.Lang {
 	case "javascript":
 		cost.AddAssign(host.Costs["JSCost"])
+	case "wasm":
+		cost.AddAssign(host.Costs["WASMCost"])
 	}
 
+	if c.Info.Lang == "wasm" && !m.wasm {
+		return nil, cost, ErrWasmInactive
+	}
 	vm, ok := m.vms[c.Info.Lang]
 	if !ok {
 		vm = Factory(c.Info.Lang)
@@ -248,6 +279,11 @@ func (m *Monitor) Compile(con *contract.Contract) (string, error) {
 	case "javascript":
 		jsvm, _ := m.vms["javascript"]
 		return jsvm.Compile(con)
+	case "wasm":
+		if !m.wasm {
+			return "", ErrWasmInactive
+		}
+		return m.vms["wasm"].Compile(con)
 	}
 	return "", errors.New("vm unsupported")
 }
@@ -260,6 +296,11 @@ func (m *Monitor) Validate(con *contract.Contract) error {
 	case "javascript":
 		jsvm, _ := m.vms["javascript"]
 		return jsvm.Validate(con)
+	case "wasm":
+		if !m.wasm {
+			return ErrWasmInactive
+		}
+		return m.vms["wasm"].Validate(con)
 	}
 	return errors.New("vm unsupported")
 }
@@ -276,6 +317,10 @@ func Factory(lang string) VM {
 		vm.Init()
 		//vm.SetJSPath(jsPath)
 		return vm
+	case "wasm":
+		vm := wasm.NewVM()
+		vm.Init()
+		return vm
 	}
 	return nil
 }
@@ -225,3 +225,51 @@ module.exports = Contract;
 	}
 
 }
+
+func TestMonitor_Wasm(t *testing.T) {
+	monitor, _, db, vi := Init(t)
+	ctx := host.NewContext(nil)
+	ctx.Set("gas_ratio", int64(100))
+	ctx.Set("stack_height", 1)
+	h := host.NewHost(ctx, vi, monitor, nil)
+
+	c := contract.Contract{
+		ID:   "Contract",
+		Code: "codes",
+		Info: &contract.Info{
+			Lang:    "wasm",
+			Version: "1.0.0",
+			Abi: []*contract.ABI{
+				{
+					Name: "abi",
+					Args: []string{"string"},
+				},
+			},
+		},
+	}
+	db.EXPECT().Get(Any(), Any()).DoAndReturn(func(table string, key string) (string, error) {
+		return c.Encode(), nil
+	}).AnyTimes()
+
+	// the wasm contracts are refused until enabled
+	if _, err := monitor.Compile(&c); err != ErrWasmInactive {
+		t.Fatal(err)
+	}
+	if err := monitor.Validate(&c); err != ErrWasmInactive {
+		t.Fatal(err)
+	}
+	if _, _, err := monitor.Call(h, "Contract", "abi", "[\"1\"]"); err != ErrWasmInactive {
+		t.Fatal(err)
+	}
+	if _, ok := monitor.vms["wasm"]; ok {
+		t.Fatal("wasm vm loaded")
+	}
+
+	monitor.SetWasm(true)
+	if _, err := monitor.Compile(&c); err == ErrWasmInactive {
+		t.Fatal(err)
+	}
+	if err := monitor.Validate(&c); err == ErrWasmInactive {
+		t.Fatal(err)
+	}
+}
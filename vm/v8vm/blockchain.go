@@ -42,6 +42,7 @@ func goBlockInfo(cSbx C.SandboxPtr, info *C.CStr, gasUsed *C.size_t) *C.char {
 
 	blkInfo, cost := sbx.host.BlockInfo()
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.blockInfo", cost.CPU)
 	info.SetString(string(blkInfo))
 
 	return nil
@@ -56,6 +57,7 @@ func goTxInfo(cSbx C.SandboxPtr, info *C.CStr, gasUsed *C.size_t) *C.char {
 
 	txInfo, cost := sbx.host.TxInfo()
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.txInfo", cost.CPU)
 	info.SetString(string(txInfo))
 
 	return nil
@@ -70,6 +72,7 @@ func goContextInfo(cSbx C.SandboxPtr, info *C.CStr, gasUsed *C.size_t) *C.char {
 
 	ctxInfo, cost := sbx.host.ContextInfo()
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.contextInfo", cost.CPU)
 	info.SetString(string(ctxInfo))
 
 	return nil
@@ -88,6 +91,7 @@ func goCall(cSbx C.SandboxPtr, contract, api, args C.CStr, result *C.CStr, gasUs
 
 	callRs, cost, err := sbx.host.Call(contractStr, apiStr, argsStr)
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.call", cost.CPU)
 	if err != nil {
 		return C.CString(err.Error())
 	}
@@ -115,6 +119,7 @@ func goCallWithAuth(cSbx C.SandboxPtr, contract, api, args C.CStr, result *C.CSt
 
 	callRs, cost, err := sbx.host.CallWithAuth(contractStr, apiStr, argsStr)
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.callWithAuth", cost.CPU)
 	if err != nil {
 		return C.CString(err.Error())
 	}
@@ -144,6 +149,7 @@ func goRequireAuth(cSbx C.SandboxPtr, ID, permission C.CStr, ok *C.bool, gasUsed
 	*ok = C.bool(callOk)
 
 	*gasUsed = C.size_t(RequireAuthCost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.requireAuth", RequireAuthCost.CPU)
 
 	return nil
 }
@@ -160,6 +166,7 @@ func goReceipt(cSbx C.SandboxPtr, content C.CStr, gasUsed *C.size_t) *C.char {
 	cost := sbx.host.Receipt(contentStr)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.receipt", cost.CPU)
 
 	return nil
 }
@@ -176,6 +183,7 @@ func goEvent(cSbx C.SandboxPtr, content C.CStr, gasUsed *C.size_t) *C.char {
 	cost := sbx.host.PostEvent(contentStr)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("blockchain.event", cost.CPU)
 
 	return nil
 }
@@ -17,6 +17,9 @@ func goSha3(cSbx C.SandboxPtr, msg C.CStr, gasUsed *C.size_t) C.CStr {
 	val := common.Base58Encode(common.Sha3([]byte(msgStr)))
 
 	*gasUsed = C.size_t(len(msgStr) + cryptGasBase)
+	if sbx, ok := GetSandbox(cSbx); ok {
+		sbx.host.Tracer().HostAPI("crypto.sha3", int64(len(msgStr)+cryptGasBase))
+	}
 
 	return newCStr(val)
 }
@@ -31,6 +34,9 @@ func goVerify(cSbx C.SandboxPtr, algo C.CStr, msg C.CStr, sig C.CStr, pubkey C.C
 		return 0
 	}
 	*gasUsed = C.size_t(len(msgBytes) + cryptGasBase)
+	if sbx, ok := GetSandbox(cSbx); ok {
+		sbx.host.Tracer().HostAPI("crypto.verify", int64(len(msgBytes)+cryptGasBase))
+	}
 	if !crypto.NewAlgorithm(algoStr).Verify(msgBytes, pubkeyBytes, sigBytes) {
 		return 0
 	}
@@ -0,0 +1,20 @@
+package v8
+
+import (
+	"github.com/iost-official/go-iost/core/contract"
+)
+
+// Preprocess checks the contract and instruments its code with gas counting as a node does when the contract is
+// published, and returns the instrumented code which the node stores. It runs in a compile vm of its own, so that
+// the contract can be checked locally before publishing.
+func Preprocess(c *contract.Contract) (string, error) {
+	if err := c.VerifySelf(); err != nil {
+		return "", err
+	}
+	e := NewVM(CompileVMPool, "")
+	defer e.release()
+	if err := e.validate(c); err != nil {
+		return "", err
+	}
+	return e.compile(c)
+}
@@ -38,6 +38,7 @@ func goPut(cSbx C.SandboxPtr, key, val, ramPayer C.CStr, gasUsed *C.size_t) *C.c
 		cost, err = sbx.host.Put(k, v, o)
 	}
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.put", cost.CPU)
 	if err != nil {
 		return C.CString(err.Error())
 	}
@@ -59,6 +60,7 @@ func goHas(cSbx C.SandboxPtr, key, ramPayer C.CStr, result *C.bool, gasUsed *C.s
 	ret, cost = sbx.host.Has(k)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.has", cost.CPU)
 	*result = C.bool(ret)
 
 	return nil
@@ -78,6 +80,7 @@ func goGet(cSbx C.SandboxPtr, key, ramPayer C.CStr, result *C.CStr, gasUsed *C.s
 	val, cost = sbx.host.Get(k)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.get", cost.CPU)
 	if val == nil {
 		return nil
 	}
@@ -103,6 +106,7 @@ func goDel(cSbx C.SandboxPtr, key, ramPayer C.CStr, gasUsed *C.size_t) *C.char {
 
 	cost, err := sbx.host.Del(k)
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.del", cost.CPU)
 
 	if err != nil {
 		return C.CString(err.Error())
@@ -131,6 +135,7 @@ func goMapPut(cSbx C.SandboxPtr, key, field, val, ramPayer C.CStr, gasUsed *C.si
 		cost, err = sbx.host.MapPut(k, f, v, o)
 	}
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapPut", cost.CPU)
 	if err != nil {
 		return C.CString(err.Error())
 	}
@@ -152,6 +157,7 @@ func goMapHas(cSbx C.SandboxPtr, key, field, ramPayer C.CStr, result *C.bool, ga
 	ret, cost = sbx.host.MapHas(k, f)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapHas", cost.CPU)
 	*result = C.bool(ret)
 
 	return nil
@@ -171,6 +177,7 @@ func goMapGet(cSbx C.SandboxPtr, key, field, ramPayer C.CStr, result *C.CStr, ga
 	val, cost = sbx.host.MapGet(k, f)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapGet", cost.CPU)
 
 	if val == nil {
 		return nil
@@ -195,6 +202,7 @@ func goMapDel(cSbx C.SandboxPtr, key, field, ramPayer C.CStr, gasUsed *C.size_t)
 	cost, err := sbx.host.MapDel(k, f)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapDel", cost.CPU)
 	if err != nil {
 		return C.CString(err.Error())
 	}
@@ -219,6 +227,7 @@ func goMapKeys(cSbx C.SandboxPtr, key, ramPayer C.CStr, result *C.CStr, gasUsed
 		return C.CString(err.Error())
 	}
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapKeys", cost.CPU)
 	result.SetString(string(j))
 
 	return nil
@@ -237,6 +246,7 @@ func goMapLen(cSbx C.SandboxPtr, key, ramPayer C.CStr, result *C.size_t, gasUsed
 	var len int
 	len, cost = sbx.host.MapLen(k)
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.mapLen", cost.CPU)
 	*result = C.size_t(len)
 
 	return nil
@@ -257,6 +267,7 @@ func goGlobalHas(cSbx C.SandboxPtr, contractName, key, ramPayer C.CStr, result *
 	ret, cost = sbx.host.GlobalHas(c, k)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalHas", cost.CPU)
 	*result = C.bool(ret)
 
 	return nil
@@ -277,6 +288,7 @@ func goGlobalGet(cSbx C.SandboxPtr, contractName, key, ramPayer C.CStr, result *
 	val, cost = sbx.host.GlobalGet(c, k)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalGet", cost.CPU)
 
 	if val == nil {
 		return nil
@@ -302,6 +314,7 @@ func goGlobalMapHas(cSbx C.SandboxPtr, contractName, key, field, ramPayer C.CStr
 	ret, cost = sbx.host.GlobalMapHas(c, k, f)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalMapHas", cost.CPU)
 	*result = C.bool(ret)
 
 	return nil
@@ -322,6 +335,7 @@ func goGlobalMapGet(cSbx C.SandboxPtr, contractName, key, field, ramPayer C.CStr
 	val, cost = sbx.host.GlobalMapGet(c, k, f)
 
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalMapGet", cost.CPU)
 
 	if val == nil {
 		return nil
@@ -350,6 +364,7 @@ func goGlobalMapKeys(cSbx C.SandboxPtr, contractName, key, ramPayer C.CStr, resu
 		return C.CString(err.Error())
 	}
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalMapKeys", cost.CPU)
 	result.SetString(string(j))
 
 	return nil
@@ -369,6 +384,7 @@ func goGlobalMapLen(cSbx C.SandboxPtr, contractName, key, ramPayer C.CStr, resul
 	var len int
 	len, cost = sbx.host.GlobalMapLen(c, k)
 	*gasUsed = C.size_t(cost.CPU)
+	sbx.host.Tracer().HostAPI("storage.globalMapLen", cost.CPU)
 	*result = C.size_t(len)
 
 	return nil
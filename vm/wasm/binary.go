@@ -0,0 +1,828 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// valueType is the type of a wasm value. Only the integer types are supported, the floats not being deterministic
+// across platforms.
+type valueType byte
+
+// value types
+const (
+	valueUnknown valueType = 0
+	valueI32     valueType = 0x7f
+	valueI64     valueType = 0x7e
+	valueF32     valueType = 0x7d
+	valueF64     valueType = 0x7c
+)
+
+func (t valueType) String() string {
+	switch t {
+	case valueI32:
+		return "i32"
+	case valueI64:
+		return "i64"
+	case valueF32:
+		return "f32"
+	case valueF64:
+		return "f64"
+	}
+	return "unknown"
+}
+
+// external kinds of the imports and exports
+const (
+	externalFunc   byte = 0x00
+	externalTable  byte = 0x01
+	externalMemory byte = 0x02
+	externalGlobal byte = 0x03
+)
+
+// section ids
+const (
+	sectionCustom byte = iota
+	sectionType
+	sectionImport
+	sectionFunction
+	sectionTable
+	sectionMemory
+	sectionGlobal
+	sectionExport
+	sectionStart
+	sectionElement
+	sectionCode
+	sectionData
+	sectionDataCount
+)
+
+// sectionOrder is the order the sections must appear in, the data count section being between element and code.
+var sectionOrder = map[byte]int{
+	sectionType:      1,
+	sectionImport:    2,
+	sectionFunction:  3,
+	sectionTable:     4,
+	sectionMemory:    5,
+	sectionGlobal:    6,
+	sectionExport:    7,
+	sectionStart:     8,
+	sectionElement:   9,
+	sectionDataCount: 10,
+	sectionCode:      11,
+	sectionData:      12,
+}
+
+const (
+	magic   = "\x00asm"
+	version = "\x01\x00\x00\x00"
+
+	funcTypeForm   byte = 0x60
+	funcRefType    byte = 0x70
+	blockTypeEmpty byte = 0x40
+)
+
+// limits of the decoded modules
+const (
+	maxFunctions = 8192
+	maxTypes     = 1024
+	maxParams    = 64
+	maxLocals    = 4096
+	maxGlobals   = 1024
+	maxTableSize = 8192
+	maxSegments  = 1024
+	// maxPages is the max number of 64 KiB pages of the memory.
+	maxPages  = 32
+	pageSize  = 65536
+	maxBrSize = 4096
+)
+
+// errors of decoding
+var (
+	ErrInvalidMagic   = errors.New("invalid wasm magic number")
+	ErrInvalidVersion = errors.New("invalid wasm version")
+	ErrUnexpectedEnd  = errors.New("unexpected end of wasm binary")
+)
+
+type funcType struct {
+	params  []valueType
+	results []valueType
+}
+
+func (t *funcType) equal(o *funcType) bool {
+	return bytes.Equal(valueTypeBytes(t.params), valueTypeBytes(o.params)) &&
+		bytes.Equal(valueTypeBytes(t.results), valueTypeBytes(o.results))
+}
+
+func (t *funcType) String() string {
+	return fmt.Sprintf("%v -> %v", t.params, t.results)
+}
+
+func valueTypeBytes(ts []valueType) []byte {
+	b := make([]byte, len(ts))
+	for i, t := range ts {
+		b[i] = byte(t)
+	}
+	return b
+}
+
+// importEntry is a function import, the only kind of import supported.
+type importEntry struct {
+	module    string
+	name      string
+	typeIndex uint32
+}
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type global struct {
+	typ     valueType
+	mutable bool
+	init    uint64
+}
+
+type exportEntry struct {
+	name  string
+	kind  byte
+	index uint32
+}
+
+type elemSegment struct {
+	offset  uint32
+	indices []uint32
+}
+
+type dataSegment struct {
+	offset uint32
+	data   []byte
+}
+
+type localEntry struct {
+	count uint32
+	typ   valueType
+}
+
+type funcBody struct {
+	locals []localEntry
+	// code is the instructions of the body, ended by the end opcode.
+	code []byte
+}
+
+// module is a decoded wasm module.
+type module struct {
+	types   []*funcType
+	imports []*importEntry
+	// funcs are the type indices of the defined functions.
+	funcs   []uint32
+	table   *limits
+	memory  *limits
+	globals []*global
+	exports []*exportEntry
+	start   *uint32
+	elems   []*elemSegment
+	bodies  []*funcBody
+	data    []*dataSegment
+}
+
+// funcType returns the type of the function of index, imported or defined.
+func (m *module) funcType(index uint32) (*funcType, error) {
+	var typeIndex uint32
+	if int(index) < len(m.imports) {
+		typeIndex = m.imports[index].typeIndex
+	} else if i := int(index) - len(m.imports); i < len(m.funcs) {
+		typeIndex = m.funcs[i]
+	} else {
+		return nil, fmt.Errorf("function index %v out of range", index)
+	}
+	return m.types[typeIndex], nil
+}
+
+func (m *module) numFuncs() int {
+	return len(m.imports) + len(m.funcs)
+}
+
+// export returns the exported function of name.
+func (m *module) export(name string) (uint32, bool) {
+	for _, e := range m.exports {
+		if e.kind == externalFunc && e.name == name {
+			return e.index, true
+		}
+	}
+	return 0, false
+}
+
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) eof() bool {
+	return r.pos >= len(r.buf)
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, ErrUnexpectedEnd
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n uint32) ([]byte, error) {
+	if uint64(r.pos)+uint64(n) > uint64(len(r.buf)) {
+		return nil, ErrUnexpectedEnd
+	}
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+func (r *reader) u32() (uint32, error) {
+	var v uint32
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if shift == 28 && b&0x70 != 0 {
+			return 0, errors.New("invalid u32 leb128")
+		}
+		v |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+		if shift == 28 {
+			return 0, errors.New("invalid u32 leb128")
+		}
+	}
+}
+
+// signed reads a signed leb128 of size bits.
+func (r *reader) signed(size uint) (int64, error) {
+	var v int64
+	var shift uint
+	maxLen := (size + 6) / 7
+	for i := uint(0); ; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if i == maxLen-1 {
+			// the unused bits of the last byte must be the sign extension
+			rest := size - shift
+			if rest < 7 {
+				mask := byte(0x7f) &^ (1<<(rest-1) - 1)
+				if b&0x80 != 0 || b&mask != 0 && b&mask != mask {
+					return 0, fmt.Errorf("invalid s%v leb128", size)
+				}
+			} else if b&0x80 != 0 {
+				return 0, fmt.Errorf("invalid s%v leb128", size)
+			}
+		}
+		v |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				v |= -1 << shift
+			}
+			return v, nil
+		}
+	}
+}
+
+func (r *reader) name() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid utf-8 name")
+	}
+	return string(b), nil
+}
+
+// count reads a vector length no more than max.
+func (r *reader) count(max int, what string) (uint32, error) {
+	n, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint32(max) {
+		return 0, fmt.Errorf("too many %v: %v, max %v", what, n, max)
+	}
+	return n, nil
+}
+
+func (r *reader) valueType() (valueType, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	switch t := valueType(b); t {
+	case valueI32, valueI64:
+		return t, nil
+	case valueF32, valueF64:
+		return 0, fmt.Errorf("float type %v is not supported", t)
+	default:
+		return 0, fmt.Errorf("invalid value type 0x%x", b)
+	}
+}
+
+func (r *reader) limits(max uint32) (*limits, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	l := &limits{}
+	if l.min, err = r.u32(); err != nil {
+		return nil, err
+	}
+	switch flag {
+	case 0x00:
+	case 0x01:
+		l.hasMax = true
+		if l.max, err = r.u32(); err != nil {
+			return nil, err
+		}
+		if l.max < l.min {
+			return nil, errors.New("limits max is less than min")
+		}
+	default:
+		return nil, fmt.Errorf("invalid limits flag 0x%x", flag)
+	}
+	if l.min > max || l.hasMax && l.max > max {
+		return nil, fmt.Errorf("limits exceed %v", max)
+	}
+	return l, nil
+}
+
+// constExpr reads an initializer expression, only the constants being supported.
+func (r *reader) constExpr(t valueType) (uint64, error) {
+	op, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	switch {
+	case op == opI32Const && t == valueI32:
+		c, err := r.signed(32)
+		if err != nil {
+			return 0, err
+		}
+		v = uint64(uint32(c))
+	case op == opI64Const && t == valueI64:
+		c, err := r.signed(64)
+		if err != nil {
+			return 0, err
+		}
+		v = uint64(c)
+	default:
+		return 0, fmt.Errorf("unsupported %v initializer opcode 0x%x", t, op)
+	}
+	end, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	if end != opEnd {
+		return 0, errors.New("initializer expression is not ended")
+	}
+	return v, nil
+}
+
+// decodeModule decodes a wasm binary, checking its structure. The function bodies are checked by validate.
+func decodeModule(b []byte) (*module, error) {
+	r := &reader{buf: b}
+	h, err := r.bytes(4)
+	if err != nil || string(h) != magic {
+		return nil, ErrInvalidMagic
+	}
+	h, err = r.bytes(4)
+	if err != nil || string(h) != version {
+		return nil, ErrInvalidVersion
+	}
+	m := &module{}
+	last := 0
+	for !r.eof() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := r.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+		if id != sectionCustom {
+			order, ok := sectionOrder[id]
+			if !ok {
+				return nil, fmt.Errorf("unknown section id %v", id)
+			}
+			if order <= last {
+				return nil, fmt.Errorf("section %v out of order", id)
+			}
+			last = order
+		}
+		s := &reader{buf: payload}
+		switch id {
+		case sectionCustom:
+			_, err = s.name()
+			s.pos = len(s.buf)
+		case sectionType:
+			err = m.decodeTypes(s)
+		case sectionImport:
+			err = m.decodeImports(s)
+		case sectionFunction:
+			err = m.decodeFunctions(s)
+		case sectionTable:
+			err = m.decodeTable(s)
+		case sectionMemory:
+			err = m.decodeMemory(s)
+		case sectionGlobal:
+			err = m.decodeGlobals(s)
+		case sectionExport:
+			err = m.decodeExports(s)
+		case sectionStart:
+			var start uint32
+			start, err = s.u32()
+			m.start = &start
+		case sectionElement:
+			err = m.decodeElements(s)
+		case sectionDataCount:
+			_, err = s.u32()
+		case sectionCode:
+			err = m.decodeCode(s)
+		case sectionData:
+			err = m.decodeData(s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %v: %v", id, err)
+		}
+		if !s.eof() {
+			return nil, fmt.Errorf("section %v: size mismatch", id)
+		}
+	}
+	if len(m.bodies) != len(m.funcs) {
+		return nil, errors.New("function and code section have inconsistent lengths")
+	}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *module) decodeTypes(r *reader) error {
+	n, err := r.count(maxTypes, "types")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		form, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if form != funcTypeForm {
+			return fmt.Errorf("invalid func type form 0x%x", form)
+		}
+		t := &funcType{}
+		for _, ts := range []*[]valueType{&t.params, &t.results} {
+			c, err := r.count(maxParams, "params")
+			if err != nil {
+				return err
+			}
+			for j := uint32(0); j < c; j++ {
+				v, err := r.valueType()
+				if err != nil {
+					return err
+				}
+				*ts = append(*ts, v)
+			}
+		}
+		m.types = append(m.types, t)
+	}
+	return nil
+}
+
+func (m *module) decodeImports(r *reader) error {
+	n, err := r.count(maxFunctions, "imports")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		e := &importEntry{}
+		if e.module, err = r.name(); err != nil {
+			return err
+		}
+		if e.name, err = r.name(); err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if kind != externalFunc {
+			return fmt.Errorf("import %v.%v: only function imports are supported", e.module, e.name)
+		}
+		if e.typeIndex, err = r.u32(); err != nil {
+			return err
+		}
+		if int(e.typeIndex) >= len(m.types) {
+			return fmt.Errorf("import %v.%v: type index %v out of range", e.module, e.name, e.typeIndex)
+		}
+		m.imports = append(m.imports, e)
+	}
+	return nil
+}
+
+func (m *module) decodeFunctions(r *reader) error {
+	n, err := r.count(maxFunctions, "functions")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		t, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(t) >= len(m.types) {
+			return fmt.Errorf("type index %v out of range", t)
+		}
+		m.funcs = append(m.funcs, t)
+	}
+	return nil
+}
+
+func (m *module) decodeTable(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if n > 1 {
+		return errors.New("only one table is supported")
+	}
+	if n == 1 {
+		t, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if t != funcRefType {
+			return fmt.Errorf("invalid table element type 0x%x", t)
+		}
+		if m.table, err = r.limits(maxTableSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *module) decodeMemory(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if n > 1 {
+		return errors.New("only one memory is supported")
+	}
+	if n == 1 {
+		if m.memory, err = r.limits(maxPages); err != nil {
+			return fmt.Errorf("memory: %v", err)
+		}
+	}
+	return nil
+}
+
+func (m *module) decodeGlobals(r *reader) error {
+	n, err := r.count(maxGlobals, "globals")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		g := &global{}
+		if g.typ, err = r.valueType(); err != nil {
+			return err
+		}
+		mut, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if mut > 1 {
+			return fmt.Errorf("invalid global mutability %v", mut)
+		}
+		g.mutable = mut == 1
+		if g.init, err = r.constExpr(g.typ); err != nil {
+			return err
+		}
+		m.globals = append(m.globals, g)
+	}
+	return nil
+}
+
+func (m *module) decodeExports(r *reader) error {
+	n, err := r.count(maxFunctions, "exports")
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for i := uint32(0); i < n; i++ {
+		e := &exportEntry{}
+		if e.name, err = r.name(); err != nil {
+			return err
+		}
+		if names[e.name] {
+			return fmt.Errorf("duplicated export %v", e.name)
+		}
+		names[e.name] = true
+		if e.kind, err = r.byte(); err != nil {
+			return err
+		}
+		if e.kind > externalGlobal {
+			return fmt.Errorf("invalid export kind %v", e.kind)
+		}
+		if e.index, err = r.u32(); err != nil {
+			return err
+		}
+		m.exports = append(m.exports, e)
+	}
+	return nil
+}
+
+func (m *module) decodeElements(r *reader) error {
+	n, err := r.count(maxSegments, "element segments")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flag != 0 {
+			return fmt.Errorf("unsupported element segment flag %v", flag)
+		}
+		e := &elemSegment{}
+		offset, err := r.constExpr(valueI32)
+		if err != nil {
+			return err
+		}
+		e.offset = uint32(offset)
+		c, err := r.count(maxTableSize, "elements")
+		if err != nil {
+			return err
+		}
+		for j := uint32(0); j < c; j++ {
+			f, err := r.u32()
+			if err != nil {
+				return err
+			}
+			e.indices = append(e.indices, f)
+		}
+		m.elems = append(m.elems, e)
+	}
+	return nil
+}
+
+func (m *module) decodeCode(r *reader) error {
+	n, err := r.count(maxFunctions, "function bodies")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		b, err := r.bytes(size)
+		if err != nil {
+			return err
+		}
+		s := &reader{buf: b}
+		body := &funcBody{}
+		c, err := s.count(maxLocals, "local entries")
+		if err != nil {
+			return err
+		}
+		var total uint64
+		for j := uint32(0); j < c; j++ {
+			l := localEntry{}
+			if l.count, err = s.u32(); err != nil {
+				return err
+			}
+			if l.typ, err = s.valueType(); err != nil {
+				return err
+			}
+			total += uint64(l.count)
+			if total > maxLocals {
+				return fmt.Errorf("too many locals, max %v", maxLocals)
+			}
+			body.locals = append(body.locals, l)
+		}
+		body.code = b[s.pos:]
+		if len(body.code) == 0 || body.code[len(body.code)-1] != opEnd {
+			return errors.New("function body is not ended")
+		}
+		m.bodies = append(m.bodies, body)
+	}
+	return nil
+}
+
+func (m *module) decodeData(r *reader) error {
+	n, err := r.count(maxSegments, "data segments")
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flag != 0 {
+			return fmt.Errorf("unsupported data segment flag %v", flag)
+		}
+		d := &dataSegment{}
+		offset, err := r.constExpr(valueI32)
+		if err != nil {
+			return err
+		}
+		d.offset = uint32(offset)
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if d.data, err = r.bytes(size); err != nil {
+			return err
+		}
+		m.data = append(m.data, d)
+	}
+	return nil
+}
+
+// check checks the indices across the sections.
+func (m *module) check() error {
+	if m.numFuncs() > maxFunctions {
+		return fmt.Errorf("too many functions, max %v", maxFunctions)
+	}
+	for _, e := range m.exports {
+		switch e.kind {
+		case externalFunc:
+			if int(e.index) >= m.numFuncs() {
+				return fmt.Errorf("export %v: function index %v out of range", e.name, e.index)
+			}
+		case externalTable:
+			if m.table == nil || e.index != 0 {
+				return fmt.Errorf("export %v: table index %v out of range", e.name, e.index)
+			}
+		case externalMemory:
+			if m.memory == nil || e.index != 0 {
+				return fmt.Errorf("export %v: memory index %v out of range", e.name, e.index)
+			}
+		case externalGlobal:
+			if int(e.index) >= len(m.globals) {
+				return fmt.Errorf("export %v: global index %v out of range", e.name, e.index)
+			}
+		}
+	}
+	if m.start != nil {
+		t, err := m.funcType(*m.start)
+		if err != nil {
+			return fmt.Errorf("start: %v", err)
+		}
+		if len(t.params) != 0 || len(t.results) != 0 {
+			return errors.New("start function should have no params and results")
+		}
+	}
+	for _, e := range m.elems {
+		if m.table == nil {
+			return errors.New("element segment without table")
+		}
+		if uint64(e.offset)+uint64(len(e.indices)) > uint64(m.table.min) {
+			return errors.New("element segment out of table range")
+		}
+		for _, f := range e.indices {
+			if int(f) >= m.numFuncs() {
+				return fmt.Errorf("element function index %v out of range", f)
+			}
+		}
+	}
+	for _, d := range m.data {
+		if m.memory == nil {
+			return errors.New("data segment without memory")
+		}
+		if uint64(d.offset)+uint64(len(d.data)) > uint64(m.memory.min)*pageSize {
+			return errors.New("data segment out of memory range")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package wasm
+
+type writer struct {
+	buf []byte
+}
+
+func (w *writer) byte(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+func (w *writer) raw(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+func (w *writer) u32(v uint32) {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			w.byte(b)
+			return
+		}
+		w.byte(b | 0x80)
+	}
+}
+
+func (w *writer) signed(v int64) {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 && b&0x40 == 0 || v == -1 && b&0x40 != 0 {
+			w.byte(b)
+			return
+		}
+		w.byte(b | 0x80)
+	}
+}
+
+func (w *writer) name(s string) {
+	w.u32(uint32(len(s)))
+	w.raw([]byte(s))
+}
+
+func (w *writer) limits(l *limits) {
+	if l.hasMax {
+		w.byte(0x01)
+		w.u32(l.min)
+		w.u32(l.max)
+		return
+	}
+	w.byte(0x00)
+	w.u32(l.min)
+}
+
+func (w *writer) constExpr(t valueType, v uint64) {
+	if t == valueI64 {
+		w.byte(opI64Const)
+		w.signed(int64(v))
+	} else {
+		w.byte(opI32Const)
+		w.signed(int64(int32(uint32(v))))
+	}
+	w.byte(opEnd)
+}
+
+// section writes the section of id, whose payload is written by f, if it is not empty.
+func (w *writer) section(id byte, n int, f func(s *writer)) {
+	if n == 0 {
+		return
+	}
+	s := &writer{}
+	f(s)
+	w.byte(id)
+	w.u32(uint32(len(s.buf)))
+	w.raw(s.buf)
+}
+
+// encode writes the module, whose function bodies are given encoded, without the custom sections.
+func (m *module) encode(bodies [][]byte) []byte {
+	w := &writer{}
+	w.raw([]byte(magic))
+	w.raw([]byte(version))
+	w.section(sectionType, len(m.types), func(s *writer) {
+		s.u32(uint32(len(m.types)))
+		for _, t := range m.types {
+			s.byte(funcTypeForm)
+			s.u32(uint32(len(t.params)))
+			s.raw(valueTypeBytes(t.params))
+			s.u32(uint32(len(t.results)))
+			s.raw(valueTypeBytes(t.results))
+		}
+	})
+	w.section(sectionImport, len(m.imports), func(s *writer) {
+		s.u32(uint32(len(m.imports)))
+		for _, e := range m.imports {
+			s.name(e.module)
+			s.name(e.name)
+			s.byte(externalFunc)
+			s.u32(e.typeIndex)
+		}
+	})
+	w.section(sectionFunction, len(m.funcs), func(s *writer) {
+		s.u32(uint32(len(m.funcs)))
+		for _, t := range m.funcs {
+			s.u32(t)
+		}
+	})
+	if m.table != nil {
+		w.section(sectionTable, 1, func(s *writer) {
+			s.u32(1)
+			s.byte(funcRefType)
+			s.limits(m.table)
+		})
+	}
+	if m.memory != nil {
+		w.section(sectionMemory, 1, func(s *writer) {
+			s.u32(1)
+			s.limits(m.memory)
+		})
+	}
+	w.section(sectionGlobal, len(m.globals), func(s *writer) {
+		s.u32(uint32(len(m.globals)))
+		for _, g := range m.globals {
+			s.byte(byte(g.typ))
+			if g.mutable {
+				s.byte(1)
+			} else {
+				s.byte(0)
+			}
+			s.constExpr(g.typ, g.init)
+		}
+	})
+	w.section(sectionExport, len(m.exports), func(s *writer) {
+		s.u32(uint32(len(m.exports)))
+		for _, e := range m.exports {
+			s.name(e.name)
+			s.byte(e.kind)
+			s.u32(e.index)
+		}
+	})
+	if m.start != nil {
+		w.section(sectionStart, 1, func(s *writer) {
+			s.u32(*m.start)
+		})
+	}
+	w.section(sectionElement, len(m.elems), func(s *writer) {
+		s.u32(uint32(len(m.elems)))
+		for _, e := range m.elems {
+			s.u32(0)
+			s.constExpr(valueI32, uint64(e.offset))
+			s.u32(uint32(len(e.indices)))
+			for _, f := range e.indices {
+				s.u32(f)
+			}
+		}
+	})
+	w.section(sectionCode, len(bodies), func(s *writer) {
+		s.u32(uint32(len(bodies)))
+		for _, b := range bodies {
+			s.u32(uint32(len(b)))
+			s.raw(b)
+		}
+	})
+	w.section(sectionData, len(m.data), func(s *writer) {
+		s.u32(uint32(len(m.data)))
+		for _, d := range m.data {
+			s.u32(0)
+			s.constExpr(valueI32, uint64(d.offset))
+			s.u32(uint32(len(d.data)))
+			s.raw(d.data)
+		}
+	})
+	return w.buf
+}
+
+// encodeLocals writes the local entries of a function body.
+func encodeLocals(w *writer, locals []localEntry) {
+	w.u32(uint32(len(locals)))
+	for _, l := range locals {
+		w.u32(l.count)
+		w.byte(byte(l.typ))
+	}
+}
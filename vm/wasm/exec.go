@@ -0,0 +1,683 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// limits of the execution
+const (
+	maxCallDepth = 1024
+	maxStackSize = 1 << 16
+)
+
+// errors of the execution, the traps
+var (
+	ErrUnreachable          = errors.New("unreachable executed")
+	ErrMemoryOutOfBounds    = errors.New("memory access out of bounds")
+	ErrDivideByZero         = errors.New("integer divide by zero")
+	ErrIntegerOverflow      = errors.New("integer overflow")
+	ErrCallStackExhausted   = errors.New("call stack exhausted")
+	ErrStackOverflow        = errors.New("operand stack overflow")
+	ErrUndefinedElement     = errors.New("undefined table element")
+	ErrIndirectCallMismatch = errors.New("indirect call type mismatch")
+)
+
+// trap is panicked by the interpreter to stop the execution, and recovered as the error of the call.
+type trap struct {
+	err error
+}
+
+func throw(err error) {
+	panic(trap{err: err})
+}
+
+// hostFunc is a function imported by the contracts. It returns the result if the type has one.
+type hostFunc struct {
+	typ  *funcType
+	call func(r *runtime, args []uint64) (uint64, error)
+}
+
+type label struct {
+	height int
+	arity  int
+	cont   int
+	loop   bool
+}
+
+// instance is an instantiated module.
+type instance struct {
+	m       *module
+	funcs   []*function
+	imports []*hostFunc
+	memory  []byte
+	// maxPages is the max number of pages the memory can grow to.
+	maxPages uint32
+	globals  []uint64
+	// table are the indices of the functions of the table, -1 for the undefined elements.
+	table []int64
+	stack []uint64
+	sp    int
+	depth int
+	rt    *runtime
+	// calleeGas is the gas of the functions called by the function profiled.
+	calleeGas int64
+}
+
+// newInstance instantiates the module, resolving its imports by resolve. The start function is not run.
+func newInstance(m *module, funcs []*function, resolve func(e *importEntry) (*hostFunc, error)) (*instance, error) {
+	in := &instance{m: m, funcs: funcs, stack: make([]uint64, 1024)}
+	for _, e := range m.imports {
+		f, err := resolve(e)
+		if err != nil {
+			return nil, err
+		}
+		if !f.typ.equal(m.types[e.typeIndex]) {
+			return nil, fmt.Errorf("import %v.%v should be of type %v", e.module, e.name, f.typ)
+		}
+		in.imports = append(in.imports, f)
+	}
+	if m.memory != nil {
+		in.memory = make([]byte, uint64(m.memory.min)*pageSize)
+		in.maxPages = maxPages
+		if m.memory.hasMax {
+			in.maxPages = m.memory.max
+		}
+	}
+	for _, g := range m.globals {
+		in.globals = append(in.globals, g.init)
+	}
+	if m.table != nil {
+		in.table = make([]int64, m.table.min)
+		for i := range in.table {
+			in.table[i] = -1
+		}
+	}
+	for _, e := range m.elems {
+		for i, f := range e.indices {
+			in.table[int(e.offset)+i] = int64(f)
+		}
+	}
+	for _, d := range m.data {
+		copy(in.memory[d.offset:], d.data)
+	}
+	return in, nil
+}
+
+// invoke calls the function of index with the args, returning the results.
+func (in *instance) invoke(index uint32, args ...uint64) (results []uint64, err error) {
+	t, err := in.m.funcType(index)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(t.params) {
+		return nil, fmt.Errorf("function %v takes %v args, got %v", index, len(t.params), len(args))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t, ok := r.(trap)
+			if !ok {
+				panic(r)
+			}
+			results, err = nil, t.err
+		}
+	}()
+	in.sp = 0
+	in.depth = 0
+	in.grow(len(args))
+	for _, a := range args {
+		in.push(a)
+	}
+	in.call(index)
+	results = make([]uint64, len(t.results))
+	copy(results, in.stack[in.sp-len(t.results):in.sp])
+	return results, nil
+}
+
+// grow makes the operand stack able to take n values more.
+func (in *instance) grow(n int) {
+	need := in.sp + n
+	if need <= len(in.stack) {
+		return
+	}
+	if need > maxStackSize {
+		throw(ErrStackOverflow)
+	}
+	size := 2 * len(in.stack)
+	for size < need {
+		size *= 2
+	}
+	if size > maxStackSize {
+		size = maxStackSize
+	}
+	stack := make([]uint64, size)
+	copy(stack, in.stack[:in.sp])
+	in.stack = stack
+}
+
+func (in *instance) push(v uint64) {
+	in.stack[in.sp] = v
+	in.sp++
+}
+
+func (in *instance) pop() uint64 {
+	in.sp--
+	return in.stack[in.sp]
+}
+
+func (in *instance) call(index uint32) {
+	if int(index) < len(in.imports) {
+		in.callHost(index)
+		return
+	}
+	f := in.funcs[int(index)-len(in.imports)]
+	if in.depth >= maxCallDepth {
+		throw(ErrCallStackExhausted)
+	}
+	in.depth++
+	n := len(f.typ.params)
+	locals := make([]uint64, n+len(f.locals))
+	in.sp -= n
+	copy(locals, in.stack[in.sp:in.sp+n])
+	in.grow(f.maxStack)
+	if in.rt.tracer != nil {
+		in.profile(index, f, locals)
+	} else {
+		in.execute(f, locals)
+	}
+	in.depth--
+}
+
+// profile executes the function and records its gas in the tracer, without the gas of the functions it calls, but
+// with the gas of the host apis.
+func (in *instance) profile(index uint32, f *function, locals []uint64) {
+	start, outer := in.rt.gasUsed, in.calleeGas
+	in.calleeGas = 0
+	defer func() {
+		gas := in.rt.gasUsed - start
+		in.rt.tracer.Function(in.funcName(index), gas-in.calleeGas)
+		in.calleeGas = outer + gas
+	}()
+	in.execute(f, locals)
+}
+
+// funcName returns the name the function is exported as, or its index in the module before the gas import is added.
+func (in *instance) funcName(index uint32) string {
+	for _, e := range in.m.exports {
+		if e.kind == externalFunc && e.index == index {
+			return e.name
+		}
+	}
+	n := len(in.m.imports)
+	if n > 0 && in.m.imports[n-1].module == hostModule && in.m.imports[n-1].name == gasName {
+		index--
+	}
+	return fmt.Sprintf("func[%v]", index)
+}
+
+func (in *instance) callHost(index uint32) {
+	f := in.imports[index]
+	n := len(f.typ.params)
+	args := make([]uint64, n)
+	in.sp -= n
+	copy(args, in.stack[in.sp:in.sp+n])
+	gas := in.rt.gasUsed
+	r, err := f.call(in.rt, args)
+	if err != nil {
+		throw(err)
+	}
+	if e := in.m.imports[index]; in.rt.tracer != nil && e.name != gasName {
+		in.rt.tracer.HostAPI(e.name, in.rt.gasUsed-gas)
+	}
+	if len(f.typ.results) > 0 {
+		in.grow(1)
+		in.push(r)
+	}
+}
+
+// address returns the effective address of an access of size bytes, checking it is in the memory.
+func (in *instance) address(base uint64, offset uint64, size uint64) uint64 {
+	ea := uint64(uint32(base)) + offset
+	if ea+size > uint64(len(in.memory)) {
+		throw(ErrMemoryOutOfBounds)
+	}
+	return ea
+}
+
+func (in *instance) memoryGrow(n uint32) uint64 {
+	pages := uint32(len(in.memory) / pageSize)
+	if uint64(pages)+uint64(n) > uint64(in.maxPages) {
+		return uint64(math.MaxUint32)
+	}
+	in.rt.useGas(int64(n) * pageGas)
+	in.memory = append(in.memory, make([]byte, int(n)*pageSize)...)
+	return uint64(pages)
+}
+
+func i32(v uint64) uint64 {
+	return uint64(uint32(v))
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// nolint: gocyclo
+func (in *instance) execute(f *function, locals []uint64) {
+	base := in.sp
+	labels := make([]label, 0, 8)
+	code := f.code
+	for pc := 0; ; pc++ {
+		ins := &code[pc]
+		switch ins.op {
+		case opUnreachable:
+			throw(ErrUnreachable)
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{height: in.sp - ins.params, arity: ins.results, cont: ins.endAt})
+		case opLoop:
+			labels = append(labels, label{height: in.sp - ins.params, arity: ins.params, cont: pc, loop: true})
+		case opIf:
+			c := in.pop()
+			labels = append(labels, label{height: in.sp - ins.params, arity: ins.results, cont: ins.endAt})
+			if uint32(c) == 0 {
+				if ins.elseAt >= 0 {
+					pc = ins.elseAt
+				} else {
+					pc = ins.endAt - 1
+				}
+			}
+		case opElse:
+			// the end of the then branch
+			pc = ins.endAt - 1
+		case opEnd:
+			if len(labels) == 0 {
+				in.ret(f, base)
+				return
+			}
+			labels = labels[:len(labels)-1]
+		case opBr, opBrIf, opBrTable:
+			var depth uint32
+			switch ins.op {
+			case opBr:
+				depth = uint32(ins.imm)
+			case opBrIf:
+				if uint32(in.pop()) == 0 {
+					continue
+				}
+				depth = uint32(ins.imm)
+			default:
+				i := uint32(in.pop())
+				if int(i) >= len(ins.table)-1 {
+					i = uint32(len(ins.table) - 1)
+				}
+				depth = ins.table[i]
+			}
+			if int(depth) == len(labels) {
+				in.ret(f, base)
+				return
+			}
+			l := labels[len(labels)-1-int(depth)]
+			copy(in.stack[l.height:], in.stack[in.sp-l.arity:in.sp])
+			in.sp = l.height + l.arity
+			if l.loop {
+				labels = labels[:len(labels)-int(depth)]
+			} else {
+				labels = labels[:len(labels)-1-int(depth)]
+			}
+			pc = l.cont
+		case opReturn:
+			in.ret(f, base)
+			return
+		case opCall:
+			in.call(uint32(ins.imm))
+		case opCallIndirect:
+			i := uint32(in.pop())
+			if int(i) >= len(in.table) || in.table[i] < 0 {
+				throw(ErrUndefinedElement)
+			}
+			index := uint32(in.table[i])
+			t, _ := in.m.funcType(index)
+			if !t.equal(in.m.types[ins.imm]) {
+				throw(ErrIndirectCallMismatch)
+			}
+			in.call(index)
+		case opDrop:
+			in.sp--
+		case opSelect, opSelectTyped:
+			c := in.pop()
+			v2 := in.pop()
+			if uint32(c) == 0 {
+				in.stack[in.sp-1] = v2
+			}
+		case opLocalGet:
+			in.push(locals[ins.imm])
+		case opLocalSet:
+			locals[ins.imm] = in.pop()
+		case opLocalTee:
+			locals[ins.imm] = in.stack[in.sp-1]
+		case opGlobalGet:
+			in.push(in.globals[ins.imm])
+		case opGlobalSet:
+			in.globals[ins.imm] = in.pop()
+
+		case opI32Load:
+			a := in.address(in.pop(), ins.imm, 4)
+			in.push(uint64(binary.LittleEndian.Uint32(in.memory[a:])))
+		case opI64Load:
+			a := in.address(in.pop(), ins.imm, 8)
+			in.push(binary.LittleEndian.Uint64(in.memory[a:]))
+		case opI32Load8S:
+			a := in.address(in.pop(), ins.imm, 1)
+			in.push(i32(uint64(int8(in.memory[a]))))
+		case opI32Load8U:
+			a := in.address(in.pop(), ins.imm, 1)
+			in.push(uint64(in.memory[a]))
+		case opI32Load16S:
+			a := in.address(in.pop(), ins.imm, 2)
+			in.push(i32(uint64(int16(binary.LittleEndian.Uint16(in.memory[a:])))))
+		case opI32Load16U:
+			a := in.address(in.pop(), ins.imm, 2)
+			in.push(uint64(binary.LittleEndian.Uint16(in.memory[a:])))
+		case opI64Load8S:
+			a := in.address(in.pop(), ins.imm, 1)
+			in.push(uint64(int8(in.memory[a])))
+		case opI64Load8U:
+			a := in.address(in.pop(), ins.imm, 1)
+			in.push(uint64(in.memory[a]))
+		case opI64Load16S:
+			a := in.address(in.pop(), ins.imm, 2)
+			in.push(uint64(int16(binary.LittleEndian.Uint16(in.memory[a:]))))
+		case opI64Load16U:
+			a := in.address(in.pop(), ins.imm, 2)
+			in.push(uint64(binary.LittleEndian.Uint16(in.memory[a:])))
+		case opI64Load32S:
+			a := in.address(in.pop(), ins.imm, 4)
+			in.push(uint64(int32(binary.LittleEndian.Uint32(in.memory[a:]))))
+		case opI64Load32U:
+			a := in.address(in.pop(), ins.imm, 4)
+			in.push(uint64(binary.LittleEndian.Uint32(in.memory[a:])))
+		case opI32Store, opI64Store32:
+			v := in.pop()
+			a := in.address(in.pop(), ins.imm, 4)
+			binary.LittleEndian.PutUint32(in.memory[a:], uint32(v))
+		case opI64Store:
+			v := in.pop()
+			a := in.address(in.pop(), ins.imm, 8)
+			binary.LittleEndian.PutUint64(in.memory[a:], v)
+		case opI32Store8, opI64Store8:
+			v := in.pop()
+			a := in.address(in.pop(), ins.imm, 1)
+			in.memory[a] = byte(v)
+		case opI32Store16, opI64Store16:
+			v := in.pop()
+			a := in.address(in.pop(), ins.imm, 2)
+			binary.LittleEndian.PutUint16(in.memory[a:], uint16(v))
+		case opMemorySize:
+			in.push(uint64(len(in.memory) / pageSize))
+		case opMemoryGrow:
+			in.push(in.memoryGrow(uint32(in.pop())))
+
+		case opI32Const, opI64Const:
+			in.push(ins.imm)
+
+		case opI32Eqz:
+			in.push(b2u(uint32(in.pop()) == 0))
+		case opI64Eqz:
+			in.push(b2u(in.pop() == 0))
+
+		case opI32WrapI64:
+			in.stack[in.sp-1] = i32(in.stack[in.sp-1])
+		case opI64ExtendI32S:
+			in.stack[in.sp-1] = uint64(int32(uint32(in.stack[in.sp-1])))
+		case opI64ExtendI32U:
+			in.stack[in.sp-1] = i32(in.stack[in.sp-1])
+
+		case opPrefixFC:
+			switch ins.imm {
+			case opMemoryCopy:
+				n := uint64(uint32(in.pop()))
+				src := in.address(in.pop(), 0, n)
+				dst := in.address(in.pop(), 0, n)
+				in.rt.useGas(int64(n/64) * byteGas)
+				copy(in.memory[dst:dst+n], in.memory[src:src+n])
+			case opMemoryFill:
+				n := uint64(uint32(in.pop()))
+				v := byte(in.pop())
+				dst := in.address(in.pop(), 0, n)
+				in.rt.useGas(int64(n/64) * byteGas)
+				m := in.memory[dst : dst+n]
+				for i := range m {
+					m[i] = v
+				}
+			}
+
+		default:
+			in.numeric(ins.op)
+		}
+	}
+}
+
+// ret moves the results of the function to the base of its frame.
+func (in *instance) ret(f *function, base int) {
+	n := len(f.typ.results)
+	copy(in.stack[base:], in.stack[in.sp-n:in.sp])
+	in.sp = base + n
+}
+
+// nolint: gocyclo
+func (in *instance) numeric(op byte) {
+	if t, ok := unaryOps[op]; ok {
+		v := in.stack[in.sp-1]
+		if t == valueI32 {
+			a := uint32(v)
+			var r uint32
+			switch op {
+			case opI32Clz:
+				r = uint32(bits.LeadingZeros32(a))
+			case opI32Ctz:
+				r = uint32(bits.TrailingZeros32(a))
+			case opI32Popcnt:
+				r = uint32(bits.OnesCount32(a))
+			case opI32Extend8S:
+				r = uint32(int32(int8(a)))
+			case opI32Extend16S:
+				r = uint32(int32(int16(a)))
+			}
+			in.stack[in.sp-1] = uint64(r)
+			return
+		}
+		var r uint64
+		switch op {
+		case opI64Clz:
+			r = uint64(bits.LeadingZeros64(v))
+		case opI64Ctz:
+			r = uint64(bits.TrailingZeros64(v))
+		case opI64Popcnt:
+			r = uint64(bits.OnesCount64(v))
+		case opI64Extend8S:
+			r = uint64(int64(int8(v)))
+		case opI64Extend16S:
+			r = uint64(int64(int16(v)))
+		case opI64Extend32S:
+			r = uint64(int64(int32(v)))
+		}
+		in.stack[in.sp-1] = r
+		return
+	}
+	b := in.pop()
+	a := in.stack[in.sp-1]
+	var r uint64
+	if t, ok := binaryOps[op]; ok && t == valueI32 {
+		r = uint64(binaryI32(op, uint32(a), uint32(b)))
+	} else if ok {
+		r = binaryI64(op, a, b)
+	} else if compareOps[op] == valueI32 {
+		r = b2u(compareI32(op, uint32(a), uint32(b)))
+	} else {
+		r = b2u(compareI64(op, a, b))
+	}
+	in.stack[in.sp-1] = r
+}
+
+// nolint: gocyclo
+func binaryI32(op byte, a, b uint32) uint32 {
+	switch op {
+	case opI32Add:
+		return a + b
+	case opI32Sub:
+		return a - b
+	case opI32Mul:
+		return a * b
+	case opI32DivS:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			throw(ErrIntegerOverflow)
+		}
+		return uint32(int32(a) / int32(b))
+	case opI32DivU:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return a / b
+	case opI32RemS:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return uint32(int32(a) % int32(b))
+	case opI32RemU:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return a % b
+	case opI32And:
+		return a & b
+	case opI32Or:
+		return a | b
+	case opI32Xor:
+		return a ^ b
+	case opI32Shl:
+		return a << (b & 31)
+	case opI32ShrS:
+		return uint32(int32(a) >> (b & 31))
+	case opI32ShrU:
+		return a >> (b & 31)
+	case opI32Rotl:
+		return bits.RotateLeft32(a, int(b&31))
+	case opI32Rotr:
+		return bits.RotateLeft32(a, -int(b&31))
+	}
+	return 0
+}
+
+// nolint: gocyclo
+func binaryI64(op byte, a, b uint64) uint64 {
+	switch op {
+	case opI64Add:
+		return a + b
+	case opI64Sub:
+		return a - b
+	case opI64Mul:
+		return a * b
+	case opI64DivS:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			throw(ErrIntegerOverflow)
+		}
+		return uint64(int64(a) / int64(b))
+	case opI64DivU:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return a / b
+	case opI64RemS:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return uint64(int64(a) % int64(b))
+	case opI64RemU:
+		if b == 0 {
+			throw(ErrDivideByZero)
+		}
+		return a % b
+	case opI64And:
+		return a & b
+	case opI64Or:
+		return a | b
+	case opI64Xor:
+		return a ^ b
+	case opI64Shl:
+		return a << (b & 63)
+	case opI64ShrS:
+		return uint64(int64(a) >> (b & 63))
+	case opI64ShrU:
+		return a >> (b & 63)
+	case opI64Rotl:
+		return bits.RotateLeft64(a, int(b&63))
+	case opI64Rotr:
+		return bits.RotateLeft64(a, -int(b&63))
+	}
+	return 0
+}
+
+func compareI32(op byte, a, b uint32) bool {
+	switch op {
+	case opI32Eq:
+		return a == b
+	case opI32Ne:
+		return a != b
+	case opI32LtS:
+		return int32(a) < int32(b)
+	case opI32LtU:
+		return a < b
+	case opI32GtS:
+		return int32(a) > int32(b)
+	case opI32GtU:
+		return a > b
+	case opI32LeS:
+		return int32(a) <= int32(b)
+	case opI32LeU:
+		return a <= b
+	case opI32GeS:
+		return int32(a) >= int32(b)
+	case opI32GeU:
+		return a >= b
+	}
+	return false
+}
+
+func compareI64(op byte, a, b uint64) bool {
+	switch op {
+	case opI64Eq:
+		return a == b
+	case opI64Ne:
+		return a != b
+	case opI64LtS:
+		return int64(a) < int64(b)
+	case opI64LtU:
+		return a < b
+	case opI64GtS:
+		return int64(a) > int64(b)
+	case opI64GtU:
+		return a > b
+	case opI64LeS:
+		return int64(a) <= int64(b)
+	case opI64LeU:
+		return a <= b
+	case opI64GeS:
+		return int64(a) >= int64(b)
+	case opI64GeU:
+		return a >= b
+	}
+	return false
+}
@@ -0,0 +1,359 @@
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+const (
+	// notFound is returned as -1 by the imports reading a value not set.
+	notFound = math.MaxUint32
+	// resultMaxLength is the max length of the result of a call.
+	resultMaxLength = 65536
+	// deadlineCheckInterval is how many gas charges are between two checks of the deadline.
+	deadlineCheckInterval = 1024
+)
+
+// errors of the imports
+var (
+	ErrTimeout          = errors.New("execution timeout")
+	ErrResultTooLong    = errors.New("result too long")
+	ErrInvalidDbValType = errors.New("invalid db value type")
+)
+
+// runtime is the environment of a contract call, used by the imports.
+type runtime struct {
+	h  *host.Host
+	in *instance
+	// tracer profiles the gas of the host apis and the functions, if the host is tracing.
+	tracer   *host.Tracer
+	args     []string
+	result   string
+	gasUsed  int64
+	gasLimit int64
+	charges  int
+}
+
+// useGas charges the gas, stopping the execution when the gas limit is exceeded.
+func (r *runtime) useGas(gas int64) {
+	r.gasUsed += gas
+	if r.gasUsed > r.gasLimit {
+		throw(host.ErrOutOfGas)
+	}
+}
+
+// charge is the gas import, checking the deadline too.
+func (r *runtime) charge(gas int64) {
+	r.useGas(gas)
+	r.charges++
+	if r.charges%deadlineCheckInterval == 0 && r.h != nil && time.Now().After(r.h.Deadline()) {
+		throw(ErrTimeout)
+	}
+}
+
+// cost charges the cpu of a host api.
+func (r *runtime) cost(c contract.Cost) {
+	r.useGas(c.CPU)
+}
+
+func (r *runtime) bytes(ptr, size uint64) []byte {
+	n := uint64(uint32(size))
+	a := r.in.address(ptr, 0, n)
+	return r.in.memory[a : a+n]
+}
+
+func (r *runtime) string(ptr, size uint64) string {
+	return string(r.bytes(ptr, size))
+}
+
+// output writes the data to dst if it fits in size, returning its length, so that a longer one can be read again.
+func (r *runtime) output(dst, size uint64, data string) uint64 {
+	if uint64(len(data)) <= uint64(uint32(size)) {
+		copy(r.bytes(dst, uint64(len(data))), data)
+	}
+	return uint64(len(data))
+}
+
+// outputValue writes a value of the storage, notFound if nil.
+func (r *runtime) outputValue(dst, size uint64, val interface{}) (uint64, error) {
+	if val == nil {
+		return notFound, nil
+	}
+	s, err := dbValToString(val)
+	if err != nil {
+		return 0, err
+	}
+	return r.output(dst, size, s), nil
+}
+
+func (r *runtime) outputJSON(dst, size uint64, v interface{}) (uint64, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0, host.ErrInvalidData
+	}
+	return r.output(dst, size, string(b)), nil
+}
+
+// payer returns the ram payer given by ptr and size, none if empty.
+func (r *runtime) payer(ptr, size uint64) []string {
+	if uint32(size) == 0 {
+		return nil
+	}
+	return []string{r.string(ptr, size)}
+}
+
+func b2i(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func dbValToString(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []byte:
+		return string(v), nil
+	case database.SerializedJSON:
+		return string(v), nil
+	default:
+		return "", ErrInvalidDbValType
+	}
+}
+
+// i32s returns the type of n i32 params and the results.
+func i32s(n int, results ...valueType) *funcType {
+	t := &funcType{results: results}
+	for i := 0; i < n; i++ {
+		t.params = append(t.params, valueI32)
+	}
+	return t
+}
+
+// hostFuncs are the imports of the module "iost". The strings are given by their pointers and lengths, the values
+// read are written to a buffer given by its pointer and size, returning their lengths, or -1 if not set.
+var hostFuncs = map[string]*hostFunc{
+	gasName: {i32s(1), func(r *runtime, a []uint64) (uint64, error) {
+		r.charge(int64(uint32(a[0])))
+		return 0, nil
+	}},
+
+	// arg(index, dst, size) reads the arg of index, -1 if there is no such arg
+	"arg": {i32s(3, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		i := uint32(a[0])
+		if int(i) >= len(r.args) {
+			return notFound, nil
+		}
+		return r.output(a[1], a[2], r.args[i]), nil
+	}},
+	// ret(ptr, len) sets the result of the call
+	"ret": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		if uint32(a[1]) > resultMaxLength {
+			return 0, ErrResultTooLong
+		}
+		r.result = r.string(a[0], a[1])
+		return 0, nil
+	}},
+	// abort(ptr, len) stops the call with the error message
+	"abort": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		return 0, errors.New(r.string(a[0], a[1]))
+	}},
+	// log(ptr, len) writes an info log
+	"log": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		if r.h.Logger() == nil {
+			return 0, errors.New("no logger error")
+		}
+		r.h.Logger().Infof("%v", r.string(a[0], a[1]))
+		return 0, nil
+	}},
+
+	// put(key, value, payer) with an empty payer for the default one
+	"put": {i32s(6), func(r *runtime, a []uint64) (uint64, error) {
+		c, err := r.h.Put(r.string(a[0], a[1]), r.string(a[2], a[3]), r.payer(a[4], a[5])...)
+		r.cost(c)
+		return 0, err
+	}},
+	// get(key, dst, size)
+	"get": {i32s(4, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		v, c := r.h.Get(r.string(a[0], a[1]))
+		r.cost(c)
+		return r.outputValue(a[2], a[3], v)
+	}},
+	// has(key)
+	"has": {i32s(2, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		ok, c := r.h.Has(r.string(a[0], a[1]))
+		r.cost(c)
+		return b2i(ok), nil
+	}},
+	// del(key)
+	"del": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		c, err := r.h.Del(r.string(a[0], a[1]))
+		r.cost(c)
+		return 0, err
+	}},
+	// map_put(key, field, value, payer) with an empty payer for the default one
+	"map_put": {i32s(8), func(r *runtime, a []uint64) (uint64, error) {
+		c, err := r.h.MapPut(r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]), r.payer(a[6], a[7])...)
+		r.cost(c)
+		return 0, err
+	}},
+	// map_get(key, field, dst, size)
+	"map_get": {i32s(6, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		v, c := r.h.MapGet(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return r.outputValue(a[4], a[5], v)
+	}},
+	// map_has(key, field)
+	"map_has": {i32s(4, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		ok, c := r.h.MapHas(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return b2i(ok), nil
+	}},
+	// map_del(key, field)
+	"map_del": {i32s(4), func(r *runtime, a []uint64) (uint64, error) {
+		c, err := r.h.MapDel(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return 0, err
+	}},
+	// map_keys(key, dst, size) reads the fields as a json array
+	"map_keys": {i32s(4, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		fields, c := r.h.MapKeys(r.string(a[0], a[1]))
+		r.cost(c)
+		return r.outputJSON(a[2], a[3], fields)
+	}},
+	// map_len(key)
+	"map_len": {i32s(2, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		n, c := r.h.MapLen(r.string(a[0], a[1]))
+		r.cost(c)
+		return uint64(n), nil
+	}},
+	// global_get(contract, key, dst, size)
+	"global_get": {i32s(6, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		v, c := r.h.GlobalGet(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return r.outputValue(a[4], a[5], v)
+	}},
+	// global_has(contract, key)
+	"global_has": {i32s(4, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		ok, c := r.h.GlobalHas(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return b2i(ok), nil
+	}},
+	// global_map_get(contract, key, field, dst, size)
+	"global_map_get": {i32s(8, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		v, c := r.h.GlobalMapGet(r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]))
+		r.cost(c)
+		return r.outputValue(a[6], a[7], v)
+	}},
+	// global_map_has(contract, key, field)
+	"global_map_has": {i32s(6, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		ok, c := r.h.GlobalMapHas(r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]))
+		r.cost(c)
+		return b2i(ok), nil
+	}},
+
+	// block_info(dst, size) reads the json of the block info
+	"block_info": {i32s(2, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		info, c := r.h.BlockInfo()
+		r.cost(c)
+		return r.output(a[0], a[1], string(info)), nil
+	}},
+	// tx_info(dst, size) reads the json of the tx info
+	"tx_info": {i32s(2, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		info, c := r.h.TxInfo()
+		r.cost(c)
+		return r.output(a[0], a[1], string(info)), nil
+	}},
+	// context_info(dst, size) reads the json of the context info
+	"context_info": {i32s(2, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		info, c := r.h.ContextInfo()
+		r.cost(c)
+		return r.output(a[0], a[1], string(info)), nil
+	}},
+	// call(contract, api, args, dst, size) calls an api with the json array of args, reading the json array of
+	// results
+	"call": {i32s(8, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		rtn, c, err := r.h.Call(r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]))
+		r.cost(c)
+		if err != nil {
+			return 0, err
+		}
+		return r.outputJSON(a[6], a[7], rtn)
+	}},
+	// call_with_auth(contract, api, args, dst, size) calls an api with the permission of the contract
+	"call_with_auth": {i32s(8, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		rtn, c, err := r.h.CallWithAuth(r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]))
+		r.cost(c)
+		if err != nil {
+			return 0, err
+		}
+		return r.outputJSON(a[6], a[7], rtn)
+	}},
+	// require_auth(account, permission)
+	"require_auth": {i32s(4, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		ok, c := r.h.RequireAuth(r.string(a[0], a[1]), r.string(a[2], a[3]))
+		r.cost(c)
+		return b2i(ok), nil
+	}},
+	// receipt(ptr, len)
+	"receipt": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		r.cost(r.h.Receipt(r.string(a[0], a[1])))
+		return 0, nil
+	}},
+	// event(ptr, len)
+	"event": {i32s(2), func(r *runtime, a []uint64) (uint64, error) {
+		r.cost(r.h.PostEvent(r.string(a[0], a[1])))
+		return 0, nil
+	}},
+
+	// transfer(token, from, to, amount, memo) transfers the token with the permission of the contract, the amount
+	// being a decimal string
+	"transfer": {i32s(10), func(r *runtime, a []uint64) (uint64, error) {
+		args, err := json.Marshal([]string{r.string(a[0], a[1]), r.string(a[2], a[3]), r.string(a[4], a[5]),
+			r.string(a[6], a[7]), r.string(a[8], a[9])})
+		if err != nil {
+			return 0, host.ErrInvalidData
+		}
+		_, c, err := r.h.CallWithAuth("token.iost", "transfer", string(args))
+		r.cost(c)
+		return 0, err
+	}},
+	// balance_of(token, account, dst, size) reads the balance as a decimal string
+	"balance_of": {i32s(6, valueI32), func(r *runtime, a []uint64) (uint64, error) {
+		args, err := json.Marshal([]string{r.string(a[0], a[1]), r.string(a[2], a[3])})
+		if err != nil {
+			return 0, host.ErrInvalidData
+		}
+		rtn, c, err := r.h.Call("token.iost", "balanceOf", string(args))
+		r.cost(c)
+		if err != nil {
+			return 0, err
+		}
+		if len(rtn) == 0 {
+			return 0, host.ErrInvalidData
+		}
+		return r.output(a[4], a[5], fmt.Sprint(rtn[0])), nil
+	}},
+}
+
+// resolveImport returns the host function of an import, the gas import being given only if metered.
+func resolveImport(e *importEntry, metered bool) (*hostFunc, error) {
+	f, ok := hostFuncs[e.name]
+	if e.module != hostModule || !ok || e.name == gasName && !metered {
+		return nil, fmt.Errorf("unknown import %v.%v", e.module, e.name)
+	}
+	return f, nil
+}
@@ -0,0 +1,134 @@
+package wasm
+
+import "fmt"
+
+// hostModule is the module of the imports given to the contracts, gasName being the gas metering injected by Compile,
+// which the contracts can't import.
+const (
+	hostModule = "iost"
+	gasName    = "gas"
+)
+
+// gas of the instructions
+const (
+	defaultInstrGas = 1
+	callGas         = 4
+	divGas          = 2
+	memoryGrowGas   = 16
+	// pageGas is charged by memory.grow for each page, and byteGas by memory.copy and memory.fill for every 64 bytes.
+	pageGas = 4096
+	byteGas = 1
+)
+
+func instrGas(ins *instr) int64 {
+	switch ins.op {
+	case opNop, opElse, opEnd:
+		return 0
+	case opCall, opCallIndirect:
+		return callGas
+	case opI32DivS, opI32DivU, opI32RemS, opI32RemU, opI64DivS, opI64DivU, opI64RemS, opI64RemU:
+		return divGas
+	case opMemoryGrow:
+		return memoryGrowGas
+	}
+	return defaultInstrGas
+}
+
+// endsSequence tells whether the instruction ends a sequence of instructions, the instructions after it being entered
+// by a branch or unreachable.
+func endsSequence(op byte) bool {
+	switch op {
+	case opBlock, opLoop, opIf, opElse, opEnd, opBr, opBrIf, opBrTable, opReturn, opUnreachable:
+		return true
+	}
+	return false
+}
+
+// instrument injects the gas metering in the module. Each sequence of instructions, which is only entered at its
+// start, calls the gas import added to the module first, with the gas of the sequence. The functions are shifted by
+// the import.
+func instrument(m *module, funcs []*function) ([]byte, error) {
+	for _, e := range m.imports {
+		if e.module == hostModule && e.name == gasName {
+			return nil, fmt.Errorf("import %v.%v is reserved", hostModule, gasName)
+		}
+	}
+	gasType := &funcType{params: []valueType{valueI32}}
+	typeIndex := -1
+	for i, t := range m.types {
+		if t.equal(gasType) {
+			typeIndex = i
+			break
+		}
+	}
+	if typeIndex < 0 {
+		if len(m.types) >= maxTypes {
+			return nil, fmt.Errorf("too many types, max %v", maxTypes)
+		}
+		typeIndex = len(m.types)
+		m.types = append(m.types, gasType)
+	}
+	if m.numFuncs() >= maxFunctions {
+		return nil, fmt.Errorf("too many functions, max %v", maxFunctions)
+	}
+	gasIndex := uint32(len(m.imports))
+	shift := func(f uint32) uint32 {
+		if f >= gasIndex {
+			return f + 1
+		}
+		return f
+	}
+	m.imports = append(m.imports, &importEntry{module: hostModule, name: gasName, typeIndex: uint32(typeIndex)})
+	for _, e := range m.exports {
+		if e.kind == externalFunc {
+			e.index = shift(e.index)
+		}
+	}
+	if m.start != nil {
+		start := shift(*m.start)
+		m.start = &start
+	}
+	for _, e := range m.elems {
+		for i, f := range e.indices {
+			e.indices[i] = shift(f)
+		}
+	}
+	bodies := make([][]byte, len(funcs))
+	for i, f := range funcs {
+		bodies[i] = meterBody(m.bodies[i], f, gasIndex, shift)
+	}
+	return m.encode(bodies), nil
+}
+
+func meterBody(body *funcBody, f *function, gasIndex uint32, shift func(uint32) uint32) []byte {
+	w := &writer{}
+	encodeLocals(w, body.locals)
+	for start := 0; start < len(f.code); {
+		var gas int64
+		end := start
+		for end < len(f.code) {
+			ins := &f.code[end]
+			gas += instrGas(ins)
+			end++
+			if endsSequence(ins.op) {
+				break
+			}
+		}
+		if gas > 0 {
+			w.byte(opI32Const)
+			w.signed(gas)
+			w.byte(opCall)
+			w.u32(gasIndex)
+		}
+		for _, ins := range f.code[start:end] {
+			if ins.op == opCall {
+				w.byte(opCall)
+				w.u32(shift(uint32(ins.imm)))
+			} else {
+				w.raw(body.code[ins.pos:ins.next])
+			}
+		}
+		start = end
+	}
+	return w.buf
+}
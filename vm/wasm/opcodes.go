@@ -0,0 +1,189 @@
+package wasm
+
+// opcodes of the supported instructions, which are those of the wasm mvp without the floats, with the sign extension
+// operators and memory.copy and memory.fill of the bulk memory operations.
+const (
+	opUnreachable  byte = 0x00
+	opNop          byte = 0x01
+	opBlock        byte = 0x02
+	opLoop         byte = 0x03
+	opIf           byte = 0x04
+	opElse         byte = 0x05
+	opEnd          byte = 0x0b
+	opBr           byte = 0x0c
+	opBrIf         byte = 0x0d
+	opBrTable      byte = 0x0e
+	opReturn       byte = 0x0f
+	opCall         byte = 0x10
+	opCallIndirect byte = 0x11
+
+	opDrop        byte = 0x1a
+	opSelect      byte = 0x1b
+	opSelectTyped byte = 0x1c
+
+	opLocalGet  byte = 0x20
+	opLocalSet  byte = 0x21
+	opLocalTee  byte = 0x22
+	opGlobalGet byte = 0x23
+	opGlobalSet byte = 0x24
+
+	opI32Load    byte = 0x28
+	opI64Load    byte = 0x29
+	opI32Load8S  byte = 0x2c
+	opI32Load8U  byte = 0x2d
+	opI32Load16S byte = 0x2e
+	opI32Load16U byte = 0x2f
+	opI64Load8S  byte = 0x30
+	opI64Load8U  byte = 0x31
+	opI64Load16S byte = 0x32
+	opI64Load16U byte = 0x33
+	opI64Load32S byte = 0x34
+	opI64Load32U byte = 0x35
+	opI32Store   byte = 0x36
+	opI64Store   byte = 0x37
+	opI32Store8  byte = 0x3a
+	opI32Store16 byte = 0x3b
+	opI64Store8  byte = 0x3c
+	opI64Store16 byte = 0x3d
+	opI64Store32 byte = 0x3e
+	opMemorySize byte = 0x3f
+	opMemoryGrow byte = 0x40
+
+	opI32Const byte = 0x41
+	opI64Const byte = 0x42
+
+	opI32Eqz byte = 0x45
+	opI32Eq  byte = 0x46
+	opI32Ne  byte = 0x47
+	opI32LtS byte = 0x48
+	opI32LtU byte = 0x49
+	opI32GtS byte = 0x4a
+	opI32GtU byte = 0x4b
+	opI32LeS byte = 0x4c
+	opI32LeU byte = 0x4d
+	opI32GeS byte = 0x4e
+	opI32GeU byte = 0x4f
+
+	opI64Eqz byte = 0x50
+	opI64Eq  byte = 0x51
+	opI64Ne  byte = 0x52
+	opI64LtS byte = 0x53
+	opI64LtU byte = 0x54
+	opI64GtS byte = 0x55
+	opI64GtU byte = 0x56
+	opI64LeS byte = 0x57
+	opI64LeU byte = 0x58
+	opI64GeS byte = 0x59
+	opI64GeU byte = 0x5a
+
+	opI32Clz    byte = 0x67
+	opI32Ctz    byte = 0x68
+	opI32Popcnt byte = 0x69
+	opI32Add    byte = 0x6a
+	opI32Sub    byte = 0x6b
+	opI32Mul    byte = 0x6c
+	opI32DivS   byte = 0x6d
+	opI32DivU   byte = 0x6e
+	opI32RemS   byte = 0x6f
+	opI32RemU   byte = 0x70
+	opI32And    byte = 0x71
+	opI32Or     byte = 0x72
+	opI32Xor    byte = 0x73
+	opI32Shl    byte = 0x74
+	opI32ShrS   byte = 0x75
+	opI32ShrU   byte = 0x76
+	opI32Rotl   byte = 0x77
+	opI32Rotr   byte = 0x78
+
+	opI64Clz    byte = 0x79
+	opI64Ctz    byte = 0x7a
+	opI64Popcnt byte = 0x7b
+	opI64Add    byte = 0x7c
+	opI64Sub    byte = 0x7d
+	opI64Mul    byte = 0x7e
+	opI64DivS   byte = 0x7f
+	opI64DivU   byte = 0x80
+	opI64RemS   byte = 0x81
+	opI64RemU   byte = 0x82
+	opI64And    byte = 0x83
+	opI64Or     byte = 0x84
+	opI64Xor    byte = 0x85
+	opI64Shl    byte = 0x86
+	opI64ShrS   byte = 0x87
+	opI64ShrU   byte = 0x88
+	opI64Rotl   byte = 0x89
+	opI64Rotr   byte = 0x8a
+
+	opI32WrapI64    byte = 0xa7
+	opI64ExtendI32S byte = 0xac
+	opI64ExtendI32U byte = 0xad
+
+	opI32Extend8S  byte = 0xc0
+	opI32Extend16S byte = 0xc1
+	opI64Extend8S  byte = 0xc2
+	opI64Extend16S byte = 0xc3
+	opI64Extend32S byte = 0xc4
+
+	// opPrefixFC is followed by the u32 code of the operations below.
+	opPrefixFC byte = 0xfc
+)
+
+// operations prefixed by opPrefixFC
+const (
+	opMemoryCopy uint64 = 10
+	opMemoryFill uint64 = 11
+)
+
+// unaryOps, binaryOps and compareOps give the operand type of the numeric instructions, the result being i32 for the
+// comparisons, and the operand type for the others.
+var (
+	unaryOps = map[byte]valueType{
+		opI32Clz: valueI32, opI32Ctz: valueI32, opI32Popcnt: valueI32,
+		opI32Extend8S: valueI32, opI32Extend16S: valueI32,
+		opI64Clz: valueI64, opI64Ctz: valueI64, opI64Popcnt: valueI64,
+		opI64Extend8S: valueI64, opI64Extend16S: valueI64, opI64Extend32S: valueI64,
+	}
+	binaryOps = map[byte]valueType{
+		opI32Add: valueI32, opI32Sub: valueI32, opI32Mul: valueI32, opI32DivS: valueI32, opI32DivU: valueI32,
+		opI32RemS: valueI32, opI32RemU: valueI32, opI32And: valueI32, opI32Or: valueI32, opI32Xor: valueI32,
+		opI32Shl: valueI32, opI32ShrS: valueI32, opI32ShrU: valueI32, opI32Rotl: valueI32, opI32Rotr: valueI32,
+		opI64Add: valueI64, opI64Sub: valueI64, opI64Mul: valueI64, opI64DivS: valueI64, opI64DivU: valueI64,
+		opI64RemS: valueI64, opI64RemU: valueI64, opI64And: valueI64, opI64Or: valueI64, opI64Xor: valueI64,
+		opI64Shl: valueI64, opI64ShrS: valueI64, opI64ShrU: valueI64, opI64Rotl: valueI64, opI64Rotr: valueI64,
+	}
+	compareOps = map[byte]valueType{
+		opI32Eq: valueI32, opI32Ne: valueI32, opI32LtS: valueI32, opI32LtU: valueI32, opI32GtS: valueI32,
+		opI32GtU: valueI32, opI32LeS: valueI32, opI32LeU: valueI32, opI32GeS: valueI32, opI32GeU: valueI32,
+		opI64Eq: valueI64, opI64Ne: valueI64, opI64LtS: valueI64, opI64LtU: valueI64, opI64GtS: valueI64,
+		opI64GtU: valueI64, opI64LeS: valueI64, opI64LeU: valueI64, opI64GeS: valueI64, opI64GeU: valueI64,
+	}
+)
+
+// memoryOp is the value type and the byte size of a load or a store.
+type memoryOp struct {
+	typ   valueType
+	size  uint32
+	store bool
+}
+
+var memoryOps = map[byte]memoryOp{
+	opI32Load:    {valueI32, 4, false},
+	opI64Load:    {valueI64, 8, false},
+	opI32Load8S:  {valueI32, 1, false},
+	opI32Load8U:  {valueI32, 1, false},
+	opI32Load16S: {valueI32, 2, false},
+	opI32Load16U: {valueI32, 2, false},
+	opI64Load8S:  {valueI64, 1, false},
+	opI64Load8U:  {valueI64, 1, false},
+	opI64Load16S: {valueI64, 2, false},
+	opI64Load16U: {valueI64, 2, false},
+	opI64Load32S: {valueI64, 4, false},
+	opI64Load32U: {valueI64, 4, false},
+	opI32Store:   {valueI32, 4, true},
+	opI64Store:   {valueI64, 8, true},
+	opI32Store8:  {valueI32, 1, true},
+	opI32Store16: {valueI32, 2, true},
+	opI64Store8:  {valueI64, 1, true},
+	opI64Store16: {valueI64, 2, true},
+	opI64Store32: {valueI64, 4, true},
+}
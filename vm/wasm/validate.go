@@ -0,0 +1,603 @@
+package wasm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxBlockDepth is the max nesting of the blocks of a function.
+const maxBlockDepth = 1024
+
+// instr is a decoded instruction.
+type instr struct {
+	op byte
+	// imm is the constant, the index, the memory offset, or the operation code after opPrefixFC.
+	imm uint64
+	// table are the labels of br_table, the default one last.
+	table []uint32
+	// pos and next are the offsets of the instruction and the next one in the code of the body.
+	pos, next int
+
+	// the block instructions are given the instructions of their else and end, and the numbers of their params and
+	// results
+	elseAt, endAt   int
+	params, results int
+}
+
+// function is a validated function defined by the module.
+type function struct {
+	typ    *funcType
+	locals []valueType
+	code   []instr
+	// maxStack is the max height of the operand stack of the function.
+	maxStack int
+}
+
+type ctrlFrame struct {
+	op          byte
+	start, end  []valueType
+	height      int
+	unreachable bool
+	at          int
+}
+
+// labelTypes are the types of the values a branch to the frame takes.
+func (f *ctrlFrame) labelTypes() []valueType {
+	if f.op == opLoop {
+		return f.start
+	}
+	return f.end
+}
+
+type validator struct {
+	m        *module
+	code     []instr
+	locals   []valueType
+	vals     []valueType
+	ctrls    []*ctrlFrame
+	maxStack int
+}
+
+func (v *validator) push(t valueType) {
+	v.vals = append(v.vals, t)
+	if len(v.vals) > v.maxStack {
+		v.maxStack = len(v.vals)
+	}
+}
+
+func (v *validator) pushAll(ts []valueType) {
+	for _, t := range ts {
+		v.push(t)
+	}
+}
+
+func (v *validator) pop() (valueType, error) {
+	f := v.ctrls[len(v.ctrls)-1]
+	if len(v.vals) == f.height {
+		if f.unreachable {
+			return valueUnknown, nil
+		}
+		return 0, errors.New("operand stack underflow")
+	}
+	t := v.vals[len(v.vals)-1]
+	v.vals = v.vals[:len(v.vals)-1]
+	return t, nil
+}
+
+func (v *validator) popExpect(expect valueType) (valueType, error) {
+	t, err := v.pop()
+	if err != nil {
+		return 0, err
+	}
+	if t != expect && t != valueUnknown && expect != valueUnknown {
+		return 0, fmt.Errorf("type mismatch, expect %v, got %v", expect, t)
+	}
+	if t == valueUnknown {
+		return expect, nil
+	}
+	return t, nil
+}
+
+func (v *validator) popAll(ts []valueType) error {
+	for i := len(ts) - 1; i >= 0; i-- {
+		if _, err := v.popExpect(ts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *validator) pushCtrl(op byte, start, end []valueType, at int) error {
+	if len(v.ctrls) >= maxBlockDepth {
+		return fmt.Errorf("blocks nested too deeply, max %v", maxBlockDepth)
+	}
+	v.ctrls = append(v.ctrls, &ctrlFrame{op: op, start: start, end: end, height: len(v.vals), at: at})
+	v.pushAll(start)
+	return nil
+}
+
+func (v *validator) popCtrl() (*ctrlFrame, error) {
+	f := v.ctrls[len(v.ctrls)-1]
+	if err := v.popAll(f.end); err != nil {
+		return nil, err
+	}
+	if len(v.vals) != f.height {
+		return nil, errors.New("values remain on the operand stack at the end of the block")
+	}
+	v.ctrls = v.ctrls[:len(v.ctrls)-1]
+	return f, nil
+}
+
+func (v *validator) setUnreachable() {
+	f := v.ctrls[len(v.ctrls)-1]
+	v.vals = v.vals[:f.height]
+	f.unreachable = true
+}
+
+func (v *validator) label(depth uint32) (*ctrlFrame, error) {
+	if int(depth) >= len(v.ctrls) {
+		return nil, fmt.Errorf("label %v out of range", depth)
+	}
+	return v.ctrls[len(v.ctrls)-1-int(depth)], nil
+}
+
+func (v *validator) local(index uint64) (valueType, error) {
+	if index >= uint64(len(v.locals)) {
+		return 0, fmt.Errorf("local index %v out of range", index)
+	}
+	return v.locals[index], nil
+}
+
+func (v *validator) global(index uint64) (*global, error) {
+	if index >= uint64(len(v.m.globals)) {
+		return nil, fmt.Errorf("global index %v out of range", index)
+	}
+	return v.m.globals[index], nil
+}
+
+// blockType reads the type of a block, which is empty, a value type or a type index.
+func (v *validator) blockType(r *reader) (*funcType, error) {
+	if r.eof() {
+		return nil, ErrUnexpectedEnd
+	}
+	switch b := r.buf[r.pos]; {
+	case b == blockTypeEmpty:
+		r.pos++
+		return &funcType{}, nil
+	case b >= 0x40 && b < 0x80:
+		t, err := r.valueType()
+		if err != nil {
+			return nil, err
+		}
+		return &funcType{results: []valueType{t}}, nil
+	}
+	index, err := r.signed(33)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= int64(len(v.m.types)) {
+		return nil, fmt.Errorf("block type index %v out of range", index)
+	}
+	return v.m.types[index], nil
+}
+
+func (v *validator) memarg(r *reader, size uint32) (uint64, error) {
+	if v.m.memory == nil {
+		return 0, errors.New("memory instruction without memory")
+	}
+	align, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if align >= 32 || 1<<align > size {
+		return 0, fmt.Errorf("alignment 2^%v larger than natural", align)
+	}
+	offset, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(offset), nil
+}
+
+// memoryIndex reads the index of the memory of the memory instructions, which must be 0.
+func (v *validator) memoryIndex(r *reader) error {
+	if v.m.memory == nil {
+		return errors.New("memory instruction without memory")
+	}
+	index, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if index != 0 {
+		return fmt.Errorf("memory index %v out of range", index)
+	}
+	return nil
+}
+
+// validateFunction decodes and checks the body of the defined function of index.
+func (m *module) validateFunction(index int) (*function, error) {
+	t := m.types[m.funcs[index]]
+	body := m.bodies[index]
+	v := &validator{m: m}
+	v.locals = append(v.locals, t.params...)
+	for _, l := range body.locals {
+		for i := uint32(0); i < l.count; i++ {
+			v.locals = append(v.locals, l.typ)
+		}
+	}
+	if len(v.locals) > maxLocals {
+		return nil, fmt.Errorf("too many locals, max %v", maxLocals)
+	}
+	v.ctrls = []*ctrlFrame{{op: opBlock, end: t.results, at: -1}}
+	r := &reader{buf: body.code}
+	for !r.eof() {
+		if len(v.ctrls) == 0 {
+			return nil, errors.New("instructions after the end of the function")
+		}
+		ins, err := v.instr(r)
+		if err != nil {
+			return nil, fmt.Errorf("instruction at %v: %v", r.pos, err)
+		}
+		v.code = append(v.code, ins)
+	}
+	if len(v.ctrls) != 0 {
+		return nil, errors.New("function is not ended")
+	}
+	return &function{typ: t, locals: v.locals[len(t.params):], code: v.code, maxStack: v.maxStack}, nil
+}
+
+// instr decodes and checks the next instruction.
+func (v *validator) instr(r *reader) (instr, error) {
+	ins := instr{pos: r.pos, elseAt: -1, endAt: -1}
+	op, err := r.byte()
+	if err != nil {
+		return ins, err
+	}
+	ins.op = op
+	at := len(v.code)
+	err = v.check(r, &ins, at)
+	ins.next = r.pos
+	return ins, err
+}
+
+// nolint: gocyclo
+func (v *validator) check(r *reader, ins *instr, at int) error {
+	switch op := ins.op; op {
+	case opUnreachable:
+		v.setUnreachable()
+	case opNop:
+	case opBlock, opLoop, opIf:
+		t, err := v.blockType(r)
+		if err != nil {
+			return err
+		}
+		if op == opIf {
+			if _, err := v.popExpect(valueI32); err != nil {
+				return err
+			}
+		}
+		if err := v.popAll(t.params); err != nil {
+			return err
+		}
+		ins.params, ins.results = len(t.params), len(t.results)
+		return v.pushCtrl(op, t.params, t.results, at)
+	case opElse:
+		f := v.ctrls[len(v.ctrls)-1]
+		if f.op != opIf {
+			return errors.New("else without if")
+		}
+		if _, err := v.popCtrl(); err != nil {
+			return err
+		}
+		v.code[f.at].elseAt = at
+		return v.pushCtrl(opElse, f.start, f.end, f.at)
+	case opEnd:
+		f, err := v.popCtrl()
+		if err != nil {
+			return err
+		}
+		if f.op == opIf && len(f.start) != len(f.end) {
+			return errors.New("if without else has different params and results")
+		}
+		if f.at >= 0 {
+			v.code[f.at].endAt = at
+			if f.op == opElse {
+				v.code[v.code[f.at].elseAt].endAt = at
+			}
+		}
+		if len(v.ctrls) > 0 {
+			v.pushAll(f.end)
+		}
+	case opBr:
+		depth, err := r.u32()
+		if err != nil {
+			return err
+		}
+		f, err := v.label(depth)
+		if err != nil {
+			return err
+		}
+		if err := v.popAll(f.labelTypes()); err != nil {
+			return err
+		}
+		ins.imm = uint64(depth)
+		v.setUnreachable()
+	case opBrIf:
+		depth, err := r.u32()
+		if err != nil {
+			return err
+		}
+		f, err := v.label(depth)
+		if err != nil {
+			return err
+		}
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		if err := v.popAll(f.labelTypes()); err != nil {
+			return err
+		}
+		v.pushAll(f.labelTypes())
+		ins.imm = uint64(depth)
+	case opBrTable:
+		n, err := r.count(maxBrSize, "br_table labels")
+		if err != nil {
+			return err
+		}
+		for i := uint32(0); i <= n; i++ {
+			depth, err := r.u32()
+			if err != nil {
+				return err
+			}
+			ins.table = append(ins.table, depth)
+		}
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		d, err := v.label(ins.table[n])
+		if err != nil {
+			return err
+		}
+		arity := len(d.labelTypes())
+		for _, depth := range ins.table {
+			f, err := v.label(depth)
+			if err != nil {
+				return err
+			}
+			if len(f.labelTypes()) != arity {
+				return errors.New("br_table labels have different arities")
+			}
+			// every label checks the values, which are kept for the others
+			if err := v.popAll(f.labelTypes()); err != nil {
+				return err
+			}
+			v.pushAll(f.labelTypes())
+		}
+		v.setUnreachable()
+	case opReturn:
+		if err := v.popAll(v.ctrls[0].end); err != nil {
+			return err
+		}
+		v.setUnreachable()
+	case opCall:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		t, err := v.m.funcType(index)
+		if err != nil {
+			return err
+		}
+		if err := v.popAll(t.params); err != nil {
+			return err
+		}
+		v.pushAll(t.results)
+		ins.imm = uint64(index)
+	case opCallIndirect:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		table, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if v.m.table == nil || table != 0 {
+			return fmt.Errorf("table index %v out of range", table)
+		}
+		if int(index) >= len(v.m.types) {
+			return fmt.Errorf("type index %v out of range", index)
+		}
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		t := v.m.types[index]
+		if err := v.popAll(t.params); err != nil {
+			return err
+		}
+		v.pushAll(t.results)
+		ins.imm = uint64(index)
+	case opDrop:
+		if _, err := v.pop(); err != nil {
+			return err
+		}
+	case opSelect, opSelectTyped:
+		expect := valueUnknown
+		if op == opSelectTyped {
+			n, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if n != 1 {
+				return errors.New("select should have one type")
+			}
+			if expect, err = r.valueType(); err != nil {
+				return err
+			}
+		}
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		t1, err := v.popExpect(expect)
+		if err != nil {
+			return err
+		}
+		t2, err := v.popExpect(t1)
+		if err != nil {
+			return err
+		}
+		v.push(t2)
+	case opLocalGet, opLocalSet, opLocalTee:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		t, err := v.local(uint64(index))
+		if err != nil {
+			return err
+		}
+		if op != opLocalGet {
+			if _, err := v.popExpect(t); err != nil {
+				return err
+			}
+		}
+		if op != opLocalSet {
+			v.push(t)
+		}
+		ins.imm = uint64(index)
+	case opGlobalGet, opGlobalSet:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		g, err := v.global(uint64(index))
+		if err != nil {
+			return err
+		}
+		if op == opGlobalGet {
+			v.push(g.typ)
+		} else {
+			if !g.mutable {
+				return fmt.Errorf("global %v is immutable", index)
+			}
+			if _, err := v.popExpect(g.typ); err != nil {
+				return err
+			}
+		}
+		ins.imm = uint64(index)
+	case opMemorySize:
+		if err := v.memoryIndex(r); err != nil {
+			return err
+		}
+		v.push(valueI32)
+	case opMemoryGrow:
+		if err := v.memoryIndex(r); err != nil {
+			return err
+		}
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		v.push(valueI32)
+	case opI32Const:
+		c, err := r.signed(32)
+		if err != nil {
+			return err
+		}
+		ins.imm = uint64(uint32(c))
+		v.push(valueI32)
+	case opI64Const:
+		c, err := r.signed(64)
+		if err != nil {
+			return err
+		}
+		ins.imm = uint64(c)
+		v.push(valueI64)
+	case opI32Eqz, opI64Eqz:
+		t := valueI32
+		if op == opI64Eqz {
+			t = valueI64
+		}
+		if _, err := v.popExpect(t); err != nil {
+			return err
+		}
+		v.push(valueI32)
+	case opI32WrapI64:
+		if _, err := v.popExpect(valueI64); err != nil {
+			return err
+		}
+		v.push(valueI32)
+	case opI64ExtendI32S, opI64ExtendI32U:
+		if _, err := v.popExpect(valueI32); err != nil {
+			return err
+		}
+		v.push(valueI64)
+	case opPrefixFC:
+		code, err := r.u32()
+		if err != nil {
+			return err
+		}
+		ins.imm = uint64(code)
+		switch ins.imm {
+		case opMemoryCopy:
+			if err := v.memoryIndex(r); err != nil {
+				return err
+			}
+			if err := v.memoryIndex(r); err != nil {
+				return err
+			}
+		case opMemoryFill:
+			if err := v.memoryIndex(r); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported opcode 0xfc %v", code)
+		}
+		return v.popAll([]valueType{valueI32, valueI32, valueI32})
+	default:
+		if t, ok := unaryOps[op]; ok {
+			if _, err := v.popExpect(t); err != nil {
+				return err
+			}
+			v.push(t)
+		} else if t, ok := binaryOps[op]; ok {
+			if err := v.popAll([]valueType{t, t}); err != nil {
+				return err
+			}
+			v.push(t)
+		} else if t, ok := compareOps[op]; ok {
+			if err := v.popAll([]valueType{t, t}); err != nil {
+				return err
+			}
+			v.push(valueI32)
+		} else if mop, ok := memoryOps[op]; ok {
+			offset, err := v.memarg(r, mop.size)
+			if err != nil {
+				return err
+			}
+			ins.imm = offset
+			if mop.store {
+				return v.popAll([]valueType{valueI32, mop.typ})
+			}
+			if _, err := v.popExpect(valueI32); err != nil {
+				return err
+			}
+			v.push(mop.typ)
+		} else {
+			return fmt.Errorf("unsupported opcode 0x%x", op)
+		}
+	}
+	return nil
+}
+
+// validate checks the function bodies of the module, returning the decoded functions.
+func (m *module) validate() ([]*function, error) {
+	funcs := make([]*function, len(m.funcs))
+	for i := range m.funcs {
+		f, err := m.validateFunction(i)
+		if err != nil {
+			return nil, fmt.Errorf("function %v: %v", len(m.imports)+i, err)
+		}
+		funcs[i] = f
+	}
+	return funcs, nil
+}
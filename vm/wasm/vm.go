@@ -0,0 +1,234 @@
+package wasm
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+// cacheSize is the number of compiled modules kept by the vm.
+const cacheSize = 64
+
+var abiNameRegexp = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_]{0,31}$")
+
+// errors of the contracts
+var (
+	ErrInvalidCode = errors.New("invalid wasm code")
+	ErrNotMetered  = errors.New("wasm code is not compiled")
+)
+
+// compiled is a module compiled by the vm, ready to be instantiated.
+type compiled struct {
+	m     *module
+	funcs []*function
+}
+
+// VM runs the contracts written in WebAssembly. The code of a contract is the base64 of its binary, which exports a
+// function of type () -> () for each abi, the args being read by the imports of the module "iost".
+type VM struct {
+	mu    sync.Mutex
+	cache map[string]*compiled
+	keys  []string
+}
+
+// NewVM returns a new wasm vm.
+func NewVM() *VM {
+	return &VM{}
+}
+
+// Init inits the vm.
+func (vm *VM) Init() error {
+	vm.cache = make(map[string]*compiled)
+	vm.keys = nil
+	return nil
+}
+
+// Release releases the vm.
+func (vm *VM) Release() {
+	vm.Init() // nolint: errcheck
+}
+
+func decodeCode(code string) (*module, []*function, error) {
+	b, err := base64.StdEncoding.DecodeString(code)
+	if err != nil {
+		return nil, nil, ErrInvalidCode
+	}
+	m, err := decodeModule(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	funcs, err := m.validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, funcs, nil
+}
+
+// checkImports checks the imports of the module are given by the host.
+func checkImports(m *module, metered bool) error {
+	for _, e := range m.imports {
+		f, err := resolveImport(e, metered)
+		if err != nil {
+			return err
+		}
+		if !f.typ.equal(m.types[e.typeIndex]) {
+			return fmt.Errorf("import %v.%v should be of type %v", e.module, e.name, f.typ)
+		}
+	}
+	return nil
+}
+
+// Validate checks the code and the abis of the contract.
+func (vm *VM) Validate(c *contract.Contract) error {
+	m, _, err := decodeCode(c.Code)
+	if err != nil {
+		return fmt.Errorf("validate code error: %v", err)
+	}
+	if err := checkImports(m, false); err != nil {
+		return fmt.Errorf("validate code error: %v", err)
+	}
+	for _, abi := range c.Info.Abi {
+		if abi.Name == "init" {
+			continue
+		}
+		if !abiNameRegexp.MatchString(abi.Name) {
+			return fmt.Errorf("validate abi error: invalid abi name %v", abi.Name)
+		}
+		for _, arg := range abi.Args {
+			switch arg {
+			case "string", "bool", "number", "json":
+			default:
+				return fmt.Errorf("validate abi error: invalid arg type %v of abi %v", arg, abi.Name)
+			}
+		}
+		index, ok := m.export(abi.Name)
+		if !ok {
+			return fmt.Errorf("validate abi error: abi %v not exported", abi.Name)
+		}
+		if t, _ := m.funcType(index); len(t.params) != 0 || len(t.results) != 0 {
+			return fmt.Errorf("validate abi error: abi %v should be of type %v, got %v", abi.Name, &funcType{}, t)
+		}
+	}
+	return nil
+}
+
+// Compile injects the gas metering in the code.
+func (vm *VM) Compile(c *contract.Contract) (string, error) {
+	m, funcs, err := decodeCode(c.Code)
+	if err != nil {
+		return "", err
+	}
+	if err := checkImports(m, false); err != nil {
+		return "", err
+	}
+	b, err := instrument(m, funcs)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// load returns the module of the compiled code, checking its last import is the gas metering.
+func (vm *VM) load(code string) (*compiled, error) {
+	key := string(common.Sha3([]byte(code)))
+	vm.mu.Lock()
+	cm, ok := vm.cache[key]
+	vm.mu.Unlock()
+	if ok {
+		return cm, nil
+	}
+	m, funcs, err := decodeCode(code)
+	if err != nil {
+		return nil, err
+	}
+	n := len(m.imports)
+	if n == 0 || m.imports[n-1].module != hostModule || m.imports[n-1].name != gasName {
+		return nil, ErrNotMetered
+	}
+	for _, e := range m.imports[:n-1] {
+		if e.module == hostModule && e.name == gasName {
+			return nil, ErrNotMetered
+		}
+	}
+	if err := checkImports(m, true); err != nil {
+		return nil, err
+	}
+	cm = &compiled{m: m, funcs: funcs}
+	vm.mu.Lock()
+	if _, ok := vm.cache[key]; !ok {
+		if len(vm.keys) >= cacheSize {
+			delete(vm.cache, vm.keys[0])
+			vm.keys = vm.keys[1:]
+		}
+		vm.cache[key] = cm
+		vm.keys = append(vm.keys, key)
+	}
+	vm.mu.Unlock()
+	return cm, nil
+}
+
+func formatArgs(args []interface{}) ([]string, error) {
+	strs := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			strs = append(strs, v)
+		case int64:
+			strs = append(strs, strconv.FormatInt(v, 10))
+		case bool:
+			strs = append(strs, strconv.FormatBool(v))
+		case []byte:
+			strs = append(strs, string(v))
+		default:
+			return nil, fmt.Errorf("invalid arg %v", arg)
+		}
+	}
+	return strs, nil
+}
+
+// LoadAndCall calls the function exported as the api, the init api doing nothing if it is not exported. The start
+// function of the module is run first.
+func (vm *VM) LoadAndCall(h *host.Host, con *contract.Contract, api string, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+	cm, err := vm.load(con.Code)
+	if err != nil {
+		return nil, host.CommonErrorCost(1), err
+	}
+	index, ok := cm.m.export(api)
+	if !ok {
+		if api == "init" {
+			return []interface{}{""}, contract.Cost0(), nil
+		}
+		return nil, host.CommonErrorCost(1), fmt.Errorf("invalid api name: %v %v", con.ID, api)
+	}
+	strs, err := formatArgs(args)
+	if err != nil {
+		return nil, host.CommonErrorCost(1), err
+	}
+	r := &runtime{h: h, tracer: h.Tracer(), args: strs, gasLimit: h.GasLimitValue()}
+	in, err := newInstance(cm.m, cm.funcs, func(e *importEntry) (*hostFunc, error) {
+		return resolveImport(e, true)
+	})
+	if err != nil {
+		return nil, host.CommonErrorCost(1), err
+	}
+	in.rt = r
+	r.in = in
+	if cm.m.start != nil {
+		_, err = in.invoke(*cm.m.start)
+	}
+	if err == nil {
+		_, err = in.invoke(index)
+	}
+	cost = contract.NewCost(0, 0, r.gasUsed)
+	if err != nil {
+		return nil, cost, err
+	}
+	return []interface{}{r.result}, cost, nil
+}
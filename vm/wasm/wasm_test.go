@@ -0,0 +1,513 @@
+package wasm
+
+import (
+	"encoding/base64"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+	"github.com/stretchr/testify/assert"
+)
+
+// code assembles the instructions given by bytes and encoded immediates.
+func code(parts ...interface{}) []byte {
+	w := &writer{}
+	for _, p := range parts {
+		switch v := p.(type) {
+		case byte:
+			w.byte(v)
+		case []byte:
+			w.raw(v)
+		}
+	}
+	return w.buf
+}
+
+func c32(v int32) []byte {
+	w := &writer{}
+	w.byte(opI32Const)
+	w.signed(int64(v))
+	return w.buf
+}
+
+func c64(v int64) []byte {
+	w := &writer{}
+	w.byte(opI64Const)
+	w.signed(v)
+	return w.buf
+}
+
+func u(v uint32) []byte {
+	w := &writer{}
+	w.u32(v)
+	return w.buf
+}
+
+func body(locals []localEntry, c []byte) []byte {
+	w := &writer{}
+	encodeLocals(w, locals)
+	w.raw(c)
+	return w.buf
+}
+
+func fn(params []valueType, results ...valueType) *funcType {
+	return &funcType{params: params, results: results}
+}
+
+var (
+	i32i32 = []valueType{valueI32, valueI32}
+	one32  = []valueType{valueI32}
+	one64  = []valueType{valueI64}
+)
+
+func exports(names ...string) []*exportEntry {
+	es := make([]*exportEntry, len(names))
+	for i, n := range names {
+		es[i] = &exportEntry{name: n, kind: externalFunc, index: uint32(i)}
+	}
+	return es
+}
+
+func newTestInstance(t *testing.T, b []byte, limit int64) (*instance, *runtime) {
+	m, err := decodeModule(b)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	funcs, err := m.validate()
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	in, err := newInstance(m, funcs, func(e *importEntry) (*hostFunc, error) {
+		return resolveImport(e, true)
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	r := &runtime{in: in, gasLimit: limit}
+	in.rt = r
+	return in, r
+}
+
+func call(t *testing.T, in *instance, name string, args ...uint64) ([]uint64, error) {
+	index, ok := in.m.export(name)
+	assert.True(t, ok, name)
+	return in.invoke(index, args...)
+}
+
+func arithmeticModule() *module {
+	return &module{
+		types:   []*funcType{fn(i32i32, valueI32), fn(one64, valueI64), fn(one32, valueI32), fn(nil, valueI32)},
+		funcs:   []uint32{0, 1, 2, 2, 2, 3, 0, 0},
+		exports: exports("add", "factorial", "fib", "switch", "memory", "wrap", "div", "indirect"),
+		memory:  &limits{min: 1, max: 2, hasMax: true},
+		table:   &limits{min: 2},
+		elems:   []*elemSegment{{offset: 1, indices: []uint32{0}}},
+		data:    []*dataSegment{{offset: 0, data: []byte("hi")}},
+	}
+}
+
+func arithmeticBodies() [][]byte {
+	local64 := []localEntry{{count: 1, typ: valueI64}}
+	return [][]byte{
+		// add
+		body(nil, code(opLocalGet, u(0), opLocalGet, u(1), opI32Add, opEnd)),
+		// factorial
+		body(local64, code(c64(1), opLocalSet, u(1),
+			opBlock, blockTypeEmpty, opLoop, blockTypeEmpty,
+			opLocalGet, u(0), opI64Eqz, opBrIf, u(1),
+			opLocalGet, u(1), opLocalGet, u(0), opI64Mul, opLocalSet, u(1),
+			opLocalGet, u(0), c64(1), opI64Sub, opLocalSet, u(0),
+			opBr, u(0), opEnd, opEnd,
+			opLocalGet, u(1), opEnd)),
+		// fib
+		body(nil, code(opLocalGet, u(0), c32(2), opI32LtS, opIf, byte(valueI32),
+			opLocalGet, u(0),
+			opElse,
+			opLocalGet, u(0), c32(1), opI32Sub, opCall, u(2),
+			opLocalGet, u(0), c32(2), opI32Sub, opCall, u(2), opI32Add,
+			opEnd, opEnd)),
+		// switch
+		body(nil, code(opBlock, blockTypeEmpty, opBlock, blockTypeEmpty, opBlock, blockTypeEmpty,
+			opLocalGet, u(0), opBrTable, u(2), u(0), u(1), u(2), opEnd,
+			c32(10), opReturn, opEnd,
+			c32(20), opReturn, opEnd,
+			c32(30), opEnd)),
+		// memory stores the arg at 8 and returns the sum of the bytes at 0 and 8
+		body(nil, code(c32(8), opLocalGet, u(0), opI32Store, u(2), u(0),
+			c32(0), opI32Load16U, u(1), u(0),
+			c32(4), opI32Load8U, u(0), u(4), opI32Add,
+			opEnd)),
+		// wrap
+		body(nil, code(c64(-1), opI32WrapI64, opEnd)),
+		// div
+		body(nil, code(opLocalGet, u(0), opLocalGet, u(1), opI32DivS, opEnd)),
+		// indirect calls the function of the table at the index 0
+		body(nil, code(opLocalGet, u(0), opLocalGet, u(1), opLocalGet, u(1), opCallIndirect, u(0), u(0), opEnd)),
+	}
+}
+
+func TestExecute(t *testing.T) {
+	in, _ := newTestInstance(t, arithmeticModule().encode(arithmeticBodies()), math.MaxInt64)
+
+	r, err := call(t, in, "add", 3, uint64(math.MaxUint32))
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2}, r)
+
+	r, err = call(t, in, "factorial", 20)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2432902008176640000}, r)
+
+	r, err = call(t, in, "fib", 20)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{6765}, r)
+
+	for i, want := range []uint64{10, 20, 30, 30, 30} {
+		r, err = call(t, in, "switch", uint64(i))
+		assert.Nil(t, err)
+		assert.Equal(t, []uint64{want}, r, "case %v", i)
+	}
+
+	r, err = call(t, in, "memory", 0x01020304)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{0x6968 + 0x04}, r)
+
+	r, err = call(t, in, "wrap")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{math.MaxUint32}, r)
+
+	r, err = call(t, in, "indirect", 5, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{6}, r)
+}
+
+func TestTraps(t *testing.T) {
+	in, _ := newTestInstance(t, arithmeticModule().encode(arithmeticBodies()), math.MaxInt64)
+
+	_, err := call(t, in, "div", 1, 0)
+	assert.Equal(t, ErrDivideByZero, err)
+	_, err = call(t, in, "div", 1<<31, math.MaxUint32)
+	assert.Equal(t, ErrIntegerOverflow, err)
+	r, err := call(t, in, "div", uint64(uint32(math.MaxUint32-6)), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{math.MaxUint32 - 2}, r)
+
+	_, err = call(t, in, "indirect", 1, 0)
+	assert.Equal(t, ErrUndefinedElement, err)
+	_, err = call(t, in, "indirect", 1, 2)
+	assert.Equal(t, ErrUndefinedElement, err)
+
+	m := &module{
+		types:   []*funcType{fn(nil), fn(one32, valueI32)},
+		funcs:   []uint32{0, 1, 0, 0},
+		exports: exports("unreachable", "load", "recurse", "indirect"),
+		memory:  &limits{min: 1},
+		table:   &limits{min: 1},
+		elems:   []*elemSegment{{offset: 0, indices: []uint32{0}}},
+	}
+	in, _ = newTestInstance(t, m.encode([][]byte{
+		body(nil, code(opUnreachable, opEnd)),
+		body(nil, code(opLocalGet, u(0), opI32Load, u(2), u(0), opEnd)),
+		body(nil, code(opCall, u(2), opEnd)),
+		body(nil, code(c32(0), c32(0), opCallIndirect, u(1), u(0), opDrop, opEnd)),
+	}), math.MaxInt64)
+
+	_, err = call(t, in, "unreachable")
+	assert.Equal(t, ErrUnreachable, err)
+	r, err = call(t, in, "load", pageSize-4)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{0}, r)
+	_, err = call(t, in, "load", pageSize-3)
+	assert.Equal(t, ErrMemoryOutOfBounds, err)
+	_, err = call(t, in, "recurse")
+	assert.Equal(t, ErrCallStackExhausted, err)
+	_, err = call(t, in, "indirect")
+	assert.Equal(t, ErrIndirectCallMismatch, err)
+}
+
+func TestDecode(t *testing.T) {
+	b := arithmeticModule().encode(arithmeticBodies())
+	m, err := decodeModule(b)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(m.bodies))
+	assert.Equal(t, b, m.encode(arithmeticBodies()))
+
+	_, err = decodeModule([]byte("\x00asn\x01\x00\x00\x00"))
+	assert.Equal(t, ErrInvalidMagic, err)
+	_, err = decodeModule([]byte("\x00asm\x02\x00\x00\x00"))
+	assert.Equal(t, ErrInvalidVersion, err)
+	_, err = decodeModule(b[:len(b)-1])
+	assert.NotNil(t, err)
+
+	// the function section after the code section
+	_, err = decodeModule([]byte("\x00asm\x01\x00\x00\x00\x0a\x01\x00\x03\x01\x00"))
+	assert.NotNil(t, err)
+	// a type with a f32 param
+	_, err = decodeModule([]byte("\x00asm\x01\x00\x00\x00\x01\x05\x01\x60\x01\x7d\x00"))
+	assert.NotNil(t, err)
+	// a function without body
+	_, err = decodeModule((&module{types: []*funcType{fn(nil)}, funcs: []uint32{0}}).encode(nil))
+	assert.NotNil(t, err)
+	// an export of a missing function
+	_, err = decodeModule((&module{exports: exports("f")}).encode(nil))
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	invalid := [][]byte{
+		// i32.add of an i64
+		code(opLocalGet, u(0), c64(1), opI32Add, opEnd),
+		// missing result
+		code(opNop, opEnd),
+		// unknown local
+		code(opLocalGet, u(1), opEnd),
+		// stack underflow
+		code(opI32Add, opEnd),
+		// branch out of the function
+		code(opBr, u(1), opEnd),
+		// unaligned load
+		code(opLocalGet, u(0), opI32Load, u(3), u(0), opEnd),
+		// no memory
+		code(opLocalGet, u(0), opI32Load, u(2), u(0), opEnd),
+		// call of a missing function
+		code(opCall, u(1), opEnd),
+		// if with a result but no else
+		code(opLocalGet, u(0), opIf, byte(valueI32), c32(1), opEnd, opEnd),
+	}
+	for i, c := range invalid {
+		m := &module{types: []*funcType{fn(one32, valueI32)}, funcs: []uint32{0}}
+		m, err := decodeModule(m.encode([][]byte{body(nil, c)}))
+		assert.Nil(t, err)
+		_, err = m.validate()
+		assert.NotNil(t, err, "case %v", i)
+	}
+
+	m := &module{types: []*funcType{fn(one32, valueI32)}, funcs: []uint32{0}}
+	m, err := decodeModule(m.encode([][]byte{body(nil, code(opLocalGet, u(0), opBr, u(0), opI32Add, opEnd))}))
+	assert.Nil(t, err)
+	_, err = m.validate()
+	assert.Nil(t, err, "code after a branch is unreachable")
+}
+
+func TestInstrument(t *testing.T) {
+	m, err := decodeModule(arithmeticModule().encode(arithmeticBodies()))
+	assert.Nil(t, err)
+	funcs, err := m.validate()
+	assert.Nil(t, err)
+	b, err := instrument(m, funcs)
+	assert.Nil(t, err)
+
+	in, r := newTestInstance(t, b, math.MaxInt64)
+	assert.Equal(t, 1, len(in.imports))
+	res, err := call(t, in, "add", 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{3}, res)
+	assert.Equal(t, int64(3), r.gasUsed)
+
+	// the loop is charged on each iteration
+	r.gasUsed = 0
+	_, err = call(t, in, "factorial", 1)
+	assert.Nil(t, err)
+	once := r.gasUsed
+	r.gasUsed = 0
+	_, err = call(t, in, "factorial", 11)
+	assert.Nil(t, err)
+	assert.Equal(t, once+10*12, r.gasUsed)
+
+	// the calls of the table are shifted by the import too
+	res, err = call(t, in, "indirect", 5, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{6}, res)
+
+	r.gasUsed = 0
+	r.gasLimit = 100
+	_, err = call(t, in, "fib", 20)
+	assert.Equal(t, host.ErrOutOfGas, err)
+	assert.True(t, r.gasUsed > 100)
+
+	m, err = decodeModule(b)
+	assert.Nil(t, err)
+	funcs, err = m.validate()
+	assert.Nil(t, err)
+	_, err = instrument(m, funcs)
+	assert.NotNil(t, err, "instrumented twice")
+}
+
+// storageContract saves its arg at the key "k" and returns what is read from it.
+func storageContract() *contract.Contract {
+	m := &module{
+		types: []*funcType{
+			fn([]valueType{valueI32, valueI32, valueI32}, valueI32),
+			fn([]valueType{valueI32, valueI32, valueI32, valueI32, valueI32, valueI32}),
+			fn([]valueType{valueI32, valueI32, valueI32, valueI32}, valueI32),
+			fn(i32i32),
+			fn(nil),
+		},
+		imports: []*importEntry{
+			{module: hostModule, name: "arg", typeIndex: 0},
+			{module: hostModule, name: "put", typeIndex: 1},
+			{module: hostModule, name: "get", typeIndex: 2},
+			{module: hostModule, name: "ret", typeIndex: 3},
+		},
+		funcs:   []uint32{4, 4},
+		memory:  &limits{min: 1},
+		exports: []*exportEntry{{name: "save", kind: externalFunc, index: 4}, {name: "loop", kind: externalFunc, index: 5}},
+		data:    []*dataSegment{{offset: 0, data: []byte("k")}},
+	}
+	b := m.encode([][]byte{
+		body([]localEntry{{count: 1, typ: valueI32}}, code(
+			c32(0), c32(16), c32(100), opCall, u(0), opLocalSet, u(0),
+			c32(0), c32(1), c32(16), opLocalGet, u(0), c32(0), c32(0), opCall, u(1),
+			c32(0), c32(1), c32(200), c32(100), opCall, u(2), opLocalSet, u(0),
+			c32(200), opLocalGet, u(0), opCall, u(3),
+			opEnd)),
+		body(nil, code(opLoop, blockTypeEmpty, opBr, u(0), opEnd, opEnd)),
+	})
+	return &contract.Contract{
+		ID:   "Contractwasm",
+		Code: base64.StdEncoding.EncodeToString(b),
+		Info: &contract.Info{Lang: "wasm", Version: "1.0.0", Abi: []*contract.ABI{
+			{Name: "save", Args: []string{"string"}},
+			{Name: "loop"},
+		}},
+	}
+}
+
+func newTestHost(gasLimit int64) *host.Host {
+	ctx := host.NewContext(nil)
+	ctx.Set("contract_name", "Contractwasm")
+	ctx.GSet("gas_limit", gasLimit)
+	h := host.NewHost(ctx, database.NewVisitor(0, database.NewDatabase()), nil, nil)
+	h.SetDeadline(time.Now().Add(time.Second))
+	return h
+}
+
+func TestVM(t *testing.T) {
+	vm := NewVM()
+	assert.Nil(t, vm.Init())
+	c := storageContract()
+	assert.Nil(t, vm.Validate(c))
+
+	_, _, err := vm.LoadAndCall(newTestHost(1000000), c, "save", "hello")
+	assert.Equal(t, ErrNotMetered, err)
+
+	code, err := vm.Compile(c)
+	assert.Nil(t, err)
+	c.Code = code
+	assert.NotNil(t, vm.Validate(c), "the gas import is reserved")
+
+	h := newTestHost(1000000)
+	rtn, cost, err := vm.LoadAndCall(h, c, "save", "hello")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"hello"}, rtn)
+	assert.True(t, cost.CPU > host.Costs["PutCost"].CPU+host.Costs["GetCost"].CPU)
+	v, _ := h.Get("k")
+	assert.Equal(t, "hello", v)
+
+	rtn, _, err = vm.LoadAndCall(h, c, "init")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{""}, rtn)
+
+	// the arg is missing
+	_, _, err = vm.LoadAndCall(h, c, "save")
+	assert.Equal(t, ErrMemoryOutOfBounds, err)
+
+	_, cost, err = vm.LoadAndCall(newTestHost(10000), c, "loop")
+	assert.Equal(t, host.ErrOutOfGas, err)
+	assert.True(t, cost.CPU > 10000)
+
+	h = newTestHost(math.MaxInt64)
+	h.SetDeadline(time.Now())
+	_, _, err = vm.LoadAndCall(h, c, "loop")
+	assert.Equal(t, ErrTimeout, err)
+}
+
+func TestVMValidate(t *testing.T) {
+	vm := NewVM()
+	assert.Nil(t, vm.Init())
+
+	c := storageContract()
+	c.Info.Abi = append(c.Info.Abi, &contract.ABI{Name: "missing"})
+	assert.NotNil(t, vm.Validate(c))
+
+	c = storageContract()
+	c.Info.Abi[0].Args = []string{"int"}
+	assert.NotNil(t, vm.Validate(c))
+
+	c = storageContract()
+	c.Info.Abi[0].Name = "_save"
+	assert.NotNil(t, vm.Validate(c))
+
+	m := &module{
+		types:   []*funcType{fn(nil)},
+		imports: []*importEntry{{module: "env", name: "print", typeIndex: 0}},
+	}
+	c.Code = base64.StdEncoding.EncodeToString(m.encode(nil))
+	c.Info.Abi = nil
+	assert.NotNil(t, vm.Validate(c))
+
+	m.imports[0].module = hostModule
+	m.imports[0].name = "put"
+	c.Code = base64.StdEncoding.EncodeToString(m.encode(nil))
+	assert.NotNil(t, vm.Validate(c), "type of the import mismatched")
+
+	c.Code = "not base64"
+	_, err := vm.Compile(c)
+	assert.Equal(t, ErrInvalidCode, err)
+}
+
+func TestProfile(t *testing.T) {
+	m, err := decodeModule(arithmeticModule().encode(arithmeticBodies()))
+	assert.Nil(t, err)
+	funcs, err := m.validate()
+	assert.Nil(t, err)
+	b, err := instrument(m, funcs)
+	assert.Nil(t, err)
+	in, r := newTestInstance(t, b, math.MaxInt64)
+	r.tracer = host.NewTracer()
+	r.tracer.BeginCall("Contractwasm", "fib", "[5]")
+	_, err = call(t, in, "fib", 5)
+	assert.Nil(t, err)
+	_, err = call(t, in, "indirect", 1, 1)
+	assert.Nil(t, err)
+	r.tracer.EndCall(nil, contract.NewCost(0, 0, r.gasUsed), nil)
+
+	c := r.tracer.Calls()[0]
+	assert.Empty(t, c.HostAPIs)
+	if assert.Equal(t, 3, len(c.Functions)) {
+		assert.Equal(t, "fib", c.Functions[0].Name)
+		assert.Equal(t, int64(15), c.Functions[0].Count)
+		assert.Equal(t, "indirect", c.Functions[1].Name)
+		assert.Equal(t, "add", c.Functions[2].Name)
+		assert.Equal(t, int64(3), c.Functions[2].Gas)
+		assert.Equal(t, r.gasUsed, c.Functions[0].Gas+c.Functions[1].Gas+c.Functions[2].Gas)
+	}
+	in.m.exports = nil
+	assert.Equal(t, "func[1]", in.funcName(2))
+
+	vm := NewVM()
+	assert.Nil(t, vm.Init())
+	con := storageContract()
+	con.Code, err = vm.Compile(con)
+	assert.Nil(t, err)
+	h := newTestHost(1000000)
+	h.SetTracer(host.NewTracer())
+	h.Tracer().BeginCall(con.ID, "save", `["hello"]`)
+	_, cost, err := vm.LoadAndCall(h, con, "save", "hello")
+	assert.Nil(t, err)
+	h.Tracer().EndCall(nil, cost, nil)
+
+	c = h.Tracer().Calls()[0]
+	names := make(map[string]int64)
+	for _, p := range c.HostAPIs {
+		names[p.Name] = p.Gas
+	}
+	assert.Equal(t, map[string]int64{"arg": 0, "put": host.Costs["PutCost"].CPU, "get": host.Costs["GetCost"].CPU, "ret": 0}, names)
+	assert.Equal(t, "put", c.HostAPIs[0].Name)
+	if assert.Equal(t, 1, len(c.Functions)) {
+		assert.Equal(t, "save", c.Functions[0].Name)
+		assert.Equal(t, cost.CPU, c.Functions[0].Gas)
+	}
+}